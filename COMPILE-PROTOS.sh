@@ -16,7 +16,11 @@
 #
 
 go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.34.1
+go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.3.0
 
 protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative plugins/*.proto
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative openapiv31/*.proto
 protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative surface/*.proto
 protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative metrics/*.proto
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative extensions/wellknown/*/*.proto
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative service/*.proto
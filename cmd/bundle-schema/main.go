@@ -0,0 +1,65 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// bundle-schema copies the external schemas that a JSON Schema references
+// into the schema, producing a single self-contained schema file.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/docopt/docopt-go"
+
+	"github.com/okkoye/gnostic/jsonschema"
+)
+
+func main() {
+	usage := `
+Usage:
+	bundle-schema help
+	bundle-schema <source> [--out=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Bundle Schema 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nCopy the external schemas that a JSON Schema references into its")
+		fmt.Println("definitions, producing a single self-contained schema.")
+		fmt.Println(usage)
+		return
+	}
+
+	schema, err := jsonschema.NewSchemaFromFile(arguments["<source>"].(string))
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	bundle, err := schema.Bundle()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	bytes := []byte(bundle.JSONString())
+	if out, ok := arguments["--out"].(string); ok {
+		if err := ioutil.WriteFile(out, bytes, 0644); err != nil {
+			log.Fatalf("%+v", err)
+		}
+	} else {
+		os.Stdout.Write(bytes)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// changelog writes Markdown release notes that describe the differences
+// between two versions of an OpenAPI description.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/docopt/docopt-go"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/diff"
+	"github.com/okkoye/gnostic/lib"
+)
+
+func main() {
+	usage := `
+Usage:
+	changelog help
+	changelog <old> <new> [--title=<title>] [--out=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Changelog 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nWrite a Markdown changelog for two versions of an OpenAPI description.")
+		fmt.Println(usage)
+		return
+	}
+
+	oldDocument, oldFormat, err := lib.ReadRawInfo(arguments["<old>"].(string))
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	newDocument, newFormat, err := lib.ReadRawInfo(arguments["<new>"].(string))
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if oldFormat != newFormat || (newFormat != lib.SourceFormatOpenAPI2 && newFormat != lib.SourceFormatOpenAPI3) {
+		log.Fatalf("both descriptions must be OpenAPI descriptions with the same version")
+	}
+
+	title, ok := arguments["--title"].(string)
+	if !ok {
+		// By default, name the release with the title and version of the new description.
+		info := compiler.MapValueForKey(newDocument.Content[0], "info")
+		name, _ := compiler.StringForScalarNode(compiler.MapValueForKey(info, "title"))
+		version, _ := compiler.StringForScalarNode(compiler.MapValueForKey(info, "version"))
+		title = name + " " + version
+	}
+	changelog := diff.Changelog(diff.Compare(oldDocument, newDocument), title)
+	if filename, ok := arguments["--out"].(string); ok {
+		if err := ioutil.WriteFile(filename, []byte(changelog), 0644); err != nil {
+			log.Fatalf("%+v", err)
+		}
+	} else {
+		os.Stdout.WriteString(changelog)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// crd-openapi extracts the schemas of Kubernetes CustomResourceDefinitions
+// and writes an OpenAPI v3 description of each custom resource.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/docopt/docopt-go"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/conversions"
+)
+
+func main() {
+	usage := `
+Usage:
+	crd-openapi help
+	crd-openapi <manifest>... [--out=<dir>] [--pb]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "CRD OpenAPI 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nWrite an OpenAPI v3 description of each CustomResourceDefinition")
+		fmt.Println("in a set of Kubernetes manifests.")
+		fmt.Println(usage)
+		return
+	}
+
+	out := "."
+	if dir, ok := arguments["--out"].(string); ok {
+		out = dir
+	}
+	if err := os.MkdirAll(out, 0755); err != nil {
+		log.Fatalf("%+v", err)
+	}
+	binary := arguments["--pb"].(bool)
+
+	for _, manifest := range arguments["<manifest>"].([]string) {
+		bytes, err := compiler.ReadBytesForFile(manifest)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		crds, err := conversions.ReadCustomResourceDefinitions(bytes)
+		if err != nil {
+			log.Fatalf("%s: %+v", manifest, err)
+		}
+		for _, crd := range crds {
+			document, err := conversions.OpenAPIv3ForCustomResourceDefinition(crd)
+			if err != nil {
+				log.Fatalf("%s: %+v", manifest, err)
+			}
+			var filename string
+			if binary {
+				filename = filepath.Join(out, crd.Name+".pb")
+				bytes, err = proto.Marshal(document)
+			} else {
+				filename = filepath.Join(out, crd.Name+".yaml")
+				bytes, err = document.YAMLValue("Generated by crd-openapi from " + manifest)
+			}
+			if err != nil {
+				log.Fatalf("%+v", err)
+			}
+			if err := ioutil.WriteFile(filename, bytes, 0644); err != nil {
+				log.Fatalf("%+v", err)
+			}
+		}
+	}
+}
@@ -15,10 +15,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/docopt/docopt-go"
@@ -33,8 +35,14 @@ func main() {
 Usage:
 	disco help
 	disco list [--raw]
-	disco get [<api>] [<version>] [--raw] [--openapi2] [--openapi3] [--features] [--schemas] [--all]
+	disco get [<api>] [<version>] [--raw] [--openapi2] [--openapi3] [--features] [--schemas] [--all] [--cache=<dir>]
+	disco fetch [--cache=<dir>] [--workers=<n>] [--refresh]
+	disco compile <dir> [--workers=<n>] [--snapshot=<file>]
+	disco diff <old> <new> [--workers=<n>] [--json]
 	disco <file> [--openapi2] [--openapi3] [--features] [--schemas]
+
+Options:
+	--workers=<n>  Number of concurrent downloads or compilations [default: 8].
 	`
 	arguments, err := docopt.Parse(usage, nil, false, "Disco 1.0", false)
 	if err != nil {
@@ -74,7 +82,8 @@ Usage:
 	// Get an API description.
 	if arguments["get"].(bool) {
 		// Read the list of APIs from the apis/list service.
-		listResponse, err := discovery.FetchList()
+		cache := openCacheIfRequested(arguments)
+		listResponse, err := fetchList(cache)
 		if err != nil {
 			log.Fatalf("%+v", err)
 		}
@@ -88,8 +97,15 @@ Usage:
 			}
 			for _, api := range listResponse.APIs {
 				log.Printf("%s/%s", api.Name, api.Version)
-				// Fetch the discovery description of the API.
-				bytes, err := discovery.FetchDocumentBytes(api.DiscoveryRestURL)
+				// Fetch the discovery description of the API. Cached
+				// descriptions are revalidated and only downloaded again
+				// if they have changed.
+				var bytes []byte
+				if cache != nil {
+					bytes, _, _, err = cache.Fetch(api, true)
+				} else {
+					bytes, err = discovery.FetchDocumentBytes(api.DiscoveryRestURL)
+				}
 				if err != nil {
 					log.Printf("%+v", err)
 					continue
@@ -132,6 +148,98 @@ Usage:
 		}
 	}
 
+	// Download all APIs into the local cache.
+	if arguments["fetch"].(bool) {
+		cacheDir := "disco-cache"
+		if arguments["--cache"] != nil {
+			cacheDir = arguments["--cache"].(string)
+		}
+		cache, err := discovery.NewCache(cacheDir)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		listResponse, err := cache.FetchList()
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		results := cache.FetchAll(listResponse.APIs, workersArgument(arguments), arguments["--refresh"].(bool))
+		fetched, cached, failed := 0, 0, 0
+		for _, result := range results {
+			switch {
+			case result.Err != nil:
+				failed++
+				log.Printf("%s/%s: %+v", result.API.Name, result.API.Version, result.Err)
+			case result.Cached:
+				cached++
+			default:
+				fetched++
+			}
+		}
+		log.Printf("%d fetched, %d already cached, %d failed", fetched, cached, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	}
+
+	// Compile a directory of API descriptions.
+	if arguments["compile"].(bool) {
+		workers := workersArgument(arguments)
+		results, err := compileCorpus(arguments["<dir>"].(string), workers)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		compiled, invalid, failed := 0, 0, 0
+		for _, result := range results {
+			switch {
+			case result.API == nil:
+				failed++
+				log.Printf("%s: %+v", result.File, result.Err)
+			case result.Err != nil:
+				invalid++
+				log.Printf("%s/%s (%s):\n%+v", result.API.Name, result.API.Version, result.File, result.Err)
+			default:
+				compiled++
+			}
+		}
+		log.Printf("%d compiled, %d with problems, %d failed", compiled, invalid, failed)
+		if arguments["--snapshot"] != nil {
+			bytes, err := json.MarshalIndent(discovery.NewSnapshotFromResults(results), "", "  ")
+			if err != nil {
+				log.Fatalf("%+v", err)
+			}
+			err = ioutil.WriteFile(arguments["--snapshot"].(string), bytes, 0644)
+			if err != nil {
+				log.Fatalf("%+v", err)
+			}
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	}
+
+	// Compare two snapshots of a corpus of API descriptions.
+	if arguments["diff"].(bool) {
+		workers := workersArgument(arguments)
+		before, err := readSnapshot(arguments["<old>"].(string), workers)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		after, err := readSnapshot(arguments["<new>"].(string), workers)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		diff := discovery.DiffSnapshots(before, after)
+		if arguments["--json"].(bool) {
+			bytes, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				log.Fatalf("%+v", err)
+			}
+			os.Stdout.Write(append(bytes, '\n'))
+		} else {
+			printSnapshotDiff(diff)
+		}
+	}
+
 	// Do something with a local API description.
 	if arguments["<file>"] != nil {
 		// Read the local file.
@@ -148,6 +256,89 @@ Usage:
 	}
 }
 
+// workersArgument returns the number of workers specified with --workers.
+func workersArgument(arguments map[string]interface{}) int {
+	workers, err := strconv.Atoi(arguments["--workers"].(string))
+	if err != nil {
+		log.Fatalf("Invalid number of workers: %s", arguments["--workers"])
+	}
+	return workers
+}
+
+// compileCorpus compiles the API descriptions in a directory or cache.
+func compileCorpus(dir string, workers int) ([]*discovery.CompileResult, error) {
+	files, err := discovery.CorpusFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	return discovery.CompileCorpus(files, workers), nil
+}
+
+// readSnapshot reads a snapshot that was saved with "disco compile" or
+// creates one by compiling the API descriptions in a directory.
+func readSnapshot(path string, workers int) (*discovery.Snapshot, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return discovery.ReadSnapshot(path)
+	}
+	results, err := compileCorpus(path, workers)
+	if err != nil {
+		return nil, err
+	}
+	return discovery.NewSnapshotFromResults(results), nil
+}
+
+// printSnapshotDiff prints the APIs and methods that were added (+),
+// removed (-), and changed (~).
+func printSnapshotDiff(diff *discovery.SnapshotDiff) {
+	if diff.IsEmpty() {
+		fmt.Println("No changes.")
+		return
+	}
+	for _, api := range diff.AddedAPIs {
+		fmt.Printf("+ %s\n", api)
+	}
+	for _, api := range diff.RemovedAPIs {
+		fmt.Printf("- %s\n", api)
+	}
+	for _, api := range diff.ChangedAPIs {
+		fmt.Printf("~ %s\n", api.API)
+		for _, method := range api.AddedMethods {
+			fmt.Printf("    + %s\n", method)
+		}
+		for _, method := range api.RemovedMethods {
+			fmt.Printf("    - %s\n", method)
+		}
+		for _, method := range api.ChangedMethods {
+			fmt.Printf("    ~ %s\n", method)
+		}
+	}
+}
+
+// fetchList reads the list of APIs with a cache, if there is one.
+func fetchList(cache *discovery.Cache) (*discovery.List, error) {
+	if cache != nil {
+		return cache.FetchList()
+	}
+	return discovery.FetchList()
+}
+
+// openCacheIfRequested returns the document cache named with --cache, or nil
+// if no cache was requested.
+func openCacheIfRequested(arguments map[string]interface{}) *discovery.Cache {
+	if arguments["--cache"] == nil {
+		return nil
+	}
+	cache, err := discovery.NewCache(arguments["--cache"].(string))
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	return cache
+}
+
 func handleExportArgumentsForBytes(arguments map[string]interface{}, bytes []byte) (handled bool, err error) {
 	// Unpack the discovery document.
 	document, err := discovery.ParseDocument(bytes)
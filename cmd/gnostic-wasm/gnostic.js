@@ -0,0 +1,64 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic.js loads gnostic.wasm and wraps its functions. It requires the
+// Go class that is defined by wasm_exec.js from the Go distribution.
+
+// load instantiates gnostic.wasm from a URL or from its bytes and returns
+// an object with compile, validate, and convert functions. Each function
+// takes the name of a description, its contents as a string, and an
+// optional object of options, and throws an Error if the description
+// can't be read.
+async function load(wasm) {
+  const go = new Go();
+  let result;
+  if (wasm instanceof ArrayBuffer || ArrayBuffer.isView(wasm)) {
+    result = await WebAssembly.instantiate(wasm, go.importObject);
+  } else {
+    result = await WebAssembly.instantiateStreaming(fetch(wasm), go.importObject);
+  }
+  go.run(result.instance);
+  const gnostic = globalThis.gnostic;
+  const check = (value) => {
+    if (value.error) {
+      throw new Error(value.error);
+    }
+    return value;
+  };
+  return {
+    // compile returns {format, version, diagnostics, document}, where
+    // document is the compiled description. Options:
+    //   resolveReferences: resolve references in OpenAPI descriptions
+    compile(name, contents, options) {
+      const value = check(gnostic.compile(name, contents, options || {}));
+      value.document = JSON.parse(value.document);
+      return value;
+    },
+    // validate returns {valid, format, diagnostics}.
+    validate(name, contents, options) {
+      return check(gnostic.validate(name, contents, options || {}));
+    },
+    // convert returns {format, contents} or, if the description has
+    // problems, {diagnostics}. Options:
+    //   format: "openapi2", "openapi3", or "discovery"
+    //   encoding: "json" (the default), "yaml", or "text"
+    convert(name, contents, options) {
+      return check(gnostic.convert(name, contents, options || {}));
+    },
+  };
+}
+
+if (typeof module !== "undefined") {
+  module.exports = { load };
+}
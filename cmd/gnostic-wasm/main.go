@@ -0,0 +1,168 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build js && wasm
+// +build js,wasm
+
+// gnostic-wasm is a WebAssembly module that compiles, validates, and
+// converts API descriptions in JavaScript programs. It sets a global
+// "gnostic" object with compile, validate, and convert functions that
+// are wrapped by gnostic.js.
+package main
+
+import (
+	"context"
+	"syscall/js"
+
+	"github.com/okkoye/gnostic/gnostic"
+	"github.com/okkoye/gnostic/lib"
+)
+
+// The names of formats in JavaScript.
+var formats = map[int]string{
+	lib.SourceFormatUnknown:   "",
+	lib.SourceFormatOpenAPI2:  "openapi2",
+	lib.SourceFormatOpenAPI3:  "openapi3",
+	lib.SourceFormatOpenAPI31: "openapi31",
+	lib.SourceFormatDiscovery: "discovery",
+}
+
+func main() {
+	js.Global().Set("gnostic", js.ValueOf(map[string]interface{}{
+		"compile":  js.FuncOf(compile),
+		"validate": js.FuncOf(validate),
+		"convert":  js.FuncOf(convert),
+	}))
+	// Keep the module running so that its functions can be called.
+	select {}
+}
+
+// compile(name, contents, options) compiles a description and returns
+// an object with its format, version, diagnostics, and document, which
+// is the compiled description as JSON.
+func compile(this js.Value, args []js.Value) interface{} {
+	result, err := compileArguments(args)
+	if err != nil {
+		return errorObject(err)
+	}
+	document, err := gnostic.EncodeWithAnnotations(result.Document, result.Annotations, gnostic.EncodingJSON)
+	if err != nil {
+		return errorObject(err)
+	}
+	return map[string]interface{}{
+		"format":      formats[result.Format],
+		"version":     result.Version,
+		"diagnostics": diagnostics(result.Diagnostics),
+		"document":    string(document),
+	}
+}
+
+// validate(name, contents, options) compiles a description and returns an
+// object with its format and diagnostics.
+func validate(this js.Value, args []js.Value) interface{} {
+	result, err := compileArguments(args)
+	if err != nil {
+		return errorObject(err)
+	}
+	return map[string]interface{}{
+		"valid":       len(result.Diagnostics) == 0,
+		"format":      formats[result.Format],
+		"diagnostics": diagnostics(result.Diagnostics),
+	}
+}
+
+// convert(name, contents, options) converts a description to the format
+// and encoding named by options.format and options.encoding and returns
+// an object with the converted contents.
+func convert(this js.Value, args []js.Value) interface{} {
+	result, err := compileArguments(args)
+	if err != nil {
+		return errorObject(err)
+	}
+	if len(result.Diagnostics) > 0 {
+		return map[string]interface{}{"diagnostics": diagnostics(result.Diagnostics)}
+	}
+	format := result.Format
+	if name := option(args, "format"); name.Type() == js.TypeString {
+		for f, n := range formats {
+			if n == name.String() && n != "" {
+				format = f
+			}
+		}
+	}
+	encoding := gnostic.EncodingJSON
+	if name := option(args, "encoding"); name.Type() == js.TypeString {
+		encoding = name.String()
+	}
+	document, err := gnostic.Convert(result.Document, format)
+	if err != nil {
+		return errorObject(err)
+	}
+	// Annotations only apply to documents that aren't converted.
+	annotations := result.Annotations
+	if format != result.Format {
+		annotations = nil
+	}
+	contents, err := gnostic.EncodeWithAnnotations(document, annotations, encoding)
+	if err != nil {
+		return errorObject(err)
+	}
+	return map[string]interface{}{
+		"format":   formats[format],
+		"contents": string(contents),
+	}
+}
+
+// Compile the description in the arguments of a function. Descriptions
+// are always compiled leniently so that their problems are returned as
+// diagnostics.
+func compileArguments(args []js.Value) (*gnostic.Result, error) {
+	if len(args) < 2 {
+		return nil, lib.NewUsageError("expected a name and contents")
+	}
+	name, contents := args[0].String(), []byte(args[1].String())
+	options := gnostic.NewOptions(
+		gnostic.WithLenientCompilation(),
+		gnostic.WithSourceContents(contents),
+	)
+	if resolve := option(args, "resolveReferences"); resolve.Type() == js.TypeBoolean && resolve.Bool() {
+		gnostic.WithReferenceResolution()(options)
+	}
+	return gnostic.Compile(context.Background(), name, options)
+}
+
+// Return the named option from a function's third argument.
+func option(args []js.Value, name string) js.Value {
+	if len(args) < 3 || args[2].Type() != js.TypeObject {
+		return js.Undefined()
+	}
+	return args[2].Get(name)
+}
+
+func diagnostics(diagnostics []gnostic.Diagnostic) []interface{} {
+	result := make([]interface{}, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		result[i] = map[string]interface{}{
+			"message": diagnostic.Message,
+			"path":    diagnostic.Path,
+			"line":    diagnostic.Line,
+			"column":  diagnostic.Column,
+		}
+	}
+	return result
+}
+
+func errorObject(err error) map[string]interface{} {
+	return map[string]interface{}{"error": err.Error()}
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gotypes generates Go types from the schemas of a JSON Schema or
+// from the component schemas of an OpenAPI description.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/conversions"
+	"github.com/okkoye/gnostic/jsonschema"
+)
+
+func main() {
+	usage := `
+Usage:
+	gotypes help
+	gotypes <source> [--package=<name>] [--type=<name>] [--out=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Go Types 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nGenerate Go types from a JSON Schema or from the component schemas")
+		fmt.Println("of an OpenAPI description.")
+		fmt.Println(usage)
+		return
+	}
+
+	source := arguments["<source>"].(string)
+	packageName, ok := arguments["--package"].(string)
+	if !ok {
+		packageName = "types"
+	}
+	typeName, _ := arguments["--type"].(string)
+
+	bytes, err := compiler.ReadBytesForFile(source)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(bytes, &document); err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		log.Fatalf("%s does not contain a JSON Schema or an OpenAPI description", source)
+	}
+	root := document.Content[0]
+
+	generator := jsonschema.NewGoTypeGenerator(packageName)
+	generator.Generator = "gotypes"
+	if schemas := componentSchemas(root); schemas != nil {
+		// Add the component schemas of an OpenAPI description.
+		named := make(map[string]*jsonschema.Schema)
+		for i := 0; i+1 < len(schemas.Content); i += 2 {
+			name := schemas.Content[i].Value
+			schema := jsonschema.NewSchemaFromObject(conversions.JSONSchemaNodeForOpenAPIv3SchemaNode(schemas.Content[i+1]))
+			if schema == nil {
+				log.Fatalf("%s: invalid schema %s", source, name)
+			}
+			named[name] = schema
+		}
+		generator.AddSchemas(named)
+	} else {
+		// Add a JSON Schema and its definitions.
+		schema, err := jsonschema.NewSchemaFromFile(source)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		named := make(map[string]*jsonschema.Schema)
+		for _, definitions := range []*[]*jsonschema.NamedSchema{schema.Definitions, schema.Defs} {
+			if definitions == nil {
+				continue
+			}
+			for _, pair := range *definitions {
+				named[pair.Name] = pair.Value
+			}
+		}
+		if typeName == "" && schema.Title != nil {
+			typeName = *schema.Title
+		}
+		if typeName == "" {
+			typeName = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+		}
+		generator.AddSchemas(named)
+		if schema.Properties != nil || schema.Type != nil || schema.OneOf != nil ||
+			schema.AnyOf != nil || schema.AllOf != nil || schema.Enumeration != nil {
+			generator.AddSchema(typeName, schema)
+		}
+	}
+
+	code, err := generator.Generate()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if out, ok := arguments["--out"].(string); ok {
+		if err := ioutil.WriteFile(out, code, 0644); err != nil {
+			log.Fatalf("%+v", err)
+		}
+	} else {
+		os.Stdout.Write(code)
+	}
+}
+
+// componentSchemas returns the map of named schemas in an OpenAPI v3
+// or v2 description, or nil if the document isn't an OpenAPI description.
+func componentSchemas(root *yaml.Node) *yaml.Node {
+	if compiler.MapValueForKey(root, "openapi") != nil {
+		if components := compiler.MapValueForKey(root, "components"); components != nil {
+			if schemas := compiler.MapValueForKey(components, "schemas"); schemas != nil {
+				return schemas
+			}
+		}
+		return &yaml.Node{Kind: yaml.MappingNode}
+	}
+	if compiler.MapValueForKey(root, "swagger") != nil {
+		if definitions := compiler.MapValueForKey(root, "definitions"); definitions != nil {
+			return definitions
+		}
+		return &yaml.Node{Kind: yaml.MappingNode}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// grpc-openapi describes the HTTP interfaces of running gRPC servers with
+// OpenAPI v3 descriptions.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/okkoye/gnostic/gnostic"
+)
+
+func main() {
+	usage := `
+Usage:
+	grpc-openapi help
+	grpc-openapi <address> [--service=<name>...] [--plaintext] [--out=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "gRPC OpenAPI 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nDescribe the HTTP interface of a running gRPC server with OpenAPI v3.")
+		fmt.Println(usage)
+		fmt.Println("The server at <address> must support the server reflection service.")
+		fmt.Println("All of its services are described unless --service options name them.")
+		fmt.Println("Methods are described by their google.api.http annotations; unary methods")
+		fmt.Println("without annotations are described as POST requests to their gRPC paths.")
+		fmt.Println("Connections use TLS unless --plaintext is specified. The description is")
+		fmt.Println("written in YAML, or in JSON or a binary protocol buffer if the --out file")
+		fmt.Println("ends in .json or .pb.")
+		fmt.Println()
+		return
+	}
+
+	creds := credentials.NewTLS(&tls.Config{})
+	if arguments["--plaintext"].(bool) {
+		creds = insecure.NewCredentials()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, arguments["<address>"].(string), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	defer conn.Close()
+	request, err := newCodeGeneratorRequest(ctx, conn, arguments["--service"].([]string))
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	document, err := newDocument(request)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	encoding := gnostic.EncodingYAML
+	if out, ok := arguments["--out"].(string); ok {
+		switch strings.ToLower(filepath.Ext(out)) {
+		case ".json":
+			encoding = gnostic.EncodingJSON
+		case ".pb":
+			encoding = gnostic.EncodingBinary
+		}
+	}
+	bytes, err := gnostic.Encode(document, encoding)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if arguments["--out"] == nil {
+		os.Stdout.Write(bytes)
+		return
+	}
+	if err := ioutil.WriteFile(arguments["--out"].(string), bytes, 0644); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/okkoye/gnostic/cmd/protoc-gen-openapi/generator"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// reflectionClient reads descriptors from a server with the server reflection service.
+type reflectionClient struct {
+	stream reflectionpb.ServerReflection_ServerReflectionInfoClient
+	files  map[string]*descriptorpb.FileDescriptorProto
+}
+
+func newReflectionClient(ctx context.Context, conn grpc.ClientConnInterface) (*reflectionClient, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &reflectionClient{stream: stream, files: make(map[string]*descriptorpb.FileDescriptorProto)}, nil
+}
+
+// send sends a request and returns its response, or an error if the server reported one.
+func (c *reflectionClient) send(request *reflectionpb.ServerReflectionRequest) (*reflectionpb.ServerReflectionResponse, error) {
+	if err := c.stream.Send(request); err != nil {
+		return nil, err
+	}
+	response, err := c.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if e := response.GetErrorResponse(); e != nil {
+		return nil, fmt.Errorf("server reflection error %d: %s", e.ErrorCode, e.ErrorMessage)
+	}
+	return response, nil
+}
+
+// listServices returns the names of the services of the server, except the reflection service.
+func (c *reflectionClient) listServices() ([]string, error) {
+	response, err := c.send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	services := make([]string, 0)
+	for _, service := range response.GetListServicesResponse().GetService() {
+		switch service.Name {
+		case "grpc.reflection.v1alpha.ServerReflection", "grpc.reflection.v1.ServerReflection":
+		default:
+			services = append(services, service.Name)
+		}
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// addFiles saves the file descriptors of a response.
+func (c *reflectionClient) addFiles(response *reflectionpb.ServerReflectionResponse) error {
+	for _, b := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(b, file); err != nil {
+			return err
+		}
+		c.files[file.GetName()] = file
+	}
+	return nil
+}
+
+// fileContainingSymbol reads the descriptor of the file that defines a symbol and returns its name.
+func (c *reflectionClient) fileContainingSymbol(symbol string) (string, error) {
+	response, err := c.send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	})
+	if err != nil {
+		return "", err
+	}
+	descriptors := response.GetFileDescriptorResponse().GetFileDescriptorProto()
+	if len(descriptors) == 0 {
+		return "", fmt.Errorf("no file defines %s", symbol)
+	}
+	if err := c.addFiles(response); err != nil {
+		return "", err
+	}
+	// The first file is the one that defines the symbol.
+	file := &descriptorpb.FileDescriptorProto{}
+	if err := proto.Unmarshal(descriptors[0], file); err != nil {
+		return "", err
+	}
+	return file.GetName(), nil
+}
+
+// readDependencies reads the descriptors of all files that the saved files depend on.
+func (c *reflectionClient) readDependencies() error {
+	for {
+		missing := ""
+		for _, file := range c.files {
+			for _, dependency := range file.Dependency {
+				if c.files[dependency] == nil {
+					missing = dependency
+				}
+			}
+		}
+		if missing == "" {
+			return nil
+		}
+		response, err := c.send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: missing},
+		})
+		if err != nil {
+			return err
+		}
+		if err := c.addFiles(response); err != nil {
+			return err
+		}
+		if c.files[missing] == nil {
+			return fmt.Errorf("the server didn't return %s", missing)
+		}
+	}
+}
+
+// sortedFiles returns the saved files ordered so that each file follows its dependencies.
+func (c *reflectionClient) sortedFiles() []*descriptorpb.FileDescriptorProto {
+	names := make([]string, 0, len(c.files))
+	for name := range c.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sorted := make([]*descriptorpb.FileDescriptorProto, 0, len(names))
+	added := make(map[string]bool)
+	var add func(name string)
+	add = func(name string) {
+		if added[name] {
+			return
+		}
+		added[name] = true
+		for _, dependency := range c.files[name].Dependency {
+			add(dependency)
+		}
+		sorted = append(sorted, c.files[name])
+	}
+	for _, name := range names {
+		add(name)
+	}
+	return sorted
+}
+
+// newCodeGeneratorRequest returns a request to generate code for services
+// of a server. When no services are named, all services are included.
+func newCodeGeneratorRequest(ctx context.Context, conn grpc.ClientConnInterface, services []string) (*pluginpb.CodeGeneratorRequest, error) {
+	c, err := newReflectionClient(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer c.stream.CloseSend()
+	if len(services) == 0 {
+		services, err = c.listServices()
+		if err != nil {
+			return nil, err
+		}
+		if len(services) == 0 {
+			return nil, fmt.Errorf("the server has no services")
+		}
+	}
+	filesToGenerate := make([]string, 0)
+	selected := make(map[string]bool)
+	for _, service := range services {
+		name, err := c.fileContainingSymbol(service)
+		if err != nil {
+			return nil, err
+		}
+		if !selected[name] {
+			filesToGenerate = append(filesToGenerate, name)
+		}
+		selected[name] = true
+		selected[service] = true
+	}
+	if err := c.readDependencies(); err != nil {
+		return nil, err
+	}
+	for _, name := range filesToGenerate {
+		file := c.files[name]
+		kept := make([]*descriptorpb.ServiceDescriptorProto, 0)
+		for _, service := range file.Service {
+			if selected[qualifiedName(file, service.GetName())] {
+				addDefaultBindings(file, service)
+				kept = append(kept, service)
+			}
+		}
+		file.Service = kept
+	}
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: filesToGenerate,
+		ProtoFile:      c.sortedFiles(),
+	}, nil
+}
+
+func qualifiedName(file *descriptorpb.FileDescriptorProto, name string) string {
+	if file.GetPackage() == "" {
+		return name
+	}
+	return file.GetPackage() + "." + name
+}
+
+// addDefaultBindings binds unary methods without transcoding annotations
+// to POST requests to their gRPC paths with the request message in the body.
+func addDefaultBindings(file *descriptorpb.FileDescriptorProto, service *descriptorpb.ServiceDescriptorProto) {
+	for _, method := range service.Method {
+		if method.GetClientStreaming() || method.GetServerStreaming() {
+			continue
+		}
+		if method.Options != nil && proto.HasExtension(method.Options, annotations.E_Http) {
+			continue
+		}
+		if method.Options == nil {
+			method.Options = &descriptorpb.MethodOptions{}
+		}
+		proto.SetExtension(method.Options, annotations.E_Http, &annotations.HttpRule{
+			Pattern: &annotations.HttpRule_Post{
+				Post: "/" + qualifiedName(file, service.GetName()) + "/" + method.GetName(),
+			},
+			Body: "*",
+		})
+	}
+}
+
+// newDocument builds an OpenAPI v3 document for the services in a code generator request.
+func newDocument(request *pluginpb.CodeGeneratorRequest) (*openapiv3.Document, error) {
+	// protogen requires Go import paths, but they aren't used to build documents.
+	for _, file := range request.ProtoFile {
+		if file.GetOptions().GetGoPackage() == "" {
+			if file.Options == nil {
+				file.Options = &descriptorpb.FileOptions{}
+			}
+			file.Options.GoPackage = proto.String(path.Dir(file.GetName()))
+		}
+	}
+	plugin, err := protogen.Options{}.New(request)
+	if err != nil {
+		return nil, err
+	}
+	circularDepth := 2
+	conf := generator.Configuration{
+		Version:         proto.String("0.0.1"),
+		Title:           proto.String(""),
+		Description:     proto.String(""),
+		Naming:          proto.String("json"),
+		FQSchemaNaming:  proto.Bool(false),
+		EnumType:        proto.String("integer"),
+		CircularDepth:   &circularDepth,
+		DefaultResponse: proto.Bool(true),
+		OutputMode:      proto.String("merged"),
+	}
+	return generator.NewOpenAPIv3Generator(plugin, conf, plugin.Files).Document(), nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// services lists the services of a reflection server.
+type services map[string]grpc.ServiceInfo
+
+func (s services) GetServiceInfo() map[string]grpc.ServiceInfo {
+	return s
+}
+
+// The bookstore service has a method with a transcoding annotation and
+// a method without one.
+func bookstoreFile() *descriptorpb.FileDescriptorProto {
+	getOptions := &descriptorpb.MethodOptions{}
+	proto.SetExtension(getOptions, annotations.E_Http, &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Get{Get: "/v1/shelves/{shelf}"},
+	})
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("bookstore.proto"),
+		Package:    proto.String("example.bookstore"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/api/annotations.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Shelf"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("name"), JsonName: proto.String("name"), Number: proto.Int32(1), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+					{Name: proto.String("theme"), JsonName: proto.String("theme"), Number: proto.Int32(2), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+				},
+			},
+			{
+				Name: proto.String("GetShelfRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("shelf"), JsonName: proto.String("shelf"), Number: proto.Int32(1), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()},
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Bookstore"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("GetShelf"), InputType: proto.String(".example.bookstore.GetShelfRequest"), OutputType: proto.String(".example.bookstore.Shelf"), Options: getOptions},
+				{Name: proto.String("CreateShelf"), InputType: proto.String(".example.bookstore.Shelf"), OutputType: proto.String(".example.bookstore.Shelf")},
+				{Name: proto.String("WatchShelf"), InputType: proto.String(".example.bookstore.GetShelfRequest"), OutputType: proto.String(".example.bookstore.Shelf"), ServerStreaming: proto.Bool(true)},
+			},
+		}},
+	}
+}
+
+func TestDocumentForReflectedServices(t *testing.T) {
+	// Describe the bookstore service with a reflection server.
+	file, err := protodesc.NewFile(bookstoreFile(), protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := &protoregistry.Files{}
+	for _, path := range []string{"google/protobuf/descriptor.proto", "google/api/http.proto", "google/api/annotations.proto"} {
+		dependency, err := protoregistry.GlobalFiles.FindFileByPath(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := files.RegisterFile(dependency); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := files.RegisterFile(file); err != nil {
+		t.Fatal(err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	reflectionpb.RegisterServerReflectionServer(server, reflection.NewServer(reflection.ServerOptions{
+		Services:           services{"example.bookstore.Bookstore": {}},
+		DescriptorResolver: files,
+	}))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	request, err := newCodeGeneratorRequest(ctx, conn, nil)
+	if err != nil {
+		t.Fatalf("newCodeGeneratorRequest() returned error %s", err)
+	}
+	if len(request.FileToGenerate) != 1 || request.FileToGenerate[0] != "bookstore.proto" {
+		t.Errorf("files to generate are %v, want [bookstore.proto]", request.FileToGenerate)
+	}
+	if n := len(request.ProtoFile); n != 4 {
+		t.Errorf("request has %d files, want 4", n)
+	}
+	document, err := newDocument(request)
+	if err != nil {
+		t.Fatalf("newDocument() returned error %s", err)
+	}
+	bytes, err := document.YAMLValue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openapiv3.ParseDocument(bytes); err != nil {
+		t.Fatalf("the document is invalid: %s", err)
+	}
+	paths := make(map[string]*openapiv3.PathItem)
+	for _, path := range document.Paths.Path {
+		paths[path.Name] = path.Value
+	}
+	if len(paths) != 2 {
+		t.Errorf("document has %d paths, want 2", len(paths))
+	}
+	if paths["/v1/shelves/{shelf}"].GetGet() == nil {
+		t.Errorf("GetShelf should be described by its annotation")
+	}
+	if paths["/example.bookstore.Bookstore/CreateShelf"].GetPost() == nil {
+		t.Errorf("CreateShelf should be described with a default binding")
+	}
+}
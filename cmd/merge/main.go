@@ -0,0 +1,109 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// merge combines several OpenAPI v3 descriptions into one.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/jsonwriter"
+	"github.com/okkoye/gnostic/lib"
+	"github.com/okkoye/gnostic/transform"
+)
+
+func main() {
+	usage := `
+Usage:
+	merge help
+	merge <source>... [--title=<title>] [--version=<version>] [--prefix-all] [--json] [--out=<file>] [--report=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Merge 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nMerge several OpenAPI v3 descriptions into one.")
+		fmt.Println(usage)
+		fmt.Println("Each <source> can be written as name=filename to set the prefix used for its")
+		fmt.Println("components when their names collide. By default, the base filename is used.")
+		fmt.Println()
+		return
+	}
+
+	inputs := make([]*transform.MergeInput, 0)
+	for _, source := range arguments["<source>"].([]string) {
+		name := strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+		if parts := strings.SplitN(source, "=", 2); len(parts) == 2 && !strings.Contains(parts[0], "/") {
+			name, source = parts[0], parts[1]
+		}
+		document, format, err := lib.ReadRawInfo(source)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		if format != lib.SourceFormatOpenAPI3 {
+			log.Fatalf("%s is not an OpenAPI v3 description", source)
+		}
+		inputs = append(inputs, &transform.MergeInput{Name: name, Document: document})
+	}
+	options := &transform.MergeOptions{PrefixAll: arguments["--prefix-all"].(bool)}
+	if title, ok := arguments["--title"].(string); ok {
+		options.Title = title
+	}
+	if version, ok := arguments["--version"].(string); ok {
+		options.Version = version
+	}
+	merged, conflicts := transform.Merge(inputs, options)
+
+	// Write the conflict report.
+	var report strings.Builder
+	fmt.Fprintf(&report, "%d conflicts\n", len(conflicts))
+	for _, conflict := range conflicts {
+		fmt.Fprintf(&report, "%s\n", conflict)
+	}
+	if filename, ok := arguments["--report"].(string); ok {
+		if err := ioutil.WriteFile(filename, []byte(report.String()), 0644); err != nil {
+			log.Fatalf("%+v", err)
+		}
+	} else {
+		fmt.Fprint(os.Stderr, report.String())
+	}
+
+	// Write the merged description.
+	var bytes []byte
+	out, _ := arguments["--out"].(string)
+	if arguments["--json"].(bool) || strings.HasSuffix(out, ".json") {
+		bytes, err = jsonwriter.Marshal(merged)
+	} else {
+		bytes, err = yaml.Marshal(merged)
+	}
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if out == "" {
+		os.Stdout.Write(bytes)
+	} else if err := ioutil.WriteFile(out, bytes, 0644); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// mock generates sample payloads from the schemas of an OpenAPI v3 description.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/docopt/docopt-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/jsonwriter"
+	"github.com/okkoye/gnostic/lib"
+	"github.com/okkoye/gnostic/mock"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+func main() {
+	usage := `
+Usage:
+	mock help
+	mock <source> [--schema=<name>...] [--seed=<n>] [--count=<n>] [--depth=<n>] [--request] [--yaml]
+
+Options:
+	--schema=<name>  Generate values for the named component schema (default: all schemas).
+	--seed=<n>       Seed for the random generator [default: 1].
+	--count=<n>      Number of values to generate for each schema [default: 1].
+	--depth=<n>      Nesting depth below which only required properties are generated [default: 5].
+	--request        Generate request payloads, omitting readOnly properties instead of writeOnly ones.
+	--yaml           Write YAML instead of JSON.
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Mock 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nGenerate sample payloads from the schemas of an OpenAPI v3 description.")
+		fmt.Println(usage)
+		return
+	}
+
+	source := arguments["<source>"].(string)
+	message, format, err := lib.ReadDocument(source)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if format != lib.SourceFormatOpenAPI3 {
+		log.Fatalf("%s is not an OpenAPI v3 description", source)
+	}
+	document := message.(*openapi_v3.Document)
+
+	seed, err := strconv.ParseInt(arguments["--seed"].(string), 10, 64)
+	if err != nil {
+		log.Fatalf("invalid seed: %s", arguments["--seed"].(string))
+	}
+	count := positiveInteger(arguments, "--count")
+	generator := mock.NewGenerator(document, seed)
+	generator.MaxDepth = positiveInteger(arguments, "--depth")
+	generator.ForRequests = arguments["--request"].(bool)
+
+	names := arguments["--schema"].([]string)
+	if len(names) == 0 {
+		for _, named := range document.GetComponents().GetSchemas().GetAdditionalProperties() {
+			names = append(names, named.Name)
+		}
+	}
+	values := make([]*yaml.Node, 0, len(names))
+	for _, name := range names {
+		var value *yaml.Node
+		if count == 1 {
+			value, err = generator.GenerateNamed(name)
+		} else {
+			value = compiler.NewSequenceNode()
+			for i := 0; i < count && err == nil; i++ {
+				var item *yaml.Node
+				item, err = generator.GenerateNamed(name)
+				value.Content = append(value.Content, item)
+			}
+		}
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		values = append(values, value)
+	}
+
+	// A single schema is written alone, several are written in a map keyed by name.
+	output := compiler.NewMappingNode()
+	if len(names) == 1 {
+		output = values[0]
+	} else {
+		for i, name := range names {
+			output.Content = append(output.Content, compiler.NewScalarNodeForString(name), values[i])
+		}
+	}
+	var bytes []byte
+	if arguments["--yaml"].(bool) {
+		bytes, err = yaml.Marshal(output)
+	} else {
+		bytes, err = jsonwriter.Marshal(output)
+	}
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	os.Stdout.Write(bytes)
+}
+
+func positiveInteger(arguments map[string]interface{}, name string) int {
+	n, err := strconv.Atoi(arguments[name].(string))
+	if err != nil || n < 1 {
+		log.Fatalf("invalid value for %s: %s", name, arguments[name].(string))
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// normalize rewrites an OpenAPI description into a canonical form.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/jsonwriter"
+	"github.com/okkoye/gnostic/lib"
+	"github.com/okkoye/gnostic/transform"
+)
+
+func main() {
+	usage := `
+Usage:
+	normalize help
+	normalize <source> [--json] [--out=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Normalize 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nRewrite an OpenAPI v2 or v3 description into a canonical form so that")
+		fmt.Println("differences between revisions reflect changes in meaning only.")
+		fmt.Println(usage)
+		return
+	}
+
+	source := arguments["<source>"].(string)
+	document, format, err := lib.ReadRawInfo(source)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if format != lib.SourceFormatOpenAPI2 && format != lib.SourceFormatOpenAPI3 {
+		log.Fatalf("%s is not an OpenAPI description", source)
+	}
+	transform.Normalize(document)
+
+	var bytes []byte
+	out, _ := arguments["--out"].(string)
+	if arguments["--json"].(bool) || strings.HasSuffix(out, ".json") {
+		bytes, err = jsonwriter.Marshal(document)
+	} else {
+		bytes, err = yaml.Marshal(document)
+	}
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if out == "" {
+		os.Stdout.Write(bytes)
+	} else if err := ioutil.WriteFile(out, bytes, 0644); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// odata converts OData metadata documents to OpenAPI descriptions.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/gnostic"
+	"github.com/okkoye/gnostic/odata"
+)
+
+func main() {
+	usage := `
+Usage:
+	odata help
+	odata import <metadata> [--service-root=<url>] [--out=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "OData 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nConvert OData metadata documents to OpenAPI descriptions.")
+		fmt.Println(usage)
+		fmt.Println("The <metadata> is a CSDL XML document, such as the $metadata of a service,")
+		fmt.Println("read from a file or a URL. An import writes an OpenAPI v3 description in")
+		fmt.Println("YAML, or in JSON or a binary protocol buffer if the --out file ends in")
+		fmt.Println(".json or .pb. Paths are relative to the --service-root URL.")
+		fmt.Println()
+	}
+
+	// Import an OData metadata document as an OpenAPI description.
+	if arguments["import"].(bool) {
+		source := arguments["<metadata>"].(string)
+		bytes, err := compiler.ReadBytesForFile(source)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		metadata, err := odata.ParseMetadata(bytes)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		serviceRoot, _ := arguments["--service-root"].(string)
+		if serviceRoot == "" && strings.HasSuffix(source, "/$metadata") {
+			serviceRoot = strings.TrimSuffix(source, "$metadata")
+		}
+		document, err := odata.NewOpenAPIv3FromMetadata(metadata, serviceRoot)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		encoding := gnostic.EncodingYAML
+		if out, ok := arguments["--out"].(string); ok {
+			switch strings.ToLower(filepath.Ext(out)) {
+			case ".json":
+				encoding = gnostic.EncodingJSON
+			case ".pb":
+				encoding = gnostic.EncodingBinary
+			}
+		}
+		bytes, err = gnostic.Encode(document, encoding)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		if arguments["--out"] == nil {
+			os.Stdout.Write(bytes)
+			return
+		}
+		if err := ioutil.WriteFile(arguments["--out"].(string), bytes, 0644); err != nil {
+			log.Fatalf("%+v", err)
+		}
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"os"
 	"os/exec"
 	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	openapi_v31 "github.com/okkoye/gnostic/openapiv31"
 )
 
 func testBuilder(version string, t *testing.T) {
@@ -94,3 +98,26 @@ func TestBuilderV2(t *testing.T) {
 func TestBuilderV3(t *testing.T) {
 	testBuilder("v3", t)
 }
+
+func TestBuilderV31(t *testing.T) {
+	document := buildDocumentV31()
+	bytes, err := document.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The exported description should compile back into the same model.
+	compiled, err := openapi_v31.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !proto.Equal(document, compiled) {
+		t.Errorf("compiled document differs from the built document:\n%s", bytes)
+	}
+	if compiled.JsonSchemaDialect != jsonSchemaDialect {
+		t.Errorf("unexpected jsonSchemaDialect: %q", compiled.JsonSchemaDialect)
+	}
+	if compiled.Webhooks == nil || len(compiled.Webhooks.AdditionalProperties) != 1 ||
+		compiled.Webhooks.AdditionalProperties[0].Value.Post == nil {
+		t.Errorf("missing newPet webhook")
+	}
+}
@@ -31,12 +31,15 @@ Options:
     Generate an OpenAPI v2 description.
   --v3
     Generate an OpenAPI v3 description.
+  --v31
+    Generate an OpenAPI v3.1 description in YAML.
 `, path.Base(os.Args[0]))
 }
 
 func main() {
 	openAPIv2 := false
 	openAPIv3 := false
+	openAPIv31 := false
 
 	for i, arg := range os.Args {
 		if i == 0 {
@@ -46,13 +49,15 @@ func main() {
 			openAPIv2 = true
 		} else if arg == "--v3" {
 			openAPIv3 = true
+		} else if arg == "--v31" {
+			openAPIv31 = true
 		} else {
 			fmt.Printf("Unknown option: %s.\n%s\n", arg, usage())
 			os.Exit(-1)
 		}
 	}
 
-	if !openAPIv2 && !openAPIv3 {
+	if !openAPIv2 && !openAPIv3 && !openAPIv31 {
 		openAPIv2 = true
 	}
 
@@ -79,4 +84,16 @@ func main() {
 			panic(err)
 		}
 	}
+
+	if openAPIv31 {
+		document := buildDocumentV31()
+		bytes, err := document.YAMLValue("")
+		if err != nil {
+			panic(err)
+		}
+		err = ioutil.WriteFile("petstore-v31.yaml", bytes, 0644)
+		if err != nil {
+			panic(err)
+		}
+	}
 }
@@ -0,0 +1,261 @@
+// Copyright 2017 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	v31 "github.com/okkoye/gnostic/openapiv31"
+)
+
+// jsonSchemaDialect is the default dialect for schemas in OpenAPI 3.1 documents.
+const jsonSchemaDialect = "https://spec.openapis.org/oas/3.1/dialect/base"
+
+// buildDocumentV31 builds an OpenAPI 3.1 description of the petstore. It
+// describes the same API as buildDocumentV3 and adds fields that are new in
+// 3.1: the license identifier, jsonSchemaDialect, and webhooks.
+func buildDocumentV31() *v31.Document {
+	d := &v31.Document{}
+	d.Openapi = "3.1.0"
+	d.Info = &v31.Info{
+		Title:   "OpenAPI Petstore",
+		Version: "1.0.0",
+		License: &v31.License{Name: "MIT", Identifier: "MIT"},
+	}
+	d.JsonSchemaDialect = jsonSchemaDialect
+	d.Servers = append(d.Servers, &v31.Server{
+		Url:         "https://petstore.openapis.org/v1",
+		Description: "Development server",
+	})
+	d.Paths = &v31.Paths{}
+	d.Paths.Path = append(d.Paths.Path,
+		&v31.NamedPathItem{
+			Name: "/pets",
+			Value: &v31.PathItem{
+				Get: &v31.Operation{
+					Summary:     "List all pets",
+					OperationId: "listPets",
+					Tags:        []string{"pets"},
+					Parameters: []*v31.ParameterOrReference{
+						parameterV31(&v31.Parameter{
+							Name:        "limit",
+							In:          "query",
+							Description: "How many items to return at one time (max 100)",
+							Required:    false,
+							Schema:      schemaV31("integer", "int32"),
+						}),
+					},
+					Responses: &v31.Responses{
+						Default: responseV31("unexpected error", "#/components/schemas/Error"),
+						ResponseOrReference: []*v31.NamedResponseOrReference{
+							&v31.NamedResponseOrReference{
+								Name: "200",
+								Value: &v31.ResponseOrReference{
+									Oneof: &v31.ResponseOrReference_Response{
+										Response: &v31.Response{
+											Description: "An paged array of pets", // [sic] match other examples
+											Content:     mediaTypesV31("application/json", "#/components/schemas/Pets"),
+											Headers: &v31.HeadersOrReferences{
+												AdditionalProperties: []*v31.NamedHeaderOrReference{
+													&v31.NamedHeaderOrReference{
+														Name: "x-next",
+														Value: &v31.HeaderOrReference{
+															Oneof: &v31.HeaderOrReference_Header{
+																Header: &v31.Header{
+																	Description: "A link to the next page of responses",
+																	Schema:      schemaV31("string", ""),
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Post: &v31.Operation{
+					Summary:     "Create a pet",
+					OperationId: "createPets",
+					Tags:        []string{"pets"},
+					Responses: &v31.Responses{
+						Default: responseV31("unexpected error", "#/components/schemas/Error"),
+						ResponseOrReference: []*v31.NamedResponseOrReference{
+							&v31.NamedResponseOrReference{
+								Name:  "201",
+								Value: responseV31("Null response", ""),
+							},
+						},
+					},
+				},
+			}},
+		&v31.NamedPathItem{
+			Name: "/pets/{petId}",
+			Value: &v31.PathItem{
+				Get: &v31.Operation{
+					Summary:     "Info for a specific pet",
+					OperationId: "showPetById",
+					Tags:        []string{"pets"},
+					Parameters: []*v31.ParameterOrReference{
+						parameterV31(&v31.Parameter{
+							Name:        "petId",
+							In:          "path",
+							Description: "The id of the pet to retrieve",
+							Required:    true,
+							Schema:      schemaV31("string", ""),
+						}),
+					},
+					Responses: &v31.Responses{
+						Default: responseV31("unexpected error", "#/components/schemas/Error"),
+						ResponseOrReference: []*v31.NamedResponseOrReference{
+							&v31.NamedResponseOrReference{
+								Name:  "200",
+								Value: responseV31("Expected response to a valid request", "#/components/schemas/Pets"),
+							},
+						},
+					},
+				},
+			}})
+	// Webhooks describe requests that the API sends to its clients.
+	d.Webhooks = &v31.PathItems{
+		AdditionalProperties: []*v31.NamedPathItem{
+			&v31.NamedPathItem{
+				Name: "newPet",
+				Value: &v31.PathItem{
+					Post: &v31.Operation{
+						Summary:     "Notify a client that a pet was added",
+						OperationId: "newPet",
+						RequestBody: &v31.RequestBodyOrReference{
+							Oneof: &v31.RequestBodyOrReference_RequestBody{
+								RequestBody: &v31.RequestBody{
+									Description: "Information about a new pet in the system",
+									Content:     mediaTypesV31("application/json", "#/components/schemas/Pet"),
+								},
+							},
+						},
+						Responses: &v31.Responses{
+							ResponseOrReference: []*v31.NamedResponseOrReference{
+								&v31.NamedResponseOrReference{
+									Name:  "200",
+									Value: responseV31("Return a 200 status to indicate that the data was received successfully", ""),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	d.Components = &v31.Components{
+		Schemas: &v31.SchemasOrReferences{
+			AdditionalProperties: []*v31.NamedSchemaOrReference{
+				&v31.NamedSchemaOrReference{
+					Name: "Pet",
+					Value: &v31.SchemaOrReference{
+						Oneof: &v31.SchemaOrReference_Schema{
+							Schema: &v31.Schema{
+								Required: []string{"id", "name"},
+								Properties: &v31.Properties{
+									AdditionalProperties: []*v31.NamedSchemaOrReference{
+										&v31.NamedSchemaOrReference{Name: "id", Value: schemaV31("integer", "int64")},
+										&v31.NamedSchemaOrReference{Name: "name", Value: schemaV31("string", "")},
+										&v31.NamedSchemaOrReference{Name: "tag", Value: schemaV31("string", "")},
+									},
+								},
+							},
+						},
+					},
+				},
+				&v31.NamedSchemaOrReference{
+					Name: "Pets",
+					Value: &v31.SchemaOrReference{
+						Oneof: &v31.SchemaOrReference_Schema{
+							Schema: &v31.Schema{
+								Type:  &v31.TypeItem{Value: []string{"array"}},
+								Items: referenceV31("#/components/schemas/Pet"),
+							},
+						},
+					},
+				},
+				&v31.NamedSchemaOrReference{
+					Name: "Error",
+					Value: &v31.SchemaOrReference{
+						Oneof: &v31.SchemaOrReference_Schema{
+							Schema: &v31.Schema{
+								Required: []string{"code", "message"},
+								Properties: &v31.Properties{
+									AdditionalProperties: []*v31.NamedSchemaOrReference{
+										&v31.NamedSchemaOrReference{Name: "code", Value: schemaV31("integer", "int32")},
+										&v31.NamedSchemaOrReference{Name: "message", Value: schemaV31("string", "")},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return d
+}
+
+func parameterV31(parameter *v31.Parameter) *v31.ParameterOrReference {
+	return &v31.ParameterOrReference{
+		Oneof: &v31.ParameterOrReference_Parameter{Parameter: parameter},
+	}
+}
+
+func schemaV31(typeName string, format string) *v31.SchemaOrReference {
+	return &v31.SchemaOrReference{
+		Oneof: &v31.SchemaOrReference_Schema{
+			Schema: &v31.Schema{
+				Type:   &v31.TypeItem{Value: []string{typeName}},
+				Format: format,
+			},
+		},
+	}
+}
+
+func referenceV31(ref string) *v31.SchemaOrReference {
+	return &v31.SchemaOrReference{
+		Oneof: &v31.SchemaOrReference_Reference{
+			Reference: &v31.Reference{XRef: ref},
+		},
+	}
+}
+
+// responseV31 returns a response with a JSON body described by ref, or
+// with no body if ref is empty.
+func responseV31(description string, ref string) *v31.ResponseOrReference {
+	response := &v31.Response{Description: description}
+	if ref != "" {
+		response.Content = mediaTypesV31("application/json", ref)
+	}
+	return &v31.ResponseOrReference{
+		Oneof: &v31.ResponseOrReference_Response{Response: response},
+	}
+}
+
+func mediaTypesV31(mediaType string, ref string) *v31.MediaTypes {
+	return &v31.MediaTypes{
+		AdditionalProperties: []*v31.NamedMediaType{
+			&v31.NamedMediaType{
+				Name:  mediaType,
+				Value: &v31.MediaType{Schema: referenceV31(ref)},
+			},
+		},
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// postman converts between OpenAPI descriptions and Postman collections.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/gnostic"
+	"github.com/okkoye/gnostic/lib"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+	"github.com/okkoye/gnostic/postman"
+)
+
+func main() {
+	usage := `
+Usage:
+	postman help
+	postman export <source> [--out=<file>]
+	postman import <collection> [--out=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Postman 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nConvert between OpenAPI descriptions and Postman collections.")
+		fmt.Println(usage)
+		fmt.Println("The <source> of an export can be an OpenAPI v2 or v3.0 description in JSON")
+		fmt.Println("or YAML or a binary protocol buffer produced by gnostic.")
+		fmt.Println("An import writes an OpenAPI v3 description in YAML, or in JSON or a")
+		fmt.Println("binary protocol buffer if the --out file ends in .json or .pb.")
+		fmt.Println()
+	}
+
+	// Export an OpenAPI description as a Postman collection.
+	if arguments["export"].(bool) {
+		source := arguments["<source>"].(string)
+		result := lib.Compile([]lib.Input{{Name: source}}, 1)[0]
+		document, format, err := result.Document, result.SourceFormat, result.Err
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		var collection *postman.Collection
+		switch format {
+		case lib.SourceFormatOpenAPI2:
+			collection, err = postman.NewCollectionFromOpenAPIv2(document.(*openapi_v2.Document))
+		case lib.SourceFormatOpenAPI3:
+			collection, err = postman.NewCollectionFromOpenAPIv3WithAnnotations(document.(*openapi_v3.Document), result.Annotations)
+		case lib.SourceFormatOpenAPI31:
+			log.Fatalf("%s: OpenAPI 3.1 is not supported by this exporter", source)
+		default:
+			log.Fatalf("%s is not an OpenAPI description", source)
+		}
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		bytes, err := collection.Marshal()
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		writeOutput(arguments, bytes)
+	}
+
+	// Import a Postman collection as an OpenAPI description.
+	if arguments["import"].(bool) {
+		bytes, err := compiler.ReadBytesForFile(arguments["<collection>"].(string))
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		collection, err := postman.ParseCollection(bytes)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		document, err := postman.NewOpenAPIv3FromCollection(collection)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		encoding := gnostic.EncodingYAML
+		if out, ok := arguments["--out"].(string); ok {
+			switch strings.ToLower(filepath.Ext(out)) {
+			case ".json":
+				encoding = gnostic.EncodingJSON
+			case ".pb":
+				encoding = gnostic.EncodingBinary
+			}
+		}
+		bytes, err = gnostic.Encode(document, encoding)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		writeOutput(arguments, bytes)
+	}
+}
+
+// writeOutput writes bytes to the file named with --out or to stdout.
+func writeOutput(arguments map[string]interface{}, bytes []byte) {
+	if arguments["--out"] == nil {
+		os.Stdout.Write(bytes)
+		return
+	}
+	err := ioutil.WriteFile(arguments["--out"].(string), bytes, 0644)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
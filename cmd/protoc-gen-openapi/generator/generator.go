@@ -97,6 +97,11 @@ func (g *OpenAPIv3Generator) Run(outputFile *protogen.GeneratedFile) error {
 	return nil
 }
 
+// Document returns the OpenAPIv3 document that describes the generator's input files.
+func (g *OpenAPIv3Generator) Document() *v3.Document {
+	return g.buildDocumentV3()
+}
+
 // buildDocumentV3 builds an OpenAPIv3 document for a plugin request.
 func (g *OpenAPIv3Generator) buildDocumentV3() *v3.Document {
 	d := &v3.Document{}
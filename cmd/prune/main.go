@@ -0,0 +1,119 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// prune removes unused components, orphaned examples, and empty objects
+// from an OpenAPI v3 description.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/jsonwriter"
+	"github.com/okkoye/gnostic/lib"
+	"github.com/okkoye/gnostic/transform"
+)
+
+func main() {
+	usage := `
+Usage:
+	prune help
+	prune <source> [--json] [--out=<file>] [--report=<file>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Prune 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nRemove unused components, orphaned examples, and empty objects from an")
+		fmt.Println("OpenAPI v3 description and report what was removed.")
+		fmt.Println(usage)
+		return
+	}
+
+	source := arguments["<source>"].(string)
+	document, format, err := lib.ReadRawInfo(source)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if format != lib.SourceFormatOpenAPI3 {
+		log.Fatalf("%s is not an OpenAPI v3 description", source)
+	}
+	out, _ := arguments["--out"].(string)
+	json := arguments["--json"].(bool) || strings.HasSuffix(out, ".json")
+	marshal := func() []byte {
+		var bytes []byte
+		if json {
+			bytes, err = jsonwriter.Marshal(document)
+		} else {
+			bytes, err = yaml.Marshal(document)
+		}
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		return bytes
+	}
+
+	before := len(marshal())
+	pruned := transform.Prune(document)
+	bytes := marshal()
+
+	// Write the report.
+	var report strings.Builder
+	for _, section := range []struct {
+		title     string
+		locations []string
+	}{
+		{"Unused components", pruned.Components},
+		{"Orphaned examples", pruned.Examples},
+		{"Empty objects", pruned.EmptyObjects},
+	} {
+		if len(section.locations) == 0 {
+			continue
+		}
+		fmt.Fprintf(&report, "%s (%d):\n", section.title, len(section.locations))
+		for _, location := range section.locations {
+			fmt.Fprintf(&report, "  %s\n", location)
+		}
+	}
+	after := len(bytes)
+	saved := 0.0
+	if before > 0 {
+		saved = 100 * float64(before-after) / float64(before)
+	}
+	fmt.Fprintf(&report, "%d parts removed, size reduced from %d to %d bytes (%.1f%%)\n",
+		pruned.Count(), before, after, saved)
+	if filename, ok := arguments["--report"].(string); ok {
+		if err := ioutil.WriteFile(filename, []byte(report.String()), 0644); err != nil {
+			log.Fatalf("%+v", err)
+		}
+	} else {
+		fmt.Fprint(os.Stderr, report.String())
+	}
+
+	// Write the pruned description.
+	if out == "" {
+		os.Stdout.Write(bytes)
+	} else if err := ioutil.WriteFile(out, bytes, 0644); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
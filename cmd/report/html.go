@@ -0,0 +1,102 @@
+// Copyright 2017 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"html"
+
+	"github.com/okkoye/gnostic/printer"
+)
+
+// htmlRenderer renders reports as a standalone HTML page.
+type htmlRenderer struct {
+	code  printer.Code
+	title string
+}
+
+func (h *htmlRenderer) Heading(level int, anchor string, text string) {
+	if h.title == "" {
+		h.title = text
+	}
+	if anchor != "" {
+		h.code.Print(`<h%d id="%s">%s</h%d>`, level, anchor, h.Escape(text), level)
+	} else {
+		h.code.Print(`<h%d>%s</h%d>`, level, h.Escape(text), level)
+	}
+}
+
+func (h *htmlRenderer) Paragraph(text string) {
+	h.code.Print("<p>%s</p>", h.Escape(text))
+}
+
+func (h *htmlRenderer) List(items []listItem) {
+	if len(items) == 0 {
+		return
+	}
+	h.code.Print("<ul>")
+	h.code.Indent()
+	for _, item := range items {
+		if item.anchor != "" {
+			h.code.Print("<li>%s</li>", h.Link(item.anchor, item.text))
+		} else {
+			h.code.Print("<li>%s</li>", h.Escape(item.text))
+		}
+	}
+	h.code.Outdent()
+	h.code.Print("</ul>")
+}
+
+func (h *htmlRenderer) Table(header []string, rows [][]string) {
+	h.code.Print("<table>")
+	h.code.Indent()
+	h.code.Print("<tr>")
+	h.code.Indent()
+	for _, cell := range header {
+		h.code.Print("<th>%s</th>", cell)
+	}
+	h.code.Outdent()
+	h.code.Print("</tr>")
+	for _, row := range rows {
+		h.code.Print("<tr>")
+		h.code.Indent()
+		for _, cell := range row {
+			h.code.Print("<td>%s</td>", cell)
+		}
+		h.code.Outdent()
+		h.code.Print("</tr>")
+	}
+	h.code.Outdent()
+	h.code.Print("</table>")
+}
+
+func (h *htmlRenderer) Link(anchor string, text string) string {
+	return `<a href="#` + anchor + `">` + h.Escape(text) + "</a>"
+}
+
+func (h *htmlRenderer) Escape(text string) string {
+	return html.EscapeString(text)
+}
+
+func (h *htmlRenderer) String() string {
+	page := &printer.Code{}
+	page.Print("<!DOCTYPE html>")
+	page.Print("<html>")
+	page.Print("<head>")
+	page.Print(`<meta charset="utf-8">`)
+	page.Print("<title>%s</title>", h.Escape(h.title))
+	page.Print("</head>")
+	page.Print("<body>")
+	return page.String() + h.code.String() + "</body>\n</html>\n"
+}
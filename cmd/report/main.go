@@ -222,11 +222,12 @@ func printVendorExtension(code *printer.Code, vendorExtension []*pb.NamedAny) {
 }
 
 func main() {
+	format := flag.String("format", "text", "output format: text, markdown, or html")
 	flag.Parse()
 	args := flag.Args()
 
 	if len(args) != 1 {
-		fmt.Printf("Usage: report <file.pb>\n")
+		fmt.Printf("Usage: report [--format=text|markdown|html] <file.pb>\n")
 		return
 	}
 
@@ -236,9 +237,25 @@ func main() {
 		log.Printf("Error reading %s. This sample expects OpenAPI v2.", args[0])
 		os.Exit(-1)
 	}
-	code := &printer.Code{}
-	code.Print("API REPORT")
-	code.Print("----------")
-	printDocument(code, document)
-	fmt.Printf("%s", code)
+	switch *format {
+	case "text":
+		code := printer.NewWriter(os.Stdout)
+		code.Print("API REPORT")
+		code.Print("----------")
+		printDocument(code, document)
+		if err := code.Flush(); err != nil {
+			log.Fatalf("%+v", err)
+		}
+	case "markdown", "md":
+		r := &markdownRenderer{}
+		renderDocument(r, document)
+		fmt.Printf("%s", r)
+	case "html":
+		r := &htmlRenderer{}
+		renderDocument(r, document)
+		fmt.Printf("%s", r)
+	default:
+		log.Printf("Unknown format %q. Supported formats are text, markdown, and html.", *format)
+		os.Exit(-1)
+	}
 }
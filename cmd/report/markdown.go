@@ -0,0 +1,94 @@
+// Copyright 2017 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/okkoye/gnostic/printer"
+)
+
+// markdownRenderer renders reports as GitHub-flavored Markdown.
+// Anchors are written as HTML elements so that links work on any
+// Markdown host, independently of its heading-slug conventions.
+type markdownRenderer struct {
+	code printer.Code
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", "&lt;",
+	">", "&gt;",
+	"|", `\|`,
+	"\n", " ",
+)
+
+func (m *markdownRenderer) Heading(level int, anchor string, text string) {
+	if anchor != "" {
+		m.code.Print(`<a id="%s"></a>`, anchor)
+		m.code.Print()
+	}
+	m.code.Print("%s %s", strings.Repeat("#", level), m.Escape(text))
+	m.code.Print()
+}
+
+func (m *markdownRenderer) Paragraph(text string) {
+	m.code.Print("%s", m.Escape(text))
+	m.code.Print()
+}
+
+func (m *markdownRenderer) List(items []listItem) {
+	if len(items) == 0 {
+		return
+	}
+	for _, item := range items {
+		if item.anchor != "" {
+			m.code.Print("- %s", m.Link(item.anchor, item.text))
+		} else {
+			m.code.Print("- %s", m.Escape(item.text))
+		}
+	}
+	m.code.Print()
+}
+
+func (m *markdownRenderer) Table(header []string, rows [][]string) {
+	m.code.Print("| %s |", strings.Join(header, " | "))
+	separators := make([]string, len(header))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	m.code.Print("| %s |", strings.Join(separators, " | "))
+	for _, row := range rows {
+		m.code.Print("| %s |", strings.Join(row, " | "))
+	}
+	m.code.Print()
+}
+
+func (m *markdownRenderer) Link(anchor string, text string) string {
+	return "[" + m.Escape(text) + "](#" + anchor + ")"
+}
+
+func (m *markdownRenderer) Escape(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+func (m *markdownRenderer) String() string {
+	return m.code.String()
+}
@@ -0,0 +1,357 @@
+// Copyright 2017 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/okkoye/gnostic/openapiv2"
+)
+
+// A renderer writes the sections of a report in a specific markup format.
+// Text passed to renderers is unescaped; renderers are responsible for
+// escaping it as required by their format.
+type renderer interface {
+	// Heading writes a section heading with an optional anchor.
+	Heading(level int, anchor string, text string)
+	// Paragraph writes a block of text.
+	Paragraph(text string)
+	// List writes a bulleted list of items, each of which may link to an anchor.
+	List(items []listItem)
+	// Table writes a table with a header row.
+	Table(header []string, rows [][]string)
+	// Link returns the representation of a link to an anchor, for use in table cells.
+	Link(anchor string, text string) string
+	// Escape returns text escaped for inclusion in a table cell.
+	Escape(text string) string
+	// String returns the rendered report.
+	String() string
+}
+
+// A listItem is an entry in a rendered list.
+type listItem struct {
+	anchor string
+	text   string
+}
+
+var operationMethods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH"}
+
+// anchorFor builds an anchor name that is safe for use in both Markdown and HTML.
+func anchorFor(prefix string, name string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteString("-")
+	dash := true
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
+
+// anchors holds the anchors of the sections of a report. Anchors are built
+// from names without their punctuation, so names like /pets/{id} and
+// /pets/id would share an anchor; each section after the first that would
+// use an anchor gets the anchor with a numeric suffix instead.
+type anchors struct {
+	sections map[string]string // anchors by section
+	used     map[string]bool
+}
+
+// newAnchors assigns anchors to the sections of a report on a document in
+// the order that they are rendered.
+func newAnchors(document *pb.Document) *anchors {
+	a := &anchors{
+		sections: make(map[string]string),
+		used:     map[string]bool{"contents": true, "paths": true, "definitions": true},
+	}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			a.add("path "+pair.Name, anchorFor("path", pair.Name))
+			methods, _ := operationsForPathItem(pair.Value)
+			for _, method := range methods {
+				a.add(method+" "+pair.Name, anchorFor("op-"+strings.ToLower(method), pair.Name))
+			}
+		}
+	}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			a.add("schema "+pair.Name, anchorFor("schema", pair.Name))
+		}
+	}
+	return a
+}
+
+func (a *anchors) add(section string, anchor string) {
+	unique := anchor
+	for i := 2; a.used[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", anchor, i)
+	}
+	a.used[unique] = true
+	a.sections[section] = unique
+}
+
+func (a *anchors) path(path string) string {
+	return a.sections["path "+path]
+}
+
+func (a *anchors) operation(path string, method string) string {
+	return a.sections[method+" "+path]
+}
+
+// schema returns the anchor of a definition, which may be referenced
+// without being defined.
+func (a *anchors) schema(name string) string {
+	if anchor, ok := a.sections["schema "+name]; ok {
+		return anchor
+	}
+	return anchorFor("schema", name)
+}
+
+// operationsForPathItem returns the operations of a path item paired with their methods.
+func operationsForPathItem(v *pb.PathItem) ([]string, []*pb.Operation) {
+	all := []*pb.Operation{v.Get, v.Put, v.Post, v.Delete, v.Options, v.Head, v.Patch}
+	methods := make([]string, 0)
+	operations := make([]*pb.Operation, 0)
+	for i, operation := range all {
+		if operation != nil {
+			methods = append(methods, operationMethods[i])
+			operations = append(operations, operation)
+		}
+	}
+	return methods, operations
+}
+
+// definitionNameForRef returns the name of a local definition referenced by ref, or "" if ref is not local.
+func definitionNameForRef(ref string) string {
+	const prefix = "#/definitions/"
+	if strings.HasPrefix(ref, prefix) {
+		return ref[len(prefix):]
+	}
+	return ""
+}
+
+// renderDocument writes a report describing document using r.
+func renderDocument(r renderer, document *pb.Document) {
+	title := "API Report"
+	if document.Info != nil && document.Info.Title != "" {
+		title = document.Info.Title
+	}
+	r.Heading(1, "", title)
+	a := newAnchors(document)
+	if info := document.Info; info != nil {
+		if info.Description != "" {
+			r.Paragraph(info.Description)
+		}
+		rows := [][]string{}
+		rows = appendRowIf(r, rows, "Version", info.Version)
+		rows = appendRowIf(r, rows, "Terms of Service", info.TermsOfService)
+		if info.Contact != nil {
+			rows = appendRowIf(r, rows, "Contact", info.Contact.Email)
+		}
+		if info.License != nil {
+			rows = appendRowIf(r, rows, "License", info.License.Name)
+		}
+		rows = appendRowIf(r, rows, "Host", document.Host)
+		rows = appendRowIf(r, rows, "Base Path", document.BasePath)
+		rows = appendRowIf(r, rows, "Schemes", strings.Join(document.Schemes, ", "))
+		rows = appendRowIf(r, rows, "Consumes", strings.Join(document.Consumes, ", "))
+		rows = appendRowIf(r, rows, "Produces", strings.Join(document.Produces, ", "))
+		if len(rows) > 0 {
+			r.Table([]string{"Field", "Value"}, rows)
+		}
+	}
+
+	// Table of contents.
+	r.Heading(2, "contents", "Contents")
+	contents := make([]listItem, 0)
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			contents = append(contents, listItem{anchor: a.path(pair.Name), text: pair.Name})
+		}
+	}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			contents = append(contents, listItem{anchor: a.schema(pair.Name), text: pair.Name})
+		}
+	}
+	r.List(contents)
+
+	// Paths.
+	r.Heading(2, "paths", "Paths")
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			r.Heading(3, a.path(pair.Name), pair.Name)
+			methods, operations := operationsForPathItem(pair.Value)
+			for i, operation := range operations {
+				renderOperation(r, a, pair.Name, methods[i], operation)
+			}
+		}
+	}
+
+	// Definitions.
+	r.Heading(2, "definitions", "Definitions")
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			r.Heading(3, a.schema(pair.Name), pair.Name)
+			renderSchema(r, a, pair.Value)
+		}
+	}
+}
+
+func appendRowIf(r renderer, rows [][]string, name string, value string) [][]string {
+	if value == "" {
+		return rows
+	}
+	return append(rows, []string{r.Escape(name), r.Escape(value)})
+}
+
+func renderOperation(r renderer, a *anchors, path string, method string, operation *pb.Operation) {
+	r.Heading(4, a.operation(path, method), method+" "+path)
+	if operation.Summary != "" {
+		r.Paragraph(operation.Summary)
+	}
+	if operation.Description != "" {
+		r.Paragraph(operation.Description)
+	}
+	rows := [][]string{}
+	rows = appendRowIf(r, rows, "Operation ID", operation.OperationId)
+	rows = appendRowIf(r, rows, "Tags", strings.Join(operation.Tags, ", "))
+	if operation.Deprecated {
+		rows = appendRowIf(r, rows, "Deprecated", "true")
+	}
+	if len(rows) > 0 {
+		r.Table([]string{"Field", "Value"}, rows)
+	}
+	if len(operation.Parameters) > 0 {
+		rows = [][]string{}
+		for _, item := range operation.Parameters {
+			rows = append(rows, parameterRow(r, a, item))
+		}
+		r.Table([]string{"Parameter", "In", "Type", "Required", "Description"}, rows)
+	}
+	if operation.Responses != nil && len(operation.Responses.ResponseCode) > 0 {
+		rows = [][]string{}
+		for _, pair := range operation.Responses.ResponseCode {
+			rows = append(rows, responseRow(r, a, pair.Name, pair.Value))
+		}
+		r.Table([]string{"Response", "Type", "Description"}, rows)
+	}
+}
+
+func parameterRow(r renderer, a *anchors, item *pb.ParametersItem) []string {
+	if ref := item.GetJsonReference(); ref != nil {
+		return []string{r.Escape(ref.XRef), "", "", "", r.Escape(ref.Description)}
+	}
+	parameter := item.GetParameter()
+	if body := parameter.GetBodyParameter(); body != nil {
+		return []string{
+			r.Escape(body.Name),
+			r.Escape(body.In),
+			typeForSchema(r, a, body.Schema),
+			fmt.Sprintf("%t", body.Required),
+			r.Escape(body.Description),
+		}
+	}
+	nonBody := parameter.GetNonBodyParameter()
+	if p := nonBody.GetHeaderParameterSubSchema(); p != nil {
+		return []string{r.Escape(p.Name), r.Escape(p.In), r.Escape(p.Type), fmt.Sprintf("%t", p.Required), r.Escape(p.Description)}
+	}
+	if p := nonBody.GetFormDataParameterSubSchema(); p != nil {
+		return []string{r.Escape(p.Name), r.Escape(p.In), r.Escape(p.Type), fmt.Sprintf("%t", p.Required), r.Escape(p.Description)}
+	}
+	if p := nonBody.GetQueryParameterSubSchema(); p != nil {
+		return []string{r.Escape(p.Name), r.Escape(p.In), r.Escape(p.Type), fmt.Sprintf("%t", p.Required), r.Escape(p.Description)}
+	}
+	if p := nonBody.GetPathParameterSubSchema(); p != nil {
+		return []string{r.Escape(p.Name), r.Escape(p.In), r.Escape(p.Type), fmt.Sprintf("%t", p.Required), r.Escape(p.Description)}
+	}
+	return []string{"", "", "", "", ""}
+}
+
+func responseRow(r renderer, a *anchors, code string, value *pb.ResponseValue) []string {
+	if ref := value.GetJsonReference(); ref != nil {
+		return []string{r.Escape(code), r.Escape(ref.XRef), r.Escape(ref.Description)}
+	}
+	response := value.GetResponse()
+	if response == nil {
+		return []string{r.Escape(code), "", ""}
+	}
+	typeName := ""
+	if schema := response.Schema.GetSchema(); schema != nil {
+		typeName = typeForSchema(r, a, schema)
+	} else if file := response.Schema.GetFileSchema(); file != nil {
+		typeName = r.Escape(file.Type)
+	}
+	return []string{r.Escape(code), typeName, r.Escape(response.Description)}
+}
+
+// typeForSchema describes the type of a schema, linking to definitions where possible.
+func typeForSchema(r renderer, a *anchors, schema *pb.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if name := definitionNameForRef(schema.XRef); name != "" {
+		return r.Link(a.schema(name), name)
+	}
+	if schema.XRef != "" {
+		return r.Escape(schema.XRef)
+	}
+	if schema.Type != nil && len(schema.Type.Value) > 0 {
+		typeName := strings.Join(schema.Type.Value, "|")
+		if typeName == "array" && schema.Items != nil && len(schema.Items.Schema) > 0 {
+			return typeForSchema(r, a, schema.Items.Schema[0]) + r.Escape("[]")
+		}
+		if schema.Format != "" {
+			typeName += "(" + schema.Format + ")"
+		}
+		return r.Escape(typeName)
+	}
+	return ""
+}
+
+func renderSchema(r renderer, a *anchors, schema *pb.Schema) {
+	if schema.Description != "" {
+		r.Paragraph(schema.Description)
+	}
+	if schema.Properties == nil || len(schema.Properties.AdditionalProperties) == 0 {
+		if t := typeForSchema(r, a, schema); t != "" {
+			r.Table([]string{"Type"}, [][]string{{t}})
+		}
+		return
+	}
+	rows := [][]string{}
+	for _, pair := range schema.Properties.AdditionalProperties {
+		required := false
+		for _, name := range schema.Required {
+			if name == pair.Name {
+				required = true
+			}
+		}
+		rows = append(rows, []string{
+			r.Escape(pair.Name),
+			typeForSchema(r, a, pair.Value),
+			fmt.Sprintf("%t", required),
+			r.Escape(pair.Value.Description),
+		})
+	}
+	r.Table([]string{"Property", "Type", "Required", "Description"}, rows)
+}
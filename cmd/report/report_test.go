@@ -0,0 +1,93 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/okkoye/gnostic/openapiv2"
+)
+
+func TestRenderedReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "report")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	for _, test := range []struct {
+		source string
+		golden string
+	}{
+		{"../../examples/v2.0/yaml/petstore.yaml", "../../testdata/v2.0/petstore-report"},
+		// Paths and definitions whose names differ only in punctuation and
+		// case have anchors with numeric suffixes.
+		{"../../testdata/v2.0/yaml/report-anchors.yaml", "../../testdata/v2.0/report-anchors"},
+	} {
+		// Write the binary form of the description that report reads.
+		bytes, err := ioutil.ReadFile(test.source)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		document, err := pb.ParseDocument(bytes)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		pbFile := filepath.Join(dir, "document.pb")
+		bytes, err = proto.Marshal(document)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err = ioutil.WriteFile(pbFile, bytes, 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		document, err = readDocumentFromFileWithName(pbFile)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		for reference, r := range map[string]renderer{
+			test.golden + ".md":   &markdownRenderer{},
+			test.golden + ".html": &htmlRenderer{},
+		} {
+			renderDocument(r, document)
+			expected, err := ioutil.ReadFile(reference)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if r.String() != string(expected) {
+				t.Errorf("report differs from %s:\n%s", reference, r)
+			}
+		}
+	}
+}
+
+func TestEscapedTableCells(t *testing.T) {
+	for _, test := range []struct {
+		r        renderer
+		text     string
+		expected string
+	}{
+		{&markdownRenderer{}, "a | *b* [c] <d>", `a \| \*b\* \[c\] &lt;d&gt;`},
+		{&htmlRenderer{}, "a | *b* <c> & d", "a | *b* &lt;c&gt; &amp; d"},
+	} {
+		if escaped := test.r.Escape(test.text); escaped != test.expected {
+			t.Errorf("unexpected escaped text %q", escaped)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// scorecard grades an OpenAPI v3 description against a weighted set of style rules.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+
+	"github.com/okkoye/gnostic/lib"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+	"github.com/okkoye/gnostic/scorecard"
+)
+
+func main() {
+	usage := `
+Usage:
+	scorecard help
+	scorecard rules
+	scorecard <source> [--weights=<file>] [--json] [--min-grade=<grade>]
+
+Options:
+	--weights=<file>     YAML or JSON file of weights for rules or categories.
+	--json               Write the report as JSON.
+	--min-grade=<grade>  Exit with status 1 if the grade is below this one.
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Scorecard 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nGrade an OpenAPI v3 description on documentation, naming, responses, and security.")
+		fmt.Println(usage)
+		return
+	}
+
+	rules := scorecard.DefaultRules()
+
+	// List the rules and their default weights.
+	if arguments["rules"].(bool) {
+		for _, rule := range rules {
+			fmt.Printf("%-24s %-14s %4g  %s\n", rule.ID, rule.Category, rule.Weight, rule.Description)
+		}
+		return
+	}
+
+	if filename, ok := arguments["--weights"].(string); ok {
+		weights, err := scorecard.ReadWeights(filename)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		if err := scorecard.SetWeights(rules, weights); err != nil {
+			log.Fatalf("%+v", err)
+		}
+	}
+	minimum := ""
+	if grade, ok := arguments["--min-grade"].(string); ok {
+		minimum = strings.ToUpper(grade)
+		if gradeRank(minimum) < 0 {
+			log.Fatalf("unknown grade: %s", grade)
+		}
+	}
+
+	source := arguments["<source>"].(string)
+	message, format, err := lib.ReadDocument(source)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if format != lib.SourceFormatOpenAPI3 {
+		log.Fatalf("%s is not an OpenAPI v3 description", source)
+	}
+	report := scorecard.Score(message.(*openapi_v3.Document), rules)
+
+	if arguments["--json"].(bool) {
+		bytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		fmt.Printf("%s\n", bytes)
+	} else {
+		fmt.Print(report.String())
+	}
+	if minimum != "" && gradeRank(report.Grade) > gradeRank(minimum) {
+		fmt.Fprintf(os.Stderr, "grade %s is below %s\n", report.Grade, minimum)
+		os.Exit(1)
+	}
+}
+
+// gradeRank returns the position of a grade in the list of grades, with
+// the best grade first, or -1 if the grade is unknown.
+func gradeRank(grade string) int {
+	for i, g := range scorecard.Grades {
+		if g.Grade == grade {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// split divides an OpenAPI v3 description into smaller self-contained descriptions.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/docopt/docopt-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/jsonwriter"
+	"github.com/okkoye/gnostic/lib"
+	"github.com/okkoye/gnostic/transform"
+)
+
+var unsafeFilenameCharacters = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+func main() {
+	usage := `
+Usage:
+	split help
+	split <source> [--by=<key>] [--depth=<n>] [--out=<dir>] [--json]
+
+Options:
+	--by=<key>     Split by "tag" or "path" [default: tag].
+	--depth=<n>    Number of path segments in prefixes when splitting by path [default: 1].
+	--out=<dir>    Directory for the split descriptions [default: .].
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Split 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nSplit an OpenAPI v3 description into one self-contained description per tag or path prefix.")
+		fmt.Println(usage)
+		return
+	}
+
+	source := arguments["<source>"].(string)
+	document, format, err := lib.ReadRawInfo(source)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if format != lib.SourceFormatOpenAPI3 {
+		log.Fatalf("%s is not an OpenAPI v3 description", source)
+	}
+
+	var slices []*transform.Slice
+	switch arguments["--by"].(string) {
+	case "tag":
+		slices = transform.SplitByTag(document)
+	case "path":
+		depth, err := strconv.Atoi(arguments["--depth"].(string))
+		if err != nil || depth < 1 {
+			log.Fatalf("invalid depth: %s", arguments["--depth"].(string))
+		}
+		slices = transform.SplitByPathPrefix(document, depth)
+	default:
+		log.Fatalf("unknown split key %q, expected \"tag\" or \"path\"", arguments["--by"].(string))
+	}
+
+	dir := arguments["--out"].(string)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatalf("%+v", err)
+	}
+	extension := ".yaml"
+	if arguments["--json"].(bool) {
+		extension = ".json"
+	}
+	for _, slice := range slices {
+		// Identify each slice in its title.
+		info := compiler.MapValueForKey(transform.Root(slice.Document), "info")
+		if title := compiler.MapValueForKey(info, "title"); title != nil {
+			title.Value = fmt.Sprintf("%s (%s)", title.Value, slice.Name)
+		}
+		var bytes []byte
+		if extension == ".json" {
+			bytes, err = jsonwriter.Marshal(slice.Document)
+		} else {
+			bytes, err = yaml.Marshal(slice.Document)
+		}
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		filename := filepath.Join(dir, unsafeFilenameCharacters.ReplaceAllString(slice.Name, "-")+extension)
+		if err := ioutil.WriteFile(filename, bytes, 0644); err != nil {
+			log.Fatalf("%+v", err)
+		}
+		fmt.Printf("%s\n", filename)
+	}
+}
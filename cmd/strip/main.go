@@ -0,0 +1,94 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// strip removes the parts of an OpenAPI v3 description that are marked with
+// an extension, such as x-internal, to produce a public description.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/jsonwriter"
+	"github.com/okkoye/gnostic/lib"
+	"github.com/okkoye/gnostic/transform"
+)
+
+func main() {
+	usage := `
+Usage:
+	strip help
+	strip <source> [--extension=<name>] [--value=<value>] [--json] [--out=<file>] [--verbose]
+
+Options:
+	--extension=<name>  Extension that marks the parts to remove [default: x-internal].
+	--value=<value>     Extension value that marks the parts to remove (default: any true value).
+	--verbose           List the removed parts.
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Strip 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nRemove the operations, schemas, properties, and other parts of an OpenAPI v3")
+		fmt.Println("description that are marked with an extension, along with the components that")
+		fmt.Println("are no longer used.")
+		fmt.Println(usage)
+		return
+	}
+
+	source := arguments["<source>"].(string)
+	document, format, err := lib.ReadRawInfo(source)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if format != lib.SourceFormatOpenAPI3 {
+		log.Fatalf("%s is not an OpenAPI v3 description", source)
+	}
+	marker := &transform.Marker{Extension: arguments["--extension"].(string)}
+	if value, ok := arguments["--value"].(string); ok {
+		marker.Value = value
+	}
+	removed := transform.Strip(document, marker)
+	if arguments["--verbose"].(bool) {
+		fmt.Fprintf(os.Stderr, "%d parts removed\n", len(removed))
+		for _, location := range removed {
+			fmt.Fprintf(os.Stderr, "%s\n", location)
+		}
+	}
+
+	var bytes []byte
+	out, _ := arguments["--out"].(string)
+	if arguments["--json"].(bool) || strings.HasSuffix(out, ".json") {
+		bytes, err = jsonwriter.Marshal(document)
+	} else {
+		bytes, err = yaml.Marshal(document)
+	}
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if out == "" {
+		os.Stdout.Write(bytes)
+	} else if err := ioutil.WriteFile(out, bytes, 0644); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// traffic builds OpenAPI descriptions from recorded HTTP traffic.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/jsonwriter"
+	"github.com/okkoye/gnostic/lib"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+	"github.com/okkoye/gnostic/traffic"
+)
+
+func main() {
+	usage := `
+Usage:
+	traffic help
+	traffic infer <har>... [--title=<title>] [--version=<version>] [--host=<host>...] [--static] [--json] [--out=<file>]
+	traffic refine <source> <log>... [--json] [--out=<file>] [--report=<file>]
+	traffic coverage <source> <log>... [--min-operations=<percent>] [--min-responses=<percent>]
+	`
+	arguments, err := docopt.Parse(usage, nil, false, "Traffic 1.0", false)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
+	// Help.
+	if arguments["help"].(bool) {
+		fmt.Println("\nBuild OpenAPI descriptions from recorded HTTP traffic.")
+		fmt.Println(usage)
+		fmt.Println("HAR files can be exported from browser developer tools and most debugging proxies.")
+		fmt.Println("Logs can be HAR files (.har), request logs with one \"METHOD PATH STATUS\" line per")
+		fmt.Println("request (.log or .txt), or JSON Lines files with one exchange per line.")
+		fmt.Println()
+	}
+
+	// Infer an OpenAPI v3 description from HAR files.
+	if arguments["infer"].(bool) {
+		samples := readSamples(arguments["<har>"].([]string))
+		options := &traffic.Options{
+			Hosts:         arguments["--host"].([]string),
+			IncludeStatic: arguments["--static"].(bool),
+		}
+		if title, ok := arguments["--title"].(string); ok {
+			options.Title = title
+		}
+		if version, ok := arguments["--version"].(string); ok {
+			options.Version = version
+		}
+		document, err := traffic.NewDocument(samples, options)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		writeDocument(arguments, document)
+	}
+
+	// Check an OpenAPI v3 description against logged traffic.
+	if arguments["refine"].(bool) {
+		document := readOpenAPIv3(arguments["<source>"].(string))
+		report := traffic.Refine(document, readSamples(arguments["<log>"].([]string)))
+		if filename, ok := arguments["--report"].(string); ok {
+			if err := ioutil.WriteFile(filename, []byte(report.String()), 0644); err != nil {
+				log.Fatalf("%+v", err)
+			}
+		} else {
+			fmt.Print(report.String())
+		}
+		if arguments["--out"] != nil {
+			writeDocument(arguments, document)
+		}
+	}
+
+	// Report the operations and responses exercised by logged requests.
+	if arguments["coverage"].(bool) {
+		document := readOpenAPIv3(arguments["<source>"].(string))
+		report := traffic.Coverage(document, readSamples(arguments["<log>"].([]string)))
+		fmt.Print(report.String())
+		failed := false
+		if minimum, ok := arguments["--min-operations"].(string); ok && report.OperationPercentage() < parsePercentage(minimum) {
+			fmt.Printf("operation coverage is below %s%%\n", minimum)
+			failed = true
+		}
+		if minimum, ok := arguments["--min-responses"].(string); ok && report.ResponsePercentage() < parsePercentage(minimum) {
+			fmt.Printf("response coverage is below %s%%\n", minimum)
+			failed = true
+		}
+		if failed {
+			os.Exit(1)
+		}
+	}
+}
+
+// readOpenAPIv3 reads an OpenAPI v3 description.
+func readOpenAPIv3(source string) *openapi_v3.Document {
+	message, format, err := lib.ReadDocument(source)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if format != lib.SourceFormatOpenAPI3 {
+		log.Fatalf("%s is not an OpenAPI v3 description", source)
+	}
+	return message.(*openapi_v3.Document)
+}
+
+func parsePercentage(s string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		log.Fatalf("invalid percentage: %s", s)
+	}
+	return f
+}
+
+// readSamples reads samples from HAR files and JSON Lines traffic logs.
+func readSamples(filenames []string) []*traffic.Sample {
+	samples := make([]*traffic.Sample, 0)
+	for _, filename := range filenames {
+		bytes, err := ioutil.ReadFile(filename)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+		var s []*traffic.Sample
+		switch filepath.Ext(filename) {
+		case ".har":
+			s, err = traffic.ParseHAR(bytes)
+		case ".log", ".txt":
+			s, err = traffic.ParseRequestLog(bytes)
+		default:
+			s, err = traffic.ParseJSONL(bytes)
+		}
+		if err != nil {
+			log.Fatalf("%s: %+v", filename, err)
+		}
+		samples = append(samples, s...)
+	}
+	return samples
+}
+
+// writeDocument writes a description in YAML, or in JSON if --json is
+// specified or the output file has a .json extension.
+func writeDocument(arguments map[string]interface{}, document *openapi_v3.Document) {
+	root := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{document.ToRawInfo()}}
+	var bytes []byte
+	var err error
+	if arguments["--json"].(bool) || strings.HasSuffix(stringArgument(arguments, "--out"), ".json") {
+		bytes, err = jsonwriter.Marshal(root)
+	} else {
+		bytes, err = yaml.Marshal(root)
+	}
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	writeOutput(arguments, bytes)
+}
+
+func stringArgument(arguments map[string]interface{}, name string) string {
+	if s, ok := arguments[name].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// writeOutput writes bytes to the file named with --out or to stdout.
+func writeOutput(arguments map[string]interface{}, bytes []byte) {
+	if arguments["--out"] == nil {
+		os.Stdout.Write(bytes)
+		return
+	}
+	err := ioutil.WriteFile(arguments["--out"].(string), bytes, 0644)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -54,6 +55,7 @@ func gatherVersionNames(paths []string) []string {
 
 // readVocabularyFromFilename accepts the filename of a Vocabulary pb
 // and parses the data in the file which is then added to a Vocabulary struct.
+// Files with .csv and .json extensions are read as exported vocabularies.
 func readVocabularyFromFilename(filename string) *metrics.Vocabulary {
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
@@ -61,10 +63,19 @@ func readVocabularyFromFilename(filename string) *metrics.Vocabulary {
 		os.Exit(1)
 	}
 
-	v := &metrics.Vocabulary{}
-	err = proto.Unmarshal(data, v)
+	var v *metrics.Vocabulary
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		v, err = vocabulary.UnmarshalCSV(data)
+	case ".json":
+		v, err = vocabulary.UnmarshalJSON(data)
+	default:
+		v = &metrics.Vocabulary{}
+		err = proto.Unmarshal(data, v)
+	}
 	if err != nil {
-		panic(err)
+		fmt.Printf("File %s error: %v\n", filename, err)
+		os.Exit(1)
 	}
 	return v
 }
@@ -116,11 +127,13 @@ func main() {
 	differencePtr := flag.Bool("difference", false, "generates the difference of pb files")
 	versionPtr := flag.Bool("version", false, "generates the difference between versions of pb files")
 	exportPtr := flag.Bool("export", false, "export a given pb file as a csv file")
+	exportJSONPtr := flag.Bool("export-json", false, "export a given pb file as a json file")
+	importPtr := flag.Bool("import", false, "import csv or json files into a pb file")
 	filterCommonPtr := flag.Bool("filter-common", false, "egenerates uniqueness within company")
 
 	flag.Parse()
 	args := flag.Args()
-	if !*unionPtr && !*intersectionPtr && !*differencePtr && !*exportPtr && !*filterCommonPtr && !*versionPtr {
+	if !*unionPtr && !*intersectionPtr && !*differencePtr && !*exportPtr && !*exportJSONPtr && !*importPtr && !*filterCommonPtr && !*versionPtr {
 		flag.PrintDefaults()
 		fmt.Printf("Please use one of the above command line arguments.\n")
 		os.Exit(-1)
@@ -162,6 +175,21 @@ func main() {
 	if *exportPtr {
 		err = vocabulary.WriteCSV(vocabularies[0], "")
 	}
+	if *exportJSONPtr {
+		var bytes []byte
+		bytes, err = vocabulary.MarshalJSON(vocabularies[0])
+		if err == nil {
+			err = ioutil.WriteFile("vocabulary-operation.json", bytes, 0644)
+		}
+	}
+	if *importPtr {
+		// Imported vocabularies are combined into one.
+		vocab := vocabulary.Union(vocabularies)
+		if len(vocabularies) == 1 {
+			vocab.Name = vocabularies[0].Name
+		}
+		err = vocabulary.WritePb(vocab)
+	}
 	if *filterCommonPtr {
 		vocab := vocabulary.FilterCommon(vocabularies)
 		err = vocabulary.WriteVocabularyList(vocab)
@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Compiled models can't hold everything that is needed to write the
+// descriptions that they were compiled from, like the text of numbers
+// that floats can't represent exactly. This is recorded in Annotations,
+// which are returned beside the models and are passed to the functions
+// that write them. Annotations aren't part of models, so they aren't
+// written in binary or text encodings of models or sent to plugins.
+//
+// Annotations name the locations in the descriptions that they were
+// recorded for with JSON pointers, so they only apply to models with the
+// same locations. Models that are changed by moving or removing the
+// values of their fields should be written without them.
+
+// Annotations are the values that were recorded when a model was compiled.
+// Nil Annotations have no values.
+type Annotations struct {
+	// Numbers are the texts of the numbers that can't be written from
+	// their floats.
+	Numbers []Annotation `json:"numbers,omitempty"`
+	// ExplicitValues are the fields that were set to null or false.
+	ExplicitValues []Annotation `json:"explicitValues,omitempty"`
+	// ReferenceSiblings are the keywords beside references other than
+	// summary and description, which reference models don't keep. Only
+	// OpenAPI 3.1 allows these keywords, so they aren't recorded for
+	// other descriptions.
+	ReferenceSiblings []Annotation `json:"referenceSiblings,omitempty"`
+}
+
+// An Annotation is a value that was recorded for a location in a description.
+type Annotation struct {
+	Pointer string `json:"pointer"`         // the JSON pointer of the location, like "/info/description"
+	Tag     string `json:"tag,omitempty"`   // the YAML tag of the value
+	Value   string `json:"value,omitempty"` // the text of a number or the YAML of a keyword
+}
+
+// NewAnnotations records the numbers and explicit values of the node that
+// a model was compiled from.
+func NewAnnotations(node *yaml.Node) *Annotations {
+	a := &Annotations{}
+	a.RecordNumbers(node)
+	a.RecordExplicitValues(node)
+	return a
+}
+
+// Restore writes annotations in a node that was created from the model
+// that they were recorded for.
+func (a *Annotations) Restore(node *yaml.Node) {
+	a.RestoreNumbers(node)
+	a.RestoreNulls(node)
+	a.RestoreReferenceSiblings(node)
+}
+
+// Filter returns the annotations of the locations that keep returns true for.
+func (a *Annotations) Filter(keep func(pointer string) bool) *Annotations {
+	if a == nil {
+		return nil
+	}
+	return &Annotations{
+		Numbers:           filterAnnotations(a.Numbers, keep),
+		ExplicitValues:    filterAnnotations(a.ExplicitValues, keep),
+		ReferenceSiblings: filterAnnotations(a.ReferenceSiblings, keep),
+	}
+}
+
+// Returns the annotations of the locations that keep returns true for.
+func filterAnnotations(annotations []Annotation, keep func(pointer string) bool) []Annotation {
+	var kept []Annotation
+	for _, annotation := range annotations {
+		if keep(annotation.Pointer) {
+			kept = append(kept, annotation)
+		}
+	}
+	return kept
+}
+
+// Returns the content of a document node, or the node if it isn't one.
+func documentContent(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+	return node
+}
+
+// escapePointer and unescapePointer convert between names and JSON
+// Pointer reference tokens.
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+func unescapePointer(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
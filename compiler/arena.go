@@ -0,0 +1,110 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// An Arena allocates the contexts created while compiling a document in
+// large blocks that are released together when the compiled model has
+// been built. Services that compile descriptions continuously can reuse
+// one arena for each compilation, so that contexts don't create work for
+// the garbage collector.
+//
+// Contexts are allocated in an arena when they descend from a root
+// context created with NewRootContext, and each context saves its arena
+// when it is created. They must not be used after the arena is reset. An
+// arena can be used by only one compilation at a time.
+type Arena struct {
+	blocks [][]Context
+	used   int // the number of blocks in use
+	next   int // the index of the next context in the last block in use
+	// saved descriptions of parent contexts
+	descriptions map[*Context]string
+	// interned strings
+	strings map[string]string
+}
+
+// Contexts are allocated in blocks to reduce the number of allocations.
+const contextBlockSize = 256
+
+// NewArena creates an empty Arena.
+func NewArena() *Arena {
+	return &Arena{}
+}
+
+// NewRootContext returns a context with no parent that is allocated in
+// the arena along with all of its descendants.
+func (a *Arena) NewRootContext(name string, node *yaml.Node, extensionHandlers *[]ExtensionHandler) *Context {
+	if a.strings == nil {
+		a.strings = make(map[string]string)
+	}
+	context := a.allocateContext()
+	*context = Context{Name: name, Node: node, ExtensionHandlers: extensionHandlers, arena: a, strings: a.strings}
+	return context
+}
+
+func (a *Arena) allocateContext() *Context {
+	if a.used == 0 || a.next == contextBlockSize {
+		if a.used == len(a.blocks) {
+			a.blocks = append(a.blocks, make([]Context, contextBlockSize))
+		}
+		a.used++
+		a.next = 0
+	}
+	context := &a.blocks[a.used-1][a.next]
+	a.next++
+	return context
+}
+
+// description returns the description of a context in the arena, saving
+// it for the errors of its other descendants.
+func (a *Arena) description(context *Context) string {
+	if description, ok := a.descriptions[context]; ok {
+		return description
+	}
+	description := context.Name
+	if context.Parent != nil {
+		description = a.description(context.Parent) + "." + context.Name
+	}
+	if a.descriptions == nil {
+		a.descriptions = make(map[*Context]string)
+	}
+	a.descriptions[context] = description
+	return description
+}
+
+// Reset releases all of the contexts allocated by the arena and the
+// strings that were interned in it. Its blocks are cleared and reused by
+// later allocations.
+func (a *Arena) Reset() {
+	a.descriptions = nil
+	a.strings = nil
+	for i := 0; i < a.used; i++ {
+		block := a.blocks[i]
+		for j := range block {
+			block[j] = Context{}
+		}
+	}
+	a.used, a.next = 0, 0
+}
+
+// Release resets the arena and drops its blocks, so that their memory can
+// be reclaimed by the garbage collector.
+func (a *Arena) Release() {
+	a.Reset()
+	a.blocks = nil
+}
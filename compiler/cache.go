@@ -0,0 +1,152 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"container/list"
+	"sync"
+)
+
+// A Cache holds the values that are read while compiling documents, like
+// the contents of files and their parsed YAML, so that they are only read
+// once. Caches must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value of a key and true, or false if the key
+	// isn't in the cache.
+	Get(key string) (value interface{}, ok bool)
+	// Add sets the value of a key.
+	Add(key string, value interface{})
+	// Remove removes a key.
+	Remove(key string)
+	// Keys returns the keys in the cache.
+	Keys() []string
+	// Clear removes every key.
+	Clear()
+}
+
+// DefaultCacheSize is the number of values held by the file and info
+// caches unless they are replaced with SetFileCache and SetInfoCache.
+const DefaultCacheSize = 1024
+
+// NewLRUCache returns a Cache that holds up to maxEntries values. When it
+// is full, adding a value removes the value that was least recently used.
+// The cache is unbounded if maxEntries is zero or less.
+func NewLRUCache(maxEntries int) Cache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		entries:    list.New(),
+		elements:   make(map[string]*list.Element),
+	}
+}
+
+type lruCache struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    *list.List // most recently used first
+	elements   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+func (c *lruCache) Get(key string) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.elements[key]
+	if !ok {
+		return nil, false
+	}
+	c.entries.MoveToFront(element)
+	return element.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) Add(key string, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.elements[key]; ok {
+		element.Value.(*lruEntry).value = value
+		c.entries.MoveToFront(element)
+		return
+	}
+	c.elements[key] = c.entries.PushFront(&lruEntry{key: key, value: value})
+	if c.maxEntries > 0 && c.entries.Len() > c.maxEntries {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.elements, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (c *lruCache) Remove(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.elements[key]; ok {
+		c.entries.Remove(element)
+		delete(c.elements, key)
+	}
+}
+
+func (c *lruCache) Keys() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	keys := make([]string, 0, len(c.elements))
+	for element := c.entries.Front(); element != nil; element = element.Next() {
+		keys = append(keys, element.Value.(*lruEntry).key)
+	}
+	return keys
+}
+
+func (c *lruCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries.Init()
+	c.elements = make(map[string]*list.Element)
+}
+
+// The caches of files and parsed files. A nil cache is disabled.
+var (
+	cachesMutex sync.RWMutex
+	fileCache   = NewLRUCache(DefaultCacheSize)
+	infoCache   = NewLRUCache(DefaultCacheSize)
+)
+
+// SetFileCache replaces the cache of the contents of files that are read
+// from URLs. A nil cache turns off file caching.
+func SetFileCache(cache Cache) {
+	cachesMutex.Lock()
+	defer cachesMutex.Unlock()
+	fileCache = cache
+}
+
+// SetInfoCache replaces the cache of parsed files, which are indexed by
+// their names. A nil cache turns off parsed info caching.
+func SetInfoCache(cache Cache) {
+	cachesMutex.Lock()
+	defer cachesMutex.Unlock()
+	infoCache = cache
+}
+
+func currentFileCache() Cache {
+	cachesMutex.RLock()
+	defer cachesMutex.RUnlock()
+	return fileCache
+}
+
+func currentInfoCache() Cache {
+	cachesMutex.RLock()
+	defer cachesMutex.RUnlock()
+	return infoCache
+}
@@ -0,0 +1,821 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"gopkg.in/yaml.v3"
+
+	models "github.com/google/gnostic-models/compiler"
+)
+
+// Returns a pointer to the bytes of a string.
+func stringData(s string) uintptr {
+	return *(*uintptr)(unsafe.Pointer(&s))
+}
+
+func TestIntern(t *testing.T) {
+	long := strings.Repeat("x", maxInternedLength+1)
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte("[description, description, type, "+long+"]"), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// Contexts without compilations don't intern strings.
+	values := StringArrayForSequenceNodeWithContext(node.Content[0], NewContext("$root", nil, nil))
+	if stringData(values[0]) == stringData(values[1]) {
+		t.Errorf("expected strings outside of compilations not to be interned")
+	}
+	// Compilations intern strings in the table of their root context.
+	root := NewContextWithExtensions("$root", nil, nil, nil)
+	values = StringArrayForSequenceNodeWithContext(node.Content[0], NewContext("info", nil, root))
+	if stringData(values[0]) != stringData(values[1]) {
+		t.Errorf("expected interned strings to share storage")
+	}
+	if len(root.strings) != 2 {
+		t.Errorf("expected long strings not to be interned, got %v", root.strings)
+	}
+	arena := NewArena()
+	values = StringArrayForSequenceNodeWithContext(node.Content[0], arena.NewRootContext("$root", nil, nil))
+	if strings.Join(values, ",") != "description,description,type,"+long {
+		t.Fatalf("unexpected values %v", values)
+	}
+	if stringData(values[0]) != stringData(values[1]) {
+		t.Errorf("expected interned strings to share storage")
+	}
+	if len(arena.strings) != 2 {
+		t.Errorf("expected long strings not to be interned, got %v", arena.strings)
+	}
+	arena.Reset()
+	if arena.strings != nil {
+		t.Errorf("expected interned strings to be released with the arena")
+	}
+}
+
+// BenchmarkIntern reads the strings of a description with 2000 operations
+// that each have 20 parameters into a model, releases the parsed
+// description, and reports the memory that the model retains. The baseline
+// reads them without a compilation, so they aren't interned.
+func BenchmarkIntern(b *testing.B) {
+	var text strings.Builder
+	text.WriteString("paths:\n")
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&text, "  /pets/%d:\n    get:\n      parameters:\n", i)
+		for j := 0; j < 20; j++ {
+			text.WriteString("      - name: limit\n        in: query\n        type: integer\n        format: int32\n")
+		}
+	}
+	source := []byte(text.String())
+	var readStrings func(node *yaml.Node, context *Context, model []string) []string
+	readStrings = func(node *yaml.Node, context *Context, model []string) []string {
+		if node.Kind == yaml.ScalarNode {
+			s, _ := StringForScalarNodeWithContext(node, context)
+			return append(model, s)
+		}
+		for _, child := range node.Content {
+			model = readStrings(child, context, model)
+		}
+		return model
+	}
+	heapSize := func() uint64 {
+		var stats runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&stats)
+		return stats.HeapAlloc
+	}
+	run := func(b *testing.B, newContext func() *Context) {
+		b.ReportAllocs()
+		var retained uint64
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			before := heapSize()
+			b.StartTimer()
+			var node yaml.Node
+			if err := yaml.Unmarshal(source, &node); err != nil {
+				b.Fatalf("%+v", err)
+			}
+			model := readStrings(&node, newContext(), nil)
+			b.StopTimer()
+			node = yaml.Node{}
+			retained += heapSize() - before
+			runtime.KeepAlive(model)
+			b.StartTimer()
+		}
+		b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+	}
+	b.Run("baseline", func(b *testing.B) {
+		run(b, func() *Context { return NewContext("$root", nil, nil) })
+	})
+	b.Run("interned", func(b *testing.B) {
+		run(b, func() *Context { return NewContextWithExtensions("$root", nil, nil, nil) })
+	})
+}
+
+func TestContexts(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte("info:\n  title: 1\n"), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	root := NewContextWithExtensions("$root", node.Content[0], nil, nil)
+	info := NewContext("info", node.Content[0].Content[1], root)
+	contexts := make([]*Context, 0)
+	for i := 0; i < 2*contextBlockSize; i++ {
+		contexts = append(contexts, NewContext("title", node.Content[0].Content[1].Content[1], info))
+	}
+	for _, context := range contexts {
+		if context.Parent != info || context.Name != "title" {
+			t.Fatalf("unexpected context %+v", context)
+		}
+	}
+	if description := ContextDescription(contexts[0]); description != "$root.info.title" {
+		t.Errorf("unexpected description %s", description)
+	}
+	err = NewError(contexts[1], "is not a string")
+	if err.Error() != "[2,10] $root.info.title is not a string" {
+		t.Errorf("unexpected error %s", err.Error())
+	}
+}
+
+// BenchmarkContexts creates the contexts of a description with 2000
+// operations that each have 20 parameters with 5 properties, like a
+// compiler does, and describes the contexts of 1% of the properties, like
+// the errors of a lenient compilation. The baseline creates the contexts
+// of gnostic-models, which are allocated one at a time and described
+// without saving the descriptions of their parents.
+func BenchmarkContexts(b *testing.B) {
+	// Contexts are kept, as they are by the values that compilers pass
+	// them to, so that they aren't allocated on the stack.
+	var kept []*models.Context
+	var keptContexts []*Context
+	const operations, parameters, properties = 2000, 20, 5
+	b.Run("baseline", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			root := models.NewContext("$root", nil, nil)
+			paths := models.NewContext("paths", nil, root)
+			for j := 0; j < operations; j++ {
+				operation := models.NewContext("get", nil, models.NewContext("/pets", nil, paths))
+				for k := 0; k < parameters; k++ {
+					schema := models.NewContext("schema", nil, models.NewContext("parameters", nil, operation))
+					for l := 0; l < properties; l++ {
+						property := models.NewContext("name", nil, schema)
+						kept = append(kept[:0], property)
+						if (j*parameters*properties+k*properties+l)%100 == 0 {
+							_ = property.Description()
+						}
+					}
+				}
+			}
+		}
+	})
+	run := func(b *testing.B, newRoot func() *Context, reset func()) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			root := newRoot()
+			paths := NewContext("paths", nil, root)
+			for j := 0; j < operations; j++ {
+				operation := NewContext("get", nil, NewContext("/pets", nil, paths))
+				for k := 0; k < parameters; k++ {
+					schema := NewContext("schema", nil, NewContext("parameters", nil, operation))
+					for l := 0; l < properties; l++ {
+						property := NewContext("name", nil, schema)
+						keptContexts = append(keptContexts[:0], property)
+						if (j*parameters*properties+k*properties+l)%100 == 0 {
+							_ = ContextDescription(property)
+						}
+					}
+				}
+			}
+			reset()
+		}
+	}
+	b.Run("heap", func(b *testing.B) {
+		run(b, func() *Context { return NewContext("$root", nil, nil) }, func() {})
+	})
+	b.Run("arena", func(b *testing.B) {
+		arena := NewArena()
+		run(b, func() *Context { return arena.NewRootContext("$root", nil, nil) }, arena.Reset)
+	})
+}
+
+func TestArena(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte("info:\n  title: 1\n"), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	arena := NewArena()
+	root := arena.NewRootContext("$root", node.Content[0], nil)
+	info := NewContext("info", node.Content[0].Content[1], root)
+	contexts := make([]*Context, 0)
+	for i := 0; i < 2*contextBlockSize; i++ {
+		contexts = append(contexts, NewContext("title", node.Content[0].Content[1].Content[1], info))
+	}
+	if arena.used != 3 {
+		t.Errorf("expected contexts to be allocated in the arena, used %d blocks", arena.used)
+	}
+	if contexts[0].arena != arena || contexts[0].Description() != "$root.info.title" {
+		t.Errorf("unexpected context %+v", contexts[0])
+	}
+	if arena.descriptions[info] != "$root.info" {
+		t.Errorf("expected the description of the parent context to be saved")
+	}
+	other := NewContext("other", nil, NewContext("$root", nil, nil))
+	arena.Reset()
+	if info.Name != "" || contexts[0].Parent != nil {
+		t.Errorf("expected contexts to be released")
+	}
+	if arena.descriptions != nil {
+		t.Errorf("expected saved descriptions of released contexts to be removed")
+	}
+	if other.Name != "other" {
+		t.Errorf("expected contexts outside of the arena to be kept")
+	}
+	if arena.NewRootContext("$root", nil, nil) != root {
+		t.Errorf("expected released blocks to be reused")
+	}
+	arena.Release()
+	if root.arena != nil || len(arena.blocks) != 0 {
+		t.Errorf("expected the arena to be released")
+	}
+}
+
+func TestNodeBuilder(t *testing.T) {
+	b := NewNodeBuilder()
+	if b.Key("name") != b.Key("name") {
+		t.Errorf("expected nodes of keys to be shared")
+	}
+	first := b.Sequence(1)
+	second := b.Sequence(1)
+	second.Content = append(second.Content, b.String("second"))
+	// Appending past the capacity of a node's content must not overwrite
+	// the contents of other nodes.
+	first.Content = append(first.Content, b.Int(1), b.Float(1.5))
+	if second.Content[0].Value != "second" {
+		t.Errorf("unexpected content %+v", second.Content[0])
+	}
+	node := b.Mapping(2)
+	node.Content = append(node.Content, b.Key("value"), first)
+	bytes, err := yaml.Marshal(node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if string(bytes) != "value:\n    - 1\n    - 1.5\n" {
+		t.Errorf("unexpected yaml %q", bytes)
+	}
+}
+
+func TestKeySet(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte("{name: 1, x-name: 2, 200: 3, 20X: 4, other: 5, /path: 6}"), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	keySet := NewKeySet([]string{"name"}, []*regexp.Regexp{
+		regexp.MustCompile("^x-"),
+		regexp.MustCompile("^([0-9X]{3})$"),
+	})
+	if len(keySet.prefixes) != 1 || len(keySet.patterns) != 1 {
+		t.Errorf("expected one prefix and one pattern")
+	}
+	invalidKeys := InvalidKeysInMapForKeySet(node.Content[0], keySet)
+	if strings.Join(invalidKeys, ",") != "other,/path" {
+		t.Errorf("unexpected invalid keys %v", invalidKeys)
+	}
+	if len(InvalidKeysInMapForKeySet(node.Content[0], NewKeySet(nil, []*regexp.Regexp{regexp.MustCompile("^")}))) != 0 {
+		t.Errorf("expected all keys to be allowed")
+	}
+}
+
+func TestReferencedFileName(t *testing.T) {
+	for _, test := range []struct {
+		referrer, file, expected string
+	}{
+		{"api.yaml", "schemas.yaml", "schemas.yaml"},
+		{"specs/api.yaml", "../common/schemas.yaml", "common/schemas.yaml"},
+		{"specs/api.yaml", `common\schemas.yaml`, "specs/common/schemas.yaml"},
+		{`specs\api.yaml`, `..\common/schemas.yaml`, "common/schemas.yaml"},
+		{`C:\specs\api.yaml`, `common\schemas.yaml`, "C:/specs/common/schemas.yaml"},
+		{`C:\specs\api.yaml`, `D:\common\schemas.yaml`, "D:/common/schemas.yaml"},
+		{"c:/specs/api.yaml", "/common/schemas.yaml", "/common/schemas.yaml"},
+		{`\\server\share\specs\api.yaml`, `..\schemas.yaml`, "//server/share/schemas.yaml"},
+		{`\\server\share\api.yaml`, `\\other\share\schemas.yaml`, "//other/share/schemas.yaml"},
+		{"https://example.com/specs/api.yaml", `common\schemas.yaml`, "https://example.com/specs/common/schemas.yaml"},
+		{`C:\specs\api.yaml`, "https://example.com/schemas.yaml", "https://example.com/schemas.yaml"},
+	} {
+		if name := ReferencedFileName(test.referrer, test.file); name != test.expected {
+			t.Errorf("%s from %s: expected %s, got %s", test.file, test.referrer, test.expected, name)
+		}
+	}
+	for name, expected := range map[string]bool{
+		`C:\api.yaml`:             false,
+		"c:/api.yaml":             false,
+		`\\server\share\api.yaml`: false,
+		"api.yaml":                false,
+		"https://example.com/api": true,
+		"file:///api.yaml":        true,
+	} {
+		if IsURL(name) != expected {
+			t.Errorf("IsURL(%q) should be %t", name, expected)
+		}
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	text := "openapi: 3.0.0\ninfo:\n  title: Pétstore 🐾\n"
+	encode := func(order binary.ByteOrder, bom bool) []byte {
+		units := utf16.Encode([]rune(text))
+		if bom {
+			units = append([]uint16{0xFEFF}, units...)
+		}
+		b := make([]byte, 2*len(units))
+		for i, unit := range units {
+			order.PutUint16(b[2*i:], unit)
+		}
+		return b
+	}
+	for name, input := range map[string][]byte{
+		"UTF-8":                  []byte(text),
+		"UTF-8 with BOM":         append([]byte{0xEF, 0xBB, 0xBF}, text...),
+		"UTF-16LE with BOM":      encode(binary.LittleEndian, true),
+		"UTF-16BE with BOM":      encode(binary.BigEndian, true),
+		"UTF-16LE without a BOM": encode(binary.LittleEndian, false),
+		"UTF-16BE without a BOM": encode(binary.BigEndian, false),
+	} {
+		output, err := DecodeText(input)
+		if err != nil {
+			t.Errorf("%s: %+v", name, err)
+		} else if string(output) != text {
+			t.Errorf("%s: expected %q, got %q", name, text, output)
+		}
+	}
+	for name, input := range map[string][]byte{
+		"UTF-32":       {0xFF, 0xFE, 0x00, 0x00, 'a', 0x00, 0x00, 0x00},
+		"odd UTF-16LE": {0xFF, 0xFE, 'a', 0x00, 'b'},
+	} {
+		if _, err := DecodeText(input); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte("[9223372036854775807, 0.1, 1.0, 9007199254740993, 9007199254740992.0, 9007199254740992.5]"), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	annotations := &Annotations{}
+	annotations.RecordNumbers(&node)
+	list := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, item := range node.Content[0].Content {
+		v, ok := FloatForScalarNode(item)
+		if !ok {
+			t.Fatalf("%s is not a number", item.Value)
+		}
+		list.Content = append(list.Content, NewScalarNodeForFloat(v))
+	}
+	annotations.RestoreNumbers(list)
+	expected := []string{"9223372036854775807", "0.1", "1", "9007199254740993", "9.007199254740992e+15", "9007199254740992.5"}
+	for i, item := range list.Content {
+		if item.Value != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], item.Value)
+		}
+	}
+	if list.Content[0].Tag != "!!int" || list.Content[2].Tag != "!!int" || list.Content[1].Tag != "!!float" {
+		t.Errorf("expected integers to be tagged as integers")
+	}
+
+	// Texts are only written where they were read, and only with the
+	// annotations that they were recorded in.
+	other := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{
+		NewScalarNodeForFloat(9007199254740992),
+		NewScalarNodeForFloat(9007199254740992),
+		NewScalarNodeForFloat(9007199254740992),
+		NewScalarNodeForFloat(1),
+	}}
+	var none *Annotations
+	none.RestoreNumbers(other)
+	if other.Content[3].Value != "1" || other.Content[3].Tag != "!!int" {
+		t.Errorf("unexpected number %s %s", other.Content[3].Tag, other.Content[3].Value)
+	}
+	annotations.RestoreNumbers(other)
+	expected = []string{"9.007199254740992e+15", "9.007199254740992e+15", "9.007199254740992e+15", "1"}
+	for i, item := range other.Content {
+		if item.Value != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], item.Value)
+		}
+	}
+}
+
+func TestPlainStrings(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte("[1.0, true, 2020-01-01, !!float 2.0, \"3.0\"]"), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	items := node.Content[0].Content
+	root := NewContext("$root", &node, nil)
+	other := NewContext("$root", &node, nil)
+	EnablePlainStrings(root)
+	defer DisablePlainStrings(root)
+	if _, ok := StringForScalarNode(items[0]); ok {
+		t.Errorf("expected 1.0 to be read as a number without a context")
+	}
+	if _, ok := StringForScalarNodeWithContext(items[0], NewContext("version", items[0], other)); ok {
+		t.Errorf("expected 1.0 to be read as a number in another compilation")
+	}
+	expected := []string{"1.0", "true", "2020-01-01", "", "3.0"}
+	for i, item := range items {
+		s, ok := StringForScalarNodeWithContext(item, NewContext("version", item, root))
+		if ok != (expected[i] != "") || s != expected[i] {
+			t.Errorf("unexpected string for %s: %q %t", item.Value, s, ok)
+		}
+	}
+	if strings := StringArrayForSequenceNodeWithContext(node.Content[0], root); len(strings) != 4 {
+		t.Errorf("unexpected strings %v", strings)
+	}
+	DisablePlainStrings(root)
+	if _, ok := StringForScalarNodeWithContext(items[0], root); ok {
+		t.Errorf("expected 1.0 to be read as a number after plain strings are disabled")
+	}
+}
+
+func TestExplicitValues(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte("{a: null, b: [{c: ~}, 1], d/e: {f: null}, g: false, h: true}"), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	annotations := NewAnnotations(&node)
+	expected := []string{"/a", "/b/0/c", "/d~1e/f"}
+	if nulls := annotations.ExplicitNulls(); !reflect.DeepEqual(nulls, expected) {
+		t.Errorf("unexpected nulls %v", nulls)
+	}
+	if !annotations.IsExplicitNull("/a") || annotations.IsExplicitNull("/b") || annotations.IsExplicitNull("/g") {
+		t.Errorf("unexpected explicit nulls")
+	}
+	if !annotations.HasExplicitValue("/g") || annotations.HasExplicitValue("/h") {
+		t.Errorf("unexpected explicit values %v", annotations.ExplicitValues)
+	}
+	var output yaml.Node
+	if err := yaml.Unmarshal([]byte("{a: '', b: [{}], x: 2}"), &output); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	annotations.RestoreNulls(&output)
+	bytes, err := yaml.Marshal(&output)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if string(bytes) != "{a: null, b: [{c: null}], x: 2}\n" {
+		t.Errorf("unexpected output %s", bytes)
+	}
+	filtered := annotations.Filter(func(pointer string) bool { return pointer != "/a" })
+	if nulls := filtered.ExplicitNulls(); !reflect.DeepEqual(nulls, expected[1:]) {
+		t.Errorf("unexpected filtered nulls %v", nulls)
+	}
+}
+
+func TestReferenceSiblings(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte("{a: {$ref: '#/b', description: d, readOnly: true, example: {x: 1}}, b: {}}"), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	annotations := NewAnnotations(&node)
+	if siblings := annotations.ReferenceSiblings; len(siblings) != 0 {
+		t.Errorf("expected explicit values not to include siblings %v", siblings)
+	}
+	annotations.RecordReferenceSiblings(&node)
+	expected := []Annotation{
+		{Pointer: "/a/readOnly", Tag: "!!bool", Value: "true\n"},
+		{Pointer: "/a/example", Tag: "!!map", Value: "{x: 1}\n"},
+	}
+	if siblings := annotations.ReferenceSiblings; !reflect.DeepEqual(siblings, expected) {
+		t.Errorf("unexpected siblings %v", siblings)
+	}
+	if annotations.HasExplicitValue("/a/readOnly") {
+		t.Errorf("expected siblings not to be explicit values")
+	}
+	var output yaml.Node
+	if err := yaml.Unmarshal([]byte("{a: {$ref: '#/b', example: 2}, b: {}}"), &output); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	annotations.RestoreReferenceSiblings(&output)
+	bytes, err := yaml.Marshal(&output)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if string(bytes) != "{a: {$ref: '#/b', example: 2, readOnly: true}, b: {}}\n" {
+		t.Errorf("unexpected output %s", bytes)
+	}
+}
+
+func TestUnicodeKeys(t *testing.T) {
+	// The second key is "café" with a combining accent, the fourth has a
+	// Cyrillic "е", and the keys of examples aren't checked.
+	source := "{\"caf\u00e9\": 1, \"cafe\u0301\": 2, user: 3, \"us\u0435r\": 4, \u540d\u524d: 5, example: {\"\u0430b\": 6}}"
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	context := NewContext("$root", nil, nil)
+	err := NormalizeKeys(&node, context)
+	if err == nil || err.Error() != "[1,13] $root.cafe\u0301 is the same as another key after normalization" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if key := node.Content[0].Content[2].Value; key != "caf\u00e9" {
+		t.Errorf("expected a normalized key, got %q", key)
+	}
+	err = CheckKeys(&node, context)
+	expected := []string{
+		"[1,34] $root.us\u0435r mixes Cyrillic and Latin scripts",
+		"[1,34] $root.us\u0435r can be confused with user",
+	}
+	group, ok := err.(*ErrorGroup)
+	if !ok || len(group.Errors) != len(expected) {
+		t.Fatalf("unexpected errors %v", err)
+	}
+	for i, err := range group.Errors {
+		if err.Error() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], err.Error())
+		}
+	}
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	var mutex sync.Mutex
+	var apiKey string
+	lastAPIKey := func() string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return apiKey
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		apiKey = r.Header.Get("X-Api-Key")
+		mutex.Unlock()
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Api-Key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/slow.yaml" {
+			select {
+			case <-time.After(200 * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		fmt.Fprint(w, "definitions: {Pet: {type: object}}\n")
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	fetcher := &HTTPFetcher{Header: http.Header{"X-Api-Key": {"key"}}, Token: "secret", Hosts: []string{server.URL}, Timeout: 50 * time.Millisecond}
+	node, err := ReadInfoForRefWithFetcher(server.URL+"/api.yaml", "pets.yaml#/definitions/Pet", fetcher)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if value, _ := StringForScalarNode(MapValueForKey(node, "type")); value != "object" {
+		t.Errorf("unexpected node %+v", node)
+	}
+	if _, ok := GetInfoCache()[server.URL+"/pets.yaml"]; ok {
+		t.Errorf("expected fetched files not to be cached")
+	}
+	if _, err := fetcher.Fetch(server.URL + "/slow.yaml"); err == nil {
+		t.Errorf("expected a slow request to time out")
+	}
+	if _, err := (&HTTPFetcher{}).Fetch(server.URL + "/pets.yaml"); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("unexpected error %v", err)
+	}
+	// The headers and token are only sent to their hosts.
+	fetcher.Hosts = []string{"example.com", "http://" + strings.Split(host, ":")[0] + ":1"}
+	if _, err := fetcher.Fetch(server.URL + "/pets.yaml"); err == nil || !strings.Contains(err.Error(), "401") || lastAPIKey() != "" {
+		t.Errorf("expected the headers and token not to be sent, got %v", err)
+	}
+	fetcher.Hosts = []string{"http://" + strings.Split(host, ":")[0]}
+	if _, err := fetcher.Fetch(server.URL + "/pets.yaml"); err != nil {
+		t.Errorf("expected the headers and token to be sent to every port of a host, got %v", err)
+	}
+	// Hosts without schemes only match https.
+	fetcher.Hosts = []string{host}
+	if _, err := fetcher.Fetch(server.URL + "/pets.yaml"); err == nil || lastAPIKey() != "" {
+		t.Errorf("expected the headers and token not to be sent with http, got %v", err)
+	}
+}
+
+func TestHTTPFetcherRedirects(t *testing.T) {
+	requests := make(chan http.Header, 1)
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.Header
+		fmt.Fprint(w, "definitions: {}\n")
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+r.URL.Path, http.StatusFound)
+	}))
+	defer server.Close()
+	fetcher := &HTTPFetcher{Header: http.Header{"X-Api-Key": {"key"}}, Token: "secret", Hosts: []string{server.URL}}
+	if _, err := fetcher.Fetch(server.URL + "/pets.yaml"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// Requests that are redirected to other hosts don't get the headers and token.
+	header := <-requests
+	if header.Get("X-Api-Key") != "" || header.Get("Authorization") != "" {
+		t.Errorf("expected the headers and token not to be sent, got %v", header)
+	}
+	fetcher.Hosts = []string{server.URL, other.URL}
+	if _, err := fetcher.Fetch(server.URL + "/pets.yaml"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	header = <-requests
+	if header.Get("X-Api-Key") != "key" {
+		t.Errorf("expected the headers to be sent, got %v", header)
+	}
+}
+
+func TestReadInfoForRefWithContext(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("definitions:\n  Pet:\n    $ref: '#/definitions/Missing'\n"), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ref := node.Content[0].Content[1].Content[1].Content[1]
+	context := NewContext("$ref", ref, NewContext("Pet", nil, NewContext("definitions", nil, NewContext("$root", nil, nil))))
+	filename := "../examples/v2.0/yaml/petstore.yaml"
+	for _, test := range []struct {
+		ref      string
+		expected string
+	}{
+		{"#/definitions/Missing", "api.yaml:3:11: $root.definitions.Pet.$ref could not resolve #/definitions/Missing"},
+		{"missing.yaml#/Pet", "api.yaml:3:11: $root.definitions.Pet.$ref open "},
+	} {
+		_, err := ReadInfoForRefWithContext(filename, test.ref, nil, context)
+		if err == nil || !strings.HasPrefix(FormatErrors("api.yaml", err), test.expected) {
+			t.Errorf("%s: unexpected error %v", test.ref, err)
+		}
+	}
+	// Without a context, errors have no locations.
+	if _, err := ReadInfoForRefWithContext(filename, "#/definitions/Missing", nil, nil); err == nil || FormatErrors("api.yaml", err) != "api.yaml: could not resolve #/definitions/Missing" {
+		t.Errorf("unexpected error %v", err)
+	}
+	// Files named with URLs are read with the fetcher of the context,
+	// which contexts inherit from their parents.
+	root := NewContext("$root", nil, nil)
+	root.Fetcher = FetcherFunc(func(fileurl string) ([]byte, error) {
+		if fileurl != "https://example.com/pet.yaml" {
+			t.Errorf("unexpected url %s", fileurl)
+		}
+		return []byte("Pet:\n  type: object\n"), nil
+	})
+	info, err := ReadInfoForRefWithContext("https://example.com/api.yaml", "pet.yaml#/Pet", nil, NewContext("definitions", nil, root))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if value, ok := StringForScalarNode(MapValueForKey(info, "type")); !ok || value != "object" {
+		t.Errorf("unexpected info %+v", info)
+	}
+}
+
+func TestCheckEquivalentPaths(t *testing.T) {
+	paths := []string{"/pets/{id}", "/pets/{petId}", "/pets", "/pets/{id}/toys/{toy}", "/pets/{pet}/toys/{name}"}
+	errors := CheckEquivalentPaths(paths, nil)
+	expected := "$root.paths./pets/{petId} is equivalent to /pets/{id}\n" +
+		"$root.paths./pets/{pet}/toys/{name} is equivalent to /pets/{id}/toys/{toy}"
+	if err := NewErrorGroupOrNil(errors); err == nil || err.Error() != expected {
+		t.Errorf("unexpected errors %v", err)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+	if value, ok := cache.Get("a"); !ok || value != 1 {
+		t.Errorf("unexpected value %v", value)
+	}
+	// b is the least recently used, so it is evicted.
+	cache.Add("c", 3)
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []string{"c", "a"}) {
+		t.Errorf("unexpected keys %v", keys)
+	}
+	cache.Remove("c")
+	cache.Clear()
+	if keys := cache.Keys(); len(keys) != 0 {
+		t.Errorf("unexpected keys %v", keys)
+	}
+	// Caches can be used by several goroutines.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("%d", (i+j)%5)
+				cache.Add(key, j)
+				cache.Get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if keys := cache.Keys(); len(keys) != 2 {
+		t.Errorf("unexpected keys %v", keys)
+	}
+}
+
+func TestInfoCache(t *testing.T) {
+	defer SetInfoCache(NewLRUCache(DefaultCacheSize))
+	SetInfoCache(NewLRUCache(1))
+	first, err := ReadInfoFromBytes("a.yaml", []byte("a: 1"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if cached, _ := ReadInfoFromBytes("a.yaml", []byte("a: 1")); cached != first {
+		t.Errorf("expected the cached node")
+	}
+	changed, _ := ReadInfoFromBytes("a.yaml", []byte("a: 2"))
+	if changed == first || changed.Content[0].Content[1].Value != "2" {
+		t.Errorf("expected a.yaml to be parsed again when its contents change")
+	}
+	ReadInfoFromBytes("b.yaml", []byte("b: 1"))
+	if _, ok := GetInfoCache()["a.yaml"]; ok {
+		t.Errorf("expected a.yaml to be evicted")
+	}
+	DisableInfoCache()
+	defer EnableInfoCache()
+	ReadInfoFromBytes("c.yaml", []byte("c: 1"))
+	if _, ok := GetInfoCache()["c.yaml"]; ok {
+		t.Errorf("expected c.yaml not to be cached")
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Line: 3, Column: 7}
+	located := NewError(NewContext("info", node, NewContext("$root", nil, nil)), "has invalid property: x")
+	if line, column := ErrorLocation(located); line != 3 || column != 7 {
+		t.Errorf("unexpected location %d:%d", line, column)
+	}
+	unlocated := NewError(nil, "could not resolve #/definitions/Pet")
+	if line, column := ErrorLocation(unlocated); line != 0 || column != 0 {
+		t.Errorf("unexpected location %d:%d", line, column)
+	}
+	err := NewErrorGroupOrNil([]error{
+		located,
+		NewErrorGroupOrNil([]error{unlocated}),
+		fmt.Errorf("unable to identify OpenAPI version"),
+	})
+	expected := "petstore.yaml:3:7: $root.info has invalid property: x\n" +
+		"petstore.yaml: could not resolve #/definitions/Pet\n" +
+		"petstore.yaml: unable to identify OpenAPI version"
+	if s := FormatErrors("petstore.yaml", err); s != expected {
+		t.Errorf("unexpected errors\n%s", s)
+	}
+	if s := FormatErrors("petstore.yaml", nil); s != "" {
+		t.Errorf("unexpected errors %q", s)
+	}
+}
+
+func TestErrorReports(t *testing.T) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Line: 3, Column: 7}
+	err := NewErrorGroupOrNil([]error{
+		NewError(NewContext("info", node, NewContext("$root", nil, nil)), "has invalid property: x"),
+		fmt.Errorf("unable to identify OpenAPI version"),
+	})
+	expected := []ErrorReport{
+		{Path: "$root.info", Line: 3, Column: 7, Severity: SeverityError, Message: "has invalid property: x"},
+		{Severity: SeverityError, Message: "unable to identify OpenAPI version"},
+	}
+	if reports := ErrorReports(err); !reflect.DeepEqual(reports, expected) {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+	if reports := ErrorReports(nil); reports == nil || len(reports) != 0 {
+		t.Errorf("expected an empty list of reports, got %+v", reports)
+	}
+}
@@ -15,14 +15,67 @@
 package compiler
 
 import (
-	"github.com/google/gnostic-models/compiler"
+	"gopkg.in/yaml.v3"
 )
 
 // Context contains state of the compiler as it traverses a document.
-type Context = compiler.Context
+type Context struct {
+	Parent            *Context
+	Name              string
+	Node              *yaml.Node
+	ExtensionHandlers *[]ExtensionHandler
+	// Fetcher reads the files named with URLs that references refer to,
+	// or is nil if they are read with http.Get. Contexts inherit the
+	// fetchers of their parents.
+	Fetcher Fetcher
+	// the arena that the context was allocated in, which is saved in each
+	// context when it is created, or nil
+	arena *Arena
+	// the strings interned in the compilation, which are shared by all of
+	// its contexts, or nil if strings aren't interned
+	strings map[string]string
+}
 
-// NewContextWithExtensions returns a new object representing the compiler state
-var NewContextWithExtensions = compiler.NewContextWithExtensions
+// NewContextWithExtensions returns a new object representing the compiler
+// state. A context without a parent is the root of a compilation, which
+// interns the strings that are read in it.
+func NewContextWithExtensions(name string, node *yaml.Node, parent *Context, extensionHandlers *[]ExtensionHandler) *Context {
+	if parent == nil {
+		return &Context{Name: name, Node: node, ExtensionHandlers: extensionHandlers, strings: make(map[string]string)}
+	}
+	if parent.arena == nil {
+		return &Context{Name: name, Node: node, Parent: parent, ExtensionHandlers: extensionHandlers, Fetcher: parent.Fetcher, strings: parent.strings}
+	}
+	// Children of contexts that were allocated in an arena are allocated
+	// in the same arena.
+	context := parent.arena.allocateContext()
+	*context = Context{Name: name, Node: node, Parent: parent, ExtensionHandlers: extensionHandlers, Fetcher: parent.Fetcher, arena: parent.arena, strings: parent.strings}
+	return context
+}
 
 // NewContext returns a new object representing the compiler state
-var NewContext = compiler.NewContext
+func NewContext(name string, node *yaml.Node, parent *Context) *Context {
+	if parent == nil {
+		return &Context{Name: name}
+	}
+	return NewContextWithExtensions(name, node, parent, parent.ExtensionHandlers)
+}
+
+// Description returns a text description of the compiler state.
+func (context *Context) Description() string {
+	return ContextDescription(context)
+}
+
+// ContextDescription returns a text description of the compiler state.
+// Errors describe their contexts with the names of all of their ancestors,
+// and many errors share ancestors, so contexts that are allocated in an
+// arena save the descriptions of their parents there until it is reset.
+func ContextDescription(context *Context) string {
+	if context.Parent == nil {
+		return context.Name
+	}
+	if context.arena != nil {
+		return context.arena.description(context.Parent) + "." + context.Name
+	}
+	return ContextDescription(context.Parent) + "." + context.Name
+}
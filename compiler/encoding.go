@@ -0,0 +1,71 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF32BE = []byte{0x00, 0x00, 0xFE, 0xFF}
+	bomUTF32LE = []byte{0xFF, 0xFE, 0x00, 0x00}
+)
+
+// DecodeText returns the UTF-8 encoding of a JSON or YAML description.
+// Descriptions written by Windows tools often begin with a byte-order mark
+// or are encoded in UTF-16; byte-order marks are removed and UTF-16 text
+// is transcoded. Like JSON text, UTF-16 text without a byte-order mark is
+// recognized by the zero bytes of its first ASCII character.
+func DecodeText(b []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(b, bomUTF32BE), bytes.HasPrefix(b, bomUTF32LE):
+		return nil, errors.New("UTF-32 encoded text is not supported")
+	case bytes.HasPrefix(b, bomUTF8):
+		return b[len(bomUTF8):], nil
+	case bytes.HasPrefix(b, bomUTF16BE):
+		return decodeUTF16(b[len(bomUTF16BE):], binary.BigEndian)
+	case bytes.HasPrefix(b, bomUTF16LE):
+		return decodeUTF16(b[len(bomUTF16LE):], binary.LittleEndian)
+	case len(b) >= 2 && b[0] == 0 && b[1] != 0:
+		return decodeUTF16(b, binary.BigEndian)
+	case len(b) >= 2 && b[0] != 0 && b[1] == 0:
+		return decodeUTF16(b, binary.LittleEndian)
+	}
+	return b, nil
+}
+
+// Returns the UTF-8 encoding of UTF-16 text. Unpaired surrogates are
+// replaced with U+FFFD.
+func decodeUTF16(b []byte, order binary.ByteOrder) ([]byte, error) {
+	if len(b)%2 != 0 {
+		return nil, errors.New("invalid UTF-16 encoded text: odd number of bytes")
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = order.Uint16(b[2*i:])
+	}
+	runes := utf16.Decode(units)
+	result := make([]byte, 0, len(runes))
+	for _, r := range runes {
+		result = append(result, string(r)...)
+	}
+	return result, nil
+}
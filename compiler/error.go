@@ -15,17 +15,139 @@
 package compiler
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/google/gnostic-models/compiler"
 )
 
 // Error represents compiler errors and their location in the document.
 type Error = compiler.Error
 
-// NewError creates an Error.
-var NewError = compiler.NewError
+// NewError creates an Error. The location of the error is described
+// when the error is created, so the error doesn't retain its context's
+// ancestors.
+func NewError(context *Context, message string) *Error {
+	if context == nil {
+		return compiler.NewError(nil, message)
+	}
+	return compiler.NewError(&compiler.Context{
+		Name:              ContextDescription(context),
+		Node:              context.Node,
+		ExtensionHandlers: context.ExtensionHandlers,
+	}, message)
+}
 
 // ErrorGroup is a container for groups of Error values.
 type ErrorGroup = compiler.ErrorGroup
 
 // NewErrorGroupOrNil returns a new ErrorGroup for a slice of errors or nil if the slice is empty.
 var NewErrorGroupOrNil = compiler.NewErrorGroupOrNil
+
+// ErrorLocation returns the line and column of the node where an error was
+// found, or zeros if they are unknown.
+func ErrorLocation(err *Error) (line, column int) {
+	if err.Context == nil || err.Context.Node == nil {
+		return 0, 0
+	}
+	return err.Context.Node.Line, err.Context.Node.Column
+}
+
+// LocateErrors returns err, which may be a group, with the errors in it
+// that have no location replaced by errors located at a context, so that
+// errors found while reading something that a node refers to are reported
+// at the node. err is returned unchanged if context is nil.
+func LocateErrors(err error, context *Context) error {
+	if err == nil || context == nil {
+		return err
+	}
+	switch err := err.(type) {
+	case *ErrorGroup:
+		errors := make([]error, 0, len(err.Errors))
+		for _, err := range err.Errors {
+			errors = append(errors, LocateErrors(err, context))
+		}
+		return NewErrorGroupOrNil(errors)
+	case *Error:
+		if line, _ := ErrorLocation(err); line != 0 {
+			return err
+		}
+	}
+	return NewError(context, err.Error())
+}
+
+// SeverityError is the severity of errors that prevent a description from
+// being compiled.
+const SeverityError = "error"
+
+// An ErrorReport describes an error in a form that can be written as JSON
+// for programs like CI systems and editors. Line and Column are zero if
+// the location of the error is unknown.
+type ErrorReport struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// ErrorReports returns a report for each of the errors in err, which may
+// be a group.
+func ErrorReports(err error) []ErrorReport {
+	reports := make([]ErrorReport, 0)
+	for _, err := range flattenErrors(err, nil) {
+		compilerError, ok := err.(*Error)
+		if !ok {
+			reports = append(reports, ErrorReport{Severity: SeverityError, Message: err.Error()})
+			continue
+		}
+		report := ErrorReport{Severity: SeverityError, Message: compilerError.Message}
+		report.Line, report.Column = ErrorLocation(compilerError)
+		if compilerError.Context != nil {
+			report.Path = compilerError.Context.Name
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// FormatErrors describes the errors in err, which may be a group, one per
+// line. Each is prefixed with the name of the file that was compiled and,
+// if it is known, the line and column where the error was found, like
+// "petstore.yaml:112:7: $root.paths has invalid property: foo", so that
+// editors and terminals can link to the location.
+func FormatErrors(filename string, err error) string {
+	lines := make([]string, 0)
+	for _, report := range ErrorReports(err) {
+		lines = append(lines, report.Format(filename))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Format describes an error on a line like the lines of FormatErrors.
+func (report ErrorReport) Format(filename string) string {
+	prefix := filename + ":"
+	if report.Line != 0 {
+		prefix = fmt.Sprintf("%s:%d:%d:", filename, report.Line, report.Column)
+	}
+	message := report.Message
+	if report.Path != "" {
+		message = report.Path + " " + message
+	}
+	return prefix + " " + message
+}
+
+// Appends the errors in an error, which may be a group, to a list.
+func flattenErrors(err error, errors []error) []error {
+	switch err := err.(type) {
+	case nil:
+		return errors
+	case *ErrorGroup:
+		for _, err := range err.Errors {
+			errors = flattenErrors(err, errors)
+		}
+		return errors
+	default:
+		return append(errors, err)
+	}
+}
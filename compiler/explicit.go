@@ -0,0 +1,257 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Compiled models can't distinguish fields that were set to null or false
+// from fields that were absent, since all of them are compiled as empty
+// values. The locations of these explicit values are recorded in the
+// annotations of each compiled model so that they can be reported, and
+// explicit nulls are written with the model. OpenAPI 3.1 allows keywords
+// beside references, and they are recorded in the same way, since
+// reference models only keep their summaries and descriptions.
+
+// The keywords of references that are kept by reference models.
+var referenceKeywords = map[string]bool{"$ref": true, "summary": true, "description": true}
+
+// RecordExplicitValues records the locations of the nulls and false
+// values in the node that a model was compiled from.
+func (a *Annotations) RecordExplicitValues(node *yaml.Node) {
+	a.ExplicitValues = findExplicitValues(node, "", nil)
+}
+
+// RecordReferenceSiblings records the keywords beside the references in
+// the node that an OpenAPI 3.1 model was compiled from.
+func (a *Annotations) RecordReferenceSiblings(node *yaml.Node) {
+	a.ReferenceSiblings = findReferenceSiblings(node, "", nil)
+}
+
+// Appends the nulls and false values of the mappings in a node to a list.
+func findExplicitValues(node *yaml.Node, pointer string, values []Annotation) []Annotation {
+	if node == nil {
+		return values
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			values = findExplicitValues(node.Content[0], pointer, values)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			child := pointer + "/" + escapePointer(node.Content[i].Value)
+			value := node.Content[i+1]
+			if value.Kind == yaml.ScalarNode && (value.Tag == "!!null" || value.Tag == "!!bool" && isFalse(value.Value)) {
+				values = append(values, Annotation{Pointer: child, Tag: value.Tag})
+			} else {
+				values = findExplicitValues(value, child, values)
+			}
+		}
+	case yaml.SequenceNode:
+		for i, value := range node.Content {
+			values = findExplicitValues(value, pointer+"/"+strconv.Itoa(i), values)
+		}
+	}
+	return values
+}
+
+// Appends the keywords beside the references in a node to a list.
+func findReferenceSiblings(node *yaml.Node, pointer string, siblings []Annotation) []Annotation {
+	if node == nil {
+		return siblings
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			siblings = findReferenceSiblings(node.Content[0], pointer, siblings)
+		}
+	case yaml.MappingNode:
+		reference := MapValueForKey(node, "$ref") != nil
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			child := pointer + "/" + escapePointer(key)
+			value := node.Content[i+1]
+			if !reference {
+				siblings = findReferenceSiblings(value, child, siblings)
+			} else if !referenceKeywords[key] {
+				if bytes, err := yaml.Marshal(value); err == nil {
+					siblings = append(siblings, Annotation{Pointer: child, Tag: value.ShortTag(), Value: string(bytes)})
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		for i, value := range node.Content {
+			siblings = findReferenceSiblings(value, pointer+"/"+strconv.Itoa(i), siblings)
+		}
+	}
+	return siblings
+}
+
+// Returns true if the text of a boolean is false.
+func isFalse(s string) bool {
+	v, ok := BoolForScalarNode(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: s})
+	return ok && !v
+}
+
+// ExplicitNulls returns the JSON pointers of the fields that were
+// explicitly set to null, like "/info/description".
+func (a *Annotations) ExplicitNulls() []string {
+	if a == nil {
+		return nil
+	}
+	var pointers []string
+	for _, value := range a.ExplicitValues {
+		if value.Tag == "!!null" {
+			pointers = append(pointers, value.Pointer)
+		}
+	}
+	return pointers
+}
+
+// IsExplicitNull returns true if the field at a JSON pointer was
+// explicitly set to null.
+func (a *Annotations) IsExplicitNull(pointer string) bool {
+	if a == nil {
+		return false
+	}
+	for _, value := range a.ExplicitValues {
+		if value.Pointer == pointer && value.Tag == "!!null" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasExplicitValue returns true if the field at a JSON pointer was
+// explicitly set to null or false.
+func (a *Annotations) HasExplicitValue(pointer string) bool {
+	if a == nil {
+		return false
+	}
+	for _, value := range a.ExplicitValues {
+		if value.Pointer == pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// RestoreNulls adds the explicit nulls to a node created from the model
+// that they were recorded for. Nulls are only added to mappings that
+// exist, and they replace the empty values that are written for required
+// fields, like an empty title, so that all of the nulls in a description
+// are written in the same way.
+func (a *Annotations) RestoreNulls(node *yaml.Node) {
+	node = documentContent(node)
+	for _, pointer := range a.ExplicitNulls() {
+		null := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+		if !replaceEmptyValue(node, pointer, null) {
+			restoreValue(node, pointer, null)
+		}
+	}
+}
+
+// Replaces an empty string, mapping, or sequence at a JSON pointer with a
+// value, returning true if it was replaced.
+func replaceEmptyValue(node *yaml.Node, pointer string, value *yaml.Node) bool {
+	parts := strings.Split(pointer, "/")[1:]
+	parent := node
+	for _, part := range parts[:len(parts)-1] {
+		parent = childForPointerPart(parent, unescapePointer(part))
+	}
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return false
+	}
+	key := unescapePointer(parts[len(parts)-1])
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value != key {
+			continue
+		}
+		current := parent.Content[i+1]
+		if isEmptyValue(current) {
+			parent.Content[i+1] = value
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// Returns true if a node is an empty string, mapping, or sequence.
+func isEmptyValue(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Tag == "!!str" && node.Value == ""
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
+
+// RestoreReferenceSiblings adds the keywords beside references to a node
+// created from the model that they were recorded for, if the node doesn't
+// have them.
+func (a *Annotations) RestoreReferenceSiblings(node *yaml.Node) {
+	if a == nil {
+		return
+	}
+	node = documentContent(node)
+	for _, sibling := range a.ReferenceSiblings {
+		var value yaml.Node
+		if yaml.Unmarshal([]byte(sibling.Value), &value) != nil || len(value.Content) == 0 {
+			continue
+		}
+		restoreValue(node, sibling.Pointer, value.Content[0])
+	}
+}
+
+// Adds a value to the mapping that contains the location named by a JSON
+// pointer. Values are only added to mappings that exist and that don't
+// have values for their keys.
+func restoreValue(node *yaml.Node, pointer string, value *yaml.Node) {
+	parts := strings.Split(pointer, "/")[1:]
+	parent := node
+	for _, part := range parts[:len(parts)-1] {
+		parent = childForPointerPart(parent, unescapePointer(part))
+	}
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return
+	}
+	key := unescapePointer(parts[len(parts)-1])
+	if MapValueForKey(parent, key) == nil {
+		parent.Content = append(parent.Content, NewScalarNodeForString(key), value)
+	}
+}
+
+// Returns the child of a mapping or sequence named by a part of a JSON pointer.
+func childForPointerPart(node *yaml.Node, part string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		return MapValueForKey(node, part)
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(part); err == nil && i >= 0 && i < len(node.Content) {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
@@ -15,6 +15,9 @@
 package compiler
 
 import (
+	"google.golang.org/protobuf/types/known/anypb"
+	"gopkg.in/yaml.v3"
+
 	"github.com/google/gnostic-models/compiler"
 )
 
@@ -22,4 +25,9 @@ import (
 type ExtensionHandler = compiler.ExtensionHandler
 
 // CallExtension calls a binary extension handler.
-var CallExtension = compiler.CallExtension
+func CallExtension(context *Context, in *yaml.Node, extensionName string) (handled bool, response *anypb.Any, err error) {
+	if context == nil {
+		return false, nil, nil
+	}
+	return compiler.CallExtension(&compiler.Context{Name: context.Name, Node: context.Node, ExtensionHandlers: context.ExtensionHandlers}, in, extensionName)
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// A Fetcher reads files that are named with URLs, so that programs can
+// control how referenced files are downloaded.
+type Fetcher interface {
+	Fetch(fileurl string) ([]byte, error)
+}
+
+// FetcherFunc is a function that is used as a Fetcher.
+type FetcherFunc func(fileurl string) ([]byte, error)
+
+// Fetch calls the function.
+func (f FetcherFunc) Fetch(fileurl string) ([]byte, error) {
+	return f(fileurl)
+}
+
+// An HTTPFetcher downloads files with an HTTP client, adding headers and
+// credentials to the requests to some hosts and giving up on requests that
+// take too long.
+type HTTPFetcher struct {
+	// Client sends requests. If it is nil, http.DefaultClient is used.
+	Client *http.Client
+	// Header contains headers that are added to the requests to Hosts.
+	Header http.Header
+	// Token is sent as a bearer token in the Authorization header of the
+	// requests to Hosts when it isn't empty.
+	Token string
+	// Hosts are the hosts that Header and Token are sent to, like
+	// example.com or example.com:8443. Hosts without ports match every
+	// port. They are only sent with https unless a host is named with
+	// another scheme, like http://localhost:8080. Headers and tokens
+	// aren't sent to other hosts, or to other hosts that requests are
+	// redirected to, so descriptions can't refer to files on other hosts
+	// to collect them.
+	Hosts []string
+	// Timeout limits the time taken by each request, including reading
+	// its response. Requests are only limited by the client if it is zero.
+	Timeout time.Duration
+}
+
+// Fetch downloads a file. Responses without a 2xx status are errors.
+func (f *HTTPFetcher) Fetch(fileurl string) ([]byte, error) {
+	return f.FetchContext(context.Background(), fileurl)
+}
+
+// FetchContext downloads a file like Fetch, giving up when ctx is done.
+func (f *HTTPFetcher) FetchContext(ctx context.Context, fileurl string) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, fileurl, nil)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+	if f.sendsCredentials(request.URL) {
+		for name, values := range f.Header {
+			for _, value := range values {
+				request.Header.Add(name, value)
+			}
+		}
+		if f.Token != "" {
+			request.Header.Set("Authorization", "Bearer "+f.Token)
+		}
+	}
+	if f.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(request.Context(), f.Timeout)
+		defer cancel()
+		request = request.WithContext(ctx)
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := f.redirectingClient(client).Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("unable to fetch %s: %s", fileurl, response.Status)
+	}
+	return ioutil.ReadAll(response.Body)
+}
+
+// Returns a copy of a client that removes the headers and token from
+// requests that are redirected to URLs that they aren't sent to.
+func (f *HTTPFetcher) redirectingClient(client *http.Client) *http.Client {
+	redirecting := *client
+	redirecting.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+		if !f.sendsCredentials(request.URL) {
+			for name := range f.Header {
+				request.Header.Del(name)
+			}
+			if f.Token != "" {
+				request.Header.Del("Authorization")
+			}
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(request, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &redirecting
+}
+
+// Returns true if the headers and token are sent in requests for a URL.
+func (f *HTTPFetcher) sendsCredentials(u *url.URL) bool {
+	for _, host := range f.Hosts {
+		scheme := "https"
+		if i := strings.Index(host, "://"); i >= 0 {
+			scheme, host = host[:i], host[i+len("://"):]
+		}
+		if !strings.EqualFold(scheme, u.Scheme) {
+			continue
+		}
+		if strings.EqualFold(host, u.Host) || !strings.Contains(host, ":") && strings.EqualFold(host, u.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
+
+// A ContextFetcher is a Fetcher that stops reading files when a context
+// is done.
+type ContextFetcher interface {
+	Fetcher
+	FetchContext(ctx context.Context, fileurl string) ([]byte, error)
+}
+
+// ReadBytesForFileWithContext reads a file like ReadBytesForFileWithFetcher,
+// but it returns the error of ctx if it is done, and files named with URLs
+// are read with FetchContext if fetcher is a ContextFetcher.
+func ReadBytesForFileWithContext(ctx context.Context, filename string, fetcher Fetcher) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if fetcher, ok := fetcher.(ContextFetcher); ok && IsURL(filename) {
+		return fetcher.FetchContext(ctx, filename)
+	}
+	return ReadBytesForFileWithFetcher(filename, fetcher)
+}
+
+// ReadBytesForFileWithFetcher reads a file like ReadBytesForFile, but files
+// named with URLs are read with a fetcher if it isn't nil. They aren't
+// added to the file cache, and ReadInfoForRefWithFetcher doesn't cache
+// their parsed text, because fetchers may read them differently.
+func ReadBytesForFileWithFetcher(filename string, fetcher Fetcher) ([]byte, error) {
+	if fetcher != nil && IsURL(filename) {
+		return fetcher.Fetch(filename)
+	}
+	return ReadBytesForFile(filename)
+}
@@ -15,13 +15,25 @@
 package compiler
 
 import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
 	"github.com/google/gnostic-models/compiler"
 )
 
 // compiler helper functions, usually called from generated code
 
-// UnpackMap gets a *yaml.Node if possible.
-var UnpackMap = compiler.UnpackMap
+// UnpackMap gets a *yaml.Node if possible. Generated code reads the
+// contents of maps as pairs of keys and values, so sequences can't be
+// unpacked; empty values are unpacked as empty maps.
+func UnpackMap(in *yaml.Node) (*yaml.Node, bool) {
+	if in == nil || in.Kind == yaml.SequenceNode {
+		return nil, false
+	}
+	return in, true
+}
 
 // SortedKeysForMap returns the sorted keys of a yamlv2.MapSlice.
 var SortedKeysForMap = compiler.SortedKeysForMap
@@ -47,12 +59,6 @@ var IntForScalarNode = compiler.IntForScalarNode
 // FloatForScalarNode returns the float value of a node.
 var FloatForScalarNode = compiler.FloatForScalarNode
 
-// StringForScalarNode returns the string value of a node.
-var StringForScalarNode = compiler.StringForScalarNode
-
-// StringArrayForSequenceNode converts a sequence node to an array of strings, if possible.
-var StringArrayForSequenceNode = compiler.StringArrayForSequenceNode
-
 // MissingKeysInMap identifies which keys from a list of required keys are not in a map.
 var MissingKeysInMap = compiler.MissingKeysInMap
 
@@ -68,6 +74,22 @@ var NewMappingNode = compiler.NewMappingNode
 // NewSequenceNode creates a new Sequence node.
 var NewSequenceNode = compiler.NewSequenceNode
 
+// NewMappingNodeWithCapacity creates a new Mapping node with room for n keys and values.
+func NewMappingNodeWithCapacity(n int) *yaml.Node {
+	return &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Content: make([]*yaml.Node, 0, n),
+	}
+}
+
+// NewSequenceNodeWithCapacity creates a new Sequence node with room for n items.
+func NewSequenceNodeWithCapacity(n int) *yaml.Node {
+	return &yaml.Node{
+		Kind:    yaml.SequenceNode,
+		Content: make([]*yaml.Node, 0, n),
+	}
+}
+
 // NewScalarNodeForString creates a new node to hold a string.
 var NewScalarNodeForString = compiler.NewScalarNodeForString
 
@@ -103,3 +125,62 @@ var Display = compiler.Display
 
 // Marshal creates a yaml version of a structure in our preferred style
 var Marshal = compiler.Marshal
+
+// A KeySet is a set of keys that are allowed in a map. Keys can be
+// listed explicitly or matched by patterns. Patterns that only match a
+// literal prefix, like "^x-", are checked without using regexps.
+type KeySet struct {
+	keys     map[string]bool
+	prefixes []string
+	patterns []*regexp.Regexp
+}
+
+// NewKeySet creates a KeySet for a list of allowed keys and patterns.
+func NewKeySet(allowedKeys []string, allowedPatterns []*regexp.Regexp) *KeySet {
+	keySet := &KeySet{keys: make(map[string]bool, len(allowedKeys))}
+	for _, key := range allowedKeys {
+		keySet.keys[key] = true
+	}
+	for _, pattern := range allowedPatterns {
+		expression := pattern.String()
+		if strings.HasPrefix(expression, "^") && regexp.QuoteMeta(expression[1:]) == expression[1:] {
+			keySet.prefixes = append(keySet.prefixes, expression[1:])
+		} else {
+			keySet.patterns = append(keySet.patterns, pattern)
+		}
+	}
+	return keySet
+}
+
+// Contains returns true if a key is in a KeySet.
+func (keySet *KeySet) Contains(key string) bool {
+	if keySet.keys[key] {
+		return true
+	}
+	for _, prefix := range keySet.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	for _, pattern := range keySet.patterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidKeysInMapForKeySet returns keys in a map that aren't in a KeySet.
+func InvalidKeysInMapForKeySet(m *yaml.Node, keySet *KeySet) []string {
+	var invalidKeys []string
+	if m == nil || m.Kind != yaml.MappingNode {
+		return invalidKeys
+	}
+	for i := 0; i < len(m.Content); i += 2 {
+		key := m.Content[i].Value
+		if !keySet.Contains(key) {
+			invalidKeys = append(invalidKeys, key)
+		}
+	}
+	return invalidKeys
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic-models/compiler"
+)
+
+// Large descriptions repeat a small number of short strings, like
+// property names, type names, and references, many thousands of times.
+// The parser has already allocated a copy of each of them for the nodes
+// of a description, so interning doesn't reduce allocation, but the nodes
+// are released once a model has been built from them. Interning these
+// strings when they are copied into the model lets all copies share one
+// allocation, so that the model retains less memory and takes less time
+// to garbage-collect. Strings are interned in a table that the contexts of
+// a compilation share, starting from its root context, or in the arena of
+// the compilation, so the table is released with them.
+
+// maxInternedLength is the length of the longest string that is interned.
+// Longer strings, like descriptions, rarely repeat.
+const maxInternedLength = 64
+
+// StringForScalarNode returns the string value of a node.
+func StringForScalarNode(node *yaml.Node) (string, bool) {
+	return StringForScalarNodeWithContext(node, nil)
+}
+
+// StringForScalarNodeWithContext returns the string value of a node in a
+// compilation. Plain scalars with other types are also read as strings if
+// plain strings are enabled for the compilation, and strings are interned
+// unless context is nil or was created with NewContext without a parent.
+func StringForScalarNodeWithContext(node *yaml.Node, context *Context) (string, bool) {
+	s, ok := compiler.StringForScalarNode(node)
+	if !ok && plainStringsForContext(context) {
+		s, ok = plainStringForScalarNode(node)
+	}
+	if context != nil && context.strings != nil {
+		s = intern(context.strings, s)
+	}
+	return s, ok
+}
+
+// intern returns a string equal to s that shares its storage with the
+// other copies of s that were interned in a table.
+func intern(strings map[string]string, s string) string {
+	if len(s) > maxInternedLength {
+		return s
+	}
+	if interned, ok := strings[s]; ok {
+		return interned
+	}
+	strings[s] = s
+	return s
+}
+
+// StringArrayForSequenceNode converts a sequence node to an array of strings, if possible.
+func StringArrayForSequenceNode(node *yaml.Node) []string {
+	return StringArrayForSequenceNodeWithContext(node, nil)
+}
+
+// StringArrayForSequenceNodeWithContext converts a sequence node in a
+// compilation to an array of strings, if possible, reading plain scalars
+// as strings if plain strings are enabled for the compilation.
+func StringArrayForSequenceNodeWithContext(node *yaml.Node, context *Context) []string {
+	stringArray := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		v, ok := StringForScalarNodeWithContext(item, context)
+		if ok {
+			stringArray = append(stringArray, v)
+		}
+	}
+	return stringArray
+}
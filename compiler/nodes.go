@@ -0,0 +1,173 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Exporting a large model builds millions of nodes, and most of them are
+// scalars for the same few dozen keys. A NodeBuilder allocates nodes and
+// the contents of mappings and sequences in blocks, and it returns one
+// shared node for each key, so exporting a model takes a small fraction
+// of the allocations that it takes to create every node separately.
+//
+// Nodes returned by Key are shared by every mapping that has that key, so
+// they must not be modified. A NodeBuilder can be used by only one
+// goroutine at a time.
+type NodeBuilder struct {
+	nodes     []yaml.Node
+	nodeCount int // the number of nodes allocated so far
+	contents  []*yaml.Node
+	keys      map[string]*yaml.Node
+}
+
+const (
+	// maxNodeBlockSize is the number of nodes in the largest blocks.
+	// Blocks start small and grow with the number of nodes allocated,
+	// so that small descriptions don't allocate unused nodes.
+	maxNodeBlockSize = 1024
+	// maxContentBlockSize is the number of node pointers in the largest
+	// blocks of mapping and sequence contents. Larger contents are
+	// allocated alone.
+	maxContentBlockSize = 4096
+)
+
+// NewNodeBuilder creates a NodeBuilder.
+func NewNodeBuilder() *NodeBuilder {
+	return &NodeBuilder{keys: make(map[string]*yaml.Node)}
+}
+
+func (b *NodeBuilder) node(kind yaml.Kind, tag string, value string) *yaml.Node {
+	if len(b.nodes) == 0 {
+		b.nodes = make([]yaml.Node, blockSize(b.nodeCount, maxNodeBlockSize))
+	}
+	node := &b.nodes[0]
+	b.nodes = b.nodes[1:]
+	b.nodeCount++
+	node.Kind, node.Tag, node.Value = kind, tag, value
+	return node
+}
+
+// content returns an empty slice with room for n nodes. Appending more
+// than n nodes reallocates it instead of overwriting other contents.
+func (b *NodeBuilder) content(n int) []*yaml.Node {
+	if n > maxContentBlockSize/8 {
+		return make([]*yaml.Node, 0, n)
+	}
+	if len(b.contents) < n {
+		b.contents = make([]*yaml.Node, blockSize(4*b.nodeCount, maxContentBlockSize))
+	}
+	content := b.contents[0:0:n]
+	b.contents = b.contents[n:]
+	return content
+}
+
+// blockSize returns the size of a new block when count items have been
+// allocated: the smaller of max and a power of two that is at least count.
+func blockSize(count int, max int) int {
+	size := max / 16
+	for size < count && size < max {
+		size *= 2
+	}
+	return size
+}
+
+// Key returns a shared node that holds a mapping key.
+func (b *NodeBuilder) Key(s string) *yaml.Node {
+	if node, ok := b.keys[s]; ok {
+		return node
+	}
+	node := b.String(s)
+	b.keys[s] = node
+	return node
+}
+
+// Mapping creates a new Mapping node with room for n keys and values.
+func (b *NodeBuilder) Mapping(n int) *yaml.Node {
+	node := b.node(yaml.MappingNode, "", "")
+	node.Content = b.content(n)
+	return node
+}
+
+// Sequence creates a new Sequence node with room for n items.
+func (b *NodeBuilder) Sequence(n int) *yaml.Node {
+	node := b.node(yaml.SequenceNode, "", "")
+	node.Content = b.content(n)
+	return node
+}
+
+// Null creates a new Null node.
+func (b *NodeBuilder) Null() *yaml.Node {
+	return b.node(yaml.ScalarNode, "!!null", "")
+}
+
+// String creates a new node to hold a string.
+func (b *NodeBuilder) String(s string) *yaml.Node {
+	return b.node(yaml.ScalarNode, "!!str", s)
+}
+
+// Bool creates a new node to hold a bool.
+func (b *NodeBuilder) Bool(v bool) *yaml.Node {
+	return b.node(yaml.ScalarNode, "!!bool", strconv.FormatBool(v))
+}
+
+// Int creates a new node to hold an integer.
+func (b *NodeBuilder) Int(i int64) *yaml.Node {
+	return b.node(yaml.ScalarNode, "!!int", strconv.FormatInt(i, 10))
+}
+
+// Float creates a new node to hold a float.
+func (b *NodeBuilder) Float(f float64) *yaml.Node {
+	return b.node(yaml.ScalarNode, "!!float", strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// StringArray creates a new node to hold an array of strings.
+func (b *NodeBuilder) StringArray(values []string) *yaml.Node {
+	node := b.Sequence(len(values))
+	for _, s := range values {
+		node.Content = append(node.Content, b.String(s))
+	}
+	return node
+}
+
+// BoolArray creates a new node to hold an array of bools.
+func (b *NodeBuilder) BoolArray(values []bool) *yaml.Node {
+	node := b.Sequence(len(values))
+	for _, v := range values {
+		node.Content = append(node.Content, b.Bool(v))
+	}
+	return node
+}
+
+// IntArray creates a new node to hold an array of integers.
+func (b *NodeBuilder) IntArray(values []int64) *yaml.Node {
+	node := b.Sequence(len(values))
+	for _, i := range values {
+		node.Content = append(node.Content, b.Int(i))
+	}
+	return node
+}
+
+// FloatArray creates a new node to hold an array of floats.
+func (b *NodeBuilder) FloatArray(values []float64) *yaml.Node {
+	node := b.Sequence(len(values))
+	for _, f := range values {
+		node.Content = append(node.Content, b.Float(f))
+	}
+	return node
+}
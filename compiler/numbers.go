@@ -0,0 +1,151 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"math/big"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Numbers in descriptions are compiled into float64 fields, which can't
+// exactly represent large integers like the bounds of int64 or decimals
+// with many digits. When the text of a number has a value that its float
+// would be written without, the text is recorded in the annotations of
+// the model with the location of the number, so that the number can be
+// written as it was read.
+
+// RecordNumbers records the text of the numbers in the node that a model
+// was compiled from if their values can't be written from their floats.
+func (a *Annotations) RecordNumbers(node *yaml.Node) {
+	a.Numbers = findNumbers(node, "", nil)
+}
+
+// Appends the numbers in a node that can't be written from their floats
+// to a list.
+func findNumbers(node *yaml.Node, pointer string, numbers []Annotation) []Annotation {
+	if node == nil {
+		return numbers
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			numbers = findNumbers(node.Content[0], pointer, numbers)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			numbers = findNumbers(node.Content[i+1], pointer+"/"+escapePointer(node.Content[i].Value), numbers)
+		}
+	case yaml.SequenceNode:
+		for i, value := range node.Content {
+			numbers = findNumbers(value, pointer+"/"+strconv.Itoa(i), numbers)
+		}
+	case yaml.ScalarNode:
+		if node.Tag != "!!int" && node.Tag != "!!float" {
+			break
+		}
+		v, err := strconv.ParseFloat(node.Value, 64)
+		if err != nil {
+			break
+		}
+		formatted := strconv.FormatFloat(v, 'g', -1, 64)
+		if formatted != node.Value && !sameNumber(formatted, node.Value) {
+			numbers = append(numbers, Annotation{Pointer: pointer, Tag: node.Tag, Value: node.Value})
+		}
+	}
+	return numbers
+}
+
+// Returns true if two numbers written as text have the same value.
+func sameNumber(a, b string) bool {
+	x, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return false
+	}
+	y, ok := new(big.Rat).SetString(b)
+	return ok && x.Cmp(y) == 0
+}
+
+// Returns true if two numbers written as text have the same float value.
+func sameFloat(a, b string) bool {
+	x, err := strconv.ParseFloat(a, 64)
+	if err != nil {
+		return false
+	}
+	y, err := strconv.ParseFloat(b, 64)
+	return err == nil && x == y
+}
+
+// RestoreNumbers rewrites the floats in a node created from a compiled
+// model with the text that they were compiled from, if it was recorded,
+// and tags floats with integer values as integers, so that they are
+// written the way they were read instead of as tagged floats like
+// "!!float 100". Texts are only written at the locations where they were
+// read, and only if the floats there still have their values.
+func (a *Annotations) RestoreNumbers(node *yaml.Node) {
+	texts := make(map[string]string)
+	if a != nil {
+		for _, number := range a.Numbers {
+			texts[number.Pointer] = number.Value
+		}
+	}
+	restoreNumbers(documentContent(node), "", texts)
+}
+
+// Restores the numbers in a node at a JSON pointer. Pointers are only
+// built if there are texts to restore.
+func restoreNumbers(node *yaml.Node, pointer string, texts map[string]string) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag != "!!float" {
+			return
+		}
+		if text, ok := texts[pointer]; ok && sameFloat(text, node.Value) {
+			node.Value = text
+		}
+		if isInteger(node.Value) {
+			node.Tag = "!!int"
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			child := pointer
+			if len(texts) > 0 {
+				child += "/" + escapePointer(node.Content[i].Value)
+			}
+			restoreNumbers(node.Content[i+1], child, texts)
+		}
+	case yaml.SequenceNode:
+		for i, value := range node.Content {
+			child := pointer
+			if len(texts) > 0 {
+				child += "/" + strconv.Itoa(i)
+			}
+			restoreNumbers(value, child, texts)
+		}
+	}
+}
+
+// Returns true if a number is an integer that YAML parsers read as one.
+func isInteger(s string) bool {
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return true
+	}
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// IsWindowsPath returns true if name is a Windows file path that begins
+// with a drive letter, like C:\api.yaml, or is a UNC path, like
+// \\server\share\api.yaml. Because a drive letter looks like a URL
+// scheme, these are otherwise mistaken for URLs.
+func IsWindowsPath(name string) bool {
+	return volumeLength(name) > 0
+}
+
+// IsURL returns true if name is a URL rather than the name of a file.
+func IsURL(name string) bool {
+	if IsWindowsPath(name) {
+		return false
+	}
+	u, err := url.Parse(name)
+	return err == nil && u.Scheme != ""
+}
+
+// ReferencedFileName returns the name of the file that is referred to by
+// file from referrer, which may be a file name or a URL. Names of files
+// are returned with forward slashes, which are accepted on all platforms,
+// so references written with backslashes or mixed separators refer to
+// the same files everywhere.
+func ReferencedFileName(referrer, file string) string {
+	if IsURL(file) {
+		return file
+	}
+	file = toSlash(file)
+	if IsURL(referrer) {
+		if base, err := url.Parse(referrer); err == nil {
+			if u, err := url.Parse(file); err == nil {
+				return base.ResolveReference(u).String()
+			}
+		}
+	}
+	if strings.HasPrefix(file, "/") || IsWindowsPath(file) {
+		return file
+	}
+	n := volumeLength(referrer)
+	volume, dir := toSlash(referrer[:n]), path.Dir(toSlash(referrer[n:]))
+	return volume + path.Join(dir, file)
+}
+
+// Returns the length of the drive letter or UNC prefix of a Windows path.
+func volumeLength(name string) int {
+	if len(name) >= 2 && name[1] == ':' && isLetter(name[0]) {
+		return 2
+	}
+	if len(name) < 5 || name[0] != '\\' || !isSeparator(name[1]) || isSeparator(name[2]) {
+		return 0
+	}
+	// A UNC path begins with \\server\share.
+	n := 3
+	for n < len(name) && !isSeparator(name[n]) {
+		n++
+	}
+	if n+1 >= len(name) || isSeparator(name[n+1]) {
+		return 0
+	}
+	n++
+	for n < len(name) && !isSeparator(name[n]) {
+		n++
+	}
+	return n
+}
+
+// Returns a file name with each backslash replaced by a forward slash.
+// Unlike filepath.ToSlash, this is done on all platforms.
+func toSlash(name string) string {
+	return strings.Replace(name, `\`, "/", -1)
+}
+
+func isSeparator(c byte) bool {
+	return c == '/' || c == '\\'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
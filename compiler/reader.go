@@ -15,47 +15,234 @@
 package compiler
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
 	"github.com/google/gnostic-models/compiler"
 )
 
-// EnableFileCache turns on file caching.
-var EnableFileCache = compiler.EnableFileCache
-
-// EnableInfoCache turns on parsed info caching.
-var EnableInfoCache = compiler.EnableInfoCache
+// Files and parsed files are cached in Caches, which are bounded and safe
+// for concurrent use. The functions that read documents in the compiler
+// package of gnostic-models, which are used by the generated
+// ResolveReferences methods, have their own caches, so the functions that
+// enable, disable, and clear caches apply to both.
+
+// EnableFileCache turns on file caching with a cache of the default size,
+// unless it is already on.
+func EnableFileCache() {
+	compiler.EnableFileCache()
+	if currentFileCache() == nil {
+		SetFileCache(NewLRUCache(DefaultCacheSize))
+	}
+}
+
+// EnableInfoCache turns on parsed info caching with a cache of the default
+// size, unless it is already on.
+func EnableInfoCache() {
+	compiler.EnableInfoCache()
+	if currentInfoCache() == nil {
+		SetInfoCache(NewLRUCache(DefaultCacheSize))
+	}
+}
 
 // DisableFileCache turns off file caching.
-var DisableFileCache = compiler.DisableFileCache
+func DisableFileCache() {
+	compiler.DisableFileCache()
+	SetFileCache(nil)
+}
 
 // DisableInfoCache turns off parsed info caching.
-var DisableInfoCache = compiler.DisableInfoCache
+func DisableInfoCache() {
+	compiler.DisableInfoCache()
+	SetInfoCache(nil)
+}
 
 // RemoveFromFileCache removes an entry from the file cache.
-var RemoveFromFileCache = compiler.RemoveFromFileCache
+func RemoveFromFileCache(fileurl string) {
+	compiler.RemoveFromFileCache(fileurl)
+	if cache := currentFileCache(); cache != nil {
+		cache.Remove(fileurl)
+	}
+}
 
 // RemoveFromInfoCache removes an entry from the info cache.
-var RemoveFromInfoCache = compiler.RemoveFromInfoCache
-
-// GetInfoCache returns the info cache map.
-var GetInfoCache = compiler.GetInfoCache
+func RemoveFromInfoCache(filename string) {
+	compiler.RemoveFromInfoCache(filename)
+	if cache := currentInfoCache(); cache != nil {
+		cache.Remove(filename)
+	}
+}
+
+// GetInfoCache returns a copy of the contents of the info caches.
+func GetInfoCache() map[string]*yaml.Node {
+	result := make(map[string]*yaml.Node)
+	for key, value := range compiler.GetInfoCache() {
+		result[key] = value
+	}
+	if cache := currentInfoCache(); cache != nil {
+		for _, key := range cache.Keys() {
+			if value, ok := cache.Get(key); ok {
+				result[key] = value.(*parsedFile).info
+			}
+		}
+	}
+	return result
+}
 
 // ClearFileCache clears the file cache.
-var ClearFileCache = compiler.ClearFileCache
+func ClearFileCache() {
+	compiler.ClearFileCache()
+	if cache := currentFileCache(); cache != nil {
+		cache.Clear()
+	}
+}
 
 // ClearInfoCache clears the info cache.
-var ClearInfoCache = compiler.ClearInfoCache
+func ClearInfoCache() {
+	compiler.ClearInfoCache()
+	if cache := currentInfoCache(); cache != nil {
+		cache.Clear()
+	}
+}
 
 // ClearCaches clears all caches.
-var ClearCaches = compiler.ClearCaches
-
-// FetchFile gets a specified file from the local filesystem or a remote location.
-var FetchFile = compiler.FetchFile
-
-// ReadBytesForFile reads the bytes of a file.
-var ReadBytesForFile = compiler.ReadBytesForFile
-
-// ReadInfoFromBytes unmarshals a file as a *yaml.Node.
-var ReadInfoFromBytes = compiler.ReadInfoFromBytes
+func ClearCaches() {
+	ClearFileCache()
+	ClearInfoCache()
+}
+
+// FetchFile gets a specified file from a remote location. Files are cached
+// by URL unless file caching is turned off.
+func FetchFile(fileurl string) ([]byte, error) {
+	cache := currentFileCache()
+	if cache != nil {
+		if bytes, ok := cache.Get(fileurl); ok {
+			return bytes.([]byte), nil
+		}
+	}
+	response, err := http.Get(fileurl)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != 200 {
+		return nil, fmt.Errorf("Error downloading %s: %s", fileurl, response.Status)
+	}
+	bytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache.Add(fileurl, bytes)
+	}
+	return bytes, nil
+}
+
+// ReadBytesForFile reads the bytes of a file, which may be named with a URL.
+func ReadBytesForFile(filename string) ([]byte, error) {
+	if IsURL(filename) {
+		return FetchFile(filename)
+	}
+	// Windows paths are local files, although they look like URLs.
+	return ioutil.ReadFile(filename)
+}
+
+// ReadInfoFromBytes unmarshals a file as a *yaml.Node. Files may be
+// encoded in UTF-8 or UTF-16 and may begin with a byte-order mark. Parsed
+// files are cached by name unless parsed info caching is turned off or the
+// name is empty. A cached file is only reused if it was parsed from the
+// same bytes, so files that change, or that are compiled from the contents
+// that programs pass, are parsed again.
+func ReadInfoFromBytes(filename string, bytes []byte) (*yaml.Node, error) {
+	cache := currentInfoCache()
+	var sum [sha256.Size]byte
+	if cache != nil && filename != "" {
+		sum = sha256.Sum256(bytes)
+		if value, ok := cache.Get(filename); ok && value.(*parsedFile).sum == sum {
+			return value.(*parsedFile).info, nil
+		}
+	}
+	bytes, err := DecodeText(bytes)
+	if err != nil {
+		return nil, err
+	}
+	var info yaml.Node
+	if err := yaml.Unmarshal(bytes, &info); err != nil {
+		return nil, err
+	}
+	if cache != nil && filename != "" {
+		cache.Add(filename, &parsedFile{sum: sum, info: &info})
+	}
+	return &info, nil
+}
+
+// A parsedFile is a parsed file in the info cache, along with the hash of
+// the bytes that it was parsed from.
+type parsedFile struct {
+	sum  [sha256.Size]byte
+	info *yaml.Node
+}
 
 // ReadInfoForRef reads a file and return the fragment needed to resolve a $ref.
-var ReadInfoForRef = compiler.ReadInfoForRef
+// Files are named relative to basefile, and either may be named with a
+// Windows path.
+func ReadInfoForRef(basefile string, ref string) (*yaml.Node, error) {
+	return ReadInfoForRefWithFetcher(basefile, ref, nil)
+}
+
+// ReadInfoForRefWithFetcher reads the fragment needed to resolve a $ref
+// like ReadInfoForRef, reading files named with URLs with a fetcher if it
+// isn't nil.
+func ReadInfoForRefWithFetcher(basefile string, ref string, fetcher Fetcher) (*yaml.Node, error) {
+	return ReadInfoForRefWithContext(basefile, ref, fetcher, nil)
+}
+
+// ReadInfoForRefWithContext reads the fragment needed to resolve a $ref
+// like ReadInfoForRefWithFetcher, reading files named with URLs with the
+// fetcher of context if fetcher is nil. If context, the context of the
+// $ref, isn't nil, errors are located at the $ref so that they are
+// reported with its line and column.
+func ReadInfoForRefWithContext(basefile string, ref string, fetcher Fetcher, context *Context) (*yaml.Node, error) {
+	if fetcher == nil && context != nil {
+		fetcher = context.Fetcher
+	}
+	parts := strings.SplitN(ref, "#", 2)
+	filename := basefile
+	if parts[0] != "" {
+		filename = ReferencedFileName(basefile, parts[0])
+	}
+	bytes, err := ReadBytesForFileWithFetcher(filename, fetcher)
+	if err != nil {
+		return nil, LocateErrors(err, context)
+	}
+	// Files that are read with fetchers aren't cached.
+	cacheName := filename
+	if fetcher != nil && IsURL(filename) {
+		cacheName = ""
+	}
+	info, err := ReadInfoFromBytes(cacheName, bytes)
+	if err != nil {
+		return nil, LocateErrors(err, context)
+	}
+	if info.Kind == yaml.DocumentNode && len(info.Content) > 0 {
+		info = info.Content[0]
+	}
+	if len(parts) > 1 {
+		for i, key := range strings.Split(parts[1], "/") {
+			if i == 0 {
+				continue
+			}
+			value := MapValueForKey(info, key)
+			if value == nil {
+				return nil, NewError(context, fmt.Sprintf("could not resolve %s", ref))
+			}
+			info = value
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML resolves the tags of plain scalars, values written without quotes,
+// from their text, so a version written as 1.0 is a float and a title
+// written as true is a boolean. Compilers reject these where strings are
+// expected unless plain strings are enabled for the compilation, which
+// reads them as the text that they were written with. Scalars that are
+// explicitly tagged are read with their tags.
+
+// The root contexts of the compilations that read plain strings.
+var plainStringRoots sync.Map // map[*Context]bool
+var plainStringRootCount int32
+
+// EnablePlainStrings reads plain scalars that YAML resolves as numbers,
+// booleans, or timestamps as strings in positions where strings are
+// expected, in the compilation with a root context. Each call should be
+// matched by a call to DisablePlainStrings when the compilation is done.
+func EnablePlainStrings(root *Context) {
+	if _, loaded := plainStringRoots.LoadOrStore(root, true); !loaded {
+		atomic.AddInt32(&plainStringRootCount, 1)
+	}
+}
+
+// DisablePlainStrings undoes a call to EnablePlainStrings.
+func DisablePlainStrings(root *Context) {
+	if _, ok := plainStringRoots.Load(root); ok {
+		plainStringRoots.Delete(root)
+		atomic.AddInt32(&plainStringRootCount, -1)
+	}
+}
+
+// Returns true if plain strings are enabled for the compilation of a
+// context.
+func plainStringsForContext(context *Context) bool {
+	if context == nil || atomic.LoadInt32(&plainStringRootCount) == 0 {
+		return false
+	}
+	for context.Parent != nil {
+		context = context.Parent
+	}
+	_, ok := plainStringRoots.Load(context)
+	return ok
+}
+
+// Returns the text of a plain scalar.
+func plainStringForScalarNode(node *yaml.Node) (string, bool) {
+	if node == nil {
+		return "", false
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.ScalarNode || node.Style != 0 {
+		return "", false
+	}
+	switch node.Tag {
+	case "!!float", "!!bool", "!!timestamp":
+		return node.Value, true
+	}
+	return "", false
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"gopkg.in/yaml.v3"
+)
+
+// Keys that are written differently can look the same or even be equal
+// once they are normalized, so that generated code and clients that
+// compare keys byte by byte treat names that look the same as different
+// names. NormalizeKeys converts the keys in a description to Unicode
+// Normalization Form C, and CheckKeys reports keys that mix scripts or
+// that can be confused with other keys in the same mapping.
+
+// The values of these keys are data, so their keys aren't names.
+var dataKeys = map[string]bool{
+	"example":  true,
+	"examples": true,
+	"enum":     true,
+	"const":    true,
+	"default":  true,
+}
+
+// Returns true if the keys of the value of a key are data.
+func isDataKey(key, parent string) bool {
+	if strings.HasPrefix(key, "x-") {
+		return true
+	}
+	// default is also the name of the catch-all response.
+	return dataKeys[key] && !(key == "default" && parent == "responses")
+}
+
+// NormalizeKeys converts the mapping keys in a node to Unicode
+// Normalization Form C. Keys whose normalized forms are the same as
+// other keys in the same mapping are reported as errors.
+func NormalizeKeys(node *yaml.Node, context *Context) error {
+	errors := make([]error, 0)
+	walkKeys(node, "", context, func(mapping *yaml.Node, i int, context *Context) {
+		key := mapping.Content[i]
+		if !norm.NFC.IsNormalString(key.Value) {
+			key.Value = norm.NFC.String(key.Value)
+		}
+		for j := 0; j < i; j += 2 {
+			if mapping.Content[j].Value == key.Value {
+				errors = append(errors, NewError(context, "is the same as another key after normalization"))
+				break
+			}
+		}
+	})
+	return NewErrorGroupOrNil(errors)
+}
+
+// CheckKeys reports mapping keys in a node that mix letters from
+// scripts that aren't used together, like Latin and Cyrillic, and keys
+// that can be confused with other keys in the same mapping.
+func CheckKeys(node *yaml.Node, context *Context) error {
+	errors := make([]error, 0)
+	walkKeys(node, "", context, func(mapping *yaml.Node, i int, context *Context) {
+		key := mapping.Content[i].Value
+		if scripts := mixedScripts(key); scripts != nil {
+			errors = append(errors, NewError(context, fmt.Sprintf("mixes %s scripts", strings.Join(scripts, " and "))))
+		}
+		s := skeleton(key)
+		for j := 0; j < i; j += 2 {
+			if other := mapping.Content[j].Value; other != key && skeleton(other) == s {
+				errors = append(errors, NewError(context, fmt.Sprintf("can be confused with %s", other)))
+				break
+			}
+		}
+	})
+	return NewErrorGroupOrNil(errors)
+}
+
+// Calls a function with each key of the mappings in a node that isn't in
+// data, along with the mapping, the index of the key, and its context.
+func walkKeys(node *yaml.Node, parent string, context *Context, f func(*yaml.Node, int, *Context)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			walkKeys(child, parent, context, f)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			keyContext := NewContext(key.Value, key, context)
+			f(node, i, keyContext)
+			if !isDataKey(key.Value, parent) {
+				walkKeys(node.Content[i+1], key.Value, keyContext, f)
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			walkKeys(child, parent, NewContext(fmt.Sprintf("%d", i), child, context), f)
+		}
+	}
+}
+
+// Sets of scripts that are used together, from the highly restrictive
+// profile of Unicode Technical Standard #39.
+var scriptSets = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// Returns the scripts of the letters in a string if they aren't used
+// together, and nil if they are.
+func mixedScripts(s string) []string {
+	found := make(map[string]bool)
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			found[scriptOf(r)] = true
+		}
+	}
+	delete(found, "")
+	if len(found) < 2 {
+		return nil
+	}
+	for _, set := range scriptSets {
+		included := 0
+		for _, script := range set {
+			if found[script] {
+				included++
+			}
+		}
+		if included == len(found) {
+			return nil
+		}
+	}
+	scripts := make([]string, 0, len(found))
+	for script := range found {
+		scripts = append(scripts, script)
+	}
+	sort.Strings(scripts)
+	return scripts
+}
+
+// Returns the name of the script of a letter. Common scripts are checked
+// first.
+func scriptOf(r rune) string {
+	for _, name := range []string{"Latin", "Greek", "Cyrillic", "Armenian", "Hebrew", "Arabic", "Han", "Hiragana", "Katakana", "Hangul", "Bopomofo"} {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	for name, table := range unicode.Scripts {
+		if name != "Common" && name != "Inherited" && unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// Letters that look like Latin letters.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x', 'і': 'i',
+	'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'һ': 'h',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P',
+	'С': 'C', 'Т': 'T', 'Х': 'X', 'І': 'I', 'Ј': 'J', 'Ѕ': 'S',
+	// Greek
+	'α': 'a', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'υ': 'u',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M',
+	'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	// Latin
+	'ı': 'i', 'ɡ': 'g',
+}
+
+// Returns a string with the letters that look like Latin letters replaced
+// by them and with invisible characters removed, so that strings that look
+// the same have the same skeletons.
+func skeleton(s string) string {
+	return strings.Map(func(r rune) rune {
+		if c, ok := confusables[r]; ok {
+			return c
+		}
+		if unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r) || unicode.In(r, unicode.Cf) {
+			return -1
+		}
+		return r
+	}, norm.NFKC.String(s))
+}
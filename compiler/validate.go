@@ -0,0 +1,50 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// templatePattern matches the templated parts of paths, like {id}.
+var templatePattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// CheckEquivalentPaths returns an error for each of the paths of a
+// document that is the same as an earlier path except for the names of
+// their templated parts, like /pets/{id} and /pets/{petId}. Servers can't
+// distinguish these when they route requests to them.
+//
+// The context is the context that the document was compiled with. Errors
+// are reported at the locations of its nodes, or without locations if it
+// is nil.
+func CheckEquivalentPaths(paths []string, context *Context) []error {
+	errors := make([]error, 0)
+	if context == nil {
+		context = NewContext("$root", nil, nil)
+	}
+	pathsContext := NewContext("paths", MapValueForKey(context.Node, "paths"), context)
+	templates := make(map[string]string)
+	for _, path := range paths {
+		template := templatePattern.ReplaceAllString(path, "{}")
+		if previous, ok := templates[template]; ok {
+			pathContext := NewContext(path, MapValueForKey(pathsContext.Node, path), pathsContext)
+			errors = append(errors, NewError(pathContext, fmt.Sprintf("is equivalent to %s", previous)))
+			continue
+		}
+		templates[template] = path
+	}
+	return errors
+}
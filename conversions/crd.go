@@ -0,0 +1,329 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// CustomResourceDefinition describes the resources that a Kubernetes
+// CustomResourceDefinition adds to a cluster.
+type CustomResourceDefinition struct {
+	Name       string
+	Group      string
+	Kind       string
+	ListKind   string
+	Plural     string
+	Namespaced bool
+	Versions   []*CustomResourceVersion
+}
+
+// CustomResourceVersion describes one version of a custom resource.
+type CustomResourceVersion struct {
+	Name    string
+	Served  bool
+	Storage bool
+	Schema  *yaml.Node // the structural openAPIV3Schema of the version
+}
+
+// ReadCustomResourceDefinitions reads the CustomResourceDefinitions in a
+// stream of YAML manifests. Other manifests are skipped.
+func ReadCustomResourceDefinitions(manifests []byte) ([]*CustomResourceDefinition, error) {
+	crds := make([]*CustomResourceDefinition, 0)
+	decoder := yaml.NewDecoder(bytes.NewReader(manifests))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			return crds, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(document.Content) == 0 {
+			continue
+		}
+		manifest := document.Content[0]
+		if stringForKey(manifest, "kind") != "CustomResourceDefinition" ||
+			!strings.HasPrefix(stringForKey(manifest, "apiVersion"), "apiextensions.k8s.io/") {
+			continue
+		}
+		crd, err := newCustomResourceDefinition(manifest)
+		if err != nil {
+			return nil, err
+		}
+		crds = append(crds, crd)
+	}
+}
+
+func newCustomResourceDefinition(manifest *yaml.Node) (*CustomResourceDefinition, error) {
+	spec := compiler.MapValueForKey(manifest, "spec")
+	names := compiler.MapValueForKey(spec, "names")
+	crd := &CustomResourceDefinition{
+		Name:       stringForKey(compiler.MapValueForKey(manifest, "metadata"), "name"),
+		Group:      stringForKey(spec, "group"),
+		Kind:       stringForKey(names, "kind"),
+		ListKind:   stringForKey(names, "listKind"),
+		Plural:     stringForKey(names, "plural"),
+		Namespaced: stringForKey(spec, "scope") != "Cluster",
+	}
+	if crd.Group == "" || crd.Kind == "" || crd.Plural == "" {
+		return nil, fmt.Errorf("%s: a CustomResourceDefinition must have a group, kind, and plural name", crd.Name)
+	}
+	if crd.ListKind == "" {
+		crd.ListKind = crd.Kind + "List"
+	}
+	// apiextensions.k8s.io/v1beta1 definitions can share one schema between versions.
+	sharedSchema := compiler.MapValueForKey(compiler.MapValueForKey(spec, "validation"), "openAPIV3Schema")
+	if versions := compiler.MapValueForKey(spec, "versions"); versions != nil {
+		for _, version := range versions.Content {
+			served, ok := compiler.BoolForScalarNode(compiler.MapValueForKey(version, "served"))
+			storage, _ := compiler.BoolForScalarNode(compiler.MapValueForKey(version, "storage"))
+			schema := compiler.MapValueForKey(compiler.MapValueForKey(version, "schema"), "openAPIV3Schema")
+			if schema == nil {
+				schema = sharedSchema
+			}
+			crd.Versions = append(crd.Versions, &CustomResourceVersion{
+				Name:    stringForKey(version, "name"),
+				Served:  served || !ok,
+				Storage: storage,
+				Schema:  schema,
+			})
+		}
+	} else if version := stringForKey(spec, "version"); version != "" {
+		crd.Versions = append(crd.Versions, &CustomResourceVersion{
+			Name:    version,
+			Served:  true,
+			Storage: true,
+			Schema:  sharedSchema,
+		})
+	}
+	if len(crd.Versions) == 0 {
+		return nil, fmt.Errorf("%s: a CustomResourceDefinition must have at least one version", crd.Name)
+	}
+	return crd, nil
+}
+
+// Returns the string value of a key in a mapping node.
+func stringForKey(node *yaml.Node, key string) string {
+	value, _ := compiler.StringForScalarNode(compiler.MapValueForKey(node, key))
+	return value
+}
+
+// OpenAPIv3ForCustomResourceDefinition returns an OpenAPI v3 description of
+// the served versions of a custom resource. It includes the schemas of the
+// resource and its lists and the paths to list, get, and patch resources.
+func OpenAPIv3ForCustomResourceDefinition(crd *CustomResourceDefinition) (*openapi3.Document, error) {
+	d := &openapi3.Document{}
+	d.Openapi = "3.0.0"
+	d.Info = &openapi3.Info{
+		Title:   crd.Name,
+		Version: crd.storageVersion(),
+	}
+	d.Components = &openapi3.Components{}
+	d.Components.Schemas = &openapi3.SchemasOrReferences{}
+	d.Paths = &openapi3.Paths{}
+	for _, version := range crd.Versions {
+		if !version.Served {
+			continue
+		}
+		if version.Schema == nil {
+			return nil, fmt.Errorf("%s: version %s has no schema", crd.Name, version.Name)
+		}
+		schema, err := openapi3.NewSchemaOrReference(version.Schema, compiler.NewContext("$root", version.Schema, nil))
+		if err != nil {
+			return nil, err
+		}
+		schemaName := crd.schemaName(version, crd.Kind)
+		listSchemaName := crd.schemaName(version, crd.ListKind)
+		d.Components.Schemas.AdditionalProperties = append(d.Components.Schemas.AdditionalProperties,
+			&openapi3.NamedSchemaOrReference{Name: schemaName, Value: schema},
+			&openapi3.NamedSchemaOrReference{Name: listSchemaName, Value: listSchemaForSchemaName(schemaName)},
+		)
+		addOpenAPI3PathsForCustomResourceVersion(d, crd, version, schemaName, listSchemaName)
+	}
+	if len(d.Paths.Path) == 0 {
+		return nil, errors.New(crd.Name + ": no versions are served")
+	}
+	return d, nil
+}
+
+// Returns the name of the storage version, or of the first version if none is marked.
+func (crd *CustomResourceDefinition) storageVersion() string {
+	for _, version := range crd.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return crd.Versions[0].Name
+}
+
+// Returns the schema name of a kind, qualified like the names of Kubernetes schemas.
+func (crd *CustomResourceDefinition) schemaName(version *CustomResourceVersion, kind string) string {
+	return crd.Group + "." + version.Name + "." + kind
+}
+
+func schemaReference(schemaName string) *openapi3.SchemaOrReference {
+	return &openapi3.SchemaOrReference{
+		Oneof: &openapi3.SchemaOrReference_Reference{
+			Reference: &openapi3.Reference{XRef: "#/components/schemas/" + schemaName},
+		},
+	}
+}
+
+func stringSchema() *openapi3.SchemaOrReference {
+	return &openapi3.SchemaOrReference{
+		Oneof: &openapi3.SchemaOrReference_Schema{Schema: &openapi3.Schema{Type: "string"}},
+	}
+}
+
+func listSchemaForSchemaName(schemaName string) *openapi3.SchemaOrReference {
+	return &openapi3.SchemaOrReference{
+		Oneof: &openapi3.SchemaOrReference_Schema{
+			Schema: &openapi3.Schema{
+				Type:     "object",
+				Required: []string{"items"},
+				Properties: &openapi3.Properties{
+					AdditionalProperties: []*openapi3.NamedSchemaOrReference{
+						{Name: "apiVersion", Value: stringSchema()},
+						{Name: "kind", Value: stringSchema()},
+						{Name: "metadata", Value: &openapi3.SchemaOrReference{
+							Oneof: &openapi3.SchemaOrReference_Schema{Schema: &openapi3.Schema{Type: "object"}},
+						}},
+						{Name: "items", Value: &openapi3.SchemaOrReference{
+							Oneof: &openapi3.SchemaOrReference_Schema{
+								Schema: &openapi3.Schema{
+									Type:  "array",
+									Items: &openapi3.ItemsItem{SchemaOrReference: []*openapi3.SchemaOrReference{schemaReference(schemaName)}},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func pathParameter(name string) *openapi3.ParameterOrReference {
+	return &openapi3.ParameterOrReference{
+		Oneof: &openapi3.ParameterOrReference_Parameter{
+			Parameter: &openapi3.Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   stringSchema(),
+			},
+		},
+	}
+}
+
+func mediaTypes(schemaName string, names ...string) *openapi3.MediaTypes {
+	mediaTypes := &openapi3.MediaTypes{}
+	for _, name := range names {
+		mediaTypes.AdditionalProperties = append(mediaTypes.AdditionalProperties, &openapi3.NamedMediaType{
+			Name:  name,
+			Value: &openapi3.MediaType{Schema: schemaReference(schemaName)},
+		})
+	}
+	return mediaTypes
+}
+
+func okResponses(schemaName string) *openapi3.Responses {
+	return &openapi3.Responses{
+		ResponseOrReference: []*openapi3.NamedResponseOrReference{
+			{
+				Name: "200",
+				Value: &openapi3.ResponseOrReference{
+					Oneof: &openapi3.ResponseOrReference_Response{
+						Response: &openapi3.Response{
+							Description: "OK",
+							Content:     mediaTypes(schemaName, "application/json"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func addOpenAPI3PathsForCustomResourceVersion(d *openapi3.Document, crd *CustomResourceDefinition, version *CustomResourceVersion, schemaName, listSchemaName string) {
+	operationSuffix := strings.Title(version.Name) + crd.Kind
+	base := "/apis/" + crd.Group + "/" + version.Name
+	collectionPath := base + "/" + crd.Plural
+	parameters := []*openapi3.ParameterOrReference{}
+	if crd.Namespaced {
+		d.Paths.Path = append(d.Paths.Path, &openapi3.NamedPathItem{
+			Name: collectionPath,
+			Value: &openapi3.PathItem{
+				Get: &openapi3.Operation{
+					OperationId: "list" + operationSuffix + "ForAllNamespaces",
+					Description: "list objects of kind " + crd.Kind + " in all namespaces",
+					Responses:   okResponses(listSchemaName),
+				},
+			},
+		})
+		collectionPath = base + "/namespaces/{namespace}/" + crd.Plural
+		parameters = append(parameters, pathParameter("namespace"))
+	}
+	d.Paths.Path = append(d.Paths.Path,
+		&openapi3.NamedPathItem{
+			Name: collectionPath,
+			Value: &openapi3.PathItem{
+				Parameters: parameters,
+				Get: &openapi3.Operation{
+					OperationId: "list" + operationSuffix,
+					Description: "list objects of kind " + crd.Kind,
+					Responses:   okResponses(listSchemaName),
+				},
+			},
+		},
+		&openapi3.NamedPathItem{
+			Name: collectionPath + "/{name}",
+			Value: &openapi3.PathItem{
+				Parameters: append(parameters, pathParameter("name")),
+				Get: &openapi3.Operation{
+					OperationId: "get" + operationSuffix,
+					Description: "read the specified " + crd.Kind,
+					Responses:   okResponses(schemaName),
+				},
+				Patch: &openapi3.Operation{
+					OperationId: "patch" + operationSuffix,
+					Description: "partially update the specified " + crd.Kind,
+					RequestBody: &openapi3.RequestBodyOrReference{
+						Oneof: &openapi3.RequestBodyOrReference_RequestBody{
+							RequestBody: &openapi3.RequestBody{
+								Required: true,
+								Content: mediaTypes(schemaName,
+									"application/merge-patch+json",
+									"application/apply-patch+yaml"),
+							},
+						},
+					},
+					Responses: okResponses(schemaName),
+				},
+			},
+		},
+	)
+}
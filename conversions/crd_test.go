@@ -0,0 +1,92 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"io/ioutil"
+	"testing"
+
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+func TestOpenAPIv3ForCustomResourceDefinition(t *testing.T) {
+	manifests, err := ioutil.ReadFile("../testdata/crd/widgets.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	crds, err := ReadCustomResourceDefinitions(manifests)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(crds) != 2 {
+		t.Fatalf("expected 2 definitions, got %d", len(crds))
+	}
+	widgets, gadgets := crds[0], crds[1]
+	if widgets.Name != "widgets.example.com" || !widgets.Namespaced || len(widgets.Versions) != 2 {
+		t.Errorf("unexpected definition %+v", widgets)
+	}
+	if gadgets.ListKind != "GadgetList" || gadgets.Namespaced {
+		t.Errorf("unexpected definition %+v", gadgets)
+	}
+
+	d, err := OpenAPIv3ForCustomResourceDefinition(widgets)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if d.Info.Version != "v1" {
+		t.Errorf("unexpected version %s", d.Info.Version)
+	}
+	paths := []string{}
+	for _, path := range d.Paths.Path {
+		paths = append(paths, path.Name)
+	}
+	expectedPaths := []string{
+		"/apis/example.com/v1/widgets",
+		"/apis/example.com/v1/namespaces/{namespace}/widgets",
+		"/apis/example.com/v1/namespaces/{namespace}/widgets/{name}",
+	}
+	if len(paths) != len(expectedPaths) {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	for i := range paths {
+		if paths[i] != expectedPaths[i] {
+			t.Errorf("unexpected path %s, expected %s", paths[i], expectedPaths[i])
+		}
+	}
+	schemas := d.Components.Schemas.AdditionalProperties
+	if len(schemas) != 2 || schemas[0].Name != "example.com.v1.Widget" || schemas[1].Name != "example.com.v1.WidgetList" {
+		t.Errorf("unexpected schemas %+v", schemas)
+	}
+	if item := d.Paths.Path[2].Value; item.Get.OperationId != "getV1Widget" || item.Patch.OperationId != "patchV1Widget" {
+		t.Errorf("unexpected operations %+v", item)
+	}
+
+	// The description must compile.
+	bytes, err := d.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := openapi3.ParseDocument(bytes); err != nil {
+		t.Errorf("%+v", err)
+	}
+
+	d, err = OpenAPIv3ForCustomResourceDefinition(gadgets)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(d.Paths.Path) != 2 || d.Paths.Path[1].Name != "/apis/example.com/v1beta1/gadgets/{name}" {
+		t.Errorf("unexpected paths %+v", d.Paths.Path)
+	}
+}
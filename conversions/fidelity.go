@@ -0,0 +1,58 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Loss describes a part of a document that a conversion couldn't carry
+// over to the converted document exactly.
+type Loss struct {
+	Path    string // a JSON pointer to the part of the source document
+	Message string
+}
+
+// A FidelityReport lists the losses of a conversion between versions of
+// OpenAPI. A report without losses describes a lossless conversion.
+type FidelityReport struct {
+	Losses []Loss
+}
+
+// Lossless returns true if a conversion lost nothing.
+func (report *FidelityReport) Lossless() bool {
+	return len(report.Losses) == 0
+}
+
+// String returns the losses of a report, one per line.
+func (report *FidelityReport) String() string {
+	lines := make([]string, 0)
+	for _, loss := range report.Losses {
+		lines = append(lines, loss.Path+": "+loss.Message)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (report *FidelityReport) add(path string, format string, args ...interface{}) {
+	report.Losses = append(report.Losses, Loss{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// pointer returns a JSON pointer to a member of the value at path.
+func pointer(path string, key string) string {
+	key = strings.Replace(key, "~", "~0", -1)
+	key = strings.Replace(key, "/", "~1", -1)
+	return path + "/" + key
+}
@@ -0,0 +1,399 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/jsonschema"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// uriTemplateExpression matches the expressions of URI templates (RFC 6570).
+var uriTemplateExpression = regexp.MustCompile(`{([+#./;?&]?)([^}]*)}`)
+
+// OpenAPIv3PathsForLinks converts the links of a hyper-schema to OpenAPI v3
+// path items. Each link becomes an operation on the path of its href,
+// which is resolved against the schema's base. Variables in the path of the
+// href become path parameters and variables in its query become query
+// parameters; their schemas are taken from the link's hrefSchema or from
+// the properties of the schema that the template pointers select. Links are
+// GET operations unless they have methods (draft-04), targetHints that
+// allow other methods, or submission schemas, which make them POST
+// operations with request bodies. Target schemas become the schemas of
+// successful responses; "self" links without target schemas return
+// instances of the schema. When several links describe the same operation,
+// only the first is converted. Link schemas that are references to the
+// hyper-schema or its subschemas are replaced by the schemas that they
+// refer to; other references are copied unchanged.
+func OpenAPIv3PathsForLinks(schema *jsonschema.Schema) (*openapi3.Paths, error) {
+	if schema == nil {
+		return nil, errors.New("no schema to convert")
+	}
+	paths := &openapi3.Paths{}
+	if schema.Links == nil {
+		return paths, nil
+	}
+	pathItems := make(map[string]*openapi3.PathItem)
+	for i, link := range *schema.Links {
+		if link.Href == nil {
+			return nil, fmt.Errorf("link %d has no href", i)
+		}
+		path, server, variables, err := linkPath(schema, *link.Href)
+		if err != nil {
+			return nil, fmt.Errorf("link %d: %s", i, err)
+		}
+		pathItem := pathItems[path]
+		if pathItem == nil {
+			pathItem = &openapi3.PathItem{}
+			pathItems[path] = pathItem
+			paths.Path = append(paths.Path, &openapi3.NamedPathItem{Name: path, Value: pathItem})
+		}
+		if server != "" && len(pathItem.Servers) == 0 {
+			pathItem.Servers = []*openapi3.Server{{Url: server}}
+		}
+		for _, method := range linkMethods(link) {
+			operation, err := linkOperation(schema, link, method, variables)
+			if err != nil {
+				return nil, fmt.Errorf("link %d: %s", i, err)
+			}
+			setOperation(pathItem, method, operation)
+		}
+	}
+	return paths, nil
+}
+
+// A templateVariable is a variable of an href template.
+type templateVariable struct {
+	name string
+	in   string // "path" or "query"
+}
+
+// linkPath returns the path of an href template with its variables,
+// converted to OpenAPI path templates, and the server of absolute hrefs.
+func linkPath(schema *jsonschema.Schema, href string) (string, string, []templateVariable, error) {
+	variables := make([]templateVariable, 0)
+	path := uriTemplateExpression.ReplaceAllStringFunc(href, func(expression string) string {
+		match := uriTemplateExpression.FindStringSubmatch(expression)
+		operator, names := match[1], make([]string, 0)
+		for _, name := range strings.Split(match[2], ",") {
+			// Value modifiers (prefixes and explosion) don't change the names.
+			name = strings.TrimSuffix(strings.SplitN(name, ":", 2)[0], "*")
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+		in := "path"
+		if operator == "?" || operator == "&" || operator == "#" {
+			in = "query"
+		}
+		parts := make([]string, 0)
+		for _, name := range names {
+			if operator != "#" {
+				variables = append(variables, templateVariable{name: name, in: in})
+			}
+			switch operator {
+			case ";":
+				parts = append(parts, ";"+name+"={"+name+"}")
+			case "/", ".":
+				parts = append(parts, operator+"{"+name+"}")
+			default:
+				parts = append(parts, "{"+name+"}")
+			}
+		}
+		if in == "query" {
+			return ""
+		}
+		if operator == ";" || operator == "/" || operator == "." {
+			return strings.Join(parts, "")
+		}
+		return strings.Join(parts, ",")
+	})
+	base := ""
+	if schema.Base != nil {
+		base = *schema.Base
+	}
+	// Path templates are resolved with placeholders because braces aren't allowed in URLs.
+	placeholders := strings.NewReplacer("{", "OPENBRACE", "}", "CLOSEBRACE")
+	restore := strings.NewReplacer("OPENBRACE", "{", "CLOSEBRACE", "}")
+	baseURL, err := url.Parse(placeholders.Replace(base))
+	if err != nil {
+		return "", "", nil, err
+	}
+	hrefURL, err := url.Parse(placeholders.Replace(path))
+	if err != nil {
+		return "", "", nil, err
+	}
+	resolved := baseURL.ResolveReference(hrefURL)
+	server := ""
+	if resolved.Scheme != "" && resolved.Host != "" {
+		server = resolved.Scheme + "://" + resolved.Host
+	}
+	path = restore.Replace(resolved.Path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path, server, variables, nil
+}
+
+// linkMethods returns the HTTP methods of the operations that a link describes.
+func linkMethods(link *jsonschema.Link) []string {
+	if link.Method != nil {
+		return []string{strings.ToUpper(*link.Method)}
+	}
+	methods := make([]string, 0)
+	if link.TargetHints != nil && link.TargetHints.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(link.TargetHints.Content); i += 2 {
+			if link.TargetHints.Content[i].Value == "allow" {
+				for _, method := range link.TargetHints.Content[i+1].Content {
+					methods = append(methods, strings.ToUpper(method.Value))
+				}
+			}
+		}
+	}
+	if len(methods) > 0 {
+		return methods
+	}
+	if link.SubmissionSchema != nil {
+		return []string{"POST"}
+	}
+	return []string{"GET"}
+}
+
+// linkOperation returns the operation that a link describes for a method.
+func linkOperation(schema *jsonschema.Schema, link *jsonschema.Link, method string, variables []templateVariable) (*openapi3.Operation, error) {
+	operation := &openapi3.Operation{
+		Responses: &openapi3.Responses{},
+	}
+	if link.Title != nil {
+		operation.Summary = *link.Title
+	}
+	if link.Description != nil {
+		operation.Description = *link.Description
+	}
+	required := make(map[string]bool)
+	if link.TemplateRequired != nil {
+		for _, name := range *link.TemplateRequired {
+			required[name] = true
+		}
+	}
+	for _, variable := range variables {
+		parameterSchema, err := openAPIv3SchemaOrString(schema, templateVariableSchema(schema, link, variable.name))
+		if err != nil {
+			return nil, err
+		}
+		operation.Parameters = append(operation.Parameters, parameter(&openapi3.Parameter{
+			Name:     variable.name,
+			In:       variable.in,
+			Required: variable.in == "path" || required[variable.name],
+			Schema:   parameterSchema,
+		}))
+	}
+	if link.HeaderSchema != nil && link.HeaderSchema.Properties != nil {
+		for _, property := range *link.HeaderSchema.Properties {
+			headerSchema, err := linkSchema(schema, property.Value)
+			if err != nil {
+				return nil, err
+			}
+			operation.Parameters = append(operation.Parameters, parameter(&openapi3.Parameter{
+				Name:     property.Name,
+				In:       "header",
+				Required: containsString(link.HeaderSchema.Required, property.Name),
+				Schema:   headerSchema,
+			}))
+		}
+	}
+
+	submission, submissionMediaType := link.SubmissionSchema, link.SubmissionMediaType
+	if link.Schema != nil {
+		// draft-04 links use "schema" for query parameters of GET requests
+		// and for request bodies of other requests.
+		submission, submissionMediaType = link.Schema, link.EncType
+		if method == "GET" {
+			submission = nil
+			if link.Schema.Properties != nil {
+				for _, property := range *link.Schema.Properties {
+					querySchema, err := linkSchema(schema, property.Value)
+					if err != nil {
+						return nil, err
+					}
+					operation.Parameters = append(operation.Parameters, parameter(&openapi3.Parameter{
+						Name:     property.Name,
+						In:       "query",
+						Required: containsString(link.Schema.Required, property.Name),
+						Schema:   querySchema,
+					}))
+				}
+			}
+		}
+	}
+	if submission != nil && method != "GET" && method != "DELETE" && method != "HEAD" {
+		bodySchema, err := linkSchema(schema, submission)
+		if err != nil {
+			return nil, err
+		}
+		operation.RequestBody = &openapi3.RequestBodyOrReference{
+			Oneof: &openapi3.RequestBodyOrReference_RequestBody{
+				RequestBody: &openapi3.RequestBody{
+					Required: true,
+					Content:  mediaTypeWithSchema(stringOrDefault(submissionMediaType, "application/json"), bodySchema),
+				},
+			},
+		}
+	}
+
+	response := &openapi3.Response{Description: "OK"}
+	target := link.TargetSchema
+	if target == nil && method == "GET" && linkHasRel(link, "self") {
+		target = schema
+	}
+	if target != nil {
+		targetSchema, err := linkSchema(schema, target)
+		if err != nil {
+			return nil, err
+		}
+		targetMediaType := link.TargetMediaType
+		if targetMediaType == nil {
+			targetMediaType = link.MediaType
+		}
+		response.Content = mediaTypeWithSchema(stringOrDefault(targetMediaType, "application/json"), targetSchema)
+	}
+	operation.Responses.ResponseOrReference = []*openapi3.NamedResponseOrReference{{
+		Name: "200",
+		Value: &openapi3.ResponseOrReference{
+			Oneof: &openapi3.ResponseOrReference_Response{Response: response},
+		},
+	}}
+	return operation, nil
+}
+
+// templateVariableSchema returns the schema of a template variable, or nil if it is unknown.
+func templateVariableSchema(schema *jsonschema.Schema, link *jsonschema.Link, name string) *jsonschema.Schema {
+	if link.HrefSchema != nil {
+		if s := link.HrefSchema.PropertyWithName(name); s != nil {
+			return s
+		}
+	}
+	property := name
+	if link.TemplatePointers != nil {
+		for _, pair := range *link.TemplatePointers {
+			if pair.Name == name {
+				tokens := strings.Split(pair.Value, "/")
+				property = tokens[len(tokens)-1]
+			}
+		}
+	}
+	if schema.Properties != nil {
+		return schema.PropertyWithName(property)
+	}
+	return nil
+}
+
+// openAPIv3SchemaOrString converts a link schema, using a string schema if there is none.
+func openAPIv3SchemaOrString(root *jsonschema.Schema, schema *jsonschema.Schema) (*openapi3.SchemaOrReference, error) {
+	if schema == nil {
+		return &openapi3.SchemaOrReference{
+			Oneof: &openapi3.SchemaOrReference_Schema{Schema: &openapi3.Schema{Type: "string"}},
+		}, nil
+	}
+	return linkSchema(root, schema)
+}
+
+// linkSchema converts a schema of a link. Link schemas that refer to the
+// hyper-schema or its subschemas, like {"$ref": "#"}, are replaced by the
+// schemas that they refer to.
+func linkSchema(root *jsonschema.Schema, schema *jsonschema.Schema) (*openapi3.SchemaOrReference, error) {
+	if schema.Ref != nil {
+		if resolved, err := root.ResolveReference(*schema.Ref); err == nil && resolved != nil {
+			schema = resolved
+		}
+	}
+	return OpenAPIv3SchemaForJSONSchema(schema)
+}
+
+func linkHasRel(link *jsonschema.Link, rel string) bool {
+	if link.Rel == nil {
+		return false
+	}
+	if link.Rel.String != nil {
+		return *link.Rel.String == rel
+	}
+	return link.Rel.StringArray != nil && containsString(link.Rel.StringArray, rel)
+}
+
+func containsString(values *[]string, value string) bool {
+	if values == nil {
+		return false
+	}
+	for _, v := range *values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func stringOrDefault(value *string, defaultValue string) string {
+	if value == nil || *value == "" {
+		return defaultValue
+	}
+	return *value
+}
+
+func parameter(p *openapi3.Parameter) *openapi3.ParameterOrReference {
+	return &openapi3.ParameterOrReference{Oneof: &openapi3.ParameterOrReference_Parameter{Parameter: p}}
+}
+
+func mediaTypeWithSchema(mediaType string, schema *openapi3.SchemaOrReference) *openapi3.MediaTypes {
+	return &openapi3.MediaTypes{
+		AdditionalProperties: []*openapi3.NamedMediaType{
+			{Name: mediaType, Value: &openapi3.MediaType{Schema: schema}},
+		},
+	}
+}
+
+// setOperation adds an operation to a path item unless it already has one for the method.
+func setOperation(pathItem *openapi3.PathItem, method string, operation *openapi3.Operation) {
+	var slot **openapi3.Operation
+	switch method {
+	case "GET":
+		slot = &pathItem.Get
+	case "PUT":
+		slot = &pathItem.Put
+	case "POST":
+		slot = &pathItem.Post
+	case "DELETE":
+		slot = &pathItem.Delete
+	case "OPTIONS":
+		slot = &pathItem.Options
+	case "HEAD":
+		slot = &pathItem.Head
+	case "PATCH":
+		slot = &pathItem.Patch
+	case "TRACE":
+		slot = &pathItem.Trace
+	default:
+		return
+	}
+	if *slot == nil {
+		*slot = operation
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/jsonschema"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// JSONSchemaForOpenAPIv3Schema converts an OpenAPI v3 schema to a JSON Schema.
+// References are copied unchanged. Because the fields of OpenAPI schema
+// messages don't record whether zero values were specified, values like
+// "minimum: 0" are lost when descriptions are compiled; to keep them, convert
+// the YAML of a schema with JSONSchemaNodeForOpenAPIv3SchemaNode.
+func JSONSchemaForOpenAPIv3Schema(schema *openapi3.SchemaOrReference) (*jsonschema.Schema, error) {
+	if schema == nil {
+		return nil, errors.New("no schema to convert")
+	}
+	result := jsonschema.NewSchemaFromObject(JSONSchemaNodeForOpenAPIv3SchemaNode(schema.ToRawInfo()))
+	if result == nil {
+		return nil, errors.New("unable to read converted schema")
+	}
+	return result, nil
+}
+
+// OpenAPIv3SchemaForJSONSchema converts a JSON Schema to an OpenAPI v3 schema.
+// Keywords that OpenAPI v3.0 doesn't support are dropped.
+func OpenAPIv3SchemaForJSONSchema(schema *jsonschema.Schema) (*openapi3.SchemaOrReference, error) {
+	if schema == nil {
+		return nil, errors.New("no schema to convert")
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(schema.JSONString()), &node); err != nil {
+		return nil, err
+	}
+	converted := OpenAPIv3SchemaNodeForJSONSchemaNode(node.Content[0])
+	return openapi3.NewSchemaOrReference(converted, compiler.NewContext("$root", converted, nil))
+}
+
+// JSONSchemaNodeForOpenAPIv3SchemaNode converts the YAML of an OpenAPI v3
+// schema to the YAML of an equivalent JSON Schema:
+//
+//   - nullable schemas accept null, which is added to their types and enums,
+//   - boolean exclusiveMaximum and exclusiveMinimum become numbers,
+//   - example becomes examples, and
+//   - discriminator, xml, externalDocs, and extensions are removed.
+func JSONSchemaNodeForOpenAPIv3SchemaNode(node *yaml.Node) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return node
+	}
+	if compiler.MapValueForKey(node, "$ref") != nil {
+		return node
+	}
+	result := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	nullable := isTrue(compiler.MapValueForKey(node, "nullable"))
+	exclusiveMaximum := isTrue(compiler.MapValueForKey(node, "exclusiveMaximum"))
+	exclusiveMinimum := isTrue(compiler.MapValueForKey(node, "exclusiveMinimum"))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "nullable", "discriminator", "xml", "externalDocs", "exclusiveMaximum", "exclusiveMinimum":
+			continue
+		case "type":
+			if nullable && value.Kind == yaml.ScalarNode {
+				value = sequenceNode(value, stringNode("null"))
+			}
+		case "enum":
+			if nullable && !containsNull(value) {
+				value = sequenceNode(append(value.Content[0:len(value.Content):len(value.Content)], nullNode())...)
+			}
+		case "maximum":
+			if exclusiveMaximum {
+				key = stringNode("exclusiveMaximum")
+			}
+		case "minimum":
+			if exclusiveMinimum {
+				key = stringNode("exclusiveMinimum")
+			}
+		case "example":
+			key, value = stringNode("examples"), sequenceNode(value)
+		case "properties":
+			value = mapSchemaNodes(value, JSONSchemaNodeForOpenAPIv3SchemaNode)
+		case "items", "additionalProperties", "not":
+			value = JSONSchemaNodeForOpenAPIv3SchemaNode(value)
+		case "allOf", "anyOf", "oneOf":
+			value = arraySchemaNodes(value, JSONSchemaNodeForOpenAPIv3SchemaNode)
+		default:
+			if strings.HasPrefix(key.Value, "x-") {
+				continue
+			}
+		}
+		result.Content = append(result.Content, key, value)
+	}
+	return result
+}
+
+// OpenAPIv3SchemaNodeForJSONSchemaNode converts the YAML of a JSON Schema to
+// the YAML of an equivalent OpenAPI v3 schema, where possible:
+//
+//   - types that include null become nullable, and schemas with several
+//     other types become anyOf alternatives,
+//   - numeric exclusiveMaximum and exclusiveMinimum become booleans,
+//   - const becomes an enum and the first of the examples becomes example,
+//   - boolean schemas become empty schemas and schemas with "not", and
+//   - keywords that OpenAPI v3.0 doesn't support, like $defs and if, are removed.
+func OpenAPIv3SchemaNodeForJSONSchemaNode(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.ScalarNode && node.Tag == "!!bool" {
+		if b, _ := strconv.ParseBool(node.Value); b {
+			return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		return mappingNode("not", &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+	}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return node
+	}
+	if ref := compiler.MapValueForKey(node, "$ref"); ref != nil {
+		return mappingNode("$ref", ref)
+	}
+	result := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	nullable := false
+	var types []*yaml.Node
+	// Numeric exclusive limits replace inclusive ones unless those are tighter.
+	skip := make(map[string]bool)
+	for _, limit := range []struct {
+		inclusive, exclusive string
+		tighter              func(a, b float64) bool
+	}{
+		{"maximum", "exclusiveMaximum", func(a, b float64) bool { return a < b }},
+		{"minimum", "exclusiveMinimum", func(a, b float64) bool { return a > b }},
+	} {
+		exclusive := compiler.MapValueForKey(node, limit.exclusive)
+		if exclusive == nil || exclusive.Tag == "!!bool" {
+			continue
+		}
+		if inclusive := compiler.MapValueForKey(node, limit.inclusive); inclusive != nil {
+			a, _ := strconv.ParseFloat(inclusive.Value, 64)
+			b, _ := strconv.ParseFloat(exclusive.Value, 64)
+			if limit.tighter(a, b) {
+				skip[limit.exclusive] = true
+				continue
+			}
+			skip[limit.inclusive] = true
+		}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if skip[key.Value] {
+			continue
+		}
+		switch key.Value {
+		case "title", "description", "default", "format", "pattern", "required", "enum",
+			"multipleOf", "maximum", "minimum", "maxLength", "minLength",
+			"maxItems", "minItems", "uniqueItems", "maxProperties", "minProperties",
+			"readOnly", "writeOnly", "deprecated":
+		case "type":
+			types = value.Content
+			if value.Kind == yaml.ScalarNode {
+				types = []*yaml.Node{value}
+			}
+			continue
+		case "exclusiveMaximum", "exclusiveMinimum":
+			if value.Tag != "!!bool" {
+				// draft-06 and later use numbers instead of booleans with limits
+				limit := "maximum"
+				if key.Value == "exclusiveMinimum" {
+					limit = "minimum"
+				}
+				result.Content = append(result.Content, stringNode(limit), value)
+				value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+			}
+		case "const":
+			key, value = stringNode("enum"), sequenceNode(value)
+		case "examples":
+			if value.Kind != yaml.SequenceNode || len(value.Content) == 0 {
+				continue
+			}
+			key, value = stringNode("example"), value.Content[0]
+		case "properties":
+			value = mapSchemaNodes(value, OpenAPIv3SchemaNodeForJSONSchemaNode)
+		case "items":
+			if value.Kind == yaml.SequenceNode {
+				// OpenAPI v3.0 has no tuples, so items can be any of the listed schemas
+				value = mappingNode("anyOf", arraySchemaNodes(value, OpenAPIv3SchemaNodeForJSONSchemaNode))
+			} else {
+				value = OpenAPIv3SchemaNodeForJSONSchemaNode(value)
+			}
+		case "additionalProperties", "not":
+			value = OpenAPIv3SchemaNodeForJSONSchemaNode(value)
+		case "allOf", "anyOf", "oneOf":
+			value = arraySchemaNodes(value, OpenAPIv3SchemaNodeForJSONSchemaNode)
+		default:
+			continue
+		}
+		result.Content = append(result.Content, key, value)
+	}
+	others := make([]*yaml.Node, 0)
+	for _, t := range types {
+		if t.Value == "null" {
+			nullable = true
+		} else {
+			others = append(others, t)
+		}
+	}
+	switch {
+	case len(others) == 1:
+		result.Content = append(result.Content, stringNode("type"), others[0])
+	case len(others) > 1:
+		alternatives := make([]*yaml.Node, 0)
+		for _, t := range others {
+			alternatives = append(alternatives, mappingNode("type", t))
+		}
+		result.Content = append(result.Content, stringNode("anyOf"), sequenceNode(alternatives...))
+	}
+	if nullable {
+		result.Content = append(result.Content, stringNode("nullable"), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+	}
+	return result
+}
+
+func isTrue(node *yaml.Node) bool {
+	b, ok := compiler.BoolForScalarNode(node)
+	return ok && b
+}
+
+func containsNull(node *yaml.Node) bool {
+	for _, item := range node.Content {
+		if item.Tag == "!!null" {
+			return true
+		}
+	}
+	return false
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func nullNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+func sequenceNode(content ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: content}
+}
+
+func mappingNode(key string, value *yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{stringNode(key), value}}
+}
+
+// mapSchemaNodes converts the schemas in a map of named schemas.
+func mapSchemaNodes(node *yaml.Node, convert func(*yaml.Node) *yaml.Node) *yaml.Node {
+	result := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		result.Content = append(result.Content, node.Content[i], convert(node.Content[i+1]))
+	}
+	return result
+}
+
+// arraySchemaNodes converts the schemas in an array of schemas.
+func arraySchemaNodes(node *yaml.Node, convert func(*yaml.Node) *yaml.Node) *yaml.Node {
+	result := sequenceNode()
+	for _, item := range node.Content {
+		result.Content = append(result.Content, convert(item))
+	}
+	return result
+}
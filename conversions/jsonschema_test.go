@@ -0,0 +1,231 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/jsonschema"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+func parseNode(t *testing.T, text string) *yaml.Node {
+	t.Helper()
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return node.Content[0]
+}
+
+func TestJSONSchemaForOpenAPIv3Schema(t *testing.T) {
+	node := parseNode(t, `
+type: object
+discriminator:
+  propertyName: kind
+x-internal: true
+properties:
+  kind:
+    type: string
+    enum: [cat, dog]
+    nullable: true
+  age:
+    type: integer
+    minimum: 1
+    maximum: 30
+    exclusiveMaximum: true
+    example: 3
+  owner:
+    $ref: '#/components/schemas/Owner'
+`)
+	schemaOrReference, err := openapi3.NewSchemaOrReference(node, compiler.NewContext("$root", node, nil))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	schema, err := JSONSchemaForOpenAPIv3Schema(schemaOrReference)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	output := schema.JSONString()
+	for _, expected := range []string{
+		`"type": [
+        "string",
+        "null"
+      ]`,
+		`"enum": [
+        "cat",
+        "dog",
+        null
+      ]`,
+		`"exclusiveMaximum": 30`,
+		`"examples": [
+        3
+      ]`,
+		`"$ref": "#/components/schemas/Owner"`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("missing %s in\n%s", expected, output)
+		}
+	}
+	for _, unexpected := range []string{"discriminator", "x-internal", "nullable", `"maximum"`} {
+		if strings.Contains(output, unexpected) {
+			t.Errorf("unexpected %s in\n%s", unexpected, output)
+		}
+	}
+}
+
+func TestOpenAPIv3SchemaForJSONSchema(t *testing.T) {
+	schema := jsonschema.NewSchemaFromObject(parseNode(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "$defs": {"unused": {"type": "string"}},
+  "properties": {
+    "name": {"type": ["string", "null"], "examples": ["rex", "fido"]},
+    "size": {"type": ["number", "string"]},
+    "weight": {"type": "number", "minimum": 0, "exclusiveMinimum": 1},
+    "kind": {"const": "dog"},
+    "tags": {"type": "array", "items": true},
+    "never": false
+  }
+}`))
+	schemaOrReference, err := OpenAPIv3SchemaForJSONSchema(schema)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	bytes, err := yaml.Marshal(schemaOrReference.ToRawInfo())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := `type: object
+properties:
+    name:
+        nullable: true
+        example: rex
+        type: string
+    size:
+        anyOf:
+            - type: number
+            - type: string
+    weight:
+        minimum: !!float 1
+        exclusiveMinimum: true
+        type: number
+    kind:
+        enum:
+            - dog
+    tags:
+        type: array
+        items: {}
+    never:
+        not: {}
+`
+	if output := string(bytes); output != expected {
+		t.Errorf("expected\n%s\nfound\n%s", expected, output)
+	}
+}
+
+func TestOpenAPIv3PathsForLinks(t *testing.T) {
+	schema := jsonschema.NewSchemaFromObject(parseNode(t, `{
+  "$schema": "https://json-schema.org/draft/2019-09/hyper-schema",
+  "type": "object",
+  "properties": {
+    "id": {"type": "integer"},
+    "name": {"type": "string"}
+  },
+  "base": "https://api.example.com/v1/",
+  "links": [
+    {
+      "rel": "self",
+      "href": "things/{thingId}",
+      "templatePointers": {"thingId": "0/id"},
+      "title": "Get a thing"
+    },
+    {
+      "rel": "edit",
+      "href": "things/{thingId}",
+      "templatePointers": {"thingId": "0/id"},
+      "targetHints": {"allow": ["PUT", "DELETE"]},
+      "submissionSchema": {"$ref": "#"}
+    },
+    {
+      "rel": "collection",
+      "href": "things{?q,limit}",
+      "hrefSchema": {"properties": {"limit": {"type": "integer", "minimum": 1}}},
+      "templateRequired": ["q"],
+      "targetSchema": {"type": "array", "items": {"type": "object"}}
+    },
+    {
+      "rel": "create-form",
+      "href": "things",
+      "headerSchema": {"properties": {"Idempotency-Key": {"type": "string"}}, "required": ["Idempotency-Key"]},
+      "submissionMediaType": "application/x-www-form-urlencoded",
+      "submissionSchema": {"type": "object", "properties": {"name": {"type": "string"}}}
+    }
+  ]
+}`))
+	paths, err := OpenAPIv3PathsForLinks(schema)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The paths should be valid.
+	document := &openapi3.Document{Openapi: "3.0.3", Info: &openapi3.Info{Title: "Things", Version: "1.0"}, Paths: paths}
+	bytes, err := document.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := openapi3.ParseDocument(bytes); err != nil {
+		t.Fatalf("the converted paths are invalid: %s", err)
+	}
+	if len(paths.Path) != 2 {
+		t.Fatalf("expected 2 paths, found %d", len(paths.Path))
+	}
+	thing, things := paths.Path[0], paths.Path[1]
+	if thing.Name != "/v1/things/{thingId}" || things.Name != "/v1/things" {
+		t.Errorf("unexpected paths %s and %s", thing.Name, things.Name)
+	}
+	if thing.Value.Servers[0].Url != "https://api.example.com" {
+		t.Errorf("unexpected server %s", thing.Value.Servers[0].Url)
+	}
+	if thing.Value.Get == nil || thing.Value.Put == nil || thing.Value.Delete == nil || thing.Value.Post != nil {
+		t.Errorf("links to a thing should describe GET, PUT, and DELETE operations")
+	}
+	// The path parameter has the type of the property that the template pointer selects.
+	if s := thing.Value.Get.Parameters[0].GetParameter().Schema.GetSchema(); s.Type != "integer" {
+		t.Errorf("thingId has type %q", s.Type)
+	}
+	// The self link returns the schema, and the edit link submits it.
+	if thing.Value.Get.Summary != "Get a thing" || thing.Value.Get.Responses.ResponseOrReference[0].Value.GetResponse().Content == nil {
+		t.Errorf("GET should return a thing")
+	}
+	if s := thing.Value.Put.RequestBody.GetRequestBody().Content.AdditionalProperties[0].Value.Schema.GetSchema(); s == nil || s.Properties == nil {
+		t.Errorf("PUT should submit a thing")
+	}
+	query := things.Value.Get.Parameters
+	if len(query) != 2 || query[0].GetParameter().Name != "q" || !query[0].GetParameter().Required ||
+		query[1].GetParameter().In != "query" || query[1].GetParameter().Schema.GetSchema().Type != "integer" {
+		t.Errorf("unexpected query parameters %v", query)
+	}
+	post := things.Value.Post
+	if post == nil || len(post.Parameters) != 1 || post.Parameters[0].GetParameter().In != "header" {
+		t.Fatalf("the create-form link should describe a POST operation with a header")
+	}
+	if name := post.RequestBody.GetRequestBody().Content.AdditionalProperties[0].Name; name != "application/x-www-form-urlencoded" {
+		t.Errorf("unexpected submission media type %s", name)
+	}
+}
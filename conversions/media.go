@@ -0,0 +1,370 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"log"
+	"net/url"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	discovery "github.com/okkoye/gnostic/discovery"
+	openapi2 "github.com/okkoye/gnostic/openapiv2"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// mediaUpload is a path that accepts media for a method along with the
+// uploadType values of the protocols that use it.
+type mediaUpload struct {
+	path        string
+	uploadTypes []string
+}
+
+// mediaUploadsForMethod returns the upload paths of a method. Simple and
+// resumable uploads usually share a path, so their protocols are combined.
+func mediaUploadsForMethod(method *discovery.Method) []*mediaUpload {
+	if !method.SupportsMediaUpload || method.MediaUpload == nil || method.MediaUpload.Protocols == nil {
+		return nil
+	}
+	uploads := make([]*mediaUpload, 0)
+	add := func(path string, uploadTypes ...string) {
+		if path == "" {
+			return
+		}
+		for _, upload := range uploads {
+			if upload.path == path {
+				upload.uploadTypes = append(upload.uploadTypes, uploadTypes...)
+				return
+			}
+		}
+		uploads = append(uploads, &mediaUpload{path: path, uploadTypes: uploadTypes})
+	}
+	if simple := method.MediaUpload.Protocols.Simple; simple != nil {
+		if simple.Multipart {
+			add(simple.Path, "media", "multipart")
+		} else {
+			add(simple.Path, "media")
+		}
+	}
+	if resumable := method.MediaUpload.Protocols.Resumable; resumable != nil {
+		add(resumable.Path, "resumable")
+	}
+	return uploads
+}
+
+func (upload *mediaUpload) supports(uploadType string) bool {
+	for _, t := range upload.uploadTypes {
+		if t == uploadType {
+			return true
+		}
+	}
+	return false
+}
+
+// operationId distinguishes the operations of a method that has more than one upload path.
+func (upload *mediaUpload) operationId(method *discovery.Method, uploads []*mediaUpload) string {
+	if len(uploads) > 1 && upload.supports("resumable") && !upload.supports("media") {
+		return method.Id + ".resumableUpload"
+	}
+	return method.Id + ".upload"
+}
+
+// mediaPath returns an absolute path for a media upload or download.
+// Unlike method paths, these are relative to the root URL of the API.
+func mediaPath(path string) string {
+	return "/" + strings.TrimPrefix(strings.Replace(path, "{+", "{", -1), "/")
+}
+
+func mediaAcceptTypes(method *discovery.Method) []string {
+	if len(method.MediaUpload.Accept) == 0 {
+		return []string{"*/*"}
+	}
+	return method.MediaUpload.Accept
+}
+
+func methodHasParameter(method *discovery.Method, name string) bool {
+	if method.Parameters == nil {
+		return false
+	}
+	for _, pair := range method.Parameters.AdditionalProperties {
+		if pair.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func buildOpenAPI3BinarySchema() *openapi3.SchemaOrReference {
+	return &openapi3.SchemaOrReference{
+		Oneof: &openapi3.SchemaOrReference_Schema{
+			Schema: &openapi3.Schema{Type: "string", Format: "binary"},
+		},
+	}
+}
+
+func buildOpenAPI3ReferenceForSchema(name string) *openapi3.SchemaOrReference {
+	return &openapi3.SchemaOrReference{
+		Oneof: &openapi3.SchemaOrReference_Reference{
+			Reference: &openapi3.Reference{
+				XRef: "#/definitions/" + name,
+			},
+		},
+	}
+}
+
+func buildOpenAPI3EnumParameter(name, description string, required bool, values []string, defaultValue string) *openapi3.ParameterOrReference {
+	schema := &openapi3.Schema{Type: "string"}
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, &openapi3.Any{Yaml: value})
+	}
+	if defaultValue != "" {
+		schema.Default = &openapi3.DefaultType{Oneof: &openapi3.DefaultType_String_{String_: defaultValue}}
+	}
+	return &openapi3.ParameterOrReference{
+		Oneof: &openapi3.ParameterOrReference_Parameter{
+			Parameter: &openapi3.Parameter{
+				Name:        name,
+				In:          "query",
+				Description: description,
+				Required:    required,
+				Schema: &openapi3.SchemaOrReference{
+					Oneof: &openapi3.SchemaOrReference_Schema{Schema: schema},
+				},
+			},
+		},
+	}
+}
+
+// rootServerForDocument returns the root URL of an API and the base path of
+// its methods, which are combined in the first server of the document.
+func rootServerForDocument(d *openapi3.Document) (string, string) {
+	if len(d.Servers) == 0 {
+		return "", "/"
+	}
+	u, err := url.Parse(d.Servers[0].Url)
+	if err != nil {
+		return "", "/"
+	}
+	return u.Scheme + "://" + u.Host, u.Path
+}
+
+// getOpenAPI3MediaPathItemForPath returns a path item for a media path.
+// Media paths don't include the base path of the API, so their path items
+// override the servers of the document with the root URL of the API.
+func getOpenAPI3MediaPathItemForPath(d *openapi3.Document, path string) *openapi3.PathItem {
+	root, _ := rootServerForDocument(d)
+	pathItem := getOpenAPI3PathItemForPath(d, path)
+	if len(pathItem.Servers) == 0 && root != "" {
+		pathItem.Servers = []*openapi3.Server{&openapi3.Server{Url: root}}
+	}
+	return pathItem
+}
+
+// addOpenAPI3MediaDownloadToOperation allows a method's response to be
+// downloaded as media by setting the alt query parameter to "media".
+func addOpenAPI3MediaDownloadToOperation(operation *openapi3.Operation, method *discovery.Method) {
+	if !methodHasParameter(method, "alt") {
+		operation.Parameters = append(operation.Parameters,
+			buildOpenAPI3EnumParameter("alt", "Data format for the response.", false, []string{"json", "media"}, "json"))
+	}
+	for _, pair := range operation.Responses.ResponseOrReference {
+		response := pair.Value.GetResponse()
+		if response == nil {
+			continue
+		}
+		if response.Content == nil {
+			response.Content = &openapi3.MediaTypes{}
+		}
+		response.Content.AdditionalProperties = append(response.Content.AdditionalProperties,
+			&openapi3.NamedMediaType{
+				Name:  "application/octet-stream",
+				Value: &openapi3.MediaType{Schema: buildOpenAPI3BinarySchema()},
+			})
+	}
+}
+
+func buildOpenAPI3RequestBodyForMediaUpload(method *discovery.Method, upload *mediaUpload) *openapi3.RequestBody {
+	content := &openapi3.MediaTypes{}
+	if upload.supports("media") {
+		for _, accept := range mediaAcceptTypes(method) {
+			content.AdditionalProperties = append(content.AdditionalProperties,
+				&openapi3.NamedMediaType{
+					Name:  accept,
+					Value: &openapi3.MediaType{Schema: buildOpenAPI3BinarySchema()},
+				})
+		}
+	}
+	if upload.supports("multipart") {
+		// Multipart uploads send the metadata of the method's request
+		// followed by the media.
+		properties := &openapi3.Properties{}
+		if method.Request != nil && method.Request.XRef != "" {
+			properties.AdditionalProperties = append(properties.AdditionalProperties,
+				&openapi3.NamedSchemaOrReference{
+					Name:  "metadata",
+					Value: buildOpenAPI3ReferenceForSchema(method.Request.XRef),
+				})
+		}
+		properties.AdditionalProperties = append(properties.AdditionalProperties,
+			&openapi3.NamedSchemaOrReference{
+				Name:  "media",
+				Value: buildOpenAPI3BinarySchema(),
+			})
+		content.AdditionalProperties = append(content.AdditionalProperties,
+			&openapi3.NamedMediaType{
+				Name: "multipart/related",
+				Value: &openapi3.MediaType{
+					Schema: &openapi3.SchemaOrReference{
+						Oneof: &openapi3.SchemaOrReference_Schema{
+							Schema: &openapi3.Schema{Type: "object", Properties: properties},
+						},
+					},
+				},
+			})
+	}
+	if upload.supports("resumable") && method.Request != nil && method.Request.XRef != "" {
+		// Resumable uploads start with a request that contains only the
+		// metadata. The media is sent in later requests to the session URI.
+		content.AdditionalProperties = append(content.AdditionalProperties,
+			&openapi3.NamedMediaType{
+				Name:  "application/json",
+				Value: &openapi3.MediaType{Schema: buildOpenAPI3ReferenceForSchema(method.Request.XRef)},
+			})
+	}
+	if len(content.AdditionalProperties) == 0 {
+		return nil
+	}
+	requestBody := &openapi3.RequestBody{Content: content}
+	if maxSize := method.MediaUpload.MaxSize; maxSize != "" {
+		requestBody.Description = "Maximum size: " + maxSize
+	}
+	return requestBody
+}
+
+// addOpenAPI3MediaPathsForMethod adds operations for the upload paths of a
+// method and, when its media is served by the download service, for its
+// download path.
+func addOpenAPI3MediaPathsForMethod(d *openapi3.Document, method *discovery.Method, hasDataWrapper bool) {
+	uploads := mediaUploadsForMethod(method)
+	for _, upload := range uploads {
+		operation := buildOpenAPI3OperationForMethod(method, hasDataWrapper)
+		operation.OperationId = upload.operationId(method, uploads)
+		operation.Parameters = append(operation.Parameters,
+			buildOpenAPI3EnumParameter("uploadType", "The protocol of the upload request.", true, upload.uploadTypes, ""))
+		operation.RequestBody = nil
+		if requestBody := buildOpenAPI3RequestBodyForMediaUpload(method, upload); requestBody != nil {
+			operation.RequestBody = &openapi3.RequestBodyOrReference{
+				Oneof: &openapi3.RequestBodyOrReference_RequestBody{RequestBody: requestBody},
+			}
+		}
+		pathItem := getOpenAPI3MediaPathItemForPath(d, mediaPath(upload.path))
+		setOpenAPI3OperationForMethod(pathItem, method.HttpMethod, operation)
+	}
+	if method.SupportsMediaDownload && method.UseMediaDownloadService {
+		_, basePath := rootServerForDocument(d)
+		operation := buildOpenAPI3OperationForMethod(method, hasDataWrapper)
+		operation.OperationId = method.Id + ".download"
+		if !methodHasParameter(method, "alt") {
+			operation.Parameters = append(operation.Parameters,
+				buildOpenAPI3EnumParameter("alt", "Data format for the response.", true, []string{"media"}, ""))
+		}
+		operation.Responses.ResponseOrReference[0].Value = &openapi3.ResponseOrReference{
+			Oneof: &openapi3.ResponseOrReference_Response{
+				Response: &openapi3.Response{
+					Description: "Successful operation",
+					Content: &openapi3.MediaTypes{
+						AdditionalProperties: []*openapi3.NamedMediaType{
+							&openapi3.NamedMediaType{
+								Name:  "application/octet-stream",
+								Value: &openapi3.MediaType{Schema: buildOpenAPI3BinarySchema()},
+							},
+						},
+					},
+				},
+			},
+		}
+		pathItem := getOpenAPI3MediaPathItemForPath(d, mediaPath(path.Join("download", basePath, method.Path)))
+		setOpenAPI3OperationForMethod(pathItem, method.HttpMethod, operation)
+	}
+}
+
+// addOpenAPI2MediaToOperation describes the media support of a method in
+// its operation. Downloads add the alt query parameter and a binary response
+// type. OpenAPI v2 paths are all relative to a single base path, so the upload
+// paths of a method can't be described as paths; they are described with an
+// x-google-media-upload extension and the media types that they accept.
+func addOpenAPI2MediaToOperation(operation *openapi2.Operation, method *discovery.Method) {
+	if method.SupportsMediaDownload {
+		if !methodHasParameter(method, "alt") {
+			operation.Parameters = append(operation.Parameters, &openapi2.ParametersItem{
+				Oneof: &openapi2.ParametersItem_Parameter{
+					Parameter: &openapi2.Parameter{
+						Oneof: &openapi2.Parameter_NonBodyParameter{
+							NonBodyParameter: &openapi2.NonBodyParameter{
+								Oneof: &openapi2.NonBodyParameter_QueryParameterSubSchema{
+									QueryParameterSubSchema: &openapi2.QueryParameterSubSchema{
+										Name:        "alt",
+										In:          "query",
+										Description: "Data format for the response.",
+										Type:        "string",
+										Enum:        []*openapi2.Any{&openapi2.Any{Yaml: "json"}, &openapi2.Any{Yaml: "media"}},
+										Default:     &openapi2.Any{Yaml: "json"},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		}
+		operation.Produces = []string{"application/json", "application/octet-stream"}
+	}
+	uploads := mediaUploadsForMethod(method)
+	if len(uploads) == 0 {
+		return
+	}
+	operation.Consumes = append([]string{"application/json"}, mediaAcceptTypes(method)...)
+	for _, upload := range uploads {
+		if upload.supports("multipart") {
+			operation.Consumes = append(operation.Consumes, "multipart/related")
+		}
+	}
+	// The extension mirrors the mediaUpload field of the discovery method.
+	extension := map[string]interface{}{
+		"accept": mediaAcceptTypes(method),
+	}
+	if maxSize := method.MediaUpload.MaxSize; maxSize != "" {
+		extension["maxSize"] = maxSize
+	}
+	protocols := map[string]interface{}{}
+	if simple := method.MediaUpload.Protocols.Simple; simple != nil && simple.Path != "" {
+		protocols["simple"] = map[string]interface{}{"multipart": simple.Multipart, "path": mediaPath(simple.Path)}
+	}
+	if resumable := method.MediaUpload.Protocols.Resumable; resumable != nil && resumable.Path != "" {
+		protocols["resumable"] = map[string]interface{}{"multipart": resumable.Multipart, "path": mediaPath(resumable.Path)}
+	}
+	extension["protocols"] = protocols
+	bytes, err := yaml.Marshal(extension)
+	if err != nil {
+		log.Printf("WARNING: Unable to describe media upload for %s: %+v", method.Id, err)
+		return
+	}
+	operation.VendorExtension = append(operation.VendorExtension, &openapi2.NamedAny{
+		Name:  "x-google-media-upload",
+		Value: &openapi2.Any{Yaml: strings.TrimSuffix(string(bytes), "\n")},
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"testing"
+
+	discovery "github.com/okkoye/gnostic/discovery"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const mediaDiscoveryDocument = `{
+ "kind": "discovery#restDescription",
+ "discoveryVersion": "v1",
+ "name": "storage",
+ "version": "v1",
+ "title": "Storage API",
+ "rootUrl": "https://storage.example.com/",
+ "servicePath": "storage/v1/",
+ "basePath": "/storage/v1/",
+ "schemas": {
+  "Object": {"id": "Object", "type": "object", "properties": {"name": {"type": "string"}}}
+ },
+ "resources": {
+  "objects": {
+   "methods": {
+    "insert": {
+     "id": "storage.objects.insert",
+     "path": "b/{bucket}/o",
+     "httpMethod": "POST",
+     "parameters": {"bucket": {"type": "string", "required": true, "location": "path"}},
+     "request": {"$ref": "Object"},
+     "response": {"$ref": "Object"},
+     "supportsMediaUpload": true,
+     "mediaUpload": {
+      "accept": ["image/*"],
+      "maxSize": "5TB",
+      "protocols": {
+       "simple": {"multipart": true, "path": "/upload/storage/v1/b/{bucket}/o"},
+       "resumable": {"multipart": true, "path": "/resumable/upload/storage/v1/b/{bucket}/o"}
+      }
+     }
+    },
+    "get": {
+     "id": "storage.objects.get",
+     "path": "b/{bucket}/o/{+object}",
+     "httpMethod": "GET",
+     "parameters": {
+      "bucket": {"type": "string", "required": true, "location": "path"},
+      "object": {"type": "string", "required": true, "location": "path"}
+     },
+     "response": {"$ref": "Object"},
+     "supportsMediaDownload": true,
+     "useMediaDownloadService": true
+    }
+   }
+  }
+ }
+}`
+
+func contentTypes(content *openapi3.MediaTypes) []string {
+	names := make([]string, 0)
+	for _, pair := range content.AdditionalProperties {
+		names = append(names, pair.Name)
+	}
+	return names
+}
+
+func parameterNamed(operation *openapi3.Operation, name string) *openapi3.Parameter {
+	for _, p := range operation.Parameters {
+		if parameter := p.GetParameter(); parameter != nil && parameter.Name == name {
+			return parameter
+		}
+	}
+	return nil
+}
+
+func pathItemNamed(d *openapi3.Document, path string) *openapi3.PathItem {
+	for _, pair := range d.Paths.Path {
+		if pair.Name == path {
+			return pair.Value
+		}
+	}
+	return nil
+}
+
+func TestMediaOpenAPIv3(t *testing.T) {
+	api, err := discovery.ParseDocument([]byte(mediaDiscoveryDocument))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	d, err := OpenAPIv3(api)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	upload := pathItemNamed(d, "/upload/storage/v1/b/{bucket}/o")
+	if upload == nil || upload.Post == nil {
+		t.Fatalf("missing simple upload operation")
+	}
+	if len(upload.Servers) != 1 || upload.Servers[0].Url != "https://storage.example.com" {
+		t.Errorf("unexpected upload servers %+v", upload.Servers)
+	}
+	if upload.Post.OperationId != "storage.objects.insert.upload" {
+		t.Errorf("unexpected upload operation id %q", upload.Post.OperationId)
+	}
+	uploadType := parameterNamed(upload.Post, "uploadType")
+	if uploadType == nil || !uploadType.Required || len(uploadType.Schema.GetSchema().Enum) != 2 {
+		t.Errorf("unexpected uploadType parameter %+v", uploadType)
+	}
+	requestBody := upload.Post.RequestBody.GetRequestBody()
+	if got := contentTypes(requestBody.Content); len(got) != 2 || got[0] != "image/*" || got[1] != "multipart/related" {
+		t.Errorf("unexpected upload content types %v", got)
+	}
+	if requestBody.Description != "Maximum size: 5TB" {
+		t.Errorf("unexpected upload description %q", requestBody.Description)
+	}
+
+	resumable := pathItemNamed(d, "/resumable/upload/storage/v1/b/{bucket}/o")
+	if resumable == nil || resumable.Post == nil {
+		t.Fatalf("missing resumable upload operation")
+	}
+	if resumable.Post.OperationId != "storage.objects.insert.resumableUpload" {
+		t.Errorf("unexpected resumable operation id %q", resumable.Post.OperationId)
+	}
+	if got := contentTypes(resumable.Post.RequestBody.GetRequestBody().Content); len(got) != 1 || got[0] != "application/json" {
+		t.Errorf("unexpected resumable content types %v", got)
+	}
+
+	get := pathItemNamed(d, "/b/{bucket}/o/{object}")
+	if get == nil || get.Get == nil {
+		t.Fatalf("missing get operation")
+	}
+	if parameterNamed(get.Get, "alt") == nil {
+		t.Errorf("missing alt parameter")
+	}
+	response := get.Get.Responses.ResponseOrReference[0].Value.GetResponse()
+	if got := contentTypes(response.Content); len(got) != 2 || got[1] != "application/octet-stream" {
+		t.Errorf("unexpected download content types %v", got)
+	}
+	download := pathItemNamed(d, "/download/storage/v1/b/{bucket}/o/{object}")
+	if download == nil || download.Get == nil || download.Get.OperationId != "storage.objects.get.download" {
+		t.Fatalf("missing download operation")
+	}
+}
+
+func TestMediaOpenAPIv2(t *testing.T) {
+	api, err := discovery.ParseDocument([]byte(mediaDiscoveryDocument))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	d, err := OpenAPIv2(api)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var insert, get bool
+	for _, pair := range d.Paths.Path {
+		if operation := pair.Value.Post; operation != nil && operation.OperationId == "storage.objects.insert" {
+			insert = true
+			if len(operation.Consumes) != 3 || operation.Consumes[2] != "multipart/related" {
+				t.Errorf("unexpected consumes %v", operation.Consumes)
+			}
+			if len(operation.VendorExtension) != 1 || operation.VendorExtension[0].Name != "x-google-media-upload" {
+				t.Errorf("missing media upload extension")
+			}
+		}
+		if operation := pair.Value.Get; operation != nil && operation.OperationId == "storage.objects.get" {
+			get = true
+			if len(operation.Produces) != 2 || operation.Produces[1] != "application/octet-stream" {
+				t.Errorf("unexpected produces %v", operation.Produces)
+			}
+		}
+	}
+	if !insert || !get {
+		t.Errorf("missing operations")
+	}
+}
@@ -213,6 +213,7 @@ func getOpenAPI2PathItemForPath(d *openapi2.Document, path string) *openapi2.Pat
 
 func addOpenAPI2PathsForMethod(d *openapi2.Document, name string, method *discovery.Method) {
 	operation := buildOpenAPI2OperationForMethod(method)
+	addOpenAPI2MediaToOperation(operation, method)
 	pathItem := getOpenAPI2PathItemForPath(d, pathForMethod(method.Path))
 	switch method.HttpMethod {
 	case "GET":
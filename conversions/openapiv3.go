@@ -226,10 +226,8 @@ func getOpenAPI3PathItemForPath(d *openapi3.Document, path string) *openapi3.Pat
 	return pathItem
 }
 
-func addOpenAPI3PathsForMethod(d *openapi3.Document, name string, method *discovery.Method, hasDataWrapper bool) {
-	operation := buildOpenAPI3OperationForMethod(method, hasDataWrapper)
-	pathItem := getOpenAPI3PathItemForPath(d, pathForMethod(method.Path))
-	switch method.HttpMethod {
+func setOpenAPI3OperationForMethod(pathItem *openapi3.PathItem, httpMethod string, operation *openapi3.Operation) {
+	switch httpMethod {
 	case "GET":
 		pathItem.Get = operation
 	case "POST":
@@ -241,10 +239,20 @@ func addOpenAPI3PathsForMethod(d *openapi3.Document, name string, method *discov
 	case "PATCH":
 		pathItem.Patch = operation
 	default:
-		log.Printf("WARNING: Unknown HTTP method %s", method.HttpMethod)
+		log.Printf("WARNING: Unknown HTTP method %s", httpMethod)
 	}
 }
 
+func addOpenAPI3PathsForMethod(d *openapi3.Document, name string, method *discovery.Method, hasDataWrapper bool) {
+	operation := buildOpenAPI3OperationForMethod(method, hasDataWrapper)
+	if method.SupportsMediaDownload {
+		addOpenAPI3MediaDownloadToOperation(operation, method)
+	}
+	pathItem := getOpenAPI3PathItemForPath(d, pathForMethod(method.Path))
+	setOpenAPI3OperationForMethod(pathItem, method.HttpMethod, operation)
+	addOpenAPI3MediaPathsForMethod(d, method, hasDataWrapper)
+}
+
 func addOpenAPI3PathsForResource(d *openapi3.Document, resource *discovery.Resource, hasDataWrapper bool) {
 	if resource.Methods != nil {
 		for _, pair := range resource.Methods.AdditionalProperties {
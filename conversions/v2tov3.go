@@ -0,0 +1,770 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi2 "github.com/okkoye/gnostic/openapiv2"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// ConvertV2ToV3 converts an OpenAPI v2 document to OpenAPI v3:
+//
+//   - host, basePath, and schemes become servers,
+//   - definitions, parameters, and responses move to components and
+//     references to them are rewritten,
+//   - body parameters become request bodies, and formData parameters become
+//     the properties of multipart/form-data or
+//     application/x-www-form-urlencoded request bodies,
+//   - consumes and produces become the media types of request bodies and
+//     responses, and collectionFormat becomes style and explode,
+//   - securityDefinitions become securitySchemes, and
+//   - x-nullable becomes nullable.
+//
+// The report lists the parts of the document that OpenAPI v3 can't describe.
+// Because the fields of compiled documents don't record whether zero values
+// were specified, values like "minimum: 0" are lost in conversions.
+func ConvertV2ToV3(document *openapi2.Document) (*openapi3.Document, *FidelityReport, error) {
+	if document == nil {
+		return nil, nil, errors.New("no document to convert")
+	}
+	c := &v2Converter{source: document, root: openapi2.RawInfo(document), report: &FidelityReport{}}
+	node := c.document()
+	converted, err := openapi3.NewDocument(node, compiler.NewContext("$root", node, nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	return converted, c.report, nil
+}
+
+// v2Converter converts the YAML of an OpenAPI v2 document.
+type v2Converter struct {
+	source   *openapi2.Document
+	root     *yaml.Node
+	report   *FidelityReport
+	consumes []string // the default media types of request bodies
+	produces []string // the default media types of responses
+}
+
+// A v2Parameter is a parameter of an operation or path item.
+type v2Parameter struct {
+	node *yaml.Node // the parameter, or the definition that ref refers to
+	ref  string
+	path string
+}
+
+func (p *v2Parameter) in() string {
+	if p.node == nil {
+		return ""
+	}
+	return stringForKey(p.node, "in")
+}
+
+func (p *v2Parameter) name() string {
+	if p.node == nil {
+		return p.ref
+	}
+	return stringForKey(p.node, "name")
+}
+
+// v2 references and the v3 references that replace them.
+var v2ReferencePrefixes = [][2]string{
+	{"#/definitions/", "#/components/schemas/"},
+	{"#/parameters/", "#/components/parameters/"},
+	{"#/responses/", "#/components/responses/"},
+}
+
+// v2 oauth2 flows and the v3 flows that replace them.
+var v2OAuthFlows = map[string]string{
+	"implicit":    "implicit",
+	"password":    "password",
+	"application": "clientCredentials",
+	"accessCode":  "authorizationCode",
+}
+
+var v2OperationKeys = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+const (
+	mediaTypeJSON      = "application/json"
+	mediaTypeForm      = "application/x-www-form-urlencoded"
+	mediaTypeMultipart = "multipart/form-data"
+)
+
+func (c *v2Converter) document() *yaml.Node {
+	d := c.root
+	c.consumes = stringsForKey(d, "consumes")
+	c.produces = stringsForKey(d, "produces")
+	result := compiler.NewMappingNode()
+	appendPair(result, "openapi", stringNode("3.0.3"))
+	copyPairs(result, d, "info")
+	schemes := stringsForKey(d, "schemes")
+	if servers := c.servers(schemes, "#/schemes"); servers != nil {
+		appendPair(result, "servers", servers)
+	}
+	paths := compiler.NewMappingNode()
+	if node := compiler.MapValueForKey(d, "paths"); node != nil {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if isExtension(key.Value) {
+				appendPair(paths, key.Value, value)
+				continue
+			}
+			appendPair(paths, key.Value, c.pathItem(value, pointer("#/paths", key.Value)))
+		}
+	}
+	appendPair(result, "paths", paths)
+	if components := c.components(); len(components.Content) > 0 {
+		appendPair(result, "components", components)
+	}
+	copyPairs(result, d, "security", "tags", "externalDocs")
+	copyExtensions(result, d)
+	return result
+}
+
+// servers returns the servers that serve an API with the document's host
+// and basePath and the specified schemes.
+func (c *v2Converter) servers(schemes []string, path string) *yaml.Node {
+	host := stringForKey(c.root, "host")
+	basePath := stringForKey(c.root, "basePath")
+	if host == "" {
+		if len(schemes) > 0 {
+			c.report.add(path, "schemes of documents without a host can't be described by server URLs")
+		}
+		if basePath == "" {
+			return nil
+		}
+		return sequenceNode(mappingNode("url", stringNode(basePath)))
+	}
+	if len(schemes) == 0 {
+		// without schemes, the API uses the scheme of the document
+		return sequenceNode(mappingNode("url", stringNode("//"+host+basePath)))
+	}
+	servers := sequenceNode()
+	for _, scheme := range schemes {
+		servers.Content = append(servers.Content, mappingNode("url", stringNode(scheme+"://"+host+basePath)))
+	}
+	return servers
+}
+
+func (c *v2Converter) pathItem(node *yaml.Node, path string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	// Body and formData parameters of path items move to the request bodies of their operations.
+	bodyParameters := make([]*v2Parameter, 0)
+	parameters := sequenceNode()
+	if node := compiler.MapValueForKey(node, "parameters"); node != nil {
+		for i, item := range node.Content {
+			p := c.parameter(item, pointer(pointer(path, "parameters"), strconv.Itoa(i)))
+			switch p.in() {
+			case "body", "formData":
+				bodyParameters = append(bodyParameters, p)
+			default:
+				parameters.Content = append(parameters.Content, c.parameterNode(p))
+			}
+		}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case key.Value == "$ref":
+			appendPair(result, key.Value, value)
+		case compiler.StringArrayContainsValue(v2OperationKeys, key.Value):
+			appendPair(result, key.Value, c.operation(value, pointer(path, key.Value), bodyParameters))
+		case isExtension(key.Value):
+			appendPair(result, key.Value, value)
+		}
+	}
+	if len(parameters.Content) > 0 {
+		appendPair(result, "parameters", parameters)
+	}
+	return result
+}
+
+// parameter returns a parameter, resolving references to parameter definitions.
+func (c *v2Converter) parameter(node *yaml.Node, path string) *v2Parameter {
+	p := &v2Parameter{node: node, path: path}
+	if ref := stringForKey(node, "$ref"); ref != "" {
+		p.ref = ref
+		p.node = nil
+		if strings.HasPrefix(ref, "#/parameters/") {
+			name := unescape(strings.TrimPrefix(ref, "#/parameters/"))
+			p.node = compiler.MapValueForKey(compiler.MapValueForKey(c.root, "parameters"), name)
+		}
+		if p.node == nil {
+			c.report.add(path, "unable to resolve %s, which is assumed not to be a body or formData parameter", ref)
+		}
+	}
+	return p
+}
+
+func (c *v2Converter) operation(node *yaml.Node, path string, pathParameters []*v2Parameter) *yaml.Node {
+	consumes, produces := c.consumes, c.produces
+	if compiler.MapHasKey(node, "consumes") {
+		consumes = stringsForKey(node, "consumes")
+	}
+	if compiler.MapHasKey(node, "produces") {
+		produces = stringsForKey(node, "produces")
+	}
+	// Operation parameters override path item parameters with the same name and location.
+	operationParameters := make([]*v2Parameter, 0)
+	if value := compiler.MapValueForKey(node, "parameters"); value != nil {
+		for i, item := range value.Content {
+			operationParameters = append(operationParameters, c.parameter(item, pointer(pointer(path, "parameters"), strconv.Itoa(i))))
+		}
+	}
+	all := make([]*v2Parameter, 0)
+	for _, p := range pathParameters {
+		overridden := false
+		for _, o := range operationParameters {
+			if o.in() == p.in() && o.name() == p.name() {
+				overridden = true
+			}
+		}
+		if !overridden {
+			all = append(all, p)
+		}
+	}
+	all = append(all, operationParameters...)
+
+	result := compiler.NewMappingNode()
+	parameters := sequenceNode()
+	var body *v2Parameter
+	form := make([]*v2Parameter, 0)
+	for _, p := range all {
+		switch p.in() {
+		case "body":
+			if body != nil {
+				c.report.add(p.path, "operations can only have one body parameter")
+				continue
+			}
+			body = p
+		case "formData":
+			form = append(form, p)
+		default:
+			parameters.Content = append(parameters.Content, c.parameterNode(p))
+		}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security":
+			appendPair(result, key.Value, value)
+		case "responses":
+			appendPair(result, key.Value, c.responses(value, pointer(path, key.Value), produces))
+		case "schemes":
+			if servers := c.servers(compiler.StringArrayForSequenceNode(value), pointer(path, key.Value)); servers != nil {
+				appendPair(result, "servers", servers)
+			}
+		default:
+			if isExtension(key.Value) {
+				appendPair(result, key.Value, value)
+			}
+		}
+	}
+	if len(parameters.Content) > 0 {
+		appendPair(result, "parameters", parameters)
+	}
+	if body != nil {
+		for _, p := range form {
+			c.report.add(p.path, "formData parameters can't be combined with a body parameter")
+		}
+		appendPair(result, "requestBody", c.bodyRequestBodyForParameter(body, consumes))
+	} else if len(form) > 0 {
+		appendPair(result, "requestBody", c.formRequestBody(form, consumes))
+	}
+	return result
+}
+
+func (c *v2Converter) parameterNode(p *v2Parameter) *yaml.Node {
+	if p.ref != "" {
+		return mappingNode("$ref", stringNode(rewriteReference(p.ref, v2ReferencePrefixes)))
+	}
+	return c.nonBodyParameter(p.node, p.path)
+}
+
+func (c *v2Converter) nonBodyParameter(node *yaml.Node, path string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "name", "in", "description", "required", "allowEmptyValue":
+			appendPair(result, key.Value, value)
+		default:
+			if isExtension(key.Value) {
+				appendPair(result, key.Value, value)
+			}
+		}
+	}
+	appendPair(result, "schema", c.primitiveSchema(node, path))
+	if style, explode, ok := c.collectionStyle(node, stringForKey(node, "in"), path); ok {
+		appendPair(result, "style", stringNode(style))
+		appendPair(result, "explode", compiler.NewScalarNodeForBool(explode))
+	}
+	return result
+}
+
+// primitiveSchema returns the schema of a non-body parameter, header, or item.
+func (c *v2Converter) primitiveSchema(node *yaml.Node, path string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "type":
+			if value.Value == "file" {
+				appendPair(result, "type", stringNode("string"))
+				appendPair(result, "format", stringNode("binary"))
+				continue
+			}
+		case "format":
+			if stringForKey(node, "type") == "file" {
+				continue
+			}
+		case "items":
+			itemsPath := pointer(path, key.Value)
+			if format := stringForKey(value, "collectionFormat"); format != "" && format != "csv" {
+				c.report.add(itemsPath, "collectionFormat %s can't be described for nested arrays", format)
+			}
+			value = c.primitiveSchema(value, itemsPath)
+		case "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum",
+			"maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems",
+			"enum", "multipleOf":
+		default:
+			continue
+		}
+		appendPair(result, key.Value, value)
+	}
+	return result
+}
+
+// collectionStyle returns the style and explode values that describe the
+// collectionFormat of an array parameter in a location.
+func (c *v2Converter) collectionStyle(node *yaml.Node, in string, path string) (string, bool, bool) {
+	if stringForKey(node, "type") != "array" {
+		return "", false, false
+	}
+	format := stringForKey(node, "collectionFormat")
+	query := in == "query" || in == "formData"
+	switch {
+	case format == "" || format == "csv":
+		if query {
+			// compiled documents don't record explode values of false
+			c.report.add(path, "comma-separated arrays of %s parameters are read as exploded arrays", in)
+			return "form", false, true
+		}
+		return "simple", false, true
+	case format == "multi" && query:
+		return "form", true, true
+	case format == "ssv" && query:
+		return "spaceDelimited", false, true
+	case format == "pipes" && query:
+		return "pipeDelimited", false, true
+	}
+	c.report.add(pointer(path, "collectionFormat"), "collectionFormat %s can't be described for %s parameters", format, in)
+	return "", false, false
+}
+
+func (c *v2Converter) bodyRequestBodyForParameter(p *v2Parameter, consumes []string) *yaml.Node {
+	if p.ref != "" && strings.HasPrefix(p.ref, "#/parameters/") && equalStrings(consumes, c.consumes) {
+		return mappingNode("$ref", stringNode("#/components/requestBodies/"+strings.TrimPrefix(p.ref, "#/parameters/")))
+	}
+	return c.bodyRequestBody(p.node, p.path, consumes)
+}
+
+func (c *v2Converter) bodyRequestBody(node *yaml.Node, path string, consumes []string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	copyPairs(result, node, "description", "required")
+	copyExtensions(result, node)
+	schema := c.schema(compiler.MapValueForKey(node, "schema"), pointer(path, "schema"))
+	content := compiler.NewMappingNode()
+	for _, mediaType := range mediaTypesOrDefault(consumes) {
+		appendPair(content, mediaType, mappingNode("schema", schema))
+	}
+	appendPair(result, "content", content)
+	return result
+}
+
+// formRequestBody returns a request body with a property for each formData parameter.
+func (c *v2Converter) formRequestBody(parameters []*v2Parameter, consumes []string) *yaml.Node {
+	properties := compiler.NewMappingNode()
+	required := sequenceNode()
+	encodings := compiler.NewMappingNode()
+	files := false
+	for _, p := range parameters {
+		if p.node == nil {
+			continue
+		}
+		name := p.name()
+		property := c.primitiveSchema(p.node, p.path)
+		copyPairs(property, p.node, "description")
+		appendPair(properties, name, property)
+		if isTrue(compiler.MapValueForKey(p.node, "required")) {
+			required.Content = append(required.Content, stringNode(name))
+		}
+		if stringForKey(p.node, "type") == "file" {
+			files = true
+		}
+		if isTrue(compiler.MapValueForKey(p.node, "allowEmptyValue")) {
+			c.report.add(pointer(p.path, "allowEmptyValue"), "allowEmptyValue can't be described for properties of request bodies")
+		}
+		if style, explode, ok := c.collectionStyle(p.node, "formData", p.path); ok {
+			encoding := mappingNode("style", stringNode(style))
+			appendPair(encoding, "explode", compiler.NewScalarNodeForBool(explode))
+			appendPair(encodings, name, encoding)
+		}
+	}
+	schema := mappingNode("type", stringNode("object"))
+	appendPair(schema, "properties", properties)
+	if len(required.Content) > 0 {
+		appendPair(schema, "required", required)
+	}
+	mediaTypes := make([]string, 0)
+	for _, mediaType := range consumes {
+		if mediaType == mediaTypeForm || mediaType == mediaTypeMultipart {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		if files {
+			mediaTypes = append(mediaTypes, mediaTypeMultipart)
+		} else {
+			mediaTypes = append(mediaTypes, mediaTypeForm)
+		}
+	}
+	content := compiler.NewMappingNode()
+	for _, mediaType := range mediaTypes {
+		mediaTypeNode := mappingNode("schema", schema)
+		if mediaType == mediaTypeForm && len(encodings.Content) > 0 {
+			appendPair(mediaTypeNode, "encoding", encodings)
+		}
+		appendPair(content, mediaType, mediaTypeNode)
+	}
+	result := mappingNode("content", content)
+	if len(required.Content) > 0 {
+		appendPair(result, "required", compiler.NewScalarNodeForBool(true))
+	}
+	return result
+}
+
+func (c *v2Converter) responses(node *yaml.Node, path string, produces []string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if isExtension(key.Value) {
+			appendPair(result, key.Value, value)
+			continue
+		}
+		appendPair(result, key.Value, c.response(value, pointer(path, key.Value), produces))
+	}
+	return result
+}
+
+func (c *v2Converter) response(node *yaml.Node, path string, produces []string) *yaml.Node {
+	if ref := stringForKey(node, "$ref"); ref != "" {
+		return mappingNode("$ref", stringNode(rewriteReference(ref, v2ReferencePrefixes)))
+	}
+	result := compiler.NewMappingNode()
+	copyPairs(result, node, "description")
+	if headers := compiler.MapValueForKey(node, "headers"); headers != nil {
+		converted := compiler.NewMappingNode()
+		for i := 0; i+1 < len(headers.Content); i += 2 {
+			name, header := headers.Content[i].Value, headers.Content[i+1]
+			converted.Content = append(converted.Content, stringNode(name), c.header(header, pointer(pointer(path, "headers"), name)))
+		}
+		appendPair(result, "headers", converted)
+	}
+	schema := compiler.MapValueForKey(node, "schema")
+	examples := compiler.MapValueForKey(node, "examples")
+	if schema != nil || examples != nil {
+		mediaTypes := mediaTypesOrDefault(produces)
+		if examples != nil {
+			for i := 0; i+1 < len(examples.Content); i += 2 {
+				if !compiler.StringArrayContainsValue(mediaTypes, examples.Content[i].Value) {
+					mediaTypes = append(mediaTypes, examples.Content[i].Value)
+				}
+			}
+		}
+		if schema != nil {
+			schema = c.schema(schema, pointer(path, "schema"))
+		}
+		content := compiler.NewMappingNode()
+		for _, mediaType := range mediaTypes {
+			mediaTypeNode := compiler.NewMappingNode()
+			if schema != nil {
+				appendPair(mediaTypeNode, "schema", schema)
+			}
+			if example := compiler.MapValueForKey(examples, mediaType); example != nil {
+				appendPair(mediaTypeNode, "example", example)
+			}
+			appendPair(content, mediaType, mediaTypeNode)
+		}
+		appendPair(result, "content", content)
+	}
+	copyExtensions(result, node)
+	return result
+}
+
+func (c *v2Converter) header(node *yaml.Node, path string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	copyPairs(result, node, "description")
+	appendPair(result, "schema", c.primitiveSchema(node, path))
+	c.collectionStyle(node, "header", path)
+	copyExtensions(result, node)
+	return result
+}
+
+func (c *v2Converter) components() *yaml.Node {
+	result := compiler.NewMappingNode()
+	if node := compiler.MapValueForKey(c.root, "definitions"); node != nil {
+		schemas := compiler.NewMappingNode()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			appendPair(schemas, name, c.schema(node.Content[i+1], pointer("#/definitions", name)))
+		}
+		appendPair(result, "schemas", schemas)
+	}
+	if node := compiler.MapValueForKey(c.root, "responses"); node != nil {
+		responses := compiler.NewMappingNode()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			appendPair(responses, name, c.response(node.Content[i+1], pointer("#/responses", name), c.produces))
+		}
+		appendPair(result, "responses", responses)
+	}
+	if node := compiler.MapValueForKey(c.root, "parameters"); node != nil {
+		// formData parameters are copied into the request bodies of the operations that use them.
+		parameters := compiler.NewMappingNode()
+		requestBodies := compiler.NewMappingNode()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, parameter := node.Content[i].Value, node.Content[i+1]
+			path := pointer("#/parameters", name)
+			switch stringForKey(parameter, "in") {
+			case "body":
+				appendPair(requestBodies, name, c.bodyRequestBody(parameter, path, c.consumes))
+			case "formData":
+			default:
+				appendPair(parameters, name, c.nonBodyParameter(parameter, path))
+			}
+		}
+		if len(parameters.Content) > 0 {
+			appendPair(result, "parameters", parameters)
+		}
+		if len(requestBodies.Content) > 0 {
+			appendPair(result, "requestBodies", requestBodies)
+		}
+	}
+	if node := compiler.MapValueForKey(c.root, "securityDefinitions"); node != nil {
+		schemes := compiler.NewMappingNode()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			appendPair(schemes, name, c.securityScheme(name, node.Content[i+1]))
+		}
+		appendPair(result, "securitySchemes", schemes)
+	}
+	return result
+}
+
+func (c *v2Converter) securityScheme(name string, node *yaml.Node) *yaml.Node {
+	result := compiler.NewMappingNode()
+	switch stringForKey(node, "type") {
+	case "basic":
+		appendPair(result, "type", stringNode("http"))
+		appendPair(result, "scheme", stringNode("basic"))
+	case "apiKey":
+		appendPair(result, "type", stringNode("apiKey"))
+		copyPairs(result, node, "name", "in")
+	case "oauth2":
+		flow := compiler.NewMappingNode()
+		copyPairs(flow, node, "authorizationUrl", "tokenUrl")
+		scopes := compiler.NewMappingNode()
+		for _, scope := range c.scopes(name) {
+			appendPair(scopes, scope.Name, stringNode(scope.Value))
+		}
+		appendPair(flow, "scopes", scopes)
+		appendPair(result, "type", stringNode("oauth2"))
+		appendPair(result, "flows", mappingNode(v2OAuthFlows[stringForKey(node, "flow")], flow))
+	}
+	copyPairs(result, node, "description")
+	copyExtensions(result, node)
+	return result
+}
+
+// scopes returns the scopes of an oauth2 security definition, which the YAML
+// of compiled documents doesn't include.
+func (c *v2Converter) scopes(name string) []*openapi2.NamedString {
+	for _, pair := range c.source.GetSecurityDefinitions().GetAdditionalProperties() {
+		if pair.Name != name {
+			continue
+		}
+		switch item := pair.Value; {
+		case item.GetOauth2ImplicitSecurity() != nil:
+			return item.GetOauth2ImplicitSecurity().GetScopes().GetAdditionalProperties()
+		case item.GetOauth2PasswordSecurity() != nil:
+			return item.GetOauth2PasswordSecurity().GetScopes().GetAdditionalProperties()
+		case item.GetOauth2ApplicationSecurity() != nil:
+			return item.GetOauth2ApplicationSecurity().GetScopes().GetAdditionalProperties()
+		case item.GetOauth2AccessCodeSecurity() != nil:
+			return item.GetOauth2AccessCodeSecurity().GetScopes().GetAdditionalProperties()
+		}
+	}
+	return nil
+}
+
+// schema converts a v2 schema. v2 schemas are a subset of v3 schemas except
+// for their discriminators, x-nullable, and the "file" type.
+func (c *v2Converter) schema(node *yaml.Node, path string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return node
+	}
+	if ref := stringForKey(node, "$ref"); ref != "" {
+		return mappingNode("$ref", stringNode(rewriteReference(ref, v2ReferencePrefixes)))
+	}
+	result := compiler.NewMappingNode()
+	file := stringForKey(node, "type") == "file"
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "x-nullable":
+			continue
+		case "type":
+			if file {
+				appendPair(result, "type", stringNode("string"))
+				appendPair(result, "format", stringNode("binary"))
+				continue
+			}
+			if value.Kind == yaml.SequenceNode {
+				c.report.add(pointer(path, key.Value), "schemas can only have one type")
+				if len(value.Content) == 0 {
+					continue
+				}
+				value = value.Content[0]
+			}
+		case "format":
+			if file {
+				continue
+			}
+		case "discriminator":
+			value = mappingNode("propertyName", value)
+		case "properties":
+			properties := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name := value.Content[j].Value
+				appendPair(properties, name, c.schema(value.Content[j+1], pointer(pointer(path, key.Value), name)))
+			}
+			value = properties
+		case "items":
+			if value.Kind == yaml.SequenceNode {
+				if len(value.Content) != 1 {
+					c.report.add(pointer(path, key.Value), "items can only have one schema")
+				}
+				if len(value.Content) == 0 {
+					continue
+				}
+				value = value.Content[0]
+			}
+			value = c.schema(value, pointer(path, key.Value))
+		case "additionalProperties":
+			value = c.schema(value, pointer(path, key.Value))
+		case "allOf":
+			allOf := sequenceNode()
+			for j, item := range value.Content {
+				allOf.Content = append(allOf.Content, c.schema(item, pointer(pointer(path, key.Value), strconv.Itoa(j))))
+			}
+			value = allOf
+		}
+		appendPair(result, key.Value, value)
+	}
+	if isTrue(compiler.MapValueForKey(node, "x-nullable")) {
+		appendPair(result, "nullable", compiler.NewScalarNodeForBool(true))
+	}
+	return result
+}
+
+// rewriteReference replaces the first matching prefix of the fragment of a
+// reference, which may refer to another document.
+func rewriteReference(ref string, prefixes [][2]string) string {
+	i := strings.Index(ref, "#")
+	if i < 0 {
+		return ref
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(ref[i:], prefix[0]) {
+			return ref[:i] + prefix[1] + ref[i+len(prefix[0]):]
+		}
+	}
+	return ref
+}
+
+// stringsForKey returns the strings in the array value of a key.
+func stringsForKey(node *yaml.Node, key string) []string {
+	value := compiler.MapValueForKey(node, key)
+	if value == nil {
+		return nil
+	}
+	return compiler.StringArrayForSequenceNode(value)
+}
+
+func mediaTypesOrDefault(mediaTypes []string) []string {
+	if len(mediaTypes) == 0 {
+		return []string{mediaTypeJSON}
+	}
+	return append([]string{}, mediaTypes...)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isExtension(key string) bool {
+	return strings.HasPrefix(key, "x-")
+}
+
+func appendPair(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, stringNode(key), value)
+}
+
+// copyPairs copies the values of keys from one mapping to another.
+func copyPairs(to, from *yaml.Node, keys ...string) {
+	for _, key := range keys {
+		if value := compiler.MapValueForKey(from, key); value != nil {
+			appendPair(to, key, value)
+		}
+	}
+}
+
+func copyExtensions(to, from *yaml.Node) {
+	for i := 0; i+1 < len(from.Content); i += 2 {
+		if isExtension(from.Content[i].Value) {
+			appendPair(to, from.Content[i].Value, from.Content[i+1])
+		}
+	}
+}
+
+// unescape decodes a token of a JSON pointer.
+func unescape(token string) string {
+	return strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+}
@@ -0,0 +1,191 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openapi2 "github.com/okkoye/gnostic/openapiv2"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const v2Document = `swagger: "2.0"
+info: {title: Pets, version: "1"}
+host: pets.example.com
+basePath: /v1
+schemes: [https, http]
+consumes: [application/json]
+produces: [application/json]
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: tags, in: query, type: array, items: {type: string}, collectionFormat: multi}
+        - {name: ids, in: header, type: array, items: {type: integer}, collectionFormat: tsv}
+      responses:
+        "200":
+          description: pets
+          schema: {type: array, items: {$ref: "#/definitions/Pet"}}
+          examples: {application/json: [{name: Rex}]}
+    post:
+      parameters:
+        - $ref: "#/parameters/pet"
+      responses:
+        "201": {$ref: "#/responses/created"}
+  /pets/{id}/photo:
+    parameters:
+      - {name: id, in: path, required: true, type: string}
+    put:
+      consumes: [multipart/form-data]
+      parameters:
+        - {name: photo, in: formData, required: true, type: file}
+        - {name: caption, in: formData, type: string, description: a caption}
+      responses:
+        "204": {description: uploaded}
+      security:
+        - oauth: [write]
+definitions:
+  Pet:
+    type: object
+    discriminator: kind
+    required: [name, kind]
+    properties:
+      name: {type: string}
+      kind: {type: string}
+      owner: {type: string, x-nullable: true}
+parameters:
+  pet: {name: pet, in: body, required: true, schema: {$ref: "#/definitions/Pet"}}
+responses:
+  created: {description: created}
+securityDefinitions:
+  basic: {type: basic}
+  key: {type: apiKey, name: X-Key, in: header}
+  oauth:
+    type: oauth2
+    flow: accessCode
+    authorizationUrl: https://example.com/authorize
+    tokenUrl: https://example.com/token
+    scopes: {write: write pets}
+`
+
+func TestConvertV2ToV3(t *testing.T) {
+	document, err := openapi2.ParseDocument([]byte(v2Document))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	d, report, err := ConvertV2ToV3(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if d.Openapi != "3.0.3" || len(d.Servers) != 2 || d.Servers[0].Url != "https://pets.example.com/v1" {
+		t.Errorf("unexpected document %+v", d)
+	}
+
+	pets := d.Paths.Path[0].Value
+	tags := pets.Get.Parameters[0].GetParameter()
+	if tags.Style != "form" || !tags.Explode || tags.Schema.GetSchema().Type != "array" {
+		t.Errorf("unexpected parameter %+v", tags)
+	}
+	content := pets.Get.Responses.ResponseOrReference[0].Value.GetResponse().Content.AdditionalProperties[0]
+	if content.Name != "application/json" || content.Value.Example == nil ||
+		content.Value.Schema.GetSchema().Items.SchemaOrReference[0].GetReference().XRef != "#/components/schemas/Pet" {
+		t.Errorf("unexpected content %+v", content)
+	}
+	if ref := pets.Post.RequestBody.GetReference(); ref == nil || ref.XRef != "#/components/requestBodies/pet" {
+		t.Errorf("unexpected request body %+v", pets.Post.RequestBody)
+	}
+	if ref := pets.Post.Responses.ResponseOrReference[0].Value.GetReference(); ref == nil || ref.XRef != "#/components/responses/created" {
+		t.Errorf("unexpected response %+v", pets.Post.Responses)
+	}
+
+	photo := d.Paths.Path[1].Value
+	if len(photo.Parameters) != 1 || photo.Parameters[0].GetParameter().Name != "id" {
+		t.Errorf("unexpected path parameters %+v", photo.Parameters)
+	}
+	body := photo.Put.RequestBody.GetRequestBody()
+	if !body.Required || len(body.Content.AdditionalProperties) != 1 || body.Content.AdditionalProperties[0].Name != "multipart/form-data" {
+		t.Fatalf("unexpected request body %+v", body)
+	}
+	schema := body.Content.AdditionalProperties[0].Value.Schema.GetSchema()
+	if schema.Type != "object" || len(schema.Required) != 1 || schema.Required[0] != "photo" {
+		t.Errorf("unexpected schema %+v", schema)
+	}
+	file := schema.Properties.AdditionalProperties[0].Value.GetSchema()
+	caption := schema.Properties.AdditionalProperties[1].Value.GetSchema()
+	if file.Type != "string" || file.Format != "binary" || caption.Description != "a caption" {
+		t.Errorf("unexpected properties %+v", schema.Properties)
+	}
+
+	pet := d.Components.Schemas.AdditionalProperties[0].Value.GetSchema()
+	if pet.Discriminator.PropertyName != "kind" || !pet.Properties.AdditionalProperties[2].Value.GetSchema().Nullable {
+		t.Errorf("unexpected schema %+v", pet)
+	}
+	if len(d.Components.RequestBodies.AdditionalProperties) != 1 || d.Components.Parameters != nil {
+		t.Errorf("unexpected components %+v", d.Components)
+	}
+	schemes := d.Components.SecuritySchemes.AdditionalProperties
+	if len(schemes) != 3 {
+		t.Fatalf("unexpected security schemes %+v", schemes)
+	}
+	if basic := schemes[0].Value.GetSecurityScheme(); basic.Type != "http" || basic.Scheme != "basic" {
+		t.Errorf("unexpected security scheme %+v", basic)
+	}
+	if key := schemes[1].Value.GetSecurityScheme(); key.Type != "apiKey" || key.Name != "X-Key" || key.In != "header" {
+		t.Errorf("unexpected security scheme %+v", key)
+	}
+	flow := schemes[2].Value.GetSecurityScheme().Flows.AuthorizationCode
+	if flow == nil || flow.TokenUrl != "https://example.com/token" || flow.Scopes.AdditionalProperties[0].Name != "write" {
+		t.Errorf("unexpected security scheme %+v", schemes[2].Value)
+	}
+
+	expected := "#/paths/~1pets/get/parameters/1/collectionFormat: collectionFormat tsv can't be described for header parameters"
+	if report.String() != expected {
+		t.Errorf("unexpected report %s", report.String())
+	}
+}
+
+func TestConvertV2ToV3Examples(t *testing.T) {
+	filenames, err := filepath.Glob("../examples/v2.0/yaml/*.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, filename := range filenames {
+		bytes, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		document, err := openapi2.ParseDocument(bytes)
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		converted, report, err := ConvertV2ToV3(document)
+		if err != nil {
+			t.Errorf("%s: %+v", filename, err)
+			continue
+		}
+		for _, loss := range report.Losses {
+			if !strings.HasSuffix(loss.Message, "are read as exploded arrays") {
+				t.Errorf("%s: unexpected loss %+v", filename, loss)
+			}
+		}
+		// the converted document should be a valid OpenAPI v3 document
+		if _, err := openapi3.NewDocument(converted.ToRawInfo(), nil); err != nil {
+			t.Errorf("%s: %+v", filename, err)
+		}
+	}
+}
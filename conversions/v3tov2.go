@@ -0,0 +1,748 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi2 "github.com/okkoye/gnostic/openapiv2"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// ConvertV3ToV2 converts an OpenAPI v3 document to OpenAPI v2, reversing
+// the translations of ConvertV2ToV3. Request bodies with form media types
+// become formData parameters and other request bodies become body
+// parameters named "body". Where a request body or response has several
+// media types, the schema of the first one is used.
+//
+// OpenAPI v2 describes less than OpenAPI v3, so conversions often lose
+// something, like cookie parameters, callbacks, links, servers with other
+// hosts, oneOf and anyOf schemas, and bearer and OpenID Connect security
+// schemes. The report lists everything that was lost.
+func ConvertV3ToV2(document *openapi3.Document) (*openapi2.Document, *FidelityReport, error) {
+	if document == nil {
+		return nil, nil, errors.New("no document to convert")
+	}
+	c := &v3Converter{source: document, root: openapi3.RawInfo(document), report: &FidelityReport{}}
+	node := c.document()
+	converted, err := openapi2.NewDocument(node, compiler.NewContext("$root", node, nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	return converted, c.report, nil
+}
+
+// v3Converter converts the YAML of an OpenAPI v3 document.
+type v3Converter struct {
+	source *openapi3.Document
+	root   *yaml.Node
+	report *FidelityReport
+}
+
+// v3 references and the v2 references that replace them.
+var v3ReferencePrefixes = [][2]string{
+	{"#/components/schemas/", "#/definitions/"},
+	{"#/components/parameters/", "#/parameters/"},
+	{"#/components/responses/", "#/responses/"},
+}
+
+// v3 oauth2 flows and the v2 flows that replace them, in order of preference.
+var v3OAuthFlows = [][2]string{
+	{"implicit", "implicit"},
+	{"password", "password"},
+	{"clientCredentials", "application"},
+	{"authorizationCode", "accessCode"},
+}
+
+func (c *v3Converter) document() *yaml.Node {
+	d := c.root
+	result := compiler.NewMappingNode()
+	appendPair(result, "swagger", stringNode("2.0"))
+	if info := compiler.MapValueForKey(d, "info"); info != nil {
+		appendPair(result, "info", c.info(info))
+	}
+	if servers := compiler.MapValueForKey(d, "servers"); servers != nil {
+		c.addServers(result, servers)
+	}
+	paths := compiler.NewMappingNode()
+	if node := compiler.MapValueForKey(d, "paths"); node != nil {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if isExtension(key.Value) {
+				appendPair(paths, key.Value, value)
+				continue
+			}
+			appendPair(paths, key.Value, c.pathItem(value, pointer("#/paths", key.Value)))
+		}
+	}
+	appendPair(result, "paths", paths)
+	hoistMediaTypes(result, paths, "consumes")
+	hoistMediaTypes(result, paths, "produces")
+	if components := compiler.MapValueForKey(d, "components"); components != nil {
+		c.addComponents(result, components)
+	}
+	copyPairs(result, d, "security", "tags", "externalDocs")
+	copyExtensions(result, d)
+	return result
+}
+
+func (c *v3Converter) info(node *yaml.Node) *yaml.Node {
+	result := compiler.NewMappingNode()
+	copyPairs(result, node, "title", "description", "termsOfService", "contact", "license", "version")
+	copyExtensions(result, node)
+	if compiler.MapHasKey(node, "summary") {
+		c.report.add("#/info/summary", "info can't have a summary")
+	}
+	return result
+}
+
+// addServers describes the servers of the API with a host, basePath, and
+// schemes, which can only describe servers with the same host and path.
+func (c *v3Converter) addServers(result *yaml.Node, servers *yaml.Node) {
+	var host, basePath string
+	schemes := make([]string, 0)
+	for i, server := range servers.Content {
+		path := pointer("#/servers", strconv.Itoa(i))
+		u, err := url.Parse(c.serverURL(server, path))
+		if err != nil {
+			c.report.add(path, "unable to parse server URL: %s", err.Error())
+			continue
+		}
+		if i == 0 {
+			host, basePath = u.Host, strings.TrimSuffix(u.Path, "/")
+		} else if u.Host != host || strings.TrimSuffix(u.Path, "/") != basePath {
+			c.report.add(path, "only servers with the host and path of the first server can be described")
+			continue
+		}
+		if u.Scheme != "" && !compiler.StringArrayContainsValue(schemes, u.Scheme) {
+			schemes = append(schemes, u.Scheme)
+		}
+	}
+	if host != "" {
+		appendPair(result, "host", stringNode(host))
+	}
+	if basePath != "" {
+		appendPair(result, "basePath", stringNode(basePath))
+	}
+	if len(schemes) > 0 {
+		appendPair(result, "schemes", compiler.NewSequenceNodeForStringArray(schemes))
+	}
+}
+
+// serverURL returns the URL of a server with the default values of its variables.
+func (c *v3Converter) serverURL(server *yaml.Node, path string) string {
+	u := stringForKey(server, "url")
+	variables := compiler.MapValueForKey(server, "variables")
+	if variables == nil {
+		return u
+	}
+	c.report.add(pointer(path, "variables"), "server variables can't be described, so their default values are used")
+	for i := 0; i+1 < len(variables.Content); i += 2 {
+		u = strings.Replace(u, "{"+variables.Content[i].Value+"}", stringForKey(variables.Content[i+1], "default"), -1)
+	}
+	return u
+}
+
+func (c *v3Converter) pathItem(node *yaml.Node, path string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case key.Value == "$ref":
+			appendPair(result, key.Value, value)
+		case compiler.StringArrayContainsValue(v2OperationKeys, key.Value):
+			appendPair(result, key.Value, c.operation(value, pointer(path, key.Value)))
+		case key.Value == "parameters":
+			if parameters := c.parameters(value, pointer(path, key.Value)); len(parameters.Content) > 0 {
+				appendPair(result, key.Value, parameters)
+			}
+		case isExtension(key.Value):
+			appendPair(result, key.Value, value)
+		default:
+			c.report.add(pointer(path, key.Value), "path items can't have %s", key.Value)
+		}
+	}
+	return result
+}
+
+func (c *v3Converter) operation(node *yaml.Node, path string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	parameters := sequenceNode()
+	var consumes, produces []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security":
+			appendPair(result, key.Value, value)
+		case "parameters":
+			parameters.Content = append(parameters.Content, c.parameters(value, pointer(path, key.Value)).Content...)
+		case "requestBody":
+			var bodyParameters []*yaml.Node
+			bodyParameters, consumes = c.requestBody(value, pointer(path, key.Value))
+			parameters.Content = append(parameters.Content, bodyParameters...)
+		case "responses":
+			var responses *yaml.Node
+			responses, produces = c.responses(value, pointer(path, key.Value))
+			appendPair(result, key.Value, responses)
+		default:
+			if isExtension(key.Value) {
+				appendPair(result, key.Value, value)
+			} else {
+				c.report.add(pointer(path, key.Value), "operations can't have %s", key.Value)
+			}
+		}
+	}
+	if len(consumes) > 0 {
+		appendPair(result, "consumes", compiler.NewSequenceNodeForStringArray(consumes))
+	}
+	if len(produces) > 0 {
+		appendPair(result, "produces", compiler.NewSequenceNodeForStringArray(produces))
+	}
+	if len(parameters.Content) > 0 {
+		appendPair(result, "parameters", parameters)
+	}
+	return result
+}
+
+func (c *v3Converter) parameters(node *yaml.Node, path string) *yaml.Node {
+	result := sequenceNode()
+	for i, item := range node.Content {
+		if parameter := c.parameter(item, pointer(path, strconv.Itoa(i))); parameter != nil {
+			result.Content = append(result.Content, parameter)
+		}
+	}
+	return result
+}
+
+// parameter converts a parameter, returning nil for parameters that can't be described.
+func (c *v3Converter) parameter(node *yaml.Node, path string) *yaml.Node {
+	if ref := stringForKey(node, "$ref"); ref != "" {
+		if parameter := c.component(ref); parameter != nil && stringForKey(parameter, "in") == "cookie" {
+			c.report.add(path, "cookie parameters can't be described")
+			return nil
+		}
+		return mappingNode("$ref", stringNode(rewriteReference(ref, v3ReferencePrefixes)))
+	}
+	in := stringForKey(node, "in")
+	if in == "cookie" {
+		c.report.add(path, "cookie parameters can't be described")
+		return nil
+	}
+	result := compiler.NewMappingNode()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "name", "in", "description", "required":
+			appendPair(result, key.Value, value)
+		case "allowEmptyValue":
+			if in == "query" {
+				appendPair(result, key.Value, value)
+			}
+		case "schema", "style", "explode":
+		case "content":
+			c.report.add(pointer(path, key.Value), "parameters can't have content, so the schema of its first media type is used")
+		default:
+			if isExtension(key.Value) {
+				appendPair(result, key.Value, value)
+			} else {
+				c.report.add(pointer(path, key.Value), "parameters can't have %s", key.Value)
+			}
+		}
+	}
+	schema, schemaPath := compiler.MapValueForKey(node, "schema"), pointer(path, "schema")
+	if content := compiler.MapValueForKey(node, "content"); schema == nil && content != nil && len(content.Content) > 1 {
+		schema = compiler.MapValueForKey(content.Content[1], "schema")
+		schemaPath = pointer(pointer(pointer(path, "content"), content.Content[0].Value), "schema")
+	}
+	c.addPrimitiveSchema(result, schema, in, schemaPath)
+	c.addCollectionFormat(result, node, in, path)
+	return result
+}
+
+// addPrimitiveSchema adds the fields of a parameter, header, or item schema.
+func (c *v3Converter) addPrimitiveSchema(result *yaml.Node, schema *yaml.Node, in string, path string) {
+	if ref := stringForKey(schema, "$ref"); ref != "" {
+		// references to schemas are only allowed in bodies, so referenced schemas are copied
+		schema = c.component(ref)
+	}
+	if schema == nil {
+		c.report.add(path, "unable to find a schema, so the type string is used")
+		appendPair(result, "type", stringNode("string"))
+		return
+	}
+	typeName := stringForKey(schema, "type")
+	switch typeName {
+	case "string", "number", "integer", "boolean", "array":
+	default:
+		c.report.add(path, "schemas of parameters and headers can only have primitive and array types, so the type string is used")
+		typeName = "string"
+	}
+	if typeName == "string" && stringForKey(schema, "format") == "binary" && in == "formData" {
+		appendPair(result, "type", stringNode("file"))
+		return
+	}
+	appendPair(result, "type", stringNode(typeName))
+	for i := 0; i+1 < len(schema.Content); i += 2 {
+		key, value := schema.Content[i], schema.Content[i+1]
+		switch key.Value {
+		case "type":
+		case "items":
+			items := compiler.NewMappingNode()
+			c.addPrimitiveSchema(items, value, "items", pointer(path, key.Value))
+			appendPair(result, key.Value, items)
+		case "format", "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum",
+			"maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems",
+			"enum", "multipleOf":
+			appendPair(result, key.Value, value)
+		case "description":
+			if in == "formData" {
+				appendPair(result, key.Value, value)
+			}
+		default:
+			if isExtension(key.Value) {
+				appendPair(result, key.Value, value)
+			} else {
+				c.report.add(pointer(path, key.Value), "schemas of parameters and headers can't have %s", key.Value)
+			}
+		}
+	}
+}
+
+// addCollectionFormat adds the collectionFormat that matches the style and
+// explode values of an array parameter in a location.
+func (c *v3Converter) addCollectionFormat(result *yaml.Node, node *yaml.Node, in string, path string) {
+	if stringForKey(result, "type") != "array" {
+		return
+	}
+	style := stringForKey(node, "style")
+	explode := isTrue(compiler.MapValueForKey(node, "explode"))
+	query := in == "query" || in == "formData"
+	if style == "" {
+		style = "simple"
+		if query {
+			// form style arrays are exploded by default, but compiled documents don't record explode values of false
+			style, explode = "form", true
+		}
+	}
+	var format string
+	switch {
+	case style == "form" && query && explode:
+		format = "multi"
+	case style == "form" && query, style == "simple" && !query && !explode:
+		format = "csv"
+	case style == "spaceDelimited" && query:
+		format = "ssv"
+	case style == "pipeDelimited" && query:
+		format = "pipes"
+	default:
+		c.report.add(pointer(path, "style"), "the %s style can't be described for %s parameters", style, in)
+		return
+	}
+	if format != "csv" {
+		appendPair(result, "collectionFormat", stringNode(format))
+	}
+}
+
+// requestBody returns the body or formData parameters that describe a
+// request body and the media types that they are consumed as.
+func (c *v3Converter) requestBody(node *yaml.Node, path string) ([]*yaml.Node, []string) {
+	if ref := stringForKey(node, "$ref"); ref != "" {
+		if node = c.component(ref); node == nil {
+			c.report.add(path, "unable to resolve %s", ref)
+			return nil, nil
+		}
+	}
+	content := compiler.MapValueForKey(node, "content")
+	if content == nil || len(content.Content) == 0 {
+		return nil, nil
+	}
+	first := content.Content[0].Value
+	form := first == mediaTypeForm || first == mediaTypeMultipart
+	consumes := make([]string, 0)
+	for i := 0; i+1 < len(content.Content); i += 2 {
+		mediaType := content.Content[i].Value
+		if (mediaType == mediaTypeForm || mediaType == mediaTypeMultipart) != form {
+			c.report.add(pointer(pointer(path, "content"), mediaType), "request bodies can't have both form and other media types")
+			continue
+		}
+		c.checkSchema(content, i, path)
+		consumes = append(consumes, mediaType)
+	}
+	if form {
+		return c.formParameters(content.Content[1], pointer(pointer(path, "content"), first)), consumes
+	}
+	result := mappingNode("name", stringNode("body"))
+	appendPair(result, "in", stringNode("body"))
+	copyPairs(result, node, "description", "required")
+	schema := compiler.MapValueForKey(content.Content[1], "schema")
+	if schema == nil {
+		schema = compiler.NewMappingNode()
+	}
+	appendPair(result, "schema", c.schema(schema, pointer(pointer(pointer(path, "content"), first), "schema")))
+	copyExtensions(result, node)
+	return []*yaml.Node{result}, consumes
+}
+
+// checkSchema reports media types with schemas that differ from the schema
+// of the first media type, which is the only one that is converted.
+func (c *v3Converter) checkSchema(content *yaml.Node, i int, path string) {
+	if i == 0 {
+		return
+	}
+	first, _ := yaml.Marshal(compiler.MapValueForKey(content.Content[1], "schema"))
+	schema, _ := yaml.Marshal(compiler.MapValueForKey(content.Content[i+1], "schema"))
+	if string(first) != string(schema) {
+		c.report.add(pointer(pointer(path, "content"), content.Content[i].Value),
+			"media types can't have different schemas, so the schema of %s is used", content.Content[0].Value)
+	}
+}
+
+// formParameters returns a formData parameter for each property of the
+// schema of a form media type.
+func (c *v3Converter) formParameters(mediaType *yaml.Node, path string) []*yaml.Node {
+	schema, schemaPath := compiler.MapValueForKey(mediaType, "schema"), pointer(path, "schema")
+	if ref := stringForKey(schema, "$ref"); ref != "" {
+		schema = c.component(ref)
+	}
+	properties := compiler.MapValueForKey(schema, "properties")
+	if properties == nil {
+		c.report.add(schemaPath, "form request bodies without properties can't be described")
+		return nil
+	}
+	required := stringsForKey(schema, "required")
+	encodings := compiler.MapValueForKey(mediaType, "encoding")
+	parameters := make([]*yaml.Node, 0)
+	for i := 0; i+1 < len(properties.Content); i += 2 {
+		name, property := properties.Content[i].Value, properties.Content[i+1]
+		parameter := mappingNode("name", stringNode(name))
+		appendPair(parameter, "in", stringNode("formData"))
+		if compiler.StringArrayContainsValue(required, name) {
+			appendPair(parameter, "required", compiler.NewScalarNodeForBool(true))
+		}
+		propertyPath := pointer(pointer(schemaPath, "properties"), name)
+		c.addPrimitiveSchema(parameter, property, "formData", propertyPath)
+		encoding := compiler.MapValueForKey(encodings, name)
+		if encoding == nil {
+			encoding = compiler.NewMappingNode()
+		}
+		c.addCollectionFormat(parameter, encoding, "formData", propertyPath)
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// responses returns the converted responses of an operation and the media
+// types that they are produced as.
+func (c *v3Converter) responses(node *yaml.Node, path string) (*yaml.Node, []string) {
+	result := compiler.NewMappingNode()
+	produces := make([]string, 0)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if isExtension(key.Value) {
+			appendPair(result, key.Value, value)
+			continue
+		}
+		response := value
+		if ref := stringForKey(value, "$ref"); ref != "" {
+			appendPair(result, key.Value, mappingNode("$ref", stringNode(rewriteReference(ref, v3ReferencePrefixes))))
+			response = c.component(ref)
+		} else {
+			appendPair(result, key.Value, c.response(value, pointer(path, key.Value)))
+		}
+		if content := compiler.MapValueForKey(response, "content"); content != nil {
+			for j := 0; j+1 < len(content.Content); j += 2 {
+				if !compiler.StringArrayContainsValue(produces, content.Content[j].Value) {
+					produces = append(produces, content.Content[j].Value)
+				}
+			}
+		}
+	}
+	return result, produces
+}
+
+func (c *v3Converter) response(node *yaml.Node, path string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	copyPairs(result, node, "description")
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "description":
+		case "headers":
+			headers := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name := value.Content[j].Value
+				appendPair(headers, name, c.header(value.Content[j+1], pointer(pointer(path, key.Value), name)))
+			}
+			appendPair(result, key.Value, headers)
+		case "content":
+			examples := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				mediaType, mediaTypePath := value.Content[j].Value, pointer(pointer(path, key.Value), value.Content[j].Value)
+				c.checkSchema(value, j, path)
+				if example := compiler.MapValueForKey(value.Content[j+1], "example"); example != nil {
+					appendPair(examples, mediaType, example)
+				}
+				if compiler.MapHasKey(value.Content[j+1], "examples") {
+					c.report.add(pointer(mediaTypePath, "examples"), "responses can only have one example of each media type")
+				}
+			}
+			if len(value.Content) > 1 {
+				if schema := compiler.MapValueForKey(value.Content[1], "schema"); schema != nil {
+					appendPair(result, "schema", c.schema(schema, pointer(pointer(pointer(path, key.Value), value.Content[0].Value), "schema")))
+				}
+			}
+			if len(examples.Content) > 0 {
+				appendPair(result, "examples", examples)
+			}
+		default:
+			if isExtension(key.Value) {
+				appendPair(result, key.Value, value)
+			} else {
+				c.report.add(pointer(path, key.Value), "responses can't have %s", key.Value)
+			}
+		}
+	}
+	return result
+}
+
+func (c *v3Converter) header(node *yaml.Node, path string) *yaml.Node {
+	if ref := stringForKey(node, "$ref"); ref != "" {
+		// headers can't be referenced, so referenced headers are copied
+		if node = c.component(ref); node == nil {
+			c.report.add(path, "unable to resolve %s", ref)
+			return mappingNode("type", stringNode("string"))
+		}
+	}
+	result := compiler.NewMappingNode()
+	copyPairs(result, node, "description")
+	c.addPrimitiveSchema(result, compiler.MapValueForKey(node, "schema"), "header", pointer(path, "schema"))
+	c.addCollectionFormat(result, node, "header", path)
+	copyExtensions(result, node)
+	return result
+}
+
+func (c *v3Converter) addComponents(result *yaml.Node, components *yaml.Node) {
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		key, value := components.Content[i], components.Content[i+1]
+		path := pointer("#/components", key.Value)
+		switch key.Value {
+		case "schemas":
+			definitions := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name := value.Content[j].Value
+				appendPair(definitions, name, c.schema(value.Content[j+1], pointer(path, name)))
+			}
+			appendPair(result, "definitions", definitions)
+		case "parameters":
+			parameters := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name := value.Content[j].Value
+				if parameter := c.parameter(value.Content[j+1], pointer(path, name)); parameter != nil {
+					appendPair(parameters, name, parameter)
+				}
+			}
+			appendPair(result, "parameters", parameters)
+		case "responses":
+			responses := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name := value.Content[j].Value
+				appendPair(responses, name, c.response(value.Content[j+1], pointer(path, name)))
+			}
+			appendPair(result, "responses", responses)
+		case "securitySchemes":
+			definitions := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name := value.Content[j].Value
+				if definition := c.securityDefinition(name, value.Content[j+1], pointer(path, name)); definition != nil {
+					appendPair(definitions, name, definition)
+				}
+			}
+			appendPair(result, "securityDefinitions", definitions)
+		case "requestBodies", "headers":
+			// copied where they are used
+		default:
+			if !isExtension(key.Value) {
+				c.report.add(path, "%s can't be described", key.Value)
+			}
+		}
+	}
+}
+
+// securityDefinition converts a security scheme, returning nil for schemes
+// that can't be described.
+func (c *v3Converter) securityDefinition(name string, node *yaml.Node, path string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	switch typeName := stringForKey(node, "type"); typeName {
+	case "http":
+		if scheme := stringForKey(node, "scheme"); !strings.EqualFold(scheme, "basic") {
+			c.report.add(path, "http security schemes can only use basic authentication")
+			return nil
+		}
+		appendPair(result, "type", stringNode("basic"))
+	case "apiKey":
+		if stringForKey(node, "in") == "cookie" {
+			c.report.add(path, "API keys can't be sent in cookies")
+			return nil
+		}
+		appendPair(result, "type", stringNode(typeName))
+		copyPairs(result, node, "name", "in")
+	case "oauth2":
+		flows := compiler.MapValueForKey(node, "flows")
+		var flow *yaml.Node
+		for _, names := range v3OAuthFlows {
+			value := compiler.MapValueForKey(flows, names[0])
+			if value == nil {
+				continue
+			}
+			if flow != nil {
+				c.report.add(pointer(pointer(path, "flows"), names[0]), "security schemes can only have one oauth2 flow")
+				continue
+			}
+			flow = value
+			appendPair(result, "type", stringNode(typeName))
+			appendPair(result, "flow", stringNode(names[1]))
+			copyPairs(result, value, "authorizationUrl", "tokenUrl")
+			appendPair(result, "scopes", c.scopes(name, names[0]))
+			if compiler.MapHasKey(value, "refreshUrl") {
+				c.report.add(pointer(pointer(pointer(path, "flows"), names[0]), "refreshUrl"), "oauth2 flows can't have a refreshUrl")
+			}
+		}
+		if flow == nil {
+			c.report.add(path, "oauth2 security schemes without flows can't be described")
+			return nil
+		}
+	default:
+		c.report.add(path, "%s security schemes can't be described", typeName)
+		return nil
+	}
+	copyPairs(result, node, "description")
+	copyExtensions(result, node)
+	return result
+}
+
+// scopes returns the scopes of an oauth2 flow, which the YAML of compiled
+// documents doesn't include.
+func (c *v3Converter) scopes(name string, flowName string) *yaml.Node {
+	result := compiler.NewMappingNode()
+	for _, pair := range c.source.GetComponents().GetSecuritySchemes().GetAdditionalProperties() {
+		if pair.Name != name {
+			continue
+		}
+		flows := pair.Value.GetSecurityScheme().GetFlows()
+		flow := map[string]*openapi3.OauthFlow{
+			"implicit":          flows.GetImplicit(),
+			"password":          flows.GetPassword(),
+			"clientCredentials": flows.GetClientCredentials(),
+			"authorizationCode": flows.GetAuthorizationCode(),
+		}[flowName]
+		for _, scope := range flow.GetScopes().GetAdditionalProperties() {
+			appendPair(result, scope.Name, stringNode(scope.Value))
+		}
+	}
+	return result
+}
+
+// schema converts a v3 schema, reporting the keywords that v2 schemas don't have.
+func (c *v3Converter) schema(node *yaml.Node, path string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return node
+	}
+	if ref := stringForKey(node, "$ref"); ref != "" {
+		return mappingNode("$ref", stringNode(rewriteReference(ref, v3ReferencePrefixes)))
+	}
+	result := compiler.NewMappingNode()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "nullable":
+			key = stringNode("x-nullable")
+		case "discriminator":
+			if compiler.MapHasKey(value, "mapping") {
+				c.report.add(pointer(pointer(path, key.Value), "mapping"), "discriminators can't have a mapping")
+			}
+			value = compiler.MapValueForKey(value, "propertyName")
+		case "oneOf", "anyOf", "not", "writeOnly", "deprecated":
+			c.report.add(pointer(path, key.Value), "schemas can't have %s", key.Value)
+			continue
+		case "properties":
+			properties := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name := value.Content[j].Value
+				appendPair(properties, name, c.schema(value.Content[j+1], pointer(pointer(path, key.Value), name)))
+			}
+			value = properties
+		case "items", "additionalProperties":
+			value = c.schema(value, pointer(path, key.Value))
+		case "allOf":
+			allOf := sequenceNode()
+			for j, item := range value.Content {
+				allOf.Content = append(allOf.Content, c.schema(item, pointer(pointer(path, key.Value), strconv.Itoa(j))))
+			}
+			value = allOf
+		}
+		appendPair(result, key.Value, value)
+	}
+	return result
+}
+
+// hoistMediaTypes moves the media types of operations to the document when
+// all operations have the same ones.
+func hoistMediaTypes(document *yaml.Node, paths *yaml.Node, key string) {
+	operations := make([]*yaml.Node, 0)
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		for _, method := range v2OperationKeys {
+			if operation := compiler.MapValueForKey(paths.Content[i+1], method); operation != nil {
+				operations = append(operations, operation)
+			}
+		}
+	}
+	if len(operations) == 0 {
+		return
+	}
+	mediaTypes := stringsForKey(operations[0], key)
+	for _, operation := range operations {
+		if !compiler.MapHasKey(operation, key) || !equalStrings(stringsForKey(operation, key), mediaTypes) {
+			return
+		}
+	}
+	for _, operation := range operations {
+		for i := 0; i+1 < len(operation.Content); i += 2 {
+			if operation.Content[i].Value == key {
+				operation.Content = append(operation.Content[:i], operation.Content[i+2:]...)
+				break
+			}
+		}
+	}
+	appendPair(document, key, compiler.NewSequenceNodeForStringArray(mediaTypes))
+}
+
+// component returns the component that a local reference refers to.
+func (c *v3Converter) component(ref string) *yaml.Node {
+	if !strings.HasPrefix(ref, "#/components/") {
+		return nil
+	}
+	parts := strings.SplitN(strings.TrimPrefix(ref, "#/components/"), "/", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	kind := compiler.MapValueForKey(compiler.MapValueForKey(c.root, "components"), parts[0])
+	return compiler.MapValueForKey(kind, unescape(parts[1]))
+}
@@ -0,0 +1,219 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"io/ioutil"
+	"testing"
+
+	openapi2 "github.com/okkoye/gnostic/openapiv2"
+	openapi3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const v3Document = `openapi: 3.0.3
+info: {title: Pets, version: "1"}
+servers:
+  - url: https://pets.example.com/v1
+  - url: http://pets.example.com/v1
+  - url: https://other.example.com
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: session, in: cookie, schema: {type: string}}
+        - {name: tags, in: query, style: pipeDelimited, schema: {type: array, items: {type: string}}}
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: "#/components/schemas/Pet"}}
+              example: [{name: Rex}]
+          links:
+            owner: {operationId: getOwner}
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Pet"}
+          application/xml:
+            schema: {type: object}
+      responses:
+        "201": {$ref: "#/components/responses/created"}
+      callbacks:
+        created:
+          "{$request.body#/callback}":
+            post: {responses: {"200": {description: ok}}}
+  /pets/{id}/photo:
+    put:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              required: [photo]
+              properties:
+                photo: {type: string, format: binary}
+                caption: {type: string, description: a caption}
+      responses:
+        "204": {description: uploaded}
+components:
+  schemas:
+    Pet:
+      type: object
+      discriminator: {propertyName: kind}
+      properties:
+        name: {type: string}
+        kind: {type: string}
+        owner: {type: string, nullable: true}
+        id: {oneOf: [{type: string}, {type: integer}]}
+  responses:
+    created: {description: created}
+  securitySchemes:
+    basic: {type: http, scheme: basic}
+    bearer: {type: http, scheme: bearer}
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes: {read: read pets}
+`
+
+func TestConvertV3ToV2(t *testing.T) {
+	document, err := openapi3.ParseDocument([]byte(v3Document))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	d, report, err := ConvertV3ToV2(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if d.Swagger != "2.0" || d.Host != "pets.example.com" || d.BasePath != "/v1" ||
+		len(d.Schemes) != 2 || d.Schemes[0] != "https" || d.Schemes[1] != "http" {
+		t.Errorf("unexpected document %+v", d)
+	}
+
+	pets := d.Paths.Path[0].Value
+	if len(d.Produces) != 0 || len(pets.Get.Produces) != 1 || pets.Get.Produces[0] != "application/json" {
+		t.Errorf("unexpected media types %+v", pets.Get.Produces)
+	}
+	if len(pets.Get.Parameters) != 1 {
+		t.Fatalf("unexpected parameters %+v", pets.Get.Parameters)
+	}
+	tags := pets.Get.Parameters[0].GetParameter().GetNonBodyParameter().GetQueryParameterSubSchema()
+	if tags.Name != "tags" || tags.Type != "array" || tags.CollectionFormat != "pipes" || tags.Items.Type != "string" {
+		t.Errorf("unexpected parameter %+v", tags)
+	}
+	response := pets.Get.Responses.ResponseCode[0].Value.GetResponse()
+	if response.Schema.GetSchema().Items.Schema[0].XRef != "#/definitions/Pet" || response.Examples.AdditionalProperties[0].Name != "application/json" {
+		t.Errorf("unexpected response %+v", response)
+	}
+	body := pets.Post.Parameters[0].GetParameter().GetBodyParameter()
+	if body.Name != "body" || !body.Required || body.Schema.XRef != "#/definitions/Pet" {
+		t.Errorf("unexpected body parameter %+v", body)
+	}
+	if len(pets.Post.Consumes) != 2 || pets.Post.Consumes[1] != "application/xml" {
+		t.Errorf("unexpected media types %+v", pets.Post.Consumes)
+	}
+	if ref := pets.Post.Responses.ResponseCode[0].Value.GetJsonReference(); ref == nil || ref.XRef != "#/responses/created" {
+		t.Errorf("unexpected response %+v", pets.Post.Responses)
+	}
+
+	photo := d.Paths.Path[1].Value.Put
+	if len(photo.Parameters) != 3 {
+		t.Fatalf("unexpected parameters %+v", photo.Parameters)
+	}
+	file := photo.Parameters[1].GetParameter().GetNonBodyParameter().GetFormDataParameterSubSchema()
+	caption := photo.Parameters[2].GetParameter().GetNonBodyParameter().GetFormDataParameterSubSchema()
+	if file.Name != "photo" || file.Type != "file" || !file.Required || caption.Description != "a caption" || caption.Required {
+		t.Errorf("unexpected parameters %+v %+v", file, caption)
+	}
+	if len(photo.Consumes) != 1 || photo.Consumes[0] != "multipart/form-data" {
+		t.Errorf("unexpected media types %+v", photo.Consumes)
+	}
+
+	pet := d.Definitions.AdditionalProperties[0].Value
+	if pet.Discriminator != "kind" || len(pet.Properties.AdditionalProperties) != 4 {
+		t.Errorf("unexpected schema %+v", pet)
+	}
+	if owner := pet.Properties.AdditionalProperties[2].Value; len(owner.VendorExtension) != 1 || owner.VendorExtension[0].Name != "x-nullable" {
+		t.Errorf("unexpected schema %+v", owner)
+	}
+	definitions := d.SecurityDefinitions.AdditionalProperties
+	if len(definitions) != 2 || definitions[0].Value.GetBasicAuthenticationSecurity() == nil {
+		t.Fatalf("unexpected security definitions %+v", definitions)
+	}
+	oauth := definitions[1].Value.GetOauth2ApplicationSecurity()
+	if oauth == nil || oauth.TokenUrl != "https://example.com/token" || oauth.Scopes.AdditionalProperties[0].Name != "read" {
+		t.Errorf("unexpected security definition %+v", definitions[1].Value)
+	}
+
+	expected := []Loss{
+		{"#/servers/2", "only servers with the host and path of the first server can be described"},
+		{"#/paths/~1pets/get/parameters/0", "cookie parameters can't be described"},
+		{"#/paths/~1pets/get/responses/200/links", "responses can't have links"},
+		{"#/paths/~1pets/post/requestBody/content/application~1xml", "media types can't have different schemas, so the schema of application/json is used"},
+		{"#/paths/~1pets/post/callbacks", "operations can't have callbacks"},
+		{"#/components/schemas/Pet/properties/id/oneOf", "schemas can't have oneOf"},
+		{"#/components/securitySchemes/bearer", "http security schemes can only use basic authentication"},
+	}
+	if len(report.Losses) != len(expected) {
+		t.Fatalf("unexpected report %s", report.String())
+	}
+	for i, loss := range report.Losses {
+		if loss != expected[i] {
+			t.Errorf("unexpected loss %+v, expected %+v", loss, expected[i])
+		}
+	}
+}
+
+func TestConvertV3ToV2RoundTrip(t *testing.T) {
+	bytes, err := ioutil.ReadFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := openapi3.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	v2, report, err := ConvertV3ToV2(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !report.Lossless() {
+		t.Errorf("unexpected report %s", report.String())
+	}
+	if _, err := openapi2.NewDocument(v2.ToRawInfo(), nil); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	v3, report, err := ConvertV2ToV3(v2)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !report.Lossless() {
+		t.Errorf("unexpected report %s", report.String())
+	}
+	if len(v3.Servers) != 1 || v3.Servers[0].Url != "https://petstore.openapis.org/v1" {
+		t.Errorf("unexpected servers %+v", v3.Servers)
+	}
+	if len(v3.Paths.Path) != len(document.Paths.Path) ||
+		len(v3.Components.Schemas.AdditionalProperties) != len(document.Components.Schemas.AdditionalProperties) {
+		t.Errorf("unexpected document %+v", v3)
+	}
+}
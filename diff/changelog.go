@@ -0,0 +1,97 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"strings"
+
+	"github.com/okkoye/gnostic/printer"
+)
+
+// subjectHeadings are the headings of the sections of a changelog, in order.
+var subjectHeadings = []struct{ subject, heading string }{
+	{Endpoint, "Endpoints"},
+	{Parameter, "Parameters"},
+	{RequestBody, "Request bodies"},
+	{Response, "Responses"},
+	{Schema, "Schemas"},
+	{Property, "Properties"},
+}
+
+// Changelog formats changes as Markdown release notes, grouped into Added,
+// Changed, and Removed sections with a subsection for each kind of subject.
+func Changelog(changes []*Change, title string) string {
+	code := &printer.Code{}
+	if title != "" {
+		code.Print("# %s", title)
+		code.Print()
+	}
+	if len(changes) == 0 {
+		code.Print("No changes.")
+		return code.String()
+	}
+	for _, changeType := range []string{Added, Changed, Removed} {
+		printed := false
+		for _, s := range subjectHeadings {
+			lines := make([]string, 0)
+			for _, change := range changes {
+				if change.Type == changeType && change.Subject == s.subject {
+					lines = append(lines, changelogEntry(change))
+				}
+			}
+			if len(lines) == 0 {
+				continue
+			}
+			if !printed {
+				code.Print("## %s", changeType)
+				code.Print()
+				printed = true
+			}
+			code.Print("### %s", s.heading)
+			code.Print()
+			for _, line := range lines {
+				code.Print("%s", line)
+			}
+			code.Print()
+		}
+	}
+	return code.String()
+}
+
+// changelogEntry formats a change as a Markdown list item.
+func changelogEntry(change *Change) string {
+	var entry string
+	switch change.Subject {
+	case Endpoint:
+		entry = "`" + change.Operation + "`"
+	case RequestBody:
+		entry = "Request body of `" + change.Operation + "`"
+	case Response:
+		entry = "`" + change.Name + "` response of `" + change.Operation + "`"
+	case Parameter:
+		// Parameters are named like "limit (query)".
+		name, in := change.Name, "parameter"
+		if i := strings.LastIndex(name, " ("); i >= 0 {
+			name, in = name[:i], strings.TrimSuffix(name[i+2:], ")")+" parameter"
+		}
+		entry = "`" + name + "` " + in + " of `" + change.Operation + "`"
+	default:
+		entry = "`" + change.Name + "`"
+	}
+	if len(change.Details) > 0 {
+		entry += ": " + strings.Join(change.Details, "; ")
+	}
+	return "- " + entry
+}
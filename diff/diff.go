@@ -0,0 +1,490 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff compares two versions of an OpenAPI description and reports
+// the differences in terms of endpoints, parameters, responses, and schemas.
+// Descriptions are compared in their JSON/YAML form, so both OpenAPI v2 and
+// v3 descriptions can be compared.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// Types of changes.
+const (
+	Added   = "Added"
+	Changed = "Changed"
+	Removed = "Removed"
+)
+
+// Subjects of changes.
+const (
+	Endpoint    = "endpoint"
+	Parameter   = "parameter"
+	RequestBody = "request body"
+	Response    = "response"
+	Schema      = "schema"
+	Property    = "property"
+)
+
+// A Change is a single difference between two versions of a description.
+type Change struct {
+	Type      string // Added, Changed, or Removed
+	Subject   string // Endpoint, Parameter, RequestBody, Response, Schema, or Property
+	Operation string // the method and path of the operation, e.g. "GET /pets"; empty for schemas
+	Name      string // the name of a parameter, response, schema, or property
+	Details   []string
+}
+
+func (c *Change) String() string {
+	s := c.Type + " " + c.Subject
+	if c.Name != "" {
+		s += " " + c.Name
+	}
+	if c.Operation != "" {
+		if c.Subject == Endpoint {
+			s = c.Type + " " + c.Subject + " " + c.Operation
+		} else {
+			s += " in " + c.Operation
+		}
+	}
+	if len(c.Details) > 0 {
+		s += ": " + strings.Join(c.Details, "; ")
+	}
+	return s
+}
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// document is a description being compared.
+type document struct {
+	root *yaml.Node
+}
+
+func newDocument(node *yaml.Node) *document {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	return &document{root: node}
+}
+
+// resolve follows local references like "#/components/parameters/limit".
+func (d *document) resolve(node *yaml.Node) *yaml.Node {
+	for depth := 0; depth < 32; depth++ {
+		ref, ok := compiler.StringForScalarNode(compiler.MapValueForKey(node, "$ref"))
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return node
+		}
+		target := d.root
+		for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+			target = compiler.MapValueForKey(target, token)
+		}
+		if target == nil {
+			return node
+		}
+		node = target
+	}
+	return node
+}
+
+// schemas returns the mapping node that contains named schemas.
+func (d *document) schemas() *yaml.Node {
+	if definitions := compiler.MapValueForKey(d.root, "definitions"); definitions != nil {
+		return definitions
+	}
+	return compiler.MapValueForKey(compiler.MapValueForKey(d.root, "components"), "schemas")
+}
+
+// operations returns the operations of a document keyed by "METHOD path".
+func (d *document) operations() (map[string]*yaml.Node, map[string]*yaml.Node) {
+	operations := make(map[string]*yaml.Node)
+	items := make(map[string]*yaml.Node)
+	paths := compiler.MapValueForKey(d.root, "paths")
+	for i := 0; paths != nil && i+1 < len(paths.Content); i += 2 {
+		path, item := paths.Content[i].Value, d.resolve(paths.Content[i+1])
+		for _, method := range methods {
+			if operation := compiler.MapValueForKey(item, method); operation != nil {
+				key := strings.ToUpper(method) + " " + path
+				operations[key] = operation
+				items[key] = item
+			}
+		}
+	}
+	return operations, items
+}
+
+// Compare returns the differences between two versions of a description,
+// sorted by operation and name, with changes to schemas first.
+func Compare(before *yaml.Node, after *yaml.Node) []*Change {
+	c := &comparison{old: newDocument(before), new: newDocument(after)}
+	c.compareOperations()
+	c.compareSchemas()
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		a, b := c.changes[i], c.changes[j]
+		if a.Operation != b.Operation {
+			return a.Operation < b.Operation
+		}
+		return a.Name < b.Name
+	})
+	return c.changes
+}
+
+type comparison struct {
+	old, new *document
+	changes  []*Change
+}
+
+func (c *comparison) add(change *Change) {
+	c.changes = append(c.changes, change)
+}
+
+func sortedKeys(m map[string]*yaml.Node) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func equal(a, b *yaml.Node) bool {
+	return bytes.Equal(marshal(a), marshal(b))
+}
+
+func marshal(node *yaml.Node) []byte {
+	if node == nil {
+		return nil
+	}
+	return compiler.Marshal(node)
+}
+
+func (c *comparison) compareOperations() {
+	oldOperations, oldItems := c.old.operations()
+	newOperations, newItems := c.new.operations()
+	for _, key := range sortedKeys(oldOperations) {
+		if _, ok := newOperations[key]; !ok {
+			c.add(&Change{Type: Removed, Subject: Endpoint, Operation: key})
+		}
+	}
+	for _, key := range sortedKeys(newOperations) {
+		oldOperation, ok := oldOperations[key]
+		if !ok {
+			c.add(&Change{Type: Added, Subject: Endpoint, Operation: key, Details: summary(newOperations[key])})
+			continue
+		}
+		newOperation := newOperations[key]
+		c.compareParameters(key,
+			c.old.parameters(oldItems[key], oldOperation),
+			c.new.parameters(newItems[key], newOperation))
+		c.compareRequestBodies(key,
+			c.old.resolve(compiler.MapValueForKey(oldOperation, "requestBody")),
+			c.new.resolve(compiler.MapValueForKey(newOperation, "requestBody")))
+		c.compareResponses(key,
+			compiler.MapValueForKey(oldOperation, "responses"),
+			compiler.MapValueForKey(newOperation, "responses"))
+		details := make([]string, 0)
+		for _, field := range []string{"deprecated", "operationId", "summary"} {
+			if detail := scalarChange(field, compiler.MapValueForKey(oldOperation, field), compiler.MapValueForKey(newOperation, field)); detail != "" {
+				details = append(details, detail)
+			}
+		}
+		if len(details) > 0 {
+			c.add(&Change{Type: Changed, Subject: Endpoint, Operation: key, Details: details})
+		}
+	}
+}
+
+// summary returns the summary of an operation as details of a change.
+func summary(operation *yaml.Node) []string {
+	if s, ok := compiler.StringForScalarNode(compiler.MapValueForKey(operation, "summary")); ok && s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+// parameters returns the parameters of an operation, including those
+// inherited from its path item, keyed by "name (in)".
+func (d *document) parameters(item *yaml.Node, operation *yaml.Node) map[string]*yaml.Node {
+	parameters := make(map[string]*yaml.Node)
+	for _, source := range []*yaml.Node{item, operation} {
+		list := compiler.MapValueForKey(source, "parameters")
+		if list == nil {
+			continue
+		}
+		for _, p := range list.Content {
+			p = d.resolve(p)
+			name, _ := compiler.StringForScalarNode(compiler.MapValueForKey(p, "name"))
+			in, _ := compiler.StringForScalarNode(compiler.MapValueForKey(p, "in"))
+			parameters[name+" ("+in+")"] = p
+		}
+	}
+	return parameters
+}
+
+func (c *comparison) compareParameters(operation string, oldParameters, newParameters map[string]*yaml.Node) {
+	for _, name := range sortedKeys(oldParameters) {
+		if _, ok := newParameters[name]; !ok {
+			c.add(&Change{Type: Removed, Subject: Parameter, Operation: operation, Name: name})
+		}
+	}
+	for _, name := range sortedKeys(newParameters) {
+		newParameter := newParameters[name]
+		oldParameter, ok := oldParameters[name]
+		if !ok {
+			change := &Change{Type: Added, Subject: Parameter, Operation: operation, Name: name}
+			if required, _ := compiler.BoolForScalarNode(compiler.MapValueForKey(newParameter, "required")); required {
+				change.Details = []string{"required"}
+			}
+			c.add(change)
+			continue
+		}
+		if equal(oldParameter, newParameter) {
+			continue
+		}
+		details := make([]string, 0)
+		for _, field := range []string{"required", "deprecated", "type", "format", "style", "explode"} {
+			if detail := scalarChange(field, compiler.MapValueForKey(oldParameter, field), compiler.MapValueForKey(newParameter, field)); detail != "" {
+				details = append(details, detail)
+			}
+		}
+		oldSchema := c.old.resolve(compiler.MapValueForKey(oldParameter, "schema"))
+		newSchema := c.new.resolve(compiler.MapValueForKey(newParameter, "schema"))
+		details = append(details, c.schemaDetails(oldSchema, newSchema)...)
+		if len(details) == 0 {
+			details = append(details, "description or other details changed")
+		}
+		c.add(&Change{Type: Changed, Subject: Parameter, Operation: operation, Name: name, Details: details})
+	}
+}
+
+func (c *comparison) compareRequestBodies(operation string, oldBody, newBody *yaml.Node) {
+	switch {
+	case oldBody == nil && newBody == nil:
+	case oldBody == nil:
+		c.add(&Change{Type: Added, Subject: RequestBody, Operation: operation})
+	case newBody == nil:
+		c.add(&Change{Type: Removed, Subject: RequestBody, Operation: operation})
+	case !equal(oldBody, newBody):
+		details := make([]string, 0)
+		if detail := scalarChange("required", compiler.MapValueForKey(oldBody, "required"), compiler.MapValueForKey(newBody, "required")); detail != "" {
+			details = append(details, detail)
+		}
+		details = append(details, c.contentDetails(compiler.MapValueForKey(oldBody, "content"), compiler.MapValueForKey(newBody, "content"))...)
+		if len(details) == 0 {
+			details = append(details, "description or other details changed")
+		}
+		c.add(&Change{Type: Changed, Subject: RequestBody, Operation: operation, Details: details})
+	}
+}
+
+// contentDetails describes changes to the media types of a body.
+func (c *comparison) contentDetails(oldContent, newContent *yaml.Node) []string {
+	details := make([]string, 0)
+	for i := 0; oldContent != nil && i+1 < len(oldContent.Content); i += 2 {
+		if compiler.MapValueForKey(newContent, oldContent.Content[i].Value) == nil {
+			details = append(details, "removed media type "+oldContent.Content[i].Value)
+		}
+	}
+	for i := 0; newContent != nil && i+1 < len(newContent.Content); i += 2 {
+		mediaType := newContent.Content[i].Value
+		old := compiler.MapValueForKey(oldContent, mediaType)
+		if old == nil {
+			details = append(details, "added media type "+mediaType)
+			continue
+		}
+		for _, detail := range c.schemaDetails(
+			c.old.resolve(compiler.MapValueForKey(old, "schema")),
+			c.new.resolve(compiler.MapValueForKey(newContent.Content[i+1], "schema"))) {
+			details = append(details, mediaType+" "+detail)
+		}
+	}
+	return details
+}
+
+func (c *comparison) compareResponses(operation string, oldResponses, newResponses *yaml.Node) {
+	for i := 0; oldResponses != nil && i+1 < len(oldResponses.Content); i += 2 {
+		code := oldResponses.Content[i].Value
+		if compiler.MapValueForKey(newResponses, code) == nil {
+			c.add(&Change{Type: Removed, Subject: Response, Operation: operation, Name: code})
+		}
+	}
+	for i := 0; newResponses != nil && i+1 < len(newResponses.Content); i += 2 {
+		code := newResponses.Content[i].Value
+		newResponse := c.new.resolve(newResponses.Content[i+1])
+		oldResponse := c.old.resolve(compiler.MapValueForKey(oldResponses, code))
+		if oldResponse == nil {
+			c.add(&Change{Type: Added, Subject: Response, Operation: operation, Name: code})
+			continue
+		}
+		if equal(oldResponse, newResponse) {
+			continue
+		}
+		details := c.contentDetails(compiler.MapValueForKey(oldResponse, "content"), compiler.MapValueForKey(newResponse, "content"))
+		// OpenAPI v2 responses have a schema instead of content.
+		details = append(details, c.schemaDetails(
+			c.old.resolve(compiler.MapValueForKey(oldResponse, "schema")),
+			c.new.resolve(compiler.MapValueForKey(newResponse, "schema")))...)
+		if len(details) == 0 {
+			details = append(details, "description or other details changed")
+		}
+		c.add(&Change{Type: Changed, Subject: Response, Operation: operation, Name: code, Details: details})
+	}
+}
+
+func (c *comparison) compareSchemas() {
+	oldSchemas, newSchemas := c.old.schemas(), c.new.schemas()
+	for i := 0; oldSchemas != nil && i+1 < len(oldSchemas.Content); i += 2 {
+		name := oldSchemas.Content[i].Value
+		if compiler.MapValueForKey(newSchemas, name) == nil {
+			c.add(&Change{Type: Removed, Subject: Schema, Name: name})
+		}
+	}
+	for i := 0; newSchemas != nil && i+1 < len(newSchemas.Content); i += 2 {
+		name, newSchema := newSchemas.Content[i].Value, newSchemas.Content[i+1]
+		oldSchema := compiler.MapValueForKey(oldSchemas, name)
+		if oldSchema == nil {
+			c.add(&Change{Type: Added, Subject: Schema, Name: name})
+			continue
+		}
+		if equal(oldSchema, newSchema) {
+			continue
+		}
+		c.compareProperties(name, oldSchema, newSchema)
+		details := c.schemaDetails(oldSchema, newSchema)
+		if len(details) > 0 {
+			c.add(&Change{Type: Changed, Subject: Schema, Name: name, Details: details})
+		}
+	}
+}
+
+// compareProperties reports added, removed, and changed properties of a named schema.
+func (c *comparison) compareProperties(name string, oldSchema, newSchema *yaml.Node) {
+	oldProperties := compiler.MapValueForKey(oldSchema, "properties")
+	newProperties := compiler.MapValueForKey(newSchema, "properties")
+	for i := 0; oldProperties != nil && i+1 < len(oldProperties.Content); i += 2 {
+		property := oldProperties.Content[i].Value
+		if compiler.MapValueForKey(newProperties, property) == nil {
+			c.add(&Change{Type: Removed, Subject: Property, Name: name + "." + property})
+		}
+	}
+	for i := 0; newProperties != nil && i+1 < len(newProperties.Content); i += 2 {
+		property, newProperty := newProperties.Content[i].Value, newProperties.Content[i+1]
+		oldProperty := compiler.MapValueForKey(oldProperties, property)
+		if oldProperty == nil {
+			c.add(&Change{Type: Added, Subject: Property, Name: name + "." + property})
+			continue
+		}
+		if equal(oldProperty, newProperty) {
+			continue
+		}
+		details := c.schemaDetails(c.old.resolve(oldProperty), c.new.resolve(newProperty))
+		if len(details) == 0 {
+			details = append(details, "description or other details changed")
+		}
+		c.add(&Change{Type: Changed, Subject: Property, Name: name + "." + property, Details: details})
+	}
+}
+
+// schemaDetails describes changes to the top-level keywords of a schema.
+// Properties are compared separately.
+func (c *comparison) schemaDetails(oldSchema, newSchema *yaml.Node) []string {
+	details := make([]string, 0)
+	if oldSchema == nil || newSchema == nil || equal(oldSchema, newSchema) {
+		return details
+	}
+	for _, field := range []string{"type", "format", "nullable", "minimum", "maximum", "minLength", "maxLength", "pattern"} {
+		if detail := scalarChange(field, compiler.MapValueForKey(oldSchema, field), compiler.MapValueForKey(newSchema, field)); detail != "" {
+			details = append(details, detail)
+		}
+	}
+	oldRequired := stringSet(compiler.MapValueForKey(oldSchema, "required"))
+	newRequired := stringSet(compiler.MapValueForKey(newSchema, "required"))
+	for _, name := range difference(newRequired, oldRequired) {
+		details = append(details, name+" is now required")
+	}
+	for _, name := range difference(oldRequired, newRequired) {
+		details = append(details, name+" is no longer required")
+	}
+	oldEnum := stringSet(compiler.MapValueForKey(oldSchema, "enum"))
+	newEnum := stringSet(compiler.MapValueForKey(newSchema, "enum"))
+	if values := difference(newEnum, oldEnum); len(values) > 0 {
+		details = append(details, "added enum values "+strings.Join(values, ", "))
+	}
+	if values := difference(oldEnum, newEnum); len(values) > 0 {
+		details = append(details, "removed enum values "+strings.Join(values, ", "))
+	}
+	if detail := scalarChange("$ref", compiler.MapValueForKey(oldSchema, "$ref"), compiler.MapValueForKey(newSchema, "$ref")); detail != "" {
+		details = append(details, detail)
+	}
+	oldItems := compiler.MapValueForKey(oldSchema, "items")
+	newItems := compiler.MapValueForKey(newSchema, "items")
+	for _, detail := range c.schemaDetails(oldItems, newItems) {
+		details = append(details, "items "+detail)
+	}
+	return details
+}
+
+// scalarChange describes a change to a scalar field, or returns "" if it didn't change.
+func scalarChange(field string, oldValue, newValue *yaml.Node) string {
+	oldText, newText := scalarText(oldValue), scalarText(newValue)
+	switch {
+	case oldText == newText:
+		return ""
+	case oldText == "":
+		return fmt.Sprintf("%s set to %s", field, newText)
+	case newText == "":
+		return fmt.Sprintf("%s %s removed", field, oldText)
+	default:
+		return fmt.Sprintf("%s changed from %s to %s", field, oldText, newText)
+	}
+}
+
+func scalarText(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+func stringSet(node *yaml.Node) map[string]bool {
+	set := make(map[string]bool)
+	if node == nil {
+		return set
+	}
+	for _, item := range node.Content {
+		set[item.Value] = true
+	}
+	return set
+}
+
+// difference returns the sorted members of a that aren't in b.
+func difference(a, b map[string]bool) []string {
+	values := make([]string, 0)
+	for value := range a {
+		if !b[value] {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,165 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const before = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: tag
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    delete:
+      responses:
+        "204":
+          description: deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+    Owner:
+      type: object
+`
+
+const after = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 2.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/limit'
+        - name: cursor
+          in: query
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        "400":
+          description: bad request
+    post:
+      summary: Create a pet
+      responses:
+        "201":
+          description: created
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: string
+  schemas:
+    Pet:
+      type: object
+      required: [name, kind]
+      properties:
+        name:
+          type: string
+        age:
+          type: number
+        kind:
+          type: string
+          enum: [cat, dog]
+`
+
+func parse(t *testing.T, text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return &node
+}
+
+func TestCompare(t *testing.T) {
+	changes := Compare(parse(t, before), parse(t, after))
+	expected := []string{
+		"Removed schema Owner",
+		"Changed schema Pet: kind is now required",
+		"Changed property Pet.age: type changed from integer to number",
+		"Added property Pet.kind",
+		"Removed endpoint DELETE /pets/{petId}",
+		"Added response 400 in GET /pets",
+		"Added parameter cursor (query) in GET /pets: required",
+		"Changed parameter limit (query) in GET /pets: type changed from integer to string",
+		"Removed parameter tag (query) in GET /pets",
+		"Added endpoint POST /pets: Create a pet",
+	}
+	actual := make([]string, 0)
+	for _, change := range changes {
+		actual = append(actual, change.String())
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected changes:\n%s\nexpected:\n%s", strings.Join(actual, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestChangelog(t *testing.T) {
+	changelog := Changelog(Compare(parse(t, before), parse(t, after)), "Pets 2.0.0")
+	for _, s := range []string{
+		"# Pets 2.0.0\n",
+		"## Added\n\n### Endpoints\n\n- `POST /pets`: Create a pet\n",
+		"## Removed\n\n### Endpoints\n\n- `DELETE /pets/{petId}`\n",
+		"- `400` response of `GET /pets`\n",
+	} {
+		if !strings.Contains(changelog, s) {
+			t.Errorf("changelog does not contain %q:\n%s", s, changelog)
+		}
+	}
+	if Changelog(nil, "") != "No changes.\n" {
+		t.Errorf("unexpected changelog for no changes")
+	}
+}
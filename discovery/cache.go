@@ -0,0 +1,331 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery_v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	cacheIndexName    = "index.json"
+	cacheListName     = "list.json"
+	cacheListETagName = "list.etag"
+)
+
+// A Cache is a local, content-addressed store of discovery documents.
+//
+// Documents are stored in the objects subdirectory under names derived
+// from the SHA-256 hash of their contents, so identical documents are
+// stored once. An index maps each API name and version to the hash of
+// its most recently fetched document. The index is saved after every
+// successful download, so an interrupted bulk fetch resumes where it
+// left off when it is run again.
+//
+// The ETags of downloaded documents and of the list of APIs are saved so
+// that they can be revalidated with conditional requests, which only
+// download them again if they have changed.
+type Cache struct {
+	// Dir is the root directory of the cache.
+	Dir string
+	// Client is used to download documents. If nil, http.DefaultClient is used.
+	Client *http.Client
+	// ListURL is the URL of the list of APIs. If empty, APIsListServiceURL is used.
+	ListURL string
+
+	mutex sync.Mutex
+	index map[string]*CacheEntry
+}
+
+// A CacheEntry describes a cached discovery document.
+type CacheEntry struct {
+	Name    string    `json:"name"`
+	Version string    `json:"version"`
+	URL     string    `json:"url"`
+	Hash    string    `json:"hash"`
+	ETag    string    `json:"etag,omitempty"`
+	Fetched time.Time `json:"fetched"`
+}
+
+// A FetchResult reports the outcome of fetching one API with FetchAll.
+type FetchResult struct {
+	API    *API
+	Entry  *CacheEntry
+	Cached bool // true if the cached document was used because it was current
+	Err    error
+}
+
+// NewCache opens (and if necessary creates) a cache in the specified directory.
+func NewCache(dir string) (*Cache, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "objects"), 0755); err != nil {
+		return nil, err
+	}
+	c := &Cache{Dir: dir, index: make(map[string]*CacheEntry)}
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, cacheIndexName))
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bytes, &c.index); err != nil {
+		return nil, fmt.Errorf("invalid cache index in %s: %v", dir, err)
+	}
+	return c, nil
+}
+
+func cacheKey(name, version string) string {
+	return name + ":" + version
+}
+
+func (c *Cache) objectPath(hash string) string {
+	return filepath.Join(c.Dir, "objects", hash[0:2], hash+".json")
+}
+
+// Entry returns the cache entry for an API, or nil if the API has not been fetched.
+func (c *Cache) Entry(api *API) *CacheEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.index[cacheKey(api.Name, api.Version)]
+}
+
+// Entries returns all cache entries sorted by name and version.
+func (c *Cache) Entries() []*CacheEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entries := make([]*CacheEntry, 0, len(c.index))
+	for _, entry := range c.index {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return cacheKey(entries[i].Name, entries[i].Version) < cacheKey(entries[j].Name, entries[j].Version)
+	})
+	return entries
+}
+
+// Read returns the bytes of a cached document.
+func (c *Cache) Read(entry *CacheEntry) ([]byte, error) {
+	return ioutil.ReadFile(c.objectPath(entry.Hash))
+}
+
+// Get returns the cached document for an API.
+func (c *Cache) Get(api *API) ([]byte, error) {
+	entry := c.Entry(api)
+	if entry == nil {
+		return nil, fmt.Errorf("%s/%s is not cached", api.Name, api.Version)
+	}
+	return c.Read(entry)
+}
+
+// Put stores the document for an API in the cache and records it in the index.
+func (c *Cache) Put(api *API, bytes []byte) (*CacheEntry, error) {
+	return c.put(api, bytes, "")
+}
+
+// put stores a document with the ETag that it was downloaded with.
+func (c *Cache) put(api *API, bytes []byte, etag string) (*CacheEntry, error) {
+	sum := sha256.Sum256(bytes)
+	hash := hex.EncodeToString(sum[:])
+	path := c.objectPath(hash)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := writeFileAtomically(path, bytes); err != nil {
+			return nil, err
+		}
+	}
+	entry := &CacheEntry{
+		Name:    api.Name,
+		Version: api.Version,
+		URL:     api.DiscoveryRestURL,
+		Hash:    hash,
+		ETag:    etag,
+		Fetched: time.Now().UTC(),
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.index[cacheKey(api.Name, api.Version)] = entry
+	return entry, c.saveIndex()
+}
+
+// revalidated records that a cached document is still current.
+func (c *Cache) revalidated(entry *CacheEntry) (*CacheEntry, error) {
+	updated := *entry
+	updated.Fetched = time.Now().UTC()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.index[cacheKey(entry.Name, entry.Version)] = &updated
+	return &updated, c.saveIndex()
+}
+
+// saveIndex writes the index to disk. It must be called with the mutex held.
+func (c *Cache) saveIndex() error {
+	bytes, err := json.MarshalIndent(c.index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(filepath.Join(c.Dir, cacheIndexName), bytes)
+}
+
+// Fetch returns the document for an API, downloading it if it is not
+// already cached. If refresh is true, cached documents are revalidated and
+// only downloaded again if they have changed.
+func (c *Cache) Fetch(api *API, refresh bool) (bytes []byte, entry *CacheEntry, cached bool, err error) {
+	etag := ""
+	if entry = c.Entry(api); entry != nil {
+		bytes, err = c.Read(entry)
+		if err == nil && !refresh {
+			return bytes, entry, true, nil
+		}
+		if err == nil && entry.URL == api.DiscoveryRestURL {
+			etag = entry.ETag
+		}
+		// Otherwise the object is missing or unreadable, so download it again.
+	}
+	downloaded, etag, err := c.download(api.DiscoveryRestURL, etag)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if downloaded == nil {
+		entry, err = c.revalidated(entry)
+		return bytes, entry, true, err
+	}
+	entry, err = c.put(api, downloaded, etag)
+	return downloaded, entry, false, err
+}
+
+// FetchList returns the list of APIs, revalidating a cached list if there
+// is one.
+func (c *Cache) FetchList() (*List, error) {
+	listURL := c.ListURL
+	if listURL == "" {
+		listURL = APIsListServiceURL
+	}
+	listPath, etagPath := filepath.Join(c.Dir, cacheListName), filepath.Join(c.Dir, cacheListETagName)
+	etag := ""
+	bytes, err := ioutil.ReadFile(listPath)
+	if err == nil {
+		if etagBytes, err := ioutil.ReadFile(etagPath); err == nil {
+			etag = string(etagBytes)
+		}
+	}
+	downloaded, etag, err := c.download(listURL, etag)
+	if err != nil {
+		return nil, err
+	}
+	if downloaded != nil {
+		bytes = downloaded
+		// The list is saved before its ETag so that an interrupted save
+		// causes the list to be downloaded again.
+		if err := writeFileAtomically(listPath, bytes); err != nil {
+			return nil, err
+		}
+		if err := writeFileAtomically(etagPath, []byte(etag)); err != nil {
+			return nil, err
+		}
+	}
+	return ParseList(bytes)
+}
+
+// FetchAll fetches the documents for a list of APIs using the specified
+// number of concurrent workers. Results are returned in the order of apis.
+func (c *Cache) FetchAll(apis []*API, workers int, refresh bool) []*FetchResult {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]*FetchResult, len(apis))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				_, entry, cached, err := c.Fetch(apis[i], refresh)
+				results[i] = &FetchResult{API: apis[i], Entry: entry, Cached: cached, Err: err}
+			}
+		}()
+	}
+	for i := range apis {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return results
+}
+
+// download reads a document directly instead of with compiler.FetchFile,
+// which serializes requests and keeps every response in memory. If etag
+// is not empty, the document is only downloaded if its ETag has changed,
+// and if it hasn't, the returned bytes are nil. The ETag of the
+// downloaded document is returned with it.
+func (c *Cache) download(documentURL, etag string) ([]byte, string, error) {
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	request, err := http.NewRequest(http.MethodGet, documentURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+	if etag != "" && response.StatusCode == http.StatusNotModified {
+		return nil, etag, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("Error downloading %s: %s", documentURL, response.Status)
+	}
+	bytes, err := ioutil.ReadAll(response.Body)
+	return bytes, response.Header.Get("ETag"), err
+}
+
+// writeFileAtomically writes a file by renaming a completed temporary file
+// so that readers never see a partially-written file.
+func writeFileAtomically(path string, bytes []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(bytes); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
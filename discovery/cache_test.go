@@ -0,0 +1,151 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery_v1
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCacheFetchAll(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"kind":"discovery#restDescription","name":"` + r.URL.Path[1:] + `"}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "disco-cache")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	apis := []*API{
+		{Name: "a", Version: "v1", DiscoveryRestURL: server.URL + "/a"},
+		{Name: "b", Version: "v1", DiscoveryRestURL: server.URL + "/b"},
+		{Name: "missing", Version: "v1", DiscoveryRestURL: server.URL + "/missing"},
+	}
+	cache, err := NewCache(dir)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	results := cache.FetchAll(apis, 2, false)
+	if results[0].Err != nil || results[1].Err != nil {
+		t.Fatalf("unexpected errors: %v %v", results[0].Err, results[1].Err)
+	}
+	if results[2].Err == nil {
+		t.Errorf("expected an error for a missing document")
+	}
+
+	// Reopen the cache to verify that the index was saved and that
+	// a second run only retries the document that failed.
+	cache, err = NewCache(dir)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if n := len(cache.Entries()); n != 2 {
+		t.Errorf("unexpected number of cache entries: %d (expected 2)", n)
+	}
+	atomic.StoreInt32(&requests, 0)
+	results = cache.FetchAll(apis, 2, false)
+	if !results[0].Cached || !results[1].Cached {
+		t.Errorf("expected cached results")
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("unexpected number of requests: %d (expected 1)", n)
+	}
+	bytes, err := cache.Get(apis[1])
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if string(bytes) != `{"kind":"discovery#restDescription","name":"b"}` {
+		t.Errorf("unexpected cached document: %s", string(bytes))
+	}
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	var downloads, notModified int32
+	version := "1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.URL.Path + version + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&downloads, 1)
+		w.Header().Set("ETag", etag)
+		if r.URL.Path == "/list" {
+			w.Write([]byte(`{"kind":"discovery#directoryList","items":[{"name":"a","version":"v1","discoveryRestUrl":"` +
+				"http://" + r.Host + `/a"}]}`))
+			return
+		}
+		w.Write([]byte(`{"kind":"discovery#restDescription","revision":"` + version + `"}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "disco-cache")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	cache, err := NewCache(dir)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	cache.ListURL = server.URL + "/list"
+
+	fetch := func() (string, bool) {
+		list, err := cache.FetchList()
+		if err != nil || len(list.APIs) != 1 {
+			t.Fatalf("unexpected list: %+v %v", list, err)
+		}
+		bytes, entry, cached, err := cache.Fetch(list.APIs[0], true)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if entry.ETag != `"/a`+version+`"` {
+			t.Errorf("unexpected ETag: %s", entry.ETag)
+		}
+		return string(bytes), cached
+	}
+	if _, cached := fetch(); cached || downloads != 2 {
+		t.Errorf("expected the list and document to be downloaded (%d downloads)", downloads)
+	}
+
+	// Unchanged documents are revalidated and read from the cache.
+	if bytes, cached := fetch(); !cached || downloads != 2 || notModified != 2 || !strings.Contains(bytes, `"revision":"1"`) {
+		t.Errorf("expected the list and document to be revalidated (%d downloads, %d not modified)", downloads, notModified)
+	}
+
+	// Changed documents are downloaded again.
+	version = "2"
+	if bytes, cached := fetch(); cached || downloads != 4 || !strings.Contains(bytes, `"revision":"2"`) {
+		t.Errorf("expected the list and document to be downloaded again (%d downloads)", downloads)
+	}
+	if bytes, err := cache.Get(&API{Name: "a", Version: "v1"}); err != nil || !strings.Contains(string(bytes), `"revision":"2"`) {
+		t.Errorf("expected the cache to contain the new document: %s %v", bytes, err)
+	}
+}
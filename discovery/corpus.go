@@ -0,0 +1,311 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery_v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A Snapshot summarizes the APIs in a corpus of discovery documents so
+// that versions of the corpus can be compared. Snapshots can be saved as
+// JSON and compared with later versions of the corpus.
+type Snapshot struct {
+	// APIs are keyed by their names and versions, like "discovery:v1".
+	APIs map[string]*APISnapshot `json:"apis"`
+}
+
+// An APISnapshot summarizes the methods of an API.
+type APISnapshot struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Revision string `json:"revision,omitempty"`
+	// Methods are keyed by their ids, like "discovery.apis.list".
+	Methods map[string]*MethodSnapshot `json:"methods"`
+}
+
+// A MethodSnapshot summarizes the signature of a method. Methods are
+// changed when any of their fields are changed.
+type MethodSnapshot struct {
+	HTTPMethod string   `json:"httpMethod"`
+	Path       string   `json:"path"`
+	Parameters []string `json:"parameters,omitempty"`
+	Request    string   `json:"request,omitempty"`
+	Response   string   `json:"response,omitempty"`
+}
+
+// NewSnapshot returns an empty snapshot.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{APIs: make(map[string]*APISnapshot)}
+}
+
+// ReadSnapshot reads a snapshot that was saved as JSON.
+func ReadSnapshot(filename string) (*Snapshot, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := NewSnapshot()
+	if err := json.Unmarshal(bytes, snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot in %s: %v", filename, err)
+	}
+	return snapshot, nil
+}
+
+// Add adds an API to a snapshot, replacing any API with the same name and version.
+func (s *Snapshot) Add(api *APISnapshot) {
+	s.APIs[cacheKey(api.Name, api.Version)] = api
+}
+
+// NewAPISnapshot summarizes the methods of a discovery document.
+func NewAPISnapshot(document *Document) *APISnapshot {
+	api := &APISnapshot{
+		Name:     document.Name,
+		Version:  document.Version,
+		Revision: document.Revision,
+		Methods:  make(map[string]*MethodSnapshot),
+	}
+	api.addMethods(document.Methods, document.Name)
+	api.addResources(document.Resources, document.Name)
+	return api
+}
+
+func (api *APISnapshot) addResources(resources *Resources, prefix string) {
+	for _, namedResource := range resources.GetAdditionalProperties() {
+		name := prefix + "." + namedResource.Name
+		api.addMethods(namedResource.GetValue().GetMethods(), name)
+		api.addResources(namedResource.GetValue().GetResources(), name)
+	}
+}
+
+func (api *APISnapshot) addMethods(methods *Methods, prefix string) {
+	for _, namedMethod := range methods.GetAdditionalProperties() {
+		method := namedMethod.GetValue()
+		if method == nil {
+			continue
+		}
+		// Methods are identified by their ids or, if they have none, by their locations.
+		id := method.Id
+		if id == "" {
+			id = prefix + "." + namedMethod.Name
+		}
+		m := &MethodSnapshot{
+			HTTPMethod: method.HttpMethod,
+			Path:       method.Path,
+			Request:    method.GetRequest().GetXRef(),
+			Response:   method.GetResponse().GetXRef(),
+		}
+		for _, namedParameter := range method.GetParameters().GetAdditionalProperties() {
+			m.Parameters = append(m.Parameters, namedParameter.Name)
+		}
+		sort.Strings(m.Parameters)
+		api.Methods[id] = m
+	}
+}
+
+// A CompileResult reports the outcome of compiling one document of a corpus.
+type CompileResult struct {
+	File string
+	// API summarizes the compiled document. It is nil if the document
+	// couldn't be read or compiled.
+	API *APISnapshot
+	// Err is the error that prevented the document from being compiled
+	// or, if it was compiled, the problems found by ValidateDocument.
+	Err error
+}
+
+// CorpusFiles returns the names of the discovery documents in a directory.
+// If the directory is a Cache, these are the documents in its index.
+// Otherwise they are the JSON files in the directory and its subdirectories.
+func CorpusFiles(dir string) ([]string, error) {
+	files := make([]string, 0)
+	if _, err := os.Stat(filepath.Join(dir, cacheIndexName)); err == nil {
+		cache, err := NewCache(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range cache.Entries() {
+			files = append(files, cache.objectPath(entry.Hash))
+		}
+		return files, nil
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && strings.HasSuffix(path, ".json") {
+			files = append(files, path)
+		}
+		return err
+	})
+	return files, err
+}
+
+// CompileCorpus compiles and validates discovery documents using the
+// specified number of concurrent workers. Results are returned in the
+// order of files.
+func CompileCorpus(files []string, workers int) []*CompileResult {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]*CompileResult, len(files))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = compileCorpusFile(files[i])
+			}
+		}()
+	}
+	for i := range files {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return results
+}
+
+func compileCorpusFile(file string) *CompileResult {
+	result := &CompileResult{File: file}
+	bytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	document, err := ParseDocument(bytes)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.API = NewAPISnapshot(document)
+	result.Err = ValidateDocument(document)
+	return result
+}
+
+// NewSnapshotFromResults returns a snapshot of the documents that were compiled.
+func NewSnapshotFromResults(results []*CompileResult) *Snapshot {
+	snapshot := NewSnapshot()
+	for _, result := range results {
+		if result.API != nil {
+			snapshot.Add(result.API)
+		}
+	}
+	return snapshot
+}
+
+// A SnapshotDiff describes the differences between two snapshots. APIs
+// and methods are named as they are in snapshots, and lists are sorted.
+type SnapshotDiff struct {
+	AddedAPIs   []string   `json:"addedAPIs,omitempty"`
+	RemovedAPIs []string   `json:"removedAPIs,omitempty"`
+	ChangedAPIs []*APIDiff `json:"changedAPIs,omitempty"`
+}
+
+// An APIDiff describes the differences between two versions of an API.
+type APIDiff struct {
+	API            string   `json:"api"`
+	AddedMethods   []string `json:"addedMethods,omitempty"`
+	RemovedMethods []string `json:"removedMethods,omitempty"`
+	ChangedMethods []string `json:"changedMethods,omitempty"`
+}
+
+// IsEmpty returns true if a diff has no differences.
+func (d *SnapshotDiff) IsEmpty() bool {
+	return len(d.AddedAPIs) == 0 && len(d.RemovedAPIs) == 0 && len(d.ChangedAPIs) == 0
+}
+
+// DiffSnapshots compares two snapshots.
+func DiffSnapshots(before, after *Snapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{}
+	for _, key := range apiKeys(before.APIs, after.APIs) {
+		beforeAPI, afterAPI := before.APIs[key], after.APIs[key]
+		switch {
+		case beforeAPI == nil:
+			diff.AddedAPIs = append(diff.AddedAPIs, key)
+		case afterAPI == nil:
+			diff.RemovedAPIs = append(diff.RemovedAPIs, key)
+		default:
+			if apiDiff := diffAPIs(key, beforeAPI, afterAPI); apiDiff != nil {
+				diff.ChangedAPIs = append(diff.ChangedAPIs, apiDiff)
+			}
+		}
+	}
+	return diff
+}
+
+// Returns the differences between the methods of two versions of an API,
+// or nil if their methods are the same.
+func diffAPIs(key string, before, after *APISnapshot) *APIDiff {
+	diff := &APIDiff{API: key}
+	for _, id := range methodKeys(before.Methods, after.Methods) {
+		beforeMethod, afterMethod := before.Methods[id], after.Methods[id]
+		switch {
+		case beforeMethod == nil:
+			diff.AddedMethods = append(diff.AddedMethods, id)
+		case afterMethod == nil:
+			diff.RemovedMethods = append(diff.RemovedMethods, id)
+		case !beforeMethod.equals(afterMethod):
+			diff.ChangedMethods = append(diff.ChangedMethods, id)
+		}
+	}
+	if len(diff.AddedMethods) == 0 && len(diff.RemovedMethods) == 0 && len(diff.ChangedMethods) == 0 {
+		return nil
+	}
+	return diff
+}
+
+func (m *MethodSnapshot) equals(other *MethodSnapshot) bool {
+	return m.HTTPMethod == other.HTTPMethod &&
+		m.Path == other.Path &&
+		m.Request == other.Request &&
+		m.Response == other.Response &&
+		strings.Join(m.Parameters, ",") == strings.Join(other.Parameters, ",")
+}
+
+// Returns the sorted keys of the APIs in two snapshots.
+func apiKeys(before, after map[string]*APISnapshot) []string {
+	keys := make([]string, 0, len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if before[key] == nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Returns the sorted ids of the methods in two versions of an API.
+func methodKeys(before, after map[string]*MethodSnapshot) []string {
+	keys := make([]string, 0, len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if before[key] == nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
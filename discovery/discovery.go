@@ -18,7 +18,6 @@ package discovery_v1
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -40,9 +39,7 @@ func NewAnnotations(in *yaml.Node, context *compiler.Context) (*Annotations, err
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"required"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForAnnotations)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -52,7 +49,7 @@ func NewAnnotations(in *yaml.Node, context *compiler.Context) (*Annotations, err
 		if v1 != nil {
 			v, ok := compiler.SequenceNodeForNode(v1)
 			if ok {
-				x.Required = compiler.StringArrayForSequenceNode(v)
+				x.Required = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for required: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -80,9 +77,7 @@ func NewAuth(in *yaml.Node, context *compiler.Context) (*Auth, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"oauth2"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForAuth)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -115,9 +110,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"auth", "basePath", "baseUrl", "batchPath", "canonicalName", "description", "discoveryVersion", "documentationLink", "etag", "features", "fullyEncodeReservedExpansion", "icons", "id", "kind", "labels", "methods", "mtlsRootUrl", "name", "ownerDomain", "ownerName", "packagePath", "parameters", "protocol", "resources", "revision", "rootUrl", "schemas", "servicePath", "title", "version", "version_module"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForDocument)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -125,7 +118,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string kind = 1;
 		v1 := compiler.MapValueForKey(m, "kind")
 		if v1 != nil {
-			x.Kind, ok = compiler.StringForScalarNode(v1)
+			x.Kind, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for kind: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -134,7 +127,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string discovery_version = 2;
 		v2 := compiler.MapValueForKey(m, "discoveryVersion")
 		if v2 != nil {
-			x.DiscoveryVersion, ok = compiler.StringForScalarNode(v2)
+			x.DiscoveryVersion, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for discoveryVersion: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -143,7 +136,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string id = 3;
 		v3 := compiler.MapValueForKey(m, "id")
 		if v3 != nil {
-			x.Id, ok = compiler.StringForScalarNode(v3)
+			x.Id, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for id: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -152,7 +145,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string name = 4;
 		v4 := compiler.MapValueForKey(m, "name")
 		if v4 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v4)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -161,7 +154,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string version = 5;
 		v5 := compiler.MapValueForKey(m, "version")
 		if v5 != nil {
-			x.Version, ok = compiler.StringForScalarNode(v5)
+			x.Version, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for version: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -170,7 +163,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string revision = 6;
 		v6 := compiler.MapValueForKey(m, "revision")
 		if v6 != nil {
-			x.Revision, ok = compiler.StringForScalarNode(v6)
+			x.Revision, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for revision: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -179,7 +172,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string title = 7;
 		v7 := compiler.MapValueForKey(m, "title")
 		if v7 != nil {
-			x.Title, ok = compiler.StringForScalarNode(v7)
+			x.Title, ok = compiler.StringForScalarNodeWithContext(v7, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for title: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -188,7 +181,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string description = 8;
 		v8 := compiler.MapValueForKey(m, "description")
 		if v8 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v8)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v8, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v8))
 				errors = append(errors, compiler.NewError(context, message))
@@ -206,7 +199,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string documentation_link = 10;
 		v10 := compiler.MapValueForKey(m, "documentationLink")
 		if v10 != nil {
-			x.DocumentationLink, ok = compiler.StringForScalarNode(v10)
+			x.DocumentationLink, ok = compiler.StringForScalarNodeWithContext(v10, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for documentationLink: %s", compiler.Display(v10))
 				errors = append(errors, compiler.NewError(context, message))
@@ -217,7 +210,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		if v11 != nil {
 			v, ok := compiler.SequenceNodeForNode(v11)
 			if ok {
-				x.Labels = compiler.StringArrayForSequenceNode(v)
+				x.Labels = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for labels: %s", compiler.Display(v11))
 				errors = append(errors, compiler.NewError(context, message))
@@ -226,7 +219,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string protocol = 12;
 		v12 := compiler.MapValueForKey(m, "protocol")
 		if v12 != nil {
-			x.Protocol, ok = compiler.StringForScalarNode(v12)
+			x.Protocol, ok = compiler.StringForScalarNodeWithContext(v12, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for protocol: %s", compiler.Display(v12))
 				errors = append(errors, compiler.NewError(context, message))
@@ -235,7 +228,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string base_url = 13;
 		v13 := compiler.MapValueForKey(m, "baseUrl")
 		if v13 != nil {
-			x.BaseUrl, ok = compiler.StringForScalarNode(v13)
+			x.BaseUrl, ok = compiler.StringForScalarNodeWithContext(v13, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for baseUrl: %s", compiler.Display(v13))
 				errors = append(errors, compiler.NewError(context, message))
@@ -244,7 +237,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string base_path = 14;
 		v14 := compiler.MapValueForKey(m, "basePath")
 		if v14 != nil {
-			x.BasePath, ok = compiler.StringForScalarNode(v14)
+			x.BasePath, ok = compiler.StringForScalarNodeWithContext(v14, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for basePath: %s", compiler.Display(v14))
 				errors = append(errors, compiler.NewError(context, message))
@@ -253,7 +246,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string root_url = 15;
 		v15 := compiler.MapValueForKey(m, "rootUrl")
 		if v15 != nil {
-			x.RootUrl, ok = compiler.StringForScalarNode(v15)
+			x.RootUrl, ok = compiler.StringForScalarNodeWithContext(v15, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for rootUrl: %s", compiler.Display(v15))
 				errors = append(errors, compiler.NewError(context, message))
@@ -262,7 +255,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string service_path = 16;
 		v16 := compiler.MapValueForKey(m, "servicePath")
 		if v16 != nil {
-			x.ServicePath, ok = compiler.StringForScalarNode(v16)
+			x.ServicePath, ok = compiler.StringForScalarNodeWithContext(v16, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for servicePath: %s", compiler.Display(v16))
 				errors = append(errors, compiler.NewError(context, message))
@@ -271,7 +264,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string batch_path = 17;
 		v17 := compiler.MapValueForKey(m, "batchPath")
 		if v17 != nil {
-			x.BatchPath, ok = compiler.StringForScalarNode(v17)
+			x.BatchPath, ok = compiler.StringForScalarNodeWithContext(v17, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for batchPath: %s", compiler.Display(v17))
 				errors = append(errors, compiler.NewError(context, message))
@@ -300,7 +293,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		if v20 != nil {
 			v, ok := compiler.SequenceNodeForNode(v20)
 			if ok {
-				x.Features = compiler.StringArrayForSequenceNode(v)
+				x.Features = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for features: %s", compiler.Display(v20))
 				errors = append(errors, compiler.NewError(context, message))
@@ -336,7 +329,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string etag = 24;
 		v24 := compiler.MapValueForKey(m, "etag")
 		if v24 != nil {
-			x.Etag, ok = compiler.StringForScalarNode(v24)
+			x.Etag, ok = compiler.StringForScalarNodeWithContext(v24, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for etag: %s", compiler.Display(v24))
 				errors = append(errors, compiler.NewError(context, message))
@@ -345,7 +338,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string owner_domain = 25;
 		v25 := compiler.MapValueForKey(m, "ownerDomain")
 		if v25 != nil {
-			x.OwnerDomain, ok = compiler.StringForScalarNode(v25)
+			x.OwnerDomain, ok = compiler.StringForScalarNodeWithContext(v25, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for ownerDomain: %s", compiler.Display(v25))
 				errors = append(errors, compiler.NewError(context, message))
@@ -354,7 +347,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string owner_name = 26;
 		v26 := compiler.MapValueForKey(m, "ownerName")
 		if v26 != nil {
-			x.OwnerName, ok = compiler.StringForScalarNode(v26)
+			x.OwnerName, ok = compiler.StringForScalarNodeWithContext(v26, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for ownerName: %s", compiler.Display(v26))
 				errors = append(errors, compiler.NewError(context, message))
@@ -372,7 +365,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string canonical_name = 28;
 		v28 := compiler.MapValueForKey(m, "canonicalName")
 		if v28 != nil {
-			x.CanonicalName, ok = compiler.StringForScalarNode(v28)
+			x.CanonicalName, ok = compiler.StringForScalarNodeWithContext(v28, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for canonicalName: %s", compiler.Display(v28))
 				errors = append(errors, compiler.NewError(context, message))
@@ -390,7 +383,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string package_path = 30;
 		v30 := compiler.MapValueForKey(m, "packagePath")
 		if v30 != nil {
-			x.PackagePath, ok = compiler.StringForScalarNode(v30)
+			x.PackagePath, ok = compiler.StringForScalarNodeWithContext(v30, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for packagePath: %s", compiler.Display(v30))
 				errors = append(errors, compiler.NewError(context, message))
@@ -399,7 +392,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string mtls_root_url = 31;
 		v31 := compiler.MapValueForKey(m, "mtlsRootUrl")
 		if v31 != nil {
-			x.MtlsRootUrl, ok = compiler.StringForScalarNode(v31)
+			x.MtlsRootUrl, ok = compiler.StringForScalarNodeWithContext(v31, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for mtlsRootUrl: %s", compiler.Display(v31))
 				errors = append(errors, compiler.NewError(context, message))
@@ -424,9 +417,7 @@ func NewIcons(in *yaml.Node, context *compiler.Context) (*Icons, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"x16", "x32"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForIcons)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -434,7 +425,7 @@ func NewIcons(in *yaml.Node, context *compiler.Context) (*Icons, error) {
 		// string x16 = 1;
 		v1 := compiler.MapValueForKey(m, "x16")
 		if v1 != nil {
-			x.X16, ok = compiler.StringForScalarNode(v1)
+			x.X16, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for x16: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -443,7 +434,7 @@ func NewIcons(in *yaml.Node, context *compiler.Context) (*Icons, error) {
 		// string x32 = 2;
 		v2 := compiler.MapValueForKey(m, "x32")
 		if v2 != nil {
-			x.X32, ok = compiler.StringForScalarNode(v2)
+			x.X32, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for x32: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -462,9 +453,7 @@ func NewMediaUpload(in *yaml.Node, context *compiler.Context) (*MediaUpload, err
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"accept", "maxSize", "protocols", "supportsSubscription"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForMediaUpload)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -474,7 +463,7 @@ func NewMediaUpload(in *yaml.Node, context *compiler.Context) (*MediaUpload, err
 		if v1 != nil {
 			v, ok := compiler.SequenceNodeForNode(v1)
 			if ok {
-				x.Accept = compiler.StringArrayForSequenceNode(v)
+				x.Accept = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for accept: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -483,7 +472,7 @@ func NewMediaUpload(in *yaml.Node, context *compiler.Context) (*MediaUpload, err
 		// string max_size = 2;
 		v2 := compiler.MapValueForKey(m, "maxSize")
 		if v2 != nil {
-			x.MaxSize, ok = compiler.StringForScalarNode(v2)
+			x.MaxSize, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for maxSize: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -520,9 +509,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"description", "etagRequired", "flatPath", "httpMethod", "id", "mediaUpload", "parameterOrder", "parameters", "path", "request", "response", "scopes", "streamingType", "supportsMediaDownload", "supportsMediaUpload", "supportsSubscription", "useMediaDownloadService"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForMethod)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -530,7 +517,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		// string id = 1;
 		v1 := compiler.MapValueForKey(m, "id")
 		if v1 != nil {
-			x.Id, ok = compiler.StringForScalarNode(v1)
+			x.Id, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for id: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -539,7 +526,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		// string path = 2;
 		v2 := compiler.MapValueForKey(m, "path")
 		if v2 != nil {
-			x.Path, ok = compiler.StringForScalarNode(v2)
+			x.Path, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for path: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -548,7 +535,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		// string http_method = 3;
 		v3 := compiler.MapValueForKey(m, "httpMethod")
 		if v3 != nil {
-			x.HttpMethod, ok = compiler.StringForScalarNode(v3)
+			x.HttpMethod, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for httpMethod: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -557,7 +544,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		// string description = 4;
 		v4 := compiler.MapValueForKey(m, "description")
 		if v4 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v4)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -577,7 +564,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		if v6 != nil {
 			v, ok := compiler.SequenceNodeForNode(v6)
 			if ok {
-				x.ParameterOrder = compiler.StringArrayForSequenceNode(v)
+				x.ParameterOrder = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for parameterOrder: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -606,7 +593,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		if v9 != nil {
 			v, ok := compiler.SequenceNodeForNode(v9)
 			if ok {
-				x.Scopes = compiler.StringArrayForSequenceNode(v)
+				x.Scopes = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for scopes: %s", compiler.Display(v9))
 				errors = append(errors, compiler.NewError(context, message))
@@ -660,7 +647,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		// string flat_path = 15;
 		v15 := compiler.MapValueForKey(m, "flatPath")
 		if v15 != nil {
-			x.FlatPath, ok = compiler.StringForScalarNode(v15)
+			x.FlatPath, ok = compiler.StringForScalarNodeWithContext(v15, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for flatPath: %s", compiler.Display(v15))
 				errors = append(errors, compiler.NewError(context, message))
@@ -678,7 +665,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 		// string streaming_type = 17;
 		v17 := compiler.MapValueForKey(m, "streamingType")
 		if v17 != nil {
-			x.StreamingType, ok = compiler.StringForScalarNode(v17)
+			x.StreamingType, ok = compiler.StringForScalarNodeWithContext(v17, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for streamingType: %s", compiler.Display(v17))
 				errors = append(errors, compiler.NewError(context, message))
@@ -701,7 +688,7 @@ func NewMethods(in *yaml.Node, context *compiler.Context) (*Methods, error) {
 		// MAP: Method
 		x.AdditionalProperties = make([]*NamedMethod, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedMethod{}
@@ -727,9 +714,7 @@ func NewNamedMethod(in *yaml.Node, context *compiler.Context) (*NamedMethod, err
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedMethod)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -737,7 +722,7 @@ func NewNamedMethod(in *yaml.Node, context *compiler.Context) (*NamedMethod, err
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -765,9 +750,7 @@ func NewNamedParameter(in *yaml.Node, context *compiler.Context) (*NamedParamete
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedParameter)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -775,7 +758,7 @@ func NewNamedParameter(in *yaml.Node, context *compiler.Context) (*NamedParamete
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -803,9 +786,7 @@ func NewNamedResource(in *yaml.Node, context *compiler.Context) (*NamedResource,
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedResource)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -813,7 +794,7 @@ func NewNamedResource(in *yaml.Node, context *compiler.Context) (*NamedResource,
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -841,9 +822,7 @@ func NewNamedSchema(in *yaml.Node, context *compiler.Context) (*NamedSchema, err
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -851,7 +830,7 @@ func NewNamedSchema(in *yaml.Node, context *compiler.Context) (*NamedSchema, err
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -879,9 +858,7 @@ func NewNamedScope(in *yaml.Node, context *compiler.Context) (*NamedScope, error
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedScope)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -889,7 +866,7 @@ func NewNamedScope(in *yaml.Node, context *compiler.Context) (*NamedScope, error
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -917,9 +894,7 @@ func NewOauth2(in *yaml.Node, context *compiler.Context) (*Oauth2, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"scopes"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOauth2)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -946,9 +921,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"$ref", "additionalProperties", "annotations", "default", "description", "enum", "enumDescriptions", "format", "id", "items", "location", "maximum", "minimum", "pattern", "properties", "repeated", "required", "type"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForParameter)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -956,7 +929,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string id = 1;
 		v1 := compiler.MapValueForKey(m, "id")
 		if v1 != nil {
-			x.Id, ok = compiler.StringForScalarNode(v1)
+			x.Id, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for id: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -965,7 +938,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string type = 2;
 		v2 := compiler.MapValueForKey(m, "type")
 		if v2 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v2)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -974,7 +947,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string _ref = 3;
 		v3 := compiler.MapValueForKey(m, "$ref")
 		if v3 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v3)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -983,7 +956,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string description = 4;
 		v4 := compiler.MapValueForKey(m, "description")
 		if v4 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v4)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -992,7 +965,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string default = 5;
 		v5 := compiler.MapValueForKey(m, "default")
 		if v5 != nil {
-			x.Default, ok = compiler.StringForScalarNode(v5)
+			x.Default, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for default: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1010,7 +983,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string format = 7;
 		v7 := compiler.MapValueForKey(m, "format")
 		if v7 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v7)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v7, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1019,7 +992,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string pattern = 8;
 		v8 := compiler.MapValueForKey(m, "pattern")
 		if v8 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v8)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v8, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v8))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1028,7 +1001,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string minimum = 9;
 		v9 := compiler.MapValueForKey(m, "minimum")
 		if v9 != nil {
-			x.Minimum, ok = compiler.StringForScalarNode(v9)
+			x.Minimum, ok = compiler.StringForScalarNodeWithContext(v9, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for minimum: %s", compiler.Display(v9))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1037,7 +1010,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string maximum = 10;
 		v10 := compiler.MapValueForKey(m, "maximum")
 		if v10 != nil {
-			x.Maximum, ok = compiler.StringForScalarNode(v10)
+			x.Maximum, ok = compiler.StringForScalarNodeWithContext(v10, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for maximum: %s", compiler.Display(v10))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1048,7 +1021,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		if v11 != nil {
 			v, ok := compiler.SequenceNodeForNode(v11)
 			if ok {
-				x.Enum = compiler.StringArrayForSequenceNode(v)
+				x.Enum = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for enum: %s", compiler.Display(v11))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1059,7 +1032,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		if v12 != nil {
 			v, ok := compiler.SequenceNodeForNode(v12)
 			if ok {
-				x.EnumDescriptions = compiler.StringArrayForSequenceNode(v)
+				x.EnumDescriptions = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for enumDescriptions: %s", compiler.Display(v12))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1077,7 +1050,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string location = 14;
 		v14 := compiler.MapValueForKey(m, "location")
 		if v14 != nil {
-			x.Location, ok = compiler.StringForScalarNode(v14)
+			x.Location, ok = compiler.StringForScalarNodeWithContext(v14, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for location: %s", compiler.Display(v14))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1136,7 +1109,7 @@ func NewParameters(in *yaml.Node, context *compiler.Context) (*Parameters, error
 		// MAP: Parameter
 		x.AdditionalProperties = make([]*NamedParameter, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedParameter{}
@@ -1162,9 +1135,7 @@ func NewProtocols(in *yaml.Node, context *compiler.Context) (*Protocols, error)
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"resumable", "simple"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForProtocols)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1200,9 +1171,7 @@ func NewRequest(in *yaml.Node, context *compiler.Context) (*Request, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"$ref", "parameterName"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForRequest)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1210,7 +1179,7 @@ func NewRequest(in *yaml.Node, context *compiler.Context) (*Request, error) {
 		// string _ref = 1;
 		v1 := compiler.MapValueForKey(m, "$ref")
 		if v1 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v1)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1219,7 +1188,7 @@ func NewRequest(in *yaml.Node, context *compiler.Context) (*Request, error) {
 		// string parameter_name = 2;
 		v2 := compiler.MapValueForKey(m, "parameterName")
 		if v2 != nil {
-			x.ParameterName, ok = compiler.StringForScalarNode(v2)
+			x.ParameterName, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for parameterName: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1238,9 +1207,7 @@ func NewResource(in *yaml.Node, context *compiler.Context) (*Resource, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"methods", "resources"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForResource)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1280,7 +1247,7 @@ func NewResources(in *yaml.Node, context *compiler.Context) (*Resources, error)
 		// MAP: Resource
 		x.AdditionalProperties = make([]*NamedResource, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedResource{}
@@ -1306,9 +1273,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"$ref"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForResponse)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1316,7 +1281,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 		// string _ref = 1;
 		v1 := compiler.MapValueForKey(m, "$ref")
 		if v1 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v1)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1335,9 +1300,7 @@ func NewResumable(in *yaml.Node, context *compiler.Context) (*Resumable, error)
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"multipart", "path"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForResumable)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1354,7 +1317,7 @@ func NewResumable(in *yaml.Node, context *compiler.Context) (*Resumable, error)
 		// string path = 2;
 		v2 := compiler.MapValueForKey(m, "path")
 		if v2 != nil {
-			x.Path, ok = compiler.StringForScalarNode(v2)
+			x.Path, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for path: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1373,9 +1336,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"$ref", "additionalProperties", "annotations", "default", "description", "enum", "enumDescriptions", "format", "id", "items", "location", "maximum", "minimum", "pattern", "properties", "readOnly", "repeated", "required", "type"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1383,7 +1344,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string id = 1;
 		v1 := compiler.MapValueForKey(m, "id")
 		if v1 != nil {
-			x.Id, ok = compiler.StringForScalarNode(v1)
+			x.Id, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for id: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1392,7 +1353,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string type = 2;
 		v2 := compiler.MapValueForKey(m, "type")
 		if v2 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v2)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1401,7 +1362,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1410,7 +1371,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string default = 4;
 		v4 := compiler.MapValueForKey(m, "default")
 		if v4 != nil {
-			x.Default, ok = compiler.StringForScalarNode(v4)
+			x.Default, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for default: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1428,7 +1389,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string format = 6;
 		v6 := compiler.MapValueForKey(m, "format")
 		if v6 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v6)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1437,7 +1398,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string pattern = 7;
 		v7 := compiler.MapValueForKey(m, "pattern")
 		if v7 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v7)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v7, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1446,7 +1407,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string minimum = 8;
 		v8 := compiler.MapValueForKey(m, "minimum")
 		if v8 != nil {
-			x.Minimum, ok = compiler.StringForScalarNode(v8)
+			x.Minimum, ok = compiler.StringForScalarNodeWithContext(v8, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for minimum: %s", compiler.Display(v8))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1455,7 +1416,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string maximum = 9;
 		v9 := compiler.MapValueForKey(m, "maximum")
 		if v9 != nil {
-			x.Maximum, ok = compiler.StringForScalarNode(v9)
+			x.Maximum, ok = compiler.StringForScalarNodeWithContext(v9, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for maximum: %s", compiler.Display(v9))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1466,7 +1427,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		if v10 != nil {
 			v, ok := compiler.SequenceNodeForNode(v10)
 			if ok {
-				x.Enum = compiler.StringArrayForSequenceNode(v)
+				x.Enum = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for enum: %s", compiler.Display(v10))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1477,7 +1438,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		if v11 != nil {
 			v, ok := compiler.SequenceNodeForNode(v11)
 			if ok {
-				x.EnumDescriptions = compiler.StringArrayForSequenceNode(v)
+				x.EnumDescriptions = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for enumDescriptions: %s", compiler.Display(v11))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1495,7 +1456,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string location = 13;
 		v13 := compiler.MapValueForKey(m, "location")
 		if v13 != nil {
-			x.Location, ok = compiler.StringForScalarNode(v13)
+			x.Location, ok = compiler.StringForScalarNodeWithContext(v13, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for location: %s", compiler.Display(v13))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1531,7 +1492,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string _ref = 17;
 		v17 := compiler.MapValueForKey(m, "$ref")
 		if v17 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v17)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v17, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v17))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1572,7 +1533,7 @@ func NewSchemas(in *yaml.Node, context *compiler.Context) (*Schemas, error) {
 		// MAP: Schema
 		x.AdditionalProperties = make([]*NamedSchema, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedSchema{}
@@ -1598,9 +1559,7 @@ func NewScope(in *yaml.Node, context *compiler.Context) (*Scope, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"description"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForScope)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1608,7 +1567,7 @@ func NewScope(in *yaml.Node, context *compiler.Context) (*Scope, error) {
 		// string description = 1;
 		v1 := compiler.MapValueForKey(m, "description")
 		if v1 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v1)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1631,7 +1590,7 @@ func NewScopes(in *yaml.Node, context *compiler.Context) (*Scopes, error) {
 		// MAP: Scope
 		x.AdditionalProperties = make([]*NamedScope, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedScope{}
@@ -1657,9 +1616,7 @@ func NewSimple(in *yaml.Node, context *compiler.Context) (*Simple, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"multipart", "path"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForSimple)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1676,7 +1633,7 @@ func NewSimple(in *yaml.Node, context *compiler.Context) (*Simple, error) {
 		// string path = 2;
 		v2 := compiler.MapValueForKey(m, "path")
 		if v2 != nil {
-			x.Path, ok = compiler.StringForScalarNode(v2)
+			x.Path, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for path: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1692,8 +1649,760 @@ func NewStringArray(in *yaml.Node, context *compiler.Context) (*StringArray, err
 	x := &StringArray{}
 	x.Value = make([]string, 0)
 	for _, node := range in.Content {
-		s, _ := compiler.StringForScalarNode(node)
+		s, _ := compiler.StringForScalarNodeWithContext(node, context)
 		x.Value = append(x.Value, s)
 	}
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
+
+// rawInfoForAnnotations returns a description of Annotations suitable for JSON or YAML export.
+func rawInfoForAnnotations(m *Annotations, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if len(m.Required) != 0 {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.StringArray(m.Required))
+	}
+	return info
+}
+
+// rawInfoForAny returns a description of Any suitable for JSON or YAML export.
+func rawInfoForAny(m *Any, b *compiler.NodeBuilder) *yaml.Node {
+	var err error
+	var node yaml.Node
+	err = yaml.Unmarshal([]byte(m.Yaml), &node)
+	if err == nil {
+		if node.Kind == yaml.DocumentNode {
+			return node.Content[0]
+		}
+		return &node
+	}
+	return b.Null()
+}
+
+// rawInfoForAuth returns a description of Auth suitable for JSON or YAML export.
+func rawInfoForAuth(m *Auth, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Oauth2 != nil {
+		info.Content = append(info.Content, b.Key("oauth2"))
+		info.Content = append(info.Content, rawInfoForOauth2(m.Oauth2, b))
+	}
+	return info
+}
+
+// rawInfoForDocument returns a description of Document suitable for JSON or YAML export.
+func rawInfoForDocument(m *Document, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16)
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("kind"))
+	info.Content = append(info.Content, b.String(m.Kind))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("discoveryVersion"))
+	info.Content = append(info.Content, b.String(m.DiscoveryVersion))
+	if m.Id != "" {
+		info.Content = append(info.Content, b.Key("id"))
+		info.Content = append(info.Content, b.String(m.Id))
+	}
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Version != "" {
+		info.Content = append(info.Content, b.Key("version"))
+		info.Content = append(info.Content, b.String(m.Version))
+	}
+	if m.Revision != "" {
+		info.Content = append(info.Content, b.Key("revision"))
+		info.Content = append(info.Content, b.String(m.Revision))
+	}
+	if m.Title != "" {
+		info.Content = append(info.Content, b.Key("title"))
+		info.Content = append(info.Content, b.String(m.Title))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Icons != nil {
+		info.Content = append(info.Content, b.Key("icons"))
+		info.Content = append(info.Content, rawInfoForIcons(m.Icons, b))
+	}
+	if m.DocumentationLink != "" {
+		info.Content = append(info.Content, b.Key("documentationLink"))
+		info.Content = append(info.Content, b.String(m.DocumentationLink))
+	}
+	if len(m.Labels) != 0 {
+		info.Content = append(info.Content, b.Key("labels"))
+		info.Content = append(info.Content, b.StringArray(m.Labels))
+	}
+	if m.Protocol != "" {
+		info.Content = append(info.Content, b.Key("protocol"))
+		info.Content = append(info.Content, b.String(m.Protocol))
+	}
+	if m.BaseUrl != "" {
+		info.Content = append(info.Content, b.Key("baseUrl"))
+		info.Content = append(info.Content, b.String(m.BaseUrl))
+	}
+	if m.BasePath != "" {
+		info.Content = append(info.Content, b.Key("basePath"))
+		info.Content = append(info.Content, b.String(m.BasePath))
+	}
+	if m.RootUrl != "" {
+		info.Content = append(info.Content, b.Key("rootUrl"))
+		info.Content = append(info.Content, b.String(m.RootUrl))
+	}
+	if m.ServicePath != "" {
+		info.Content = append(info.Content, b.Key("servicePath"))
+		info.Content = append(info.Content, b.String(m.ServicePath))
+	}
+	if m.BatchPath != "" {
+		info.Content = append(info.Content, b.Key("batchPath"))
+		info.Content = append(info.Content, b.String(m.BatchPath))
+	}
+	if m.Parameters != nil {
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, rawInfoForParameters(m.Parameters, b))
+	}
+	if m.Auth != nil {
+		info.Content = append(info.Content, b.Key("auth"))
+		info.Content = append(info.Content, rawInfoForAuth(m.Auth, b))
+	}
+	if len(m.Features) != 0 {
+		info.Content = append(info.Content, b.Key("features"))
+		info.Content = append(info.Content, b.StringArray(m.Features))
+	}
+	if m.Schemas != nil {
+		info.Content = append(info.Content, b.Key("schemas"))
+		info.Content = append(info.Content, rawInfoForSchemas(m.Schemas, b))
+	}
+	if m.Methods != nil {
+		info.Content = append(info.Content, b.Key("methods"))
+		info.Content = append(info.Content, rawInfoForMethods(m.Methods, b))
+	}
+	if m.Resources != nil {
+		info.Content = append(info.Content, b.Key("resources"))
+		info.Content = append(info.Content, rawInfoForResources(m.Resources, b))
+	}
+	if m.Etag != "" {
+		info.Content = append(info.Content, b.Key("etag"))
+		info.Content = append(info.Content, b.String(m.Etag))
+	}
+	if m.OwnerDomain != "" {
+		info.Content = append(info.Content, b.Key("ownerDomain"))
+		info.Content = append(info.Content, b.String(m.OwnerDomain))
+	}
+	if m.OwnerName != "" {
+		info.Content = append(info.Content, b.Key("ownerName"))
+		info.Content = append(info.Content, b.String(m.OwnerName))
+	}
+	if m.VersionModule != false {
+		info.Content = append(info.Content, b.Key("version_module"))
+		info.Content = append(info.Content, b.Bool(m.VersionModule))
+	}
+	if m.CanonicalName != "" {
+		info.Content = append(info.Content, b.Key("canonicalName"))
+		info.Content = append(info.Content, b.String(m.CanonicalName))
+	}
+	if m.FullyEncodeReservedExpansion != false {
+		info.Content = append(info.Content, b.Key("fullyEncodeReservedExpansion"))
+		info.Content = append(info.Content, b.Bool(m.FullyEncodeReservedExpansion))
+	}
+	if m.PackagePath != "" {
+		info.Content = append(info.Content, b.Key("packagePath"))
+		info.Content = append(info.Content, b.String(m.PackagePath))
+	}
+	if m.MtlsRootUrl != "" {
+		info.Content = append(info.Content, b.Key("mtlsRootUrl"))
+		info.Content = append(info.Content, b.String(m.MtlsRootUrl))
+	}
+	return info
+}
+
+// rawInfoForIcons returns a description of Icons suitable for JSON or YAML export.
+func rawInfoForIcons(m *Icons, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("x16"))
+	info.Content = append(info.Content, b.String(m.X16))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("x32"))
+	info.Content = append(info.Content, b.String(m.X32))
+	return info
+}
+
+// rawInfoForMediaUpload returns a description of MediaUpload suitable for JSON or YAML export.
+func rawInfoForMediaUpload(m *MediaUpload, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(8)
+	if len(m.Accept) != 0 {
+		info.Content = append(info.Content, b.Key("accept"))
+		info.Content = append(info.Content, b.StringArray(m.Accept))
+	}
+	if m.MaxSize != "" {
+		info.Content = append(info.Content, b.Key("maxSize"))
+		info.Content = append(info.Content, b.String(m.MaxSize))
+	}
+	if m.Protocols != nil {
+		info.Content = append(info.Content, b.Key("protocols"))
+		info.Content = append(info.Content, rawInfoForProtocols(m.Protocols, b))
+	}
+	if m.SupportsSubscription != false {
+		info.Content = append(info.Content, b.Key("supportsSubscription"))
+		info.Content = append(info.Content, b.Bool(m.SupportsSubscription))
+	}
+	return info
+}
+
+// rawInfoForMethod returns a description of Method suitable for JSON or YAML export.
+func rawInfoForMethod(m *Method, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16)
+	if m.Id != "" {
+		info.Content = append(info.Content, b.Key("id"))
+		info.Content = append(info.Content, b.String(m.Id))
+	}
+	if m.Path != "" {
+		info.Content = append(info.Content, b.Key("path"))
+		info.Content = append(info.Content, b.String(m.Path))
+	}
+	if m.HttpMethod != "" {
+		info.Content = append(info.Content, b.Key("httpMethod"))
+		info.Content = append(info.Content, b.String(m.HttpMethod))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Parameters != nil {
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, rawInfoForParameters(m.Parameters, b))
+	}
+	if len(m.ParameterOrder) != 0 {
+		info.Content = append(info.Content, b.Key("parameterOrder"))
+		info.Content = append(info.Content, b.StringArray(m.ParameterOrder))
+	}
+	if m.Request != nil {
+		info.Content = append(info.Content, b.Key("request"))
+		info.Content = append(info.Content, rawInfoForRequest(m.Request, b))
+	}
+	if m.Response != nil {
+		info.Content = append(info.Content, b.Key("response"))
+		info.Content = append(info.Content, rawInfoForResponse(m.Response, b))
+	}
+	if len(m.Scopes) != 0 {
+		info.Content = append(info.Content, b.Key("scopes"))
+		info.Content = append(info.Content, b.StringArray(m.Scopes))
+	}
+	if m.SupportsMediaDownload != false {
+		info.Content = append(info.Content, b.Key("supportsMediaDownload"))
+		info.Content = append(info.Content, b.Bool(m.SupportsMediaDownload))
+	}
+	if m.SupportsMediaUpload != false {
+		info.Content = append(info.Content, b.Key("supportsMediaUpload"))
+		info.Content = append(info.Content, b.Bool(m.SupportsMediaUpload))
+	}
+	if m.UseMediaDownloadService != false {
+		info.Content = append(info.Content, b.Key("useMediaDownloadService"))
+		info.Content = append(info.Content, b.Bool(m.UseMediaDownloadService))
+	}
+	if m.MediaUpload != nil {
+		info.Content = append(info.Content, b.Key("mediaUpload"))
+		info.Content = append(info.Content, rawInfoForMediaUpload(m.MediaUpload, b))
+	}
+	if m.SupportsSubscription != false {
+		info.Content = append(info.Content, b.Key("supportsSubscription"))
+		info.Content = append(info.Content, b.Bool(m.SupportsSubscription))
+	}
+	if m.FlatPath != "" {
+		info.Content = append(info.Content, b.Key("flatPath"))
+		info.Content = append(info.Content, b.String(m.FlatPath))
+	}
+	if m.EtagRequired != false {
+		info.Content = append(info.Content, b.Key("etagRequired"))
+		info.Content = append(info.Content, b.Bool(m.EtagRequired))
+	}
+	if m.StreamingType != "" {
+		info.Content = append(info.Content, b.Key("streamingType"))
+		info.Content = append(info.Content, b.String(m.StreamingType))
+	}
+	return info
+}
+
+// rawInfoForMethods returns a description of Methods suitable for JSON or YAML export.
+func rawInfoForMethods(m *Methods, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForMethod(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForNamedMethod returns a description of NamedMethod suitable for JSON or YAML export.
+func rawInfoForNamedMethod(m *NamedMethod, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Method StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedParameter returns a description of NamedParameter suitable for JSON or YAML export.
+func rawInfoForNamedParameter(m *NamedParameter, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Parameter StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedResource returns a description of NamedResource suitable for JSON or YAML export.
+func rawInfoForNamedResource(m *NamedResource, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Resource StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedSchema returns a description of NamedSchema suitable for JSON or YAML export.
+func rawInfoForNamedSchema(m *NamedSchema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Schema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedScope returns a description of NamedScope suitable for JSON or YAML export.
+func rawInfoForNamedScope(m *NamedScope, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Scope StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForOauth2 returns a description of Oauth2 suitable for JSON or YAML export.
+func rawInfoForOauth2(m *Oauth2, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Scopes != nil {
+		info.Content = append(info.Content, b.Key("scopes"))
+		info.Content = append(info.Content, rawInfoForScopes(m.Scopes, b))
+	}
+	return info
+}
+
+// rawInfoForParameter returns a description of Parameter suitable for JSON or YAML export.
+func rawInfoForParameter(m *Parameter, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16)
+	if m.Id != "" {
+		info.Content = append(info.Content, b.Key("id"))
+		info.Content = append(info.Content, b.String(m.Id))
+	}
+	if m.Type != "" {
+		info.Content = append(info.Content, b.Key("type"))
+		info.Content = append(info.Content, b.String(m.Type))
+	}
+	if m.XRef != "" {
+		info.Content = append(info.Content, b.Key("$ref"))
+		info.Content = append(info.Content, b.String(m.XRef))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Default != "" {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, b.String(m.Default))
+	}
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.Minimum != "" {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.String(m.Minimum))
+	}
+	if m.Maximum != "" {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.String(m.Maximum))
+	}
+	if len(m.Enum) != 0 {
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, b.StringArray(m.Enum))
+	}
+	if len(m.EnumDescriptions) != 0 {
+		info.Content = append(info.Content, b.Key("enumDescriptions"))
+		info.Content = append(info.Content, b.StringArray(m.EnumDescriptions))
+	}
+	if m.Repeated != false {
+		info.Content = append(info.Content, b.Key("repeated"))
+		info.Content = append(info.Content, b.Bool(m.Repeated))
+	}
+	if m.Location != "" {
+		info.Content = append(info.Content, b.Key("location"))
+		info.Content = append(info.Content, b.String(m.Location))
+	}
+	if m.Properties != nil {
+		info.Content = append(info.Content, b.Key("properties"))
+		info.Content = append(info.Content, rawInfoForSchemas(m.Properties, b))
+	}
+	if m.AdditionalProperties != nil {
+		info.Content = append(info.Content, b.Key("additionalProperties"))
+		info.Content = append(info.Content, rawInfoForSchema(m.AdditionalProperties, b))
+	}
+	if m.Items != nil {
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, rawInfoForSchema(m.Items, b))
+	}
+	if m.Annotations != nil {
+		info.Content = append(info.Content, b.Key("annotations"))
+		info.Content = append(info.Content, rawInfoForAnnotations(m.Annotations, b))
+	}
+	return info
+}
+
+// rawInfoForParameters returns a description of Parameters suitable for JSON or YAML export.
+func rawInfoForParameters(m *Parameters, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForParameter(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForProtocols returns a description of Protocols suitable for JSON or YAML export.
+func rawInfoForProtocols(m *Protocols, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.Simple != nil {
+		info.Content = append(info.Content, b.Key("simple"))
+		info.Content = append(info.Content, rawInfoForSimple(m.Simple, b))
+	}
+	if m.Resumable != nil {
+		info.Content = append(info.Content, b.Key("resumable"))
+		info.Content = append(info.Content, rawInfoForResumable(m.Resumable, b))
+	}
+	return info
+}
+
+// rawInfoForRequest returns a description of Request suitable for JSON or YAML export.
+func rawInfoForRequest(m *Request, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.XRef != "" {
+		info.Content = append(info.Content, b.Key("$ref"))
+		info.Content = append(info.Content, b.String(m.XRef))
+	}
+	if m.ParameterName != "" {
+		info.Content = append(info.Content, b.Key("parameterName"))
+		info.Content = append(info.Content, b.String(m.ParameterName))
+	}
+	return info
+}
+
+// rawInfoForResource returns a description of Resource suitable for JSON or YAML export.
+func rawInfoForResource(m *Resource, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.Methods != nil {
+		info.Content = append(info.Content, b.Key("methods"))
+		info.Content = append(info.Content, rawInfoForMethods(m.Methods, b))
+	}
+	if m.Resources != nil {
+		info.Content = append(info.Content, b.Key("resources"))
+		info.Content = append(info.Content, rawInfoForResources(m.Resources, b))
+	}
+	return info
+}
+
+// rawInfoForResources returns a description of Resources suitable for JSON or YAML export.
+func rawInfoForResources(m *Resources, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForResource(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForResponse returns a description of Response suitable for JSON or YAML export.
+func rawInfoForResponse(m *Response, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.XRef != "" {
+		info.Content = append(info.Content, b.Key("$ref"))
+		info.Content = append(info.Content, b.String(m.XRef))
+	}
+	return info
+}
+
+// rawInfoForResumable returns a description of Resumable suitable for JSON or YAML export.
+func rawInfoForResumable(m *Resumable, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.Multipart != false {
+		info.Content = append(info.Content, b.Key("multipart"))
+		info.Content = append(info.Content, b.Bool(m.Multipart))
+	}
+	if m.Path != "" {
+		info.Content = append(info.Content, b.Key("path"))
+		info.Content = append(info.Content, b.String(m.Path))
+	}
+	return info
+}
+
+// rawInfoForSchema returns a description of Schema suitable for JSON or YAML export.
+func rawInfoForSchema(m *Schema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16)
+	if m.Id != "" {
+		info.Content = append(info.Content, b.Key("id"))
+		info.Content = append(info.Content, b.String(m.Id))
+	}
+	if m.Type != "" {
+		info.Content = append(info.Content, b.Key("type"))
+		info.Content = append(info.Content, b.String(m.Type))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Default != "" {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, b.String(m.Default))
+	}
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.Minimum != "" {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.String(m.Minimum))
+	}
+	if m.Maximum != "" {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.String(m.Maximum))
+	}
+	if len(m.Enum) != 0 {
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, b.StringArray(m.Enum))
+	}
+	if len(m.EnumDescriptions) != 0 {
+		info.Content = append(info.Content, b.Key("enumDescriptions"))
+		info.Content = append(info.Content, b.StringArray(m.EnumDescriptions))
+	}
+	if m.Repeated != false {
+		info.Content = append(info.Content, b.Key("repeated"))
+		info.Content = append(info.Content, b.Bool(m.Repeated))
+	}
+	if m.Location != "" {
+		info.Content = append(info.Content, b.Key("location"))
+		info.Content = append(info.Content, b.String(m.Location))
+	}
+	if m.Properties != nil {
+		info.Content = append(info.Content, b.Key("properties"))
+		info.Content = append(info.Content, rawInfoForSchemas(m.Properties, b))
+	}
+	if m.AdditionalProperties != nil {
+		info.Content = append(info.Content, b.Key("additionalProperties"))
+		info.Content = append(info.Content, rawInfoForSchema(m.AdditionalProperties, b))
+	}
+	if m.Items != nil {
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, rawInfoForSchema(m.Items, b))
+	}
+	if m.XRef != "" {
+		info.Content = append(info.Content, b.Key("$ref"))
+		info.Content = append(info.Content, b.String(m.XRef))
+	}
+	if m.Annotations != nil {
+		info.Content = append(info.Content, b.Key("annotations"))
+		info.Content = append(info.Content, rawInfoForAnnotations(m.Annotations, b))
+	}
+	if m.ReadOnly != false {
+		info.Content = append(info.Content, b.Key("readOnly"))
+		info.Content = append(info.Content, b.Bool(m.ReadOnly))
+	}
+	return info
+}
+
+// rawInfoForSchemas returns a description of Schemas suitable for JSON or YAML export.
+func rawInfoForSchemas(m *Schemas, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForSchema(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForScope returns a description of Scope suitable for JSON or YAML export.
+func rawInfoForScope(m *Scope, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	return info
+}
+
+// rawInfoForScopes returns a description of Scopes suitable for JSON or YAML export.
+func rawInfoForScopes(m *Scopes, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForScope(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSimple returns a description of Simple suitable for JSON or YAML export.
+func rawInfoForSimple(m *Simple, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.Multipart != false {
+		info.Content = append(info.Content, b.Key("multipart"))
+		info.Content = append(info.Content, b.Bool(m.Multipart))
+	}
+	if m.Path != "" {
+		info.Content = append(info.Content, b.Key("path"))
+		info.Content = append(info.Content, b.String(m.Path))
+	}
+	return info
+}
+
+// rawInfoForStringArray returns a description of StringArray suitable for JSON or YAML export.
+func rawInfoForStringArray(m *StringArray, b *compiler.NodeBuilder) *yaml.Node {
+	return b.StringArray(m.Value)
+}
+
+var (
+	allowedKeysForAnnotations    = compiler.NewKeySet([]string{"required"}, nil)
+	allowedKeysForAuth           = compiler.NewKeySet([]string{"oauth2"}, nil)
+	allowedKeysForDocument       = compiler.NewKeySet([]string{"auth", "basePath", "baseUrl", "batchPath", "canonicalName", "description", "discoveryVersion", "documentationLink", "etag", "features", "fullyEncodeReservedExpansion", "icons", "id", "kind", "labels", "methods", "mtlsRootUrl", "name", "ownerDomain", "ownerName", "packagePath", "parameters", "protocol", "resources", "revision", "rootUrl", "schemas", "servicePath", "title", "version", "version_module"}, nil)
+	allowedKeysForIcons          = compiler.NewKeySet([]string{"x16", "x32"}, nil)
+	allowedKeysForMediaUpload    = compiler.NewKeySet([]string{"accept", "maxSize", "protocols", "supportsSubscription"}, nil)
+	allowedKeysForMethod         = compiler.NewKeySet([]string{"description", "etagRequired", "flatPath", "httpMethod", "id", "mediaUpload", "parameterOrder", "parameters", "path", "request", "response", "scopes", "streamingType", "supportsMediaDownload", "supportsMediaUpload", "supportsSubscription", "useMediaDownloadService"}, nil)
+	allowedKeysForNamedMethod    = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedParameter = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedResource  = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedSchema    = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedScope     = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForOauth2         = compiler.NewKeySet([]string{"scopes"}, nil)
+	allowedKeysForParameter      = compiler.NewKeySet([]string{"$ref", "additionalProperties", "annotations", "default", "description", "enum", "enumDescriptions", "format", "id", "items", "location", "maximum", "minimum", "pattern", "properties", "repeated", "required", "type"}, nil)
+	allowedKeysForProtocols      = compiler.NewKeySet([]string{"resumable", "simple"}, nil)
+	allowedKeysForRequest        = compiler.NewKeySet([]string{"$ref", "parameterName"}, nil)
+	allowedKeysForResource       = compiler.NewKeySet([]string{"methods", "resources"}, nil)
+	allowedKeysForResponse       = compiler.NewKeySet([]string{"$ref"}, nil)
+	allowedKeysForResumable      = compiler.NewKeySet([]string{"multipart", "path"}, nil)
+	allowedKeysForSchema         = compiler.NewKeySet([]string{"$ref", "additionalProperties", "annotations", "default", "description", "enum", "enumDescriptions", "format", "id", "items", "location", "maximum", "minimum", "pattern", "properties", "readOnly", "repeated", "required", "type"}, nil)
+	allowedKeysForScope          = compiler.NewKeySet([]string{"description"}, nil)
+	allowedKeysForSimple         = compiler.NewKeySet([]string{"multipart", "path"}, nil)
+)
@@ -16,7 +16,14 @@ package discovery_v1
 
 import (
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
 )
 
 func TestParseDocument(t *testing.T) {
@@ -71,3 +78,172 @@ func TestParseDocument_Empty(t *testing.T) {
 		})
 	}
 }
+
+// TestRawInfo checks that descriptions made by RawInfo compile back into
+// the documents that they describe.
+func TestRawInfo(t *testing.T) {
+	filenames, err := filepath.Glob("../examples/discovery/*.json")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, filename := range filenames {
+		b, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		d, err := ParseDocument(b)
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		b, err = yaml.Marshal(RawInfo(d))
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		d2, err := ParseDocument(b)
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		if !proto.Equal(d, d2) {
+			t.Errorf("%s: description differs from the document:\n%s", filename, b)
+		}
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	b, err := ioutil.ReadFile("../examples/discovery/discovery-v1.json")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	d, err := ParseDocument(b)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := ValidateDocument(d); err != nil {
+		t.Errorf("unexpected errors: %s", err)
+	}
+
+	d, err = ParseDocument([]byte(`{
+  "kind": "discovery#restDescription",
+  "discoveryVersion": "v1",
+  "name": "pets",
+  "parameters": {"alt": {"type": "string", "location": "query"}},
+  "auth": {"oauth2": {"scopes": {"https://example.com/auth/pets": {"description": "Manage pets"}}}},
+  "schemas": {
+    "Pet": {"id": "Pet", "type": "object", "properties": {"owner": {"$ref": "Owner"}}},
+    "Pets": {"id": "Pets", "type": "object", "properties": {"items": {"type": "array", "items": {"$ref": "Pet"}}}}
+  },
+  "resources": {
+    "pets": {
+      "methods": {
+        "get": {
+          "path": "pets/{id}",
+          "httpMethod": "GET",
+          "parameters": {
+            "id": {"type": "string", "required": true, "location": "body"},
+            "view": {"type": "string"}
+          },
+          "parameterOrder": ["id", "name"],
+          "request": {"$ref": "PetRequest"},
+          "response": {"$ref": "Pet"},
+          "scopes": ["https://example.com/auth/pets", "https://example.com/auth/owners"]
+        }
+      }
+    }
+  }
+}`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := []string{
+		"$root.schemas.Pet.properties.owner.$ref refers to unknown schema Owner",
+		"$root.resources.pets.methods.get.parameters.id.location has unknown value body (expected path or query)",
+		"$root.resources.pets.methods.get.parameters.view has no location",
+		"$root.resources.pets.methods.get.parameterOrder refers to unknown parameter name",
+		"$root.resources.pets.methods.get.request.$ref refers to unknown schema PetRequest",
+		"$root.resources.pets.methods.get.scopes refers to undeclared scope https://example.com/auth/owners",
+	}
+	err = ValidateDocument(d)
+	if err == nil {
+		t.Fatalf("expected errors")
+	}
+	if err.Error() != strings.Join(expected, "\n") {
+		t.Errorf("unexpected errors:\n%s", err)
+	}
+}
+
+func TestCompileCorpus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "corpus")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	documents := map[string]string{
+		"pets.json": `{"kind": "discovery#restDescription", "discoveryVersion": "v1", "name": "pets", "version": "v1",
+  "resources": {"pets": {"methods": {
+    "get": {"id": "pets.pets.get", "path": "pets/{id}", "httpMethod": "GET",
+      "parameters": {"id": {"type": "string", "location": "path"}}, "response": {"$ref": "Pet"}},
+    "list": {"id": "pets.pets.list", "path": "pets", "httpMethod": "GET"}}}}}`,
+		"owners/owners.json": `{"kind": "discovery#restDescription", "discoveryVersion": "v1", "name": "owners", "version": "v1"}`,
+		"bad.json":           `[]`,
+		"notes.txt":          `not a discovery document`,
+	}
+	for name, document := range documents {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(document), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	files, err := CorpusFiles(dir)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	results := CompileCorpus(files, 2)
+	if len(results) != 3 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	for _, result := range results {
+		switch filepath.Base(result.File) {
+		case "bad.json":
+			if result.API != nil || result.Err == nil {
+				t.Errorf("expected %s to fail", result.File)
+			}
+		case "pets.json":
+			if result.API == nil || result.Err == nil || !strings.Contains(result.Err.Error(), "unknown schema Pet") {
+				t.Errorf("expected %s to be compiled with problems: %v", result.File, result.Err)
+			}
+		default:
+			if result.API == nil || result.Err != nil {
+				t.Errorf("expected %s to be compiled: %v", result.File, result.Err)
+			}
+		}
+	}
+	before := NewSnapshotFromResults(results)
+	if len(before.APIs) != 2 || len(before.APIs["pets:v1"].Methods) != 2 {
+		t.Fatalf("unexpected snapshot: %+v", before.APIs)
+	}
+
+	after := NewSnapshot()
+	after.Add(&APISnapshot{Name: "pets", Version: "v1", Methods: map[string]*MethodSnapshot{
+		"pets.pets.get":    {HTTPMethod: "GET", Path: "pets/{id}", Parameters: []string{"id", "view"}, Response: "Pet"},
+		"pets.pets.delete": {HTTPMethod: "DELETE", Path: "pets/{id}"},
+	}})
+	after.Add(&APISnapshot{Name: "stores", Version: "v1"})
+	expected := &SnapshotDiff{
+		AddedAPIs:   []string{"stores:v1"},
+		RemovedAPIs: []string{"owners:v1"},
+		ChangedAPIs: []*APIDiff{{
+			API:            "pets:v1",
+			AddedMethods:   []string{"pets.pets.delete"},
+			RemovedMethods: []string{"pets.pets.list"},
+			ChangedMethods: []string{"pets.pets.get"},
+		}},
+	}
+	if diff := DiffSnapshots(before, after); !reflect.DeepEqual(diff, expected) {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+	if diff := DiffSnapshots(before, before); !diff.IsEmpty() {
+		t.Errorf("expected no differences: %+v", diff)
+	}
+}
@@ -17,6 +17,8 @@ package discovery_v1
 import (
 	"errors"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/okkoye/gnostic/compiler"
 )
 
@@ -27,15 +29,35 @@ func FetchDocumentBytes(documentURL string) ([]byte, error) {
 
 // ParseDocument reads a Discovery description from a YAML/JSON representation.
 func ParseDocument(b []byte) (*Document, error) {
+	document, _, err := ParseDocumentWithAnnotations(b)
+	return document, err
+}
+
+// ParseDocumentWithAnnotations reads a description like ParseDocument and
+// also returns the annotations that are needed to write it as it was read.
+func ParseDocumentWithAnnotations(b []byte) (*Document, *compiler.Annotations, error) {
 	info, err := compiler.ReadInfoFromBytes("", b)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(info.Content) < 1 {
-		return nil, errors.New("document has no content")
+		return nil, nil, errors.New("document has no content")
 	}
 
 	root := info.Content[0]
-	return NewDocument(root, compiler.NewContext("$root", root, nil))
+	document, err := NewDocument(root, compiler.NewContext("$root", root, nil))
+	if document == nil {
+		return nil, nil, err
+	}
+	annotations := compiler.NewAnnotations(root)
+	return document, annotations, err
+}
+
+// RawInfo returns a description of a document suitable for JSON or YAML
+// export. It has the same nodes as the description that ToRawInfo returns,
+// but they are allocated in blocks, and mapping keys share one node for
+// each key, so the nodes of keys must not be modified.
+func RawInfo(document *Document) *yaml.Node {
+	return rawInfoForDocument(document, compiler.NewNodeBuilder())
 }
@@ -0,0 +1,49 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package discovery_v1
+
+import (
+	"testing"
+
+	"github.com/okkoye/gnostic/internal/fuzzing"
+)
+
+// FuzzParseDocument checks that ParseDocument and ValidateDocument return
+// errors for malformed documents instead of panicking. Run it with
+//
+//	go test ./discovery -run=NONE -fuzz=FuzzParseDocument
+func FuzzParseDocument(f *testing.F) {
+	fuzzing.AddFiles(f,
+		"../examples/discovery/*.json",
+	)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		document, err := ParseDocument(b)
+		if err == nil && document != nil {
+			ValidateDocument(document)
+		}
+	})
+}
+
+// FuzzParseList checks that ParseList returns an error for malformed
+// API lists instead of panicking.
+func FuzzParseList(f *testing.F) {
+	f.Add([]byte(`{"kind": "discovery#directoryList", "items": [{"name": "storage", "version": "v1", "discoveryRestUrl": "https://storage.googleapis.com/$discovery/rest?version=v1"}]}`))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		ParseList(b)
+	})
+}
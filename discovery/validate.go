@@ -0,0 +1,152 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery_v1
+
+import (
+	"fmt"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// ValidateDocument reports the problems in a discovery document that
+// aren't found when it is compiled: parameters with unknown locations,
+// parameterOrder entries that don't name parameters, references to
+// schemas that don't exist, and methods that use undeclared scopes.
+func ValidateDocument(document *Document) error {
+	v := &validator{
+		schemas: make(map[string]bool),
+		scopes:  make(map[string]bool),
+		errors:  make([]error, 0),
+	}
+	for _, namedSchema := range document.GetSchemas().GetAdditionalProperties() {
+		v.schemas[namedSchema.Name] = true
+		if id := namedSchema.GetValue().GetId(); id != "" {
+			v.schemas[id] = true
+		}
+	}
+	for _, namedScope := range document.GetAuth().GetOauth2().GetScopes().GetAdditionalProperties() {
+		v.scopes[namedScope.Name] = true
+	}
+	root := compiler.NewContext("$root", nil, nil)
+	v.validateParameters(document.Parameters, false, compiler.NewContext("parameters", nil, root))
+	v.validateSchemas(document.Schemas, compiler.NewContext("schemas", nil, root))
+	v.validateMethods(document.Methods, compiler.NewContext("methods", nil, root))
+	v.validateResources(document.Resources, compiler.NewContext("resources", nil, root))
+	return compiler.NewErrorGroupOrNil(v.errors)
+}
+
+// A validator collects the problems found in a document.
+type validator struct {
+	schemas map[string]bool // the names and ids of the document's schemas
+	scopes  map[string]bool // the names of the document's OAuth 2.0 scopes
+	errors  []error
+}
+
+func (v *validator) addError(context *compiler.Context, format string, args ...interface{}) {
+	v.errors = append(v.errors, compiler.NewError(context, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) validateResources(resources *Resources, context *compiler.Context) {
+	for _, namedResource := range resources.GetAdditionalProperties() {
+		resourceContext := compiler.NewContext(namedResource.Name, nil, context)
+		v.validateMethods(namedResource.GetValue().GetMethods(), compiler.NewContext("methods", nil, resourceContext))
+		v.validateResources(namedResource.GetValue().GetResources(), compiler.NewContext("resources", nil, resourceContext))
+	}
+}
+
+func (v *validator) validateMethods(methods *Methods, context *compiler.Context) {
+	for _, namedMethod := range methods.GetAdditionalProperties() {
+		v.validateMethod(namedMethod.GetValue(), compiler.NewContext(namedMethod.Name, nil, context))
+	}
+}
+
+func (v *validator) validateMethod(method *Method, context *compiler.Context) {
+	if method == nil {
+		return
+	}
+	v.validateParameters(method.Parameters, true, compiler.NewContext("parameters", nil, context))
+	parameters := make(map[string]bool)
+	for _, namedParameter := range method.GetParameters().GetAdditionalProperties() {
+		parameters[namedParameter.Name] = true
+	}
+	for _, name := range method.ParameterOrder {
+		if !parameters[name] {
+			v.addError(compiler.NewContext("parameterOrder", nil, context), "refers to unknown parameter %s", name)
+		}
+	}
+	if ref := method.GetRequest().GetXRef(); ref != "" {
+		v.validateReference(ref, compiler.NewContext("request", nil, context))
+	}
+	if ref := method.GetResponse().GetXRef(); ref != "" {
+		v.validateReference(ref, compiler.NewContext("response", nil, context))
+	}
+	for _, scope := range method.Scopes {
+		if !v.scopes[scope] {
+			v.addError(compiler.NewContext("scopes", nil, context), "refers to undeclared scope %s", scope)
+		}
+	}
+}
+
+// Validates the parameters of a document or method. Method parameters
+// must have locations.
+func (v *validator) validateParameters(parameters *Parameters, requireLocation bool, context *compiler.Context) {
+	for _, namedParameter := range parameters.GetAdditionalProperties() {
+		parameter := namedParameter.GetValue()
+		if parameter == nil {
+			continue
+		}
+		parameterContext := compiler.NewContext(namedParameter.Name, nil, context)
+		switch parameter.Location {
+		case "path", "query":
+		case "":
+			if requireLocation && parameter.XRef == "" {
+				v.addError(parameterContext, "has no location")
+			}
+		default:
+			v.addError(compiler.NewContext("location", nil, parameterContext), "has unknown value %s (expected path or query)", parameter.Location)
+		}
+		if parameter.XRef != "" {
+			v.validateReference(parameter.XRef, parameterContext)
+		}
+		v.validateSchemas(parameter.Properties, compiler.NewContext("properties", nil, parameterContext))
+		v.validateSchema(parameter.AdditionalProperties, compiler.NewContext("additionalProperties", nil, parameterContext))
+		v.validateSchema(parameter.Items, compiler.NewContext("items", nil, parameterContext))
+	}
+}
+
+func (v *validator) validateSchemas(schemas *Schemas, context *compiler.Context) {
+	for _, namedSchema := range schemas.GetAdditionalProperties() {
+		v.validateSchema(namedSchema.GetValue(), compiler.NewContext(namedSchema.Name, nil, context))
+	}
+}
+
+func (v *validator) validateSchema(schema *Schema, context *compiler.Context) {
+	if schema == nil {
+		return
+	}
+	if schema.XRef != "" {
+		v.validateReference(schema.XRef, context)
+	}
+	v.validateSchemas(schema.Properties, compiler.NewContext("properties", nil, context))
+	v.validateSchema(schema.AdditionalProperties, compiler.NewContext("additionalProperties", nil, context))
+	v.validateSchema(schema.Items, compiler.NewContext("items", nil, context))
+}
+
+// References in discovery documents are the names or ids of schemas.
+func (v *validator) validateReference(ref string, context *compiler.Context) {
+	if !v.schemas[ref] {
+		v.addError(compiler.NewContext("$ref", nil, context), "refers to unknown schema %s", ref)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-x-amazon-apigateway is an extension handler for the
+// x-amazon-apigateway-* extensions. gnostic compiles these extensions without
+// it, but it can be used by other programs that call extension handlers.
+package main
+
+import (
+	gnostic_extension_v1 "github.com/okkoye/gnostic/extensions"
+	"github.com/okkoye/gnostic/extensions/wellknown/amazon"
+)
+
+func main() {
+	gnostic_extension_v1.Main(amazon.HandleExtension)
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package amazon
+
+import (
+	"github.com/okkoye/gnostic/compiler"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gopkg.in/yaml.v3"
+)
+
+// HandleExtension compiles the extensions described in x-amazon-apigateway.json.
+// It returns false for extensions that it does not handle.
+func HandleExtension(extensionName string, yamlInput string) (bool, proto.Message, error) {
+	switch extensionName {
+	// All supported extensions
+
+	case "x-amazon-apigateway-api-key-source":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-amazon-apigateway-authorizer":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewAuthorizer(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-amazon-apigateway-authtype":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-amazon-apigateway-cors":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewCors(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-amazon-apigateway-endpoint-configuration":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewEndpointConfiguration(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-amazon-apigateway-gateway-responses":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewGatewayResponses(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-amazon-apigateway-importexport-version":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-amazon-apigateway-integration":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewIntegration(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-amazon-apigateway-minimum-compression-size":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.IntForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.Int64Value{Value: v}
+		return true, newObject, nil
+	case "x-amazon-apigateway-request-validators":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewRequestValidators(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-amazon-apigateway-tag-value":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	default:
+		return false, nil, nil
+	}
+}
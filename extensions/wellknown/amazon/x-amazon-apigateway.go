@@ -0,0 +1,1827 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package amazon
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// Version returns the package name (and OpenAPI version).
+func Version() string {
+	return "amazon"
+}
+
+// NewAuthorizer creates an object of type Authorizer if possible, returning an error if not.
+func NewAuthorizer(in *yaml.Node, context *compiler.Context) (*Authorizer, error) {
+	errors := make([]error, 0)
+	x := &Authorizer{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForAuthorizer)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string type = 1;
+		v1 := compiler.MapValueForKey(m, "type")
+		if v1 != nil {
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string authorizer_uri = 2;
+		v2 := compiler.MapValueForKey(m, "authorizerUri")
+		if v2 != nil {
+			x.AuthorizerUri, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for authorizerUri: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string authorizer_credentials = 3;
+		v3 := compiler.MapValueForKey(m, "authorizerCredentials")
+		if v3 != nil {
+			x.AuthorizerCredentials, ok = compiler.StringForScalarNodeWithContext(v3, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for authorizerCredentials: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string authorizer_payload_format_version = 4;
+		v4 := compiler.MapValueForKey(m, "authorizerPayloadFormatVersion")
+		if v4 != nil {
+			x.AuthorizerPayloadFormatVersion, ok = compiler.StringForScalarNodeWithContext(v4, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for authorizerPayloadFormatVersion: %s", compiler.Display(v4))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// bool enable_simple_responses = 5;
+		v5 := compiler.MapValueForKey(m, "enableSimpleResponses")
+		if v5 != nil {
+			x.EnableSimpleResponses, ok = compiler.BoolForScalarNode(v5)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for enableSimpleResponses: %s", compiler.Display(v5))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string identity_source = 6;
+		v6 := compiler.MapValueForKey(m, "identitySource")
+		if v6 != nil {
+			x.IdentitySource, ok = compiler.StringForScalarNodeWithContext(v6, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for identitySource: %s", compiler.Display(v6))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string identity_validation_expression = 7;
+		v7 := compiler.MapValueForKey(m, "identityValidationExpression")
+		if v7 != nil {
+			x.IdentityValidationExpression, ok = compiler.StringForScalarNodeWithContext(v7, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for identityValidationExpression: %s", compiler.Display(v7))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// int64 authorizer_result_ttl_in_seconds = 8;
+		v8 := compiler.MapValueForKey(m, "authorizerResultTtlInSeconds")
+		if v8 != nil {
+			t, ok := compiler.IntForScalarNode(v8)
+			if ok {
+				x.AuthorizerResultTtlInSeconds = int64(t)
+			} else {
+				message := fmt.Sprintf("has unexpected value for authorizerResultTtlInSeconds: %s", compiler.Display(v8))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// repeated string provider_a_r_ns = 9;
+		v9 := compiler.MapValueForKey(m, "providerARNs")
+		if v9 != nil {
+			v, ok := compiler.SequenceNodeForNode(v9)
+			if ok {
+				x.ProviderARNs = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for providerARNs: %s", compiler.Display(v9))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// JwtConfiguration jwt_configuration = 10;
+		v10 := compiler.MapValueForKey(m, "jwtConfiguration")
+		if v10 != nil {
+			var err error
+			x.JwtConfiguration, err = NewJwtConfiguration(v10, compiler.NewContext("jwtConfiguration", v10, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewCors creates an object of type Cors if possible, returning an error if not.
+func NewCors(in *yaml.Node, context *compiler.Context) (*Cors, error) {
+	errors := make([]error, 0)
+	x := &Cors{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForCors)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// repeated string allow_origins = 1;
+		v1 := compiler.MapValueForKey(m, "allowOrigins")
+		if v1 != nil {
+			v, ok := compiler.SequenceNodeForNode(v1)
+			if ok {
+				x.AllowOrigins = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for allowOrigins: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// bool allow_credentials = 2;
+		v2 := compiler.MapValueForKey(m, "allowCredentials")
+		if v2 != nil {
+			x.AllowCredentials, ok = compiler.BoolForScalarNode(v2)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for allowCredentials: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// repeated string expose_headers = 3;
+		v3 := compiler.MapValueForKey(m, "exposeHeaders")
+		if v3 != nil {
+			v, ok := compiler.SequenceNodeForNode(v3)
+			if ok {
+				x.ExposeHeaders = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for exposeHeaders: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// int64 max_age = 4;
+		v4 := compiler.MapValueForKey(m, "maxAge")
+		if v4 != nil {
+			t, ok := compiler.IntForScalarNode(v4)
+			if ok {
+				x.MaxAge = int64(t)
+			} else {
+				message := fmt.Sprintf("has unexpected value for maxAge: %s", compiler.Display(v4))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// repeated string allow_methods = 5;
+		v5 := compiler.MapValueForKey(m, "allowMethods")
+		if v5 != nil {
+			v, ok := compiler.SequenceNodeForNode(v5)
+			if ok {
+				x.AllowMethods = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for allowMethods: %s", compiler.Display(v5))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// repeated string allow_headers = 6;
+		v6 := compiler.MapValueForKey(m, "allowHeaders")
+		if v6 != nil {
+			v, ok := compiler.SequenceNodeForNode(v6)
+			if ok {
+				x.AllowHeaders = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for allowHeaders: %s", compiler.Display(v6))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewEndpointConfiguration creates an object of type EndpointConfiguration if possible, returning an error if not.
+func NewEndpointConfiguration(in *yaml.Node, context *compiler.Context) (*EndpointConfiguration, error) {
+	errors := make([]error, 0)
+	x := &EndpointConfiguration{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForEndpointConfiguration)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// repeated string types = 1;
+		v1 := compiler.MapValueForKey(m, "types")
+		if v1 != nil {
+			v, ok := compiler.SequenceNodeForNode(v1)
+			if ok {
+				x.Types = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for types: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+			// check for valid enum values
+			// [EDGE REGIONAL PRIVATE]
+			if ok && !compiler.StringArrayContainsValues([]string{"EDGE", "REGIONAL", "PRIVATE"}, x.Types) {
+				message := fmt.Sprintf("has unexpected value for types: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// repeated string vpc_endpoint_ids = 2;
+		v2 := compiler.MapValueForKey(m, "vpcEndpointIds")
+		if v2 != nil {
+			v, ok := compiler.SequenceNodeForNode(v2)
+			if ok {
+				x.VpcEndpointIds = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for vpcEndpointIds: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// bool disable_execute_api_endpoint = 3;
+		v3 := compiler.MapValueForKey(m, "disableExecuteApiEndpoint")
+		if v3 != nil {
+			x.DisableExecuteApiEndpoint, ok = compiler.BoolForScalarNode(v3)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for disableExecuteApiEndpoint: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewGatewayResponse creates an object of type GatewayResponse if possible, returning an error if not.
+func NewGatewayResponse(in *yaml.Node, context *compiler.Context) (*GatewayResponse, error) {
+	errors := make([]error, 0)
+	x := &GatewayResponse{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForGatewayResponse)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string status_code = 1;
+		v1 := compiler.MapValueForKey(m, "statusCode")
+		if v1 != nil {
+			x.StatusCode, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for statusCode: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// ResponseParameters response_parameters = 2;
+		v2 := compiler.MapValueForKey(m, "responseParameters")
+		if v2 != nil {
+			var err error
+			x.ResponseParameters, err = NewResponseParameters(v2, compiler.NewContext("responseParameters", v2, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+		// ResponseTemplates response_templates = 3;
+		v3 := compiler.MapValueForKey(m, "responseTemplates")
+		if v3 != nil {
+			var err error
+			x.ResponseTemplates, err = NewResponseTemplates(v3, compiler.NewContext("responseTemplates", v3, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewGatewayResponses creates an object of type GatewayResponses if possible, returning an error if not.
+func NewGatewayResponses(in *yaml.Node, context *compiler.Context) (*GatewayResponses, error) {
+	errors := make([]error, 0)
+	x := &GatewayResponses{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		// repeated NamedGatewayResponse additional_properties = 1;
+		// MAP: GatewayResponse
+		x.AdditionalProperties = make([]*NamedGatewayResponse, 0)
+		for i := 0; i < len(m.Content); i += 2 {
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
+			if ok {
+				v := m.Content[i+1]
+				pair := &NamedGatewayResponse{}
+				pair.Name = k
+				var err error
+				pair.Value, err = NewGatewayResponse(v, compiler.NewContext(k, v, context))
+				if err != nil {
+					errors = append(errors, err)
+				}
+				x.AdditionalProperties = append(x.AdditionalProperties, pair)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewIntegration creates an object of type Integration if possible, returning an error if not.
+func NewIntegration(in *yaml.Node, context *compiler.Context) (*Integration, error) {
+	errors := make([]error, 0)
+	x := &Integration{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForIntegration)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string type = 1;
+		v1 := compiler.MapValueForKey(m, "type")
+		if v1 != nil {
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string uri = 2;
+		v2 := compiler.MapValueForKey(m, "uri")
+		if v2 != nil {
+			x.Uri, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for uri: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string http_method = 3;
+		v3 := compiler.MapValueForKey(m, "httpMethod")
+		if v3 != nil {
+			x.HttpMethod, ok = compiler.StringForScalarNodeWithContext(v3, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for httpMethod: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string credentials = 4;
+		v4 := compiler.MapValueForKey(m, "credentials")
+		if v4 != nil {
+			x.Credentials, ok = compiler.StringForScalarNodeWithContext(v4, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for credentials: %s", compiler.Display(v4))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string connection_type = 5;
+		v5 := compiler.MapValueForKey(m, "connectionType")
+		if v5 != nil {
+			x.ConnectionType, ok = compiler.StringForScalarNodeWithContext(v5, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for connectionType: %s", compiler.Display(v5))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string connection_id = 6;
+		v6 := compiler.MapValueForKey(m, "connectionId")
+		if v6 != nil {
+			x.ConnectionId, ok = compiler.StringForScalarNodeWithContext(v6, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for connectionId: %s", compiler.Display(v6))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string passthrough_behavior = 7;
+		v7 := compiler.MapValueForKey(m, "passthroughBehavior")
+		if v7 != nil {
+			x.PassthroughBehavior, ok = compiler.StringForScalarNodeWithContext(v7, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for passthroughBehavior: %s", compiler.Display(v7))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string content_handling = 8;
+		v8 := compiler.MapValueForKey(m, "contentHandling")
+		if v8 != nil {
+			x.ContentHandling, ok = compiler.StringForScalarNodeWithContext(v8, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for contentHandling: %s", compiler.Display(v8))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// int64 timeout_in_millis = 9;
+		v9 := compiler.MapValueForKey(m, "timeoutInMillis")
+		if v9 != nil {
+			t, ok := compiler.IntForScalarNode(v9)
+			if ok {
+				x.TimeoutInMillis = int64(t)
+			} else {
+				message := fmt.Sprintf("has unexpected value for timeoutInMillis: %s", compiler.Display(v9))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string cache_namespace = 10;
+		v10 := compiler.MapValueForKey(m, "cacheNamespace")
+		if v10 != nil {
+			x.CacheNamespace, ok = compiler.StringForScalarNodeWithContext(v10, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for cacheNamespace: %s", compiler.Display(v10))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// repeated string cache_key_parameters = 11;
+		v11 := compiler.MapValueForKey(m, "cacheKeyParameters")
+		if v11 != nil {
+			v, ok := compiler.SequenceNodeForNode(v11)
+			if ok {
+				x.CacheKeyParameters = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for cacheKeyParameters: %s", compiler.Display(v11))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// RequestParameters request_parameters = 12;
+		v12 := compiler.MapValueForKey(m, "requestParameters")
+		if v12 != nil {
+			var err error
+			x.RequestParameters, err = NewRequestParameters(v12, compiler.NewContext("requestParameters", v12, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+		// RequestTemplates request_templates = 13;
+		v13 := compiler.MapValueForKey(m, "requestTemplates")
+		if v13 != nil {
+			var err error
+			x.RequestTemplates, err = NewRequestTemplates(v13, compiler.NewContext("requestTemplates", v13, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+		// Responses responses = 14;
+		v14 := compiler.MapValueForKey(m, "responses")
+		if v14 != nil {
+			var err error
+			x.Responses, err = NewResponses(v14, compiler.NewContext("responses", v14, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+		// TlsConfig tls_config = 15;
+		v15 := compiler.MapValueForKey(m, "tlsConfig")
+		if v15 != nil {
+			var err error
+			x.TlsConfig, err = NewTlsConfig(v15, compiler.NewContext("tlsConfig", v15, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+		// string integration_subtype = 16;
+		v16 := compiler.MapValueForKey(m, "integrationSubtype")
+		if v16 != nil {
+			x.IntegrationSubtype, ok = compiler.StringForScalarNodeWithContext(v16, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for integrationSubtype: %s", compiler.Display(v16))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string payload_format_version = 17;
+		v17 := compiler.MapValueForKey(m, "payloadFormatVersion")
+		if v17 != nil {
+			x.PayloadFormatVersion, ok = compiler.StringForScalarNodeWithContext(v17, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for payloadFormatVersion: %s", compiler.Display(v17))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewIntegrationResponse creates an object of type IntegrationResponse if possible, returning an error if not.
+func NewIntegrationResponse(in *yaml.Node, context *compiler.Context) (*IntegrationResponse, error) {
+	errors := make([]error, 0)
+	x := &IntegrationResponse{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForIntegrationResponse)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string status_code = 1;
+		v1 := compiler.MapValueForKey(m, "statusCode")
+		if v1 != nil {
+			x.StatusCode, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for statusCode: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// ResponseParameters response_parameters = 2;
+		v2 := compiler.MapValueForKey(m, "responseParameters")
+		if v2 != nil {
+			var err error
+			x.ResponseParameters, err = NewResponseParameters(v2, compiler.NewContext("responseParameters", v2, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+		// ResponseTemplates response_templates = 3;
+		v3 := compiler.MapValueForKey(m, "responseTemplates")
+		if v3 != nil {
+			var err error
+			x.ResponseTemplates, err = NewResponseTemplates(v3, compiler.NewContext("responseTemplates", v3, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+		// string content_handling = 4;
+		v4 := compiler.MapValueForKey(m, "contentHandling")
+		if v4 != nil {
+			x.ContentHandling, ok = compiler.StringForScalarNodeWithContext(v4, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for contentHandling: %s", compiler.Display(v4))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewJwtConfiguration creates an object of type JwtConfiguration if possible, returning an error if not.
+func NewJwtConfiguration(in *yaml.Node, context *compiler.Context) (*JwtConfiguration, error) {
+	errors := make([]error, 0)
+	x := &JwtConfiguration{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForJwtConfiguration)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string issuer = 1;
+		v1 := compiler.MapValueForKey(m, "issuer")
+		if v1 != nil {
+			x.Issuer, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for issuer: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// repeated string audience = 2;
+		v2 := compiler.MapValueForKey(m, "audience")
+		if v2 != nil {
+			v, ok := compiler.SequenceNodeForNode(v2)
+			if ok {
+				x.Audience = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for audience: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewNamedGatewayResponse creates an object of type NamedGatewayResponse if possible, returning an error if not.
+func NewNamedGatewayResponse(in *yaml.Node, context *compiler.Context) (*NamedGatewayResponse, error) {
+	errors := make([]error, 0)
+	x := &NamedGatewayResponse{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedGatewayResponse)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string name = 1;
+		v1 := compiler.MapValueForKey(m, "name")
+		if v1 != nil {
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// GatewayResponse value = 2;
+		v2 := compiler.MapValueForKey(m, "value")
+		if v2 != nil {
+			var err error
+			x.Value, err = NewGatewayResponse(v2, compiler.NewContext("value", v2, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewNamedIntegrationResponse creates an object of type NamedIntegrationResponse if possible, returning an error if not.
+func NewNamedIntegrationResponse(in *yaml.Node, context *compiler.Context) (*NamedIntegrationResponse, error) {
+	errors := make([]error, 0)
+	x := &NamedIntegrationResponse{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedIntegrationResponse)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string name = 1;
+		v1 := compiler.MapValueForKey(m, "name")
+		if v1 != nil {
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// IntegrationResponse value = 2;
+		v2 := compiler.MapValueForKey(m, "value")
+		if v2 != nil {
+			var err error
+			x.Value, err = NewIntegrationResponse(v2, compiler.NewContext("value", v2, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewNamedRequestValidator creates an object of type NamedRequestValidator if possible, returning an error if not.
+func NewNamedRequestValidator(in *yaml.Node, context *compiler.Context) (*NamedRequestValidator, error) {
+	errors := make([]error, 0)
+	x := &NamedRequestValidator{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedRequestValidator)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string name = 1;
+		v1 := compiler.MapValueForKey(m, "name")
+		if v1 != nil {
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// RequestValidator value = 2;
+		v2 := compiler.MapValueForKey(m, "value")
+		if v2 != nil {
+			var err error
+			x.Value, err = NewRequestValidator(v2, compiler.NewContext("value", v2, context))
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewNamedString creates an object of type NamedString if possible, returning an error if not.
+func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, error) {
+	errors := make([]error, 0)
+	x := &NamedString{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedString)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string name = 1;
+		v1 := compiler.MapValueForKey(m, "name")
+		if v1 != nil {
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string value = 2;
+		v2 := compiler.MapValueForKey(m, "value")
+		if v2 != nil {
+			x.Value, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for value: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewRequestParameters creates an object of type RequestParameters if possible, returning an error if not.
+func NewRequestParameters(in *yaml.Node, context *compiler.Context) (*RequestParameters, error) {
+	errors := make([]error, 0)
+	x := &RequestParameters{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		// repeated NamedString additional_properties = 1;
+		// MAP: string
+		x.AdditionalProperties = make([]*NamedString, 0)
+		for i := 0; i < len(m.Content); i += 2 {
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
+			if ok {
+				v := m.Content[i+1]
+				pair := &NamedString{}
+				pair.Name = k
+				pair.Value, _ = compiler.StringForScalarNodeWithContext(v, context)
+				x.AdditionalProperties = append(x.AdditionalProperties, pair)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewRequestTemplates creates an object of type RequestTemplates if possible, returning an error if not.
+func NewRequestTemplates(in *yaml.Node, context *compiler.Context) (*RequestTemplates, error) {
+	errors := make([]error, 0)
+	x := &RequestTemplates{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		// repeated NamedString additional_properties = 1;
+		// MAP: string
+		x.AdditionalProperties = make([]*NamedString, 0)
+		for i := 0; i < len(m.Content); i += 2 {
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
+			if ok {
+				v := m.Content[i+1]
+				pair := &NamedString{}
+				pair.Name = k
+				pair.Value, _ = compiler.StringForScalarNodeWithContext(v, context)
+				x.AdditionalProperties = append(x.AdditionalProperties, pair)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewRequestValidator creates an object of type RequestValidator if possible, returning an error if not.
+func NewRequestValidator(in *yaml.Node, context *compiler.Context) (*RequestValidator, error) {
+	errors := make([]error, 0)
+	x := &RequestValidator{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForRequestValidator)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// bool validate_request_body = 1;
+		v1 := compiler.MapValueForKey(m, "validateRequestBody")
+		if v1 != nil {
+			x.ValidateRequestBody, ok = compiler.BoolForScalarNode(v1)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for validateRequestBody: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// bool validate_request_parameters = 2;
+		v2 := compiler.MapValueForKey(m, "validateRequestParameters")
+		if v2 != nil {
+			x.ValidateRequestParameters, ok = compiler.BoolForScalarNode(v2)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for validateRequestParameters: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewRequestValidators creates an object of type RequestValidators if possible, returning an error if not.
+func NewRequestValidators(in *yaml.Node, context *compiler.Context) (*RequestValidators, error) {
+	errors := make([]error, 0)
+	x := &RequestValidators{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		// repeated NamedRequestValidator additional_properties = 1;
+		// MAP: RequestValidator
+		x.AdditionalProperties = make([]*NamedRequestValidator, 0)
+		for i := 0; i < len(m.Content); i += 2 {
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
+			if ok {
+				v := m.Content[i+1]
+				pair := &NamedRequestValidator{}
+				pair.Name = k
+				var err error
+				pair.Value, err = NewRequestValidator(v, compiler.NewContext(k, v, context))
+				if err != nil {
+					errors = append(errors, err)
+				}
+				x.AdditionalProperties = append(x.AdditionalProperties, pair)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewResponseParameters creates an object of type ResponseParameters if possible, returning an error if not.
+func NewResponseParameters(in *yaml.Node, context *compiler.Context) (*ResponseParameters, error) {
+	errors := make([]error, 0)
+	x := &ResponseParameters{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		// repeated NamedString additional_properties = 1;
+		// MAP: string
+		x.AdditionalProperties = make([]*NamedString, 0)
+		for i := 0; i < len(m.Content); i += 2 {
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
+			if ok {
+				v := m.Content[i+1]
+				pair := &NamedString{}
+				pair.Name = k
+				pair.Value, _ = compiler.StringForScalarNodeWithContext(v, context)
+				x.AdditionalProperties = append(x.AdditionalProperties, pair)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewResponseTemplates creates an object of type ResponseTemplates if possible, returning an error if not.
+func NewResponseTemplates(in *yaml.Node, context *compiler.Context) (*ResponseTemplates, error) {
+	errors := make([]error, 0)
+	x := &ResponseTemplates{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		// repeated NamedString additional_properties = 1;
+		// MAP: string
+		x.AdditionalProperties = make([]*NamedString, 0)
+		for i := 0; i < len(m.Content); i += 2 {
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
+			if ok {
+				v := m.Content[i+1]
+				pair := &NamedString{}
+				pair.Name = k
+				pair.Value, _ = compiler.StringForScalarNodeWithContext(v, context)
+				x.AdditionalProperties = append(x.AdditionalProperties, pair)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewResponses creates an object of type Responses if possible, returning an error if not.
+func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error) {
+	errors := make([]error, 0)
+	x := &Responses{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		// repeated NamedIntegrationResponse additional_properties = 1;
+		// MAP: IntegrationResponse
+		x.AdditionalProperties = make([]*NamedIntegrationResponse, 0)
+		for i := 0; i < len(m.Content); i += 2 {
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
+			if ok {
+				v := m.Content[i+1]
+				pair := &NamedIntegrationResponse{}
+				pair.Name = k
+				var err error
+				pair.Value, err = NewIntegrationResponse(v, compiler.NewContext(k, v, context))
+				if err != nil {
+					errors = append(errors, err)
+				}
+				x.AdditionalProperties = append(x.AdditionalProperties, pair)
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewTlsConfig creates an object of type TlsConfig if possible, returning an error if not.
+func NewTlsConfig(in *yaml.Node, context *compiler.Context) (*TlsConfig, error) {
+	errors := make([]error, 0)
+	x := &TlsConfig{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForTlsConfig)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// bool insecure_skip_verification = 1;
+		v1 := compiler.MapValueForKey(m, "insecureSkipVerification")
+		if v1 != nil {
+			x.InsecureSkipVerification, ok = compiler.BoolForScalarNode(v1)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for insecureSkipVerification: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string server_name_to_verify = 2;
+		v2 := compiler.MapValueForKey(m, "serverNameToVerify")
+		if v2 != nil {
+			x.ServerNameToVerify, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for serverNameToVerify: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Authorizer objects.
+func (m *Authorizer) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Authorizer objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Authorizer) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	if m.JwtConfiguration != nil {
+		_, err := m.JwtConfiguration.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Cors objects.
+func (m *Cors) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Cors objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Cors) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside EndpointConfiguration objects.
+func (m *EndpointConfiguration) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside EndpointConfiguration objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *EndpointConfiguration) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside GatewayResponse objects.
+func (m *GatewayResponse) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside GatewayResponse objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *GatewayResponse) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	if m.ResponseParameters != nil {
+		_, err := m.ResponseParameters.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if m.ResponseTemplates != nil {
+		_, err := m.ResponseTemplates.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside GatewayResponses objects.
+func (m *GatewayResponses) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside GatewayResponses objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *GatewayResponses) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.AdditionalProperties {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Integration objects.
+func (m *Integration) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Integration objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Integration) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	if m.RequestParameters != nil {
+		_, err := m.RequestParameters.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if m.RequestTemplates != nil {
+		_, err := m.RequestTemplates.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if m.Responses != nil {
+		_, err := m.Responses.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if m.TlsConfig != nil {
+		_, err := m.TlsConfig.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside IntegrationResponse objects.
+func (m *IntegrationResponse) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside IntegrationResponse objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *IntegrationResponse) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	if m.ResponseParameters != nil {
+		_, err := m.ResponseParameters.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if m.ResponseTemplates != nil {
+		_, err := m.ResponseTemplates.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside JwtConfiguration objects.
+func (m *JwtConfiguration) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside JwtConfiguration objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *JwtConfiguration) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside NamedGatewayResponse objects.
+func (m *NamedGatewayResponse) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside NamedGatewayResponse objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *NamedGatewayResponse) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	if m.Value != nil {
+		_, err := m.Value.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside NamedIntegrationResponse objects.
+func (m *NamedIntegrationResponse) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside NamedIntegrationResponse objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *NamedIntegrationResponse) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	if m.Value != nil {
+		_, err := m.Value.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside NamedRequestValidator objects.
+func (m *NamedRequestValidator) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside NamedRequestValidator objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *NamedRequestValidator) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	if m.Value != nil {
+		_, err := m.Value.ResolveReferencesWithContext(root, context)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside NamedString objects.
+func (m *NamedString) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside NamedString objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *NamedString) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside RequestParameters objects.
+func (m *RequestParameters) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside RequestParameters objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *RequestParameters) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.AdditionalProperties {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside RequestTemplates objects.
+func (m *RequestTemplates) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside RequestTemplates objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *RequestTemplates) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.AdditionalProperties {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside RequestValidator objects.
+func (m *RequestValidator) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside RequestValidator objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *RequestValidator) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside RequestValidators objects.
+func (m *RequestValidators) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside RequestValidators objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *RequestValidators) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.AdditionalProperties {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside ResponseParameters objects.
+func (m *ResponseParameters) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside ResponseParameters objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *ResponseParameters) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.AdditionalProperties {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside ResponseTemplates objects.
+func (m *ResponseTemplates) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside ResponseTemplates objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *ResponseTemplates) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.AdditionalProperties {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Responses objects.
+func (m *Responses) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Responses objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Responses) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.AdditionalProperties {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside TlsConfig objects.
+func (m *TlsConfig) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside TlsConfig objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *TlsConfig) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ToRawInfo returns a description of Authorizer suitable for JSON or YAML export.
+func (m *Authorizer) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(20)
+	if m.Type != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("type"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Type))
+	}
+	if m.AuthorizerUri != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("authorizerUri"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.AuthorizerUri))
+	}
+	if m.AuthorizerCredentials != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("authorizerCredentials"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.AuthorizerCredentials))
+	}
+	if m.AuthorizerPayloadFormatVersion != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("authorizerPayloadFormatVersion"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.AuthorizerPayloadFormatVersion))
+	}
+	if m.EnableSimpleResponses != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("enableSimpleResponses"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.EnableSimpleResponses))
+	}
+	if m.IdentitySource != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("identitySource"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.IdentitySource))
+	}
+	if m.IdentityValidationExpression != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("identityValidationExpression"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.IdentityValidationExpression))
+	}
+	if m.AuthorizerResultTtlInSeconds != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("authorizerResultTtlInSeconds"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForInt(m.AuthorizerResultTtlInSeconds))
+	}
+	if len(m.ProviderARNs) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("providerARNs"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.ProviderARNs))
+	}
+	if m.JwtConfiguration != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("jwtConfiguration"))
+		info.Content = append(info.Content, m.JwtConfiguration.ToRawInfo())
+	}
+	return info
+}
+
+// ToRawInfo returns a description of Cors suitable for JSON or YAML export.
+func (m *Cors) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(12)
+	if len(m.AllowOrigins) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("allowOrigins"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.AllowOrigins))
+	}
+	if m.AllowCredentials != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("allowCredentials"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.AllowCredentials))
+	}
+	if len(m.ExposeHeaders) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("exposeHeaders"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.ExposeHeaders))
+	}
+	if m.MaxAge != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("maxAge"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForInt(m.MaxAge))
+	}
+	if len(m.AllowMethods) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("allowMethods"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.AllowMethods))
+	}
+	if len(m.AllowHeaders) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("allowHeaders"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.AllowHeaders))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of EndpointConfiguration suitable for JSON or YAML export.
+func (m *EndpointConfiguration) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(6)
+	if len(m.Types) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("types"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.Types))
+	}
+	if len(m.VpcEndpointIds) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("vpcEndpointIds"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.VpcEndpointIds))
+	}
+	if m.DisableExecuteApiEndpoint != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("disableExecuteApiEndpoint"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.DisableExecuteApiEndpoint))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of GatewayResponse suitable for JSON or YAML export.
+func (m *GatewayResponse) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(6)
+	if m.StatusCode != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("statusCode"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.StatusCode))
+	}
+	if m.ResponseParameters != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("responseParameters"))
+		info.Content = append(info.Content, m.ResponseParameters.ToRawInfo())
+	}
+	if m.ResponseTemplates != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("responseTemplates"))
+		info.Content = append(info.Content, m.ResponseTemplates.ToRawInfo())
+	}
+	return info
+}
+
+// ToRawInfo returns a description of GatewayResponses suitable for JSON or YAML export.
+func (m *GatewayResponses) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))
+			info.Content = append(info.Content, item.Value.ToRawInfo())
+		}
+	}
+	return info
+}
+
+// ToRawInfo returns a description of Integration suitable for JSON or YAML export.
+func (m *Integration) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(34)
+	if m.Type != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("type"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Type))
+	}
+	if m.Uri != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("uri"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Uri))
+	}
+	if m.HttpMethod != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("httpMethod"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.HttpMethod))
+	}
+	if m.Credentials != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("credentials"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Credentials))
+	}
+	if m.ConnectionType != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("connectionType"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.ConnectionType))
+	}
+	if m.ConnectionId != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("connectionId"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.ConnectionId))
+	}
+	if m.PassthroughBehavior != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("passthroughBehavior"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.PassthroughBehavior))
+	}
+	if m.ContentHandling != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("contentHandling"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.ContentHandling))
+	}
+	if m.TimeoutInMillis != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("timeoutInMillis"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForInt(m.TimeoutInMillis))
+	}
+	if m.CacheNamespace != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("cacheNamespace"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.CacheNamespace))
+	}
+	if len(m.CacheKeyParameters) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("cacheKeyParameters"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.CacheKeyParameters))
+	}
+	if m.RequestParameters != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("requestParameters"))
+		info.Content = append(info.Content, m.RequestParameters.ToRawInfo())
+	}
+	if m.RequestTemplates != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("requestTemplates"))
+		info.Content = append(info.Content, m.RequestTemplates.ToRawInfo())
+	}
+	if m.Responses != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("responses"))
+		info.Content = append(info.Content, m.Responses.ToRawInfo())
+	}
+	if m.TlsConfig != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("tlsConfig"))
+		info.Content = append(info.Content, m.TlsConfig.ToRawInfo())
+	}
+	if m.IntegrationSubtype != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("integrationSubtype"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.IntegrationSubtype))
+	}
+	if m.PayloadFormatVersion != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("payloadFormatVersion"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.PayloadFormatVersion))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of IntegrationResponse suitable for JSON or YAML export.
+func (m *IntegrationResponse) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(8)
+	if m.StatusCode != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("statusCode"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.StatusCode))
+	}
+	if m.ResponseParameters != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("responseParameters"))
+		info.Content = append(info.Content, m.ResponseParameters.ToRawInfo())
+	}
+	if m.ResponseTemplates != nil {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("responseTemplates"))
+		info.Content = append(info.Content, m.ResponseTemplates.ToRawInfo())
+	}
+	if m.ContentHandling != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("contentHandling"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.ContentHandling))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of JwtConfiguration suitable for JSON or YAML export.
+func (m *JwtConfiguration) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(4)
+	if m.Issuer != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("issuer"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Issuer))
+	}
+	if len(m.Audience) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("audience"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.Audience))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of NamedGatewayResponse suitable for JSON or YAML export.
+func (m *NamedGatewayResponse) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("name"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Name))
+	}
+	// &{Name:value Type:GatewayResponse StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// ToRawInfo returns a description of NamedIntegrationResponse suitable for JSON or YAML export.
+func (m *NamedIntegrationResponse) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("name"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Name))
+	}
+	// &{Name:value Type:IntegrationResponse StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// ToRawInfo returns a description of NamedRequestValidator suitable for JSON or YAML export.
+func (m *NamedRequestValidator) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("name"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Name))
+	}
+	// &{Name:value Type:RequestValidator StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// ToRawInfo returns a description of NamedString suitable for JSON or YAML export.
+func (m *NamedString) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(4)
+	if m.Name != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("name"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Name))
+	}
+	if m.Value != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("value"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Value))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of RequestParameters suitable for JSON or YAML export.
+func (m *RequestParameters) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Value))
+		}
+	}
+	return info
+}
+
+// ToRawInfo returns a description of RequestTemplates suitable for JSON or YAML export.
+func (m *RequestTemplates) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Value))
+		}
+	}
+	return info
+}
+
+// ToRawInfo returns a description of RequestValidator suitable for JSON or YAML export.
+func (m *RequestValidator) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(4)
+	if m.ValidateRequestBody != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("validateRequestBody"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.ValidateRequestBody))
+	}
+	if m.ValidateRequestParameters != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("validateRequestParameters"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.ValidateRequestParameters))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of RequestValidators suitable for JSON or YAML export.
+func (m *RequestValidators) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))
+			info.Content = append(info.Content, item.Value.ToRawInfo())
+		}
+	}
+	return info
+}
+
+// ToRawInfo returns a description of ResponseParameters suitable for JSON or YAML export.
+func (m *ResponseParameters) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Value))
+		}
+	}
+	return info
+}
+
+// ToRawInfo returns a description of ResponseTemplates suitable for JSON or YAML export.
+func (m *ResponseTemplates) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Value))
+		}
+	}
+	return info
+}
+
+// ToRawInfo returns a description of Responses suitable for JSON or YAML export.
+func (m *Responses) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))
+			info.Content = append(info.Content, item.Value.ToRawInfo())
+		}
+	}
+	return info
+}
+
+// ToRawInfo returns a description of TlsConfig suitable for JSON or YAML export.
+func (m *TlsConfig) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(4)
+	if m.InsecureSkipVerification != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("insecureSkipVerification"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.InsecureSkipVerification))
+	}
+	if m.ServerNameToVerify != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("serverNameToVerify"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.ServerNameToVerify))
+	}
+	return info
+}
+
+var (
+	allowedKeysForAuthorizer               = compiler.NewKeySet([]string{"authorizerCredentials", "authorizerPayloadFormatVersion", "authorizerResultTtlInSeconds", "authorizerUri", "enableSimpleResponses", "identitySource", "identityValidationExpression", "jwtConfiguration", "providerARNs", "type"}, nil)
+	allowedKeysForCors                     = compiler.NewKeySet([]string{"allowCredentials", "allowHeaders", "allowMethods", "allowOrigins", "exposeHeaders", "maxAge"}, nil)
+	allowedKeysForEndpointConfiguration    = compiler.NewKeySet([]string{"disableExecuteApiEndpoint", "types", "vpcEndpointIds"}, nil)
+	allowedKeysForGatewayResponse          = compiler.NewKeySet([]string{"responseParameters", "responseTemplates", "statusCode"}, nil)
+	allowedKeysForIntegration              = compiler.NewKeySet([]string{"cacheKeyParameters", "cacheNamespace", "connectionId", "connectionType", "contentHandling", "credentials", "httpMethod", "integrationSubtype", "passthroughBehavior", "payloadFormatVersion", "requestParameters", "requestTemplates", "responses", "timeoutInMillis", "tlsConfig", "type", "uri"}, nil)
+	allowedKeysForIntegrationResponse      = compiler.NewKeySet([]string{"contentHandling", "responseParameters", "responseTemplates", "statusCode"}, nil)
+	allowedKeysForJwtConfiguration         = compiler.NewKeySet([]string{"audience", "issuer"}, nil)
+	allowedKeysForNamedGatewayResponse     = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedIntegrationResponse = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedRequestValidator    = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedString              = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForRequestValidator         = compiler.NewKeySet([]string{"validateRequestBody", "validateRequestParameters"}, nil)
+	allowedKeysForTlsConfig                = compiler.NewKeySet([]string{"insecureSkipVerification", "serverNameToVerify"}, nil)
+)
@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+)
+
+// Handle compiles the x-ms-* extensions. It adds x-ms-paths, which contains
+// OpenAPI v2 path items and can't be described by the JSON schema of the
+// other extensions, to the extensions compiled by HandleExtension.
+func Handle(extensionName string, yamlInput string) (bool, proto.Message, error) {
+	if extensionName != "x-ms-paths" {
+		return HandleExtension(extensionName, yamlInput)
+	}
+	var info yaml.Node
+	err := yaml.Unmarshal([]byte(yamlInput), &info)
+	if err != nil {
+		return true, nil, err
+	}
+	root := info.Content[0]
+	paths, err := openapi_v2.NewPaths(root, compiler.NewContext("$root", root, nil))
+	return true, paths, err
+}
+
+// SplitPath splits a path in x-ms-paths into the path of its operations and
+// the query string that distinguishes them from the operations of other
+// paths with the same path, such as "/{container}" and "restype=container"
+// for "/{container}?restype=container".
+func SplitPath(path string) (string, string) {
+	if i := strings.Index(path, "?"); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+	return path, ""
+}
@@ -0,0 +1,226 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package azure
+
+import (
+	"github.com/okkoye/gnostic/compiler"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gopkg.in/yaml.v3"
+)
+
+// HandleExtension compiles the extensions described in x-ms.json.
+// It returns false for extensions that it does not handle.
+func HandleExtension(extensionName string, yamlInput string) (bool, proto.Message, error) {
+	switch extensionName {
+	// All supported extensions
+
+	case "x-ms-azure-resource":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-client-flatten":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-client-name":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-client-request-id":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-discriminator-value":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-enum":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewEnum(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-ms-error-response":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-external":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-long-running-operation":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-mutability":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		items := []*yaml.Node{&info}
+		if info.Kind == yaml.SequenceNode {
+			items = info.Content
+		}
+		newObject := &Mutability{}
+		for _, item := range items {
+			value, ok := compiler.StringForScalarNode(item)
+			if !ok {
+				return true, nil, nil
+			}
+			newObject.Value = append(newObject.Value, value)
+		}
+		return true, newObject, nil
+	case "x-ms-odata":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-pageable":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewPageable(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-ms-parameter-grouping":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewParameterGrouping(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-ms-parameter-location":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-secret":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-ms-skip-url-encoding":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	default:
+		return false, nil, nil
+	}
+}
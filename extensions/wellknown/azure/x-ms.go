@@ -0,0 +1,421 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// Version returns the package name (and OpenAPI version).
+func Version() string {
+	return "azure"
+}
+
+// NewEnum creates an object of type Enum if possible, returning an error if not.
+func NewEnum(in *yaml.Node, context *compiler.Context) (*Enum, error) {
+	errors := make([]error, 0)
+	x := &Enum{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		requiredKeys := []string{"name"}
+		missingKeys := compiler.MissingKeysInMap(m, requiredKeys)
+		if len(missingKeys) > 0 {
+			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForEnum)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string name = 1;
+		v1 := compiler.MapValueForKey(m, "name")
+		if v1 != nil {
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// bool model_as_string = 2;
+		v2 := compiler.MapValueForKey(m, "modelAsString")
+		if v2 != nil {
+			x.ModelAsString, ok = compiler.BoolForScalarNode(v2)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for modelAsString: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// repeated EnumValue values = 3;
+		v3 := compiler.MapValueForKey(m, "values")
+		if v3 != nil {
+			// repeated EnumValue
+			x.Values = make([]*EnumValue, 0)
+			a, ok := compiler.SequenceNodeForNode(v3)
+			if ok {
+				for _, item := range a.Content {
+					y, err := NewEnumValue(item, compiler.NewContext("values", item, context))
+					if err != nil {
+						errors = append(errors, err)
+					}
+					x.Values = append(x.Values, y)
+				}
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewEnumValue creates an object of type EnumValue if possible, returning an error if not.
+func NewEnumValue(in *yaml.Node, context *compiler.Context) (*EnumValue, error) {
+	errors := make([]error, 0)
+	x := &EnumValue{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		requiredKeys := []string{"value"}
+		missingKeys := compiler.MissingKeysInMap(m, requiredKeys)
+		if len(missingKeys) > 0 {
+			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForEnumValue)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string value = 1;
+		v1 := compiler.MapValueForKey(m, "value")
+		if v1 != nil {
+			x.Value, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for value: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string description = 2;
+		v2 := compiler.MapValueForKey(m, "description")
+		if v2 != nil {
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string name = 3;
+		v3 := compiler.MapValueForKey(m, "name")
+		if v3 != nil {
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v3, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewMutability creates an object of type Mutability if possible, returning an error if not.
+func NewMutability(in *yaml.Node, context *compiler.Context) (*Mutability, error) {
+	errors := make([]error, 0)
+	x := &Mutability{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForMutability)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// repeated string value = 1;
+		v1 := compiler.MapValueForKey(m, "value")
+		if v1 != nil {
+			v, ok := compiler.SequenceNodeForNode(v1)
+			if ok {
+				x.Value = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for value: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewPageable creates an object of type Pageable if possible, returning an error if not.
+func NewPageable(in *yaml.Node, context *compiler.Context) (*Pageable, error) {
+	errors := make([]error, 0)
+	x := &Pageable{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForPageable)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string next_link_name = 1;
+		v1 := compiler.MapValueForKey(m, "nextLinkName")
+		if v1 != nil {
+			x.NextLinkName, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for nextLinkName: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string item_name = 2;
+		v2 := compiler.MapValueForKey(m, "itemName")
+		if v2 != nil {
+			x.ItemName, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for itemName: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string operation_name = 3;
+		v3 := compiler.MapValueForKey(m, "operationName")
+		if v3 != nil {
+			x.OperationName, ok = compiler.StringForScalarNodeWithContext(v3, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for operationName: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewParameterGrouping creates an object of type ParameterGrouping if possible, returning an error if not.
+func NewParameterGrouping(in *yaml.Node, context *compiler.Context) (*ParameterGrouping, error) {
+	errors := make([]error, 0)
+	x := &ParameterGrouping{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForParameterGrouping)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string name = 1;
+		v1 := compiler.MapValueForKey(m, "name")
+		if v1 != nil {
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string postfix = 2;
+		v2 := compiler.MapValueForKey(m, "postfix")
+		if v2 != nil {
+			x.Postfix, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for postfix: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Enum objects.
+func (m *Enum) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Enum objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Enum) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.Values {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside EnumValue objects.
+func (m *EnumValue) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside EnumValue objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *EnumValue) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Mutability objects.
+func (m *Mutability) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Mutability objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Mutability) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Pageable objects.
+func (m *Pageable) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Pageable objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Pageable) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside ParameterGrouping objects.
+func (m *ParameterGrouping) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside ParameterGrouping objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *ParameterGrouping) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ToRawInfo returns a description of Enum suitable for JSON or YAML export.
+func (m *Enum) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(6)
+	// always include this required field.
+	info.Content = append(info.Content, compiler.NewScalarNodeForString("name"))
+	info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Name))
+	if m.ModelAsString != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("modelAsString"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.ModelAsString))
+	}
+	if len(m.Values) != 0 {
+		items := compiler.NewSequenceNodeWithCapacity(len(m.Values))
+		for _, item := range m.Values {
+			items.Content = append(items.Content, item.ToRawInfo())
+		}
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("values"))
+		info.Content = append(info.Content, items)
+	}
+	return info
+}
+
+// ToRawInfo returns a description of EnumValue suitable for JSON or YAML export.
+func (m *EnumValue) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(6)
+	// always include this required field.
+	info.Content = append(info.Content, compiler.NewScalarNodeForString("value"))
+	info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Value))
+	if m.Description != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("description"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Description))
+	}
+	if m.Name != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("name"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Name))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of Mutability suitable for JSON or YAML export.
+func (m *Mutability) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2)
+	if len(m.Value) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("value"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.Value))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of Pageable suitable for JSON or YAML export.
+func (m *Pageable) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(6)
+	if m.NextLinkName != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("nextLinkName"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.NextLinkName))
+	}
+	if m.ItemName != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("itemName"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.ItemName))
+	}
+	if m.OperationName != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("operationName"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.OperationName))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of ParameterGrouping suitable for JSON or YAML export.
+func (m *ParameterGrouping) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(4)
+	if m.Name != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("name"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Name))
+	}
+	if m.Postfix != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("postfix"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Postfix))
+	}
+	return info
+}
+
+var (
+	allowedKeysForEnum              = compiler.NewKeySet([]string{"modelAsString", "name", "values"}, nil)
+	allowedKeysForEnumValue         = compiler.NewKeySet([]string{"description", "name", "value"}, nil)
+	allowedKeysForMutability        = compiler.NewKeySet([]string{"value"}, nil)
+	allowedKeysForPageable          = compiler.NewKeySet([]string{"itemName", "nextLinkName", "operationName"}, nil)
+	allowedKeysForParameterGrouping = compiler.NewKeySet([]string{"name", "postfix"}, nil)
+)
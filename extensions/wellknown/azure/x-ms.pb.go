@@ -0,0 +1,504 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        v4.24.3
+// source: extensions/wellknown/azure/x-ms.proto
+
+package azure
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Describes the enumerated values of a schema or parameter.
+type Enum struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the generated enum type.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// If true, the values are modeled as strings that can have other values.
+	ModelAsString bool `protobuf:"varint,2,opt,name=model_as_string,json=modelAsString,proto3" json:"model_as_string,omitempty"`
+	// The values and their descriptions.
+	Values []*EnumValue `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Enum) Reset() {
+	*x = Enum{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Enum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Enum) ProtoMessage() {}
+
+func (x *Enum) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Enum.ProtoReflect.Descriptor instead.
+func (*Enum) Descriptor() ([]byte, []int) {
+	return file_extensions_wellknown_azure_x_ms_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Enum) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Enum) GetModelAsString() bool {
+	if x != nil {
+		return x.ModelAsString
+	}
+	return false
+}
+
+func (x *Enum) GetValues() []*EnumValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// A value of an enum.
+type EnumValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The value.
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// The description of the value.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// The name used for the value in generated code.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *EnumValue) Reset() {
+	*x = EnumValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnumValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumValue) ProtoMessage() {}
+
+func (x *EnumValue) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnumValue.ProtoReflect.Descriptor instead.
+func (*EnumValue) Descriptor() ([]byte, []int) {
+	return file_extensions_wellknown_azure_x_ms_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EnumValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *EnumValue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EnumValue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// When a property can be set: create, read, or update.
+type Mutability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value []string `protobuf:"bytes,1,rep,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Mutability) Reset() {
+	*x = Mutability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Mutability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mutability) ProtoMessage() {}
+
+func (x *Mutability) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mutability.ProtoReflect.Descriptor instead.
+func (*Mutability) Descriptor() ([]byte, []int) {
+	return file_extensions_wellknown_azure_x_ms_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Mutability) GetValue() []string {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Describes an operation that returns results in pages.
+type Pageable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the property that contains the link to the next page.
+	NextLinkName string `protobuf:"bytes,1,opt,name=next_link_name,json=nextLinkName,proto3" json:"next_link_name,omitempty"`
+	// The name of the property that contains the items of a page, which defaults to value.
+	ItemName string `protobuf:"bytes,2,opt,name=item_name,json=itemName,proto3" json:"item_name,omitempty"`
+	// The name of the operation that gets the following pages.
+	OperationName string `protobuf:"bytes,3,opt,name=operation_name,json=operationName,proto3" json:"operation_name,omitempty"`
+}
+
+func (x *Pageable) Reset() {
+	*x = Pageable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pageable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pageable) ProtoMessage() {}
+
+func (x *Pageable) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pageable.ProtoReflect.Descriptor instead.
+func (*Pageable) Descriptor() ([]byte, []int) {
+	return file_extensions_wellknown_azure_x_ms_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Pageable) GetNextLinkName() string {
+	if x != nil {
+		return x.NextLinkName
+	}
+	return ""
+}
+
+func (x *Pageable) GetItemName() string {
+	if x != nil {
+		return x.ItemName
+	}
+	return ""
+}
+
+func (x *Pageable) GetOperationName() string {
+	if x != nil {
+		return x.OperationName
+	}
+	return ""
+}
+
+// Groups the parameters of an operation in generated code.
+type ParameterGrouping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the group.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// A suffix for the name of the group.
+	Postfix string `protobuf:"bytes,2,opt,name=postfix,proto3" json:"postfix,omitempty"`
+}
+
+func (x *ParameterGrouping) Reset() {
+	*x = ParameterGrouping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParameterGrouping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParameterGrouping) ProtoMessage() {}
+
+func (x *ParameterGrouping) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_wellknown_azure_x_ms_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParameterGrouping.ProtoReflect.Descriptor instead.
+func (*ParameterGrouping) Descriptor() ([]byte, []int) {
+	return file_extensions_wellknown_azure_x_ms_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ParameterGrouping) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParameterGrouping) GetPostfix() string {
+	if x != nil {
+		return x.Postfix
+	}
+	return ""
+}
+
+var File_extensions_wellknown_azure_x_ms_proto protoreflect.FileDescriptor
+
+var file_extensions_wellknown_azure_x_ms_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x77, 0x65, 0x6c,
+	0x6c, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x78, 0x2d, 0x6d,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x6d, 0x73, 0x22, 0x7c,
+	0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x5f, 0x61, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x41, 0x73, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x12, 0x38, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x6d, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x57, 0x0a, 0x09,
+	0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x22, 0x0a, 0x0a, 0x4d, 0x75, 0x74, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x74, 0x0a, 0x08, 0x50, 0x61, 0x67,
+	0x65, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x6c, 0x69,
+	0x6e, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e,
+	0x65, 0x78, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69,
+	0x74, 0x65, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x74, 0x65, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22,
+	0x41, 0x0a, 0x11, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74,
+	0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x66,
+	0x69, 0x78, 0x42, 0x73, 0x0a, 0x18, 0x6f, 0x72, 0x67, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70,
+	0x69, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x6d, 0x73, 0x42, 0x14,
+	0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6f, 0x6b, 0x6b, 0x6f, 0x79, 0x65, 0x2f, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x77, 0x65, 0x6c,
+	0x6c, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x3b, 0x61, 0x7a, 0x75,
+	0x72, 0x65, 0xa2, 0x02, 0x02, 0x6d, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_extensions_wellknown_azure_x_ms_proto_rawDescOnce sync.Once
+	file_extensions_wellknown_azure_x_ms_proto_rawDescData = file_extensions_wellknown_azure_x_ms_proto_rawDesc
+)
+
+func file_extensions_wellknown_azure_x_ms_proto_rawDescGZIP() []byte {
+	file_extensions_wellknown_azure_x_ms_proto_rawDescOnce.Do(func() {
+		file_extensions_wellknown_azure_x_ms_proto_rawDescData = protoimpl.X.CompressGZIP(file_extensions_wellknown_azure_x_ms_proto_rawDescData)
+	})
+	return file_extensions_wellknown_azure_x_ms_proto_rawDescData
+}
+
+var file_extensions_wellknown_azure_x_ms_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_extensions_wellknown_azure_x_ms_proto_goTypes = []interface{}{
+	(*Enum)(nil),              // 0: gnostic.extensions.ms.Enum
+	(*EnumValue)(nil),         // 1: gnostic.extensions.ms.EnumValue
+	(*Mutability)(nil),        // 2: gnostic.extensions.ms.Mutability
+	(*Pageable)(nil),          // 3: gnostic.extensions.ms.Pageable
+	(*ParameterGrouping)(nil), // 4: gnostic.extensions.ms.ParameterGrouping
+}
+var file_extensions_wellknown_azure_x_ms_proto_depIdxs = []int32{
+	1, // 0: gnostic.extensions.ms.Enum.values:type_name -> gnostic.extensions.ms.EnumValue
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_extensions_wellknown_azure_x_ms_proto_init() }
+func file_extensions_wellknown_azure_x_ms_proto_init() {
+	if File_extensions_wellknown_azure_x_ms_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_extensions_wellknown_azure_x_ms_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Enum); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_wellknown_azure_x_ms_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnumValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_wellknown_azure_x_ms_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Mutability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_wellknown_azure_x_ms_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pageable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_wellknown_azure_x_ms_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParameterGrouping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_extensions_wellknown_azure_x_ms_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_extensions_wellknown_azure_x_ms_proto_goTypes,
+		DependencyIndexes: file_extensions_wellknown_azure_x_ms_proto_depIdxs,
+		MessageInfos:      file_extensions_wellknown_azure_x_ms_proto_msgTypes,
+	}.Build()
+	File_extensions_wellknown_azure_x_ms_proto = out.File
+	file_extensions_wellknown_azure_x_ms_proto_rawDesc = nil
+	file_extensions_wellknown_azure_x_ms_proto_goTypes = nil
+	file_extensions_wellknown_azure_x_ms_proto_depIdxs = nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package google
+
+import (
+	"github.com/okkoye/gnostic/compiler"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gopkg.in/yaml.v3"
+)
+
+// HandleExtension compiles the extensions described in x-google.json.
+// It returns false for extensions that it does not handle.
+func HandleExtension(extensionName string, yamlInput string) (bool, proto.Message, error) {
+	switch extensionName {
+	// All supported extensions
+
+	case "x-google-allow":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-google-api-name":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-google-audiences":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-google-backend":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		newObject, err := NewBackend(&info, compiler.NewContext("$root", &info, nil))
+		return true, newObject, err
+	case "x-google-issuer":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-google-jwks_uri":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	default:
+		return false, nil, nil
+	}
+}
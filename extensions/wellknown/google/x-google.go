@@ -0,0 +1,171 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// Version returns the package name (and OpenAPI version).
+func Version() string {
+	return "google"
+}
+
+// NewBackend creates an object of type Backend if possible, returning an error if not.
+func NewBackend(in *yaml.Node, context *compiler.Context) (*Backend, error) {
+	errors := make([]error, 0)
+	x := &Backend{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		requiredKeys := []string{"address"}
+		missingKeys := compiler.MissingKeysInMap(m, requiredKeys)
+		if len(missingKeys) > 0 {
+			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForBackend)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string address = 1;
+		v1 := compiler.MapValueForKey(m, "address")
+		if v1 != nil {
+			x.Address, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for address: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string jwt_audience = 2;
+		v2 := compiler.MapValueForKey(m, "jwt_audience")
+		if v2 != nil {
+			x.JwtAudience, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for jwt_audience: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// bool disable_auth = 3;
+		v3 := compiler.MapValueForKey(m, "disable_auth")
+		if v3 != nil {
+			x.DisableAuth, ok = compiler.BoolForScalarNode(v3)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for disable_auth: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string path_translation = 4;
+		v4 := compiler.MapValueForKey(m, "path_translation")
+		if v4 != nil {
+			x.PathTranslation, ok = compiler.StringForScalarNodeWithContext(v4, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for path_translation: %s", compiler.Display(v4))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+			// check for valid enum values
+			// [APPEND_PATH_TO_ADDRESS CONSTANT_ADDRESS]
+			if ok && !compiler.StringArrayContainsValue([]string{"APPEND_PATH_TO_ADDRESS", "CONSTANT_ADDRESS"}, x.PathTranslation) {
+				message := fmt.Sprintf("has unexpected value for path_translation: %s", compiler.Display(v4))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// float deadline = 5;
+		v5 := compiler.MapValueForKey(m, "deadline")
+		if v5 != nil {
+			v, ok := compiler.FloatForScalarNode(v5)
+			if ok {
+				x.Deadline = v
+			} else {
+				message := fmt.Sprintf("has unexpected value for deadline: %s", compiler.Display(v5))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string protocol = 6;
+		v6 := compiler.MapValueForKey(m, "protocol")
+		if v6 != nil {
+			x.Protocol, ok = compiler.StringForScalarNodeWithContext(v6, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for protocol: %s", compiler.Display(v6))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+			// check for valid enum values
+			// [http/1.1 h2]
+			if ok && !compiler.StringArrayContainsValue([]string{"http/1.1", "h2"}, x.Protocol) {
+				message := fmt.Sprintf("has unexpected value for protocol: %s", compiler.Display(v6))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Backend objects.
+func (m *Backend) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Backend objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Backend) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ToRawInfo returns a description of Backend suitable for JSON or YAML export.
+func (m *Backend) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(12)
+	// always include this required field.
+	info.Content = append(info.Content, compiler.NewScalarNodeForString("address"))
+	info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Address))
+	if m.JwtAudience != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("jwt_audience"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.JwtAudience))
+	}
+	if m.DisableAuth != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("disable_auth"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.DisableAuth))
+	}
+	if m.PathTranslation != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("path_translation"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.PathTranslation))
+	}
+	if m.Deadline != 0.0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("deadline"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForFloat(m.Deadline))
+	}
+	if m.Protocol != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("protocol"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Protocol))
+	}
+	return info
+}
+
+var (
+	allowedKeysForBackend = compiler.NewKeySet([]string{"address", "deadline", "disable_auth", "jwt_audience", "path_translation", "protocol"}, nil)
+)
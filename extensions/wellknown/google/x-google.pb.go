@@ -0,0 +1,225 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        v4.24.3
+// source: extensions/wellknown/google/x-google.proto
+
+package google
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Configures the backend that requests to an operation are routed to by Cloud Endpoints or API Gateway.
+type Backend struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The URL of the backend.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The audience of the ID token that is sent to the backend.
+	JwtAudience string `protobuf:"bytes,2,opt,name=jwt_audience,json=jwtAudience,proto3" json:"jwt_audience,omitempty"`
+	// If true, no ID token is sent to the backend.
+	DisableAuth bool `protobuf:"varint,3,opt,name=disable_auth,json=disableAuth,proto3" json:"disable_auth,omitempty"`
+	// How the path of a request is translated for the backend.
+	PathTranslation string `protobuf:"bytes,4,opt,name=path_translation,json=pathTranslation,proto3" json:"path_translation,omitempty"`
+	// The number of seconds to wait for a response from the backend.
+	Deadline float64 `protobuf:"fixed64,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// The protocol used to send requests to the backend.
+	Protocol string `protobuf:"bytes,6,opt,name=protocol,proto3" json:"protocol,omitempty"`
+}
+
+func (x *Backend) Reset() {
+	*x = Backend{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_wellknown_google_x_google_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Backend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backend) ProtoMessage() {}
+
+func (x *Backend) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_wellknown_google_x_google_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backend.ProtoReflect.Descriptor instead.
+func (*Backend) Descriptor() ([]byte, []int) {
+	return file_extensions_wellknown_google_x_google_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Backend) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Backend) GetJwtAudience() string {
+	if x != nil {
+		return x.JwtAudience
+	}
+	return ""
+}
+
+func (x *Backend) GetDisableAuth() bool {
+	if x != nil {
+		return x.DisableAuth
+	}
+	return false
+}
+
+func (x *Backend) GetPathTranslation() string {
+	if x != nil {
+		return x.PathTranslation
+	}
+	return ""
+}
+
+func (x *Backend) GetDeadline() float64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *Backend) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+var File_extensions_wellknown_google_x_google_proto protoreflect.FileDescriptor
+
+var file_extensions_wellknown_google_x_google_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x77, 0x65, 0x6c,
+	0x6c, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x78, 0x2d,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x67, 0x6e,
+	0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x22, 0xcc, 0x01, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x6a, 0x77, 0x74, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6a, 0x77, 0x74, 0x41, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x75, 0x74, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x41,
+	0x75, 0x74, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70,
+	0x61, 0x74, 0x68, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x42, 0x7d, 0x0a, 0x1c, 0x6f, 0x72, 0x67, 0x2e, 0x6f, 0x70,
+	0x65, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x42, 0x14, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3c,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6b, 0x6b, 0x6f, 0x79,
+	0x65, 0x2f, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x77, 0x65, 0x6c, 0x6c, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x2f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x3b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0xa2, 0x02, 0x06, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_extensions_wellknown_google_x_google_proto_rawDescOnce sync.Once
+	file_extensions_wellknown_google_x_google_proto_rawDescData = file_extensions_wellknown_google_x_google_proto_rawDesc
+)
+
+func file_extensions_wellknown_google_x_google_proto_rawDescGZIP() []byte {
+	file_extensions_wellknown_google_x_google_proto_rawDescOnce.Do(func() {
+		file_extensions_wellknown_google_x_google_proto_rawDescData = protoimpl.X.CompressGZIP(file_extensions_wellknown_google_x_google_proto_rawDescData)
+	})
+	return file_extensions_wellknown_google_x_google_proto_rawDescData
+}
+
+var file_extensions_wellknown_google_x_google_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_extensions_wellknown_google_x_google_proto_goTypes = []interface{}{
+	(*Backend)(nil), // 0: gnostic.extensions.google.Backend
+}
+var file_extensions_wellknown_google_x_google_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_extensions_wellknown_google_x_google_proto_init() }
+func file_extensions_wellknown_google_x_google_proto_init() {
+	if File_extensions_wellknown_google_x_google_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_extensions_wellknown_google_x_google_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Backend); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_extensions_wellknown_google_x_google_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_extensions_wellknown_google_x_google_proto_goTypes,
+		DependencyIndexes: file_extensions_wellknown_google_x_google_proto_depIdxs,
+		MessageInfos:      file_extensions_wellknown_google_x_google_proto_msgTypes,
+	}.Build()
+	File_extensions_wellknown_google_x_google_proto = out.File
+	file_extensions_wellknown_google_x_google_proto_rawDesc = nil
+	file_extensions_wellknown_google_x_google_proto_goTypes = nil
+	file_extensions_wellknown_google_x_google_proto_depIdxs = nil
+}
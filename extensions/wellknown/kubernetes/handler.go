@@ -0,0 +1,191 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package kubernetes
+
+import (
+	"github.com/okkoye/gnostic/compiler"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gopkg.in/yaml.v3"
+)
+
+// HandleExtension compiles the extensions described in x-kubernetes.json.
+// It returns false for extensions that it does not handle.
+func HandleExtension(extensionName string, yamlInput string) (bool, proto.Message, error) {
+	switch extensionName {
+	// All supported extensions
+
+	case "x-kubernetes-action":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-kubernetes-embedded-resource":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-kubernetes-group-version-kind":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		items := []*yaml.Node{&info}
+		if info.Kind == yaml.SequenceNode {
+			items = info.Content
+		}
+		newObject := &GroupVersionKinds{}
+		for _, item := range items {
+			value, err := NewGroupVersionKind(item, compiler.NewContext("$root", item, nil))
+			if err != nil {
+				return true, nil, err
+			}
+			newObject.Value = append(newObject.Value, value)
+		}
+		return true, newObject, nil
+	case "x-kubernetes-int-or-string":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-kubernetes-list-map-keys":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		items := []*yaml.Node{&info}
+		if info.Kind == yaml.SequenceNode {
+			items = info.Content
+		}
+		newObject := &ListMapKeys{}
+		for _, item := range items {
+			value, ok := compiler.StringForScalarNode(item)
+			if !ok {
+				return true, nil, nil
+			}
+			newObject.Value = append(newObject.Value, value)
+		}
+		return true, newObject, nil
+	case "x-kubernetes-list-type":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-kubernetes-map-type":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-kubernetes-patch-merge-key":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-kubernetes-patch-strategy":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.StringForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.StringValue{Value: v}
+		return true, newObject, nil
+	case "x-kubernetes-preserve-unknown-fields":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		v, ok := compiler.BoolForScalarNode(&info)
+		if !ok {
+			return true, nil, nil
+		}
+		newObject := &wrapperspb.BoolValue{Value: v}
+		return true, newObject, nil
+	case "x-kubernetes-validations":
+		var info yaml.Node
+		err := yaml.Unmarshal([]byte(yamlInput), &info)
+		if err != nil {
+			return true, nil, err
+		}
+		info = *info.Content[0]
+		items := []*yaml.Node{&info}
+		if info.Kind == yaml.SequenceNode {
+			items = info.Content
+		}
+		newObject := &Validations{}
+		for _, item := range items {
+			value, err := NewValidationRule(item, compiler.NewContext("$root", item, nil))
+			if err != nil {
+				return true, nil, err
+			}
+			newObject.Value = append(newObject.Value, value)
+		}
+		return true, newObject, nil
+	default:
+		return false, nil, nil
+	}
+}
@@ -0,0 +1,424 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// Version returns the package name (and OpenAPI version).
+func Version() string {
+	return "kubernetes"
+}
+
+// NewGroupVersionKind creates an object of type GroupVersionKind if possible, returning an error if not.
+func NewGroupVersionKind(in *yaml.Node, context *compiler.Context) (*GroupVersionKind, error) {
+	errors := make([]error, 0)
+	x := &GroupVersionKind{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		requiredKeys := []string{"group", "kind", "version"}
+		missingKeys := compiler.MissingKeysInMap(m, requiredKeys)
+		if len(missingKeys) > 0 {
+			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForGroupVersionKind)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string group = 1;
+		v1 := compiler.MapValueForKey(m, "group")
+		if v1 != nil {
+			x.Group, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for group: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string version = 2;
+		v2 := compiler.MapValueForKey(m, "version")
+		if v2 != nil {
+			x.Version, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for version: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string kind = 3;
+		v3 := compiler.MapValueForKey(m, "kind")
+		if v3 != nil {
+			x.Kind, ok = compiler.StringForScalarNodeWithContext(v3, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for kind: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewGroupVersionKinds creates an object of type GroupVersionKinds if possible, returning an error if not.
+func NewGroupVersionKinds(in *yaml.Node, context *compiler.Context) (*GroupVersionKinds, error) {
+	errors := make([]error, 0)
+	x := &GroupVersionKinds{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForGroupVersionKinds)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// repeated GroupVersionKind value = 1;
+		v1 := compiler.MapValueForKey(m, "value")
+		if v1 != nil {
+			// repeated GroupVersionKind
+			x.Value = make([]*GroupVersionKind, 0)
+			a, ok := compiler.SequenceNodeForNode(v1)
+			if ok {
+				for _, item := range a.Content {
+					y, err := NewGroupVersionKind(item, compiler.NewContext("value", item, context))
+					if err != nil {
+						errors = append(errors, err)
+					}
+					x.Value = append(x.Value, y)
+				}
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewListMapKeys creates an object of type ListMapKeys if possible, returning an error if not.
+func NewListMapKeys(in *yaml.Node, context *compiler.Context) (*ListMapKeys, error) {
+	errors := make([]error, 0)
+	x := &ListMapKeys{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForListMapKeys)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// repeated string value = 1;
+		v1 := compiler.MapValueForKey(m, "value")
+		if v1 != nil {
+			v, ok := compiler.SequenceNodeForNode(v1)
+			if ok {
+				x.Value = compiler.StringArrayForSequenceNodeWithContext(v, context)
+			} else {
+				message := fmt.Sprintf("has unexpected value for value: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewValidationRule creates an object of type ValidationRule if possible, returning an error if not.
+func NewValidationRule(in *yaml.Node, context *compiler.Context) (*ValidationRule, error) {
+	errors := make([]error, 0)
+	x := &ValidationRule{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		requiredKeys := []string{"rule"}
+		missingKeys := compiler.MissingKeysInMap(m, requiredKeys)
+		if len(missingKeys) > 0 {
+			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForValidationRule)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// string rule = 1;
+		v1 := compiler.MapValueForKey(m, "rule")
+		if v1 != nil {
+			x.Rule, ok = compiler.StringForScalarNodeWithContext(v1, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for rule: %s", compiler.Display(v1))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string message = 2;
+		v2 := compiler.MapValueForKey(m, "message")
+		if v2 != nil {
+			x.Message, ok = compiler.StringForScalarNodeWithContext(v2, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for message: %s", compiler.Display(v2))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string message_expression = 3;
+		v3 := compiler.MapValueForKey(m, "messageExpression")
+		if v3 != nil {
+			x.MessageExpression, ok = compiler.StringForScalarNodeWithContext(v3, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for messageExpression: %s", compiler.Display(v3))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string reason = 4;
+		v4 := compiler.MapValueForKey(m, "reason")
+		if v4 != nil {
+			x.Reason, ok = compiler.StringForScalarNodeWithContext(v4, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for reason: %s", compiler.Display(v4))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// string field_path = 5;
+		v5 := compiler.MapValueForKey(m, "fieldPath")
+		if v5 != nil {
+			x.FieldPath, ok = compiler.StringForScalarNodeWithContext(v5, context)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for fieldPath: %s", compiler.Display(v5))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+		// bool optional_old_self = 6;
+		v6 := compiler.MapValueForKey(m, "optionalOldSelf")
+		if v6 != nil {
+			x.OptionalOldSelf, ok = compiler.BoolForScalarNode(v6)
+			if !ok {
+				message := fmt.Sprintf("has unexpected value for optionalOldSelf: %s", compiler.Display(v6))
+				errors = append(errors, compiler.NewError(context, message))
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// NewValidations creates an object of type Validations if possible, returning an error if not.
+func NewValidations(in *yaml.Node, context *compiler.Context) (*Validations, error) {
+	errors := make([]error, 0)
+	x := &Validations{}
+	m, ok := compiler.UnpackMap(in)
+	if !ok {
+		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
+		errors = append(errors, compiler.NewError(context, message))
+	} else {
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForValidations)
+		if len(invalidKeys) > 0 {
+			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
+			errors = append(errors, compiler.NewError(context, message))
+		}
+		// repeated ValidationRule value = 1;
+		v1 := compiler.MapValueForKey(m, "value")
+		if v1 != nil {
+			// repeated ValidationRule
+			x.Value = make([]*ValidationRule, 0)
+			a, ok := compiler.SequenceNodeForNode(v1)
+			if ok {
+				for _, item := range a.Content {
+					y, err := NewValidationRule(item, compiler.NewContext("value", item, context))
+					if err != nil {
+						errors = append(errors, err)
+					}
+					x.Value = append(x.Value, y)
+				}
+			}
+		}
+	}
+	return x, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside GroupVersionKind objects.
+func (m *GroupVersionKind) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside GroupVersionKind objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *GroupVersionKind) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside GroupVersionKinds objects.
+func (m *GroupVersionKinds) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside GroupVersionKinds objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *GroupVersionKinds) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.Value {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside ListMapKeys objects.
+func (m *ListMapKeys) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside ListMapKeys objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *ListMapKeys) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside ValidationRule objects.
+func (m *ValidationRule) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside ValidationRule objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *ValidationRule) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ResolveReferences resolves references found inside Validations objects.
+func (m *Validations) ResolveReferences(root string) (*yaml.Node, error) {
+	return m.ResolveReferencesWithContext(root, nil)
+}
+
+// ResolveReferencesWithContext resolves references found inside Validations objects,
+// reading files named with URLs with the fetcher of context if it has one.
+func (m *Validations) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {
+	errors := make([]error, 0)
+	for _, item := range m.Value {
+		if item != nil {
+			_, err := item.ResolveReferencesWithContext(root, context)
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return nil, compiler.NewErrorGroupOrNil(errors)
+}
+
+// ToRawInfo returns a description of GroupVersionKind suitable for JSON or YAML export.
+func (m *GroupVersionKind) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(6)
+	// always include this required field.
+	info.Content = append(info.Content, compiler.NewScalarNodeForString("group"))
+	info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Group))
+	// always include this required field.
+	info.Content = append(info.Content, compiler.NewScalarNodeForString("version"))
+	info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Version))
+	// always include this required field.
+	info.Content = append(info.Content, compiler.NewScalarNodeForString("kind"))
+	info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Kind))
+	return info
+}
+
+// ToRawInfo returns a description of GroupVersionKinds suitable for JSON or YAML export.
+func (m *GroupVersionKinds) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(0)
+	// &{Name:value Type:GroupVersionKind StringEnumValues:[] MapType: Repeated:true Pattern: Implicit:false Description:}
+	return info
+}
+
+// ToRawInfo returns a description of ListMapKeys suitable for JSON or YAML export.
+func (m *ListMapKeys) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(2)
+	if len(m.Value) != 0 {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("value"))
+		info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.Value))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of ValidationRule suitable for JSON or YAML export.
+func (m *ValidationRule) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(12)
+	// always include this required field.
+	info.Content = append(info.Content, compiler.NewScalarNodeForString("rule"))
+	info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Rule))
+	if m.Message != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("message"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Message))
+	}
+	if m.MessageExpression != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("messageExpression"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.MessageExpression))
+	}
+	if m.Reason != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("reason"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Reason))
+	}
+	if m.FieldPath != "" {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("fieldPath"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForString(m.FieldPath))
+	}
+	if m.OptionalOldSelf != false {
+		info.Content = append(info.Content, compiler.NewScalarNodeForString("optionalOldSelf"))
+		info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.OptionalOldSelf))
+	}
+	return info
+}
+
+// ToRawInfo returns a description of Validations suitable for JSON or YAML export.
+func (m *Validations) ToRawInfo() *yaml.Node {
+	if m == nil {
+		return compiler.NewMappingNode()
+	}
+	info := compiler.NewMappingNodeWithCapacity(0)
+	// &{Name:value Type:ValidationRule StringEnumValues:[] MapType: Repeated:true Pattern: Implicit:false Description:}
+	return info
+}
+
+var (
+	allowedKeysForGroupVersionKind  = compiler.NewKeySet([]string{"group", "kind", "version"}, nil)
+	allowedKeysForGroupVersionKinds = compiler.NewKeySet([]string{"value"}, nil)
+	allowedKeysForListMapKeys       = compiler.NewKeySet([]string{"value"}, nil)
+	allowedKeysForValidationRule    = compiler.NewKeySet([]string{"fieldPath", "message", "messageExpression", "optionalOldSelf", "reason", "rule"}, nil)
+	allowedKeysForValidations       = compiler.NewKeySet([]string{"value"}, nil)
+)
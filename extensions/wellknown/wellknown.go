@@ -0,0 +1,138 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wellknown compiles widely used vendor extensions into typed models.
+//
+// Handlers for each family of extensions are generated by generate-gnostic
+// from the JSON schemas in the subdirectories of this package. When gnostic
+// compiles an OpenAPI description, extensions with handlers are stored in
+// the compiled document as protocol buffer messages along with their YAML.
+package wellknown
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/okkoye/gnostic/extensions/wellknown/amazon"
+	"github.com/okkoye/gnostic/extensions/wellknown/azure"
+	"github.com/okkoye/gnostic/extensions/wellknown/google"
+	"github.com/okkoye/gnostic/extensions/wellknown/kubernetes"
+)
+
+// Handler compiles an extension. It returns false if it doesn't handle the extension.
+type Handler func(extensionName string, yamlInput string) (bool, proto.Message, error)
+
+// Family is a set of extensions with names that begin with the same prefix.
+type Family struct {
+	Prefix  string
+	Handler Handler
+}
+
+var families = []Family{
+	{Prefix: "x-google-", Handler: google.HandleExtension},
+	{Prefix: "x-amazon-apigateway-", Handler: amazon.HandleExtension},
+	{Prefix: "x-kubernetes-", Handler: kubernetes.HandleExtension},
+	{Prefix: "x-ms-", Handler: azure.Handle},
+}
+
+// Register adds a family of extensions to the registry. It is not safe to
+// call while documents are being compiled, so it is usually called by init
+// functions.
+func Register(prefix string, handler Handler) {
+	families = append(families, Family{Prefix: prefix, Handler: handler})
+}
+
+// Families returns the registered families of extensions.
+func Families() []Family {
+	return append([]Family{}, families...)
+}
+
+// HandleExtension compiles an extension with the handler of its family.
+func HandleExtension(extensionName string, yamlInput string) (bool, proto.Message, error) {
+	for _, family := range families {
+		if strings.HasPrefix(extensionName, family.Prefix) {
+			handled, message, err := family.Handler(extensionName, yamlInput)
+			if handled {
+				return handled, message, err
+			}
+		}
+	}
+	return false, nil, nil
+}
+
+// The names of the fields that contain the extensions of OpenAPI v2 and v3 objects.
+var extensionFieldNames = []protoreflect.Name{"vendor_extension", "specification_extension"}
+
+// Populate sets the values of the well-known extensions in a compiled
+// document. Extensions that were compiled by extension handlers are
+// unchanged, and extensions that don't match their models are left as YAML.
+func Populate(document proto.Message) {
+	if document != nil {
+		populate(document.ProtoReflect())
+	}
+}
+
+func populate(message protoreflect.Message) {
+	fields := message.Descriptor().Fields()
+	for _, name := range extensionFieldNames {
+		if field := fields.ByName(name); field != nil && field.IsList() && message.Has(field) {
+			list := message.Get(field).List()
+			for i := 0; i < list.Len(); i++ {
+				populateExtension(list.Get(i).Message())
+			}
+		}
+	}
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Message() == nil || field.IsMap() || !message.Has(field) {
+			continue
+		}
+		if field.IsList() {
+			list := message.Get(field).List()
+			for j := 0; j < list.Len(); j++ {
+				populate(list.Get(j).Message())
+			}
+		} else {
+			populate(message.Get(field).Message())
+		}
+	}
+}
+
+// Sets the value of a NamedAny extension from its YAML.
+func populateExtension(namedAny protoreflect.Message) {
+	fields := namedAny.Descriptor().Fields()
+	name, value := fields.ByName("name"), fields.ByName("value")
+	if name == nil || value == nil || !namedAny.Has(value) {
+		return
+	}
+	extension := namedAny.Get(value).Message()
+	anyField, yamlField := extension.Descriptor().Fields().ByName("value"), extension.Descriptor().Fields().ByName("yaml")
+	if anyField == nil || yamlField == nil || extension.Has(anyField) {
+		return
+	}
+	handled, result, err := HandleExtension(namedAny.Get(name).String(), extension.Get(yamlField).String())
+	if !handled || err != nil || result == nil {
+		return
+	}
+	// extensions like x-ms-paths can contain other extensions
+	populate(result.ProtoReflect())
+	packed, err := anypb.New(result)
+	if err != nil {
+		return
+	}
+	extension.Set(anyField, protoreflect.ValueOfMessage(packed.ProtoReflect()))
+}
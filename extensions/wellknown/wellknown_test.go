@@ -0,0 +1,404 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wellknown
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/extensions/wellknown/amazon"
+	"github.com/okkoye/gnostic/extensions/wellknown/azure"
+	"github.com/okkoye/gnostic/extensions/wellknown/google"
+	"github.com/okkoye/gnostic/extensions/wellknown/kubernetes"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const description = `
+swagger: "2.0"
+info:
+  title: Echo
+  version: 1.0.0
+x-amazon-apigateway-endpoint-configuration:
+  types: [REGIONAL]
+x-amazon-apigateway-minimum-compression-size: 1024
+paths:
+  /echo:
+    post:
+      x-google-backend:
+        address: https://example.com/echo
+        path_translation: APPEND_PATH_TO_ADDRESS
+        deadline: 10.5
+      x-other: true
+      responses:
+        "200":
+          description: ok
+securityDefinitions:
+  jwt:
+    type: oauth2
+    flow: implicit
+    authorizationUrl: ""
+    x-google-issuer: issuer@example.com
+    x-google-backend:
+      unknown: true
+`
+
+func compileDescription(t *testing.T) *openapi_v2.Document {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(description), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	root := node.Content[0]
+	document, err := openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	Populate(document)
+	return document
+}
+
+func unpack(t *testing.T, extension *openapi_v2.NamedAny, message proto.Message) {
+	if extension.Value.Value == nil {
+		t.Fatalf("%s was not compiled", extension.Name)
+	}
+	if err := anypb.UnmarshalTo(extension.Value.Value, message, proto.UnmarshalOptions{}); err != nil {
+		t.Fatalf("%s: %+v", extension.Name, err)
+	}
+	if extension.Value.Yaml == "" {
+		t.Errorf("%s has no YAML", extension.Name)
+	}
+}
+
+func TestPopulate(t *testing.T) {
+	document := compileDescription(t)
+
+	configuration := &amazon.EndpointConfiguration{}
+	unpack(t, document.VendorExtension[0], configuration)
+	if len(configuration.Types) != 1 || configuration.Types[0] != "REGIONAL" {
+		t.Errorf("unexpected endpoint configuration: %v", configuration)
+	}
+	size := &wrapperspb.Int64Value{}
+	unpack(t, document.VendorExtension[1], size)
+	if size.Value != 1024 {
+		t.Errorf("unexpected minimum compression size: %d", size.Value)
+	}
+
+	operation := document.Paths.Path[0].Value.Post
+	backend := &google.Backend{}
+	unpack(t, operation.VendorExtension[0], backend)
+	if backend.Address != "https://example.com/echo" ||
+		backend.PathTranslation != "APPEND_PATH_TO_ADDRESS" ||
+		backend.Deadline != 10.5 {
+		t.Errorf("unexpected backend: %v", backend)
+	}
+	if operation.VendorExtension[1].Value.Value != nil {
+		t.Errorf("x-other was compiled")
+	}
+
+	security := document.SecurityDefinitions.AdditionalProperties[0].Value.GetOauth2ImplicitSecurity()
+	issuer := &wrapperspb.StringValue{}
+	unpack(t, security.VendorExtension[0], issuer)
+	if issuer.Value != "issuer@example.com" {
+		t.Errorf("unexpected issuer: %s", issuer.Value)
+	}
+	// extensions that don't match their models are left as YAML
+	if security.VendorExtension[1].Value.Value != nil {
+		t.Errorf("invalid x-google-backend was compiled")
+	}
+}
+
+const kubernetesDescription = `
+openapi: 3.0.0
+info:
+  title: Kubernetes
+  version: v1.28.0
+paths:
+  /api/v1/namespaces/{namespace}/pods:
+    get:
+      x-kubernetes-action: list
+      x-kubernetes-group-version-kind:
+        group: ""
+        kind: Pod
+        version: v1
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    io.k8s.api.core.v1.Pod:
+      type: object
+      x-kubernetes-group-version-kind:
+      - group: ""
+        kind: Pod
+        version: v1
+      properties:
+        port:
+          x-kubernetes-int-or-string: true
+        containers:
+          type: array
+          x-kubernetes-list-type: map
+          x-kubernetes-list-map-keys: [name]
+          x-kubernetes-patch-strategy: merge
+`
+
+func TestKubernetes(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(kubernetesDescription), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	root := node.Content[0]
+	document, err := openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	Populate(document)
+
+	// single values of array extensions are read as arrays with one item
+	operation := document.Paths.Path[0].Value.Get
+	kinds := &kubernetes.GroupVersionKinds{}
+	unpackV3(t, operation.SpecificationExtension[1], kinds)
+	if len(kinds.Value) != 1 || kinds.Value[0].Kind != "Pod" || kinds.Value[0].Version != "v1" {
+		t.Errorf("unexpected kinds: %v", kinds)
+	}
+
+	schema := document.Components.Schemas.AdditionalProperties[0].Value.GetSchema()
+	kinds = &kubernetes.GroupVersionKinds{}
+	unpackV3(t, schema.SpecificationExtension[0], kinds)
+	if len(kinds.Value) != 1 || kinds.Value[0].Kind != "Pod" {
+		t.Errorf("unexpected kinds: %v", kinds)
+	}
+	properties := schema.Properties.AdditionalProperties
+	intOrString := &wrapperspb.BoolValue{}
+	unpackV3(t, properties[0].Value.GetSchema().SpecificationExtension[0], intOrString)
+	if !intOrString.Value {
+		t.Errorf("x-kubernetes-int-or-string is false")
+	}
+	containers := properties[1].Value.GetSchema()
+	listType := &wrapperspb.StringValue{}
+	unpackV3(t, containers.SpecificationExtension[0], listType)
+	keys := &kubernetes.ListMapKeys{}
+	unpackV3(t, containers.SpecificationExtension[1], keys)
+	strategy := &wrapperspb.StringValue{}
+	unpackV3(t, containers.SpecificationExtension[2], strategy)
+	if listType.Value != "map" || len(keys.Value) != 1 || keys.Value[0] != "name" || strategy.Value != "merge" {
+		t.Errorf("unexpected list extensions: %v %v %v", listType, keys, strategy)
+	}
+}
+
+const amazonDescription = `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: "1.0"
+x-amazon-apigateway-request-validators:
+  all:
+    validateRequestBody: true
+    validateRequestParameters: true
+  params-only:
+    validateRequestParameters: true
+paths:
+  /pets/{petId}:
+    get:
+      x-amazon-apigateway-integration:
+        type: http
+        httpMethod: GET
+        uri: http://petstore.example.com/petstore/pets/{petId}
+        passthroughBehavior: when_no_match
+        timeoutInMillis: 29000
+        cacheKeyParameters: [method.request.path.petId]
+        requestParameters:
+          integration.request.path.petId: method.request.path.petId
+        responses:
+          default:
+            statusCode: "200"
+            responseParameters:
+              method.response.header.Access-Control-Allow-Origin: "'*'"
+        tlsConfig:
+          insecureSkipVerification: false
+      responses:
+        "200":
+          description: ok
+components:
+  securitySchemes:
+    lambda:
+      type: apiKey
+      name: Authorization
+      in: header
+      x-amazon-apigateway-authtype: custom
+      x-amazon-apigateway-authorizer:
+        type: token
+        authorizerUri: arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/arn/invocations
+        authorizerResultTtlInSeconds: 300
+`
+
+func TestAmazonAPIGateway(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(amazonDescription), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	root := node.Content[0]
+	document, err := openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	Populate(document)
+
+	validators := &amazon.RequestValidators{}
+	unpackV3(t, document.SpecificationExtension[0], validators)
+	if len(validators.AdditionalProperties) != 2 ||
+		validators.AdditionalProperties[1].Name != "params-only" ||
+		validators.AdditionalProperties[1].Value.ValidateRequestBody ||
+		!validators.AdditionalProperties[1].Value.ValidateRequestParameters {
+		t.Errorf("unexpected request validators: %v", validators)
+	}
+
+	integration := &amazon.Integration{}
+	unpackV3(t, document.Paths.Path[0].Value.Get.SpecificationExtension[0], integration)
+	if integration.Type != "http" || integration.TimeoutInMillis != 29000 ||
+		len(integration.CacheKeyParameters) != 1 ||
+		integration.RequestParameters.AdditionalProperties[0].Value != "method.request.path.petId" {
+		t.Errorf("unexpected integration: %v", integration)
+	}
+	if len(integration.Responses.AdditionalProperties) != 1 {
+		t.Fatalf("unexpected integration responses: %v", integration.Responses)
+	}
+	response := integration.Responses.AdditionalProperties[0]
+	if response.Name != "default" || response.Value.StatusCode != "200" ||
+		response.Value.ResponseParameters.AdditionalProperties[0].Value != "'*'" {
+		t.Errorf("unexpected integration response: %v", response)
+	}
+	if integration.TlsConfig == nil || integration.TlsConfig.InsecureSkipVerification {
+		t.Errorf("unexpected TLS configuration: %v", integration.TlsConfig)
+	}
+
+	scheme := document.Components.SecuritySchemes.AdditionalProperties[0].Value.GetSecurityScheme()
+	authorizer := &amazon.Authorizer{}
+	unpackV3(t, scheme.SpecificationExtension[1], authorizer)
+	if authorizer.Type != "token" || authorizer.AuthorizerResultTtlInSeconds != 300 {
+		t.Errorf("unexpected authorizer: %v", authorizer)
+	}
+}
+
+const azureDescription = `
+swagger: "2.0"
+info:
+  title: Storage
+  version: "2019-02-02"
+paths:
+  /{containerName}:
+    get:
+      operationId: Container_GetProperties
+      x-ms-pageable:
+        nextLinkName: null
+      responses:
+        "200":
+          description: ok
+x-ms-paths:
+  /{containerName}?restype=container&comp=list:
+    get:
+      operationId: Container_ListBlobs
+      x-ms-pageable:
+        nextLinkName: NextMarker
+        itemName: Blobs
+      parameters:
+      - name: state
+        in: query
+        type: string
+        enum: [Succeeded, Failed]
+        x-ms-enum:
+          name: ProvisioningState
+          modelAsString: true
+          values:
+          - value: Succeeded
+            description: done
+          - value: Failed
+      responses:
+        "200":
+          description: ok
+`
+
+func TestAzure(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(azureDescription), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	root := node.Content[0]
+	document, err := openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	Populate(document)
+
+	// a null nextLinkName means that there is only one page
+	pageable := &azure.Pageable{}
+	unpack(t, document.Paths.Path[0].Value.Get.VendorExtension[0], pageable)
+	if pageable.NextLinkName != "" {
+		t.Errorf("unexpected next link name: %s", pageable.NextLinkName)
+	}
+
+	paths := &openapi_v2.Paths{}
+	unpack(t, document.VendorExtension[0], paths)
+	if len(paths.Path) != 1 {
+		t.Fatalf("unexpected paths: %v", paths)
+	}
+	path, query := azure.SplitPath(paths.Path[0].Name)
+	if path != "/{containerName}" || query != "restype=container&comp=list" {
+		t.Errorf("unexpected path and query: %s %s", path, query)
+	}
+	// extensions in x-ms-paths are also compiled
+	operation := paths.Path[0].Value.Get
+	unpack(t, operation.VendorExtension[0], pageable)
+	if pageable.NextLinkName != "NextMarker" || pageable.ItemName != "Blobs" {
+		t.Errorf("unexpected pageable: %v", pageable)
+	}
+	parameter := operation.Parameters[0].GetParameter().GetNonBodyParameter().GetQueryParameterSubSchema()
+	enum := &azure.Enum{}
+	unpack(t, parameter.VendorExtension[0], enum)
+	if enum.Name != "ProvisioningState" || !enum.ModelAsString || len(enum.Values) != 2 ||
+		enum.Values[0].Value != "Succeeded" || enum.Values[0].Description != "done" {
+		t.Errorf("unexpected enum: %v", enum)
+	}
+}
+
+func unpackV3(t *testing.T, extension *openapi_v3.NamedAny, message proto.Message) {
+	if extension.Value.Value == nil {
+		t.Fatalf("%s was not compiled", extension.Name)
+	}
+	if err := anypb.UnmarshalTo(extension.Value.Value, message, proto.UnmarshalOptions{}); err != nil {
+		t.Fatalf("%s: %+v", extension.Name, err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	saved := families
+	defer func() { families = saved }()
+	Register("x-test-", func(name string, yamlInput string) (bool, proto.Message, error) {
+		return name == "x-test-name", wrapperspb.String(name), nil
+	})
+	if handled, _, _ := HandleExtension("x-test-name", "value"); !handled {
+		t.Errorf("x-test-name was not handled")
+	}
+	if handled, _, _ := HandleExtension("x-test-other", "value"); handled {
+		t.Errorf("x-test-other was handled")
+	}
+	if handled, _, _ := HandleExtension("x-google-api-name", "echo"); !handled {
+		t.Errorf("x-google-api-name was not handled")
+	}
+}
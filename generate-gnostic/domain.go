@@ -20,6 +20,7 @@ import (
 	"log"
 	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/okkoye/gnostic/jsonschema"
 )
@@ -34,6 +35,10 @@ type Domain struct {
 	ObjectTypeRequests    map[string]*TypeRequest // anonymous types implied by type instantiation
 	MapTypeRequests       map[string]string       // "NamedObject" types that will be used to implement ordered maps
 	Version               string                  // OpenAPI Version ("v2" or "v3")
+	ModelsAreAliases      bool                    // if set, models are aliases of gnostic-models types and get rawInfoFor functions instead of methods
+	RawInfoFunctions      bool                    // if set, models with ToRawInfo methods also get rawInfoFor functions
+	OptionalNumbers       bool                    // if set, number fields are optional, so zero values are kept
+	Templates             *template.Template      // if set, templates that generate the methods of types
 }
 
 // NewDomain creates a domain representation.
@@ -49,6 +54,14 @@ func NewDomain(schema *jsonschema.Schema, version string) *Domain {
 	return cc
 }
 
+// IsOptional returns true if a property of a type is an optional field,
+// which records whether it was set even when its value is zero. Fields of
+// oneofs already record which of them was set.
+func (domain *Domain) IsOptional(typeModel *TypeModel, property *TypeProperty) bool {
+	return domain.OptionalNumbers && !typeModel.OneOfWrapper && !property.Repeated &&
+		(property.Type == "int" || property.Type == "float")
+}
+
 // TypeNameForStub returns a capitalized name to use for a generated type.
 func (domain *Domain) TypeNameForStub(stub string) string {
 
@@ -544,6 +557,38 @@ func (domain *Domain) Build() (err error) {
 		}
 	}
 
+	domain.buildRequestedTypes()
+
+	// add a type for string arrays
+	stringArrayType := NewTypeModel()
+	stringArrayType.Name = "StringArray"
+	stringProperty := NewTypeProperty()
+	stringProperty.Name = "value"
+	stringProperty.Type = "string"
+	stringProperty.Repeated = true
+	stringArrayType.addProperty(stringProperty)
+	domain.TypeModels[stringArrayType.Name] = stringArrayType
+
+	// add a type for "Any"
+	anyType := NewTypeModel()
+	anyType.Name = "Any"
+	anyType.Open = true
+	anyType.IsBlob = true
+	valueProperty := NewTypeProperty()
+	valueProperty.Name = "value"
+	valueProperty.Type = "google.protobuf.Any"
+	anyType.addProperty(valueProperty)
+	yamlProperty := NewTypeProperty()
+	yamlProperty.Name = "yaml"
+	yamlProperty.Type = "string"
+	anyType.addProperty(yamlProperty)
+	domain.TypeModels[anyType.Name] = anyType
+	return err
+}
+
+// buildRequestedTypes builds the anonymous object types and map item types
+// that were requested while building other types.
+func (domain *Domain) buildRequestedTypes() {
 	// iterate over anonymous object types to be instantiated and generate a type for each
 	// we loop because these implied types could imply other types.
 	// when implied types are instantiated (with buildTypeForDefinitionObject),
@@ -589,32 +634,6 @@ func (domain *Domain) Build() (err error) {
 
 		domain.TypeModels[typeName] = typeModel
 	}
-
-	// add a type for string arrays
-	stringArrayType := NewTypeModel()
-	stringArrayType.Name = "StringArray"
-	stringProperty := NewTypeProperty()
-	stringProperty.Name = "value"
-	stringProperty.Type = "string"
-	stringProperty.Repeated = true
-	stringArrayType.addProperty(stringProperty)
-	domain.TypeModels[stringArrayType.Name] = stringArrayType
-
-	// add a type for "Any"
-	anyType := NewTypeModel()
-	anyType.Name = "Any"
-	anyType.Open = true
-	anyType.IsBlob = true
-	valueProperty := NewTypeProperty()
-	valueProperty.Name = "value"
-	valueProperty.Type = "google.protobuf.Any"
-	anyType.addProperty(valueProperty)
-	yamlProperty := NewTypeProperty()
-	yamlProperty.Name = "yaml"
-	yamlProperty.Type = "string"
-	anyType.addProperty(yamlProperty)
-	domain.TypeModels[anyType.Name] = anyType
-	return err
 }
 
 func (domain *Domain) sortedTypeNames() []string {
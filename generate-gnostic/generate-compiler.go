@@ -65,8 +65,17 @@ func (p *patternNames) Names() map[string]string {
 }
 
 // GenerateCompiler generates the compiler code for a domain.
-func (domain *Domain) GenerateCompiler(packageName string, license string, imports []string) string {
+func (domain *Domain) GenerateCompiler(packageName string, license string, imports []string) (string, error) {
+	compiler, _, err := domain.GenerateCompilerWithSourceMap(packageName, license, imports)
+	return compiler, err
+}
+
+// GenerateCompilerWithSourceMap generates the compiler code for a domain
+// and a map from its lines to the generator functions and the types and
+// properties that produced them.
+func (domain *Domain) GenerateCompilerWithSourceMap(packageName string, license string, imports []string) (string, *printer.SourceMap, error) {
 	code := &printer.Code{}
+	code.SetSource("GenerateCompiler")
 	code.Print(license)
 	code.Print("// THIS FILE IS AUTOMATICALLY GENERATED.\n")
 
@@ -96,25 +105,77 @@ func (domain *Domain) GenerateCompiler(packageName string, license string, impor
 		},
 	}
 
+	// key sets are precomputed for types that only allow certain keys
+	keySets := make(map[string]string)
+
 	// generate NewX() constructor functions for each type
 	for _, typeName := range typeNames {
-		domain.generateConstructorForType(code, typeName, regexPatterns)
+		code.SetSource(compilerSource("generateConstructorForType", typeName, ""))
+		domain.generateConstructorForType(code, typeName, regexPatterns, keySets)
 	}
 
 	// generate ResolveReferences() methods for each type
 	for _, typeName := range typeNames {
-		domain.generateResolveReferencesMethodsForType(code, typeName)
+		if domain.ModelsAreAliases {
+			break // aliased types have the methods of their package
+		}
+		if domain.Templates != nil {
+			code.SetSource(compilerSource(resolveReferencesTemplate+".tmpl", typeName, ""))
+			if err := domain.generateMethodFromTemplate(code, resolveReferencesTemplate, typeName); err != nil {
+				return "", nil, err
+			}
+		} else {
+			code.SetSource(compilerSource("generateResolveReferencesMethodsForType", typeName, ""))
+			domain.generateResolveReferencesMethodsForType(code, typeName)
+		}
 	}
 
-	// generate ToRawInfo() methods for each type
+	// generate ToRawInfo() methods for each type, or rawInfoFor functions
+	// for aliased types
 	for _, typeName := range typeNames {
-		domain.generateToRawInfoMethodForType(code, typeName)
+		if domain.Templates != nil && !domain.ModelsAreAliases {
+			code.SetSource(compilerSource(toRawInfoTemplate+".tmpl", typeName, ""))
+			if err := domain.generateMethodFromTemplate(code, toRawInfoTemplate, typeName); err != nil {
+				return "", nil, err
+			}
+		} else {
+			code.SetSource(compilerSource("generateToRawInfoMethodForType", typeName, ""))
+			domain.generateToRawInfoMethodForType(code, typeName, domain.rawInfoNodes())
+		}
 	}
 
-	// generate precompiled regexps for use during parsing
-	domain.generateConstantVariables(code, regexPatterns)
+	// generate rawInfoFor() functions for each type of models with methods
+	for _, typeName := range typeNames {
+		if domain.ModelsAreAliases || !domain.RawInfoFunctions {
+			break // aliased types got them in place of methods
+		}
+		code.SetSource(compilerSource("generateToRawInfoMethodForType", typeName, ""))
+		domain.generateToRawInfoMethodForType(code, typeName, rawInfoNodes{builder: true})
+	}
+
+	// generate precompiled regexps and key sets for use during parsing
+	code.SetSource("generateConstantVariables")
+	domain.generateConstantVariables(code, regexPatterns, keySets)
+
+	return code.String(), code.SourceMap(""), nil
+}
+
+// compilerSource describes the generator function and the type or property
+// that produce lines of generated code.
+func compilerSource(generator string, typeName string, propertyName string) string {
+	if propertyName != "" {
+		typeName += "." + propertyName
+	}
+	return generator + " " + typeName
+}
 
-	return code.String()
+// generateMethodFromTemplate executes one of the domain's templates for a type.
+func (domain *Domain) generateMethodFromTemplate(code *printer.Code, templateName string, typeName string) error {
+	return code.PrintTemplate(domain.Templates, templateName, &compilerTemplateData{
+		Domain:    domain,
+		TypeName:  typeName,
+		TypeModel: domain.TypeModels[typeName],
+	})
 }
 
 func escapeSlashes(pattern string) string {
@@ -133,7 +194,7 @@ func nameForPattern(regexPatterns *patternNames, pattern string) string {
 	return regexPatterns.VariableName(pattern)
 }
 
-func (domain *Domain) generateConstructorForType(code *printer.Code, typeName string, regexPatterns *patternNames) {
+func (domain *Domain) generateConstructorForType(code *printer.Code, typeName string, regexPatterns *patternNames, keySets map[string]string) {
 	code.Print("// New%s creates an object of type %s if possible, returning an error if not.", typeName, typeName)
 	code.Print("func New%s(in *yaml.Node, context *compiler.Context) (*%s, error) {", typeName, typeName)
 	code.Print("errors := make([]error, 0)")
@@ -202,7 +263,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 		code.Print("x := &StringArray{}")
 		code.Print("x.Value = make([]string, 0)")
 		code.Print("for _, node := range in.Content {")
-		code.Print("  s, _ := compiler.StringForScalarNode(node)")
+		code.Print("  s, _ := compiler.StringForScalarNodeWithContext(node, context)")
 		code.Print("  x.Value = append(x.Value, s)")
 		code.Print("}")
 	} else if typeModel.Name == "SpecificationExtension" {
@@ -213,18 +274,14 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 		code.Print("        var v bool")
 		code.Print("        v, matched = compiler.BoolForScalarNode(in)")
 		code.Print("		x.Oneof = &SpecificationExtension_Boolean{Boolean: v}")
-		code.Print("    case \"!!str\":")
+		code.Print("    case \"!!str\", \"!!timestamp\":")
 		code.Print("        var v string")
-		code.Print("        v, matched = compiler.StringForScalarNode(in)")
+		code.Print("        v, matched = compiler.StringForScalarNodeWithContext(in, context)")
 		code.Print("		x.Oneof = &SpecificationExtension_String_{String_: v}")
-		code.Print("    case \"!!float\":")
+		code.Print("    case \"!!float\", \"!!int\":")
 		code.Print("        var v float64")
 		code.Print("        v, matched = compiler.FloatForScalarNode(in)")
 		code.Print("		x.Oneof = &SpecificationExtension_Number{Number: v}")
-		code.Print("    case \"!!int\":")
-		code.Print("        var v int64")
-		code.Print("        v, matched = compiler.IntForScalarNode(in)")
-		code.Print("		x.Oneof = &SpecificationExtension_Number{Number: float64(v)}")
 		code.Print("	}")
 		code.Print("	if matched {")
 		code.Print("		// since the oneof matched one of its possibilities, discard any matching errors")
@@ -238,18 +295,14 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 		code.Print("        var v bool")
 		code.Print("        v, matched = compiler.BoolForScalarNode(in)")
 		code.Print("		x.Oneof = &DefaultType_Boolean{Boolean: v}")
-		code.Print("    case \"!!str\":")
+		code.Print("    case \"!!str\", \"!!timestamp\":")
 		code.Print("        var v string")
-		code.Print("        v, matched = compiler.StringForScalarNode(in)")
+		code.Print("        v, matched = compiler.StringForScalarNodeWithContext(in, context)")
 		code.Print("		x.Oneof = &DefaultType_String_{String_: v}")
-		code.Print("    case \"!!float\":")
+		code.Print("    case \"!!float\", \"!!int\":")
 		code.Print("        var v float64")
 		code.Print("        v, matched = compiler.FloatForScalarNode(in)")
 		code.Print("		x.Oneof = &DefaultType_Number{Number: v}")
-		code.Print("    case \"!!int\":")
-		code.Print("        var v int64")
-		code.Print("        v, matched = compiler.IntForScalarNode(in)")
-		code.Print("		x.Oneof = &DefaultType_Number{Number: float64(v)}")
 		code.Print("	}")
 		code.Print("	if matched {")
 		code.Print("		// since the oneof matched one of its possibilities, discard any matching errors")
@@ -320,14 +373,13 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				}
 			}
 			// verify that map includes only allowed keys and patterns
-			code.Print("allowedKeys := []string{%s}", allowedKeyString)
+			keySetName := "allowedKeysFor" + typeName
 			if len(allowedPatternString) > 0 {
-				code.Print("allowedPatterns := []*regexp.Regexp{%s}", allowedPatternString)
+				keySets[keySetName] = fmt.Sprintf("compiler.NewKeySet([]string{%s}, []*regexp.Regexp{%s})", allowedKeyString, allowedPatternString)
 			} else {
-				code.Print("var allowedPatterns []*regexp.Regexp")
-
+				keySets[keySetName] = fmt.Sprintf("compiler.NewKeySet([]string{%s}, nil)", allowedKeyString)
 			}
-			code.Print("invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)")
+			code.Print("invalidKeys := compiler.InvalidKeysInMapForKeySet(m, %s)", keySetName)
 			code.Print("if len(invalidKeys) > 0 {")
 			code.Print("  message := fmt.Sprintf(\"has invalid %%s: %%+v\", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, \", \"))")
 			code.Print("  errors = append(errors, compiler.NewError(context, message))")
@@ -337,30 +389,28 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 		var fieldNumber = 0
 		for _, propertyModel := range typeModel.Properties {
 			propertyName := propertyModel.Name
+			code.SetSource(compilerSource("generateConstructorForType", typeName, propertyName))
+			optional := domain.IsOptional(typeModel, propertyModel)
 			fieldNumber++
 			propertyType := propertyModel.Type
 			if propertyType == "int" {
 				propertyType = "int64"
 			}
 			var displayName = propertyName
-			if displayName == "$ref" {
-				displayName = "_ref"
-			}
-			if displayName == "$schema" {
-				displayName = "_schema"
+			if strings.HasPrefix(displayName, "$") {
+				displayName = "_" + displayName[1:]
 			}
 			displayName = camelCaseToSnakeCase(displayName)
 
 			var line = fmt.Sprintf("%s %s = %d;", propertyType, displayName, fieldNumber)
 			if propertyModel.Repeated {
 				line = "repeated " + line
+			} else if optional {
+				line = "optional " + line
 			}
 			code.Print("// " + line)
 
-			fieldName := strings.Title(snakeCaseToCamelCase(propertyName))
-			if propertyName == "$ref" {
-				fieldName = "XRef"
-			}
+			fieldName := propertyModel.FieldName()
 
 			typeModel, typeFound := domain.TypeModels[propertyType]
 			if typeFound && !typeModel.IsPair {
@@ -417,7 +467,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 					code.Print("if (v%d != nil) {", fieldNumber)
 					code.Print("  v, ok := compiler.SequenceNodeForNode(v%d)", fieldNumber)
 					code.Print("  if ok {")
-					code.Print("    x.%s = compiler.StringArrayForSequenceNode(v)", fieldName)
+					code.Print("    x.%s = compiler.StringArrayForSequenceNodeWithContext(v, context)", fieldName)
 					code.Print("  } else {")
 					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
 					code.Print("    errors = append(errors, compiler.NewError(context, message))")
@@ -445,7 +495,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				} else {
 					code.Print("v%d := compiler.MapValueForKey(m, \"%s\")", fieldNumber, propertyName)
 					code.Print("if (v%d != nil) {", fieldNumber)
-					code.Print("  x.%s, ok = compiler.StringForScalarNode(v%d)", fieldName, fieldNumber)
+					code.Print("  x.%s, ok = compiler.StringForScalarNodeWithContext(v%d, context)", fieldName, fieldNumber)
 					code.Print("  if !ok {")
 					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
 					code.Print("    errors = append(errors, compiler.NewError(context, message))")
@@ -476,7 +526,11 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				code.Print("if (v%d != nil) {", fieldNumber)
 				code.Print("  v, ok := compiler.FloatForScalarNode(v%d)", fieldNumber)
 				code.Print("  if ok {")
-				code.Print("    x.%s = v", fieldName)
+				if optional {
+					code.Print("    x.%s = &v", fieldName)
+				} else {
+					code.Print("    x.%s = v", fieldName)
+				}
 				code.Print("  } else {")
 				code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
 				code.Print("    errors = append(errors, compiler.NewError(context, message))")
@@ -487,7 +541,12 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				code.Print("if (v%d != nil) {", fieldNumber)
 				code.Print("  t, ok := compiler.IntForScalarNode(v%d)", fieldNumber)
 				code.Print("  if ok {")
-				code.Print("    x.%s = int64(t)", fieldName)
+				if optional {
+					code.Print("    v := int64(t)")
+					code.Print("    x.%s = &v", fieldName)
+				} else {
+					code.Print("    x.%s = int64(t)", fieldName)
+				}
 				code.Print("  } else {")
 				code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
 				code.Print("    errors = append(errors, compiler.NewError(context, message))")
@@ -521,7 +580,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 						code.Print("x.%s = make([]*Named%s, 0)", fieldName, mapTypeName)
 					}
 					code.Print("for i := 0; i < len(m.Content); i += 2 {")
-					code.Print("k, ok := compiler.StringForScalarNode(m.Content[i])")
+					code.Print("k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)")
 					code.Print("if ok {")
 					code.Print("v := m.Content[i+1]")
 					if pattern := propertyModel.Pattern; pattern != "" {
@@ -536,7 +595,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 					code.Print("pair.Name = k")
 
 					if mapTypeName == "string" {
-						code.Print("pair.Value, _ = compiler.StringForScalarNode(v)")
+						code.Print("pair.Value, _ = compiler.StringForScalarNodeWithContext(v, context)")
 					} else if mapTypeName == "Any" {
 						code.Print("result := &Any{}")
 						code.Print("handled, resultFromExt, err := compiler.CallExtension(context, v, k)")
@@ -573,6 +632,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				}
 			}
 		}
+		code.SetSource(compilerSource("generateConstructorForType", typeName, ""))
 		if unpackAtTop {
 			code.Print("}")
 		}
@@ -599,116 +659,117 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 // ResolveReferences() methods
 func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code, typeName string) {
 	code.Print("// ResolveReferences resolves references found inside %s objects.", typeName)
-	code.Print("func (m *%s) ResolveReferences(root string) (*yaml.Node, error) {", typeName)
-	code.Print("errors := make([]error, 0)")
+	code.Block(fmt.Sprintf("func (m *%s) ResolveReferences(root string) (*yaml.Node, error) {", typeName), "}\n", func() {
+		code.Print("return m.ResolveReferencesWithContext(root, nil)")
+	})
+	code.Print("// ResolveReferencesWithContext resolves references found inside %s objects,", typeName)
+	code.Print("// reading files named with URLs with the fetcher of context if it has one.")
+	code.Block(fmt.Sprintf("func (m *%s) ResolveReferencesWithContext(root string, context *compiler.Context) (*yaml.Node, error) {", typeName), "}\n", func() {
+		code.Print("errors := make([]error, 0)")
 
-	typeModel := domain.TypeModels[typeName]
-	if typeModel.OneOfWrapper {
-		// call ResolveReferences on whatever is in the Oneof.
-		for _, propertyModel := range typeModel.Properties {
-			propertyType := propertyModel.Type
-			_, typeFound := domain.TypeModels[propertyType]
-			if typeFound {
-				code.Print("{")
-				code.Print("p, ok := m.Oneof.(*%s_%s)", typeName, propertyType)
-				code.Print("if ok {")
-				if propertyType == "JsonReference" { // Special case for OpenAPI
-					code.Print("info, err := p.%s.ResolveReferences(root)", propertyType)
-					code.Print("if err != nil {")
-					code.Print("  return nil, err")
-					code.Print("} else if info != nil {")
-					code.Print("  n, err := New%s(info, nil)", typeName)
-					code.Print("  if err != nil {")
-					code.Print("    return nil, err")
-					code.Print("  } else if n != nil {")
-					code.Print("    *m = *n")
-					code.Print("    return nil, nil")
-					code.Print("  }")
-					code.Print("}")
-				} else {
-					code.Print("_, err := p.%s.ResolveReferences(root)", propertyType)
-					code.Print("if err != nil {")
-					code.Print("	return nil, err")
-					code.Print("}")
+		typeModel := domain.TypeModels[typeName]
+		if typeModel.OneOfWrapper {
+			// call ResolveReferences on whatever is in the Oneof.
+			for _, propertyModel := range typeModel.Properties {
+				propertyType := propertyModel.Type
+				_, typeFound := domain.TypeModels[propertyType]
+				if !typeFound {
+					continue
 				}
-				code.Print("}")
-				code.Print("}")
-			}
-		}
-	} else {
-		for _, propertyModel := range typeModel.Properties {
-			propertyName := propertyModel.Name
-			var displayName = propertyName
-			if displayName == "$ref" {
-				displayName = "_ref"
-			}
-			if displayName == "$schema" {
-				displayName = "_schema"
+				code.Block("{", "}", func() {
+					code.Print("p, ok := m.Oneof.(*%s_%s)", typeName, propertyType)
+					code.Block("if ok {", "}", func() {
+						if propertyType == "JsonReference" { // Special case for OpenAPI
+							code.Print("info, err := p.%s.ResolveReferencesWithContext(root, context)", propertyType)
+							code.Block("if err != nil {", "} else if info != nil {", func() {
+								code.Print("return nil, err")
+							})
+							code.Indented(func() {
+								code.Print("n, err := New%s(info, nil)", typeName)
+								code.Block("if err != nil {", "} else if n != nil {", func() {
+									code.Print("return nil, err")
+								})
+								code.Indented(func() {
+									code.Print("*m = *n")
+									code.Print("return nil, nil")
+								})
+								code.Print("}")
+							})
+							code.Print("}")
+						} else {
+							code.Print("_, err := p.%s.ResolveReferencesWithContext(root, context)", propertyType)
+							code.Block("if err != nil {", "}", func() {
+								code.Print("return nil, err")
+							})
+						}
+					})
+				})
 			}
-			displayName = camelCaseToSnakeCase(displayName)
-
-			fieldName := strings.Title(propertyName)
-			if propertyName == "$ref" {
-				fieldName = "XRef"
-				code.Print("if m.XRef != \"\" {")
-				//code.Print("log.Printf(\"%s reference to resolve %%+v\", m.XRef)", typeName)
-				code.Print("info, err := compiler.ReadInfoForRef(root, m.XRef)")
-
-				code.Print("if err != nil {")
-				code.Print("	return nil, err")
-				code.Print("}")
-				//code.Print("log.Printf(\"%%+v\", info)")
-
-				if len(typeModel.Properties) > 1 {
-					code.Print("if info != nil {")
-					code.Print("  replacement, err := New%s(info, nil)", typeName)
-					code.Print("  if err == nil {")
-					code.Print("    *m = *replacement")
-					code.Print("    return m.ResolveReferences(root)")
-					code.Print("  }")
-					code.Print("}")
+		} else {
+			for _, propertyModel := range typeModel.Properties {
+				propertyName := propertyModel.Name
+				code.SetSource(compilerSource("generateResolveReferencesMethodsForType", typeName, propertyName))
+				fieldName := strings.Title(propertyName)
+				if strings.HasPrefix(propertyName, "$") {
+					fieldName = propertyModel.FieldName()
 				}
-
-				code.Print("return info, nil")
-				code.Print("}")
-			}
-
-			if !propertyModel.Repeated {
-				propertyType := propertyModel.Type
-				typeModel, typeFound := domain.TypeModels[propertyType]
-				if typeFound && !typeModel.IsPair {
-					code.Print("if m.%s != nil {", fieldName)
-					code.Print("    _, err := m.%s.ResolveReferences(root)", fieldName)
-					code.Print("    if err != nil {")
-					code.Print("       errors = append(errors, err)")
-					code.Print("    }")
-					code.Print("}")
+				if propertyName == "$ref" {
+					code.Block("if m.XRef != \"\" {", "}", func() {
+						code.Print("info, err := compiler.ReadInfoForRefWithContext(root, m.XRef, nil, context)")
+						code.Block("if err != nil {", "}", func() {
+							code.Print("return nil, err")
+						})
+						if len(typeModel.Properties) > 1 {
+							code.Block("if info != nil {", "}", func() {
+								code.Print("replacement, err := New%s(info, nil)", typeName)
+								code.Block("if err == nil {", "}", func() {
+									code.Print("proto.Reset(m)")
+									code.Print("proto.Merge(m, replacement)")
+									code.Print("return m.ResolveReferencesWithContext(root, context)")
+								})
+							})
+						}
+						code.Print("return info, nil")
+					})
 				}
-			} else {
+
 				propertyType := propertyModel.Type
-				_, typeFound := domain.TypeModels[propertyType]
-				if typeFound {
-					code.Print("for _, item := range m.%s {", fieldName)
-					code.Print("if item != nil {")
-					code.Print("  _, err := item.ResolveReferences(root)")
-					code.Print("  if err != nil {")
-					code.Print("     errors = append(errors, err)")
-					code.Print("  }")
-					code.Print("}")
-					code.Print("}")
+				propertyTypeModel, typeFound := domain.TypeModels[propertyType]
+				if !propertyModel.Repeated {
+					if typeFound && !propertyTypeModel.IsPair {
+						code.Block(fmt.Sprintf("if m.%s != nil {", fieldName), "}", func() {
+							code.Print("_, err := m.%s.ResolveReferencesWithContext(root, context)", fieldName)
+							code.Block("if err != nil {", "}", func() {
+								code.Print("errors = append(errors, err)")
+							})
+						})
+					}
+				} else if typeFound {
+					code.Block(fmt.Sprintf("for _, item := range m.%s {", fieldName), "}", func() {
+						code.Block("if item != nil {", "}", func() {
+							code.Print("_, err := item.ResolveReferencesWithContext(root, context)")
+							code.Block("if err != nil {", "}", func() {
+								code.Print("errors = append(errors, err)")
+							})
+						})
+					})
 				}
-
 			}
+			code.SetSource(compilerSource("generateResolveReferencesMethodsForType", typeName, ""))
 		}
-	}
-	code.Print("  return nil, compiler.NewErrorGroupOrNil(errors)")
-	code.Print("}\n")
+		code.Print("return nil, compiler.NewErrorGroupOrNil(errors)")
+	})
 }
 
 // ToRawInfo() methods
-func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeName string) {
-	code.Print("// ToRawInfo returns a description of %s suitable for JSON or YAML export.", typeName)
-	code.Print("func (m *%s) ToRawInfo() *yaml.Node {", typeName)
+func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeName string, n rawInfoNodes) {
+	if n.builder {
+		code.Print("// rawInfoFor%s returns a description of %s suitable for JSON or YAML export.", typeName, typeName)
+		code.Print("func rawInfoFor%s(m *%s, b *compiler.NodeBuilder) *yaml.Node {", typeName, typeName)
+	} else {
+		code.Print("// ToRawInfo returns a description of %s suitable for JSON or YAML export.", typeName)
+		code.Print("func (m *%s) ToRawInfo() *yaml.Node {", typeName)
+	}
 	typeModel := domain.TypeModels[typeName]
 	if typeName == "Any" {
 		code.Print("var err error")
@@ -720,9 +781,9 @@ func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeNam
 		code.Print("	}")
 		code.Print("	return &node")
 		code.Print("}")
-		code.Print("return compiler.NewNullNode()")
+		code.Print("return %s", n.null())
 	} else if typeName == "StringArray" {
-		code.Print("return compiler.NewSequenceNodeForStringArray(m.Value)")
+		code.Print("return %s", n.array("String", "m.Value"))
 	} else if typeModel.OneOfWrapper {
 		code.Print("// ONE OF WRAPPER")
 		code.Print("// %s", typeModel.Name)
@@ -730,28 +791,29 @@ func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeNam
 			code.Print("// %+v", *item)
 			if item.Type == "float" {
 				code.Print("if v%d, ok := m.GetOneof().(*%s_Number); ok {", i, typeName)
-				code.Print("return compiler.NewScalarNodeForFloat(v%d.Number)", i)
+				code.Print("return %s", n.scalar("Float", fmt.Sprintf("v%d.Number", i)))
 				code.Print("}")
 			} else if item.Type == "bool" {
 				code.Print("if v%d, ok := m.GetOneof().(*%s_Boolean); ok {", i, typeName)
-				code.Print("return compiler.NewScalarNodeForBool(v%d.Boolean)", i)
+				code.Print("return %s", n.scalar("Bool", fmt.Sprintf("v%d.Boolean", i)))
 				code.Print("}")
 			} else if item.Type == "string" {
 				code.Print("if v%d, ok := m.GetOneof().(*%s_String_); ok {", i, typeName)
-				code.Print("return compiler.NewScalarNodeForString(v%d.String_)", i)
+				code.Print("return %s", n.scalar("String", fmt.Sprintf("v%d.String_", i)))
 				code.Print("}")
 			} else {
 				code.Print("v%d := m.Get%s()", i, item.Type)
 				code.Print("if v%d != nil {", i)
-				code.Print(" return v%d.ToRawInfo()", i)
+				code.Print(" return %s", n.child(fmt.Sprintf("v%d", i), item.Type))
 				code.Print("}")
 			}
 		}
-		code.Print("return compiler.NewNullNode()")
+		code.Print("return %s", n.null())
 	} else {
-		code.Print("info := compiler.NewMappingNode()")
-		code.Print("if m == nil {return info}")
+		code.Print("if m == nil {return %s}", n.mapping(""))
+		code.Print("info := %s", n.mapping(domain.mappingCapacityForType(typeModel, n.builder)))
 		for _, propertyModel := range typeModel.Properties {
+			code.SetSource(compilerSource("generateToRawInfoMethodForType", typeName, propertyModel.Name))
 			isRequired := typeModel.IsRequired(propertyModel.Name)
 			switch propertyModel.Type {
 			case "string":
@@ -759,13 +821,13 @@ func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeNam
 				if !propertyModel.Repeated {
 					code.PrintIf(isRequired, "// always include this required field.")
 					code.PrintIf(!isRequired, "if m.%s != \"\" {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(m.%s))", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("String", "m."+propertyModel.FieldName()))
 					code.PrintIf(!isRequired, "}")
 				} else {
 					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-					code.Print("info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.%s))", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.array("String", "m."+propertyModel.FieldName()))
 					code.Print("}")
 				}
 			case "bool":
@@ -773,41 +835,51 @@ func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeNam
 				if !propertyModel.Repeated {
 					code.PrintIf(isRequired, "// always include this required field.")
 					code.PrintIf(!isRequired, "if m.%s != false {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForBool(m.%s))", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("Bool", "m."+propertyModel.FieldName()))
 					code.PrintIf(!isRequired, "}")
 				} else {
 					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-					code.Print("info.Content = append(info.Content, compiler.NewSequenceNodeForBoolArray(m.%s))", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.array("Bool", "m."+propertyModel.FieldName()))
 					code.Print("}")
 				}
 			case "int":
 				propertyName := propertyModel.Name
-				if !propertyModel.Repeated {
+				if domain.IsOptional(typeModel, propertyModel) {
+					code.Print("if m.%s != nil {", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("Int", "*m."+propertyModel.FieldName()))
+					code.Print("}")
+				} else if !propertyModel.Repeated {
 					code.PrintIf(isRequired, "// always include this required field.")
 					code.PrintIf(!isRequired, "if m.%s != 0 {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForInt(m.%s))", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("Int", "m."+propertyModel.FieldName()))
 					code.PrintIf(!isRequired, "}")
 				} else {
 					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-					code.Print("info.Content = append(info.Content, compiler.NewSequenceNodeForIntArray(m.%s))", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.array("Int", "m."+propertyModel.FieldName()))
 					code.Print("}")
 				}
 			case "float":
 				propertyName := propertyModel.Name
-				if !propertyModel.Repeated {
+				if domain.IsOptional(typeModel, propertyModel) {
+					code.Print("if m.%s != nil {", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("Float", "*m."+propertyModel.FieldName()))
+					code.Print("}")
+				} else if !propertyModel.Repeated {
 					code.PrintIf(isRequired, "// always include this required field.")
 					code.PrintIf(!isRequired, "if m.%s != 0.0 {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForFloat(m.%s))", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("Float", "m."+propertyModel.FieldName()))
 					code.PrintIf(!isRequired, "}")
 				} else {
 					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-					code.Print("info.Content = append(info.Content, compiler.NewSequenceNodeForFloatArray(m.%s))", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+					code.Print("info.Content = append(info.Content, %s)", n.array("Float", "m."+propertyModel.FieldName()))
 					code.Print("}")
 				}
 			default:
@@ -819,63 +891,176 @@ func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeNam
 					code.PrintIf(!isRequired, "if m.%s != nil {", propertyModel.FieldName())
 					if propertyModel.Type == "TypeItem" {
 						code.Print("if len(m.Type.Value) == 1 {")
-						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"type\"))")
-						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Type.Value[0]))")
+						code.Print("info.Content = append(info.Content, %s)", n.key("type"))
+						code.Print("info.Content = append(info.Content, %s)", n.scalar("String", "m.Type.Value[0]"))
 						code.Print("} else {")
-						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"type\"))")
-						code.Print("info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.Type.Value))")
+						code.Print("info.Content = append(info.Content, %s)", n.key("type"))
+						code.Print("info.Content = append(info.Content, %s)", n.array("String", "m.Type.Value"))
 						code.Print("}")
 					} else if propertyModel.Type == "ItemsItem" {
-						code.Print("items := compiler.NewSequenceNode()")
 						if domain.Version == "v2" {
+							code.Print("items := %s", n.sequence("len(m.Items.Schema)"))
 							code.Print("for _, item := range m.Items.Schema {")
+							code.Print("	items.Content = append(items.Content, %s)", n.child("item", "Schema"))
 						} else {
+							code.Print("items := %s", n.sequence("len(m.Items.SchemaOrReference)"))
 							code.Print("for _, item := range m.Items.SchemaOrReference {")
+							code.Print("	items.Content = append(items.Content, %s)", n.child("item", "SchemaOrReference"))
 						}
-						code.Print("	items.Content = append(items.Content, item.ToRawInfo())")
 						code.Print("}")
 						code.Print("if len(items.Content) == 1 {items = items.Content[0]}")
-						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"items\"))")
+						code.Print("info.Content = append(info.Content, %s)", n.key("items"))
 						code.Print("info.Content = append(info.Content, items)")
 					} else {
-						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
-						code.Print("info.Content = append(info.Content, m.%s.ToRawInfo())", propertyModel.FieldName())
+						code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
+						code.Print("info.Content = append(info.Content, %s)", n.child("m."+propertyModel.FieldName(), propertyModel.Type))
 					}
 					code.PrintIf(!isRequired, "}")
 				} else if propertyModel.MapType == "string" {
 					code.Print("if m.%s != nil {", propertyModel.FieldName())
 					code.Print("for _, item := range m.%s {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))")
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Value))")
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("String", "item.Name"))
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("String", "item.Value"))
 					code.Print("}")
 					code.Print("}")
 				} else if propertyModel.MapType != "" {
 					code.Print("if m.%s != nil {", propertyModel.FieldName())
 					code.Print("for _, item := range m.%s {", propertyModel.FieldName())
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))")
-					code.Print("info.Content = append(info.Content, item.Value.ToRawInfo())")
+					code.Print("info.Content = append(info.Content, %s)", n.scalar("String", "item.Name"))
+					code.Print("info.Content = append(info.Content, %s)", n.child("item.Value", propertyModel.MapType))
 					code.Print("}")
 					code.Print("}")
 				} else {
 					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("items := compiler.NewSequenceNode()")
+					code.Print("items := %s", n.sequence("len(m."+propertyModel.FieldName()+")"))
 					code.Print("for _, item := range m.%s {", propertyModel.FieldName())
-					code.Print("items.Content = append(items.Content, item.ToRawInfo())")
+					code.Print("items.Content = append(items.Content, %s)", n.child("item", propertyModel.Type))
 					code.Print("}")
-					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
+					code.Print("info.Content = append(info.Content, %s)", n.key(propertyName))
 					code.Print("info.Content = append(info.Content, items)")
 					code.Print("}")
 				}
 			}
 		}
+		code.SetSource(compilerSource("generateToRawInfoMethodForType", typeName, ""))
 		code.Print("return info")
 	}
 	code.Print("}\n")
 }
 
-func (domain *Domain) generateConstantVariables(code *printer.Code, regexPatterns *patternNames) {
+// rawInfoNodes returns expressions that create the nodes of a raw info
+// description. ToRawInfo methods create each node with the compiler
+// helpers. Models that are aliases of types in another package can't have
+// methods, so they only get functions that create nodes with a NodeBuilder.
+// Other models get both, and their packages' RawInfo functions use the
+// NodeBuilder to describe whole documents.
+func (domain *Domain) rawInfoNodes() rawInfoNodes {
+	return rawInfoNodes{builder: domain.ModelsAreAliases}
+}
+
+type rawInfoNodes struct {
+	builder bool
+}
+
+func (n rawInfoNodes) key(name string) string {
+	if n.builder {
+		return fmt.Sprintf("b.Key(%q)", name)
+	}
+	return fmt.Sprintf("compiler.NewScalarNodeForString(%q)", name)
+}
+
+// scalar returns an expression for a node that holds a String, Bool, Int, or Float.
+func (n rawInfoNodes) scalar(kind string, value string) string {
+	if n.builder {
+		return fmt.Sprintf("b.%s(%s)", kind, value)
+	}
+	return fmt.Sprintf("compiler.NewScalarNodeFor%s(%s)", kind, value)
+}
+
+func (n rawInfoNodes) array(kind string, value string) string {
+	if n.builder {
+		return fmt.Sprintf("b.%sArray(%s)", kind, value)
+	}
+	return fmt.Sprintf("compiler.NewSequenceNodeFor%sArray(%s)", kind, value)
+}
+
+func (n rawInfoNodes) null() string {
+	if n.builder {
+		return "b.Null()"
+	}
+	return "compiler.NewNullNode()"
+}
+
+// mapping returns an expression for a mapping node with the given
+// capacity, or for an empty mapping node if capacity is empty.
+func (n rawInfoNodes) mapping(capacity string) string {
+	if n.builder {
+		if capacity == "" {
+			capacity = "0"
+		}
+		return fmt.Sprintf("b.Mapping(%s)", capacity)
+	}
+	if capacity == "" {
+		return "compiler.NewMappingNode()"
+	}
+	return fmt.Sprintf("compiler.NewMappingNodeWithCapacity(%s)", capacity)
+}
+
+func (n rawInfoNodes) sequence(capacity string) string {
+	if n.builder {
+		return fmt.Sprintf("b.Sequence(%s)", capacity)
+	}
+	return fmt.Sprintf("compiler.NewSequenceNodeWithCapacity(%s)", capacity)
+}
+
+// child returns an expression for the description of a value of a type.
+func (n rawInfoNodes) child(value string, typeName string) string {
+	if n.builder {
+		return fmt.Sprintf("rawInfoFor%s(%s, b)", typeName, value)
+	}
+	return fmt.Sprintf("%s.ToRawInfo()", value)
+}
+
+// maxReservedProperties is the largest number of properties that the
+// mapping nodes of rawInfoFor functions reserve room for.
+const maxReservedProperties = 8
+
+// Returns an expression for the number of keys and values that
+// ToRawInfo can add to the mapping node for a type. Allocating
+// Content with this capacity avoids growing it as nodes are added.
+// Mappings that are created with a NodeBuilder reserve less.
+func (domain *Domain) mappingCapacityForType(typeModel *TypeModel, builder bool) string {
+	fixed := 0
+	terms := make([]string, 0)
+	for _, propertyModel := range typeModel.Properties {
+		switch propertyModel.Type {
+		case "string", "bool", "int", "float":
+			fixed += 2
+		default:
+			if propertyModel.Name == "value" && propertyModel.Type != "Any" {
+				// not written
+			} else if propertyModel.Repeated && propertyModel.MapType != "" {
+				terms = append(terms, fmt.Sprintf("2*len(m.%s)", propertyModel.FieldName()))
+			} else {
+				fixed += 2
+			}
+		}
+	}
+	// Most objects have only a few of their properties. Contents allocated
+	// by a NodeBuilder share blocks, so their unused capacity is never
+	// released, and they reserve room for at most a few properties.
+	if builder && fixed > 2*maxReservedProperties {
+		fixed = 2 * maxReservedProperties
+	}
+	if fixed > 0 || len(terms) == 0 {
+		terms = append([]string{fmt.Sprintf("%d", fixed)}, terms...)
+	}
+	return strings.Join(terms, "+")
+}
+
+func (domain *Domain) generateConstantVariables(code *printer.Code, regexPatterns *patternNames, keySets map[string]string) {
 	names := regexPatterns.Names()
-	if len(names) == 0 {
+	if len(names) == 0 && len(keySets) == 0 {
 		return
 	}
 	var sortedNames []string
@@ -883,9 +1068,17 @@ func (domain *Domain) generateConstantVariables(code *printer.Code, regexPattern
 		sortedNames = append(sortedNames, name)
 	}
 	sort.Strings(sortedNames)
-	code.Print("var (")
-	for _, name := range sortedNames {
-		code.Print("%s = regexp.MustCompile(\"%s\")", name, escapeSlashes(names[name]))
+	var sortedKeySetNames []string
+	for name := range keySets {
+		sortedKeySetNames = append(sortedKeySetNames, name)
 	}
-	code.Print(")\n")
+	sort.Strings(sortedKeySetNames)
+	code.Block("var (", ")\n", func() {
+		for _, name := range sortedNames {
+			code.Print("%s = regexp.MustCompile(\"%s\")", name, escapeSlashes(names[name]))
+		}
+		for _, name := range sortedKeySetNames {
+			code.Print("%s = %s", name, keySets[name])
+		}
+	})
 }
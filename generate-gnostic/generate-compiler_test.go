@@ -0,0 +1,72 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/okkoye/gnostic/jsonschema"
+	"github.com/okkoye/gnostic/printer"
+)
+
+func TestCompilerTemplatesMatchGenerator(t *testing.T) {
+	templates, err := loadCompilerTemplates("templates")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, version := range []string{"v2", "v3", "v31"} {
+		filename := map[string]string{
+			"v2":  "../openapiv2/openapi-2.0.json",
+			"v3":  "../openapiv3/openapi-3.1.json",
+			"v31": "../openapiv31/openapi-3.1.json",
+		}[version]
+		schema, err := jsonschema.NewSchemaFromFile(filename)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		schema.ResolveRefs()
+		schema.ResolveAllOfs()
+		domain := NewDomain(schema, version)
+		domain.OptionalNumbers = version == "v31"
+		domain.PropertyNameOverrides = map[string]string{
+			"PathItem":      "Path",
+			"ResponseValue": "ResponseCode",
+		}
+		if err := domain.Build(); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		expected, err := domain.GenerateCompiler("openapi_"+version, License, nil)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		domain.Templates = templates
+		actual, err := domain.GenerateCompiler("openapi_"+version, License, nil)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		// indentation differs until the code is formatted
+		expected, err = printer.FormatGo("expected.go", expected)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		actual, err = printer.FormatGo("actual.go", actual)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if actual != expected {
+			t.Errorf("Templates for %s generated different code", version)
+		}
+	}
+}
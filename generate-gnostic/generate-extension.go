@@ -18,19 +18,23 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"regexp"
-	"runtime"
 	"sort"
 	"strings"
+	"text/template"
+
+	"golang.org/x/tools/imports"
 
 	"github.com/okkoye/gnostic/compiler"
 	"github.com/okkoye/gnostic/jsonschema"
 	"github.com/okkoye/gnostic/printer"
 )
 
+// gnosticModule is the module path used in the imports of generated extension handlers.
+const gnosticModule = "github.com/okkoye/gnostic"
+
 var protoOptionsForExtensions = []ProtoOption{
 	ProtoOption{
 		Name:  "java_multiple_files",
@@ -64,6 +68,18 @@ const additionalCompilerCodeWithMain = "" +
 	"	gnostic_extension_v1.Main(handleExtension)\n" +
 	"}\n"
 
+const additionalCompilerCodeForLibrary = "" +
+	"// HandleExtension compiles the extensions described in %s.\n" +
+	"// It returns false for extensions that it does not handle.\n" +
+	"func HandleExtension(extensionName string, yamlInput string) (bool, proto.Message, error) {\n" +
+	"      switch extensionName {\n" +
+	"      // All supported extensions\n" +
+	"      %s\n" +
+	"      default:\n" +
+	"        return false, nil, nil\n" +
+	"       }\n" +
+	"}\n"
+
 const caseStringForObjectTypes = "\n" +
 	"case \"%s\":\n" +
 	"var info yaml.Node\n" +
@@ -72,7 +88,7 @@ const caseStringForObjectTypes = "\n" +
 	"  return true, nil, err\n" +
 	"}\n" +
 	"info = *info.Content[0]\n" +
-	"newObject, err := %s.New%s(&info, compiler.NewContext(\"$root\", &info, nil))\n" +
+	"newObject, err := %s(&info, compiler.NewContext(\"$root\", &info, nil))\n" +
 	"return true, newObject, err"
 
 const caseStringForWrapperTypes = "\n" +
@@ -89,6 +105,38 @@ const caseStringForWrapperTypes = "\n" +
 	"newObject := &wrapperspb.%s{Value: v}\n" +
 	"return true, newObject, nil"
 
+// A single value is read as an array with one item.
+const caseStringForArrayTypes = "\n" +
+	"case \"%s\":\n" +
+	"var info yaml.Node\n" +
+	"err := yaml.Unmarshal([]byte(yamlInput), &info)\n" +
+	"if err != nil {\n" +
+	"  return true, nil, err\n" +
+	"}\n" +
+	"info = *info.Content[0]\n" +
+	"items := []*yaml.Node{&info}\n" +
+	"if info.Kind == yaml.SequenceNode {\n" +
+	"  items = info.Content\n" +
+	"}\n" +
+	"newObject := &%s{}\n" +
+	"for _, item := range items {\n" +
+	"%s" +
+	"  newObject.Value = append(newObject.Value, value)\n" +
+	"}\n" +
+	"return true, newObject, nil"
+
+const arrayItemStringForObjectTypes = "" +
+	"  value, err := %s(item, compiler.NewContext(\"$root\", item, nil))\n" +
+	"  if err != nil {\n" +
+	"    return true, nil, err\n" +
+	"  }\n"
+
+const arrayItemStringForStrings = "" +
+	"  value, ok := compiler.StringForScalarNode(item)\n" +
+	"  if !ok {\n" +
+	"    return true, nil, nil\n" +
+	"  }\n"
+
 // generateMainFile generates the main program for an extension.
 func generateMainFile(packageName string, license string, codeBody string, imports []string) string {
 	code := &printer.Code{}
@@ -148,20 +196,88 @@ var supportedPrimitiveTypeInfos = map[string]primitiveTypeInfo{
 
 type generatedTypeInfo struct {
 	schemaName string
+	typeName   string
 	// if this is not nil, the schema should be treataed as a primitive type.
 	optionalPrimitiveTypeInfo *primitiveTypeInfo
+	// if this is not empty, the schema is an array of objects of this type or of strings.
+	arrayItemTypeName string
+}
+
+// arrayItemTypeName returns the type of the items of an array schema, which
+// can be strings or objects that are described by other definitions.
+func (domain *Domain) arrayItemTypeName(schema *jsonschema.Schema) (string, bool) {
+	if schema.Items == nil || schema.Items.Schema == nil {
+		return "", false
+	}
+	if schema.Items.Schema.Ref != nil {
+		return domain.typeNameForReference(*schema.Items.Schema.Ref), true
+	}
+	if schema.Items.Schema.TypeIs("string") {
+		return "string", true
+	}
+	return "", false
+}
+
+// definitionReferences adds the names of the definitions that a schema refers to.
+func definitionReferences(schema *jsonschema.Schema, names map[string]bool) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != nil && strings.HasPrefix(*schema.Ref, "#/definitions/") {
+		names[strings.TrimPrefix(*schema.Ref, "#/definitions/")] = true
+	}
+	subschemas := make([]*jsonschema.Schema, 0)
+	for _, namedSchemas := range []*[]*jsonschema.NamedSchema{schema.Properties, schema.PatternProperties} {
+		if namedSchemas != nil {
+			for _, pair := range *namedSchemas {
+				subschemas = append(subschemas, pair.Value)
+			}
+		}
+	}
+	for _, schemas := range []*[]*jsonschema.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		if schemas != nil {
+			subschemas = append(subschemas, *schemas...)
+		}
+	}
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			subschemas = append(subschemas, schema.Items.Schema)
+		}
+		if schema.Items.SchemaArray != nil {
+			subschemas = append(subschemas, *schema.Items.SchemaArray...)
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		subschemas = append(subschemas, schema.AdditionalProperties.Schema)
+	}
+	for _, subschema := range subschemas {
+		definitionReferences(subschema, names)
+	}
 }
 
 // generateExtension generates the implementation of an extension.
-func generateExtension(schemaFile string, outDir string) error {
+// When goPackage is empty, it writes an extension handler program to a
+// directory in outDir. Otherwise it writes a Go package with the import path
+// goPackage to outDir, and the package's HandleExtension function can be
+// called directly by programs that compile API descriptions. If templates
+// are given, they generate the methods of its types.
+func generateExtension(schemaFile string, outDir string, goPackage string, templates *template.Template) error {
 	outFileBaseName := getBaseFileNameWithoutExt(schemaFile)
 	extensionNameWithoutXDashPrefix := outFileBaseName[len("x-"):]
-	outDir = path.Join(outDir, "gnostic-x-"+extensionNameWithoutXDashPrefix)
 	protoPackage := toProtoPackageName(extensionNameWithoutXDashPrefix)
 	protoPackageName := strings.ToLower(protoPackage)
 	goPackageName := protoPackageName
-
-	protoOutDirectory := outDir + "/" + "proto"
+	goPackageOption := "./;" + strings.ToLower(protoPackage)
+
+	protoOutDirectory := outDir
+	if goPackage == "" {
+		outDir = path.Join(outDir, "gnostic-x-"+extensionNameWithoutXDashPrefix)
+		protoOutDirectory = outDir + "/" + "proto"
+	} else {
+		goPackageName = path.Base(goPackage)
+		goPackageOption = goPackage + ";" + goPackageName
+		protoPackageName = "gnostic.extensions." + protoPackageName
+	}
 	var err error
 
 	baseSchema, err := jsonschema.NewBaseSchema()
@@ -175,11 +291,20 @@ func generateExtension(schemaFile string, outDir string) error {
 	if err != nil {
 		return err
 	}
+	// definitions that are referenced by other definitions describe parts of
+	// extensions, so they don't need ids.
+	referencedDefinitions := make(map[string]bool)
+	if openapiSchema.Definitions != nil {
+		for _, pair := range *(openapiSchema.Definitions) {
+			definitionReferences(pair.Value, referencedDefinitions)
+		}
+	}
 	openapiSchema.ResolveRefs()
 	openapiSchema.ResolveAllOfs()
 
 	// build a simplified model of the types described by the schema
 	cc := NewDomain(openapiSchema, "v2") // TODO fix for OpenAPI v3
+	cc.Templates = templates
 
 	// create a type for each object defined in the schema
 	extensionNameToMessageName := make(map[string]generatedTypeInfo)
@@ -193,23 +318,45 @@ func generateExtension(schemaFile string, outDir string) error {
 		for _, pair := range *(cc.Schema.Definitions) {
 			definitionName := pair.Name
 			definitionSchema := pair.Value
+			typeName := cc.TypeNameForStub(definitionName)
 			// ensure the id field is set
 			if definitionSchema.ID == nil || len(*(definitionSchema.ID)) == 0 {
-				schemaErrors = append(schemaErrors,
-					fmt.Errorf("schema %s has no 'id' field, which must match the "+
-						"name of the OpenAPI extension that the schema represents",
-						definitionName))
+				if !referencedDefinitions[definitionName] {
+					schemaErrors = append(schemaErrors,
+						fmt.Errorf("schema %s has no 'id' field, which must match the "+
+							"name of the OpenAPI extension that the schema represents",
+							definitionName))
+				}
 			} else {
 				if _, ok := extensionNameToMessageName[*(definitionSchema.ID)]; ok {
 					schemaErrors = append(schemaErrors,
 						fmt.Errorf("schema %s and %s have the same 'id' field value",
 							definitionName, extensionNameToMessageName[*(definitionSchema.ID)].schemaName))
 				} else if (definitionSchema.Type == nil) || (*definitionSchema.Type.String == "object") {
-					extensionNameToMessageName[*(definitionSchema.ID)] = generatedTypeInfo{schemaName: definitionName}
+					extensionNameToMessageName[*(definitionSchema.ID)] = generatedTypeInfo{schemaName: definitionName, typeName: typeName}
+				} else if *definitionSchema.Type.String == "array" {
+					if itemTypeName, ok := cc.arrayItemTypeName(definitionSchema); ok {
+						extensionNameToMessageName[*(definitionSchema.ID)] = generatedTypeInfo{schemaName: definitionName, typeName: typeName, arrayItemTypeName: itemTypeName}
+						// the array is represented by a message with a repeated field
+						typeModel := NewTypeModel()
+						typeModel.Name = typeName
+						if definitionSchema.Description != nil {
+							typeModel.Description = *definitionSchema.Description
+						}
+						valueProperty := NewTypePropertyWithNameAndType("value", itemTypeName)
+						valueProperty.Repeated = true
+						typeModel.addProperty(valueProperty)
+						cc.TypeModels[typeName] = typeModel
+					} else {
+						schemaErrors = append(schemaErrors,
+							fmt.Errorf("Schema %s is an array with items that are not supported. "+
+								"Items must be strings or references to object definitions.\n",
+								definitionName))
+					}
 				} else {
 					// this is a primitive type
 					if val, ok := supportedPrimitiveTypeInfos[*definitionSchema.Type.String]; ok {
-						extensionNameToMessageName[*(definitionSchema.ID)] = generatedTypeInfo{schemaName: definitionName, optionalPrimitiveTypeInfo: &val}
+						extensionNameToMessageName[*(definitionSchema.ID)] = generatedTypeInfo{schemaName: definitionName, typeName: typeName, optionalPrimitiveTypeInfo: &val}
 					} else {
 						schemaErrors = append(schemaErrors,
 							fmt.Errorf("Schema %s has type '%s' which is "+
@@ -220,7 +367,6 @@ func generateExtension(schemaFile string, outDir string) error {
 					}
 				}
 			}
-			typeName := cc.TypeNameForStub(definitionName)
 			typeModel := cc.BuildTypeForDefinition(typeName, definitionName, definitionSchema)
 			if typeModel != nil {
 				cc.TypeModels[typeName] = typeModel
@@ -231,6 +377,8 @@ func generateExtension(schemaFile string, outDir string) error {
 		// error has been reported.
 		return compiler.NewErrorGroupOrNil(schemaErrors)
 	}
+	// create the types of nested objects and maps
+	cc.buildRequestedTypes()
 
 	err = os.MkdirAll(outDir, os.ModePerm)
 	if err != nil {
@@ -254,7 +402,7 @@ func generateExtension(schemaFile string, outDir string) error {
 		},
 		ProtoOption{
 			Name:    "go_package",
-			Value:   "./;" + strings.ToLower(protoPackage),
+			Value:   goPackageOption,
 			Comment: "// The Go package path.",
 		},
 	)
@@ -268,29 +416,38 @@ func generateExtension(schemaFile string, outDir string) error {
 	}
 
 	// generate the compiler
-	compiler := cc.GenerateCompiler(goPackageName, License, []string{
+	compiler, err := cc.GenerateCompiler(goPackageName, License, []string{
 		"fmt",
 		"regexp",
 		"strings",
-		"github.com/google/gnostic/compiler",
+		gnosticModule + "/compiler",
 		"gopkg.in/yaml.v3",
 	})
-	goFilename := path.Join(protoOutDirectory, outFileBaseName+".go")
-	err = ioutil.WriteFile(goFilename, []byte(compiler), 0644)
 	if err != nil {
 		return err
 	}
-	err = exec.Command(runtime.GOROOT()+"/bin/gofmt", "-w", goFilename).Run()
+	goFilename := path.Join(protoOutDirectory, outFileBaseName+".go")
+	// remove the imports that the compiler doesn't use
+	imports.LocalPrefix = gnosticModule
+	data, err := imports.Process(goFilename, []byte(compiler), &imports.Options{
+		TabWidth:  8,
+		TabIndent: true,
+		Comments:  true,
+		Fragment:  true,
+	})
+	if err != nil {
+		return printer.NewSourceError(goFilename, compiler, err)
+	}
+	err = ioutil.WriteFile(goFilename, data, 0644)
 	if err != nil {
 		return err
 	}
 
-	// generate the main file.
-
-	// TODO: This path is currently fixed to the location of the samples.
-	//       Can we make it relative, perhaps with an option or by generating
-	//       a go.mod file for the generated extension handler?
-	outDirRelativeToPackageRoot := "github.com/google/gnostic/extensions/sample/" + outDir
+	// generate the extension handler.
+	qualifier := goPackageName + "."
+	if goPackage != "" {
+		qualifier = ""
+	}
 
 	var extensionNameKeys []string
 	for k := range extensionNameToMessageName {
@@ -301,11 +458,19 @@ func generateExtension(schemaFile string, outDir string) error {
 	wrapperTypeIncluded := false
 	var cases string
 	for _, extensionName := range extensionNameKeys {
-		if extensionNameToMessageName[extensionName].optionalPrimitiveTypeInfo == nil {
+		if itemTypeName := extensionNameToMessageName[extensionName].arrayItemTypeName; itemTypeName != "" {
+			itemString := arrayItemStringForStrings
+			if itemTypeName != "string" {
+				itemString = fmt.Sprintf(arrayItemStringForObjectTypes, qualifier+"New"+itemTypeName)
+			}
+			cases += fmt.Sprintf(caseStringForArrayTypes,
+				extensionName,
+				qualifier+extensionNameToMessageName[extensionName].typeName,
+				itemString)
+		} else if extensionNameToMessageName[extensionName].optionalPrimitiveTypeInfo == nil {
 			cases += fmt.Sprintf(caseStringForObjectTypes,
 				extensionName,
-				goPackageName,
-				extensionNameToMessageName[extensionName].schemaName)
+				qualifier+"New"+extensionNameToMessageName[extensionName].typeName)
 		} else {
 			wrapperTypeIncluded = true
 			cases += fmt.Sprintf(caseStringForWrapperTypes,
@@ -315,10 +480,34 @@ func generateExtension(schemaFile string, outDir string) error {
 		}
 
 	}
+	if goPackage != "" {
+		imports := []string{
+			gnosticModule + "/compiler",
+			"google.golang.org/protobuf/proto",
+			"gopkg.in/yaml.v3",
+		}
+		if wrapperTypeIncluded {
+			imports = append(imports, "google.golang.org/protobuf/types/known/wrapperspb")
+		}
+		handlerCode := fmt.Sprintf(additionalCompilerCodeForLibrary, filepath.Base(schemaFile), cases)
+		handler := generateMainFile(goPackageName, License, handlerCode, imports)
+		handlerFileName := path.Join(outDir, "handler.go")
+		handler, err = printer.FormatGo(handlerFileName, handler)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(handlerFileName, []byte(handler), 0644)
+	}
+
+	// TODO: This path is currently fixed to the location of the samples.
+	//       Can we make it relative, perhaps with an option or by generating
+	//       a go.mod file for the generated extension handler?
+	outDirRelativeToPackageRoot := gnosticModule + "/extensions/sample/" + outDir
+
 	extMainCode := fmt.Sprintf(additionalCompilerCodeWithMain, cases)
 	imports := []string{
-		"github.com/google/gnostic/extensions",
-		"github.com/google/gnostic/compiler",
+		gnosticModule + "/extensions",
+		gnosticModule + "/compiler",
 		"google.golang.org/protobuf/proto",
 		"gopkg.in/yaml.v3",
 		outDirRelativeToPackageRoot + "/" + "proto",
@@ -328,19 +517,18 @@ func generateExtension(schemaFile string, outDir string) error {
 	}
 	main := generateMainFile("main", License, extMainCode, imports)
 	mainFileName := path.Join(outDir, "main.go")
-	err = ioutil.WriteFile(mainFileName, []byte(main), 0644)
+	main, err = printer.FormatGo(mainFileName, main)
 	if err != nil {
 		return err
 	}
-
-	// format the compiler
-	return exec.Command(runtime.GOROOT()+"/bin/gofmt", "-w", mainFileName).Run()
+	return ioutil.WriteFile(mainFileName, []byte(main), 0644)
 }
 
-func generateExtensions() error {
+func generateExtensions(templates *template.Template) error {
 
 	outDir := ""
 	schemaFile := ""
+	goPackage := ""
 
 	extParamRegex, _ := regexp.Compile("--(.+)=(.+)")
 
@@ -355,6 +543,10 @@ func generateExtensions() error {
 			switch flagName {
 			case "out_dir":
 				outDir = flagValue
+			case "go_package":
+				goPackage = flagValue
+			case "templates":
+				// templates are loaded by main
 			default:
 				fmt.Printf("Unknown option: %s.\n%s\n", arg, usage())
 				os.Exit(-1)
@@ -382,5 +574,5 @@ func generateExtensions() error {
 		os.Exit(-1)
 	}
 
-	return generateExtension(schemaFile, outDir)
+	return generateExtension(schemaFile, outDir, goPackage, templates)
 }
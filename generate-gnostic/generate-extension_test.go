@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path"
 	"testing"
 )
 
@@ -56,3 +57,33 @@ func TestErrorExtensionGeneratorNameCollision(t *testing.T) {
 		os.Remove(outputFile)
 	}
 }
+
+func TestWellKnownExtensionsAreUpToDate(t *testing.T) {
+	for _, family := range []string{"amazon/x-amazon-apigateway", "azure/x-ms", "google/x-google", "kubernetes/x-kubernetes"} {
+		directory := path.Join("../extensions/wellknown", path.Dir(family))
+		outDir, err := ioutil.TempDir("", "wellknown")
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		defer os.RemoveAll(outDir)
+		err = generateExtension(path.Join("../extensions/wellknown", family+".json"), outDir,
+			"github.com/okkoye/gnostic/extensions/wellknown/"+path.Dir(family), nil)
+		if err != nil {
+			t.Fatalf("%s: %+v", family, err)
+		}
+		base := path.Base(family)
+		for _, filename := range []string{base + ".proto", base + ".go", "handler.go"} {
+			generated, err := ioutil.ReadFile(path.Join(outDir, filename))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			checkedIn, err := ioutil.ReadFile(path.Join(directory, filename))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if string(generated) != string(checkedIn) {
+				t.Errorf("%s is out of date, regenerate it with generate-gnostic", path.Join(directory, filename))
+			}
+		}
+	}
+}
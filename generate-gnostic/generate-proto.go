@@ -31,15 +31,16 @@ type ProtoOption struct {
 // generateProto produces the contents of a .proto file corresponding to the domain.
 func (domain *Domain) generateProto(packageName string, license string, options []ProtoOption, imports []string) string {
 	code := &printer.Code{}
+	code.SetLanguage(printer.Proto)
 	code.Print(license)
 	code.Print("// THIS FILE IS AUTOMATICALLY GENERATED.")
 	code.Print()
-	code.Print("syntax = \"proto3\";")
+	code.Print("syntax = %s;", code.Quote("proto3"))
 	code.Print()
 	code.Print("package " + packageName + ";")
 	for _, importString := range imports {
 		code.Print()
-		code.Print("import \"" + importString + "\";")
+		code.Print("import %s;", code.Quote(importString))
 	}
 	code.Print()
 
@@ -66,16 +67,16 @@ func (domain *Domain) generateOptionDeclaration(code *printer.Code, option Proto
 	if option.Value == "true" || option.Value == "false" {
 		line += option.Value
 	} else {
-		line += "\"" + option.Value + "\""
+		line += code.Quote(option.Value)
 	}
 	line += ";\n"
-	code.Print(line)
+	code.Print("%s", line)
 }
 
 func (domain *Domain) generateProtoMessage(code *printer.Code, typeName string) {
 	typeModel := domain.TypeModels[typeName]
 	if typeModel.Description != "" {
-		code.Print("// %s", typeModel.Description)
+		code.Comment(typeModel.Description)
 	}
 	code.Print("message %s {", typeName)
 	code.Indent()
@@ -87,7 +88,7 @@ func (domain *Domain) generateProtoMessage(code *printer.Code, typeName string)
 	for _, propertyModel := range typeModel.Properties {
 		// print a leading comment if available
 		if propertyModel.Description != "" {
-			code.Print("// %s", propertyModel.Description)
+			code.Comment(propertyModel.Description)
 		}
 		// adjust the property type to a valid type name
 		propertyType := propertyModel.Type
@@ -103,11 +104,8 @@ func (domain *Domain) generateProtoMessage(code *printer.Code, typeName string)
 		// adjust the display name to a valid identifier
 		propertyName := propertyModel.Name
 		var displayName = propertyName
-		if displayName == "$ref" {
-			displayName = "_ref"
-		}
-		if displayName == "$schema" {
-			displayName = "_schema"
+		if strings.HasPrefix(displayName, "$") {
+			displayName = "_" + displayName[1:]
 		}
 		displayName = camelCaseToSnakeCase(displayName)
 		// assign a field number to the property
@@ -116,6 +114,8 @@ func (domain *Domain) generateProtoMessage(code *printer.Code, typeName string)
 		var line = fmt.Sprintf("%s %s = %d;", propertyType, displayName, fieldNumber)
 		if propertyModel.Repeated {
 			line = "repeated " + line
+		} else if domain.IsOptional(typeModel, propertyModel) {
+			line = "optional " + line
 		}
 		code.Print(line)
 	}
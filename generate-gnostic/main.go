@@ -25,10 +25,12 @@ import (
 	"os"
 	"path"
 	"strings"
+	"text/template"
 
 	"golang.org/x/tools/imports"
 
 	"github.com/okkoye/gnostic/jsonschema"
+	"github.com/okkoye/gnostic/printer"
 )
 
 // License is the software license applied to generated code.
@@ -47,7 +49,7 @@ const License = "" +
 	"// See the License for the specific language governing permissions and\n" +
 	"// limitations under the License.\n"
 
-func protoOptions(directoryName string, packageName string) []ProtoOption {
+func protoOptions(goPackagePath string, packageName string) []ProtoOption {
 	return []ProtoOption{
 		ProtoOption{
 			Name:  "java_multiple_files",
@@ -84,13 +86,13 @@ func protoOptions(directoryName string, packageName string) []ProtoOption {
 
 		ProtoOption{
 			Name:    "go_package",
-			Value:   "./" + directoryName + ";" + packageName,
+			Value:   goPackagePath + ";" + packageName,
 			Comment: "// The Go package name.",
 		},
 	}
 }
 
-func generateOpenAPIModel(version string) error {
+func generateOpenAPIModel(version string, templates *template.Template, writeSourceMap bool) error {
 	var input string
 	var filename string
 	var protoPackageName string
@@ -107,6 +109,11 @@ func generateOpenAPIModel(version string) error {
 		filename = "OpenAPIv3"
 		protoPackageName = "openapi.v3"
 		directoryName = "openapiv3"
+	case "v31":
+		input = "openapi-3.1.json"
+		filename = "OpenAPIv31"
+		protoPackageName = "openapi.v31"
+		directoryName = "openapiv31"
 	case "discovery":
 		input = "discovery.json"
 		filename = "discovery"
@@ -118,6 +125,13 @@ func generateOpenAPIModel(version string) error {
 
 	goPackageName := strings.Replace(protoPackageName, ".", "_", -1)
 
+	// The other models are compiled into gnostic-models and aliased here,
+	// so their descriptions keep the relative package that they always had.
+	goPackagePath := "./" + directoryName
+	if version == "v31" {
+		goPackagePath = "github.com/okkoye/gnostic/" + directoryName
+	}
+
 	projectRoot := "./"
 
 	baseSchema, err := jsonschema.NewBaseSchema()
@@ -136,6 +150,13 @@ func generateOpenAPIModel(version string) error {
 
 	// build a simplified model of the types described by the schema
 	cc := NewDomain(openapiSchema, version)
+	// These models are defined in github.com/google/gnostic-models.
+	cc.ModelsAreAliases = version == "v2" || version == "v3" || version == "discovery"
+	// The other models have RawInfo functions like the models of gnostic-models.
+	cc.RawInfoFunctions = !cc.ModelsAreAliases
+	// JSON Schema 2020-12 keywords like "minimum: 0" have meaningful zero values.
+	cc.OptionalNumbers = version == "v31"
+	cc.Templates = templates
 	// generators will map these patterns to the associated property names
 	// these pattern names are a bit of a hack until we find a more automated way to obtain them
 
@@ -148,7 +169,7 @@ func generateOpenAPIModel(version string) error {
 			"PathItem":      "Path",
 			"ResponseValue": "ResponseCode",
 		}
-	case "v3":
+	case "v3", "v31":
 		cc.TypeNameOverrides = map[string]string{
 			"SpecificationExtension": "Any",
 		}
@@ -181,7 +202,7 @@ func generateOpenAPIModel(version string) error {
 	// generate the protocol buffer description
 	log.Printf("Generating protocol buffer description")
 	proto := cc.generateProto(protoPackageName, License,
-		protoOptions(directoryName, goPackageName), []string{"google/protobuf/any.proto"})
+		protoOptions(goPackagePath, goPackageName), []string{"google/protobuf/any.proto"})
 	protoFileName := projectRoot + directoryName + "/" + filename + ".proto"
 	err = ioutil.WriteFile(protoFileName, []byte(proto), 0644)
 	if err != nil {
@@ -193,16 +214,20 @@ func generateOpenAPIModel(version string) error {
 		"gopkg.in/yaml.v3",
 		"strings",
 		"regexp",
-		"github.com/google/gnostic/compiler",
+		"google.golang.org/protobuf/proto",
+		"github.com/okkoye/gnostic/compiler",
 	}
 	// generate the compiler
 	log.Printf("Generating compiler support code")
-	compiler := cc.GenerateCompiler(goPackageName, License, packageImports)
+	compiler, sourceMap, err := cc.GenerateCompilerWithSourceMap(goPackageName, License, packageImports)
+	if err != nil {
+		return err
+	}
 	goFileName := projectRoot + directoryName + "/" + filename + ".go"
 
 	// format the compiler
 	log.Printf("Formatting compiler support code")
-	imports.LocalPrefix = "github.com/google/gnostic"
+	imports.LocalPrefix = "github.com/okkoye/gnostic"
 	data, err := imports.Process(goFileName, []byte(compiler), &imports.Options{
 		TabWidth:  8,
 		TabIndent: true,
@@ -210,7 +235,21 @@ func generateOpenAPIModel(version string) error {
 		Fragment:  true,
 	})
 	if err != nil {
-		return err
+		return printer.NewSourceError(goFileName, compiler, err)
+	}
+
+	if writeSourceMap {
+		log.Printf("Writing source map")
+		sourceMap = sourceMap.Remap(compiler, string(data))
+		sourceMap.File = filename + ".go"
+		bytes, err := sourceMap.Marshal()
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(goFileName+".map", bytes, 0644)
+		if err != nil {
+			return err
+		}
 	}
 
 	return ioutil.WriteFile(goFileName, []byte(data), 0644)
@@ -228,17 +267,36 @@ Options:
     Generate Protocol Buffer representation and support code for OpenAPI v3
     Files are read from and written to appropriate locations in the gnostic
     project directory.
+  --v31
+    Generate Protocol Buffer representation and support code for OpenAPI
+    v3.1, which describes schemas with JSON Schema 2020-12 and has webhooks.
+    Files are read from and written to appropriate locations in the gnostic
+    project directory.
+  --templates=DIRECTORY
+    Generate the ResolveReferences and ToRawInfo methods of each type with
+    the templates in DIRECTORY (usually generate-gnostic/templates) instead
+    of the built-in code generator. Templates are not used for the OpenAPI
+    and Discovery models, which are aliases of gnostic-models types.
+  --source-map
+    Also write a source map that describes the generator function and the
+    type or property that produced each line of the support code. It is
+    written next to the support code with a ".map" suffix.
   --extension EXTENSION_SCHEMA [EXTENSIONOPTIONS]
     Generate a gnostic extension that reads a set of OpenAPI extensions.
     EXTENSION_SCHEMA is the json schema for the OpenAPI extensions to be
     supported.
     EXTENSION_OPTIONS
       --out_dir=PATH: Location for writing extension models and support code.
+      --go_package=IMPORT_PATH: Write a Go package with this import path
+        instead of a handler program. Its HandleExtension function can be
+        called by programs that compile OpenAPI descriptions.
 `, path.Base(os.Args[0]))
 }
 
 func main() {
 	var openapiVersion = ""
+	var templatesDirectory = ""
+	var writeSourceMap = false
 	var shouldGenerateExtensions = false
 
 	for i, arg := range os.Args {
@@ -249,8 +307,14 @@ func main() {
 			openapiVersion = "v2"
 		} else if arg == "--v3" {
 			openapiVersion = "v3"
+		} else if arg == "--v31" {
+			openapiVersion = "v31"
 		} else if arg == "--discovery" {
 			openapiVersion = "discovery"
+		} else if arg == "--source-map" {
+			writeSourceMap = true
+		} else if strings.HasPrefix(arg, "--templates=") {
+			templatesDirectory = strings.TrimPrefix(arg, "--templates=")
 		} else if arg == "--extension" {
 			shouldGenerateExtensions = true
 			break
@@ -260,13 +324,23 @@ func main() {
 		}
 	}
 
+	var templates *template.Template
+	if templatesDirectory != "" {
+		var err error
+		templates, err = loadCompilerTemplates(templatesDirectory)
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+	}
+
 	if openapiVersion != "" {
-		err := generateOpenAPIModel(openapiVersion)
+		err := generateOpenAPIModel(openapiVersion, templates, writeSourceMap)
 		if err != nil {
 			fmt.Printf("%+v\n", err)
 		}
 	} else if shouldGenerateExtensions {
-		err := generateExtensions()
+		err := generateExtensions(templates)
 		if err != nil {
 			fmt.Printf("%+v\n", err)
 		}
@@ -0,0 +1,100 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Names of the templates that can replace the code.Print calls that
+// generate methods for each type.
+const (
+	resolveReferencesTemplate = "resolve-references"
+	toRawInfoTemplate         = "to-raw-info"
+)
+
+// compilerTemplateData is passed to the templates that generate methods
+// for a type.
+type compilerTemplateData struct {
+	Domain    *Domain
+	TypeName  string
+	TypeModel *TypeModel
+}
+
+// HasType returns true if a type is modeled in the domain.
+func (data *compilerTemplateData) HasType(typeName string) bool {
+	_, ok := data.Domain.TypeModels[typeName]
+	return ok
+}
+
+// IsMessageType returns true if a type is modeled in the domain and
+// isn't a name-value pair.
+func (data *compilerTemplateData) IsMessageType(typeName string) bool {
+	typeModel, ok := data.Domain.TypeModels[typeName]
+	return ok && !typeModel.IsPair
+}
+
+// ReferenceFieldName returns the field name that ResolveReferences uses
+// for a property.
+func (data *compilerTemplateData) ReferenceFieldName(property *TypeProperty) string {
+	if strings.HasPrefix(property.Name, "$") {
+		return property.FieldName()
+	}
+	return strings.Title(property.Name)
+}
+
+// Optional returns true if a property of the type is an optional field.
+func (data *compilerTemplateData) Optional(property *TypeProperty) bool {
+	return data.Domain.IsOptional(data.TypeModel, property)
+}
+
+// MappingCapacity returns an expression for the capacity of the mapping
+// node that ToRawInfo creates.
+func (data *compilerTemplateData) MappingCapacity() string {
+	return data.Domain.mappingCapacityForType(data.TypeModel, false)
+}
+
+var compilerTemplateFuncs = template.FuncMap{
+	"deref": func(property *TypeProperty) TypeProperty {
+		return *property
+	},
+	// scalarName returns the name used in compiler functions for scalar
+	// types, or an empty string for other types.
+	"scalarName": func(typeName string) string {
+		switch typeName {
+		case "string", "bool", "int", "float":
+			return strings.Title(typeName)
+		}
+		return ""
+	},
+	"zeroValue": func(typeName string) string {
+		switch typeName {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "float":
+			return "0.0"
+		}
+		return "0"
+	},
+}
+
+// loadCompilerTemplates reads the *.tmpl files in a directory.
+func loadCompilerTemplates(directory string) (*template.Template, error) {
+	return template.New("compiler").Funcs(compilerTemplateFuncs).ParseGlob(filepath.Join(directory, "*.tmpl"))
+}
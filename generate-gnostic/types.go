@@ -79,8 +79,9 @@ func NewTypePropertyWithNameTypeAndPattern(name string, typeName string, pattern
 // FieldName returns the message field name to use for a property.
 func (typeProperty *TypeProperty) FieldName() string {
 	propertyName := typeProperty.Name
-	if propertyName == "$ref" {
-		return "XRef"
+	if strings.HasPrefix(propertyName, "$") {
+		// keywords like $ref are in fields like _ref, which Go names XRef
+		return "X" + strings.Title(snakeCaseToCamelCase(propertyName[1:]))
 	}
 	return strings.Title(snakeCaseToCamelCase(propertyName))
 }
@@ -39,6 +39,18 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		err := serve(os.Args[2:])
+		if err != nil {
+			if _, ok := err.(*lib.UsageError); ok {
+				fmt.Fprintf(os.Stdout, "%s\n%s\n", err.Error(), serveUsage)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			}
+			os.Exit(-1)
+		}
+		return
+	}
 	// To simplify testing, Gnostic is implemented in an embeddable library.
 	g := lib.NewGnostic(os.Args)
 	err := g.Main()
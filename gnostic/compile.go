@@ -0,0 +1,281 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gnostic compiles API descriptions into the protocol buffer
+// models used by the gnostic tool, so that Go programs can read
+// descriptions the way that the tool does.
+package gnostic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/okkoye/gnostic/compiler"
+	discovery_v1 "github.com/okkoye/gnostic/discovery"
+	"github.com/okkoye/gnostic/lib"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+	openapi_v31 "github.com/okkoye/gnostic/openapiv31"
+)
+
+// Options control how descriptions are read, compiled, and written.
+// They are shared with the gnostic command, which sets them from its
+// command-line options.
+type Options = lib.Options
+
+// An Option sets a field of an Options struct.
+type Option = lib.Option
+
+// NewOptions returns Options with the specified options set.
+var NewOptions = lib.NewOptions
+
+// WithReferenceResolution resolves references in OpenAPI descriptions.
+var WithReferenceResolution = lib.WithReferenceResolution
+
+// WithExtensions calls the named extension plugins to compile vendor extensions.
+var WithExtensions = lib.WithExtensions
+
+// An ExtensionHandlerFunc compiles the value of a specification extension.
+type ExtensionHandlerFunc = lib.ExtensionHandlerFunc
+
+// WithExtensionHandler compiles the extensions with a name with a Go
+// function. Handlers can also be added with Options.RegisterExtensionHandler.
+var WithExtensionHandler = lib.WithExtensionHandler
+
+// WithExtensionsStripped removes extensions with names that match
+// patterns from written documents, or all extensions if there are no patterns.
+var WithExtensionsStripped = lib.WithExtensionsStripped
+
+// WithExtensionsKept keeps extensions with names that match patterns in
+// written documents and removes the others.
+var WithExtensionsKept = lib.WithExtensionsKept
+
+// WithRoundTripVerification checks that compiled documents can be written
+// without losing the values of their sources.
+var WithRoundTripVerification = lib.WithRoundTripVerification
+
+// WithCacheDirectory saves compiled descriptions in a cache directory.
+var WithCacheDirectory = lib.WithCacheDirectory
+
+// WithSourceContents compiles the contents of a source description instead
+// of reading the source.
+var WithSourceContents = lib.WithSourceContents
+
+// WithFetcher reads sources and referenced files that are named with URLs
+// with a fetcher, like a compiler.HTTPFetcher.
+var WithFetcher = lib.WithFetcher
+
+// WithReferenceRoot only reads sources and the files that they refer to
+// if they are in a directory.
+var WithReferenceRoot = lib.WithReferenceRoot
+
+// WithURLsAllowed reads sources and referenced files that are named with
+// URLs when a reference root is set.
+var WithURLsAllowed = lib.WithURLsAllowed
+
+// WithPlainStrings reads unquoted values as strings where strings are
+// expected.
+var WithPlainStrings = lib.WithPlainStrings
+
+// WithUnicodeNormalization normalizes the keys of descriptions and
+// reports keys that mix scripts or that look like other keys.
+var WithUnicodeNormalization = lib.WithUnicodeNormalization
+
+// WithLenientCompilation accepts descriptions with problems.
+var WithLenientCompilation = lib.WithLenientCompilation
+
+// WithErrorFormat writes errors in a format, "text" or "json".
+var WithErrorFormat = lib.WithErrorFormat
+
+// WithOutput writes compiled documents in a format to a path.
+var WithOutput = lib.WithOutput
+
+// A Diagnostic describes a problem found in a description.
+type Diagnostic struct {
+	Message string
+	// Path describes the location of the problem in the compiled model,
+	// such as $root.paths./pets.get. It is empty for problems that are
+	// not found at a location in the description.
+	Path   string
+	Line   int // zero if the location is unknown
+	Column int // zero if the location is unknown
+}
+
+// Format returns a Diagnostic in the format of the gnostic tool's errors,
+// which begin with the name of the source and the location of the
+// problem, like "petstore.yaml:3:3: $root.info has invalid property: x".
+func (d Diagnostic) Format(source string) string {
+	report := compiler.ErrorReport{Path: d.Path, Line: d.Line, Column: d.Column, Severity: compiler.SeverityError, Message: d.Message}
+	return report.Format(source)
+}
+
+// String returns a Diagnostic with its line, column, and path.
+func (d Diagnostic) String() string {
+	s := d.Message
+	if d.Path != "" {
+		s = d.Path + " " + s
+	}
+	if d.Line != 0 {
+		s = fmt.Sprintf("[%d,%d] %s", d.Line, d.Column, s)
+	}
+	return s
+}
+
+// Metadata describes a compiled description.
+type Metadata struct {
+	// Source is the filename or URL of the description.
+	Source string
+	// Format is one of the lib.SourceFormat constants.
+	Format int
+	// Version is the version of the specification that the description
+	// conforms to, such as 2.0, 3.0.3, or 3.1.0.
+	Version string
+}
+
+// A Result is a compiled description.
+type Result struct {
+	Metadata
+	// Document is the compiled model. If the description has problems,
+	// it contains the parts of the description that could be compiled.
+	Document proto.Message
+	// Diagnostics describe the problems found in the description.
+	Diagnostics []Diagnostic
+	// Annotations are recorded when the description is compiled. They
+	// hold what the compiled model can't, like explicit nulls, and they
+	// are needed to write the document as it was read.
+	Annotations *compiler.Annotations
+}
+
+// OpenAPIv2 returns the compiled document if it is an OpenAPI v2 document.
+func (r *Result) OpenAPIv2() *openapi_v2.Document {
+	document, _ := r.Document.(*openapi_v2.Document)
+	return document
+}
+
+// OpenAPIv3 returns the compiled document if it is an OpenAPI v3 document.
+func (r *Result) OpenAPIv3() *openapi_v3.Document {
+	document, _ := r.Document.(*openapi_v3.Document)
+	return document
+}
+
+// OpenAPIv31 returns the compiled document if it is an OpenAPI v3.1
+// document.
+func (r *Result) OpenAPIv31() *openapi_v31.Document {
+	document, _ := r.Document.(*openapi_v31.Document)
+	return document
+}
+
+// Discovery returns the compiled document if it is a Discovery document.
+func (r *Result) Discovery() *discovery_v1.Document {
+	document, _ := r.Document.(*discovery_v1.Document)
+	return document
+}
+
+// ExplicitNulls returns the JSON pointers of the fields that were set to
+// null in the description. Compiled documents hold empty values for
+// these fields, like they do for absent fields, and they are written as
+// nulls.
+func (r *Result) ExplicitNulls() []string {
+	return r.Annotations.ExplicitNulls()
+}
+
+// ReferenceSiblings returns the JSON pointers of the keywords beside
+// references other than summary and description in OpenAPI 3.1
+// descriptions. Compiled references don't hold these keywords, and they
+// are written with the references.
+func (r *Result) ReferenceSiblings() []string {
+	if r.Annotations == nil {
+		return nil
+	}
+	var pointers []string
+	for _, sibling := range r.Annotations.ReferenceSiblings {
+		pointers = append(pointers, sibling.Pointer)
+	}
+	return pointers
+}
+
+// Compile reads and compiles the description at source, which is a
+// filename or URL. Files with .json and .yaml extensions are compiled
+// and files with a .pb extension are read as binary protocol buffers.
+//
+// If the description has problems, Compile returns a Result that
+// describes them along with an error that lists them, or with no error if
+// options.Lenient is set. Compiled documents are written in the output
+// formats that have paths in options unless they have problems and
+// options.Lenient is not set. Compile returns only an error if the
+// description can't be read or its format can't be determined, or if ctx
+// is done before the compilation finishes. The source and the files that
+// it refers to aren't read after ctx is done, and documents aren't written.
+func Compile(ctx context.Context, source string, options *Options) (*Result, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	// Read leniently so that documents with problems are returned and
+	// their problems can be reported as diagnostics.
+	readOptions := *options
+	readOptions.Lenient = true
+	document, annotations, format, err := lib.ReadAnnotatedDocument(ctx, source, &readOptions)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if document == nil {
+		return nil, err
+	}
+	result := &Result{
+		Metadata:    Metadata{Source: source, Format: format, Version: documentVersion(document)},
+		Document:    document,
+		Diagnostics: appendDiagnostics(nil, err),
+		Annotations: annotations,
+	}
+	if len(result.Diagnostics) == 0 || options.Lenient {
+		if err := lib.WriteDocumentWithAnnotations(document, annotations, format, source, options); err != nil {
+			return result, err
+		}
+	}
+	if len(result.Diagnostics) > 0 && !options.Lenient {
+		messages := make([]string, len(result.Diagnostics))
+		for i, diagnostic := range result.Diagnostics {
+			messages[i] = diagnostic.String()
+		}
+		return result, errors.New(strings.Join(messages, "\n"))
+	}
+	return result, nil
+}
+
+// Append the diagnostics reported by an error, which may be a group.
+func appendDiagnostics(diagnostics []Diagnostic, err error) []Diagnostic {
+	for _, report := range compiler.ErrorReports(err) {
+		diagnostics = append(diagnostics, Diagnostic{Message: report.Message, Path: report.Path, Line: report.Line, Column: report.Column})
+	}
+	return diagnostics
+}
+
+// Return the specification version of a compiled document.
+func documentVersion(document proto.Message) string {
+	switch document := document.(type) {
+	case *openapi_v2.Document:
+		return document.GetSwagger()
+	case *openapi_v3.Document:
+		return document.GetOpenapi()
+	case *openapi_v31.Document:
+		return document.GetOpenapi()
+	case *discovery_v1.Document:
+		return document.GetDiscoveryVersion()
+	}
+	return ""
+}
@@ -0,0 +1,247 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/lib"
+)
+
+func TestCompile(t *testing.T) {
+	result, err := Compile(context.Background(), "../examples/v3.0/yaml/petstore.yaml", nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if result.Format != lib.SourceFormatOpenAPI3 || result.Version != "3.0" {
+		t.Errorf("unexpected metadata %+v", result.Metadata)
+	}
+	if result.OpenAPIv3() == nil || result.OpenAPIv2() != nil {
+		t.Errorf("expected an OpenAPI v3 document")
+	}
+	if len(result.Diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics %+v", result.Diagnostics)
+	}
+
+	result, err = Compile(context.Background(), "../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml", NewOptions(WithReferenceResolution()))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if result.OpenAPIv2().Paths.Path[0].Value.Get.Parameters[0].GetJsonReference() != nil {
+		t.Errorf("expected references to be resolved")
+	}
+
+	result, err = Compile(context.Background(), "../examples/v3.1/yaml/petstore.yaml", nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if result.Format != lib.SourceFormatOpenAPI31 || result.Version != "3.1.0" || FormatOf(result.Document) != lib.SourceFormatOpenAPI31 {
+		t.Errorf("unexpected metadata %+v", result.Metadata)
+	}
+	if result.OpenAPIv31() == nil || result.OpenAPIv3() != nil {
+		t.Errorf("expected an OpenAPI v3.1 document")
+	}
+}
+
+func TestCompileDiagnostics(t *testing.T) {
+	result, err := Compile(context.Background(), "../examples/errors/petstore-badproperties.yaml", nil)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if result == nil || result.OpenAPIv2() == nil {
+		t.Fatalf("expected a partially-compiled document")
+	}
+	if result.OpenAPIv2().Info.Title != "Swagger Petstore" {
+		t.Errorf("unexpected title %s", result.OpenAPIv2().Info.Title)
+	}
+	expected := Diagnostic{Message: "is missing required property: version", Path: "$root.info", Line: 3, Column: 3}
+	if len(result.Diagnostics) != 4 || result.Diagnostics[0] != expected {
+		t.Errorf("unexpected diagnostics %+v", result.Diagnostics)
+	}
+	if !strings.HasPrefix(err.Error(), "[3,3] $root.info is missing required property: version\n") {
+		t.Errorf("unexpected error %s", err)
+	}
+	if s := expected.Format("petstore.yaml"); s != "petstore.yaml:3:3: $root.info is missing required property: version" {
+		t.Errorf("unexpected format %s", s)
+	}
+
+	result, err = Compile(context.Background(), "../examples/errors/petstore-unresolvedrefs.yaml", &Options{ResolveReferences: true})
+	if err == nil || len(result.Diagnostics) != 2 || result.Diagnostics[0].Message != "could not resolve #/definitions/Pet" {
+		t.Errorf("unexpected result %+v %v", result, err)
+	}
+	if s := result.Diagnostics[0].Format("petstore.yaml"); s != "petstore.yaml:91:13: $root.definitions.Pets.items.$ref could not resolve #/definitions/Pet" {
+		t.Errorf("unexpected format %s", s)
+	}
+}
+
+const extensionsDescription = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+x-google-api-name: pets
+paths:
+  /pets:
+    get:
+      x-rate-limit:
+        requests: 100
+      x-invalid: true
+      responses:
+        "200":
+          description: ok
+`
+
+func TestCompileExtensionHandlers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "extensions")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "pets.yaml")
+	if err := ioutil.WriteFile(source, []byte(extensionsDescription), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	options := NewOptions(WithExtensionHandler("x-rate-limit", func(node yaml.Node) (proto.Message, error) {
+		if len(node.Content) != 2 || node.Content[0].Value != "requests" {
+			return nil, errors.New("expected requests")
+		}
+		requests, err := strconv.ParseInt(node.Content[1].Value, 10, 64)
+		return wrapperspb.Int64(requests), err
+	}))
+	// handlers registered by programs replace the built-in handlers
+	options.RegisterExtensionHandler("x-google-api-name", func(node yaml.Node) (proto.Message, error) {
+		return wrapperspb.String("handled " + node.Value), nil
+	})
+	options.RegisterExtensionHandler("x-invalid", func(node yaml.Node) (proto.Message, error) {
+		return nil, errors.New("is invalid")
+	})
+
+	result, err := Compile(context.Background(), source, options)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	expected := Diagnostic{Message: "is invalid", Path: "$root.paths./pets.get.x-invalid"}
+	if len(result.Diagnostics) != 1 || result.Diagnostics[0] != expected {
+		t.Errorf("unexpected diagnostics %+v", result.Diagnostics)
+	}
+	document := result.OpenAPIv3()
+	name := &wrapperspb.StringValue{}
+	if err := document.SpecificationExtension[0].Value.Value.UnmarshalTo(name); err != nil || name.Value != "handled pets" {
+		t.Errorf("unexpected x-google-api-name %v %v", name, err)
+	}
+	requests := &wrapperspb.Int64Value{}
+	operation := document.Paths.Path[0].Value.Get
+	if err := operation.SpecificationExtension[0].Value.Value.UnmarshalTo(requests); err != nil || requests.Value != 100 {
+		t.Errorf("unexpected x-rate-limit %v %v", requests, err)
+	}
+	if operation.SpecificationExtension[1].Value.Value != nil {
+		t.Errorf("expected x-invalid to be uncompiled")
+	}
+}
+
+func TestCompileCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Compile(ctx, "../examples/v3.0/yaml/petstore.yaml", nil); err != context.Canceled {
+		t.Errorf("expected the compilation to be canceled, got %v", err)
+	}
+}
+
+func TestCompileCanceledWhileReading(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source := "swagger: '2.0'\ninfo: {title: Pets, version: '1'}\npaths: {}\ndefinitions:\n  Name: {$ref: 'https://example.com/name.yaml'}\n"
+	fetched := false
+	options := NewOptions(
+		WithReferenceResolution(),
+		WithFetcher(compiler.FetcherFunc(func(fileurl string) ([]byte, error) {
+			if fileurl == "https://example.com/canceled.yaml" {
+				// Cancel the compilation after its source is read.
+				cancel()
+				return []byte(source), nil
+			}
+			fetched = true
+			return []byte("type: string\n"), nil
+		})))
+	if _, err := Compile(ctx, "https://example.com/canceled.yaml", options); err != context.Canceled {
+		t.Errorf("expected the compilation to be canceled, got %v", err)
+	}
+	if fetched {
+		t.Errorf("expected referenced files not to be read after the compilation was canceled")
+	}
+}
+
+func TestCompileOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "petstore.yaml")
+	options := NewOptions(WithLenientCompilation(), WithOutput("yaml", output))
+	result, err := Compile(context.Background(), "../examples/errors/petstore-badproperties.yaml", options)
+	if err != nil {
+		t.Fatalf("expected no error from a lenient compilation, got %v", err)
+	}
+	if len(result.Diagnostics) != 4 {
+		t.Errorf("unexpected diagnostics %+v", result.Diagnostics)
+	}
+	bytes, err := ioutil.ReadFile(output)
+	if err != nil || !strings.Contains(string(bytes), "title: Swagger Petstore") {
+		t.Errorf("expected the document to be written, got %s %v", string(bytes), err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	result, err := Compile(context.Background(), "../examples/discovery/discovery-v1.json", nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !CanConvert(result.Format, lib.SourceFormatOpenAPI3) || CanConvert(lib.SourceFormatOpenAPI3, lib.SourceFormatDiscovery) {
+		t.Errorf("unexpected conversions")
+	}
+	document, err := Convert(result.Document, lib.SourceFormatOpenAPI3)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if FormatOf(document) != lib.SourceFormatOpenAPI3 {
+		t.Errorf("expected an OpenAPI v3 document")
+	}
+	bytes, err := Encode(document, EncodingJSON)
+	if err != nil || !strings.HasPrefix(string(bytes), "{\n  \"openapi\": ") {
+		t.Errorf("unexpected encoding %s %v", string(bytes), err)
+	}
+	if _, err := Convert(document, lib.SourceFormatDiscovery); err == nil || err.Error() != "OpenAPI v3 documents can't be converted to Discovery" {
+		t.Errorf("unexpected error %v", err)
+	}
+	document, err = Convert(document, lib.SourceFormatOpenAPI2)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if FormatOf(document) != lib.SourceFormatOpenAPI2 {
+		t.Errorf("expected an OpenAPI v2 document")
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/conversions"
+	discovery_v1 "github.com/okkoye/gnostic/discovery"
+	"github.com/okkoye/gnostic/jsonwriter"
+	"github.com/okkoye/gnostic/lib"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+	openapi_v31 "github.com/okkoye/gnostic/openapiv31"
+)
+
+// Encodings of documents written by Encode.
+const (
+	EncodingYAML   = "yaml"
+	EncodingJSON   = "json"
+	EncodingBinary = "pb"   // a binary protocol buffer
+	EncodingText   = "text" // a text protocol buffer
+)
+
+// FormatOf returns the format of a compiled document as one of the
+// lib.SourceFormat constants.
+func FormatOf(document proto.Message) int {
+	switch document.(type) {
+	case *openapi_v2.Document:
+		return lib.SourceFormatOpenAPI2
+	case *openapi_v3.Document:
+		return lib.SourceFormatOpenAPI3
+	case *openapi_v31.Document:
+		return lib.SourceFormatOpenAPI31
+	case *discovery_v1.Document:
+		return lib.SourceFormatDiscovery
+	}
+	return lib.SourceFormatUnknown
+}
+
+var formatNames = map[int]string{
+	lib.SourceFormatUnknown:   "unknown",
+	lib.SourceFormatOpenAPI2:  "OpenAPI v2",
+	lib.SourceFormatOpenAPI3:  "OpenAPI v3",
+	lib.SourceFormatOpenAPI31: "OpenAPI v3.1",
+	lib.SourceFormatDiscovery: "Discovery",
+}
+
+// CanConvert returns true if documents can be converted from one format
+// to another. Documents can be converted to their own format, Discovery
+// documents can be converted to OpenAPI v2 and v3, and OpenAPI v2 and v3
+// documents can be converted to each other.
+func CanConvert(from, to int) bool {
+	openapi := func(format int) bool {
+		return format == lib.SourceFormatOpenAPI2 || format == lib.SourceFormatOpenAPI3
+	}
+	return from == to || ((from == lib.SourceFormatDiscovery || openapi(from)) && openapi(to))
+}
+
+// Convert returns a compiled document in another format. Conversions
+// between OpenAPI v2 and v3 can lose parts of documents; to find out what
+// was lost, use conversions.ConvertV2ToV3 and conversions.ConvertV3ToV2.
+func Convert(document proto.Message, format int) (proto.Message, error) {
+	from := FormatOf(document)
+	if !CanConvert(from, format) {
+		return nil, fmt.Errorf("%s documents can't be converted to %s", formatNames[from], formatNames[format])
+	}
+	if from == format {
+		return document, nil
+	}
+	var converted proto.Message
+	var err error
+	switch d := document.(type) {
+	case *openapi_v2.Document:
+		converted, _, err = conversions.ConvertV2ToV3(d)
+	case *openapi_v3.Document:
+		converted, _, err = conversions.ConvertV3ToV2(d)
+	case *discovery_v1.Document:
+		if format == lib.SourceFormatOpenAPI2 {
+			converted, err = conversions.OpenAPIv2(d)
+		} else {
+			converted, err = conversions.OpenAPIv3(d)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+// Encode writes a compiled document in one of the Encoding formats.
+func Encode(document proto.Message, encoding string) ([]byte, error) {
+	return EncodeWithAnnotations(document, nil, encoding)
+}
+
+// EncodeWithAnnotations writes a compiled document like Encode, using the
+// annotations that were recorded when it was compiled to write YAML and
+// JSON as they were read. Converted documents don't have annotations.
+func EncodeWithAnnotations(document proto.Message, annotations *compiler.Annotations, encoding string) ([]byte, error) {
+	switch encoding {
+	case EncodingBinary:
+		return proto.Marshal(document)
+	case EncodingText:
+		return []byte(proto.MarshalTextString(document)), nil
+	case EncodingYAML:
+		return yaml.Marshal(lib.DocumentNodeWithAnnotations(document, annotations, FormatOf(document)))
+	case EncodingJSON:
+		return jsonwriter.Marshal(lib.DocumentNodeWithAnnotations(document, annotations, FormatOf(document)))
+	}
+	return nil, fmt.Errorf("unknown encoding %q", encoding)
+}
@@ -0,0 +1,222 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gnostictest compares the outputs of gnostic and its plugins with
+// golden files, the reference outputs that are checked in with tests.
+//
+// When tests are run with the -update-golden flag, or with the
+// GNOSTIC_UPDATE_GOLDEN environment variable set to a nonempty value,
+// golden files are rewritten with the outputs of the tests instead:
+//
+//	go test ./... -update-golden
+//	GNOSTIC_UPDATE_GOLDEN=1 go test ./...
+//
+// The environment variable is useful when some of the packages that are
+// tested don't import this package and wouldn't accept the flag.
+package gnostictest
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/gnostic"
+	"github.com/okkoye/gnostic/lib"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "rewrite golden files with the outputs of tests")
+
+// Update returns true if golden files should be rewritten instead of compared.
+func Update() bool {
+	return *updateGolden || os.Getenv("GNOSTIC_UPDATE_GOLDEN") != ""
+}
+
+// Compare compares output with the contents of a golden file, or writes
+// output to the golden file if golden files are being updated. Differences
+// are reported as test failures.
+func Compare(t testing.TB, output []byte, goldenFile string) {
+	t.Helper()
+	if Update() {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0755); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := ioutil.WriteFile(goldenFile, output, 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return
+	}
+	golden, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("%+v (run with -update-golden to create it)", err)
+		return
+	}
+	if string(golden) != string(output) {
+		diff := cmp.Diff(strings.Split(string(golden), "\n"), strings.Split(string(output), "\n"))
+		t.Errorf("output differs from %s (-golden +output):\n%s", goldenFile, diff)
+	}
+}
+
+// CompareFile compares a file written by a test with a golden file.
+func CompareFile(t testing.TB, outputFile string, goldenFile string) {
+	t.Helper()
+	output, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	Compare(t, output, goldenFile)
+}
+
+// CompareDirectory compares the files written by a test in outputDir with
+// the golden files in goldenDir, such as the files written by a plugin.
+// Files that are missing from either directory are reported as failures.
+// When golden files are being updated, goldenDir is made a copy of outputDir.
+func CompareDirectory(t testing.TB, outputDir string, goldenDir string) {
+	t.Helper()
+	outputs := filesInDirectory(t, outputDir)
+	goldens := filesInDirectory(t, goldenDir)
+	if Update() {
+		for _, name := range goldens {
+			if !containsString(outputs, name) {
+				if err := os.Remove(filepath.Join(goldenDir, name)); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			}
+		}
+	} else {
+		for _, name := range goldens {
+			if !containsString(outputs, name) {
+				t.Errorf("%s was not written", filepath.Join(outputDir, name))
+			}
+		}
+	}
+	for _, name := range outputs {
+		if !Update() && !containsString(goldens, name) {
+			t.Errorf("%s has no golden file in %s", filepath.Join(outputDir, name), goldenDir)
+			continue
+		}
+		CompareFile(t, filepath.Join(outputDir, name), filepath.Join(goldenDir, name))
+	}
+}
+
+// Return the sorted names of the files in a directory and its
+// subdirectories, relative to the directory.
+func filesInDirectory(t testing.TB, dir string) []string {
+	t.Helper()
+	names := make([]string, 0)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return names
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile compiles a description with gnostic.Compile. The test fails if
+// the description can't be read; problems in the description are returned
+// as diagnostics in the result.
+func Compile(t testing.TB, source string, options ...gnostic.Option) *gnostic.Result {
+	t.Helper()
+	o := gnostic.NewOptions(options...)
+	o.Lenient = true
+	result, err := gnostic.Compile(context.Background(), source, o)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return result
+}
+
+// Output returns the output of the gnostic command for a compiled
+// description in a format that is named like the command's output options:
+// "pb", "text", "json", or "yaml" for the compiled document, or "errors"
+// for its problems.
+func Output(t testing.TB, result *gnostic.Result, format string) []byte {
+	t.Helper()
+	if format == "errors" {
+		lines := make([]string, len(result.Diagnostics))
+		for i, diagnostic := range result.Diagnostics {
+			lines[i] = diagnostic.Format(filepath.ToSlash(result.Source))
+		}
+		return []byte(strings.Join(lines, "\n"))
+	}
+	bytes, err := gnostic.EncodeWithAnnotations(result.Document, result.Annotations, format)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return bytes
+}
+
+// CompileAndCompare compiles a description and compares the output named
+// by the extension of the golden file (.pb, .text, .json, .yaml, or
+// .errors) with the golden file. Golden files for descriptions that have
+// problems or can't be read should be .errors files; for other formats,
+// problems are reported as failures.
+func CompileAndCompare(t testing.TB, source string, goldenFile string, options ...gnostic.Option) {
+	t.Helper()
+	format := strings.TrimPrefix(filepath.Ext(goldenFile), ".")
+	o := gnostic.NewOptions(options...)
+	o.Lenient = true
+	result, err := gnostic.Compile(context.Background(), source, o)
+	if err != nil {
+		if format != "errors" {
+			t.Fatalf("%+v", err)
+		}
+		Compare(t, []byte(compiler.FormatErrors(filepath.ToSlash(source), err)), goldenFile)
+		return
+	}
+	if format != "errors" && len(result.Diagnostics) > 0 {
+		for _, diagnostic := range result.Diagnostics {
+			t.Errorf("%s: %s", source, diagnostic)
+		}
+		return
+	}
+	Compare(t, Output(t, result, format), goldenFile)
+}
+
+// Run runs the gnostic command with arguments, such as a description and
+// plugin options, and returns its error. Use CompareFile or
+// CompareDirectory to check the files that it writes.
+func Run(t testing.TB, args ...string) error {
+	t.Helper()
+	return lib.NewGnostic(append([]string{"gnostic"}, args...)).Main()
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostictest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okkoye/gnostic/gnostic"
+)
+
+// recorder records the failures of a test that is expected to fail.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
+
+func withUpdate(f func()) {
+	*updateGolden = true
+	defer func() { *updateGolden = false }()
+	f()
+}
+
+func TestCompileAndCompare(t *testing.T) {
+	CompileAndCompare(t,
+		"../../examples/v2.0/json/petstore.json",
+		"../../testdata/v2.0/petstore.text",
+		gnostic.WithReferenceResolution())
+}
+
+func TestCompareErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostictest")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := "../../examples/errors/petstore-badproperties.yaml"
+	goldenFile := filepath.Join(dir, "petstore-badproperties.errors")
+	withUpdate(func() {
+		CompileAndCompare(t, source, goldenFile)
+	})
+	golden, err := ioutil.ReadFile("../../testdata/errors/petstore-badproperties.errors")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	Compare(t, []byte(strings.Replace(string(golden), "examples/", "../../examples/", -1)), goldenFile)
+
+	// A golden file for a document can't be used for a description with problems.
+	r := &recorder{TB: t}
+	CompileAndCompare(r, source, filepath.Join(dir, "petstore-badproperties.yaml"))
+	if len(r.failures) != 4 {
+		t.Errorf("unexpected failures %v", r.failures)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostictest")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	goldenFile := filepath.Join(dir, "golden", "output.txt")
+
+	r := &recorder{TB: t}
+	Compare(r, []byte("one\ntwo\n"), goldenFile)
+	if len(r.failures) != 1 {
+		t.Errorf("missing golden file was not reported: %v", r.failures)
+	}
+	withUpdate(func() {
+		Compare(t, []byte("one\ntwo\n"), goldenFile)
+	})
+	Compare(t, []byte("one\ntwo\n"), goldenFile)
+
+	r = &recorder{TB: t}
+	Compare(r, []byte("one\nthree\n"), goldenFile)
+	if len(r.failures) != 1 || !strings.Contains(r.failures[0], `"three"`) {
+		t.Errorf("difference was not reported: %v", r.failures)
+	}
+}
+
+func TestCompareDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostictest")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	outputDir := filepath.Join(dir, "output")
+	goldenDir := filepath.Join(dir, "golden")
+	write := func(name, text string) {
+		filename := filepath.Join(outputDir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	write("a.txt", "a")
+	write("b/c.txt", "c")
+	withUpdate(func() {
+		CompareDirectory(t, outputDir, goldenDir)
+	})
+	CompareDirectory(t, outputDir, goldenDir)
+
+	// Extra and missing files are reported.
+	write("d.txt", "d")
+	os.Remove(filepath.Join(outputDir, "a.txt"))
+	r := &recorder{TB: t}
+	CompareDirectory(r, outputDir, goldenDir)
+	if len(r.failures) != 2 {
+		t.Errorf("unexpected failures %v", r.failures)
+	}
+
+	// Updates remove golden files that are no longer written.
+	withUpdate(func() {
+		CompareDirectory(t, outputDir, goldenDir)
+	})
+	if _, err := os.Stat(filepath.Join(goldenDir, "a.txt")); !os.IsNotExist(err) {
+		t.Errorf("stale golden file was not removed")
+	}
+	CompareDirectory(t, outputDir, goldenDir)
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"io/ioutil"
 	"net/url"
 	"os"
 	"os/exec"
@@ -22,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/okkoye/gnostic/gnostic/gnostictest"
 	"github.com/okkoye/gnostic/lib"
 )
 
@@ -49,31 +51,19 @@ func testCompiler(t *testing.T, inputFile string, referenceFile string, expectEr
 	// remove any preexisting output files
 	os.Remove(outputFile)
 	os.Remove(errorsFile)
-	var err error
+	defer os.Remove(outputFile)
+	defer os.Remove(errorsFile)
 	// run the compiler
-	args := []string{
-		"gnostic",
+	gnostictest.Run(t,
 		inputFile,
-		"--" + outputFormat + "-out=.",
+		"--"+outputFormat+"-out=.",
 		"--errors-out=.",
-		"--resolve-refs"}
-	g := lib.NewGnostic(args)
-	err = g.Main()
+		"--resolve-refs")
 	// verify the output against a reference
-	var testFile string
 	if expectErrors {
-		testFile = errorsFile
-	} else {
-		testFile = outputFile
-	}
-	err = exec.Command("diff", testFile, referenceFile).Run()
-	if err != nil {
-		t.Logf("Diff failed: %+v", err)
-		t.FailNow()
+		gnostictest.CompareFile(t, errorsFile, referenceFile)
 	} else {
-		// if the test succeeded, clean up
-		os.Remove(outputFile)
-		os.Remove(errorsFile)
+		gnostictest.CompareFile(t, outputFile, referenceFile)
 	}
 }
 
@@ -275,6 +265,14 @@ func TestPetstoreJSON_30(t *testing.T) {
 		"testdata/v3.0/petstore.text")
 }
 
+// OpenAPI 3.1 tests
+
+func TestPetstoreYAML_31(t *testing.T) {
+	testNormal(t,
+		"examples/v3.1/yaml/petstore.yaml",
+		"testdata/v3.1/petstore.text")
+}
+
 // Test that empty required fields are exported.
 
 func TestEmptyRequiredFields_v2(t *testing.T) {
@@ -294,3 +292,44 @@ func TestDiscoveryJSON(t *testing.T) {
 		"examples/discovery/discovery-v1.json",
 		"testdata/discovery/discovery-v1.text")
 }
+
+// runGnostic runs gnostic with the given arguments and returns what it
+// writes to stdout.
+func runGnostic(t *testing.T, args ...string) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	output := make(chan []byte)
+	go func() {
+		bytes, _ := ioutil.ReadAll(r)
+		output <- bytes
+	}()
+	err = lib.NewGnostic(append([]string{"gnostic"}, args...)).Main()
+	os.Stdout = stdout
+	w.Close()
+	bytes := <-output
+	if err != nil {
+		t.Fatalf("gnostic %s failed: %+v", strings.Join(args, " "), err)
+	}
+	return string(bytes)
+}
+
+func TestQuery(t *testing.T) {
+	output := runGnostic(t, "query",
+		"examples/v3.0/yaml/petstore.yaml",
+		"$.paths[*].get.operationId")
+	if output != "listPets\nshowPetById\n" {
+		t.Errorf("unexpected query output: %q", output)
+	}
+}
+
+func TestStats(t *testing.T) {
+	output := runGnostic(t, "stats",
+		"examples/v2.0/yaml/petstore.yaml")
+	if !strings.HasPrefix(output, "paths:             2\nGET operations:    2\n") {
+		t.Errorf("unexpected stats output: %q", output)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fuzzing has helpers for the fuzz tests of gnostic's packages.
+package fuzzing
+
+import (
+	"io/ioutil"
+	"path/filepath"
+)
+
+// A Corpus is the seed corpus of a fuzz test, like a *testing.F.
+type Corpus interface {
+	Add(args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Helper()
+}
+
+// AddFiles adds the contents of the files that match a list of patterns
+// to a corpus.
+func AddFiles(f Corpus, patterns ...string) {
+	f.Helper()
+	for _, pattern := range patterns {
+		filenames, err := filepath.Glob(pattern)
+		if err != nil {
+			f.Fatalf("%+v", err)
+		}
+		for _, filename := range filenames {
+			b, err := ioutil.ReadFile(filename)
+			if err != nil {
+				f.Fatalf("%+v", err)
+			}
+			f.Add(b)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpath
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A Match is a node selected by a path along with its location, which is
+// written as a normalized path like $['paths']['/pets']['get'].
+type Match struct {
+	Node     *yaml.Node
+	Location string
+}
+
+// Select returns the nodes of a document that are selected by a path.
+func (path *Path) Select(document *yaml.Node) []*Match {
+	root := &Match{Node: rootNode(document), Location: "$"}
+	return evaluate(path.segments, []*Match{root}, root)
+}
+
+// Nodes returns the nodes of a document that are selected by a path.
+func (path *Path) Nodes(document *yaml.Node) []*yaml.Node {
+	matches := path.Select(document)
+	nodes := make([]*yaml.Node, len(matches))
+	for i, m := range matches {
+		nodes[i] = m.Node
+	}
+	return nodes
+}
+
+func rootNode(document *yaml.Node) *yaml.Node {
+	if document != nil && document.Kind == yaml.DocumentNode && len(document.Content) > 0 {
+		return document.Content[0]
+	}
+	return document
+}
+
+// resolve follows aliases to the nodes they refer to.
+func resolve(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+func evaluate(segments []*segment, matches []*Match, root *Match) []*Match {
+	for _, s := range segments {
+		results := make([]*Match, 0)
+		for _, m := range matches {
+			inputs := []*Match{m}
+			if s.descendants {
+				inputs = descendants(m, inputs)
+			}
+			for _, input := range inputs {
+				for _, sel := range s.selectors {
+					results = append(results, apply(sel, input, root)...)
+				}
+			}
+		}
+		matches = results
+	}
+	return matches
+}
+
+// descendants appends the descendants of a match to a list, in document order.
+func descendants(m *Match, list []*Match) []*Match {
+	for _, child := range children(m) {
+		list = append(list, child)
+		list = descendants(child, list)
+	}
+	return list
+}
+
+// children returns the values of a mapping or the items of a sequence.
+func children(m *Match) []*Match {
+	node := resolve(m.Node)
+	if node == nil {
+		return nil
+	}
+	var result []*Match
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			result = append(result, &Match{Node: node.Content[i+1], Location: m.Location + nameLocation(node.Content[i].Value)})
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			result = append(result, &Match{Node: item, Location: m.Location + "[" + strconv.Itoa(i) + "]"})
+		}
+	}
+	return result
+}
+
+func nameLocation(name string) string {
+	return "['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(name) + "']"
+}
+
+func apply(sel selector, m *Match, root *Match) []*Match {
+	node := resolve(m.Node)
+	if node == nil {
+		return nil
+	}
+	switch sel := sel.(type) {
+	case nameSelector:
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == sel.name {
+					return []*Match{{Node: node.Content[i+1], Location: m.Location + nameLocation(sel.name)}}
+				}
+			}
+		}
+	case wildcardSelector:
+		return children(m)
+	case indexSelector:
+		if node.Kind == yaml.SequenceNode {
+			i := sel.index
+			if i < 0 {
+				i += len(node.Content)
+			}
+			if i >= 0 && i < len(node.Content) {
+				return []*Match{{Node: node.Content[i], Location: m.Location + "[" + strconv.Itoa(i) + "]"}}
+			}
+		}
+	case sliceSelector:
+		if node.Kind == yaml.SequenceNode {
+			items := children(m)
+			result := make([]*Match, 0)
+			for _, i := range sliceIndices(sel, len(items)) {
+				result = append(result, items[i])
+			}
+			return result
+		}
+	case filterSelector:
+		result := make([]*Match, 0)
+		for _, child := range children(m) {
+			if test(sel.expression, child, root) {
+				result = append(result, child)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// sliceIndices returns the indices selected by a slice of a sequence with n items.
+func sliceIndices(sel sliceSelector, n int) []int {
+	step := 1
+	if sel.step != nil {
+		step = *sel.step
+	}
+	normalize := func(i int) int {
+		if i < 0 {
+			return i + n
+		}
+		return i
+	}
+	clamp := func(i, low, high int) int {
+		if i < low {
+			return low
+		}
+		if i > high {
+			return high
+		}
+		return i
+	}
+	indices := make([]int, 0)
+	if step > 0 {
+		start, end := 0, n
+		if sel.start != nil {
+			start = clamp(normalize(*sel.start), 0, n)
+		}
+		if sel.end != nil {
+			end = clamp(normalize(*sel.end), 0, n)
+		}
+		for i := start; i < end; i += step {
+			indices = append(indices, i)
+		}
+	} else {
+		start, end := n-1, -1
+		if sel.start != nil {
+			start = clamp(normalize(*sel.start), -1, n-1)
+		}
+		if sel.end != nil {
+			end = clamp(normalize(*sel.end), -1, n-1)
+		}
+		for i := start; i > end; i += step {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// test evaluates a filter expression for a node.
+func test(e expression, current *Match, root *Match) bool {
+	switch e := e.(type) {
+	case orExpression:
+		return test(e.left, current, root) || test(e.right, current, root)
+	case andExpression:
+		return test(e.left, current, root) && test(e.right, current, root)
+	case notExpression:
+		return !test(e.operand, current, root)
+	case queryExpression:
+		return len(query(e, current, root)) > 0
+	case comparisonExpression:
+		left, leftOK := value(e.left, current, root)
+		right, rightOK := value(e.right, current, root)
+		return compare(e.operator, left, leftOK, right, rightOK)
+	}
+	return false
+}
+
+func query(e queryExpression, current *Match, root *Match) []*Match {
+	if e.relative {
+		return evaluate(e.segments, []*Match{current}, root)
+	}
+	return evaluate(e.segments, []*Match{root}, root)
+}
+
+// value returns the value of an operand of a comparison. Queries have values
+// only when they select exactly one node.
+func value(e expression, current *Match, root *Match) (interface{}, bool) {
+	switch e := e.(type) {
+	case literal:
+		return e.value, true
+	case queryExpression:
+		matches := query(e, current, root)
+		if len(matches) != 1 {
+			return nil, false
+		}
+		return nodeValue(resolve(matches[0].Node)), true
+	}
+	return nil, false
+}
+
+// A structure is a mapping or sequence node, which can only be compared for identity.
+type structure struct{ node *yaml.Node }
+
+// nodeValue converts a node into a value that can be compared with literals.
+func nodeValue(node *yaml.Node) interface{} {
+	if node.Kind != yaml.ScalarNode {
+		return structure{node: node}
+	}
+	switch node.ShortTag() {
+	case "!!int", "!!float":
+		if f, err := strconv.ParseFloat(strings.ReplaceAll(node.Value, "_", ""), 64); err == nil {
+			return f
+		}
+	case "!!bool":
+		if b, err := strconv.ParseBool(node.Value); err == nil {
+			return b
+		}
+		return strings.EqualFold(node.Value, "yes") || strings.EqualFold(node.Value, "on")
+	case "!!null":
+		return nil
+	}
+	return node.Value
+}
+
+// compare applies a comparison operator. Missing values are equal only to
+// other missing values, and values of different types are never equal.
+func compare(operator string, left interface{}, leftOK bool, right interface{}, rightOK bool) bool {
+	switch operator {
+	case "==":
+		return equal(left, leftOK, right, rightOK)
+	case "!=":
+		return !equal(left, leftOK, right, rightOK)
+	case "<":
+		return less(left, leftOK, right, rightOK)
+	case ">":
+		return less(right, rightOK, left, leftOK)
+	case "<=":
+		return less(left, leftOK, right, rightOK) || equal(left, leftOK, right, rightOK)
+	case ">=":
+		return less(right, rightOK, left, leftOK) || equal(left, leftOK, right, rightOK)
+	}
+	return false
+}
+
+func equal(left interface{}, leftOK bool, right interface{}, rightOK bool) bool {
+	if !leftOK || !rightOK {
+		return leftOK == rightOK
+	}
+	return left == right
+}
+
+func less(left interface{}, leftOK bool, right interface{}, rightOK bool) bool {
+	if !leftOK || !rightOK {
+		return false
+	}
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		return ok && l < r
+	case string:
+		r, ok := right.(string)
+		return ok && l < r
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpath
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testDocument = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          required: false
+        - name: offset
+          in: query
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createPet
+      deprecated: true
+      responses:
+        "201":
+          description: Created
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+      responses:
+        "200":
+          description: OK
+        "404":
+          description: Not found
+`
+
+func values(t *testing.T, expression string) []string {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(testDocument), &document); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	path, err := Compile(expression)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	result := make([]string, 0)
+	for _, node := range path.Nodes(&document) {
+		if node.Kind == yaml.ScalarNode {
+			result = append(result, node.Value)
+		} else {
+			result = append(result, node.Tag)
+		}
+	}
+	return result
+}
+
+func TestSelectors(t *testing.T) {
+	for _, test := range []struct {
+		expression string
+		expected   []string
+	}{
+		{`$.info.title`, []string{"Pets"}},
+		{`$['info']["version"]`, []string{"1.0.0"}},
+		{`$.paths[*].get.operationId`, []string{"listPets", "getPet"}},
+		{`$.paths['/pets/{petId}'].get.parameters[0].name`, []string{"petId"}},
+		{`$.paths['/pets'].get.parameters[-1].name`, []string{"offset"}},
+		{`$.paths['/pets'].get.parameters[::-1].name`, []string{"offset", "limit"}},
+		{`$.paths['/pets'].get.parameters[0:1].name`, []string{"limit"}},
+		{`$.paths['/pets']['get','post'].operationId`, []string{"listPets", "createPet"}},
+		{`$..operationId`, []string{"listPets", "createPet", "getPet"}},
+		{`$..responses.*.description`, []string{"OK", "Created", "OK", "Not found"}},
+		{`$..parameters[?@.in == 'path'].name`, []string{"petId"}},
+		{`$..parameters[?(@.required)].name`, []string{"limit", "petId"}},
+		{`$..parameters[?@.required == true].name`, []string{"petId"}},
+		{`$..parameters[?!@.required].name`, []string{"offset"}},
+		{`$.paths.*[?@.deprecated == true || @.operationId == 'getPet'].operationId`, []string{"createPet", "getPet"}},
+		{`$.paths.*[?@.responses['404'] && @.parameters].operationId`, []string{"getPet"}},
+		{`$.paths.*.*.responses[?@.description > 'O'].description`, []string{"OK", "OK"}},
+		{`$.missing`, []string{}},
+		{`$.info`, []string{"!!map"}},
+	} {
+		if result := values(t, test.expression); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("%s: got %+v, expected %+v", test.expression, result, test.expected)
+		}
+	}
+}
+
+func TestLocations(t *testing.T) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(testDocument), &document); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	matches := MustCompile(`$..parameters[?@.in == 'path']`).Select(&document)
+	if len(matches) != 1 || matches[0].Location != `$['paths']['/pets/{petId}']['get']['parameters'][0]` {
+		t.Errorf("unexpected matches: %+v", matches)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, expression := range []string{``, `info`, `$.`, `$[`, `$['unterminated]`, `$[1:2:0]`, `$[?@.a ==]`, `$.a b`} {
+		if _, err := Compile(expression); err == nil {
+			t.Errorf("expected an error for %q", expression)
+		}
+	}
+}
@@ -0,0 +1,434 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonpath evaluates JSONPath expressions over documents that are
+// represented as YAML nodes, such as the compiled forms of API descriptions.
+//
+// The supported syntax follows RFC 9535: the root identifier ($), child
+// segments with names (.name, ['name']), wildcards (.*, [*]), indices
+// ([0], [-1]), slices ([start:end:step]), unions ([0,2], ['a','b']), the
+// descendant segment (..), and filter selectors ([?@.key == 'value'])
+// with comparisons, existence tests, and logical operators.
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A Path is a compiled JSONPath expression.
+type Path struct {
+	expression string
+	segments   []*segment
+}
+
+// A segment selects nodes from the children, or with descendants set, from
+// all of the descendants of each input node.
+type segment struct {
+	descendants bool
+	selectors   []selector
+}
+
+// A selector chooses among the children of a node.
+type selector interface{}
+
+type nameSelector struct{ name string }
+
+type wildcardSelector struct{}
+
+type indexSelector struct{ index int }
+
+type sliceSelector struct {
+	start, end, step *int
+}
+
+type filterSelector struct{ expression expression }
+
+// An expression is part of a filter.
+type expression interface{}
+
+type orExpression struct{ left, right expression }
+
+type andExpression struct{ left, right expression }
+
+type notExpression struct{ operand expression }
+
+// A queryExpression is a path relative to the current node (@) or the root ($).
+type queryExpression struct {
+	relative bool
+	segments []*segment
+}
+
+type comparisonExpression struct {
+	operator    string
+	left, right expression
+}
+
+// A literal is a string, float64, bool, or nil.
+type literal struct{ value interface{} }
+
+// Compile parses a JSONPath expression.
+func Compile(expression string) (*Path, error) {
+	p := &parser{text: expression}
+	p.skipSpace()
+	if !p.consume("$") {
+		return nil, p.errorf("expected $")
+	}
+	segments, err := p.segments()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.text[p.position:])
+	}
+	return &Path{expression: expression, segments: segments}, nil
+}
+
+// MustCompile is like Compile but panics if the expression can't be parsed.
+func MustCompile(expression string) *Path {
+	path, err := Compile(expression)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+// String returns the expression that was compiled.
+func (path *Path) String() string {
+	return path.expression
+}
+
+type parser struct {
+	text     string
+	position int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid path %q at position %d: %s", p.text, p.position, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) done() bool {
+	return p.position >= len(p.text)
+}
+
+func (p *parser) peek(s string) bool {
+	return strings.HasPrefix(p.text[p.position:], s)
+}
+
+func (p *parser) consume(s string) bool {
+	if p.peek(s) {
+		p.position += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.text[p.position])) {
+		p.position++
+	}
+}
+
+// segments parses a sequence of segments, stopping at anything that can't start one.
+func (p *parser) segments() ([]*segment, error) {
+	segments := make([]*segment, 0)
+	for {
+		start := p.position
+		p.skipSpace()
+		var s *segment
+		var err error
+		switch {
+		case p.consume(".."):
+			if p.peek("[") {
+				s, err = p.bracket()
+			} else {
+				s, err = p.dotted()
+			}
+			if s != nil {
+				s.descendants = true
+			}
+		case p.consume("."):
+			s, err = p.dotted()
+		case p.peek("["):
+			s, err = p.bracket()
+		default:
+			// Whitespace is only allowed between segments.
+			p.position = start
+			return segments, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, s)
+	}
+}
+
+// dotted parses the name or wildcard that follows a dot.
+func (p *parser) dotted() (*segment, error) {
+	if p.consume("*") {
+		return &segment{selectors: []selector{wildcardSelector{}}}, nil
+	}
+	start := p.position
+	for !p.done() {
+		c := rune(p.text[p.position])
+		if c == '.' || c == '[' || c == ']' || c == ')' || c == ',' || unicode.IsSpace(c) || strings.ContainsRune("=!<>&|", c) {
+			break
+		}
+		p.position++
+	}
+	if p.position == start {
+		return nil, p.errorf("expected a name")
+	}
+	return &segment{selectors: []selector{nameSelector{name: p.text[start:p.position]}}}, nil
+}
+
+// bracket parses a bracketed list of selectors.
+func (p *parser) bracket() (*segment, error) {
+	p.consume("[")
+	s := &segment{}
+	for {
+		p.skipSpace()
+		sel, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		s.selectors = append(s.selectors, sel)
+		p.skipSpace()
+		if p.consume("]") {
+			return s, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ]")
+		}
+	}
+}
+
+func (p *parser) selector() (selector, error) {
+	switch {
+	case p.consume("*"):
+		return wildcardSelector{}, nil
+	case p.peek("'") || p.peek("\""):
+		name, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		return nameSelector{name: name}, nil
+	case p.consume("?"):
+		p.skipSpace()
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expression: e}, nil
+	}
+	// An index or a slice.
+	var parts [3]*int
+	part := 0
+	for {
+		p.skipSpace()
+		if n, ok := p.integer(); ok {
+			parts[part] = &n
+		}
+		p.skipSpace()
+		if part < 2 && p.consume(":") {
+			part++
+			continue
+		}
+		break
+	}
+	if part == 0 {
+		if parts[0] == nil {
+			return nil, p.errorf("expected a selector")
+		}
+		return indexSelector{index: *parts[0]}, nil
+	}
+	if parts[2] != nil && *parts[2] == 0 {
+		return nil, p.errorf("slice step can't be zero")
+	}
+	return sliceSelector{start: parts[0], end: parts[1], step: parts[2]}, nil
+}
+
+func (p *parser) integer() (int, bool) {
+	start := p.position
+	p.consume("-")
+	for !p.done() && p.text[p.position] >= '0' && p.text[p.position] <= '9' {
+		p.position++
+	}
+	n, err := strconv.Atoi(p.text[start:p.position])
+	if err != nil {
+		p.position = start
+		return 0, false
+	}
+	return n, true
+}
+
+// quoted parses a single- or double-quoted string with backslash escapes.
+func (p *parser) quoted() (string, error) {
+	quote := p.text[p.position]
+	p.position++
+	var b strings.Builder
+	for !p.done() {
+		c := p.text[p.position]
+		p.position++
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\\' && !p.done():
+			e := p.text[p.position]
+			p.position++
+			switch e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'u':
+				if p.position+4 > len(p.text) {
+					return "", p.errorf("invalid escape")
+				}
+				r, err := strconv.ParseUint(p.text[p.position:p.position+4], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid escape")
+				}
+				b.WriteRune(rune(r))
+				p.position += 4
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) or() (expression, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("||") {
+			return left, nil
+		}
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpression{left: left, right: right}
+	}
+}
+
+func (p *parser) and() (expression, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpression{left: left, right: right}
+	}
+}
+
+func (p *parser) unary() (expression, error) {
+	p.skipSpace()
+	if p.peek("!") && !p.peek("!=") {
+		p.consume("!")
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpression{operand: operand}, nil
+	}
+	if p.consume("(") {
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return e, nil
+	}
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	for _, operator := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(operator) {
+			p.skipSpace()
+			right, err := p.operand()
+			if err != nil {
+				return nil, err
+			}
+			return comparisonExpression{operator: operator, left: left, right: right}, nil
+		}
+	}
+	if _, ok := left.(literal); ok {
+		return nil, p.errorf("expected a comparison")
+	}
+	return left, nil
+}
+
+func (p *parser) operand() (expression, error) {
+	switch {
+	case p.consume("@"), p.consume("$"):
+		relative := p.text[p.position-1] == '@'
+		segments, err := p.segments()
+		if err != nil {
+			return nil, err
+		}
+		return queryExpression{relative: relative, segments: segments}, nil
+	case p.peek("'") || p.peek("\""):
+		s, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		return literal{value: s}, nil
+	case p.consume("true"):
+		return literal{value: true}, nil
+	case p.consume("false"):
+		return literal{value: false}, nil
+	case p.consume("null"):
+		return literal{value: nil}, nil
+	}
+	start := p.position
+	for !p.done() && strings.ContainsRune("-+.0123456789eE", rune(p.text[p.position])) {
+		p.position++
+	}
+	f, err := strconv.ParseFloat(p.text[start:p.position], 64)
+	if err != nil {
+		p.position = start
+		return nil, p.errorf("expected a value")
+	}
+	return literal{value: f}, nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle returns a self-contained copy of a schema. The schema resources of
+// other documents that it references, directly or indirectly, are copied
+// into "$defs" ("definitions" before 2019-09) and the references to them are
+// rewritten as local JSON Pointers. Referenced files are read from the local
+// filesystem and http and https URLs are fetched. Copied resources lose their
+// "$id" and "$schema" keywords, so that the rewritten references are resolved
+// against the base URI of the bundle. The schema itself is not modified.
+func (schema *Schema) Bundle() (*Schema, error) {
+	if schema.resource == nil {
+		schema.register(nil, nil)
+	}
+	b := &bundler{
+		root:      schema,
+		locations: make(map[*Schema]string),
+		embedded:  make(map[*Schema]bool),
+		names:     make(map[string]bool),
+		keyword:   "$defs",
+	}
+	switch schema.Dialect() {
+	case DialectDraft04, DialectDraft06, DialectDraft07:
+		b.keyword = "definitions"
+	}
+	existing := schema.Defs
+	if b.keyword == "definitions" {
+		existing = schema.Definitions
+	}
+	if existing != nil {
+		for _, pair := range *existing {
+			b.names[pair.Name] = true
+		}
+	}
+	b.locate(schema, "", false)
+	// Embedding resources adds schemas to b.schemas, which are also bundled.
+	for i := 0; i < len(b.schemas); i++ {
+		if err := b.bundleReference(b.schemas[i]); err != nil {
+			return nil, err
+		}
+	}
+	return b.build(), nil
+}
+
+// A bundler collects the schema resources that are needed by a schema.
+type bundler struct {
+	root *Schema
+	// the locations of schemas in the bundle
+	locations map[*Schema]string
+	// schemas in the bundle, in the order that they were located
+	schemas []*Schema
+	// schemas that are copied from other documents
+	embedded map[*Schema]bool
+	// the names of the members of the definitions keyword
+	names map[string]bool
+	// the definitions keyword, "$defs" or "definitions"
+	keyword string
+	// resources to add to the definitions
+	resources []*NamedSchema
+	// new references, keyed by the locations of the schemas that contain them
+	references map[string]string
+}
+
+// locate records the locations of a schema and its subschemas.
+func (b *bundler) locate(schema *Schema, pointer string, embedded bool) {
+	if _, ok := b.locations[schema]; ok {
+		return
+	}
+	b.locations[schema] = pointer
+	b.schemas = append(b.schemas, schema)
+	if embedded {
+		b.embedded[schema] = true
+	}
+	for _, s := range schema.locatedSubschemas() {
+		b.locate(s.schema, pointer+s.pointer, embedded)
+	}
+}
+
+// bundleReference embeds the target of a schema's reference if it is in
+// another document and records a new reference if one is needed.
+func (b *bundler) bundleReference(schema *Schema) error {
+	if schema.Ref == nil {
+		return nil
+	}
+	ref := *schema.Ref
+	if err := fetchReferencedDocument(schema, ref); err != nil {
+		return err
+	}
+	target, err := schema.ResolveReference(ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := b.locations[target]; !ok {
+		resource := target.resource
+		if resource == nil {
+			resource = target
+		}
+		if _, ok := b.locations[resource]; ok {
+			return fmt.Errorf("unable to bundle %s: its target is not in a known location", ref)
+		}
+		name := b.name(resource)
+		b.resources = append(b.resources, NewNamedSchema(name, resource))
+		b.locate(resource, "/"+escapePointerToken(b.keyword)+"/"+escapePointerToken(name), true)
+		if _, ok := b.locations[target]; !ok {
+			return fmt.Errorf("unable to bundle %s: its target is not in a known location", ref)
+		}
+	}
+	// References in copied resources and references to copied resources
+	// are rewritten, and so are references by URI in the root resource.
+	if b.embedded[schema] || b.embedded[target] ||
+		(!strings.HasPrefix(ref, "#") && schema.resource == b.root.resource) {
+		if b.references == nil {
+			b.references = make(map[string]string)
+		}
+		b.references[b.locations[schema]] = "#" + b.locations[target]
+	}
+	return nil
+}
+
+// name returns an unused name for a copied resource that is derived from
+// its URI.
+func (b *bundler) name(resource *Schema) string {
+	name := "schema"
+	if u, err := url.Parse(resource.base); err == nil {
+		if base := path.Base(u.Path); base != "." && base != "/" {
+			name = strings.TrimSuffix(base, path.Ext(base))
+		}
+	}
+	unique := name
+	for i := 2; b.names[unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	b.names[unique] = true
+	return unique
+}
+
+// build returns a copy of the root schema that contains the copied resources.
+func (b *bundler) build() *Schema {
+	bundle := readSchema(b.root.nodeValue(), b.root.dialect)
+	if len(b.resources) == 0 && len(b.references) == 0 {
+		return bundle
+	}
+	definitions := &bundle.Defs
+	if b.keyword == "definitions" {
+		definitions = &bundle.Definitions
+	}
+	if *definitions == nil {
+		*definitions = &[]*NamedSchema{}
+	}
+	for _, resource := range b.resources {
+		copied := readSchema(resource.Value.nodeValue(), bundle.Dialect())
+		copied.Schema = nil
+		**definitions = append(**definitions, NewNamedSchema(resource.Name, copied))
+	}
+	for schema := range b.embedded {
+		// Identifiers of copied resources would change the base URI of
+		// the rewritten references, but plain-name fragments are kept.
+		if schema.ID != nil && !strings.HasPrefix(*schema.ID, "#") {
+			if s := bundle.resolvePointer(pointerTokens(b.locations[schema])); s != nil {
+				s.ID = nil
+			}
+		}
+	}
+	for pointer, ref := range b.references {
+		if s := bundle.resolvePointer(pointerTokens(pointer)); s != nil {
+			s.Ref = stringptr(ref)
+		}
+	}
+	return bundle
+}
+
+// pointerTokens returns the unescaped tokens of a JSON Pointer.
+func pointerTokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+// fetchReferencedDocument reads a document that is referenced with an http
+// or https URL if it hasn't been read before.
+func fetchReferencedDocument(schema *Schema, ref string) error {
+	base, err := url.Parse(schema.base)
+	if err != nil {
+		return err
+	}
+	target, err := base.Parse(ref)
+	if err != nil {
+		return err
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return nil
+	}
+	key := resourceKey(target)
+	if schemas[key] != nil {
+		return nil
+	}
+	response, err := http.Get(key)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to fetch %s: %s", key, response.Status)
+	}
+	bytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(bytes, &node); err != nil {
+		return err
+	}
+	u, _ := url.Parse(key)
+	newSchema(&node, u)
+	return nil
+}
@@ -0,0 +1,778 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Impact describes how a change to a schema affects the set of instances
+// that the schema accepts. A narrowing change to a schema of values that
+// clients send can break those clients, and so can a widening change to
+// a schema of values that clients receive.
+type Impact int
+
+const (
+	// NoImpact changes only annotations, like titles and descriptions.
+	NoImpact Impact = iota
+	// Widening changes accept every instance that was accepted before.
+	Widening
+	// Narrowing changes accept only instances that were accepted before.
+	Narrowing
+	// Incompatible changes can both accept and reject instances that were
+	// handled differently before.
+	Incompatible
+)
+
+// String returns the name of an impact.
+func (i Impact) String() string {
+	switch i {
+	case NoImpact:
+		return "none"
+	case Widening:
+		return "widening"
+	case Narrowing:
+		return "narrowing"
+	default:
+		return "incompatible"
+	}
+}
+
+// Combine returns the impact of making two changes.
+func (i Impact) Combine(j Impact) Impact {
+	switch {
+	case i == j || j == NoImpact:
+		return i
+	case i == NoImpact:
+		return j
+	default:
+		return Incompatible
+	}
+}
+
+// inverse returns the impact of a change inside a "not" keyword.
+func (i Impact) inverse() Impact {
+	switch i {
+	case Widening:
+		return Narrowing
+	case Narrowing:
+		return Widening
+	default:
+		return i
+	}
+}
+
+// Types of schema changes.
+const (
+	KeywordAdded   = "added"
+	KeywordRemoved = "removed"
+	KeywordChanged = "changed"
+)
+
+// A SchemaChange is a difference in a keyword of two versions of a schema.
+type SchemaChange struct {
+	// Path is a JSON Pointer to the keyword in the schemas.
+	Path string
+	// Type is KeywordAdded, KeywordRemoved, or KeywordChanged.
+	Type string
+	// Old and New describe the values of the keyword, and are empty
+	// when the keyword is absent.
+	Old string
+	New string
+	// Impact is the effect of the change on the instances that are accepted.
+	Impact Impact
+}
+
+// String returns a description of a schema change.
+func (c *SchemaChange) String() string {
+	path := c.Path
+	if path == "" {
+		path = "schema"
+	}
+	var s string
+	switch c.Type {
+	case KeywordAdded:
+		s = fmt.Sprintf("%s added: %s", path, c.New)
+	case KeywordRemoved:
+		s = fmt.Sprintf("%s removed: %s", path, c.Old)
+	default:
+		s = fmt.Sprintf("%s changed from %s to %s", path, c.Old, c.New)
+	}
+	return s + " (" + c.Impact.String() + ")"
+}
+
+// CompareSchemas returns the differences between two versions of a schema,
+// keyword by keyword, with the impact of each change on the instances that
+// the schema accepts. Subschemas are compared in place and references are
+// compared as strings, so changes to referenced schemas are found by
+// comparing them separately. Impacts are conservative: changes whose effect
+// can't be determined from the keywords alone, like a changed pattern, are
+// incompatible.
+func CompareSchemas(before, after *Schema) []*SchemaChange {
+	c := &schemaComparison{}
+	c.compare("", before, after)
+	return c.changes
+}
+
+// OverallImpact returns the combined impact of a list of changes.
+func OverallImpact(changes []*SchemaChange) Impact {
+	impact := NoImpact
+	for _, change := range changes {
+		impact = impact.Combine(change.Impact)
+	}
+	return impact
+}
+
+type schemaComparison struct {
+	changes []*SchemaChange
+	// inverted is set when comparing the contents of "not" keywords
+	inverted bool
+	// conditional is set when comparing the contents of "if" keywords,
+	// where any change to the accepted instances is incompatible
+	conditional bool
+}
+
+func (c *schemaComparison) add(path, changeType, before, after string, impact Impact) {
+	if c.inverted {
+		impact = impact.inverse()
+	}
+	if c.conditional && impact != NoImpact {
+		impact = Incompatible
+	}
+	c.changes = append(c.changes, &SchemaChange{Path: path, Type: changeType, Old: before, New: after, Impact: impact})
+}
+
+// changed records a change to a keyword that might have been added or removed,
+// with the impact of each kind of change.
+func (c *schemaComparison) changed(path string, before, after string, hasBefore, hasAfter bool, added, removed, modified Impact) {
+	switch {
+	case hasBefore && hasAfter:
+		if before != after {
+			c.add(path, KeywordChanged, before, after, modified)
+		}
+	case hasAfter:
+		c.add(path, KeywordAdded, "", after, added)
+	case hasBefore:
+		c.add(path, KeywordRemoved, before, "", removed)
+	}
+}
+
+func (c *schemaComparison) annotation(path string, before, after *string) {
+	c.changed(path, stringValue(before), stringValue(after), before != nil, after != nil, NoImpact, NoImpact, NoImpact)
+}
+
+func (c *schemaComparison) annotationNode(path string, before, after *yaml.Node) {
+	c.changed(path, nodeText(before), nodeText(after), before != nil, after != nil, NoImpact, NoImpact, NoImpact)
+}
+
+func (c *schemaComparison) annotationFlag(path string, before, after *bool) {
+	c.changed(path, boolText(before), boolText(after), before != nil, after != nil, NoImpact, NoImpact, NoImpact)
+}
+
+// constraint compares a keyword that restricts instances in a way that
+// can't be ordered, like a pattern or a format.
+func (c *schemaComparison) constraint(path string, before, after *string) {
+	c.changed(path, stringValue(before), stringValue(after), before != nil, after != nil, Narrowing, Widening, Incompatible)
+}
+
+// flag compares a keyword that restricts instances when it is true.
+func (c *schemaComparison) flag(path string, before, after *bool) {
+	o, n := before != nil && *before, after != nil && *after
+	impact := Widening
+	if n {
+		impact = Narrowing
+	}
+	if o != n {
+		c.changed(path, boolText(before), boolText(after), before != nil, after != nil, impact, impact, impact)
+	}
+}
+
+// bound compares a numeric limit. Raising an upper bound or lowering a
+// lower bound widens a schema.
+func (c *schemaComparison) bound(path string, before, after *big.Rat, upper bool) {
+	raised, lowered := Widening, Narrowing
+	if !upper {
+		raised, lowered = Narrowing, Widening
+	}
+	changed := NoImpact
+	if before != nil && after != nil {
+		switch before.Cmp(after) {
+		case -1:
+			changed = raised
+		case 1:
+			changed = lowered
+		default:
+			return
+		}
+	}
+	c.changed(path, ratText(before), ratText(after), before != nil, after != nil, Narrowing, Widening, changed)
+}
+
+func (c *schemaComparison) numberBound(path string, before, after *SchemaNumber, upper bool) {
+	c.bound(path, schemaNumberRat(before), schemaNumberRat(after), upper)
+}
+
+func (c *schemaComparison) integerBound(path string, before, after *int64, upper bool) {
+	c.bound(path, integerRat(before), integerRat(after), upper)
+}
+
+// multipleOf compares divisors. Instances that are multiples of a number
+// are also multiples of its divisors.
+func (c *schemaComparison) multipleOf(path string, before, after *SchemaNumber) {
+	o, n := schemaNumberRat(before), schemaNumberRat(after)
+	changed := Incompatible
+	if o != nil && n != nil {
+		if o.Cmp(n) == 0 {
+			return
+		}
+		if o.Sign() != 0 && n.Sign() != 0 {
+			if new(big.Rat).Quo(o, n).IsInt() {
+				changed = Widening
+			} else if new(big.Rat).Quo(n, o).IsInt() {
+				changed = Narrowing
+			}
+		}
+	}
+	c.changed(path, ratText(o), ratText(n), o != nil, n != nil, Narrowing, Widening, changed)
+}
+
+// types compares the type keyword, where an absent type allows any type.
+func (c *schemaComparison) types(path string, before, after *StringOrStringArray) {
+	o, n := typeNames(before), typeNames(after)
+	oldText, newText := strings.Join(o, ", "), strings.Join(n, ", ")
+	if before != nil && after != nil && oldText == newText {
+		return
+	}
+	changed := Incompatible
+	if before != nil && after != nil {
+		switch {
+		case includesTypes(n, o):
+			changed = Widening
+		case includesTypes(o, n):
+			changed = Narrowing
+		}
+	}
+	c.changed(path, oldText, newText, before != nil, after != nil, Narrowing, Widening, changed)
+}
+
+func typeNames(types *StringOrStringArray) []string {
+	if types == nil {
+		return nil
+	}
+	if types.String != nil {
+		return []string{*types.String}
+	}
+	if types.StringArray != nil {
+		return *types.StringArray
+	}
+	return nil
+}
+
+// includesTypes returns true if every instance of the types in b is
+// an instance of the types in a.
+func includesTypes(a, b []string) bool {
+	for _, t := range b {
+		found := false
+		for _, u := range a {
+			if t == u || (t == "integer" && u == "number") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// values compares sets of allowed values, like enum and const.
+func (c *schemaComparison) values(path string, before, after []*yaml.Node, hasBefore, hasAfter bool) {
+	oldText, newText := valuesText(before), valuesText(after)
+	changed := Incompatible
+	if hasBefore && hasAfter {
+		o, n := includesValues(after, before), includesValues(before, after)
+		switch {
+		case o && n:
+			return
+		case o:
+			changed = Widening
+		case n:
+			changed = Narrowing
+		}
+	}
+	c.changed(path, oldText, newText, hasBefore, hasAfter, Narrowing, Widening, changed)
+}
+
+// includesValues returns true if every value in b is also in a.
+func includesValues(a, b []*yaml.Node) bool {
+	for _, x := range b {
+		found := false
+		for _, y := range a {
+			if equalValues(x, y) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func enumValues(values *[]SchemaEnumValue) []*yaml.Node {
+	if values == nil {
+		return nil
+	}
+	nodes := make([]*yaml.Node, 0)
+	for _, value := range *values {
+		nodes = append(nodes, enumValueNode(value))
+	}
+	return nodes
+}
+
+func valuesText(values []*yaml.Node) string {
+	texts := make([]string, 0)
+	for _, value := range values {
+		texts = append(texts, nodeText(value))
+	}
+	return strings.Join(texts, ", ")
+}
+
+// names compares sets of property names, like the required keyword,
+// where adding names narrows a schema.
+func (c *schemaComparison) names(path string, before, after *[]string) {
+	o, n := stringSlice(before), stringSlice(after)
+	for _, name := range o {
+		if !containsString(n, name) {
+			c.add(path, KeywordRemoved, name, "", Widening)
+		}
+	}
+	for _, name := range n {
+		if !containsString(o, name) {
+			c.add(path, KeywordAdded, "", name, Narrowing)
+		}
+	}
+}
+
+func stringSlice(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// subschema compares the values of a keyword that contains a schema,
+// where an absent schema accepts everything.
+func (c *schemaComparison) subschema(path string, before, after *Schema) {
+	switch {
+	case before == nil && after == nil:
+	case before == nil:
+		c.add(path, KeywordAdded, "", schemaSummary(after), Narrowing)
+	case after == nil:
+		c.add(path, KeywordRemoved, schemaSummary(before), "", Widening)
+	default:
+		c.compare(path, before, after)
+	}
+}
+
+// inverted compares schemas whose impact is reversed, like those of "not".
+func (c *schemaComparison) invertedSubschema(path string, before, after *Schema) {
+	c.inverted = !c.inverted
+	c.subschema(path, before, after)
+	c.inverted = !c.inverted
+}
+
+// conditionalSubschema compares the schemas of "if" keywords.
+func (c *schemaComparison) conditionalSubschema(path string, before, after *Schema) {
+	conditional := c.conditional
+	c.conditional = true
+	c.subschema(path, before, after)
+	c.conditional = conditional
+}
+
+// schemaList compares arrays of subschemas by position. Added and
+// removed subschemas have the specified impacts.
+func (c *schemaComparison) schemaList(path string, before, after *[]*Schema, added, removed Impact) {
+	var o, n []*Schema
+	if before != nil {
+		o = *before
+	}
+	if after != nil {
+		n = *after
+	}
+	for i := 0; i < len(o) || i < len(n); i++ {
+		itemPath := path + "/" + strconv.Itoa(i)
+		switch {
+		case i >= len(n):
+			c.add(itemPath, KeywordRemoved, schemaSummary(o[i]), "", removed)
+		case i >= len(o):
+			c.add(itemPath, KeywordAdded, "", schemaSummary(n[i]), added)
+		default:
+			c.compare(itemPath, o[i], n[i])
+		}
+	}
+}
+
+// alternatives compares the subschemas of anyOf and oneOf keywords.
+// Adding the keyword narrows a schema, but adding alternatives widens it.
+// Changing the alternatives of oneOf is incompatible because instances
+// must match exactly one of them.
+func (c *schemaComparison) alternatives(path string, before, after *[]*Schema, exclusive bool) {
+	switch {
+	case before == nil && after == nil:
+	case before == nil:
+		c.add(path, KeywordAdded, "", schemaListSummary(after), Narrowing)
+	case after == nil:
+		c.add(path, KeywordRemoved, schemaListSummary(before), "", Widening)
+	case exclusive:
+		c.schemaList(path, before, after, Incompatible, Incompatible)
+	default:
+		c.schemaList(path, before, after, Widening, Narrowing)
+	}
+}
+
+// namedSchemas compares maps of subschemas by name.
+func (c *schemaComparison) namedSchemas(path string, before, after *[]*NamedSchema, added, removed Impact) {
+	var o, n []*NamedSchema
+	if before != nil {
+		o = *before
+	}
+	if after != nil {
+		n = *after
+	}
+	for _, pair := range o {
+		if namedSchemaArrayElementWithName(after, pair.Name) == nil {
+			c.add(path+"/"+escapePointerToken(pair.Name), KeywordRemoved, schemaSummary(pair.Value), "", removed)
+		}
+	}
+	for _, pair := range n {
+		namePath := path + "/" + escapePointerToken(pair.Name)
+		if previous := namedSchemaArrayElementWithName(before, pair.Name); previous != nil {
+			c.compare(namePath, previous, pair.Value)
+		} else {
+			c.add(namePath, KeywordAdded, "", schemaSummary(pair.Value), added)
+		}
+	}
+}
+
+// dependencies compares dependencies keywords, which contain schemas
+// and arrays of property names.
+func (c *schemaComparison) dependencies(path string, before, after *[]*NamedSchemaOrStringArray) {
+	find := func(array *[]*NamedSchemaOrStringArray, name string) *SchemaOrStringArray {
+		if array != nil {
+			for _, pair := range *array {
+				if pair.Name == name {
+					return pair.Value
+				}
+			}
+		}
+		return nil
+	}
+	summary := func(value *SchemaOrStringArray) string {
+		if value.Schema != nil {
+			return schemaSummary(value.Schema)
+		}
+		return strings.Join(stringSlice(value.StringArray), ", ")
+	}
+	if before != nil {
+		for _, pair := range *before {
+			if find(after, pair.Name) == nil {
+				c.add(path+"/"+escapePointerToken(pair.Name), KeywordRemoved, summary(pair.Value), "", Widening)
+			}
+		}
+	}
+	if after == nil {
+		return
+	}
+	for _, pair := range *after {
+		namePath := path + "/" + escapePointerToken(pair.Name)
+		previous := find(before, pair.Name)
+		switch {
+		case previous == nil:
+			c.add(namePath, KeywordAdded, "", summary(pair.Value), Narrowing)
+		case previous.Schema != nil && pair.Value.Schema != nil:
+			c.compare(namePath, previous.Schema, pair.Value.Schema)
+		case previous.StringArray != nil && pair.Value.StringArray != nil:
+			c.names(namePath, previous.StringArray, pair.Value.StringArray)
+		default:
+			c.add(namePath, KeywordChanged, summary(previous), summary(pair.Value), Incompatible)
+		}
+	}
+}
+
+// booleanOrSchema returns the schema of a keyword that can be a schema or
+// a boolean, using a boolean schema for booleans.
+func booleanOrSchema(value *SchemaOrBoolean) *Schema {
+	if value == nil {
+		return nil
+	}
+	if value.Schema != nil {
+		return value.Schema
+	}
+	return &Schema{Boolean: value.Boolean}
+}
+
+// items compares items keywords, which contain a schema or an array of
+// schemas that apply to array items by position.
+func (c *schemaComparison) items(path string, before, after *SchemaOrSchemaArray) {
+	switch {
+	case before == nil && after == nil:
+	case before == nil || after == nil || (before.Schema != nil) != (after.Schema != nil):
+		var o, n *Schema
+		if before != nil {
+			o = itemsSchema(before)
+		}
+		if after != nil {
+			n = itemsSchema(after)
+		}
+		if before != nil && after != nil {
+			c.add(path, KeywordChanged, schemaSummary(o), schemaSummary(n), Incompatible)
+		} else {
+			c.subschema(path, o, n)
+		}
+	case before.Schema != nil:
+		c.compare(path, before.Schema, after.Schema)
+	default:
+		c.schemaList(path, before.SchemaArray, after.SchemaArray, Narrowing, Widening)
+	}
+}
+
+func itemsSchema(items *SchemaOrSchemaArray) *Schema {
+	if items.Schema != nil {
+		return items.Schema
+	}
+	if items.SchemaArray != nil {
+		return &Schema{PrefixItems: items.SchemaArray}
+	}
+	return nil
+}
+
+// properties compares properties keywords. Adding a property narrows a
+// schema unless additional properties weren't allowed, and removing one
+// widens it unless additional properties aren't allowed.
+func (c *schemaComparison) properties(path string, before, after *Schema) {
+	added, removed := Narrowing, Widening
+	if isFalse(booleanOrSchema(before.AdditionalProperties)) {
+		added = Widening
+	}
+	if isFalse(booleanOrSchema(after.AdditionalProperties)) {
+		removed = Narrowing
+	}
+	c.namedSchemas(path, before.Properties, after.Properties, added, removed)
+}
+
+func isFalse(schema *Schema) bool {
+	return schema != nil && schema.Boolean != nil && !*schema.Boolean
+}
+
+// compare compares two schemas at a path.
+func (c *schemaComparison) compare(path string, before, after *Schema) {
+	if before.Boolean != nil || after.Boolean != nil {
+		if before.Boolean != nil && after.Boolean != nil {
+			if *before.Boolean != *after.Boolean {
+				impact := Narrowing
+				if *after.Boolean {
+					impact = Widening
+				}
+				c.add(path, KeywordChanged, schemaSummary(before), schemaSummary(after), impact)
+			}
+			return
+		}
+		// Compare other schemas with the equivalent schemas that have no
+		// keywords, which accept everything, or with "not" keywords.
+		before, after = booleanEquivalent(before), booleanEquivalent(after)
+	}
+
+	// Identifiers and annotations.
+	c.constraint(path+"/$schema", before.Schema, after.Schema)
+	c.annotation(path+"/"+after.idKeyword(), before.ID, after.ID)
+	c.annotation(path+"/$anchor", before.Anchor, after.Anchor)
+	c.annotation(path+"/$dynamicAnchor", before.DynamicAnchor, after.DynamicAnchor)
+	c.annotationFlag(path+"/$recursiveAnchor", before.RecursiveAnchor, after.RecursiveAnchor)
+	c.annotation(path+"/$comment", before.Comment, after.Comment)
+	c.annotation(path+"/title", before.Title, after.Title)
+	c.annotation(path+"/description", before.Description, after.Description)
+	c.annotationNode(path+"/default", before.Default, after.Default)
+	c.annotationNode(path+"/examples", before.Examples, after.Examples)
+	c.annotationFlag(path+"/deprecated", before.Deprecated, after.Deprecated)
+	c.annotationFlag(path+"/readOnly", before.ReadOnly, after.ReadOnly)
+	c.annotationFlag(path+"/writeOnly", before.WriteOnly, after.WriteOnly)
+	c.annotation(path+"/contentEncoding", before.ContentEncoding, after.ContentEncoding)
+	c.annotation(path+"/contentMediaType", before.ContentMediaType, after.ContentMediaType)
+	c.annotation(path+"/base", before.Base, after.Base)
+	c.annotationNode(path+"/links", nodeForLinks(before.Links), nodeForLinks(after.Links))
+	c.namedSchemas(path+"/definitions", before.Definitions, after.Definitions, NoImpact, NoImpact)
+	c.namedSchemas(path+"/$defs", before.Defs, after.Defs, NoImpact, NoImpact)
+
+	// References.
+	c.constraint(path+"/$ref", before.Ref, after.Ref)
+	c.constraint(path+"/$recursiveRef", before.RecursiveRef, after.RecursiveRef)
+	c.constraint(path+"/$dynamicRef", before.DynamicRef, after.DynamicRef)
+
+	// Keywords for any instance type.
+	c.types(path+"/type", before.Type, after.Type)
+	c.values(path+"/enum", enumValues(before.Enumeration), enumValues(after.Enumeration), before.Enumeration != nil, after.Enumeration != nil)
+	c.values(path+"/const", []*yaml.Node{before.Const}, []*yaml.Node{after.Const}, before.Const != nil, after.Const != nil)
+	c.constraint(path+"/format", before.Format, after.Format)
+
+	// Numbers.
+	c.multipleOf(path+"/multipleOf", before.MultipleOf, after.MultipleOf)
+	c.numberBound(path+"/maximum", before.Maximum, after.Maximum, true)
+	c.flag(path+"/exclusiveMaximum", before.ExclusiveMaximum, after.ExclusiveMaximum)
+	c.numberBound(path+"/exclusiveMaximum", before.ExclusiveMaximumValue, after.ExclusiveMaximumValue, true)
+	c.numberBound(path+"/minimum", before.Minimum, after.Minimum, false)
+	c.flag(path+"/exclusiveMinimum", before.ExclusiveMinimum, after.ExclusiveMinimum)
+	c.numberBound(path+"/exclusiveMinimum", before.ExclusiveMinimumValue, after.ExclusiveMinimumValue, false)
+
+	// Strings.
+	c.integerBound(path+"/maxLength", before.MaxLength, after.MaxLength, true)
+	c.integerBound(path+"/minLength", before.MinLength, after.MinLength, false)
+	c.constraint(path+"/pattern", before.Pattern, after.Pattern)
+
+	// Arrays.
+	c.schemaList(path+"/prefixItems", before.PrefixItems, after.PrefixItems, Narrowing, Widening)
+	c.items(path+"/items", before.Items, after.Items)
+	c.subschema(path+"/additionalItems", booleanOrSchema(before.AdditionalItems), booleanOrSchema(after.AdditionalItems))
+	c.subschema(path+"/unevaluatedItems", booleanOrSchema(before.UnevaluatedItems), booleanOrSchema(after.UnevaluatedItems))
+	c.integerBound(path+"/maxItems", before.MaxItems, after.MaxItems, true)
+	c.integerBound(path+"/minItems", before.MinItems, after.MinItems, false)
+	c.flag(path+"/uniqueItems", before.UniqueItems, after.UniqueItems)
+	c.subschema(path+"/contains", before.Contains, after.Contains)
+	c.integerBound(path+"/maxContains", before.MaxContains, after.MaxContains, true)
+	c.integerBound(path+"/minContains", before.MinContains, after.MinContains, false)
+
+	// Objects.
+	c.integerBound(path+"/maxProperties", before.MaxProperties, after.MaxProperties, true)
+	c.integerBound(path+"/minProperties", before.MinProperties, after.MinProperties, false)
+	c.names(path+"/required", before.Required, after.Required)
+	c.properties(path+"/properties", before, after)
+	c.namedSchemas(path+"/patternProperties", before.PatternProperties, after.PatternProperties, Narrowing, Widening)
+	c.subschema(path+"/additionalProperties", booleanOrSchema(before.AdditionalProperties), booleanOrSchema(after.AdditionalProperties))
+	c.subschema(path+"/unevaluatedProperties", booleanOrSchema(before.UnevaluatedProperties), booleanOrSchema(after.UnevaluatedProperties))
+	c.subschema(path+"/propertyNames", before.PropertyNames, after.PropertyNames)
+	c.dependencies(path+"/dependencies", before.Dependencies, after.Dependencies)
+	c.dependencies(path+"/dependentRequired", before.DependentRequired, after.DependentRequired)
+	c.namedSchemas(path+"/dependentSchemas", before.DependentSchemas, after.DependentSchemas, Narrowing, Widening)
+
+	// Applicators.
+	c.schemaList(path+"/allOf", before.AllOf, after.AllOf, Narrowing, Widening)
+	c.alternatives(path+"/anyOf", before.AnyOf, after.AnyOf, false)
+	c.alternatives(path+"/oneOf", before.OneOf, after.OneOf, true)
+	c.invertedSubschema(path+"/not", before.Not, after.Not)
+	c.conditionalSubschema(path+"/if", before.If, after.If)
+	c.subschema(path+"/then", before.Then, after.Then)
+	c.subschema(path+"/else", before.Else, after.Else)
+	c.subschema(path+"/contentSchema", before.ContentSchema, after.ContentSchema)
+}
+
+// booleanEquivalent returns a schema with keywords that is equivalent to
+// a boolean schema.
+func booleanEquivalent(schema *Schema) *Schema {
+	if schema.Boolean == nil {
+		return schema
+	}
+	if *schema.Boolean {
+		return &Schema{}
+	}
+	return &Schema{Not: &Schema{}}
+}
+
+// schemaSummary returns a short description of a schema.
+func schemaSummary(schema *Schema) string {
+	switch {
+	case schema == nil:
+		return ""
+	case schema.Boolean != nil:
+		return strconv.FormatBool(*schema.Boolean)
+	case schema.Ref != nil:
+		return *schema.Ref
+	case schema.Type != nil:
+		return schema.Type.Description()
+	case schema.Title != nil:
+		return *schema.Title
+	default:
+		return "schema"
+	}
+}
+
+func schemaListSummary(schemas *[]*Schema) string {
+	summaries := make([]string, 0)
+	for _, schema := range *schemas {
+		summaries = append(summaries, schemaSummary(schema))
+	}
+	return strings.Join(summaries, ", ")
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolText(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func nodeText(node *yaml.Node) string {
+	node = instanceValue(node)
+	if node == nil {
+		return ""
+	}
+	return describeNode(node)
+}
+
+func schemaNumberRat(n *SchemaNumber) *big.Rat {
+	if n == nil {
+		return nil
+	}
+	return schemaNumberValue(n)
+}
+
+func integerRat(i *int64) *big.Rat {
+	if i == nil {
+		return nil
+	}
+	return new(big.Rat).SetInt64(*i)
+}
+
+func ratText(r *big.Rat) string {
+	if r == nil {
+		return ""
+	}
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	return strings.TrimRight(r.FloatString(6), "0")
+}
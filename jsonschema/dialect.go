@@ -0,0 +1,131 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import "strings"
+
+// A Dialect identifies the version of JSON Schema that a schema is written in.
+type Dialect int
+
+const (
+	// DialectUnspecified is used for schemas that don't declare a known dialect.
+	DialectUnspecified Dialect = iota
+	// DialectDraft04 is JSON Schema draft-04.
+	DialectDraft04
+	// DialectDraft06 is JSON Schema draft-06.
+	DialectDraft06
+	// DialectDraft07 is JSON Schema draft-07.
+	DialectDraft07
+	// Dialect201909 is JSON Schema 2019-09.
+	Dialect201909
+	// Dialect202012 is JSON Schema 2020-12.
+	Dialect202012
+)
+
+var dialectURIs = map[Dialect]string{
+	DialectDraft04: "http://json-schema.org/draft-04/schema#",
+	DialectDraft06: "http://json-schema.org/draft-06/schema#",
+	DialectDraft07: "http://json-schema.org/draft-07/schema#",
+	Dialect201909:  "https://json-schema.org/draft/2019-09/schema",
+	Dialect202012:  "https://json-schema.org/draft/2020-12/schema",
+}
+
+// URI returns the $schema value that identifies a dialect.
+func (d Dialect) URI() string {
+	return dialectURIs[d]
+}
+
+// String returns a short name for a dialect.
+func (d Dialect) String() string {
+	switch d {
+	case DialectDraft04:
+		return "draft-04"
+	case DialectDraft06:
+		return "draft-06"
+	case DialectDraft07:
+		return "draft-07"
+	case Dialect201909:
+		return "2019-09"
+	case Dialect202012:
+		return "2020-12"
+	default:
+		return "unspecified"
+	}
+}
+
+// normalizeDialectURI removes the parts of a $schema value that are
+// commonly varied without changing the dialect it names. Hyper-schemas
+// are written in the dialects that they extend.
+func normalizeDialectURI(uri string) string {
+	uri = strings.TrimSuffix(uri, "#")
+	uri = strings.Replace(uri, "/hyper-schema", "/schema", 1)
+	uri = strings.TrimPrefix(uri, "https://")
+	uri = strings.TrimPrefix(uri, "http://")
+	return uri
+}
+
+// DialectForURI returns the dialect identified by a $schema value.
+// The scheme and an empty fragment are ignored, so
+// "https://json-schema.org/draft-07/schema" is recognized as draft-07.
+func DialectForURI(uri string) Dialect {
+	normalized := normalizeDialectURI(uri)
+	for d, u := range dialectURIs {
+		if normalizeDialectURI(u) == normalized {
+			return d
+		}
+	}
+	return DialectUnspecified
+}
+
+// Dialect returns the dialect of a schema. This is taken from the schema's
+// $schema keyword if it has one, and otherwise from the closest enclosing
+// schema that declared a dialect when the schema was read.
+func (schema *Schema) Dialect() Dialect {
+	if schema.Schema != nil {
+		return DialectForURI(*schema.Schema)
+	}
+	return schema.dialect
+}
+
+// idKeyword returns the keyword used to write a schema's ID,
+// which was renamed from "id" to "$id" in draft-06.
+func (schema *Schema) idKeyword() string {
+	switch schema.Dialect() {
+	case DialectDraft04:
+		return "id"
+	case DialectUnspecified:
+		if schema.Schema == nil || *schema.Schema == "" || *schema.Schema == "#" {
+			return "id"
+		}
+	}
+	return "$id"
+}
+
+// hasConditionals returns true if "if", "then", and "else" are keywords
+// of a dialect. They were added in draft-07.
+func (d Dialect) hasConditionals() bool {
+	return d != DialectDraft04 && d != DialectDraft06
+}
+
+// hasDependentKeywords returns true if "dependentRequired" and
+// "dependentSchemas" are keywords of a dialect. They replaced
+// "dependencies" in 2019-09.
+func (d Dialect) hasDependentKeywords() bool {
+	switch d {
+	case DialectDraft04, DialectDraft06, DialectDraft07:
+		return false
+	}
+	return true
+}
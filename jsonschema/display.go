@@ -17,6 +17,8 @@ package jsonschema
 import (
 	"fmt"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 //
@@ -35,6 +37,39 @@ func (s *StringOrStringArray) Description() string {
 	return ""
 }
 
+// String returns a string representation of a number.
+func (n SchemaNumber) String() string {
+	if n.Integer != nil {
+		return fmt.Sprintf("%d", *n.Integer)
+	}
+	if n.Float != nil {
+		return fmt.Sprintf("%g", *n.Float)
+	}
+	return ""
+}
+
+// describeNode returns a one-line representation of a yaml.Node.
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value
+	case yaml.SequenceNode:
+		items := make([]string, 0)
+		for _, item := range node.Content {
+			items = append(items, describeNode(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case yaml.MappingNode:
+		pairs := make([]string, 0)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, node.Content[i].Value+": "+describeNode(node.Content[i+1]))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	default:
+		return fmt.Sprintf("%+v", *node)
+	}
+}
+
 // Returns a string representation of a Schema.
 func (schema *Schema) String() string {
 	return schema.describeSchema("")
@@ -43,9 +78,24 @@ func (schema *Schema) String() string {
 // Helper: Returns a string representation of a Schema indented by a specified string.
 func (schema *Schema) describeSchema(indent string) string {
 	result := ""
+	if schema.Boolean != nil {
+		result += indent + fmt.Sprintf("%+v\n", *(schema.Boolean))
+	}
 	if schema.Schema != nil {
 		result += indent + "$schema: " + *(schema.Schema) + "\n"
 	}
+	if schema.Comment != nil {
+		result += indent + "$comment: " + *(schema.Comment) + "\n"
+	}
+	if schema.Anchor != nil {
+		result += indent + "$anchor: " + *(schema.Anchor) + "\n"
+	}
+	if schema.DynamicAnchor != nil {
+		result += indent + "$dynamicAnchor: " + *(schema.DynamicAnchor) + "\n"
+	}
+	if schema.RecursiveAnchor != nil {
+		result += indent + fmt.Sprintf("$recursiveAnchor: %+v\n", *(schema.RecursiveAnchor))
+	}
 	if schema.ReadOnly != nil && *schema.ReadOnly {
 		result += indent + fmt.Sprintf("readOnly: %+v\n", *(schema.ReadOnly))
 	}
@@ -53,16 +103,7 @@ func (schema *Schema) describeSchema(indent string) string {
 		result += indent + fmt.Sprintf("writeOnly: %+v\n", *(schema.WriteOnly))
 	}
 	if schema.ID != nil {
-		switch strings.TrimSuffix(*schema.Schema, "#") {
-		case "http://json-schema.org/draft-04/schema#":
-			fallthrough
-		case "#":
-			fallthrough
-		case "":
-			result += indent + "id: " + *(schema.ID) + "\n"
-		default:
-			result += indent + "$id: " + *(schema.ID) + "\n"
-		}
+		result += indent + schema.idKeyword() + ": " + *(schema.ID) + "\n"
 	}
 	if schema.MultipleOf != nil {
 		result += indent + fmt.Sprintf("multipleOf: %+v\n", *(schema.MultipleOf))
@@ -73,12 +114,18 @@ func (schema *Schema) describeSchema(indent string) string {
 	if schema.ExclusiveMaximum != nil {
 		result += indent + fmt.Sprintf("exclusiveMaximum: %+v\n", *(schema.ExclusiveMaximum))
 	}
+	if schema.ExclusiveMaximumValue != nil {
+		result += indent + fmt.Sprintf("exclusiveMaximum: %+v\n", *(schema.ExclusiveMaximumValue))
+	}
 	if schema.Minimum != nil {
 		result += indent + fmt.Sprintf("minimum: %+v\n", *(schema.Minimum))
 	}
 	if schema.ExclusiveMinimum != nil {
 		result += indent + fmt.Sprintf("exclusiveMinimum: %+v\n", *(schema.ExclusiveMinimum))
 	}
+	if schema.ExclusiveMinimumValue != nil {
+		result += indent + fmt.Sprintf("exclusiveMinimum: %+v\n", *(schema.ExclusiveMinimumValue))
+	}
 	if schema.MaxLength != nil {
 		result += indent + fmt.Sprintf("maxLength: %+v\n", *(schema.MaxLength))
 	}
@@ -119,6 +166,31 @@ func (schema *Schema) describeSchema(indent string) string {
 	if schema.UniqueItems != nil {
 		result += indent + fmt.Sprintf("uniqueItems: %+v\n", *(schema.UniqueItems))
 	}
+	if schema.Contains != nil {
+		result += indent + "contains:\n"
+		result += schema.Contains.describeSchema(indent + "  ")
+	}
+	if schema.MaxContains != nil {
+		result += indent + fmt.Sprintf("maxContains: %+v\n", *(schema.MaxContains))
+	}
+	if schema.MinContains != nil {
+		result += indent + fmt.Sprintf("minContains: %+v\n", *(schema.MinContains))
+	}
+	if schema.PrefixItems != nil {
+		result += indent + "prefixItems:\n"
+		for i, s := range *(schema.PrefixItems) {
+			result += indent + "  " + fmt.Sprintf("%d", i) + ":\n"
+			result += s.describeSchema(indent + "  " + "  ")
+		}
+	}
+	if schema.UnevaluatedItems != nil {
+		if s := schema.UnevaluatedItems.Schema; s != nil {
+			result += indent + "unevaluatedItems:\n"
+			result += s.describeSchema(indent + "  ")
+		} else if b := schema.UnevaluatedItems.Boolean; b != nil {
+			result += indent + fmt.Sprintf("unevaluatedItems: %+v\n", *b)
+		}
+	}
 	if schema.MaxProperties != nil {
 		result += indent + fmt.Sprintf("maxProperties: %+v\n", *(schema.MaxProperties))
 	}
@@ -177,16 +249,48 @@ func (schema *Schema) describeSchema(indent string) string {
 
 		}
 	}
+	if schema.PropertyNames != nil {
+		result += indent + "propertyNames:\n"
+		result += schema.PropertyNames.describeSchema(indent + "  ")
+	}
+	if schema.DependentRequired != nil {
+		result += indent + "dependentRequired:\n"
+		for _, pair := range *(schema.DependentRequired) {
+			if a := pair.Value.StringArray; a != nil {
+				result += indent + "  " + pair.Name + fmt.Sprintf(": %+v\n", *a)
+			}
+		}
+	}
+	if schema.DependentSchemas != nil {
+		result += indent + "dependentSchemas:\n"
+		for _, pair := range *(schema.DependentSchemas) {
+			result += indent + "  " + pair.Name + ":\n"
+			result += pair.Value.describeSchema(indent + "  " + "  ")
+		}
+	}
+	if schema.UnevaluatedProperties != nil {
+		if s := schema.UnevaluatedProperties.Schema; s != nil {
+			result += indent + "unevaluatedProperties:\n"
+			result += s.describeSchema(indent + "  ")
+		} else if b := schema.UnevaluatedProperties.Boolean; b != nil {
+			result += indent + fmt.Sprintf("unevaluatedProperties: %+v\n", *b)
+		}
+	}
 	if schema.Enumeration != nil {
 		result += indent + "enumeration:\n"
 		for _, value := range *(schema.Enumeration) {
 			if value.String != nil {
 				result += indent + "  " + fmt.Sprintf("%+v\n", *value.String)
-			} else {
+			} else if value.Bool != nil {
 				result += indent + "  " + fmt.Sprintf("%+v\n", *value.Bool)
+			} else if value.Value != nil {
+				result += indent + "  " + describeNode(value.Value) + "\n"
 			}
 		}
 	}
+	if schema.Const != nil {
+		result += indent + "const: " + describeNode(schema.Const) + "\n"
+	}
 	if schema.Type != nil {
 		result += indent + fmt.Sprintf("type: %+v\n", schema.Type.Description())
 	}
@@ -215,6 +319,18 @@ func (schema *Schema) describeSchema(indent string) string {
 		result += indent + "not:\n"
 		result += schema.Not.describeSchema(indent + "  ")
 	}
+	if schema.If != nil {
+		result += indent + "if:\n"
+		result += schema.If.describeSchema(indent + "  ")
+	}
+	if schema.Then != nil {
+		result += indent + "then:\n"
+		result += schema.Then.describeSchema(indent + "  ")
+	}
+	if schema.Else != nil {
+		result += indent + "else:\n"
+		result += schema.Else.describeSchema(indent + "  ")
+	}
 	if schema.Definitions != nil {
 		result += indent + "definitions:\n"
 		for _, pair := range *(schema.Definitions) {
@@ -224,6 +340,13 @@ func (schema *Schema) describeSchema(indent string) string {
 			result += s.describeSchema(indent + "  " + "  ")
 		}
 	}
+	if schema.Defs != nil {
+		result += indent + "$defs:\n"
+		for _, pair := range *(schema.Defs) {
+			result += indent + "  " + pair.Name + ":\n"
+			result += pair.Value.describeSchema(indent + "  " + "  ")
+		}
+	}
 	if schema.Title != nil {
 		result += indent + "title: " + *(schema.Title) + "\n"
 	}
@@ -234,11 +357,66 @@ func (schema *Schema) describeSchema(indent string) string {
 		result += indent + "default:\n"
 		result += indent + fmt.Sprintf("  %+v\n", *(schema.Default))
 	}
+	if schema.Examples != nil {
+		result += indent + "examples: " + describeNode(schema.Examples) + "\n"
+	}
+	if schema.Deprecated != nil {
+		result += indent + fmt.Sprintf("deprecated: %+v\n", *(schema.Deprecated))
+	}
+	if schema.ContentEncoding != nil {
+		result += indent + "contentEncoding: " + *(schema.ContentEncoding) + "\n"
+	}
+	if schema.ContentMediaType != nil {
+		result += indent + "contentMediaType: " + *(schema.ContentMediaType) + "\n"
+	}
+	if schema.ContentSchema != nil {
+		result += indent + "contentSchema:\n"
+		result += schema.ContentSchema.describeSchema(indent + "  ")
+	}
 	if schema.Format != nil {
 		result += indent + "format: " + *(schema.Format) + "\n"
 	}
+	if schema.Base != nil {
+		result += indent + "base: " + *(schema.Base) + "\n"
+	}
+	if schema.Links != nil {
+		result += indent + "links:\n"
+		for _, link := range *(schema.Links) {
+			result += indent + "  " + link.describeLink() + "\n"
+			for _, pair := range link.subschemas() {
+				result += indent + "    " + pair.Name + ":\n"
+				result += pair.Value.describeSchema(indent + "    " + "  ")
+			}
+		}
+	}
 	if schema.Ref != nil {
 		result += indent + "$ref: " + *(schema.Ref) + "\n"
 	}
+	if schema.RecursiveRef != nil {
+		result += indent + "$recursiveRef: " + *(schema.RecursiveRef) + "\n"
+	}
+	if schema.DynamicRef != nil {
+		result += indent + "$dynamicRef: " + *(schema.DynamicRef) + "\n"
+	}
+	return result
+}
+
+// describeLink returns a one-line description of a link: its relation
+// types, method, and href.
+func (link *Link) describeLink() string {
+	result := "-"
+	if link.Rel != nil {
+		if link.Rel.String != nil {
+			result += " " + *link.Rel.String
+		} else if link.Rel.StringArray != nil {
+			result += " " + strings.Join(*link.Rel.StringArray, ",")
+		}
+	}
+	if link.Method != nil {
+		result += " " + *link.Method
+	}
+	if link.Href != nil {
+		result += " " + *link.Href
+	}
 	return result
 }
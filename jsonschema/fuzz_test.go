@@ -0,0 +1,51 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package jsonschema
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/internal/fuzzing"
+)
+
+// FuzzNewSchemaFromObject checks that malformed schemas can be read,
+// bundled, and written without panicking. Run it with
+//
+//	go test ./jsonschema -run=NONE -fuzz=FuzzNewSchemaFromObject
+func FuzzNewSchemaFromObject(f *testing.F) {
+	fuzzing.AddFiles(f,
+		"schema.json",
+		"../openapiv2/openapi-2.0.json",
+		"../openapiv3/openapi-3.*.json",
+		"../discovery/discovery.json",
+	)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var node yaml.Node
+		if yaml.Unmarshal(b, &node) != nil || len(node.Content) == 0 {
+			return
+		}
+		schema := NewSchemaFromObject(node.Content[0])
+		if schema == nil {
+			return
+		}
+		schema.Bundle()
+		schema.JSONString()
+	})
+}
@@ -0,0 +1,527 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A GoTypeGenerator converts JSON Schemas into Go type declarations.
+// Objects become structs with json tags, string enumerations become named
+// string types with constants, oneOf and anyOf become structs with one
+// field for each alternative, and objects without properties become maps.
+type GoTypeGenerator struct {
+	// PackageName is the name of the package of the generated code.
+	PackageName string
+	// Generator names the program in the "Code generated" comment.
+	Generator string
+
+	declarations []string
+	// names of the types that have been declared
+	names map[string]bool
+	// types that have been declared for schemas
+	schemaTypes map[*Schema]string
+	// types that were added by name, used to match references by name
+	addedTypes map[string]*Schema
+	// types that are structs, which are used with pointers when optional
+	structs map[string]bool
+	unions  bool
+}
+
+// NewGoTypeGenerator creates a generator for a package.
+func NewGoTypeGenerator(packageName string) *GoTypeGenerator {
+	return &GoTypeGenerator{
+		PackageName: packageName,
+		Generator:   "gnostic",
+		names:       make(map[string]bool),
+		schemaTypes: make(map[*Schema]string),
+		addedTypes:  make(map[string]*Schema),
+		structs:     make(map[string]bool),
+	}
+}
+
+// AddSchemas adds named schemas, which are declared in name order after
+// all of them have been added so that references between them can be
+// matched by name. References match an added schema if they resolve to
+// it or if their last path segment is its name, as with
+// "#/components/schemas/Pet" in OpenAPI descriptions.
+func (g *GoTypeGenerator) AddSchemas(schemas map[string]*Schema) {
+	names := make([]string, 0, len(schemas))
+	for name, schema := range schemas {
+		names = append(names, name)
+		g.addedTypes[name] = schema
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.AddSchema(name, schemas[name])
+	}
+}
+
+// AddSchema adds a type declaration for a schema, with a name derived from
+// the specified name. Types are also declared for the nested objects,
+// enumerations, and alternatives that the schema contains.
+func (g *GoTypeGenerator) AddSchema(name string, schema *Schema) string {
+	if typeName, ok := g.schemaTypes[schema]; ok {
+		return typeName
+	}
+	g.addedTypes[name] = schema
+	return g.declare(schema, name)
+}
+
+// Generate returns the formatted source code of the declared types.
+func (g *GoTypeGenerator) Generate() ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by %s. DO NOT EDIT.\n\n", g.Generator)
+	fmt.Fprintf(&b, "package %s\n\n", g.PackageName)
+	if g.unions {
+		b.WriteString("import (\n\t\"bytes\"\n\t\"encoding/json\"\n\t\"fmt\"\n)\n\n")
+	}
+	for _, declaration := range g.declarations {
+		b.WriteString(declaration)
+		b.WriteString("\n")
+	}
+	if g.unions {
+		b.WriteString(`// strictUnmarshal reads JSON into a value, failing if the JSON has fields
+// that the value doesn't.
+func strictUnmarshal(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+`)
+	}
+	source := []byte(b.String())
+	formatted, err := format.Source(source)
+	if err != nil {
+		return source, err
+	}
+	return formatted, nil
+}
+
+// typeName returns an unused Go type name based on a name from a schema.
+func (g *GoTypeGenerator) typeName(name string) string {
+	base := GoName(name)
+	if base == "" {
+		base = "Type"
+	}
+	result := base
+	for i := 2; g.names[result]; i++ {
+		result = base + strconv.Itoa(i)
+	}
+	g.names[result] = true
+	return result
+}
+
+// referencedSchema returns the schema that a reference refers to and the
+// name of a declared or added type for it, if there is one.
+func (g *GoTypeGenerator) referencedSchema(schema *Schema) (*Schema, string) {
+	ref := *schema.Ref
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	if i := strings.LastIndex(name, "#"); i >= 0 {
+		name = name[i+1:]
+	}
+	target, err := schema.ResolveReference(ref)
+	if err == nil {
+		if typeName, ok := g.schemaTypes[target]; ok {
+			return target, typeName
+		}
+		// A schema may have been added with several names; use the first.
+		names := make([]string, 0, len(g.addedTypes))
+		for addedName, added := range g.addedTypes {
+			if added == target {
+				names = append(names, addedName)
+			}
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			return target, g.AddSchema(names[0], target)
+		}
+		return target, ""
+	}
+	if added, ok := g.addedTypes[name]; ok {
+		return added, g.AddSchema(name, added)
+	}
+	return nil, ""
+}
+
+// goType returns the Go type for a schema, declaring named types where they are needed.
+func (g *GoTypeGenerator) goType(schema *Schema, name string) string {
+	if schema == nil || schema.Boolean != nil {
+		return "interface{}"
+	}
+	if typeName, ok := g.schemaTypes[schema]; ok {
+		return typeName
+	}
+	if schema.Ref != nil {
+		target, typeName := g.referencedSchema(schema)
+		if typeName != "" {
+			return typeName
+		}
+		if target != nil {
+			refName := *schema.Ref
+			return g.declare(target, refName[strings.LastIndexAny(refName, "/#")+1:])
+		}
+		return "interface{}"
+	}
+	if needsDeclaration(schema) {
+		return g.declare(schema, name)
+	}
+	return g.inlineType(schema, name)
+}
+
+// needsDeclaration returns true for schemas that are represented with named types.
+func needsDeclaration(schema *Schema) bool {
+	return stringEnumeration(schema) != nil ||
+		schema.OneOf != nil || schema.AnyOf != nil || schema.AllOf != nil ||
+		schema.Properties != nil
+}
+
+// schemaTypes returns the types allowed by a schema, without "null".
+func typesOfSchema(schema *Schema) (types []string, nullable bool) {
+	if schema.Type == nil {
+		return nil, false
+	}
+	all := []string{}
+	if schema.Type.String != nil {
+		all = append(all, *schema.Type.String)
+	} else if schema.Type.StringArray != nil {
+		all = *schema.Type.StringArray
+	}
+	for _, t := range all {
+		if t == "null" {
+			nullable = true
+		} else {
+			types = append(types, t)
+		}
+	}
+	return types, nullable
+}
+
+// inlineType returns a type expression for a schema that doesn't need a named type.
+func (g *GoTypeGenerator) inlineType(schema *Schema, name string) string {
+	types, nullable := typesOfSchema(schema)
+	if len(types) != 1 {
+		if len(types) == 0 && schema.Items != nil {
+			types = []string{"array"}
+		} else if len(types) == 0 && schema.AdditionalProperties != nil {
+			types = []string{"object"}
+		} else {
+			return "interface{}"
+		}
+	}
+	format := ""
+	if schema.Format != nil {
+		format = *schema.Format
+	}
+	var result string
+	switch types[0] {
+	case "string":
+		result = "string"
+		if format == "byte" || format == "binary" {
+			return "[]byte"
+		}
+	case "integer":
+		result = "int64"
+		if format == "int32" {
+			result = "int32"
+		}
+	case "number":
+		result = "float64"
+		if format == "float" {
+			result = "float32"
+		}
+	case "boolean":
+		result = "bool"
+	case "array":
+		if schema.Items != nil && schema.Items.Schema != nil {
+			return "[]" + g.fieldType(schema.Items.Schema, name+"Item", true)
+		}
+		return "[]interface{}"
+	case "object":
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "map[string]" + g.fieldType(schema.AdditionalProperties.Schema, name+"Value", true)
+		}
+		return "map[string]interface{}"
+	default:
+		return "interface{}"
+	}
+	if nullable {
+		return "*" + result
+	}
+	return result
+}
+
+// fieldType returns the type used for a field or element with a schema.
+// Optional structs and nullable values are pointers.
+func (g *GoTypeGenerator) fieldType(schema *Schema, name string, required bool) string {
+	t := g.goType(schema, name)
+	if strings.HasPrefix(t, "*") {
+		return t
+	}
+	_, nullable := typesOfSchema(schema)
+	if g.structs[t] && (nullable || !required) {
+		return "*" + t
+	}
+	if nullable && !strings.HasPrefix(t, "[]") && !strings.HasPrefix(t, "map[") && t != "interface{}" {
+		return "*" + t
+	}
+	return t
+}
+
+// stringEnumeration returns the values of a schema's enum if they are all strings.
+func stringEnumeration(schema *Schema) []string {
+	if schema.Enumeration == nil || len(*schema.Enumeration) == 0 {
+		return nil
+	}
+	values := make([]string, 0)
+	for _, value := range *schema.Enumeration {
+		if value.String == nil {
+			return nil
+		}
+		values = append(values, *value.String)
+	}
+	return values
+}
+
+// comment returns a Go comment for a declaration from a schema description.
+func comment(name string, schema *Schema, indent string) string {
+	description := ""
+	if schema.Description != nil {
+		description = strings.TrimSpace(*schema.Description)
+	} else if schema.Title != nil {
+		description = strings.TrimSpace(*schema.Title)
+	}
+	if description == "" {
+		return ""
+	}
+	var b strings.Builder
+	for i, line := range strings.Split(name+" "+description, "\n") {
+		if i > 0 && strings.TrimSpace(line) == "" {
+			b.WriteString(indent + "//\n")
+		} else {
+			b.WriteString(indent + "// " + strings.TrimRight(line, " \t") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// declare adds a named type for a schema and returns its name.
+func (g *GoTypeGenerator) declare(schema *Schema, name string) string {
+	typeName := g.typeName(name)
+	g.schemaTypes[schema] = typeName
+	// reserve a place so that types precede the nested types that they declare
+	index := len(g.declarations)
+	g.declarations = append(g.declarations, "")
+	var declaration string
+	switch {
+	case stringEnumeration(schema) != nil:
+		declaration = g.enumDeclaration(schema, typeName)
+	case schema.OneOf != nil || schema.AnyOf != nil:
+		g.structs[typeName] = true
+		declaration = g.unionDeclaration(schema, typeName)
+	case schema.Properties != nil || schema.AllOf != nil:
+		g.structs[typeName] = true
+		declaration = g.structDeclaration(schema, typeName)
+	default:
+		declaration = comment(typeName, schema, "") + "type " + typeName + " " + g.inlineType(schema, typeName) + "\n"
+	}
+	g.declarations[index] = declaration
+	return typeName
+}
+
+func (g *GoTypeGenerator) enumDeclaration(schema *Schema, typeName string) string {
+	var b strings.Builder
+	b.WriteString(comment(typeName, schema, ""))
+	fmt.Fprintf(&b, "type %s string\n\n", typeName)
+	fmt.Fprintf(&b, "// Values of %s.\n", typeName)
+	b.WriteString("const (\n")
+	used := make(map[string]bool)
+	for _, value := range stringEnumeration(schema) {
+		constant := typeName + GoName(value)
+		if used[constant] || constant == typeName {
+			constant = fmt.Sprintf("%s%d", typeName, len(used))
+		}
+		used[constant] = true
+		fmt.Fprintf(&b, "\t%s %s = %s\n", constant, typeName, strconv.Quote(value))
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// structFields writes the fields of an object schema, including those that
+// come from inline allOf members, and returns the names of the fields.
+func (g *GoTypeGenerator) structFields(b *strings.Builder, schema *Schema, typeName string, used map[string]bool) {
+	if schema.AllOf != nil {
+		for _, member := range *schema.AllOf {
+			if member.Ref != nil {
+				if _, embedded := g.referencedSchema(member); embedded != "" && g.structs[embedded] {
+					fmt.Fprintf(b, "\t%s\n", embedded)
+					used[embedded] = true
+					continue
+				}
+				if target, _ := g.referencedSchema(member); target != nil {
+					member = target
+				}
+			}
+			g.structFields(b, member, typeName, used)
+		}
+	}
+	if schema.Properties == nil {
+		return
+	}
+	required := make(map[string]bool)
+	if schema.Required != nil {
+		for _, name := range *schema.Required {
+			required[name] = true
+		}
+	}
+	for _, property := range *schema.Properties {
+		fieldName := GoName(property.Name)
+		if fieldName == "" {
+			fieldName = "Field"
+		}
+		for i := 2; used[fieldName]; i++ {
+			fieldName = GoName(property.Name) + strconv.Itoa(i)
+		}
+		used[fieldName] = true
+		fieldType := g.fieldType(property.Value, typeName+GoName(property.Name), required[property.Name])
+		tag := property.Name
+		if !required[property.Name] {
+			tag += ",omitempty"
+		}
+		b.WriteString(comment(fieldName, property.Value, "\t"))
+		fmt.Fprintf(b, "\t%s %s `json:%s`\n", fieldName, fieldType, strconv.Quote(tag))
+	}
+}
+
+func (g *GoTypeGenerator) structDeclaration(schema *Schema, typeName string) string {
+	var fields strings.Builder
+	g.structFields(&fields, schema, typeName, make(map[string]bool))
+	return comment(typeName, schema, "") + "type " + typeName + " struct {\n" + fields.String() + "}\n"
+}
+
+func (g *GoTypeGenerator) unionDeclaration(schema *Schema, typeName string) string {
+	g.unions = true
+	alternatives := schema.OneOf
+	keyword := "oneOf"
+	if alternatives == nil {
+		alternatives = schema.AnyOf
+		keyword = "anyOf"
+	}
+	type field struct{ name, goType string }
+	fields := make([]field, 0)
+	used := make(map[string]bool)
+	for i, alternative := range *alternatives {
+		t := g.goType(alternative, fmt.Sprintf("%sOption%d", typeName, i+1))
+		t = strings.TrimPrefix(t, "*")
+		base := GoName(strings.NewReplacer("[]", "Array_", "map[string]", "Map_", "interface{}", "Value").Replace(t))
+		name := base
+		for j := 2; used[name]; j++ {
+			name = fmt.Sprintf("%s%d", base, j)
+		}
+		used[name] = true
+		fields = append(fields, field{name: name, goType: t})
+	}
+
+	var b strings.Builder
+	description := comment(typeName, schema, "")
+	if description == "" {
+		names := make([]string, 0)
+		for _, f := range fields {
+			names = append(names, f.name)
+		}
+		description = fmt.Sprintf("// %s holds one of %s (%s).\n", typeName, strings.Join(names, ", "), keyword)
+	}
+	b.WriteString(description)
+	fmt.Fprintf(&b, "type %s struct {\n", typeName)
+	for _, f := range fields {
+		fmt.Fprintf(&b, "\t%s *%s\n", f.name, f.goType)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "// MarshalJSON writes the alternative that is set.\n")
+	fmt.Fprintf(&b, "func (u %s) MarshalJSON() ([]byte, error) {\n", typeName)
+	for _, f := range fields {
+		fmt.Fprintf(&b, "\tif u.%s != nil {\n\t\treturn json.Marshal(u.%s)\n\t}\n", f.name, f.name)
+	}
+	b.WriteString("\treturn []byte(\"null\"), nil\n}\n\n")
+
+	fmt.Fprintf(&b, "// UnmarshalJSON reads the first alternative that accepts the JSON.\n")
+	fmt.Fprintf(&b, "func (u *%s) UnmarshalJSON(data []byte) error {\n", typeName)
+	fmt.Fprintf(&b, "\t*u = %s{}\n", typeName)
+	for _, f := range fields {
+		fmt.Fprintf(&b, "\t{\n\t\tvar v %s\n\t\tif err := strictUnmarshal(data, &v); err == nil {\n\t\t\tu.%s = &v\n\t\t\treturn nil\n\t\t}\n\t}\n", f.goType, f.name)
+	}
+	fmt.Fprintf(&b, "\treturn fmt.Errorf(\"JSON does not match any alternative of %s\")\n}\n", typeName)
+	return b.String()
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]bool{
+	"api": true, "ascii": true, "cpu": true, "css": true, "dns": true, "eof": true,
+	"guid": true, "html": true, "http": true, "https": true, "id": true, "ip": true,
+	"json": true, "lhs": true, "qps": true, "ram": true, "rhs": true, "rpc": true,
+	"sla": true, "smtp": true, "sql": true, "ssh": true, "tcp": true, "tls": true,
+	"ttl": true, "udp": true, "ui": true, "uid": true, "uri": true, "url": true,
+	"utf8": true, "uuid": true, "vm": true, "xml": true,
+}
+
+// GoName converts a name from a schema into an exported Go identifier,
+// such as "pet_id" to "PetID" and "x-rate-limit" to "XRateLimit".
+func GoName(name string) string {
+	words := make([]string, 0)
+	word := make([]rune, 0)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			// a new word starts at "Id" in "petId" and at "Name" in "HTTPName"
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+	var b strings.Builder
+	for _, w := range words {
+		lower := strings.ToLower(w)
+		if initialisms[lower] {
+			b.WriteString(strings.ToUpper(w))
+		} else {
+			r := []rune(w)
+			b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+		}
+	}
+	result := b.String()
+	if result != "" && !unicode.IsLetter([]rune(result)[0]) {
+		result = "X" + result
+	}
+	return result
+}
@@ -0,0 +1,972 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func parseSchema(t *testing.T, text string) *Schema {
+	t.Helper()
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	schema := NewSchemaFromObject(&node)
+	if schema == nil {
+		t.Fatalf("failed to read schema")
+	}
+	return schema
+}
+
+func TestDraft07Keywords(t *testing.T) {
+	schema := parseSchema(t, `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "http://example.com/pet.json",
+  "$comment": "pets",
+  "type": "object",
+  "propertyNames": {"pattern": "^[a-z]+$"},
+  "properties": {
+    "id": {"type": "integer", "readOnly": true},
+    "password": {"type": "string", "writeOnly": true},
+    "kind": {"const": "pet"},
+    "tags": {"type": "array", "contains": {"type": "string"}}
+  },
+  "if": {"properties": {"kind": {"const": "dog"}}},
+  "then": {"required": ["breed"]},
+  "else": {"required": ["name"]}
+}`)
+	if schema.ID == nil || *schema.ID != "http://example.com/pet.json" {
+		t.Errorf("unexpected $id %v", schema.ID)
+	}
+	if schema.Comment == nil || *schema.Comment != "pets" {
+		t.Errorf("unexpected $comment %v", schema.Comment)
+	}
+	if schema.PropertyNames == nil || schema.PropertyNames.Pattern == nil {
+		t.Errorf("propertyNames not read")
+	}
+	if schema.If == nil || schema.Then == nil || schema.Else == nil {
+		t.Fatalf("if/then/else not read")
+	}
+	if schema.Then.Required == nil || (*schema.Then.Required)[0] != "breed" {
+		t.Errorf("unexpected then %+v", schema.Then)
+	}
+	properties := map[string]*Schema{}
+	for _, pair := range *schema.Properties {
+		properties[pair.Name] = pair.Value
+	}
+	if p := properties["id"]; p.ReadOnly == nil || !*p.ReadOnly {
+		t.Errorf("readOnly not read")
+	}
+	if p := properties["password"]; p.WriteOnly == nil || !*p.WriteOnly {
+		t.Errorf("writeOnly not read")
+	}
+	if p := properties["kind"]; p.Const == nil || p.Const.Value != "pet" {
+		t.Errorf("const not read")
+	}
+	if p := properties["tags"]; p.Contains == nil || !p.Contains.TypeIs("string") {
+		t.Errorf("contains not read")
+	}
+
+	count := 0
+	schema.applyToSchemas(func(s *Schema, context string) { count++ }, "")
+	// root, 4 properties, tags.contains, propertyNames, if, if.kind, then, else
+	if count != 11 {
+		t.Errorf("visited %d schemas, expected 11", count)
+	}
+
+	text := schema.JSONString()
+	for _, keyword := range []string{`"$id"`, `"$comment"`, `"propertyNames"`, `"readOnly": true`,
+		`"writeOnly": true`, `"const": "pet"`, `"contains"`, `"if"`, `"then"`, `"else"`} {
+		if !strings.Contains(text, keyword) {
+			t.Errorf("output does not contain %s:\n%s", keyword, text)
+		}
+	}
+	// The written schema should read back to the same schema.
+	if !parseSchema(t, text).IsEqual(schema) {
+		t.Errorf("schema changed after writing and reading:\n%s", text)
+	}
+}
+
+func TestIDWithoutSchema(t *testing.T) {
+	schema := parseSchema(t, `{"id": "http://example.com/a.json", "type": "string"}`)
+	if text := schema.JSONString(); !strings.Contains(text, `"id": "http://example.com/a.json"`) {
+		t.Errorf("unexpected output %s", text)
+	}
+	if description := schema.String(); !strings.Contains(description, "id: http://example.com/a.json") {
+		t.Errorf("unexpected description %s", description)
+	}
+}
+
+func TestDialects(t *testing.T) {
+	for _, test := range []struct {
+		uri     string
+		dialect Dialect
+	}{
+		{"http://json-schema.org/draft-04/schema#", DialectDraft04},
+		{"http://json-schema.org/draft-06/schema#", DialectDraft06},
+		{"https://json-schema.org/draft-07/schema", DialectDraft07},
+		{"https://json-schema.org/draft/2019-09/schema", Dialect201909},
+		{"https://json-schema.org/draft/2020-12/schema#", Dialect202012},
+		{"https://example.com/custom", DialectUnspecified},
+	} {
+		if d := DialectForURI(test.uri); d != test.dialect {
+			t.Errorf("%s: got %s, expected %s", test.uri, d, test.dialect)
+		}
+	}
+}
+
+func TestModernKeywords(t *testing.T) {
+	schema := parseSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/tree",
+  "$dynamicAnchor": "node",
+  "type": "object",
+  "properties": {
+    "data": true,
+    "point": {
+      "type": "array",
+      "prefixItems": [{"type": "number"}, {"type": "number"}],
+      "items": false,
+      "minContains": 1,
+      "maxContains": 2,
+      "contains": {"type": "number"}
+    },
+    "size": {"type": "integer", "exclusiveMinimum": 0, "exclusiveMaximum": 100},
+    "children": {"type": "array", "items": {"$dynamicRef": "#node"}},
+    "legacy": {"$recursiveRef": "#", "$recursiveAnchor": true, "deprecated": true},
+    "photo": {"type": "string", "contentEncoding": "base64", "contentMediaType": "image/png"}
+  },
+  "dependentRequired": {"size": ["children"]},
+  "dependentSchemas": {"photo": {"required": ["size"]}},
+  "unevaluatedProperties": false,
+  "examples": [{"size": 1}],
+  "$defs": {
+    "name": {"$anchor": "name", "type": "string", "unevaluatedItems": {"type": "string"}}
+  }
+}`)
+	if schema.Dialect() != Dialect202012 {
+		t.Errorf("unexpected dialect %s", schema.Dialect())
+	}
+	point := schema.PropertyWithName("point")
+	if point.Dialect() != Dialect202012 {
+		t.Errorf("subschema dialect %s, expected 2020-12", point.Dialect())
+	}
+	if point.PrefixItems == nil || len(*point.PrefixItems) != 2 {
+		t.Errorf("prefixItems not read")
+	}
+	if point.MinContains == nil || *point.MinContains != 1 || point.MaxContains == nil || *point.MaxContains != 2 {
+		t.Errorf("minContains/maxContains not read")
+	}
+	size := schema.PropertyWithName("size")
+	if size.ExclusiveMinimumValue == nil || size.ExclusiveMaximumValue == nil || size.ExclusiveMaximum != nil {
+		t.Errorf("numeric exclusive bounds not read")
+	}
+	if children := schema.PropertyWithName("children"); children.Items.Schema.DynamicRef == nil {
+		t.Errorf("$dynamicRef not read")
+	}
+	if legacy := schema.PropertyWithName("legacy"); legacy.RecursiveRef == nil || legacy.RecursiveAnchor == nil {
+		t.Errorf("$recursiveRef/$recursiveAnchor not read")
+	}
+	if schema.DependentRequired == nil || schema.DependentSchemas == nil {
+		t.Errorf("dependentRequired/dependentSchemas not read")
+	}
+	if schema.UnevaluatedProperties == nil || schema.UnevaluatedProperties.Boolean == nil {
+		t.Errorf("unevaluatedProperties not read")
+	}
+	name := schema.DefWithName("name")
+	if name == nil || name.Anchor == nil || *name.Anchor != "name" || name.UnevaluatedItems == nil {
+		t.Errorf("$defs not read")
+	}
+
+	text := schema.JSONString()
+	for _, keyword := range []string{`"$id"`, `"$dynamicAnchor"`, `"$dynamicRef"`, `"$recursiveRef"`,
+		`"$anchor"`, `"$defs"`, `"prefixItems"`, `"unevaluatedProperties": false`, `"dependentRequired"`,
+		`"dependentSchemas"`, `"contentEncoding"`, `"examples"`, `"exclusiveMinimum"`} {
+		if !strings.Contains(text, keyword) {
+			t.Errorf("output does not contain %s:\n%s", keyword, text)
+		}
+	}
+	if !parseSchema(t, text).IsEqual(schema) {
+		t.Errorf("schema changed after writing and reading:\n%s", text)
+	}
+}
+
+func TestResolveReferenceWithinDocument(t *testing.T) {
+	schema := parseSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/root.json",
+  "$defs": {
+    "a/b": {"type": "integer"},
+    "named": {"$anchor": "name", "type": "string"},
+    "embedded": {
+      "$id": "https://example.com/nested/embedded.json",
+      "$defs": {"local": {"type": "boolean"}},
+      "properties": {"x": {"$ref": "#/$defs/local"}, "y": {"$ref": "../root.json#name"}}
+    }
+  },
+  "properties": {
+    "p": {"$ref": "#/$defs/a~1b"},
+    "q": {"$ref": "#name"},
+    "r": {"$ref": "nested/embedded.json#/$defs/local"},
+    "s": {"type": "array", "prefixItems": [{"type": "number"}, {"$ref": "#/properties/s/prefixItems/0"}]}
+  }
+}`)
+	for _, test := range []struct {
+		from     *Schema
+		ref      string
+		typeName string
+	}{
+		{schema.PropertyWithName("p"), "#/$defs/a~1b", "integer"},
+		{schema.PropertyWithName("q"), "#name", "string"},
+		{schema.PropertyWithName("r"), "nested/embedded.json#/$defs/local", "boolean"},
+		{schema, "https://example.com/root.json#/$defs/named", "string"},
+		{schema.DefWithName("embedded").PropertyWithName("x"), "#/$defs/local", "boolean"},
+		{schema.DefWithName("embedded").PropertyWithName("y"), "../root.json#name", "string"},
+		{schema, "#/properties/s/prefixItems/1", ""},
+	} {
+		resolved, err := test.from.ResolveReference(test.ref)
+		if err != nil {
+			t.Errorf("%s: %+v", test.ref, err)
+			continue
+		}
+		if test.typeName != "" && !resolved.TypeIs(test.typeName) {
+			t.Errorf("%s: resolved to %s", test.ref, resolved.String())
+		}
+	}
+	if _, err := schema.ResolveReference("#/$defs/missing"); err == nil {
+		t.Errorf("expected an error for a missing definition")
+	}
+	if _, err := schema.ResolveReference("#missing"); err == nil {
+		t.Errorf("expected an error for a missing anchor")
+	}
+}
+
+func TestResolveReferenceWithLegacyIDs(t *testing.T) {
+	schema := parseSchema(t, `{
+  "id": "http://example.com/legacy.json#",
+  "definitions": {
+    "item": {"id": "#item", "type": "string"},
+    "list": {"type": "array", "items": {"$ref": "#item"}}
+  }
+}`)
+	list := schema.DefinitionWithName("list")
+	resolved, err := list.Items.Schema.ResolveReference(*list.Items.Schema.Ref)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if resolved != schema.DefinitionWithName("item") {
+		t.Errorf("unexpected resolution %s", resolved.String())
+	}
+}
+
+func TestResolveReferenceAcrossFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jsonschema")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, text string) string {
+		filename := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return filename
+	}
+	write("common.json", `{"definitions": {"id": {"type": "integer", "minimum": 1}}}`)
+	main := write("main.json", `{
+  "type": "object",
+  "properties": {"id": {"$ref": "common.json#/definitions/id"}}
+}`)
+	schema, err := NewSchemaFromFile(main)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	resolved, err := schema.PropertyWithName("id").ResolveReference("common.json#/definitions/id")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !resolved.TypeIs("integer") {
+		t.Errorf("unexpected resolution %s", resolved.String())
+	}
+	schema.ResolveRefs()
+	if id := schema.PropertyWithName("id"); id.Ref != nil || id.Minimum == nil {
+		t.Errorf("reference was not replaced: %s", id.String())
+	}
+}
+
+func parseInstance(t *testing.T, text string) *yaml.Node {
+	t.Helper()
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return &node
+}
+
+func TestValidate(t *testing.T) {
+	schema := parseSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["id", "name"],
+  "properties": {
+    "id": {"type": "integer", "minimum": 1, "multipleOf": 1},
+    "name": {"type": "string", "minLength": 1, "maxLength": 8, "pattern": "^[a-z]+$"},
+    "kind": {"enum": ["cat", "dog", 3, null]},
+    "price": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.01},
+    "tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true, "maxItems": 3},
+    "point": {"prefixItems": [{"type": "number"}, {"type": "number"}], "items": false},
+    "owner": {"$ref": "#/$defs/owner"}
+  },
+  "additionalProperties": false,
+  "dependentRequired": {"price": ["kind"]},
+  "$defs": {
+    "owner": {"type": "object", "properties": {"email": {"type": "string"}}, "required": ["email"]}
+  }
+}`)
+	for _, test := range []struct {
+		instance string
+		errors   []string
+	}{
+		{`{"id": 1, "name": "rex", "kind": "dog", "price": 9.99, "tags": ["a", "b"], "point": [1, 2.5]}`, nil},
+		{`{"id": 2.0, "name": "rex", "kind": 3, "owner": {"email": "a@b.c"}}`, nil},
+		{`id: 1
+name: rex
+kind: null`, nil},
+		{`{"name": "rex"}`, []string{"/ /required"}},
+		{`{"id": 0, "name": "Rex"}`, []string{"/id /properties/id/minimum", "/name /properties/name/pattern"}},
+		{`{"id": 1.5, "name": "rex"}`, []string{"/id /properties/id/type", "/id /properties/id/multipleOf"}},
+		{`{"id": 1, "name": "rex", "kind": "cow"}`, []string{"/kind /properties/kind/enum"}},
+		{`{"id": 1, "name": "rex", "price": 0.001, "kind": "cat"}`, []string{"/price /properties/price/multipleOf"}},
+		{`{"id": 1, "name": "rex", "price": 1}`, []string{"/ /dependentRequired/price"}},
+		{`{"id": 1, "name": "rex", "tags": ["a", "a", 1, "b"]}`,
+			[]string{"/tags /properties/tags/maxItems", "/tags /properties/tags/uniqueItems", "/tags/2 /properties/tags/items/type"}},
+		{`{"id": 1, "name": "rex", "point": [1, 2, 3]}`, []string{"/point/2 /properties/point/items"}},
+		{`{"id": 1, "name": "rex", "owner": {}}`, []string{"/owner /properties/owner/$ref/required"}},
+		{`{"id": 1, "name": "rex", "age": 3}`, []string{"/age /additionalProperties"}},
+		{`[1, 2]`, []string{"/ /type"}},
+	} {
+		errors := schema.Validate(parseInstance(t, test.instance))
+		got := make([]string, 0)
+		for _, e := range errors {
+			path := e.InstancePath
+			if path == "" {
+				path = "/"
+			}
+			got = append(got, path+" "+e.SchemaPath)
+		}
+		if strings.Join(got, "\n") != strings.Join(test.errors, "\n") {
+			t.Errorf("%s:\ngot\n%s\nexpected\n%s", test.instance, strings.Join(got, "\n"), strings.Join(test.errors, "\n"))
+		}
+	}
+}
+
+func TestValidateApplicators(t *testing.T) {
+	schema := parseSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "oneOf": [
+    {"properties": {"kind": {"const": "circle"}, "radius": {"type": "number"}}, "required": ["kind", "radius"]},
+    {"properties": {"kind": {"const": "square"}, "side": {"type": "number"}}, "required": ["kind", "side"]}
+  ],
+  "if": {"properties": {"kind": {"const": "square"}}},
+  "then": {"properties": {"side": {"minimum": 1}}},
+  "not": {"required": ["forbidden"]},
+  "unevaluatedProperties": false
+}`)
+	for _, test := range []struct {
+		instance string
+		valid    bool
+	}{
+		{`{"kind": "circle", "radius": 2}`, true},
+		{`{"kind": "square", "side": 2}`, true},
+		{`{"kind": "square", "side": 0.5}`, false},
+		{`{"kind": "triangle"}`, false},
+		{`{"kind": "circle", "radius": 2, "color": "red"}`, false},
+		{`{"kind": "circle", "radius": 2, "forbidden": true}`, false},
+	} {
+		errors := schema.Validate(parseInstance(t, test.instance))
+		if (len(errors) == 0) != test.valid {
+			t.Errorf("%s: expected valid=%t, got %v", test.instance, test.valid, errors)
+		}
+	}
+}
+
+func TestValidateDynamicReferences(t *testing.T) {
+	parseSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/tree",
+  "$dynamicAnchor": "node",
+  "type": "object",
+  "properties": {"children": {"type": "array", "items": {"$dynamicRef": "#node"}}}
+}`)
+	strict := parseSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/strict-tree",
+  "$dynamicAnchor": "node",
+  "$ref": "tree",
+  "unevaluatedProperties": false
+}`)
+	if errors := strict.Validate(parseInstance(t, `{"children": [{"children": []}]}`)); len(errors) != 0 {
+		t.Errorf("unexpected errors %v", errors)
+	}
+	// the extra property in the nested node is rejected because $dynamicRef refers to strict-tree
+	errors := strict.Validate(parseInstance(t, `{"children": [{"daat": 1}]}`))
+	if len(errors) != 1 || errors[0].InstancePath != "/children/0/daat" {
+		t.Errorf("unexpected errors %v", errors)
+	}
+}
+
+func TestValidateDraft04(t *testing.T) {
+	schema := parseSchema(t, `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "definitions": {"positive": {"type": "number", "minimum": 0, "exclusiveMinimum": true}},
+  "properties": {
+    "size": {"$ref": "#/definitions/positive", "maximum": 1},
+    "pair": {"items": [{"type": "string"}], "additionalItems": false}
+  },
+  "dependencies": {"size": ["pair"]}
+}`)
+	for _, test := range []struct {
+		instance string
+		errors   int
+	}{
+		{`{"size": 5, "pair": ["a"]}`, 0}, // maximum is ignored next to $ref
+		{`{"size": 0, "pair": ["a"]}`, 1},
+		{`{"size": 1}`, 1},
+		{`{"pair": ["a", "b"]}`, 1},
+	} {
+		if errors := schema.Validate(parseInstance(t, test.instance)); len(errors) != test.errors {
+			t.Errorf("%s: expected %d errors, got %v", test.instance, test.errors, errors)
+		}
+	}
+}
+
+func TestGoTypeGenerator(t *testing.T) {
+	schema := parseSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["id", "kind"],
+  "properties": {
+    "id": {"type": "string"},
+    "kind": {"enum": ["cat", "dog"]},
+    "age": {"type": "integer", "format": "int32"},
+    "tags": {"type": "array", "items": {"type": "string"}},
+    "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+    "owner": {"$ref": "#/$defs/owner"},
+    "size": {"oneOf": [{"type": "number"}, {"type": "string"}]}
+  },
+  "$defs": {
+    "owner": {
+      "type": "object",
+      "properties": {"name": {"type": "string"}}
+    }
+  }
+}`)
+	generator := NewGoTypeGenerator("pets")
+	generator.AddSchemas(map[string]*Schema{"owner": schema.DefWithName("owner")})
+	generator.AddSchema("pet", schema)
+	code, err := generator.Generate()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "types.go", code, 0)
+	if err != nil {
+		t.Fatalf("%+v\n%s", err, code)
+	}
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		if g, ok := decl.(*ast.GenDecl); ok {
+			for _, spec := range g.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					declared[s.Name.Name] = true
+				case *ast.ValueSpec:
+					declared[s.Names[0].Name] = true
+				}
+			}
+		}
+	}
+	for _, name := range []string{"Pet", "PetKind", "PetKindCat", "PetKindDog", "PetSize", "Owner"} {
+		if !declared[name] {
+			t.Errorf("%s is not declared:\n%s", name, code)
+		}
+	}
+	for _, field := range []string{
+		"ID     string            `json:\"id\"`",
+		"Kind   PetKind           `json:\"kind\"`",
+		"Age    int32             `json:\"age,omitempty\"`",
+		"Tags   []string          `json:\"tags,omitempty\"`",
+		"Labels map[string]string `json:\"labels,omitempty\"`",
+		"Owner  *Owner            `json:\"owner,omitempty\"`",
+		"Size   *PetSize          `json:\"size,omitempty\"`",
+	} {
+		if !strings.Contains(string(code), field) {
+			t.Errorf("missing field %s:\n%s", field, code)
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"pet":          "Pet",
+		"petId":        "PetID",
+		"api_url":      "APIURL",
+		"content-type": "ContentType",
+		"2fa":          "X2fa",
+	} {
+		if got := GoName(name); got != expected {
+			t.Errorf("GoName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func TestCompareSchemas(t *testing.T) {
+	before := parseSchema(t, `{
+  "type": "object",
+  "description": "A pet.",
+  "required": ["name"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "maxLength": 10},
+    "age": {"type": "integer", "minimum": 0},
+    "kind": {"enum": ["cat", "dog"]},
+    "weight": {"type": "number", "multipleOf": 0.5},
+    "size": {"oneOf": [{"type": "string"}, {"type": "integer"}]}
+  }
+}`)
+	after := parseSchema(t, `{
+  "type": "object",
+  "description": "A pet in the store.",
+  "required": ["name", "kind"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "maxLength": 5},
+    "age": {"type": "number", "minimum": 0},
+    "kind": {"enum": ["cat", "dog", "bird"]},
+    "weight": {"type": "number", "multipleOf": 0.25},
+    "size": {"oneOf": [{"type": "string"}]},
+    "color": {"type": "string"}
+  }
+}`)
+	expected := []string{
+		"/description changed from A pet. to A pet in the store. (none)",
+		"/required added: kind (narrowing)",
+		"/properties/name/maxLength changed from 10 to 5 (narrowing)",
+		"/properties/age/type changed from integer to number (widening)",
+		"/properties/kind/enum changed from cat, dog to cat, dog, bird (widening)",
+		"/properties/weight/multipleOf changed from 0.5 to 0.25 (widening)",
+		"/properties/size/oneOf/1 removed: integer (incompatible)",
+		"/properties/color added: string (widening)",
+	}
+	changes := CompareSchemas(before, after)
+	if len(changes) != len(expected) {
+		t.Errorf("expected %d changes, found %d: %v", len(expected), len(changes), changes)
+	}
+	for i, change := range changes {
+		if i < len(expected) && change.String() != expected[i] {
+			t.Errorf("expected %q, found %q", expected[i], change.String())
+		}
+	}
+	if impact := OverallImpact(changes); impact != Incompatible {
+		t.Errorf("expected incompatible changes, found %s", impact)
+	}
+
+	// Booleans and "not" keywords.
+	for _, test := range []struct {
+		before, after string
+		impact        Impact
+	}{
+		{`{"items": true}`, `{"items": false}`, Narrowing},
+		{`{"items": {"type": "string"}}`, `{"items": true}`, Widening},
+		{`{"not": {"type": "string"}}`, `{"not": {"type": ["string", "null"]}}`, Narrowing},
+		{`{"maximum": 10, "title": "a"}`, `{"maximum": 20, "title": "b"}`, Widening},
+		{`{"pattern": "^a"}`, `{"pattern": "^b"}`, Incompatible},
+		{`{"format": "date"}`, `{"format": "date", "$comment": "dates"}`, NoImpact},
+	} {
+		changes := CompareSchemas(parseSchema(t, test.before), parseSchema(t, test.after))
+		if impact := OverallImpact(changes); impact != test.impact {
+			t.Errorf("%s -> %s: expected %s, found %s %v", test.before, test.after, test.impact, impact, changes)
+		}
+	}
+}
+
+func TestBundle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+  "$id": "pet.json",
+  "type": "object",
+  "properties": {"name": {"$ref": "#/$defs/name"}, "tag": {"$ref": "#tag"}},
+  "$defs": {
+    "name": {"type": "string"},
+    "tag": {"$anchor": "tag", "type": "string", "maxLength": 8}
+  }
+}`))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "jsonschema")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, text string) string {
+		filename := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return filename
+	}
+	write("common.json", `{"$defs": {"id": {"type": "integer", "minimum": 1}}}`)
+	main := write("main.json", `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "id": {"$ref": "common.json#/$defs/id"},
+    "pet": {"$ref": "`+server.URL+`/pet.json"},
+    "pets": {"type": "array", "items": {"$ref": "#/properties/pet"}}
+  }
+}`)
+	schema, err := NewSchemaFromFile(main)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	bundle, err := schema.Bundle()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for name, ref := range map[string]string{
+		"id":   "#/$defs/common/$defs/id",
+		"pet":  "#/$defs/pet",
+		"pets": "",
+	} {
+		property := bundle.PropertyWithName(name)
+		if ref == "" {
+			property = property.Items.Schema
+			ref = "#/properties/pet"
+		}
+		if property.Ref == nil || *property.Ref != ref {
+			t.Errorf("expected a reference to %s, found %s", ref, property.String())
+		}
+	}
+	pet := bundle.DefWithName("pet")
+	if pet == nil || pet.ID != nil {
+		t.Fatalf("pet is not bundled without its id:\n%s", bundle.JSONString())
+	}
+	if ref := pet.PropertyWithName("name").Ref; ref == nil || *ref != "#/$defs/pet/$defs/name" {
+		t.Errorf("unexpected reference in bundled pet: %s", pet.PropertyWithName("name").String())
+	}
+	if ref := pet.PropertyWithName("tag").Ref; ref == nil || *ref != "#/$defs/pet/$defs/tag" {
+		t.Errorf("unexpected reference in bundled pet: %s", pet.PropertyWithName("tag").String())
+	}
+	if original := schema.PropertyWithName("id").Ref; *original != "common.json#/$defs/id" {
+		t.Errorf("the original schema was modified: %s", *original)
+	}
+
+	// The bundle is self-contained and validates like the original.
+	reparsed := parseSchema(t, bundle.JSONString())
+	instance := parseInstance(t, `{"id": 0, "pets": [{"name": 1, "tag": "a long tag"}]}`)
+	if errors := reparsed.Validate(instance); len(errors) != 3 {
+		t.Errorf("expected 3 errors, found %v", errors)
+	}
+}
+
+func TestKeywordOrder(t *testing.T) {
+	text := `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "title": "Pet",
+  "required": [
+    "name",
+    "id"
+  ],
+  "properties": {
+    "name": {
+      "description": "The name of the pet.",
+      "type": "string"
+    },
+    "id": {
+      "type": "integer",
+      "minimum": 1
+    }
+  },
+  "definitions": {
+    "tag": {
+      "type": "string"
+    },
+    "color": {
+      "enum": [
+        "black",
+        "white"
+      ],
+      "description": "A color."
+    }
+  }
+}
+`
+	schema := parseSchema(t, text)
+	if output := schema.JSONString(); output != text {
+		t.Errorf("keywords were reordered:\n%s", output)
+	}
+	// Keywords that weren't read follow the ones that were.
+	schema.Description = stringptr("A pet.")
+	if output := schema.JSONString(); !strings.HasSuffix(output, "}\n  },\n  \"description\": \"A pet.\"\n}\n") {
+		t.Errorf("unexpected order:\n%s", output)
+	}
+}
+
+func TestBooleanSchemas(t *testing.T) {
+	text := `{
+  "items": true,
+  "contains": false,
+  "properties": {
+    "a": true,
+    "b": false
+  },
+  "patternProperties": {
+    "^x-": true
+  },
+  "additionalProperties": false,
+  "dependencies": {
+    "a": false,
+    "b": [
+      "a"
+    ]
+  },
+  "propertyNames": true,
+  "allOf": [
+    true,
+    {
+      "not": false
+    }
+  ],
+  "definitions": {
+    "never": false
+  }
+}
+`
+	schema := parseSchema(t, text)
+	if output := schema.JSONString(); output != text {
+		t.Errorf("boolean schemas were not preserved:\n%s", output)
+	}
+	if output := parseSchema(t, "true").JSONString(); output != "true\n" {
+		t.Errorf("unexpected output for a boolean schema: %s", output)
+	}
+	if dependency := (*schema.Dependencies)[0].Value.Schema; dependency == nil || dependency.Boolean == nil || *dependency.Boolean {
+		t.Errorf("boolean dependency was not read")
+	}
+	if errors := schema.Validate(parseInstance(t, `{"a": 1}`)); len(errors) != 1 || errors[0].SchemaPath != "/dependencies/a" {
+		t.Errorf("unexpected errors %v", errors)
+	}
+
+	// Including false makes a schema reject everything.
+	schema = parseSchema(t, `{"allOf": [{"type": "string"}, false]}`)
+	schema.ResolveAllOfs()
+	if schema.Boolean == nil || *schema.Boolean {
+		t.Errorf("expected false, found %s", schema.JSONString())
+	}
+}
+
+func TestConditionalsAndDependencies(t *testing.T) {
+	text := `{
+  "$schema": "%s",
+  "type": "object",
+  "if": {"properties": {"country": {"const": "US"}}, "required": ["country"]},
+  "then": {"required": ["zip"]},
+  "else": {"required": ["postcode"]},
+  "dependentRequired": {"card": ["billing"]},
+  "dependentSchemas": {"gift": {"required": ["message"]}}
+}`
+	schema := parseSchema(t, fmt.Sprintf(text, "https://json-schema.org/draft/2020-12/schema"))
+	if required := schema.DependentRequiredWithName("card"); len(required) != 1 || required[0] != "billing" {
+		t.Errorf("unexpected dependentRequired %v", required)
+	}
+	if gift := schema.DependentSchemaWithName("gift"); gift == nil || gift.Required == nil {
+		t.Errorf("dependentSchemas were not read")
+	}
+	for _, test := range []struct {
+		instance string
+		errors   []string
+	}{
+		{`{"country": "US", "zip": "94043"}`, nil},
+		{`{"country": "US"}`, []string{"/then/required"}},
+		{`{"country": "CA"}`, []string{"/else/required"}},
+		{`{"postcode": "K1A", "card": 1}`, []string{"/dependentRequired/card"}},
+		{`{"postcode": "K1A", "gift": true}`, []string{"/dependentSchemas/gift/required"}},
+	} {
+		errors := schema.Validate(parseInstance(t, test.instance))
+		paths := make([]string, 0)
+		for _, e := range errors {
+			paths = append(paths, e.SchemaPath)
+		}
+		if strings.Join(paths, ",") != strings.Join(test.errors, ",") {
+			t.Errorf("%s: expected errors at %v, found %v", test.instance, test.errors, errors)
+		}
+	}
+
+	// These keywords are ignored by dialects that don't define them.
+	schema = parseSchema(t, fmt.Sprintf(text, "http://json-schema.org/draft-06/schema#"))
+	if errors := schema.Validate(parseInstance(t, `{"country": "US", "card": 1, "gift": true}`)); len(errors) != 0 {
+		t.Errorf("unexpected errors %v", errors)
+	}
+	schema = parseSchema(t, fmt.Sprintf(text, "http://json-schema.org/draft-07/schema#"))
+	if errors := schema.Validate(parseInstance(t, `{"country": "US", "card": 1, "gift": true}`)); len(errors) != 1 {
+		t.Errorf("expected only the conditional to fail, found %v", errors)
+	}
+}
+
+func TestWriterOptions(t *testing.T) {
+	schema := parseSchema(t, `{
+  "type": "object",
+  "title": "Quote",
+  "properties": {
+    "text": {"description": "Says \"hi\".\nTwice.", "type": "string"},
+    "author": {"type": "string"}
+  },
+  "examples": [[1, 2], {}]
+}`)
+	for _, test := range []struct {
+		options  *WriterOptions
+		expected string
+	}{
+		{nil, `{
+  "type": "object",
+  "title": "Quote",
+  "properties": {
+    "text": {
+      "description": "Says \"hi\".\nTwice.",
+      "type": "string"
+    },
+    "author": {
+      "type": "string"
+    }
+  },
+  "examples": [
+    [
+      1,
+      2
+    ],
+    {}
+  ]
+}
+`},
+		{&WriterOptions{Format: FormatYAML, KeyOrder: CanonicalKeyOrder}, `title: Quote
+type: object
+properties:
+  text:
+    type: string
+    description: |-
+      Says "hi".
+      Twice.
+  author:
+    type: string
+examples: [[1, 2], {}]
+`},
+		{&WriterOptions{Format: FormatYAML, Indent: 4, KeyOrder: AlphabeticalKeyOrder}, `examples: [[1, 2], {}]
+properties:
+    author:
+        type: string
+    text:
+        description: |-
+            Says "hi".
+            Twice.
+        type: string
+title: Quote
+type: object
+`},
+	} {
+		output, err := schema.Write(test.options)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if string(output) != test.expected {
+			t.Errorf("expected\n%s\nfound\n%s", test.expected, output)
+		}
+	}
+	// Writing with options doesn't change the schema.
+	if output, _ := schema.Write(nil); string(output) != schema.JSONString() {
+		t.Errorf("schema was modified:\n%s", output)
+	}
+	if text := schema.PropertyWithName("text"); text.keywords[0] != "description" {
+		t.Errorf("keyword order was modified: %v", text.keywords)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	text := `{
+  "$schema": "https://json-schema.org/draft/2019-09/hyper-schema",
+  "type": "object",
+  "properties": {
+    "id": {"type": "integer"}
+  },
+  "base": "https://api.example.com/",
+  "links": [
+    {
+      "rel": "self",
+      "href": "things/{id}",
+      "templateRequired": ["id"]
+    },
+    {
+      "rel": ["collection", "search"],
+      "href": "things{?q}",
+      "hrefSchema": {"properties": {"q": {"type": "string"}}},
+      "targetSchema": {"type": "array", "items": {"$ref": "#"}}
+    }
+  ]
+}`
+	schema := parseSchema(t, text)
+	if schema.Dialect() != Dialect201909 {
+		t.Errorf("hyper-schema has dialect %s", schema.Dialect())
+	}
+	if schema.Base == nil || *schema.Base != "https://api.example.com/" {
+		t.Errorf("base was not read")
+	}
+	if schema.Links == nil || len(*schema.Links) != 2 {
+		t.Fatalf("links were not read")
+	}
+	self, search := (*schema.Links)[0], (*schema.Links)[1]
+	if *self.Rel.String != "self" || *self.Href != "things/{id}" || (*self.TemplateRequired)[0] != "id" {
+		t.Errorf("unexpected link %s", self.describeLink())
+	}
+	if len(*search.Rel.StringArray) != 2 || search.HrefSchema == nil {
+		t.Errorf("unexpected link %s", search.describeLink())
+	}
+	// Link schemas can be referenced and are resolved like other subschemas.
+	target, err := schema.ResolveReference("#/links/1/targetSchema")
+	if err != nil || target != search.TargetSchema {
+		t.Errorf("#/links/1/targetSchema resolved to %v (%v)", target, err)
+	}
+	if items, err := target.ResolveReference(*target.Items.Schema.Ref); err != nil || items != schema {
+		t.Errorf("the target items resolved to %v (%v)", items, err)
+	}
+	// Links are written as they were read.
+	if written := parseSchema(t, schema.JSONString()); written.String() != schema.String() {
+		t.Errorf("links were not written:\n%s", schema.JSONString())
+	}
+	// Links don't affect validation.
+	if errors := schema.Validate(parseInstance(t, `{"id": 1}`)); len(errors) != 0 {
+		t.Errorf("unexpected errors %v", errors)
+	}
+}
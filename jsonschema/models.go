@@ -23,11 +23,21 @@ import "gopkg.in/yaml.v3"
 // All fields are pointers and are nil if the associated values
 // are not specified.
 type Schema struct {
+	Boolean *bool // set if the schema is true or false (draft-06 and later)
+
 	Schema    *string // $schema
-	ID        *string // id keyword used for $ref resolution scope
+	ID        *string // id keyword used for $ref resolution scope ($id in draft-06 and later)
 	Ref       *string // $ref, i.e. JSON Pointers
 	ReadOnly  *bool
 	WriteOnly *bool
+	Comment   *string // $comment (draft-07)
+
+	// Identifiers and references added in 2019-09 and 2020-12
+	Anchor          *string // $anchor
+	RecursiveAnchor *bool   // $recursiveAnchor (2019-09)
+	RecursiveRef    *string // $recursiveRef (2019-09)
+	DynamicAnchor   *string // $dynamicAnchor (2020-12)
+	DynamicRef      *string // $dynamicRef (2020-12)
 
 	// http://json-schema.org/latest/json-schema-validation.html
 	// 5.1.  Validation keywords for numeric instances (number and integer)
@@ -37,43 +47,86 @@ type Schema struct {
 	Minimum          *SchemaNumber
 	ExclusiveMinimum *bool
 
+	// In draft-06 and later, exclusiveMaximum and exclusiveMinimum are numbers.
+	ExclusiveMaximumValue *SchemaNumber
+	ExclusiveMinimumValue *SchemaNumber
+
 	// 5.2.  Validation keywords for strings
 	MaxLength *int64
 	MinLength *int64
 	Pattern   *string
 
 	// 5.3.  Validation keywords for arrays
-	AdditionalItems *SchemaOrBoolean
-	Items           *SchemaOrSchemaArray
-	MaxItems        *int64
-	MinItems        *int64
-	UniqueItems     *bool
+	AdditionalItems  *SchemaOrBoolean
+	Items            *SchemaOrSchemaArray
+	MaxItems         *int64
+	MinItems         *int64
+	UniqueItems      *bool
+	Contains         *Schema          // draft-06
+	MaxContains      *int64           // 2019-09
+	MinContains      *int64           // 2019-09
+	PrefixItems      *[]*Schema       // 2020-12
+	UnevaluatedItems *SchemaOrBoolean // 2019-09
 
 	// 5.4.  Validation keywords for objects
-	MaxProperties        *int64
-	MinProperties        *int64
-	Required             *[]string
-	AdditionalProperties *SchemaOrBoolean
-	Properties           *[]*NamedSchema
-	PatternProperties    *[]*NamedSchema
-	Dependencies         *[]*NamedSchemaOrStringArray
+	MaxProperties         *int64
+	MinProperties         *int64
+	Required              *[]string
+	AdditionalProperties  *SchemaOrBoolean
+	Properties            *[]*NamedSchema
+	PatternProperties     *[]*NamedSchema
+	Dependencies          *[]*NamedSchemaOrStringArray
+	PropertyNames         *Schema                      // draft-06
+	DependentRequired     *[]*NamedSchemaOrStringArray // 2019-09
+	DependentSchemas      *[]*NamedSchema              // 2019-09
+	UnevaluatedProperties *SchemaOrBoolean             // 2019-09
 
 	// 5.5.  Validation keywords for any instance type
 	Enumeration *[]SchemaEnumValue
+	Const       *yaml.Node // draft-06
 	Type        *StringOrStringArray
 	AllOf       *[]*Schema
 	AnyOf       *[]*Schema
 	OneOf       *[]*Schema
 	Not         *Schema
 	Definitions *[]*NamedSchema
+	Defs        *[]*NamedSchema // $defs (2019-09)
+
+	// Conditional subschemas (draft-07)
+	If   *Schema
+	Then *Schema
+	Else *Schema
 
 	// 6.  Metadata keywords
 	Title       *string
 	Description *string
 	Default     *yaml.Node
+	Examples    *yaml.Node // draft-06
+	Deprecated  *bool      // 2019-09
+
+	// Content keywords (draft-07)
+	ContentEncoding  *string
+	ContentMediaType *string
+	ContentSchema    *Schema // 2019-09
 
 	// 7.  Semantic validation with "format"
 	Format *string
+
+	// Hyper-schema keywords
+	// https://json-schema.org/draft/2019-09/json-schema-hypermedia.html
+	Base  *string  // a URI template that link targets are resolved against
+	Links *[]*Link // descriptions of the links of instances
+
+	// the dialect of the enclosing schema, used when $schema isn't specified
+	dialect Dialect
+	// the base URI used to resolve references, without a fragment
+	base string
+	// the root of the schema resource that contains this schema
+	resource *Schema
+	// named locations in a schema resource, set for resource roots
+	anchors map[string]*Schema
+	// the keywords of a schema that was read, in the order that they were read
+	keywords []string
 }
 
 // These helper structs represent "combination" types that generally can
@@ -174,6 +227,7 @@ func NewSchemaOrSchemaArrayWithSchemaArray(a []*Schema) *SchemaOrSchemaArray {
 type SchemaEnumValue struct {
 	String *string
 	Bool   *bool
+	Value  *yaml.Node // any other value, such as a number, null, array, or object
 }
 
 // NamedSchema is a name-value pair that is used to emulate maps
@@ -195,6 +249,61 @@ type NamedSchemaOrStringArray struct {
 	Value *SchemaOrStringArray
 }
 
+// NamedString is a name-value pair that is used to emulate maps
+// with ordered keys.
+type NamedString struct {
+	Name  string
+	Value string
+}
+
+// A Link is a link description object of a hyper-schema. It describes
+// links from instances of the schema to the resources identified by its
+// href, a URI template (RFC 6570) whose variables are filled in from the
+// instances. The Method, EncType, MediaType, and Schema fields hold the
+// corresponding keywords of draft-04 hyper-schemas, which later drafts
+// replaced with targetHints, submissionMediaType, targetMediaType, and
+// submissionSchema.
+type Link struct {
+	Rel                 *StringOrStringArray
+	Href                *string
+	Anchor              *string
+	AnchorPointer       *string
+	TemplatePointers    *[]*NamedString
+	TemplateRequired    *[]string
+	Title               *string
+	Description         *string
+	Comment             *string // $comment
+	HrefSchema          *Schema
+	TargetSchema        *Schema
+	TargetMediaType     *string
+	TargetHints         *yaml.Node
+	HeaderSchema        *Schema
+	SubmissionMediaType *string
+	SubmissionSchema    *Schema
+
+	Method    *string // draft-04
+	EncType   *string // draft-04
+	MediaType *string // draft-04
+	Schema    *Schema // draft-04
+}
+
+// subschemas returns the schemas of a link, named with their keywords.
+func (link *Link) subschemas() []*NamedSchema {
+	result := make([]*NamedSchema, 0)
+	for _, pair := range []*NamedSchema{
+		{Name: "hrefSchema", Value: link.HrefSchema},
+		{Name: "targetSchema", Value: link.TargetSchema},
+		{Name: "headerSchema", Value: link.HeaderSchema},
+		{Name: "submissionSchema", Value: link.SubmissionSchema},
+		{Name: "schema", Value: link.Schema},
+	} {
+		if pair.Value != nil {
+			result = append(result, pair)
+		}
+	}
+	return result
+}
+
 // Access named subschemas by name
 
 func namedSchemaArrayElementWithName(array *[]*NamedSchema, name string) *Schema {
@@ -219,11 +328,35 @@ func (s *Schema) PatternPropertyWithName(name string) *Schema {
 	return namedSchemaArrayElementWithName(s.PatternProperties, name)
 }
 
+// DefWithName returns the selected element of $defs.
+func (s *Schema) DefWithName(name string) *Schema {
+	return namedSchemaArrayElementWithName(s.Defs, name)
+}
+
 // DefinitionWithName returns the selected element.
 func (s *Schema) DefinitionWithName(name string) *Schema {
 	return namedSchemaArrayElementWithName(s.Definitions, name)
 }
 
+// DependentSchemaWithName returns the schema that applies when an object
+// has the named property.
+func (s *Schema) DependentSchemaWithName(name string) *Schema {
+	return namedSchemaArrayElementWithName(s.DependentSchemas, name)
+}
+
+// DependentRequiredWithName returns the properties that are required when
+// an object has the named property.
+func (s *Schema) DependentRequiredWithName(name string) []string {
+	if s.DependentRequired != nil {
+		for _, pair := range *s.DependentRequired {
+			if pair.Name == name && pair.Value.StringArray != nil {
+				return *pair.Value.StringArray
+			}
+		}
+	}
+	return nil
+}
+
 // AddProperty adds a named property.
 func (s *Schema) AddProperty(name string, property *Schema) {
 	*s.Properties = append(*s.Properties, NewNamedSchema(name, property))
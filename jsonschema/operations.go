@@ -15,9 +15,7 @@
 package jsonschema
 
 import (
-	"fmt"
 	"log"
-	"strings"
 )
 
 //
@@ -27,8 +25,12 @@ import (
 
 // IsEmpty returns true if no members of the Schema are specified.
 func (schema *Schema) IsEmpty() bool {
-	return (schema.Schema == nil) &&
+	return (schema.Boolean == nil) &&
+		(schema.Schema == nil) &&
 		(schema.ID == nil) &&
+		(schema.Comment == nil) &&
+		(schema.ReadOnly == nil) &&
+		(schema.WriteOnly == nil) &&
 		(schema.MultipleOf == nil) &&
 		(schema.Maximum == nil) &&
 		(schema.ExclusiveMaximum == nil) &&
@@ -42,6 +44,7 @@ func (schema *Schema) IsEmpty() bool {
 		(schema.MaxItems == nil) &&
 		(schema.MinItems == nil) &&
 		(schema.UniqueItems == nil) &&
+		(schema.Contains == nil) &&
 		(schema.MaxProperties == nil) &&
 		(schema.MinProperties == nil) &&
 		(schema.Required == nil) &&
@@ -49,17 +52,44 @@ func (schema *Schema) IsEmpty() bool {
 		(schema.Properties == nil) &&
 		(schema.PatternProperties == nil) &&
 		(schema.Dependencies == nil) &&
+		(schema.PropertyNames == nil) &&
 		(schema.Enumeration == nil) &&
+		(schema.Const == nil) &&
 		(schema.Type == nil) &&
 		(schema.AllOf == nil) &&
 		(schema.AnyOf == nil) &&
 		(schema.OneOf == nil) &&
 		(schema.Not == nil) &&
 		(schema.Definitions == nil) &&
+		(schema.If == nil) &&
+		(schema.Then == nil) &&
+		(schema.Else == nil) &&
 		(schema.Title == nil) &&
 		(schema.Description == nil) &&
 		(schema.Default == nil) &&
 		(schema.Format == nil) &&
+		(schema.Anchor == nil) &&
+		(schema.RecursiveAnchor == nil) &&
+		(schema.RecursiveRef == nil) &&
+		(schema.DynamicAnchor == nil) &&
+		(schema.DynamicRef == nil) &&
+		(schema.ExclusiveMaximumValue == nil) &&
+		(schema.ExclusiveMinimumValue == nil) &&
+		(schema.MaxContains == nil) &&
+		(schema.MinContains == nil) &&
+		(schema.PrefixItems == nil) &&
+		(schema.UnevaluatedItems == nil) &&
+		(schema.DependentRequired == nil) &&
+		(schema.DependentSchemas == nil) &&
+		(schema.UnevaluatedProperties == nil) &&
+		(schema.Defs == nil) &&
+		(schema.Examples == nil) &&
+		(schema.Deprecated == nil) &&
+		(schema.ContentEncoding == nil) &&
+		(schema.ContentMediaType == nil) &&
+		(schema.ContentSchema == nil) &&
+		(schema.Base == nil) &&
+		(schema.Links == nil) &&
 		(schema.Ref == nil)
 }
 
@@ -91,12 +121,34 @@ func (schema *Schema) applyToSchemas(operation SchemaOperation, context string)
 		}
 	}
 
+	if schema.Contains != nil {
+		schema.Contains.applyToSchemas(operation, "Contains")
+	}
+
+	if schema.PrefixItems != nil {
+		for _, s := range *(schema.PrefixItems) {
+			s.applyToSchemas(operation, "PrefixItems")
+		}
+	}
+	if schema.UnevaluatedItems != nil {
+		s := schema.UnevaluatedItems.Schema
+		if s != nil {
+			s.applyToSchemas(operation, "UnevaluatedItems")
+		}
+	}
+
 	if schema.AdditionalProperties != nil {
 		s := schema.AdditionalProperties.Schema
 		if s != nil {
 			s.applyToSchemas(operation, "AdditionalProperties")
 		}
 	}
+	if schema.UnevaluatedProperties != nil {
+		s := schema.UnevaluatedProperties.Schema
+		if s != nil {
+			s.applyToSchemas(operation, "UnevaluatedProperties")
+		}
+	}
 
 	if schema.Properties != nil {
 		for _, pair := range *(schema.Properties) {
@@ -121,6 +173,17 @@ func (schema *Schema) applyToSchemas(operation SchemaOperation, context string)
 		}
 	}
 
+	if schema.PropertyNames != nil {
+		schema.PropertyNames.applyToSchemas(operation, "PropertyNames")
+	}
+
+	if schema.DependentSchemas != nil {
+		for _, pair := range *(schema.DependentSchemas) {
+			s := pair.Value
+			s.applyToSchemas(operation, "DependentSchemas")
+		}
+	}
+
 	if schema.AllOf != nil {
 		for _, s := range *(schema.AllOf) {
 			s.applyToSchemas(operation, "AllOf")
@@ -140,24 +203,65 @@ func (schema *Schema) applyToSchemas(operation SchemaOperation, context string)
 		schema.Not.applyToSchemas(operation, "Not")
 	}
 
+	if schema.If != nil {
+		schema.If.applyToSchemas(operation, "If")
+	}
+	if schema.Then != nil {
+		schema.Then.applyToSchemas(operation, "Then")
+	}
+	if schema.Else != nil {
+		schema.Else.applyToSchemas(operation, "Else")
+	}
+
 	if schema.Definitions != nil {
 		for _, pair := range *(schema.Definitions) {
 			s := pair.Value
 			s.applyToSchemas(operation, "Definitions")
 		}
 	}
+	if schema.Defs != nil {
+		for _, pair := range *(schema.Defs) {
+			s := pair.Value
+			s.applyToSchemas(operation, "Defs")
+		}
+	}
+
+	if schema.ContentSchema != nil {
+		schema.ContentSchema.applyToSchemas(operation, "ContentSchema")
+	}
+
+	if schema.Links != nil {
+		for _, link := range *(schema.Links) {
+			for _, pair := range link.subschemas() {
+				pair.Value.applyToSchemas(operation, "Links")
+			}
+		}
+	}
 
 	operation(schema, context)
 }
 
 // CopyProperties copies all non-nil properties from the source Schema to the schema Schema.
 func (schema *Schema) CopyProperties(source *Schema) {
+	if source.Boolean != nil && !*source.Boolean {
+		// false rejects everything, and so does any schema that includes it
+		schema.Boolean = source.Boolean
+	}
 	if source.Schema != nil {
 		schema.Schema = source.Schema
 	}
 	if source.ID != nil {
 		schema.ID = source.ID
 	}
+	if source.Comment != nil {
+		schema.Comment = source.Comment
+	}
+	if source.ReadOnly != nil {
+		schema.ReadOnly = source.ReadOnly
+	}
+	if source.WriteOnly != nil {
+		schema.WriteOnly = source.WriteOnly
+	}
 	if source.MultipleOf != nil {
 		schema.MultipleOf = source.MultipleOf
 	}
@@ -197,6 +301,9 @@ func (schema *Schema) CopyProperties(source *Schema) {
 	if source.UniqueItems != nil {
 		schema.UniqueItems = source.UniqueItems
 	}
+	if source.Contains != nil {
+		schema.Contains = source.Contains
+	}
 	if source.MaxProperties != nil {
 		schema.MaxProperties = source.MaxProperties
 	}
@@ -218,9 +325,15 @@ func (schema *Schema) CopyProperties(source *Schema) {
 	if source.Dependencies != nil {
 		schema.Dependencies = source.Dependencies
 	}
+	if source.PropertyNames != nil {
+		schema.PropertyNames = source.PropertyNames
+	}
 	if source.Enumeration != nil {
 		schema.Enumeration = source.Enumeration
 	}
+	if source.Const != nil {
+		schema.Const = source.Const
+	}
 	if source.Type != nil {
 		schema.Type = source.Type
 	}
@@ -239,6 +352,15 @@ func (schema *Schema) CopyProperties(source *Schema) {
 	if source.Definitions != nil {
 		schema.Definitions = source.Definitions
 	}
+	if source.If != nil {
+		schema.If = source.If
+	}
+	if source.Then != nil {
+		schema.Then = source.Then
+	}
+	if source.Else != nil {
+		schema.Else = source.Else
+	}
 	if source.Title != nil {
 		schema.Title = source.Title
 	}
@@ -253,6 +375,75 @@ func (schema *Schema) CopyProperties(source *Schema) {
 	}
 	if source.Ref != nil {
 		schema.Ref = source.Ref
+		// a copied reference is resolved relative to the schema that contained it
+		schema.base = source.base
+		schema.resource = source.resource
+	}
+	if source.Anchor != nil {
+		schema.Anchor = source.Anchor
+	}
+	if source.RecursiveAnchor != nil {
+		schema.RecursiveAnchor = source.RecursiveAnchor
+	}
+	if source.RecursiveRef != nil {
+		schema.RecursiveRef = source.RecursiveRef
+	}
+	if source.DynamicAnchor != nil {
+		schema.DynamicAnchor = source.DynamicAnchor
+	}
+	if source.DynamicRef != nil {
+		schema.DynamicRef = source.DynamicRef
+	}
+	if source.ExclusiveMaximumValue != nil {
+		schema.ExclusiveMaximumValue = source.ExclusiveMaximumValue
+	}
+	if source.ExclusiveMinimumValue != nil {
+		schema.ExclusiveMinimumValue = source.ExclusiveMinimumValue
+	}
+	if source.MaxContains != nil {
+		schema.MaxContains = source.MaxContains
+	}
+	if source.MinContains != nil {
+		schema.MinContains = source.MinContains
+	}
+	if source.PrefixItems != nil {
+		schema.PrefixItems = source.PrefixItems
+	}
+	if source.UnevaluatedItems != nil {
+		schema.UnevaluatedItems = source.UnevaluatedItems
+	}
+	if source.DependentRequired != nil {
+		schema.DependentRequired = source.DependentRequired
+	}
+	if source.DependentSchemas != nil {
+		schema.DependentSchemas = source.DependentSchemas
+	}
+	if source.UnevaluatedProperties != nil {
+		schema.UnevaluatedProperties = source.UnevaluatedProperties
+	}
+	if source.Defs != nil {
+		schema.Defs = source.Defs
+	}
+	if source.Examples != nil {
+		schema.Examples = source.Examples
+	}
+	if source.Deprecated != nil {
+		schema.Deprecated = source.Deprecated
+	}
+	if source.ContentEncoding != nil {
+		schema.ContentEncoding = source.ContentEncoding
+	}
+	if source.ContentMediaType != nil {
+		schema.ContentMediaType = source.ContentMediaType
+	}
+	if source.ContentSchema != nil {
+		schema.ContentSchema = source.ContentSchema
+	}
+	if source.Base != nil {
+		schema.Base = source.Base
+	}
+	if source.Links != nil {
+		schema.Links = source.Links
 	}
 }
 
@@ -278,14 +469,15 @@ func (schema *Schema) TypeIs(typeName string) bool {
 // the reference is kept and we expect downstream tools to separately model these
 // referenced schemas.
 func (schema *Schema) ResolveRefs() {
-	rootSchema := schema
+	// schemas that were created directly rather than read share the scope of their parents
+	schema.register(nil, nil)
 	count := 1
 	for count > 0 {
 		count = 0
 		schema.applyToSchemas(
 			func(schema *Schema, context string) {
 				if schema.Ref != nil {
-					resolvedRef, err := rootSchema.resolveJSONPointer(*(schema.Ref))
+					resolvedRef, err := schema.ResolveReference(*(schema.Ref))
 					if err != nil {
 						log.Printf("%+v", err)
 					} else if resolvedRef.TypeIs("object") {
@@ -306,50 +498,6 @@ func (schema *Schema) ResolveRefs() {
 	}
 }
 
-// resolveJSONPointer resolves JSON pointers.
-// This current implementation is very crude and custom for OpenAPI 2.0 schemas.
-// It panics for any pointer that it is unable to resolve.
-func (schema *Schema) resolveJSONPointer(ref string) (result *Schema, err error) {
-	parts := strings.Split(ref, "#")
-	if len(parts) == 2 {
-		documentName := parts[0] + "#"
-		if documentName == "#" && schema.ID != nil {
-			documentName = *(schema.ID)
-		}
-		path := parts[1]
-		document := schemas[documentName]
-		pathParts := strings.Split(path, "/")
-
-		// we currently do a very limited (hard-coded) resolution of certain paths and log errors for missed cases
-		if len(pathParts) == 1 {
-			return document, nil
-		} else if len(pathParts) == 3 {
-			switch pathParts[1] {
-			case "definitions":
-				dictionary := document.Definitions
-				for _, pair := range *dictionary {
-					if pair.Name == pathParts[2] {
-						result = pair.Value
-					}
-				}
-			case "properties":
-				dictionary := document.Properties
-				for _, pair := range *dictionary {
-					if pair.Name == pathParts[2] {
-						result = pair.Value
-					}
-				}
-			default:
-				break
-			}
-		}
-	}
-	if result == nil {
-		return nil, fmt.Errorf("unresolved pointer: %+v", ref)
-	}
-	return result, nil
-}
-
 // ResolveAllOfs replaces "allOf" elements by merging their properties into the parent Schema.
 func (schema *Schema) ResolveAllOfs() {
 	schema.applyToSchemas(
@@ -19,13 +19,15 @@ package jsonschema
 import (
 	"fmt"
 	"io/ioutil"
+	"net/url"
+	"path/filepath"
 	"strconv"
 
 	"gopkg.in/yaml.v3"
 )
 
-// This is a global map of all known Schemas.
-// It is initialized when the first Schema is created and inserted.
+// This is a global map of all known Schemas, indexed by the URIs of
+// schema resources. It is initialized when the first Schema is registered.
 var schemas map[string]*Schema
 
 // NewBaseSchema builds a schema object from an embedded json representation.
@@ -54,39 +56,92 @@ func NewSchemaFromFile(filename string) (schema *Schema, err error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewSchemaFromObject(&node), nil
+	// References are resolved relative to the file unless the schema has an id.
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	base := &url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return newSchema(&node, base), nil
 }
 
 // NewSchemaFromObject constructs a schema from a parsed JSON object.
 // Due to the complexity of the schema representation, this is a
 // custom reader and not the standard Go JSON reader (encoding/json).
 func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
+	return newSchema(jsonData, nil)
+}
+
+// newSchema reads a schema and registers it using the specified base URI.
+func newSchema(jsonData *yaml.Node, base *url.URL) *Schema {
+	schema := readSchema(jsonData, DialectUnspecified)
+	schema.register(base, nil)
+	return schema
+}
+
+// readSchema reads a schema that is enclosed by a schema of the specified dialect.
+func readSchema(jsonData *yaml.Node, dialect Dialect) *Schema {
 	switch jsonData.Kind {
 	case yaml.DocumentNode:
-		return NewSchemaFromObject(jsonData.Content[0])
+		return readSchema(jsonData.Content[0], dialect)
 	case yaml.MappingNode:
-		schema := &Schema{}
+		schema := &Schema{dialect: dialect}
+		// $schema determines the dialect of the schema and its subschemas,
+		// so look for it before reading anything else.
+		for i := 0; i < len(jsonData.Content); i += 2 {
+			if jsonData.Content[i].Value == "$schema" {
+				schema.Schema = schema.stringValue(jsonData.Content[i+1])
+				if schema.Schema != nil {
+					schema.dialect = DialectForURI(*schema.Schema)
+				}
+			}
+		}
 
 		for i := 0; i < len(jsonData.Content); i += 2 {
 			k := jsonData.Content[i].Value
 			v := jsonData.Content[i+1]
+			schema.keywords = append(schema.keywords, k)
 
 			switch k {
 			case "$schema":
-				schema.Schema = schema.stringValue(v)
-			case "id":
+				// already read
+			case "id", "$id":
 				schema.ID = schema.stringValue(v)
+			case "$comment":
+				schema.Comment = schema.stringValue(v)
+			case "readOnly":
+				schema.ReadOnly = schema.boolValue(v)
+			case "writeOnly":
+				schema.WriteOnly = schema.boolValue(v)
+			case "$anchor":
+				schema.Anchor = schema.stringValue(v)
+			case "$recursiveAnchor":
+				schema.RecursiveAnchor = schema.boolValue(v)
+			case "$recursiveRef":
+				schema.RecursiveRef = schema.stringValue(v)
+			case "$dynamicAnchor":
+				schema.DynamicAnchor = schema.stringValue(v)
+			case "$dynamicRef":
+				schema.DynamicRef = schema.stringValue(v)
 
 			case "multipleOf":
 				schema.MultipleOf = schema.numberValue(v)
 			case "maximum":
 				schema.Maximum = schema.numberValue(v)
 			case "exclusiveMaximum":
-				schema.ExclusiveMaximum = schema.boolValue(v)
+				if v.Tag == "!!bool" {
+					schema.ExclusiveMaximum = schema.boolValue(v)
+				} else {
+					schema.ExclusiveMaximumValue = schema.numberValue(v)
+				}
 			case "minimum":
 				schema.Minimum = schema.numberValue(v)
 			case "exclusiveMinimum":
-				schema.ExclusiveMinimum = schema.boolValue(v)
+				if v.Tag == "!!bool" {
+					schema.ExclusiveMinimum = schema.boolValue(v)
+				} else {
+					schema.ExclusiveMinimumValue = schema.numberValue(v)
+				}
 
 			case "maxLength":
 				schema.MaxLength = schema.intValue(v)
@@ -105,6 +160,16 @@ func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
 				schema.MinItems = schema.intValue(v)
 			case "uniqueItems":
 				schema.UniqueItems = schema.boolValue(v)
+			case "contains":
+				schema.Contains = schema.subschemaValue(v)
+			case "maxContains":
+				schema.MaxContains = schema.intValue(v)
+			case "minContains":
+				schema.MinContains = schema.intValue(v)
+			case "prefixItems":
+				schema.PrefixItems = schema.arrayOfSchemasValue(v)
+			case "unevaluatedItems":
+				schema.UnevaluatedItems = schema.schemaOrBooleanValue(v)
 
 			case "maxProperties":
 				schema.MaxProperties = schema.intValue(v)
@@ -120,9 +185,19 @@ func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
 				schema.PatternProperties = schema.mapOfSchemasValue(v)
 			case "dependencies":
 				schema.Dependencies = schema.mapOfSchemasOrStringArraysValue(v)
+			case "propertyNames":
+				schema.PropertyNames = schema.subschemaValue(v)
+			case "dependentRequired":
+				schema.DependentRequired = schema.mapOfSchemasOrStringArraysValue(v)
+			case "dependentSchemas":
+				schema.DependentSchemas = schema.mapOfSchemasValue(v)
+			case "unevaluatedProperties":
+				schema.UnevaluatedProperties = schema.schemaOrBooleanValue(v)
 
 			case "enum":
 				schema.Enumeration = schema.arrayOfEnumValuesValue(v)
+			case "const":
+				schema.Const = v
 
 			case "type":
 				schema.Type = schema.stringOrStringArrayValue(v)
@@ -133,9 +208,18 @@ func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
 			case "oneOf":
 				schema.OneOf = schema.arrayOfSchemasValue(v)
 			case "not":
-				schema.Not = NewSchemaFromObject(v)
+				schema.Not = schema.subschemaValue(v)
 			case "definitions":
 				schema.Definitions = schema.mapOfSchemasValue(v)
+			case "$defs":
+				schema.Defs = schema.mapOfSchemasValue(v)
+
+			case "if":
+				schema.If = schema.subschemaValue(v)
+			case "then":
+				schema.Then = schema.subschemaValue(v)
+			case "else":
+				schema.Else = schema.subschemaValue(v)
 
 			case "title":
 				schema.Title = schema.stringValue(v)
@@ -144,9 +228,26 @@ func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
 
 			case "default":
 				schema.Default = v
+			case "examples":
+				schema.Examples = v
+			case "deprecated":
+				schema.Deprecated = schema.boolValue(v)
+
+			case "contentEncoding":
+				schema.ContentEncoding = schema.stringValue(v)
+			case "contentMediaType":
+				schema.ContentMediaType = schema.stringValue(v)
+			case "contentSchema":
+				schema.ContentSchema = schema.subschemaValue(v)
 
 			case "format":
 				schema.Format = schema.stringValue(v)
+
+			case "base":
+				schema.Base = schema.stringValue(v)
+			case "links":
+				schema.Links = schema.linksValue(v)
+
 			case "$ref":
 				schema.Ref = schema.stringValue(v)
 			default:
@@ -154,15 +255,16 @@ func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
 			}
 		}
 
-		// insert schema in global map
-		if schema.ID != nil {
-			if schemas == nil {
-				schemas = make(map[string]*Schema, 0)
-			}
-			schemas[*(schema.ID)] = schema
-		}
 		return schema
 
+	case yaml.ScalarNode:
+		// Since draft-06, true and false are schemas that accept or reject everything.
+		if jsonData.Tag == "!!bool" {
+			b, _ := strconv.ParseBool(jsonData.Value)
+			return &Schema{Boolean: &b, dialect: dialect}
+		}
+		fmt.Printf("schemaValue: unexpected node %+v\n", jsonData)
+
 	default:
 		fmt.Printf("schemaValue: unexpected node %+v\n", jsonData)
 	}
@@ -176,6 +278,11 @@ func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
 // Each returns nil if it is unable to build the desired element.
 //
 
+// Gets a subschema of a schema, which shares the schema's dialect.
+func (schema *Schema) subschemaValue(v *yaml.Node) *Schema {
+	return readSchema(v, schema.dialect)
+}
+
 // Gets the string value of an interface{} value if possible.
 func (schema *Schema) stringValue(v *yaml.Node) *string {
 	switch v.Kind {
@@ -256,8 +363,9 @@ func (schema *Schema) mapOfSchemasValue(v *yaml.Node) *[]*NamedSchema {
 		for i := 0; i < len(v.Content); i += 2 {
 			k2 := v.Content[i].Value
 			v2 := v.Content[i+1]
-			pair := &NamedSchema{Name: k2, Value: NewSchemaFromObject(v2)}
-			m = append(m, pair)
+			if s := schema.subschemaValue(v2); s != nil {
+				m = append(m, &NamedSchema{Name: k2, Value: s})
+			}
 		}
 		return &m
 	default:
@@ -272,18 +380,14 @@ func (schema *Schema) arrayOfSchemasValue(v *yaml.Node) *[]*Schema {
 	case yaml.SequenceNode:
 		m := make([]*Schema, 0)
 		for _, v2 := range v.Content {
-			switch v2.Kind {
-			case yaml.MappingNode:
-				s := NewSchemaFromObject(v2)
+			if s := schema.subschemaValue(v2); s != nil {
 				m = append(m, s)
-			default:
-				fmt.Printf("arrayOfSchemasValue: unexpected node %+v\n", v2)
 			}
 		}
 		return &m
 	case yaml.MappingNode:
 		m := make([]*Schema, 0)
-		s := NewSchemaFromObject(v)
+		s := schema.subschemaValue(v)
 		m = append(m, s)
 		return &m
 	default:
@@ -298,18 +402,15 @@ func (schema *Schema) schemaOrSchemaArrayValue(v *yaml.Node) *SchemaOrSchemaArra
 	case yaml.SequenceNode:
 		m := make([]*Schema, 0)
 		for _, v2 := range v.Content {
-			switch v2.Kind {
-			case yaml.MappingNode:
-				s := NewSchemaFromObject(v2)
+			if s := schema.subschemaValue(v2); s != nil {
 				m = append(m, s)
-			default:
-				fmt.Printf("schemaOrSchemaArrayValue: unexpected node %+v\n", v2)
 			}
 		}
 		return &SchemaOrSchemaArray{SchemaArray: &m}
-	case yaml.MappingNode:
-		s := NewSchemaFromObject(v)
-		return &SchemaOrSchemaArray{Schema: s}
+	case yaml.MappingNode, yaml.ScalarNode:
+		if s := schema.subschemaValue(v); s != nil {
+			return &SchemaOrSchemaArray{Schema: s}
+		}
 	default:
 		fmt.Printf("schemaOrSchemaArrayValue: unexpected node %+v\n", v)
 	}
@@ -371,19 +472,14 @@ func (schema *Schema) arrayOfEnumValuesValue(v *yaml.Node) *[]SchemaEnumValue {
 	switch v.Kind {
 	case yaml.SequenceNode:
 		for _, v2 := range v.Content {
-			switch v2.Kind {
-			case yaml.ScalarNode:
-				switch v2.Tag {
-				case "!!str":
-					a = append(a, SchemaEnumValue{String: &v2.Value})
-				case "!!bool":
-					v3, _ := strconv.ParseBool(v2.Value)
-					a = append(a, SchemaEnumValue{Bool: &v3})
-				default:
-					fmt.Printf("arrayOfEnumValuesValue: unexpected type %s\n", v2.Tag)
-				}
+			switch {
+			case v2.Kind == yaml.ScalarNode && v2.Tag == "!!str":
+				a = append(a, SchemaEnumValue{String: &v2.Value})
+			case v2.Kind == yaml.ScalarNode && v2.Tag == "!!bool":
+				v3, _ := strconv.ParseBool(v2.Value)
+				a = append(a, SchemaEnumValue{Bool: &v3})
 			default:
-				fmt.Printf("arrayOfEnumValuesValue: unexpected node %+v\n", v2)
+				a = append(a, SchemaEnumValue{Value: v2})
 			}
 		}
 	default:
@@ -415,6 +511,14 @@ func (schema *Schema) mapOfSchemasOrStringArraysValue(v *yaml.Node) *[]*NamedSch
 				s.StringArray = &a
 				pair := &NamedSchemaOrStringArray{Name: k2, Value: s}
 				m = append(m, pair)
+			case yaml.MappingNode, yaml.ScalarNode:
+				// dependencies can also be schemas, including boolean schemas
+				s := &SchemaOrStringArray{}
+				s.Schema = schema.subschemaValue(v2)
+				if s.Schema != nil {
+					pair := &NamedSchemaOrStringArray{Name: k2, Value: s}
+					m = append(m, pair)
+				}
 			default:
 				fmt.Printf("mapOfSchemasOrStringArraysValue: unexpected node %+v\n", v2)
 			}
@@ -433,9 +537,101 @@ func (schema *Schema) schemaOrBooleanValue(v *yaml.Node) *SchemaOrBoolean {
 		v2, _ := strconv.ParseBool(v.Value)
 		schemaOrBoolean.Boolean = &v2
 	case yaml.MappingNode:
-		schemaOrBoolean.Schema = NewSchemaFromObject(v)
+		schemaOrBoolean.Schema = schema.subschemaValue(v)
 	default:
 		fmt.Printf("schemaOrBooleanValue: unexpected node %+v\n", v)
 	}
 	return schemaOrBoolean
 }
+
+// Gets an array of Links from an interface{} value if possible.
+func (schema *Schema) linksValue(v *yaml.Node) *[]*Link {
+	switch v.Kind {
+	case yaml.SequenceNode:
+		a := make([]*Link, 0)
+		for _, v2 := range v.Content {
+			if link := schema.linkValue(v2); link != nil {
+				a = append(a, link)
+			}
+		}
+		return &a
+	default:
+		fmt.Printf("linksValue: unexpected node %+v\n", v)
+	}
+	return nil
+}
+
+// Gets a Link from an interface{} value if possible.
+func (schema *Schema) linkValue(v *yaml.Node) *Link {
+	if v.Kind != yaml.MappingNode {
+		fmt.Printf("linkValue: unexpected node %+v\n", v)
+		return nil
+	}
+	link := &Link{}
+	for i := 0; i < len(v.Content); i += 2 {
+		k := v.Content[i].Value
+		v2 := v.Content[i+1]
+		switch k {
+		case "rel":
+			link.Rel = schema.stringOrStringArrayValue(v2)
+		case "href":
+			link.Href = schema.stringValue(v2)
+		case "anchor":
+			link.Anchor = schema.stringValue(v2)
+		case "anchorPointer":
+			link.AnchorPointer = schema.stringValue(v2)
+		case "templatePointers":
+			link.TemplatePointers = schema.mapOfStringsValue(v2)
+		case "templateRequired":
+			link.TemplateRequired = schema.arrayOfStringsValue(v2)
+		case "title":
+			link.Title = schema.stringValue(v2)
+		case "description":
+			link.Description = schema.stringValue(v2)
+		case "$comment":
+			link.Comment = schema.stringValue(v2)
+		case "hrefSchema":
+			link.HrefSchema = schema.subschemaValue(v2)
+		case "targetSchema":
+			link.TargetSchema = schema.subschemaValue(v2)
+		case "targetMediaType":
+			link.TargetMediaType = schema.stringValue(v2)
+		case "targetHints":
+			link.TargetHints = v2
+		case "headerSchema":
+			link.HeaderSchema = schema.subschemaValue(v2)
+		case "submissionMediaType":
+			link.SubmissionMediaType = schema.stringValue(v2)
+		case "submissionSchema":
+			link.SubmissionSchema = schema.subschemaValue(v2)
+		case "method":
+			link.Method = schema.stringValue(v2)
+		case "encType":
+			link.EncType = schema.stringValue(v2)
+		case "mediaType":
+			link.MediaType = schema.stringValue(v2)
+		case "schema":
+			link.Schema = schema.subschemaValue(v2)
+		default:
+			fmt.Printf("UNSUPPORTED link keyword (%s)\n", k)
+		}
+	}
+	return link
+}
+
+// Gets a map of Strings from an interface{} value if possible.
+func (schema *Schema) mapOfStringsValue(v *yaml.Node) *[]*NamedString {
+	switch v.Kind {
+	case yaml.MappingNode:
+		m := make([]*NamedString, 0)
+		for i := 0; i+1 < len(v.Content); i += 2 {
+			if s := schema.stringValue(v.Content[i+1]); s != nil {
+				m = append(m, &NamedString{Name: v.Content[i].Value, Value: *s})
+			}
+		}
+		return &m
+	default:
+		fmt.Printf("mapOfStringsValue: unexpected node %+v\n", v)
+	}
+	return nil
+}
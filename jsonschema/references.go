@@ -0,0 +1,356 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//
+// REFERENCES
+// The following methods establish the base URIs of Schemas and resolve
+// references to them, following the rules of JSON Schema for "$id",
+// "id", "$anchor", and "$dynamicAnchor".
+//
+
+// registerSchema adds a schema resource to the global map of known Schemas.
+func registerSchema(uri *url.URL, schema *Schema) {
+	key := resourceKey(uri)
+	if key == "" {
+		return
+	}
+	if schemas == nil {
+		schemas = make(map[string]*Schema, 0)
+	}
+	schemas[key] = schema
+}
+
+// resourceKey returns the key used to store a schema resource in the global map.
+func resourceKey(uri *url.URL) string {
+	u := *uri
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// register establishes the base URI of a schema and its subschemas and records
+// the resources and anchors that they define. Schemas that were registered
+// previously, such as ones copied from other documents, keep their scope.
+func (schema *Schema) register(base *url.URL, resource *Schema) {
+	if schema == nil {
+		return
+	}
+	if schema.resource != nil {
+		if base, err := url.Parse(schema.base); err == nil {
+			for _, s := range schema.subschemas() {
+				s.register(base, schema.resource)
+			}
+		}
+		return
+	}
+	if base == nil {
+		base = &url.URL{}
+	}
+	if resource == nil {
+		resource = schema
+		registerSchema(base, schema)
+	}
+	if schema.ID != nil {
+		if id, err := base.Parse(*schema.ID); err == nil {
+			if !strings.HasPrefix(*schema.ID, "#") {
+				// a non-fragment id starts a new schema resource
+				base = id
+				resource = schema
+				registerSchema(base, schema)
+			}
+			if id.Fragment != "" && !strings.HasPrefix(id.Fragment, "/") {
+				// before 2019-09, ids with plain-name fragments were used as anchors
+				resource.addAnchor(id.Fragment, schema)
+			}
+		}
+	}
+	if schema.Anchor != nil {
+		resource.addAnchor(*schema.Anchor, schema)
+	}
+	if schema.DynamicAnchor != nil {
+		resource.addAnchor(*schema.DynamicAnchor, schema)
+	}
+	schema.base = resourceKey(base)
+	schema.resource = resource
+	for _, s := range schema.subschemas() {
+		s.register(base, resource)
+	}
+}
+
+// addAnchor records a named location in a schema resource.
+func (schema *Schema) addAnchor(name string, target *Schema) {
+	if schema.anchors == nil {
+		schema.anchors = make(map[string]*Schema)
+	}
+	if _, ok := schema.anchors[name]; !ok {
+		schema.anchors[name] = target
+	}
+}
+
+// subschemas returns the Schemas that are directly contained by a Schema.
+func (schema *Schema) subschemas() []*Schema {
+	result := make([]*Schema, 0)
+	for _, s := range schema.locatedSubschemas() {
+		result = append(result, s.schema)
+	}
+	return result
+}
+
+// A locatedSubschema is a subschema and the JSON Pointer to it from the
+// Schema that contains it.
+type locatedSubschema struct {
+	pointer string
+	schema  *Schema
+}
+
+// locatedSubschemas returns the Schemas that are directly contained by a
+// Schema with their locations.
+func (schema *Schema) locatedSubschemas() []locatedSubschema {
+	result := make([]locatedSubschema, 0)
+	add := func(pointer string, s *Schema) {
+		if s != nil {
+			result = append(result, locatedSubschema{pointer: pointer, schema: s})
+		}
+	}
+	addArray := func(keyword string, a *[]*Schema) {
+		if a != nil {
+			for i, s := range *a {
+				add("/"+keyword+"/"+strconv.Itoa(i), s)
+			}
+		}
+	}
+	addNamed := func(keyword string, a *[]*NamedSchema) {
+		if a != nil {
+			for _, pair := range *a {
+				add("/"+keyword+"/"+escapePointerToken(pair.Name), pair.Value)
+			}
+		}
+	}
+	addSchemaOrBoolean := func(keyword string, s *SchemaOrBoolean) {
+		if s != nil {
+			add("/"+keyword, s.Schema)
+		}
+	}
+	if schema.Items != nil {
+		add("/items", schema.Items.Schema)
+		addArray("items", schema.Items.SchemaArray)
+	}
+	addArray("prefixItems", schema.PrefixItems)
+	addSchemaOrBoolean("additionalItems", schema.AdditionalItems)
+	addSchemaOrBoolean("unevaluatedItems", schema.UnevaluatedItems)
+	add("/contains", schema.Contains)
+	addNamed("properties", schema.Properties)
+	addNamed("patternProperties", schema.PatternProperties)
+	addSchemaOrBoolean("additionalProperties", schema.AdditionalProperties)
+	addSchemaOrBoolean("unevaluatedProperties", schema.UnevaluatedProperties)
+	add("/propertyNames", schema.PropertyNames)
+	if schema.Dependencies != nil {
+		for _, pair := range *schema.Dependencies {
+			add("/dependencies/"+escapePointerToken(pair.Name), pair.Value.Schema)
+		}
+	}
+	addNamed("dependentSchemas", schema.DependentSchemas)
+	addArray("allOf", schema.AllOf)
+	addArray("anyOf", schema.AnyOf)
+	addArray("oneOf", schema.OneOf)
+	add("/not", schema.Not)
+	add("/if", schema.If)
+	add("/then", schema.Then)
+	add("/else", schema.Else)
+	addNamed("definitions", schema.Definitions)
+	addNamed("$defs", schema.Defs)
+	add("/contentSchema", schema.ContentSchema)
+	if schema.Links != nil {
+		for i, link := range *schema.Links {
+			for _, pair := range link.subschemas() {
+				add("/links/"+strconv.Itoa(i)+"/"+pair.Name, pair.Value)
+			}
+		}
+	}
+	return result
+}
+
+// ResolveReference returns the Schema identified by a reference that appears
+// in a Schema. Relative references are resolved against the base URI of the
+// Schema, which is established by the "$id" (or "id") of the closest enclosing
+// schema resource. Fragments can be JSON Pointers or anchor names.
+func (schema *Schema) ResolveReference(ref string) (*Schema, error) {
+	if schema.resource == nil {
+		schema.register(nil, nil)
+	}
+	base, err := url.Parse(schema.base)
+	if err != nil {
+		return nil, err
+	}
+	target, err := base.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	key := resourceKey(target)
+	var document *Schema
+	if key == schema.base {
+		document = schema.resource
+	} else if document = schemas[key]; document == nil && target.Scheme == "file" {
+		// local schemas that haven't been read yet are read on demand
+		if document, err = NewSchemaFromFile(filepath.FromSlash(target.Path)); err != nil {
+			return nil, err
+		}
+	}
+	if document == nil {
+		return nil, fmt.Errorf("unresolved pointer: %+v (unknown schema %s)", ref, key)
+	}
+	fragment := target.Fragment
+	if fragment == "" {
+		return document, nil
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		if s := document.anchors[fragment]; s != nil {
+			return s, nil
+		}
+		return nil, fmt.Errorf("unresolved pointer: %+v (unknown anchor %s)", ref, fragment)
+	}
+	tokens := strings.Split(fragment, "/")[1:]
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	if result := document.resolvePointer(tokens); result != nil {
+		return result, nil
+	}
+	return nil, fmt.Errorf("unresolved pointer: %+v", ref)
+}
+
+// resolvePointer follows the tokens of a JSON Pointer through the keywords
+// of a Schema. It returns nil if the tokens don't identify a Schema.
+func (schema *Schema) resolvePointer(tokens []string) *Schema {
+	if len(tokens) == 0 {
+		return schema
+	}
+	keyword, rest := tokens[0], tokens[1:]
+	// Some keywords contain maps or arrays of schemas, and the next token selects one of them.
+	member := ""
+	switch keyword {
+	case "definitions", "$defs", "properties", "patternProperties", "dependentSchemas", "dependencies",
+		"prefixItems", "allOf", "anyOf", "oneOf", "links":
+		if len(rest) == 0 {
+			return nil
+		}
+		member, rest = rest[0], rest[1:]
+	case "items":
+		if schema.Items != nil && schema.Items.SchemaArray != nil {
+			if len(rest) == 0 {
+				return nil
+			}
+			member, rest = rest[0], rest[1:]
+		}
+	}
+	var next *Schema
+	switch keyword {
+	case "definitions":
+		next = namedSchemaArrayElementWithName(schema.Definitions, member)
+	case "$defs":
+		next = namedSchemaArrayElementWithName(schema.Defs, member)
+	case "properties":
+		next = namedSchemaArrayElementWithName(schema.Properties, member)
+	case "patternProperties":
+		next = namedSchemaArrayElementWithName(schema.PatternProperties, member)
+	case "dependentSchemas":
+		next = namedSchemaArrayElementWithName(schema.DependentSchemas, member)
+	case "dependencies":
+		if schema.Dependencies != nil {
+			for _, pair := range *schema.Dependencies {
+				if pair.Name == member {
+					next = pair.Value.Schema
+				}
+			}
+		}
+	case "items":
+		if schema.Items != nil {
+			if schema.Items.SchemaArray != nil {
+				next = schemaArrayElementWithIndex(schema.Items.SchemaArray, member)
+			} else {
+				next = schema.Items.Schema
+			}
+		}
+	case "prefixItems":
+		next = schemaArrayElementWithIndex(schema.PrefixItems, member)
+	case "allOf":
+		next = schemaArrayElementWithIndex(schema.AllOf, member)
+	case "anyOf":
+		next = schemaArrayElementWithIndex(schema.AnyOf, member)
+	case "oneOf":
+		next = schemaArrayElementWithIndex(schema.OneOf, member)
+	case "not":
+		next = schema.Not
+	case "if":
+		next = schema.If
+	case "then":
+		next = schema.Then
+	case "else":
+		next = schema.Else
+	case "contains":
+		next = schema.Contains
+	case "propertyNames":
+		next = schema.PropertyNames
+	case "contentSchema":
+		next = schema.ContentSchema
+	case "links":
+		// The token after the index of a link selects one of its schemas.
+		i, err := strconv.Atoi(member)
+		if schema.Links != nil && err == nil && i >= 0 && i < len(*schema.Links) && len(rest) > 0 {
+			for _, pair := range (*schema.Links)[i].subschemas() {
+				if pair.Name == rest[0] {
+					next = pair.Value
+				}
+			}
+			rest = rest[1:]
+		}
+	case "additionalItems":
+		next = schemaOrBooleanSchema(schema.AdditionalItems)
+	case "unevaluatedItems":
+		next = schemaOrBooleanSchema(schema.UnevaluatedItems)
+	case "additionalProperties":
+		next = schemaOrBooleanSchema(schema.AdditionalProperties)
+	case "unevaluatedProperties":
+		next = schemaOrBooleanSchema(schema.UnevaluatedProperties)
+	}
+	if next == nil {
+		return nil
+	}
+	return next.resolvePointer(rest)
+}
+
+func schemaArrayElementWithIndex(array *[]*Schema, index string) *Schema {
+	i, err := strconv.Atoi(index)
+	if array == nil || err != nil || i < 0 || i >= len(*array) {
+		return nil
+	}
+	return (*array)[i]
+}
+
+func schemaOrBooleanSchema(value *SchemaOrBoolean) *Schema {
+	if value == nil {
+		return nil
+	}
+	return value.Schema
+}
@@ -0,0 +1,773 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A ValidationError describes a part of an instance that fails to satisfy a Schema.
+type ValidationError struct {
+	// InstancePath is a JSON Pointer to the invalid value in the instance.
+	InstancePath string
+	// SchemaPath is a JSON Pointer to the keyword that failed, following
+	// references through the keywords that contain them.
+	SchemaPath string
+	// Message describes the failure.
+	Message string
+}
+
+// Error returns a description of a validation error.
+func (e *ValidationError) Error() string {
+	instancePath := e.InstancePath
+	if instancePath == "" {
+		instancePath = "/"
+	}
+	return fmt.Sprintf("%s: %s (%s)", instancePath, e.Message, e.SchemaPath)
+}
+
+// Validate evaluates an instance against a Schema and returns the errors that
+// it finds, or nil if the instance is valid. Instances are YAML nodes, which
+// can be read from either YAML or JSON. References are resolved as described
+// for ResolveReference. The "format" keyword is treated as an annotation.
+func (schema *Schema) Validate(instance *yaml.Node) []*ValidationError {
+	v := &validator{regexps: make(map[string]*regexp.Regexp), active: make(map[visit]bool)}
+	schema.register(nil, nil)
+	result := v.evaluate(schema, instanceValue(instance), "", "", nil)
+	if len(result.errors) == 0 {
+		return nil
+	}
+	return result.errors
+}
+
+// validator holds the state of a single validation.
+type validator struct {
+	regexps map[string]*regexp.Regexp
+	// schema and instance pairs that are being evaluated, used to stop reference cycles
+	active map[visit]bool
+}
+
+type visit struct {
+	schema   *Schema
+	instance *yaml.Node
+}
+
+// evaluation is the result of evaluating an instance against a schema.
+type evaluation struct {
+	errors []*ValidationError
+	// properties and array items that were successfully evaluated,
+	// used by unevaluatedProperties and unevaluatedItems
+	properties map[string]bool
+	items      int
+	allItems   bool
+}
+
+func (e *evaluation) valid() bool {
+	return len(e.errors) == 0
+}
+
+func (e *evaluation) fail(instancePath, schemaPath, format string, args ...interface{}) {
+	e.errors = append(e.errors, &ValidationError{
+		InstancePath: instancePath,
+		SchemaPath:   schemaPath,
+		Message:      fmt.Sprintf(format, args...),
+	})
+}
+
+// add adds the errors of the evaluation of a part of the instance.
+func (e *evaluation) add(sub *evaluation) {
+	e.errors = append(e.errors, sub.errors...)
+}
+
+// merge adds the errors and annotations of a subschema evaluation of the same
+// instance. Annotations are kept when the subschema fails because the failure
+// is reported and to avoid reporting the same properties as unevaluated.
+func (e *evaluation) merge(sub *evaluation) {
+	e.errors = append(e.errors, sub.errors...)
+	e.annotate(sub)
+}
+
+// annotate adds the annotations of a successful subschema evaluation.
+func (e *evaluation) annotate(sub *evaluation) {
+	for name := range sub.properties {
+		e.evaluatedProperty(name)
+	}
+	if sub.items > e.items {
+		e.items = sub.items
+	}
+	e.allItems = e.allItems || sub.allItems
+}
+
+func (e *evaluation) evaluatedProperty(name string) {
+	if e.properties == nil {
+		e.properties = make(map[string]bool)
+	}
+	e.properties[name] = true
+}
+
+// instanceValue removes documents and aliases that wrap an instance value.
+func instanceValue(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return nil
+			}
+			node = node.Content[0]
+		case yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// escapePointerToken escapes a JSON Pointer reference token.
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// evaluate evaluates an instance against a schema. The scope contains the schema
+// resources that have been entered, outermost first, and is used to resolve
+// $dynamicRef and $recursiveRef.
+func (v *validator) evaluate(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, scope []*Schema) *evaluation {
+	result := &evaluation{}
+	if schema == nil || instance == nil {
+		return result
+	}
+	if schema.Boolean != nil {
+		if !*schema.Boolean {
+			result.fail(instancePath, schemaPath, "no values are allowed")
+		}
+		return result
+	}
+	key := visit{schema: schema, instance: instance}
+	if v.active[key] {
+		return result
+	}
+	v.active[key] = true
+	defer delete(v.active, key)
+	if schema.resource != nil && (len(scope) == 0 || scope[len(scope)-1] != schema.resource) {
+		scope = append(scope[:len(scope):len(scope)], schema.resource)
+	}
+
+	if schema.Ref != nil {
+		target, err := schema.ResolveReference(*schema.Ref)
+		if err != nil {
+			result.fail(instancePath, schemaPath+"/$ref", "%s", err.Error())
+		} else {
+			result.merge(v.evaluate(target, instance, instancePath, schemaPath+"/$ref", scope))
+		}
+		switch schema.Dialect() {
+		case DialectDraft04, DialectDraft06, DialectDraft07:
+			// before 2019-09, keywords next to $ref are ignored
+			return result
+		}
+	}
+	if schema.DynamicRef != nil {
+		v.evaluateDynamicRef(schema, instance, instancePath, schemaPath, scope, result)
+	}
+	if schema.RecursiveRef != nil {
+		v.evaluateRecursiveRef(schema, instance, instancePath, schemaPath, scope, result)
+	}
+
+	v.evaluateType(schema, instance, instancePath, schemaPath, result)
+	if schema.Enumeration != nil {
+		found := false
+		for _, value := range *schema.Enumeration {
+			if equalValues(enumValueNode(value), instance) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.fail(instancePath, schemaPath+"/enum", "value is not one of the allowed values")
+		}
+	}
+	if schema.Const != nil && !equalValues(instanceValue(schema.Const), instance) {
+		result.fail(instancePath, schemaPath+"/const", "value must be %s", describeNode(schema.Const))
+	}
+
+	switch instance.Kind {
+	case yaml.ScalarNode:
+		switch instance.ShortTag() {
+		case "!!int", "!!float":
+			v.evaluateNumber(schema, instance, instancePath, schemaPath, result)
+		case "!!str":
+			v.evaluateString(schema, instance, instancePath, schemaPath, result)
+		}
+	case yaml.SequenceNode:
+		v.evaluateArray(schema, instance, instancePath, schemaPath, scope, result)
+	case yaml.MappingNode:
+		v.evaluateObject(schema, instance, instancePath, schemaPath, scope, result)
+	}
+
+	v.evaluateApplicators(schema, instance, instancePath, schemaPath, scope, result)
+
+	// unevaluated keywords depend on the annotations of all of the other keywords
+	if instance.Kind == yaml.SequenceNode && schema.UnevaluatedItems != nil && !result.allItems {
+		for i := result.items; i < len(instance.Content); i++ {
+			path := fmt.Sprintf("%s/%d", instancePath, i)
+			result.add(v.evaluateSchemaOrBoolean(schema.UnevaluatedItems, instance.Content[i], path, schemaPath+"/unevaluatedItems", scope))
+		}
+		result.allItems = true
+	}
+	if instance.Kind == yaml.MappingNode && schema.UnevaluatedProperties != nil {
+		for i := 0; i+1 < len(instance.Content); i += 2 {
+			name := instance.Content[i].Value
+			if result.properties[name] {
+				continue
+			}
+			path := instancePath + "/" + escapePointerToken(name)
+			result.add(v.evaluateSchemaOrBoolean(schema.UnevaluatedProperties, instance.Content[i+1], path, schemaPath+"/unevaluatedProperties", scope))
+			result.evaluatedProperty(name)
+		}
+	}
+	return result
+}
+
+// evaluateSchemaOrBoolean evaluates an instance against a keyword that can be a schema or a boolean.
+func (v *validator) evaluateSchemaOrBoolean(s *SchemaOrBoolean, instance *yaml.Node, instancePath, schemaPath string, scope []*Schema) *evaluation {
+	if s.Schema != nil {
+		return v.evaluate(s.Schema, instance, instancePath, schemaPath, scope)
+	}
+	result := &evaluation{}
+	if s.Boolean != nil && !*s.Boolean {
+		result.fail(instancePath, schemaPath, "no values are allowed")
+	}
+	return result
+}
+
+// evaluateDynamicRef follows a $dynamicRef. If its target has a matching
+// $dynamicAnchor, the outermost resource in the dynamic scope with that
+// anchor is used instead.
+func (v *validator) evaluateDynamicRef(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, scope []*Schema, result *evaluation) {
+	path := schemaPath + "/$dynamicRef"
+	target, err := schema.ResolveReference(*schema.DynamicRef)
+	if err != nil {
+		result.fail(instancePath, path, "%s", err.Error())
+		return
+	}
+	if i := strings.Index(*schema.DynamicRef, "#"); i >= 0 {
+		anchor := (*schema.DynamicRef)[i+1:]
+		if target.DynamicAnchor != nil && *target.DynamicAnchor == anchor {
+			for _, resource := range scope {
+				if s := resource.anchors[anchor]; s != nil && s.DynamicAnchor != nil && *s.DynamicAnchor == anchor {
+					target = s
+					break
+				}
+			}
+		}
+	}
+	result.merge(v.evaluate(target, instance, instancePath, path, scope))
+}
+
+// evaluateRecursiveRef follows a $recursiveRef. If its target sets
+// $recursiveAnchor, the outermost resource in the dynamic scope that
+// also sets it is used instead.
+func (v *validator) evaluateRecursiveRef(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, scope []*Schema, result *evaluation) {
+	path := schemaPath + "/$recursiveRef"
+	target, err := schema.ResolveReference(*schema.RecursiveRef)
+	if err != nil {
+		result.fail(instancePath, path, "%s", err.Error())
+		return
+	}
+	if target.RecursiveAnchor != nil && *target.RecursiveAnchor {
+		for _, resource := range scope {
+			if resource.RecursiveAnchor != nil && *resource.RecursiveAnchor {
+				target = resource
+				break
+			}
+		}
+	}
+	result.merge(v.evaluate(target, instance, instancePath, path, scope))
+}
+
+// instanceType returns the JSON type of an instance value.
+func instanceType(instance *yaml.Node) string {
+	switch instance.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch instance.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	default:
+		return "string"
+	}
+}
+
+func (v *validator) evaluateType(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, result *evaluation) {
+	if schema.Type == nil {
+		return
+	}
+	var types []string
+	if schema.Type.String != nil {
+		types = []string{*schema.Type.String}
+	} else if schema.Type.StringArray != nil {
+		types = *schema.Type.StringArray
+	}
+	actual := instanceType(instance)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return
+		}
+		if t == "integer" && actual == "number" {
+			// numbers with zero fractional parts are integers
+			if r, ok := numberValue(instance); ok && r.IsInt() {
+				return
+			}
+		}
+	}
+	result.fail(instancePath, schemaPath+"/type", "expected %s, found %s", strings.Join(types, " or "), actual)
+}
+
+// numberValue returns the exact value of a numeric instance.
+func numberValue(instance *yaml.Node) (*big.Rat, bool) {
+	return new(big.Rat).SetString(instance.Value)
+}
+
+// schemaNumberValue returns the exact value of a number in a schema.
+func schemaNumberValue(n *SchemaNumber) *big.Rat {
+	if n.Integer != nil {
+		return new(big.Rat).SetInt64(*n.Integer)
+	}
+	if n.Float != nil {
+		// use the shortest decimal representation so that values like 0.01 are exact
+		if r, ok := new(big.Rat).SetString(strconv.FormatFloat(*n.Float, 'g', -1, 64)); ok {
+			return r
+		}
+	}
+	return nil
+}
+
+func (v *validator) evaluateNumber(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, result *evaluation) {
+	value, ok := numberValue(instance)
+	if !ok {
+		return
+	}
+	if schema.MultipleOf != nil {
+		if m := schemaNumberValue(schema.MultipleOf); m != nil && m.Sign() != 0 {
+			if !new(big.Rat).Quo(value, m).IsInt() {
+				result.fail(instancePath, schemaPath+"/multipleOf", "%s is not a multiple of %s", instance.Value, schema.MultipleOf)
+			}
+		}
+	}
+	if schema.Maximum != nil {
+		if m := schemaNumberValue(schema.Maximum); m != nil {
+			if schema.ExclusiveMaximum != nil && *schema.ExclusiveMaximum {
+				if value.Cmp(m) >= 0 {
+					result.fail(instancePath, schemaPath+"/maximum", "%s is not less than %s", instance.Value, schema.Maximum)
+				}
+			} else if value.Cmp(m) > 0 {
+				result.fail(instancePath, schemaPath+"/maximum", "%s is greater than %s", instance.Value, schema.Maximum)
+			}
+		}
+	}
+	if schema.ExclusiveMaximumValue != nil {
+		if m := schemaNumberValue(schema.ExclusiveMaximumValue); m != nil && value.Cmp(m) >= 0 {
+			result.fail(instancePath, schemaPath+"/exclusiveMaximum", "%s is not less than %s", instance.Value, schema.ExclusiveMaximumValue)
+		}
+	}
+	if schema.Minimum != nil {
+		if m := schemaNumberValue(schema.Minimum); m != nil {
+			if schema.ExclusiveMinimum != nil && *schema.ExclusiveMinimum {
+				if value.Cmp(m) <= 0 {
+					result.fail(instancePath, schemaPath+"/minimum", "%s is not greater than %s", instance.Value, schema.Minimum)
+				}
+			} else if value.Cmp(m) < 0 {
+				result.fail(instancePath, schemaPath+"/minimum", "%s is less than %s", instance.Value, schema.Minimum)
+			}
+		}
+	}
+	if schema.ExclusiveMinimumValue != nil {
+		if m := schemaNumberValue(schema.ExclusiveMinimumValue); m != nil && value.Cmp(m) <= 0 {
+			result.fail(instancePath, schemaPath+"/exclusiveMinimum", "%s is not greater than %s", instance.Value, schema.ExclusiveMinimumValue)
+		}
+	}
+}
+
+func (v *validator) evaluateString(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, result *evaluation) {
+	length := int64(utf8.RuneCountInString(instance.Value))
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		result.fail(instancePath, schemaPath+"/maxLength", "length %d is greater than %d", length, *schema.MaxLength)
+	}
+	if schema.MinLength != nil && length < *schema.MinLength {
+		result.fail(instancePath, schemaPath+"/minLength", "length %d is less than %d", length, *schema.MinLength)
+	}
+	if schema.Pattern != nil {
+		re, err := v.regexp(*schema.Pattern)
+		if err != nil {
+			result.fail(instancePath, schemaPath+"/pattern", "invalid pattern: %s", err.Error())
+		} else if !re.MatchString(instance.Value) {
+			result.fail(instancePath, schemaPath+"/pattern", "value does not match %s", *schema.Pattern)
+		}
+	}
+}
+
+// regexp compiles a pattern, caching the results for the validation.
+func (v *validator) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.regexps[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.regexps[pattern] = re
+	return re, nil
+}
+
+func (v *validator) evaluateArray(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, scope []*Schema, result *evaluation) {
+	items := instance.Content
+	count := int64(len(items))
+	if schema.MaxItems != nil && count > *schema.MaxItems {
+		result.fail(instancePath, schemaPath+"/maxItems", "array has %d items, more than %d", count, *schema.MaxItems)
+	}
+	if schema.MinItems != nil && count < *schema.MinItems {
+		result.fail(instancePath, schemaPath+"/minItems", "array has %d items, fewer than %d", count, *schema.MinItems)
+	}
+	if schema.UniqueItems != nil && *schema.UniqueItems {
+	unique:
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if equalValues(items[i], items[j]) {
+					result.fail(instancePath, schemaPath+"/uniqueItems", "items %d and %d are equal", i, j)
+					break unique
+				}
+			}
+		}
+	}
+
+	itemPath := func(i int) string {
+		return instancePath + "/" + strconv.Itoa(i)
+	}
+	start := 0
+	if schema.PrefixItems != nil {
+		for i, s := range *schema.PrefixItems {
+			if i >= len(items) {
+				break
+			}
+			result.add(v.evaluate(s, items[i], itemPath(i), fmt.Sprintf("%s/prefixItems/%d", schemaPath, i), scope))
+			start = i + 1
+		}
+		if start > result.items {
+			result.items = start
+		}
+	}
+	if schema.Items != nil {
+		if schema.Items.SchemaArray != nil {
+			// the array form of items validates items by position
+			tuple := *schema.Items.SchemaArray
+			for i, s := range tuple {
+				if i >= len(items) {
+					break
+				}
+				result.add(v.evaluate(s, items[i], itemPath(i), fmt.Sprintf("%s/items/%d", schemaPath, i), scope))
+			}
+			if len(tuple) > result.items {
+				result.items = len(tuple)
+			}
+			if schema.AdditionalItems != nil {
+				for i := len(tuple); i < len(items); i++ {
+					result.add(v.evaluateSchemaOrBoolean(schema.AdditionalItems, items[i], itemPath(i), schemaPath+"/additionalItems", scope))
+				}
+				result.allItems = true
+			}
+		} else if schema.Items.Schema != nil {
+			for i := start; i < len(items); i++ {
+				result.add(v.evaluate(schema.Items.Schema, items[i], itemPath(i), schemaPath+"/items", scope))
+			}
+			result.allItems = true
+		}
+	}
+	if schema.Contains != nil {
+		matches := int64(0)
+		for i, item := range items {
+			if v.evaluate(schema.Contains, item, itemPath(i), schemaPath+"/contains", scope).valid() {
+				matches++
+			}
+		}
+		minContains := int64(1)
+		if schema.MinContains != nil {
+			minContains = *schema.MinContains
+		}
+		if matches < minContains {
+			result.fail(instancePath, schemaPath+"/contains", "array contains %d matching items, fewer than %d", matches, minContains)
+		}
+		if schema.MaxContains != nil && matches > *schema.MaxContains {
+			result.fail(instancePath, schemaPath+"/maxContains", "array contains %d matching items, more than %d", matches, *schema.MaxContains)
+		}
+	}
+}
+
+func (v *validator) evaluateObject(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, scope []*Schema, result *evaluation) {
+	names := make([]string, 0)
+	values := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(instance.Content); i += 2 {
+		name := instance.Content[i].Value
+		names = append(names, name)
+		values[name] = instance.Content[i+1]
+	}
+	propertyPath := func(name string) string {
+		return instancePath + "/" + escapePointerToken(name)
+	}
+	count := int64(len(names))
+	if schema.MaxProperties != nil && count > *schema.MaxProperties {
+		result.fail(instancePath, schemaPath+"/maxProperties", "object has %d properties, more than %d", count, *schema.MaxProperties)
+	}
+	if schema.MinProperties != nil && count < *schema.MinProperties {
+		result.fail(instancePath, schemaPath+"/minProperties", "object has %d properties, fewer than %d", count, *schema.MinProperties)
+	}
+	if schema.Required != nil {
+		for _, name := range *schema.Required {
+			if _, ok := values[name]; !ok {
+				result.fail(instancePath, schemaPath+"/required", "missing required property %q", name)
+			}
+		}
+	}
+
+	// properties that are matched by properties or patternProperties aren't "additional"
+	matched := make(map[string]bool)
+	if schema.Properties != nil {
+		for _, pair := range *schema.Properties {
+			if value, ok := values[pair.Name]; ok {
+				matched[pair.Name] = true
+				path := schemaPath + "/properties/" + escapePointerToken(pair.Name)
+				result.add(v.evaluate(pair.Value, value, propertyPath(pair.Name), path, scope))
+				result.evaluatedProperty(pair.Name)
+			}
+		}
+	}
+	if schema.PatternProperties != nil {
+		for _, pair := range *schema.PatternProperties {
+			re, err := v.regexp(pair.Name)
+			path := schemaPath + "/patternProperties/" + escapePointerToken(pair.Name)
+			if err != nil {
+				result.fail(instancePath, path, "invalid pattern: %s", err.Error())
+				continue
+			}
+			for _, name := range names {
+				if re.MatchString(name) {
+					matched[name] = true
+					result.add(v.evaluate(pair.Value, values[name], propertyPath(name), path, scope))
+					result.evaluatedProperty(name)
+				}
+			}
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		for _, name := range names {
+			if matched[name] {
+				continue
+			}
+			result.add(v.evaluateSchemaOrBoolean(schema.AdditionalProperties, values[name], propertyPath(name), schemaPath+"/additionalProperties", scope))
+			result.evaluatedProperty(name)
+		}
+	}
+	if schema.PropertyNames != nil {
+		for _, name := range names {
+			path := schemaPath + "/propertyNames"
+			result.add(v.evaluate(schema.PropertyNames, nodeForString(name), propertyPath(name), path, scope))
+		}
+	}
+
+	required := func(keyword, name string, dependencies []string) {
+		if _, ok := values[name]; !ok {
+			return
+		}
+		for _, dependency := range dependencies {
+			if _, ok := values[dependency]; !ok {
+				result.fail(instancePath, schemaPath+"/"+keyword+"/"+escapePointerToken(name), "property %q requires property %q", name, dependency)
+			}
+		}
+	}
+	if schema.Dependencies != nil {
+		for _, pair := range *schema.Dependencies {
+			if pair.Value.StringArray != nil {
+				required("dependencies", pair.Name, *pair.Value.StringArray)
+			} else if _, ok := values[pair.Name]; ok && pair.Value.Schema != nil {
+				path := schemaPath + "/dependencies/" + escapePointerToken(pair.Name)
+				result.merge(v.evaluate(pair.Value.Schema, instance, instancePath, path, scope))
+			}
+		}
+	}
+	if !schema.Dialect().hasDependentKeywords() {
+		return
+	}
+	if schema.DependentRequired != nil {
+		for _, pair := range *schema.DependentRequired {
+			if pair.Value.StringArray != nil {
+				required("dependentRequired", pair.Name, *pair.Value.StringArray)
+			}
+		}
+	}
+	if schema.DependentSchemas != nil {
+		for _, pair := range *schema.DependentSchemas {
+			if _, ok := values[pair.Name]; ok {
+				path := schemaPath + "/dependentSchemas/" + escapePointerToken(pair.Name)
+				result.merge(v.evaluate(pair.Value, instance, instancePath, path, scope))
+			}
+		}
+	}
+}
+
+func (v *validator) evaluateApplicators(schema *Schema, instance *yaml.Node, instancePath, schemaPath string, scope []*Schema, result *evaluation) {
+	if schema.AllOf != nil {
+		for i, s := range *schema.AllOf {
+			result.merge(v.evaluate(s, instance, instancePath, fmt.Sprintf("%s/allOf/%d", schemaPath, i), scope))
+		}
+	}
+	if schema.AnyOf != nil {
+		var errors []*ValidationError
+		matched := false
+		for i, s := range *schema.AnyOf {
+			sub := v.evaluate(s, instance, instancePath, fmt.Sprintf("%s/anyOf/%d", schemaPath, i), scope)
+			if sub.valid() {
+				matched = true
+				result.annotate(sub)
+			} else {
+				errors = append(errors, sub.errors...)
+			}
+		}
+		if !matched {
+			result.fail(instancePath, schemaPath+"/anyOf", "value does not match any of the schemas")
+			result.errors = append(result.errors, errors...)
+		}
+	}
+	if schema.OneOf != nil {
+		var errors []*ValidationError
+		var matches []int
+		for i, s := range *schema.OneOf {
+			sub := v.evaluate(s, instance, instancePath, fmt.Sprintf("%s/oneOf/%d", schemaPath, i), scope)
+			if sub.valid() {
+				matches = append(matches, i)
+				result.annotate(sub)
+			} else {
+				errors = append(errors, sub.errors...)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			result.fail(instancePath, schemaPath+"/oneOf", "value does not match any of the schemas")
+			result.errors = append(result.errors, errors...)
+		case 1:
+		default:
+			result.fail(instancePath, schemaPath+"/oneOf", "value matches more than one schema %v", matches)
+		}
+	}
+	if schema.Not != nil {
+		if v.evaluate(schema.Not, instance, instancePath, schemaPath+"/not", scope).valid() {
+			result.fail(instancePath, schemaPath+"/not", "value matches a schema that it must not match")
+		}
+	}
+	if schema.If != nil && schema.Dialect().hasConditionals() {
+		condition := v.evaluate(schema.If, instance, instancePath, schemaPath+"/if", scope)
+		if condition.valid() {
+			result.annotate(condition)
+			if schema.Then != nil {
+				result.merge(v.evaluate(schema.Then, instance, instancePath, schemaPath+"/then", scope))
+			}
+		} else if schema.Else != nil {
+			result.merge(v.evaluate(schema.Else, instance, instancePath, schemaPath+"/else", scope))
+		}
+	}
+}
+
+// enumValueNode returns the value of an enum entry as a YAML node.
+func enumValueNode(value SchemaEnumValue) *yaml.Node {
+	if value.String != nil {
+		return nodeForString(*value.String)
+	}
+	if value.Bool != nil {
+		return nodeForBoolean(*value.Bool)
+	}
+	return instanceValue(value.Value)
+}
+
+// equalValues compares two instance values using the JSON data model:
+// numbers are equal if their values are equal, and object properties
+// are compared without regard to order.
+func equalValues(a, b *yaml.Node) bool {
+	a, b = instanceValue(a), instanceValue(b)
+	if a == nil || b == nil {
+		return a == b
+	}
+	ta, tb := instanceType(a), instanceType(b)
+	numeric := func(t string) bool { return t == "integer" || t == "number" }
+	if numeric(ta) && numeric(tb) {
+		x, ok1 := numberValue(a)
+		y, ok2 := numberValue(b)
+		return ok1 && ok2 && x.Cmp(y) == 0
+	}
+	if ta != tb {
+		return false
+	}
+	switch ta {
+	case "array":
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := range a.Content {
+			if !equalValues(a.Content[i], b.Content[i]) {
+				return false
+			}
+		}
+		return true
+	case "object":
+		ma, mb := mappingValues(a), mappingValues(b)
+		if len(ma) != len(mb) {
+			return false
+		}
+		for k, va := range ma {
+			if vb, ok := mb[k]; !ok || !equalValues(va, vb) {
+				return false
+			}
+		}
+		return true
+	case "boolean":
+		x, _ := strconv.ParseBool(a.Value)
+		y, _ := strconv.ParseBool(b.Value)
+		return x == y
+	case "null":
+		return true
+	default:
+		return a.Value == b.Value
+	}
+}
+
+func mappingValues(node *yaml.Node) map[string]*yaml.Node {
+	m := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		m[node.Content[i].Value] = node.Content[i+1]
+	}
+	return m
+}
@@ -15,7 +15,11 @@
 package jsonschema
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -23,29 +27,23 @@ import (
 
 const indentation = "  "
 
-func renderMappingNode(node *yaml.Node, indent string) (result string) {
+// A renderer writes yaml.Nodes as JSON.
+type renderer struct {
+	indentation string
+}
+
+func (r *renderer) renderMappingNode(node *yaml.Node, indent string) (result string) {
+	if len(node.Content) == 0 {
+		return "{}"
+	}
 	result = "{\n"
-	innerIndent := indent + indentation
+	innerIndent := indent + r.indentation
 	for i := 0; i < len(node.Content); i += 2 {
 		// first print the key
 		key := node.Content[i].Value
-		result += fmt.Sprintf("%s\"%+v\": ", innerIndent, key)
+		result += innerIndent + jsonString(key) + ": "
 		// then the value
-		value := node.Content[i+1]
-		switch value.Kind {
-		case yaml.ScalarNode:
-			if value.Tag == "!!bool" {
-				result += value.Value
-			} else {
-				result += "\"" + value.Value + "\""
-			}
-		case yaml.MappingNode:
-			result += renderMappingNode(value, innerIndent)
-		case yaml.SequenceNode:
-			result += renderSequenceNode(value, innerIndent)
-		default:
-			result += fmt.Sprintf("???MapItem(Key:%+v, Value:%T)", value, value)
-		}
+		result += r.renderNode(node.Content[i+1], innerIndent)
 		if i < len(node.Content)-2 {
 			result += ","
 		}
@@ -56,23 +54,14 @@ func renderMappingNode(node *yaml.Node, indent string) (result string) {
 	return result
 }
 
-func renderSequenceNode(node *yaml.Node, indent string) (result string) {
+func (r *renderer) renderSequenceNode(node *yaml.Node, indent string) (result string) {
+	if len(node.Content) == 0 {
+		return "[]"
+	}
 	result = "[\n"
-	innerIndent := indent + indentation
+	innerIndent := indent + r.indentation
 	for i := 0; i < len(node.Content); i++ {
-		item := node.Content[i]
-		switch item.Kind {
-		case yaml.ScalarNode:
-			if item.Tag == "!!bool" {
-				result += innerIndent + item.Value
-			} else {
-				result += innerIndent + "\"" + item.Value + "\""
-			}
-		case yaml.MappingNode:
-			result += innerIndent + renderMappingNode(item, innerIndent) + ""
-		default:
-			result += innerIndent + fmt.Sprintf("???ArrayItem(%+v)", item)
-		}
+		result += innerIndent + r.renderNode(node.Content[i], innerIndent)
 		if i < len(node.Content)-1 {
 			result += ","
 		}
@@ -82,32 +71,58 @@ func renderSequenceNode(node *yaml.Node, indent string) (result string) {
 	return result
 }
 
-func renderStringArray(array []string, indent string) (result string) {
-	result = "[\n"
-	innerIndent := indent + indentation
-	for i, item := range array {
-		result += innerIndent + "\"" + item + "\""
-		if i < len(array)-1 {
-			result += ","
+func (r *renderer) renderNode(node *yaml.Node, indent string) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return renderScalarNode(node)
+	case yaml.MappingNode:
+		return r.renderMappingNode(node, indent)
+	case yaml.SequenceNode:
+		return r.renderSequenceNode(node, indent)
+	case yaml.DocumentNode:
+		if len(node.Content) == 1 {
+			return r.renderNode(node.Content[0], indent)
 		}
-		result += "\n"
+	case yaml.AliasNode:
+		return r.renderNode(node.Alias, indent)
 	}
-	result += indent + "]"
-	return result
+	return fmt.Sprintf("???Node(%+v)", node)
+}
+
+func renderScalarNode(node *yaml.Node) string {
+	switch node.Tag {
+	case "!!bool", "!!int", "!!float", "!!null":
+		return node.Value
+	default:
+		return jsonString(node.Value)
+	}
+}
+
+// jsonString returns a string as a quoted and escaped JSON string.
+func jsonString(s string) string {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return strconv.Quote(s)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
 }
 
 // Render renders a yaml.Node as JSON
 func Render(node *yaml.Node) string {
+	return renderWithIndentation(node, indentation)
+}
+
+func renderWithIndentation(node *yaml.Node, indentation string) string {
 	if node.Kind == yaml.DocumentNode {
 		if len(node.Content) == 1 {
-			return Render(node.Content[0])
+			return renderWithIndentation(node.Content[0], indentation)
 		}
-	} else if node.Kind == yaml.MappingNode {
-		return renderMappingNode(node, "") + "\n"
-	} else if node.Kind == yaml.SequenceNode {
-		return renderSequenceNode(node, "") + "\n"
+		return ""
 	}
-	return ""
+	r := &renderer{indentation: indentation}
+	return r.renderNode(node, "") + "\n"
 }
 
 func (object *SchemaNumber) nodeValue() *yaml.Node {
@@ -182,7 +197,7 @@ func (object *SchemaEnumValue) nodeValue() *yaml.Node {
 	} else if object.Bool != nil {
 		return nodeForBoolean(*object.Bool)
 	} else {
-		return nil
+		return object.Value
 	}
 }
 
@@ -210,6 +225,25 @@ func nodeForSchemaEnumArray(array *[]SchemaEnumValue) *yaml.Node {
 	return nodeForSequence(content)
 }
 
+func nodeForNamedStringArray(array *[]*NamedString) *yaml.Node {
+	content := make([]*yaml.Node, 0)
+	for _, pair := range *(array) {
+		content = appendPair(content, pair.Name, nodeForString(pair.Value))
+	}
+	return nodeForMapping(content)
+}
+
+func nodeForLinks(links *[]*Link) *yaml.Node {
+	if links == nil {
+		return nil
+	}
+	content := make([]*yaml.Node, 0)
+	for _, link := range *links {
+		content = append(content, link.nodeValue())
+	}
+	return nodeForSequence(content)
+}
+
 func nodeForMapping(content []*yaml.Node) *yaml.Node {
 	return &yaml.Node{
 		Kind:    yaml.MappingNode,
@@ -263,26 +297,32 @@ func appendPair(nodes []*yaml.Node, name string, value *yaml.Node) []*yaml.Node
 }
 
 func (schema *Schema) nodeValue() *yaml.Node {
+	if schema.Boolean != nil {
+		return nodeForBoolean(*schema.Boolean)
+	}
 	n := &yaml.Node{Kind: yaml.MappingNode}
 	content := make([]*yaml.Node, 0)
 	if schema.Title != nil {
 		content = appendPair(content, "title", nodeForString(*schema.Title))
 	}
 	if schema.ID != nil {
-		switch strings.TrimSuffix(*schema.Schema, "#") {
-		case "http://json-schema.org/draft-04/schema":
-			fallthrough
-		case "#":
-			fallthrough
-		case "":
-			content = appendPair(content, "id", nodeForString(*schema.ID))
-		default:
-			content = appendPair(content, "$id", nodeForString(*schema.ID))
-		}
+		content = appendPair(content, schema.idKeyword(), nodeForString(*schema.ID))
 	}
 	if schema.Schema != nil {
 		content = appendPair(content, "$schema", nodeForString(*schema.Schema))
 	}
+	if schema.Comment != nil {
+		content = appendPair(content, "$comment", nodeForString(*schema.Comment))
+	}
+	if schema.Anchor != nil {
+		content = appendPair(content, "$anchor", nodeForString(*schema.Anchor))
+	}
+	if schema.DynamicAnchor != nil {
+		content = appendPair(content, "$dynamicAnchor", nodeForString(*schema.DynamicAnchor))
+	}
+	if schema.RecursiveAnchor != nil {
+		content = appendPair(content, "$recursiveAnchor", nodeForBoolean(*schema.RecursiveAnchor))
+	}
 	if schema.ReadOnly != nil && *schema.ReadOnly {
 		content = appendPair(content, "readOnly", nodeForBoolean(*schema.ReadOnly))
 	}
@@ -313,9 +353,27 @@ func (schema *Schema) nodeValue() *yaml.Node {
 	if schema.Dependencies != nil {
 		content = appendPair(content, "dependencies", nodeForNamedSchemaOrStringArray(schema.Dependencies))
 	}
+	if schema.PropertyNames != nil {
+		content = appendPair(content, "propertyNames", schema.PropertyNames.nodeValue())
+	}
+	if schema.DependentRequired != nil {
+		content = appendPair(content, "dependentRequired", nodeForNamedSchemaOrStringArray(schema.DependentRequired))
+	}
+	if schema.DependentSchemas != nil {
+		content = appendPair(content, "dependentSchemas", nodeForNamedSchemaArray(schema.DependentSchemas))
+	}
+	if schema.UnevaluatedProperties != nil {
+		content = appendPair(content, "unevaluatedProperties", schema.UnevaluatedProperties.nodeValue())
+	}
 	if schema.Ref != nil {
 		content = appendPair(content, "$ref", nodeForString(*schema.Ref))
 	}
+	if schema.RecursiveRef != nil {
+		content = appendPair(content, "$recursiveRef", nodeForString(*schema.RecursiveRef))
+	}
+	if schema.DynamicRef != nil {
+		content = appendPair(content, "$dynamicRef", nodeForString(*schema.DynamicRef))
+	}
 	if schema.MultipleOf != nil {
 		content = appendPair(content, "multipleOf", schema.MultipleOf.nodeValue())
 	}
@@ -325,12 +383,18 @@ func (schema *Schema) nodeValue() *yaml.Node {
 	if schema.ExclusiveMaximum != nil {
 		content = appendPair(content, "exclusiveMaximum", nodeForBoolean(*schema.ExclusiveMaximum))
 	}
+	if schema.ExclusiveMaximumValue != nil {
+		content = appendPair(content, "exclusiveMaximum", schema.ExclusiveMaximumValue.nodeValue())
+	}
 	if schema.Minimum != nil {
 		content = appendPair(content, "minimum", schema.Minimum.nodeValue())
 	}
 	if schema.ExclusiveMinimum != nil {
 		content = appendPair(content, "exclusiveMinimum", nodeForBoolean(*schema.ExclusiveMinimum))
 	}
+	if schema.ExclusiveMinimumValue != nil {
+		content = appendPair(content, "exclusiveMinimum", schema.ExclusiveMinimumValue.nodeValue())
+	}
 	if schema.MaxLength != nil {
 		content = appendPair(content, "maxLength", nodeForInt64(*schema.MaxLength))
 	}
@@ -352,6 +416,21 @@ func (schema *Schema) nodeValue() *yaml.Node {
 	if schema.UniqueItems != nil {
 		content = appendPair(content, "uniqueItems", nodeForBoolean(*schema.UniqueItems))
 	}
+	if schema.Contains != nil {
+		content = appendPair(content, "contains", schema.Contains.nodeValue())
+	}
+	if schema.MaxContains != nil {
+		content = appendPair(content, "maxContains", nodeForInt64(*schema.MaxContains))
+	}
+	if schema.MinContains != nil {
+		content = appendPair(content, "minContains", nodeForInt64(*schema.MinContains))
+	}
+	if schema.PrefixItems != nil {
+		content = appendPair(content, "prefixItems", nodeForSchemaArray(*schema.PrefixItems))
+	}
+	if schema.UnevaluatedItems != nil {
+		content = appendPair(content, "unevaluatedItems", schema.UnevaluatedItems.nodeValue())
+	}
 	if schema.MaxProperties != nil {
 		content = appendPair(content, "maxProperties", nodeForInt64(*schema.MaxProperties))
 	}
@@ -361,6 +440,9 @@ func (schema *Schema) nodeValue() *yaml.Node {
 	if schema.Enumeration != nil {
 		content = appendPair(content, "enum", nodeForSchemaEnumArray(schema.Enumeration))
 	}
+	if schema.Const != nil {
+		content = appendPair(content, "const", schema.Const)
+	}
 	if schema.AllOf != nil {
 		content = appendPair(content, "allOf", nodeForSchemaArray(*schema.AllOf))
 	}
@@ -373,21 +455,245 @@ func (schema *Schema) nodeValue() *yaml.Node {
 	if schema.Not != nil {
 		content = appendPair(content, "not", schema.Not.nodeValue())
 	}
+	if schema.If != nil {
+		content = appendPair(content, "if", schema.If.nodeValue())
+	}
+	if schema.Then != nil {
+		content = appendPair(content, "then", schema.Then.nodeValue())
+	}
+	if schema.Else != nil {
+		content = appendPair(content, "else", schema.Else.nodeValue())
+	}
 	if schema.Definitions != nil {
 		content = appendPair(content, "definitions", nodeForNamedSchemaArray(schema.Definitions))
 	}
+	if schema.Defs != nil {
+		content = appendPair(content, "$defs", nodeForNamedSchemaArray(schema.Defs))
+	}
 	if schema.Default != nil {
 		// m = append(m, yaml.MapItem{Key: "default", Value: *schema.Default})
 	}
+	if schema.Examples != nil {
+		content = appendPair(content, "examples", schema.Examples)
+	}
+	if schema.Deprecated != nil {
+		content = appendPair(content, "deprecated", nodeForBoolean(*schema.Deprecated))
+	}
+	if schema.ContentEncoding != nil {
+		content = appendPair(content, "contentEncoding", nodeForString(*schema.ContentEncoding))
+	}
+	if schema.ContentMediaType != nil {
+		content = appendPair(content, "contentMediaType", nodeForString(*schema.ContentMediaType))
+	}
+	if schema.ContentSchema != nil {
+		content = appendPair(content, "contentSchema", schema.ContentSchema.nodeValue())
+	}
 	if schema.Format != nil {
 		content = appendPair(content, "format", nodeForString(*schema.Format))
 	}
-	n.Content = content
+	if schema.Base != nil {
+		content = appendPair(content, "base", nodeForString(*schema.Base))
+	}
+	if schema.Links != nil {
+		content = appendPair(content, "links", nodeForLinks(schema.Links))
+	}
+	n.Content = orderKeywords(content, schema.keywords)
 	return n
 }
 
+// orderKeywords arranges the keyword-value pairs of a schema in the order
+// that the keywords were read. Keywords that weren't read follow them.
+func orderKeywords(content []*yaml.Node, keywords []string) []*yaml.Node {
+	if len(keywords) == 0 {
+		return content
+	}
+	normalize := func(keyword string) string {
+		if keyword == "id" {
+			return "$id"
+		}
+		return keyword
+	}
+	position := make(map[string]int)
+	for i, keyword := range keywords {
+		position[normalize(keyword)] = i
+	}
+	pairs := make([][]*yaml.Node, 0, len(content)/2)
+	for i := 0; i+1 < len(content); i += 2 {
+		pairs = append(pairs, content[i:i+2])
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		a, aok := position[normalize(pairs[i][0].Value)]
+		b, bok := position[normalize(pairs[j][0].Value)]
+		if aok && bok {
+			return a < b
+		}
+		return aok && !bok
+	})
+	ordered := make([]*yaml.Node, 0, len(content))
+	for _, pair := range pairs {
+		ordered = append(ordered, pair...)
+	}
+	return ordered
+}
+
 // JSONString returns a json representation of a schema.
 func (schema *Schema) JSONString() string {
 	node := schema.nodeValue()
 	return Render(node)
 }
+
+// Format selects the syntax that is used to write schemas.
+type Format int
+
+const (
+	// FormatJSON writes schemas as JSON.
+	FormatJSON Format = iota
+	// FormatYAML writes schemas as YAML.
+	FormatYAML
+)
+
+// KeyOrder selects the order in which the keys of objects are written.
+type KeyOrder int
+
+const (
+	// SourceKeyOrder writes keywords in the order that they were read,
+	// followed by any others in the order of CanonicalKeyOrder.
+	SourceKeyOrder KeyOrder = iota
+	// CanonicalKeyOrder writes keywords in a fixed order that groups
+	// related keywords, like the JSON Schema specifications.
+	CanonicalKeyOrder
+	// AlphabeticalKeyOrder sorts the keys of all objects, including the
+	// names of properties and definitions.
+	AlphabeticalKeyOrder
+)
+
+// WriterOptions control how schemas are written.
+type WriterOptions struct {
+	Format   Format
+	Indent   int // the number of spaces in each level of indentation, 2 if zero
+	KeyOrder KeyOrder
+}
+
+// Write returns a text representation of a schema that is formatted with
+// the specified options. With nil options, it writes JSON like JSONString().
+func (schema *Schema) Write(options *WriterOptions) ([]byte, error) {
+	if options == nil {
+		options = &WriterOptions{}
+	}
+	var node *yaml.Node
+	switch options.KeyOrder {
+	case SourceKeyOrder:
+		node = schema.nodeValue()
+	case CanonicalKeyOrder:
+		node = schema.withoutKeywordOrder().nodeValue()
+	case AlphabeticalKeyOrder:
+		node = sortedKeys(schema.nodeValue())
+	default:
+		return nil, fmt.Errorf("unknown key order %d", options.KeyOrder)
+	}
+	indent := options.Indent
+	if indent <= 0 {
+		indent = len(indentation)
+	}
+	switch options.Format {
+	case FormatJSON:
+		return []byte(renderWithIndentation(node, strings.Repeat(" ", indent))), nil
+	case FormatYAML:
+		var b bytes.Buffer
+		encoder := yaml.NewEncoder(&b)
+		encoder.SetIndent(indent)
+		if err := encoder.Encode(node); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %d", options.Format)
+	}
+}
+
+// withoutKeywordOrder returns a copy of a schema that doesn't remember
+// the order of the keywords that were read.
+func (schema *Schema) withoutKeywordOrder() *Schema {
+	copied := readSchema(schema.nodeValue(), schema.dialect)
+	var forget func(s *Schema)
+	forget = func(s *Schema) {
+		s.keywords = nil
+		for _, subschema := range s.locatedSubschemas() {
+			forget(subschema.schema)
+		}
+	}
+	forget(copied)
+	return copied
+}
+
+// sortedKeys returns a copy of a node in which the keys of all of the
+// mappings are sorted. Nodes are copied because the nodes of a schema's
+// values, like its default, are shared with the schema.
+func sortedKeys(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Content = make([]*yaml.Node, 0, len(node.Content))
+	for _, child := range node.Content {
+		copied.Content = append(copied.Content, sortedKeys(child))
+	}
+	if copied.Kind == yaml.MappingNode {
+		pairs := make([][]*yaml.Node, 0, len(copied.Content)/2)
+		for i := 0; i+1 < len(copied.Content); i += 2 {
+			pairs = append(pairs, copied.Content[i:i+2])
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return pairs[i][0].Value < pairs[j][0].Value
+		})
+		content := make([]*yaml.Node, 0, len(copied.Content))
+		for _, pair := range pairs {
+			content = append(content, pair...)
+		}
+		copied.Content = content
+	}
+	return &copied
+}
+
+func (link *Link) nodeValue() *yaml.Node {
+	content := make([]*yaml.Node, 0)
+	addString := func(name string, value *string) {
+		if value != nil {
+			content = appendPair(content, name, nodeForString(*value))
+		}
+	}
+	addSchema := func(name string, value *Schema) {
+		if value != nil {
+			content = appendPair(content, name, value.nodeValue())
+		}
+	}
+	if link.Rel != nil {
+		content = appendPair(content, "rel", link.Rel.nodeValue())
+	}
+	addString("href", link.Href)
+	addString("anchor", link.Anchor)
+	addString("anchorPointer", link.AnchorPointer)
+	if link.TemplatePointers != nil {
+		content = appendPair(content, "templatePointers", nodeForNamedStringArray(link.TemplatePointers))
+	}
+	if link.TemplateRequired != nil {
+		content = appendPair(content, "templateRequired", nodeForStringArray(*link.TemplateRequired))
+	}
+	addString("title", link.Title)
+	addString("description", link.Description)
+	addString("$comment", link.Comment)
+	addString("method", link.Method)
+	addSchema("hrefSchema", link.HrefSchema)
+	addSchema("targetSchema", link.TargetSchema)
+	addString("targetMediaType", link.TargetMediaType)
+	addString("mediaType", link.MediaType)
+	if link.TargetHints != nil {
+		content = appendPair(content, "targetHints", link.TargetHints)
+	}
+	addSchema("headerSchema", link.HeaderSchema)
+	addString("submissionMediaType", link.SubmissionMediaType)
+	addString("encType", link.EncType)
+	addSchema("submissionSchema", link.SubmissionSchema)
+	addSchema("schema", link.Schema)
+	return nodeForMapping(content)
+}
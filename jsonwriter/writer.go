@@ -90,6 +90,9 @@ func (w *writer) writeScalar(node *yaml.Node, indent string) {
 		w.writeString(node.Value)
 	case "!!null":
 		w.writeString(null)
+	default:
+		// Timestamps and other tags have no JSON equivalents.
+		w.writeString(strconv.Quote(node.Value))
 	}
 }
 
@@ -39,6 +39,7 @@ func TestMarshal(t *testing.T) {
 		scalarIntTestCase(),
 		scalarStringTestCase(),
 		scalarNullTestCase(),
+		scalarTimestampTestCase(),
 		sequenceStringArrayTestCase(),
 		sequenceBoolArrayTestCase(),
 		sequenceFloatArrayTestCase(),
@@ -215,3 +216,11 @@ func aliasNodeTestCase() *MarshalTestCase {
 		Err:  true,
 	}
 }
+
+func scalarTimestampTestCase() *MarshalTestCase {
+	return &MarshalTestCase{
+		Name:     "scalar timestamp",
+		Node:     &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: "2020-01-01"},
+		Expected: "\"2020-01-01\"\n",
+	}
+}
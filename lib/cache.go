@@ -0,0 +1,235 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// cacheVersion is included in every cache key. Change it when changes
+// to the compiler change the documents that it produces.
+const cacheVersion = "14"
+
+// Compiled documents can be saved in a cache directory so that later
+// runs with unchanged inputs don't need to compile them again. Each
+// document is saved in a binary protocol buffer file named with the
+// SHA-256 hash of everything that it was compiled from: the source, the
+// files that the source refers to, and the extensions that were used.
+
+// Returns the cache key of a source.
+func (g *Gnostic) cacheKey(bytes []byte) (string, error) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "gnostic %s\n", cacheVersion)
+	if g.options.PlainStrings {
+		fmt.Fprintf(hash, "plain-strings\n")
+	}
+	if g.options.NormalizeUnicode {
+		fmt.Fprintf(hash, "normalize-unicode\n")
+	}
+	for _, handler := range g.options.ExtensionHandlers {
+		fmt.Fprintf(hash, "extension %s\n", handler.Name)
+	}
+	// the names of extension functions are hashed, but not their code
+	names := make([]string, 0, len(g.options.ExtensionFunctions))
+	for name := range g.options.ExtensionFunctions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(hash, "extension-function %s\n", name)
+	}
+	fmt.Fprintf(hash, "source %s %d\n", filepath.Ext(g.sourceName), len(bytes))
+	hash.Write(bytes)
+	references, err := referencedFiles(g.ctx, g.sourceName, bytes, &g.options)
+	if err != nil {
+		return "", err
+	}
+	for _, name := range references {
+		contents, err := g.options.readFile(g.ctx, name)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "file %s %d\n", name, len(contents))
+		hash.Write(contents)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Returns the names of all files that a source refers to, directly or
+// indirectly, in sorted order. Files that can't be read with options,
+// including files outside of its reference root if it has one, are
+// included in the names, and the first error reading them is returned. No
+// files are read after ctx is done.
+func referencedFiles(ctx context.Context, sourceName string, bytes []byte, options *Options) ([]string, error) {
+	visited := map[string]bool{sourceName: true}
+	pending := []string{sourceName}
+	names := make([]string, 0)
+	var firstErr error
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		contents := bytes
+		if name != sourceName {
+			names = append(names, name)
+			var err error
+			contents, err = options.readFile(ctx, name)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+		}
+		info, err := options.readInfo(name, contents)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, reference := range referencesInNode(info) {
+			file := strings.SplitN(reference, "#", 2)[0]
+			if file == "" {
+				continue
+			}
+			file = compiler.ReferencedFileName(name, file)
+			if !visited[file] {
+				visited[file] = true
+				pending = append(pending, file)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, firstErr
+}
+
+// Returns the values of all $ref keys in a node.
+func referencesInNode(node *yaml.Node) []string {
+	references := make([]string, 0)
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+				references = append(references, node.Content[i+1].Value)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		references = append(references, referencesInNode(child)...)
+	}
+	return references
+}
+
+// Reads a document and its annotations from the cache, returning nil if
+// they aren't there.
+func (g *Gnostic) readCachedDocument(key string) proto.Message {
+	data, err := ioutil.ReadFile(filepath.Join(g.options.CacheDirectory, key+".pb"))
+	if err != nil {
+		return nil
+	}
+	message, err := g.readOpenAPIBinary(data)
+	if err != nil {
+		return nil
+	}
+	// Binary models don't hold annotations, so they are saved beside them.
+	data, err = ioutil.ReadFile(filepath.Join(g.options.CacheDirectory, key+".annotations"))
+	if err != nil {
+		return nil
+	}
+	annotations := &compiler.Annotations{}
+	if json.Unmarshal(data, annotations) != nil {
+		return nil
+	}
+	g.annotations = annotations
+	return message
+}
+
+// Writes a document to the cache, along with its annotations. The
+// annotations are written first so that they are there when the document
+// is read.
+func (g *Gnostic) writeCachedDocument(key string, message proto.Message) error {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	annotations, err := json.Marshal(g.annotations)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(g.options.CacheDirectory, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	if err := g.writeCacheFile(key, ".annotations", annotations); err != nil {
+		return err
+	}
+	return g.writeCacheFile(key, ".pb", data)
+}
+
+// Writes a file to the cache. The file is written to a temporary file
+// that is renamed so that other processes sharing the cache never read a
+// partially-written file.
+func (g *Gnostic) writeCacheFile(key, extension string, data []byte) error {
+	file, err := ioutil.TempFile(g.options.CacheDirectory, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), filepath.Join(g.options.CacheDirectory, key+extension))
+}
+
+// Reads a document from the cache if it has been compiled before and
+// otherwise compiles it and saves it in the cache.
+func (g *Gnostic) readDocumentWithCache(bytes []byte) (proto.Message, error) {
+	if g.options.CacheDirectory == "" || strings.ToLower(filepath.Ext(g.sourceName)) == ".pb" {
+		return g.readDocument(bytes)
+	}
+	key, err := g.cacheKey(bytes)
+	if err != nil {
+		// Errors reading references are reported when they are resolved.
+		return g.readDocument(bytes)
+	}
+	if message := g.readCachedDocument(key); message != nil {
+		return message, nil
+	}
+	message, err := g.readDocument(bytes)
+	if err != nil {
+		return message, err
+	}
+	if err := g.writeCachedDocument(key, message); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing to cache %s\n", err.Error())
+	}
+	return message, nil
+}
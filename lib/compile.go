@@ -0,0 +1,100 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// An Input is an API description to be compiled by Compile.
+type Input struct {
+	// Name is the filename or URL of the description. It determines how
+	// the description is read and is used to resolve relative references.
+	Name string
+	// Bytes is the contents of the description. If it is nil, the
+	// description is read from Name.
+	Bytes []byte
+}
+
+// A Result is the outcome of compiling one Input.
+type Result struct {
+	Name         string
+	Document     proto.Message
+	SourceFormat int // one of the SourceFormat constants
+	// Annotations are needed to write the document as it was read.
+	Annotations *compiler.Annotations
+	Err         error
+}
+
+// Compile compiles API descriptions in parallel using up to parallelism
+// goroutines, or one per CPU if parallelism is zero or less. Results are
+// returned in the order of the inputs, and an error compiling one input
+// doesn't stop the compilation of the others.
+//
+// All compilations share the compiler's file and info caches, so files
+// that are read by more than one input, such as commonly-referenced
+// schemas, are fetched and parsed only once. Each goroutine compiles its
+// inputs in a compiler.Arena, and the parsed text of each input is
+// released when its model has been built.
+func Compile(inputs []Input, parallelism int) []*Result {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	results := make([]*Result, len(inputs))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism && i < len(inputs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			arena := compiler.NewArena()
+			for index := range indices {
+				results[index] = compileInput(inputs[index], arena)
+			}
+		}()
+	}
+	for i := range inputs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return results
+}
+
+// Compile a single input, in an arena if one is given.
+func compileInput(input Input, arena *compiler.Arena) *Result {
+	result := &Result{Name: input.Name, SourceFormat: SourceFormatUnknown}
+	bytes := input.Bytes
+	if bytes == nil {
+		bytes, result.Err = compiler.ReadBytesForFile(input.Name)
+		if result.Err != nil {
+			return result
+		}
+	}
+	g := NewGnostic([]string{"gnostic", input.Name})
+	g.sourceName = input.Name
+	g.arena = arena
+	result.Document, result.Err = g.readDocument(bytes)
+	if result.Err == nil {
+		result.SourceFormat = g.sourceFormat
+		result.Annotations = g.annotations
+	}
+	return result
+}
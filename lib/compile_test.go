@@ -0,0 +1,1303 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+	openapi_v31 "github.com/okkoye/gnostic/openapiv31"
+	plugins "github.com/okkoye/gnostic/plugins"
+)
+
+// The benchmark corpus is the set of example descriptions in the
+// repository, which covers each supported source format.
+func corpus(t testing.TB) []Input {
+	patterns := []string{
+		"../examples/v2.0/yaml/*.yaml",
+		"../examples/v2.0/json/*.json",
+		"../examples/v3.0/yaml/*.yaml",
+		"../examples/v3.0/json/*.json",
+		"../examples/discovery/*.json",
+	}
+	inputs := make([]Input, 0)
+	for _, pattern := range patterns {
+		filenames, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		for _, filename := range filenames {
+			bytes, err := compiler.ReadBytesForFile(filename)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			inputs = append(inputs, Input{Name: filename, Bytes: bytes})
+		}
+	}
+	if len(inputs) == 0 {
+		t.Fatalf("no inputs found")
+	}
+	return inputs
+}
+
+func TestCompile(t *testing.T) {
+	inputs := corpus(t)
+	// Descriptions may be saved in UTF-16 without a byte-order mark.
+	text, err := compiler.ReadBytesForFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	units := utf16.Encode([]rune(string(text)))
+	utf16Text := make([]byte, 2*len(units))
+	for i, unit := range units {
+		binary.LittleEndian.PutUint16(utf16Text[2*i:], unit)
+	}
+	inputs = append(inputs,
+		Input{Name: "../examples/v3.0/yaml/petstore.yaml"},
+		Input{Name: "../examples/v3.0/yaml/petstore-utf16.yaml", Bytes: utf16Text},
+		Input{Name: "unknown.yaml", Bytes: []byte("info:\n  title: unknown\n")},
+		Input{Name: "missing.yaml"},
+	)
+	results := Compile(inputs, 4)
+	if len(results) != len(inputs) {
+		t.Fatalf("expected %d results, got %d", len(inputs), len(results))
+	}
+	for i, result := range results {
+		if result.Name != inputs[i].Name {
+			t.Errorf("result %d: expected %s, got %s", i, inputs[i].Name, result.Name)
+		}
+	}
+	for _, result := range results[:len(results)-2] {
+		if result.Err != nil {
+			t.Errorf("%s: %+v", result.Name, result.Err)
+			continue
+		}
+		var expected int
+		switch filepath.Base(filepath.Dir(filepath.Dir(result.Name))) {
+		case "v2.0":
+			expected = SourceFormatOpenAPI2
+		case "v3.0":
+			expected = SourceFormatOpenAPI3
+		default:
+			expected = SourceFormatDiscovery
+		}
+		if result.SourceFormat != expected || result.Document == nil {
+			t.Errorf("%s: expected format %d, got %d", result.Name, expected, result.SourceFormat)
+		}
+	}
+	for _, result := range results[len(results)-2:] {
+		if result.Err == nil || result.Document != nil || result.SourceFormat != SourceFormatUnknown {
+			t.Errorf("%s: expected an error", result.Name)
+		}
+	}
+}
+
+func benchmarkCompile(b *testing.B, parallelism int) {
+	inputs := corpus(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		compiler.ClearInfoCache()
+		for _, result := range Compile(inputs, parallelism) {
+			if result.Err != nil {
+				b.Fatalf("%s: %+v", result.Name, result.Err)
+			}
+		}
+	}
+}
+
+func BenchmarkCompileSequential(b *testing.B) {
+	benchmarkCompile(b, 1)
+}
+
+func BenchmarkCompileParallel(b *testing.B) {
+	benchmarkCompile(b, 0)
+}
+
+func TestCompileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "openapi.yaml")
+	schemas := filepath.Join(dir, "schemas.yaml")
+	cache := filepath.Join(dir, "cache")
+	output := filepath.Join(dir, "openapi.pb")
+	write := func(filename, contents string) {
+		if err := ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	compile := func() *openapi_v3.Document {
+		compiler.ClearCaches()
+		g := NewGnostic([]string{"gnostic", source, "--cache-dir=" + cache, "--pb-out=" + output})
+		if err := g.Main(); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		data, err := ioutil.ReadFile(output)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		document := &openapi_v3.Document{}
+		if err := proto.Unmarshal(data, document); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return document
+	}
+	cached := func() []string {
+		filenames, err := filepath.Glob(filepath.Join(cache, "*.pb"))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return filenames
+	}
+	write(source, `openapi: 3.0.0
+info:
+  title: Cached
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: "schemas.yaml#/Pet"
+`)
+	write(schemas, "Pet:\n  type: object\n")
+
+	if document := compile(); document.Info.Title != "Cached" {
+		t.Fatalf("unexpected title %s", document.Info.Title)
+	}
+	filenames := cached()
+	if len(filenames) != 1 {
+		t.Fatalf("expected one cached document, found %d", len(filenames))
+	}
+	// Replace the cached document to show that it is used.
+	document := compile()
+	document.Info.Title = "From cache"
+	data, err := proto.Marshal(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	write(filenames[0], string(data))
+	if document := compile(); document.Info.Title != "From cache" {
+		t.Errorf("expected the cached document, got %s", document.Info.Title)
+	}
+	// Changing a referenced file changes the cache key.
+	write(schemas, "Pet:\n  type: string\n")
+	if document := compile(); document.Info.Title != "Cached" {
+		t.Errorf("expected a new compilation, got %s", document.Info.Title)
+	}
+	if len(cached()) != 2 {
+		t.Errorf("expected two cached documents, found %d", len(cached()))
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, contents string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	names := func(results []*Result) []string {
+		names := make([]string, 0)
+		for _, result := range results {
+			names = append(names, filepath.Base(result.Name))
+			if result.Err != nil {
+				names = append(names, "error")
+			}
+		}
+		return names
+	}
+	description := "openapi: 3.0.0\ninfo:\n  title: %s\n  version: 1.0.0\npaths: {}\n"
+	write("a.yaml", fmt.Sprintf(description, "A")+"components:\n  schemas:\n    Pet:\n      $ref: \"schemas.yaml#/Pet\"\n")
+	write("b.yaml", fmt.Sprintf(description, "B"))
+	write("schemas.yaml", "Pet:\n  type: object\n")
+	compiler.ClearCaches()
+	w := NewWatcher([]string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")})
+
+	for _, test := range []struct {
+		change   func()
+		expected string
+	}{
+		{func() {}, "a.yaml b.yaml"},
+		{func() {}, ""},
+		{func() { write("schemas.yaml", "Pet:\n  type: integer\n") }, "a.yaml"},
+		{func() { write("b.yaml", fmt.Sprintf(description, "Bee")) }, "b.yaml"},
+		{func() { os.Remove(filepath.Join(dir, "schemas.yaml")) }, "a.yaml error"},
+		{func() { write("schemas.yaml", "Pet:\n  type: object\n") }, "a.yaml"},
+	} {
+		test.change()
+		results := w.Update()
+		if got := strings.Join(names(results), " "); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestWatchCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, contents string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	description := "openapi: 3.0.0\ninfo:\n  title: %s\n  version: 1.0.0\npaths: {}\ncomponents:\n  schemas:\n    Pet:\n      $ref: \"schemas.yaml#/Pet\"\n"
+	write("schemas.yaml", "Pet:\n  type: object\n")
+	out := filepath.Join(dir, "out")
+	if err := os.Mkdir(out, 0755); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sources := []string{filepath.Join(dir, "a.yaml")}
+	if _, err := newCommandWatcher(sources, []string{"gnostic", "--resolve-refs"}); err == nil || err.Error() != "missing output directives" {
+		t.Errorf("expected an error for a watch without outputs, got %v", err)
+	}
+	compiler.ClearCaches()
+	w, err := newCommandWatcher(sources, []string{"gnostic", "--resolve-refs", "--yaml-out=" + out, "--errors-out=" + out})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// Each compilation writes the outputs of the options.
+	for _, title := range []string{"A", "Aye"} {
+		write("a.yaml", fmt.Sprintf(description, title))
+		results := w.Update()
+		if len(results) != 1 || results[0].Err != nil || results[0].SourceFormat != SourceFormatOpenAPI3 {
+			t.Fatalf("unexpected results %+v", results)
+		}
+		bytes, err := ioutil.ReadFile(filepath.Join(out, dir, "a.yaml"))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if !strings.Contains(string(bytes), "title: "+title+"\n") {
+			t.Errorf("expected the title %s in the output:\n%s", title, bytes)
+		}
+	}
+	// References are resolved with the options of the command, and
+	// errors are written where they are sent.
+	os.Remove(filepath.Join(dir, "schemas.yaml"))
+	if results := w.Update(); len(results) != 1 || results[0].Err == nil {
+		t.Errorf("expected an error for a missing reference, got %+v", results)
+	}
+	if bytes, err := ioutil.ReadFile(filepath.Join(out, dir, "a.errors")); err != nil || !strings.Contains(string(bytes), "schemas.yaml") {
+		t.Errorf("expected an error for schemas.yaml, got %q (%v)", bytes, err)
+	}
+}
+
+func TestResolveReferencesWithFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api.yaml":
+			fmt.Fprint(w, `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    $ref: "schemas.yaml#/Pet"
+`)
+		case "/schemas.yaml":
+			fmt.Fprint(w, "Pet: {type: object, description: A pet.}\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	compiler.ClearCaches()
+	options := NewOptions(WithReferenceResolution(), WithFetcher(&compiler.HTTPFetcher{Token: "secret", Hosts: []string{server.URL}}))
+	message, _, err := ReadDocumentWithOptions(server.URL+"/api.yaml", options)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	schema := message.(*openapi_v2.Document).Definitions.AdditionalProperties[0].Value
+	if schema.Description != "A pet." {
+		t.Errorf("unexpected schema %+v", schema)
+	}
+	// Without the fetcher, the files can't be read.
+	if _, _, err := ReadDocumentWithOptions(server.URL+"/api.yaml", NewOptions(WithReferenceResolution())); err == nil {
+		t.Errorf("expected an error reading a file without credentials")
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	for _, filename := range []string{
+		"../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml",
+		"../examples/v2.0/json/petstore-separate/spec/swagger.json",
+		"../examples/v2.0/yaml/petstore.yaml",
+		"../examples/v3.0/yaml/petstore.yaml",
+	} {
+		compiler.ClearCaches()
+		expected, format, err := ReadDocument(filename)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		switch format {
+		case SourceFormatOpenAPI2:
+			_, err = expected.(*openapi_v2.Document).ResolveReferences(filename)
+		case SourceFormatOpenAPI3:
+			_, err = expected.(*openapi_v3.Document).ResolveReferences(filename)
+		}
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		compiler.ClearCaches()
+		document, _, err := ReadDocument(filename)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := ResolveReferences(document, filename, 4); err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		if !proto.Equal(document, expected) {
+			t.Errorf("%s: documents resolved differently", filename)
+		}
+	}
+	// A schema that refers to itself is resolved once.
+	dir, err := ioutil.TempDir("", "resolve")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "recursive.yaml")
+	err = ioutil.WriteFile(filename, []byte(`swagger: "2.0"
+info:
+  title: Recursive
+  version: 1.0.0
+paths: {}
+definitions:
+  Node:
+    type: object
+    properties:
+      next:
+        $ref: "#/definitions/Node"
+      missing:
+        $ref: "#/definitions/Missing"
+      again:
+        $ref: "#/definitions/Missing"
+`), 0644)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	compiler.ClearCaches()
+	message, _, err := ReadDocument(filename)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	err = ResolveReferences(message, filename, 0)
+	if err == nil || compiler.FormatErrors("recursive.yaml", err) != "recursive.yaml:13:15: $root.definitions.Node.properties.missing.$ref could not resolve #/definitions/Missing" {
+		t.Errorf("unexpected error %v", err)
+	}
+	document := message.(*openapi_v2.Document)
+	next := document.Definitions.AdditionalProperties[0].Value.Properties.AdditionalProperties[0].Value
+	if next.Type == nil || next.Properties.AdditionalProperties[0].Value.XRef != "#/definitions/Node" {
+		t.Errorf("expected one level of the recursive schema to be resolved")
+	}
+	// References written on Windows may use backslashes and mixed separators.
+	err = os.Mkdir(filepath.Join(dir, "common"), 0755)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "common", "pet.yaml"), []byte("Pet:\n  type: string\n"), 0644)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	filename = filepath.Join(dir, "windows.yaml")
+	err = ioutil.WriteFile(filename, []byte(`swagger: "2.0"
+info:
+  title: Windows
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    $ref: 'common\pet.yaml#/Pet'
+  Pets:
+    type: array
+    items:
+      $ref: '.\common/pet.yaml#/Pet'
+`), 0644)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	compiler.ClearCaches()
+	message, _, err = ReadDocument(filename)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err = ResolveReferences(message, filename, 0); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document = message.(*openapi_v2.Document)
+	pet := document.Definitions.AdditionalProperties[0].Value
+	pets := document.Definitions.AdditionalProperties[1].Value
+	if pet.Type == nil || pets.Items.Schema[0].Type == nil {
+		t.Errorf("expected references with backslashes to be resolved")
+	}
+}
+
+// Compiling the same descriptions must produce the same documents and the
+// same errors in the same order, however many goroutines are used.
+func TestDeterminism(t *testing.T) {
+	inputs := corpus(t)
+	filenames, err := filepath.Glob("../examples/errors/*.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, filename := range filenames {
+		inputs = append(inputs, Input{Name: filename})
+	}
+	outputs := func(parallelism int) []string {
+		compiler.ClearCaches()
+		results := Compile(inputs, parallelism)
+		outputs := make([]string, len(results))
+		for i, result := range results {
+			if result.Err != nil {
+				g := &Gnostic{sourceName: result.Name}
+				outputs[i] = string(g.errorBytes(result.Err))
+				continue
+			}
+			outputs[i] = proto.MarshalTextString(result.Document)
+			if err := ResolveReferences(result.Document, result.Name, parallelism); err != nil {
+				outputs[i] += err.Error()
+			}
+		}
+		return outputs
+	}
+	expected := outputs(1)
+	for _, parallelism := range []int{1, 4, 8} {
+		for i, output := range outputs(parallelism) {
+			if output != expected[i] {
+				t.Errorf("%s: output differs when compiled with %d goroutines", inputs[i].Name, parallelism)
+			}
+		}
+	}
+}
+
+func TestReferenceRoot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "root")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	root := filepath.Join(dir, "specs")
+	if err = os.Mkdir(root, 0755); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	definitions := []byte("Pet:\n  type: string\n")
+	for _, filename := range []string{filepath.Join(root, "pet.yaml"), filepath.Join(dir, "secret.yaml")} {
+		if err = ioutil.WriteFile(filename, definitions, 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	if err = os.Symlink(filepath.Join(dir, "secret.yaml"), filepath.Join(root, "link.yaml")); err != nil {
+		t.Skipf("symbolic links aren't supported: %v", err)
+	}
+	if err = os.Symlink(filepath.Join(root, "pet.yaml"), filepath.Join(root, "inner.yaml")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{"pet.yaml#/Pet", ""},
+		{"inner.yaml#/Pet", ""},
+		{"../secret.yaml#/Pet", "is outside of"},
+		{"link.yaml#/Pet", "links to a file outside of"},
+	}
+	for _, test := range tests {
+		filename := filepath.Join(root, "api.yaml")
+		err = ioutil.WriteFile(filename, []byte(`swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    $ref: "`+test.ref+`"
+`), 0644)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		compiler.ClearCaches()
+		_, _, err = ReadDocumentWithOptions(filename, NewOptions(WithReferenceResolution()))
+		if err != nil {
+			t.Errorf("%s: %+v", test.ref, err)
+		}
+		compiler.ClearCaches()
+		_, _, err = ReadDocumentWithOptions(filename, NewOptions(WithReferenceResolution(), WithReferenceRoot(root)))
+		if test.expected == "" && err != nil {
+			t.Errorf("%s: %+v", test.ref, err)
+		} else if test.expected != "" && (err == nil || !strings.Contains(err.Error(), test.expected)) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.ref, test.expected, err)
+		} else if test.expected != "" {
+			// Rejected files are reported at the references to them.
+			reports := compiler.ErrorReports(err)
+			if len(reports) != 1 || reports[0].Path != "$root.definitions.Pet.$ref" || reports[0].Line != 8 || reports[0].Column != 11 {
+				t.Errorf("%s: unexpected reports %+v", test.ref, reports)
+			}
+		}
+	}
+	// Sources are also read only from the root.
+	_, _, err = ReadDocumentWithOptions(filepath.Join(dir, "secret.yaml"), NewOptions(WithReferenceRoot(root)))
+	if err == nil || !strings.Contains(err.Error(), "is outside of") {
+		t.Errorf("expected an error reading a source outside of the root, got %v", err)
+	}
+	_, _, err = ReadDocumentWithOptions(filepath.Join(root, "missing.yaml"), NewOptions(WithReferenceRoot(root)))
+	if err == nil || !os.IsNotExist(err) {
+		t.Errorf("expected an error reading a missing source, got %v", err)
+	}
+	// Sources that are read with fetchers must be named as if they were
+	// in the root.
+	contents := WithSourceContents(definitions)
+	_, _, err = ReadDocumentWithOptions(filepath.Join(dir, "secret.yaml"), NewOptions(WithReferenceRoot(root), contents))
+	if err == nil || !strings.Contains(err.Error(), "is outside of") {
+		t.Errorf("expected an error compiling contents outside of the root, got %v", err)
+	}
+	// Files named with URLs are only read from a root if they are allowed.
+	fetcher := WithFetcher(compiler.FetcherFunc(func(fileurl string) ([]byte, error) {
+		return definitions, nil
+	}))
+	filename := filepath.Join(root, "api.yaml")
+	err = ioutil.WriteFile(filename, []byte(`swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    $ref: "https://example.com/pet.yaml#/Pet"
+`), 0644)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, source := range []string{"https://example.com/api.yaml", filename} {
+		options := NewOptions(WithReferenceResolution(), WithReferenceRoot(root), fetcher)
+		if source != filename {
+			WithSourceContents([]byte("swagger: \"2.0\"\n"))(options)
+		}
+		_, _, err = ReadDocumentWithOptions(source, options)
+		if err == nil || !strings.Contains(err.Error(), "URLs aren't read") {
+			t.Errorf("%s: expected an error reading a URL, got %v", source, err)
+		}
+	}
+	_, _, err = ReadDocumentWithOptions(filename, NewOptions(WithReferenceResolution(), WithReferenceRoot(root), WithURLsAllowed(), fetcher))
+	if err != nil {
+		t.Errorf("expected allowed URLs to be read, got %+v", err)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	g := NewGnostic([]string{"gnostic", "petstore.yaml", "--pb-out=-", "--json_out=out", "--x-sample", "--resolve-refs", "--lenient", "--verify-roundtrip", "--cache-dir=cache", "--ref-root=specs", "--allow-urls", "--plain-strings", "--normalize-unicode", "--strip-extensions", "--keep-extensions=x-public-*,x-logo", "--ref-header=X-Api-Key: key", "--ref-token=secret", "--ref-host=example.com", "--ref-timeout=30s", "--errors=json", "--lint-out=.", "--summary", "--plugin-requirements=lint"})
+	if err := g.readOptions(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := NewOptions(
+		WithOutput("pb", "-"),
+		WithOutput("json", "out"),
+		WithExtensions("sample"),
+		WithReferenceResolution(),
+		WithLenientCompilation(),
+		WithRoundTripVerification(),
+		WithErrorFormat("json"),
+		WithCacheDirectory("cache"),
+		WithReferenceRoot("specs"),
+		WithURLsAllowed(),
+		WithPlainStrings(),
+		WithUnicodeNormalization(),
+		WithExtensionsStripped(),
+		WithExtensionsKept("x-public-*", "x-logo"),
+		WithFetcher(&compiler.HTTPFetcher{
+			Header:  http.Header{"X-Api-Key": {"key"}},
+			Token:   "secret",
+			Hosts:   []string{"example.com"},
+			Timeout: 30 * time.Second,
+		}),
+	)
+	if !reflect.DeepEqual(&g.options, expected) {
+		t.Errorf("unexpected options %+v", g.options)
+	}
+	// Only the plugins that are named with --plugin-requirements are asked
+	// for their requirements.
+	if len(g.pluginCalls) != 2 || g.pluginCalls[0].Name != "lint" || !g.pluginCalls[0].Negotiate ||
+		g.pluginCalls[1].Name != "summary" || g.pluginCalls[1].Negotiate {
+		t.Errorf("unexpected plugin calls %+v", g.pluginCalls)
+	}
+	// Headers and tokens are sent to the host of the source unless other
+	// hosts are named, and sources that aren't URLs have no host.
+	for _, arg := range []string{"--ref-token=secret", "--ref-header=X-Api-Key: key"} {
+		g = NewGnostic([]string{"gnostic", "petstore.yaml", "--pb-out=-", arg})
+		if err := g.readOptions(); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := g.validateOptions(); err == nil || !strings.Contains(err.Error(), "--ref-host") {
+			t.Errorf("%s: expected an error without hosts, got %v", arg, err)
+		}
+	}
+	g = NewGnostic([]string{"gnostic", "https://example.com:8443/petstore.yaml", "--pb-out=-", "--ref-token=secret"})
+	if err := g.readOptions(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := g.validateOptions(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if hosts := g.options.Fetcher.(*compiler.HTTPFetcher).Hosts; !reflect.DeepEqual(hosts, []string{"https://example.com:8443"}) {
+		t.Errorf("unexpected hosts %v", hosts)
+	}
+
+	contents, err := ioutil.ReadFile("../examples/errors/petstore-badproperties.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	options := NewOptions(WithLenientCompilation(), WithSourceContents(contents))
+	message, format, err := ReadDocumentWithOptions("petstore-badproperties.yaml", options)
+	if message == nil || format != SourceFormatOpenAPI2 || err == nil {
+		t.Errorf("expected a document with errors, got %v %d %v", message, format, err)
+	}
+	options.Lenient = false
+	if message, _, err := ReadDocumentWithOptions("petstore-badproperties.yaml", options); message != nil || err == nil {
+		t.Errorf("expected only an error")
+	}
+}
+
+const extensionsDescription = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+  x-logo: pets.png
+paths:
+  /pets:
+    get:
+      x-internal-owner: pets-team
+      x-public-rate-limit: 100
+      responses:
+        "200":
+          description: ok
+x-internal-id: 123
+`
+
+func TestStripExtensions(t *testing.T) {
+	tests := []struct {
+		options  []Option
+		expected []string
+	}{
+		{nil, []string{"x-logo", "x-internal-owner", "x-public-rate-limit", "x-internal-id"}},
+		{[]Option{WithExtensionsStripped()}, nil},
+		{[]Option{WithExtensionsStripped("x-internal-*")}, []string{"x-logo", "x-public-rate-limit"}},
+		{[]Option{WithExtensionsKept("x-public-*", "x-logo")}, []string{"x-logo", "x-public-rate-limit"}},
+		{[]Option{WithExtensionsStripped("x-internal-*"), WithExtensionsKept("x-internal-id")}, []string{"x-logo", "x-public-rate-limit", "x-internal-id"}},
+	}
+	dir, err := ioutil.TempDir("", "strip")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "pets.yaml")
+	for _, test := range tests {
+		options := NewOptions(append(test.options, WithOutput("yaml", output), WithSourceContents([]byte(extensionsDescription)))...)
+		document, format, err := ReadDocumentWithOptions("pets.yaml", options)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := WriteDocument(document, format, "pets.yaml", options); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		bytes, err := ioutil.ReadFile(output)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		var written []string
+		for _, line := range strings.Split(string(bytes), "\n") {
+			if name := strings.TrimSpace(line); strings.HasPrefix(name, "x-") {
+				written = append(written, strings.SplitN(name, ":", 2)[0])
+			}
+		}
+		if !reflect.DeepEqual(written, test.expected) {
+			t.Errorf("expected %v, got %v", test.expected, written)
+		}
+		// Extensions are removed from the written copy of the document.
+		if len(document.(*openapi_v3.Document).SpecificationExtension) != 1 {
+			t.Errorf("expected the compiled document to be unchanged")
+		}
+	}
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	for _, filename := range []string{
+		"../examples/v2.0/yaml/petstore.yaml",
+		"../examples/v3.0/yaml/petstore.yaml",
+		"../examples/v3.1/yaml/petstore.yaml",
+		"../examples/discovery/discovery-v1.json",
+	} {
+		_, _, err := ReadDocumentWithOptions(filename, NewOptions(WithRoundTripVerification()))
+		if err != nil {
+			t.Errorf("%s: %+v", filename, err)
+		}
+	}
+
+	// Values that the models can't hold are reported with their pointers.
+	// The number fields of OpenAPI 3.1 schemas hold zero values.
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		source := `openapi: ` + version + `
+info:
+  title: Limits
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Count:
+      type: integer
+      minimum: 0
+      maximum: 10
+    Counts:
+      type: array
+      maxItems: 0
+      items:
+        $ref: "#/components/schemas/Count"
+`
+		_, _, err := ReadDocumentWithOptions("limits.yaml", NewOptions(WithSourceContents([]byte(source)), WithRoundTripVerification()))
+		if version == "3.1.0" {
+			if err != nil {
+				t.Errorf("%s: %+v", version, err)
+			}
+			continue
+		}
+		for _, expected := range []string{
+			"round trip: yaml output loses /components/schemas/Count/minimum",
+			"round trip: json output loses /components/schemas/Counts/maxItems",
+		} {
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Errorf("%s: expected %q in %v", version, expected, err)
+			}
+		}
+	}
+
+	// Differences are reported with the pointers of their values.
+	var before, after yaml.Node
+	if err := yaml.Unmarshal([]byte("{info: {title: Pets, version: 1.0, license: {name: MIT}}, servers: [{url: a}], x: 1.0}"), &before); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := yaml.Unmarshal([]byte("{x: 1, info: {title: Pet Store, version: '1.0'}, servers: [{url: a}, {url: b}], y: null}"), &after); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	differences := compareNodes("", &before, &after, nil)
+	expected := []string{
+		"changes /info/title from Pets to Pet Store",
+		"loses /info/license",
+		"changes the length of /servers from 1 to 2",
+		"adds /y",
+	}
+	if !reflect.DeepEqual(differences, expected) {
+		t.Errorf("expected %v, got %v", expected, differences)
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	source := `openapi: 3.0.0
+info:
+  title: Numbers
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Count:
+      type: integer
+      format: uint64
+      minimum: -1
+      maximum: 18446744073709551615
+      default: 9223372036854775807
+      multipleOf: 0.1000000000000000055511151231257827
+`
+	results := Compile([]Input{{Name: "numbers.yaml", Bytes: []byte(source)}}, 1)
+	if results[0].Err != nil {
+		t.Fatalf("%+v", results[0].Err)
+	}
+	bytes, err := yaml.Marshal(DocumentNodeWithAnnotations(results[0].Document, results[0].Annotations, results[0].SourceFormat))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, line := range []string{
+		"minimum: -1\n",
+		"maximum: 18446744073709551615\n",
+		"default: 9223372036854775807\n",
+		"multipleOf: 0.1000000000000000055511151231257827\n",
+	} {
+		if !strings.Contains(string(bytes), line) {
+			t.Errorf("expected %q in\n%s", line, bytes)
+		}
+	}
+
+	// Texts are kept in the annotations of each document, so documents
+	// with numbers that have the same floats are written with their own
+	// texts, and binary encodings of documents don't hold them.
+	results = Compile([]Input{
+		{Name: "first.yaml", Bytes: []byte(strings.Replace(source, "-1", "9007199254740993", 1))},
+		{Name: "second.yaml", Bytes: []byte(strings.Replace(source, "-1", "9007199254740992", 1))},
+	}, 1)
+	data, err := proto.Marshal(results[0].Document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	first := &openapi_v3.Document{}
+	if err := proto.Unmarshal(data, first); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, test := range []struct {
+		document    proto.Message
+		annotations *compiler.Annotations
+		line        string
+	}{
+		{first, results[0].Annotations, "minimum: 9007199254740993\n"},
+		{first, nil, "minimum: 9.007199254740992e+15\n"},
+		{results[1].Document, results[1].Annotations, "minimum: 9.007199254740992e+15\n"},
+	} {
+		bytes, err := yaml.Marshal(DocumentNodeWithAnnotations(test.document, test.annotations, SourceFormatOpenAPI3))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if !strings.Contains(string(bytes), test.line) {
+			t.Errorf("expected %q in\n%s", test.line, bytes)
+		}
+	}
+}
+
+func TestExplicitNulls(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nulls")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "openapi.yaml")
+	output := filepath.Join(dir, "out.yaml")
+	err = ioutil.WriteFile(source, []byte(`openapi: 3.0.0
+info:
+  title: Nulls
+  version: 1.0.0
+  description: null
+  x-internal: ~
+paths:
+  /pets:
+    get:
+      summary: null
+      responses:
+        200:
+          description: ok
+`), 0644)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The second compilation reads the document from the cache.
+	for i := 0; i < 2; i++ {
+		compiler.ClearCaches()
+		g := NewGnostic([]string{"gnostic", source, "--cache-dir=" + filepath.Join(dir, "cache"), "--strip-extensions=x-internal", "--yaml-out=" + output})
+		if err := g.Main(); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		bytes, err := ioutil.ReadFile(output)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		for _, line := range []string{"    description: null\n", "            summary: null\n"} {
+			if !strings.Contains(string(bytes), line) {
+				t.Errorf("expected %q in\n%s", line, bytes)
+			}
+		}
+		if strings.Contains(string(bytes), "x-internal") {
+			t.Errorf("expected x-internal to be removed from\n%s", bytes)
+		}
+	}
+	message, annotations, _, err := ReadAnnotatedDocument(context.Background(), source, NewOptions())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := []string{"/info/description", "/info/x-internal", "/paths/~1pets/get/summary"}
+	if nulls := annotations.ExplicitNulls(); !reflect.DeepEqual(nulls, expected) {
+		t.Errorf("unexpected nulls %v", nulls)
+	}
+	// Annotations aren't saved in the model, so they aren't written with it.
+	if unknown := proto.MessageReflect(message).GetUnknown(); len(unknown) != 0 {
+		t.Errorf("unexpected unknown fields %v", unknown)
+	}
+}
+
+func TestReferenceSiblings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "siblings")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "openapi.yaml")
+	output := filepath.Join(dir, "out.yaml")
+	err = ioutil.WriteFile(source, []byte(`openapi: 3.1.0
+info:
+  title: Siblings
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          $ref: "#/components/responses/Pets"
+          description: The pets.
+components:
+  responses:
+    Pets:
+      $ref: "#/components/responses/AllPets"
+      description: Some pets.
+    AllPets:
+      description: All pets.
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/Pet"
+            nullable: true
+  schemas:
+    Pet:
+      type: object
+`), 0644)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The second compilation reads the document from the cache.
+	for i := 0; i < 2; i++ {
+		compiler.ClearCaches()
+		g := NewGnostic([]string{"gnostic", source, "--cache-dir=" + filepath.Join(dir, "cache"), "--resolve-refs", "--yaml-out=" + output})
+		if err := g.Main(); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		bytes, err := ioutil.ReadFile(output)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		// Descriptions beside references replace the referenced descriptions.
+		for _, text := range []string{
+			"                    $ref: '#/components/responses/AllPets'\n                    description: The pets.\n",
+			"                        $ref: '#/components/schemas/Pet'\n                        nullable: true\n",
+		} {
+			if !strings.Contains(string(bytes), text) {
+				t.Errorf("expected %q in\n%s", text, bytes)
+			}
+		}
+	}
+	_, annotations, _, err := ReadAnnotatedDocument(context.Background(), source, NewOptions())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	siblings := annotations.ReferenceSiblings
+	expected := []compiler.Annotation{
+		{Pointer: "/components/responses/AllPets/content/application~1json/schema/nullable", Tag: "!!bool", Value: "true\n"},
+	}
+	if !reflect.DeepEqual(siblings, expected) {
+		t.Errorf("unexpected siblings %v", siblings)
+	}
+	// Earlier versions ignore keywords beside references.
+	bytes, err := ioutil.ReadFile(source)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	err = ioutil.WriteFile(source, []byte(strings.Replace(string(bytes), "openapi: 3.1.0", "openapi: 3.0.0", 1)), 0644)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	compiler.ClearCaches()
+	g := NewGnostic([]string{"gnostic", source, "--resolve-refs", "--yaml-out=" + output})
+	if err := g.Main(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	bytes, err = ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if strings.Contains(string(bytes), "The pets.") || strings.Contains(string(bytes), "nullable: true") {
+		t.Errorf("expected keywords beside references to be ignored in\n%s", bytes)
+	}
+	_, annotations, _, err = ReadAnnotatedDocument(context.Background(), source, NewOptions())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if siblings := annotations.ReferenceSiblings; len(siblings) != 0 {
+		t.Errorf("unexpected siblings %v", siblings)
+	}
+}
+
+func TestPlainStrings(t *testing.T) {
+	source := `openapi: 3.0.0
+info:
+  title: 2020-01-01
+  version: 1.0
+paths:
+  /pets:
+    get:
+      tags: [yes, 1.10, true]
+      responses:
+        200:
+          description: ok
+`
+	contents := WithSourceContents([]byte(source))
+	if _, _, err := ReadDocumentWithOptions("plain.yaml", NewOptions(contents)); err == nil {
+		t.Errorf("expected an error for an unquoted version")
+	}
+	message, _, err := ReadDocumentWithOptions("plain.yaml", NewOptions(contents, WithPlainStrings()))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document := message.(*openapi_v3.Document)
+	if document.Info.Title != "2020-01-01" || document.Info.Version != "1.0" {
+		t.Errorf("unexpected info %+v", document.Info)
+	}
+	tags := document.Paths.Path[0].Value.Get.Tags
+	if !reflect.DeepEqual(tags, []string{"yes", "1.10", "true"}) {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	// Compilations that don't set the option don't read plain strings,
+	// even while other compilations do.
+	var wg sync.WaitGroup
+	errs := make([]error, 16)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			options := NewOptions(contents)
+			if i%2 == 0 {
+				WithPlainStrings()(options)
+			}
+			_, _, errs[i] = ReadDocumentWithOptions("plain.yaml", options)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if (i%2 == 0) != (err == nil) {
+			t.Errorf("compilation %d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestConformance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "conformance")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"v3.0/petstore.yaml":      "openapi: 3.0.3\ninfo: {title: Pets, version: 1.0.0}\npaths: {}\n",
+		"v3.0/schemas/pet.yaml":   "type: object\n",
+		"v3.1/pass/webhooks.yaml": "openapi: 3.1.0\ninfo: {title: Hooks, version: 1.0.0}\nwebhooks: {}\n",
+		"v3.1/pass/servers.yaml":  "openapi: 3.1.0\ninfo: {title: Servers, version: 1.0.0}\nservers: [{description: no url}]\n",
+		"v3.1/fail/no-info.yaml":  "openapi: 3.1.0\npaths: {}\n",
+		"v2.0/fail/no-paths.yml":  "swagger: '2.0'\ninfo: {title: Pets, version: 1.0.0}\n",
+	}
+	for name, text := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	report, err := RunConformance(dir, NewOptions())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	results := make(map[string]bool)
+	for _, result := range report.Results {
+		results[result.File] = result.Passed
+	}
+	expected := map[string]bool{
+		"v3.0/petstore.yaml":      true,
+		"v3.1/pass/webhooks.yaml": true,
+		"v3.1/pass/servers.yaml":  false,
+		"v3.1/fail/no-info.yaml":  true,
+		"v2.0/fail/no-paths.yml":  true,
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %v, got %v", expected, results)
+	}
+	summaries := make([]ConformanceSummary, 0)
+	for _, summary := range report.Summaries {
+		summaries = append(summaries, *summary)
+	}
+	expectedSummaries := []ConformanceSummary{
+		{Version: "2.0", Files: 1, Passed: 1},
+		{Version: "3.0", Files: 1, Passed: 1},
+		{Version: "3.1", Files: 3, Passed: 2},
+	}
+	if !reflect.DeepEqual(summaries, expectedSummaries) {
+		t.Errorf("expected %v, got %v", expectedSummaries, summaries)
+	}
+
+	// Documents that passed in a baseline and fail now are regressions.
+	baseline := &ConformanceReport{Results: []*ConformanceResult{
+		{File: "v3.0/petstore.yaml", Passed: true},
+		{File: "v3.1/pass/webhooks.yaml", Passed: true},
+		{File: "v3.1/pass/servers.yaml", Passed: true},
+	}}
+	if regressions := report.Regressions(baseline); !reflect.DeepEqual(regressions, []string{"v3.1/pass/servers.yaml"}) {
+		t.Errorf("unexpected regressions %v", regressions)
+	}
+}
+
+func TestVocabularyAndStats(t *testing.T) {
+	for _, source := range []string{"../examples/v3.0/yaml/petstore.yaml", "../examples/discovery/discovery-v1.json"} {
+		document, _, err := ReadDocument(source)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		v, err := Vocabulary(document)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if len(v.Schemas) == 0 || len(v.Operations) == 0 {
+			t.Errorf("%s: unexpected vocabulary %v", source, v)
+		}
+	}
+	document, _, err := ReadDocument("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stats, err := Stats(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if stats.PathCount != 2 || stats.GetCount != 2 || stats.PostCount != 1 || stats.SchemaCount != 8 {
+		t.Errorf("unexpected statistics %v", stats)
+	}
+	if _, err := Stats(&openapi_v2.Document{}); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "errors")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "petstore.errors")
+	g := NewGnostic([]string{"gnostic", "../examples/errors/petstore-badproperties.yaml", "--errors=json", "--errors-out=" + output})
+	if err := g.Main(); err == nil {
+		t.Fatalf("expected an error")
+	}
+	bytes, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var reports []compiler.ErrorReport
+	if err := json.Unmarshal(bytes, &reports); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := compiler.ErrorReport{Path: "$root.info", Line: 3, Column: 3, Severity: "error", Message: "is missing required property: version"}
+	if len(reports) != 4 || reports[0] != expected {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+
+	// References that can't be resolved are reported where they are.
+	source := filepath.Join(dir, "unresolved.yaml")
+	err = ioutil.WriteFile(source, []byte(`swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    $ref: "#/definitions/Missing"
+`), 0644)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	g = NewGnostic([]string{"gnostic", source, "--resolve-refs", "--pb-out=" + filepath.Join(dir, "unresolved.pb"), "--errors=json", "--errors-out=" + output})
+	if err := g.Main(); err == nil {
+		t.Fatalf("expected an error")
+	}
+	bytes, err = ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	reports = nil
+	if err := json.Unmarshal(bytes, &reports); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected = compiler.ErrorReport{Path: "$root.definitions.Pet.$ref", Line: 8, Column: 11, Severity: "error", Message: "could not resolve #/definitions/Missing"}
+	if len(reports) != 1 || reports[0] != expected {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+
+	g = NewGnostic([]string{"gnostic", "petstore.yaml", "--errors=xml"})
+	if err := g.readOptions(); err == nil {
+		t.Errorf("expected an invalid error format to be rejected")
+	}
+}
+
+func TestOpenAPI31(t *testing.T) {
+	for version, expected := range map[string]int{"3.0.3": SourceFormatOpenAPI3, "3.1.0": SourceFormatOpenAPI31, "3.2.0": SourceFormatUnknown} {
+		info, err := compiler.ReadInfoFromBytes("", []byte("openapi: "+version))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if format := SourceFormatForInfo(info); format != expected {
+			t.Errorf("unexpected format %d for %s", format, version)
+		}
+	}
+	message, format, err := ReadDocumentWithOptions("../examples/v3.1/yaml/petstore.yaml", NewOptions(WithReferenceResolution()))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, ok := message.(*openapi_v31.Document)
+	if !ok || format != SourceFormatOpenAPI31 {
+		t.Fatalf("unexpected document %T %d", message, format)
+	}
+	if webhooks := document.GetWebhooks().GetAdditionalProperties(); len(webhooks) != 1 {
+		t.Errorf("unexpected webhooks %+v", webhooks)
+	}
+	bytes, err := yaml.Marshal(DocumentNode(message, format))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !strings.Contains(string(bytes), "webhooks:\n    newPet:\n") {
+		t.Errorf("unexpected yaml %s", bytes)
+	}
+	if models := availableModels(format, false); !reflect.DeepEqual(models, []string{plugins.ModelOpenAPIv31}) {
+		t.Errorf("unexpected models %v", models)
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+const conformanceUsage = `
+Usage: gnostic conformance DIRECTORY [OPTIONS]
+  DIRECTORY contains OpenAPI documents to compile, such as a checkout of
+  https://github.com/OAI/OpenAPI-Specification. Documents in directories
+  named "fail" or "invalid" are expected to have errors; all others are
+  expected to compile without errors.
+Options:
+  --json              Write the report as JSON.
+  --baseline=FILE     Compare the results with a JSON report written by an
+                      earlier run, and fail if any document regressed.
+  --resolve-refs      Resolve $ref references in the documents.
+  --help              Print usage information and exit.
+`
+
+// A ConformanceResult describes the compilation of one document of a
+// conformance corpus.
+type ConformanceResult struct {
+	// File is the path of the document relative to the corpus directory.
+	File string `json:"file"`
+	// Version is the version of the specification that the document is
+	// written for, such as 3.0.
+	Version string `json:"version"`
+	// Valid is true if the document is expected to compile without errors.
+	Valid bool `json:"valid"`
+	// Passed is true if the document compiled without errors when it was
+	// expected to, or had errors when it was expected to.
+	Passed bool `json:"passed"`
+	// Error is the first error that was reported for the document.
+	Error string `json:"error,omitempty"`
+}
+
+// A ConformanceSummary counts the documents that passed for one version
+// of the specification.
+type ConformanceSummary struct {
+	Version string `json:"version"`
+	Files   int    `json:"files"`
+	Passed  int    `json:"passed"`
+}
+
+// Level returns the percentage of documents that passed.
+func (s *ConformanceSummary) Level() float64 {
+	if s.Files == 0 {
+		return 0
+	}
+	return 100 * float64(s.Passed) / float64(s.Files)
+}
+
+// A ConformanceReport contains the results of compiling a conformance
+// corpus.
+type ConformanceReport struct {
+	Results   []*ConformanceResult  `json:"results"`
+	Summaries []*ConformanceSummary `json:"summaries"`
+}
+
+// RunConformance compiles each JSON and YAML document in a directory and
+// its subdirectories with options and reports whether each one compiled
+// as expected. Documents in directories named "fail" or "invalid", like
+// the failing tests of the OpenAPI Specification repository, are
+// expected to have errors.
+func RunConformance(dir string, options *Options) (*ConformanceReport, error) {
+	report := &ConformanceReport{Results: make([]*ConformanceResult, 0)}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if _, ok := declaredVersion(path); !ok && expectedValid(name) {
+			// Skip the parts of descriptions that are in separate files.
+			return nil
+		}
+		report.Results = append(report.Results, runConformanceTest(path, name, options))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.summarize()
+	return report, nil
+}
+
+// Compile one document and compare its outcome with the expected one.
+func runConformanceTest(path, name string, options *Options) *ConformanceResult {
+	result := &ConformanceResult{File: name, Valid: expectedValid(name)}
+	result.Version = conformanceVersion(name)
+	if result.Version == "" {
+		result.Version, _ = declaredVersion(path)
+	}
+	readOptions := *options
+	readOptions.Lenient = false
+	_, _, err := ReadDocumentWithOptions(path, &readOptions)
+	if err != nil {
+		result.Error = firstError(err)
+	}
+	result.Passed = (err == nil) == result.Valid
+	return result
+}
+
+// Returns false if a document is in a directory of documents that are
+// expected to have errors.
+func expectedValid(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if part == "fail" || part == "invalid" {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the version of the specification that a file declares with an
+// openapi or swagger key, including versions that gnostic doesn't
+// support, and false if the file doesn't declare one.
+func declaredVersion(path string) (string, bool) {
+	bytes, err := ioutil.ReadFile(path)
+	if err == nil {
+		bytes, err = compiler.DecodeText(bytes)
+	}
+	if err != nil {
+		return "", false
+	}
+	var node yaml.Node
+	if yaml.Unmarshal(bytes, &node) != nil || len(node.Content) == 0 {
+		return "", false
+	}
+	for _, key := range []string{"openapi", "swagger"} {
+		if value := compiler.MapValueForKey(node.Content[0], key); value != nil {
+			version := value.Value
+			if parts := strings.SplitN(version, ".", 3); len(parts) >= 2 {
+				version = parts[0] + "." + parts[1]
+			}
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// Return the version of the specification named in a path, such as
+// v3.1 in tests/v3.1/pass/info.yaml, or an empty string.
+func conformanceVersion(name string) string {
+	for _, part := range strings.Split(name, "/") {
+		if len(part) > 1 && part[0] == 'v' && strings.Contains(part, ".") {
+			if _, err := fmt.Sscanf(part[1:], "%d.%d", new(int), new(int)); err == nil {
+				return part[1:]
+			}
+		}
+	}
+	return ""
+}
+
+// Return the first of the errors reported by an error, which may be a group.
+func firstError(err error) string {
+	if group, ok := err.(*compiler.ErrorGroup); ok && len(group.Errors) > 0 {
+		return firstError(group.Errors[0])
+	}
+	return err.Error()
+}
+
+// Count the documents that passed for each version of the specification.
+func (r *ConformanceReport) summarize() {
+	summaries := make(map[string]*ConformanceSummary)
+	for _, result := range r.Results {
+		version := result.Version
+		if version == "" {
+			version = "unknown"
+		}
+		summary, ok := summaries[version]
+		if !ok {
+			summary = &ConformanceSummary{Version: version}
+			summaries[version] = summary
+		}
+		summary.Files++
+		if result.Passed {
+			summary.Passed++
+		}
+	}
+	r.Summaries = make([]*ConformanceSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		r.Summaries = append(r.Summaries, summary)
+	}
+	sort.Slice(r.Summaries, func(i, j int) bool {
+		return r.Summaries[i].Version < r.Summaries[j].Version
+	})
+}
+
+// Regressions returns the files that passed in a baseline report but
+// don't pass in this report. Files that aren't in the baseline are ignored.
+func (r *ConformanceReport) Regressions(baseline *ConformanceReport) []string {
+	passed := make(map[string]bool)
+	for _, result := range baseline.Results {
+		passed[result.File] = result.Passed
+	}
+	regressions := make([]string, 0)
+	for _, result := range r.Results {
+		if passed[result.File] && !result.Passed {
+			regressions = append(regressions, result.File)
+		}
+	}
+	return regressions
+}
+
+// Marshal returns the JSON representation of a report.
+func (r *ConformanceReport) Marshal() ([]byte, error) {
+	bytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
+
+// ReadConformanceReport reads a report that was written as JSON.
+func ReadConformanceReport(filename string) (*ConformanceReport, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	report := &ConformanceReport{}
+	if err := json.Unmarshal(bytes, report); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return report, nil
+}
+
+// conformance compiles a corpus of documents and reports the documents
+// that compiled as expected. It implements the "conformance" command.
+func (g *Gnostic) conformance() error {
+	jsonOutput := false
+	baseline := ""
+	dirs := make([]string, 0)
+	for _, arg := range g.args[2:] {
+		switch {
+		case arg == "--help":
+			fmt.Printf("%s", conformanceUsage)
+			return nil
+		case arg == "--json":
+			jsonOutput = true
+		case arg == "--resolve-refs":
+			WithReferenceResolution()(&g.options)
+		case strings.HasPrefix(arg, "--baseline="):
+			baseline = strings.TrimPrefix(arg, "--baseline=")
+		case strings.HasPrefix(arg, "--"):
+			return NewUsageError(fmt.Sprintf("unknown option: %s", arg))
+		default:
+			dirs = append(dirs, arg)
+		}
+	}
+	g.usage = conformanceUsage
+	if len(dirs) != 1 {
+		return NewUsageError("conformance requires a directory")
+	}
+	report, err := RunConformance(dirs[0], &g.options)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		return err
+	}
+	if jsonOutput {
+		bytes, err := report.Marshal()
+		if err != nil {
+			return err
+		}
+		os.Stdout.Write(bytes)
+	} else {
+		for _, result := range report.Results {
+			status := "PASS"
+			if !result.Passed {
+				status = "FAIL"
+			}
+			fmt.Printf("%s\t%s", status, result.File)
+			if !result.Passed && result.Error != "" {
+				fmt.Printf("\t%s", result.Error)
+			} else if !result.Passed {
+				fmt.Printf("\texpected errors")
+			}
+			fmt.Printf("\n")
+		}
+		for _, summary := range report.Summaries {
+			fmt.Printf("%s: %d of %d passed (%.1f%%)\n", summary.Version, summary.Passed, summary.Files, summary.Level())
+		}
+	}
+	if baseline == "" {
+		return nil
+	}
+	previous, err := ReadConformanceReport(baseline)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		return err
+	}
+	if regressions := report.Regressions(previous); len(regressions) > 0 {
+		err := fmt.Errorf("%d documents passed in %s but failed now:\n%s", len(regressions), baseline, strings.Join(regressions, "\n"))
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		return err
+	}
+	return nil
+}
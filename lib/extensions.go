@@ -0,0 +1,199 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// An ExtensionHandlerFunc compiles the value of a specification extension
+// into a protocol buffer message.
+type ExtensionHandlerFunc func(node yaml.Node) (proto.Message, error)
+
+// RegisterExtensionHandler compiles the extensions with a name, such as
+// x-rate-limit, with a Go function instead of an extension plugin.
+func (o *Options) RegisterExtensionHandler(name string, handler ExtensionHandlerFunc) {
+	if o.ExtensionFunctions == nil {
+		o.ExtensionFunctions = make(map[string]ExtensionHandlerFunc)
+	}
+	o.ExtensionFunctions[name] = handler
+}
+
+// WithExtensionHandler compiles the extensions with a name with a Go function.
+func WithExtensionHandler(name string, handler ExtensionHandlerFunc) Option {
+	return func(o *Options) {
+		o.RegisterExtensionHandler(name, handler)
+	}
+}
+
+// Call the extension functions for the extensions of a compiled document
+// that weren't compiled by extension plugins, adding their errors to err.
+func (o *Options) callExtensionFunctions(document proto.Message, err error) error {
+	if len(o.ExtensionFunctions) == 0 || document == nil {
+		return err
+	}
+	errs := make([]error, 0)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	errs = o.callExtensionFunctionsForMessage(document.ProtoReflect(), compiler.NewContext("$root", nil, nil), errs)
+	return compiler.NewErrorGroupOrNil(errs)
+}
+
+// Calls the extension functions for the extensions in a message and its
+// fields. Contexts are named like the contexts of the compiler so that
+// errors describe their locations in the same way.
+func (o *Options) callExtensionFunctionsForMessage(message protoreflect.Message, context *compiler.Context, errs []error) []error {
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Message() == nil || field.IsMap() || !message.Has(field) {
+			continue
+		}
+		if !field.IsList() {
+			errs = o.callExtensionFunctionsForMessage(message.Get(field).Message(),
+				compiler.NewContext(field.JSONName(), nil, context), errs)
+			continue
+		}
+		list := message.Get(field).List()
+		for j := 0; j < list.Len(); j++ {
+			item := list.Get(j).Message()
+			name, value := namedValue(item)
+			switch {
+			case field.Name() == "vendor_extension" || field.Name() == "specification_extension":
+				errs = o.callExtensionFunction(name, value, context, errs)
+			case value != nil:
+				errs = o.callExtensionFunctionsForMessage(value, compiler.NewContext(name, nil, context), errs)
+			default:
+				errs = o.callExtensionFunctionsForMessage(item, compiler.NewContext(field.JSONName(), nil, context), errs)
+			}
+		}
+	}
+	return errs
+}
+
+// Calls the extension function for an extension with the Any message that
+// contains its value.
+func (o *Options) callExtensionFunction(name string, value protoreflect.Message, context *compiler.Context, errs []error) []error {
+	handler, ok := o.ExtensionFunctions[name]
+	if !ok || value == nil {
+		return errs
+	}
+	anyField, yamlField := value.Descriptor().Fields().ByName("value"), value.Descriptor().Fields().ByName("yaml")
+	if anyField == nil || yamlField == nil || value.Has(anyField) {
+		return errs
+	}
+	context = compiler.NewContext(name, nil, context)
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(value.Get(yamlField).String()), &node); err != nil {
+		return append(errs, compiler.NewError(context, err.Error()))
+	}
+	if len(node.Content) > 0 {
+		node = *node.Content[0]
+	}
+	result, err := handler(node)
+	if err != nil {
+		return append(errs, compiler.NewError(context, err.Error()))
+	}
+	if result == nil {
+		return errs
+	}
+	packed, err := anypb.New(result)
+	if err != nil {
+		return append(errs, compiler.NewError(context, err.Error()))
+	}
+	value.Set(anyField, protoreflect.ValueOfMessage(packed.ProtoReflect()))
+	return errs
+}
+
+// Returns the name and value of a message that represents an entry of a
+// map, like NamedAny, or nil if the message isn't a map entry.
+func namedValue(message protoreflect.Message) (string, protoreflect.Message) {
+	fields := message.Descriptor().Fields()
+	name, value := fields.ByName("name"), fields.ByName("value")
+	if name == nil || value == nil || name.Kind() != protoreflect.StringKind ||
+		value.Message() == nil || value.IsList() || !message.Has(value) {
+		return "", nil
+	}
+	return message.Get(name).String(), message.Get(value).Message()
+}
+
+// Returns a document without the extensions that are removed by the
+// options, with the annotations of its other locations. Documents are
+// copied before extensions are removed from them.
+func (o *Options) stripExtensions(document proto.Message, annotations *compiler.Annotations) (proto.Message, *compiler.Annotations) {
+	if len(o.StripExtensions) == 0 && len(o.KeepExtensions) == 0 || document == nil {
+		return document, annotations
+	}
+	document = proto.Clone(document)
+	o.stripExtensionsFromMessage(document.ProtoReflect())
+	annotations = annotations.Filter(func(pointer string) bool {
+		name := pointer[strings.LastIndex(pointer, "/")+1:]
+		return !strings.HasPrefix(name, "x-") || !o.stripsExtension(name)
+	})
+	return document, annotations
+}
+
+// Removes extensions from a message and its fields.
+func (o *Options) stripExtensionsFromMessage(message protoreflect.Message) {
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.Message() == nil || field.IsMap():
+		case !field.IsList():
+			o.stripExtensionsFromMessage(value.Message())
+		case field.Name() == "vendor_extension" || field.Name() == "specification_extension":
+			list, kept := value.List(), 0
+			for j := 0; j < list.Len(); j++ {
+				if name, _ := namedValue(list.Get(j).Message()); !o.stripsExtension(name) {
+					list.Set(kept, list.Get(j))
+					kept++
+				}
+			}
+			list.Truncate(kept)
+		default:
+			list := value.List()
+			for j := 0; j < list.Len(); j++ {
+				o.stripExtensionsFromMessage(list.Get(j).Message())
+			}
+		}
+		return true
+	})
+}
+
+// Returns true if the extension with a name is removed from written documents.
+func (o *Options) stripsExtension(name string) bool {
+	if matchesAnyPattern(name, o.KeepExtensions) {
+		return false
+	}
+	return len(o.StripExtensions) == 0 || matchesAnyPattern(name, o.StripExtensions)
+}
+
+// Returns true if a name matches any of a list of patterns.
+func matchesAnyPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package lib
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/internal/fuzzing"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// Returns true if a node contains references to other files, which
+// would make the resolver read files or fetch URLs that are named by
+// fuzzed input.
+func hasExternalReferences(node *yaml.Node) bool {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" && !strings.HasPrefix(node.Content[i+1].Value, "#") {
+				return true
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if hasExternalReferences(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// FuzzResolveReferences checks that ResolveReferences returns errors for
+// malformed and recursive references instead of panicking or hanging.
+// Only descriptions with internal references are resolved. Run it with
+//
+//	go test ./lib -run=NONE -fuzz=FuzzResolveReferences
+func FuzzResolveReferences(f *testing.F) {
+	fuzzing.AddFiles(f,
+		"../examples/v2.0/yaml/*.yaml",
+		"../examples/v3.0/yaml/*.yaml",
+		"../examples/errors/*.yaml",
+	)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var node yaml.Node
+		if yaml.Unmarshal(b, &node) != nil || hasExternalReferences(&node) {
+			return
+		}
+		var document proto.Message
+		var err error
+		if strings.Contains(string(b), "swagger") {
+			document, err = openapi_v2.ParseDocument(b)
+		} else {
+			document, err = openapi_v3.ParseDocument(b)
+		}
+		if err != nil {
+			return
+		}
+		// The resolver reads the root document for internal references.
+		filename := filepath.Join(t.TempDir(), "openapi.yaml")
+		if err := ioutil.WriteFile(filename, b, 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		defer compiler.ClearCaches()
+		ResolveReferences(document, filename, 0)
+	})
+}
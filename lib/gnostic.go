@@ -16,10 +16,13 @@ package lib
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
@@ -33,9 +36,11 @@ import (
 
 	"github.com/okkoye/gnostic/compiler"
 	discovery_v1 "github.com/okkoye/gnostic/discovery"
+	"github.com/okkoye/gnostic/extensions/wellknown"
 	"github.com/okkoye/gnostic/jsonwriter"
 	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
 	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+	openapi_v31 "github.com/okkoye/gnostic/openapiv31"
 	plugins "github.com/okkoye/gnostic/plugins"
 	surface "github.com/okkoye/gnostic/surface"
 )
@@ -64,17 +69,20 @@ const (
 	SourceFormatOpenAPI3 = 3
 	// SourceFormatDiscovery represents a Google Discovery document
 	SourceFormatDiscovery = 4
+	// SourceFormatOpenAPI31 represents an OpenAPI v3.1 document
+	SourceFormatOpenAPI31 = 5
 )
 
-// Determine the version of an OpenAPI description read from JSON or YAML.
-func getOpenAPIVersionFromInfo(info *yaml.Node) int {
+// SourceFormatForInfo returns the format of an API description read from
+// JSON or YAML as one of the SourceFormat constants.
+func SourceFormatForInfo(info *yaml.Node) int {
 	m, ok := compiler.UnpackMap(info)
 	if !ok {
 		return SourceFormatUnknown
 	}
 
 	if m.Kind == yaml.DocumentNode {
-		return getOpenAPIVersionFromInfo(m.Content[0])
+		return SourceFormatForInfo(m.Content[0])
 	}
 
 	swagger, ok := compiler.StringForScalarNode(compiler.MapValueForKey(m, "swagger"))
@@ -86,6 +94,9 @@ func getOpenAPIVersionFromInfo(info *yaml.Node) int {
 	if ok && strings.HasPrefix(openapi, "3.0") {
 		return SourceFormatOpenAPI3
 	}
+	if ok && strings.HasPrefix(openapi, "3.1") {
+		return SourceFormatOpenAPI31
+	}
 
 	kind, ok := compiler.StringForScalarNode(compiler.MapValueForKey(m, "kind"))
 	if ok && kind == "discovery#restDescription" {
@@ -103,10 +114,13 @@ const (
 type pluginCall struct {
 	Name       string
 	Invocation string
+	// Negotiate is true for plugins that are named with
+	// --plugin-requirements and are asked for their requirements.
+	Negotiate bool
 }
 
 // Invokes a plugin.
-func (p *pluginCall) perform(document proto.Message, sourceFormat int, sourceName string, timePlugins bool, excludeSurface bool) ([]*plugins.Message, error) {
+func (p *pluginCall) perform(document proto.Message, annotations *compiler.Annotations, sourceFormat int, sourceName string, source []byte, timePlugins bool, excludeSurface bool) ([]*plugins.Message, error) {
 	if p.Name != "" {
 		request := &plugins.Request{}
 
@@ -156,28 +170,66 @@ func (p *pluginCall) perform(document proto.Message, sourceFormat int, sourceNam
 
 		request.OutputPath = outputLocation
 
-		request.SourceName = sourceName
+		// Plugins that follow version 2 of the protocol are only sent what
+		// they use. Other plugins would be run once more if they were asked,
+		// so only those that are named with --plugin-requirements are.
+		capabilities := &plugins.Capabilities{
+			ProtocolVersion: plugins.ProtocolVersion,
+			CompilerVersion: version,
+			Models:          availableModels(sourceFormat, excludeSurface),
+			Features:        []string{plugins.FeatureSource},
+		}
+		var requirements *plugins.Requirements
+		if p.Negotiate {
+			var err error
+			requirements, err = plugins.Negotiate(executableName, capabilities)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if requirements != nil {
+			request.ProtocolVersion = plugins.ProtocolVersion
+			if len(requirements.Models) > 0 && !requiresAnyModel(requirements, capabilities.Models) {
+				return nil, fmt.Errorf("%s can't process %s: it uses %s models and gnostic can build %s models",
+					executableName, sourceName, strings.Join(requirements.Models, ", "), strings.Join(capabilities.Models, ", "))
+			}
+			if requirements.RequiresFeature(plugins.FeatureSource) {
+				request.Source = source
+			}
+		}
+
+		request.SourceName = filepath.ToSlash(sourceName)
 		switch sourceFormat {
 		case SourceFormatOpenAPI2:
-			request.AddModel("openapi.v2.Document", document)
-			if !excludeSurface {
+			if requirements.RequiresModel(plugins.ModelOpenAPIv2) {
+				request.AddModel(plugins.ModelOpenAPIv2, document)
+			}
+			if !excludeSurface && requirements.RequiresModel(plugins.ModelSurface) {
 				// include experimental API surface model
 				surfaceModel, err := surface.NewModelFromOpenAPI2(document.(*openapi_v2.Document), sourceName)
 				if err == nil {
-					request.AddModel("surface.v1.Model", surfaceModel)
+					request.AddModel(plugins.ModelSurface, surfaceModel)
 				}
 			}
 		case SourceFormatOpenAPI3:
-			request.AddModel("openapi.v3.Document", document)
-			if !excludeSurface {
+			if requirements.RequiresModel(plugins.ModelOpenAPIv3) {
+				request.AddModel(plugins.ModelOpenAPIv3, document)
+			}
+			if !excludeSurface && requirements.RequiresModel(plugins.ModelSurface) {
 				// include experimental API surface model
-				surfaceModel, err := surface.NewModelFromOpenAPI3(document.(*openapi_v3.Document), sourceName)
+				surfaceModel, err := surface.NewModelFromOpenAPI3WithAnnotations(document.(*openapi_v3.Document), annotations, sourceName)
 				if err == nil {
-					request.AddModel("surface.v1.Model", surfaceModel)
+					request.AddModel(plugins.ModelSurface, surfaceModel)
 				}
 			}
+		case SourceFormatOpenAPI31:
+			if requirements.RequiresModel(plugins.ModelOpenAPIv31) {
+				request.AddModel(plugins.ModelOpenAPIv31, document)
+			}
 		case SourceFormatDiscovery:
-			request.AddModel("discovery.v1.Document", document)
+			if requirements.RequiresModel(plugins.ModelDiscovery) {
+				request.AddModel(plugins.ModelDiscovery, document)
+			}
 		default:
 		}
 
@@ -211,6 +263,43 @@ func (p *pluginCall) perform(document proto.Message, sourceFormat int, sourceNam
 	return nil, nil
 }
 
+// Returns the types of the models that can be sent to plugins for
+// documents in a format.
+func availableModels(sourceFormat int, excludeSurface bool) []string {
+	models := make([]string, 0)
+	switch sourceFormat {
+	case SourceFormatOpenAPI2:
+		models = append(models, plugins.ModelOpenAPIv2)
+	case SourceFormatOpenAPI3:
+		models = append(models, plugins.ModelOpenAPIv3)
+	case SourceFormatOpenAPI31:
+		models = append(models, plugins.ModelOpenAPIv31)
+	case SourceFormatDiscovery:
+		models = append(models, plugins.ModelDiscovery)
+	}
+	if !excludeSurface && (sourceFormat == SourceFormatOpenAPI2 || sourceFormat == SourceFormatOpenAPI3) {
+		models = append(models, plugins.ModelSurface)
+	}
+	return models
+}
+
+// Returns true if references are resolved in documents in a format.
+func resolvesReferences(sourceFormat int) bool {
+	return sourceFormat == SourceFormatOpenAPI2 ||
+		sourceFormat == SourceFormatOpenAPI3 ||
+		sourceFormat == SourceFormatOpenAPI31
+}
+
+// Returns true if a plugin uses any of a list of models.
+func requiresAnyModel(requirements *plugins.Requirements, models []string) bool {
+	for _, model := range models {
+		if requirements.RequiresModel(model) {
+			return true
+		}
+	}
+	return false
+}
+
 func isFile(path string) bool {
 	fileInfo, err := os.Stat(path)
 	if err != nil {
@@ -290,36 +379,50 @@ func writeFile(name string, bytes []byte, source string, extension string) {
 
 // The Gnostic structure holds global state information for gnostic.
 type Gnostic struct {
-	args              []string
-	usage             string
-	sourceName        string
-	binaryOutputPath  string
-	textOutputPath    string
-	yamlOutputPath    string
-	jsonOutputPath    string
-	errorOutputPath   string
-	messageOutputPath string
-	resolveReferences bool
-	pluginCalls       []*pluginCall
-	extensionHandlers []compiler.ExtensionHandler
-	sourceFormat      int
-	timePlugins       bool
-	excludeSurface    bool
+	args           []string
+	usage          string
+	sourceName     string
+	sourceBytes    []byte
+	options        Options
+	pluginCalls    []*pluginCall
+	sourceFormat   int
+	timePlugins    bool
+	excludeSurface bool
+	arena          *compiler.Arena
+	// annotations are recorded when a document is compiled and are used
+	// to write it as it was read. Documents read from binary files have
+	// no annotations.
+	annotations *compiler.Annotations
+	// ctx stops the files of a compilation from being read when it is
+	// done. The gnostic command's compilations are never stopped.
+	ctx context.Context
 }
 
 // NewGnostic initializes a structure to store global application state.
 func NewGnostic(args []string) *Gnostic {
-	g := &Gnostic{args: args}
+	g := &Gnostic{args: args, ctx: context.Background()}
 	// Option fields initialize to their default values.
 	g.usage = `
 Usage: gnostic SOURCE [OPTIONS]
+       gnostic query SOURCE EXPRESSION [--json] [--paths]
+       gnostic watch SOURCE... [OPTIONS] [--interval=DURATION]
+       gnostic serve --grpc ADDRESS
+       gnostic conformance DIRECTORY [--json] [--baseline=FILE]
+       gnostic vocabulary SOURCE [--json | --csv]
+       gnostic stats SOURCE [--json]
   SOURCE is the filename or URL of an API description.
+  EXPRESSION is a JSONPath expression to evaluate over SOURCE.
+  ADDRESS is the address where the gRPC service is served, such as :9000.
+  DIRECTORY contains OpenAPI documents to compile and check for conformance.
 Options:
   --pb-out=PATH       Write a binary proto to the specified location.
   --text-out=PATH     Write a text proto to the specified location.
   --json-out=PATH     Write a json API description to the specified location.
   --yaml-out=PATH     Write a yaml API description to the specified location.
   --errors-out=PATH   Write compilation errors to the specified location.
+  --errors=FORMAT     Write errors as text, the default, or as json, an
+                      array of objects with the path, line, column,
+                      severity, and message of each error.
   --messages-out=PATH Write messages generated by plugins to the specified
                       location. Messages from all plugin invocations are
                       written to a single common file.
@@ -330,15 +433,58 @@ Options:
                       PLUGIN must not match any other gnostic option.
   --x-EXTENSION       Use the extension named gnostic-x-EXTENSION
                       to process OpenAPI specification extensions.
-  --resolve-refs      Explicitly resolve $ref references.
-                      This could have problems with recursive definitions.
+  --resolve-refs      Explicitly resolve $ref references. Referenced files
+                      are read concurrently, and recursive references are
+                      resolved once.
+  --ref-root=DIR      Only read the source and the files that it refers to
+                      if they are in DIR, and not through symbolic links
+                      that lead out of it. URLs aren't read unless
+                      --allow-urls is also used.
+  --allow-urls        Read the source and the files that it refers to
+                      from URLs when --ref-root is used.
+  --ref-header=NAME:VALUE
+                      Add a header to the requests that read the source
+                      and the files that it refers to from the host of
+                      the source, or from the hosts named with --ref-host.
+                      This can be repeated to add several headers.
+  --ref-token=TOKEN   Send TOKEN as a bearer token with the same requests.
+  --ref-host=HOST     Send the headers and bearer token to HOST, like
+                      example.com or example.com:8443, instead of the
+                      host of the source. They are only sent with https
+                      unless HOST names another scheme, like
+                      http://localhost:8080. This can be repeated.
+  --ref-timeout=DURATION
+                      Give up on these requests after DURATION, like 30s.
+  --plain-strings     Read unquoted values like 1.0, true, and 2020-01-01
+                      as strings where strings are expected.
+  --normalize-unicode Normalize keys to Unicode NFC and report keys that
+                      mix scripts or that look like other keys.
+  --plugin-requirements=PLUGINS
+                      Ask the named plugins, a comma-separated list, for
+                      the models that they use before running them. They
+                      must support version 2 of the plugin protocol.
   --time-plugins      Report plugin runtimes.
   --no-surface        Exclude surface model from calls to plugins.
+  --strip-extensions[=NAMES]
+                      Remove specification extensions from written
+                      documents. NAMES is a comma-separated list of
+                      extension names or patterns, like x-internal-*,
+                      and without it all extensions are removed.
+  --keep-extensions=NAMES
+                      Keep the named extensions in written documents and
+                      remove the others that --strip-extensions matches,
+                      or all others if it isn't used.
+  --verify-roundtrip  Check that compiled documents can be written as yaml
+                      and json without losing the values of their sources.
+  --cache-dir=PATH    Save compiled documents in the specified directory
+                      and reuse them when their sources haven't changed.
+  --lenient           Write documents that have errors as well as
+                      possible, along with their errors.
   --help              Print usage information and exit.
 `
 	// Initialize internal structures.
 	g.pluginCalls = make([]*pluginCall, 0)
-	g.extensionHandlers = make([]compiler.ExtensionHandler, 0)
+	g.options.ExtensionHandlers = make([]compiler.ExtensionHandler, 0)
 	return g
 }
 
@@ -355,6 +501,7 @@ func (g *Gnostic) readOptions() error {
 	// extension processing matches patterns of the form "--x-EXTENSION"
 	extensionRegex := regexp.MustCompile("--x-(.+)")
 
+	negotiate := make(map[string]bool)
 	for i, arg := range g.args {
 		if i == 0 {
 			continue // skip the tool name
@@ -363,33 +510,71 @@ func (g *Gnostic) readOptions() error {
 		if m = pluginRegex.FindSubmatch([]byte(arg)); m != nil {
 			pluginName := string(m[1])
 			invocation := string(m[2])
-			switch pluginName {
-			case "pb":
-				g.binaryOutputPath = invocation
-			case "text":
-				g.textOutputPath = invocation
-			case "json":
-				g.jsonOutputPath = invocation
-			case "yaml":
-				g.yamlOutputPath = invocation
-			case "errors":
-				g.errorOutputPath = invocation
-			case "messages":
-				g.messageOutputPath = invocation
-			default:
+			if isOutputFormat(pluginName) {
+				WithOutput(pluginName, invocation)(&g.options)
+			} else {
 				p := &pluginCall{Name: pluginName, Invocation: invocation}
 				g.pluginCalls = append(g.pluginCalls, p)
 			}
 		} else if m = extensionRegex.FindSubmatch([]byte(arg)); m != nil {
-			extensionName := string(m[1])
-			extensionHandler := compiler.ExtensionHandler{Name: extensionPrefix + extensionName}
-			g.extensionHandlers = append(g.extensionHandlers, extensionHandler)
+			WithExtensions(string(m[1]))(&g.options)
 		} else if arg == "--resolve-refs" {
-			g.resolveReferences = true
+			WithReferenceResolution()(&g.options)
+		} else if arg == "--verify-roundtrip" {
+			WithRoundTripVerification()(&g.options)
+		} else if arg == "--lenient" {
+			WithLenientCompilation()(&g.options)
+		} else if strings.HasPrefix(arg, "--plugin-requirements=") {
+			for _, name := range strings.Split(strings.TrimPrefix(arg, "--plugin-requirements="), ",") {
+				negotiate[name] = true
+			}
 		} else if arg == "--time-plugins" {
 			g.timePlugins = true
 		} else if arg == "--no-surface" {
 			g.excludeSurface = true
+		} else if arg == "--strip-extensions" {
+			WithExtensionsStripped()(&g.options)
+		} else if strings.HasPrefix(arg, "--strip-extensions=") {
+			WithExtensionsStripped(strings.Split(strings.TrimPrefix(arg, "--strip-extensions="), ",")...)(&g.options)
+		} else if strings.HasPrefix(arg, "--keep-extensions=") {
+			WithExtensionsKept(strings.Split(strings.TrimPrefix(arg, "--keep-extensions="), ",")...)(&g.options)
+		} else if strings.HasPrefix(arg, "--ref-root=") {
+			WithReferenceRoot(strings.TrimPrefix(arg, "--ref-root="))(&g.options)
+		} else if arg == "--allow-urls" {
+			WithURLsAllowed()(&g.options)
+		} else if strings.HasPrefix(arg, "--ref-header=") {
+			header := strings.SplitN(strings.TrimPrefix(arg, "--ref-header="), ":", 2)
+			if len(header) != 2 || strings.TrimSpace(header[0]) == "" {
+				return NewUsageError(fmt.Sprintf("invalid header: %s", arg))
+			}
+			fetcher := g.httpFetcher()
+			if fetcher.Header == nil {
+				fetcher.Header = make(http.Header)
+			}
+			fetcher.Header.Add(strings.TrimSpace(header[0]), strings.TrimSpace(header[1]))
+		} else if strings.HasPrefix(arg, "--ref-token=") {
+			g.httpFetcher().Token = strings.TrimPrefix(arg, "--ref-token=")
+		} else if strings.HasPrefix(arg, "--ref-host=") {
+			fetcher := g.httpFetcher()
+			fetcher.Hosts = append(fetcher.Hosts, strings.TrimPrefix(arg, "--ref-host="))
+		} else if strings.HasPrefix(arg, "--ref-timeout=") {
+			timeout, err := time.ParseDuration(strings.TrimPrefix(arg, "--ref-timeout="))
+			if err != nil || timeout <= 0 {
+				return NewUsageError(fmt.Sprintf("invalid timeout: %s", arg))
+			}
+			g.httpFetcher().Timeout = timeout
+		} else if strings.HasPrefix(arg, "--errors=") {
+			format := strings.TrimPrefix(arg, "--errors=")
+			if !isErrorFormat(format) {
+				return NewUsageError(fmt.Sprintf("invalid error format: %s", arg))
+			}
+			WithErrorFormat(format)(&g.options)
+		} else if arg == "--plain-strings" {
+			WithPlainStrings()(&g.options)
+		} else if arg == "--normalize-unicode" {
+			WithUnicodeNormalization()(&g.options)
+		} else if strings.HasPrefix(arg, "--cache-dir=") {
+			WithCacheDirectory(strings.TrimPrefix(arg, "--cache-dir="))(&g.options)
 		} else if len(arg) > 2 && arg[0] == '-' && arg[1] == '-' {
 			// try letting the option specify a plugin with no output files (or unwanted output files)
 			// this is useful for calling plugins like linters that only return messages
@@ -401,70 +586,178 @@ func (g *Gnostic) readOptions() error {
 			g.sourceName = arg
 		}
 	}
+	for _, p := range g.pluginCalls {
+		p.Negotiate = negotiate[p.Name]
+	}
 	return nil
 }
 
+// Returns the fetcher that the command-line options configure, creating
+// it when the first of them is read.
+func (g *Gnostic) httpFetcher() *compiler.HTTPFetcher {
+	fetcher, ok := g.options.Fetcher.(*compiler.HTTPFetcher)
+	if !ok {
+		fetcher = &compiler.HTTPFetcher{}
+		WithFetcher(fetcher)(&g.options)
+	}
+	return fetcher
+}
+
 // Validate command-line options.
 func (g *Gnostic) validateOptions() error {
-	if g.binaryOutputPath == "" &&
-		g.textOutputPath == "" &&
-		g.yamlOutputPath == "" &&
-		g.jsonOutputPath == "" &&
-		g.errorOutputPath == "" &&
-		g.messageOutputPath == "" &&
+	if g.options.BinaryOutputPath == "" &&
+		g.options.TextOutputPath == "" &&
+		g.options.YAMLOutputPath == "" &&
+		g.options.JSONOutputPath == "" &&
+		g.options.ErrorOutputPath == "" &&
+		g.options.MessageOutputPath == "" &&
 		len(g.pluginCalls) == 0 {
 		return NewUsageError("missing output directives")
 	}
 	if g.sourceName == "" {
 		return NewUsageError("no input specified")
 	}
+	// Headers and bearer tokens are sent to the host of the source unless
+	// other hosts are named.
+	if fetcher, ok := g.options.Fetcher.(*compiler.HTTPFetcher); ok && (fetcher.Token != "" || len(fetcher.Header) > 0) && len(fetcher.Hosts) == 0 {
+		u, err := url.Parse(g.sourceName)
+		if err != nil || !compiler.IsURL(g.sourceName) {
+			return NewUsageError("--ref-header and --ref-token require --ref-host for sources that aren't URLs")
+		}
+		fetcher.Hosts = []string{u.Scheme + "://" + u.Host}
+	}
 	// If we get here and the error output is unspecified, write errors to stderr.
-	if g.errorOutputPath == "" {
-		g.errorOutputPath = "="
+	if g.options.ErrorOutputPath == "" {
+		g.options.ErrorOutputPath = "="
 	}
 	return nil
 }
 
-// Generate an error message to be written to stderr or a file.
+// Generate an error message to be written to stderr or a file, with a
+// line for each error that begins with the source name and location, or
+// a JSON array of error reports. Source names are written with forward
+// slashes so that messages are the same on all platforms.
 func (g *Gnostic) errorBytes(err error) []byte {
-	return []byte("Errors reading " + g.sourceName + "\n" + err.Error())
+	if g.options.ErrorFormat == "json" {
+		bytes, _ := json.MarshalIndent(compiler.ErrorReports(err), "", "  ")
+		return bytes
+	}
+	return []byte(compiler.FormatErrors(filepath.ToSlash(g.sourceName), err))
+}
+
+// Combine two errors, either of which may be nil.
+func combineErrors(err1, err2 error) error {
+	if err1 == nil {
+		return err2
+	}
+	if err2 == nil {
+		return err1
+	}
+	return compiler.NewErrorGroupOrNil([]error{err1, err2})
 }
 
 // Read an OpenAPI description from YAML or JSON.
 func (g *Gnostic) readOpenAPIText(bytes []byte) (message proto.Message, err error) {
-	info, err := compiler.ReadInfoFromBytes(g.sourceName, bytes)
+	defer g.releaseArena()
+	info, err := g.options.readInfo(g.sourceName, bytes)
 	if err != nil {
 		return nil, err
 	}
 	// Determine the OpenAPI version.
-	g.sourceFormat = getOpenAPIVersionFromInfo(info)
+	g.sourceFormat = SourceFormatForInfo(info)
 	if g.sourceFormat == SourceFormatUnknown {
 		return nil, errors.New("unable to identify OpenAPI version")
 	}
-	// Compile to the proto model.
+	// Compile to the proto model. Lenient compilations return documents
+	// that have errors along with their errors.
+	root := info.Content[0]
+	var keysErr error
+	if g.options.NormalizeUnicode {
+		context := compiler.NewContext("$root", nil, nil)
+		keysErr = combineErrors(compiler.NormalizeKeys(root, context), compiler.CheckKeys(root, context))
+	}
+	context := g.newRootContext(root)
+	if g.options.PlainStrings {
+		compiler.EnablePlainStrings(context)
+		defer compiler.DisablePlainStrings(context)
+	}
 	if g.sourceFormat == SourceFormatOpenAPI2 {
-		root := info.Content[0]
-		document, err := openapi_v2.NewDocument(root, compiler.NewContextWithExtensions("$root", root, nil, &g.extensionHandlers))
-		if err != nil {
+		document, err := openapi_v2.NewDocument(root, context)
+		err = g.options.callExtensionFunctions(document, err)
+		if document != nil {
+			err = combineErrors(err, openapi_v2.ValidateDocument(document, context))
+		}
+		err = combineErrors(err, keysErr)
+		if err != nil && !g.options.Lenient {
 			return nil, err
 		}
-		message = document
+		wellknown.Populate(document)
+		if document != nil {
+			g.annotations = compiler.NewAnnotations(root)
+		}
+		return document, err
 	} else if g.sourceFormat == SourceFormatOpenAPI3 {
-		root := info.Content[0]
-		document, err := openapi_v3.NewDocument(root, compiler.NewContextWithExtensions("$root", root, nil, &g.extensionHandlers))
-		if err != nil {
+		document, err := openapi_v3.NewDocument(root, context)
+		err = g.options.callExtensionFunctions(document, err)
+		if document != nil {
+			err = combineErrors(err, openapi_v3.ValidateDocument(document, context))
+		}
+		err = combineErrors(err, keysErr)
+		if err != nil && !g.options.Lenient {
 			return nil, err
 		}
-		message = document
-	} else {
-		root := info.Content[0]
-		document, err := discovery_v1.NewDocument(root, compiler.NewContextWithExtensions("$root", root, nil, &g.extensionHandlers))
-		if err != nil {
+		wellknown.Populate(document)
+		if document != nil {
+			g.annotations = compiler.NewAnnotations(root)
+		}
+		return document, err
+	} else if g.sourceFormat == SourceFormatOpenAPI31 {
+		document, err := openapi_v31.NewDocument(root, context)
+		err = g.options.callExtensionFunctions(document, err)
+		if document != nil {
+			err = combineErrors(err, openapi_v31.ValidateDocument(document, context))
+		}
+		err = combineErrors(err, keysErr)
+		if err != nil && !g.options.Lenient {
 			return nil, err
 		}
-		message = document
+		wellknown.Populate(document)
+		if document != nil {
+			g.annotations = compiler.NewAnnotations(root)
+			g.annotations.RecordReferenceSiblings(root)
+		}
+		return document, err
+	}
+	document, err := discovery_v1.NewDocument(root, context)
+	if document != nil {
+		err = combineErrors(err, discovery_v1.ValidateDocument(document))
+	}
+	err = combineErrors(err, keysErr)
+	if err != nil && !g.options.Lenient {
+		return nil, err
+	}
+	if document != nil {
+		g.annotations = compiler.NewAnnotations(root)
+	}
+	return document, err
+}
+
+// Create the context of the root of a compiled document. If the document
+// is compiled in an arena, its contexts are released with its parsed
+// text once the model has been built.
+func (g *Gnostic) newRootContext(root *yaml.Node) *compiler.Context {
+	if g.arena == nil {
+		return compiler.NewContextWithExtensions("$root", root, nil, &g.options.ExtensionHandlers)
+	}
+	return g.arena.NewRootContext("$root", root, &g.options.ExtensionHandlers)
+}
+
+// Release the contexts and parsed text of a document compiled in an arena.
+func (g *Gnostic) releaseArena() {
+	if g.arena != nil {
+		compiler.RemoveFromInfoCache(g.sourceName)
+		g.arena.Reset()
 	}
-	return message, err
 }
 
 func (g *Gnostic) ReadOpenAPIText(bytes []byte) (message proto.Message, err error) {
@@ -480,6 +773,13 @@ func (g *Gnostic) readOpenAPIBinary(data []byte) (message proto.Message, err err
 		g.sourceFormat = SourceFormatOpenAPI3
 		return documentV3, nil
 	}
+	// if that failed, try to read an OpenAPI v3.1 document
+	documentV31 := &openapi_v31.Document{}
+	err = proto.Unmarshal(data, documentV31)
+	if err == nil && strings.HasPrefix(documentV31.Openapi, "3.1") {
+		g.sourceFormat = SourceFormatOpenAPI31
+		return documentV31, nil
+	}
 	// if that failed, try to read an OpenAPI v2 document
 	documentV2 := &openapi_v2.Document{}
 	err = proto.Unmarshal(data, documentV2)
@@ -497,13 +797,139 @@ func (g *Gnostic) readOpenAPIBinary(data []byte) (message proto.Message, err err
 	return nil, err
 }
 
+// Read a document in the format indicated by the source file extension.
+func (g *Gnostic) readDocument(bytes []byte) (message proto.Message, err error) {
+	g.annotations = nil
+	extension := strings.ToLower(filepath.Ext(g.sourceName))
+	if extension == ".json" || extension == ".yaml" || extension == ".yml" {
+		// Try to read the source as JSON/YAML.
+		return g.readOpenAPIText(bytes)
+	} else if extension == ".pb" {
+		// Try to read the source as a binary protocol buffer.
+		return g.readOpenAPIBinary(bytes)
+	}
+	return nil, errors.New("unknown file extension. 'json', 'yaml', and 'pb' are accepted")
+}
+
+// ReadDocument reads an API description from a file or URL and returns it
+// along with its source format (one of the SourceFormat constants).
+// As with the gnostic command, files with .json and .yaml extensions are
+// compiled and files with a .pb extension are read as binary protocol buffers.
+func ReadDocument(sourceName string) (proto.Message, int, error) {
+	result := compileInput(Input{Name: sourceName}, nil)
+	return result.Document, result.SourceFormat, result.Err
+}
+
+// ReadDocumentWithOptions reads an API description like ReadDocument,
+// using the options that control how descriptions are read and compiled.
+// If options.Lenient is set, documents that have errors are returned
+// along with their errors.
+func ReadDocumentWithOptions(sourceName string, options *Options) (proto.Message, int, error) {
+	return ReadDocumentWithContext(context.Background(), sourceName, options)
+}
+
+// ReadDocumentWithContext reads a document like ReadDocumentWithOptions,
+// but it stops reading the source and the files that it refers to when
+// ctx is done and returns the error of ctx.
+func ReadDocumentWithContext(ctx context.Context, sourceName string, options *Options) (proto.Message, int, error) {
+	message, _, format, err := ReadAnnotatedDocument(ctx, sourceName, options)
+	return message, format, err
+}
+
+// ReadAnnotatedDocument reads a document like ReadDocumentWithContext and
+// also returns the annotations that were recorded when it was compiled,
+// which are needed to write it as it was read. Documents that are read
+// from binary files have no annotations.
+func ReadAnnotatedDocument(ctx context.Context, sourceName string, options *Options) (proto.Message, *compiler.Annotations, int, error) {
+	g := &Gnostic{sourceName: sourceName, options: *options, ctx: ctx}
+	bytes, err := options.readBytes(ctx, sourceName)
+	if err != nil {
+		return nil, nil, SourceFormatUnknown, err
+	}
+	message, err := g.readDocumentWithCache(bytes)
+	if message == nil {
+		return nil, nil, SourceFormatUnknown, err
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, nil, SourceFormatUnknown, ctxErr
+	}
+	if options.VerifyRoundTrip && err == nil {
+		err = g.verifyRoundTrip(message, bytes)
+	}
+	if options.ResolveReferences && resolvesReferences(g.sourceFormat) {
+		resolveErr := resolveReferences(ctx, message, sourceName, 0, options)
+		if resolveErr != nil && err == nil {
+			err = resolveErr
+		}
+	}
+	return message, g.annotations, g.sourceFormat, err
+}
+
+// WriteDocument writes a document in each of the output formats that
+// have paths in options. Relative paths and directories are interpreted
+// as they are by the gnostic command.
+func WriteDocument(message proto.Message, sourceFormat int, sourceName string, options *Options) error {
+	return WriteDocumentWithAnnotations(message, nil, sourceFormat, sourceName, options)
+}
+
+// WriteDocumentWithAnnotations writes a document like WriteDocument,
+// using the annotations that were recorded when it was compiled to write
+// JSON and YAML as it was read.
+func WriteDocumentWithAnnotations(message proto.Message, annotations *compiler.Annotations, sourceFormat int, sourceName string, options *Options) error {
+	g := &Gnostic{sourceName: sourceName, sourceFormat: sourceFormat, options: *options, annotations: annotations}
+	return g.writeDocument(message)
+}
+
+// ReadRawInfo reads an API description like ReadDocument and returns its
+// JSON/YAML representation as a YAML document node.
+func ReadRawInfo(sourceName string) (*yaml.Node, int, error) {
+	result := compileInput(Input{Name: sourceName}, nil)
+	if result.Err != nil {
+		return nil, result.SourceFormat, result.Err
+	}
+	return DocumentNodeWithAnnotations(result.Document, result.Annotations, result.SourceFormat), result.SourceFormat, nil
+}
+
+// DocumentNode converts a document into a YAML document node. Mappings
+// share the nodes of keys that are names of fields, so those nodes must
+// not be modified.
+func DocumentNode(message proto.Message, sourceFormat int) *yaml.Node {
+	return DocumentNodeWithAnnotations(message, nil, sourceFormat)
+}
+
+// DocumentNodeWithAnnotations converts a document into a YAML document
+// node like DocumentNode, and writes the annotations that were recorded
+// when it was compiled in the node: numbers are written with the text
+// that they were compiled from when their values would otherwise change,
+// and explicit nulls and the keywords beside references are added.
+func DocumentNodeWithAnnotations(message proto.Message, annotations *compiler.Annotations, sourceFormat int) *yaml.Node {
+	var rawInfo *yaml.Node
+	if sourceFormat == SourceFormatOpenAPI2 {
+		rawInfo = openapi_v2.RawInfo(message.(*openapi_v2.Document))
+	} else if sourceFormat == SourceFormatOpenAPI3 {
+		rawInfo = openapi_v3.RawInfo(message.(*openapi_v3.Document))
+	} else if sourceFormat == SourceFormatOpenAPI31 {
+		rawInfo = openapi_v31.RawInfo(message.(*openapi_v31.Document))
+	} else if sourceFormat == SourceFormatDiscovery {
+		rawInfo = discovery_v1.RawInfo(message.(*discovery_v1.Document))
+	}
+	if rawInfo != nil && rawInfo.Kind != yaml.DocumentNode {
+		rawInfo = &yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{rawInfo},
+		}
+	}
+	annotations.Restore(rawInfo)
+	return rawInfo
+}
+
 // Write a binary pb representation.
 func (g *Gnostic) writeBinaryOutput(message proto.Message) error {
 	protoBytes, err := proto.Marshal(message)
 	if err != nil {
-		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		writeFile(g.options.ErrorOutputPath, g.errorBytes(err), g.sourceName, "errors")
 	} else {
-		writeFile(g.binaryOutputPath, protoBytes, g.sourceName, "pb")
+		writeFile(g.options.BinaryOutputPath, protoBytes, g.sourceName, "pb")
 	}
 	return err
 }
@@ -511,44 +937,28 @@ func (g *Gnostic) writeBinaryOutput(message proto.Message) error {
 // Write a text pb representation.
 func (g *Gnostic) writeTextOutput(message proto.Message) {
 	bytes := []byte(proto.MarshalTextString(message))
-	writeFile(g.textOutputPath, bytes, g.sourceName, "text")
+	writeFile(g.options.TextOutputPath, bytes, g.sourceName, "text")
 }
 
 // Write JSON/YAML OpenAPI representations.
-func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
+func (g *Gnostic) writeJSONYAMLOutput(message proto.Message, annotations *compiler.Annotations) {
 	// Convert the OpenAPI document into an exportable MapSlice.
-	var rawInfo *yaml.Node
-	if g.sourceFormat == SourceFormatOpenAPI2 {
-		document := message.(*openapi_v2.Document)
-		rawInfo = document.ToRawInfo()
-	} else if g.sourceFormat == SourceFormatOpenAPI3 {
-		document := message.(*openapi_v3.Document)
-		rawInfo = document.ToRawInfo()
-	} else if g.sourceFormat == SourceFormatDiscovery {
-		document := message.(*discovery_v1.Document)
-		rawInfo = document.ToRawInfo()
-	}
-	if rawInfo.Kind != yaml.DocumentNode {
-		rawInfo = &yaml.Node{
-			Kind:    yaml.DocumentNode,
-			Content: []*yaml.Node{rawInfo},
-		}
-	}
+	rawInfo := DocumentNodeWithAnnotations(message, annotations, g.sourceFormat)
 	// Optionally write description in yaml format.
-	if g.yamlOutputPath != "" {
+	if g.options.YAMLOutputPath != "" {
 		if rawInfo != nil {
 			bytes, err := yaml.Marshal(rawInfo)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating yaml output %s\n", err.Error())
 				fmt.Fprintf(os.Stderr, "info %+v", rawInfo)
 			}
-			writeFile(g.yamlOutputPath, bytes, g.sourceName, "yaml")
+			writeFile(g.options.YAMLOutputPath, bytes, g.sourceName, "yaml")
 		} else {
 			fmt.Fprintf(os.Stderr, "No yaml output available.\n")
 		}
 	}
 	// Optionally write description in json format.
-	if g.jsonOutputPath != "" {
+	if g.options.JSONOutputPath != "" {
 		if rawInfo != nil {
 			rawInfo := &yaml.Node{
 				Kind:    yaml.DocumentNode,
@@ -558,7 +968,7 @@ func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating json output %s\n", err.Error())
 			}
-			writeFile(g.jsonOutputPath, bytes, g.sourceName, "json")
+			writeFile(g.options.JSONOutputPath, bytes, g.sourceName, "json")
 		} else {
 			fmt.Fprintf(os.Stderr, "No json output available.\n")
 		}
@@ -569,55 +979,88 @@ func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
 func (g *Gnostic) writeMessagesOutput(message proto.Message) error {
 	protoBytes, err := proto.Marshal(message)
 	if err != nil {
-		writeFile(g.messageOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		writeFile(g.options.MessageOutputPath, g.errorBytes(err), g.sourceName, "errors")
 	} else {
-		writeFile(g.messageOutputPath, protoBytes, g.sourceName, "messages.pb")
+		writeFile(g.options.MessageOutputPath, protoBytes, g.sourceName, "messages.pb")
 	}
 	return err
 }
 
+// Write a document in each of the requested output formats.
+func (g *Gnostic) writeDocument(message proto.Message) error {
+	// Remove extensions that shouldn't be written.
+	document, annotations := g.options.stripExtensions(proto.MessageV2(message), g.annotations)
+	message = proto.MessageV1(document)
+	// Optionally write proto in binary format.
+	if g.options.BinaryOutputPath != "" {
+		err := g.writeBinaryOutput(message)
+		if err != nil {
+			return err
+		}
+	}
+	// Optionally write proto in text format.
+	if g.options.TextOutputPath != "" {
+		g.writeTextOutput(message)
+	}
+	// Optionally write document in yaml and/or json formats.
+	if g.options.YAMLOutputPath != "" || g.options.JSONOutputPath != "" {
+		g.writeJSONYAMLOutput(message, annotations)
+	}
+	return nil
+}
+
+// Check that a document can be written without losing information by
+// comparing what is written with the source that it was compiled from.
+// Documents that are read from binary files have no source to compare
+// them with, so they aren't checked.
+func (g *Gnostic) verifyRoundTrip(message proto.Message, bytes []byte) error {
+	if strings.ToLower(filepath.Ext(g.sourceName)) == ".pb" {
+		return nil
+	}
+	info, err := g.options.readInfo(g.sourceName, bytes)
+	if err != nil {
+		return err
+	}
+	return VerifyRoundTrip(info, message, g.annotations, g.sourceFormat)
+}
+
 // Perform all actions specified in the command-line options.
 func (g *Gnostic) performActions(message proto.Message) (err error) {
-	// Optionally resolve internal references.
-	if g.resolveReferences {
-		if g.sourceFormat == SourceFormatOpenAPI2 {
-			document := message.(*openapi_v2.Document)
-			_, err = document.ResolveReferences(g.sourceName)
-		} else if g.sourceFormat == SourceFormatOpenAPI3 {
-			document := message.(*openapi_v3.Document)
-			_, err = document.ResolveReferences(g.sourceName)
-		}
+	// Optionally check that the document can be written without losing
+	// information. This is checked before references are resolved, since
+	// resolved documents aren't written as they were read.
+	if g.options.VerifyRoundTrip {
+		err = g.verifyRoundTrip(message, g.sourceBytes)
 		if err != nil {
 			return err
 		}
 	}
-	// Optionally write proto in binary format.
-	if g.binaryOutputPath != "" {
-		err = g.writeBinaryOutput(message)
+	// Optionally resolve internal references.
+	if g.options.ResolveReferences {
+		if resolvesReferences(g.sourceFormat) {
+			err = resolveReferences(g.ctx, message, g.sourceName, 0, &g.options)
+		}
 		if err != nil {
 			return err
 		}
 	}
-	// Optionally write proto in text format.
-	if g.textOutputPath != "" {
-		g.writeTextOutput(message)
-	}
-	// Optionally write document in yaml and/or json formats.
-	if g.yamlOutputPath != "" || g.jsonOutputPath != "" {
-		g.writeJSONYAMLOutput(message)
+	// Write the document in the requested formats.
+	err = g.writeDocument(message)
+	if err != nil {
+		return err
 	}
 	// Call all specified plugins.
 	messages := make([]*plugins.Message, 0)
 	errors := make([]error, 0)
 	for _, p := range g.pluginCalls {
-		pluginMessages, err := p.perform(message, g.sourceFormat, g.sourceName, g.timePlugins, g.excludeSurface)
+		pluginMessages, err := p.perform(message, g.annotations, g.sourceFormat, g.sourceName, g.sourceBytes, g.timePlugins, g.excludeSurface)
 		if err != nil {
 			// we don't exit or fail here so that we run all plugins even when some have errors
 			errors = append(errors, err)
 		}
 		messages = append(messages, pluginMessages...)
 	}
-	if g.messageOutputPath != "" {
+	if g.options.MessageOutputPath != "" {
 		err = g.writeMessagesOutput(&plugins.Messages{Messages: messages})
 		if err != nil {
 			return err
@@ -635,6 +1078,21 @@ func (g *Gnostic) performActions(message proto.Message) (err error) {
 
 // Main is the main program for Gnostic.
 func (g *Gnostic) Main() error {
+	if len(g.args) > 1 && g.args[1] == "query" {
+		return g.query()
+	}
+	if len(g.args) > 1 && g.args[1] == "watch" {
+		return g.watch()
+	}
+	if len(g.args) > 1 && g.args[1] == "conformance" {
+		return g.conformance()
+	}
+	if len(g.args) > 1 && g.args[1] == "vocabulary" {
+		return g.vocabulary()
+	}
+	if len(g.args) > 1 && g.args[1] == "stats" {
+		return g.stats()
+	}
 	// if help is requested, print usage and immediately exit
 	for _, arg := range g.args {
 		if arg == "--help" {
@@ -654,37 +1112,29 @@ func (g *Gnostic) Main() error {
 	if err != nil {
 		return err
 	}
+	return g.compileSource()
+}
+
+// Compile the source and perform the actions of the command-line options.
+func (g *Gnostic) compileSource() error {
 	// Read the OpenAPI source.
-	bytes, err := compiler.ReadBytesForFile(g.sourceName)
+	bytes, err := g.options.readBytes(g.ctx, g.sourceName)
 	if err != nil {
-		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		writeFile(g.options.ErrorOutputPath, g.errorBytes(err), g.sourceName, "errors")
 		return err
 	}
-	extension := strings.ToLower(filepath.Ext(g.sourceName))
-	var message proto.Message
-	if extension == ".json" || extension == ".yaml" {
-		// Try to read the source as JSON/YAML.
-		message, err = g.readOpenAPIText(bytes)
-		if err != nil {
-			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-			return err
-		}
-	} else if extension == ".pb" {
-		// Try to read the source as a binary protocol buffer.
-		message, err = g.readOpenAPIBinary(bytes)
-		if err != nil {
-			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+	g.sourceBytes = bytes
+	message, err := g.readDocumentWithCache(bytes)
+	if err != nil {
+		writeFile(g.options.ErrorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		if message == nil {
 			return err
 		}
-	} else {
-		err = errors.New("unknown file extension. 'json', 'yaml', and 'pb' are accepted")
-		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-		return err
 	}
 	// Perform actions specified by command options.
 	err = g.performActions(message)
 	if err != nil {
-		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		writeFile(g.options.ErrorOutputPath, g.errorBytes(err), g.sourceName, "errors")
 		return err
 	}
 	return nil
@@ -0,0 +1,180 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	discovery_v1 "github.com/okkoye/gnostic/discovery"
+	metrics "github.com/okkoye/gnostic/metrics"
+	"github.com/okkoye/gnostic/metrics/complexity"
+	"github.com/okkoye/gnostic/metrics/vocabulary"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const vocabularyUsage = `
+Usage: gnostic vocabulary SOURCE [OPTIONS]
+  SOURCE is the filename or URL of an API description.
+Options:
+  --json              Write the vocabulary as JSON.
+  --csv               Write the vocabulary as CSV.
+  --help              Print usage information and exit.
+`
+
+const statsUsage = `
+Usage: gnostic stats SOURCE [OPTIONS]
+  SOURCE is the filename or URL of an OpenAPI description.
+Options:
+  --json              Write the statistics as JSON.
+  --help              Print usage information and exit.
+`
+
+// Vocabulary returns the words used in the names of the schemas,
+// properties, operations, and parameters of an API description.
+func Vocabulary(document interface{}) (*metrics.Vocabulary, error) {
+	switch d := document.(type) {
+	case *openapi_v2.Document:
+		return vocabulary.NewVocabularyFromOpenAPIv2(d), nil
+	case *openapi_v3.Document:
+		return vocabulary.NewVocabularyFromOpenAPIv3(d), nil
+	case *discovery_v1.Document:
+		return vocabulary.NewVocabularyFromDiscovery(d), nil
+	default:
+		return nil, errors.New("vocabularies are only computed for OpenAPI and Discovery descriptions")
+	}
+}
+
+// Stats returns the complexity metrics of an OpenAPI description.
+func Stats(document interface{}) (*metrics.Complexity, error) {
+	switch d := document.(type) {
+	case *openapi_v2.Document:
+		return complexity.NewComplexityFromOpenAPIv2(d), nil
+	case *openapi_v3.Document:
+		return complexity.NewComplexityFromOpenAPIv3(d), nil
+	default:
+		return nil, errors.New("statistics are only computed for OpenAPI descriptions")
+	}
+}
+
+// readMetricsSource parses the arguments of a metrics command and reads
+// the API description that they name. The names of the options that were
+// set are returned in a map.
+func (g *Gnostic) readMetricsSource(usage string, options ...string) (interface{}, map[string]bool, error) {
+	set := make(map[string]bool)
+	operands := make([]string, 0)
+	for _, arg := range g.args[2:] {
+		switch {
+		case arg == "--help":
+			set[arg] = true
+			fmt.Printf("%s", usage)
+			return nil, set, nil
+		case strings.HasPrefix(arg, "--"):
+			known := false
+			for _, option := range options {
+				known = known || arg == option
+			}
+			if !known {
+				return nil, nil, NewUsageError(fmt.Sprintf("unknown option: %s", arg))
+			}
+			set[arg] = true
+		default:
+			operands = append(operands, arg)
+		}
+	}
+	g.usage = usage
+	if len(operands) != 1 {
+		return nil, nil, NewUsageError(fmt.Sprintf("%s requires a source", g.args[1]))
+	}
+	g.sourceName = operands[0]
+	document, _, err := ReadDocument(g.sourceName)
+	if err != nil {
+		os.Stderr.Write(g.errorBytes(err))
+		return nil, nil, err
+	}
+	return document, set, nil
+}
+
+// vocabulary writes the vocabulary of an API description. It implements
+// the "vocabulary" command.
+func (g *Gnostic) vocabulary() error {
+	document, options, err := g.readMetricsSource(vocabularyUsage, "--json", "--csv")
+	if err != nil || options["--help"] {
+		return err
+	}
+	v, err := Vocabulary(document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		return err
+	}
+	var bytes []byte
+	switch {
+	case options["--json"]:
+		bytes, err = vocabulary.MarshalJSON(v)
+		bytes = append(bytes, '\n')
+	case options["--csv"]:
+		bytes, err = vocabulary.MarshalCSV(v)
+	default:
+		for _, e := range vocabulary.Entries(v) {
+			bytes = append(bytes, fmt.Sprintf("%s\t%s\t%d\n", e.Kind, e.Word, e.Count)...)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	os.Stdout.Write(bytes)
+	return nil
+}
+
+// stats writes the complexity metrics of an OpenAPI description. It
+// implements the "stats" command.
+func (g *Gnostic) stats() error {
+	document, options, err := g.readMetricsSource(statsUsage, "--json")
+	if err != nil || options["--help"] {
+		return err
+	}
+	c, err := Stats(document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		return err
+	}
+	if options["--json"] {
+		bytes, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", bytes)
+		return nil
+	}
+	for _, stat := range []struct {
+		name  string
+		count int32
+	}{
+		{"paths", c.PathCount},
+		{"GET operations", c.GetCount},
+		{"POST operations", c.PostCount},
+		{"PUT operations", c.PutCount},
+		{"DELETE operations", c.DeleteCount},
+		{"schemas", c.SchemaCount},
+		{"schema properties", c.SchemaPropertyCount},
+	} {
+		fmt.Printf("%-18s %d\n", stat.name+":", stat.count)
+	}
+	return nil
+}
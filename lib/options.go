@@ -0,0 +1,372 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// Options control how API descriptions are read, compiled, and written.
+// The gnostic command sets them from its command-line options, and
+// programs that embed gnostic can create them with NewOptions. The zero
+// value reads descriptions the way that the gnostic command does when it
+// is given no options.
+type Options struct {
+	// ResolveReferences replaces references in OpenAPI descriptions with
+	// the values that they refer to (--resolve-refs).
+	ResolveReferences bool
+	// ExtensionHandlers are the extension plugins that are called to
+	// compile vendor extensions (--x-NAME).
+	ExtensionHandlers []compiler.ExtensionHandler
+	// ExtensionFunctions are Go functions that compile the extensions
+	// with their names. They are called for extensions that weren't
+	// compiled by ExtensionHandlers, and before the built-in handlers for
+	// well-known extensions.
+	ExtensionFunctions map[string]ExtensionHandlerFunc
+	// StripExtensions are patterns, like x-internal-*, that match the
+	// names of extensions to remove from written documents
+	// (--strip-extensions). The pattern x-* removes all extensions.
+	StripExtensions []string
+	// KeepExtensions are patterns that match the names of extensions to
+	// keep in written documents when others are removed
+	// (--keep-extensions). If StripExtensions is empty, all other
+	// extensions are removed.
+	KeepExtensions []string
+	// CacheDirectory is a directory where compiled descriptions are saved
+	// and reused when their inputs are unchanged (--cache-dir).
+	CacheDirectory string
+	// SourceContents are the contents of the source description, which
+	// are compiled instead of reading the source, so that descriptions
+	// can be compiled from memory. The source must still be named as if it
+	// were in ReferenceRoot, since the files that it refers to are found
+	// with its name.
+	SourceContents []byte
+	// Fetcher reads the sources and referenced files that are named with
+	// URLs, so that they can be read with headers, credentials, and
+	// timeouts (--ref-header, --ref-token, --ref-host, and
+	// --ref-timeout). If it is nil, they are read with http.Get.
+	Fetcher compiler.Fetcher
+	// ReferenceRoot is a directory that contains all of the files that
+	// are read (--ref-root). Files outside of it, including files that
+	// are reached with ../ or with symbolic links that lead out of it,
+	// aren't read, so programs that compile descriptions from untrusted
+	// sources can't be made to read other files. Sources that are read
+	// with SourceContents must be named as if they were in it. Files
+	// named with URLs aren't read when it is set unless AllowURLs is set.
+	ReferenceRoot string
+	// AllowURLs reads sources and referenced files that are named with
+	// URLs when ReferenceRoot is set (--allow-urls). They are read from
+	// any host.
+	AllowURLs bool
+	// PlainStrings reads values written without quotes as strings where
+	// strings are expected, even if YAML would read them as numbers,
+	// booleans, or timestamps, so that a version written as 1.0 isn't
+	// rejected (--plain-strings). Only the compilations that set it read
+	// values this way.
+	PlainStrings bool
+	// NormalizeUnicode converts the keys of descriptions to Unicode
+	// Normalization Form C and reports keys that mix scripts, like Latin
+	// and Cyrillic, or that look like other keys (--normalize-unicode).
+	NormalizeUnicode bool
+	// VerifyRoundTrip checks that compiled documents can be written as
+	// YAML and JSON without losing information by comparing what is
+	// written with their sources, and reports the information that is
+	// lost as problems (--verify-roundtrip).
+	VerifyRoundTrip bool
+	// Lenient accepts descriptions with problems: documents are
+	// compiled as well as possible and written along with the problems
+	// that were found (--lenient).
+	Lenient bool
+	// ErrorFormat is the format of written errors (--errors): "text",
+	// the default, writes a line for each error, and "json" writes a JSON
+	// array with the path, line, column, severity, and message of each.
+	ErrorFormat string
+	// The paths where documents are written in each output format
+	// (--pb-out, --text-out, --yaml-out, --json-out, --errors-out, and
+	// --messages-out). An empty path writes nothing and a directory path
+	// writes a file named after the source.
+	BinaryOutputPath  string
+	TextOutputPath    string
+	YAMLOutputPath    string
+	JSONOutputPath    string
+	ErrorOutputPath   string
+	MessageOutputPath string
+}
+
+// An Option sets a field of an Options struct.
+type Option func(*Options)
+
+// NewOptions returns Options with the specified options set.
+func NewOptions(options ...Option) *Options {
+	result := &Options{}
+	for _, option := range options {
+		option(result)
+	}
+	return result
+}
+
+// WithReferenceResolution resolves references in OpenAPI descriptions.
+func WithReferenceResolution() Option {
+	return func(o *Options) {
+		o.ResolveReferences = true
+	}
+}
+
+// WithExtensions calls the named extension plugins to compile vendor
+// extensions. A name is the plugin name without its gnostic-x- prefix.
+func WithExtensions(names ...string) Option {
+	return func(o *Options) {
+		for _, name := range names {
+			o.ExtensionHandlers = append(o.ExtensionHandlers, compiler.ExtensionHandler{Name: extensionPrefix + name})
+		}
+	}
+}
+
+// WithExtensionsStripped removes extensions with names that match
+// patterns from written documents. Patterns use the syntax of path.Match,
+// and with no patterns, all extensions are removed.
+func WithExtensionsStripped(patterns ...string) Option {
+	return func(o *Options) {
+		if len(patterns) == 0 {
+			patterns = []string{"x-*"}
+		}
+		o.StripExtensions = append(o.StripExtensions, patterns...)
+	}
+}
+
+// WithExtensionsKept keeps extensions with names that match patterns in
+// written documents and removes the others.
+func WithExtensionsKept(patterns ...string) Option {
+	return func(o *Options) {
+		o.KeepExtensions = append(o.KeepExtensions, patterns...)
+	}
+}
+
+// WithRoundTripVerification checks that compiled documents can be written
+// without losing the values of their sources.
+func WithRoundTripVerification() Option {
+	return func(o *Options) {
+		o.VerifyRoundTrip = true
+	}
+}
+
+// WithCacheDirectory saves compiled descriptions in a cache directory.
+func WithCacheDirectory(path string) Option {
+	return func(o *Options) {
+		o.CacheDirectory = path
+	}
+}
+
+// WithSourceContents compiles the contents of a source description instead
+// of reading the source.
+func WithSourceContents(contents []byte) Option {
+	return func(o *Options) {
+		o.SourceContents = contents
+	}
+}
+
+// WithFetcher reads sources and referenced files that are named with URLs
+// with a fetcher, like a compiler.HTTPFetcher.
+func WithFetcher(fetcher compiler.Fetcher) Option {
+	return func(o *Options) {
+		o.Fetcher = fetcher
+	}
+}
+
+// WithReferenceRoot only reads sources and the files that they refer to
+// if they are in a directory.
+func WithReferenceRoot(dir string) Option {
+	return func(o *Options) {
+		o.ReferenceRoot = dir
+	}
+}
+
+// WithURLsAllowed reads sources and referenced files that are named with
+// URLs when a reference root is set.
+func WithURLsAllowed() Option {
+	return func(o *Options) {
+		o.AllowURLs = true
+	}
+}
+
+// WithPlainStrings reads unquoted values as strings where strings are
+// expected.
+func WithPlainStrings() Option {
+	return func(o *Options) {
+		o.PlainStrings = true
+	}
+}
+
+// WithUnicodeNormalization normalizes the keys of descriptions and
+// reports keys that mix scripts or that look like other keys.
+func WithUnicodeNormalization() Option {
+	return func(o *Options) {
+		o.NormalizeUnicode = true
+	}
+}
+
+// WithLenientCompilation accepts descriptions with problems.
+func WithLenientCompilation() Option {
+	return func(o *Options) {
+		o.Lenient = true
+	}
+}
+
+// WithErrorFormat writes errors in a format, "text" or "json".
+func WithErrorFormat(format string) Option {
+	return func(o *Options) {
+		o.ErrorFormat = format
+	}
+}
+
+// isErrorFormat returns true if errors can be written in a format.
+func isErrorFormat(format string) bool {
+	return format == "text" || format == "json"
+}
+
+// WithOutput writes documents in a format to a path. The format is one
+// of pb, text, yaml, json, errors, or messages, and other formats are
+// ignored.
+func WithOutput(format, path string) Option {
+	return func(o *Options) {
+		switch format {
+		case "pb":
+			o.BinaryOutputPath = path
+		case "text":
+			o.TextOutputPath = path
+		case "yaml":
+			o.YAMLOutputPath = path
+		case "json":
+			o.JSONOutputPath = path
+		case "errors":
+			o.ErrorOutputPath = path
+		case "messages":
+			o.MessageOutputPath = path
+		}
+	}
+}
+
+// isOutputFormat returns true if a format can be written with WithOutput.
+func isOutputFormat(format string) bool {
+	switch format {
+	case "pb", "text", "yaml", "json", "errors", "messages":
+		return true
+	}
+	return false
+}
+
+// Read the bytes of a source description. Sources with SourceContents must
+// also be named as if they were in ReferenceRoot, since the files that they
+// refer to are found with their names.
+func (o *Options) readBytes(ctx context.Context, name string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if o.SourceContents != nil {
+		if err := o.checkURL(name); err != nil {
+			return nil, err
+		}
+		if _, err := pathInReferenceRoot(o.ReferenceRoot, name); err != nil {
+			return nil, err
+		}
+		return o.SourceContents, nil
+	}
+	return o.readFile(ctx, name)
+}
+
+// Reads a file that must be in ReferenceRoot if it isn't empty. The file
+// is read with the path that its symbolic links lead to, which is the path
+// that is checked, so that links that are changed after they are checked
+// can't lead out of the root. Files named with URLs are read with Fetcher
+// if it isn't nil.
+func (o *Options) readFile(ctx context.Context, name string) ([]byte, error) {
+	root := o.ReferenceRoot
+	if root == "" || compiler.IsURL(name) {
+		if err := o.checkURL(name); err != nil {
+			return nil, err
+		}
+		return compiler.ReadBytesForFileWithContext(ctx, name, o.Fetcher)
+	}
+	path, err := pathInReferenceRoot(root, name)
+	if err != nil {
+		return nil, err
+	}
+	// Compare the paths with their symbolic links replaced.
+	if root, err = filepath.Abs(root); err != nil {
+		return nil, err
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, err
+	}
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		return nil, err
+	}
+	if !withinDirectory(root, path) {
+		return nil, fmt.Errorf("%s links to a file outside of %s", filepath.ToSlash(name), filepath.ToSlash(root))
+	}
+	return compiler.ReadBytesForFileWithContext(ctx, path, o.Fetcher)
+}
+
+// Returns an error if a file is named with a URL and URLs aren't read,
+// which they aren't when ReferenceRoot is set unless AllowURLs is set.
+func (o *Options) checkURL(name string) error {
+	if o.ReferenceRoot != "" && !o.AllowURLs && compiler.IsURL(name) {
+		return fmt.Errorf("%s can't be read: URLs aren't read with a reference root unless they are allowed", name)
+	}
+	return nil
+}
+
+// Parses the text of a file. Files that are read with Fetcher aren't
+// cached, so their parsed text isn't cached either.
+func (o *Options) readInfo(name string, bytes []byte) (*yaml.Node, error) {
+	if o.Fetcher != nil && compiler.IsURL(name) {
+		name = ""
+	}
+	return compiler.ReadInfoFromBytes(name, bytes)
+}
+
+// Returns the absolute path of a file, or an error if its name leads out
+// of a root directory. Nothing is checked if the root is empty or if the
+// file is named with a URL.
+func pathInReferenceRoot(root, name string) (string, error) {
+	if root == "" || compiler.IsURL(name) {
+		return name, nil
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	path, err := filepath.Abs(filepath.FromSlash(name))
+	if err != nil {
+		return "", err
+	}
+	if !withinDirectory(root, path) {
+		return "", fmt.Errorf("%s is outside of %s", filepath.ToSlash(name), filepath.ToSlash(root))
+	}
+	return path, nil
+}
+
+// Returns true if an absolute path is in a directory or its subdirectories.
+func withinDirectory(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/jsonpath"
+	"github.com/okkoye/gnostic/jsonwriter"
+)
+
+const queryUsage = `
+Usage: gnostic query SOURCE EXPRESSION [OPTIONS]
+  SOURCE is the filename or URL of an API description.
+  EXPRESSION is a JSONPath expression, such as '$.paths[*].get.operationId'.
+Options:
+  --json              Write the results as a JSON array.
+  --paths             Write the location of each result before it.
+  --help              Print usage information and exit.
+`
+
+// query evaluates a JSONPath expression over a compiled API description
+// and writes the results. It implements the "query" command.
+func (g *Gnostic) query() error {
+	var expression string
+	jsonOutput, paths := false, false
+	operands := make([]string, 0)
+	for _, arg := range g.args[2:] {
+		switch {
+		case arg == "--help":
+			fmt.Printf("%s", queryUsage)
+			return nil
+		case arg == "--json":
+			jsonOutput = true
+		case arg == "--paths":
+			paths = true
+		case strings.HasPrefix(arg, "--"):
+			return &UsageError{message: fmt.Sprintf("unknown option: %s", arg)}
+		default:
+			operands = append(operands, arg)
+		}
+	}
+	g.usage = queryUsage
+	if len(operands) != 2 {
+		return NewUsageError("query requires a source and an expression")
+	}
+	g.sourceName, expression = operands[0], operands[1]
+	path, err := jsonpath.Compile(expression)
+	if err != nil {
+		return NewUsageError(err.Error())
+	}
+	document, _, err := ReadRawInfo(g.sourceName)
+	if err != nil {
+		os.Stderr.Write(g.errorBytes(err))
+		return err
+	}
+	matches := path.Select(document)
+
+	if jsonOutput {
+		results := make([]json.RawMessage, 0, len(matches))
+		for _, m := range matches {
+			bytes, err := jsonwriter.Marshal(m.Node)
+			if err != nil {
+				return err
+			}
+			if paths {
+				location, _ := json.Marshal(m.Location)
+				bytes = []byte(fmt.Sprintf(`{"path":%s,"value":%s}`, location, bytes))
+			}
+			results = append(results, json.RawMessage(bytes))
+		}
+		bytes, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", bytes)
+		return nil
+	}
+	// Scalars are written as plain text and other values as JSON.
+	for _, m := range matches {
+		if paths {
+			fmt.Printf("%s\t", m.Location)
+		}
+		if m.Node.Kind == yaml.ScalarNode {
+			fmt.Printf("%s\n", m.Node.Value)
+			continue
+		}
+		bytes, err := jsonwriter.Marshal(m.Node)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s", bytes)
+	}
+	return nil
+}
@@ -0,0 +1,442 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+	openapi_v31 "github.com/okkoye/gnostic/openapiv31"
+)
+
+// The constructors of the messages that contain references that can be
+// replaced by their targets, indexed by message name.
+var referenceConstructors = map[protoreflect.FullName]func(*yaml.Node, *compiler.Context) (protov1.Message, error){
+	"openapi.v2.JsonReference": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v2.NewJsonReference(node, context)
+	},
+	"openapi.v2.PathItem": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v2.NewPathItem(node, context)
+	},
+	"openapi.v2.Schema": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v2.NewSchema(node, context)
+	},
+	"openapi.v3.PathItem": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v3.NewPathItem(node, context)
+	},
+	"openapi.v3.Reference": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v3.NewReference(node, context)
+	},
+	"openapi.v31.PathItem": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v31.NewPathItem(node, context)
+	},
+	"openapi.v31.Reference": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v31.NewReference(node, context)
+	},
+}
+
+// The constructors of messages that are either a value or a JSON
+// reference. When the reference is resolved, the message is replaced
+// with its target, compiled as the message.
+var referenceWrapperConstructors = map[protoreflect.FullName]func(*yaml.Node, *compiler.Context) (protov1.Message, error){
+	"openapi.v2.ParametersItem": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v2.NewParametersItem(node, context)
+	},
+	"openapi.v2.ResponseValue": func(node *yaml.Node, context *compiler.Context) (protov1.Message, error) {
+		return openapi_v2.NewResponseValue(node, context)
+	},
+}
+
+// ResolveReferences resolves the references in an OpenAPI v2, v3, or v3.1
+// document in the same way as the document's ResolveReferences method:
+// references to values that can be compiled as the referring message are
+// replaced with those values. But referenced files are read and their
+// values are compiled concurrently using up to parallelism goroutines
+// (one per CPU if parallelism is zero or less), and each file and each
+// target is read and compiled only once, no matter how many references
+// refer to it. References that would replace a value with itself are
+// left unresolved.
+func ResolveReferences(document protov1.Message, sourceName string, parallelism int) error {
+	return resolveReferences(context.Background(), document, sourceName, parallelism, &Options{})
+}
+
+// ResolveReferencesWithFetcher resolves references like ResolveReferences,
+// reading the files that are named with URLs with a fetcher.
+func ResolveReferencesWithFetcher(document protov1.Message, sourceName string, parallelism int, fetcher compiler.Fetcher) error {
+	return resolveReferences(context.Background(), document, sourceName, parallelism, &Options{Fetcher: fetcher})
+}
+
+// Resolve references, only reading files that are in the reference root
+// of options if it isn't empty, reading files named with URLs with its URL
+// fetcher if it isn't nil, and compiling referenced values with its plain
+// strings setting. Files aren't read after ctx is done.
+func resolveReferences(ctx context.Context, document protov1.Message, sourceName string, parallelism int, options *Options) error {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	r := &referenceResolver{
+		ctx:       ctx,
+		root:      sourceName,
+		options:   options,
+		semaphore: make(chan bool, parallelism),
+		files:     make(map[string]*referencedFile),
+		targets:   make(map[referenceTarget]*referencedValue),
+	}
+	if options.PlainStrings {
+		r.context = compiler.NewContext("$ref", nil, nil)
+		compiler.EnablePlainStrings(r.context)
+		defer compiler.DisablePlainStrings(r.context)
+	}
+	errors := make([]error, 0)
+	for _, site := range r.sites(protov1.MessageReflect(document), nil) {
+		errors = r.resolve(site, errors)
+	}
+	r.wg.Wait()
+	return compiler.NewErrorGroupOrNil(errors)
+}
+
+type referenceResolver struct {
+	ctx     context.Context
+	root    string
+	options *Options
+	// the root context of compiled values, or nil if values are compiled
+	// without one
+	context   *compiler.Context
+	semaphore chan bool
+	wg        sync.WaitGroup
+	mutex     sync.Mutex
+	files     map[string]*referencedFile
+	targets   map[referenceTarget]*referencedValue
+}
+
+// A location in a document that contains a reference.
+type referenceSite struct {
+	message  protoreflect.Message
+	ref      string
+	resolved []string // references that were replaced to produce message
+}
+
+func (site referenceSite) target() referenceTarget {
+	return referenceTarget{name: site.message.Descriptor().FullName(), ref: site.ref}
+}
+
+// A file that is read when resolving references.
+type referencedFile struct {
+	once sync.Once
+	node *yaml.Node
+	err  error
+}
+
+// A message type and a reference to a value of that type.
+type referenceTarget struct {
+	name protoreflect.FullName
+	ref  string
+}
+
+// The value that a reference refers to, compiled as its referring message.
+type referencedValue struct {
+	once     sync.Once
+	message  protov1.Message // nil if the value can't be compiled
+	err      error
+	reported bool // true if err has been returned
+}
+
+// Resolve the reference at a site and then the references in its
+// replacement, appending any errors to a list. Sites are visited in
+// document order, so errors are reported in the same order as the
+// ResolveReferences methods report them.
+func (r *referenceResolver) resolve(site referenceSite, errors []error) []error {
+	value := r.value(site.target())
+	if value.err != nil {
+		// Each unresolvable reference is reported once, located at the
+		// reference in the root document, or at the one that led to it if
+		// it is in another file.
+		if !value.reported {
+			errors = append(errors, compiler.LocateErrors(value.err, r.referenceContext(site)))
+			value.reported = true
+		}
+		return errors
+	}
+	if value.message == nil || containsString(site.resolved, site.ref) {
+		return errors
+	}
+	target := site.message.Interface()
+	if _, ok := referenceWrapperConstructors[site.message.Descriptor().FullName()]; ok {
+		proto.Reset(target)
+		proto.Merge(target, protov1.MessageV2(value.message))
+		// Replaced wrappers aren't resolved further.
+		return errors
+	}
+	// In OpenAPI 3.1, keywords beside a reference, like summary and
+	// description, take precedence over the values of the referenced
+	// object. Earlier versions ignore them.
+	siblings := proto.Clone(target).ProtoReflect()
+	proto.Reset(target)
+	proto.Merge(target, protov1.MessageV2(value.message))
+	if site.message.Descriptor().ParentFile().Package() == "openapi.v31" {
+		siblings.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			if field.Name() != "_ref" {
+				site.message.Set(field, value)
+			}
+			return true
+		})
+	}
+	resolved := append(append([]string{}, site.resolved...), site.ref)
+	for _, site := range r.sites(site.message, resolved) {
+		errors = r.resolve(site, errors)
+	}
+	return errors
+}
+
+// Returns the sites of the references in a message and starts compiling
+// their targets in the background.
+func (r *referenceResolver) sites(message protoreflect.Message, resolved []string) []referenceSite {
+	sites := referenceSites(message, resolved, nil)
+	for _, site := range sites {
+		target := site.target()
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.semaphore <- true
+			r.value(target)
+			<-r.semaphore
+		}()
+	}
+	return sites
+}
+
+// Returns the value of a target, reading and compiling it if necessary.
+func (r *referenceResolver) value(target referenceTarget) *referencedValue {
+	r.mutex.Lock()
+	value, ok := r.targets[target]
+	if !ok {
+		value = &referencedValue{}
+		r.targets[target] = value
+	}
+	r.mutex.Unlock()
+	value.once.Do(func() {
+		if constructor, ok := referenceWrapperConstructors[target.name]; ok {
+			node, err := r.follow(target.ref)
+			if err != nil || node == nil {
+				value.err = err
+				return
+			}
+			value.message, value.err = constructor(node, r.context)
+			if value.err != nil {
+				value.message = nil
+			}
+			return
+		}
+		var node *yaml.Node
+		node, value.err = r.lookup(target.ref)
+		if node != nil {
+			message, err := referenceConstructors[target.name](node, r.context)
+			if err == nil {
+				value.message = message
+			}
+		}
+	})
+	return value
+}
+
+// Returns the node that a JSON reference refers to, following any
+// references to other references.
+func (r *referenceResolver) follow(ref string) (*yaml.Node, error) {
+	visited := make(map[string]bool)
+	for !visited[ref] {
+		visited[ref] = true
+		node, err := r.lookup(ref)
+		if err != nil || node == nil {
+			return nil, err
+		}
+		reference, err := openapi_v2.NewJsonReference(node, nil)
+		if err != nil || reference.XRef == "" {
+			return node, nil
+		}
+		ref = reference.XRef
+	}
+	return nil, nil
+}
+
+// Returns the node that a reference refers to. Like compiler.ReadInfoForRef,
+// references are relative to the root document.
+func (r *referenceResolver) lookup(ref string) (*yaml.Node, error) {
+	parts := strings.Split(ref, "#")
+	filename := r.root
+	if parts[0] != "" {
+		filename = compiler.ReferencedFileName(r.root, parts[0])
+	}
+	node, err := r.read(filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) > 1 {
+		for i, key := range strings.Split(parts[1], "/") {
+			if i == 0 {
+				continue
+			}
+			found := false
+			m := node
+			for j := 0; j+1 < len(m.Content); j += 2 {
+				if m.Content[j].Value == key {
+					node = m.Content[j+1]
+					found = true
+				}
+			}
+			if !found {
+				return nil, compiler.NewError(nil, fmt.Sprintf("could not resolve %s", ref))
+			}
+		}
+	}
+	return node, nil
+}
+
+// Returns the context of the $ref in the root document that a site's
+// reference was found with, or nil if it can't be found. References are
+// relative to the root, so the first $ref in the root with the same value
+// refers to the same target. If there isn't one, the references that were
+// replaced to reach the site are tried, from the innermost out.
+func (r *referenceResolver) referenceContext(site referenceSite) *compiler.Context {
+	root, err := r.read(r.root)
+	if err != nil || root == nil {
+		return nil
+	}
+	refs := append(append([]string{}, site.resolved...), site.ref)
+	for i := len(refs) - 1; i >= 0; i-- {
+		if context := findReference(root, refs[i], compiler.NewContext("$root", root, nil)); context != nil {
+			return context
+		}
+	}
+	return nil
+}
+
+// Returns the context of the first $ref below a node, in document order,
+// that has a value, or nil if there isn't one. Like the contexts of
+// compiled values, the items of sequences share the context of the
+// sequence.
+func findReference(node *yaml.Node, ref string, context *compiler.Context) *compiler.Context {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if key == "$ref" && value.Kind == yaml.ScalarNode && value.Value == ref {
+				return compiler.NewContext(key, value, context)
+			}
+			if found := findReference(value, ref, compiler.NewContext(key, value, context)); found != nil {
+				return found
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if found := findReference(item, ref, compiler.NewContext(context.Name, item, context.Parent)); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// Read and parse a file once.
+func (r *referenceResolver) read(filename string) (*yaml.Node, error) {
+	r.mutex.Lock()
+	file, ok := r.files[filename]
+	if !ok {
+		file = &referencedFile{}
+		r.files[filename] = file
+	}
+	r.mutex.Unlock()
+	file.once.Do(func() {
+		var bytes []byte
+		bytes, file.err = r.options.readFile(r.ctx, filename)
+		if file.err != nil {
+			return
+		}
+		var info *yaml.Node
+		if filename == r.root {
+			// The root was parsed when it was compiled and is cached.
+			info, file.err = r.options.readInfo(filename, bytes)
+		} else {
+			bytes, file.err = compiler.DecodeText(bytes)
+			if file.err != nil {
+				return
+			}
+			info = &yaml.Node{}
+			file.err = yaml.Unmarshal(bytes, info)
+		}
+		if file.err != nil {
+			return
+		}
+		if info.Kind == yaml.DocumentNode && len(info.Content) > 0 {
+			info = info.Content[0]
+		}
+		file.node = info
+	})
+	return file.node, file.err
+}
+
+// Appends the sites of the references in a message to a list.
+func referenceSites(message protoreflect.Message, resolved []string, sites []referenceSite) []referenceSite {
+	descriptor := message.Descriptor()
+	if _, ok := referenceWrapperConstructors[descriptor.FullName()]; ok {
+		field := descriptor.Fields().ByName("json_reference")
+		if message.Has(field) {
+			reference := message.Get(field).Message()
+			if ref := reference.Get(reference.Descriptor().Fields().ByName("_ref")).String(); ref != "" {
+				return append(sites, referenceSite{message: message, ref: ref, resolved: resolved})
+			}
+		}
+	}
+	if _, ok := referenceConstructors[descriptor.FullName()]; ok {
+		if ref := message.Get(descriptor.Fields().ByName("_ref")).String(); ref != "" {
+			return append(sites, referenceSite{message: message, ref: ref, resolved: resolved})
+		}
+	}
+	fields := descriptor.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Message() == nil || field.IsMap() || !message.Has(field) {
+			continue
+		}
+		if field.IsList() {
+			list := message.Get(field).List()
+			for j := 0; j < list.Len(); j++ {
+				sites = referenceSites(list.Get(j).Message(), resolved, sites)
+			}
+		} else {
+			sites = referenceSites(message.Get(field).Message(), resolved, sites)
+		}
+	}
+	return sites
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
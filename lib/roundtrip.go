@@ -0,0 +1,192 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/jsonwriter"
+)
+
+// maxRoundTripDifferences limits the differences reported for each encoding.
+const maxRoundTripDifferences = 20
+
+// VerifyRoundTrip checks that a compiled document can be written as YAML
+// and JSON without losing information. The document is written with the
+// annotations that were recorded when it was compiled, and the written
+// descriptions are read and compared with the source node that the
+// document was compiled from. Each value of the source that is lost or
+// changed is reported as an error with its JSON pointer, like values that
+// the model can't hold, such as "minimum: 0" in OpenAPI v3 schemas.
+func VerifyRoundTrip(source *yaml.Node, document proto.Message, annotations *compiler.Annotations, sourceFormat int) error {
+	node := DocumentNodeWithAnnotations(document, annotations, sourceFormat)
+	if node == nil {
+		return fmt.Errorf("documents in format %d can't be written as YAML or JSON", sourceFormat)
+	}
+	errors := make([]error, 0)
+	for _, encoding := range []struct {
+		name    string
+		marshal func(*yaml.Node) ([]byte, error)
+	}{
+		{name: "yaml", marshal: func(node *yaml.Node) ([]byte, error) { return yaml.Marshal(node) }},
+		{name: "json", marshal: jsonwriter.Marshal},
+	} {
+		b, err := encoding.marshal(node)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("round trip: can't write %s: %s", encoding.name, err))
+			continue
+		}
+		var written yaml.Node
+		if err := yaml.Unmarshal(b, &written); err != nil {
+			errors = append(errors, fmt.Errorf("round trip: %s output can't be read: %s", encoding.name, err))
+			continue
+		}
+		differences := compareNodes("", source, &written, nil)
+		for i, difference := range differences {
+			if i == maxRoundTripDifferences {
+				errors = append(errors, fmt.Errorf("round trip: %s output has %d more differences", encoding.name, len(differences)-i))
+				break
+			}
+			errors = append(errors, fmt.Errorf("round trip: %s output %s", encoding.name, difference))
+		}
+	}
+	return compiler.NewErrorGroupOrNil(errors)
+}
+
+// Append the differences between a source node and a written node to a
+// list. Each difference is described with the JSON pointer of its value.
+// The keys of mappings are compared without their order, and scalars are
+// compared by their values, so numbers like 1.0 and 1 are the same.
+func compareNodes(pointer string, before, after *yaml.Node, differences []string) []string {
+	before, after = contentNode(before), contentNode(after)
+	location := pointer
+	if location == "" {
+		location = "/"
+	}
+	if before == nil || after == nil {
+		if before != after {
+			differences = append(differences, "changes "+location)
+		}
+		return differences
+	}
+	if before.Kind != after.Kind {
+		return append(differences, "changes "+location)
+	}
+	switch before.Kind {
+	case yaml.MappingNode:
+		keys := make(map[string]bool)
+		for i := 0; i+1 < len(before.Content); i += 2 {
+			key := before.Content[i].Value
+			keys[key] = true
+			child := pointer + "/" + escapePointer(key)
+			value := compiler.MapValueForKey(after, key)
+			if value == nil {
+				if !isEmptyValue(key, before.Content[i+1]) {
+					differences = append(differences, "loses "+child)
+				}
+				continue
+			}
+			differences = compareNodes(child, before.Content[i+1], value, differences)
+		}
+		for i := 0; i+1 < len(after.Content); i += 2 {
+			if key := after.Content[i].Value; !keys[key] {
+				differences = append(differences, "adds "+pointer+"/"+escapePointer(key))
+			}
+		}
+	case yaml.SequenceNode:
+		if len(before.Content) != len(after.Content) {
+			return append(differences, fmt.Sprintf("changes the length of %s from %d to %d", location, len(before.Content), len(after.Content)))
+		}
+		for i := range before.Content {
+			differences = compareNodes(pointer+"/"+strconv.Itoa(i), before.Content[i], after.Content[i], differences)
+		}
+	case yaml.ScalarNode:
+		if !equalScalars(before, after) {
+			differences = append(differences, fmt.Sprintf("changes %s from %s to %s", location, before.Value, after.Value))
+		}
+	}
+	return differences
+}
+
+// Returns true if a value is an empty string or false, which models
+// don't hold and aren't written. Empty strings mean the same as absent
+// ones, and the fields of descriptions are false by default other than
+// explode, which is true by default for form parameters.
+func isEmptyValue(key string, value *yaml.Node) bool {
+	value = contentNode(value)
+	if value.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch value.ShortTag() {
+	case "!!str":
+		return value.Value == ""
+	case "!!bool":
+		return key != "explode" && strings.EqualFold(value.Value, "false")
+	}
+	return false
+}
+
+// Returns the value of a document or alias node, or the node if it is a value.
+func contentNode(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) > 0:
+			node = node.Content[0]
+		case node.Kind == yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// Returns true if two scalars have the same value. Numbers are compared
+// by their values, and other scalars by their text, since descriptions
+// can write strings like versions and dates without quotes.
+func equalScalars(before, after *yaml.Node) bool {
+	beforeTag, afterTag := before.ShortTag(), after.ShortTag()
+	if beforeTag == "!!null" || afterTag == "!!null" {
+		return beforeTag == afterTag
+	}
+	if beforeTag == "!!bool" && afterTag == "!!bool" {
+		return strings.EqualFold(before.Value, after.Value)
+	}
+	if isNumber(beforeTag) && isNumber(afterTag) {
+		x, ok := new(big.Rat).SetString(before.Value)
+		if !ok {
+			return before.Value == after.Value
+		}
+		y, ok := new(big.Rat).SetString(after.Value)
+		return ok && x.Cmp(y) == 0
+	}
+	return before.Value == after.Value
+}
+
+func isNumber(tag string) bool {
+	return tag == "!!int" || tag == "!!float"
+}
+
+// Returns a name as a JSON Pointer reference token.
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
@@ -0,0 +1,228 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+const watchUsage = `
+Usage: gnostic watch SOURCE... [OPTIONS]
+  SOURCE is the filename of an API description.
+  Each SOURCE is compiled and then recompiled when it or any file that
+  it refers to changes. Each compilation writes the outputs and runs the
+  plugins of the options, like "gnostic SOURCE [OPTIONS]".
+Options:
+  --interval=DURATION Check for changes at the specified interval,
+                      such as 500ms or 2s. The default is 1s.
+  --help              Print usage information and exit.
+  The other options are the options of gnostic, such as --pb-out=PATH,
+  --json-out=PATH, and --PLUGIN-out=PATH. Outputs that are directories
+  get a file for each SOURCE.
+`
+
+// A Watcher compiles API descriptions and recompiles them when they
+// change. It records the files that each description refers to, so when
+// a file changes, only the descriptions that depend on it are compiled
+// again.
+type Watcher struct {
+	sources      []string
+	dependencies map[string][]string // the files read by each source, including itself
+	files        map[string]fileState
+	options      *Options // options used to find the files that sources refer to
+	// run compiles a source and writes its outputs. If it is nil,
+	// sources are compiled without writing anything.
+	run func(source string) (sourceFormat int, err error)
+}
+
+// The state of a file when it was last read.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+func statFile(name string) fileState {
+	info, err := os.Stat(name)
+	if err != nil {
+		return fileState{size: -1}
+	}
+	return fileState{size: info.Size(), modTime: info.ModTime()}
+}
+
+// NewWatcher creates a watcher for the named files.
+func NewWatcher(sources []string) *Watcher {
+	return &Watcher{
+		sources:      sources,
+		dependencies: make(map[string][]string),
+		files:        make(map[string]fileState),
+		options:      &Options{},
+	}
+}
+
+// Creates a watcher that compiles sources like the gnostic command with
+// the arguments of a watch command, which are the command-line options
+// of gnostic without a source.
+func newCommandWatcher(sources []string, args []string) (*Watcher, error) {
+	command := func(source string) (*Gnostic, error) {
+		g := NewGnostic(append(append([]string{}, args...), source))
+		if err := g.readOptions(); err != nil {
+			return nil, err
+		}
+		return g, g.validateOptions()
+	}
+	// Check the options before anything is compiled.
+	g, err := command(sources[0])
+	if err != nil {
+		return nil, err
+	}
+	w := NewWatcher(sources)
+	w.options = &g.options
+	w.run = func(source string) (int, error) {
+		g, err := command(source)
+		if err != nil {
+			return SourceFormatUnknown, err
+		}
+		err = g.compileSource()
+		return g.sourceFormat, err
+	}
+	return w, nil
+}
+
+// Update compiles the sources that have not been compiled and the sources
+// that depend on files that have changed since they were compiled, and
+// returns the results of these compilations in the order of the sources.
+func (w *Watcher) Update() []*Result {
+	changed := make(map[string]bool)
+	for name, state := range w.files {
+		if statFile(name) != state {
+			changed[name] = true
+			delete(w.files, name)
+			compiler.RemoveFromFileCache(name)
+			compiler.RemoveFromInfoCache(name)
+		}
+	}
+	if len(changed) > 0 {
+		// Parsed references are cached by reference rather than by
+		// file, so remove all of them.
+		references := make([]string, 0)
+		for key := range compiler.GetInfoCache() {
+			if strings.Contains(key, "#") {
+				references = append(references, key)
+			}
+		}
+		for _, reference := range references {
+			compiler.RemoveFromInfoCache(reference)
+		}
+	}
+	results := make([]*Result, 0)
+	for _, source := range w.sources {
+		dependencies, ok := w.dependencies[source]
+		affected := !ok
+		for _, name := range dependencies {
+			affected = affected || changed[name]
+		}
+		if affected {
+			results = append(results, w.compile(source))
+		}
+	}
+	return results
+}
+
+// Compile a source and record the files that it depends on.
+func (w *Watcher) compile(source string) *Result {
+	state := statFile(source)
+	bytes, err := compiler.ReadBytesForFile(source)
+	if err != nil {
+		w.dependencies[source] = []string{source}
+		w.files[source] = state
+		if w.run != nil {
+			// The command reports the error.
+			_, err = w.run(source)
+		}
+		return &Result{Name: source, SourceFormat: SourceFormatUnknown, Err: err}
+	}
+	var result *Result
+	if w.run != nil {
+		result = &Result{Name: source}
+		result.SourceFormat, result.Err = w.run(source)
+	} else {
+		result = compileInput(Input{Name: source, Bytes: bytes}, nil)
+	}
+	dependencies := []string{source}
+	// Files that can't be read are watched until they can be. Commands
+	// report them when they need them.
+	references, err := referencedFiles(context.Background(), source, bytes, w.options)
+	if err != nil && result.Err == nil && w.run == nil {
+		result.Err = err
+	}
+	dependencies = append(dependencies, references...)
+	w.dependencies[source] = dependencies
+	w.files[source] = state
+	for _, name := range dependencies[1:] {
+		if _, ok := w.files[name]; !ok {
+			w.files[name] = statFile(name)
+		}
+	}
+	return result
+}
+
+// watch compiles API descriptions whenever they change and writes their
+// outputs. It implements the "watch" command.
+func (g *Gnostic) watch() error {
+	g.usage = watchUsage
+	interval := time.Second
+	sources := make([]string, 0)
+	args := []string{g.args[0]}
+	for _, arg := range g.args[2:] {
+		switch {
+		case arg == "--help":
+			fmt.Printf("%s", watchUsage)
+			return nil
+		case strings.HasPrefix(arg, "--interval="):
+			var err error
+			interval, err = time.ParseDuration(strings.TrimPrefix(arg, "--interval="))
+			if err != nil {
+				return NewUsageError(err.Error())
+			}
+		case strings.HasPrefix(arg, "-"):
+			args = append(args, arg)
+		default:
+			sources = append(sources, arg)
+		}
+	}
+	if len(sources) == 0 {
+		return NewUsageError("no input specified")
+	}
+	w, err := newCommandWatcher(sources, args)
+	if err != nil {
+		return err
+	}
+	for {
+		// Errors are written by the compilations, like the errors of the
+		// gnostic command.
+		for _, result := range w.Update() {
+			if result.Err == nil {
+				fmt.Printf("Compiled %s\n", result.Name)
+			}
+		}
+		time.Sleep(interval)
+	}
+}
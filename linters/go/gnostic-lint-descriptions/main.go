@@ -34,7 +34,9 @@ type DocumentLinter interface {
 
 // This is the main function for the plugin.
 func main() {
-	env, err := plugins.NewEnvironment()
+	env, err := plugins.NewEnvironmentWithRequirements(&plugins.Requirements{
+		Models: []string{plugins.ModelOpenAPIv2, plugins.ModelOpenAPIv3},
+	})
 	env.RespondAndExitIfError(err)
 
 	var linter DocumentLinter
@@ -50,7 +50,9 @@ func checkPathsV3(document *openapiv3.Document, messages []*plugins.Message) []*
 }
 
 func main() {
-	env, err := plugins.NewEnvironment()
+	env, err := plugins.NewEnvironmentWithRequirements(&plugins.Requirements{
+		Models: []string{plugins.ModelOpenAPIv2, plugins.ModelOpenAPIv3},
+	})
 	env.RespondAndExitIfError(err)
 
 	messages := make([]*plugins.Message, 0, 0)
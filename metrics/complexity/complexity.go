@@ -0,0 +1,101 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package complexity computes simple complexity metrics of API descriptions.
+package complexity
+
+import (
+	metrics "github.com/okkoye/gnostic/metrics"
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// NewComplexityFromOpenAPIv2 measures the complexity of an OpenAPI v2 description.
+func NewComplexityFromOpenAPIv2(document *openapiv2.Document) *metrics.Complexity {
+	summary := &metrics.Complexity{}
+
+	if document.Definitions != nil && document.Definitions.AdditionalProperties != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			analyzeSchemaV2(summary, pair.Value)
+		}
+	}
+
+	for _, pair := range document.GetPaths().GetPath() {
+		summary.PathCount++
+		v := pair.Value
+		if v.Get != nil {
+			summary.GetCount++
+		}
+		if v.Post != nil {
+			summary.PostCount++
+		}
+		if v.Put != nil {
+			summary.PutCount++
+		}
+		if v.Delete != nil {
+			summary.DeleteCount++
+		}
+	}
+	return summary
+}
+
+func analyzeSchemaV2(summary *metrics.Complexity, schema *openapiv2.Schema) {
+	summary.SchemaCount++
+	if schema.Properties != nil {
+		for _, pair := range schema.Properties.AdditionalProperties {
+			summary.SchemaPropertyCount++
+			analyzeSchemaV2(summary, pair.Value)
+		}
+	}
+}
+
+// NewComplexityFromOpenAPIv3 measures the complexity of an OpenAPI v3 description.
+func NewComplexityFromOpenAPIv3(document *openapiv3.Document) *metrics.Complexity {
+	summary := &metrics.Complexity{}
+
+	if document.Components != nil && document.Components.Schemas != nil {
+		for _, pair := range document.Components.Schemas.AdditionalProperties {
+			analyzeSchemaV3(summary, pair.Value)
+		}
+	}
+
+	for _, pair := range document.GetPaths().GetPath() {
+		summary.PathCount++
+		v := pair.Value
+		if v.Get != nil {
+			summary.GetCount++
+		}
+		if v.Post != nil {
+			summary.PostCount++
+		}
+		if v.Put != nil {
+			summary.PutCount++
+		}
+		if v.Delete != nil {
+			summary.DeleteCount++
+		}
+	}
+	return summary
+}
+
+func analyzeSchemaV3(summary *metrics.Complexity, schemaOrReference *openapiv3.SchemaOrReference) {
+	summary.SchemaCount++
+	schema := schemaOrReference.GetSchema()
+	if schema != nil && schema.Properties != nil {
+		for _, pair := range schema.Properties.AdditionalProperties {
+			summary.SchemaPropertyCount++
+			analyzeSchemaV3(summary, pair.Value)
+		}
+	}
+}
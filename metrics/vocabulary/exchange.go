@@ -0,0 +1,166 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vocabulary
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	metrics "github.com/okkoye/gnostic/metrics"
+)
+
+// Kinds of words in a vocabulary, as they are named in exported files.
+const (
+	KindSchemas    = "schemas"
+	KindProperties = "properties"
+	KindOperations = "operations"
+	KindParameters = "parameters"
+)
+
+// An Entry is a word of a vocabulary in the form used for exchange with
+// spreadsheets and other tools.
+type Entry struct {
+	Word  string `json:"word"`
+	Kind  string `json:"kind"`
+	Count int    `json:"count"`
+}
+
+// exchangeFile is the JSON representation of a vocabulary.
+type exchangeFile struct {
+	Name  string   `json:"name,omitempty"`
+	Words []*Entry `json:"words"`
+}
+
+// Entries returns the words of a vocabulary as a list of entries, grouped by kind.
+func Entries(v *metrics.Vocabulary) []*Entry {
+	entries := make([]*Entry, 0)
+	for _, group := range []struct {
+		kind  string
+		words []*metrics.WordCount
+	}{
+		{KindSchemas, v.Schemas},
+		{KindProperties, v.Properties},
+		{KindOperations, v.Operations},
+		{KindParameters, v.Parameters},
+	} {
+		for _, w := range group.words {
+			entries = append(entries, &Entry{Word: w.Word, Kind: group.kind, Count: int(w.Count)})
+		}
+	}
+	return entries
+}
+
+// FromEntries builds a vocabulary from a list of entries. Counts of
+// repeated words are added together.
+func FromEntries(name string, entries []*Entry) (*metrics.Vocabulary, error) {
+	var vocab Vocabulary
+	vocab.schemas = make(map[string]int)
+	vocab.operationID = make(map[string]int)
+	vocab.parameters = make(map[string]int)
+	vocab.properties = make(map[string]int)
+	for _, e := range entries {
+		switch e.Kind {
+		case KindSchemas:
+			vocab.schemas[e.Word] += e.Count
+		case KindProperties:
+			vocab.properties[e.Word] += e.Count
+		case KindOperations:
+			vocab.operationID[e.Word] += e.Count
+		case KindParameters:
+			vocab.parameters[e.Word] += e.Count
+		default:
+			return nil, fmt.Errorf("unknown kind %q for word %q", e.Kind, e.Word)
+		}
+	}
+	return &metrics.Vocabulary{
+		Name:       name,
+		Properties: fillProtoStructure(vocab.properties),
+		Schemas:    fillProtoStructure(vocab.schemas),
+		Operations: fillProtoStructure(vocab.operationID),
+		Parameters: fillProtoStructure(vocab.parameters),
+	}, nil
+}
+
+// csvHeader is the first row of exported CSV files.
+var csvHeader = []string{"kind", "word", "count"}
+
+// MarshalCSV returns a CSV representation of a vocabulary with one row
+// for each word. The first row is a header naming the columns.
+func MarshalCSV(v *metrics.Vocabulary) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, e := range Entries(v) {
+		if err := w.Write([]string{e.Kind, e.Word, strconv.Itoa(e.Count)}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
+
+// UnmarshalCSV reads a vocabulary from a CSV representation like the ones
+// written by MarshalCSV and WriteCSV. The header row is optional.
+func UnmarshalCSV(b []byte) (*metrics.Vocabulary, error) {
+	r := csv.NewReader(bytes.NewReader(b))
+	r.FieldsPerRecord = len(csvHeader)
+	r.TrimLeadingSpace = true
+	entries := make([]*Entry, 0)
+	for row := 1; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row == 1 && strings.EqualFold(record[0], csvHeader[0]) {
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(record[2]))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid count %q", row, record[2])
+		}
+		entries = append(entries, &Entry{Kind: record[0], Word: record[1], Count: count})
+	}
+	return FromEntries("", entries)
+}
+
+// MarshalJSON returns a JSON representation of a vocabulary as an object
+// with the vocabulary name and a list of words.
+func MarshalJSON(v *metrics.Vocabulary) ([]byte, error) {
+	return json.MarshalIndent(&exchangeFile{Name: v.Name, Words: Entries(v)}, "", "  ")
+}
+
+// UnmarshalJSON reads a vocabulary from a JSON representation like the
+// ones written by MarshalJSON. A bare list of words is also accepted.
+func UnmarshalJSON(b []byte) (*metrics.Vocabulary, error) {
+	var file exchangeFile
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &file.Words); err != nil {
+			return nil, err
+		}
+	} else if err := json.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+	return FromEntries(file.Name, file.Words)
+}
@@ -306,3 +306,52 @@ func TestSampleVocabularyDiscovery(t *testing.T) {
 		&reference,
 	)
 }
+
+func TestVocabularyExchange(t *testing.T) {
+	v := &metrics.Vocabulary{
+		Name:       "sample",
+		Schemas:    fillTestProtoStructure([]string{"Pet", "Pet, Store"}, []int{2, 1}),
+		Properties: fillTestProtoStructure([]string{"name", "\"quoted\""}, []int{4, 3}),
+		Operations: fillTestProtoStructure([]string{"listPets"}, []int{1}),
+		Parameters: fillTestProtoStructure([]string{"limit"}, []int{5}),
+	}
+	csvBytes, err := MarshalCSV(v)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	fromCSV, err := UnmarshalCSV(csvBytes)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	jsonBytes, err := MarshalJSON(v)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	fromJSON, err := UnmarshalJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if fromJSON.Name != "sample" {
+		t.Errorf("unexpected name: %s", fromJSON.Name)
+	}
+	for _, result := range []*metrics.Vocabulary{fromCSV, fromJSON} {
+		if !isEmpty(Difference([]*metrics.Vocabulary{v, result})) || !isEmpty(Difference([]*metrics.Vocabulary{result, v})) {
+			t.Errorf("vocabulary changed in exchange: %+v", result)
+		}
+		if len(result.Properties) != 2 || result.Properties[0].Word != "\"quoted\"" || result.Properties[0].Count != 3 {
+			t.Errorf("unexpected properties: %+v", result.Properties)
+		}
+	}
+
+	// Files written by WriteCSV have no header and can be read too, and repeated words are combined.
+	legacy, err := UnmarshalCSV([]byte("schemas,\"Pet\",1\nschemas,\"Pet\",2\nparameters,\"id\",1\n"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(legacy.Schemas) != 1 || legacy.Schemas[0].Count != 3 || len(legacy.Parameters) != 1 {
+		t.Errorf("unexpected vocabulary: %+v", legacy)
+	}
+	if _, err := UnmarshalCSV([]byte("verbs,run,1\n")); err == nil {
+		t.Errorf("expected an error for an unknown kind")
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mock generates sample values from the schemas of OpenAPI v3
+// descriptions for use in mock servers, tests, and demonstrations.
+package mock
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// A Generator generates sample values for schemas. Generators that are
+// created with the same seed generate the same values for the same
+// sequence of calls.
+type Generator struct {
+	// MaxDepth limits the nesting of generated values. Below it, objects
+	// contain only required properties and arrays are empty.
+	MaxDepth int
+	// ForRequests generates values for request bodies, which omit readOnly
+	// properties. Otherwise writeOnly properties are omitted.
+	ForRequests bool
+	// UseExamples uses examples and defaults from schemas when they are available.
+	UseExamples bool
+
+	document *openapi_v3.Document
+	random   *rand.Rand
+}
+
+// NewGenerator creates a generator for the schemas of a document.
+func NewGenerator(document *openapi_v3.Document, seed int64) *Generator {
+	return &Generator{
+		MaxDepth:    5,
+		UseExamples: true,
+		document:    document,
+		random:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// GenerateNamed generates a value for a schema in the components of the document.
+func (g *Generator) GenerateNamed(name string) (*yaml.Node, error) {
+	for _, named := range g.document.GetComponents().GetSchemas().GetAdditionalProperties() {
+		if named.Name == name {
+			return g.Generate(named.Value), nil
+		}
+	}
+	return nil, fmt.Errorf("no schema named %s", name)
+}
+
+// Generate generates a value for a schema.
+func (g *Generator) Generate(schema *openapi_v3.SchemaOrReference) *yaml.Node {
+	return g.generate(schema, "", 0)
+}
+
+// resolve follows references to schemas in the components of the document.
+func (g *Generator) resolve(v *openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	for depth := 0; v != nil && depth < 32; depth++ {
+		if schema := v.GetSchema(); schema != nil {
+			return schema
+		}
+		name := strings.TrimPrefix(v.GetReference().GetXRef(), "#/components/schemas/")
+		v = nil
+		for _, named := range g.document.GetComponents().GetSchemas().GetAdditionalProperties() {
+			if named.Name == name {
+				v = named.Value
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// generate generates a value for a schema. name is the name of the property
+// that holds the value, if there is one, and is used to choose realistic strings.
+func (g *Generator) generate(v *openapi_v3.SchemaOrReference, name string, depth int) *yaml.Node {
+	schema := g.resolve(v)
+	if schema == nil {
+		return compiler.NewNullNode()
+	}
+	if g.UseExamples {
+		if schema.Example != nil {
+			return schema.Example.ToRawInfo()
+		}
+		if schema.Default != nil {
+			return schema.Default.ToRawInfo()
+		}
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[g.random.Intn(len(schema.Enum))].ToRawInfo()
+	}
+	if len(schema.AllOf) > 0 {
+		return g.generateAllOf(schema, name, depth)
+	}
+	if alternatives := append(schema.OneOf, schema.AnyOf...); len(alternatives) > 0 {
+		return g.generate(alternatives[g.random.Intn(len(alternatives))], name, depth)
+	}
+	switch schemaType(schema) {
+	case "boolean":
+		return compiler.NewScalarNodeForBool(g.random.Intn(2) == 1)
+	case "integer":
+		return compiler.NewScalarNodeForInt(g.integer(schema))
+	case "number":
+		value := strconv.FormatFloat(g.number(schema), 'f', -1, 64)
+		if !strings.Contains(value, ".") {
+			value += ".0"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: value}
+	case "string":
+		return compiler.NewScalarNodeForString(g.string(schema, name))
+	case "array":
+		return g.array(schema, name, depth)
+	default:
+		return g.object(schema, depth)
+	}
+}
+
+// schemaType returns the type of a schema, inferring it from other keywords if it isn't specified.
+func schemaType(schema *openapi_v3.Schema) string {
+	switch {
+	case schema.Type != "":
+		return schema.Type
+	case schema.Items != nil:
+		return "array"
+	case schema.Properties != nil || schema.AdditionalProperties != nil:
+		return "object"
+	case schema.Format != "" || schema.Pattern != "" || schema.MinLength > 0 || schema.MaxLength > 0:
+		return "string"
+	}
+	return "object"
+}
+
+// generateAllOf generates a value for each schema of an allOf and merges them.
+func (g *Generator) generateAllOf(schema *openapi_v3.Schema, name string, depth int) *yaml.Node {
+	var merged *yaml.Node
+	for _, s := range schema.AllOf {
+		value := g.generate(s, name, depth)
+		if merged == nil || merged.Kind != yaml.MappingNode || value.Kind != yaml.MappingNode {
+			merged = value
+			continue
+		}
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			if compiler.MapValueForKey(merged, value.Content[i].Value) == nil {
+				merged.Content = append(merged.Content, value.Content[i], value.Content[i+1])
+			}
+		}
+	}
+	// Properties defined alongside allOf are generated too.
+	if schema.Properties != nil && merged != nil && merged.Kind == yaml.MappingNode {
+		object := g.object(schema, depth)
+		for i := 0; i+1 < len(object.Content); i += 2 {
+			if compiler.MapValueForKey(merged, object.Content[i].Value) == nil {
+				merged.Content = append(merged.Content, object.Content[i], object.Content[i+1])
+			}
+		}
+	}
+	return merged
+}
+
+func (g *Generator) object(schema *openapi_v3.Schema, depth int) *yaml.Node {
+	object := compiler.NewMappingNode()
+	required := make(map[string]bool)
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	for _, property := range schema.GetProperties().GetAdditionalProperties() {
+		if depth >= g.MaxDepth && !required[property.Name] {
+			continue
+		}
+		if s := g.resolve(property.Value); s != nil && ((g.ForRequests && s.ReadOnly) || (!g.ForRequests && s.WriteOnly)) {
+			continue
+		}
+		object.Content = append(object.Content,
+			compiler.NewScalarNodeForString(property.Name),
+			g.generate(property.Value, property.Name, depth+1))
+	}
+	if additional := schema.AdditionalProperties.GetSchemaOrReference(); additional != nil &&
+		len(object.Content) == 0 && depth < g.MaxDepth {
+		object.Content = append(object.Content,
+			compiler.NewScalarNodeForString(g.word()),
+			g.generate(additional, "", depth+1))
+	}
+	return object
+}
+
+func (g *Generator) array(schema *openapi_v3.Schema, name string, depth int) *yaml.Node {
+	array := compiler.NewSequenceNode()
+	if schema.Items == nil || len(schema.Items.SchemaOrReference) == 0 {
+		return array
+	}
+	minimum, maximum := int(schema.MinItems), int(schema.MaxItems)
+	if maximum == 0 {
+		maximum = minimum + 2
+		if maximum < 1 {
+			maximum = 1
+		}
+	}
+	if depth >= g.MaxDepth {
+		maximum = minimum
+	}
+	count := minimum
+	if maximum > minimum {
+		count += g.random.Intn(maximum - minimum + 1)
+	}
+	seen := make(map[string]bool)
+	for attempts := 0; len(array.Content) < count && attempts < 10*count+10; attempts++ {
+		item := g.generate(schema.Items.SchemaOrReference[0], name, depth+1)
+		if schema.UniqueItems {
+			key := string(compiler.Marshal(item))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		array.Content = append(array.Content, item)
+	}
+	return array
+}
+
+// bounds returns the range of numbers allowed by a schema, using a default
+// range for missing limits.
+func bounds(schema *openapi_v3.Schema) (float64, float64) {
+	minimum, maximum := 0.0, 1000.0
+	hasMinimum := schema.Minimum != 0 || schema.ExclusiveMinimum
+	hasMaximum := schema.Maximum != 0 || schema.ExclusiveMaximum
+	if hasMinimum {
+		minimum = schema.Minimum
+		if !hasMaximum {
+			maximum = minimum + 1000
+		}
+	}
+	if hasMaximum {
+		maximum = schema.Maximum
+		if !hasMinimum && maximum < minimum {
+			minimum = maximum - 1000
+		}
+	}
+	return minimum, maximum
+}
+
+func (g *Generator) integer(schema *openapi_v3.Schema) int64 {
+	minimum, maximum := bounds(schema)
+	low, high := int64(math.Ceil(minimum)), int64(math.Floor(maximum))
+	if schema.ExclusiveMinimum && float64(low) == minimum {
+		low++
+	}
+	if schema.ExclusiveMaximum && float64(high) == maximum {
+		high--
+	}
+	if high < low {
+		return low
+	}
+	value := low + g.random.Int63n(high-low+1)
+	if step := int64(schema.MultipleOf); step > 0 && float64(step) == schema.MultipleOf {
+		value = value / step * step
+		if value < low {
+			value += step
+		}
+	}
+	return value
+}
+
+func (g *Generator) number(schema *openapi_v3.Schema) float64 {
+	minimum, maximum := bounds(schema)
+	value := minimum + g.random.Float64()*(maximum-minimum)
+	if schema.MultipleOf > 0 {
+		value = math.Ceil(value/schema.MultipleOf) * schema.MultipleOf
+		if value > maximum {
+			value -= schema.MultipleOf
+		}
+	}
+	// Round to two decimal places to keep samples readable.
+	rounded := math.Round(value*100) / 100
+	if rounded >= minimum && rounded <= maximum && (schema.MultipleOf == 0 || rounded == value) {
+		value = rounded
+	}
+	return value
+}
+
+// baseTime is the earliest time used for generated dates.
+var baseTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (g *Generator) string(schema *openapi_v3.Schema, name string) string {
+	var s string
+	switch schema.Format {
+	case "date-time":
+		return baseTime.Add(time.Duration(g.random.Int63n(3*365*24)) * time.Hour).Format(time.RFC3339)
+	case "date":
+		return baseTime.AddDate(0, 0, g.random.Intn(3*365)).Format("2006-01-02")
+	case "time":
+		return fmt.Sprintf("%02d:%02d:%02d", g.random.Intn(24), g.random.Intn(60), g.random.Intn(60))
+	case "uuid":
+		b := make([]byte, 16)
+		g.random.Read(b)
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	case "email":
+		return g.pick(firstNames) + "." + g.pick(lastNames) + "@example.com"
+	case "uri", "url":
+		return "https://example.com/" + g.word()
+	case "hostname":
+		return g.word() + ".example.com"
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", 1+g.random.Intn(254))
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+g.random.Intn(0xfffe))
+	case "byte":
+		return base64.StdEncoding.EncodeToString([]byte(g.word()))
+	case "password":
+		s = g.pattern(`[A-Za-z0-9]{12}`)
+	}
+	if s == "" && schema.Pattern != "" {
+		s = g.pattern(schema.Pattern)
+	}
+	if s == "" {
+		s = g.text(name)
+	}
+	return fitLength(s, int(schema.MinLength), int(schema.MaxLength))
+}
+
+// fitLength pads or truncates a string to satisfy length limits.
+func fitLength(s string, minimum int, maximum int) string {
+	runes := []rune(s)
+	for len(runes) < minimum {
+		runes = append(runes, 'x')
+	}
+	if maximum > 0 && len(runes) > maximum {
+		runes = runes[:maximum]
+	}
+	return string(runes)
+}
+
+func (g *Generator) pick(values []string) string {
+	return values[g.random.Intn(len(values))]
+}
+
+func (g *Generator) word() string {
+	return g.pick(words)
+}
+
+// text chooses a realistic string for a property based on its name.
+func (g *Generator) text(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case lower == "id" || strings.HasSuffix(lower, "id"):
+		return strconv.Itoa(1000 + g.random.Intn(9000))
+	case strings.Contains(lower, "firstname") || lower == "givenname":
+		return g.pick(firstNames)
+	case strings.Contains(lower, "lastname") || lower == "surname" || lower == "familyname":
+		return g.pick(lastNames)
+	case strings.Contains(lower, "name"):
+		return g.pick(firstNames) + " " + g.pick(lastNames)
+	case strings.Contains(lower, "email"):
+		return strings.ToLower(g.pick(firstNames)) + "@example.com"
+	case strings.Contains(lower, "city"):
+		return g.pick(cities)
+	case strings.Contains(lower, "phone"):
+		return fmt.Sprintf("+1-555-%04d", g.random.Intn(10000))
+	case strings.Contains(lower, "url") || strings.Contains(lower, "uri") || strings.Contains(lower, "link"):
+		return "https://example.com/" + g.word()
+	case strings.Contains(lower, "description") || strings.Contains(lower, "summary") ||
+		strings.Contains(lower, "message") || strings.Contains(lower, "comment"):
+		n := 4 + g.random.Intn(6)
+		sentence := make([]string, n)
+		for i := range sentence {
+			sentence[i] = g.word()
+		}
+		return strings.ToUpper(sentence[0][:1]) + strings.Join(sentence, " ")[1:] + "."
+	}
+	return g.word()
+}
+
+var firstNames = []string{"Ada", "Alan", "Barbara", "Dennis", "Edsger", "Frances", "Grace", "John", "Katherine", "Ken", "Linus", "Margaret", "Niklaus", "Radia"}
+
+var lastNames = []string{"Allen", "Dijkstra", "Hamilton", "Hopper", "Johnson", "Kernighan", "Liskov", "Lovelace", "McCarthy", "Perlman", "Ritchie", "Thompson", "Turing", "Wirth"}
+
+var cities = []string{"Amsterdam", "Austin", "Bangalore", "Berlin", "Lagos", "London", "Mountain View", "Nairobi", "Paris", "São Paulo", "Seoul", "Sydney", "Tokyo", "Toronto"}
+
+var words = []string{"alpha", "amber", "anchor", "aurora", "basil", "beacon", "birch", "canyon", "cedar", "cobalt", "comet", "delta", "ember", "falcon", "fern", "garnet", "glacier", "harbor", "indigo", "juniper", "lagoon", "maple", "meadow", "nova", "onyx", "orchid", "pebble", "quartz", "river", "saffron", "summit", "tundra", "violet", "willow", "zephyr"}
@@ -0,0 +1,186 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const testDocument = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+          minimum: 10
+          maximum: 20
+          readOnly: true
+        name:
+          type: string
+          maxLength: 8
+        code:
+          type: string
+          pattern: '^[A-Z]{3}-\d{4}$'
+        born:
+          type: string
+          format: date-time
+        kind:
+          type: string
+          enum: [cat, dog]
+        weight:
+          type: number
+          minimum: 1
+          maximum: 2
+          multipleOf: 0.25
+        tags:
+          type: array
+          minItems: 2
+          maxItems: 2
+          items:
+            type: string
+        owner:
+          $ref: '#/components/schemas/Owner'
+        secret:
+          type: string
+          writeOnly: true
+    Owner:
+      allOf:
+        - type: object
+          properties:
+            email:
+              type: string
+              format: email
+        - type: object
+          properties:
+            pets:
+              type: array
+              items:
+                $ref: '#/components/schemas/Pet'
+    Status:
+      type: string
+      example: available
+`
+
+func testDocumentModel(t *testing.T) *openapi_v3.Document {
+	document, err := openapi_v3.ParseDocument([]byte(testDocument))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return document
+}
+
+func generate(t *testing.T, g *Generator, name string) *yaml.Node {
+	value, err := g.GenerateNamed(name)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return value
+}
+
+func TestConstraints(t *testing.T) {
+	document := testDocumentModel(t)
+	g := NewGenerator(document, 1)
+	for i := 0; i < 20; i++ {
+		pet := generate(t, g, "Pet")
+		id, _ := strconv.Atoi(compiler.MapValueForKey(pet, "id").Value)
+		if id < 10 || id > 20 {
+			t.Errorf("id %d is out of range", id)
+		}
+		if name := compiler.MapValueForKey(pet, "name").Value; len(name) > 8 {
+			t.Errorf("name %q is too long", name)
+		}
+		if code := compiler.MapValueForKey(pet, "code").Value; !regexp.MustCompile(`^[A-Z]{3}-\d{4}$`).MatchString(code) {
+			t.Errorf("code %q doesn't match its pattern", code)
+		}
+		if born := compiler.MapValueForKey(pet, "born").Value; born != "" {
+			if _, err := time.Parse(time.RFC3339, born); err != nil {
+				t.Errorf("born %q is not a date-time", born)
+			}
+		}
+		if kind := compiler.MapValueForKey(pet, "kind").Value; kind != "cat" && kind != "dog" {
+			t.Errorf("kind %q is not in its enum", kind)
+		}
+		weight, _ := strconv.ParseFloat(compiler.MapValueForKey(pet, "weight").Value, 64)
+		if weight < 1 || weight > 2 || weight*4 != float64(int(weight*4)) {
+			t.Errorf("weight %f doesn't satisfy its constraints", weight)
+		}
+		if tags := compiler.MapValueForKey(pet, "tags"); len(tags.Content) != 2 {
+			t.Errorf("expected 2 tags, got %d", len(tags.Content))
+		}
+		if compiler.MapValueForKey(pet, "secret") != nil {
+			t.Errorf("writeOnly property was generated for a response")
+		}
+		owner := compiler.MapValueForKey(pet, "owner")
+		if compiler.MapValueForKey(owner, "email") == nil || compiler.MapValueForKey(owner, "pets") == nil {
+			t.Errorf("allOf properties were not merged: %s", compiler.Marshal(owner))
+		}
+	}
+	if status := generate(t, g, "Status"); status.Value != "available" {
+		t.Errorf("expected the example value, got %q", status.Value)
+	}
+}
+
+func TestRequests(t *testing.T) {
+	g := NewGenerator(testDocumentModel(t), 1)
+	g.ForRequests = true
+	pet := generate(t, g, "Pet")
+	if compiler.MapValueForKey(pet, "id") != nil {
+		t.Errorf("readOnly property was generated for a request")
+	}
+	if compiler.MapValueForKey(pet, "secret") == nil {
+		t.Errorf("writeOnly property was not generated for a request")
+	}
+}
+
+func TestDepth(t *testing.T) {
+	g := NewGenerator(testDocumentModel(t), 1)
+	g.MaxDepth = 1
+	pet := generate(t, g, "Pet")
+	owner := compiler.MapValueForKey(pet, "owner")
+	if owner == nil {
+		t.Fatalf("expected an owner")
+	}
+	if len(owner.Content) != 0 {
+		t.Errorf("expected an empty owner below the maximum depth, got %s", compiler.Marshal(owner))
+	}
+}
+
+func TestSeeding(t *testing.T) {
+	document := testDocumentModel(t)
+	a := string(compiler.Marshal(generate(t, NewGenerator(document, 42), "Pet")))
+	b := string(compiler.Marshal(generate(t, NewGenerator(document, 42), "Pet")))
+	c := string(compiler.Marshal(generate(t, NewGenerator(document, 43), "Pet")))
+	if a != b {
+		t.Errorf("generators with the same seed produced different values:\n%s\n%s", a, b)
+	}
+	if a == c {
+		t.Errorf("generators with different seeds produced the same value")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"regexp/syntax"
+	"strings"
+)
+
+// maxRepeat limits the repetitions generated for unbounded quantifiers like * and +.
+const maxRepeat = 4
+
+// pattern generates a string that matches a regular expression. It returns
+// an empty string if the expression can't be parsed.
+func (g *Generator) pattern(expression string) string {
+	re, err := syntax.Parse(expression, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	g.regexp(&b, re.Simplify())
+	return b.String()
+}
+
+func (g *Generator) regexp(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		b.WriteRune(g.charClass(re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		b.WriteRune(rune('a' + g.random.Intn(26)))
+	case syntax.OpCapture:
+		g.regexp(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.regexp(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.regexp(b, re.Sub[g.random.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		minimum, maximum := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			minimum, maximum = 0, maxRepeat
+		case syntax.OpPlus:
+			minimum, maximum = 1, maxRepeat
+		case syntax.OpQuest:
+			minimum, maximum = 0, 1
+		}
+		if maximum < 0 {
+			maximum = minimum + maxRepeat
+		}
+		count := minimum + g.random.Intn(maximum-minimum+1)
+		for i := 0; i < count; i++ {
+			g.regexp(b, re.Sub[0])
+		}
+	}
+	// Anchors, word boundaries, and empty matches generate nothing.
+}
+
+// charClass chooses a rune from a character class, given as pairs of inclusive ranges.
+// Printable ASCII runes are preferred when the class contains any.
+func (g *Generator) charClass(ranges []rune) rune {
+	printable := make([]rune, 0, len(ranges))
+	for i := 0; i+1 < len(ranges); i += 2 {
+		low, high := ranges[i], ranges[i+1]
+		if low < ' ' {
+			low = ' '
+		}
+		if high > '~' {
+			high = '~'
+		}
+		if low <= high {
+			printable = append(printable, low, high)
+		}
+	}
+	if len(printable) > 0 {
+		ranges = printable
+	}
+	if len(ranges) < 2 {
+		return 'x'
+	}
+	var total int
+	for i := 0; i+1 < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+	n := g.random.Intn(total)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if n < size {
+			return ranges[i] + rune(n)
+		}
+		n -= size
+	}
+	return ranges[0]
+}
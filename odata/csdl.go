@@ -0,0 +1,173 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package odata provides a model of OData CSDL metadata documents and
+// conversions of them to OpenAPI descriptions.
+package odata
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+)
+
+// Metadata is an OData service metadata document in the XML
+// representation of the Common Schema Definition Language (CSDL).
+// http://docs.oasis-open.org/odata/odata-csdl-xml/v4.01/odata-csdl-xml-v4.01.html
+type Metadata struct {
+	XMLName xml.Name  `xml:"Edmx"`
+	Version string    `xml:"Version,attr"`
+	Schemas []*Schema `xml:"DataServices>Schema"`
+}
+
+// A Schema is a namespace of model elements.
+type Schema struct {
+	Namespace       string             `xml:"Namespace,attr"`
+	Alias           string             `xml:"Alias,attr"`
+	EntityTypes     []*StructuredType  `xml:"EntityType"`
+	ComplexTypes    []*StructuredType  `xml:"ComplexType"`
+	EnumTypes       []*EnumType        `xml:"EnumType"`
+	TypeDefinitions []*TypeDefinition  `xml:"TypeDefinition"`
+	Functions       []*Operation       `xml:"Function"`
+	Actions         []*Operation       `xml:"Action"`
+	EntityContainer *EntityContainer   `xml:"EntityContainer"`
+	Annotations     []*Annotation      `xml:"Annotation"`
+	Targets         []*AnnotationGroup `xml:"Annotations"`
+}
+
+// A StructuredType is an entity type or a complex type.
+type StructuredType struct {
+	Name                 string         `xml:"Name,attr"`
+	BaseType             string         `xml:"BaseType,attr"`
+	Abstract             bool           `xml:"Abstract,attr"`
+	OpenType             bool           `xml:"OpenType,attr"`
+	Key                  []*PropertyRef `xml:"Key>PropertyRef"`
+	Properties           []*Property    `xml:"Property"`
+	NavigationProperties []*Property    `xml:"NavigationProperty"`
+	Annotations          []*Annotation  `xml:"Annotation"`
+}
+
+// A PropertyRef names a key property.
+type PropertyRef struct {
+	Name string `xml:"Name,attr"`
+}
+
+// A Property is a structural or navigation property. Its type is a
+// qualified type name or Collection(name).
+type Property struct {
+	Name        string        `xml:"Name,attr"`
+	Type        string        `xml:"Type,attr"`
+	Nullable    string        `xml:"Nullable,attr"`
+	MaxLength   string        `xml:"MaxLength,attr"`
+	Annotations []*Annotation `xml:"Annotation"`
+}
+
+// IsNullable returns true if the value of a property can be null.
+func (p *Property) IsNullable() bool {
+	return p.Nullable != "false"
+}
+
+// An EnumType is a named set of values.
+type EnumType struct {
+	Name        string        `xml:"Name,attr"`
+	IsFlags     bool          `xml:"IsFlags,attr"`
+	Members     []*Member     `xml:"Member"`
+	Annotations []*Annotation `xml:"Annotation"`
+}
+
+// A Member is a value of an enum type.
+type Member struct {
+	Name  string `xml:"Name,attr"`
+	Value string `xml:"Value,attr"`
+}
+
+// A TypeDefinition names a primitive type.
+type TypeDefinition struct {
+	Name           string        `xml:"Name,attr"`
+	UnderlyingType string        `xml:"UnderlyingType,attr"`
+	Annotations    []*Annotation `xml:"Annotation"`
+}
+
+// An Operation is a function (without side effects) or an action.
+type Operation struct {
+	Name        string        `xml:"Name,attr"`
+	IsBound     bool          `xml:"IsBound,attr"`
+	Parameters  []*Property   `xml:"Parameter"`
+	ReturnType  *Property     `xml:"ReturnType"`
+	Annotations []*Annotation `xml:"Annotation"`
+}
+
+// An EntityContainer holds the resources that a service exposes.
+type EntityContainer struct {
+	Name            string             `xml:"Name,attr"`
+	EntitySets      []*EntitySet       `xml:"EntitySet"`
+	Singletons      []*EntitySet       `xml:"Singleton"`
+	FunctionImports []*OperationImport `xml:"FunctionImport"`
+	ActionImports   []*OperationImport `xml:"ActionImport"`
+	Annotations     []*Annotation      `xml:"Annotation"`
+}
+
+// An EntitySet is a collection of entities; a Singleton is a single entity.
+type EntitySet struct {
+	Name        string        `xml:"Name,attr"`
+	EntityType  string        `xml:"EntityType,attr"`
+	Type        string        `xml:"Type,attr"` // for singletons
+	Annotations []*Annotation `xml:"Annotation"`
+}
+
+// An OperationImport exposes an unbound function or action.
+type OperationImport struct {
+	Name        string        `xml:"Name,attr"`
+	Function    string        `xml:"Function,attr"`
+	Action      string        `xml:"Action,attr"`
+	Annotations []*Annotation `xml:"Annotation"`
+}
+
+// An Annotation applies a term to a model element.
+type Annotation struct {
+	Term   string `xml:"Term,attr"`
+	String string `xml:"String,attr"`
+	Value  string `xml:"String"`
+}
+
+// An AnnotationGroup applies annotations to a target model element.
+type AnnotationGroup struct {
+	Target      string        `xml:"Target,attr"`
+	Annotations []*Annotation `xml:"Annotation"`
+}
+
+// ParseMetadata reads a metadata document from its XML representation.
+func ParseMetadata(b []byte) (*Metadata, error) {
+	var m Metadata
+	if err := xml.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if len(m.Schemas) == 0 {
+		return nil, errors.New("the metadata document has no schemas")
+	}
+	return &m, nil
+}
+
+// description returns the value of the Core.Description annotation in a list.
+func description(annotations []*Annotation) string {
+	for _, annotation := range annotations {
+		if annotation.Term == "Core.Description" || annotation.Term == "Org.OData.Core.V1.Description" {
+			if annotation.String != "" {
+				return annotation.String
+			}
+			return strings.TrimSpace(annotation.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package odata
+
+import (
+	"io/ioutil"
+	"testing"
+
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+func TestOpenAPIv3FromMetadata(t *testing.T) {
+	b, err := ioutil.ReadFile("../testdata/odata/trippin.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata, err := ParseMetadata(b)
+	if err != nil {
+		t.Fatalf("ParseMetadata() returned error %s", err)
+	}
+	if n := len(metadata.Schemas[0].EntityTypes); n != 4 {
+		t.Errorf("ParseMetadata() read %d entity types, want 4", n)
+	}
+	document, err := NewOpenAPIv3FromMetadata(metadata, "https://services.odata.org/TripPinRESTierService/")
+	if err != nil {
+		t.Fatalf("NewOpenAPIv3FromMetadata() returned error %s", err)
+	}
+	// The document should be valid.
+	bytes, err := document.YAMLValue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openapiv3.ParseDocument(bytes); err != nil {
+		t.Fatalf("the converted document is invalid: %s", err)
+	}
+	if document.Info.Title != "Container" {
+		t.Errorf("title is %q, want %q", document.Info.Title, "Container")
+	}
+	if url := document.Servers[0].Url; url != "https://services.odata.org/TripPinRESTierService" {
+		t.Errorf("server is %q", url)
+	}
+
+	paths := make(map[string]*openapiv3.PathItem)
+	for _, path := range document.Paths.Path {
+		paths[path.Name] = path.Value
+	}
+	for _, test := range []struct {
+		path       string
+		operations []*openapiv3.Operation
+	}{
+		{"/People", []*openapiv3.Operation{paths["/People"].GetGet(), paths["/People"].GetPost()}},
+		{"/People('{UserName}')", []*openapiv3.Operation{paths["/People('{UserName}')"].GetGet(), paths["/People('{UserName}')"].GetPatch(), paths["/People('{UserName}')"].GetDelete()}},
+		{"/Trips(PersonName='{PersonName}',TripId={TripId})", []*openapiv3.Operation{paths["/Trips(PersonName='{PersonName}',TripId={TripId})"].GetGet()}},
+		{"/Me", []*openapiv3.Operation{paths["/Me"].GetGet(), paths["/Me"].GetPatch()}},
+		{"/GetNearestAirport(lat={lat},lon={lon})", []*openapiv3.Operation{paths["/GetNearestAirport(lat={lat},lon={lon})"].GetGet()}},
+		{"/ShareTrip", []*openapiv3.Operation{paths["/ShareTrip"].GetPost()}},
+	} {
+		if paths[test.path] == nil {
+			t.Errorf("missing path %s", test.path)
+			continue
+		}
+		for i, operation := range test.operations {
+			if operation == nil {
+				t.Errorf("missing operation %d of %s", i, test.path)
+			}
+		}
+	}
+	if len(paths) != 11 {
+		t.Errorf("converted %d paths, want 11", len(paths))
+	}
+
+	schemas := make(map[string]*openapiv3.Schema)
+	for _, schema := range document.Components.Schemas.AdditionalProperties {
+		schemas[schema.Name] = schema.Value.GetSchema()
+	}
+	person := schemas["Trippin.Person"]
+	if person == nil {
+		t.Fatal("missing schema Trippin.Person")
+	}
+	if person.Description != "A traveller." {
+		t.Errorf("Trippin.Person has description %q", person.Description)
+	}
+	properties := make(map[string]*openapiv3.SchemaOrReference)
+	for _, property := range person.Properties.AdditionalProperties {
+		properties[property.Name] = property.Value
+	}
+	if s := properties["Age"].GetSchema(); s == nil || s.Type != "integer" || s.Format != "int64" || !s.Nullable {
+		t.Errorf("Age has schema %v", s)
+	}
+	if r := properties["Friends"].GetSchema().GetItems().GetSchemaOrReference()[0].GetReference(); r == nil || r.XRef != "#/components/schemas/Trippin.Person" {
+		t.Errorf("Friends has items %v", r)
+	}
+	if employee := schemas["Trippin.Employee"]; employee == nil || len(employee.AllOf) != 2 {
+		t.Errorf("Trippin.Employee should extend Trippin.Person")
+	}
+	if gender := schemas["Trippin.PersonGender"]; gender == nil || len(gender.Enum) != 3 {
+		t.Errorf("Trippin.PersonGender should have 3 values")
+	}
+}
@@ -0,0 +1,559 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package odata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// errorSchemaName is the name of the schema of OData error responses.
+const errorSchemaName = "odata.error"
+
+type openAPI3Converter struct {
+	metadata     *Metadata
+	document     *openapiv3.Document
+	aliases      map[string]string // namespaces of schema aliases
+	entityTypes  map[string]*StructuredType
+	complexTypes map[string]*StructuredType
+	operations   map[string]*Operation
+	descriptions map[string]string // descriptions of annotation targets
+}
+
+// NewOpenAPIv3FromMetadata builds an OpenAPI v3 document for an OData
+// service. Entity, complex, and enum types become schemas named with their
+// qualified names. Entity sets get paths to list and create entities and to
+// get, update, and delete them by key; singletons get paths to get and
+// update them. Function imports become GET operations whose parameters are
+// in their paths and action imports become POST operations whose
+// parameters are in their request bodies. Bound functions and actions
+// aren't described. Paths are relative to the service root.
+func NewOpenAPIv3FromMetadata(metadata *Metadata, serviceRoot string) (*openapiv3.Document, error) {
+	if metadata == nil || len(metadata.Schemas) == 0 {
+		return nil, errors.New("no metadata to convert")
+	}
+	if serviceRoot == "" {
+		serviceRoot = "/"
+	}
+	c := &openAPI3Converter{
+		metadata: metadata,
+		document: &openapiv3.Document{
+			Openapi: "3.0.3",
+			Info:    &openapiv3.Info{Version: "1.0.0"},
+			Servers: []*openapiv3.Server{{Url: strings.TrimSuffix(serviceRoot, "/")}},
+			Paths:   &openapiv3.Paths{},
+			Components: &openapiv3.Components{
+				Schemas: &openapiv3.SchemasOrReferences{},
+			},
+		},
+		aliases:      make(map[string]string),
+		entityTypes:  make(map[string]*StructuredType),
+		complexTypes: make(map[string]*StructuredType),
+		operations:   make(map[string]*Operation),
+		descriptions: make(map[string]string),
+	}
+	if c.document.Servers[0].Url == "" {
+		c.document.Servers[0].Url = "/"
+	}
+	for _, schema := range metadata.Schemas {
+		if schema.Alias != "" {
+			c.aliases[schema.Alias] = schema.Namespace
+		}
+	}
+	var container *EntityContainer
+	for _, schema := range metadata.Schemas {
+		for _, group := range schema.Targets {
+			if d := description(group.Annotations); d != "" {
+				c.descriptions[c.qualifiedName(group.Target)] = d
+			}
+		}
+		for _, t := range schema.EntityTypes {
+			c.entityTypes[schema.Namespace+"."+t.Name] = t
+		}
+		for _, t := range schema.ComplexTypes {
+			c.complexTypes[schema.Namespace+"."+t.Name] = t
+		}
+		for _, operation := range append(append([]*Operation{}, schema.Functions...), schema.Actions...) {
+			if !operation.IsBound {
+				c.operations[schema.Namespace+"."+operation.Name] = operation
+			}
+		}
+		if schema.EntityContainer != nil && container == nil {
+			container = schema.EntityContainer
+			c.document.Info.Title = container.Name
+			c.document.Info.Description = description(container.Annotations)
+		}
+	}
+	if c.document.Info.Title == "" {
+		c.document.Info.Title = metadata.Schemas[0].Namespace
+	}
+	for _, schema := range metadata.Schemas {
+		c.addSchemas(schema)
+	}
+	c.addSchema(errorSchemaName, errorSchema())
+	if container != nil {
+		if err := c.addPaths(container); err != nil {
+			return nil, err
+		}
+	}
+	return c.document, nil
+}
+
+// qualifiedName replaces the alias that qualifies a name with its namespace.
+func (c *openAPI3Converter) qualifiedName(name string) string {
+	for alias, namespace := range c.aliases {
+		if strings.HasPrefix(name, alias+".") {
+			return namespace + name[len(alias):]
+		}
+	}
+	return name
+}
+
+// elementDescription returns the description of an element from its annotations or from a target annotation.
+func (c *openAPI3Converter) elementDescription(target string, annotations []*Annotation) string {
+	if d := description(annotations); d != "" {
+		return d
+	}
+	return c.descriptions[target]
+}
+
+func (c *openAPI3Converter) addSchema(name string, schema *openapiv3.Schema) {
+	c.document.Components.Schemas.AdditionalProperties = append(c.document.Components.Schemas.AdditionalProperties,
+		&openapiv3.NamedSchemaOrReference{Name: name, Value: schemaOrReference(schema)})
+}
+
+func (c *openAPI3Converter) addSchemas(schema *Schema) {
+	for _, t := range schema.EnumTypes {
+		name := schema.Namespace + "." + t.Name
+		s := &openapiv3.Schema{Type: "string", Title: t.Name, Description: c.elementDescription(name, t.Annotations)}
+		for _, member := range t.Members {
+			s.Enum = append(s.Enum, &openapiv3.Any{Yaml: member.Name})
+		}
+		c.addSchema(name, s)
+	}
+	for _, t := range schema.TypeDefinitions {
+		name := schema.Namespace + "." + t.Name
+		s := c.primitiveSchema(t.UnderlyingType)
+		s.Title = t.Name
+		s.Description = c.elementDescription(name, t.Annotations)
+		c.addSchema(name, s)
+	}
+	for _, t := range append(append([]*StructuredType{}, schema.ComplexTypes...), schema.EntityTypes...) {
+		name := schema.Namespace + "." + t.Name
+		s := &openapiv3.Schema{Type: "object", Title: t.Name, Properties: &openapiv3.Properties{}}
+		for _, property := range append(append([]*Property{}, t.Properties...), t.NavigationProperties...) {
+			p := c.propertySchema(property)
+			if d := c.elementDescription(name+"/"+property.Name, property.Annotations); d != "" {
+				p = describedSchema(p, d)
+			}
+			s.Properties.AdditionalProperties = append(s.Properties.AdditionalProperties,
+				&openapiv3.NamedSchemaOrReference{Name: property.Name, Value: p})
+		}
+		if t.BaseType != "" {
+			s = &openapiv3.Schema{
+				Title: t.Name,
+				AllOf: []*openapiv3.SchemaOrReference{
+					schemaReference(c.qualifiedName(t.BaseType)),
+					schemaOrReference(s),
+				},
+			}
+			s.AllOf[1].GetSchema().Title = ""
+		}
+		s.Description = c.elementDescription(name, t.Annotations)
+		c.addSchema(name, s)
+	}
+}
+
+// collectionElementType returns the element type of a Collection(type) or "" for other types.
+func collectionElementType(typeName string) string {
+	if strings.HasPrefix(typeName, "Collection(") && strings.HasSuffix(typeName, ")") {
+		return typeName[len("Collection(") : len(typeName)-1]
+	}
+	return ""
+}
+
+// propertySchema returns the schema of a property, parameter, or return type.
+func (c *openAPI3Converter) propertySchema(property *Property) *openapiv3.SchemaOrReference {
+	if element := collectionElementType(property.Type); element != "" {
+		return schemaOrReference(&openapiv3.Schema{
+			Type:  "array",
+			Items: &openapiv3.ItemsItem{SchemaOrReference: []*openapiv3.SchemaOrReference{c.typeSchema(element, false)}},
+		})
+	}
+	return c.typeSchema(property.Type, property.IsNullable())
+}
+
+// typeSchema returns the schema of a primitive type or a reference to the schema of another type.
+func (c *openAPI3Converter) typeSchema(typeName string, nullable bool) *openapiv3.SchemaOrReference {
+	if strings.HasPrefix(typeName, "Edm.") {
+		s := c.primitiveSchema(typeName)
+		s.Nullable = nullable
+		return schemaOrReference(s)
+	}
+	return schemaReference(c.qualifiedName(typeName))
+}
+
+// primitiveSchema returns the schema of a primitive type.
+func (c *openAPI3Converter) primitiveSchema(typeName string) *openapiv3.Schema {
+	switch typeName {
+	case "Edm.String":
+		return &openapiv3.Schema{Type: "string"}
+	case "Edm.Boolean":
+		return &openapiv3.Schema{Type: "boolean"}
+	case "Edm.Byte":
+		return &openapiv3.Schema{Type: "integer", Format: "uint8"}
+	case "Edm.SByte":
+		return &openapiv3.Schema{Type: "integer", Format: "int8"}
+	case "Edm.Int16":
+		return &openapiv3.Schema{Type: "integer", Format: "int16"}
+	case "Edm.Int32":
+		return &openapiv3.Schema{Type: "integer", Format: "int32"}
+	case "Edm.Int64":
+		return &openapiv3.Schema{Type: "integer", Format: "int64"}
+	case "Edm.Single":
+		return &openapiv3.Schema{Type: "number", Format: "float"}
+	case "Edm.Double":
+		return &openapiv3.Schema{Type: "number", Format: "double"}
+	case "Edm.Decimal":
+		return &openapiv3.Schema{Type: "number", Format: "decimal"}
+	case "Edm.Date":
+		return &openapiv3.Schema{Type: "string", Format: "date"}
+	case "Edm.DateTimeOffset":
+		return &openapiv3.Schema{Type: "string", Format: "date-time"}
+	case "Edm.TimeOfDay":
+		return &openapiv3.Schema{Type: "string", Format: "time"}
+	case "Edm.Duration":
+		return &openapiv3.Schema{Type: "string", Format: "duration"}
+	case "Edm.Guid":
+		return &openapiv3.Schema{Type: "string", Format: "uuid"}
+	case "Edm.Binary":
+		return &openapiv3.Schema{Type: "string", Format: "base64url"}
+	case "Edm.Stream":
+		return &openapiv3.Schema{Type: "string", Format: "binary"}
+	}
+	if strings.HasPrefix(typeName, "Edm.Geography") || strings.HasPrefix(typeName, "Edm.Geometry") {
+		return &openapiv3.Schema{Type: "object"}
+	}
+	return &openapiv3.Schema{}
+}
+
+// describedSchema adds a description to a schema, wrapping references because their siblings are ignored.
+func describedSchema(s *openapiv3.SchemaOrReference, description string) *openapiv3.SchemaOrReference {
+	if schema := s.GetSchema(); schema != nil {
+		schema.Description = description
+		return s
+	}
+	return schemaOrReference(&openapiv3.Schema{AllOf: []*openapiv3.SchemaOrReference{s}, Description: description})
+}
+
+func errorSchema() *openapiv3.Schema {
+	stringProperty := func(name string) *openapiv3.NamedSchemaOrReference {
+		return &openapiv3.NamedSchemaOrReference{Name: name, Value: schemaOrReference(&openapiv3.Schema{Type: "string"})}
+	}
+	return &openapiv3.Schema{
+		Type:     "object",
+		Required: []string{"error"},
+		Properties: &openapiv3.Properties{
+			AdditionalProperties: []*openapiv3.NamedSchemaOrReference{{
+				Name: "error",
+				Value: schemaOrReference(&openapiv3.Schema{
+					Type:     "object",
+					Required: []string{"code", "message"},
+					Properties: &openapiv3.Properties{
+						AdditionalProperties: []*openapiv3.NamedSchemaOrReference{
+							stringProperty("code"), stringProperty("message"), stringProperty("target"),
+						},
+					},
+				}),
+			}},
+		},
+	}
+}
+
+func (c *openAPI3Converter) addPaths(container *EntityContainer) error {
+	for _, set := range container.EntitySets {
+		entityTypeName := c.qualifiedName(set.EntityType)
+		entityType := c.entityTypes[entityTypeName]
+		if entityType == nil {
+			return fmt.Errorf("entity set %s has unknown type %s", set.Name, set.EntityType)
+		}
+		c.document.Tags = append(c.document.Tags, &openapiv3.Tag{
+			Name:        set.Name,
+			Description: c.elementDescription(container.Name+"/"+set.Name, set.Annotations),
+		})
+		c.addPath("/"+set.Name, &openapiv3.PathItem{
+			Get: &openapiv3.Operation{
+				Tags:        []string{set.Name},
+				Summary:     "Get entities from " + set.Name,
+				OperationId: set.Name + ".List",
+				Parameters:  queryOptionParameters(true),
+				Responses:   responses("200", "Retrieved entities", collectionSchema(entityTypeName)),
+			},
+			Post: &openapiv3.Operation{
+				Tags:        []string{set.Name},
+				Summary:     "Add a new entity to " + set.Name,
+				OperationId: set.Name + ".Create",
+				RequestBody: requestBody(schemaReference(entityTypeName)),
+				Responses:   responses("201", "Created entity", schemaReference(entityTypeName)),
+			},
+		})
+		keyPath, keyParameters := c.keyPath(entityTypeName, entityType)
+		c.addPath("/"+set.Name+keyPath, &openapiv3.PathItem{
+			Parameters: keyParameters,
+			Get: &openapiv3.Operation{
+				Tags:        []string{set.Name},
+				Summary:     "Get an entity from " + set.Name + " by key",
+				OperationId: set.Name + ".Get",
+				Parameters:  queryOptionParameters(false),
+				Responses:   responses("200", "Retrieved entity", schemaReference(entityTypeName)),
+			},
+			Patch: &openapiv3.Operation{
+				Tags:        []string{set.Name},
+				Summary:     "Update an entity in " + set.Name,
+				OperationId: set.Name + ".Update",
+				RequestBody: requestBody(schemaReference(entityTypeName)),
+				Responses:   responses("204", "Success", nil),
+			},
+			Delete: &openapiv3.Operation{
+				Tags:        []string{set.Name},
+				Summary:     "Delete an entity from " + set.Name,
+				OperationId: set.Name + ".Delete",
+				Responses:   responses("204", "Success", nil),
+			},
+		})
+	}
+	for _, singleton := range container.Singletons {
+		typeName := c.qualifiedName(singleton.Type)
+		c.document.Tags = append(c.document.Tags, &openapiv3.Tag{
+			Name:        singleton.Name,
+			Description: c.elementDescription(container.Name+"/"+singleton.Name, singleton.Annotations),
+		})
+		c.addPath("/"+singleton.Name, &openapiv3.PathItem{
+			Get: &openapiv3.Operation{
+				Tags:        []string{singleton.Name},
+				Summary:     "Get " + singleton.Name,
+				OperationId: singleton.Name + ".Get",
+				Parameters:  queryOptionParameters(false),
+				Responses:   responses("200", "Retrieved entity", schemaReference(typeName)),
+			},
+			Patch: &openapiv3.Operation{
+				Tags:        []string{singleton.Name},
+				Summary:     "Update " + singleton.Name,
+				OperationId: singleton.Name + ".Update",
+				RequestBody: requestBody(schemaReference(typeName)),
+				Responses:   responses("204", "Success", nil),
+			},
+		})
+	}
+	for _, functionImport := range container.FunctionImports {
+		function := c.operations[c.qualifiedName(functionImport.Function)]
+		if function == nil {
+			return fmt.Errorf("function import %s has unknown function %s", functionImport.Name, functionImport.Function)
+		}
+		parameters := make([]*openapiv3.ParameterOrReference, 0)
+		arguments := make([]string, 0)
+		for _, parameter := range function.Parameters {
+			arguments = append(arguments, parameter.Name+"="+literalTemplate(parameter.Type, parameter.Name))
+			parameters = append(parameters, pathParameter(parameter.Name, c.propertySchema(parameter)))
+		}
+		c.addPath("/"+functionImport.Name+"("+strings.Join(arguments, ",")+")", &openapiv3.PathItem{
+			Get: &openapiv3.Operation{
+				Summary:     "Invoke function " + functionImport.Name,
+				Description: c.elementDescription(c.qualifiedName(functionImport.Function), function.Annotations),
+				OperationId: "FunctionImport." + functionImport.Name,
+				Parameters:  parameters,
+				Responses:   c.operationResponses(function),
+			},
+		})
+	}
+	for _, actionImport := range container.ActionImports {
+		action := c.operations[c.qualifiedName(actionImport.Action)]
+		if action == nil {
+			return fmt.Errorf("action import %s has unknown action %s", actionImport.Name, actionImport.Action)
+		}
+		operation := &openapiv3.Operation{
+			Summary:     "Invoke action " + actionImport.Name,
+			Description: c.elementDescription(c.qualifiedName(actionImport.Action), action.Annotations),
+			OperationId: "ActionImport." + actionImport.Name,
+			Responses:   c.operationResponses(action),
+		}
+		if len(action.Parameters) > 0 {
+			body := &openapiv3.Schema{Type: "object", Properties: &openapiv3.Properties{}}
+			for _, parameter := range action.Parameters {
+				body.Properties.AdditionalProperties = append(body.Properties.AdditionalProperties,
+					&openapiv3.NamedSchemaOrReference{Name: parameter.Name, Value: c.propertySchema(parameter)})
+			}
+			operation.RequestBody = requestBody(schemaOrReference(body))
+		}
+		c.addPath("/"+actionImport.Name, &openapiv3.PathItem{Post: operation})
+	}
+	return nil
+}
+
+func (c *openAPI3Converter) addPath(path string, pathItem *openapiv3.PathItem) {
+	c.document.Paths.Path = append(c.document.Paths.Path, &openapiv3.NamedPathItem{Name: path, Value: pathItem})
+}
+
+// keyPath returns the path segment that addresses an entity by key, e.g.
+// ('{UserName}') or (Id={Id},Name='{Name}'), and its parameters. Keys are
+// inherited from base types.
+func (c *openAPI3Converter) keyPath(typeName string, entityType *StructuredType) (string, []*openapiv3.ParameterOrReference) {
+	for entityType != nil && len(entityType.Key) == 0 && entityType.BaseType != "" {
+		entityType = c.entityTypes[c.qualifiedName(entityType.BaseType)]
+	}
+	if entityType == nil || len(entityType.Key) == 0 {
+		return "({key})", []*openapiv3.ParameterOrReference{pathParameter("key", schemaOrReference(&openapiv3.Schema{Type: "string"}))}
+	}
+	parts := make([]string, 0)
+	parameters := make([]*openapiv3.ParameterOrReference, 0)
+	for _, key := range entityType.Key {
+		keyType := "Edm.String"
+		for _, property := range entityType.Properties {
+			if property.Name == key.Name {
+				keyType = property.Type
+			}
+		}
+		parts = append(parts, key.Name+"="+literalTemplate(keyType, key.Name))
+		parameters = append(parameters, pathParameter(key.Name, c.typeSchema(keyType, false)))
+	}
+	if len(parts) == 1 {
+		return "(" + strings.SplitN(parts[0], "=", 2)[1] + ")", parameters
+	}
+	return "(" + strings.Join(parts, ",") + ")", parameters
+}
+
+// literalTemplate returns the template of a literal in a URL, which is quoted for strings.
+func literalTemplate(typeName string, name string) string {
+	if typeName == "Edm.String" {
+		return "'{" + name + "}'"
+	}
+	return "{" + name + "}"
+}
+
+// operationResponses returns the responses of a function or action.
+func (c *openAPI3Converter) operationResponses(operation *Operation) *openapiv3.Responses {
+	if operation.ReturnType == nil {
+		return responses("204", "Success", nil)
+	}
+	schema := c.propertySchema(operation.ReturnType)
+	// Results that aren't single entities or complex values are wrapped in objects.
+	if collectionElementType(operation.ReturnType.Type) != "" || c.complexTypes[c.qualifiedName(operation.ReturnType.Type)] == nil && c.entityTypes[c.qualifiedName(operation.ReturnType.Type)] == nil {
+		schema = schemaOrReference(&openapiv3.Schema{
+			Type: "object",
+			Properties: &openapiv3.Properties{
+				AdditionalProperties: []*openapiv3.NamedSchemaOrReference{{Name: "value", Value: schema}},
+			},
+		})
+	}
+	return responses("200", "Success", schema)
+}
+
+func queryOptionParameters(collection bool) []*openapiv3.ParameterOrReference {
+	names := []string{"$select", "$expand"}
+	if collection {
+		names = append([]string{"$top", "$skip", "$filter", "$orderby", "$count"}, names...)
+	}
+	parameters := make([]*openapiv3.ParameterOrReference, 0, len(names))
+	for _, name := range names {
+		schema := &openapiv3.Schema{Type: "string"}
+		switch name {
+		case "$top", "$skip":
+			schema = &openapiv3.Schema{Type: "integer"}
+		case "$count":
+			schema = &openapiv3.Schema{Type: "boolean"}
+		}
+		parameters = append(parameters, &openapiv3.ParameterOrReference{
+			Oneof: &openapiv3.ParameterOrReference_Parameter{
+				Parameter: &openapiv3.Parameter{Name: name, In: "query", Schema: schemaOrReference(schema)},
+			},
+		})
+	}
+	return parameters
+}
+
+func pathParameter(name string, schema *openapiv3.SchemaOrReference) *openapiv3.ParameterOrReference {
+	return &openapiv3.ParameterOrReference{
+		Oneof: &openapiv3.ParameterOrReference_Parameter{
+			Parameter: &openapiv3.Parameter{Name: name, In: "path", Required: true, Schema: schema},
+		},
+	}
+}
+
+func collectionSchema(typeName string) *openapiv3.SchemaOrReference {
+	return schemaOrReference(&openapiv3.Schema{
+		Type: "object",
+		Properties: &openapiv3.Properties{
+			AdditionalProperties: []*openapiv3.NamedSchemaOrReference{{
+				Name: "value",
+				Value: schemaOrReference(&openapiv3.Schema{
+					Type:  "array",
+					Items: &openapiv3.ItemsItem{SchemaOrReference: []*openapiv3.SchemaOrReference{schemaReference(typeName)}},
+				}),
+			}},
+		},
+	})
+}
+
+func requestBody(schema *openapiv3.SchemaOrReference) *openapiv3.RequestBodyOrReference {
+	return &openapiv3.RequestBodyOrReference{
+		Oneof: &openapiv3.RequestBodyOrReference_RequestBody{
+			RequestBody: &openapiv3.RequestBody{
+				Required: true,
+				Content:  jsonContent(schema),
+			},
+		},
+	}
+}
+
+func jsonContent(schema *openapiv3.SchemaOrReference) *openapiv3.MediaTypes {
+	return &openapiv3.MediaTypes{
+		AdditionalProperties: []*openapiv3.NamedMediaType{
+			{Name: "application/json", Value: &openapiv3.MediaType{Schema: schema}},
+		},
+	}
+}
+
+// responses returns the responses of an operation: a successful response
+// with an optional body and the default error response.
+func responses(code string, description string, schema *openapiv3.SchemaOrReference) *openapiv3.Responses {
+	success := &openapiv3.Response{Description: description}
+	if schema != nil {
+		success.Content = jsonContent(schema)
+	}
+	return &openapiv3.Responses{
+		Default: &openapiv3.ResponseOrReference{
+			Oneof: &openapiv3.ResponseOrReference_Response{
+				Response: &openapiv3.Response{Description: "Error", Content: jsonContent(schemaReference(errorSchemaName))},
+			},
+		},
+		ResponseOrReference: []*openapiv3.NamedResponseOrReference{{
+			Name:  code,
+			Value: &openapiv3.ResponseOrReference{Oneof: &openapiv3.ResponseOrReference_Response{Response: success}},
+		}},
+	}
+}
+
+func schemaOrReference(schema *openapiv3.Schema) *openapiv3.SchemaOrReference {
+	return &openapiv3.SchemaOrReference{Oneof: &openapiv3.SchemaOrReference_Schema{Schema: schema}}
+}
+
+func schemaReference(name string) *openapiv3.SchemaOrReference {
+	return &openapiv3.SchemaOrReference{
+		Oneof: &openapiv3.SchemaOrReference_Reference{Reference: &openapiv3.Reference{XRef: "#/components/schemas/" + name}},
+	}
+}
@@ -91,9 +91,7 @@ func NewApiKeySecurity(in *yaml.Node, context *compiler.Context) (*ApiKeySecurit
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "in", "name", "type"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForApiKeySecurity)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -101,7 +99,7 @@ func NewApiKeySecurity(in *yaml.Node, context *compiler.Context) (*ApiKeySecurit
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -116,7 +114,7 @@ func NewApiKeySecurity(in *yaml.Node, context *compiler.Context) (*ApiKeySecurit
 		// string name = 2;
 		v2 := compiler.MapValueForKey(m, "name")
 		if v2 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v2)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -125,7 +123,7 @@ func NewApiKeySecurity(in *yaml.Node, context *compiler.Context) (*ApiKeySecurit
 		// string in = 3;
 		v3 := compiler.MapValueForKey(m, "in")
 		if v3 != nil {
-			x.In, ok = compiler.StringForScalarNode(v3)
+			x.In, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for in: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -140,7 +138,7 @@ func NewApiKeySecurity(in *yaml.Node, context *compiler.Context) (*ApiKeySecurit
 		// string description = 4;
 		v4 := compiler.MapValueForKey(m, "description")
 		if v4 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v4)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -150,7 +148,7 @@ func NewApiKeySecurity(in *yaml.Node, context *compiler.Context) (*ApiKeySecurit
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -196,9 +194,7 @@ func NewBasicAuthenticationSecurity(in *yaml.Node, context *compiler.Context) (*
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "type"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForBasicAuthenticationSecurity)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -206,7 +202,7 @@ func NewBasicAuthenticationSecurity(in *yaml.Node, context *compiler.Context) (*
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -221,7 +217,7 @@ func NewBasicAuthenticationSecurity(in *yaml.Node, context *compiler.Context) (*
 		// string description = 2;
 		v2 := compiler.MapValueForKey(m, "description")
 		if v2 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v2)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -231,7 +227,7 @@ func NewBasicAuthenticationSecurity(in *yaml.Node, context *compiler.Context) (*
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -277,9 +273,7 @@ func NewBodyParameter(in *yaml.Node, context *compiler.Context) (*BodyParameter,
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "in", "name", "required", "schema"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForBodyParameter)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -287,7 +281,7 @@ func NewBodyParameter(in *yaml.Node, context *compiler.Context) (*BodyParameter,
 		// string description = 1;
 		v1 := compiler.MapValueForKey(m, "description")
 		if v1 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v1)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -296,7 +290,7 @@ func NewBodyParameter(in *yaml.Node, context *compiler.Context) (*BodyParameter,
 		// string name = 2;
 		v2 := compiler.MapValueForKey(m, "name")
 		if v2 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v2)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -305,7 +299,7 @@ func NewBodyParameter(in *yaml.Node, context *compiler.Context) (*BodyParameter,
 		// string in = 3;
 		v3 := compiler.MapValueForKey(m, "in")
 		if v3 != nil {
-			x.In, ok = compiler.StringForScalarNode(v3)
+			x.In, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for in: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -339,7 +333,7 @@ func NewBodyParameter(in *yaml.Node, context *compiler.Context) (*BodyParameter,
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -379,9 +373,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"email", "name", "url"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForContact)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -389,7 +381,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -398,7 +390,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// string url = 2;
 		v2 := compiler.MapValueForKey(m, "url")
 		if v2 != nil {
-			x.Url, ok = compiler.StringForScalarNode(v2)
+			x.Url, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for url: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -407,7 +399,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// string email = 3;
 		v3 := compiler.MapValueForKey(m, "email")
 		if v3 != nil {
-			x.Email, ok = compiler.StringForScalarNode(v3)
+			x.Email, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for email: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -417,7 +409,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -461,7 +453,7 @@ func NewDefault(in *yaml.Node, context *compiler.Context) (*Default, error) {
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedAny{}
@@ -503,7 +495,7 @@ func NewDefinitions(in *yaml.Node, context *compiler.Context) (*Definitions, err
 		// MAP: Schema
 		x.AdditionalProperties = make([]*NamedSchema, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedSchema{}
@@ -535,9 +527,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"basePath", "consumes", "definitions", "externalDocs", "host", "info", "parameters", "paths", "produces", "responses", "schemes", "security", "securityDefinitions", "swagger", "tags"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForDocument)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -545,7 +535,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string swagger = 1;
 		v1 := compiler.MapValueForKey(m, "swagger")
 		if v1 != nil {
-			x.Swagger, ok = compiler.StringForScalarNode(v1)
+			x.Swagger, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for swagger: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -569,7 +559,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string host = 3;
 		v3 := compiler.MapValueForKey(m, "host")
 		if v3 != nil {
-			x.Host, ok = compiler.StringForScalarNode(v3)
+			x.Host, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for host: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -578,7 +568,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string base_path = 4;
 		v4 := compiler.MapValueForKey(m, "basePath")
 		if v4 != nil {
-			x.BasePath, ok = compiler.StringForScalarNode(v4)
+			x.BasePath, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for basePath: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -589,7 +579,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		if v5 != nil {
 			v, ok := compiler.SequenceNodeForNode(v5)
 			if ok {
-				x.Schemes = compiler.StringArrayForSequenceNode(v)
+				x.Schemes = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for schemes: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -606,7 +596,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		if v6 != nil {
 			v, ok := compiler.SequenceNodeForNode(v6)
 			if ok {
-				x.Consumes = compiler.StringArrayForSequenceNode(v)
+				x.Consumes = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for consumes: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -617,7 +607,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		if v7 != nil {
 			v, ok := compiler.SequenceNodeForNode(v7)
 			if ok {
-				x.Produces = compiler.StringArrayForSequenceNode(v)
+				x.Produces = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for produces: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -713,7 +703,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -757,7 +747,7 @@ func NewExamples(in *yaml.Node, context *compiler.Context) (*Examples, error) {
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedAny{}
@@ -801,9 +791,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "url"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForExternalDocs)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -811,7 +799,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 		// string description = 1;
 		v1 := compiler.MapValueForKey(m, "description")
 		if v1 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v1)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -820,7 +808,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 		// string url = 2;
 		v2 := compiler.MapValueForKey(m, "url")
 		if v2 != nil {
-			x.Url, ok = compiler.StringForScalarNode(v2)
+			x.Url, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for url: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -830,7 +818,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -876,9 +864,7 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"default", "description", "example", "externalDocs", "format", "readOnly", "required", "title", "type"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForFileSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -886,7 +872,7 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 		// string format = 1;
 		v1 := compiler.MapValueForKey(m, "format")
 		if v1 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v1)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -895,7 +881,7 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 		// string title = 2;
 		v2 := compiler.MapValueForKey(m, "title")
 		if v2 != nil {
-			x.Title, ok = compiler.StringForScalarNode(v2)
+			x.Title, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for title: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -904,7 +890,7 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -924,7 +910,7 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 		if v5 != nil {
 			v, ok := compiler.SequenceNodeForNode(v5)
 			if ok {
-				x.Required = compiler.StringArrayForSequenceNode(v)
+				x.Required = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for required: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -933,7 +919,7 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 		// string type = 6;
 		v6 := compiler.MapValueForKey(m, "type")
 		if v6 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v6)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -976,7 +962,7 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1016,9 +1002,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"allowEmptyValue", "collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "in", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "name", "pattern", "required", "type", "uniqueItems"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForFormDataParameterSubSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1035,7 +1019,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// string in = 2;
 		v2 := compiler.MapValueForKey(m, "in")
 		if v2 != nil {
-			x.In, ok = compiler.StringForScalarNode(v2)
+			x.In, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for in: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1050,7 +1034,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1059,7 +1043,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// string name = 4;
 		v4 := compiler.MapValueForKey(m, "name")
 		if v4 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v4)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1077,7 +1061,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// string type = 6;
 		v6 := compiler.MapValueForKey(m, "type")
 		if v6 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v6)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1092,7 +1076,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// string format = 7;
 		v7 := compiler.MapValueForKey(m, "format")
 		if v7 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v7)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v7, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1110,7 +1094,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// string collection_format = 9;
 		v9 := compiler.MapValueForKey(m, "collectionFormat")
 		if v9 != nil {
-			x.CollectionFormat, ok = compiler.StringForScalarNode(v9)
+			x.CollectionFormat, ok = compiler.StringForScalarNodeWithContext(v9, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for collectionFormat: %s", compiler.Display(v9))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1196,7 +1180,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// string pattern = 17;
 		v17 := compiler.MapValueForKey(m, "pattern")
 		if v17 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v17)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v17, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v17))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1264,7 +1248,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1310,9 +1294,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "pattern", "type", "uniqueItems"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForHeader)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1320,7 +1302,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1335,7 +1317,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// string format = 2;
 		v2 := compiler.MapValueForKey(m, "format")
 		if v2 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v2)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1353,7 +1335,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// string collection_format = 4;
 		v4 := compiler.MapValueForKey(m, "collectionFormat")
 		if v4 != nil {
-			x.CollectionFormat, ok = compiler.StringForScalarNode(v4)
+			x.CollectionFormat, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for collectionFormat: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1439,7 +1421,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// string pattern = 12;
 		v12 := compiler.MapValueForKey(m, "pattern")
 		if v12 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v12)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v12, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v12))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1506,7 +1488,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// string description = 18;
 		v18 := compiler.MapValueForKey(m, "description")
 		if v18 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v18)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v18, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v18))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1516,7 +1498,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1556,9 +1538,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "in", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "name", "pattern", "required", "type", "uniqueItems"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForHeaderParameterSubSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1575,7 +1555,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// string in = 2;
 		v2 := compiler.MapValueForKey(m, "in")
 		if v2 != nil {
-			x.In, ok = compiler.StringForScalarNode(v2)
+			x.In, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for in: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1590,7 +1570,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1599,7 +1579,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// string name = 4;
 		v4 := compiler.MapValueForKey(m, "name")
 		if v4 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v4)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1608,7 +1588,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// string type = 5;
 		v5 := compiler.MapValueForKey(m, "type")
 		if v5 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v5)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1623,7 +1603,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// string format = 6;
 		v6 := compiler.MapValueForKey(m, "format")
 		if v6 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v6)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1641,7 +1621,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// string collection_format = 8;
 		v8 := compiler.MapValueForKey(m, "collectionFormat")
 		if v8 != nil {
-			x.CollectionFormat, ok = compiler.StringForScalarNode(v8)
+			x.CollectionFormat, ok = compiler.StringForScalarNodeWithContext(v8, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for collectionFormat: %s", compiler.Display(v8))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1727,7 +1707,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// string pattern = 16;
 		v16 := compiler.MapValueForKey(m, "pattern")
 		if v16 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v16)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v16, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v16))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1795,7 +1775,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1839,7 +1819,7 @@ func NewHeaders(in *yaml.Node, context *compiler.Context) (*Headers, error) {
 		// MAP: Header
 		x.AdditionalProperties = make([]*NamedHeader, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedHeader{}
@@ -1871,9 +1851,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"contact", "description", "license", "termsOfService", "title", "version"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForInfo)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1881,7 +1859,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string title = 1;
 		v1 := compiler.MapValueForKey(m, "title")
 		if v1 != nil {
-			x.Title, ok = compiler.StringForScalarNode(v1)
+			x.Title, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for title: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1890,7 +1868,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string version = 2;
 		v2 := compiler.MapValueForKey(m, "version")
 		if v2 != nil {
-			x.Version, ok = compiler.StringForScalarNode(v2)
+			x.Version, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for version: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1899,7 +1877,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1908,7 +1886,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string terms_of_service = 4;
 		v4 := compiler.MapValueForKey(m, "termsOfService")
 		if v4 != nil {
-			x.TermsOfService, ok = compiler.StringForScalarNode(v4)
+			x.TermsOfService, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for termsOfService: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1936,7 +1914,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -2004,7 +1982,7 @@ func NewJsonReference(in *yaml.Node, context *compiler.Context) (*JsonReference,
 		// string _ref = 1;
 		v1 := compiler.MapValueForKey(m, "$ref")
 		if v1 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v1)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2013,7 +1991,7 @@ func NewJsonReference(in *yaml.Node, context *compiler.Context) (*JsonReference,
 		// string description = 2;
 		v2 := compiler.MapValueForKey(m, "description")
 		if v2 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v2)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2038,9 +2016,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"name", "url"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForLicense)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2048,7 +2024,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2057,7 +2033,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 		// string url = 2;
 		v2 := compiler.MapValueForKey(m, "url")
 		if v2 != nil {
-			x.Url, ok = compiler.StringForScalarNode(v2)
+			x.Url, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for url: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2067,7 +2043,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -2107,9 +2083,7 @@ func NewNamedAny(in *yaml.Node, context *compiler.Context) (*NamedAny, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedAny)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2117,7 +2091,7 @@ func NewNamedAny(in *yaml.Node, context *compiler.Context) (*NamedAny, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2145,9 +2119,7 @@ func NewNamedHeader(in *yaml.Node, context *compiler.Context) (*NamedHeader, err
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedHeader)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2155,7 +2127,7 @@ func NewNamedHeader(in *yaml.Node, context *compiler.Context) (*NamedHeader, err
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2183,9 +2155,7 @@ func NewNamedParameter(in *yaml.Node, context *compiler.Context) (*NamedParamete
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedParameter)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2193,7 +2163,7 @@ func NewNamedParameter(in *yaml.Node, context *compiler.Context) (*NamedParamete
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2221,9 +2191,7 @@ func NewNamedPathItem(in *yaml.Node, context *compiler.Context) (*NamedPathItem,
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedPathItem)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2231,7 +2199,7 @@ func NewNamedPathItem(in *yaml.Node, context *compiler.Context) (*NamedPathItem,
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2259,9 +2227,7 @@ func NewNamedResponse(in *yaml.Node, context *compiler.Context) (*NamedResponse,
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedResponse)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2269,7 +2235,7 @@ func NewNamedResponse(in *yaml.Node, context *compiler.Context) (*NamedResponse,
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2297,9 +2263,7 @@ func NewNamedResponseValue(in *yaml.Node, context *compiler.Context) (*NamedResp
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedResponseValue)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2307,7 +2271,7 @@ func NewNamedResponseValue(in *yaml.Node, context *compiler.Context) (*NamedResp
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2335,9 +2299,7 @@ func NewNamedSchema(in *yaml.Node, context *compiler.Context) (*NamedSchema, err
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2345,7 +2307,7 @@ func NewNamedSchema(in *yaml.Node, context *compiler.Context) (*NamedSchema, err
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2373,9 +2335,7 @@ func NewNamedSecurityDefinitionsItem(in *yaml.Node, context *compiler.Context) (
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedSecurityDefinitionsItem)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2383,7 +2343,7 @@ func NewNamedSecurityDefinitionsItem(in *yaml.Node, context *compiler.Context) (
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2411,9 +2371,7 @@ func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, err
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedString)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2421,7 +2379,7 @@ func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, err
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2430,7 +2388,7 @@ func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, err
 		// string value = 2;
 		v2 := compiler.MapValueForKey(m, "value")
 		if v2 != nil {
-			x.Value, ok = compiler.StringForScalarNode(v2)
+			x.Value, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for value: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2449,9 +2407,7 @@ func NewNamedStringArray(in *yaml.Node, context *compiler.Context) (*NamedString
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedStringArray)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2459,7 +2415,7 @@ func NewNamedStringArray(in *yaml.Node, context *compiler.Context) (*NamedString
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2565,9 +2521,7 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"authorizationUrl", "description", "flow", "scopes", "tokenUrl", "type"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOauth2AccessCodeSecurity)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2575,7 +2529,7 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2590,7 +2544,7 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 		// string flow = 2;
 		v2 := compiler.MapValueForKey(m, "flow")
 		if v2 != nil {
-			x.Flow, ok = compiler.StringForScalarNode(v2)
+			x.Flow, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for flow: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2614,7 +2568,7 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 		// string authorization_url = 4;
 		v4 := compiler.MapValueForKey(m, "authorizationUrl")
 		if v4 != nil {
-			x.AuthorizationUrl, ok = compiler.StringForScalarNode(v4)
+			x.AuthorizationUrl, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for authorizationUrl: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2623,7 +2577,7 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 		// string token_url = 5;
 		v5 := compiler.MapValueForKey(m, "tokenUrl")
 		if v5 != nil {
-			x.TokenUrl, ok = compiler.StringForScalarNode(v5)
+			x.TokenUrl, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for tokenUrl: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2632,7 +2586,7 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 		// string description = 6;
 		v6 := compiler.MapValueForKey(m, "description")
 		if v6 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v6)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2642,7 +2596,7 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -2688,9 +2642,7 @@ func NewOauth2ApplicationSecurity(in *yaml.Node, context *compiler.Context) (*Oa
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "flow", "scopes", "tokenUrl", "type"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOauth2ApplicationSecurity)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2698,7 +2650,7 @@ func NewOauth2ApplicationSecurity(in *yaml.Node, context *compiler.Context) (*Oa
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2713,7 +2665,7 @@ func NewOauth2ApplicationSecurity(in *yaml.Node, context *compiler.Context) (*Oa
 		// string flow = 2;
 		v2 := compiler.MapValueForKey(m, "flow")
 		if v2 != nil {
-			x.Flow, ok = compiler.StringForScalarNode(v2)
+			x.Flow, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for flow: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2737,7 +2689,7 @@ func NewOauth2ApplicationSecurity(in *yaml.Node, context *compiler.Context) (*Oa
 		// string token_url = 4;
 		v4 := compiler.MapValueForKey(m, "tokenUrl")
 		if v4 != nil {
-			x.TokenUrl, ok = compiler.StringForScalarNode(v4)
+			x.TokenUrl, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for tokenUrl: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2746,7 +2698,7 @@ func NewOauth2ApplicationSecurity(in *yaml.Node, context *compiler.Context) (*Oa
 		// string description = 5;
 		v5 := compiler.MapValueForKey(m, "description")
 		if v5 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v5)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2756,7 +2708,7 @@ func NewOauth2ApplicationSecurity(in *yaml.Node, context *compiler.Context) (*Oa
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -2802,9 +2754,7 @@ func NewOauth2ImplicitSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"authorizationUrl", "description", "flow", "scopes", "type"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOauth2ImplicitSecurity)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2812,7 +2762,7 @@ func NewOauth2ImplicitSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2827,7 +2777,7 @@ func NewOauth2ImplicitSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// string flow = 2;
 		v2 := compiler.MapValueForKey(m, "flow")
 		if v2 != nil {
-			x.Flow, ok = compiler.StringForScalarNode(v2)
+			x.Flow, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for flow: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2851,7 +2801,7 @@ func NewOauth2ImplicitSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// string authorization_url = 4;
 		v4 := compiler.MapValueForKey(m, "authorizationUrl")
 		if v4 != nil {
-			x.AuthorizationUrl, ok = compiler.StringForScalarNode(v4)
+			x.AuthorizationUrl, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for authorizationUrl: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2860,7 +2810,7 @@ func NewOauth2ImplicitSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// string description = 5;
 		v5 := compiler.MapValueForKey(m, "description")
 		if v5 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v5)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2870,7 +2820,7 @@ func NewOauth2ImplicitSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -2916,9 +2866,7 @@ func NewOauth2PasswordSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "flow", "scopes", "tokenUrl", "type"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOauth2PasswordSecurity)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2926,7 +2874,7 @@ func NewOauth2PasswordSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2941,7 +2889,7 @@ func NewOauth2PasswordSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// string flow = 2;
 		v2 := compiler.MapValueForKey(m, "flow")
 		if v2 != nil {
-			x.Flow, ok = compiler.StringForScalarNode(v2)
+			x.Flow, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for flow: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2965,7 +2913,7 @@ func NewOauth2PasswordSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// string token_url = 4;
 		v4 := compiler.MapValueForKey(m, "tokenUrl")
 		if v4 != nil {
-			x.TokenUrl, ok = compiler.StringForScalarNode(v4)
+			x.TokenUrl, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for tokenUrl: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2974,7 +2922,7 @@ func NewOauth2PasswordSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// string description = 5;
 		v5 := compiler.MapValueForKey(m, "description")
 		if v5 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v5)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2984,7 +2932,7 @@ func NewOauth2PasswordSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3028,12 +2976,12 @@ func NewOauth2Scopes(in *yaml.Node, context *compiler.Context) (*Oauth2Scopes, e
 		// MAP: string
 		x.AdditionalProperties = make([]*NamedString, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedString{}
 				pair.Name = k
-				pair.Value, _ = compiler.StringForScalarNode(v)
+				pair.Value, _ = compiler.StringForScalarNodeWithContext(v, context)
 				x.AdditionalProperties = append(x.AdditionalProperties, pair)
 			}
 		}
@@ -3056,9 +3004,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"consumes", "deprecated", "description", "externalDocs", "operationId", "parameters", "produces", "responses", "schemes", "security", "summary", "tags"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOperation)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3068,7 +3014,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		if v1 != nil {
 			v, ok := compiler.SequenceNodeForNode(v1)
 			if ok {
-				x.Tags = compiler.StringArrayForSequenceNode(v)
+				x.Tags = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for tags: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3077,7 +3023,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// string summary = 2;
 		v2 := compiler.MapValueForKey(m, "summary")
 		if v2 != nil {
-			x.Summary, ok = compiler.StringForScalarNode(v2)
+			x.Summary, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for summary: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3086,7 +3032,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3104,7 +3050,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// string operation_id = 5;
 		v5 := compiler.MapValueForKey(m, "operationId")
 		if v5 != nil {
-			x.OperationId, ok = compiler.StringForScalarNode(v5)
+			x.OperationId, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for operationId: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3115,7 +3061,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		if v6 != nil {
 			v, ok := compiler.SequenceNodeForNode(v6)
 			if ok {
-				x.Produces = compiler.StringArrayForSequenceNode(v)
+				x.Produces = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for produces: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3126,7 +3072,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		if v7 != nil {
 			v, ok := compiler.SequenceNodeForNode(v7)
 			if ok {
-				x.Consumes = compiler.StringArrayForSequenceNode(v)
+				x.Consumes = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for consumes: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3162,7 +3108,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		if v10 != nil {
 			v, ok := compiler.SequenceNodeForNode(v10)
 			if ok {
-				x.Schemes = compiler.StringArrayForSequenceNode(v)
+				x.Schemes = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for schemes: %s", compiler.Display(v10))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3203,7 +3149,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3291,7 +3237,7 @@ func NewParameterDefinitions(in *yaml.Node, context *compiler.Context) (*Paramet
 		// MAP: Parameter
 		x.AdditionalProperties = make([]*NamedParameter, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedParameter{}
@@ -3361,9 +3307,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"$ref", "delete", "get", "head", "options", "parameters", "patch", "post", "put"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForPathItem)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3371,7 +3315,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		// string _ref = 1;
 		v1 := compiler.MapValueForKey(m, "$ref")
 		if v1 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v1)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3460,7 +3404,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3506,9 +3450,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "in", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "name", "pattern", "required", "type", "uniqueItems"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForPathParameterSubSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3525,7 +3467,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// string in = 2;
 		v2 := compiler.MapValueForKey(m, "in")
 		if v2 != nil {
-			x.In, ok = compiler.StringForScalarNode(v2)
+			x.In, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for in: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3540,7 +3482,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3549,7 +3491,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// string name = 4;
 		v4 := compiler.MapValueForKey(m, "name")
 		if v4 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v4)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3558,7 +3500,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// string type = 5;
 		v5 := compiler.MapValueForKey(m, "type")
 		if v5 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v5)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3573,7 +3515,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// string format = 6;
 		v6 := compiler.MapValueForKey(m, "format")
 		if v6 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v6)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3591,7 +3533,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// string collection_format = 8;
 		v8 := compiler.MapValueForKey(m, "collectionFormat")
 		if v8 != nil {
-			x.CollectionFormat, ok = compiler.StringForScalarNode(v8)
+			x.CollectionFormat, ok = compiler.StringForScalarNodeWithContext(v8, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for collectionFormat: %s", compiler.Display(v8))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3677,7 +3619,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// string pattern = 16;
 		v16 := compiler.MapValueForKey(m, "pattern")
 		if v16 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v16)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v16, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v16))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3745,7 +3687,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3785,9 +3727,7 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{}
-		allowedPatterns := []*regexp.Regexp{pattern0, pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForPaths)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3796,7 +3736,7 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3827,7 +3767,7 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		// MAP: PathItem ^/
 		x.Path = make([]*NamedPathItem, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "/") {
@@ -3855,9 +3795,7 @@ func NewPrimitivesItems(in *yaml.Node, context *compiler.Context) (*PrimitivesIt
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"collectionFormat", "default", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "pattern", "type", "uniqueItems"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForPrimitivesItems)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3865,7 +3803,7 @@ func NewPrimitivesItems(in *yaml.Node, context *compiler.Context) (*PrimitivesIt
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3880,7 +3818,7 @@ func NewPrimitivesItems(in *yaml.Node, context *compiler.Context) (*PrimitivesIt
 		// string format = 2;
 		v2 := compiler.MapValueForKey(m, "format")
 		if v2 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v2)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3898,7 +3836,7 @@ func NewPrimitivesItems(in *yaml.Node, context *compiler.Context) (*PrimitivesIt
 		// string collection_format = 4;
 		v4 := compiler.MapValueForKey(m, "collectionFormat")
 		if v4 != nil {
-			x.CollectionFormat, ok = compiler.StringForScalarNode(v4)
+			x.CollectionFormat, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for collectionFormat: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3984,7 +3922,7 @@ func NewPrimitivesItems(in *yaml.Node, context *compiler.Context) (*PrimitivesIt
 		// string pattern = 12;
 		v12 := compiler.MapValueForKey(m, "pattern")
 		if v12 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v12)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v12, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v12))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4052,7 +3990,7 @@ func NewPrimitivesItems(in *yaml.Node, context *compiler.Context) (*PrimitivesIt
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4096,7 +4034,7 @@ func NewProperties(in *yaml.Node, context *compiler.Context) (*Properties, error
 		// MAP: Schema
 		x.AdditionalProperties = make([]*NamedSchema, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedSchema{}
@@ -4122,9 +4060,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"allowEmptyValue", "collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "in", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "name", "pattern", "required", "type", "uniqueItems"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForQueryParameterSubSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4141,7 +4077,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// string in = 2;
 		v2 := compiler.MapValueForKey(m, "in")
 		if v2 != nil {
-			x.In, ok = compiler.StringForScalarNode(v2)
+			x.In, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for in: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4156,7 +4092,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4165,7 +4101,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// string name = 4;
 		v4 := compiler.MapValueForKey(m, "name")
 		if v4 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v4)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4183,7 +4119,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// string type = 6;
 		v6 := compiler.MapValueForKey(m, "type")
 		if v6 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v6)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4198,7 +4134,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// string format = 7;
 		v7 := compiler.MapValueForKey(m, "format")
 		if v7 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v7)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v7, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4216,7 +4152,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// string collection_format = 9;
 		v9 := compiler.MapValueForKey(m, "collectionFormat")
 		if v9 != nil {
-			x.CollectionFormat, ok = compiler.StringForScalarNode(v9)
+			x.CollectionFormat, ok = compiler.StringForScalarNodeWithContext(v9, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for collectionFormat: %s", compiler.Display(v9))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4302,7 +4238,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// string pattern = 17;
 		v17 := compiler.MapValueForKey(m, "pattern")
 		if v17 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v17)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v17, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v17))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4370,7 +4306,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4416,9 +4352,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "examples", "headers", "schema"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForResponse)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4426,7 +4360,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 		// string description = 1;
 		v1 := compiler.MapValueForKey(m, "description")
 		if v1 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v1)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4463,7 +4397,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4507,7 +4441,7 @@ func NewResponseDefinitions(in *yaml.Node, context *compiler.Context) (*Response
 		// MAP: Response
 		x.AdditionalProperties = make([]*NamedResponse, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedResponse{}
@@ -4577,9 +4511,7 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{}
-		allowedPatterns := []*regexp.Regexp{pattern2, pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForResponses)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4588,7 +4520,7 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		// MAP: ResponseValue ^([0-9]{3})$|^(default)$
 		x.ResponseCode = make([]*NamedResponseValue, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if pattern2.MatchString(k) {
@@ -4607,7 +4539,7 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4647,9 +4579,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"$ref", "additionalProperties", "allOf", "default", "description", "discriminator", "enum", "example", "exclusiveMaximum", "exclusiveMinimum", "externalDocs", "format", "items", "maxItems", "maxLength", "maxProperties", "maximum", "minItems", "minLength", "minProperties", "minimum", "multipleOf", "pattern", "properties", "readOnly", "required", "title", "type", "uniqueItems", "xml"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4657,7 +4587,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string _ref = 1;
 		v1 := compiler.MapValueForKey(m, "$ref")
 		if v1 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v1)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4666,7 +4596,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string format = 2;
 		v2 := compiler.MapValueForKey(m, "format")
 		if v2 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v2)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4675,7 +4605,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string title = 3;
 		v3 := compiler.MapValueForKey(m, "title")
 		if v3 != nil {
-			x.Title, ok = compiler.StringForScalarNode(v3)
+			x.Title, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for title: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4684,7 +4614,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string description = 4;
 		v4 := compiler.MapValueForKey(m, "description")
 		if v4 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v4)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4775,7 +4705,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string pattern = 13;
 		v13 := compiler.MapValueForKey(m, "pattern")
 		if v13 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v13)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v13, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v13))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4839,7 +4769,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		if v19 != nil {
 			v, ok := compiler.SequenceNodeForNode(v19)
 			if ok {
-				x.Required = compiler.StringArrayForSequenceNode(v)
+				x.Required = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for required: %s", compiler.Display(v19))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4916,7 +4846,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string discriminator = 26;
 		v26 := compiler.MapValueForKey(m, "discriminator")
 		if v26 != nil {
-			x.Discriminator, ok = compiler.StringForScalarNode(v26)
+			x.Discriminator, ok = compiler.StringForScalarNodeWithContext(v26, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for discriminator: %s", compiler.Display(v26))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4962,7 +4892,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -5050,7 +4980,7 @@ func NewSecurityDefinitions(in *yaml.Node, context *compiler.Context) (*Security
 		// MAP: SecurityDefinitionsItem
 		x.AdditionalProperties = make([]*NamedSecurityDefinitionsItem, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedSecurityDefinitionsItem{}
@@ -5180,7 +5110,7 @@ func NewSecurityRequirement(in *yaml.Node, context *compiler.Context) (*Security
 		// MAP: StringArray
 		x.AdditionalProperties = make([]*NamedStringArray, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedStringArray{}
@@ -5203,7 +5133,7 @@ func NewStringArray(in *yaml.Node, context *compiler.Context) (*StringArray, err
 	x := &StringArray{}
 	x.Value = make([]string, 0)
 	for _, node := range in.Content {
-		s, _ := compiler.StringForScalarNode(node)
+		s, _ := compiler.StringForScalarNodeWithContext(node, context)
 		x.Value = append(x.Value, s)
 	}
 	return x, compiler.NewErrorGroupOrNil(errors)
@@ -5224,9 +5154,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "externalDocs", "name"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForTag)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -5234,7 +5162,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -5243,7 +5171,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 		// string description = 2;
 		v2 := compiler.MapValueForKey(m, "description")
 		if v2 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v2)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -5262,7 +5190,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -5334,7 +5262,7 @@ func NewVendorExtension(in *yaml.Node, context *compiler.Context) (*VendorExtens
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedAny{}
@@ -5372,9 +5300,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"attribute", "name", "namespace", "prefix", "wrapped"}
-		allowedPatterns := []*regexp.Regexp{pattern0}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForXml)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -5382,7 +5308,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -5391,7 +5317,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// string namespace = 2;
 		v2 := compiler.MapValueForKey(m, "namespace")
 		if v2 != nil {
-			x.Namespace, ok = compiler.StringForScalarNode(v2)
+			x.Namespace, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for namespace: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -5400,7 +5326,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// string prefix = 3;
 		v3 := compiler.MapValueForKey(m, "prefix")
 		if v3 != nil {
-			x.Prefix, ok = compiler.StringForScalarNode(v3)
+			x.Prefix, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for prefix: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -5428,7 +5354,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -5459,8 +5385,2029 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
+// rawInfoForAdditionalPropertiesItem returns a description of AdditionalPropertiesItem suitable for JSON or YAML export.
+func rawInfoForAdditionalPropertiesItem(m *AdditionalPropertiesItem, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// AdditionalPropertiesItem
+	// {Name:schema Type:Schema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetSchema()
+	if v0 != nil {
+		return rawInfoForSchema(v0, b)
+	}
+	// {Name:boolean Type:bool StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	if v1, ok := m.GetOneof().(*AdditionalPropertiesItem_Boolean); ok {
+		return b.Bool(v1.Boolean)
+	}
+	return b.Null()
+}
+
+// rawInfoForAny returns a description of Any suitable for JSON or YAML export.
+func rawInfoForAny(m *Any, b *compiler.NodeBuilder) *yaml.Node {
+	var err error
+	var node yaml.Node
+	err = yaml.Unmarshal([]byte(m.Yaml), &node)
+	if err == nil {
+		if node.Kind == yaml.DocumentNode {
+			return node.Content[0]
+		}
+		return &node
+	}
+	return b.Null()
+}
+
+// rawInfoForApiKeySecurity returns a description of ApiKeySecurity suitable for JSON or YAML export.
+func rawInfoForApiKeySecurity(m *ApiKeySecurity, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(8 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("name"))
+	info.Content = append(info.Content, b.String(m.Name))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("in"))
+	info.Content = append(info.Content, b.String(m.In))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForBasicAuthenticationSecurity returns a description of BasicAuthenticationSecurity suitable for JSON or YAML export.
+func rawInfoForBasicAuthenticationSecurity(m *BasicAuthenticationSecurity, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForBodyParameter returns a description of BodyParameter suitable for JSON or YAML export.
+func rawInfoForBodyParameter(m *BodyParameter, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(10 + 2*len(m.VendorExtension))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("name"))
+	info.Content = append(info.Content, b.String(m.Name))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("in"))
+	info.Content = append(info.Content, b.String(m.In))
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("schema"))
+	info.Content = append(info.Content, rawInfoForSchema(m.Schema, b))
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForContact returns a description of Contact suitable for JSON or YAML export.
+func rawInfoForContact(m *Contact, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(6 + 2*len(m.VendorExtension))
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Url != "" {
+		info.Content = append(info.Content, b.Key("url"))
+		info.Content = append(info.Content, b.String(m.Url))
+	}
+	if m.Email != "" {
+		info.Content = append(info.Content, b.Key("email"))
+		info.Content = append(info.Content, b.String(m.Email))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForDefault returns a description of Default suitable for JSON or YAML export.
+func rawInfoForDefault(m *Default, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForDefinitions returns a description of Definitions suitable for JSON or YAML export.
+func rawInfoForDefinitions(m *Definitions, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForSchema(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForDocument returns a description of Document suitable for JSON or YAML export.
+func rawInfoForDocument(m *Document, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("swagger"))
+	info.Content = append(info.Content, b.String(m.Swagger))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("info"))
+	info.Content = append(info.Content, rawInfoForInfo(m.Info, b))
+	if m.Host != "" {
+		info.Content = append(info.Content, b.Key("host"))
+		info.Content = append(info.Content, b.String(m.Host))
+	}
+	if m.BasePath != "" {
+		info.Content = append(info.Content, b.Key("basePath"))
+		info.Content = append(info.Content, b.String(m.BasePath))
+	}
+	if len(m.Schemes) != 0 {
+		info.Content = append(info.Content, b.Key("schemes"))
+		info.Content = append(info.Content, b.StringArray(m.Schemes))
+	}
+	if len(m.Consumes) != 0 {
+		info.Content = append(info.Content, b.Key("consumes"))
+		info.Content = append(info.Content, b.StringArray(m.Consumes))
+	}
+	if len(m.Produces) != 0 {
+		info.Content = append(info.Content, b.Key("produces"))
+		info.Content = append(info.Content, b.StringArray(m.Produces))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("paths"))
+	info.Content = append(info.Content, rawInfoForPaths(m.Paths, b))
+	if m.Definitions != nil {
+		info.Content = append(info.Content, b.Key("definitions"))
+		info.Content = append(info.Content, rawInfoForDefinitions(m.Definitions, b))
+	}
+	if m.Parameters != nil {
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, rawInfoForParameterDefinitions(m.Parameters, b))
+	}
+	if m.Responses != nil {
+		info.Content = append(info.Content, b.Key("responses"))
+		info.Content = append(info.Content, rawInfoForResponseDefinitions(m.Responses, b))
+	}
+	if len(m.Security) != 0 {
+		items := b.Sequence(len(m.Security))
+		for _, item := range m.Security {
+			items.Content = append(items.Content, rawInfoForSecurityRequirement(item, b))
+		}
+		info.Content = append(info.Content, b.Key("security"))
+		info.Content = append(info.Content, items)
+	}
+	if m.SecurityDefinitions != nil {
+		info.Content = append(info.Content, b.Key("securityDefinitions"))
+		info.Content = append(info.Content, rawInfoForSecurityDefinitions(m.SecurityDefinitions, b))
+	}
+	if len(m.Tags) != 0 {
+		items := b.Sequence(len(m.Tags))
+		for _, item := range m.Tags {
+			items.Content = append(items.Content, rawInfoForTag(item, b))
+		}
+		info.Content = append(info.Content, b.Key("tags"))
+		info.Content = append(info.Content, items)
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForExamples returns a description of Examples suitable for JSON or YAML export.
+func rawInfoForExamples(m *Examples, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForExternalDocs returns a description of ExternalDocs suitable for JSON or YAML export.
+func rawInfoForExternalDocs(m *ExternalDocs, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4 + 2*len(m.VendorExtension))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("url"))
+	info.Content = append(info.Content, b.String(m.Url))
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForFileSchema returns a description of FileSchema suitable for JSON or YAML export.
+func rawInfoForFileSchema(m *FileSchema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Title != "" {
+		info.Content = append(info.Content, b.Key("title"))
+		info.Content = append(info.Content, b.String(m.Title))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForAny(m.Default, b))
+	}
+	if len(m.Required) != 0 {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.StringArray(m.Required))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	if m.ReadOnly != false {
+		info.Content = append(info.Content, b.Key("readOnly"))
+		info.Content = append(info.Content, b.Bool(m.ReadOnly))
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.Example != nil {
+		info.Content = append(info.Content, b.Key("example"))
+		info.Content = append(info.Content, rawInfoForAny(m.Example, b))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForFormDataParameterSubSchema returns a description of FormDataParameterSubSchema suitable for JSON or YAML export.
+func rawInfoForFormDataParameterSubSchema(m *FormDataParameterSubSchema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	if m.In != "" {
+		info.Content = append(info.Content, b.Key("in"))
+		info.Content = append(info.Content, b.String(m.In))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.AllowEmptyValue != false {
+		info.Content = append(info.Content, b.Key("allowEmptyValue"))
+		info.Content = append(info.Content, b.Bool(m.AllowEmptyValue))
+	}
+	if m.Type != "" {
+		info.Content = append(info.Content, b.Key("type"))
+		info.Content = append(info.Content, b.String(m.Type))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Items != nil {
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, rawInfoForPrimitivesItems(m.Items, b))
+	}
+	if m.CollectionFormat != "" {
+		info.Content = append(info.Content, b.Key("collectionFormat"))
+		info.Content = append(info.Content, b.String(m.CollectionFormat))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForAny(m.Default, b))
+	}
+	if m.Maximum != 0.0 {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.Float(m.Maximum))
+	}
+	if m.ExclusiveMaximum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMaximum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMaximum))
+	}
+	if m.Minimum != 0.0 {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.Float(m.Minimum))
+	}
+	if m.ExclusiveMinimum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMinimum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMinimum))
+	}
+	if m.MaxLength != 0 {
+		info.Content = append(info.Content, b.Key("maxLength"))
+		info.Content = append(info.Content, b.Int(m.MaxLength))
+	}
+	if m.MinLength != 0 {
+		info.Content = append(info.Content, b.Key("minLength"))
+		info.Content = append(info.Content, b.Int(m.MinLength))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.MaxItems != 0 {
+		info.Content = append(info.Content, b.Key("maxItems"))
+		info.Content = append(info.Content, b.Int(m.MaxItems))
+	}
+	if m.MinItems != 0 {
+		info.Content = append(info.Content, b.Key("minItems"))
+		info.Content = append(info.Content, b.Int(m.MinItems))
+	}
+	if m.UniqueItems != false {
+		info.Content = append(info.Content, b.Key("uniqueItems"))
+		info.Content = append(info.Content, b.Bool(m.UniqueItems))
+	}
+	if len(m.Enum) != 0 {
+		items := b.Sequence(len(m.Enum))
+		for _, item := range m.Enum {
+			items.Content = append(items.Content, rawInfoForAny(item, b))
+		}
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, items)
+	}
+	if m.MultipleOf != 0.0 {
+		info.Content = append(info.Content, b.Key("multipleOf"))
+		info.Content = append(info.Content, b.Float(m.MultipleOf))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForHeader returns a description of Header suitable for JSON or YAML export.
+func rawInfoForHeader(m *Header, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Items != nil {
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, rawInfoForPrimitivesItems(m.Items, b))
+	}
+	if m.CollectionFormat != "" {
+		info.Content = append(info.Content, b.Key("collectionFormat"))
+		info.Content = append(info.Content, b.String(m.CollectionFormat))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForAny(m.Default, b))
+	}
+	if m.Maximum != 0.0 {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.Float(m.Maximum))
+	}
+	if m.ExclusiveMaximum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMaximum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMaximum))
+	}
+	if m.Minimum != 0.0 {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.Float(m.Minimum))
+	}
+	if m.ExclusiveMinimum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMinimum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMinimum))
+	}
+	if m.MaxLength != 0 {
+		info.Content = append(info.Content, b.Key("maxLength"))
+		info.Content = append(info.Content, b.Int(m.MaxLength))
+	}
+	if m.MinLength != 0 {
+		info.Content = append(info.Content, b.Key("minLength"))
+		info.Content = append(info.Content, b.Int(m.MinLength))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.MaxItems != 0 {
+		info.Content = append(info.Content, b.Key("maxItems"))
+		info.Content = append(info.Content, b.Int(m.MaxItems))
+	}
+	if m.MinItems != 0 {
+		info.Content = append(info.Content, b.Key("minItems"))
+		info.Content = append(info.Content, b.Int(m.MinItems))
+	}
+	if m.UniqueItems != false {
+		info.Content = append(info.Content, b.Key("uniqueItems"))
+		info.Content = append(info.Content, b.Bool(m.UniqueItems))
+	}
+	if len(m.Enum) != 0 {
+		items := b.Sequence(len(m.Enum))
+		for _, item := range m.Enum {
+			items.Content = append(items.Content, rawInfoForAny(item, b))
+		}
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, items)
+	}
+	if m.MultipleOf != 0.0 {
+		info.Content = append(info.Content, b.Key("multipleOf"))
+		info.Content = append(info.Content, b.Float(m.MultipleOf))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForHeaderParameterSubSchema returns a description of HeaderParameterSubSchema suitable for JSON or YAML export.
+func rawInfoForHeaderParameterSubSchema(m *HeaderParameterSubSchema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	if m.In != "" {
+		info.Content = append(info.Content, b.Key("in"))
+		info.Content = append(info.Content, b.String(m.In))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Type != "" {
+		info.Content = append(info.Content, b.Key("type"))
+		info.Content = append(info.Content, b.String(m.Type))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Items != nil {
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, rawInfoForPrimitivesItems(m.Items, b))
+	}
+	if m.CollectionFormat != "" {
+		info.Content = append(info.Content, b.Key("collectionFormat"))
+		info.Content = append(info.Content, b.String(m.CollectionFormat))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForAny(m.Default, b))
+	}
+	if m.Maximum != 0.0 {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.Float(m.Maximum))
+	}
+	if m.ExclusiveMaximum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMaximum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMaximum))
+	}
+	if m.Minimum != 0.0 {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.Float(m.Minimum))
+	}
+	if m.ExclusiveMinimum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMinimum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMinimum))
+	}
+	if m.MaxLength != 0 {
+		info.Content = append(info.Content, b.Key("maxLength"))
+		info.Content = append(info.Content, b.Int(m.MaxLength))
+	}
+	if m.MinLength != 0 {
+		info.Content = append(info.Content, b.Key("minLength"))
+		info.Content = append(info.Content, b.Int(m.MinLength))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.MaxItems != 0 {
+		info.Content = append(info.Content, b.Key("maxItems"))
+		info.Content = append(info.Content, b.Int(m.MaxItems))
+	}
+	if m.MinItems != 0 {
+		info.Content = append(info.Content, b.Key("minItems"))
+		info.Content = append(info.Content, b.Int(m.MinItems))
+	}
+	if m.UniqueItems != false {
+		info.Content = append(info.Content, b.Key("uniqueItems"))
+		info.Content = append(info.Content, b.Bool(m.UniqueItems))
+	}
+	if len(m.Enum) != 0 {
+		items := b.Sequence(len(m.Enum))
+		for _, item := range m.Enum {
+			items.Content = append(items.Content, rawInfoForAny(item, b))
+		}
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, items)
+	}
+	if m.MultipleOf != 0.0 {
+		info.Content = append(info.Content, b.Key("multipleOf"))
+		info.Content = append(info.Content, b.Float(m.MultipleOf))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForHeaders returns a description of Headers suitable for JSON or YAML export.
+func rawInfoForHeaders(m *Headers, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForHeader(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForInfo returns a description of Info suitable for JSON or YAML export.
+func rawInfoForInfo(m *Info, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(12 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("title"))
+	info.Content = append(info.Content, b.String(m.Title))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("version"))
+	info.Content = append(info.Content, b.String(m.Version))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.TermsOfService != "" {
+		info.Content = append(info.Content, b.Key("termsOfService"))
+		info.Content = append(info.Content, b.String(m.TermsOfService))
+	}
+	if m.Contact != nil {
+		info.Content = append(info.Content, b.Key("contact"))
+		info.Content = append(info.Content, rawInfoForContact(m.Contact, b))
+	}
+	if m.License != nil {
+		info.Content = append(info.Content, b.Key("license"))
+		info.Content = append(info.Content, rawInfoForLicense(m.License, b))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForItemsItem returns a description of ItemsItem suitable for JSON or YAML export.
+func rawInfoForItemsItem(m *ItemsItem, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if len(m.Schema) != 0 {
+		items := b.Sequence(len(m.Schema))
+		for _, item := range m.Schema {
+			items.Content = append(items.Content, rawInfoForSchema(item, b))
+		}
+		info.Content = append(info.Content, b.Key("schema"))
+		info.Content = append(info.Content, items)
+	}
+	return info
+}
+
+// rawInfoForJsonReference returns a description of JsonReference suitable for JSON or YAML export.
+func rawInfoForJsonReference(m *JsonReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("$ref"))
+	info.Content = append(info.Content, b.String(m.XRef))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	return info
+}
+
+// rawInfoForLicense returns a description of License suitable for JSON or YAML export.
+func rawInfoForLicense(m *License, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("name"))
+	info.Content = append(info.Content, b.String(m.Name))
+	if m.Url != "" {
+		info.Content = append(info.Content, b.Key("url"))
+		info.Content = append(info.Content, b.String(m.Url))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForNamedAny returns a description of NamedAny suitable for JSON or YAML export.
+func rawInfoForNamedAny(m *NamedAny, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Value != nil {
+		info.Content = append(info.Content, b.Key("value"))
+		info.Content = append(info.Content, rawInfoForAny(m.Value, b))
+	}
+	return info
+}
+
+// rawInfoForNamedHeader returns a description of NamedHeader suitable for JSON or YAML export.
+func rawInfoForNamedHeader(m *NamedHeader, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Header StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedParameter returns a description of NamedParameter suitable for JSON or YAML export.
+func rawInfoForNamedParameter(m *NamedParameter, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Parameter StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedPathItem returns a description of NamedPathItem suitable for JSON or YAML export.
+func rawInfoForNamedPathItem(m *NamedPathItem, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:PathItem StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedResponse returns a description of NamedResponse suitable for JSON or YAML export.
+func rawInfoForNamedResponse(m *NamedResponse, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Response StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedResponseValue returns a description of NamedResponseValue suitable for JSON or YAML export.
+func rawInfoForNamedResponseValue(m *NamedResponseValue, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:ResponseValue StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedSchema returns a description of NamedSchema suitable for JSON or YAML export.
+func rawInfoForNamedSchema(m *NamedSchema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Schema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedSecurityDefinitionsItem returns a description of NamedSecurityDefinitionsItem suitable for JSON or YAML export.
+func rawInfoForNamedSecurityDefinitionsItem(m *NamedSecurityDefinitionsItem, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:SecurityDefinitionsItem StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedString returns a description of NamedString suitable for JSON or YAML export.
+func rawInfoForNamedString(m *NamedString, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Value != "" {
+		info.Content = append(info.Content, b.Key("value"))
+		info.Content = append(info.Content, b.String(m.Value))
+	}
+	return info
+}
+
+// rawInfoForNamedStringArray returns a description of NamedStringArray suitable for JSON or YAML export.
+func rawInfoForNamedStringArray(m *NamedStringArray, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:StringArray StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNonBodyParameter returns a description of NonBodyParameter suitable for JSON or YAML export.
+func rawInfoForNonBodyParameter(m *NonBodyParameter, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// NonBodyParameter
+	// {Name:headerParameterSubSchema Type:HeaderParameterSubSchema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetHeaderParameterSubSchema()
+	if v0 != nil {
+		return rawInfoForHeaderParameterSubSchema(v0, b)
+	}
+	// {Name:formDataParameterSubSchema Type:FormDataParameterSubSchema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetFormDataParameterSubSchema()
+	if v1 != nil {
+		return rawInfoForFormDataParameterSubSchema(v1, b)
+	}
+	// {Name:queryParameterSubSchema Type:QueryParameterSubSchema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v2 := m.GetQueryParameterSubSchema()
+	if v2 != nil {
+		return rawInfoForQueryParameterSubSchema(v2, b)
+	}
+	// {Name:pathParameterSubSchema Type:PathParameterSubSchema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v3 := m.GetPathParameterSubSchema()
+	if v3 != nil {
+		return rawInfoForPathParameterSubSchema(v3, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForOauth2AccessCodeSecurity returns a description of Oauth2AccessCodeSecurity suitable for JSON or YAML export.
+func rawInfoForOauth2AccessCodeSecurity(m *Oauth2AccessCodeSecurity, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(12 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("flow"))
+	info.Content = append(info.Content, b.String(m.Flow))
+	if m.Scopes != nil {
+		info.Content = append(info.Content, b.Key("scopes"))
+		info.Content = append(info.Content, rawInfoForOauth2Scopes(m.Scopes, b))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("authorizationUrl"))
+	info.Content = append(info.Content, b.String(m.AuthorizationUrl))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("tokenUrl"))
+	info.Content = append(info.Content, b.String(m.TokenUrl))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForOauth2ApplicationSecurity returns a description of Oauth2ApplicationSecurity suitable for JSON or YAML export.
+func rawInfoForOauth2ApplicationSecurity(m *Oauth2ApplicationSecurity, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(10 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("flow"))
+	info.Content = append(info.Content, b.String(m.Flow))
+	if m.Scopes != nil {
+		info.Content = append(info.Content, b.Key("scopes"))
+		info.Content = append(info.Content, rawInfoForOauth2Scopes(m.Scopes, b))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("tokenUrl"))
+	info.Content = append(info.Content, b.String(m.TokenUrl))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForOauth2ImplicitSecurity returns a description of Oauth2ImplicitSecurity suitable for JSON or YAML export.
+func rawInfoForOauth2ImplicitSecurity(m *Oauth2ImplicitSecurity, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(10 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("flow"))
+	info.Content = append(info.Content, b.String(m.Flow))
+	if m.Scopes != nil {
+		info.Content = append(info.Content, b.Key("scopes"))
+		info.Content = append(info.Content, rawInfoForOauth2Scopes(m.Scopes, b))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("authorizationUrl"))
+	info.Content = append(info.Content, b.String(m.AuthorizationUrl))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForOauth2PasswordSecurity returns a description of Oauth2PasswordSecurity suitable for JSON or YAML export.
+func rawInfoForOauth2PasswordSecurity(m *Oauth2PasswordSecurity, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(10 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("flow"))
+	info.Content = append(info.Content, b.String(m.Flow))
+	if m.Scopes != nil {
+		info.Content = append(info.Content, b.Key("scopes"))
+		info.Content = append(info.Content, rawInfoForOauth2Scopes(m.Scopes, b))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("tokenUrl"))
+	info.Content = append(info.Content, b.String(m.TokenUrl))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForOauth2Scopes returns a description of Oauth2Scopes suitable for JSON or YAML export.
+func rawInfoForOauth2Scopes(m *Oauth2Scopes, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, b.String(item.Value))
+		}
+	}
+	return info
+}
+
+// rawInfoForOperation returns a description of Operation suitable for JSON or YAML export.
+func rawInfoForOperation(m *Operation, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	if len(m.Tags) != 0 {
+		info.Content = append(info.Content, b.Key("tags"))
+		info.Content = append(info.Content, b.StringArray(m.Tags))
+	}
+	if m.Summary != "" {
+		info.Content = append(info.Content, b.Key("summary"))
+		info.Content = append(info.Content, b.String(m.Summary))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.OperationId != "" {
+		info.Content = append(info.Content, b.Key("operationId"))
+		info.Content = append(info.Content, b.String(m.OperationId))
+	}
+	if len(m.Produces) != 0 {
+		info.Content = append(info.Content, b.Key("produces"))
+		info.Content = append(info.Content, b.StringArray(m.Produces))
+	}
+	if len(m.Consumes) != 0 {
+		info.Content = append(info.Content, b.Key("consumes"))
+		info.Content = append(info.Content, b.StringArray(m.Consumes))
+	}
+	if len(m.Parameters) != 0 {
+		items := b.Sequence(len(m.Parameters))
+		for _, item := range m.Parameters {
+			items.Content = append(items.Content, rawInfoForParametersItem(item, b))
+		}
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, items)
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("responses"))
+	info.Content = append(info.Content, rawInfoForResponses(m.Responses, b))
+	if len(m.Schemes) != 0 {
+		info.Content = append(info.Content, b.Key("schemes"))
+		info.Content = append(info.Content, b.StringArray(m.Schemes))
+	}
+	if m.Deprecated != false {
+		info.Content = append(info.Content, b.Key("deprecated"))
+		info.Content = append(info.Content, b.Bool(m.Deprecated))
+	}
+	if len(m.Security) != 0 {
+		items := b.Sequence(len(m.Security))
+		for _, item := range m.Security {
+			items.Content = append(items.Content, rawInfoForSecurityRequirement(item, b))
+		}
+		info.Content = append(info.Content, b.Key("security"))
+		info.Content = append(info.Content, items)
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForParameter returns a description of Parameter suitable for JSON or YAML export.
+func rawInfoForParameter(m *Parameter, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// Parameter
+	// {Name:bodyParameter Type:BodyParameter StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetBodyParameter()
+	if v0 != nil {
+		return rawInfoForBodyParameter(v0, b)
+	}
+	// {Name:nonBodyParameter Type:NonBodyParameter StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetNonBodyParameter()
+	if v1 != nil {
+		return rawInfoForNonBodyParameter(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForParameterDefinitions returns a description of ParameterDefinitions suitable for JSON or YAML export.
+func rawInfoForParameterDefinitions(m *ParameterDefinitions, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForParameter(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForParametersItem returns a description of ParametersItem suitable for JSON or YAML export.
+func rawInfoForParametersItem(m *ParametersItem, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// ParametersItem
+	// {Name:parameter Type:Parameter StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetParameter()
+	if v0 != nil {
+		return rawInfoForParameter(v0, b)
+	}
+	// {Name:jsonReference Type:JsonReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetJsonReference()
+	if v1 != nil {
+		return rawInfoForJsonReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForPathItem returns a description of PathItem suitable for JSON or YAML export.
+func rawInfoForPathItem(m *PathItem, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	if m.XRef != "" {
+		info.Content = append(info.Content, b.Key("$ref"))
+		info.Content = append(info.Content, b.String(m.XRef))
+	}
+	if m.Get != nil {
+		info.Content = append(info.Content, b.Key("get"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Get, b))
+	}
+	if m.Put != nil {
+		info.Content = append(info.Content, b.Key("put"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Put, b))
+	}
+	if m.Post != nil {
+		info.Content = append(info.Content, b.Key("post"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Post, b))
+	}
+	if m.Delete != nil {
+		info.Content = append(info.Content, b.Key("delete"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Delete, b))
+	}
+	if m.Options != nil {
+		info.Content = append(info.Content, b.Key("options"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Options, b))
+	}
+	if m.Head != nil {
+		info.Content = append(info.Content, b.Key("head"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Head, b))
+	}
+	if m.Patch != nil {
+		info.Content = append(info.Content, b.Key("patch"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Patch, b))
+	}
+	if len(m.Parameters) != 0 {
+		items := b.Sequence(len(m.Parameters))
+		for _, item := range m.Parameters {
+			items.Content = append(items.Content, rawInfoForParametersItem(item, b))
+		}
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, items)
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForPathParameterSubSchema returns a description of PathParameterSubSchema suitable for JSON or YAML export.
+func rawInfoForPathParameterSubSchema(m *PathParameterSubSchema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("required"))
+	info.Content = append(info.Content, b.Bool(m.Required))
+	if m.In != "" {
+		info.Content = append(info.Content, b.Key("in"))
+		info.Content = append(info.Content, b.String(m.In))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Type != "" {
+		info.Content = append(info.Content, b.Key("type"))
+		info.Content = append(info.Content, b.String(m.Type))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Items != nil {
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, rawInfoForPrimitivesItems(m.Items, b))
+	}
+	if m.CollectionFormat != "" {
+		info.Content = append(info.Content, b.Key("collectionFormat"))
+		info.Content = append(info.Content, b.String(m.CollectionFormat))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForAny(m.Default, b))
+	}
+	if m.Maximum != 0.0 {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.Float(m.Maximum))
+	}
+	if m.ExclusiveMaximum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMaximum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMaximum))
+	}
+	if m.Minimum != 0.0 {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.Float(m.Minimum))
+	}
+	if m.ExclusiveMinimum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMinimum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMinimum))
+	}
+	if m.MaxLength != 0 {
+		info.Content = append(info.Content, b.Key("maxLength"))
+		info.Content = append(info.Content, b.Int(m.MaxLength))
+	}
+	if m.MinLength != 0 {
+		info.Content = append(info.Content, b.Key("minLength"))
+		info.Content = append(info.Content, b.Int(m.MinLength))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.MaxItems != 0 {
+		info.Content = append(info.Content, b.Key("maxItems"))
+		info.Content = append(info.Content, b.Int(m.MaxItems))
+	}
+	if m.MinItems != 0 {
+		info.Content = append(info.Content, b.Key("minItems"))
+		info.Content = append(info.Content, b.Int(m.MinItems))
+	}
+	if m.UniqueItems != false {
+		info.Content = append(info.Content, b.Key("uniqueItems"))
+		info.Content = append(info.Content, b.Bool(m.UniqueItems))
+	}
+	if len(m.Enum) != 0 {
+		items := b.Sequence(len(m.Enum))
+		for _, item := range m.Enum {
+			items.Content = append(items.Content, rawInfoForAny(item, b))
+		}
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, items)
+	}
+	if m.MultipleOf != 0.0 {
+		info.Content = append(info.Content, b.Key("multipleOf"))
+		info.Content = append(info.Content, b.Float(m.MultipleOf))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForPaths returns a description of Paths suitable for JSON or YAML export.
+func rawInfoForPaths(m *Paths, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2*len(m.VendorExtension) + 2*len(m.Path))
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	if m.Path != nil {
+		for _, item := range m.Path {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForPathItem(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForPrimitivesItems returns a description of PrimitivesItems suitable for JSON or YAML export.
+func rawInfoForPrimitivesItems(m *PrimitivesItems, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	if m.Type != "" {
+		info.Content = append(info.Content, b.Key("type"))
+		info.Content = append(info.Content, b.String(m.Type))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Items != nil {
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, rawInfoForPrimitivesItems(m.Items, b))
+	}
+	if m.CollectionFormat != "" {
+		info.Content = append(info.Content, b.Key("collectionFormat"))
+		info.Content = append(info.Content, b.String(m.CollectionFormat))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForAny(m.Default, b))
+	}
+	if m.Maximum != 0.0 {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.Float(m.Maximum))
+	}
+	if m.ExclusiveMaximum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMaximum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMaximum))
+	}
+	if m.Minimum != 0.0 {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.Float(m.Minimum))
+	}
+	if m.ExclusiveMinimum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMinimum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMinimum))
+	}
+	if m.MaxLength != 0 {
+		info.Content = append(info.Content, b.Key("maxLength"))
+		info.Content = append(info.Content, b.Int(m.MaxLength))
+	}
+	if m.MinLength != 0 {
+		info.Content = append(info.Content, b.Key("minLength"))
+		info.Content = append(info.Content, b.Int(m.MinLength))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.MaxItems != 0 {
+		info.Content = append(info.Content, b.Key("maxItems"))
+		info.Content = append(info.Content, b.Int(m.MaxItems))
+	}
+	if m.MinItems != 0 {
+		info.Content = append(info.Content, b.Key("minItems"))
+		info.Content = append(info.Content, b.Int(m.MinItems))
+	}
+	if m.UniqueItems != false {
+		info.Content = append(info.Content, b.Key("uniqueItems"))
+		info.Content = append(info.Content, b.Bool(m.UniqueItems))
+	}
+	if len(m.Enum) != 0 {
+		items := b.Sequence(len(m.Enum))
+		for _, item := range m.Enum {
+			items.Content = append(items.Content, rawInfoForAny(item, b))
+		}
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, items)
+	}
+	if m.MultipleOf != 0.0 {
+		info.Content = append(info.Content, b.Key("multipleOf"))
+		info.Content = append(info.Content, b.Float(m.MultipleOf))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForProperties returns a description of Properties suitable for JSON or YAML export.
+func rawInfoForProperties(m *Properties, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForSchema(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForQueryParameterSubSchema returns a description of QueryParameterSubSchema suitable for JSON or YAML export.
+func rawInfoForQueryParameterSubSchema(m *QueryParameterSubSchema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	if m.In != "" {
+		info.Content = append(info.Content, b.Key("in"))
+		info.Content = append(info.Content, b.String(m.In))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.AllowEmptyValue != false {
+		info.Content = append(info.Content, b.Key("allowEmptyValue"))
+		info.Content = append(info.Content, b.Bool(m.AllowEmptyValue))
+	}
+	if m.Type != "" {
+		info.Content = append(info.Content, b.Key("type"))
+		info.Content = append(info.Content, b.String(m.Type))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Items != nil {
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, rawInfoForPrimitivesItems(m.Items, b))
+	}
+	if m.CollectionFormat != "" {
+		info.Content = append(info.Content, b.Key("collectionFormat"))
+		info.Content = append(info.Content, b.String(m.CollectionFormat))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForAny(m.Default, b))
+	}
+	if m.Maximum != 0.0 {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.Float(m.Maximum))
+	}
+	if m.ExclusiveMaximum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMaximum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMaximum))
+	}
+	if m.Minimum != 0.0 {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.Float(m.Minimum))
+	}
+	if m.ExclusiveMinimum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMinimum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMinimum))
+	}
+	if m.MaxLength != 0 {
+		info.Content = append(info.Content, b.Key("maxLength"))
+		info.Content = append(info.Content, b.Int(m.MaxLength))
+	}
+	if m.MinLength != 0 {
+		info.Content = append(info.Content, b.Key("minLength"))
+		info.Content = append(info.Content, b.Int(m.MinLength))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.MaxItems != 0 {
+		info.Content = append(info.Content, b.Key("maxItems"))
+		info.Content = append(info.Content, b.Int(m.MaxItems))
+	}
+	if m.MinItems != 0 {
+		info.Content = append(info.Content, b.Key("minItems"))
+		info.Content = append(info.Content, b.Int(m.MinItems))
+	}
+	if m.UniqueItems != false {
+		info.Content = append(info.Content, b.Key("uniqueItems"))
+		info.Content = append(info.Content, b.Bool(m.UniqueItems))
+	}
+	if len(m.Enum) != 0 {
+		items := b.Sequence(len(m.Enum))
+		for _, item := range m.Enum {
+			items.Content = append(items.Content, rawInfoForAny(item, b))
+		}
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, items)
+	}
+	if m.MultipleOf != 0.0 {
+		info.Content = append(info.Content, b.Key("multipleOf"))
+		info.Content = append(info.Content, b.Float(m.MultipleOf))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForResponse returns a description of Response suitable for JSON or YAML export.
+func rawInfoForResponse(m *Response, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(8 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("description"))
+	info.Content = append(info.Content, b.String(m.Description))
+	if m.Schema != nil {
+		info.Content = append(info.Content, b.Key("schema"))
+		info.Content = append(info.Content, rawInfoForSchemaItem(m.Schema, b))
+	}
+	if m.Headers != nil {
+		info.Content = append(info.Content, b.Key("headers"))
+		info.Content = append(info.Content, rawInfoForHeaders(m.Headers, b))
+	}
+	if m.Examples != nil {
+		info.Content = append(info.Content, b.Key("examples"))
+		info.Content = append(info.Content, rawInfoForExamples(m.Examples, b))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForResponseDefinitions returns a description of ResponseDefinitions suitable for JSON or YAML export.
+func rawInfoForResponseDefinitions(m *ResponseDefinitions, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForResponse(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForResponseValue returns a description of ResponseValue suitable for JSON or YAML export.
+func rawInfoForResponseValue(m *ResponseValue, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// ResponseValue
+	// {Name:response Type:Response StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetResponse()
+	if v0 != nil {
+		return rawInfoForResponse(v0, b)
+	}
+	// {Name:jsonReference Type:JsonReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetJsonReference()
+	if v1 != nil {
+		return rawInfoForJsonReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForResponses returns a description of Responses suitable for JSON or YAML export.
+func rawInfoForResponses(m *Responses, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2*len(m.ResponseCode) + 2*len(m.VendorExtension))
+	if m.ResponseCode != nil {
+		for _, item := range m.ResponseCode {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForResponseValue(item.Value, b))
+		}
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSchema returns a description of Schema suitable for JSON or YAML export.
+func rawInfoForSchema(m *Schema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.VendorExtension))
+	if m.XRef != "" {
+		info.Content = append(info.Content, b.Key("$ref"))
+		info.Content = append(info.Content, b.String(m.XRef))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.Title != "" {
+		info.Content = append(info.Content, b.Key("title"))
+		info.Content = append(info.Content, b.String(m.Title))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForAny(m.Default, b))
+	}
+	if m.MultipleOf != 0.0 {
+		info.Content = append(info.Content, b.Key("multipleOf"))
+		info.Content = append(info.Content, b.Float(m.MultipleOf))
+	}
+	if m.Maximum != 0.0 {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.Float(m.Maximum))
+	}
+	if m.ExclusiveMaximum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMaximum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMaximum))
+	}
+	if m.Minimum != 0.0 {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.Float(m.Minimum))
+	}
+	if m.ExclusiveMinimum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMinimum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMinimum))
+	}
+	if m.MaxLength != 0 {
+		info.Content = append(info.Content, b.Key("maxLength"))
+		info.Content = append(info.Content, b.Int(m.MaxLength))
+	}
+	if m.MinLength != 0 {
+		info.Content = append(info.Content, b.Key("minLength"))
+		info.Content = append(info.Content, b.Int(m.MinLength))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.MaxItems != 0 {
+		info.Content = append(info.Content, b.Key("maxItems"))
+		info.Content = append(info.Content, b.Int(m.MaxItems))
+	}
+	if m.MinItems != 0 {
+		info.Content = append(info.Content, b.Key("minItems"))
+		info.Content = append(info.Content, b.Int(m.MinItems))
+	}
+	if m.UniqueItems != false {
+		info.Content = append(info.Content, b.Key("uniqueItems"))
+		info.Content = append(info.Content, b.Bool(m.UniqueItems))
+	}
+	if m.MaxProperties != 0 {
+		info.Content = append(info.Content, b.Key("maxProperties"))
+		info.Content = append(info.Content, b.Int(m.MaxProperties))
+	}
+	if m.MinProperties != 0 {
+		info.Content = append(info.Content, b.Key("minProperties"))
+		info.Content = append(info.Content, b.Int(m.MinProperties))
+	}
+	if len(m.Required) != 0 {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.StringArray(m.Required))
+	}
+	if len(m.Enum) != 0 {
+		items := b.Sequence(len(m.Enum))
+		for _, item := range m.Enum {
+			items.Content = append(items.Content, rawInfoForAny(item, b))
+		}
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, items)
+	}
+	if m.AdditionalProperties != nil {
+		info.Content = append(info.Content, b.Key("additionalProperties"))
+		info.Content = append(info.Content, rawInfoForAdditionalPropertiesItem(m.AdditionalProperties, b))
+	}
+	if m.Type != nil {
+		if len(m.Type.Value) == 1 {
+			info.Content = append(info.Content, b.Key("type"))
+			info.Content = append(info.Content, b.String(m.Type.Value[0]))
+		} else {
+			info.Content = append(info.Content, b.Key("type"))
+			info.Content = append(info.Content, b.StringArray(m.Type.Value))
+		}
+	}
+	if m.Items != nil {
+		items := b.Sequence(len(m.Items.Schema))
+		for _, item := range m.Items.Schema {
+			items.Content = append(items.Content, rawInfoForSchema(item, b))
+		}
+		if len(items.Content) == 1 {
+			items = items.Content[0]
+		}
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, items)
+	}
+	if len(m.AllOf) != 0 {
+		items := b.Sequence(len(m.AllOf))
+		for _, item := range m.AllOf {
+			items.Content = append(items.Content, rawInfoForSchema(item, b))
+		}
+		info.Content = append(info.Content, b.Key("allOf"))
+		info.Content = append(info.Content, items)
+	}
+	if m.Properties != nil {
+		info.Content = append(info.Content, b.Key("properties"))
+		info.Content = append(info.Content, rawInfoForProperties(m.Properties, b))
+	}
+	if m.Discriminator != "" {
+		info.Content = append(info.Content, b.Key("discriminator"))
+		info.Content = append(info.Content, b.String(m.Discriminator))
+	}
+	if m.ReadOnly != false {
+		info.Content = append(info.Content, b.Key("readOnly"))
+		info.Content = append(info.Content, b.Bool(m.ReadOnly))
+	}
+	if m.Xml != nil {
+		info.Content = append(info.Content, b.Key("xml"))
+		info.Content = append(info.Content, rawInfoForXml(m.Xml, b))
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.Example != nil {
+		info.Content = append(info.Content, b.Key("example"))
+		info.Content = append(info.Content, rawInfoForAny(m.Example, b))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSchemaItem returns a description of SchemaItem suitable for JSON or YAML export.
+func rawInfoForSchemaItem(m *SchemaItem, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// SchemaItem
+	// {Name:schema Type:Schema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetSchema()
+	if v0 != nil {
+		return rawInfoForSchema(v0, b)
+	}
+	// {Name:fileSchema Type:FileSchema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetFileSchema()
+	if v1 != nil {
+		return rawInfoForFileSchema(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForSecurityDefinitions returns a description of SecurityDefinitions suitable for JSON or YAML export.
+func rawInfoForSecurityDefinitions(m *SecurityDefinitions, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForSecurityDefinitionsItem(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSecurityDefinitionsItem returns a description of SecurityDefinitionsItem suitable for JSON or YAML export.
+func rawInfoForSecurityDefinitionsItem(m *SecurityDefinitionsItem, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// SecurityDefinitionsItem
+	// {Name:basicAuthenticationSecurity Type:BasicAuthenticationSecurity StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetBasicAuthenticationSecurity()
+	if v0 != nil {
+		return rawInfoForBasicAuthenticationSecurity(v0, b)
+	}
+	// {Name:apiKeySecurity Type:ApiKeySecurity StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetApiKeySecurity()
+	if v1 != nil {
+		return rawInfoForApiKeySecurity(v1, b)
+	}
+	// {Name:oauth2ImplicitSecurity Type:Oauth2ImplicitSecurity StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v2 := m.GetOauth2ImplicitSecurity()
+	if v2 != nil {
+		return rawInfoForOauth2ImplicitSecurity(v2, b)
+	}
+	// {Name:oauth2PasswordSecurity Type:Oauth2PasswordSecurity StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v3 := m.GetOauth2PasswordSecurity()
+	if v3 != nil {
+		return rawInfoForOauth2PasswordSecurity(v3, b)
+	}
+	// {Name:oauth2ApplicationSecurity Type:Oauth2ApplicationSecurity StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v4 := m.GetOauth2ApplicationSecurity()
+	if v4 != nil {
+		return rawInfoForOauth2ApplicationSecurity(v4, b)
+	}
+	// {Name:oauth2AccessCodeSecurity Type:Oauth2AccessCodeSecurity StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v5 := m.GetOauth2AccessCodeSecurity()
+	if v5 != nil {
+		return rawInfoForOauth2AccessCodeSecurity(v5, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForSecurityRequirement returns a description of SecurityRequirement suitable for JSON or YAML export.
+func rawInfoForSecurityRequirement(m *SecurityRequirement, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForStringArray(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForStringArray returns a description of StringArray suitable for JSON or YAML export.
+func rawInfoForStringArray(m *StringArray, b *compiler.NodeBuilder) *yaml.Node {
+	return b.StringArray(m.Value)
+}
+
+// rawInfoForTag returns a description of Tag suitable for JSON or YAML export.
+func rawInfoForTag(m *Tag, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(6 + 2*len(m.VendorExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("name"))
+	info.Content = append(info.Content, b.String(m.Name))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForTypeItem returns a description of TypeItem suitable for JSON or YAML export.
+func rawInfoForTypeItem(m *TypeItem, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if len(m.Value) != 0 {
+		info.Content = append(info.Content, b.Key("value"))
+		info.Content = append(info.Content, b.StringArray(m.Value))
+	}
+	return info
+}
+
+// rawInfoForVendorExtension returns a description of VendorExtension suitable for JSON or YAML export.
+func rawInfoForVendorExtension(m *VendorExtension, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForXml returns a description of Xml suitable for JSON or YAML export.
+func rawInfoForXml(m *Xml, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(10 + 2*len(m.VendorExtension))
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Namespace != "" {
+		info.Content = append(info.Content, b.Key("namespace"))
+		info.Content = append(info.Content, b.String(m.Namespace))
+	}
+	if m.Prefix != "" {
+		info.Content = append(info.Content, b.Key("prefix"))
+		info.Content = append(info.Content, b.String(m.Prefix))
+	}
+	if m.Attribute != false {
+		info.Content = append(info.Content, b.Key("attribute"))
+		info.Content = append(info.Content, b.Bool(m.Attribute))
+	}
+	if m.Wrapped != false {
+		info.Content = append(info.Content, b.Key("wrapped"))
+		info.Content = append(info.Content, b.Bool(m.Wrapped))
+	}
+	if m.VendorExtension != nil {
+		for _, item := range m.VendorExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
 var (
-	pattern0 = regexp.MustCompile("^x-")
-	pattern1 = regexp.MustCompile("^/")
-	pattern2 = regexp.MustCompile("^([0-9]{3})$|^(default)$")
+	pattern0                                   = regexp.MustCompile("^x-")
+	pattern1                                   = regexp.MustCompile("^/")
+	pattern2                                   = regexp.MustCompile("^([0-9]{3})$|^(default)$")
+	allowedKeysForApiKeySecurity               = compiler.NewKeySet([]string{"description", "in", "name", "type"}, []*regexp.Regexp{pattern0})
+	allowedKeysForBasicAuthenticationSecurity  = compiler.NewKeySet([]string{"description", "type"}, []*regexp.Regexp{pattern0})
+	allowedKeysForBodyParameter                = compiler.NewKeySet([]string{"description", "in", "name", "required", "schema"}, []*regexp.Regexp{pattern0})
+	allowedKeysForContact                      = compiler.NewKeySet([]string{"email", "name", "url"}, []*regexp.Regexp{pattern0})
+	allowedKeysForDocument                     = compiler.NewKeySet([]string{"basePath", "consumes", "definitions", "externalDocs", "host", "info", "parameters", "paths", "produces", "responses", "schemes", "security", "securityDefinitions", "swagger", "tags"}, []*regexp.Regexp{pattern0})
+	allowedKeysForExternalDocs                 = compiler.NewKeySet([]string{"description", "url"}, []*regexp.Regexp{pattern0})
+	allowedKeysForFileSchema                   = compiler.NewKeySet([]string{"default", "description", "example", "externalDocs", "format", "readOnly", "required", "title", "type"}, []*regexp.Regexp{pattern0})
+	allowedKeysForFormDataParameterSubSchema   = compiler.NewKeySet([]string{"allowEmptyValue", "collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "in", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "name", "pattern", "required", "type", "uniqueItems"}, []*regexp.Regexp{pattern0})
+	allowedKeysForHeader                       = compiler.NewKeySet([]string{"collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "pattern", "type", "uniqueItems"}, []*regexp.Regexp{pattern0})
+	allowedKeysForHeaderParameterSubSchema     = compiler.NewKeySet([]string{"collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "in", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "name", "pattern", "required", "type", "uniqueItems"}, []*regexp.Regexp{pattern0})
+	allowedKeysForInfo                         = compiler.NewKeySet([]string{"contact", "description", "license", "termsOfService", "title", "version"}, []*regexp.Regexp{pattern0})
+	allowedKeysForLicense                      = compiler.NewKeySet([]string{"name", "url"}, []*regexp.Regexp{pattern0})
+	allowedKeysForNamedAny                     = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedHeader                  = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedParameter               = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedPathItem                = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedResponse                = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedResponseValue           = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedSchema                  = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedSecurityDefinitionsItem = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedString                  = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedStringArray             = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForOauth2AccessCodeSecurity     = compiler.NewKeySet([]string{"authorizationUrl", "description", "flow", "scopes", "tokenUrl", "type"}, []*regexp.Regexp{pattern0})
+	allowedKeysForOauth2ApplicationSecurity    = compiler.NewKeySet([]string{"description", "flow", "scopes", "tokenUrl", "type"}, []*regexp.Regexp{pattern0})
+	allowedKeysForOauth2ImplicitSecurity       = compiler.NewKeySet([]string{"authorizationUrl", "description", "flow", "scopes", "type"}, []*regexp.Regexp{pattern0})
+	allowedKeysForOauth2PasswordSecurity       = compiler.NewKeySet([]string{"description", "flow", "scopes", "tokenUrl", "type"}, []*regexp.Regexp{pattern0})
+	allowedKeysForOperation                    = compiler.NewKeySet([]string{"consumes", "deprecated", "description", "externalDocs", "operationId", "parameters", "produces", "responses", "schemes", "security", "summary", "tags"}, []*regexp.Regexp{pattern0})
+	allowedKeysForPathItem                     = compiler.NewKeySet([]string{"$ref", "delete", "get", "head", "options", "parameters", "patch", "post", "put"}, []*regexp.Regexp{pattern0})
+	allowedKeysForPathParameterSubSchema       = compiler.NewKeySet([]string{"collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "in", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "name", "pattern", "required", "type", "uniqueItems"}, []*regexp.Regexp{pattern0})
+	allowedKeysForPaths                        = compiler.NewKeySet([]string{}, []*regexp.Regexp{pattern0, pattern1})
+	allowedKeysForPrimitivesItems              = compiler.NewKeySet([]string{"collectionFormat", "default", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "pattern", "type", "uniqueItems"}, []*regexp.Regexp{pattern0})
+	allowedKeysForQueryParameterSubSchema      = compiler.NewKeySet([]string{"allowEmptyValue", "collectionFormat", "default", "description", "enum", "exclusiveMaximum", "exclusiveMinimum", "format", "in", "items", "maxItems", "maxLength", "maximum", "minItems", "minLength", "minimum", "multipleOf", "name", "pattern", "required", "type", "uniqueItems"}, []*regexp.Regexp{pattern0})
+	allowedKeysForResponse                     = compiler.NewKeySet([]string{"description", "examples", "headers", "schema"}, []*regexp.Regexp{pattern0})
+	allowedKeysForResponses                    = compiler.NewKeySet([]string{}, []*regexp.Regexp{pattern2, pattern0})
+	allowedKeysForSchema                       = compiler.NewKeySet([]string{"$ref", "additionalProperties", "allOf", "default", "description", "discriminator", "enum", "example", "exclusiveMaximum", "exclusiveMinimum", "externalDocs", "format", "items", "maxItems", "maxLength", "maxProperties", "maximum", "minItems", "minLength", "minProperties", "minimum", "multipleOf", "pattern", "properties", "readOnly", "required", "title", "type", "uniqueItems", "xml"}, []*regexp.Regexp{pattern0})
+	allowedKeysForTag                          = compiler.NewKeySet([]string{"description", "externalDocs", "name"}, []*regexp.Regexp{pattern0})
+	allowedKeysForXml                          = compiler.NewKeySet([]string{"attribute", "name", "namespace", "prefix", "wrapped"}, []*regexp.Regexp{pattern0})
 )
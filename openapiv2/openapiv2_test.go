@@ -15,8 +15,15 @@
 package openapi_v2
 
 import (
+	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
 )
 
 func TestParseDocument(t *testing.T) {
@@ -36,3 +43,95 @@ func TestParseDocument(t *testing.T) {
 		t.Errorf("unexpected value for Title: %s (expected %s)", d.Info.Title, title)
 	}
 }
+
+func TestValidateDocument(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte(`swagger: "2.0"
+info: {title: Paths, version: 1.0.0}
+paths:
+  /users/{user}/repos/{repo}: {}
+  /users/{owner}/repos/{name}: {}
+  /users/{user}/repos: {}
+`), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	root := node.Content[0]
+	context := compiler.NewContextWithExtensions("$root", root, nil, nil)
+	d, err := NewDocument(root, context)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	err = ValidateDocument(d, context)
+	if err == nil || compiler.FormatErrors("paths.yaml", err) != "paths.yaml:5:32: $root.paths./users/{owner}/repos/{name} is equivalent to /users/{user}/repos/{repo}" {
+		t.Errorf("unexpected error %v", err)
+	}
+	// Without a context, errors have no locations.
+	err = ValidateDocument(d, nil)
+	if err == nil || err.Error() != "$root.paths./users/{owner}/repos/{name} is equivalent to /users/{user}/repos/{repo}" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// TestRawInfo checks that descriptions made by RawInfo compile back into
+// the documents that they describe.
+func TestRawInfo(t *testing.T) {
+	filenames, err := filepath.Glob("../examples/v2.0/yaml/*.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, filename := range filenames {
+		b, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		d, err := ParseDocument(b)
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		b, err = yaml.Marshal(RawInfo(d))
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		d2, err := ParseDocument(b)
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		if !proto.Equal(d, d2) {
+			t.Errorf("%s: description differs from the document:\n%s", filename, b)
+		}
+	}
+}
+
+// The benchmarks compare the allocations made by ToRawInfo and RawInfo.
+func benchmarkRawInfo(b *testing.B, rawInfo func(*Document) *yaml.Node) {
+	bytes, err := ioutil.ReadFile("../examples/v2.0/yaml/uber.yaml")
+	if err != nil {
+		b.Fatalf("%+v", err)
+	}
+	d, err := ParseDocument(bytes)
+	if err != nil {
+		b.Fatalf("%+v", err)
+	}
+	// Repeat the paths to describe a larger API.
+	paths := d.Paths.Path
+	for i := 0; i < 100; i++ {
+		for _, path := range paths {
+			path = proto.Clone(path).(*NamedPathItem)
+			path.Name = fmt.Sprintf("/v%d%s", i, path.Name)
+			d.Paths.Path = append(d.Paths.Path, path)
+		}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rawInfo(d)
+	}
+}
+
+func BenchmarkToRawInfo(b *testing.B) {
+	benchmarkRawInfo(b, (*Document).ToRawInfo)
+}
+
+func BenchmarkRawInfo(b *testing.B) {
+	benchmarkRawInfo(b, RawInfo)
+}
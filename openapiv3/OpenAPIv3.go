@@ -129,9 +129,7 @@ func NewCallback(in *yaml.Node, context *compiler.Context) (*Callback, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{}
-		allowedPatterns := []*regexp.Regexp{pattern0, pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForCallback)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -140,7 +138,7 @@ func NewCallback(in *yaml.Node, context *compiler.Context) (*Callback, error) {
 		// MAP: PathItem ^
 		x.Path = make([]*NamedPathItem, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if true {
@@ -159,7 +157,7 @@ func NewCallback(in *yaml.Node, context *compiler.Context) (*Callback, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -247,7 +245,7 @@ func NewCallbacksOrReferences(in *yaml.Node, context *compiler.Context) (*Callba
 		// MAP: CallbackOrReference
 		x.AdditionalProperties = make([]*NamedCallbackOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedCallbackOrReference{}
@@ -273,9 +271,7 @@ func NewComponents(in *yaml.Node, context *compiler.Context) (*Components, error
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"callbacks", "examples", "headers", "links", "parameters", "requestBodies", "responses", "schemas", "securitySchemes"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForComponents)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -365,7 +361,7 @@ func NewComponents(in *yaml.Node, context *compiler.Context) (*Components, error
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -405,9 +401,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"email", "name", "url"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForContact)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -415,7 +409,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -424,7 +418,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// string url = 2;
 		v2 := compiler.MapValueForKey(m, "url")
 		if v2 != nil {
-			x.Url, ok = compiler.StringForScalarNode(v2)
+			x.Url, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for url: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -433,7 +427,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// string email = 3;
 		v3 := compiler.MapValueForKey(m, "email")
 		if v3 != nil {
-			x.Email, ok = compiler.StringForScalarNode(v3)
+			x.Email, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for email: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -443,7 +437,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -484,18 +478,14 @@ func NewDefaultType(in *yaml.Node, context *compiler.Context) (*DefaultType, err
 		var v bool
 		v, matched = compiler.BoolForScalarNode(in)
 		x.Oneof = &DefaultType_Boolean{Boolean: v}
-	case "!!str":
+	case "!!str", "!!timestamp":
 		var v string
-		v, matched = compiler.StringForScalarNode(in)
+		v, matched = compiler.StringForScalarNodeWithContext(in, context)
 		x.Oneof = &DefaultType_String_{String_: v}
-	case "!!float":
+	case "!!float", "!!int":
 		var v float64
 		v, matched = compiler.FloatForScalarNode(in)
 		x.Oneof = &DefaultType_Number{Number: v}
-	case "!!int":
-		var v int64
-		v, matched = compiler.IntForScalarNode(in)
-		x.Oneof = &DefaultType_Number{Number: float64(v)}
 	}
 	if matched {
 		// since the oneof matched one of its possibilities, discard any matching errors
@@ -519,9 +509,7 @@ func NewDiscriminator(in *yaml.Node, context *compiler.Context) (*Discriminator,
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"mapping", "propertyName"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForDiscriminator)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -529,7 +517,7 @@ func NewDiscriminator(in *yaml.Node, context *compiler.Context) (*Discriminator,
 		// string property_name = 1;
 		v1 := compiler.MapValueForKey(m, "propertyName")
 		if v1 != nil {
-			x.PropertyName, ok = compiler.StringForScalarNode(v1)
+			x.PropertyName, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for propertyName: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -548,7 +536,7 @@ func NewDiscriminator(in *yaml.Node, context *compiler.Context) (*Discriminator,
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -594,9 +582,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"components", "externalDocs", "info", "openapi", "paths", "security", "servers", "tags"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForDocument)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -604,7 +590,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// string openapi = 1;
 		v1 := compiler.MapValueForKey(m, "openapi")
 		if v1 != nil {
-			x.Openapi, ok = compiler.StringForScalarNode(v1)
+			x.Openapi, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for openapi: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -698,7 +684,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -738,9 +724,7 @@ func NewEncoding(in *yaml.Node, context *compiler.Context) (*Encoding, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"allowReserved", "contentType", "explode", "headers", "style"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForEncoding)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -748,7 +732,7 @@ func NewEncoding(in *yaml.Node, context *compiler.Context) (*Encoding, error) {
 		// string content_type = 1;
 		v1 := compiler.MapValueForKey(m, "contentType")
 		if v1 != nil {
-			x.ContentType, ok = compiler.StringForScalarNode(v1)
+			x.ContentType, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for contentType: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -766,7 +750,7 @@ func NewEncoding(in *yaml.Node, context *compiler.Context) (*Encoding, error) {
 		// string style = 3;
 		v3 := compiler.MapValueForKey(m, "style")
 		if v3 != nil {
-			x.Style, ok = compiler.StringForScalarNode(v3)
+			x.Style, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for style: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -794,7 +778,7 @@ func NewEncoding(in *yaml.Node, context *compiler.Context) (*Encoding, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -838,7 +822,7 @@ func NewEncodings(in *yaml.Node, context *compiler.Context) (*Encodings, error)
 		// MAP: Encoding
 		x.AdditionalProperties = make([]*NamedEncoding, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedEncoding{}
@@ -864,9 +848,7 @@ func NewExample(in *yaml.Node, context *compiler.Context) (*Example, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"description", "externalValue", "summary", "value"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForExample)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -874,7 +856,7 @@ func NewExample(in *yaml.Node, context *compiler.Context) (*Example, error) {
 		// string summary = 1;
 		v1 := compiler.MapValueForKey(m, "summary")
 		if v1 != nil {
-			x.Summary, ok = compiler.StringForScalarNode(v1)
+			x.Summary, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for summary: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -883,7 +865,7 @@ func NewExample(in *yaml.Node, context *compiler.Context) (*Example, error) {
 		// string description = 2;
 		v2 := compiler.MapValueForKey(m, "description")
 		if v2 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v2)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -901,7 +883,7 @@ func NewExample(in *yaml.Node, context *compiler.Context) (*Example, error) {
 		// string external_value = 4;
 		v4 := compiler.MapValueForKey(m, "externalValue")
 		if v4 != nil {
-			x.ExternalValue, ok = compiler.StringForScalarNode(v4)
+			x.ExternalValue, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for externalValue: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -911,7 +893,7 @@ func NewExample(in *yaml.Node, context *compiler.Context) (*Example, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -999,7 +981,7 @@ func NewExamplesOrReferences(in *yaml.Node, context *compiler.Context) (*Example
 		// MAP: ExampleOrReference
 		x.AdditionalProperties = make([]*NamedExampleOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedExampleOrReference{}
@@ -1029,7 +1011,7 @@ func NewExpression(in *yaml.Node, context *compiler.Context) (*Expression, error
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedAny{}
@@ -1073,9 +1055,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "url"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForExternalDocs)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1083,7 +1063,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 		// string description = 1;
 		v1 := compiler.MapValueForKey(m, "description")
 		if v1 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v1)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1092,7 +1072,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 		// string url = 2;
 		v2 := compiler.MapValueForKey(m, "url")
 		if v2 != nil {
-			x.Url, ok = compiler.StringForScalarNode(v2)
+			x.Url, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for url: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1102,7 +1082,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1142,9 +1122,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"allowEmptyValue", "allowReserved", "content", "deprecated", "description", "example", "examples", "explode", "required", "schema", "style"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForHeader)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1152,7 +1130,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// string description = 1;
 		v1 := compiler.MapValueForKey(m, "description")
 		if v1 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v1)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1188,7 +1166,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// string style = 5;
 		v5 := compiler.MapValueForKey(m, "style")
 		if v5 != nil {
-			x.Style, ok = compiler.StringForScalarNode(v5)
+			x.Style, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for style: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1252,7 +1230,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1340,7 +1318,7 @@ func NewHeadersOrReferences(in *yaml.Node, context *compiler.Context) (*HeadersO
 		// MAP: HeaderOrReference
 		x.AdditionalProperties = make([]*NamedHeaderOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedHeaderOrReference{}
@@ -1372,9 +1350,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"contact", "description", "license", "summary", "termsOfService", "title", "version"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForInfo)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1382,7 +1358,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string title = 1;
 		v1 := compiler.MapValueForKey(m, "title")
 		if v1 != nil {
-			x.Title, ok = compiler.StringForScalarNode(v1)
+			x.Title, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for title: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1391,7 +1367,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string description = 2;
 		v2 := compiler.MapValueForKey(m, "description")
 		if v2 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v2)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1400,7 +1376,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string terms_of_service = 3;
 		v3 := compiler.MapValueForKey(m, "termsOfService")
 		if v3 != nil {
-			x.TermsOfService, ok = compiler.StringForScalarNode(v3)
+			x.TermsOfService, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for termsOfService: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1427,7 +1403,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string version = 6;
 		v6 := compiler.MapValueForKey(m, "version")
 		if v6 != nil {
-			x.Version, ok = compiler.StringForScalarNode(v6)
+			x.Version, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for version: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1436,7 +1412,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// string summary = 7;
 		v7 := compiler.MapValueForKey(m, "summary")
 		if v7 != nil {
-			x.Summary, ok = compiler.StringForScalarNode(v7)
+			x.Summary, ok = compiler.StringForScalarNodeWithContext(v7, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for summary: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1446,7 +1422,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1511,9 +1487,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"name", "url"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForLicense)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1521,7 +1495,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1530,7 +1504,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 		// string url = 2;
 		v2 := compiler.MapValueForKey(m, "url")
 		if v2 != nil {
-			x.Url, ok = compiler.StringForScalarNode(v2)
+			x.Url, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for url: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1540,7 +1514,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1580,9 +1554,7 @@ func NewLink(in *yaml.Node, context *compiler.Context) (*Link, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"description", "operationId", "operationRef", "parameters", "requestBody", "server"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForLink)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1590,7 +1562,7 @@ func NewLink(in *yaml.Node, context *compiler.Context) (*Link, error) {
 		// string operation_ref = 1;
 		v1 := compiler.MapValueForKey(m, "operationRef")
 		if v1 != nil {
-			x.OperationRef, ok = compiler.StringForScalarNode(v1)
+			x.OperationRef, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for operationRef: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1599,7 +1571,7 @@ func NewLink(in *yaml.Node, context *compiler.Context) (*Link, error) {
 		// string operation_id = 2;
 		v2 := compiler.MapValueForKey(m, "operationId")
 		if v2 != nil {
-			x.OperationId, ok = compiler.StringForScalarNode(v2)
+			x.OperationId, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for operationId: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1626,7 +1598,7 @@ func NewLink(in *yaml.Node, context *compiler.Context) (*Link, error) {
 		// string description = 5;
 		v5 := compiler.MapValueForKey(m, "description")
 		if v5 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v5)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1645,7 +1617,7 @@ func NewLink(in *yaml.Node, context *compiler.Context) (*Link, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1733,7 +1705,7 @@ func NewLinksOrReferences(in *yaml.Node, context *compiler.Context) (*LinksOrRef
 		// MAP: LinkOrReference
 		x.AdditionalProperties = make([]*NamedLinkOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedLinkOrReference{}
@@ -1759,9 +1731,7 @@ func NewMediaType(in *yaml.Node, context *compiler.Context) (*MediaType, error)
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"encoding", "example", "examples", "schema"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForMediaType)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1806,7 +1776,7 @@ func NewMediaType(in *yaml.Node, context *compiler.Context) (*MediaType, error)
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -1850,7 +1820,7 @@ func NewMediaTypes(in *yaml.Node, context *compiler.Context) (*MediaTypes, error
 		// MAP: MediaType
 		x.AdditionalProperties = make([]*NamedMediaType, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedMediaType{}
@@ -1876,9 +1846,7 @@ func NewNamedAny(in *yaml.Node, context *compiler.Context) (*NamedAny, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedAny)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1886,7 +1854,7 @@ func NewNamedAny(in *yaml.Node, context *compiler.Context) (*NamedAny, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1914,9 +1882,7 @@ func NewNamedCallbackOrReference(in *yaml.Node, context *compiler.Context) (*Nam
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedCallbackOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1924,7 +1890,7 @@ func NewNamedCallbackOrReference(in *yaml.Node, context *compiler.Context) (*Nam
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1952,9 +1918,7 @@ func NewNamedEncoding(in *yaml.Node, context *compiler.Context) (*NamedEncoding,
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedEncoding)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -1962,7 +1926,7 @@ func NewNamedEncoding(in *yaml.Node, context *compiler.Context) (*NamedEncoding,
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -1990,9 +1954,7 @@ func NewNamedExampleOrReference(in *yaml.Node, context *compiler.Context) (*Name
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedExampleOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2000,7 +1962,7 @@ func NewNamedExampleOrReference(in *yaml.Node, context *compiler.Context) (*Name
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2028,9 +1990,7 @@ func NewNamedHeaderOrReference(in *yaml.Node, context *compiler.Context) (*Named
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedHeaderOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2038,7 +1998,7 @@ func NewNamedHeaderOrReference(in *yaml.Node, context *compiler.Context) (*Named
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2066,9 +2026,7 @@ func NewNamedLinkOrReference(in *yaml.Node, context *compiler.Context) (*NamedLi
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedLinkOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2076,7 +2034,7 @@ func NewNamedLinkOrReference(in *yaml.Node, context *compiler.Context) (*NamedLi
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2104,9 +2062,7 @@ func NewNamedMediaType(in *yaml.Node, context *compiler.Context) (*NamedMediaTyp
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedMediaType)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2114,7 +2070,7 @@ func NewNamedMediaType(in *yaml.Node, context *compiler.Context) (*NamedMediaTyp
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2142,9 +2098,7 @@ func NewNamedParameterOrReference(in *yaml.Node, context *compiler.Context) (*Na
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedParameterOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2152,7 +2106,7 @@ func NewNamedParameterOrReference(in *yaml.Node, context *compiler.Context) (*Na
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2180,9 +2134,7 @@ func NewNamedPathItem(in *yaml.Node, context *compiler.Context) (*NamedPathItem,
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedPathItem)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2190,7 +2142,7 @@ func NewNamedPathItem(in *yaml.Node, context *compiler.Context) (*NamedPathItem,
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2218,9 +2170,7 @@ func NewNamedRequestBodyOrReference(in *yaml.Node, context *compiler.Context) (*
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedRequestBodyOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2228,7 +2178,7 @@ func NewNamedRequestBodyOrReference(in *yaml.Node, context *compiler.Context) (*
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2256,9 +2206,7 @@ func NewNamedResponseOrReference(in *yaml.Node, context *compiler.Context) (*Nam
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedResponseOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2266,7 +2214,7 @@ func NewNamedResponseOrReference(in *yaml.Node, context *compiler.Context) (*Nam
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2294,9 +2242,7 @@ func NewNamedSchemaOrReference(in *yaml.Node, context *compiler.Context) (*Named
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedSchemaOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2304,7 +2250,7 @@ func NewNamedSchemaOrReference(in *yaml.Node, context *compiler.Context) (*Named
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2332,9 +2278,7 @@ func NewNamedSecuritySchemeOrReference(in *yaml.Node, context *compiler.Context)
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedSecuritySchemeOrReference)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2342,7 +2286,7 @@ func NewNamedSecuritySchemeOrReference(in *yaml.Node, context *compiler.Context)
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2370,9 +2314,7 @@ func NewNamedServerVariable(in *yaml.Node, context *compiler.Context) (*NamedSer
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedServerVariable)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2380,7 +2322,7 @@ func NewNamedServerVariable(in *yaml.Node, context *compiler.Context) (*NamedSer
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2408,9 +2350,7 @@ func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, err
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedString)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2418,7 +2358,7 @@ func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, err
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2427,7 +2367,7 @@ func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, err
 		// string value = 2;
 		v2 := compiler.MapValueForKey(m, "value")
 		if v2 != nil {
-			x.Value, ok = compiler.StringForScalarNode(v2)
+			x.Value, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for value: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2446,9 +2386,7 @@ func NewNamedStringArray(in *yaml.Node, context *compiler.Context) (*NamedString
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"name", "value"}
-		var allowedPatterns []*regexp.Regexp
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForNamedStringArray)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2456,7 +2394,7 @@ func NewNamedStringArray(in *yaml.Node, context *compiler.Context) (*NamedString
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2484,9 +2422,7 @@ func NewOauthFlow(in *yaml.Node, context *compiler.Context) (*OauthFlow, error)
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"authorizationUrl", "refreshUrl", "scopes", "tokenUrl"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOauthFlow)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2494,7 +2430,7 @@ func NewOauthFlow(in *yaml.Node, context *compiler.Context) (*OauthFlow, error)
 		// string authorization_url = 1;
 		v1 := compiler.MapValueForKey(m, "authorizationUrl")
 		if v1 != nil {
-			x.AuthorizationUrl, ok = compiler.StringForScalarNode(v1)
+			x.AuthorizationUrl, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for authorizationUrl: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2503,7 +2439,7 @@ func NewOauthFlow(in *yaml.Node, context *compiler.Context) (*OauthFlow, error)
 		// string token_url = 2;
 		v2 := compiler.MapValueForKey(m, "tokenUrl")
 		if v2 != nil {
-			x.TokenUrl, ok = compiler.StringForScalarNode(v2)
+			x.TokenUrl, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for tokenUrl: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2512,7 +2448,7 @@ func NewOauthFlow(in *yaml.Node, context *compiler.Context) (*OauthFlow, error)
 		// string refresh_url = 3;
 		v3 := compiler.MapValueForKey(m, "refreshUrl")
 		if v3 != nil {
-			x.RefreshUrl, ok = compiler.StringForScalarNode(v3)
+			x.RefreshUrl, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for refreshUrl: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2531,7 +2467,7 @@ func NewOauthFlow(in *yaml.Node, context *compiler.Context) (*OauthFlow, error)
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -2571,9 +2507,7 @@ func NewOauthFlows(in *yaml.Node, context *compiler.Context) (*OauthFlows, error
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"authorizationCode", "clientCredentials", "implicit", "password"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOauthFlows)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2618,7 +2552,7 @@ func NewOauthFlows(in *yaml.Node, context *compiler.Context) (*OauthFlows, error
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -2662,7 +2596,7 @@ func NewObject(in *yaml.Node, context *compiler.Context) (*Object, error) {
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedAny{}
@@ -2706,9 +2640,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"callbacks", "deprecated", "description", "externalDocs", "operationId", "parameters", "requestBody", "responses", "security", "servers", "summary", "tags"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForOperation)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2718,7 +2650,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		if v1 != nil {
 			v, ok := compiler.SequenceNodeForNode(v1)
 			if ok {
-				x.Tags = compiler.StringArrayForSequenceNode(v)
+				x.Tags = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for tags: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2727,7 +2659,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// string summary = 2;
 		v2 := compiler.MapValueForKey(m, "summary")
 		if v2 != nil {
-			x.Summary, ok = compiler.StringForScalarNode(v2)
+			x.Summary, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for summary: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2736,7 +2668,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2754,7 +2686,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// string operation_id = 5;
 		v5 := compiler.MapValueForKey(m, "operationId")
 		if v5 != nil {
-			x.OperationId, ok = compiler.StringForScalarNode(v5)
+			x.OperationId, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for operationId: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2848,7 +2780,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -2894,9 +2826,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"allowEmptyValue", "allowReserved", "content", "deprecated", "description", "example", "examples", "explode", "in", "name", "required", "schema", "style"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForParameter)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -2904,7 +2834,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2913,7 +2843,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string in = 2;
 		v2 := compiler.MapValueForKey(m, "in")
 		if v2 != nil {
-			x.In, ok = compiler.StringForScalarNode(v2)
+			x.In, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for in: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2922,7 +2852,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -2958,7 +2888,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// string style = 7;
 		v7 := compiler.MapValueForKey(m, "style")
 		if v7 != nil {
-			x.Style, ok = compiler.StringForScalarNode(v7)
+			x.Style, ok = compiler.StringForScalarNodeWithContext(v7, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for style: %s", compiler.Display(v7))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3022,7 +2952,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3110,7 +3040,7 @@ func NewParametersOrReferences(in *yaml.Node, context *compiler.Context) (*Param
 		// MAP: ParameterOrReference
 		x.AdditionalProperties = make([]*NamedParameterOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedParameterOrReference{}
@@ -3136,9 +3066,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"$ref", "delete", "description", "get", "head", "options", "parameters", "patch", "post", "put", "servers", "summary", "trace"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForPathItem)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3146,7 +3074,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		// string _ref = 1;
 		v1 := compiler.MapValueForKey(m, "$ref")
 		if v1 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v1)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3155,7 +3083,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		// string summary = 2;
 		v2 := compiler.MapValueForKey(m, "summary")
 		if v2 != nil {
-			x.Summary, ok = compiler.StringForScalarNode(v2)
+			x.Summary, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for summary: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3164,7 +3092,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3278,7 +3206,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3318,9 +3246,7 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{}
-		allowedPatterns := []*regexp.Regexp{pattern2, pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForPaths)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3329,7 +3255,7 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		// MAP: PathItem ^/
 		x.Path = make([]*NamedPathItem, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "/") {
@@ -3348,7 +3274,7 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3392,7 +3318,7 @@ func NewProperties(in *yaml.Node, context *compiler.Context) (*Properties, error
 		// MAP: SchemaOrReference
 		x.AdditionalProperties = make([]*NamedSchemaOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedSchemaOrReference{}
@@ -3427,7 +3353,7 @@ func NewReference(in *yaml.Node, context *compiler.Context) (*Reference, error)
 		// string _ref = 1;
 		v1 := compiler.MapValueForKey(m, "$ref")
 		if v1 != nil {
-			x.XRef, ok = compiler.StringForScalarNode(v1)
+			x.XRef, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for $ref: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3436,7 +3362,7 @@ func NewReference(in *yaml.Node, context *compiler.Context) (*Reference, error)
 		// string summary = 2;
 		v2 := compiler.MapValueForKey(m, "summary")
 		if v2 != nil {
-			x.Summary, ok = compiler.StringForScalarNode(v2)
+			x.Summary, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for summary: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3445,7 +3371,7 @@ func NewReference(in *yaml.Node, context *compiler.Context) (*Reference, error)
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3468,7 +3394,7 @@ func NewRequestBodiesOrReferences(in *yaml.Node, context *compiler.Context) (*Re
 		// MAP: RequestBodyOrReference
 		x.AdditionalProperties = make([]*NamedRequestBodyOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedRequestBodyOrReference{}
@@ -3500,9 +3426,7 @@ func NewRequestBody(in *yaml.Node, context *compiler.Context) (*RequestBody, err
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"content", "description", "required"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForRequestBody)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3510,7 +3434,7 @@ func NewRequestBody(in *yaml.Node, context *compiler.Context) (*RequestBody, err
 		// string description = 1;
 		v1 := compiler.MapValueForKey(m, "description")
 		if v1 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v1)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3538,7 +3462,7 @@ func NewRequestBody(in *yaml.Node, context *compiler.Context) (*RequestBody, err
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3628,9 +3552,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"content", "description", "headers", "links"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForResponse)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3638,7 +3560,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 		// string description = 1;
 		v1 := compiler.MapValueForKey(m, "description")
 		if v1 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v1)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -3675,7 +3597,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3759,9 +3681,7 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"default"}
-		allowedPatterns := []*regexp.Regexp{pattern3, pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForResponses)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3779,7 +3699,7 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		// MAP: ResponseOrReference ^([0-9X]{3})$
 		x.ResponseOrReference = make([]*NamedResponseOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if pattern3.MatchString(k) {
@@ -3798,7 +3718,7 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -3842,7 +3762,7 @@ func NewResponsesOrReferences(in *yaml.Node, context *compiler.Context) (*Respon
 		// MAP: ResponseOrReference
 		x.AdditionalProperties = make([]*NamedResponseOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedResponseOrReference{}
@@ -3868,9 +3788,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"additionalProperties", "allOf", "anyOf", "default", "deprecated", "description", "discriminator", "enum", "example", "exclusiveMaximum", "exclusiveMinimum", "externalDocs", "format", "items", "maxItems", "maxLength", "maxProperties", "maximum", "minItems", "minLength", "minProperties", "minimum", "multipleOf", "not", "nullable", "oneOf", "pattern", "properties", "readOnly", "required", "title", "type", "uniqueItems", "writeOnly", "xml"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForSchema)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -3950,7 +3868,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string title = 9;
 		v9 := compiler.MapValueForKey(m, "title")
 		if v9 != nil {
-			x.Title, ok = compiler.StringForScalarNode(v9)
+			x.Title, ok = compiler.StringForScalarNodeWithContext(v9, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for title: %s", compiler.Display(v9))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4032,7 +3950,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string pattern = 17;
 		v17 := compiler.MapValueForKey(m, "pattern")
 		if v17 != nil {
-			x.Pattern, ok = compiler.StringForScalarNode(v17)
+			x.Pattern, ok = compiler.StringForScalarNodeWithContext(v17, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for pattern: %s", compiler.Display(v17))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4096,7 +4014,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		if v23 != nil {
 			v, ok := compiler.SequenceNodeForNode(v23)
 			if ok {
-				x.Required = compiler.StringArrayForSequenceNode(v)
+				x.Required = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for required: %s", compiler.Display(v23))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4121,7 +4039,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string type = 25;
 		v25 := compiler.MapValueForKey(m, "type")
 		if v25 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v25)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v25, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v25))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4223,7 +4141,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string description = 34;
 		v34 := compiler.MapValueForKey(m, "description")
 		if v34 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v34)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v34, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v34))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4232,7 +4150,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// string format = 35;
 		v35 := compiler.MapValueForKey(m, "format")
 		if v35 != nil {
-			x.Format, ok = compiler.StringForScalarNode(v35)
+			x.Format, ok = compiler.StringForScalarNodeWithContext(v35, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for format: %s", compiler.Display(v35))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4242,7 +4160,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4330,7 +4248,7 @@ func NewSchemasOrReferences(in *yaml.Node, context *compiler.Context) (*SchemasO
 		// MAP: SchemaOrReference
 		x.AdditionalProperties = make([]*NamedSchemaOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedSchemaOrReference{}
@@ -4360,7 +4278,7 @@ func NewSecurityRequirement(in *yaml.Node, context *compiler.Context) (*Security
 		// MAP: StringArray
 		x.AdditionalProperties = make([]*NamedStringArray, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedStringArray{}
@@ -4392,9 +4310,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"bearerFormat", "description", "flows", "in", "name", "openIdConnectUrl", "scheme", "type"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForSecurityScheme)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4402,7 +4318,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// string type = 1;
 		v1 := compiler.MapValueForKey(m, "type")
 		if v1 != nil {
-			x.Type, ok = compiler.StringForScalarNode(v1)
+			x.Type, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for type: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4411,7 +4327,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// string description = 2;
 		v2 := compiler.MapValueForKey(m, "description")
 		if v2 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v2)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4420,7 +4336,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// string name = 3;
 		v3 := compiler.MapValueForKey(m, "name")
 		if v3 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v3)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4429,7 +4345,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// string in = 4;
 		v4 := compiler.MapValueForKey(m, "in")
 		if v4 != nil {
-			x.In, ok = compiler.StringForScalarNode(v4)
+			x.In, ok = compiler.StringForScalarNodeWithContext(v4, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for in: %s", compiler.Display(v4))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4438,7 +4354,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// string scheme = 5;
 		v5 := compiler.MapValueForKey(m, "scheme")
 		if v5 != nil {
-			x.Scheme, ok = compiler.StringForScalarNode(v5)
+			x.Scheme, ok = compiler.StringForScalarNodeWithContext(v5, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for scheme: %s", compiler.Display(v5))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4447,7 +4363,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// string bearer_format = 6;
 		v6 := compiler.MapValueForKey(m, "bearerFormat")
 		if v6 != nil {
-			x.BearerFormat, ok = compiler.StringForScalarNode(v6)
+			x.BearerFormat, ok = compiler.StringForScalarNodeWithContext(v6, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for bearerFormat: %s", compiler.Display(v6))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4465,7 +4381,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// string open_id_connect_url = 8;
 		v8 := compiler.MapValueForKey(m, "openIdConnectUrl")
 		if v8 != nil {
-			x.OpenIdConnectUrl, ok = compiler.StringForScalarNode(v8)
+			x.OpenIdConnectUrl, ok = compiler.StringForScalarNodeWithContext(v8, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for openIdConnectUrl: %s", compiler.Display(v8))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4475,7 +4391,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4563,7 +4479,7 @@ func NewSecuritySchemesOrReferences(in *yaml.Node, context *compiler.Context) (*
 		// MAP: SecuritySchemeOrReference
 		x.AdditionalProperties = make([]*NamedSecuritySchemeOrReference, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedSecuritySchemeOrReference{}
@@ -4595,9 +4511,7 @@ func NewServer(in *yaml.Node, context *compiler.Context) (*Server, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "url", "variables"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForServer)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4605,7 +4519,7 @@ func NewServer(in *yaml.Node, context *compiler.Context) (*Server, error) {
 		// string url = 1;
 		v1 := compiler.MapValueForKey(m, "url")
 		if v1 != nil {
-			x.Url, ok = compiler.StringForScalarNode(v1)
+			x.Url, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for url: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4614,7 +4528,7 @@ func NewServer(in *yaml.Node, context *compiler.Context) (*Server, error) {
 		// string description = 2;
 		v2 := compiler.MapValueForKey(m, "description")
 		if v2 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v2)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4633,7 +4547,7 @@ func NewServer(in *yaml.Node, context *compiler.Context) (*Server, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4679,9 +4593,7 @@ func NewServerVariable(in *yaml.Node, context *compiler.Context) (*ServerVariabl
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"default", "description", "enum"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForServerVariable)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4691,7 +4603,7 @@ func NewServerVariable(in *yaml.Node, context *compiler.Context) (*ServerVariabl
 		if v1 != nil {
 			v, ok := compiler.SequenceNodeForNode(v1)
 			if ok {
-				x.Enum = compiler.StringArrayForSequenceNode(v)
+				x.Enum = compiler.StringArrayForSequenceNodeWithContext(v, context)
 			} else {
 				message := fmt.Sprintf("has unexpected value for enum: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4700,7 +4612,7 @@ func NewServerVariable(in *yaml.Node, context *compiler.Context) (*ServerVariabl
 		// string default = 2;
 		v2 := compiler.MapValueForKey(m, "default")
 		if v2 != nil {
-			x.Default, ok = compiler.StringForScalarNode(v2)
+			x.Default, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for default: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4709,7 +4621,7 @@ func NewServerVariable(in *yaml.Node, context *compiler.Context) (*ServerVariabl
 		// string description = 3;
 		v3 := compiler.MapValueForKey(m, "description")
 		if v3 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v3)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4719,7 +4631,7 @@ func NewServerVariable(in *yaml.Node, context *compiler.Context) (*ServerVariabl
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4763,7 +4675,7 @@ func NewServerVariables(in *yaml.Node, context *compiler.Context) (*ServerVariab
 		// MAP: ServerVariable
 		x.AdditionalProperties = make([]*NamedServerVariable, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedServerVariable{}
@@ -4790,18 +4702,14 @@ func NewSpecificationExtension(in *yaml.Node, context *compiler.Context) (*Speci
 		var v bool
 		v, matched = compiler.BoolForScalarNode(in)
 		x.Oneof = &SpecificationExtension_Boolean{Boolean: v}
-	case "!!str":
+	case "!!str", "!!timestamp":
 		var v string
-		v, matched = compiler.StringForScalarNode(in)
+		v, matched = compiler.StringForScalarNodeWithContext(in, context)
 		x.Oneof = &SpecificationExtension_String_{String_: v}
-	case "!!float":
+	case "!!float", "!!int":
 		var v float64
 		v, matched = compiler.FloatForScalarNode(in)
 		x.Oneof = &SpecificationExtension_Number{Number: v}
-	case "!!int":
-		var v int64
-		v, matched = compiler.IntForScalarNode(in)
-		x.Oneof = &SpecificationExtension_Number{Number: float64(v)}
 	}
 	if matched {
 		// since the oneof matched one of its possibilities, discard any matching errors
@@ -4816,7 +4724,7 @@ func NewStringArray(in *yaml.Node, context *compiler.Context) (*StringArray, err
 	x := &StringArray{}
 	x.Value = make([]string, 0)
 	for _, node := range in.Content {
-		s, _ := compiler.StringForScalarNode(node)
+		s, _ := compiler.StringForScalarNodeWithContext(node, context)
 		x.Value = append(x.Value, s)
 	}
 	return x, compiler.NewErrorGroupOrNil(errors)
@@ -4835,12 +4743,12 @@ func NewStrings(in *yaml.Node, context *compiler.Context) (*Strings, error) {
 		// MAP: string
 		x.AdditionalProperties = make([]*NamedString, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				pair := &NamedString{}
 				pair.Name = k
-				pair.Value, _ = compiler.StringForScalarNode(v)
+				pair.Value, _ = compiler.StringForScalarNodeWithContext(v, context)
 				x.AdditionalProperties = append(x.AdditionalProperties, pair)
 			}
 		}
@@ -4863,9 +4771,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 			message := fmt.Sprintf("is missing required %s: %+v", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
 		}
-		allowedKeys := []string{"description", "externalDocs", "name"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForTag)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4873,7 +4779,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4882,7 +4788,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 		// string description = 2;
 		v2 := compiler.MapValueForKey(m, "description")
 		if v2 != nil {
-			x.Description, ok = compiler.StringForScalarNode(v2)
+			x.Description, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for description: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4901,7 +4807,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -4941,9 +4847,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		message := fmt.Sprintf("has unexpected value: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	} else {
-		allowedKeys := []string{"attribute", "name", "namespace", "prefix", "wrapped"}
-		allowedPatterns := []*regexp.Regexp{pattern1}
-		invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)
+		invalidKeys := compiler.InvalidKeysInMapForKeySet(m, allowedKeysForXml)
 		if len(invalidKeys) > 0 {
 			message := fmt.Sprintf("has invalid %s: %+v", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, ", "))
 			errors = append(errors, compiler.NewError(context, message))
@@ -4951,7 +4855,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// string name = 1;
 		v1 := compiler.MapValueForKey(m, "name")
 		if v1 != nil {
-			x.Name, ok = compiler.StringForScalarNode(v1)
+			x.Name, ok = compiler.StringForScalarNodeWithContext(v1, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for name: %s", compiler.Display(v1))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4960,7 +4864,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// string namespace = 2;
 		v2 := compiler.MapValueForKey(m, "namespace")
 		if v2 != nil {
-			x.Namespace, ok = compiler.StringForScalarNode(v2)
+			x.Namespace, ok = compiler.StringForScalarNodeWithContext(v2, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for namespace: %s", compiler.Display(v2))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4969,7 +4873,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// string prefix = 3;
 		v3 := compiler.MapValueForKey(m, "prefix")
 		if v3 != nil {
-			x.Prefix, ok = compiler.StringForScalarNode(v3)
+			x.Prefix, ok = compiler.StringForScalarNodeWithContext(v3, context)
 			if !ok {
 				message := fmt.Sprintf("has unexpected value for prefix: %s", compiler.Display(v3))
 				errors = append(errors, compiler.NewError(context, message))
@@ -4997,7 +4901,7 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
 		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
+			k, ok := compiler.StringForScalarNodeWithContext(m.Content[i], context)
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
@@ -5028,9 +4932,1990 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
+// rawInfoForAdditionalPropertiesItem returns a description of AdditionalPropertiesItem suitable for JSON or YAML export.
+func rawInfoForAdditionalPropertiesItem(m *AdditionalPropertiesItem, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// AdditionalPropertiesItem
+	// {Name:schemaOrReference Type:SchemaOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetSchemaOrReference()
+	if v0 != nil {
+		return rawInfoForSchemaOrReference(v0, b)
+	}
+	// {Name:boolean Type:bool StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	if v1, ok := m.GetOneof().(*AdditionalPropertiesItem_Boolean); ok {
+		return b.Bool(v1.Boolean)
+	}
+	return b.Null()
+}
+
+// rawInfoForAny returns a description of Any suitable for JSON or YAML export.
+func rawInfoForAny(m *Any, b *compiler.NodeBuilder) *yaml.Node {
+	var err error
+	var node yaml.Node
+	err = yaml.Unmarshal([]byte(m.Yaml), &node)
+	if err == nil {
+		if node.Kind == yaml.DocumentNode {
+			return node.Content[0]
+		}
+		return &node
+	}
+	return b.Null()
+}
+
+// rawInfoForAnyOrExpression returns a description of AnyOrExpression suitable for JSON or YAML export.
+func rawInfoForAnyOrExpression(m *AnyOrExpression, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// AnyOrExpression
+	// {Name:any Type:Any StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetAny()
+	if v0 != nil {
+		return rawInfoForAny(v0, b)
+	}
+	// {Name:expression Type:Expression StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetExpression()
+	if v1 != nil {
+		return rawInfoForExpression(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForCallback returns a description of Callback suitable for JSON or YAML export.
+func rawInfoForCallback(m *Callback, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2*len(m.Path) + 2*len(m.SpecificationExtension))
+	if m.Path != nil {
+		for _, item := range m.Path {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForPathItem(item.Value, b))
+		}
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForCallbackOrReference returns a description of CallbackOrReference suitable for JSON or YAML export.
+func rawInfoForCallbackOrReference(m *CallbackOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// CallbackOrReference
+	// {Name:callback Type:Callback StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetCallback()
+	if v0 != nil {
+		return rawInfoForCallback(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForCallbacksOrReferences returns a description of CallbacksOrReferences suitable for JSON or YAML export.
+func rawInfoForCallbacksOrReferences(m *CallbacksOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForCallbackOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForComponents returns a description of Components suitable for JSON or YAML export.
+func rawInfoForComponents(m *Components, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.SpecificationExtension))
+	if m.Schemas != nil {
+		info.Content = append(info.Content, b.Key("schemas"))
+		info.Content = append(info.Content, rawInfoForSchemasOrReferences(m.Schemas, b))
+	}
+	if m.Responses != nil {
+		info.Content = append(info.Content, b.Key("responses"))
+		info.Content = append(info.Content, rawInfoForResponsesOrReferences(m.Responses, b))
+	}
+	if m.Parameters != nil {
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, rawInfoForParametersOrReferences(m.Parameters, b))
+	}
+	if m.Examples != nil {
+		info.Content = append(info.Content, b.Key("examples"))
+		info.Content = append(info.Content, rawInfoForExamplesOrReferences(m.Examples, b))
+	}
+	if m.RequestBodies != nil {
+		info.Content = append(info.Content, b.Key("requestBodies"))
+		info.Content = append(info.Content, rawInfoForRequestBodiesOrReferences(m.RequestBodies, b))
+	}
+	if m.Headers != nil {
+		info.Content = append(info.Content, b.Key("headers"))
+		info.Content = append(info.Content, rawInfoForHeadersOrReferences(m.Headers, b))
+	}
+	if m.SecuritySchemes != nil {
+		info.Content = append(info.Content, b.Key("securitySchemes"))
+		info.Content = append(info.Content, rawInfoForSecuritySchemesOrReferences(m.SecuritySchemes, b))
+	}
+	if m.Links != nil {
+		info.Content = append(info.Content, b.Key("links"))
+		info.Content = append(info.Content, rawInfoForLinksOrReferences(m.Links, b))
+	}
+	if m.Callbacks != nil {
+		info.Content = append(info.Content, b.Key("callbacks"))
+		info.Content = append(info.Content, rawInfoForCallbacksOrReferences(m.Callbacks, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForContact returns a description of Contact suitable for JSON or YAML export.
+func rawInfoForContact(m *Contact, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(6 + 2*len(m.SpecificationExtension))
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Url != "" {
+		info.Content = append(info.Content, b.Key("url"))
+		info.Content = append(info.Content, b.String(m.Url))
+	}
+	if m.Email != "" {
+		info.Content = append(info.Content, b.Key("email"))
+		info.Content = append(info.Content, b.String(m.Email))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForDefaultType returns a description of DefaultType suitable for JSON or YAML export.
+func rawInfoForDefaultType(m *DefaultType, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// DefaultType
+	// {Name:number Type:float StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	if v0, ok := m.GetOneof().(*DefaultType_Number); ok {
+		return b.Float(v0.Number)
+	}
+	// {Name:boolean Type:bool StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	if v1, ok := m.GetOneof().(*DefaultType_Boolean); ok {
+		return b.Bool(v1.Boolean)
+	}
+	// {Name:string Type:string StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	if v2, ok := m.GetOneof().(*DefaultType_String_); ok {
+		return b.String(v2.String_)
+	}
+	return b.Null()
+}
+
+// rawInfoForDiscriminator returns a description of Discriminator suitable for JSON or YAML export.
+func rawInfoForDiscriminator(m *Discriminator, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("propertyName"))
+	info.Content = append(info.Content, b.String(m.PropertyName))
+	if m.Mapping != nil {
+		info.Content = append(info.Content, b.Key("mapping"))
+		info.Content = append(info.Content, rawInfoForStrings(m.Mapping, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForDocument returns a description of Document suitable for JSON or YAML export.
+func rawInfoForDocument(m *Document, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("openapi"))
+	info.Content = append(info.Content, b.String(m.Openapi))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("info"))
+	info.Content = append(info.Content, rawInfoForInfo(m.Info, b))
+	if len(m.Servers) != 0 {
+		items := b.Sequence(len(m.Servers))
+		for _, item := range m.Servers {
+			items.Content = append(items.Content, rawInfoForServer(item, b))
+		}
+		info.Content = append(info.Content, b.Key("servers"))
+		info.Content = append(info.Content, items)
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("paths"))
+	info.Content = append(info.Content, rawInfoForPaths(m.Paths, b))
+	if m.Components != nil {
+		info.Content = append(info.Content, b.Key("components"))
+		info.Content = append(info.Content, rawInfoForComponents(m.Components, b))
+	}
+	if len(m.Security) != 0 {
+		items := b.Sequence(len(m.Security))
+		for _, item := range m.Security {
+			items.Content = append(items.Content, rawInfoForSecurityRequirement(item, b))
+		}
+		info.Content = append(info.Content, b.Key("security"))
+		info.Content = append(info.Content, items)
+	}
+	if len(m.Tags) != 0 {
+		items := b.Sequence(len(m.Tags))
+		for _, item := range m.Tags {
+			items.Content = append(items.Content, rawInfoForTag(item, b))
+		}
+		info.Content = append(info.Content, b.Key("tags"))
+		info.Content = append(info.Content, items)
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForEncoding returns a description of Encoding suitable for JSON or YAML export.
+func rawInfoForEncoding(m *Encoding, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(10 + 2*len(m.SpecificationExtension))
+	if m.ContentType != "" {
+		info.Content = append(info.Content, b.Key("contentType"))
+		info.Content = append(info.Content, b.String(m.ContentType))
+	}
+	if m.Headers != nil {
+		info.Content = append(info.Content, b.Key("headers"))
+		info.Content = append(info.Content, rawInfoForHeadersOrReferences(m.Headers, b))
+	}
+	if m.Style != "" {
+		info.Content = append(info.Content, b.Key("style"))
+		info.Content = append(info.Content, b.String(m.Style))
+	}
+	if m.Explode != false {
+		info.Content = append(info.Content, b.Key("explode"))
+		info.Content = append(info.Content, b.Bool(m.Explode))
+	}
+	if m.AllowReserved != false {
+		info.Content = append(info.Content, b.Key("allowReserved"))
+		info.Content = append(info.Content, b.Bool(m.AllowReserved))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForEncodings returns a description of Encodings suitable for JSON or YAML export.
+func rawInfoForEncodings(m *Encodings, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForEncoding(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForExample returns a description of Example suitable for JSON or YAML export.
+func rawInfoForExample(m *Example, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(8 + 2*len(m.SpecificationExtension))
+	if m.Summary != "" {
+		info.Content = append(info.Content, b.Key("summary"))
+		info.Content = append(info.Content, b.String(m.Summary))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Value != nil {
+		info.Content = append(info.Content, b.Key("value"))
+		info.Content = append(info.Content, rawInfoForAny(m.Value, b))
+	}
+	if m.ExternalValue != "" {
+		info.Content = append(info.Content, b.Key("externalValue"))
+		info.Content = append(info.Content, b.String(m.ExternalValue))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForExampleOrReference returns a description of ExampleOrReference suitable for JSON or YAML export.
+func rawInfoForExampleOrReference(m *ExampleOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// ExampleOrReference
+	// {Name:example Type:Example StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetExample()
+	if v0 != nil {
+		return rawInfoForExample(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForExamplesOrReferences returns a description of ExamplesOrReferences suitable for JSON or YAML export.
+func rawInfoForExamplesOrReferences(m *ExamplesOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForExampleOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForExpression returns a description of Expression suitable for JSON or YAML export.
+func rawInfoForExpression(m *Expression, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForExternalDocs returns a description of ExternalDocs suitable for JSON or YAML export.
+func rawInfoForExternalDocs(m *ExternalDocs, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4 + 2*len(m.SpecificationExtension))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("url"))
+	info.Content = append(info.Content, b.String(m.Url))
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForHeader returns a description of Header suitable for JSON or YAML export.
+func rawInfoForHeader(m *Header, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.SpecificationExtension))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	if m.Deprecated != false {
+		info.Content = append(info.Content, b.Key("deprecated"))
+		info.Content = append(info.Content, b.Bool(m.Deprecated))
+	}
+	if m.AllowEmptyValue != false {
+		info.Content = append(info.Content, b.Key("allowEmptyValue"))
+		info.Content = append(info.Content, b.Bool(m.AllowEmptyValue))
+	}
+	if m.Style != "" {
+		info.Content = append(info.Content, b.Key("style"))
+		info.Content = append(info.Content, b.String(m.Style))
+	}
+	if m.Explode != false {
+		info.Content = append(info.Content, b.Key("explode"))
+		info.Content = append(info.Content, b.Bool(m.Explode))
+	}
+	if m.AllowReserved != false {
+		info.Content = append(info.Content, b.Key("allowReserved"))
+		info.Content = append(info.Content, b.Bool(m.AllowReserved))
+	}
+	if m.Schema != nil {
+		info.Content = append(info.Content, b.Key("schema"))
+		info.Content = append(info.Content, rawInfoForSchemaOrReference(m.Schema, b))
+	}
+	if m.Example != nil {
+		info.Content = append(info.Content, b.Key("example"))
+		info.Content = append(info.Content, rawInfoForAny(m.Example, b))
+	}
+	if m.Examples != nil {
+		info.Content = append(info.Content, b.Key("examples"))
+		info.Content = append(info.Content, rawInfoForExamplesOrReferences(m.Examples, b))
+	}
+	if m.Content != nil {
+		info.Content = append(info.Content, b.Key("content"))
+		info.Content = append(info.Content, rawInfoForMediaTypes(m.Content, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForHeaderOrReference returns a description of HeaderOrReference suitable for JSON or YAML export.
+func rawInfoForHeaderOrReference(m *HeaderOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// HeaderOrReference
+	// {Name:header Type:Header StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetHeader()
+	if v0 != nil {
+		return rawInfoForHeader(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForHeadersOrReferences returns a description of HeadersOrReferences suitable for JSON or YAML export.
+func rawInfoForHeadersOrReferences(m *HeadersOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForHeaderOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForInfo returns a description of Info suitable for JSON or YAML export.
+func rawInfoForInfo(m *Info, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(14 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("title"))
+	info.Content = append(info.Content, b.String(m.Title))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.TermsOfService != "" {
+		info.Content = append(info.Content, b.Key("termsOfService"))
+		info.Content = append(info.Content, b.String(m.TermsOfService))
+	}
+	if m.Contact != nil {
+		info.Content = append(info.Content, b.Key("contact"))
+		info.Content = append(info.Content, rawInfoForContact(m.Contact, b))
+	}
+	if m.License != nil {
+		info.Content = append(info.Content, b.Key("license"))
+		info.Content = append(info.Content, rawInfoForLicense(m.License, b))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("version"))
+	info.Content = append(info.Content, b.String(m.Version))
+	if m.Summary != "" {
+		info.Content = append(info.Content, b.Key("summary"))
+		info.Content = append(info.Content, b.String(m.Summary))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForItemsItem returns a description of ItemsItem suitable for JSON or YAML export.
+func rawInfoForItemsItem(m *ItemsItem, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if len(m.SchemaOrReference) != 0 {
+		items := b.Sequence(len(m.SchemaOrReference))
+		for _, item := range m.SchemaOrReference {
+			items.Content = append(items.Content, rawInfoForSchemaOrReference(item, b))
+		}
+		info.Content = append(info.Content, b.Key("schemaOrReference"))
+		info.Content = append(info.Content, items)
+	}
+	return info
+}
+
+// rawInfoForLicense returns a description of License suitable for JSON or YAML export.
+func rawInfoForLicense(m *License, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("name"))
+	info.Content = append(info.Content, b.String(m.Name))
+	if m.Url != "" {
+		info.Content = append(info.Content, b.Key("url"))
+		info.Content = append(info.Content, b.String(m.Url))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForLink returns a description of Link suitable for JSON or YAML export.
+func rawInfoForLink(m *Link, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(12 + 2*len(m.SpecificationExtension))
+	if m.OperationRef != "" {
+		info.Content = append(info.Content, b.Key("operationRef"))
+		info.Content = append(info.Content, b.String(m.OperationRef))
+	}
+	if m.OperationId != "" {
+		info.Content = append(info.Content, b.Key("operationId"))
+		info.Content = append(info.Content, b.String(m.OperationId))
+	}
+	if m.Parameters != nil {
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, rawInfoForAnyOrExpression(m.Parameters, b))
+	}
+	if m.RequestBody != nil {
+		info.Content = append(info.Content, b.Key("requestBody"))
+		info.Content = append(info.Content, rawInfoForAnyOrExpression(m.RequestBody, b))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Server != nil {
+		info.Content = append(info.Content, b.Key("server"))
+		info.Content = append(info.Content, rawInfoForServer(m.Server, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForLinkOrReference returns a description of LinkOrReference suitable for JSON or YAML export.
+func rawInfoForLinkOrReference(m *LinkOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// LinkOrReference
+	// {Name:link Type:Link StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetLink()
+	if v0 != nil {
+		return rawInfoForLink(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForLinksOrReferences returns a description of LinksOrReferences suitable for JSON or YAML export.
+func rawInfoForLinksOrReferences(m *LinksOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForLinkOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForMediaType returns a description of MediaType suitable for JSON or YAML export.
+func rawInfoForMediaType(m *MediaType, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(8 + 2*len(m.SpecificationExtension))
+	if m.Schema != nil {
+		info.Content = append(info.Content, b.Key("schema"))
+		info.Content = append(info.Content, rawInfoForSchemaOrReference(m.Schema, b))
+	}
+	if m.Example != nil {
+		info.Content = append(info.Content, b.Key("example"))
+		info.Content = append(info.Content, rawInfoForAny(m.Example, b))
+	}
+	if m.Examples != nil {
+		info.Content = append(info.Content, b.Key("examples"))
+		info.Content = append(info.Content, rawInfoForExamplesOrReferences(m.Examples, b))
+	}
+	if m.Encoding != nil {
+		info.Content = append(info.Content, b.Key("encoding"))
+		info.Content = append(info.Content, rawInfoForEncodings(m.Encoding, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForMediaTypes returns a description of MediaTypes suitable for JSON or YAML export.
+func rawInfoForMediaTypes(m *MediaTypes, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForMediaType(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForNamedAny returns a description of NamedAny suitable for JSON or YAML export.
+func rawInfoForNamedAny(m *NamedAny, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Value != nil {
+		info.Content = append(info.Content, b.Key("value"))
+		info.Content = append(info.Content, rawInfoForAny(m.Value, b))
+	}
+	return info
+}
+
+// rawInfoForNamedCallbackOrReference returns a description of NamedCallbackOrReference suitable for JSON or YAML export.
+func rawInfoForNamedCallbackOrReference(m *NamedCallbackOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:CallbackOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedEncoding returns a description of NamedEncoding suitable for JSON or YAML export.
+func rawInfoForNamedEncoding(m *NamedEncoding, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:Encoding StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedExampleOrReference returns a description of NamedExampleOrReference suitable for JSON or YAML export.
+func rawInfoForNamedExampleOrReference(m *NamedExampleOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:ExampleOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedHeaderOrReference returns a description of NamedHeaderOrReference suitable for JSON or YAML export.
+func rawInfoForNamedHeaderOrReference(m *NamedHeaderOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:HeaderOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedLinkOrReference returns a description of NamedLinkOrReference suitable for JSON or YAML export.
+func rawInfoForNamedLinkOrReference(m *NamedLinkOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:LinkOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedMediaType returns a description of NamedMediaType suitable for JSON or YAML export.
+func rawInfoForNamedMediaType(m *NamedMediaType, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:MediaType StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedParameterOrReference returns a description of NamedParameterOrReference suitable for JSON or YAML export.
+func rawInfoForNamedParameterOrReference(m *NamedParameterOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:ParameterOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedPathItem returns a description of NamedPathItem suitable for JSON or YAML export.
+func rawInfoForNamedPathItem(m *NamedPathItem, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:PathItem StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedRequestBodyOrReference returns a description of NamedRequestBodyOrReference suitable for JSON or YAML export.
+func rawInfoForNamedRequestBodyOrReference(m *NamedRequestBodyOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:RequestBodyOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedResponseOrReference returns a description of NamedResponseOrReference suitable for JSON or YAML export.
+func rawInfoForNamedResponseOrReference(m *NamedResponseOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:ResponseOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedSchemaOrReference returns a description of NamedSchemaOrReference suitable for JSON or YAML export.
+func rawInfoForNamedSchemaOrReference(m *NamedSchemaOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:SchemaOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedSecuritySchemeOrReference returns a description of NamedSecuritySchemeOrReference suitable for JSON or YAML export.
+func rawInfoForNamedSecuritySchemeOrReference(m *NamedSecuritySchemeOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:SecuritySchemeOrReference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedServerVariable returns a description of NamedServerVariable suitable for JSON or YAML export.
+func rawInfoForNamedServerVariable(m *NamedServerVariable, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:ServerVariable StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForNamedString returns a description of NamedString suitable for JSON or YAML export.
+func rawInfoForNamedString(m *NamedString, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(4)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Value != "" {
+		info.Content = append(info.Content, b.Key("value"))
+		info.Content = append(info.Content, b.String(m.Value))
+	}
+	return info
+}
+
+// rawInfoForNamedStringArray returns a description of NamedStringArray suitable for JSON or YAML export.
+func rawInfoForNamedStringArray(m *NamedStringArray, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2)
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	// &{Name:value Type:StringArray StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:Mapped value}
+	return info
+}
+
+// rawInfoForOauthFlow returns a description of OauthFlow suitable for JSON or YAML export.
+func rawInfoForOauthFlow(m *OauthFlow, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(8 + 2*len(m.SpecificationExtension))
+	if m.AuthorizationUrl != "" {
+		info.Content = append(info.Content, b.Key("authorizationUrl"))
+		info.Content = append(info.Content, b.String(m.AuthorizationUrl))
+	}
+	if m.TokenUrl != "" {
+		info.Content = append(info.Content, b.Key("tokenUrl"))
+		info.Content = append(info.Content, b.String(m.TokenUrl))
+	}
+	if m.RefreshUrl != "" {
+		info.Content = append(info.Content, b.Key("refreshUrl"))
+		info.Content = append(info.Content, b.String(m.RefreshUrl))
+	}
+	if m.Scopes != nil {
+		info.Content = append(info.Content, b.Key("scopes"))
+		info.Content = append(info.Content, rawInfoForStrings(m.Scopes, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForOauthFlows returns a description of OauthFlows suitable for JSON or YAML export.
+func rawInfoForOauthFlows(m *OauthFlows, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(8 + 2*len(m.SpecificationExtension))
+	if m.Implicit != nil {
+		info.Content = append(info.Content, b.Key("implicit"))
+		info.Content = append(info.Content, rawInfoForOauthFlow(m.Implicit, b))
+	}
+	if m.Password != nil {
+		info.Content = append(info.Content, b.Key("password"))
+		info.Content = append(info.Content, rawInfoForOauthFlow(m.Password, b))
+	}
+	if m.ClientCredentials != nil {
+		info.Content = append(info.Content, b.Key("clientCredentials"))
+		info.Content = append(info.Content, rawInfoForOauthFlow(m.ClientCredentials, b))
+	}
+	if m.AuthorizationCode != nil {
+		info.Content = append(info.Content, b.Key("authorizationCode"))
+		info.Content = append(info.Content, rawInfoForOauthFlow(m.AuthorizationCode, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForObject returns a description of Object suitable for JSON or YAML export.
+func rawInfoForObject(m *Object, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForOperation returns a description of Operation suitable for JSON or YAML export.
+func rawInfoForOperation(m *Operation, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.SpecificationExtension))
+	if len(m.Tags) != 0 {
+		info.Content = append(info.Content, b.Key("tags"))
+		info.Content = append(info.Content, b.StringArray(m.Tags))
+	}
+	if m.Summary != "" {
+		info.Content = append(info.Content, b.Key("summary"))
+		info.Content = append(info.Content, b.String(m.Summary))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.OperationId != "" {
+		info.Content = append(info.Content, b.Key("operationId"))
+		info.Content = append(info.Content, b.String(m.OperationId))
+	}
+	if len(m.Parameters) != 0 {
+		items := b.Sequence(len(m.Parameters))
+		for _, item := range m.Parameters {
+			items.Content = append(items.Content, rawInfoForParameterOrReference(item, b))
+		}
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, items)
+	}
+	if m.RequestBody != nil {
+		info.Content = append(info.Content, b.Key("requestBody"))
+		info.Content = append(info.Content, rawInfoForRequestBodyOrReference(m.RequestBody, b))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("responses"))
+	info.Content = append(info.Content, rawInfoForResponses(m.Responses, b))
+	if m.Callbacks != nil {
+		info.Content = append(info.Content, b.Key("callbacks"))
+		info.Content = append(info.Content, rawInfoForCallbacksOrReferences(m.Callbacks, b))
+	}
+	if m.Deprecated != false {
+		info.Content = append(info.Content, b.Key("deprecated"))
+		info.Content = append(info.Content, b.Bool(m.Deprecated))
+	}
+	if len(m.Security) != 0 {
+		items := b.Sequence(len(m.Security))
+		for _, item := range m.Security {
+			items.Content = append(items.Content, rawInfoForSecurityRequirement(item, b))
+		}
+		info.Content = append(info.Content, b.Key("security"))
+		info.Content = append(info.Content, items)
+	}
+	if len(m.Servers) != 0 {
+		items := b.Sequence(len(m.Servers))
+		for _, item := range m.Servers {
+			items.Content = append(items.Content, rawInfoForServer(item, b))
+		}
+		info.Content = append(info.Content, b.Key("servers"))
+		info.Content = append(info.Content, items)
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForParameter returns a description of Parameter suitable for JSON or YAML export.
+func rawInfoForParameter(m *Parameter, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("name"))
+	info.Content = append(info.Content, b.String(m.Name))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("in"))
+	info.Content = append(info.Content, b.String(m.In))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	if m.Deprecated != false {
+		info.Content = append(info.Content, b.Key("deprecated"))
+		info.Content = append(info.Content, b.Bool(m.Deprecated))
+	}
+	if m.AllowEmptyValue != false {
+		info.Content = append(info.Content, b.Key("allowEmptyValue"))
+		info.Content = append(info.Content, b.Bool(m.AllowEmptyValue))
+	}
+	if m.Style != "" {
+		info.Content = append(info.Content, b.Key("style"))
+		info.Content = append(info.Content, b.String(m.Style))
+	}
+	if m.Explode != false {
+		info.Content = append(info.Content, b.Key("explode"))
+		info.Content = append(info.Content, b.Bool(m.Explode))
+	}
+	if m.AllowReserved != false {
+		info.Content = append(info.Content, b.Key("allowReserved"))
+		info.Content = append(info.Content, b.Bool(m.AllowReserved))
+	}
+	if m.Schema != nil {
+		info.Content = append(info.Content, b.Key("schema"))
+		info.Content = append(info.Content, rawInfoForSchemaOrReference(m.Schema, b))
+	}
+	if m.Example != nil {
+		info.Content = append(info.Content, b.Key("example"))
+		info.Content = append(info.Content, rawInfoForAny(m.Example, b))
+	}
+	if m.Examples != nil {
+		info.Content = append(info.Content, b.Key("examples"))
+		info.Content = append(info.Content, rawInfoForExamplesOrReferences(m.Examples, b))
+	}
+	if m.Content != nil {
+		info.Content = append(info.Content, b.Key("content"))
+		info.Content = append(info.Content, rawInfoForMediaTypes(m.Content, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForParameterOrReference returns a description of ParameterOrReference suitable for JSON or YAML export.
+func rawInfoForParameterOrReference(m *ParameterOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// ParameterOrReference
+	// {Name:parameter Type:Parameter StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetParameter()
+	if v0 != nil {
+		return rawInfoForParameter(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForParametersOrReferences returns a description of ParametersOrReferences suitable for JSON or YAML export.
+func rawInfoForParametersOrReferences(m *ParametersOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForParameterOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForPathItem returns a description of PathItem suitable for JSON or YAML export.
+func rawInfoForPathItem(m *PathItem, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.SpecificationExtension))
+	if m.XRef != "" {
+		info.Content = append(info.Content, b.Key("$ref"))
+		info.Content = append(info.Content, b.String(m.XRef))
+	}
+	if m.Summary != "" {
+		info.Content = append(info.Content, b.Key("summary"))
+		info.Content = append(info.Content, b.String(m.Summary))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Get != nil {
+		info.Content = append(info.Content, b.Key("get"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Get, b))
+	}
+	if m.Put != nil {
+		info.Content = append(info.Content, b.Key("put"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Put, b))
+	}
+	if m.Post != nil {
+		info.Content = append(info.Content, b.Key("post"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Post, b))
+	}
+	if m.Delete != nil {
+		info.Content = append(info.Content, b.Key("delete"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Delete, b))
+	}
+	if m.Options != nil {
+		info.Content = append(info.Content, b.Key("options"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Options, b))
+	}
+	if m.Head != nil {
+		info.Content = append(info.Content, b.Key("head"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Head, b))
+	}
+	if m.Patch != nil {
+		info.Content = append(info.Content, b.Key("patch"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Patch, b))
+	}
+	if m.Trace != nil {
+		info.Content = append(info.Content, b.Key("trace"))
+		info.Content = append(info.Content, rawInfoForOperation(m.Trace, b))
+	}
+	if len(m.Servers) != 0 {
+		items := b.Sequence(len(m.Servers))
+		for _, item := range m.Servers {
+			items.Content = append(items.Content, rawInfoForServer(item, b))
+		}
+		info.Content = append(info.Content, b.Key("servers"))
+		info.Content = append(info.Content, items)
+	}
+	if len(m.Parameters) != 0 {
+		items := b.Sequence(len(m.Parameters))
+		for _, item := range m.Parameters {
+			items.Content = append(items.Content, rawInfoForParameterOrReference(item, b))
+		}
+		info.Content = append(info.Content, b.Key("parameters"))
+		info.Content = append(info.Content, items)
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForPaths returns a description of Paths suitable for JSON or YAML export.
+func rawInfoForPaths(m *Paths, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2*len(m.Path) + 2*len(m.SpecificationExtension))
+	if m.Path != nil {
+		for _, item := range m.Path {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForPathItem(item.Value, b))
+		}
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForProperties returns a description of Properties suitable for JSON or YAML export.
+func rawInfoForProperties(m *Properties, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForSchemaOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForReference returns a description of Reference suitable for JSON or YAML export.
+func rawInfoForReference(m *Reference, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(6)
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("$ref"))
+	info.Content = append(info.Content, b.String(m.XRef))
+	if m.Summary != "" {
+		info.Content = append(info.Content, b.Key("summary"))
+		info.Content = append(info.Content, b.String(m.Summary))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	return info
+}
+
+// rawInfoForRequestBodiesOrReferences returns a description of RequestBodiesOrReferences suitable for JSON or YAML export.
+func rawInfoForRequestBodiesOrReferences(m *RequestBodiesOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForRequestBodyOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForRequestBody returns a description of RequestBody suitable for JSON or YAML export.
+func rawInfoForRequestBody(m *RequestBody, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(6 + 2*len(m.SpecificationExtension))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("content"))
+	info.Content = append(info.Content, rawInfoForMediaTypes(m.Content, b))
+	if m.Required != false {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.Bool(m.Required))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForRequestBodyOrReference returns a description of RequestBodyOrReference suitable for JSON or YAML export.
+func rawInfoForRequestBodyOrReference(m *RequestBodyOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// RequestBodyOrReference
+	// {Name:requestBody Type:RequestBody StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetRequestBody()
+	if v0 != nil {
+		return rawInfoForRequestBody(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForResponse returns a description of Response suitable for JSON or YAML export.
+func rawInfoForResponse(m *Response, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(8 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("description"))
+	info.Content = append(info.Content, b.String(m.Description))
+	if m.Headers != nil {
+		info.Content = append(info.Content, b.Key("headers"))
+		info.Content = append(info.Content, rawInfoForHeadersOrReferences(m.Headers, b))
+	}
+	if m.Content != nil {
+		info.Content = append(info.Content, b.Key("content"))
+		info.Content = append(info.Content, rawInfoForMediaTypes(m.Content, b))
+	}
+	if m.Links != nil {
+		info.Content = append(info.Content, b.Key("links"))
+		info.Content = append(info.Content, rawInfoForLinksOrReferences(m.Links, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForResponseOrReference returns a description of ResponseOrReference suitable for JSON or YAML export.
+func rawInfoForResponseOrReference(m *ResponseOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// ResponseOrReference
+	// {Name:response Type:Response StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetResponse()
+	if v0 != nil {
+		return rawInfoForResponse(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForResponses returns a description of Responses suitable for JSON or YAML export.
+func rawInfoForResponses(m *Responses, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 + 2*len(m.ResponseOrReference) + 2*len(m.SpecificationExtension))
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForResponseOrReference(m.Default, b))
+	}
+	if m.ResponseOrReference != nil {
+		for _, item := range m.ResponseOrReference {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForResponseOrReference(item.Value, b))
+		}
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForResponsesOrReferences returns a description of ResponsesOrReferences suitable for JSON or YAML export.
+func rawInfoForResponsesOrReferences(m *ResponsesOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForResponseOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSchema returns a description of Schema suitable for JSON or YAML export.
+func rawInfoForSchema(m *Schema, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.SpecificationExtension))
+	if m.Nullable != false {
+		info.Content = append(info.Content, b.Key("nullable"))
+		info.Content = append(info.Content, b.Bool(m.Nullable))
+	}
+	if m.Discriminator != nil {
+		info.Content = append(info.Content, b.Key("discriminator"))
+		info.Content = append(info.Content, rawInfoForDiscriminator(m.Discriminator, b))
+	}
+	if m.ReadOnly != false {
+		info.Content = append(info.Content, b.Key("readOnly"))
+		info.Content = append(info.Content, b.Bool(m.ReadOnly))
+	}
+	if m.WriteOnly != false {
+		info.Content = append(info.Content, b.Key("writeOnly"))
+		info.Content = append(info.Content, b.Bool(m.WriteOnly))
+	}
+	if m.Xml != nil {
+		info.Content = append(info.Content, b.Key("xml"))
+		info.Content = append(info.Content, rawInfoForXml(m.Xml, b))
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.Example != nil {
+		info.Content = append(info.Content, b.Key("example"))
+		info.Content = append(info.Content, rawInfoForAny(m.Example, b))
+	}
+	if m.Deprecated != false {
+		info.Content = append(info.Content, b.Key("deprecated"))
+		info.Content = append(info.Content, b.Bool(m.Deprecated))
+	}
+	if m.Title != "" {
+		info.Content = append(info.Content, b.Key("title"))
+		info.Content = append(info.Content, b.String(m.Title))
+	}
+	if m.MultipleOf != 0.0 {
+		info.Content = append(info.Content, b.Key("multipleOf"))
+		info.Content = append(info.Content, b.Float(m.MultipleOf))
+	}
+	if m.Maximum != 0.0 {
+		info.Content = append(info.Content, b.Key("maximum"))
+		info.Content = append(info.Content, b.Float(m.Maximum))
+	}
+	if m.ExclusiveMaximum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMaximum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMaximum))
+	}
+	if m.Minimum != 0.0 {
+		info.Content = append(info.Content, b.Key("minimum"))
+		info.Content = append(info.Content, b.Float(m.Minimum))
+	}
+	if m.ExclusiveMinimum != false {
+		info.Content = append(info.Content, b.Key("exclusiveMinimum"))
+		info.Content = append(info.Content, b.Bool(m.ExclusiveMinimum))
+	}
+	if m.MaxLength != 0 {
+		info.Content = append(info.Content, b.Key("maxLength"))
+		info.Content = append(info.Content, b.Int(m.MaxLength))
+	}
+	if m.MinLength != 0 {
+		info.Content = append(info.Content, b.Key("minLength"))
+		info.Content = append(info.Content, b.Int(m.MinLength))
+	}
+	if m.Pattern != "" {
+		info.Content = append(info.Content, b.Key("pattern"))
+		info.Content = append(info.Content, b.String(m.Pattern))
+	}
+	if m.MaxItems != 0 {
+		info.Content = append(info.Content, b.Key("maxItems"))
+		info.Content = append(info.Content, b.Int(m.MaxItems))
+	}
+	if m.MinItems != 0 {
+		info.Content = append(info.Content, b.Key("minItems"))
+		info.Content = append(info.Content, b.Int(m.MinItems))
+	}
+	if m.UniqueItems != false {
+		info.Content = append(info.Content, b.Key("uniqueItems"))
+		info.Content = append(info.Content, b.Bool(m.UniqueItems))
+	}
+	if m.MaxProperties != 0 {
+		info.Content = append(info.Content, b.Key("maxProperties"))
+		info.Content = append(info.Content, b.Int(m.MaxProperties))
+	}
+	if m.MinProperties != 0 {
+		info.Content = append(info.Content, b.Key("minProperties"))
+		info.Content = append(info.Content, b.Int(m.MinProperties))
+	}
+	if len(m.Required) != 0 {
+		info.Content = append(info.Content, b.Key("required"))
+		info.Content = append(info.Content, b.StringArray(m.Required))
+	}
+	if len(m.Enum) != 0 {
+		items := b.Sequence(len(m.Enum))
+		for _, item := range m.Enum {
+			items.Content = append(items.Content, rawInfoForAny(item, b))
+		}
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, items)
+	}
+	if m.Type != "" {
+		info.Content = append(info.Content, b.Key("type"))
+		info.Content = append(info.Content, b.String(m.Type))
+	}
+	if len(m.AllOf) != 0 {
+		items := b.Sequence(len(m.AllOf))
+		for _, item := range m.AllOf {
+			items.Content = append(items.Content, rawInfoForSchemaOrReference(item, b))
+		}
+		info.Content = append(info.Content, b.Key("allOf"))
+		info.Content = append(info.Content, items)
+	}
+	if len(m.OneOf) != 0 {
+		items := b.Sequence(len(m.OneOf))
+		for _, item := range m.OneOf {
+			items.Content = append(items.Content, rawInfoForSchemaOrReference(item, b))
+		}
+		info.Content = append(info.Content, b.Key("oneOf"))
+		info.Content = append(info.Content, items)
+	}
+	if len(m.AnyOf) != 0 {
+		items := b.Sequence(len(m.AnyOf))
+		for _, item := range m.AnyOf {
+			items.Content = append(items.Content, rawInfoForSchemaOrReference(item, b))
+		}
+		info.Content = append(info.Content, b.Key("anyOf"))
+		info.Content = append(info.Content, items)
+	}
+	if m.Not != nil {
+		info.Content = append(info.Content, b.Key("not"))
+		info.Content = append(info.Content, rawInfoForSchema(m.Not, b))
+	}
+	if m.Items != nil {
+		items := b.Sequence(len(m.Items.SchemaOrReference))
+		for _, item := range m.Items.SchemaOrReference {
+			items.Content = append(items.Content, rawInfoForSchemaOrReference(item, b))
+		}
+		if len(items.Content) == 1 {
+			items = items.Content[0]
+		}
+		info.Content = append(info.Content, b.Key("items"))
+		info.Content = append(info.Content, items)
+	}
+	if m.Properties != nil {
+		info.Content = append(info.Content, b.Key("properties"))
+		info.Content = append(info.Content, rawInfoForProperties(m.Properties, b))
+	}
+	if m.AdditionalProperties != nil {
+		info.Content = append(info.Content, b.Key("additionalProperties"))
+		info.Content = append(info.Content, rawInfoForAdditionalPropertiesItem(m.AdditionalProperties, b))
+	}
+	if m.Default != nil {
+		info.Content = append(info.Content, b.Key("default"))
+		info.Content = append(info.Content, rawInfoForDefaultType(m.Default, b))
+	}
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Format != "" {
+		info.Content = append(info.Content, b.Key("format"))
+		info.Content = append(info.Content, b.String(m.Format))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSchemaOrReference returns a description of SchemaOrReference suitable for JSON or YAML export.
+func rawInfoForSchemaOrReference(m *SchemaOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// SchemaOrReference
+	// {Name:schema Type:Schema StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetSchema()
+	if v0 != nil {
+		return rawInfoForSchema(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForSchemasOrReferences returns a description of SchemasOrReferences suitable for JSON or YAML export.
+func rawInfoForSchemasOrReferences(m *SchemasOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForSchemaOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSecurityRequirement returns a description of SecurityRequirement suitable for JSON or YAML export.
+func rawInfoForSecurityRequirement(m *SecurityRequirement, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForStringArray(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSecurityScheme returns a description of SecurityScheme suitable for JSON or YAML export.
+func rawInfoForSecurityScheme(m *SecurityScheme, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(16 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("type"))
+	info.Content = append(info.Content, b.String(m.Type))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.In != "" {
+		info.Content = append(info.Content, b.Key("in"))
+		info.Content = append(info.Content, b.String(m.In))
+	}
+	if m.Scheme != "" {
+		info.Content = append(info.Content, b.Key("scheme"))
+		info.Content = append(info.Content, b.String(m.Scheme))
+	}
+	if m.BearerFormat != "" {
+		info.Content = append(info.Content, b.Key("bearerFormat"))
+		info.Content = append(info.Content, b.String(m.BearerFormat))
+	}
+	if m.Flows != nil {
+		info.Content = append(info.Content, b.Key("flows"))
+		info.Content = append(info.Content, rawInfoForOauthFlows(m.Flows, b))
+	}
+	if m.OpenIdConnectUrl != "" {
+		info.Content = append(info.Content, b.Key("openIdConnectUrl"))
+		info.Content = append(info.Content, b.String(m.OpenIdConnectUrl))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSecuritySchemeOrReference returns a description of SecuritySchemeOrReference suitable for JSON or YAML export.
+func rawInfoForSecuritySchemeOrReference(m *SecuritySchemeOrReference, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// SecuritySchemeOrReference
+	// {Name:securityScheme Type:SecurityScheme StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v0 := m.GetSecurityScheme()
+	if v0 != nil {
+		return rawInfoForSecurityScheme(v0, b)
+	}
+	// {Name:reference Type:Reference StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	v1 := m.GetReference()
+	if v1 != nil {
+		return rawInfoForReference(v1, b)
+	}
+	return b.Null()
+}
+
+// rawInfoForSecuritySchemesOrReferences returns a description of SecuritySchemesOrReferences suitable for JSON or YAML export.
+func rawInfoForSecuritySchemesOrReferences(m *SecuritySchemesOrReferences, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForSecuritySchemeOrReference(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForServer returns a description of Server suitable for JSON or YAML export.
+func rawInfoForServer(m *Server, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(6 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("url"))
+	info.Content = append(info.Content, b.String(m.Url))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.Variables != nil {
+		info.Content = append(info.Content, b.Key("variables"))
+		info.Content = append(info.Content, rawInfoForServerVariables(m.Variables, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForServerVariable returns a description of ServerVariable suitable for JSON or YAML export.
+func rawInfoForServerVariable(m *ServerVariable, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(6 + 2*len(m.SpecificationExtension))
+	if len(m.Enum) != 0 {
+		info.Content = append(info.Content, b.Key("enum"))
+		info.Content = append(info.Content, b.StringArray(m.Enum))
+	}
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("default"))
+	info.Content = append(info.Content, b.String(m.Default))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForServerVariables returns a description of ServerVariables suitable for JSON or YAML export.
+func rawInfoForServerVariables(m *ServerVariables, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForServerVariable(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForSpecificationExtension returns a description of SpecificationExtension suitable for JSON or YAML export.
+func rawInfoForSpecificationExtension(m *SpecificationExtension, b *compiler.NodeBuilder) *yaml.Node {
+	// ONE OF WRAPPER
+	// SpecificationExtension
+	// {Name:number Type:float StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	if v0, ok := m.GetOneof().(*SpecificationExtension_Number); ok {
+		return b.Float(v0.Number)
+	}
+	// {Name:boolean Type:bool StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	if v1, ok := m.GetOneof().(*SpecificationExtension_Boolean); ok {
+		return b.Bool(v1.Boolean)
+	}
+	// {Name:string Type:string StringEnumValues:[] MapType: Repeated:false Pattern: Implicit:false Description:}
+	if v2, ok := m.GetOneof().(*SpecificationExtension_String_); ok {
+		return b.String(v2.String_)
+	}
+	return b.Null()
+}
+
+// rawInfoForStringArray returns a description of StringArray suitable for JSON or YAML export.
+func rawInfoForStringArray(m *StringArray, b *compiler.NodeBuilder) *yaml.Node {
+	return b.StringArray(m.Value)
+}
+
+// rawInfoForStrings returns a description of Strings suitable for JSON or YAML export.
+func rawInfoForStrings(m *Strings, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(2 * len(m.AdditionalProperties))
+	if m.AdditionalProperties != nil {
+		for _, item := range m.AdditionalProperties {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, b.String(item.Value))
+		}
+	}
+	return info
+}
+
+// rawInfoForTag returns a description of Tag suitable for JSON or YAML export.
+func rawInfoForTag(m *Tag, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(6 + 2*len(m.SpecificationExtension))
+	// always include this required field.
+	info.Content = append(info.Content, b.Key("name"))
+	info.Content = append(info.Content, b.String(m.Name))
+	if m.Description != "" {
+		info.Content = append(info.Content, b.Key("description"))
+		info.Content = append(info.Content, b.String(m.Description))
+	}
+	if m.ExternalDocs != nil {
+		info.Content = append(info.Content, b.Key("externalDocs"))
+		info.Content = append(info.Content, rawInfoForExternalDocs(m.ExternalDocs, b))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
+// rawInfoForXml returns a description of Xml suitable for JSON or YAML export.
+func rawInfoForXml(m *Xml, b *compiler.NodeBuilder) *yaml.Node {
+	if m == nil {
+		return b.Mapping(0)
+	}
+	info := b.Mapping(10 + 2*len(m.SpecificationExtension))
+	if m.Name != "" {
+		info.Content = append(info.Content, b.Key("name"))
+		info.Content = append(info.Content, b.String(m.Name))
+	}
+	if m.Namespace != "" {
+		info.Content = append(info.Content, b.Key("namespace"))
+		info.Content = append(info.Content, b.String(m.Namespace))
+	}
+	if m.Prefix != "" {
+		info.Content = append(info.Content, b.Key("prefix"))
+		info.Content = append(info.Content, b.String(m.Prefix))
+	}
+	if m.Attribute != false {
+		info.Content = append(info.Content, b.Key("attribute"))
+		info.Content = append(info.Content, b.Bool(m.Attribute))
+	}
+	if m.Wrapped != false {
+		info.Content = append(info.Content, b.Key("wrapped"))
+		info.Content = append(info.Content, b.Bool(m.Wrapped))
+	}
+	if m.SpecificationExtension != nil {
+		for _, item := range m.SpecificationExtension {
+			info.Content = append(info.Content, b.String(item.Name))
+			info.Content = append(info.Content, rawInfoForAny(item.Value, b))
+		}
+	}
+	return info
+}
+
 var (
-	pattern0 = regexp.MustCompile("^")
-	pattern1 = regexp.MustCompile("^x-")
-	pattern2 = regexp.MustCompile("^/")
-	pattern3 = regexp.MustCompile("^([0-9X]{3})$")
+	pattern0                                     = regexp.MustCompile("^")
+	pattern1                                     = regexp.MustCompile("^x-")
+	pattern2                                     = regexp.MustCompile("^/")
+	pattern3                                     = regexp.MustCompile("^([0-9X]{3})$")
+	allowedKeysForCallback                       = compiler.NewKeySet([]string{}, []*regexp.Regexp{pattern0, pattern1})
+	allowedKeysForComponents                     = compiler.NewKeySet([]string{"callbacks", "examples", "headers", "links", "parameters", "requestBodies", "responses", "schemas", "securitySchemes"}, []*regexp.Regexp{pattern1})
+	allowedKeysForContact                        = compiler.NewKeySet([]string{"email", "name", "url"}, []*regexp.Regexp{pattern1})
+	allowedKeysForDiscriminator                  = compiler.NewKeySet([]string{"mapping", "propertyName"}, []*regexp.Regexp{pattern1})
+	allowedKeysForDocument                       = compiler.NewKeySet([]string{"components", "externalDocs", "info", "openapi", "paths", "security", "servers", "tags"}, []*regexp.Regexp{pattern1})
+	allowedKeysForEncoding                       = compiler.NewKeySet([]string{"allowReserved", "contentType", "explode", "headers", "style"}, []*regexp.Regexp{pattern1})
+	allowedKeysForExample                        = compiler.NewKeySet([]string{"description", "externalValue", "summary", "value"}, []*regexp.Regexp{pattern1})
+	allowedKeysForExternalDocs                   = compiler.NewKeySet([]string{"description", "url"}, []*regexp.Regexp{pattern1})
+	allowedKeysForHeader                         = compiler.NewKeySet([]string{"allowEmptyValue", "allowReserved", "content", "deprecated", "description", "example", "examples", "explode", "required", "schema", "style"}, []*regexp.Regexp{pattern1})
+	allowedKeysForInfo                           = compiler.NewKeySet([]string{"contact", "description", "license", "summary", "termsOfService", "title", "version"}, []*regexp.Regexp{pattern1})
+	allowedKeysForLicense                        = compiler.NewKeySet([]string{"name", "url"}, []*regexp.Regexp{pattern1})
+	allowedKeysForLink                           = compiler.NewKeySet([]string{"description", "operationId", "operationRef", "parameters", "requestBody", "server"}, []*regexp.Regexp{pattern1})
+	allowedKeysForMediaType                      = compiler.NewKeySet([]string{"encoding", "example", "examples", "schema"}, []*regexp.Regexp{pattern1})
+	allowedKeysForNamedAny                       = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedCallbackOrReference       = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedEncoding                  = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedExampleOrReference        = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedHeaderOrReference         = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedLinkOrReference           = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedMediaType                 = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedParameterOrReference      = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedPathItem                  = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedRequestBodyOrReference    = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedResponseOrReference       = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedSchemaOrReference         = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedSecuritySchemeOrReference = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedServerVariable            = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedString                    = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForNamedStringArray               = compiler.NewKeySet([]string{"name", "value"}, nil)
+	allowedKeysForOauthFlow                      = compiler.NewKeySet([]string{"authorizationUrl", "refreshUrl", "scopes", "tokenUrl"}, []*regexp.Regexp{pattern1})
+	allowedKeysForOauthFlows                     = compiler.NewKeySet([]string{"authorizationCode", "clientCredentials", "implicit", "password"}, []*regexp.Regexp{pattern1})
+	allowedKeysForOperation                      = compiler.NewKeySet([]string{"callbacks", "deprecated", "description", "externalDocs", "operationId", "parameters", "requestBody", "responses", "security", "servers", "summary", "tags"}, []*regexp.Regexp{pattern1})
+	allowedKeysForParameter                      = compiler.NewKeySet([]string{"allowEmptyValue", "allowReserved", "content", "deprecated", "description", "example", "examples", "explode", "in", "name", "required", "schema", "style"}, []*regexp.Regexp{pattern1})
+	allowedKeysForPathItem                       = compiler.NewKeySet([]string{"$ref", "delete", "description", "get", "head", "options", "parameters", "patch", "post", "put", "servers", "summary", "trace"}, []*regexp.Regexp{pattern1})
+	allowedKeysForPaths                          = compiler.NewKeySet([]string{}, []*regexp.Regexp{pattern2, pattern1})
+	allowedKeysForRequestBody                    = compiler.NewKeySet([]string{"content", "description", "required"}, []*regexp.Regexp{pattern1})
+	allowedKeysForResponse                       = compiler.NewKeySet([]string{"content", "description", "headers", "links"}, []*regexp.Regexp{pattern1})
+	allowedKeysForResponses                      = compiler.NewKeySet([]string{"default"}, []*regexp.Regexp{pattern3, pattern1})
+	allowedKeysForSchema                         = compiler.NewKeySet([]string{"additionalProperties", "allOf", "anyOf", "default", "deprecated", "description", "discriminator", "enum", "example", "exclusiveMaximum", "exclusiveMinimum", "externalDocs", "format", "items", "maxItems", "maxLength", "maxProperties", "maximum", "minItems", "minLength", "minProperties", "minimum", "multipleOf", "not", "nullable", "oneOf", "pattern", "properties", "readOnly", "required", "title", "type", "uniqueItems", "writeOnly", "xml"}, []*regexp.Regexp{pattern1})
+	allowedKeysForSecurityScheme                 = compiler.NewKeySet([]string{"bearerFormat", "description", "flows", "in", "name", "openIdConnectUrl", "scheme", "type"}, []*regexp.Regexp{pattern1})
+	allowedKeysForServer                         = compiler.NewKeySet([]string{"description", "url", "variables"}, []*regexp.Regexp{pattern1})
+	allowedKeysForServerVariable                 = compiler.NewKeySet([]string{"default", "description", "enum"}, []*regexp.Regexp{pattern1})
+	allowedKeysForTag                            = compiler.NewKeySet([]string{"description", "externalDocs", "name"}, []*regexp.Regexp{pattern1})
+	allowedKeysForXml                            = compiler.NewKeySet([]string{"attribute", "name", "namespace", "prefix", "wrapped"}, []*regexp.Regexp{pattern1})
 )
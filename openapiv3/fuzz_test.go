@@ -0,0 +1,38 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package openapi_v3
+
+import (
+	"testing"
+
+	"github.com/okkoye/gnostic/internal/fuzzing"
+)
+
+// FuzzParseDocument checks that ParseDocument returns an error for
+// malformed descriptions instead of panicking. Run it with
+//
+//	go test ./openapiv3 -run=NONE -fuzz=FuzzParseDocument
+func FuzzParseDocument(f *testing.F) {
+	fuzzing.AddFiles(f,
+		"../examples/v3.0/json/*.json",
+		"../examples/v3.0/yaml/*.yaml",
+	)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		ParseDocument(b)
+	})
+}
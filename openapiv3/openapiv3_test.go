@@ -16,7 +16,14 @@ package openapi_v3
 
 import (
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
 )
 
 func TestParseDocument(t *testing.T) {
@@ -61,3 +68,185 @@ func TestParseDocument_Empty(t *testing.T) {
 		})
 	}
 }
+
+func TestResolver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resolver")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"openapi.yaml": `openapi: 3.0.0
+info:
+  title: Resolver
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: "#/components/parameters/limit"
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pets"
+        default:
+          $ref: "common/responses.yaml#/error"
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+  schemas:
+    Pets:
+      $ref: "#/components/schemas/PetList"
+    PetList:
+      type: array
+      items:
+        $ref: "common/schemas.yaml#/Pet"
+    Loop:
+      $ref: "#/components/schemas/Loop"
+`,
+		"common/responses.yaml": `error:
+  description: error
+  content:
+    application/json:
+      schema:
+        $ref: "schemas.yaml#/Error"
+`,
+		"common/schemas.yaml": `Pet:
+  type: object
+Error:
+  type: object
+  properties:
+    code:
+      $ref: "#/Code"
+Code:
+  type: integer
+`,
+	}
+	for name, contents := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	root := filepath.Join(dir, "openapi.yaml")
+	b, err := ioutil.ReadFile(root)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	d, err := ParseDocument(b)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	r := NewResolver(root)
+	get := d.Paths.Path[0].Value.Get
+
+	parameter, err := r.Parameter(get.Parameters[0])
+	if err != nil || parameter.Name != "limit" {
+		t.Errorf("unexpected parameter %v (%v)", parameter, err)
+	}
+	// References to references are followed.
+	pets, err := r.Schema(get.Responses.ResponseOrReference[0].Value.GetResponse().Content.AdditionalProperties[0].Value.Schema)
+	if err != nil || pets.Type != "array" {
+		t.Fatalf("unexpected schema %v (%v)", pets, err)
+	}
+	// References in other files are relative to those files.
+	pet, err := r.Schema(pets.Items.SchemaOrReference[0])
+	if err != nil || pet.Type != "object" {
+		t.Errorf("unexpected schema %v (%v)", pet, err)
+	}
+	response, err := r.Response(get.Responses.Default)
+	if err != nil || response.Description != "error" {
+		t.Fatalf("unexpected response %v (%v)", response, err)
+	}
+	e, err := r.Schema(response.Content.AdditionalProperties[0].Value.Schema)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	code, err := r.Schema(e.Properties.AdditionalProperties[0].Value)
+	if err != nil || code.Type != "integer" {
+		t.Errorf("unexpected schema %v (%v)", code, err)
+	}
+	// Targets are compiled once.
+	again, err := r.Schema(pets.Items.SchemaOrReference[0])
+	if err != nil || again != pet {
+		t.Errorf("expected the previously resolved schema")
+	}
+	// Inline values are returned directly.
+	if s, err := r.Schema(d.Components.Schemas.AdditionalProperties[1].Value); err != nil || s.Type != "array" {
+		t.Errorf("unexpected schema %v (%v)", s, err)
+	}
+	if _, err := r.Schema(&SchemaOrReference{Oneof: &SchemaOrReference_Reference{Reference: &Reference{XRef: "#/components/schemas/Loop"}}}); err == nil {
+		t.Errorf("expected an error for a circular reference")
+	}
+	if _, err := r.Schema(&SchemaOrReference{Oneof: &SchemaOrReference_Reference{Reference: &Reference{XRef: "#/components/schemas/Missing"}}}); err == nil {
+		t.Errorf("expected an error for a missing reference")
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte(`openapi: 3.0.0
+info: {title: Paths, version: 1.0.0}
+paths:
+  /pets/{id}: {}
+  /pets/{id}/toys: {}
+  /pets/mine: {}
+  /pets/{petId}: {}
+`), &node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	root := node.Content[0]
+	context := compiler.NewContextWithExtensions("$root", root, nil, nil)
+	d, err := NewDocument(root, context)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	err = ValidateDocument(d, context)
+	if err == nil || compiler.FormatErrors("paths.yaml", err) != "paths.yaml:7:18: $root.paths./pets/{petId} is equivalent to /pets/{id}" {
+		t.Errorf("unexpected error %v", err)
+	}
+	// Without a context, errors have no locations.
+	err = ValidateDocument(d, nil)
+	if err == nil || err.Error() != "$root.paths./pets/{petId} is equivalent to /pets/{id}" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// TestRawInfo checks that descriptions made by RawInfo compile back into
+// the documents that they describe.
+func TestRawInfo(t *testing.T) {
+	filenames, err := filepath.Glob("../examples/v3.0/yaml/*.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, filename := range filenames {
+		b, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		d, err := ParseDocument(b)
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		b, err = yaml.Marshal(RawInfo(d))
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		d2, err := ParseDocument(b)
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		if !proto.Equal(d, d2) {
+			t.Errorf("%s: description differs from the document:\n%s", filename, b)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v3
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// A Resolver resolves the references in a document when they are used.
+// Document.ResolveReferences visits every reference in a document and
+// reads every file that they refer to; a Resolver instead reads only the
+// targets of the references that it is asked to resolve, and it compiles
+// each target at most once. This is faster for programs that only use
+// part of a large description.
+//
+// A Resolver is safe for concurrent use.
+type Resolver struct {
+	root    string
+	mutex   sync.Mutex
+	targets map[resolverKey]interface{}
+}
+
+type resolverKey struct {
+	kind string
+	ref  string
+}
+
+// NewResolver creates a resolver for references in a document that was
+// read from the named file or URL.
+func NewResolver(root string) *Resolver {
+	return &Resolver{root: root, targets: make(map[resolverKey]interface{})}
+}
+
+// Schema returns the schema of a SchemaOrReference, resolving its
+// reference if necessary.
+func (r *Resolver) Schema(s *SchemaOrReference) (*Schema, error) {
+	if s.GetReference() == nil {
+		return s.GetSchema(), nil
+	}
+	target, err := r.resolve("schema", s.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewSchema(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*Schema), nil
+}
+
+// Parameter returns the parameter of a ParameterOrReference, resolving
+// its reference if necessary.
+func (r *Resolver) Parameter(p *ParameterOrReference) (*Parameter, error) {
+	if p.GetReference() == nil {
+		return p.GetParameter(), nil
+	}
+	target, err := r.resolve("parameter", p.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewParameter(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*Parameter), nil
+}
+
+// RequestBody returns the request body of a RequestBodyOrReference,
+// resolving its reference if necessary.
+func (r *Resolver) RequestBody(b *RequestBodyOrReference) (*RequestBody, error) {
+	if b.GetReference() == nil {
+		return b.GetRequestBody(), nil
+	}
+	target, err := r.resolve("requestBody", b.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewRequestBody(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*RequestBody), nil
+}
+
+// Response returns the response of a ResponseOrReference, resolving its
+// reference if necessary.
+func (r *Resolver) Response(s *ResponseOrReference) (*Response, error) {
+	if s.GetReference() == nil {
+		return s.GetResponse(), nil
+	}
+	target, err := r.resolve("response", s.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewResponse(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*Response), nil
+}
+
+// Header returns the header of a HeaderOrReference, resolving its
+// reference if necessary.
+func (r *Resolver) Header(h *HeaderOrReference) (*Header, error) {
+	if h.GetReference() == nil {
+		return h.GetHeader(), nil
+	}
+	target, err := r.resolve("header", h.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewHeader(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*Header), nil
+}
+
+// Example returns the example of an ExampleOrReference, resolving its
+// reference if necessary.
+func (r *Resolver) Example(e *ExampleOrReference) (*Example, error) {
+	if e.GetReference() == nil {
+		return e.GetExample(), nil
+	}
+	target, err := r.resolve("example", e.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewExample(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*Example), nil
+}
+
+// Link returns the link of a LinkOrReference, resolving its reference if
+// necessary.
+func (r *Resolver) Link(l *LinkOrReference) (*Link, error) {
+	if l.GetReference() == nil {
+		return l.GetLink(), nil
+	}
+	target, err := r.resolve("link", l.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewLink(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*Link), nil
+}
+
+// Callback returns the callback of a CallbackOrReference, resolving its
+// reference if necessary.
+func (r *Resolver) Callback(c *CallbackOrReference) (*Callback, error) {
+	if c.GetReference() == nil {
+		return c.GetCallback(), nil
+	}
+	target, err := r.resolve("callback", c.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewCallback(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*Callback), nil
+}
+
+// SecurityScheme returns the security scheme of a
+// SecuritySchemeOrReference, resolving its reference if necessary.
+func (r *Resolver) SecurityScheme(s *SecuritySchemeOrReference) (*SecurityScheme, error) {
+	if s.GetReference() == nil {
+		return s.GetSecurityScheme(), nil
+	}
+	target, err := r.resolve("securityScheme", s.GetReference().XRef, func(node *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewSecurityScheme(node, context)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*SecurityScheme), nil
+}
+
+// Resolve a reference and compile its target, or return the target
+// that was compiled when the reference was last resolved.
+func (r *Resolver) resolve(kind, ref string, compile func(*yaml.Node, *compiler.Context) (interface{}, error)) (interface{}, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	key := resolverKey{kind: kind, ref: ref}
+	if target, ok := r.targets[key]; ok {
+		return target, nil
+	}
+	node, err := r.lookup(ref)
+	if err != nil {
+		return nil, err
+	}
+	target, err := compile(node, compiler.NewContext("$ref", node, nil))
+	if err != nil {
+		return nil, err
+	}
+	r.targets[key] = target
+	return target, nil
+}
+
+// Read the node that a reference refers to, following references to
+// references. References in nodes read from other files are rewritten
+// so that, like the references in the root document, they are relative
+// to the root.
+func (r *Resolver) lookup(ref string) (*yaml.Node, error) {
+	visited := make(map[string]bool)
+	for {
+		if visited[ref] {
+			return nil, fmt.Errorf("circular reference %s", ref)
+		}
+		visited[ref] = true
+		node, err := compiler.ReadInfoForRef(r.root, ref)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, fmt.Errorf("could not resolve %s", ref)
+		}
+		if file := strings.SplitN(ref, "#", 2)[0]; file != "" {
+			node = rebaseReferences(node, file)
+		}
+		next, ok := compiler.StringForScalarNode(compiler.MapValueForKey(node, "$ref"))
+		if !ok {
+			return node, nil
+		}
+		ref = next
+	}
+}
+
+// Copy a node, rewriting the references that it contains so that
+// references relative to file are relative to the root instead.
+func rebaseReferences(node *yaml.Node, file string) *yaml.Node {
+	result := *node
+	if node.Content != nil {
+		result.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			if node.Kind == yaml.MappingNode && i%2 == 1 &&
+				node.Content[i-1].Value == "$ref" && child.Kind == yaml.ScalarNode {
+				ref := *child
+				ref.Value = rebaseReference(child.Value, file)
+				result.Content[i] = &ref
+			} else {
+				result.Content[i] = rebaseReferences(child, file)
+			}
+		}
+	}
+	return &result
+}
+
+// Rewrite a reference that is relative to file.
+func rebaseReference(ref, file string) string {
+	parts := strings.SplitN(ref, "#", 2)
+	fragment := ""
+	if len(parts) == 2 {
+		fragment = "#" + parts[1]
+	}
+	if parts[0] == "" {
+		return file + fragment
+	}
+	return compiler.ReferencedFileName(file, parts[0]) + fragment
+}
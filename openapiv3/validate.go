@@ -0,0 +1,31 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v3
+
+import (
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// ValidateDocument reports the problems in a document that aren't found
+// when it is compiled, like paths that can't be distinguished (see
+// compiler.CheckEquivalentPaths). The context is the context that the
+// document was compiled with, or nil to report errors without locations.
+func ValidateDocument(document *Document, context *compiler.Context) error {
+	paths := make([]string, 0)
+	for _, namedPathItem := range document.GetPaths().GetPath() {
+		paths = append(paths, namedPathItem.Name)
+	}
+	return compiler.NewErrorGroupOrNil(compiler.CheckEquivalentPaths(paths, context))
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v31
+
+import (
+	"errors"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// ParseDocument reads an OpenAPI v3.1 description from a YAML/JSON representation.
+func ParseDocument(b []byte) (*Document, error) {
+	document, _, err := ParseDocumentWithAnnotations(b)
+	return document, err
+}
+
+// ParseDocumentWithAnnotations reads a description like ParseDocument and
+// also returns the annotations that are needed to write it as it was read.
+func ParseDocumentWithAnnotations(b []byte) (*Document, *compiler.Annotations, error) {
+	info, err := compiler.ReadInfoFromBytes("", b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(info.Content) < 1 {
+		return nil, nil, errors.New("document has no content")
+	}
+
+	root := info.Content[0]
+	document, err := NewDocument(root, compiler.NewContextWithExtensions("$root", root, nil, nil))
+	if document == nil {
+		return nil, nil, err
+	}
+	annotations := compiler.NewAnnotations(root)
+	annotations.RecordReferenceSiblings(root)
+	return document, annotations, err
+}
+
+// RawInfo returns a description of a document suitable for JSON or YAML
+// export. It has the same nodes as the description that ToRawInfo returns,
+// but they are allocated in blocks, and mapping keys share one node for
+// each key, so the nodes of keys must not be modified.
+func RawInfo(document *Document) *yaml.Node {
+	return rawInfoForDocument(document, compiler.NewNodeBuilder())
+}
+
+// YAMLValue produces a serialized YAML representation of the document.
+func (d *Document) YAMLValue(comment string) ([]byte, error) {
+	rawInfo := d.ToRawInfo()
+	rawInfo = &yaml.Node{
+		Kind:        yaml.DocumentNode,
+		Content:     []*yaml.Node{rawInfo},
+		HeadComment: comment,
+	}
+	return yaml.Marshal(rawInfo)
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v31
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+func TestParseDocument(t *testing.T) {
+	filename := "../examples/v3.1/yaml/petstore.yaml"
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read file %s", filename)
+	}
+	d, err := ParseDocument(b)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if d.Info.Summary != "A store that sells pets." || d.Info.License.Identifier != "MIT" {
+		t.Errorf("unexpected info %+v", d.Info)
+	}
+	if d.JsonSchemaDialect != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf("unexpected dialect %s", d.JsonSchemaDialect)
+	}
+	webhooks := d.GetWebhooks().GetAdditionalProperties()
+	if len(webhooks) != 1 || webhooks[0].Name != "newPet" || webhooks[0].Value.Post == nil {
+		t.Errorf("unexpected webhooks %+v", webhooks)
+	}
+	pet := d.Components.Schemas.AdditionalProperties[0].Value.GetSchema()
+	properties := make(map[string]*Schema)
+	for _, property := range pet.Properties.AdditionalProperties {
+		properties[property.Name] = property.Value.GetSchema()
+	}
+	if tag := properties["tag"]; !tag.IsNullable() || !reflect.DeepEqual(tag.NonNullTypes(), []string{"string"}) {
+		t.Errorf("expected tag to be a nullable string, got %v", tag.Types())
+	}
+	if name := properties["name"]; name.IsNullable() || !reflect.DeepEqual(name.Types(), []string{"string"}) {
+		t.Errorf("expected name to be a string, got %v", name.Types())
+	}
+	if kind := properties["kind"]; kind.Const.GetYaml() != "pet\n" {
+		t.Errorf("unexpected const %q", kind.Const.GetYaml())
+	}
+}
+
+func TestParseDocument_Empty(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte{}, []byte("   ")} {
+		d, err := ParseDocument(data)
+		if err == nil || err.Error() != "document has no content" {
+			t.Errorf("unexpected error %v", err)
+		}
+		if d != nil {
+			t.Error("expected document to be nil")
+		}
+	}
+}
+
+func TestResolveReferencesWithContext(t *testing.T) {
+	d, err := ParseDocument([]byte(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    $ref: 'paths.yaml#/pets'
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	fetched := make([]string, 0)
+	context := compiler.NewContext("$root", nil, nil)
+	context.Fetcher = compiler.FetcherFunc(func(fileurl string) ([]byte, error) {
+		fetched = append(fetched, fileurl)
+		return []byte("pets:\n  get:\n    operationId: listPets\n    responses:\n      '200':\n        description: ok\n"), nil
+	})
+	// The fetcher of the context is used by the resolvers of every message.
+	if _, err := d.ResolveReferencesWithContext("https://example.com/api/openapi.yaml", context); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !reflect.DeepEqual(fetched, []string{"https://example.com/api/paths.yaml"}) {
+		t.Errorf("unexpected fetched files %v", fetched)
+	}
+	if get := d.Paths.Path[0].Value.Get; get == nil || get.OperationId != "listPets" {
+		t.Errorf("unexpected path item %+v", d.Paths.Path[0].Value)
+	}
+}
+
+func TestSchemaKeywords(t *testing.T) {
+	d, err := ParseDocument([]byte(`openapi: 3.1.0
+info:
+  title: Keywords
+  version: 1.0.0
+components:
+  schemas:
+    Point:
+      $id: https://example.com/point
+      $defs:
+        coordinate: {type: number}
+      type: array
+      prefixItems:
+      - $ref: "#/components/schemas/Point/$defs/coordinate"
+      exclusiveMinimum: 1
+      minItems: 0
+      unevaluatedItems: false
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items: {$dynamicRef: "#node"}
+      dependentRequired:
+        left: [right]
+      unevaluatedProperties: {type: string}
+      minimum: 0
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	point := d.Components.Schemas.AdditionalProperties[0].Value.GetSchema()
+	if point.XId != "https://example.com/point" || point.XDefs.AdditionalProperties[0].Name != "coordinate" {
+		t.Errorf("unexpected keywords %+v", point)
+	}
+	if len(point.PrefixItems) != 1 || point.PrefixItems[0].GetReference() == nil || point.GetExclusiveMinimum() != 1 {
+		t.Errorf("unexpected keywords %+v", point)
+	}
+	if point.MinItems == nil || *point.MinItems != 0 || point.MaxItems != nil {
+		t.Errorf("expected only minItems to be set, got %v and %v", point.MinItems, point.MaxItems)
+	}
+	if unevaluated, ok := point.UnevaluatedItems.GetOneof().(*UnevaluatedItemsItem_Boolean); !ok || unevaluated.Boolean {
+		t.Errorf("unexpected unevaluatedItems %+v", point.UnevaluatedItems)
+	}
+	tree := d.Components.Schemas.AdditionalProperties[1].Value.GetSchema()
+	children := tree.Properties.AdditionalProperties[0].Value.GetSchema()
+	if tree.XDynamicAnchor != "node" || children.Items.GetSchema().GetXDynamicRef() != "#node" {
+		t.Errorf("unexpected dynamic keywords %+v", tree)
+	}
+	dependent := tree.DependentRequired.GetAdditionalProperties()
+	if len(dependent) != 1 || dependent[0].Name != "left" || !reflect.DeepEqual(dependent[0].Value.Value, []string{"right"}) {
+		t.Errorf("unexpected dependentRequired %+v", tree.DependentRequired)
+	}
+	if tree.UnevaluatedProperties.GetSchemaOrReference().GetSchema() == nil {
+		t.Errorf("unexpected unevaluatedProperties %+v", tree.UnevaluatedProperties)
+	}
+	if tree.Minimum == nil || *tree.Minimum != 0 {
+		t.Errorf("expected minimum to be set, got %v", tree.Minimum)
+	}
+	// Zero values are written.
+	b, err := yaml.Marshal(RawInfo(d))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, keyword := range []string{"minItems: 0", "minimum: !!float 0", "unevaluatedItems: false", "$dynamicRef: '#node'"} {
+		if !strings.Contains(string(b), keyword) {
+			t.Errorf("expected %q in:\n%s", keyword, b)
+		}
+	}
+}
+
+func TestRawInfo(t *testing.T) {
+	filenames, err := filepath.Glob("../examples/v3.1/yaml/*.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, filename := range filenames {
+		b, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		d, err := ParseDocument(b)
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		expected, err := yaml.Marshal(d.ToRawInfo())
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		actual, err := yaml.Marshal(RawInfo(d))
+		if err != nil {
+			t.Fatalf("%s: %+v", filename, err)
+		}
+		if string(actual) != string(expected) {
+			t.Errorf("%s: description differs from ToRawInfo:\n%s", filename, actual)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v31
+
+// Types returns the names of the types that a schema allows. In OpenAPI
+// 3.1, schemas use JSON Schema 2020-12, where a type can be a list of
+// names like ["string", "null"].
+func (m *Schema) Types() []string {
+	return m.GetType().GetValue()
+}
+
+// IsNullable returns true if a schema allows null values. OpenAPI 3.1
+// describes these with "null" in the list of types instead of with the
+// nullable property of OpenAPI 3.0.
+func (m *Schema) IsNullable() bool {
+	for _, t := range m.Types() {
+		if t == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+// NonNullTypes returns the names of the types that a schema allows other
+// than null, so a schema with the types ["string", "null"] is a nullable
+// string.
+func (m *Schema) NonNullTypes() []string {
+	types := make([]string, 0)
+	for _, t := range m.Types() {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
@@ -0,0 +1,218 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic_plugin_v1
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/cmd/protoc-gen-openapi/generator"
+	"github.com/okkoye/gnostic/compiler"
+	discovery "github.com/okkoye/gnostic/discovery"
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+	surface "github.com/okkoye/gnostic/surface"
+)
+
+// Plugins can also be run by protoc and Buf as code generators. These
+// write a CodeGeneratorRequest to the plugin's stdin and read a
+// CodeGeneratorResponse from its stdout. The API description that the
+// plugin processes is named with the openapi parameter, as in this
+// buf.gen.yaml:
+//
+//	plugins:
+//	  - local: gnostic-summary
+//	    out: gen
+//	    opt: openapi=petstore.yaml
+//
+// Other parameters are passed to the plugin as request parameters.
+//
+// When there is no openapi parameter, the API description is built from
+// the OpenAPI annotations of the proto files that protoc was asked to
+// generate, in the same way that protoc-gen-openapi builds it.
+
+// Returns true if stdin is a pipe or a file rather than a terminal.
+func stdinIsRedirected() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+// Returns a plugin request for a CodeGeneratorRequest.
+func newRequestForCodeGeneratorRequest(codeGeneratorRequest *pluginpb.CodeGeneratorRequest) (*Request, error) {
+	request := &Request{}
+	for _, parameter := range strings.Split(codeGeneratorRequest.GetParameter(), ",") {
+		if parameter == "" {
+			continue
+		}
+		pair := strings.SplitN(parameter, "=", 2)
+		if pair[0] == "openapi" && len(pair) == 2 {
+			request.SourceName = pair[1]
+			continue
+		}
+		value := ""
+		if len(pair) == 2 {
+			value = pair[1]
+		}
+		request.Parameters = append(request.Parameters, &Parameter{Name: pair[0], Value: value})
+	}
+	if request.SourceName == "" {
+		if len(codeGeneratorRequest.FileToGenerate) == 0 {
+			return nil, errors.New("the openapi parameter must name an API description")
+		}
+		document, err := documentForAnnotations(codeGeneratorRequest)
+		if err != nil {
+			return nil, err
+		}
+		request.SourceName = "openapi.yaml"
+		return request, request.addDocument(document, request.SourceName)
+	}
+	document, err := readDocument(request.SourceName)
+	if err != nil {
+		return nil, err
+	}
+	return request, request.addDocument(document, request.SourceName)
+}
+
+// Builds an OpenAPI v3 document from the annotations of the files in a CodeGeneratorRequest.
+func documentForAnnotations(codeGeneratorRequest *pluginpb.CodeGeneratorRequest) (*openapiv3.Document, error) {
+	// The parameters are for the plugin, not the generator.
+	codeGeneratorRequest = proto.Clone(codeGeneratorRequest).(*pluginpb.CodeGeneratorRequest)
+	codeGeneratorRequest.Parameter = nil
+	// protogen requires Go import paths, but they aren't used to build documents.
+	for _, file := range codeGeneratorRequest.ProtoFile {
+		if file.GetOptions().GetGoPackage() == "" {
+			if file.Options == nil {
+				file.Options = &descriptorpb.FileOptions{}
+			}
+			file.Options.GoPackage = proto.String(path.Dir(file.GetName()))
+		}
+	}
+	plugin, err := protogen.Options{}.New(codeGeneratorRequest)
+	if err != nil {
+		return nil, err
+	}
+	circularDepth := 2
+	conf := generator.Configuration{
+		Version:         proto.String("0.0.1"),
+		Title:           proto.String(""),
+		Description:     proto.String(""),
+		Naming:          proto.String("json"),
+		FQSchemaNaming:  proto.Bool(false),
+		EnumType:        proto.String("integer"),
+		CircularDepth:   &circularDepth,
+		DefaultResponse: proto.Bool(true),
+		OutputMode:      proto.String("merged"),
+	}
+	return generator.NewOpenAPIv3Generator(plugin, conf, plugin.Files).Document(), nil
+}
+
+// Returns a CodeGeneratorResponse for a plugin response.
+func newCodeGeneratorResponse(response *Response) *pluginpb.CodeGeneratorResponse {
+	codeGeneratorResponse := &pluginpb.CodeGeneratorResponse{}
+	if len(response.Errors) > 0 {
+		codeGeneratorResponse.Error = proto.String(strings.Join(response.Errors, "\n"))
+	}
+	for _, file := range response.Files {
+		codeGeneratorResponse.File = append(codeGeneratorResponse.File, &pluginpb.CodeGeneratorResponse_File{
+			Name:    proto.String(file.Name),
+			Content: proto.String(string(file.Data)),
+		})
+	}
+	return codeGeneratorResponse
+}
+
+// Reads an API description from a JSON, YAML, or binary protocol buffer file.
+func readDocument(filename string) (proto.Message, error) {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(filepath.Ext(filename)) == ".pb" {
+		return documentForBinary(bytes)
+	}
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		return nil, err
+	}
+	root := info
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	context := compiler.NewContext("$root", root, nil)
+	if swagger, ok := compiler.StringForScalarNode(compiler.MapValueForKey(root, "swagger")); ok && strings.HasPrefix(swagger, "2.0") {
+		return openapiv2.NewDocument(root, context)
+	}
+	if openapi, ok := compiler.StringForScalarNode(compiler.MapValueForKey(root, "openapi")); ok && strings.HasPrefix(openapi, "3.0") {
+		return openapiv3.NewDocument(root, context)
+	}
+	if kind, ok := compiler.StringForScalarNode(compiler.MapValueForKey(root, "kind")); ok && kind == "discovery#restDescription" {
+		return discovery.NewDocument(root, context)
+	}
+	return nil, fmt.Errorf("unable to identify the format of %s", filename)
+}
+
+// Reads an API description from a binary protocol buffer.
+func documentForBinary(apiData []byte) (proto.Message, error) {
+	// First try to unmarshal OpenAPI v2.
+	documentv2 := &openapiv2.Document{}
+	if err := proto.Unmarshal(apiData, documentv2); err == nil {
+		return documentv2, nil
+	}
+	// If that failed, ignore deserialization errors and try to unmarshal OpenAPI v3.
+	documentv3 := &openapiv3.Document{}
+	if err := proto.Unmarshal(apiData, documentv3); err == nil {
+		return documentv3, nil
+	}
+	// If that failed, ignore deserialization errors and try to unmarshal a Discovery document.
+	discoveryDocument := &discovery.Document{}
+	if err := proto.Unmarshal(apiData, discoveryDocument); err == nil {
+		return discoveryDocument, nil
+	}
+	// If we get here, we don't know what we got
+	return nil, errors.New("Unrecognized format for input")
+}
+
+// Adds an API description and its surface model to a request.
+func (request *Request) addDocument(document proto.Message, sourceName string) error {
+	switch document := document.(type) {
+	case *openapiv2.Document:
+		request.AddModel(ModelOpenAPIv2, document)
+		// include experimental API surface model
+		surfaceModel, err := surface.NewModelFromOpenAPI2(document, sourceName)
+		if err != nil {
+			return err
+		}
+		return request.AddModel(ModelSurface, surfaceModel)
+	case *openapiv3.Document:
+		request.AddModel(ModelOpenAPIv3, document)
+		// include experimental API surface model
+		surfaceModel, err := surface.NewModelFromOpenAPI3(document, sourceName)
+		if err != nil {
+			return err
+		}
+		return request.AddModel(ModelSurface, surfaceModel)
+	}
+	return request.AddModel("discovery.v1.Document", document)
+}
@@ -1,7 +1,6 @@
 package gnostic_plugin_v1
 
 import (
-	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -13,24 +12,38 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
-
-	discovery "github.com/okkoye/gnostic/discovery"
-	openapiv2 "github.com/okkoye/gnostic/openapiv2"
-	openapiv3 "github.com/okkoye/gnostic/openapiv3"
-	surface "github.com/okkoye/gnostic/surface"
+	"google.golang.org/protobuf/types/pluginpb"
 )
 
 // Environment contains the environment of a plugin call.
 type Environment struct {
-	Request         *Request  // plugin request object
-	Response        *Response // response message
-	Invocation      string    // string representation of call
-	RunningAsPlugin bool      // true if app is being run as a plugin
-	Verbose         bool      // if true, plugin should log details to stderr
+	Request                *Request  // plugin request object
+	Response               *Response // response message
+	Invocation             string    // string representation of call
+	RunningAsPlugin        bool      // true if app is being run as a plugin
+	RunningAsCodeGenerator bool      // true if app is being run by protoc or Buf
+	Verbose                bool      // if true, plugin should log details to stderr
 }
 
 // NewEnvironment creates a plugin context from arguments and standard input.
+// The plugin is sent every model that gnostic can build.
 func NewEnvironment() (env *Environment, err error) {
+	return NewEnvironmentWithRequirements(nil)
+}
+
+// NewEnvironmentWithRequirements creates a plugin context for a plugin that
+// declares the models and features that it uses. When gnostic asks for them,
+// they are written to stdout and the program exits. Otherwise the request
+// contains only the models that the plugin uses.
+func NewEnvironmentWithRequirements(requirements *Requirements) (env *Environment, err error) {
+	env, err = newEnvironment(requirements)
+	if env.Request != nil {
+		env.Request.filterModels(requirements)
+	}
+	return env, err
+}
+
+func newEnvironment(requirements *Requirements) (env *Environment, err error) {
 	env = &Environment{
 		Invocation: os.Args[0],
 		Response:   &Response{},
@@ -39,13 +52,38 @@ func NewEnvironment() (env *Environment, err error) {
 	input := flag.String("input", "", "API description (in binary protocol buffer form)")
 	output := flag.String("output", "-", "Output file or directory")
 	plugin := flag.Bool("plugin", false, "Run as a gnostic plugin (other flags are ignored).")
+	capabilities := flag.Bool("capabilities", false, "Describe the requirements of the plugin to gnostic.")
 	verbose := flag.Bool("verbose", false, "Write details to stderr.")
 	flag.Parse()
 
+	if *capabilities {
+		respondWithRequirements(requirements)
+	}
+
 	env.RunningAsPlugin = *plugin
 	env.Verbose = *verbose
 	programName := path.Base(os.Args[0])
 
+	var codeGeneratorData []byte
+	if (*input == "") && !*plugin && stdinIsRedirected() {
+		codeGeneratorData, _ = ioutil.ReadAll(os.Stdin)
+	}
+
+	if len(codeGeneratorData) > 0 {
+		// Handle invocation as a protoc or Buf code generator.
+		env.RunningAsCodeGenerator = true
+
+		codeGeneratorRequest := &pluginpb.CodeGeneratorRequest{}
+		err := proto.Unmarshal(codeGeneratorData, codeGeneratorRequest)
+		env.RespondAndExitIfError(err)
+		env.Request, err = newRequestForCodeGeneratorRequest(codeGeneratorRequest)
+		env.RespondAndExitIfError(err)
+		for _, parameter := range env.Request.Parameters {
+			env.Invocation += " " + parameter.Name + "=" + parameter.Value
+		}
+		return env, nil
+	}
+
 	if (*input == "") && !*plugin {
 		flag.Usage = func() {
 			fmt.Fprintf(os.Stderr, "\n")
@@ -54,6 +92,11 @@ func NewEnvironment() (env *Environment, err error) {
 When it is run from gnostic, the -plugin option is specified and gnostic
 writes a binary request to stdin and waits for a binary response on stdout.
 
+When it is run from protoc or Buf with no flags, it reads a CodeGeneratorRequest
+from stdin and processes the API description named by its openapi parameter
+or, without one, the description built from the OpenAPI annotations of the
+proto files that it was asked to generate.
+
 This program can also be run standalone using the other flags listed below.
 When the -plugin option is specified, these flags are ignored.`)
 			fmt.Fprintf(os.Stderr, "\n\nUsage:\n")
@@ -102,46 +145,34 @@ When the -plugin option is specified, these flags are ignored.`)
 		env.Request.OutputPath = *output
 		env.Request.SourceName = path.Base(*input)
 
-		// First try to unmarshal OpenAPI v2.
-		documentv2 := &openapiv2.Document{}
-		err = proto.Unmarshal(apiData, documentv2)
-		if err == nil {
-			env.Request.AddModel("openapi.v2.Document", documentv2)
-			sourceName := guessSourceName(*input)
-			// include experimental API surface model
-			surfaceModel, err := surface.NewModelFromOpenAPI2(documentv2, sourceName)
-			if err == nil {
-				env.Request.AddModel("surface.v1.Model", surfaceModel)
-			}
-			return env, err
-		}
-		// If that failed, ignore deserialization errors and try to unmarshal OpenAPI v3.
-		documentv3 := &openapiv3.Document{}
-		err = proto.Unmarshal(apiData, documentv3)
-		if err == nil {
-			env.Request.AddModel("openapi.v3.Document", documentv3)
-			sourceName := guessSourceName(*input)
-			// include experimental API surface model
-			surfaceModel, err := surface.NewModelFromOpenAPI3(documentv3, sourceName)
-			if err == nil {
-				env.Request.AddModel("surface.v1.Model", surfaceModel)
-			}
-			return env, err
-		}
-		// If that failed, ignore deserialization errors and try to unmarshal a Discovery document.
-		discoveryDocument := &discovery.Document{}
-		err = proto.Unmarshal(apiData, discoveryDocument)
-		if err == nil {
-			env.Request.AddModel("discovery.v1.Document", discoveryDocument)
+		document, err := documentForBinary(apiData)
+		if err != nil {
 			return env, err
 		}
-		// If we get here, we don't know what we got
-		err = errors.New("Unrecognized format for input")
+		err = env.Request.addDocument(document, guessSourceName(*input))
 		return env, err
 	}
 	return env, err
 }
 
+// respondWithRequirements reads gnostic's capabilities, writes the
+// requirements of the plugin to stdout, and exits. Plugins without
+// requirements use every model that gnostic can build.
+func respondWithRequirements(requirements *Requirements) {
+	capabilities, err := readCapabilities()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if requirements == nil {
+		requirements = &Requirements{Models: capabilities.Models}
+	}
+	requirements = proto.Clone(requirements).(*Requirements)
+	requirements.ProtocolVersion = ProtocolVersion
+	requirementsBytes, _ := proto.Marshal(requirements)
+	os.Stdout.Write(requirementsBytes)
+	os.Exit(0)
+}
+
 // RespondAndExitIfError checks an error and if it is non-nil, records it and serializes and returns the response and then exits.
 func (env *Environment) RespondAndExitIfError(err error) {
 	if err != nil {
@@ -152,7 +183,13 @@ func (env *Environment) RespondAndExitIfError(err error) {
 
 // RespondAndExit serializes and returns the plugin response and then exits.
 func (env *Environment) RespondAndExit() {
-	if env.RunningAsPlugin {
+	if env.RunningAsCodeGenerator {
+		for _, message := range env.Response.Messages {
+			log.Printf("%s: %s", message.Level, message.Text)
+		}
+		responseBytes, _ := proto.Marshal(newCodeGeneratorResponse(env.Response))
+		os.Stdout.Write(responseBytes)
+	} else if env.RunningAsPlugin {
 		responseBytes, _ := proto.Marshal(env.Response)
 		os.Stdout.Write(responseBytes)
 	} else {
@@ -21,6 +21,8 @@
 //   - The parameter types used and their frequencies.
 //   - The response types used and their frequencies.
 //   - The types used in definition objects and arrays and their frequencies.
+//   - The specification extensions used, with their locations and the
+//     shapes of their values.
 //
 // Results are returned in a JSON structure.
 package main
@@ -57,7 +59,9 @@ func sendAndExit(response *plugins.Response) {
 
 // This is the main function for the plugin.
 func main() {
-	env, err := plugins.NewEnvironment()
+	env, err := plugins.NewEnvironmentWithRequirements(&plugins.Requirements{
+		Models: []string{plugins.ModelOpenAPIv2, plugins.ModelOpenAPIv3},
+	})
 	env.RespondAndExitIfError(err)
 
 	var stats *statistics.DocumentStatistics
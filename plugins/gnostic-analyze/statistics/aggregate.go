@@ -0,0 +1,228 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Names of the per-document metrics that are aggregated by Summarize.
+const (
+	MetricOperations          = "operations"
+	MetricAnonymousOperations = "anonymousOperations"
+	MetricDefinitions         = "definitions"
+	MetricParameters          = "parameters"
+	MetricResults             = "results"
+	MetricAnonymousObjects    = "anonymousObjects"
+)
+
+// Percentiles are the percentiles reported for each metric.
+var Percentiles = []int{50, 75, 90, 95, 99}
+
+// Metrics returns the numeric measurements of a document that are aggregated by Summarize.
+func (s *DocumentStatistics) Metrics() map[string]float64 {
+	return map[string]float64{
+		MetricOperations:          float64(s.Operations["total"]),
+		MetricAnonymousOperations: float64(s.Operations["anonymous"]),
+		MetricDefinitions:         float64(s.DefinitionCount),
+		MetricParameters:          float64(sum(s.ParameterTypes)),
+		MetricResults:             float64(sum(s.ResultTypes)),
+		MetricAnonymousObjects:    float64(len(s.AnonymousObjects)),
+	}
+}
+
+// frequencies returns the frequency tables of a document, keyed by their JSON names.
+func (s *DocumentStatistics) frequencies() map[string]map[string]int {
+	return map[string]map[string]int{
+		"operations":               s.Operations,
+		"parameterTypes":           s.ParameterTypes,
+		"resultTypes":              s.ResultTypes,
+		"definitionFieldTypes":     s.DefinitionFieldTypes,
+		"definitionArrayTypes":     s.DefinitionArrayTypes,
+		"definitionPrimitiveTypes": s.DefinitionPrimitiveTypes,
+	}
+}
+
+func sum(m map[string]int) int {
+	total := 0
+	for _, v := range m {
+		total += v
+	}
+	return total
+}
+
+// A Bucket counts the values in the range [Low, High) of a histogram.
+type Bucket struct {
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+	Count int     `json:"count"`
+}
+
+// A Distribution describes the values of a metric across many documents.
+type Distribution struct {
+	Count       int                `json:"count"`
+	Sum         float64            `json:"sum"`
+	Min         float64            `json:"min"`
+	Max         float64            `json:"max"`
+	Mean        float64            `json:"mean"`
+	Percentiles map[string]float64 `json:"percentiles"`
+	// Histogram buckets have power-of-two bounds ([0,1), [1,2), [2,4), ...)
+	// so that the long tails of API sizes can be compared between groups.
+	Histogram []*Bucket `json:"histogram"`
+}
+
+// NewDistribution computes a distribution from a list of values.
+func NewDistribution(values []float64) *Distribution {
+	d := &Distribution{
+		Count:       len(values),
+		Percentiles: make(map[string]float64),
+		Histogram:   make([]*Bucket, 0),
+	}
+	if len(values) == 0 {
+		return d
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	d.Min, d.Max = sorted[0], sorted[len(sorted)-1]
+	for _, v := range sorted {
+		d.Sum += v
+	}
+	d.Mean = d.Sum / float64(len(sorted))
+	for _, p := range Percentiles {
+		d.Percentiles[percentileName(p)] = Percentile(sorted, float64(p))
+	}
+	for _, v := range sorted {
+		low, high := bucketBounds(v)
+		if n := len(d.Histogram); n > 0 && d.Histogram[n-1].Low == low {
+			d.Histogram[n-1].Count++
+		} else {
+			d.Histogram = append(d.Histogram, &Bucket{Low: low, High: high, Count: 1})
+		}
+	}
+	return d
+}
+
+func percentileName(p int) string {
+	return "p" + strconv.Itoa(p)
+}
+
+// bucketBounds returns the bounds of the histogram bucket that contains a value.
+func bucketBounds(v float64) (float64, float64) {
+	if v < 1 {
+		return 0, 1
+	}
+	low := math.Pow(2, math.Floor(math.Log2(v)))
+	return low, 2 * low
+}
+
+// Percentile returns the pth percentile of a sorted list of values,
+// interpolating linearly between the closest ranks.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if upper >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// A Summary aggregates the statistics of a collection of documents.
+type Summary struct {
+	Documents                        int                        `json:"documents"`
+	DocumentsWithAnonymousOperations int                        `json:"documentsWithAnonymousOperations"`
+	DocumentsWithAnonymousObjects    int                        `json:"documentsWithAnonymousObjects"`
+	Metrics                          map[string]*Distribution   `json:"metrics"`
+	Frequencies                      map[string]map[string]int  `json:"frequencies"`
+	Extensions                       map[string]*ExtensionUsage `json:"extensions"`
+	Groups                           map[string]*Summary        `json:"groups,omitempty"`
+}
+
+// Summarize aggregates the statistics of a collection of documents. If group
+// is not nil, documents are also summarized in groups named by its results.
+func Summarize(stats []*DocumentStatistics, group func(*DocumentStatistics) string) *Summary {
+	summary := summarize(stats)
+	if group == nil {
+		return summary
+	}
+	members := make(map[string][]*DocumentStatistics)
+	for _, s := range stats {
+		name := group(s)
+		members[name] = append(members[name], s)
+	}
+	summary.Groups = make(map[string]*Summary)
+	for name, m := range members {
+		summary.Groups[name] = summarize(m)
+	}
+	return summary
+}
+
+func summarize(stats []*DocumentStatistics) *Summary {
+	summary := &Summary{
+		Documents:   len(stats),
+		Metrics:     make(map[string]*Distribution),
+		Frequencies: make(map[string]map[string]int),
+		Extensions:  make(map[string]*ExtensionUsage),
+	}
+	values := make(map[string][]float64)
+	for _, s := range stats {
+		if s.Operations["anonymous"] != 0 {
+			summary.DocumentsWithAnonymousOperations++
+		}
+		if len(s.AnonymousObjects) > 0 {
+			summary.DocumentsWithAnonymousObjects++
+		}
+		for name, value := range s.Metrics() {
+			values[name] = append(values[name], value)
+		}
+		for name, m := range s.frequencies() {
+			if summary.Frequencies[name] == nil {
+				summary.Frequencies[name] = make(map[string]int)
+			}
+			for k, v := range m {
+				summary.Frequencies[name][k] += v
+			}
+		}
+		for name, usage := range s.Extensions {
+			summary.addExtensionUsage(name, s.Name, usage)
+		}
+	}
+	for name, v := range values {
+		summary.Metrics[name] = NewDistribution(v)
+	}
+	return summary
+}
+
+// addExtensionUsage adds the uses of an extension in a document to a summary.
+func (summary *Summary) addExtensionUsage(name, document string, usage *ExtensionUsage) {
+	total := summary.Extensions[name]
+	if total == nil {
+		total = &ExtensionUsage{Shapes: make(map[string]int), Locations: make([]string, 0)}
+		summary.Extensions[name] = total
+	}
+	total.Count += usage.Count
+	total.Documents++
+	for shape, count := range usage.Shapes {
+		total.Shapes[shape] += count
+	}
+	for _, location := range usage.Locations {
+		total.Locations = append(total.Locations, document+"#"+location)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"reflect"
+	"testing"
+
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+func TestDistribution(t *testing.T) {
+	values := []float64{10, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0}
+	d := NewDistribution(values)
+	if d.Count != 11 || d.Min != 0 || d.Max != 10 || d.Mean != 5 {
+		t.Errorf("unexpected distribution: %+v", d)
+	}
+	for name, expected := range map[string]float64{"p50": 5, "p90": 9, "p99": 9.9} {
+		if d.Percentiles[name] != expected {
+			t.Errorf("unexpected %s: %f (expected %f)", name, d.Percentiles[name], expected)
+		}
+	}
+	// Buckets are [0,1), [1,2), [2,4), [4,8), and [8,16).
+	counts := []int{1, 1, 2, 4, 3}
+	if len(d.Histogram) != len(counts) {
+		t.Fatalf("unexpected histogram: %+v", d.Histogram)
+	}
+	for i, bucket := range d.Histogram {
+		if bucket.Count != counts[i] {
+			t.Errorf("unexpected count for bucket [%f,%f): %d", bucket.Low, bucket.High, bucket.Count)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	stats := []*DocumentStatistics{
+		{Name: "a", Operations: map[string]int{"total": 4, "get": 4}, DefinitionCount: 2},
+		{Name: "b", Operations: map[string]int{"total": 2, "get": 1, "post": 1, "anonymous": 1}, AnonymousObjects: []string{"x"}},
+		{Name: "c", Operations: map[string]int{"total": 6, "get": 6}},
+	}
+	summary := Summarize(stats, func(s *DocumentStatistics) string {
+		if s.Name == "a" {
+			return "first"
+		}
+		return "rest"
+	})
+	if summary.Documents != 3 || summary.DocumentsWithAnonymousOperations != 1 || summary.DocumentsWithAnonymousObjects != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.Frequencies["operations"]["get"] != 11 {
+		t.Errorf("unexpected operation frequencies: %+v", summary.Frequencies["operations"])
+	}
+	if p50 := summary.Metrics[MetricOperations].Percentiles["p50"]; p50 != 4 {
+		t.Errorf("unexpected median operations: %f", p50)
+	}
+	if len(summary.Groups) != 2 || summary.Groups["rest"].Documents != 2 || summary.Groups["rest"].Metrics[MetricOperations].Max != 6 {
+		t.Errorf("unexpected groups: %+v", summary.Groups)
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	document, err := openapiv3.ParseDocument([]byte(`openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+  x-logo:
+    url: pets.png
+paths:
+  /pets/{id}:
+    get:
+      x-go-name: FetchPet
+      parameters:
+      - name: id
+        in: path
+        x-go-name: ID
+        schema:
+          type: string
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      x-nullable: true
+x-tags: [a, b]
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	s := NewDocumentStatisticsV3("pets.yaml", document)
+	expected := map[string]*ExtensionUsage{
+		"x-logo":     {Count: 1, Shapes: map[string]int{"object": 1}, Locations: []string{"/info/x-logo"}},
+		"x-go-name":  {Count: 2, Shapes: map[string]int{"string": 2}, Locations: []string{"/paths/~1pets~1{id}/get/parameters/0/x-go-name", "/paths/~1pets~1{id}/get/x-go-name"}},
+		"x-nullable": {Count: 1, Shapes: map[string]int{"boolean": 1}, Locations: []string{"/components/schemas/Pet/x-nullable"}},
+		"x-tags":     {Count: 1, Shapes: map[string]int{"array": 1}, Locations: []string{"/x-tags"}},
+	}
+	if !reflect.DeepEqual(s.Extensions, expected) {
+		for name, usage := range s.Extensions {
+			t.Logf("%s: %+v", name, usage)
+		}
+		t.Errorf("unexpected extensions")
+	}
+
+	other := &DocumentStatistics{Name: "other.yaml", Extensions: map[string]*ExtensionUsage{
+		"x-go-name": {Count: 1, Shapes: map[string]int{"object": 1}, Locations: []string{"/x-go-name"}},
+	}}
+	summary := Summarize([]*DocumentStatistics{s, other}, nil)
+	usage := summary.Extensions["x-go-name"]
+	if usage.Count != 3 || usage.Documents != 2 || usage.Shapes["string"] != 2 || usage.Shapes["object"] != 1 ||
+		usage.Locations[2] != "other.yaml#/x-go-name" {
+		t.Errorf("unexpected summary of x-go-name: %+v", usage)
+	}
+}
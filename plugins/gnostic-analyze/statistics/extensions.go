@@ -0,0 +1,141 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtensionUsage describes the uses of a specification extension.
+type ExtensionUsage struct {
+	// Count is the number of times that the extension is used.
+	Count int `json:"count"`
+	// Documents is the number of documents that use the extension. It is
+	// only set in summaries.
+	Documents int `json:"documents,omitempty"`
+	// Shapes counts the kinds of the extension's values: object, array,
+	// string, number, boolean, or null.
+	Shapes map[string]int `json:"shapes"`
+	// Locations are JSON Pointers to the uses of the extension. In
+	// summaries they follow the names of the documents and a "#".
+	Locations []string `json:"locations"`
+}
+
+func (s *DocumentStatistics) addExtension(name, location, shape string) {
+	if s.Extensions == nil {
+		s.Extensions = make(map[string]*ExtensionUsage)
+	}
+	usage := s.Extensions[name]
+	if usage == nil {
+		usage = &ExtensionUsage{Shapes: make(map[string]int), Locations: make([]string, 0)}
+		s.Extensions[name] = usage
+	}
+	usage.Count++
+	usage.Shapes[shape]++
+	usage.Locations = append(usage.Locations, location)
+}
+
+// Analyze the specification extensions in a document. Extensions are found
+// in the vendor_extension and specification_extension fields of the
+// document's messages, and their locations are built from the names of
+// the fields and of the map entries that contain them.
+func (s *DocumentStatistics) analyzeExtensions(message protoreflect.Message, pointer string) {
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Message() == nil || field.IsMap() || !message.Has(field) {
+			continue
+		}
+		value := message.Get(field)
+		location := pointer
+		if field.ContainingOneof() == nil {
+			// Fields of oneofs, like SchemaOrReference, hold the values of their containers.
+			location += "/" + escapePointerToken(field.JSONName())
+		}
+		switch {
+		case !field.IsList():
+			s.analyzeExtensions(value.Message(), location)
+		case field.Name() == "vendor_extension" || field.Name() == "specification_extension":
+			list := value.List()
+			for j := 0; j < list.Len(); j++ {
+				name, value := namedValue(list.Get(j).Message())
+				if value != nil {
+					s.addExtension(name, pointer+"/"+escapePointerToken(name), shapeOfExtension(value))
+				}
+			}
+		default:
+			list := value.List()
+			for j := 0; j < list.Len(); j++ {
+				item := list.Get(j).Message()
+				if name, value := namedValue(item); value != nil {
+					// Entries of maps, like NamedSchema, are named by their keys.
+					s.analyzeExtensions(value, pointer+"/"+escapePointerToken(name))
+				} else {
+					s.analyzeExtensions(item, location+"/"+strconv.Itoa(j))
+				}
+			}
+		}
+	}
+}
+
+// Returns the name and value of a message that represents an entry of a
+// map, like NamedAny, or nil if the message isn't a map entry.
+func namedValue(message protoreflect.Message) (string, protoreflect.Message) {
+	fields := message.Descriptor().Fields()
+	name, value := fields.ByName("name"), fields.ByName("value")
+	if fields.Len() != 2 || name == nil || value == nil || name.Kind() != protoreflect.StringKind ||
+		value.Message() == nil || value.IsList() || !message.Has(value) {
+		return "", nil
+	}
+	return message.Get(name).String(), message.Get(value).Message()
+}
+
+// Returns the shape of the value of an extension, which is an Any message
+// that contains the value's YAML.
+func shapeOfExtension(value protoreflect.Message) string {
+	field := value.Descriptor().Fields().ByName("yaml")
+	if field == nil {
+		return "unknown"
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(value.Get(field).String()), &node); err != nil || len(node.Content) == 0 {
+		return "unknown"
+	}
+	switch node := node.Content[0]; node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!int", "!!float":
+			return "number"
+		case "!!bool":
+			return "boolean"
+		case "!!null":
+			return "null"
+		}
+	}
+	return "string"
+}
+
+// Escapes a reference token of a JSON Pointer.
+func escapePointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
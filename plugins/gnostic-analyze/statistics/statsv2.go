@@ -34,6 +34,8 @@ type DocumentStatistics struct {
 	DefinitionPrimitiveTypes map[string]int `json:"definitionPrimitiveTypes"`
 	AnonymousOperations      []string       `json:"anonymousOperations"`
 	AnonymousObjects         []string       `json:"anonymousObjects"`
+	// Extensions describe the uses of specification extensions by name.
+	Extensions map[string]*ExtensionUsage `json:"extensions,omitempty"`
 }
 
 // NewDocumentStatistics builds a new DocumentStatistics object.
@@ -230,6 +232,7 @@ func (s *DocumentStatistics) analyzeDocument(source string, document *openapi.Do
 			s.analyzeDefinition("definitions/"+pair.Name, definition)
 		}
 	}
+	s.analyzeExtensions(document.ProtoReflect(), "")
 }
 
 // helpers
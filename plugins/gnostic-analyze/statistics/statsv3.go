@@ -29,8 +29,11 @@ func NewDocumentStatisticsV3(source string, document *openapi.Document) *Documen
 	s.DefinitionPrimitiveTypes = make(map[string]int, 0)
 	s.AnonymousOperations = make([]string, 0)
 	s.AnonymousObjects = make([]string, 0)
+	s.Name = source
+	s.Title = document.GetInfo().GetTitle()
 	// TODO
 	//s.analyzeDocumentV3(source, document)
+	s.analyzeExtensions(document.ProtoReflect(), "")
 	return s
 }
 
@@ -17,18 +17,26 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/okkoye/gnostic/plugins/gnostic-analyze/statistics"
 )
 
 // Results are collected in this global slice.
-var stats []statistics.DocumentStatistics
+var stats []*statistics.DocumentStatistics
+
+// The directories containing each summary file, relative to the root of the search.
+var directories = make(map[*statistics.DocumentStatistics]string)
+
+// root is the directory that is searched for summary files.
+var root string
 
 // walker is called for each summary file found.
 func walker(p string, info os.FileInfo, err error) error {
@@ -48,10 +56,37 @@ func walker(p string, info os.FileInfo, err error) error {
 	if err != nil {
 		return err
 	}
-	stats = append(stats, s)
+	stats = append(stats, &s)
+	if dir, err := filepath.Rel(root, filepath.Dir(p)); err == nil {
+		directories[&s] = filepath.ToSlash(dir)
+	}
 	return nil
 }
 
+// groupFunction returns a function that names the group of a document.
+// Providers are the first directory below the root and versions are the
+// directories that contain the summary files.
+func groupFunction(groupBy string) func(*statistics.DocumentStatistics) string {
+	if groupBy == "" {
+		return nil
+	}
+	return func(s *statistics.DocumentStatistics) string {
+		parts := strings.Split(directories[s], "/")
+		provider, version := parts[0], parts[len(parts)-1]
+		if provider == "." || provider == "" {
+			return "(none)"
+		}
+		switch groupBy {
+		case "provider":
+			return provider
+		case "version":
+			return version
+		default:
+			return provider + "/" + version
+		}
+	}
+}
+
 func printFrequencies(m map[string]int) {
 	for _, pair := range rankByCount(m) {
 		fmt.Printf("%6d %s\n", pair.Value, pair.Key)
@@ -80,31 +115,107 @@ func (p pairList) Len() int           { return len(p) }
 func (p pairList) Less(i, j int) bool { return p[i].Value < p[j].Value }
 func (p pairList) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 
+// printExtensions prints the uses of extensions in a summary, ranked by
+// their counts, with the number of documents that use each one and the
+// shapes of their values. With locations, the uses are listed too.
+func printExtensions(summary *statistics.Summary, locations bool) {
+	counts := make(map[string]int, len(summary.Extensions))
+	for name, usage := range summary.Extensions {
+		counts[name] = usage.Count
+	}
+	for _, pair := range rankByCount(counts) {
+		usage := summary.Extensions[pair.Key]
+		shapes := make([]string, 0, len(usage.Shapes))
+		for _, shape := range rankByCount(usage.Shapes) {
+			shapes = append(shapes, fmt.Sprintf("%s: %d", shape.Key, shape.Value))
+		}
+		fmt.Printf("%6d %6d %s (%s)\n", usage.Count, usage.Documents, pair.Key, strings.Join(shapes, ", "))
+		if locations {
+			for _, location := range usage.Locations {
+				fmt.Printf("%14s%s\n", "", location)
+			}
+		}
+	}
+}
+
+// printMetrics prints a table of the distributions of the metrics in a summary.
+func printMetrics(summary *statistics.Summary) {
+	names := make([]string, 0, len(summary.Metrics))
+	for name := range summary.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("%-20s %8s %8s", "metric", "min", "mean")
+	for _, p := range statistics.Percentiles {
+		fmt.Printf(" %8s", fmt.Sprintf("p%d", p))
+	}
+	fmt.Printf(" %8s\n", "max")
+	for _, name := range names {
+		d := summary.Metrics[name]
+		fmt.Printf("%-20s %8.0f %8.1f", name, d.Min, d.Mean)
+		for _, p := range statistics.Percentiles {
+			fmt.Printf(" %8.1f", d.Percentiles[fmt.Sprintf("p%d", p)])
+		}
+		fmt.Printf(" %8.0f\n", d.Max)
+	}
+}
+
+// printHistogram prints the histogram of a metric in a summary.
+func printHistogram(summary *statistics.Summary, name string) {
+	d := summary.Metrics[name]
+	if d == nil {
+		return
+	}
+	for _, bucket := range d.Histogram {
+		fmt.Printf("%6.0f - %-6.0f %6d %s\n", bucket.Low, bucket.High-1, bucket.Count,
+			strings.Repeat("*", (bucket.Count*50+d.Count-1)/d.Count))
+	}
+}
+
 func main() {
-	// Collect all statistics in the current directory and its subdirectories.
-	stats = make([]statistics.DocumentStatistics, 0)
-	filepath.Walk(".", walker)
+	jsonOutput := flag.Bool("json", false, "write the aggregate statistics as JSON")
+	extensionsOnly := flag.Bool("extensions", false, "only report the uses of specification extensions")
+	groupBy := flag.String("group-by", "", "group results by \"provider\", \"version\", or \"provider-version\"")
+	flag.StringVar(&root, "root", ".", "directory to search for summary files")
+	flag.Parse()
+	if *groupBy != "" && *groupBy != "provider" && *groupBy != "version" && *groupBy != "provider-version" {
+		fmt.Fprintf(os.Stderr, "unknown grouping: %s\n", *groupBy)
+		os.Exit(1)
+	}
 
-	// Compute some interesting properties.
-	apisWithAnonymousOperations := 0
-	apisWithAnonymousObjects := 0
-	apisWithAnonymousAnything := 0
-	opFrequencies := make(map[string]int, 0)
-	parameterTypeFrequencies := make(map[string]int, 0)
-	resultTypeFrequencies := make(map[string]int, 0)
-	definitionFieldTypeFrequencies := make(map[string]int, 0)
-	definitionArrayTypeFrequencies := make(map[string]int, 0)
-	definitionPrimitiveTypeFrequencies := make(map[string]int, 0)
+	// Collect all statistics in the root directory and its subdirectories.
+	stats = make([]*statistics.DocumentStatistics, 0)
+	if err := filepath.Walk(root, walker); err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+	summary := statistics.Summarize(stats, groupFunction(*groupBy))
 
-	for _, api := range stats {
-		if api.Operations["anonymous"] != 0 {
-			apisWithAnonymousOperations++
+	if *jsonOutput {
+		var value interface{} = summary
+		if *extensionsOnly {
+			value = summary.Extensions
 		}
-		if len(api.AnonymousObjects) > 0 {
-			apisWithAnonymousObjects++
+		bytes, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%+v\n", err)
+			os.Exit(1)
 		}
-		if len(api.AnonymousOperations) > 0 {
+		os.Stdout.Write(append(bytes, '\n'))
+		return
+	}
+	if *extensionsOnly {
+		fmt.Printf("Extensions used in %d APIs (uses, APIs, name, and value shapes):\n", summary.Documents)
+		printExtensions(summary, true)
+		return
+	}
+
+	apisWithAnonymousAnything := 0
+	for _, api := range stats {
+		if len(api.AnonymousOperations) > 0 || len(api.AnonymousObjects) > 0 {
 			apisWithAnonymousAnything++
+		}
+		if len(api.AnonymousOperations) > 0 {
 			if len(api.AnonymousObjects) > 0 {
 				fmt.Printf("%s has anonymous operations and objects\n", api.Name)
 			} else {
@@ -112,48 +223,49 @@ func main() {
 			}
 		} else {
 			if len(api.AnonymousObjects) > 0 {
-				apisWithAnonymousAnything++
 				fmt.Printf("%s has anonymous objects\n", api.Name)
 			} else {
 				fmt.Printf("%s has no anonymous operations or objects\n", api.Name)
 			}
 		}
-		for k, v := range api.Operations {
-			opFrequencies[k] += v
-		}
-		for k, v := range api.ParameterTypes {
-			parameterTypeFrequencies[k] += v
-		}
-		for k, v := range api.ResultTypes {
-			resultTypeFrequencies[k] += v
-		}
-		for k, v := range api.DefinitionFieldTypes {
-			definitionFieldTypeFrequencies[k] += v
-		}
-		for k, v := range api.DefinitionArrayTypes {
-			definitionArrayTypeFrequencies[k] += v
-		}
-		for k, v := range api.DefinitionPrimitiveTypes {
-			definitionPrimitiveTypeFrequencies[k] += v
-		}
 	}
 
 	// Report the results.
 	fmt.Printf("\n")
-	fmt.Printf("Collected information on %d APIs.\n\n", len(stats))
-	fmt.Printf("APIs with anonymous operations: %d\n", apisWithAnonymousOperations)
-	fmt.Printf("APIs with anonymous objects: %d\n", apisWithAnonymousObjects)
+	fmt.Printf("Collected information on %d APIs.\n\n", summary.Documents)
+	fmt.Printf("APIs with anonymous operations: %d\n", summary.DocumentsWithAnonymousOperations)
+	fmt.Printf("APIs with anonymous objects: %d\n", summary.DocumentsWithAnonymousObjects)
 	fmt.Printf("APIs with anonymous anything: %d\n", apisWithAnonymousAnything)
 	fmt.Printf("\nOperation frequencies:\n")
-	printFrequencies(opFrequencies)
+	printFrequencies(summary.Frequencies["operations"])
 	fmt.Printf("\nParameter type frequencies:\n")
-	printFrequencies(parameterTypeFrequencies)
+	printFrequencies(summary.Frequencies["parameterTypes"])
 	fmt.Printf("\nResult type frequencies:\n")
-	printFrequencies(resultTypeFrequencies)
+	printFrequencies(summary.Frequencies["resultTypes"])
 	fmt.Printf("\nDefinition object field type frequencies:\n")
-	printFrequencies(definitionFieldTypeFrequencies)
+	printFrequencies(summary.Frequencies["definitionFieldTypes"])
 	fmt.Printf("\nDefinition array type frequencies:\n")
-	printFrequencies(definitionArrayTypeFrequencies)
+	printFrequencies(summary.Frequencies["definitionArrayTypes"])
 	fmt.Printf("\nDefinition primitive type frequencies:\n")
-	printFrequencies(definitionPrimitiveTypeFrequencies)
+	printFrequencies(summary.Frequencies["definitionPrimitiveTypes"])
+	fmt.Printf("\nExtension frequencies (uses, APIs, name, and value shapes):\n")
+	printExtensions(summary, false)
+	if summary.Documents == 0 {
+		return
+	}
+	fmt.Printf("\nDistributions:\n")
+	printMetrics(summary)
+	fmt.Printf("\nOperations per API:\n")
+	printHistogram(summary, statistics.MetricOperations)
+
+	groups := make([]string, 0, len(summary.Groups))
+	for name := range summary.Groups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	for _, name := range groups {
+		group := summary.Groups[name]
+		fmt.Printf("\n%s (%d APIs):\n", name, group.Documents)
+		printMetrics(group)
+	}
 }
@@ -0,0 +1,324 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stoewer/go-strcase"
+
+	"github.com/okkoye/gnostic/printer"
+	surface "github.com/okkoye/gnostic/surface"
+)
+
+// jsonScalar is the custom scalar used for values that GraphQL types can't describe.
+const jsonScalar = "JSON"
+
+var nameRegex = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+var invalidNameCharacterRegex = regexp.MustCompile(`[^_0-9A-Za-z]`)
+
+type generator struct {
+	model      *surface.Model
+	types      map[string]*surface.Type
+	objects    map[string]bool // types that are returned by operations
+	inputs     map[string]bool // types that are sent to operations
+	usesJSON   bool
+	fieldNames map[string]bool
+}
+
+// NewSchema returns a GraphQL schema (in SDL) for an API. GET operations
+// become queries and other operations become mutations. Their
+// parameters become arguments (required for path parameters) and request
+// bodies become input arguments. The types of responses become object
+// types and the types of request bodies become input types. Maps and
+// values of unknown types use the JSON scalar.
+func NewSchema(model *surface.Model) string {
+	g := &generator{
+		model:   model,
+		types:   make(map[string]*surface.Type),
+		objects: make(map[string]bool),
+		inputs:  make(map[string]bool),
+	}
+	for _, t := range model.Types {
+		g.types[t.Name] = t
+	}
+	for _, method := range model.Methods {
+		if field := g.responseField(method); field != nil {
+			g.mark(g.objects, field.Type)
+		}
+		for _, field := range g.bodyFields(method) {
+			g.mark(g.inputs, field.Type)
+		}
+	}
+
+	queries := &printer.Code{}
+	mutations := &printer.Code{}
+	queries.Indent()
+	mutations.Indent()
+	queryNames := make(map[string]bool)
+	mutationNames := make(map[string]bool)
+	for _, method := range model.Methods {
+		if method.Method == "GET" {
+			g.fieldNames = queryNames
+			g.printOperation(queries, method)
+		} else {
+			g.fieldNames = mutationNames
+			g.printOperation(mutations, method)
+		}
+	}
+
+	code := &printer.Code{}
+	if model.Name != "" {
+		code.Print("# GraphQL schema for %s", model.Name)
+		code.Print()
+	}
+	for _, t := range model.Types {
+		if g.objects[t.Name] {
+			g.printType(code, "type", t, false)
+		}
+		if g.inputs[t.Name] {
+			g.printType(code, "input", t, true)
+		}
+	}
+	if len(queryNames) > 0 {
+		code.Print("type Query {")
+		code.Print("%s", strings.TrimSuffix(queries.String(), "\n"))
+		code.Print("}")
+		code.Print()
+	}
+	if len(mutationNames) > 0 {
+		code.Print("type Mutation {")
+		code.Print("%s", strings.TrimSuffix(mutations.String(), "\n"))
+		code.Print("}")
+		code.Print()
+	}
+	if g.usesJSON {
+		code.Print("scalar %s", jsonScalar)
+	}
+	return strings.TrimRight(code.String(), "\n") + "\n"
+}
+
+// mark adds a type and the types that it refers to to a set.
+func (g *generator) mark(set map[string]bool, name string) {
+	t := g.types[name]
+	if t == nil || set[name] {
+		return
+	}
+	if element := g.elementType(t); element != nil {
+		g.mark(set, element.Type)
+		return
+	}
+	if !g.isObject(t) {
+		return
+	}
+	set[name] = true
+	for _, field := range g.fields(t) {
+		g.mark(set, field.Type)
+	}
+}
+
+// isObject returns true if a type can be represented with a GraphQL object or input type.
+func (g *generator) isObject(t *surface.Type) bool {
+	return t.Kind == surface.TypeKind_STRUCT && len(g.fields(t)) > 0 && g.elementType(t) == nil
+}
+
+// elementType returns the field of a type that wraps an array, as OpenAPI v3 array schemas are.
+func (g *generator) elementType(t *surface.Type) *surface.Field {
+	if len(t.Fields) == 1 && t.Fields[0].Name == "value" && t.Fields[0].Kind == surface.FieldKind_ARRAY {
+		return t.Fields[0]
+	}
+	return nil
+}
+
+// fields returns the fields of a type, including the fields of the types that it combines with allOf.
+func (g *generator) fields(t *surface.Type) []*surface.Field {
+	if t == nil {
+		return nil
+	}
+	fields := make([]*surface.Field, 0, len(t.Fields))
+	for _, field := range t.Fields {
+		if strings.HasPrefix(field.Name, "all_of_") && field.Kind == surface.FieldKind_REFERENCE {
+			fields = append(fields, g.fields(g.types[field.Type])...)
+		} else {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// responseField returns the field of the successful response of a method.
+func (g *generator) responseField(method *surface.Method) *surface.Field {
+	responses := g.types[method.ResponsesTypeName]
+	if responses == nil {
+		return nil
+	}
+	for _, field := range responses.Fields {
+		if strings.HasPrefix(field.Name, "2") {
+			return field
+		}
+	}
+	return nil
+}
+
+// bodyFields returns the fields of the request body of a method.
+func (g *generator) bodyFields(method *surface.Method) []*surface.Field {
+	fields := make([]*surface.Field, 0)
+	if parameters := g.types[method.ParametersTypeName]; parameters != nil {
+		for _, field := range parameters.Fields {
+			if field.Position == surface.Position_BODY {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+// typeName returns the GraphQL name of a type.
+func (g *generator) typeName(name string, input bool) string {
+	name = graphQLName(strcase.UpperCamelCase(name), name)
+	if input {
+		return name + "Input"
+	}
+	return name
+}
+
+// fieldName returns the GraphQL name of a field or argument.
+func fieldName(name string) string {
+	return graphQLName(name, strcase.LowerCamelCase(name))
+}
+
+// graphQLName returns a name if it is valid in GraphQL, or an alternative that is.
+func graphQLName(name string, alternative string) string {
+	for _, candidate := range []string{name, alternative} {
+		if nameRegex.MatchString(candidate) {
+			return candidate
+		}
+	}
+	return "_" + invalidNameCharacterRegex.ReplaceAllString(name, "_")
+}
+
+// fieldType returns the GraphQL type of a field.
+func (g *generator) fieldType(field *surface.Field, input bool) string {
+	switch field.Kind {
+	case surface.FieldKind_ARRAY:
+		return "[" + g.namedType(field.Type, input) + "]"
+	case surface.FieldKind_MAP, surface.FieldKind_ANY:
+		g.usesJSON = true
+		return jsonScalar
+	}
+	return g.namedType(field.Type, input)
+}
+
+// namedType returns the GraphQL type of a named type.
+func (g *generator) namedType(name string, input bool) string {
+	switch name {
+	case "integer":
+		return "Int"
+	case "number":
+		return "Float"
+	case "boolean":
+		return "Boolean"
+	case "string":
+		return "String"
+	}
+	if t := g.types[name]; t != nil {
+		if element := g.elementType(t); element != nil {
+			return "[" + g.namedType(element.Type, input) + "]"
+		}
+		if g.isObject(t) {
+			return g.typeName(name, input)
+		}
+	}
+	g.usesJSON = true
+	return jsonScalar
+}
+
+// printType prints an object or input type.
+func (g *generator) printType(code *printer.Code, keyword string, t *surface.Type, input bool) {
+	printDescription(code, t.Description)
+	code.Print("%s %s {", keyword, g.typeName(t.Name, input))
+	code.Indent()
+	names := make(map[string]bool)
+	for _, field := range g.fields(t) {
+		name := fieldName(field.Name)
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		code.Print("%s: %s", name, g.fieldType(field, input))
+	}
+	code.Outdent()
+	code.Print("}")
+	code.Print()
+}
+
+// printOperation prints the query or mutation field for a method.
+func (g *generator) printOperation(code *printer.Code, method *surface.Method) {
+	operation := method.Operation
+	if operation == "" {
+		operation = method.Name
+	}
+	name := graphQLName(strcase.LowerCamelCase(operation), operation)
+	base := name
+	for n := 2; g.fieldNames[name]; n++ {
+		name = base + strconv.Itoa(n)
+	}
+	g.fieldNames[name] = true
+
+	arguments := make([]string, 0)
+	argumentNames := make(map[string]bool)
+	if parameters := g.types[method.ParametersTypeName]; parameters != nil {
+		for _, field := range parameters.Fields {
+			argument := fieldName(field.Name)
+			if argumentNames[argument] {
+				continue
+			}
+			argumentNames[argument] = true
+			argumentType := g.fieldType(field, true)
+			if field.Position == surface.Position_PATH || field.Position == surface.Position_BODY {
+				argumentType += "!"
+			}
+			arguments = append(arguments, argument+": "+argumentType)
+		}
+	}
+	returnType := "Boolean"
+	if field := g.responseField(method); field != nil {
+		returnType = g.fieldType(field, false)
+	}
+
+	printDescription(code, firstLine(method.Description))
+	if len(arguments) == 0 {
+		code.Print("%s: %s", name, returnType)
+	} else {
+		code.Print("%s(%s): %s", name, strings.Join(arguments, ", "), returnType)
+	}
+}
+
+// printDescription prints a description as a GraphQL block string.
+func printDescription(code *printer.Code, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	code.Print(`"""%s"""`, strings.Replace(description, `"""`, `\"""`, -1))
+}
+
+// firstLine returns the first line of a description.
+func firstLine(description string) string {
+	return strings.TrimSpace(strings.SplitN(description, "\n", 2)[0])
+}
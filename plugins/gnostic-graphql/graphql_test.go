@@ -0,0 +1,105 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	surface "github.com/okkoye/gnostic/surface"
+)
+
+func TestSchema(t *testing.T) {
+	bytes, err := ioutil.ReadFile("../../examples/v2.0/yaml/petstore-expanded.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := openapiv2.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	model, err := surface.NewModelFromOpenAPI2(document, "../../examples/v2.0/yaml/petstore-expanded.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := `# GraphQL schema for Swagger Petstore
+
+type Pet {
+  name: String
+  tag: String
+  id: Int
+}
+
+input NewPetInput {
+  name: String
+  tag: String
+}
+
+type Query {
+  """Returns all pets from the system that the user has access to"""
+  findPets(tags: [String], limit: Int): [Pet]
+  """Returns a user based on a single ID, if the user does not have access to the pet"""
+  findPetById(id: Int!): Pet
+}
+
+type Mutation {
+  """Creates a new pet in the store.  Duplicates are allowed"""
+  addPet(pet: NewPetInput!): Pet
+  """deletes a single pet based on the ID supplied"""
+  deletePet(id: Int!): Boolean
+}
+`
+	if diff := cmp.Diff(expected, NewSchema(model)); diff != "" {
+		t.Errorf("unexpected schema (-want +got):\n%s", diff)
+	}
+}
+
+func TestSchemaTypes(t *testing.T) {
+	model := &surface.Model{
+		Types: []*surface.Type{
+			{Name: "node-info", Fields: []*surface.Field{
+				{Name: "labels", Type: "string", Kind: surface.FieldKind_MAP},
+				{Name: "next", Type: "node-info", Kind: surface.FieldKind_REFERENCE},
+				{Name: "x-rank", Type: "number"},
+			}},
+			{Name: "GetNodeResponses", Fields: []*surface.Field{
+				{Name: "200 application/json", Type: "node-info", Kind: surface.FieldKind_REFERENCE},
+			}},
+		},
+		Methods: []*surface.Method{
+			{Operation: "get node", Method: "GET", ResponsesTypeName: "GetNodeResponses"},
+			{Operation: "get node", Method: "GET", ResponsesTypeName: "GetNodeResponses"},
+		},
+	}
+	expected := `type NodeInfo {
+  labels: JSON
+  next: NodeInfo
+  xRank: Float
+}
+
+type Query {
+  getNode: NodeInfo
+  getNode2: NodeInfo
+}
+
+scalar JSON
+`
+	if diff := cmp.Diff(expected, NewSchema(model)); diff != "" {
+		t.Errorf("unexpected schema (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-graphql is a plugin that generates a GraphQL schema for an API.
+package main
+
+import (
+	plugins "github.com/okkoye/gnostic/plugins"
+)
+
+// This is the main function for the plugin.
+func main() {
+	plugins.Run(&plugins.Requirements{
+		Models: []string{plugins.ModelSurface},
+	}, func(env *plugins.Environment) error {
+		model, err := env.Request.Surface()
+		if model != nil {
+			env.AddFile("schema.graphql", []byte(NewSchema(model)))
+		}
+		return err
+	})
+}
@@ -0,0 +1,217 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/conversions"
+	"github.com/okkoye/gnostic/jsonschema"
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// Options control how schemas are written.
+type Options struct {
+	// Dialect is the version of JSON Schema that schemas are written in.
+	Dialect jsonschema.Dialect
+}
+
+// A NamedSchema is a standalone JSON Schema for a schema of an API.
+type NamedSchema struct {
+	Name  string
+	Value *yaml.Node
+}
+
+// FileName returns the name of the file that a schema is written to.
+func (schema *NamedSchema) FileName() string {
+	return fileName(schema.Name)
+}
+
+func fileName(name string) string {
+	return name + ".json"
+}
+
+// NewSchemasFromOpenAPIv2 returns standalone schemas for the definitions
+// of an OpenAPI v2 document. When the source of the document is given, the
+// schemas are read from it, because compiled schemas lose values like
+// "minimum: 0" that are the same as their defaults.
+func NewSchemasFromOpenAPIv2(document *openapiv2.Document, source []byte, options *Options) []*NamedSchema {
+	c := &converter{options: options, prefix: "#/definitions/"}
+	nodes := sourceSchemaNodes(source, "definitions")
+	schemas := []*NamedSchema{}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			node := nodes[pair.Name]
+			if node == nil {
+				node = pair.Value.ToRawInfo()
+			}
+			schemas = append(schemas, c.standalone(pair.Name, node))
+		}
+	}
+	return sortedSchemas(schemas)
+}
+
+// NewSchemasFromOpenAPIv3 returns standalone schemas for the component
+// schemas of an OpenAPI v3 document, which are read from the source of the
+// document when it is given.
+func NewSchemasFromOpenAPIv3(document *openapiv3.Document, source []byte, options *Options) []*NamedSchema {
+	c := &converter{options: options, prefix: "#/components/schemas/"}
+	nodes := sourceSchemaNodes(source, "components", "schemas")
+	schemas := []*NamedSchema{}
+	if document.Components != nil && document.Components.Schemas != nil {
+		for _, pair := range document.Components.Schemas.AdditionalProperties {
+			node := nodes[pair.Name]
+			if node == nil {
+				node = pair.Value.ToRawInfo()
+			}
+			schemas = append(schemas, c.standalone(pair.Name, node))
+		}
+	}
+	return sortedSchemas(schemas)
+}
+
+// sourceSchemaNodes returns the YAML of the named schemas at a path in the
+// source of a document.
+func sourceSchemaNodes(source []byte, path ...string) map[string]*yaml.Node {
+	nodes := make(map[string]*yaml.Node)
+	if len(source) == 0 {
+		return nodes
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(source, &document); err != nil || len(document.Content) == 0 {
+		return nodes
+	}
+	node := document.Content[0]
+	for _, key := range path {
+		if node = compiler.MapValueForKey(node, key); node == nil {
+			return nodes
+		}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		nodes[node.Content[i].Value] = node.Content[i+1]
+	}
+	return nodes
+}
+
+func sortedSchemas(schemas []*NamedSchema) []*NamedSchema {
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Name < schemas[j].Name
+	})
+	return schemas
+}
+
+// A converter rewrites OpenAPI schemas as JSON Schemas.
+type converter struct {
+	options *Options
+	// the prefix of references to the schemas that are written to files
+	prefix string
+}
+
+// standalone returns a schema with the $schema keyword of the dialect.
+func (c *converter) standalone(name string, node *yaml.Node) *NamedSchema {
+	schema := c.convert(conversions.JSONSchemaNodeForOpenAPIv3SchemaNode(node))
+	header := []*yaml.Node{
+		compiler.NewScalarNodeForString("$schema"),
+		compiler.NewScalarNodeForString(c.options.Dialect.URI()),
+	}
+	schema.Content = append(header, schema.Content...)
+	return &NamedSchema{Name: name, Value: schema}
+}
+
+// convert adapts a JSON Schema that was converted from an OpenAPI schema
+// to a standalone file: references to the schemas that are written to files
+// are rewritten as references to the files, and keywords are written as the
+// dialect expects them. Exclusive bounds are flags in draft-04, which has no
+// examples, and readOnly and writeOnly were added in draft-07.
+func (c *converter) convert(node *yaml.Node) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return node
+	}
+	d := c.options.Dialect
+	result := compiler.NewMappingNode()
+	add := func(key string, value *yaml.Node) {
+		result.Content = append(result.Content, compiler.NewScalarNodeForString(key), value)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch key {
+		case "$ref":
+			add(key, compiler.NewScalarNodeForString(c.reference(value.Value)))
+		case "properties", "patternProperties":
+			properties := compiler.NewMappingNode()
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				properties.Content = append(properties.Content, value.Content[j], c.convert(value.Content[j+1]))
+			}
+			add(key, properties)
+		case "items", "additionalProperties", "not":
+			add(key, c.convert(value))
+		case "allOf", "anyOf", "oneOf":
+			items := compiler.NewSequenceNode()
+			for _, item := range value.Content {
+				items.Content = append(items.Content, c.convert(item))
+			}
+			add(key, items)
+		case "exclusiveMinimum", "exclusiveMaximum":
+			if d == jsonschema.DialectDraft04 {
+				add(bounds[key], value)
+				add(key, compiler.NewScalarNodeForBool(true))
+			} else {
+				add(key, value)
+			}
+		case "examples":
+			if d != jsonschema.DialectDraft04 {
+				add(key, value)
+			}
+		case "readOnly", "writeOnly":
+			if d != jsonschema.DialectDraft04 && d != jsonschema.DialectDraft06 {
+				add(key, value)
+			}
+		default:
+			add(key, value)
+		}
+	}
+	return result
+}
+
+// bounds maps the keywords of exclusive bounds to the keywords of the
+// bounds that they flag in draft-04.
+var bounds = map[string]string{
+	"exclusiveMinimum": "minimum",
+	"exclusiveMaximum": "maximum",
+}
+
+// reference rewrites a reference to a schema that is written to a file,
+// or to a location within one, as a reference to the file.
+func (c *converter) reference(ref string) string {
+	if !strings.HasPrefix(ref, c.prefix) {
+		return ref
+	}
+	name := strings.TrimPrefix(ref, c.prefix)
+	pointer := ""
+	if i := strings.Index(name, "/"); i >= 0 {
+		name, pointer = name[:i], name[i:]
+	}
+	name = strings.Replace(strings.Replace(name, "~1", "/", -1), "~0", "~", -1)
+	if pointer != "" {
+		pointer = "#" + pointer
+	}
+	return url.PathEscape(fileName(name)) + pointer
+}
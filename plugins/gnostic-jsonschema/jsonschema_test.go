@@ -0,0 +1,181 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/okkoye/gnostic/jsonschema"
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const source = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        age: {type: integer, minimum: 0, exclusiveMinimum: true, readOnly: true}
+        owner: {$ref: "#/components/schemas/Owner"}
+        tag: {type: string, nullable: true, example: cat, x-go-name: Label}
+    Owner:
+      type: object
+      properties:
+        name: {$ref: "#/components/schemas/Pet/properties/tag"}
+`
+
+func TestSchemasFromOpenAPIv3(t *testing.T) {
+	document, err := openapiv3.ParseDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	tests := []struct {
+		dialect jsonschema.Dialect
+		source  []byte
+		pet     string
+	}{
+		{jsonschema.Dialect202012, []byte(source), `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "age": {
+      "type": "integer",
+      "exclusiveMinimum": 0,
+      "readOnly": true
+    },
+    "owner": {
+      "$ref": "Owner.json"
+    },
+    "tag": {
+      "type": [
+        "string",
+        "null"
+      ],
+      "examples": [
+        "cat"
+      ]
+    }
+  }
+}
+`},
+		{jsonschema.DialectDraft04, []byte(source), `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "properties": {
+    "age": {
+      "type": "integer",
+      "minimum": 0,
+      "exclusiveMinimum": true
+    },
+    "owner": {
+      "$ref": "Owner.json"
+    },
+    "tag": {
+      "type": [
+        "string",
+        "null"
+      ]
+    }
+  }
+}
+`},
+		// Without the source, compiled schemas lose minimums of zero.
+		{jsonschema.DialectDraft07, nil, `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "age": {
+      "readOnly": true,
+      "type": "integer"
+    },
+    "owner": {
+      "$ref": "Owner.json"
+    },
+    "tag": {
+      "examples": [
+        "cat"
+      ],
+      "type": [
+        "string",
+        "null"
+      ]
+    }
+  }
+}
+`},
+	}
+	for _, test := range tests {
+		schemas := NewSchemasFromOpenAPIv3(document, test.source, &Options{Dialect: test.dialect})
+		if len(schemas) != 2 || schemas[0].Name != "Owner" || schemas[1].Name != "Pet" {
+			t.Fatalf("unexpected schemas %+v", schemas)
+		}
+		if got := jsonschema.Render(schemas[1].Value); got != test.pet {
+			t.Errorf("unexpected %s schema:\n%s", test.dialect, got)
+		}
+		owner := jsonschema.NewSchemaFromObject(schemas[0].Value)
+		if ref := (*owner.Properties)[0].Value.Ref; ref == nil || *ref != "Pet.json#/properties/tag" {
+			t.Errorf("unexpected reference %v", ref)
+		}
+	}
+}
+
+func TestSchemasFromOpenAPIv2(t *testing.T) {
+	document, err := openapiv2.ParseDocument([]byte(`swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+definitions:
+  Pets:
+    type: array
+    items: {$ref: "#/definitions/Pet"}
+  Pet:
+    type: object
+    properties:
+      name: {type: string}
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	schemas := NewSchemasFromOpenAPIv2(document, nil, &Options{Dialect: jsonschema.Dialect201909})
+	if len(schemas) != 2 || schemas[0].FileName() != "Pet.json" || schemas[1].FileName() != "Pets.json" {
+		t.Fatalf("unexpected schemas %+v", schemas)
+	}
+	expected := `{
+  "$schema": "https://json-schema.org/draft/2019-09/schema",
+  "type": "array",
+  "items": {
+    "$ref": "Pet.json"
+  }
+}
+`
+	if got := jsonschema.Render(schemas[1].Value); got != expected {
+		t.Errorf("unexpected schema:\n%s", got)
+	}
+}
+
+func TestDialectForName(t *testing.T) {
+	if d := dialectForName("draft-07"); d != jsonschema.DialectDraft07 {
+		t.Errorf("unexpected dialect %s", d)
+	}
+	if d := dialectForName("draft-99"); d != jsonschema.DialectUnspecified {
+		t.Errorf("unexpected dialect %s", d)
+	}
+}
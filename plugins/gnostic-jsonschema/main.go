@@ -0,0 +1,74 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-jsonschema is a plugin that writes the schemas of an API
+// description as standalone JSON Schemas.
+package main
+
+import (
+	"fmt"
+
+	"github.com/okkoye/gnostic/jsonschema"
+	plugins "github.com/okkoye/gnostic/plugins"
+)
+
+// This is the main function for the plugin.
+func main() {
+	plugins.Run(&plugins.Requirements{
+		Models:   []string{plugins.ModelOpenAPIv2, plugins.ModelOpenAPIv3},
+		Features: []string{plugins.FeatureSource},
+	}, func(env *plugins.Environment) error {
+		options := &Options{Dialect: jsonschema.Dialect202012}
+		for _, parameter := range env.Request.Parameters {
+			if parameter.Name == "dialect" {
+				options.Dialect = dialectForName(parameter.Value)
+				if options.Dialect == jsonschema.DialectUnspecified {
+					return fmt.Errorf("unknown dialect %q", parameter.Value)
+				}
+			}
+		}
+
+		var schemas []*NamedSchema
+		if documentv2, err := env.Request.OpenAPIv2(); err != nil {
+			return err
+		} else if documentv2 != nil {
+			schemas = NewSchemasFromOpenAPIv2(documentv2, env.Request.Source, options)
+		}
+		if documentv3, err := env.Request.OpenAPIv3(); err != nil {
+			return err
+		} else if documentv3 != nil {
+			schemas = NewSchemasFromOpenAPIv3(documentv3, env.Request.Source, options)
+		}
+		for _, schema := range schemas {
+			env.AddFile(schema.FileName(), []byte(jsonschema.Render(schema.Value)))
+		}
+		return nil
+	})
+}
+
+// dialectForName returns the dialect with a short name like "draft-07".
+func dialectForName(name string) jsonschema.Dialect {
+	for _, d := range []jsonschema.Dialect{
+		jsonschema.DialectDraft04,
+		jsonschema.DialectDraft06,
+		jsonschema.DialectDraft07,
+		jsonschema.Dialect201909,
+		jsonschema.Dialect202012,
+	} {
+		if d.String() == name {
+			return d
+		}
+	}
+	return jsonschema.DialectUnspecified
+}
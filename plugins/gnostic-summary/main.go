@@ -18,9 +18,6 @@ package main
 
 import (
 	"log"
-	"path/filepath"
-
-	"github.com/golang/protobuf/proto"
 
 	openapiv2 "github.com/okkoye/gnostic/openapiv2"
 	openapiv3 "github.com/okkoye/gnostic/openapiv3"
@@ -95,32 +92,22 @@ func printDocumentV3(code *printer.Code, document *openapiv3.Document) {
 
 // This is the main function for the plugin.
 func main() {
-	env, err := plugins.NewEnvironment()
-	env.RespondAndExitIfError(err)
-	code := &printer.Code{}
-	for _, model := range env.Request.Models {
-		switch model.TypeUrl {
-		case "openapi.v2.Document":
-			documentv2 := &openapiv2.Document{}
-			err = proto.Unmarshal(model.Value, documentv2)
-			if err == nil {
-				printDocumentV2(code, documentv2)
-			}
-		case "openapi.v3.Document":
-			documentv3 := &openapiv3.Document{}
-			err = proto.Unmarshal(model.Value, documentv3)
-			if err == nil {
-				printDocumentV3(code, documentv3)
-			}
+	plugins.Run(&plugins.Requirements{
+		Models: []string{plugins.ModelOpenAPIv2, plugins.ModelOpenAPIv3},
+	}, func(env *plugins.Environment) error {
+		code := &printer.Code{}
+		if documentv2, err := env.Request.OpenAPIv2(); err != nil {
+			return err
+		} else if documentv2 != nil {
+			printDocumentV2(code, documentv2)
 		}
-	}
-	outputName := filepath.Join(
-		filepath.Dir(env.Request.SourceName), "summary.txt")
-	log.Printf("generating %+v", outputName)
-	f := &plugins.File{
-		Name: outputName,
-		Data: []byte(code.String()),
-	}
-	env.Response.Files = append(env.Response.Files, f)
-	env.RespondAndExit()
+		if documentv3, err := env.Request.OpenAPIv3(); err != nil {
+			return err
+		} else if documentv3 != nil {
+			printDocumentV3(code, documentv3)
+		}
+		log.Printf("generating %+v", "summary.txt")
+		env.AddFile("summary.txt", []byte(code.String()))
+		return nil
+	})
 }
@@ -0,0 +1,278 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/stoewer/go-strcase"
+
+	surface "github.com/okkoye/gnostic/surface"
+)
+
+// The schemas are written in the format of "terraform providers schema -json".
+
+// ProviderSchemas is the schema document of one or more providers.
+type ProviderSchemas struct {
+	FormatVersion   string                     `json:"format_version"`
+	ProviderSchemas map[string]*ProviderSchema `json:"provider_schemas"`
+}
+
+// ProviderSchema describes the resources and data sources of a provider.
+type ProviderSchema struct {
+	Provider          *Schema            `json:"provider"`
+	ResourceSchemas   map[string]*Schema `json:"resource_schemas"`
+	DataSourceSchemas map[string]*Schema `json:"data_source_schemas"`
+}
+
+// Schema is the schema of a provider, resource, or data source.
+type Schema struct {
+	Version int    `json:"version"`
+	Block   *Block `json:"block"`
+}
+
+// Block is a configuration block.
+type Block struct {
+	Attributes  map[string]*Attribute `json:"attributes,omitempty"`
+	Description string                `json:"description,omitempty"`
+}
+
+// Attribute is an attribute of a block. Types are written as in
+// Terraform's JSON type syntax, e.g. "string" or ["list", "number"].
+type Attribute struct {
+	Type        interface{} `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Optional    bool        `json:"optional,omitempty"`
+	Computed    bool        `json:"computed,omitempty"`
+}
+
+type generator struct {
+	model *surface.Model
+	types map[string]*surface.Type
+}
+
+// NewProviderSchemas returns a provider schema skeleton for an API.
+// Collections that are created with POST become resources whose
+// attributes are the fields of the create request (optional) and of the
+// created object (computed). Items that can be read with GET become data
+// sources whose path parameters are required and whose response fields
+// are computed. The surface model doesn't record which fields are
+// required, so the skeleton needs refinement before it is used.
+func NewProviderSchemas(model *surface.Model, provider string) *ProviderSchemas {
+	g := &generator{model: model, types: make(map[string]*surface.Type)}
+	for _, t := range model.Types {
+		g.types[t.Name] = t
+	}
+	if provider == "" {
+		provider = strcase.SnakeCase(model.Name)
+	}
+	schema := &ProviderSchema{
+		Provider:          &Schema{Block: &Block{}},
+		ResourceSchemas:   make(map[string]*Schema),
+		DataSourceSchemas: make(map[string]*Schema),
+	}
+	for _, method := range model.Methods {
+		switch {
+		case method.Method == "POST" && !isItemPath(method.Path):
+			name := resourceName(schema.ResourceSchemas, provider, method.Path)
+			schema.ResourceSchemas[name] = &Schema{Block: g.resourceBlock(method)}
+		case method.Method == "GET" && isItemPath(method.Path):
+			name := resourceName(schema.DataSourceSchemas, provider, parentPath(method.Path))
+			schema.DataSourceSchemas[name] = &Schema{Block: g.dataSourceBlock(method)}
+		}
+	}
+	return &ProviderSchemas{
+		FormatVersion:   "1.0",
+		ProviderSchemas: map[string]*ProviderSchema{provider: schema},
+	}
+}
+
+// Returns true if a path ends with a path parameter.
+func isItemPath(path string) bool {
+	return strings.HasSuffix(path, "}")
+}
+
+// Returns a path without its last segment.
+func parentPath(path string) string {
+	return path[:strings.LastIndex(path, "/")]
+}
+
+// Returns the literal segments of a path.
+func literalSegments(path string) []string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			segments = append(segments, strcase.SnakeCase(segment))
+		}
+	}
+	return segments
+}
+
+// Returns the name of a resource or data source for a collection path,
+// which is normally named with the singular of the last literal segment.
+func resourceName(schemas map[string]*Schema, provider, path string) string {
+	segments := literalSegments(path)
+	if len(segments) == 0 {
+		segments = []string{"root"}
+	}
+	name := provider + "_" + singular(segments[len(segments)-1])
+	if _, ok := schemas[name]; ok {
+		name = provider + "_" + strings.Join(segments, "_")
+	}
+	return name
+}
+
+// Returns the singular form of a plural English noun, approximately.
+func singular(plural string) string {
+	switch {
+	case strings.HasSuffix(plural, "ies"):
+		return strings.TrimSuffix(plural, "ies") + "y"
+	case strings.HasSuffix(plural, "sses"):
+		return strings.TrimSuffix(plural, "es")
+	case strings.HasSuffix(plural, "s") && !strings.HasSuffix(plural, "ss"):
+		return strings.TrimSuffix(plural, "s")
+	}
+	return plural
+}
+
+// Returns the block of a resource created by a method.
+func (g *generator) resourceBlock(create *surface.Method) *Block {
+	block := &Block{Attributes: make(map[string]*Attribute), Description: firstLine(create.Description)}
+	for _, field := range g.fields(g.types[create.ParametersTypeName]) {
+		switch field.Position {
+		case surface.Position_BODY:
+			if field.Kind == surface.FieldKind_REFERENCE {
+				g.addAttributes(block, g.fields(g.types[field.Type]), func(a *Attribute) { a.Optional = true })
+			} else {
+				g.addAttribute(block, field, func(a *Attribute) { a.Optional = true })
+			}
+		case surface.Position_PATH:
+			g.addAttribute(block, field, func(a *Attribute) { a.Required = true })
+		case surface.Position_QUERY:
+			g.addAttribute(block, field, func(a *Attribute) { a.Optional = true })
+		}
+	}
+	// Fields of the created object that aren't inputs are computed.
+	g.addAttributes(block, g.responseFields(create), func(a *Attribute) { a.Computed = true })
+	return block
+}
+
+// Returns the block of a data source read by a method.
+func (g *generator) dataSourceBlock(read *surface.Method) *Block {
+	block := &Block{Attributes: make(map[string]*Attribute), Description: firstLine(read.Description)}
+	for _, field := range g.fields(g.types[read.ParametersTypeName]) {
+		switch field.Position {
+		case surface.Position_PATH:
+			g.addAttribute(block, field, func(a *Attribute) { a.Required = true })
+		case surface.Position_QUERY:
+			g.addAttribute(block, field, func(a *Attribute) { a.Optional = true })
+		}
+	}
+	g.addAttributes(block, g.responseFields(read), func(a *Attribute) { a.Computed = true })
+	return block
+}
+
+// Adds attributes for fields that aren't already in a block.
+func (g *generator) addAttributes(block *Block, fields []*surface.Field, set func(*Attribute)) {
+	for _, field := range fields {
+		g.addAttribute(block, field, set)
+	}
+}
+
+// Adds an attribute for a field if it isn't already in a block.
+func (g *generator) addAttribute(block *Block, field *surface.Field, set func(*Attribute)) {
+	name := strcase.SnakeCase(field.Name)
+	if _, ok := block.Attributes[name]; ok {
+		return
+	}
+	attribute := &Attribute{Type: g.attributeType(field, make(map[string]bool))}
+	set(attribute)
+	block.Attributes[name] = attribute
+}
+
+// Returns the fields of the object in the successful response of a method.
+func (g *generator) responseFields(method *surface.Method) []*surface.Field {
+	responses := g.types[method.ResponsesTypeName]
+	if responses == nil {
+		return nil
+	}
+	for _, field := range responses.Fields {
+		if strings.HasPrefix(field.Name, "2") && field.Kind == surface.FieldKind_REFERENCE {
+			return g.fields(g.types[field.Type])
+		}
+	}
+	return nil
+}
+
+// Returns the fields of a type, including the fields of the types that it combines with allOf.
+func (g *generator) fields(t *surface.Type) []*surface.Field {
+	if t == nil {
+		return nil
+	}
+	fields := make([]*surface.Field, 0, len(t.Fields))
+	for _, field := range t.Fields {
+		if strings.HasPrefix(field.Name, "all_of_") && field.Kind == surface.FieldKind_REFERENCE {
+			fields = append(fields, g.fields(g.types[field.Type])...)
+		} else {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Returns the Terraform type of a field.
+func (g *generator) attributeType(field *surface.Field, visiting map[string]bool) interface{} {
+	switch field.Kind {
+	case surface.FieldKind_ARRAY:
+		return []interface{}{"list", g.typeForName(field.Type, visiting)}
+	case surface.FieldKind_MAP:
+		return []interface{}{"map", g.typeForName(field.Type, visiting)}
+	case surface.FieldKind_ANY:
+		return "dynamic"
+	}
+	return g.typeForName(field.Type, visiting)
+}
+
+// Returns the Terraform type of a named type. Recursive types are dynamic.
+func (g *generator) typeForName(name string, visiting map[string]bool) interface{} {
+	switch name {
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "bool"
+	case "string":
+		return "string"
+	}
+	t := g.types[name]
+	if t == nil || visiting[name] {
+		return "dynamic"
+	}
+	if t.Kind == surface.TypeKind_OBJECT {
+		return []interface{}{"map", g.typeForName(t.ContentType, visiting)}
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+	attributes := make(map[string]interface{})
+	for _, field := range g.fields(t) {
+		attributes[strcase.SnakeCase(field.Name)] = g.attributeType(field, visiting)
+	}
+	return []interface{}{"object", attributes}
+}
+
+// Returns the first line of a description.
+func firstLine(description string) string {
+	return strings.TrimSpace(strings.SplitN(description, "\n", 2)[0])
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	surface "github.com/okkoye/gnostic/surface"
+)
+
+func TestProviderSchemas(t *testing.T) {
+	bytes, err := ioutil.ReadFile("../../examples/v2.0/yaml/petstore-expanded.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := openapiv2.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	model, err := surface.NewModelFromOpenAPI2(document, "petstore-expanded.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	schemas := NewProviderSchemas(model, "petstore")
+	schema := schemas.ProviderSchemas["petstore"]
+	if schema == nil {
+		t.Fatalf("missing provider schema %+v", schemas.ProviderSchemas)
+	}
+	resource := schema.ResourceSchemas["petstore_pet"]
+	if resource == nil {
+		t.Fatalf("missing resource %+v", schema.ResourceSchemas)
+	}
+	expected := map[string]*Attribute{
+		"id":   {Type: "number", Computed: true},
+		"name": {Type: "string", Optional: true},
+		"tag":  {Type: "string", Optional: true},
+	}
+	if diff := cmp.Diff(expected, resource.Block.Attributes); diff != "" {
+		t.Errorf("unexpected resource attributes (-want +got):\n%s", diff)
+	}
+	dataSource := schema.DataSourceSchemas["petstore_pet"]
+	if dataSource == nil {
+		t.Fatalf("missing data source %+v", schema.DataSourceSchemas)
+	}
+	expected = map[string]*Attribute{
+		"id":   {Type: "number", Required: true},
+		"name": {Type: "string", Computed: true},
+		"tag":  {Type: "string", Computed: true},
+	}
+	if diff := cmp.Diff(expected, dataSource.Block.Attributes); diff != "" {
+		t.Errorf("unexpected data source attributes (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttributeTypes(t *testing.T) {
+	g := &generator{types: map[string]*surface.Type{
+		"Node": {Name: "Node", Fields: []*surface.Field{
+			{Name: "value", Type: "integer"},
+			{Name: "children", Type: "Node", Kind: surface.FieldKind_ARRAY},
+		}},
+		"Labels": {Name: "Labels", Kind: surface.TypeKind_OBJECT, ContentType: "string"},
+	}}
+	tests := []struct {
+		field    *surface.Field
+		expected interface{}
+	}{
+		{&surface.Field{Type: "boolean"}, "bool"},
+		{&surface.Field{Type: "string", Kind: surface.FieldKind_ARRAY}, []interface{}{"list", "string"}},
+		{&surface.Field{Type: "number", Kind: surface.FieldKind_MAP}, []interface{}{"map", "number"}},
+		{&surface.Field{Type: "Labels", Kind: surface.FieldKind_REFERENCE}, []interface{}{"map", "string"}},
+		{&surface.Field{Kind: surface.FieldKind_ANY}, "dynamic"},
+		{&surface.Field{Type: "Node", Kind: surface.FieldKind_REFERENCE}, []interface{}{"object", map[string]interface{}{
+			"value":    "number",
+			"children": []interface{}{"list", "dynamic"},
+		}}},
+	}
+	for _, test := range tests {
+		if diff := cmp.Diff(test.expected, g.attributeType(test.field, make(map[string]bool))); diff != "" {
+			t.Errorf("unexpected type for %+v (-want +got):\n%s", test.field, diff)
+		}
+	}
+}
@@ -14,91 +14,34 @@
 package main
 
 import (
-	"encoding/json"
-	"log"
-	"os"
-	"path/filepath"
-
-	"github.com/golang/protobuf/proto"
-
-	discovery_v1 "github.com/okkoye/gnostic/discovery"
 	metrics "github.com/okkoye/gnostic/metrics"
 	vocabulary "github.com/okkoye/gnostic/metrics/vocabulary"
-	openapiv2 "github.com/okkoye/gnostic/openapiv2"
-	openapiv3 "github.com/okkoye/gnostic/openapiv3"
 	plugins "github.com/okkoye/gnostic/plugins"
 )
 
-// Record an error, then serialize and return a response.
-func sendAndExitIfError(err error, response *plugins.Response) {
-	if err != nil {
-		response.Errors = append(response.Errors, err.Error())
-		sendAndExit(response)
-	}
-}
-
-// Serialize and return a response.
-func sendAndExit(response *plugins.Response) {
-	responseBytes, _ := proto.Marshal(response)
-	os.Stdout.Write(responseBytes)
-	os.Exit(0)
-}
-
 // This is the main function for the plugin.
 func main() {
-	env, err := plugins.NewEnvironment()
-	env.RespondAndExitIfError(err)
-
-	var vocab *metrics.Vocabulary
-
-	for _, model := range env.Request.Models {
-		switch model.TypeUrl {
-		case "openapi.v2.Document":
-			documentv2 := &openapiv2.Document{}
-			err = proto.Unmarshal(model.Value, documentv2)
-			if err == nil {
-				// Analyze the API document.
-				vocab = vocabulary.NewVocabularyFromOpenAPIv2(documentv2)
-			}
-		case "openapi.v3.Document":
-			documentv3 := &openapiv3.Document{}
-			err = proto.Unmarshal(model.Value, documentv3)
-			if err == nil {
-				// Analyze the API document.
-				vocab = vocabulary.NewVocabularyFromOpenAPIv3(documentv3)
-			}
-		case "discovery.v1.Document":
-			discoveryDocument := &discovery_v1.Document{}
-			err = proto.Unmarshal(model.Value, discoveryDocument)
-			if err == nil {
-				// Analyze the API document.
-				vocab = vocabulary.NewVocabularyFromDiscovery(discoveryDocument)
-			}
-		default:
-			log.Printf("unsupported document type %s", model.TypeUrl)
+	plugins.Run(&plugins.Requirements{
+		Models: []string{plugins.ModelOpenAPIv2, plugins.ModelOpenAPIv3},
+	}, func(env *plugins.Environment) error {
+		// Analyze the API document.
+		var vocab *metrics.Vocabulary
+		if documentv2, err := env.Request.OpenAPIv2(); err != nil {
+			return err
+		} else if documentv2 != nil {
+			vocab = vocabulary.NewVocabularyFromOpenAPIv2(documentv2)
 		}
-	}
-
-	if vocab != nil {
-		outputName1 := filepath.Join(
-			filepath.Dir(env.Request.SourceName), "vocabulary.json")
-		outputName2 := filepath.Join(
-			filepath.Dir(env.Request.SourceName), "vocabulary.pb")
-		file := &plugins.File{}
-
-		file.Name = outputName1
-		file.Data, err = json.MarshalIndent(vocab, "", "  ")
-		env.RespondAndExitIfError(err)
-		file.Data = append(file.Data, []byte("\n")...)
-		env.Response.Files = append(env.Response.Files, file)
-
-		file2 := &plugins.File{}
-		file2.Name = outputName2
-		file2.Data, err = proto.Marshal(vocab)
-		env.RespondAndExitIfError(err)
-		env.Response.Files = append(env.Response.Files, file2)
-
-	}
-
-	env.RespondAndExit()
+		if documentv3, err := env.Request.OpenAPIv3(); err != nil {
+			return err
+		} else if documentv3 != nil {
+			vocab = vocabulary.NewVocabularyFromOpenAPIv3(documentv3)
+		}
+		if vocab == nil {
+			return nil
+		}
+		if err := env.AddJSONFile("vocabulary.json", vocab); err != nil {
+			return err
+		}
+		return env.AddProtoFile("vocabulary.pb", vocab)
+	})
 }
@@ -15,10 +15,20 @@
 package gnostic_plugin_v1
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
 )
 
 func testPlugin(t *testing.T, plugin string, inputFile string, outputFile string, referenceFile string) {
@@ -111,3 +121,206 @@ func TestValidPluginInvocations(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestCodeGeneratorRequest(t *testing.T) {
+	request, err := newRequestForCodeGeneratorRequest(&pluginpb.CodeGeneratorRequest{
+		Parameter: proto.String("openapi=../examples/v3.0/yaml/petstore.yaml,a=b,c"),
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if request.SourceName != "../examples/v3.0/yaml/petstore.yaml" {
+		t.Errorf("unexpected source name %s", request.SourceName)
+	}
+	if len(request.Parameters) != 2 ||
+		request.Parameters[0].Name != "a" || request.Parameters[0].Value != "b" ||
+		request.Parameters[1].Name != "c" || request.Parameters[1].Value != "" {
+		t.Errorf("unexpected parameters %+v", request.Parameters)
+	}
+	if len(request.Models) != 2 ||
+		request.Models[0].TypeUrl != "openapi.v3.Document" ||
+		request.Models[1].TypeUrl != "surface.v1.Model" {
+		t.Errorf("unexpected models %+v", request.Models)
+	}
+	_, err = newRequestForCodeGeneratorRequest(&pluginpb.CodeGeneratorRequest{})
+	if err == nil {
+		t.Errorf("a request without an openapi parameter was accepted")
+	}
+	response := newCodeGeneratorResponse(&Response{
+		Errors: []string{"first", "second"},
+		Files:  []*File{{Name: "summary.txt", Data: []byte("summary")}},
+	})
+	if response.GetError() != "first\nsecond" {
+		t.Errorf("unexpected error %q", response.GetError())
+	}
+	if len(response.File) != 1 || response.File[0].GetName() != "summary.txt" || response.File[0].GetContent() != "summary" {
+		t.Errorf("unexpected files %+v", response.File)
+	}
+}
+
+func TestCodeGeneratorRequestWithAnnotations(t *testing.T) {
+	methodOptions := &descriptorpb.MethodOptions{}
+	proto.SetExtension(methodOptions, annotations.E_Http, &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Get{Get: "/v1/{name=shelves/*}"},
+	})
+	library := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("library.proto"),
+		Package:    proto.String("library"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/api/annotations.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Shelf"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("name"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				JsonName: proto.String("name"),
+			}},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Library"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("GetShelf"),
+				InputType:  proto.String(".library.Shelf"),
+				OutputType: proto.String(".library.Shelf"),
+				Options:    methodOptions,
+			}},
+		}},
+	}
+	request, err := newRequestForCodeGeneratorRequest(&pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String("a=b"),
+		FileToGenerate: []string{"library.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+			protodesc.ToFileDescriptorProto(annotations.File_google_api_http_proto),
+			protodesc.ToFileDescriptorProto(annotations.File_google_api_annotations_proto),
+			library,
+		},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(request.Parameters) != 1 || request.Parameters[0].Name != "a" {
+		t.Errorf("unexpected parameters %+v", request.Parameters)
+	}
+	if len(request.Models) != 2 || request.Models[0].TypeUrl != "openapi.v3.Document" {
+		t.Fatalf("unexpected models %+v", request.Models)
+	}
+	document := &openapiv3.Document{}
+	if err := proto.Unmarshal(request.Models[0].Value, document); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	paths := document.GetPaths().GetPath()
+	if len(paths) != 1 || paths[0].Name != "/v1/shelves/{shelf}" || paths[0].Value.Get == nil {
+		t.Errorf("unexpected paths %+v", paths)
+	}
+}
+
+func TestRequirements(t *testing.T) {
+	request := &Request{
+		Models: []*any.Any{
+			{TypeUrl: ModelOpenAPIv3},
+			{TypeUrl: ModelSurface},
+		},
+	}
+	// Plugins without requirements use every model.
+	var requirements *Requirements
+	if !requirements.RequiresModel(ModelSurface) || requirements.RequiresFeature(FeatureSource) {
+		t.Fatalf("Plugins without requirements should use every model and no features")
+	}
+	request.filterModels(requirements)
+	if len(request.Models) != 2 {
+		t.Fatalf("Expected 2 models, got %d", len(request.Models))
+	}
+	requirements = &Requirements{
+		ProtocolVersion: ProtocolVersion,
+		Models:          []string{ModelSurface},
+		Features:        []string{FeatureSource},
+	}
+	if requirements.RequiresModel(ModelOpenAPIv3) || !requirements.RequiresFeature(FeatureSource) {
+		t.Fatalf("Requirements were not matched: %+v", requirements)
+	}
+	request.filterModels(requirements)
+	if len(request.Models) != 1 || request.Models[0].TypeUrl != ModelSurface {
+		t.Fatalf("Expected only the surface model, got %+v", request.Models)
+	}
+}
+
+func TestModelsAndFiles(t *testing.T) {
+	request := &Request{SourceName: "apis/petstore.yaml"}
+	if err := request.AddModel(ModelOpenAPIv3, &openapiv3.Document{Openapi: "3.0.0"}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := request.OpenAPIv3()
+	if err != nil || document == nil || document.Openapi != "3.0.0" {
+		t.Errorf("unexpected document %+v %+v", document, err)
+	}
+	// Models that aren't in the request are nil.
+	if document, err := request.OpenAPIv2(); document != nil || err != nil {
+		t.Errorf("unexpected document %+v %+v", document, err)
+	}
+	request.Models = append(request.Models, &any.Any{TypeUrl: ModelSurface, Value: []byte("invalid")})
+	if model, err := request.Surface(); model != nil || err == nil {
+		t.Errorf("an invalid model was read: %+v", model)
+	}
+
+	env := &Environment{Request: request, Response: &Response{}}
+	env.AddFile("summary.txt", []byte("summary"))
+	if err := env.AddJSONFile("summary.json", map[string]int{"paths": 1}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	env.AddError(nil)
+	env.AddError(errors.New("failed"))
+	env.AddMessage(Message_WARNING, "CODE", "warning", "paths")
+	files := env.Response.Files
+	if len(files) != 2 ||
+		files[0].Name != "apis/summary.txt" || string(files[0].Data) != "summary" ||
+		files[1].Name != "apis/summary.json" || string(files[1].Data) != "{\n  \"paths\": 1\n}\n" {
+		t.Errorf("unexpected files %+v", files)
+	}
+	if len(env.Response.Errors) != 1 || env.Response.Errors[0] != "failed" {
+		t.Errorf("unexpected errors %+v", env.Response.Errors)
+	}
+	if len(env.Response.Messages) != 1 || env.Response.Messages[0].Keys[0] != "paths" {
+		t.Errorf("unexpected messages %+v", env.Response.Messages)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "negotiate")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	capabilities := &Capabilities{ProtocolVersion: ProtocolVersion, Models: []string{ModelOpenAPIv3}}
+	scripts := map[string]string{
+		// Version 1 plugins that ignore flags read an empty request and
+		// may write a response, which isn't mistaken for requirements.
+		"v1": `cat > "$0.input"; printf '\022\004test'`,
+		// Version 1 plugins that reject the flag exit with errors.
+		"v1-flags": `exit 2`,
+		// Version 2 plugins reply with requirements.
+		"v2": `[ -n "$` + CapabilitiesVariable + `" ] && printf '\010\002\022\023` + ModelOpenAPIv3 + `'`,
+	}
+	for name, script := range scripts {
+		executable := dir + "/" + name
+		if err := ioutil.WriteFile(executable, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		requirements, err := Negotiate(executable, capabilities)
+		if err != nil {
+			t.Fatalf("%s: %+v", name, err)
+		}
+		if name == "v2" {
+			if requirements == nil || requirements.ProtocolVersion != 2 || !requirements.RequiresModel(ModelOpenAPIv3) {
+				t.Errorf("%s: unexpected requirements %+v", name, requirements)
+			}
+		} else if requirements != nil {
+			t.Errorf("%s: expected version 1, got %+v", name, requirements)
+		}
+	}
+	if input, err := ioutil.ReadFile(dir + "/v1.input"); err != nil || len(input) != 0 {
+		t.Errorf("expected no input to be sent, got %q %v", input, err)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic_plugin_v1
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// ProtocolVersion is the highest version of the plugin protocol that this
+// package supports.
+const ProtocolVersion = 2
+
+// Types of the models that gnostic sends to plugins.
+const (
+	ModelOpenAPIv2  = "openapi.v2.Document"
+	ModelOpenAPIv3  = "openapi.v3.Document"
+	ModelOpenAPIv31 = "openapi.v31.Document"
+	ModelDiscovery  = "discovery.v1.Document"
+	ModelSurface    = "surface.v1.Model"
+)
+
+// FeatureSource is the feature of plugins that read the text of source
+// documents from Request.Source.
+const FeatureSource = "source"
+
+// RequiresModel returns true if a plugin with these requirements uses a
+// type of model. Plugins without requirements use every model.
+func (r *Requirements) RequiresModel(model string) bool {
+	if r == nil {
+		return true
+	}
+	return containsString(r.Models, model)
+}
+
+// RequiresFeature returns true if a plugin with these requirements uses a
+// feature. Plugins without requirements use no optional features.
+func (r *Requirements) RequiresFeature(feature string) bool {
+	return r != nil && containsString(r.Features, feature)
+}
+
+// CapabilitiesVariable is the environment variable that Negotiate uses to
+// send gnostic's capabilities to plugins, as a base64-encoded Capabilities
+// message.
+const CapabilitiesVariable = "GNOSTIC_PLUGIN_CAPABILITIES"
+
+// Negotiate runs a plugin executable with the "-capabilities" flag to read
+// its requirements. The capabilities are sent in CapabilitiesVariable and
+// nothing is written to the plugin's stdin. Plugins are assumed to use
+// version 1 of the protocol, and nil requirements are returned, unless they
+// exit successfully after writing a Requirements message for version 2 or
+// later; anything else that they write is ignored. Version 1 plugins are
+// still run, so gnostic only negotiates with plugins that opt in.
+func Negotiate(executableName string, capabilities *Capabilities) (*Requirements, error) {
+	capabilitiesBytes, err := proto.Marshal(capabilities)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(executableName, "-capabilities")
+	cmd.Env = append(os.Environ(), CapabilitiesVariable+"="+base64.StdEncoding.EncodeToString(capabilitiesBytes))
+	// Version 1 plugins report the unknown flag, which isn't interesting.
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if _, ok := err.(*exec.ExitError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	requirements := &Requirements{}
+	if err := proto.Unmarshal(output, requirements); err != nil || requirements.ProtocolVersion < 2 {
+		return nil, nil
+	}
+	return requirements, nil
+}
+
+// Returns the capabilities that gnostic sent to a plugin that it runs with
+// the "-capabilities" flag. Earlier versions of gnostic wrote them to stdin.
+func readCapabilities() (*Capabilities, error) {
+	var capabilitiesBytes []byte
+	var err error
+	if encoded, ok := os.LookupEnv(CapabilitiesVariable); ok {
+		capabilitiesBytes, err = base64.StdEncoding.DecodeString(encoded)
+	} else {
+		capabilitiesBytes, err = ioutil.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return nil, err
+	}
+	capabilities := &Capabilities{}
+	if err := proto.Unmarshal(capabilitiesBytes, capabilities); err != nil {
+		return nil, err
+	}
+	return capabilities, nil
+}
+
+// filterModels removes the models that a plugin doesn't use from a request.
+func (request *Request) filterModels(requirements *Requirements) {
+	if requirements == nil {
+		return
+	}
+	models := request.Models[:0]
+	for _, model := range request.Models {
+		if requirements.RequiresModel(model.TypeUrl) {
+			models = append(models, model)
+		}
+	}
+	request.Models = models
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic_plugin_v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+
+	discovery "github.com/okkoye/gnostic/discovery"
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+	openapiv31 "github.com/okkoye/gnostic/openapiv31"
+	surface "github.com/okkoye/gnostic/surface"
+)
+
+// Run is the main function of a plugin. It creates the environment of the
+// plugin, calls the handler to fill in the response, and writes the
+// response. An error returned by the handler is added to the response.
+//
+//	func main() {
+//		plugins.Run(&plugins.Requirements{
+//			Models: []string{plugins.ModelOpenAPIv3},
+//		}, func(env *plugins.Environment) error {
+//			document, err := env.Request.OpenAPIv3()
+//			...
+//			env.AddFile("summary.txt", data)
+//			return nil
+//		})
+//	}
+func Run(requirements *Requirements, handler func(env *Environment) error) {
+	env, err := NewEnvironmentWithRequirements(requirements)
+	env.RespondAndExitIfError(err)
+	env.RespondAndExitIfError(handler(env))
+	env.RespondAndExit()
+}
+
+// OpenAPIv2 returns the OpenAPI v2 document of a request, or nil if the
+// request doesn't contain one.
+func (request *Request) OpenAPIv2() (*openapiv2.Document, error) {
+	document := &openapiv2.Document{}
+	if ok, err := request.unmarshalModel(ModelOpenAPIv2, document); !ok {
+		return nil, err
+	}
+	return document, nil
+}
+
+// OpenAPIv3 returns the OpenAPI v3 document of a request, or nil if the
+// request doesn't contain one.
+func (request *Request) OpenAPIv3() (*openapiv3.Document, error) {
+	document := &openapiv3.Document{}
+	if ok, err := request.unmarshalModel(ModelOpenAPIv3, document); !ok {
+		return nil, err
+	}
+	return document, nil
+}
+
+// OpenAPIv31 returns the OpenAPI v3.1 document of a request, or nil if the
+// request doesn't contain one.
+func (request *Request) OpenAPIv31() (*openapiv31.Document, error) {
+	document := &openapiv31.Document{}
+	if ok, err := request.unmarshalModel(ModelOpenAPIv31, document); !ok {
+		return nil, err
+	}
+	return document, nil
+}
+
+// Discovery returns the Discovery document of a request, or nil if the
+// request doesn't contain one.
+func (request *Request) Discovery() (*discovery.Document, error) {
+	document := &discovery.Document{}
+	if ok, err := request.unmarshalModel(ModelDiscovery, document); !ok {
+		return nil, err
+	}
+	return document, nil
+}
+
+// Surface returns the surface model of a request, or nil if the request
+// doesn't contain one.
+func (request *Request) Surface() (*surface.Model, error) {
+	model := &surface.Model{}
+	if ok, err := request.unmarshalModel(ModelSurface, model); !ok {
+		return nil, err
+	}
+	return model, nil
+}
+
+// unmarshalModel reads the model of a type into a message. It returns
+// false if the request has no model of the type or it can't be read.
+func (request *Request) unmarshalModel(typeURL string, message proto.Message) (bool, error) {
+	for _, model := range request.Models {
+		if model.TypeUrl == typeURL {
+			if err := proto.Unmarshal(model.Value, message); err != nil {
+				return false, fmt.Errorf("unable to read %s: %v", typeURL, err)
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AddFile adds a file to the response. Its name is relative to the
+// directory of the API description, so plugins that are run with several
+// descriptions write their files beside the files of each description.
+func (env *Environment) AddFile(name string, data []byte) {
+	env.Response.Files = append(env.Response.Files, &File{
+		Name: filepath.Join(filepath.Dir(env.Request.SourceName), name),
+		Data: data,
+	})
+}
+
+// AddJSONFile adds a file containing the indented JSON of a value to the
+// response.
+func (env *Environment) AddJSONFile(name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	env.AddFile(name, append(data, '\n'))
+	return nil
+}
+
+// AddProtoFile adds a file containing the binary encoding of a message to
+// the response.
+func (env *Environment) AddProtoFile(name string, message proto.Message) error {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	env.AddFile(name, data)
+	return nil
+}
+
+// AddError adds an error to the response without ending the plugin, so that
+// plugins can report every problem that they find. gnostic reports the
+// errors and ignores the files of responses that contain errors.
+func (env *Environment) AddError(err error) {
+	if err != nil {
+		env.Response.Errors = append(env.Response.Errors, err.Error())
+	}
+}
+
+// AddMessage adds a message to the response.
+func (env *Environment) AddMessage(level Message_Level, code, text string, keys ...string) {
+	env.Response.Messages = append(env.Response.Messages, &Message{
+		Level: level,
+		Code:  code,
+		Text:  text,
+		Keys:  keys,
+	})
+}
@@ -0,0 +1,268 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/jsonwriter"
+)
+
+// baseURLVariable is the collection variable that holds the server address.
+const baseURLVariable = "baseUrl"
+
+// maxSampleDepth limits the nesting of sample bodies built from recursive schemas.
+const maxSampleDepth = 6
+
+var pathTemplateRegex = regexp.MustCompile(`{([^}]+)}`)
+
+// collectionBuilder accumulates the items and variables of a collection,
+// grouping requests into folders named by their first tag.
+type collectionBuilder struct {
+	collection *Collection
+	folders    map[string]*Item
+	variables  map[string]bool
+}
+
+func newCollectionBuilder(name string, description string) *collectionBuilder {
+	return &collectionBuilder{
+		collection: &Collection{
+			Info:  &Info{Name: name, Description: description, Schema: SchemaURL},
+			Items: make([]*Item, 0),
+		},
+		folders:   make(map[string]*Item),
+		variables: make(map[string]bool),
+	}
+}
+
+// addVariable adds a collection variable unless one with the same key already exists.
+func (b *collectionBuilder) addVariable(key string, value string, description string) {
+	if b.variables[key] {
+		return
+	}
+	b.variables[key] = true
+	b.collection.Variables = append(b.collection.Variables,
+		&Variable{Key: key, Value: value, Type: "string", Description: description})
+}
+
+// folder returns the folder with the specified name, creating it if necessary.
+func (b *collectionBuilder) folder(name string) *Item {
+	f, ok := b.folders[name]
+	if !ok {
+		f = &Item{Name: name, Items: make([]*Item, 0)}
+		b.folders[name] = f
+		b.collection.Items = append(b.collection.Items, f)
+	}
+	return f
+}
+
+// addItem adds a request to the folder for its first tag, or to the top level if it has no tags.
+func (b *collectionBuilder) addItem(tags []string, item *Item) {
+	if len(tags) == 0 {
+		b.collection.Items = append(b.collection.Items, item)
+		return
+	}
+	f := b.folder(tags[0])
+	f.Items = append(f.Items, item)
+}
+
+// addAuthVariables adds collection variables for the {{placeholders}} used by an Auth.
+func (b *collectionBuilder) addAuthVariables(auth *Auth) {
+	if auth == nil {
+		return
+	}
+	for _, attributes := range [][]*AuthAttribute{auth.APIKey, auth.Basic, auth.Bearer, auth.OAuth2} {
+		for _, attribute := range attributes {
+			if key := variableName(attribute.Value); key != "" {
+				b.addVariable(key, "", "")
+			}
+		}
+	}
+}
+
+// variableName returns the name of a variable if s is a {{variable}} placeholder.
+func variableName(s string) string {
+	if strings.HasPrefix(s, "{{") && strings.HasSuffix(s, "}}") {
+		return s[2 : len(s)-2]
+	}
+	return ""
+}
+
+// templateURL converts OpenAPI {variable} templates to Postman {{variable}} references.
+func templateURL(url string) string {
+	return pathTemplateRegex.ReplaceAllString(url, "{{$1}}")
+}
+
+// newURL builds a request URL for a templated path relative to the base URL variable.
+func newURL(path string) *URL {
+	u := &URL{Host: []string{"{{" + baseURLVariable + "}}"}, Path: make([]string, 0)}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		if m := pathTemplateRegex.FindStringSubmatch(segment); m != nil && m[0] == segment {
+			segment = ":" + m[1]
+			u.Variables = append(u.Variables, &Variable{Key: m[1]})
+		}
+		u.Path = append(u.Path, segment)
+	}
+	return u
+}
+
+// pathVariable returns the variable for a named path parameter.
+func (u *URL) pathVariable(name string) *Variable {
+	for _, v := range u.Variables {
+		if v.Key == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// finish computes the raw representation of a URL from its parts.
+func (u *URL) finish() {
+	raw := strings.Join(u.Host, ".")
+	if len(u.Path) > 0 {
+		raw += "/" + strings.Join(u.Path, "/")
+	}
+	separator := "?"
+	for _, q := range u.Query {
+		if q.Disabled {
+			continue
+		}
+		raw += separator + q.Key + "=" + q.Value
+		separator = "&"
+	}
+	u.Raw = raw
+}
+
+// placeholder returns a value to use for a parameter when no example is available.
+func placeholder(typeName string) string {
+	if typeName == "" {
+		typeName = "string"
+	}
+	return "<" + typeName + ">"
+}
+
+// exampleString converts a YAML example value to a string suitable for a
+// parameter (scalars) or a raw body (JSON for everything else).
+func exampleString(text string) string {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil || len(node.Content) == 0 {
+		return strings.TrimSpace(text)
+	}
+	if node.Content[0].Kind == yaml.ScalarNode {
+		return node.Content[0].Value
+	}
+	return jsonString(&node)
+}
+
+// exampleValue converts a YAML example value to the scalars, slices, and maps
+// that parameters are encoded from. Scalars keep the text of the example.
+func exampleValue(text string) (interface{}, bool) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil || len(node.Content) == 0 {
+		return nil, false
+	}
+	return nodeValue(node.Content[0]), true
+}
+
+func nodeValue(node *yaml.Node) interface{} {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		values := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			values[i] = nodeValue(item)
+		}
+		return values
+	case yaml.MappingNode:
+		values := make(map[string]interface{})
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			values[node.Content[i].Value] = nodeValue(node.Content[i+1])
+		}
+		return values
+	case yaml.AliasNode:
+		return nodeValue(node.Alias)
+	}
+	return node.Value
+}
+
+// jsonString returns the JSON representation of a YAML node.
+func jsonString(node *yaml.Node) string {
+	if node.Kind != yaml.DocumentNode {
+		node = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}
+	}
+	bytes, err := jsonwriter.Marshal(node)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(bytes))
+}
+
+// jsonBody returns a raw body containing JSON text.
+func jsonBody(text string) *Body {
+	return &Body{
+		Mode:    "raw",
+		Raw:     text,
+		Options: &BodyOptions{Raw: &RawOptions{Language: "json"}},
+	}
+}
+
+func newScalarNode(value string, tag string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+// sampleScalar returns a sample node for a schema type with no example.
+func sampleScalar(typeName string, format string) *yaml.Node {
+	switch typeName {
+	case "integer":
+		return newScalarNode("0", "!!int")
+	case "number":
+		return newScalarNode("0", "!!float")
+	case "boolean":
+		return newScalarNode("true", "!!bool")
+	default:
+		if format != "" {
+			return newScalarNode(placeholder(format), "!!str")
+		}
+		return newScalarNode(placeholder(typeName), "!!str")
+	}
+}
+
+// exampleNode parses a YAML example into a node, returning nil if it can't be read.
+func exampleNode(text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil || len(node.Content) == 0 {
+		return nil
+	}
+	return node.Content[0]
+}
+
+// methodNames are the HTTP methods of operations, in path item order.
+var methodNames = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+// itemName chooses a display name for a request.
+func itemName(summary string, operationID string, method string, path string) string {
+	if summary != "" {
+		return summary
+	}
+	if operationID != "" {
+		return operationID
+	}
+	return method + " " + path
+}
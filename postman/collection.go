@@ -0,0 +1,255 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postman provides a model of Postman collections and conversions
+// between collections and OpenAPI descriptions.
+package postman
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// SchemaURL identifies the version of the collection format written by this package.
+const SchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// A Collection is a Postman collection in the v2.1 collection format.
+// https://schema.postman.com/collection/json/v2.1.0/draft-07/docs/index.html
+type Collection struct {
+	Info      *Info       `json:"info"`
+	Items     []*Item     `json:"item"`
+	Auth      *Auth       `json:"auth,omitempty"`
+	Variables []*Variable `json:"variable,omitempty"`
+}
+
+// Info describes a collection.
+type Info struct {
+	PostmanID   string `json:"_postman_id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// An Item is either a request or a folder containing other items.
+type Item struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Items       []*Item     `json:"item,omitempty"`
+	Request     *Request    `json:"request,omitempty"`
+	Responses   []*Response `json:"response,omitempty"`
+	Auth        *Auth       `json:"auth,omitempty"`
+}
+
+// IsFolder returns true if an item is a folder of other items.
+func (item *Item) IsFolder() bool {
+	return item.Request == nil
+}
+
+// A Request describes an HTTP request.
+type Request struct {
+	Method      string      `json:"method"`
+	Header      []*KeyValue `json:"header"`
+	URL         *URL        `json:"url"`
+	Body        *Body       `json:"body,omitempty"`
+	Auth        *Auth       `json:"auth,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
+
+// A URL is a request URL along with its decomposed parts.
+type URL struct {
+	Raw       string      `json:"raw"`
+	Host      []string    `json:"host,omitempty"`
+	Path      []string    `json:"path,omitempty"`
+	Query     []*KeyValue `json:"query,omitempty"`
+	Variables []*Variable `json:"variable,omitempty"`
+}
+
+// A KeyValue is a header, query parameter, or form field.
+type KeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// A Variable is a named value that can be referenced as {{key}}.
+type Variable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// A Body is the body of a request.
+type Body struct {
+	Mode       string       `json:"mode"`
+	Raw        string       `json:"raw,omitempty"`
+	URLEncoded []*KeyValue  `json:"urlencoded,omitempty"`
+	FormData   []*KeyValue  `json:"formdata,omitempty"`
+	Options    *BodyOptions `json:"options,omitempty"`
+}
+
+// BodyOptions describe how a raw body should be presented.
+type BodyOptions struct {
+	Raw *RawOptions `json:"raw,omitempty"`
+}
+
+// RawOptions describe the language of a raw body.
+type RawOptions struct {
+	Language string `json:"language"`
+}
+
+// A Response is a saved example response to a request.
+type Response struct {
+	Name            string      `json:"name"`
+	OriginalRequest *Request    `json:"originalRequest,omitempty"`
+	Status          string      `json:"status,omitempty"`
+	Code            int         `json:"code,omitempty"`
+	Header          []*KeyValue `json:"header,omitempty"`
+	Body            string      `json:"body,omitempty"`
+}
+
+// Auth describes the authentication used by a request, folder, or collection.
+// The Type field names the field that contains the attributes of the
+// selected authentication method.
+type Auth struct {
+	Type   string           `json:"type"`
+	APIKey []*AuthAttribute `json:"apikey,omitempty"`
+	Basic  []*AuthAttribute `json:"basic,omitempty"`
+	Bearer []*AuthAttribute `json:"bearer,omitempty"`
+	OAuth2 []*AuthAttribute `json:"oauth2,omitempty"`
+}
+
+// An AuthAttribute is a setting of an authentication method.
+type AuthAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// Attribute returns the value of a named authentication attribute.
+func (a *Auth) Attribute(key string) string {
+	if a == nil {
+		return ""
+	}
+	for _, attributes := range [][]*AuthAttribute{a.APIKey, a.Basic, a.Bearer, a.OAuth2} {
+		for _, attribute := range attributes {
+			if attribute.Key == key {
+				return attribute.Value
+			}
+		}
+	}
+	return ""
+}
+
+// Marshal returns the JSON representation of a collection.
+func (c *Collection) Marshal() ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	// Placeholders like <string> are common in collections, so don't escape them.
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(c)
+	return b.Bytes(), err
+}
+
+// ParseCollection reads a collection from its JSON representation.
+func ParseCollection(b []byte) (*Collection, error) {
+	var value interface{}
+	err := json.Unmarshal(b, &value)
+	if err != nil {
+		return nil, err
+	}
+	b, err = json.Marshal(normalized(value))
+	if err != nil {
+		return nil, err
+	}
+	var c Collection
+	err = json.Unmarshal(b, &c)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// normalized rewrites the alternative forms that the collection format
+// allows for some fields into the forms modeled by this package:
+// descriptions can be objects with content, requests and URLs can be
+// strings, authentication attributes can be objects (as in v2.0), and
+// values can be numbers, booleans, or structures.
+func normalized(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			switch c := child.(type) {
+			case string:
+				if key == "request" {
+					v[key] = map[string]interface{}{"method": "GET", "url": map[string]interface{}{"raw": c}}
+				} else if key == "url" {
+					v[key] = map[string]interface{}{"raw": c}
+				}
+			case map[string]interface{}:
+				if key == "description" {
+					v[key], _ = c["content"].(string)
+				} else if authAttributeKeys[key] {
+					v[key] = authAttributes(c)
+				} else if key == "value" {
+					v[key] = jsonText(c)
+				} else {
+					v[key] = normalized(c)
+				}
+			case []interface{}:
+				if key == "value" {
+					v[key] = jsonText(c)
+				} else {
+					v[key] = normalized(c)
+				}
+			case bool, float64:
+				if key == "value" {
+					v[key] = jsonText(c)
+				}
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = normalized(v[i])
+		}
+	}
+	return value
+}
+
+// authAttributeKeys are the keys of the authentication attributes in an Auth.
+var authAttributeKeys = map[string]bool{"apikey": true, "basic": true, "bearer": true, "oauth2": true}
+
+// authAttributes converts an object of authentication attributes to a list.
+func authAttributes(attributes map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	list := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, map[string]interface{}{"key": key, "value": attributes[key]})
+	}
+	return normalized(list).([]interface{})
+}
+
+// jsonText returns the JSON representation of a value.
+func jsonText(value interface{}) string {
+	b, _ := json.Marshal(value)
+	return string(b)
+}
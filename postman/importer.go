@@ -0,0 +1,588 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stoewer/go-strcase"
+	"gopkg.in/yaml.v3"
+
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+var variableReferenceRegex = regexp.MustCompile(`{{([^{}]+)}}`)
+
+type openAPI3Importer struct {
+	document     *openapiv3.Document
+	variables    map[string]string // the values of collection variables
+	paths        map[string]*openapiv3.PathItem
+	servers      map[string]bool
+	tags         map[string]bool
+	schemes      map[string]bool
+	operationIDs map[string]bool
+}
+
+// NewOpenAPIv3FromCollection builds an OpenAPI v3 document with an operation
+// for each request in a Postman collection. Folders become tags, the
+// addresses of requests become servers (with collection variables replaced
+// by their values or, if they have none, by server variables), and Postman
+// authentication settings become security schemes. Schemas of bodies are
+// inferred from the JSON of requests and saved responses. When several
+// requests have the same method and path, the first one is used.
+func NewOpenAPIv3FromCollection(collection *Collection) (*openapiv3.Document, error) {
+	if collection == nil || collection.Info == nil {
+		return nil, errors.New("no collection to import")
+	}
+	i := &openAPI3Importer{
+		document: &openapiv3.Document{
+			Openapi: "3.0.3",
+			Info: &openapiv3.Info{
+				Title:       collection.Info.Name,
+				Description: collection.Info.Description,
+				Version:     "1.0.0",
+			},
+			Paths: &openapiv3.Paths{},
+		},
+		variables:    make(map[string]string),
+		paths:        make(map[string]*openapiv3.PathItem),
+		servers:      make(map[string]bool),
+		tags:         make(map[string]bool),
+		schemes:      make(map[string]bool),
+		operationIDs: make(map[string]bool),
+	}
+	if i.document.Info.Title == "" {
+		i.document.Info.Title = "Postman collection"
+	}
+	for _, variable := range collection.Variables {
+		i.variables[variable.Key] = variable.Value
+	}
+	i.importItems(collection.Items, "", nil)
+	if len(i.document.Servers) == 0 {
+		i.document.Servers = []*openapiv3.Server{{Url: "/"}}
+	}
+	if requirement := i.securityRequirement(collection.Auth); requirement != nil {
+		i.document.Security = []*openapiv3.SecurityRequirement{requirement}
+	}
+	return i.document, nil
+}
+
+// importItems imports the requests in a list of items. Folders become tags
+// and their authentication settings apply to the requests that they contain.
+func (i *openAPI3Importer) importItems(items []*Item, tag string, auth *Auth) {
+	for _, item := range items {
+		if item.IsFolder() {
+			if !i.tags[item.Name] {
+				i.tags[item.Name] = true
+				i.document.Tags = append(i.document.Tags, &openapiv3.Tag{Name: item.Name, Description: item.Description})
+			}
+			i.importItems(item.Items, item.Name, inheritedAuth(item.Auth, auth))
+			continue
+		}
+		i.importRequest(item, tag, inheritedAuth(item.Request.Auth, inheritedAuth(item.Auth, auth)))
+	}
+}
+
+// inheritedAuth returns the authentication settings that apply to an item.
+func inheritedAuth(auth *Auth, parent *Auth) *Auth {
+	if auth == nil || auth.Type == "inherit" {
+		return parent
+	}
+	return auth
+}
+
+func (i *openAPI3Importer) importRequest(item *Item, tag string, auth *Auth) {
+	request := item.Request
+	server, path, query := i.splitURL(request.URL)
+	i.addServer(server)
+	pathItem, ok := i.paths[path]
+	if !ok {
+		pathItem = &openapiv3.PathItem{}
+		i.paths[path] = pathItem
+		i.document.Paths.Path = append(i.document.Paths.Path, &openapiv3.NamedPathItem{Name: path, Value: pathItem})
+	}
+	method := strings.ToUpper(request.Method)
+	if method == "" {
+		method = "GET"
+	}
+	slot := operationForMethod(pathItem, method)
+	if slot == nil || *slot != nil {
+		return
+	}
+	operation := &openapiv3.Operation{
+		Summary:     item.Name,
+		Description: request.Description,
+		OperationId: i.operationID(item.Name, method, path),
+		Responses:   i.responses(item.Responses),
+	}
+	if operation.Description == "" {
+		operation.Description = item.Description
+	}
+	if tag != "" {
+		operation.Tags = []string{tag}
+	}
+	for _, name := range pathParameterNames(path) {
+		parameter := &openapiv3.Parameter{Name: name, In: "path", Required: true}
+		value := i.variables[name]
+		if request.URL != nil {
+			if v := request.URL.pathVariable(name); v != nil {
+				parameter.Description = v.Description
+				value = v.Value
+			}
+		}
+		i.setParameterSchema(parameter, value)
+		operation.Parameters = append(operation.Parameters, parameterOrReference(parameter))
+	}
+	for _, q := range query {
+		parameter := &openapiv3.Parameter{Name: q.Key, In: "query", Description: q.Description}
+		i.setParameterSchema(parameter, q.Value)
+		operation.Parameters = append(operation.Parameters, parameterOrReference(parameter))
+	}
+	for _, header := range request.Header {
+		switch strings.ToLower(header.Key) {
+		case "accept", "content-type", "authorization":
+			continue
+		}
+		parameter := &openapiv3.Parameter{Name: header.Key, In: "header", Description: header.Description}
+		i.setParameterSchema(parameter, header.Value)
+		operation.Parameters = append(operation.Parameters, parameterOrReference(parameter))
+	}
+	operation.RequestBody = i.requestBody(request)
+	if requirement := i.securityRequirement(auth); requirement != nil {
+		operation.Security = []*openapiv3.SecurityRequirement{requirement}
+	}
+	*slot = operation
+}
+
+// operationForMethod returns the field of a path item that holds the operation for a method.
+func operationForMethod(pathItem *openapiv3.PathItem, method string) **openapiv3.Operation {
+	switch method {
+	case "GET":
+		return &pathItem.Get
+	case "PUT":
+		return &pathItem.Put
+	case "POST":
+		return &pathItem.Post
+	case "DELETE":
+		return &pathItem.Delete
+	case "OPTIONS":
+		return &pathItem.Options
+	case "HEAD":
+		return &pathItem.Head
+	case "PATCH":
+		return &pathItem.Patch
+	case "TRACE":
+		return &pathItem.Trace
+	}
+	return nil
+}
+
+// operationID returns a unique operation ID for a request.
+func (i *openAPI3Importer) operationID(name string, method string, path string) string {
+	id := strcase.LowerCamelCase(name)
+	if id == "" {
+		id = strcase.LowerCamelCase(method + " " + pathTemplateRegex.ReplaceAllString(path, "by $1"))
+	}
+	unique := id
+	for n := 2; i.operationIDs[unique]; n++ {
+		unique = id + strconv.Itoa(n)
+	}
+	i.operationIDs[unique] = true
+	return unique
+}
+
+// splitURL splits a request URL into a server address, a templated path, and query parameters.
+func (i *openAPI3Importer) splitURL(u *URL) (string, string, []*KeyValue) {
+	if u == nil {
+		return "", "/", nil
+	}
+	raw := u.Raw
+	if raw == "" {
+		raw = strings.Join(u.Host, ".")
+		if len(u.Path) > 0 {
+			raw += "/" + strings.Join(u.Path, "/")
+		}
+	}
+	query := u.Query
+	if n := strings.IndexAny(raw, "?#"); n >= 0 {
+		if len(query) == 0 && raw[n] == '?' {
+			query = parseQuery(strings.SplitN(raw[n+1:], "#", 2)[0])
+		}
+		raw = raw[:n]
+	}
+	scheme := ""
+	if n := strings.Index(raw, "://"); n >= 0 {
+		scheme, raw = raw[:n+3], raw[n+3:]
+	}
+	server, path := raw, ""
+	if n := strings.Index(raw, "/"); n >= 0 {
+		server, path = raw[:n], raw[n:]
+	}
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, ":") {
+			segment = "{" + segment[1:] + "}"
+		} else {
+			segment = variableReferenceRegex.ReplaceAllString(segment, "{$1}")
+		}
+		segments = append(segments, segment)
+	}
+	if server != "" {
+		server = scheme + server
+	}
+	return server, "/" + strings.Join(segments, "/"), query
+}
+
+// parseQuery reads the parameters of a query string.
+func parseQuery(query string) []*KeyValue {
+	parameters := make([]*KeyValue, 0)
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		keyValue := strings.SplitN(pair, "=", 2)
+		parameter := &KeyValue{Key: keyValue[0]}
+		if len(keyValue) == 2 {
+			parameter.Value = keyValue[1]
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// pathParameterNames returns the names of the parameters of a templated path.
+func pathParameterNames(path string) []string {
+	names := make([]string, 0)
+	for _, m := range pathTemplateRegex.FindAllStringSubmatch(path, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// addServer adds a server for an address. Collection variables in the
+// address are replaced by their values or, if they have none, by server
+// variables.
+func (i *openAPI3Importer) addServer(address string) {
+	if address == "" {
+		return
+	}
+	address = strings.TrimSuffix(i.substitute(address), "/")
+	if i.servers[address] {
+		return
+	}
+	i.servers[address] = true
+	server := &openapiv3.Server{Url: variableReferenceRegex.ReplaceAllString(address, "{$1}")}
+	for _, m := range variableReferenceRegex.FindAllStringSubmatch(address, -1) {
+		if server.Variables == nil {
+			server.Variables = &openapiv3.ServerVariables{}
+		}
+		server.Variables.AdditionalProperties = append(server.Variables.AdditionalProperties,
+			&openapiv3.NamedServerVariable{Name: m[1], Value: &openapiv3.ServerVariable{Default: ""}})
+	}
+	i.document.Servers = append(i.document.Servers, server)
+}
+
+// substitute replaces references to collection variables that have values with those values.
+func (i *openAPI3Importer) substitute(text string) string {
+	return variableReferenceRegex.ReplaceAllStringFunc(text, func(reference string) string {
+		if value := i.variables[variableName(reference)]; value != "" && !variableReferenceRegex.MatchString(value) {
+			return value
+		}
+		return reference
+	})
+}
+
+// setParameterSchema sets the schema and example of a parameter from a sample value.
+func (i *openAPI3Importer) setParameterSchema(parameter *openapiv3.Parameter, value string) {
+	value = i.substitute(value)
+	if value == "" || variableReferenceRegex.MatchString(value) || (strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">")) {
+		parameter.Schema = schemaOrReference(&openapiv3.Schema{Type: "string"})
+		return
+	}
+	node := newScalarNode(value, "!!str")
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		node.Tag = "!!int"
+	} else if _, err := strconv.ParseFloat(value, 64); err == nil {
+		node.Tag = "!!float"
+	} else if value == "true" || value == "false" {
+		node.Tag = "!!bool"
+	}
+	parameter.Schema = schemaOrReference(schemaForExample(node))
+	parameter.Example = anyForNode(node)
+}
+
+// requestBody returns the request body of a request.
+func (i *openAPI3Importer) requestBody(request *Request) *openapiv3.RequestBodyOrReference {
+	body := request.Body
+	if body == nil {
+		return nil
+	}
+	var contentType string
+	var mediaType *openapiv3.MediaType
+	switch body.Mode {
+	case "raw":
+		if body.Raw == "" {
+			return nil
+		}
+		contentType = headerValue(request.Header, "Content-Type")
+		if contentType == "" && body.Options != nil && body.Options.Raw != nil {
+			contentType = rawLanguageContentTypes[body.Options.Raw.Language]
+		}
+		contentType, mediaType = i.mediaType(contentType, body.Raw)
+	case "urlencoded":
+		contentType, mediaType = "application/x-www-form-urlencoded", formMediaType(body.URLEncoded)
+	case "formdata":
+		contentType, mediaType = "multipart/form-data", formMediaType(body.FormData)
+	case "file":
+		contentType = "application/octet-stream"
+		mediaType = &openapiv3.MediaType{Schema: schemaOrReference(&openapiv3.Schema{Type: "string", Format: "binary"})}
+	default:
+		return nil
+	}
+	return &openapiv3.RequestBodyOrReference{
+		Oneof: &openapiv3.RequestBodyOrReference_RequestBody{
+			RequestBody: &openapiv3.RequestBody{
+				Content: &openapiv3.MediaTypes{
+					AdditionalProperties: []*openapiv3.NamedMediaType{{Name: contentType, Value: mediaType}},
+				},
+			},
+		},
+	}
+}
+
+// rawLanguageContentTypes are the content types of the languages of raw bodies.
+var rawLanguageContentTypes = map[string]string{
+	"json":       "application/json",
+	"xml":        "application/xml",
+	"html":       "text/html",
+	"javascript": "application/javascript",
+	"text":       "text/plain",
+}
+
+// headerValue returns the value of a header.
+func headerValue(headers []*KeyValue, key string) string {
+	for _, header := range headers {
+		if strings.EqualFold(header.Key, key) && !header.Disabled {
+			return strings.TrimSpace(strings.SplitN(header.Value, ";", 2)[0])
+		}
+	}
+	return ""
+}
+
+// mediaType returns a media type for a sample body. JSON bodies get
+// schemas that are inferred from them. If no content type is specified,
+// bodies are assumed to be JSON if they can be read as JSON.
+func (i *openAPI3Importer) mediaType(contentType string, text string) (string, *openapiv3.MediaType) {
+	text = i.substitute(text)
+	if contentType == "" || isJSONMediaType(contentType) {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(text), &node); err == nil && len(node.Content) > 0 &&
+			(node.Content[0].Kind == yaml.MappingNode || node.Content[0].Kind == yaml.SequenceNode) {
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			return contentType, &openapiv3.MediaType{
+				Schema:  schemaOrReference(schemaForExample(node.Content[0])),
+				Example: anyForNode(node.Content[0]),
+			}
+		}
+	}
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	return contentType, &openapiv3.MediaType{
+		Schema:  schemaOrReference(&openapiv3.Schema{Type: "string"}),
+		Example: anyForNode(newScalarNode(text, "!!str")),
+	}
+}
+
+// formMediaType returns a media type for the fields of a form.
+func formMediaType(fields []*KeyValue) *openapiv3.MediaType {
+	schema := &openapiv3.Schema{Type: "object", Properties: &openapiv3.Properties{}}
+	for _, field := range fields {
+		property := &openapiv3.Schema{Type: "string", Description: field.Description}
+		if field.Type == "file" {
+			property.Format = "binary"
+		}
+		schema.Properties.AdditionalProperties = append(schema.Properties.AdditionalProperties,
+			&openapiv3.NamedSchemaOrReference{Name: field.Key, Value: schemaOrReference(property)})
+	}
+	return &openapiv3.MediaType{Schema: schemaOrReference(schema)}
+}
+
+// responses returns the responses of an operation from the saved responses of a request.
+func (i *openAPI3Importer) responses(saved []*Response) *openapiv3.Responses {
+	responses := &openapiv3.Responses{}
+	codes := make(map[string]bool)
+	for _, s := range saved {
+		code := "default"
+		if s.Code != 0 {
+			code = strconv.Itoa(s.Code)
+		}
+		if codes[code] {
+			continue
+		}
+		codes[code] = true
+		response := &openapiv3.Response{Description: s.Name}
+		if response.Description == "" {
+			response.Description = s.Status
+		}
+		if s.Body != "" {
+			contentType, mediaType := i.mediaType(headerValue(s.Header, "Content-Type"), s.Body)
+			response.Content = &openapiv3.MediaTypes{
+				AdditionalProperties: []*openapiv3.NamedMediaType{{Name: contentType, Value: mediaType}},
+			}
+		}
+		responses.ResponseOrReference = append(responses.ResponseOrReference, namedResponse(code, response))
+	}
+	if len(responses.ResponseOrReference) == 0 {
+		responses.ResponseOrReference = append(responses.ResponseOrReference, namedResponse("200", &openapiv3.Response{Description: "OK"}))
+	}
+	return responses
+}
+
+func namedResponse(code string, response *openapiv3.Response) *openapiv3.NamedResponseOrReference {
+	return &openapiv3.NamedResponseOrReference{
+		Name:  code,
+		Value: &openapiv3.ResponseOrReference{Oneof: &openapiv3.ResponseOrReference_Response{Response: response}},
+	}
+}
+
+// schemaForExample infers a schema from an example value.
+func schemaForExample(node *yaml.Node) *openapiv3.Schema {
+	switch node.Kind {
+	case yaml.MappingNode:
+		schema := &openapiv3.Schema{Type: "object", Properties: &openapiv3.Properties{}}
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			schema.Properties.AdditionalProperties = append(schema.Properties.AdditionalProperties,
+				&openapiv3.NamedSchemaOrReference{
+					Name:  node.Content[j].Value,
+					Value: schemaOrReference(schemaForExample(node.Content[j+1])),
+				})
+		}
+		return schema
+	case yaml.SequenceNode:
+		items := &openapiv3.Schema{}
+		if len(node.Content) > 0 {
+			items = schemaForExample(node.Content[0])
+		}
+		return &openapiv3.Schema{
+			Type:  "array",
+			Items: &openapiv3.ItemsItem{SchemaOrReference: []*openapiv3.SchemaOrReference{schemaOrReference(items)}},
+		}
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!int":
+			return &openapiv3.Schema{Type: "integer"}
+		case "!!float":
+			return &openapiv3.Schema{Type: "number"}
+		case "!!bool":
+			return &openapiv3.Schema{Type: "boolean"}
+		case "!!null":
+			return &openapiv3.Schema{Nullable: true}
+		}
+	}
+	return &openapiv3.Schema{Type: "string"}
+}
+
+func schemaOrReference(schema *openapiv3.Schema) *openapiv3.SchemaOrReference {
+	return &openapiv3.SchemaOrReference{Oneof: &openapiv3.SchemaOrReference_Schema{Schema: schema}}
+}
+
+func parameterOrReference(parameter *openapiv3.Parameter) *openapiv3.ParameterOrReference {
+	return &openapiv3.ParameterOrReference{Oneof: &openapiv3.ParameterOrReference_Parameter{Parameter: parameter}}
+}
+
+// anyForNode returns an Any that holds a YAML node.
+func anyForNode(node *yaml.Node) *openapiv3.Any {
+	bytes, err := yaml.Marshal(node)
+	if err != nil {
+		return nil
+	}
+	return &openapiv3.Any{Yaml: string(bytes)}
+}
+
+// securityRequirement returns a requirement of the security scheme for
+// Postman authentication settings, adding the scheme to the document.
+func (i *openAPI3Importer) securityRequirement(auth *Auth) *openapiv3.SecurityRequirement {
+	if auth == nil {
+		return nil
+	}
+	var name string
+	var scheme *openapiv3.SecurityScheme
+	switch auth.Type {
+	case "apikey":
+		in := auth.Attribute("in")
+		if in == "" {
+			in = "header"
+		}
+		name = "apiKey"
+		scheme = &openapiv3.SecurityScheme{Type: "apiKey", Name: auth.Attribute("key"), In: in}
+		if scheme.Name == "" {
+			scheme.Name = "X-API-Key"
+		}
+	case "basic":
+		name = "basicAuth"
+		scheme = &openapiv3.SecurityScheme{Type: "http", Scheme: "basic"}
+	case "bearer":
+		name = "bearerAuth"
+		scheme = &openapiv3.SecurityScheme{Type: "http", Scheme: "bearer"}
+	case "oauth2":
+		authorizationURL, tokenURL := auth.Attribute("authUrl"), auth.Attribute("accessTokenUrl")
+		flow := &openapiv3.OauthFlow{AuthorizationUrl: authorizationURL, TokenUrl: tokenURL, Scopes: &openapiv3.Strings{}}
+		flows := &openapiv3.OauthFlows{}
+		switch {
+		case authorizationURL != "" && tokenURL != "":
+			flows.AuthorizationCode = flow
+		case tokenURL != "":
+			flows.ClientCredentials = flow
+		case authorizationURL != "":
+			flows.Implicit = flow
+		default:
+			// Without URLs, the token is sent as a bearer token.
+			name = "bearerAuth"
+			scheme = &openapiv3.SecurityScheme{Type: "http", Scheme: "bearer"}
+		}
+		if scheme == nil {
+			name = "oauth2"
+			scheme = &openapiv3.SecurityScheme{Type: "oauth2", Flows: flows}
+		}
+	default:
+		return nil
+	}
+	if !i.schemes[name] {
+		i.schemes[name] = true
+		if i.document.Components == nil {
+			i.document.Components = &openapiv3.Components{}
+		}
+		if i.document.Components.SecuritySchemes == nil {
+			i.document.Components.SecuritySchemes = &openapiv3.SecuritySchemesOrReferences{}
+		}
+		i.document.Components.SecuritySchemes.AdditionalProperties = append(i.document.Components.SecuritySchemes.AdditionalProperties,
+			&openapiv3.NamedSecuritySchemeOrReference{
+				Name:  name,
+				Value: &openapiv3.SecuritySchemeOrReference{Oneof: &openapiv3.SecuritySchemeOrReference_SecurityScheme{SecurityScheme: scheme}},
+			})
+	}
+	return &openapiv3.SecurityRequirement{
+		AdditionalProperties: []*openapiv3.NamedStringArray{{Name: name, Value: &openapiv3.StringArray{}}},
+	}
+}
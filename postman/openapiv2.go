@@ -0,0 +1,404 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+)
+
+type openAPI2Exporter struct {
+	document *openapiv2.Document
+	builder  *collectionBuilder
+}
+
+// simpleParameter holds the fields shared by all OpenAPI v2 non-body parameters.
+type simpleParameter struct {
+	name        string
+	in          string
+	description string
+	required    bool
+	typeName    string
+	format      string
+	defaultAny  *openapiv2.Any
+	enum        []*openapiv2.Any
+}
+
+// NewCollectionFromOpenAPIv2 builds a Postman collection with a request for
+// each operation in an OpenAPI v2 document. Requests are grouped in folders
+// by tag, the host and base path are stored in the baseUrl collection
+// variable, and security requirements are mapped to Postman authentication
+// settings.
+func NewCollectionFromOpenAPIv2(document *openapiv2.Document) (*Collection, error) {
+	e := &openAPI2Exporter{document: document}
+	name, description := "", ""
+	if document.Info != nil {
+		name, description = document.Info.Title, document.Info.Description
+	}
+	e.builder = newCollectionBuilder(name, description)
+	e.builder.addVariable(baseURLVariable, e.baseURL(), "")
+	for _, tag := range document.Tags {
+		e.builder.folder(tag.Name).Description = tag.Description
+	}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			e.buildPathItem(pair.Name, pair.Value)
+		}
+	}
+	// Remove folders for tags that have no operations.
+	items := make([]*Item, 0, len(e.builder.collection.Items))
+	for _, item := range e.builder.collection.Items {
+		if !item.IsFolder() || len(item.Items) > 0 {
+			items = append(items, item)
+		}
+	}
+	e.builder.collection.Items = items
+	if auth := e.authForRequirements(document.Security); auth != nil {
+		e.builder.collection.Auth = auth
+		e.builder.addAuthVariables(auth)
+	}
+	return e.builder.collection, nil
+}
+
+func (e *openAPI2Exporter) baseURL() string {
+	basePath := strings.TrimSuffix(e.document.BasePath, "/")
+	if e.document.Host == "" {
+		if basePath == "" {
+			return "/"
+		}
+		return basePath
+	}
+	scheme := "https"
+	if len(e.document.Schemes) > 0 {
+		scheme = e.document.Schemes[0]
+	}
+	return scheme + "://" + e.document.Host + basePath
+}
+
+func (e *openAPI2Exporter) buildPathItem(path string, pathItem *openapiv2.PathItem) {
+	operations := []*openapiv2.Operation{
+		pathItem.Get, pathItem.Put, pathItem.Post, pathItem.Delete,
+		pathItem.Options, pathItem.Head, pathItem.Patch,
+	}
+	for i, operation := range operations {
+		if operation == nil {
+			continue
+		}
+		method := methodNames[i]
+		item := &Item{
+			Name:        itemName(operation.Summary, operation.OperationId, method, path),
+			Description: operation.Description,
+			Request:     e.buildRequest(path, method, pathItem, operation),
+		}
+		e.builder.addItem(operation.Tags, item)
+	}
+}
+
+func (e *openAPI2Exporter) buildRequest(path string, method string, pathItem *openapiv2.PathItem, operation *openapiv2.Operation) *Request {
+	request := &Request{
+		Method:      method,
+		Header:      make([]*KeyValue, 0),
+		URL:         newURL(path),
+		Description: operation.Description,
+	}
+	consumes := operation.Consumes
+	if len(consumes) == 0 {
+		consumes = e.document.Consumes
+	}
+	contentType := ""
+	if len(consumes) > 0 {
+		contentType = consumes[0]
+	}
+	formFields := make([]*KeyValue, 0)
+	for _, parameter := range e.parameters(pathItem, operation) {
+		if body := parameter.GetBodyParameter(); body != nil {
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			request.Body = jsonBody(jsonString(e.sample(body.Schema, 0)))
+			continue
+		}
+		p := simpleParameterFor(parameter.GetNonBodyParameter())
+		if p == nil {
+			continue
+		}
+		value := p.value()
+		switch p.in {
+		case "path":
+			if v := request.URL.pathVariable(p.name); v != nil {
+				v.Value = value
+				v.Description = p.description
+			}
+		case "query":
+			request.URL.Query = append(request.URL.Query, &KeyValue{
+				Key:         p.name,
+				Value:       value,
+				Description: p.description,
+				Disabled:    !p.required,
+			})
+		case "header":
+			request.Header = append(request.Header, &KeyValue{
+				Key:         p.name,
+				Value:       value,
+				Description: p.description,
+				Disabled:    !p.required,
+			})
+		case "formData":
+			field := &KeyValue{Key: p.name, Value: value, Type: "text", Description: p.description}
+			if p.typeName == "file" {
+				field.Type = "file"
+				field.Value = ""
+			}
+			formFields = append(formFields, field)
+		}
+	}
+	if len(formFields) > 0 {
+		if strings.HasPrefix(contentType, "multipart/") {
+			request.Body = &Body{Mode: "formdata", FormData: formFields}
+		} else {
+			contentType = "application/x-www-form-urlencoded"
+			request.Body = &Body{Mode: "urlencoded", URLEncoded: formFields}
+		}
+	}
+	if request.Body != nil && contentType != "" {
+		request.Header = append(request.Header, &KeyValue{Key: "Content-Type", Value: contentType})
+	}
+	produces := operation.Produces
+	if len(produces) == 0 {
+		produces = e.document.Produces
+	}
+	if len(produces) > 0 {
+		request.Header = append(request.Header, &KeyValue{Key: "Accept", Value: produces[0]})
+	}
+	if auth := e.authForRequirements(operation.Security); auth != nil {
+		request.Auth = auth
+		e.builder.addAuthVariables(auth)
+	}
+	request.URL.finish()
+	return request
+}
+
+// parameters returns the parameters of an operation, including those inherited
+// from its path item that the operation doesn't override.
+func (e *openAPI2Exporter) parameters(pathItem *openapiv2.PathItem, operation *openapiv2.Operation) []*openapiv2.Parameter {
+	parameters := make([]*openapiv2.Parameter, 0)
+	for _, p := range operation.Parameters {
+		if parameter := e.parameter(p); parameter != nil {
+			parameters = append(parameters, parameter)
+		}
+	}
+	for _, p := range pathItem.Parameters {
+		parameter := e.parameter(p)
+		if parameter == nil {
+			continue
+		}
+		name, in := parameterNameAndLocation(parameter)
+		overridden := false
+		for _, other := range parameters {
+			otherName, otherIn := parameterNameAndLocation(other)
+			if otherName == name && otherIn == in {
+				overridden = true
+			}
+		}
+		if !overridden {
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters
+}
+
+// parameter returns a parameter, following local references to parameter definitions.
+func (e *openAPI2Exporter) parameter(item *openapiv2.ParametersItem) *openapiv2.Parameter {
+	if parameter := item.GetParameter(); parameter != nil {
+		return parameter
+	}
+	reference := item.GetJsonReference()
+	const prefix = "#/parameters/"
+	if reference == nil || !strings.HasPrefix(reference.XRef, prefix) || e.document.Parameters == nil {
+		return nil
+	}
+	name := reference.XRef[len(prefix):]
+	for _, pair := range e.document.Parameters.AdditionalProperties {
+		if pair.Name == name {
+			return pair.Value
+		}
+	}
+	return nil
+}
+
+func parameterNameAndLocation(parameter *openapiv2.Parameter) (string, string) {
+	if body := parameter.GetBodyParameter(); body != nil {
+		return body.Name, body.In
+	}
+	if p := simpleParameterFor(parameter.GetNonBodyParameter()); p != nil {
+		return p.name, p.in
+	}
+	return "", ""
+}
+
+func simpleParameterFor(parameter *openapiv2.NonBodyParameter) *simpleParameter {
+	if p := parameter.GetHeaderParameterSubSchema(); p != nil {
+		return &simpleParameter{p.Name, p.In, p.Description, p.Required, p.Type, p.Format, p.Default, p.Enum}
+	}
+	if p := parameter.GetFormDataParameterSubSchema(); p != nil {
+		return &simpleParameter{p.Name, p.In, p.Description, p.Required, p.Type, p.Format, p.Default, p.Enum}
+	}
+	if p := parameter.GetQueryParameterSubSchema(); p != nil {
+		return &simpleParameter{p.Name, p.In, p.Description, p.Required, p.Type, p.Format, p.Default, p.Enum}
+	}
+	if p := parameter.GetPathParameterSubSchema(); p != nil {
+		return &simpleParameter{p.Name, p.In, p.Description, p.Required, p.Type, p.Format, p.Default, p.Enum}
+	}
+	return nil
+}
+
+// value returns an example value for a parameter.
+func (p *simpleParameter) value() string {
+	if p.defaultAny != nil {
+		return exampleString(p.defaultAny.Yaml)
+	}
+	if len(p.enum) > 0 {
+		return exampleString(p.enum[0].Yaml)
+	}
+	return placeholder(p.typeName)
+}
+
+// schema returns a schema, following local references to definitions.
+func (e *openAPI2Exporter) schema(schema *openapiv2.Schema) *openapiv2.Schema {
+	const prefix = "#/definitions/"
+	if schema == nil || !strings.HasPrefix(schema.XRef, prefix) {
+		return schema
+	}
+	if e.document.Definitions == nil {
+		return nil
+	}
+	name := schema.XRef[len(prefix):]
+	for _, pair := range e.document.Definitions.AdditionalProperties {
+		if pair.Name == name {
+			return e.schema(pair.Value)
+		}
+	}
+	return nil
+}
+
+// sample builds an example value for a schema, using examples where they are available.
+func (e *openAPI2Exporter) sample(schema *openapiv2.Schema, depth int) *yaml.Node {
+	schema = e.schema(schema)
+	if schema == nil {
+		return compiler.NewNullNode()
+	}
+	for _, example := range []*openapiv2.Any{schema.Example, schema.Default} {
+		if example != nil {
+			if node := exampleNode(example.Yaml); node != nil {
+				return node
+			}
+		}
+	}
+	if len(schema.Enum) > 0 {
+		if node := exampleNode(schema.Enum[0].Yaml); node != nil {
+			return node
+		}
+	}
+	if depth > maxSampleDepth {
+		return compiler.NewNullNode()
+	}
+	if len(schema.AllOf) > 0 {
+		merged := compiler.NewMappingNode()
+		for _, member := range schema.AllOf {
+			node := e.sample(member, depth+1)
+			if node.Kind == yaml.MappingNode {
+				merged.Content = append(merged.Content, node.Content...)
+			}
+		}
+		return merged
+	}
+	typeName := ""
+	if schema.Type != nil && len(schema.Type.Value) > 0 {
+		typeName = schema.Type.Value[0]
+	}
+	switch typeName {
+	case "object", "":
+		node := compiler.NewMappingNode()
+		if schema.Properties != nil {
+			for _, pair := range schema.Properties.AdditionalProperties {
+				if property := e.schema(pair.Value); property != nil && !property.ReadOnly {
+					node.Content = append(node.Content,
+						compiler.NewScalarNodeForString(pair.Name),
+						e.sample(property, depth+1))
+				}
+			}
+		}
+		return node
+	case "array":
+		node := compiler.NewSequenceNode()
+		if schema.Items != nil && len(schema.Items.Schema) > 0 {
+			node.Content = append(node.Content, e.sample(schema.Items.Schema[0], depth+1))
+		}
+		return node
+	default:
+		return sampleScalar(typeName, schema.Format)
+	}
+}
+
+// authForRequirements returns the authentication settings for the first
+// scheme of the first security requirement that can be represented in Postman.
+func (e *openAPI2Exporter) authForRequirements(requirements []*openapiv2.SecurityRequirement) *Auth {
+	if e.document.SecurityDefinitions == nil {
+		return nil
+	}
+	for _, requirement := range requirements {
+		for _, pair := range requirement.AdditionalProperties {
+			for _, definition := range e.document.SecurityDefinitions.AdditionalProperties {
+				if definition.Name != pair.Name {
+					continue
+				}
+				if auth := authForSecurityDefinition(definition.Value); auth != nil {
+					return auth
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func authForSecurityDefinition(definition *openapiv2.SecurityDefinitionsItem) *Auth {
+	switch t := definition.Oneof.(type) {
+	case *openapiv2.SecurityDefinitionsItem_ApiKeySecurity:
+		return apiKeyAuth(t.ApiKeySecurity.Name, t.ApiKeySecurity.In)
+	case *openapiv2.SecurityDefinitionsItem_BasicAuthenticationSecurity:
+		return basicAuth()
+	case *openapiv2.SecurityDefinitionsItem_Oauth2ImplicitSecurity:
+		auth := oauth2Auth()
+		addOAuth2FlowAttributes(auth, t.Oauth2ImplicitSecurity.AuthorizationUrl, "")
+		return auth
+	case *openapiv2.SecurityDefinitionsItem_Oauth2PasswordSecurity:
+		auth := oauth2Auth()
+		addOAuth2FlowAttributes(auth, "", t.Oauth2PasswordSecurity.TokenUrl)
+		return auth
+	case *openapiv2.SecurityDefinitionsItem_Oauth2ApplicationSecurity:
+		auth := oauth2Auth()
+		addOAuth2FlowAttributes(auth, "", t.Oauth2ApplicationSecurity.TokenUrl)
+		return auth
+	case *openapiv2.SecurityDefinitionsItem_Oauth2AccessCodeSecurity:
+		auth := oauth2Auth()
+		addOAuth2FlowAttributes(auth, t.Oauth2AccessCodeSecurity.AuthorizationUrl, t.Oauth2AccessCodeSecurity.TokenUrl)
+		return auth
+	}
+	return nil
+}
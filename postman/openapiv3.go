@@ -0,0 +1,612 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+	surface "github.com/okkoye/gnostic/surface"
+)
+
+type openAPI3Exporter struct {
+	document    *openapiv3.Document
+	annotations *compiler.Annotations
+	builder     *collectionBuilder
+}
+
+// A parameter and the JSON pointer of its description in the document.
+type openAPI3Parameter struct {
+	*openapiv3.Parameter
+	pointer string
+}
+
+// NewCollectionFromOpenAPIv3 builds a Postman collection with a request for
+// each operation in an OpenAPI v3 document. Requests are grouped in folders
+// by tag, the first server is stored in the baseUrl collection variable, and
+// security requirements are mapped to Postman authentication settings.
+func NewCollectionFromOpenAPIv3(document *openapiv3.Document) (*Collection, error) {
+	return NewCollectionFromOpenAPIv3WithAnnotations(document, nil)
+}
+
+// NewCollectionFromOpenAPIv3WithAnnotations builds a collection like
+// NewCollectionFromOpenAPIv3, using the annotations that were recorded
+// when the document was compiled to encode the parameters that set
+// explode to false.
+func NewCollectionFromOpenAPIv3WithAnnotations(document *openapiv3.Document, annotations *compiler.Annotations) (*Collection, error) {
+	e := &openAPI3Exporter{document: document, annotations: annotations}
+	name, description := "", ""
+	if document.Info != nil {
+		name, description = document.Info.Title, document.Info.Description
+	}
+	e.builder = newCollectionBuilder(name, description)
+	e.buildServers()
+	for _, tag := range document.Tags {
+		e.builder.folder(tag.Name).Description = tag.Description
+	}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			e.buildPathItem(pair.Name, pair.Value)
+		}
+	}
+	// Remove folders for tags that have no operations.
+	items := make([]*Item, 0, len(e.builder.collection.Items))
+	for _, item := range e.builder.collection.Items {
+		if !item.IsFolder() || len(item.Items) > 0 {
+			items = append(items, item)
+		}
+	}
+	e.builder.collection.Items = items
+	if auth := e.authForRequirements(document.Security); auth != nil {
+		e.builder.collection.Auth = auth
+		e.builder.addAuthVariables(auth)
+	}
+	return e.builder.collection, nil
+}
+
+func (e *openAPI3Exporter) buildServers() {
+	if len(e.document.Servers) == 0 {
+		e.builder.addVariable(baseURLVariable, "/", "")
+		return
+	}
+	server := e.document.Servers[0]
+	e.builder.addVariable(baseURLVariable, templateURL(strings.TrimSuffix(server.Url, "/")), server.Description)
+	if server.Variables != nil {
+		for _, pair := range server.Variables.AdditionalProperties {
+			e.builder.addVariable(pair.Name, pair.Value.Default, pair.Value.Description)
+		}
+	}
+}
+
+func (e *openAPI3Exporter) buildPathItem(path string, pathItem *openapiv3.PathItem) {
+	operations := []*openapiv3.Operation{
+		pathItem.Get, pathItem.Put, pathItem.Post, pathItem.Delete,
+		pathItem.Options, pathItem.Head, pathItem.Patch, pathItem.Trace,
+	}
+	for i, operation := range operations {
+		if operation == nil {
+			continue
+		}
+		method := methodNames[i]
+		item := &Item{
+			Name:        itemName(operation.Summary, operation.OperationId, method, path),
+			Description: operation.Description,
+			Request:     e.buildRequest(path, method, pathItem, operation),
+		}
+		e.builder.addItem(operation.Tags, item)
+	}
+}
+
+func (e *openAPI3Exporter) buildRequest(path string, method string, pathItem *openapiv3.PathItem, operation *openapiv3.Operation) *Request {
+	request := &Request{
+		Method:      method,
+		Header:      make([]*KeyValue, 0),
+		URL:         newURL(path),
+		Description: operation.Description,
+	}
+	cookies := make([]string, 0)
+	pointer := "/paths/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(path)
+	for _, parameter := range e.parameters(pointer, strings.ToLower(method), pathItem, operation) {
+		value := e.parameterValue(parameter.Parameter)
+		encoded := e.encodeParameter(parameter)
+		switch parameter.In {
+		case "path":
+			if v := request.URL.pathVariable(parameter.Name); v != nil {
+				v.Value = value
+				if len(encoded) > 0 {
+					v.Value = encoded[0]
+				}
+				v.Description = parameter.Description
+			}
+		case "query":
+			if encoded == nil {
+				encoded = []string{parameter.Name + "=" + value}
+			}
+			// Exploded arrays and objects are sent as several parameters.
+			for _, pair := range encoded {
+				parts := strings.SplitN(pair, "=", 2)
+				request.URL.Query = append(request.URL.Query, &KeyValue{
+					Key:         parts[0],
+					Value:       parts[len(parts)-1],
+					Description: parameter.Description,
+					Disabled:    !parameter.Required,
+				})
+			}
+		case "header":
+			if len(encoded) > 0 {
+				value = encoded[0]
+			}
+			request.Header = append(request.Header, &KeyValue{
+				Key:         parameter.Name,
+				Value:       value,
+				Description: parameter.Description,
+				Disabled:    !parameter.Required,
+			})
+		case "cookie":
+			cookies = append(cookies, parameter.Name+"="+value)
+		}
+	}
+	if len(cookies) > 0 {
+		request.Header = append(request.Header, &KeyValue{Key: "Cookie", Value: strings.Join(cookies, "; ")})
+	}
+	if body := e.requestBody(operation.RequestBody); body != nil && body.Content != nil {
+		if mediaType, contentType := preferredMediaType(body.Content); mediaType != nil {
+			request.Header = append(request.Header, &KeyValue{Key: "Content-Type", Value: contentType})
+			request.Body = e.buildBody(contentType, mediaType)
+		}
+	}
+	if contentType := e.acceptedContentType(operation.Responses); contentType != "" {
+		request.Header = append(request.Header, &KeyValue{Key: "Accept", Value: contentType})
+	}
+	if auth := e.authForRequirements(operation.Security); auth != nil {
+		request.Auth = auth
+		e.builder.addAuthVariables(auth)
+	}
+	request.URL.finish()
+	return request
+}
+
+// parameters returns the parameters of an operation, including those inherited
+// from its path item that the operation doesn't override. The pointer is the
+// JSON pointer of the path item.
+func (e *openAPI3Exporter) parameters(pointer string, method string, pathItem *openapiv3.PathItem, operation *openapiv3.Operation) []openAPI3Parameter {
+	parameters := make([]openAPI3Parameter, 0)
+	for i, p := range operation.Parameters {
+		if parameter := e.parameter(p, pointer+"/"+method+"/parameters/"+strconv.Itoa(i)); parameter.Parameter != nil {
+			parameters = append(parameters, parameter)
+		}
+	}
+	for i, p := range pathItem.Parameters {
+		parameter := e.parameter(p, pointer+"/parameters/"+strconv.Itoa(i))
+		if parameter.Parameter == nil {
+			continue
+		}
+		overridden := false
+		for _, other := range parameters {
+			if other.Name == parameter.Name && other.In == parameter.In {
+				overridden = true
+			}
+		}
+		if !overridden {
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters
+}
+
+// parameter returns a parameter at a JSON pointer, following local references
+// to components.
+func (e *openAPI3Exporter) parameter(p *openapiv3.ParameterOrReference, pointer string) openAPI3Parameter {
+	if parameter := p.GetParameter(); parameter != nil {
+		return openAPI3Parameter{Parameter: parameter, pointer: pointer}
+	}
+	name := componentName(p.GetReference(), "parameters")
+	if name == "" || e.document.Components == nil || e.document.Components.Parameters == nil {
+		return openAPI3Parameter{}
+	}
+	for _, pair := range e.document.Components.Parameters.AdditionalProperties {
+		if pair.Name == name {
+			return openAPI3Parameter{Parameter: pair.Value.GetParameter(), pointer: strings.TrimPrefix(p.GetReference().XRef, "#")}
+		}
+	}
+	return openAPI3Parameter{}
+}
+
+// requestBody returns a request body, following local references to components.
+func (e *openAPI3Exporter) requestBody(b *openapiv3.RequestBodyOrReference) *openapiv3.RequestBody {
+	if body := b.GetRequestBody(); body != nil {
+		return body
+	}
+	name := componentName(b.GetReference(), "requestBodies")
+	if name == "" || e.document.Components == nil || e.document.Components.RequestBodies == nil {
+		return nil
+	}
+	for _, pair := range e.document.Components.RequestBodies.AdditionalProperties {
+		if pair.Name == name {
+			return pair.Value.GetRequestBody()
+		}
+	}
+	return nil
+}
+
+// schema returns a schema, following local references to components.
+func (e *openAPI3Exporter) schema(s *openapiv3.SchemaOrReference) *openapiv3.Schema {
+	if schema := s.GetSchema(); schema != nil {
+		return schema
+	}
+	name := componentName(s.GetReference(), "schemas")
+	if name == "" || e.document.Components == nil || e.document.Components.Schemas == nil {
+		return nil
+	}
+	for _, pair := range e.document.Components.Schemas.AdditionalProperties {
+		if pair.Name == name {
+			return e.schema(pair.Value)
+		}
+	}
+	return nil
+}
+
+// componentName returns the name of a component if reference refers to a local component of the specified kind.
+func componentName(reference *openapiv3.Reference, kind string) string {
+	if reference == nil {
+		return ""
+	}
+	prefix := "#/components/" + kind + "/"
+	if strings.HasPrefix(reference.XRef, prefix) {
+		return reference.XRef[len(prefix):]
+	}
+	return ""
+}
+
+// parameterValue returns an example value for a parameter.
+func (e *openAPI3Exporter) parameterValue(parameter *openapiv3.Parameter) string {
+	if example := e.parameterExample(parameter); example != "" {
+		return exampleString(example)
+	}
+	return placeholder(e.schema(parameter.Schema).GetType())
+}
+
+// parameterExample returns the YAML of an example value for a parameter, or
+// an empty string if it has none.
+func (e *openAPI3Exporter) parameterExample(parameter *openapiv3.Parameter) string {
+	if parameter.Example != nil {
+		return parameter.Example.Yaml
+	}
+	if parameter.Examples != nil {
+		for _, pair := range parameter.Examples.AdditionalProperties {
+			if example := pair.Value.GetExample(); example != nil && example.Value != nil {
+				return example.Value.Yaml
+			}
+		}
+	}
+	schema := e.schema(parameter.Schema)
+	switch {
+	case schema == nil:
+	case schema.Example != nil:
+		return schema.Example.Yaml
+	case schema.Default != nil:
+		return string(compiler.Marshal(defaultNode(schema.Default)))
+	case len(schema.Enum) > 0:
+		return schema.Enum[0].Yaml
+	}
+	return ""
+}
+
+// encodeParameter returns the example value of a path, query, or header
+// parameter encoded with the parameter's style, or nil if the parameter
+// has no example. Query parameters are encoded as name=value pairs.
+func (e *openAPI3Exporter) encodeParameter(parameter openAPI3Parameter) []string {
+	positions := map[string]surface.Position{
+		"path":   surface.Position_PATH,
+		"query":  surface.Position_QUERY,
+		"header": surface.Position_HEADER,
+	}
+	position, ok := positions[parameter.In]
+	example := e.parameterExample(parameter.Parameter)
+	if !ok || example == "" {
+		return nil
+	}
+	value, ok := exampleValue(example)
+	if !ok {
+		return nil
+	}
+	field := &surface.Field{Name: parameter.Name, Position: position}
+	field.Style, field.Explode, field.AllowReserved = surface.ParameterSerialization(e.annotations, parameter.Parameter, parameter.pointer)
+	return field.EncodeParameter(value)
+}
+
+func defaultNode(d *openapiv3.DefaultType) *yaml.Node {
+	switch v := d.Oneof.(type) {
+	case *openapiv3.DefaultType_Boolean:
+		return compiler.NewScalarNodeForBool(v.Boolean)
+	case *openapiv3.DefaultType_Number:
+		return compiler.NewScalarNodeForFloat(v.Number)
+	case *openapiv3.DefaultType_String_:
+		return compiler.NewScalarNodeForString(v.String_)
+	}
+	return compiler.NewNullNode()
+}
+
+// preferredMediaType chooses the media type to use for a request body, preferring JSON.
+func preferredMediaType(content *openapiv3.MediaTypes) (*openapiv3.MediaType, string) {
+	if len(content.AdditionalProperties) == 0 {
+		return nil, ""
+	}
+	for _, pair := range content.AdditionalProperties {
+		if isJSONMediaType(pair.Name) {
+			return pair.Value, pair.Name
+		}
+	}
+	pair := content.AdditionalProperties[0]
+	return pair.Value, pair.Name
+}
+
+func isJSONMediaType(name string) bool {
+	return name == "application/json" || strings.HasSuffix(name, "+json")
+}
+
+func (e *openAPI3Exporter) buildBody(contentType string, mediaType *openapiv3.MediaType) *Body {
+	schema := e.schema(mediaType.Schema)
+	switch {
+	case contentType == "application/x-www-form-urlencoded":
+		return &Body{Mode: "urlencoded", URLEncoded: e.formFields(schema, false)}
+	case strings.HasPrefix(contentType, "multipart/"):
+		return &Body{Mode: "formdata", FormData: e.formFields(schema, true)}
+	}
+	if mediaType.Example != nil {
+		return jsonBody(exampleString(mediaType.Example.Yaml))
+	}
+	if mediaType.Examples != nil {
+		for _, pair := range mediaType.Examples.AdditionalProperties {
+			if example := pair.Value.GetExample(); example != nil && example.Value != nil {
+				return jsonBody(exampleString(example.Value.Yaml))
+			}
+		}
+	}
+	if schema == nil {
+		return &Body{Mode: "raw"}
+	}
+	if isJSONMediaType(contentType) {
+		return jsonBody(jsonString(e.sample(schema, 0)))
+	}
+	return &Body{Mode: "raw", Raw: placeholder(schema.Type)}
+}
+
+// formFields returns the fields of a form body described by an object schema.
+func (e *openAPI3Exporter) formFields(schema *openapiv3.Schema, multipart bool) []*KeyValue {
+	fields := make([]*KeyValue, 0)
+	if schema == nil || schema.Properties == nil {
+		return fields
+	}
+	for _, pair := range schema.Properties.AdditionalProperties {
+		field := &KeyValue{Key: pair.Name, Type: "text"}
+		if property := e.schema(pair.Value); property != nil {
+			field.Description = property.Description
+			if multipart && (property.Format == "binary" || property.Format == "base64") {
+				field.Type = "file"
+			} else {
+				field.Value = exampleString(string(compiler.Marshal(e.sample(property, 0))))
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// sample builds an example value for a schema, using examples where they are available.
+func (e *openAPI3Exporter) sample(schema *openapiv3.Schema, depth int) *yaml.Node {
+	if schema.Example != nil {
+		if node := exampleNode(schema.Example.Yaml); node != nil {
+			return node
+		}
+	}
+	if schema.Default != nil {
+		return defaultNode(schema.Default)
+	}
+	if len(schema.Enum) > 0 {
+		if node := exampleNode(schema.Enum[0].Yaml); node != nil {
+			return node
+		}
+	}
+	if depth > maxSampleDepth {
+		return compiler.NewNullNode()
+	}
+	if len(schema.AllOf) > 0 {
+		merged := compiler.NewMappingNode()
+		for _, s := range schema.AllOf {
+			if member := e.schema(s); member != nil {
+				node := e.sample(member, depth+1)
+				if node.Kind == yaml.MappingNode {
+					merged.Content = append(merged.Content, node.Content...)
+				}
+			}
+		}
+		return merged
+	}
+	for _, alternatives := range [][]*openapiv3.SchemaOrReference{schema.OneOf, schema.AnyOf} {
+		if len(alternatives) > 0 {
+			if member := e.schema(alternatives[0]); member != nil {
+				return e.sample(member, depth+1)
+			}
+		}
+	}
+	switch schema.Type {
+	case "object", "":
+		node := compiler.NewMappingNode()
+		if schema.Properties != nil {
+			for _, pair := range schema.Properties.AdditionalProperties {
+				if property := e.schema(pair.Value); property != nil && !property.ReadOnly {
+					node.Content = append(node.Content,
+						compiler.NewScalarNodeForString(pair.Name),
+						e.sample(property, depth+1))
+				}
+			}
+		}
+		return node
+	case "array":
+		node := compiler.NewSequenceNode()
+		if schema.Items != nil && len(schema.Items.SchemaOrReference) > 0 {
+			if items := e.schema(schema.Items.SchemaOrReference[0]); items != nil {
+				node.Content = append(node.Content, e.sample(items, depth+1))
+			}
+		}
+		return node
+	default:
+		return sampleScalar(schema.Type, schema.Format)
+	}
+}
+
+// acceptedContentType returns the content type of the first successful response.
+func (e *openAPI3Exporter) acceptedContentType(responses *openapiv3.Responses) string {
+	if responses == nil {
+		return ""
+	}
+	for _, pair := range responses.ResponseOrReference {
+		if !strings.HasPrefix(pair.Name, "2") {
+			continue
+		}
+		response := pair.Value.GetResponse()
+		if response == nil {
+			name := componentName(pair.Value.GetReference(), "responses")
+			if name != "" && e.document.Components != nil && e.document.Components.Responses != nil {
+				for _, r := range e.document.Components.Responses.AdditionalProperties {
+					if r.Name == name {
+						response = r.Value.GetResponse()
+					}
+				}
+			}
+		}
+		if response != nil && response.Content != nil {
+			if _, contentType := preferredMediaType(response.Content); contentType != "" {
+				return contentType
+			}
+		}
+	}
+	return ""
+}
+
+// authForRequirements returns the authentication settings for the first
+// scheme of the first security requirement that can be represented in Postman.
+func (e *openAPI3Exporter) authForRequirements(requirements []*openapiv3.SecurityRequirement) *Auth {
+	for _, requirement := range requirements {
+		for _, pair := range requirement.AdditionalProperties {
+			if scheme := e.securityScheme(pair.Name); scheme != nil {
+				if auth := authForSecuritySchemeV3(pair.Name, scheme); auth != nil {
+					return auth
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (e *openAPI3Exporter) securityScheme(name string) *openapiv3.SecurityScheme {
+	if e.document.Components == nil || e.document.Components.SecuritySchemes == nil {
+		return nil
+	}
+	for _, pair := range e.document.Components.SecuritySchemes.AdditionalProperties {
+		if pair.Name == name {
+			return pair.Value.GetSecurityScheme()
+		}
+	}
+	return nil
+}
+
+func authForSecuritySchemeV3(name string, scheme *openapiv3.SecurityScheme) *Auth {
+	switch scheme.Type {
+	case "apiKey":
+		if scheme.In == "cookie" {
+			return nil
+		}
+		return apiKeyAuth(scheme.Name, scheme.In)
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			return basicAuth()
+		case "bearer":
+			return bearerAuth()
+		}
+	case "oauth2":
+		auth := oauth2Auth()
+		if flows := scheme.Flows; flows != nil {
+			for _, flow := range []*openapiv3.OauthFlow{flows.AuthorizationCode, flows.ClientCredentials, flows.Password, flows.Implicit} {
+				if flow != nil {
+					addOAuth2FlowAttributes(auth, flow.AuthorizationUrl, flow.TokenUrl)
+					break
+				}
+			}
+		}
+		return auth
+	case "openIdConnect":
+		return oauth2Auth()
+	}
+	return nil
+}
+
+func apiKeyAuth(name string, in string) *Auth {
+	if in != "query" {
+		in = "header"
+	}
+	return &Auth{
+		Type: "apikey",
+		APIKey: []*AuthAttribute{
+			{Key: "key", Value: name, Type: "string"},
+			{Key: "value", Value: "{{apiKey}}", Type: "string"},
+			{Key: "in", Value: in, Type: "string"},
+		},
+	}
+}
+
+func basicAuth() *Auth {
+	return &Auth{
+		Type: "basic",
+		Basic: []*AuthAttribute{
+			{Key: "username", Value: "{{username}}", Type: "string"},
+			{Key: "password", Value: "{{password}}", Type: "string"},
+		},
+	}
+}
+
+func bearerAuth() *Auth {
+	return &Auth{
+		Type: "bearer",
+		Bearer: []*AuthAttribute{
+			{Key: "token", Value: "{{bearerToken}}", Type: "string"},
+		},
+	}
+}
+
+func oauth2Auth() *Auth {
+	return &Auth{
+		Type: "oauth2",
+		OAuth2: []*AuthAttribute{
+			{Key: "accessToken", Value: "{{accessToken}}", Type: "string"},
+			{Key: "addTokenTo", Value: "header", Type: "string"},
+		},
+	}
+}
+
+func addOAuth2FlowAttributes(auth *Auth, authorizationURL string, tokenURL string) {
+	if authorizationURL != "" {
+		auth.OAuth2 = append(auth.OAuth2, &AuthAttribute{Key: "authUrl", Value: authorizationURL, Type: "string"})
+	}
+	if tokenURL != "" {
+		auth.OAuth2 = append(auth.OAuth2, &AuthAttribute{Key: "accessTokenUrl", Value: tokenURL, Type: "string"})
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postman
+
+import (
+	"io/ioutil"
+	"testing"
+
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+func TestCollectionFromOpenAPIv2(t *testing.T) {
+	b, err := ioutil.ReadFile("../examples/v2.0/yaml/petstore-expanded.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := openapiv2.ParseDocument(b)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	collection, err := NewCollectionFromOpenAPIv2(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(collection.Items) != 4 {
+		t.Fatalf("unexpected number of items: %d (expected 4)", len(collection.Items))
+	}
+	if v := collection.Variables[0]; v.Key != "baseUrl" || v.Value != "http://petstore.swagger.io/api" {
+		t.Errorf("unexpected base URL variable: %+v", v)
+	}
+	addPet := collection.Items[1].Request
+	if addPet.Method != "POST" || addPet.Body == nil || addPet.Body.Mode != "raw" {
+		t.Errorf("unexpected addPet request: %+v", addPet)
+	}
+	findPet := collection.Items[2].Request
+	if findPet.URL.Raw != "{{baseUrl}}/pets/:id" || len(findPet.URL.Variables) != 1 {
+		t.Errorf("unexpected URL: %+v", findPet.URL)
+	}
+}
+
+func TestCollectionFromOpenAPIv3(t *testing.T) {
+	b, err := ioutil.ReadFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := openapiv3.ParseDocument(b)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	collection, err := NewCollectionFromOpenAPIv3(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// All operations are tagged "pets", so they should be in one folder.
+	if len(collection.Items) != 1 || !collection.Items[0].IsFolder() || collection.Items[0].Name != "pets" {
+		t.Fatalf("unexpected items: %+v", collection.Items)
+	}
+	if n := len(collection.Items[0].Items); n != 3 {
+		t.Errorf("unexpected number of requests: %d (expected 3)", n)
+	}
+	bytes, err := collection.Marshal()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	roundTrip, err := ParseCollection(bytes)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if roundTrip.Info.Schema != SchemaURL {
+		t.Errorf("unexpected schema: %s", roundTrip.Info.Schema)
+	}
+}
+
+func TestParameterStyles(t *testing.T) {
+	document, annotations, err := openapiv3.ParseDocumentWithAnnotations([]byte(`openapi: 3.0.0
+info:
+  title: Styles
+  version: 1.0.0
+paths:
+  /colors/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          style: label
+          example: [1, 2]
+        - name: ids
+          in: query
+          required: true
+          example: [3, 4]
+        - name: names
+          in: query
+          required: true
+          explode: false
+          example: [red, blue]
+        - $ref: "#/components/parameters/Levels"
+        - name: filter
+          in: query
+          required: true
+          style: deepObject
+          explode: true
+          example: {color: red, size: 2}
+      responses:
+        200:
+          description: The colors.
+components:
+  parameters:
+    Levels:
+      name: levels
+      in: query
+      required: true
+      style: pipeDelimited
+      explode: false
+      example: [5, 6]
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	collection, err := NewCollectionFromOpenAPIv3WithAnnotations(document, annotations)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	url := collection.Items[0].Request.URL
+	expected := "{{baseUrl}}/colors/:id?ids=3&ids=4&names=red,blue&levels=5%7C6&filter%5Bcolor%5D=red&filter%5Bsize%5D=2"
+	if url.Raw != expected {
+		t.Errorf("unexpected URL %s (expected %s)", url.Raw, expected)
+	}
+	if v := url.pathVariable("id"); v == nil || v.Value != ".1,2" {
+		t.Errorf("unexpected path variable %+v", v)
+	}
+}
+
+const libraryCollection = `{
+  "info": {
+    "name": "Library",
+    "description": {"content": "A library of books.", "type": "text/markdown"},
+    "schema": "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"
+  },
+  "auth": {"type": "bearer", "bearer": {"token": "{{token}}"}},
+  "variable": [
+    {"key": "baseUrl", "value": "https://library.example.com/v1"},
+    {"key": "limit", "value": 10}
+  ],
+  "item": [
+    {
+      "name": "Books",
+      "description": "Operations on books.",
+      "item": [
+        {
+          "name": "List books",
+          "request": {
+            "method": "GET",
+            "url": "{{baseUrl}}/shelves/:shelf/books?limit={{limit}}&available=true"
+          }
+        },
+        {
+          "name": "Create book",
+          "request": {
+            "method": "POST",
+            "header": [{"key": "Content-Type", "value": "application/json"}],
+            "url": {"raw": "{{baseUrl}}/shelves/:shelf/books", "variable": [{"key": "shelf", "value": "7", "description": "The shelf ID."}]},
+            "body": {"mode": "raw", "raw": "{\"title\": \"Dune\", \"pages\": 412, \"tags\": [\"fiction\"]}"}
+          },
+          "response": [
+            {"name": "Created", "code": 201, "header": [{"key": "Content-Type", "value": "application/json"}], "body": "{\"id\": 1, \"title\": \"Dune\"}"}
+          ]
+        }
+      ]
+    },
+    {
+      "name": "Upload cover",
+      "request": {
+        "method": "PUT",
+        "url": "{{host}}/covers/{{coverId}}",
+        "auth": {"type": "apikey", "apikey": [{"key": "key", "value": "X-Key"}, {"key": "in", "value": "header"}]},
+        "body": {"mode": "formdata", "formdata": [{"key": "image", "type": "file"}]}
+      }
+    }
+  ]
+}`
+
+func TestOpenAPIv3FromCollection(t *testing.T) {
+	collection, err := ParseCollection([]byte(libraryCollection))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := NewOpenAPIv3FromCollection(collection)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The description must compile.
+	bytes, err := document.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := openapiv3.ParseDocument(bytes); err != nil {
+		t.Fatalf("%+v\n%s", err, string(bytes))
+	}
+	if document.Info.Description != "A library of books." {
+		t.Errorf("unexpected description %q", document.Info.Description)
+	}
+	if len(document.Servers) != 2 ||
+		document.Servers[0].Url != "https://library.example.com/v1" ||
+		document.Servers[1].Url != "{host}" {
+		t.Errorf("unexpected servers %+v", document.Servers)
+	}
+	if len(document.Tags) != 1 || document.Tags[0].Name != "Books" {
+		t.Errorf("unexpected tags %+v", document.Tags)
+	}
+	paths := document.Paths.Path
+	if len(paths) != 2 || paths[0].Name != "/shelves/{shelf}/books" || paths[1].Name != "/covers/{coverId}" {
+		t.Fatalf("unexpected paths %+v", paths)
+	}
+	list := paths[0].Value.Get
+	if list.OperationId != "listBooks" || len(list.Parameters) != 3 {
+		t.Fatalf("unexpected operation %+v", list)
+	}
+	if limit := list.Parameters[1].GetParameter(); limit.Name != "limit" || limit.Schema.GetSchema().Type != "integer" {
+		t.Errorf("unexpected parameter %+v", limit)
+	}
+	create := paths[0].Value.Post
+	if shelf := create.Parameters[0].GetParameter(); shelf.Description != "The shelf ID." {
+		t.Errorf("unexpected parameter %+v", shelf)
+	}
+	body := create.RequestBody.GetRequestBody().Content.AdditionalProperties[0]
+	if body.Name != "application/json" {
+		t.Errorf("unexpected content type %s", body.Name)
+	}
+	properties := body.Value.Schema.GetSchema().Properties.AdditionalProperties
+	if len(properties) != 3 || properties[1].Name != "pages" || properties[1].Value.GetSchema().Type != "integer" ||
+		properties[2].Value.GetSchema().Type != "array" {
+		t.Errorf("unexpected properties %+v", properties)
+	}
+	if response := create.Responses.ResponseOrReference[0]; response.Name != "201" {
+		t.Errorf("unexpected response %+v", response)
+	}
+	if len(document.Security) != 1 || document.Security[0].AdditionalProperties[0].Name != "bearerAuth" {
+		t.Errorf("unexpected security %+v", document.Security)
+	}
+	upload := paths[1].Value.Put
+	if len(upload.Security) != 1 || upload.Security[0].AdditionalProperties[0].Name != "apiKey" {
+		t.Errorf("unexpected security %+v", upload.Security)
+	}
+	if content := upload.RequestBody.GetRequestBody().Content.AdditionalProperties[0]; content.Name != "multipart/form-data" {
+		t.Errorf("unexpected content type %s", content.Name)
+	}
+}
+
+func TestCollectionRoundTrip(t *testing.T) {
+	b, err := ioutil.ReadFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := openapiv3.ParseDocument(b)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	collection, err := NewCollectionFromOpenAPIv3(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	imported, err := NewOpenAPIv3FromCollection(collection)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(imported.Servers) != 1 || imported.Servers[0].Url != "https://petstore.openapis.org/v1" {
+		t.Errorf("unexpected servers %+v", imported.Servers)
+	}
+	if len(imported.Paths.Path) != len(document.Paths.Path) {
+		t.Fatalf("unexpected paths %+v", imported.Paths.Path)
+	}
+	for i, path := range document.Paths.Path {
+		if imported.Paths.Path[i].Name != path.Name {
+			t.Errorf("unexpected path %s, expected %s", imported.Paths.Path[i].Name, path.Name)
+		}
+	}
+}
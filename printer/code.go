@@ -16,26 +16,61 @@
 package printer
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"strings"
 )
 
 const indentation = "  "
 
 // Code represents a file of code to be printed.
 type Code struct {
-	text   string
+	text   strings.Builder
 	indent int
+	out    *bufio.Writer
+	err    error
+
+	language *Language
+
+	line    int      // number of completed lines
+	source  string   // source of the lines being printed
+	sources []string // source of each line, recorded after SetSource is first called
+}
+
+// NewWriter creates a Code that streams lines to w as they are printed
+// instead of accumulating them. Lines are buffered, so Flush must be
+// called after the last line is printed.
+func NewWriter(w io.Writer) *Code {
+	return &Code{out: bufio.NewWriter(w)}
+}
+
+// write adds text to the accumulated code or to the output writer.
+// After a write fails, later writes are ignored and Flush returns the error.
+func (c *Code) write(s string) {
+	if n := strings.Count(s, "\n"); n > 0 {
+		c.line += n
+		for ; c.sources != nil && n > 0; n-- {
+			c.sources = append(c.sources, c.source)
+		}
+	}
+	if c.out == nil {
+		c.text.WriteString(s)
+	} else if c.err == nil {
+		_, c.err = c.out.WriteString(s)
+	}
 }
 
 // Print adds a line of code using the current indentation. Accepts printf-style format strings and arguments.
 func (c *Code) Print(args ...interface{}) {
 	if len(args) > 0 {
+		indentation := c.Language().Indentation
 		for i := 0; i < c.indent; i++ {
-			c.text += indentation
+			c.write(indentation)
 		}
-		c.text += fmt.Sprintf(args[0].(string), args[1:]...)
+		c.write(fmt.Sprintf(args[0].(string), args[1:]...))
 	}
-	c.text += "\n"
+	c.write("\n")
 }
 
 // PrintIf adds a line of code using the current indentation if a condition is true. Accepts printf-style format strings and arguments.
@@ -43,18 +78,43 @@ func (c *Code) PrintIf(condition bool, args ...interface{}) {
 	if !condition {
 		return
 	}
-	if len(args) > 0 {
-		for i := 0; i < c.indent; i++ {
-			c.text += indentation
-		}
-		c.text += fmt.Sprintf(args[0].(string), args[1:]...)
-	}
-	c.text += "\n"
+	c.Print(args...)
 }
 
-// String returns the accumulated code as a string.
+// String returns the accumulated code as a string. Code that is streamed
+// to a writer isn't accumulated, so its string is empty.
 func (c *Code) String() string {
-	return c.text
+	return c.text.String()
+}
+
+// Flush writes any buffered lines to the writer of a Code created with
+// NewWriter and returns the first error that occurred while writing.
+func (c *Code) Flush() error {
+	if c.out == nil || c.err != nil {
+		return c.err
+	}
+	c.err = c.out.Flush()
+	return c.err
+}
+
+// Block prints an opening line, the lines printed by body with one more
+// level of indentation, and a closing line. The open and close lines are
+// printed without printf-style formatting. Indentation is restored after
+// body returns, so unbalanced calls to Indent and Outdent inside body don't
+// affect the lines that follow the block.
+func (c *Code) Block(open string, close string, body func()) {
+	c.Print("%s", open)
+	c.Indented(body)
+	c.Print("%s", close)
+}
+
+// Indented prints the lines printed by body with one more level of
+// indentation and then restores the current indentation.
+func (c *Code) Indented(body func()) {
+	indent := c.indent
+	c.indent++
+	defer func() { c.indent = indent }()
+	body()
 }
 
 // Indent adds one level of indentation.
@@ -0,0 +1,92 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package printer
+
+import (
+	"fmt"
+	"go/format"
+	"go/scanner"
+	"strings"
+)
+
+// SourceError reports a problem in generated source code along with the
+// generated line that caused it.
+type SourceError struct {
+	Filename string
+	Line     int
+	Column   int
+	Text     string
+	Message  string
+}
+
+// Error returns the position and message of the error followed by the
+// offending line.
+func (e *SourceError) Error() string {
+	filename := e.Filename
+	if filename == "" {
+		filename = "generated code"
+	}
+	return fmt.Sprintf("%s:%d:%d: %s\n\t%d | %s", filename, e.Line, e.Column, e.Message, e.Line, e.Text)
+}
+
+// FormatGo formats the accumulated code as Go source with go/format.
+// If the code can't be parsed, the error is a *SourceError that includes the
+// offending generated line.
+func (c *Code) FormatGo() (string, error) {
+	return FormatGo("", c.String())
+}
+
+// FormatGo formats Go source with go/format. The filename is only used to
+// describe errors, which are returned as *SourceError values.
+func FormatGo(filename string, source string) (string, error) {
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		return "", NewSourceError(filename, source, err)
+	}
+	return string(formatted), nil
+}
+
+// NewSourceError converts errors from the Go parser into a *SourceError that
+// includes the text of the first line with an error. Other errors are
+// returned unchanged.
+func NewSourceError(filename string, source string, err error) error {
+	var first *scanner.Error
+	switch err := err.(type) {
+	case scanner.ErrorList:
+		if len(err) == 0 {
+			return err
+		}
+		first = err[0]
+	case *scanner.Error:
+		first = err
+	default:
+		return err
+	}
+	lines := strings.Split(source, "\n")
+	text := ""
+	if first.Pos.Line > 0 && first.Pos.Line <= len(lines) {
+		text = strings.TrimSpace(lines[first.Pos.Line-1])
+	}
+	if filename == "" {
+		filename = first.Pos.Filename
+	}
+	return &SourceError{
+		Filename: filename,
+		Line:     first.Pos.Line,
+		Column:   first.Pos.Column,
+		Text:     text,
+		Message:  first.Msg,
+	}
+}
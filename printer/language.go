@@ -0,0 +1,130 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package printer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Language describes the conventions for printing code in a language.
+type Language struct {
+	Name        string
+	Indentation string              // added to lines for each level of indentation
+	LineComment string              // starts comments that continue to the end of the line
+	Quote       func(string) string // returns a string literal for a value
+}
+
+// Go prints Go code in the style of gofmt.
+var Go = &Language{
+	Name:        "go",
+	Indentation: "\t",
+	LineComment: "//",
+	Quote:       strconv.Quote,
+}
+
+// Proto prints Protocol Buffer language files in the style of the
+// Protocol Buffers style guide.
+var Proto = &Language{
+	Name:        "proto",
+	Indentation: "  ",
+	LineComment: "//",
+	Quote:       quoteProto,
+}
+
+// Python prints Python code in the style of PEP 8.
+var Python = &Language{
+	Name:        "python",
+	Indentation: "    ",
+	LineComment: "#",
+	// Go escapes are also valid in Python string literals.
+	Quote: strconv.Quote,
+}
+
+// defaultLanguage is used by Code that has no language. Its indentation is
+// the one that Code has always used.
+var defaultLanguage = &Language{
+	Name:        "",
+	Indentation: indentation,
+	LineComment: "//",
+	Quote:       strconv.Quote,
+}
+
+// SetLanguage sets the conventions that are used to print the code.
+func (c *Code) SetLanguage(language *Language) {
+	c.language = language
+}
+
+// Language returns the conventions that are used to print the code.
+func (c *Code) Language() *Language {
+	if c.language == nil {
+		return defaultLanguage
+	}
+	return c.language
+}
+
+// Comment prints text as comments using the current indentation, with one
+// comment for each line of the text.
+func (c *Code) Comment(text string) {
+	prefix := c.Language().LineComment
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			c.Print("%s", prefix)
+		} else {
+			c.Print("%s %s", prefix, line)
+		}
+	}
+}
+
+// Quote returns a string literal for a value in the language of the code.
+func (c *Code) Quote(value string) string {
+	return c.Language().Quote(value)
+}
+
+// quoteProto returns a Protocol Buffer language string literal. The
+// language only supports octal, hexadecimal, and C-style escapes, so
+// printable Unicode characters are written unescaped.
+func quoteProto(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&b, "\\x%02x", value[i])
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString("\\n")
+		case r == '\r':
+			b.WriteString("\\r")
+		case r == '\t':
+			b.WriteString("\\t")
+		case unicode.IsPrint(r):
+			b.WriteRune(r)
+		default:
+			for _, c := range []byte(value[i : i+size]) {
+				fmt.Fprintf(&b, "\\x%02x", c)
+			}
+		}
+		i += size
+	}
+	b.WriteByte('"')
+	return b.String()
+}
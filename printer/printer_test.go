@@ -0,0 +1,201 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFormatGo(t *testing.T) {
+	code := &Code{}
+	code.Print("package sample")
+	code.Print("func Sum(a, b int) int {")
+	code.Indent()
+	code.Print("return a+b")
+	code.Outdent()
+	code.Print("}")
+	formatted, err := code.FormatGo()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := "package sample\n\nfunc Sum(a, b int) int {\n\treturn a + b\n}\n"
+	if formatted != expected {
+		t.Fatalf("Unexpected formatting:\n%s", formatted)
+	}
+}
+
+func TestFormatGoError(t *testing.T) {
+	code := &Code{}
+	code.Print("package sample")
+	code.Print("func Sum(a, b int) int {")
+	code.Indent()
+	code.Print("return a + ) b")
+	code.Outdent()
+	code.Print("}")
+	_, err := code.FormatGo()
+	sourceError, ok := err.(*SourceError)
+	if !ok {
+		t.Fatalf("Expected a *SourceError, got %+v", err)
+	}
+	if sourceError.Line != 3 || sourceError.Text != "return a + ) b" {
+		t.Fatalf("Unexpected error location: %+v", sourceError)
+	}
+	if !strings.Contains(err.Error(), "3 | return a + ) b") {
+		t.Fatalf("Error doesn't include the generated line: %s", err.Error())
+	}
+}
+
+func TestPrintTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("").Parse(`{{define "getter"}}func (m *{{.}}) Get() *{{.}} {
+return m
+}{{end}}`))
+	code := &Code{}
+	code.Print("// getters")
+	code.Indent()
+	if err := code.PrintTemplate(tmpl, "getter", "Info"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	code.Outdent()
+	expected := "// getters\n  func (m *Info) Get() *Info {\n  return m\n  }\n"
+	if code.String() != expected {
+		t.Fatalf("Unexpected output:\n%s", code.String())
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	code := NewWriter(&buffer)
+	code.Print("type %s struct {", "Info")
+	code.Indent()
+	code.Print("Title string")
+	code.Outdent()
+	code.Print("}")
+	if code.String() != "" {
+		t.Fatalf("Streamed code should not be accumulated")
+	}
+	if err := code.Flush(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := "type Info struct {\n  Title string\n}\n"
+	if buffer.String() != expected {
+		t.Fatalf("Unexpected output:\n%s", buffer.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestNewWriterError(t *testing.T) {
+	code := NewWriter(failingWriter{})
+	code.Print("package sample")
+	if err := code.Flush(); err == nil {
+		t.Fatalf("Expected an error from Flush")
+	}
+}
+
+func TestBlock(t *testing.T) {
+	code := &Code{}
+	code.Block("func main() {", "}", func() {
+		code.Block("for {", "}", func() {
+			code.Print("break")
+			// unbalanced indentation is repaired when the block ends
+			code.Indent()
+		})
+		code.Print("return")
+	})
+	code.Print("// done")
+	expected := "func main() {\n  for {\n    break\n  }\n  return\n}\n// done\n"
+	if code.String() != expected {
+		t.Fatalf("Unexpected output:\n%s", code.String())
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	code := &Code{}
+	code.Print("package sample\n")
+	code.SetSource("Info")
+	code.Block("type Info struct {", "}", func() {
+		code.WithSource("Info.title", func() {
+			code.Print("Title   string")
+		})
+		code.WithSource("Info.version", func() {
+			code.Print("Version string")
+		})
+	})
+	code.Print("func (m *Info) Get() *Info {return m}")
+	code.WithSource("Info.valid", func() {
+		code.Print("func (m *Info) Valid() bool {")
+		code.Print("if (m.Title == \"\") {return false}")
+		code.Print("return true")
+		code.Print("}")
+	})
+	m := code.SourceMap("sample.go")
+	expected := map[int]string{1: "", 3: "Info", 4: "Info.title", 5: "Info.version", 6: "Info", 7: "Info"}
+	for line, source := range expected {
+		if m.SourceForLine(line) != source {
+			t.Errorf("Line %d: expected %q, got %q", line, source, m.SourceForLine(line))
+		}
+	}
+	// formatting removes parentheses and splits a line into three
+	formatted, err := code.FormatGo()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	m = m.Remap(code.String(), formatted)
+	expected = map[int]string{1: "", 3: "Info", 4: "Info.title", 5: "Info.version", 6: "Info", 8: "Info",
+		9: "Info.valid", 10: "Info.valid", 11: "Info.valid", 12: "Info.valid", 13: "Info.valid", 14: "Info.valid"}
+	for line, source := range expected {
+		if m.SourceForLine(line) != source {
+			t.Errorf("Formatted line %d: expected %q, got %q\n%s", line, source, m.SourceForLine(line), formatted)
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	tests := []struct {
+		language *Language
+		expected string
+	}{
+		{Go, "func f() string {\n\t// Returns a greeting.\n\t//\n\t// Usage: f()\n\treturn \"say \\\"hi\\\"\\n\"\n}\n"},
+		{Proto, "func f() string {\n  // Returns a greeting.\n  //\n  // Usage: f()\n  return \"say \\\"hi\\\"\\n\"\n}\n"},
+		{Python, "func f() string {\n    # Returns a greeting.\n    #\n    # Usage: f()\n    return \"say \\\"hi\\\"\\n\"\n}\n"},
+	}
+	for _, test := range tests {
+		code := &Code{}
+		code.SetLanguage(test.language)
+		code.Block("func f() string {", "}", func() {
+			code.Comment("Returns a greeting.\n\nUsage: f()")
+			code.Print("return %s", code.Quote("say \"hi\"\n"))
+		})
+		if code.String() != test.expected {
+			t.Errorf("Unexpected %s output:\n%s", test.language.Name, code.String())
+		}
+	}
+}
+
+func TestQuoteProto(t *testing.T) {
+	quoted := quoteProto("caf\u00e9 \"\\\x01\xff")
+	expected := "\"caf\u00e9 \\\"\\\\\\x01\\xff\""
+	if quoted != expected {
+		t.Fatalf("Expected %s, got %s", expected, quoted)
+	}
+}
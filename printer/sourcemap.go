@@ -0,0 +1,159 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package printer
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// SourceMap maps ranges of generated lines to the sources that produced
+// them, such as the generator functions and schema elements of a code
+// generator.
+type SourceMap struct {
+	File     string           `json:"file,omitempty"`
+	Mappings []*SourceMapping `json:"mappings"`
+}
+
+// SourceMapping describes the source of a range of generated lines.
+type SourceMapping struct {
+	StartLine int    `json:"startLine"` // first line in the range, counting from 1
+	EndLine   int    `json:"endLine"`   // last line in the range
+	Source    string `json:"source"`
+}
+
+// SetSource sets the source of the lines that are printed after it is
+// called. Lines that were printed before SetSource was first called have
+// no source.
+func (c *Code) SetSource(source string) {
+	if c.sources == nil {
+		c.sources = make([]string, c.line)
+	}
+	c.source = source
+}
+
+// WithSource sets the source of the lines printed by body and then
+// restores the current source.
+func (c *Code) WithSource(source string, body func()) {
+	previous := c.source
+	c.SetSource(source)
+	defer func() { c.source = previous }()
+	body()
+}
+
+// SourceMap returns a map from the lines of the code to their sources.
+func (c *Code) SourceMap(file string) *SourceMap {
+	return newSourceMap(file, c.sources)
+}
+
+// newSourceMap combines adjacent lines with the same source into mappings.
+func newSourceMap(file string, sources []string) *SourceMap {
+	m := &SourceMap{File: file, Mappings: []*SourceMapping{}}
+	var last *SourceMapping
+	for i, source := range sources {
+		line := i + 1
+		if last != nil && last.Source == source && last.EndLine == line-1 {
+			last.EndLine = line
+		} else if source != "" {
+			last = &SourceMapping{StartLine: line, EndLine: line, Source: source}
+			m.Mappings = append(m.Mappings, last)
+		}
+	}
+	return m
+}
+
+// SourceForLine returns the source of a line, counting from 1, or an empty
+// string if the source is unknown.
+func (m *SourceMap) SourceForLine(line int) string {
+	for _, mapping := range m.Mappings {
+		if line >= mapping.StartLine && line <= mapping.EndLine {
+			return mapping.Source
+		}
+	}
+	return ""
+}
+
+// remapLookahead limits how many lines of the original code Remap searches
+// for a line of the formatted code.
+const remapLookahead = 100
+
+// Remap returns a source map for a reformatted copy of the code that the
+// map describes. Formatters like go/format change whitespace, split lines,
+// and remove redundant parentheses, so lines of the formatted code are
+// matched with the text that follows the previous match in the original
+// after whitespace and parentheses are removed. Lines that can't be matched
+// and blank lines take the source of the line before them.
+func (m *SourceMap) Remap(original string, formatted string) *SourceMap {
+	originalLines := strings.Split(original, "\n")
+	for i, line := range originalLines {
+		originalLines[i] = normalizeLine(line)
+	}
+	originalSources := m.lineSources()
+	var sources []string
+	current, offset := 0, 0 // the end of the previous match
+	source := ""
+	for _, line := range strings.Split(strings.TrimSuffix(formatted, "\n"), "\n") {
+		line = normalizeLine(line)
+		if line != "" {
+			for i := current; i < len(originalLines) && i < current+remapLookahead; i++ {
+				start := 0
+				if i == current {
+					start = offset
+				}
+				if k := strings.Index(originalLines[i][start:], line); k >= 0 {
+					current, offset = i, start+k+len(line)
+					if i < len(originalSources) {
+						source = originalSources[i]
+					} else {
+						source = ""
+					}
+					break
+				}
+			}
+		}
+		sources = append(sources, source)
+	}
+	return newSourceMap(m.File, sources)
+}
+
+// normalizeLine removes the characters of a line that formatters change.
+func normalizeLine(line string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '(' || r == ')' {
+			return -1
+		}
+		return r
+	}, line)
+}
+
+// lineSources returns the source of each line in the map.
+func (m *SourceMap) lineSources() []string {
+	var sources []string
+	for _, mapping := range m.Mappings {
+		for len(sources) < mapping.EndLine {
+			sources = append(sources, "")
+		}
+		for line := mapping.StartLine; line <= mapping.EndLine; line++ {
+			sources[line-1] = mapping.Source
+		}
+	}
+	return sources
+}
+
+// Marshal returns the source map as JSON.
+func (m *SourceMap) Marshal() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// PrintTemplate adds the lines produced by executing a named template with
+// the current indentation. Lines are added without printf-style formatting.
+func (c *Code) PrintTemplate(t *template.Template, name string, data interface{}) error {
+	var buffer bytes.Buffer
+	if err := t.ExecuteTemplate(&buffer, name, data); err != nil {
+		return err
+	}
+	text := strings.TrimSuffix(buffer.String(), "\n")
+	for _, line := range strings.Split(text, "\n") {
+		c.Print("%s", line)
+	}
+	return nil
+}
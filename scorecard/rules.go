@@ -0,0 +1,439 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"regexp"
+	"strings"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// DefaultRules returns the standard rule set. Its weights give 30% of the
+// score to documentation, 20% to naming, 30% to responses, and 20% to security.
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{ID: "info-description", Category: Documentation, Weight: 4, check: checkInfoDescription,
+			Description: "The API has a description and contact information."},
+		{ID: "operation-description", Category: Documentation, Weight: 10, check: checkOperationDescriptions,
+			Description: "Operations have summaries or descriptions."},
+		{ID: "parameter-description", Category: Documentation, Weight: 6, check: checkParameterDescriptions,
+			Description: "Parameters have descriptions."},
+		{ID: "schema-description", Category: Documentation, Weight: 5, check: checkSchemaDescriptions,
+			Description: "Component schemas have descriptions."},
+		{ID: "property-description", Category: Documentation, Weight: 5, check: checkPropertyDescriptions,
+			Description: "Properties of component schemas have descriptions."},
+		{ID: "operation-id", Category: Naming, Weight: 6, check: checkOperationIDs,
+			Description: "Operations have unique operation IDs."},
+		{ID: "operation-id-case", Category: Naming, Weight: 5, check: checkOperationIDCase,
+			Description: "Operation IDs use the same case style."},
+		{ID: "path-segment-case", Category: Naming, Weight: 4, check: checkPathSegmentCase,
+			Description: "Path segments use the same case style."},
+		{ID: "property-name-case", Category: Naming, Weight: 5, check: checkPropertyNameCase,
+			Description: "Property names use the same case style."},
+		{ID: "success-response", Category: Responses, Weight: 8, check: checkSuccessResponses,
+			Description: "Operations describe a successful response."},
+		{ID: "error-response", Category: Responses, Weight: 10, check: checkErrorResponses,
+			Description: "Operations describe error responses (4XX, 5XX, or default)."},
+		{ID: "response-schema", Category: Responses, Weight: 8, check: checkResponseSchemas,
+			Description: "Successful responses with content have schemas."},
+		{ID: "response-description", Category: Responses, Weight: 4, check: checkResponseDescriptions,
+			Description: "Responses have non-empty descriptions."},
+		{ID: "security-schemes", Category: Security, Weight: 5, check: checkSecuritySchemes,
+			Description: "The API defines security schemes."},
+		{ID: "operation-security", Category: Security, Weight: 10, check: checkOperationSecurity,
+			Description: "Operations require authentication."},
+		{ID: "unauthorized-response", Category: Security, Weight: 5, check: checkUnauthorizedResponses,
+			Description: "Secured operations describe 401 or 403 responses."},
+	}
+}
+
+// An operation is an operation in a description along with its location.
+type operation struct {
+	method    string
+	path      string
+	operation *openapi_v3.Operation
+	// parameters includes the parameters of the path item.
+	parameters []*openapi_v3.Parameter
+}
+
+func (o *operation) String() string {
+	return strings.ToUpper(o.method) + " " + o.path
+}
+
+// description provides the parts of a document that rules check.
+type description struct {
+	document   *openapi_v3.Document
+	operations []*operation
+}
+
+func newDescription(document *openapi_v3.Document) *description {
+	d := &description{document: document}
+	for _, item := range document.GetPaths().GetPath() {
+		v := item.Value
+		for _, o := range []struct {
+			method    string
+			operation *openapi_v3.Operation
+		}{
+			{"get", v.Get}, {"put", v.Put}, {"post", v.Post}, {"delete", v.Delete},
+			{"options", v.Options}, {"head", v.Head}, {"patch", v.Patch}, {"trace", v.Trace},
+		} {
+			if o.operation == nil {
+				continue
+			}
+			op := &operation{method: o.method, path: item.Name, operation: o.operation}
+			for _, p := range append(append([]*openapi_v3.ParameterOrReference{}, v.Parameters...), o.operation.Parameters...) {
+				if parameter := d.parameter(p); parameter != nil {
+					op.parameters = append(op.parameters, parameter)
+				}
+			}
+			d.operations = append(d.operations, op)
+		}
+	}
+	return d
+}
+
+// parameter resolves references to parameters in the components of the document.
+func (d *description) parameter(p *openapi_v3.ParameterOrReference) *openapi_v3.Parameter {
+	if parameter := p.GetParameter(); parameter != nil {
+		return parameter
+	}
+	name := strings.TrimPrefix(p.GetReference().GetXRef(), "#/components/parameters/")
+	for _, named := range d.document.GetComponents().GetParameters().GetAdditionalProperties() {
+		if named.Name == name {
+			return named.Value.GetParameter()
+		}
+	}
+	return nil
+}
+
+// response resolves references to responses in the components of the document.
+func (d *description) response(r *openapi_v3.ResponseOrReference) *openapi_v3.Response {
+	if response := r.GetResponse(); response != nil {
+		return response
+	}
+	name := strings.TrimPrefix(r.GetReference().GetXRef(), "#/components/responses/")
+	for _, named := range d.document.GetComponents().GetResponses().GetAdditionalProperties() {
+		if named.Name == name {
+			return named.Value.GetResponse()
+		}
+	}
+	return nil
+}
+
+// A namedResponse is a response along with its status code or "default".
+type namedResponse struct {
+	code     string
+	response *openapi_v3.Response
+}
+
+// responses returns the responses of an operation in document order.
+func (d *description) responses(o *operation) []*namedResponse {
+	responses := make([]*namedResponse, 0)
+	for _, named := range o.operation.GetResponses().GetResponseOrReference() {
+		responses = append(responses, &namedResponse{code: strings.ToUpper(named.Name), response: d.response(named.Value)})
+	}
+	if r := o.operation.GetResponses().GetDefault(); r != nil {
+		responses = append(responses, &namedResponse{code: "default", response: d.response(r)})
+	}
+	return responses
+}
+
+// hasResponse returns true if any response code starts with one of the prefixes.
+func hasResponse(responses []*namedResponse, prefixes ...string) bool {
+	for _, r := range responses {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(r.code, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// schemas returns the schemas in the components of the document.
+func (d *description) schemas() []*openapi_v3.NamedSchemaOrReference {
+	return d.document.GetComponents().GetSchemas().GetAdditionalProperties()
+}
+
+// security returns the security requirements that apply to an operation.
+func (d *description) security(o *operation) []*openapi_v3.SecurityRequirement {
+	if o.operation.Security != nil {
+		return o.operation.Security
+	}
+	return d.document.Security
+}
+
+func checkInfoDescription(d *description, r *RuleResult) {
+	info := d.document.GetInfo()
+	if info.GetDescription() != "" {
+		r.pass()
+	} else {
+		r.fail("the API has no description")
+	}
+	if info.GetContact() != nil {
+		r.pass()
+	} else {
+		r.fail("the API has no contact information")
+	}
+}
+
+func checkOperationDescriptions(d *description, r *RuleResult) {
+	for _, o := range d.operations {
+		if o.operation.Summary != "" || o.operation.Description != "" {
+			r.pass()
+		} else {
+			r.fail("%s has no summary or description", o)
+		}
+	}
+}
+
+func checkParameterDescriptions(d *description, r *RuleResult) {
+	for _, o := range d.operations {
+		for _, p := range o.parameters {
+			if p.Description != "" {
+				r.pass()
+			} else {
+				r.fail("%s parameter %q has no description", o, p.Name)
+			}
+		}
+	}
+}
+
+func checkSchemaDescriptions(d *description, r *RuleResult) {
+	for _, named := range d.schemas() {
+		if schema := named.Value.GetSchema(); schema != nil {
+			if schema.Description != "" || schema.Title != "" {
+				r.pass()
+			} else {
+				r.fail("schema %s has no description", named.Name)
+			}
+		}
+	}
+}
+
+func checkPropertyDescriptions(d *description, r *RuleResult) {
+	for _, named := range d.schemas() {
+		for _, property := range named.Value.GetSchema().GetProperties().GetAdditionalProperties() {
+			// Properties that refer to other schemas are described by those schemas.
+			if property.Value.GetReference() != nil {
+				continue
+			}
+			if property.Value.GetSchema().GetDescription() != "" {
+				r.pass()
+			} else {
+				r.fail("property %s.%s has no description", named.Name, property.Name)
+			}
+		}
+	}
+}
+
+func checkOperationIDs(d *description, r *RuleResult) {
+	seen := make(map[string]bool)
+	for _, o := range d.operations {
+		id := o.operation.OperationId
+		switch {
+		case id == "":
+			r.fail("%s has no operation ID", o)
+		case seen[id]:
+			r.fail("%s reuses operation ID %q", o, id)
+		default:
+			r.pass()
+		}
+		seen[id] = true
+	}
+}
+
+// Case styles of names.
+var caseStyles = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"camelCase", regexp.MustCompile(`^[a-z][a-z0-9]*([A-Z][a-z0-9]*)*$`)},
+	{"PascalCase", regexp.MustCompile(`^([A-Z][a-z0-9]*)+$`)},
+	{"snake_case", regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)},
+	{"kebab-case", regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)},
+}
+
+// checkCase checks that names use the case style used by most of them.
+// Names like "pets" that fit several styles count toward each of them.
+func checkCase(r *RuleResult, names []string, describe func(i int) string) {
+	counts := make(map[string]int)
+	for _, name := range names {
+		for _, style := range caseStyles {
+			if style.pattern.MatchString(name) {
+				counts[style.name]++
+			}
+		}
+	}
+	dominant := ""
+	for _, style := range caseStyles {
+		if counts[style.name] > counts[dominant] {
+			dominant = style.name
+		}
+	}
+	for i, name := range names {
+		matched := false
+		for _, style := range caseStyles {
+			if style.name == dominant && style.pattern.MatchString(name) {
+				matched = true
+			}
+		}
+		if matched {
+			r.pass()
+		} else if dominant == "" {
+			r.fail("%s doesn't use a recognized case style", describe(i))
+		} else {
+			r.fail("%s doesn't use %s", describe(i), dominant)
+		}
+	}
+}
+
+func checkOperationIDCase(d *description, r *RuleResult) {
+	names := make([]string, 0)
+	for _, o := range d.operations {
+		if o.operation.OperationId != "" {
+			names = append(names, o.operation.OperationId)
+		}
+	}
+	checkCase(r, names, func(i int) string { return "operation ID " + names[i] })
+}
+
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+func checkPathSegmentCase(d *description, r *RuleResult) {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, item := range d.document.GetPaths().GetPath() {
+		for _, segment := range strings.Split(item.Name, "/") {
+			// Skip templates and version segments like "v1".
+			if segment == "" || strings.HasPrefix(segment, "{") || versionSegment.MatchString(segment) || seen[segment] {
+				continue
+			}
+			seen[segment] = true
+			names = append(names, segment)
+		}
+	}
+	checkCase(r, names, func(i int) string { return "path segment " + names[i] })
+}
+
+func checkPropertyNameCase(d *description, r *RuleResult) {
+	names := make([]string, 0)
+	locations := make([]string, 0)
+	for _, named := range d.schemas() {
+		for _, property := range named.Value.GetSchema().GetProperties().GetAdditionalProperties() {
+			names = append(names, property.Name)
+			locations = append(locations, named.Name+"."+property.Name)
+		}
+	}
+	checkCase(r, names, func(i int) string { return "property " + locations[i] })
+}
+
+func checkSuccessResponses(d *description, r *RuleResult) {
+	for _, o := range d.operations {
+		if hasResponse(d.responses(o), "2", "3") {
+			r.pass()
+		} else {
+			r.fail("%s has no successful response", o)
+		}
+	}
+}
+
+func checkErrorResponses(d *description, r *RuleResult) {
+	for _, o := range d.operations {
+		if hasResponse(d.responses(o), "4", "5", "default") {
+			r.pass()
+		} else {
+			r.fail("%s has no error responses", o)
+		}
+	}
+}
+
+func checkResponseSchemas(d *description, r *RuleResult) {
+	for _, o := range d.operations {
+		for _, named := range d.responses(o) {
+			if !strings.HasPrefix(named.code, "2") || named.response == nil {
+				continue
+			}
+			for _, media := range named.response.GetContent().GetAdditionalProperties() {
+				if media.Value.GetSchema() != nil {
+					r.pass()
+				} else {
+					r.fail("%s response %s (%s) has no schema", o, named.code, media.Name)
+				}
+			}
+		}
+	}
+}
+
+func checkResponseDescriptions(d *description, r *RuleResult) {
+	for _, o := range d.operations {
+		for _, named := range d.responses(o) {
+			if named.response == nil {
+				continue
+			}
+			if strings.TrimSpace(named.response.Description) != "" {
+				r.pass()
+			} else {
+				r.fail("%s response %s has no description", o, named.code)
+			}
+		}
+	}
+}
+
+func checkSecuritySchemes(d *description, r *RuleResult) {
+	if len(d.document.GetComponents().GetSecuritySchemes().GetAdditionalProperties()) > 0 {
+		r.pass()
+	} else {
+		r.fail("the API defines no security schemes")
+	}
+}
+
+// secured returns true if a list of security requirements requires authentication.
+// An empty requirement makes authentication optional.
+func secured(requirements []*openapi_v3.SecurityRequirement) bool {
+	if len(requirements) == 0 {
+		return false
+	}
+	for _, requirement := range requirements {
+		if len(requirement.AdditionalProperties) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func checkOperationSecurity(d *description, r *RuleResult) {
+	for _, o := range d.operations {
+		if secured(d.security(o)) {
+			r.pass()
+		} else {
+			r.fail("%s doesn't require authentication", o)
+		}
+	}
+}
+
+func checkUnauthorizedResponses(d *description, r *RuleResult) {
+	for _, o := range d.operations {
+		if !secured(d.security(o)) {
+			continue
+		}
+		if hasResponse(d.responses(o), "401", "403", "4XX") {
+			r.pass()
+		} else {
+			r.fail("%s has no 401 or 403 response", o)
+		}
+	}
+}
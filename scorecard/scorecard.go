@@ -0,0 +1,221 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scorecard grades OpenAPI v3 descriptions against a weighted set
+// of style rules for use in API governance programs.
+package scorecard
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// Categories of rules.
+const (
+	Documentation = "documentation"
+	Naming        = "naming"
+	Responses     = "responses"
+	Security      = "security"
+)
+
+// A Rule checks one aspect of the style of a description.
+type Rule struct {
+	ID          string
+	Category    string
+	Description string
+	// Weight is the importance of the rule relative to the other rules.
+	Weight float64
+	check  func(d *description, r *RuleResult)
+}
+
+// A RuleResult describes how well a description follows a rule.
+type RuleResult struct {
+	ID       string   `json:"id"`
+	Category string   `json:"category"`
+	Weight   float64  `json:"weight"`
+	Passed   int      `json:"passed"`
+	Total    int      `json:"total"`
+	Score    float64  `json:"score"`
+	Findings []string `json:"findings,omitempty"`
+}
+
+// pass and fail count the items checked by a rule.
+func (r *RuleResult) pass() {
+	r.Passed++
+	r.Total++
+}
+
+func (r *RuleResult) fail(format string, args ...interface{}) {
+	r.Total++
+	r.Findings = append(r.Findings, fmt.Sprintf(format, args...))
+}
+
+// A CategoryResult summarizes the results of the rules in a category.
+type CategoryResult struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+	Score  float64 `json:"score"`
+}
+
+// A Report is the scorecard of a description.
+type Report struct {
+	Title      string            `json:"title"`
+	Version    string            `json:"version"`
+	Score      float64           `json:"score"`
+	Grade      string            `json:"grade"`
+	Categories []*CategoryResult `json:"categories"`
+	Rules      []*RuleResult     `json:"rules"`
+}
+
+// Grades are the minimum scores for each letter grade, from highest to lowest.
+var Grades = []struct {
+	Grade   string
+	Minimum float64
+}{
+	{"A", 90},
+	{"B", 80},
+	{"C", 70},
+	{"D", 60},
+	{"F", 0},
+}
+
+// Grade returns the letter grade for a score between 0 and 100.
+func Grade(score float64) string {
+	for _, g := range Grades {
+		if score >= g.Minimum {
+			return g.Grade
+		}
+	}
+	return "F"
+}
+
+// Score checks a description against a set of rules. Rules with no weight
+// and rules that have nothing to check are not included in the score,
+// which is the weighted average of the rule scores, from 0 to 100.
+func Score(document *openapi_v3.Document, rules []*Rule) *Report {
+	d := newDescription(document)
+	report := &Report{
+		Title:      document.GetInfo().GetTitle(),
+		Version:    document.GetInfo().GetVersion(),
+		Categories: make([]*CategoryResult, 0),
+		Rules:      make([]*RuleResult, 0),
+	}
+	categories := make(map[string]*CategoryResult)
+	var total, weights float64
+	for _, rule := range rules {
+		if rule.Weight <= 0 {
+			continue
+		}
+		result := &RuleResult{ID: rule.ID, Category: rule.Category, Weight: rule.Weight}
+		rule.check(d, result)
+		if result.Total == 0 {
+			continue
+		}
+		result.Score = 100 * float64(result.Passed) / float64(result.Total)
+		report.Rules = append(report.Rules, result)
+		c := categories[rule.Category]
+		if c == nil {
+			c = &CategoryResult{Name: rule.Category}
+			categories[rule.Category] = c
+			report.Categories = append(report.Categories, c)
+		}
+		// Category scores are accumulated as weighted sums and averaged below.
+		c.Weight += rule.Weight
+		c.Score += rule.Weight * result.Score
+		total += rule.Weight * result.Score
+		weights += rule.Weight
+	}
+	for _, c := range report.Categories {
+		c.Score /= c.Weight
+	}
+	if weights > 0 {
+		report.Score = total / weights
+	}
+	report.Grade = Grade(report.Score)
+	return report
+}
+
+// SetWeights changes the weights of rules. Weights are keyed by rule ID or
+// by category, and weights for rule IDs take precedence. A weight of zero
+// disables a rule. It returns an error if a key names no rule or category.
+func SetWeights(rules []*Rule, weights map[string]float64) error {
+	known := make(map[string]bool)
+	for _, rule := range rules {
+		known[rule.ID] = true
+		known[rule.Category] = true
+	}
+	unknown := make([]string, 0)
+	for key := range weights {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown rules or categories: %s", strings.Join(unknown, ", "))
+	}
+	for _, rule := range rules {
+		if w, ok := weights[rule.ID]; ok {
+			rule.Weight = w
+		} else if w, ok := weights[rule.Category]; ok {
+			rule.Weight = w
+		}
+	}
+	return nil
+}
+
+// ReadWeights reads rule weights from a YAML or JSON file containing a
+// mapping from rule IDs or categories to numbers.
+func ReadWeights(filename string) (map[string]float64, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[string]float64)
+	if err := yaml.Unmarshal(bytes, &weights); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return weights, nil
+}
+
+// String returns a text rendering of a report.
+func (report *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Scorecard for %s %s\n\n", report.Title, report.Version)
+	fmt.Fprintf(&b, "Grade: %s (%.1f/100)\n\n", report.Grade, report.Score)
+	fmt.Fprintf(&b, "%-16s %6s %7s\n", "Category", "Score", "Weight")
+	for _, c := range report.Categories {
+		fmt.Fprintf(&b, "%-16s %6.1f %7g\n", c.Name, c.Score, c.Weight)
+	}
+	fmt.Fprintf(&b, "\n%-24s %6s %9s %7s\n", "Rule", "Score", "Passed", "Weight")
+	for _, r := range report.Rules {
+		fmt.Fprintf(&b, "%-24s %6.1f %9s %7g\n", r.ID, r.Score, fmt.Sprintf("%d/%d", r.Passed, r.Total), r.Weight)
+	}
+	for _, r := range report.Rules {
+		if len(r.Findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s:\n", r.ID)
+		for _, f := range r.Findings {
+			fmt.Fprintf(&b, "  %s\n", f)
+		}
+	}
+	return b.String()
+}
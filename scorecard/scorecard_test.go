@@ -0,0 +1,142 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"testing"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const testDocument = `openapi: 3.0.0
+info:
+  title: Store
+  version: 1.0.0
+  description: A store.
+  contact:
+    name: Store team
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets.
+      security:
+        - key: []
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        "401":
+          description: Unauthorized
+  /pet-owners/{ownerId}:
+    get:
+      operationId: get_owner
+      parameters:
+        - name: ownerId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ""
+components:
+  schemas:
+    Pet:
+      description: A pet.
+      properties:
+        name:
+          type: string
+          description: The name of the pet.
+        owner_id:
+          type: string
+  securitySchemes:
+    key:
+      type: apiKey
+      in: header
+      name: X-Key
+`
+
+func testReport(t *testing.T, rules []*Rule) (*Report, map[string]*RuleResult) {
+	document, err := openapi_v3.ParseDocument([]byte(testDocument))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	report := Score(document, rules)
+	results := make(map[string]*RuleResult)
+	for _, r := range report.Rules {
+		results[r.ID] = r
+	}
+	return report, results
+}
+
+func TestRules(t *testing.T) {
+	_, results := testReport(t, DefaultRules())
+	for id, expected := range map[string][2]int{
+		"info-description":      {2, 2},
+		"operation-description": {1, 2},
+		"parameter-description": {0, 1},
+		"schema-description":    {1, 1},
+		"property-description":  {1, 2},
+		"operation-id":          {2, 2},
+		"operation-id-case":     {1, 2},
+		"path-segment-case":     {2, 2},
+		"property-name-case":    {2, 2},
+		"success-response":      {2, 2},
+		"error-response":        {1, 2},
+		"response-schema":       {1, 1},
+		"response-description":  {2, 3},
+		"security-schemes":      {1, 1},
+		"operation-security":    {1, 2},
+		"unauthorized-response": {1, 1},
+	} {
+		r := results[id]
+		if r == nil {
+			t.Errorf("missing result for %s", id)
+			continue
+		}
+		if r.Passed != expected[0] || r.Total != expected[1] {
+			t.Errorf("%s: passed %d/%d, expected %d/%d (%v)", id, r.Passed, r.Total, expected[0], expected[1], r.Findings)
+		}
+	}
+}
+
+func TestWeights(t *testing.T) {
+	rules := DefaultRules()
+	if err := SetWeights(rules, map[string]float64{Documentation: 0, Naming: 0, Responses: 0, "operation-security": 0, "security-schemes": 2}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	report, _ := testReport(t, rules)
+	// security-schemes passes with weight 2 and unauthorized-response passes with weight 5.
+	if len(report.Rules) != 2 || report.Score != 100 || report.Grade != "A" {
+		t.Errorf("unexpected report: %s", report)
+	}
+	if err := SetWeights(rules, map[string]float64{"no-such-rule": 1}); err == nil {
+		t.Errorf("expected an error for an unknown rule")
+	}
+}
+
+func TestGrade(t *testing.T) {
+	for score, grade := range map[float64]string{100: "A", 90: "A", 89.9: "B", 75: "C", 60: "D", 12: "F"} {
+		if g := Grade(score); g != grade {
+			t.Errorf("grade for %f is %s, expected %s", score, g, grade)
+		}
+	}
+}
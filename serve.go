@@ -0,0 +1,88 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/lib"
+	service "github.com/okkoye/gnostic/service"
+)
+
+const serveUsage = `
+Usage: gnostic serve --grpc ADDRESS [OPTIONS]
+  ADDRESS is the address to listen on, such as :9000.
+  The gnostic.service.v1.Gnostic service is served with gRPC. It compiles,
+  validates, converts, and compares API descriptions; see
+  service/service.proto. The server reflection service is also served.
+Options:
+  --ref-root=DIR      Only read sources and the files that they refer to
+                      if they are in DIR. By default, only files in the
+                      current directory are read.
+  --allow-urls        Read sources and referenced files that are named
+                      with URLs. By default, they aren't read, so that
+                      clients can't make the server send requests.
+  --x-EXTENSION       Let clients ask for the extension plugin named
+                      gnostic-x-EXTENSION. Clients can't use other
+                      extensions. This can be repeated.
+  --help              Print usage information and exit.
+`
+
+// Serve the Gnostic service with gRPC. Its arguments follow "serve".
+func serve(args []string) error {
+	address := ""
+	server := service.NewServer()
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--help" {
+			fmt.Printf("%s", serveUsage)
+			return nil
+		} else if arg == "--grpc" && i+1 < len(args) {
+			i++
+			address = args[i]
+		} else if strings.HasPrefix(arg, "--grpc=") {
+			address = strings.TrimPrefix(arg, "--grpc=")
+		} else if strings.HasPrefix(arg, "--ref-root=") {
+			server.ReferenceRoot = strings.TrimPrefix(arg, "--ref-root=")
+		} else if arg == "--allow-urls" {
+			server.AllowURLs = true
+		} else if strings.HasPrefix(arg, "--x-") && len(arg) > len("--x-") {
+			server.Extensions = append(server.Extensions, strings.TrimPrefix(arg, "--x-"))
+		} else {
+			return lib.NewUsageError(fmt.Sprintf("unknown option: %s", arg))
+		}
+	}
+	if address == "" {
+		return lib.NewUsageError("no address specified")
+	}
+	// Files can change while the server runs, so they are read for
+	// every request.
+	compiler.DisableFileCache()
+	compiler.DisableInfoCache()
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	service.RegisterGnosticServer(grpcServer, server)
+	reflection.Register(grpcServer)
+	return grpcServer.Serve(listener)
+}
@@ -0,0 +1,262 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic_service_v1
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/okkoye/gnostic/compiler"
+	"github.com/okkoye/gnostic/diff"
+	"github.com/okkoye/gnostic/gnostic"
+	"github.com/okkoye/gnostic/lib"
+)
+
+// Server implements the Gnostic service.
+//
+// Sources that are sent without their contents are read by the server,
+// as are the files that sources refer to. Only files in ReferenceRoot
+// are read, and sources and files that are named with URLs are only read
+// if AllowURLs is set, so that clients can't make the server read other
+// files or send requests to other hosts. Clients can only ask for the
+// extension plugins named in Extensions, so that they can't make the
+// server run other programs.
+//
+// The compiler's caches are shared by all requests. Parsed files are only
+// reused if their contents are unchanged, so descriptions that clients
+// send aren't seen by other clients, but servers that run for a long time
+// should disable the file cache with compiler.DisableFileCache to see
+// changes to the files that they read from URLs with http.Get.
+type Server struct {
+	UnimplementedGnosticServer
+	// ReferenceRoot is a directory that contains all of the files that
+	// the server reads. If it is empty, the server only reads files in
+	// its working directory.
+	ReferenceRoot string
+	// AllowURLs reads sources and referenced files that are named with
+	// URLs. They are read with Fetcher, or with http.Get if it is nil.
+	AllowURLs bool
+	// Fetcher reads the files that are named with URLs when AllowURLs is
+	// set.
+	Fetcher compiler.Fetcher
+	// Extensions are the names of the extension plugins that clients can
+	// ask for, without their gnostic-x- prefix. Requests for other
+	// extensions are rejected.
+	Extensions []string
+}
+
+// NewServer creates a Server.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Compile compiles an API description into its protocol buffer model.
+func (s *Server) Compile(ctx context.Context, request *CompileRequest) (*CompileResponse, error) {
+	result, err := s.compile(ctx, request.GetSource(), request.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	document, err := proto.Marshal(result.Document)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &CompileResponse{
+		Format:      Format(result.Format),
+		Version:     result.Version,
+		Document:    &any.Any{TypeUrl: "type.googleapis.com/" + proto.MessageName(result.Document), Value: document},
+		Diagnostics: diagnostics(result.Diagnostics),
+	}, nil
+}
+
+// Validate reports the problems in an API description.
+func (s *Server) Validate(ctx context.Context, request *ValidateRequest) (*ValidateResponse, error) {
+	result, err := s.compile(ctx, request.GetSource(), request.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	return &ValidateResponse{
+		Valid:       len(result.Diagnostics) == 0,
+		Format:      Format(result.Format),
+		Diagnostics: diagnostics(result.Diagnostics),
+	}, nil
+}
+
+// Convert writes an API description in another format or encoding.
+func (s *Server) Convert(ctx context.Context, request *ConvertRequest) (*ConvertResponse, error) {
+	result, err := s.compileValid(ctx, request.GetSource(), request.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	format := request.Format
+	if format == Format_FORMAT_UNKNOWN {
+		format = Format(result.Format)
+	}
+	if !gnostic.CanConvert(result.Format, int(format)) {
+		return nil, status.Errorf(codes.Unimplemented, "%s descriptions can't be converted to %s", Format(result.Format), format)
+	}
+	document, err := gnostic.Convert(result.Document, int(format))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	encoding := request.Encoding
+	if encoding == Encoding_ENCODING_UNSPECIFIED {
+		encoding = encodingForName(request.GetSource().GetName())
+	}
+	// Annotations only apply to documents that aren't converted.
+	annotations := result.Annotations
+	if int(format) != result.Format {
+		annotations = nil
+	}
+	contents, err := gnostic.EncodeWithAnnotations(document, annotations, encodings[encoding])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &ConvertResponse{Format: format, Encoding: encoding, Contents: contents}, nil
+}
+
+// Diff compares two versions of an API description.
+func (s *Server) Diff(ctx context.Context, request *DiffRequest) (*DiffResponse, error) {
+	before, err := s.compileValid(ctx, request.GetBefore(), nil)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.compileValid(ctx, request.GetAfter(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if before.Format != after.Format || (after.Format != lib.SourceFormatOpenAPI2 && after.Format != lib.SourceFormatOpenAPI3) {
+		return nil, status.Error(codes.InvalidArgument, "both descriptions must be OpenAPI descriptions with the same version")
+	}
+	afterNode := lib.DocumentNodeWithAnnotations(after.Document, after.Annotations, after.Format)
+	changes := diff.Compare(lib.DocumentNodeWithAnnotations(before.Document, before.Annotations, before.Format), afterNode)
+	// Name the release with the title and version of the new description.
+	info := compiler.MapValueForKey(afterNode.Content[0], "info")
+	name, _ := compiler.StringForScalarNode(compiler.MapValueForKey(info, "title"))
+	version, _ := compiler.StringForScalarNode(compiler.MapValueForKey(info, "version"))
+	response := &DiffResponse{Changelog: diff.Changelog(changes, name+" "+version)}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, &Change{
+			Type:      change.Type,
+			Subject:   change.Subject,
+			Operation: change.Operation,
+			Name:      change.Name,
+			Details:   change.Details,
+		})
+	}
+	return response, nil
+}
+
+// Compile a source, returning descriptions that have problems along with
+// their diagnostics.
+func (s *Server) compile(ctx context.Context, source *Source, options *Options) (*gnostic.Result, error) {
+	if source.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sources must be named")
+	}
+	for _, extension := range options.GetExtensions() {
+		if !s.allowsExtension(extension) {
+			return nil, status.Errorf(codes.PermissionDenied, "the extension %s isn't available on this server", extension)
+		}
+	}
+	root := s.ReferenceRoot
+	if root == "" {
+		root = "."
+	}
+	compileOptions := gnostic.NewOptions(gnostic.WithLenientCompilation(), gnostic.WithExtensions(options.GetExtensions()...), gnostic.WithReferenceRoot(root))
+	if options.GetResolveReferences() {
+		gnostic.WithReferenceResolution()(compileOptions)
+	}
+	contents := source.GetContents()
+	if len(contents) > 0 {
+		gnostic.WithSourceContents(contents)(compileOptions)
+	}
+	if s.AllowURLs {
+		gnostic.WithURLsAllowed()(compileOptions)
+		gnostic.WithFetcher(s.Fetcher)(compileOptions)
+	} else if len(contents) == 0 && compiler.IsURL(source.GetName()) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s can't be read: this server doesn't read URLs", source.GetName())
+	}
+	result, err := gnostic.Compile(ctx, source.GetName(), compileOptions)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return result, nil
+}
+
+// Returns true if clients can ask for an extension plugin.
+func (s *Server) allowsExtension(name string) bool {
+	for _, extension := range s.Extensions {
+		if extension == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile a source, returning an error if it has problems.
+func (s *Server) compileValid(ctx context.Context, source *Source, options *Options) (*gnostic.Result, error) {
+	result, err := s.compile(ctx, source, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Diagnostics) > 0 {
+		messages := make([]string, len(result.Diagnostics))
+		for i, diagnostic := range result.Diagnostics {
+			messages[i] = diagnostic.String()
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "%s has problems:\n%s", source.GetName(), strings.Join(messages, "\n"))
+	}
+	return result, nil
+}
+
+func diagnostics(diagnostics []gnostic.Diagnostic) []*Diagnostic {
+	result := make([]*Diagnostic, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		result[i] = &Diagnostic{
+			Message: diagnostic.Message,
+			Path:    diagnostic.Path,
+			Line:    int32(diagnostic.Line),
+			Column:  int32(diagnostic.Column),
+		}
+	}
+	return result
+}
+
+// The gnostic package's names of encodings.
+var encodings = map[Encoding]string{
+	Encoding_ENCODING_YAML:   gnostic.EncodingYAML,
+	Encoding_ENCODING_JSON:   gnostic.EncodingJSON,
+	Encoding_ENCODING_BINARY: gnostic.EncodingBinary,
+	Encoding_ENCODING_TEXT:   gnostic.EncodingText,
+}
+
+// Returns the encoding of a source with the specified name.
+func encodingForName(name string) Encoding {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return Encoding_ENCODING_JSON
+	case ".pb":
+		return Encoding_ENCODING_BINARY
+	}
+	return Encoding_ENCODING_YAML
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic_service_v1
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/okkoye/gnostic/compiler"
+	openapi_v2 "github.com/okkoye/gnostic/openapiv2"
+)
+
+func TestCompile(t *testing.T) {
+	server := &Server{ReferenceRoot: ".."}
+	response, err := server.Compile(context.Background(), &CompileRequest{
+		Source: &Source{Name: "../examples/v2.0/yaml/petstore.yaml"},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if response.Format != Format_FORMAT_OPENAPI_V2 || response.Version != "2.0" || len(response.Diagnostics) != 0 {
+		t.Errorf("unexpected response %+v", response)
+	}
+	document := &openapi_v2.Document{}
+	if response.Document.TypeUrl != "type.googleapis.com/openapi.v2.Document" || proto.Unmarshal(response.Document.Value, document) != nil {
+		t.Fatalf("unexpected document %+v", response.Document)
+	}
+	if document.Info.Title != "Swagger Petstore" {
+		t.Errorf("unexpected title %s", document.Info.Title)
+	}
+
+	_, err = server.Compile(context.Background(), &CompileRequest{Source: &Source{}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected an invalid argument error, got %v", err)
+	}
+}
+
+func TestCompileRestrictions(t *testing.T) {
+	server := NewServer()
+	_, err := server.Compile(context.Background(), &CompileRequest{
+		Source: &Source{Name: "../examples/v2.0/yaml/petstore.yaml"},
+	})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "is outside of") {
+		t.Errorf("expected a file outside of the working directory to be rejected, got %v", err)
+	}
+	_, err = server.Compile(context.Background(), &CompileRequest{
+		Source: &Source{Name: "https://example.com/petstore.yaml"},
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected a URL to be rejected, got %v", err)
+	}
+	fetched := false
+	server.Fetcher = compiler.FetcherFunc(func(fileurl string) ([]byte, error) {
+		fetched = true
+		return []byte("type: string\n"), nil
+	})
+	request := &CompileRequest{
+		Source: &Source{
+			Name:     "uploaded/references.yaml",
+			Contents: []byte("swagger: '2.0'\ninfo: {title: Pets, version: '1'}\npaths: {}\ndefinitions:\n  Name: {$ref: 'https://example.com/name.yaml'}\n"),
+		},
+		Options: &Options{ResolveReferences: true},
+	}
+	response, err := server.Compile(context.Background(), request)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if fetched || len(response.Diagnostics) != 1 || !strings.Contains(response.Diagnostics[0].Message, "URLs aren't read") {
+		t.Errorf("expected a referenced URL to be rejected, got %+v", response.Diagnostics)
+	}
+	server.AllowURLs = true
+	response, err = server.Compile(context.Background(), request)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !fetched || len(response.Diagnostics) != 0 {
+		t.Errorf("expected a referenced URL to be read, got %+v", response.Diagnostics)
+	}
+}
+
+func TestCompileExtensions(t *testing.T) {
+	server := NewServer()
+	request := &CompileRequest{
+		Source:  &Source{Name: "petstore.yaml", Contents: []byte("swagger: '2.0'\ninfo: {title: Pets, version: '1'}\npaths: {}\n")},
+		Options: &Options{Extensions: []string{"sample"}},
+	}
+	_, err := server.Compile(context.Background(), request)
+	if status.Code(err) != codes.PermissionDenied || !strings.Contains(err.Error(), "sample") {
+		t.Errorf("expected an extension that isn't allowed to be rejected, got %v", err)
+	}
+	server.Extensions = []string{"sample"}
+	if _, err = server.Compile(context.Background(), request); err != nil {
+		t.Errorf("expected an allowed extension to be used, got %v", err)
+	}
+}
+
+func TestCompileContents(t *testing.T) {
+	// Clients that send different contents with the same name get their
+	// own descriptions.
+	server := NewServer()
+	for _, title := range []string{"Pets", "Stores"} {
+		response, err := server.Compile(context.Background(), &CompileRequest{
+			Source: &Source{Name: "api.yaml", Contents: []byte("swagger: '2.0'\ninfo: {title: " + title + ", version: '1'}\npaths: {}\n")},
+		})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		document := &openapi_v2.Document{}
+		if err = proto.Unmarshal(response.Document.Value, document); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if document.Info.Title != title {
+			t.Errorf("expected the title %s, got %s", title, document.Info.Title)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	contents, err := ioutil.ReadFile("../examples/errors/petstore-badproperties.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	response, err := NewServer().Validate(context.Background(), &ValidateRequest{
+		Source: &Source{Name: "uploaded/petstore.yaml", Contents: contents},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if response.Valid || len(response.Diagnostics) != 4 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	expected := &Diagnostic{Message: "is missing required property: version", Path: "$root.info", Line: 3, Column: 3}
+	if !proto.Equal(response.Diagnostics[0], expected) {
+		t.Errorf("unexpected diagnostic %+v", response.Diagnostics[0])
+	}
+}
+
+func TestConvert(t *testing.T) {
+	server := &Server{ReferenceRoot: ".."}
+	response, err := server.Convert(context.Background(), &ConvertRequest{
+		Source:   &Source{Name: "../examples/discovery/discovery-v1.json"},
+		Format:   Format_FORMAT_OPENAPI_V3,
+		Encoding: Encoding_ENCODING_YAML,
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if response.Format != Format_FORMAT_OPENAPI_V3 || !strings.HasPrefix(string(response.Contents), "openapi: ") {
+		t.Errorf("unexpected response %s", string(response.Contents))
+	}
+
+	response, err = server.Convert(context.Background(), &ConvertRequest{
+		Source: &Source{Name: "../examples/v3.0/yaml/petstore.yaml"},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if response.Encoding != Encoding_ENCODING_YAML || !strings.Contains(string(response.Contents), "title: OpenAPI Petstore") {
+		t.Errorf("unexpected response %+v", response)
+	}
+
+	response, err = server.Convert(context.Background(), &ConvertRequest{
+		Source: &Source{Name: "../examples/v3.0/yaml/petstore.yaml"},
+		Format: Format_FORMAT_OPENAPI_V2,
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !strings.HasPrefix(string(response.Contents), "swagger: ") {
+		t.Errorf("unexpected response %s", string(response.Contents))
+	}
+
+	_, err = server.Convert(context.Background(), &ConvertRequest{
+		Source: &Source{Name: "../examples/v3.0/yaml/petstore.yaml"},
+		Format: Format_FORMAT_DISCOVERY,
+	})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected an unimplemented error, got %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := []byte("swagger: '2.0'\ninfo: {title: Pets, version: '1'}\npaths:\n  /pets: {get: {responses: {'200': {description: ok}}}}\n")
+	after := []byte("swagger: '2.0'\ninfo: {title: Pets, version: '2'}\npaths:\n  /pets: {get: {responses: {'200': {description: ok}}}}\n  /owners: {get: {responses: {'200': {description: ok}}}}\n")
+	response, err := NewServer().Diff(context.Background(), &DiffRequest{
+		Before: &Source{Name: "before.yaml", Contents: before},
+		After:  &Source{Name: "after.yaml", Contents: after},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(response.Changes) != 1 || response.Changes[0].Type != "Added" || response.Changes[0].Operation != "GET /owners" {
+		t.Errorf("unexpected changes %+v", response.Changes)
+	}
+	if !strings.Contains(response.Changelog, "Pets 2") {
+		t.Errorf("unexpected changelog %s", response.Changelog)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic can run as a service that compiles, validates, converts, and
+// compares API descriptions for its clients. The service is started with
+// "gnostic serve --grpc ADDRESS".
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.3
+// source: service/service.proto
+
+package gnostic_service_v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Gnostic_Compile_FullMethodName  = "/gnostic.service.v1.Gnostic/Compile"
+	Gnostic_Validate_FullMethodName = "/gnostic.service.v1.Gnostic/Validate"
+	Gnostic_Convert_FullMethodName  = "/gnostic.service.v1.Gnostic/Convert"
+	Gnostic_Diff_FullMethodName     = "/gnostic.service.v1.Gnostic/Diff"
+)
+
+// GnosticClient is the client API for Gnostic service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GnosticClient interface {
+	// Compile an API description into its protocol buffer model.
+	Compile(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (*CompileResponse, error)
+	// Report the problems in an API description.
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// Write an API description in another format or encoding.
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	// Compare two versions of an API description.
+	Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error)
+}
+
+type gnosticClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGnosticClient(cc grpc.ClientConnInterface) GnosticClient {
+	return &gnosticClient{cc}
+}
+
+func (c *gnosticClient) Compile(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (*CompileResponse, error) {
+	out := new(CompileResponse)
+	err := c.cc.Invoke(ctx, Gnostic_Compile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gnosticClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, Gnostic_Validate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gnosticClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, Gnostic_Convert_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gnosticClient) Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error) {
+	out := new(DiffResponse)
+	err := c.cc.Invoke(ctx, Gnostic_Diff_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GnosticServer is the server API for Gnostic service.
+// All implementations must embed UnimplementedGnosticServer
+// for forward compatibility
+type GnosticServer interface {
+	// Compile an API description into its protocol buffer model.
+	Compile(context.Context, *CompileRequest) (*CompileResponse, error)
+	// Report the problems in an API description.
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// Write an API description in another format or encoding.
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	// Compare two versions of an API description.
+	Diff(context.Context, *DiffRequest) (*DiffResponse, error)
+	mustEmbedUnimplementedGnosticServer()
+}
+
+// UnimplementedGnosticServer must be embedded to have forward compatible implementations.
+type UnimplementedGnosticServer struct {
+}
+
+func (UnimplementedGnosticServer) Compile(context.Context, *CompileRequest) (*CompileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compile not implemented")
+}
+func (UnimplementedGnosticServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedGnosticServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedGnosticServer) Diff(context.Context, *DiffRequest) (*DiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
+func (UnimplementedGnosticServer) mustEmbedUnimplementedGnosticServer() {}
+
+// UnsafeGnosticServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GnosticServer will
+// result in compilation errors.
+type UnsafeGnosticServer interface {
+	mustEmbedUnimplementedGnosticServer()
+}
+
+func RegisterGnosticServer(s grpc.ServiceRegistrar, srv GnosticServer) {
+	s.RegisterService(&Gnostic_ServiceDesc, srv)
+}
+
+func _Gnostic_Compile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnosticServer).Compile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gnostic_Compile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnosticServer).Compile(ctx, req.(*CompileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gnostic_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnosticServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gnostic_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnosticServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gnostic_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnosticServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gnostic_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnosticServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gnostic_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnosticServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gnostic_Diff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnosticServer).Diff(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Gnostic_ServiceDesc is the grpc.ServiceDesc for Gnostic service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Gnostic_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gnostic.service.v1.Gnostic",
+	HandlerType: (*GnosticServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Compile",
+			Handler:    _Gnostic_Compile_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _Gnostic_Validate_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _Gnostic_Convert_Handler,
+		},
+		{
+			MethodName: "Diff",
+			Handler:    _Gnostic_Diff_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service/service.proto",
+}
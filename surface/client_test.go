@@ -0,0 +1,476 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surface_v1
+
+// The tests in this file call servers with the client helpers of surface
+// models, which read models like the code that generators write from them.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// Returns the model of an OpenAPI v3 description.
+func modelForOpenAPI3(t *testing.T, description string) *Model {
+	t.Helper()
+	document, annotations, err := openapiv3.ParseDocumentWithAnnotations([]byte(description))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI3WithAnnotations(document, annotations, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	return m
+}
+
+// Returns the method of a model with an operation ID.
+func methodWithOperation(t *testing.T, m *Model, operation string) *Method {
+	t.Helper()
+	for _, method := range m.Methods {
+		if method.Operation == operation {
+			return method
+		}
+	}
+	t.Fatalf("missing method %s", operation)
+	return nil
+}
+
+func TestPaginationListsAllItems(t *testing.T) {
+	m := modelForOpenAPI3(t, `openapi: 3.0.0
+info:
+  title: Pages
+  version: 1.0.0
+paths:
+  /books:
+    get:
+      operationId: listBooks
+      parameters:
+      - {name: pageToken, in: query, schema: {type: string}}
+      - {name: pageSize, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  books: {type: array, items: {type: string}}
+                  nextPageToken: {type: string}
+  /authors:
+    get:
+      operationId: listAuthors
+      parameters:
+      - {name: page, in: query, schema: {type: integer}}
+      - {name: per_page, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  authors: {type: array, items: {type: string}}
+  /stores:
+    get:
+      operationId: listStores
+      parameters:
+      - {name: offset, in: query, schema: {type: integer}}
+      - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  stores: {type: array, items: {type: string}}
+  /shelves:
+    get:
+      operationId: listShelves
+      parameters:
+      - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          headers:
+            Link: {schema: {type: string}}
+          content:
+            application/json:
+              schema: {type: array, items: {type: string}}
+`)
+	all := []string{"a", "b", "c", "d", "e"}
+	// Returns the items of a page and the position of the next page.
+	page := func(start, size int) ([]string, int) {
+		if start > len(all) {
+			start = len(all)
+		}
+		end := start + size
+		if end > len(all) {
+			end = len(all)
+		}
+		return all[start:end], end
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		number := func(name string) int {
+			n, _ := strconv.Atoi(query.Get(name))
+			return n
+		}
+		var body interface{}
+		switch r.URL.Path {
+		case "/books":
+			items, end := page(number("pageToken"), number("pageSize"))
+			next := ""
+			if end < len(all) {
+				next = strconv.Itoa(end)
+			}
+			body = map[string]interface{}{"books": items, "nextPageToken": next}
+		case "/authors":
+			items, _ := page((number("page")-1)*number("per_page"), number("per_page"))
+			body = map[string]interface{}{"authors": items}
+		case "/stores":
+			items, _ := page(number("offset"), number("limit"))
+			body = map[string]interface{}{"stores": items}
+		case "/shelves":
+			items, end := page(number("from"), number("limit"))
+			if end < len(all) {
+				w.Header().Set("Link", fmt.Sprintf(`</shelves?from=%d&limit=%d>; rel="next"`, end, number("limit")))
+			}
+			body = items
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+	for _, operation := range []string{"listBooks", "listAuthors", "listStores", "listShelves"} {
+		method := methodWithOperation(t, m, operation)
+		if method.Pagination == nil {
+			t.Errorf("%s isn't paginated", operation)
+			continue
+		}
+		items, err := method.Pagination.ListAll(nil, server.URL+method.Path, 2, 0)
+		if err != nil {
+			t.Errorf("Failed to list the items of %s: %+v", operation, err)
+			continue
+		}
+		var names []string
+		for _, item := range items {
+			var name string
+			json.Unmarshal(item, &name)
+			names = append(names, name)
+		}
+		if fmt.Sprint(names) != fmt.Sprint(all) {
+			t.Errorf("unexpected items of %s: %v", operation, names)
+		}
+	}
+}
+
+func TestPaginationStopsListing(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Every page has items, and the next page token is always the same.
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": []string{"a"}, "next": "again"})
+	}))
+	defer server.Close()
+	token := &Pagination{Style: PaginationStyle_TOKEN, PageParameter: "cursor", NextField: "next", ItemsField: "items"}
+	items, err := token.ListAll(nil, server.URL+"/pets", 0, 0)
+	if err != nil || len(items) != 2 || requests != 2 {
+		t.Errorf("expected a repeated token to end the list after 2 pages, got %d items in %d requests: %v", len(items), requests, err)
+	}
+	// Servers that ignore page numbers never return an empty page.
+	requests = 0
+	page := &Pagination{Style: PaginationStyle_PAGE, PageParameter: "page", ItemsField: "items"}
+	_, err = page.ListAll(nil, server.URL+"/pets", 0, 5)
+	if err == nil || !strings.Contains(err.Error(), "more than 5 pages") || requests != 5 {
+		t.Errorf("expected listing to stop after 5 pages, got %d requests: %v", requests, err)
+	}
+}
+
+func TestSecuritySchemesAuthenticateRequests(t *testing.T) {
+	c := &Credentials{APIKey: "key", Username: "user", Password: "secret", Token: "jwt", ClientID: "client", ClientSecret: "client-secret"}
+	// Returns the reason that a request isn't authenticated, or "" if it is.
+	check := func(r *http.Request) string {
+		username, password, _ := r.BasicAuth()
+		cookie, _ := r.Cookie("session")
+		switch r.URL.Path {
+		case "/pets":
+			if r.Header.Get("X-API-Key") != c.APIKey {
+				return "missing API key header"
+			}
+		case "/owners":
+			if r.URL.Query().Get("api_key") != c.APIKey || cookie == nil || cookie.Value != c.APIKey {
+				return "missing API key parameter and cookie"
+			}
+		case "/stores":
+			if username != c.Username || password != c.Password {
+				return "missing basic credentials"
+			}
+		case "/orders":
+			if r.Header.Get("Authorization") != "Bearer "+c.Token {
+				return "missing bearer token"
+			}
+		case "/token":
+			if username != c.ClientID || password != c.ClientSecret || r.FormValue("grant_type") != "client_credentials" {
+				return "missing client credentials"
+			}
+		case "/vets":
+			if r.Header.Get("Authorization") != "Bearer read write" {
+				return "missing access token"
+			}
+		}
+		return ""
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reason := check(r); reason != "" {
+			http.Error(w, reason, http.StatusUnauthorized)
+		} else if r.URL.Path == "/token" {
+			// The access token is the scopes that were granted.
+			json.NewEncoder(w).Encode(map[string]string{"access_token": r.FormValue("scope"), "token_type": "bearer"})
+		}
+	}))
+	defer server.Close()
+	m := modelForOpenAPI3(t, strings.Replace(`openapi: 3.0.0
+info:
+  title: Security
+  version: 1.0.0
+security:
+- header: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+  /owners:
+    get:
+      operationId: listOwners
+      security:
+      - query: []
+        cookie: []
+      responses:
+        "200":
+          description: ok
+  /stores:
+    get:
+      operationId: listStores
+      security:
+      - basic: []
+      responses:
+        "200":
+          description: ok
+  /orders:
+    get:
+      operationId: listOrders
+      security:
+      - bearer: []
+      responses:
+        "200":
+          description: ok
+  /vets:
+    get:
+      operationId: listVets
+      security:
+      - oauth: [read, write]
+      responses:
+        "200":
+          description: ok
+components:
+  securitySchemes:
+    header: {type: apiKey, name: X-API-Key, in: header}
+    query: {type: apiKey, name: api_key, in: query}
+    cookie: {type: apiKey, name: session, in: cookie}
+    basic: {type: http, scheme: basic}
+    bearer: {type: http, scheme: bearer, bearerFormat: JWT}
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: SERVER/token
+          scopes: {read: read pets, write: write pets}
+`, "SERVER", server.URL, 1))
+	for _, method := range m.Methods {
+		request, err := http.NewRequest(method.Method, server.URL+method.Path, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %+v", err)
+		}
+		if err := m.Authenticate(request, method, c); err != nil {
+			t.Errorf("Failed to authenticate %s: %+v", method.Operation, err)
+			continue
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("Failed to call %s: %+v", method.Operation, err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("%s isn't authenticated: %s", method.Operation, response.Status)
+		}
+	}
+}
+
+func TestRequestBodiesAreEncoded(t *testing.T) {
+	m := modelForOpenAPI3(t, `openapi: 3.0.0
+info:
+  title: Uploads
+  version: 1.0.0
+paths:
+  /photos:
+    post:
+      operationId: uploadPhotos
+      requestBody:
+        required: true
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                caption: {type: string}
+                metadata: {$ref: "#/components/schemas/Metadata"}
+                photos: {type: array, items: {type: string, format: binary}}
+            encoding:
+              caption:
+                headers:
+                  X-Language: {schema: {type: string}}
+              photos:
+                contentType: image/png, image/jpeg
+      responses:
+        "200":
+          description: ok
+  /data:
+    put:
+      operationId: putData
+      requestBody:
+        content:
+          application/octet-stream: {}
+      responses:
+        "200":
+          description: ok
+  /search:
+    post:
+      operationId: search
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                tags: {type: array, items: {type: string}}
+                query: {type: string}
+            encoding:
+              tags: {style: form, explode: false}
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Metadata: {type: object, properties: {name: {type: string}}}
+`)
+	// Returns the reason that a request body is unexpected, or "" if it isn't.
+	check := func(r *http.Request) string {
+		switch r.URL.Path {
+		case "/photos":
+			reader, err := r.MultipartReader()
+			if err != nil {
+				return err.Error()
+			}
+			var parts []string
+			for {
+				part, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					return err.Error()
+				}
+				content, _ := io.ReadAll(part)
+				parts = append(parts, fmt.Sprintf("%s %s %s %s %s", part.FormName(), part.FileName(),
+					part.Header.Get("Content-Type"), part.Header.Get("X-Language"), bytes.TrimSpace(content)))
+			}
+			expected := []string{
+				"caption  text/plain en A cat",
+				`metadata  application/json  {"name":"Tom"}`,
+				"photos photos image/png  first",
+				"photos photos image/png  second",
+			}
+			if strings.Join(parts, "\n") != strings.Join(expected, "\n") {
+				return fmt.Sprintf("unexpected parts %q", parts)
+			}
+		case "/data":
+			content, _ := io.ReadAll(r.Body)
+			if r.Header.Get("Content-Type") != "application/octet-stream" || string(content) != "data" {
+				return fmt.Sprintf("unexpected data %s %q", r.Header.Get("Content-Type"), content)
+			}
+		case "/search":
+			content, _ := io.ReadAll(r.Body)
+			if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" || string(content) != "tags=a,b&query=a%20cat" {
+				return fmt.Sprintf("unexpected form %s %q", r.Header.Get("Content-Type"), content)
+			}
+		}
+		return ""
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reason := check(r); reason != "" {
+			http.Error(w, reason, http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	values := map[string]interface{}{
+		"uploadPhotos": map[string]interface{}{
+			"caption":  "A cat",
+			"metadata": map[string]string{"name": "Tom"},
+			"photos":   []io.Reader{strings.NewReader("first"), strings.NewReader("second")},
+		},
+		"putData": strings.NewReader("data"),
+		"search":  map[string]interface{}{"tags": []string{"a", "b"}, "query": "a cat"},
+	}
+	for _, method := range m.Methods {
+		if method.RequestBody == nil || len(method.RequestBody.Content) != 1 {
+			t.Errorf("unexpected request body of %s: %v", method.Operation, method.RequestBody)
+			continue
+		}
+		body, contentType, err := method.RequestBody.Content[0].EncodeBody(values[method.Operation], map[string]string{"X-Language": "en"})
+		if err != nil {
+			t.Errorf("Failed to encode the body of %s: %+v", method.Operation, err)
+			continue
+		}
+		request, err := http.NewRequest(method.Method, server.URL+method.Path, body)
+		if err != nil {
+			t.Fatalf("Failed to create request: %+v", err)
+		}
+		request.Header.Set("Content-Type", contentType)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("Failed to call %s: %+v", method.Operation, err)
+		}
+		message, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("unexpected body of %s: %s", method.Operation, message)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surface_v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The delimiters of the values of arrays and objects in query parameters
+// with delimited styles, percent-encoded.
+var styleDelimiters = map[string]string{
+	"spaceDelimited": "%20",
+	"pipeDelimited":  "%7C",
+	"tabDelimited":   "%09",
+}
+
+// EncodeParameter returns the parts of a request that send a value for a
+// parameter, encoded with the style, explode, and allow_reserved of the
+// field. Query and form parameters are encoded as name=value pairs, which
+// are joined with "&" in query strings and form bodies. Path parameters
+// are encoded as the text that replaces their template expressions, and
+// header parameters as header values.
+//
+// Values can be scalars, slices, or maps, whose entries are written in the
+// order of their keys. Nil values aren't sent, so they have no parts.
+func (f *Field) EncodeParameter(value interface{}) []string {
+	if value == nil {
+		return nil
+	}
+	escape := func(s string) string {
+		return escapeParameter(s, f.Position, f.AllowReserved)
+	}
+	name := escape(f.Name)
+	// Arrays are lists of values and objects are lists of keys and values.
+	var values []string
+	scalar, object := false, false
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, escape(scalarText(v.Index(i).Interface())))
+		}
+	case reflect.Map:
+		object = true
+		keys := make([]string, 0, v.Len())
+		entries := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			k := scalarText(key.Interface())
+			keys = append(keys, k)
+			entries[k] = v.MapIndex(key).Interface()
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			values = append(values, escape(k), escape(scalarText(entries[k])))
+		}
+	default:
+		scalar = true
+		values = []string{escape(scalarText(value))}
+	}
+	// Exploded objects are lists of key=value pairs.
+	pairs := func(separator string) []string {
+		var result []string
+		for i := 0; i+1 < len(values); i += 2 {
+			result = append(result, values[i]+separator+values[i+1])
+		}
+		return result
+	}
+	switch style := f.Style; {
+	case style == "matrix":
+		if !f.Explode || scalar {
+			return []string{";" + name + "=" + strings.Join(values, ",")}
+		}
+		if object {
+			return []string{";" + strings.Join(pairs("="), ";")}
+		}
+		return []string{";" + name + "=" + strings.Join(values, ";"+name+"=")}
+	case style == "label":
+		if f.Explode && object {
+			return []string{"." + strings.Join(pairs("="), ".")}
+		}
+		if f.Explode {
+			return []string{"." + strings.Join(values, ".")}
+		}
+		return []string{"." + strings.Join(values, ",")}
+	case style == "deepObject" && object:
+		var parts []string
+		for i := 0; i+1 < len(values); i += 2 {
+			parts = append(parts, name+"%5B"+values[i]+"%5D="+values[i+1])
+		}
+		return parts
+	case style == "form" || style == "deepObject" || styleDelimiters[style] != "":
+		if scalar {
+			return []string{name + "=" + values[0]}
+		}
+		if f.Explode && object {
+			return pairs("=")
+		}
+		if f.Explode {
+			parts := make([]string, len(values))
+			for i, value := range values {
+				parts[i] = name + "=" + value
+			}
+			return parts
+		}
+		delimiter := styleDelimiters[style]
+		if delimiter == "" {
+			delimiter = ","
+		}
+		return []string{name + "=" + strings.Join(values, delimiter)}
+	}
+	// Other parameters are simple parameters.
+	if f.Explode && object {
+		return []string{strings.Join(pairs("="), ",")}
+	}
+	return []string{strings.Join(values, ",")}
+}
+
+// Returns the text of a scalar value.
+func scalarText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprint(value)
+}
+
+// Percent-encodes the characters of a parameter's text that aren't
+// unreserved. Query and form parameters that allow reserved characters
+// keep them, and header values aren't encoded.
+func escapeParameter(s string, position Position, allowReserved bool) string {
+	if position == Position_HEADER {
+		return s
+	}
+	allowReserved = allowReserved && (position == Position_QUERY || position == Position_FORMDATA)
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUnreserved(c) || allowReserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// Returns true if a character can be written in a URL without encoding.
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-._~", c) >= 0
+}
+
+// EncodeBody returns a request body in a media type and its content type.
+// Binary bodies are read from an io.Reader. The values of the parts of
+// multipart/form-data and application/x-www-form-urlencoded bodies are
+// taken from a map[string]interface{}, where binary parts are read from
+// io.Readers, or from a []io.Reader to send several files. Parts in JSON are
+// encoded as JSON and other parts as text, and the headers of parts are
+// taken from headers. Other bodies are encoded as JSON.
+func (media *MediaType) EncodeBody(value interface{}, headers map[string]string) (io.Reader, string, error) {
+	if media.Binary {
+		reader, ok := value.(io.Reader)
+		if !ok {
+			return nil, "", fmt.Errorf("%s bodies are read from readers, not %T", media.Name, value)
+		}
+		return reader, media.Name, nil
+	}
+	if media.Name != "multipart/form-data" && media.Name != "application/x-www-form-urlencoded" {
+		body, err := json.Marshal(value)
+		if err != nil {
+			return nil, "", err
+		}
+		return bytes.NewReader(body), media.Name, nil
+	}
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, "", fmt.Errorf("%s bodies are encoded from maps, not %T", media.Name, value)
+	}
+	if media.Name == "application/x-www-form-urlencoded" {
+		var pairs []string
+		for _, part := range media.Parts {
+			f := &Field{Name: part.Name, Position: Position_FORMDATA, Style: part.Style, Explode: part.Explode, AllowReserved: part.AllowReserved}
+			pairs = append(pairs, f.EncodeParameter(values[part.Name])...)
+		}
+		return strings.NewReader(strings.Join(pairs, "&")), media.Name, nil
+	}
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, part := range media.Parts {
+		value, ok := values[part.Name]
+		if !ok {
+			continue
+		}
+		contents := []interface{}{value}
+		if readers, ok := value.([]io.Reader); ok {
+			contents = contents[:0]
+			for _, r := range readers {
+				contents = append(contents, r)
+			}
+		}
+		for _, content := range contents {
+			if err := part.write(w, content, headers); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &body, w.FormDataContentType(), nil
+}
+
+// Writes a value of a part to a multipart body. Parts with several
+// content types are sent with the first.
+func (part *Part) write(w *multipart.Writer, value interface{}, headers map[string]string) error {
+	contentType := strings.TrimSpace(strings.Split(part.ContentType, ",")[0])
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	disposition := map[string]string{"name": part.Name}
+	if part.Binary {
+		disposition["filename"] = part.Name
+	}
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", disposition))
+	for _, h := range part.Headers {
+		header.Set(h.Name, headers[h.Name])
+	}
+	pw, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	switch {
+	case part.Binary:
+		reader, ok := value.(io.Reader)
+		if !ok {
+			return fmt.Errorf("%s is read from readers, not %T", part.Name, value)
+		}
+		_, err = io.Copy(pw, reader)
+	case contentType == "application/json":
+		err = json.NewEncoder(pw).Encode(value)
+	default:
+		_, err = io.WriteString(pw, scalarText(value))
+	}
+	return err
+}
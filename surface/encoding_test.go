@@ -0,0 +1,111 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surface_v1
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestEncodeParameter checks the examples of the OpenAPI parameter
+// serialization rules.
+func TestEncodeParameter(t *testing.T) {
+	primitive := "blue"
+	array := []string{"blue", "black", "brown"}
+	object := map[string]int{"R": 100, "G": 200, "B": 150}
+	tests := []struct {
+		style    string
+		explode  bool
+		position Position
+		value    interface{}
+		expected string
+	}{
+		{"matrix", false, Position_PATH, primitive, ";color=blue"},
+		{"matrix", false, Position_PATH, array, ";color=blue,black,brown"},
+		{"matrix", false, Position_PATH, object, ";color=B,150,G,200,R,100"},
+		{"matrix", true, Position_PATH, array, ";color=blue;color=black;color=brown"},
+		{"matrix", true, Position_PATH, object, ";B=150;G=200;R=100"},
+		{"label", false, Position_PATH, primitive, ".blue"},
+		{"label", false, Position_PATH, array, ".blue,black,brown"},
+		{"label", true, Position_PATH, array, ".blue.black.brown"},
+		{"label", true, Position_PATH, object, ".B=150.G=200.R=100"},
+		{"simple", false, Position_PATH, array, "blue,black,brown"},
+		{"simple", false, Position_HEADER, object, "B,150,G,200,R,100"},
+		{"simple", true, Position_HEADER, object, "B=150,G=200,R=100"},
+		{"form", false, Position_QUERY, primitive, "color=blue"},
+		{"form", false, Position_QUERY, array, "color=blue,black,brown"},
+		{"form", false, Position_QUERY, object, "color=B,150,G,200,R,100"},
+		{"form", true, Position_QUERY, array, "color=blue&color=black&color=brown"},
+		{"form", true, Position_QUERY, object, "B=150&G=200&R=100"},
+		{"spaceDelimited", false, Position_QUERY, array, "color=blue%20black%20brown"},
+		{"spaceDelimited", true, Position_QUERY, array, "color=blue&color=black&color=brown"},
+		{"pipeDelimited", false, Position_QUERY, array, "color=blue%7Cblack%7Cbrown"},
+		{"pipeDelimited", false, Position_QUERY, object, "color=B%7C150%7CG%7C200%7CR%7C100"},
+		{"deepObject", true, Position_QUERY, object, "color%5BB%5D=150&color%5BG%5D=200&color%5BR%5D=100"},
+		{"form", true, Position_QUERY, nil, ""},
+	}
+	for _, test := range tests {
+		f := &Field{Name: "color", Style: test.style, Explode: test.explode, Position: test.position}
+		if encoded := strings.Join(f.EncodeParameter(test.value), "&"); encoded != test.expected {
+			t.Errorf("unexpected encoding of %v with %s (explode: %t): %s (expected %s)", test.value, test.style, test.explode, encoded, test.expected)
+		}
+	}
+}
+
+func TestEncodeParameterReservedCharacters(t *testing.T) {
+	value := []interface{}{"a/b c", 1.5, true}
+	f := &Field{Name: "q", Style: "form", Explode: true, Position: Position_QUERY}
+	expected := []string{"q=a%2Fb%20c", "q=1.5", "q=true"}
+	if encoded := f.EncodeParameter(value); !reflect.DeepEqual(encoded, expected) {
+		t.Errorf("unexpected encoding %v", encoded)
+	}
+	f.AllowReserved = true
+	expected[0] = "q=a/b%20c"
+	if encoded := f.EncodeParameter(value); !reflect.DeepEqual(encoded, expected) {
+		t.Errorf("unexpected encoding with reserved characters %v", encoded)
+	}
+	// Only query parameters can allow reserved characters.
+	f.Position, f.Style = Position_PATH, "simple"
+	if encoded := f.EncodeParameter("a/b"); !reflect.DeepEqual(encoded, []string{"a%2Fb"}) {
+		t.Errorf("unexpected encoding of a path parameter %v", encoded)
+	}
+}
+
+func TestEncodeBody(t *testing.T) {
+	media := &MediaType{Name: "application/json"}
+	body, contentType, err := media.EncodeBody(map[string]string{"name": "Tom"}, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if content, _ := ioutil.ReadAll(body); string(content) != `{"name":"Tom"}` || contentType != "application/json" {
+		t.Errorf("unexpected body %s %q", contentType, content)
+	}
+	// Values of the wrong types are errors.
+	for _, media := range []*MediaType{
+		{Name: "application/octet-stream", Binary: true},
+		{Name: "multipart/form-data"},
+		{Name: "multipart/form-data", Parts: []*Part{{Name: "photo", ContentType: "image/png", Binary: true}}},
+	} {
+		value := interface{}("data")
+		if len(media.Parts) > 0 {
+			value = map[string]interface{}{"photo": "data"}
+		}
+		if _, _, err := media.EncodeBody(value, nil); err == nil {
+			t.Errorf("%s: expected an error", media.Name)
+		}
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"path"
 	"strconv"
 	"strings"
+
+	"github.com/okkoye/gnostic/compiler"
 )
 
 // The structure to transport information during the recursive calls inside model_openapiv2.go
@@ -33,6 +35,11 @@ type FieldInfo struct {
 	fieldPosition Position
 	fieldName     string
 	enumValues    []string
+	extensions    map[string]string
+	// How parameters are serialized
+	style         string
+	explode       bool
+	allowReserved bool
 }
 
 func (m *Model) addType(t *Type) {
@@ -91,10 +98,28 @@ func makeFieldAndAppendToType(info *FieldInfo, schemaType *Type, fieldName strin
 			f.Name = fieldName
 		}
 		f.Type, f.Kind, f.Format, f.Position, f.EnumValues = info.fieldType, info.fieldKind, info.fieldFormat, info.fieldPosition, info.enumValues
+		f.Extensions = info.extensions
+		f.Style, f.Explode, f.AllowReserved = info.style, info.explode, info.allowReserved
 		schemaType.Fields = append(schemaType.Fields, f)
 	}
 }
 
+// mergeExtensions returns the extensions in 'extensions' and 'overrides'. When both have an extension
+// with the same name, the one in 'overrides' is used.
+func mergeExtensions(extensions, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return extensions
+	}
+	merged := make(map[string]string, len(extensions)+len(overrides))
+	for name, value := range extensions {
+		merged[name] = value
+	}
+	for name, value := range overrides {
+		merged[name] = value
+	}
+	return merged
+}
+
 // Helper method to determine the type of the value property for a map.
 func determineMapValueType(fInfo FieldInfo) (mapValueType string) {
 	if fInfo.fieldKind == FieldKind_ARRAY {
@@ -121,6 +146,28 @@ func convertStatusCodeToText(c string) (statusText string) {
 	return statusText
 }
 
+// Returns true if bodies of a media type are forms with parts that correspond to the properties of their schema.
+func isFormMediaType(mediaType string) bool {
+	return mediaType == "application/x-www-form-urlencoded" || strings.HasPrefix(mediaType, "multipart/")
+}
+
+// Marks the responses that describe errors. Responses with 4XX and 5XX status codes are errors, and default responses
+// are errors if there are successful responses.
+func markErrorResponses(responses []*Response) {
+	successful := false
+	for _, r := range responses {
+		successful = successful || strings.HasPrefix(r.StatusCode, "2")
+	}
+	for _, r := range responses {
+		switch {
+		case strings.HasPrefix(r.StatusCode, "4"), strings.HasPrefix(r.StatusCode, "5"):
+			r.Error = true
+		case r.StatusCode == "default":
+			r.Error = successful
+		}
+	}
+}
+
 // Searches all created types so far and returns the Type where 'typeName' matches.
 func findType(types []*Type, typeName string) *Type {
 	for _, t := range types {
@@ -133,6 +180,9 @@ func findType(types []*Type, typeName string) *Type {
 
 // Returns true if s is a valid URL.
 func isSymbolicReference(s string) bool {
+	if compiler.IsWindowsPath(s) {
+		return false
+	}
 	_, err := url.ParseRequestURI(s)
 	if err != nil {
 		return false
@@ -140,6 +190,11 @@ func isSymbolicReference(s string) bool {
 	return true
 }
 
+// Converts a name to a JSON Pointer reference token.
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
 // Replace encoded URLS with actual characters
 func validTypeForRef(XRef string) string {
 	t, _ := url.QueryUnescape(typeForRef(XRef))
@@ -16,7 +16,9 @@ package surface_v1
 
 import (
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/okkoye/gnostic/compiler"
 	openapiv2 "github.com/okkoye/gnostic/openapiv2"
@@ -49,6 +51,8 @@ func (b *OpenAPI2Builder) buildModel(document *openapiv2.Document, sourceName st
 	// Set model properties from passed-in document.
 	b.model.Name = document.Info.Title
 	b.buildFromDocument(document)
+	b.model.SecuritySchemes = securitySchemesForOpenAPI2(document.SecurityDefinitions)
+	b.model.detectPagination()
 	err := b.buildSymbolicReferences(document, sourceName)
 	if err != nil {
 		log.Printf("Error while building symbolic references. This might cause the plugin to fail: %v", err)
@@ -141,6 +145,7 @@ func (b *OpenAPI2Builder) buildSymbolicReferences(document *openapiv2.Document,
 			b.model.SymbolicReferences = append(b.model.SymbolicReferences, ref)
 		}
 	}
+	sort.Strings(b.model.SymbolicReferences)
 	// Clear compiler cache for recursive calls
 	compiler.ClearInfoCache()
 	return nil
@@ -180,19 +185,25 @@ func (b *OpenAPI2Builder) buildFromNamedPath(name string, pathItem *openapiv2.Pa
 				Method:      method,
 				Name:        sanitizeOperationName(op.OperationId),
 				Description: op.Description,
+				Extensions:  extensionsForOpenAPI2(op.VendorExtension),
 			}
 			if m.Name == "" {
 				m.Name = generateOperationName(method, name)
 			}
-			m.ParametersTypeName, m.ResponsesTypeName = b.buildFromNamedOperation(m.Name, op)
+			m.ParametersTypeName, m.ResponsesTypeName, m.Responses = b.buildFromNamedOperation(m.Name, op)
+			m.Security = securityRequirementsForOpenAPI2(op, b.document)
+			m.RequestBody = b.buildRequestBody(op, m.ParametersTypeName)
+			if hasLinkHeaderForOpenAPI2(op) {
+				m.Pagination = &Pagination{Style: PaginationStyle_LINK}
+			}
 			b.model.addMethod(m)
 		}
 	}
 }
 
-// Builds the "Parameters" and "Responses" types for an operation, adds them to the model, and returns the names of the types.
-// If no such Type is added to the model an empty string is returned.
-func (b *OpenAPI2Builder) buildFromNamedOperation(name string, operation *openapiv2.Operation) (parametersTypeName string, responseTypeName string) {
+// Builds the "Parameters" and "Responses" types for an operation, adds them to the model, and returns the names of the types
+// and the responses of the operation. If no such Type is added to the model an empty string is returned.
+func (b *OpenAPI2Builder) buildFromNamedOperation(name string, operation *openapiv2.Operation) (parametersTypeName string, responseTypeName string, methodResponses []*Response) {
 	// At first, we build the operations input parameters. This includes parameters (like PATH or QUERY parameters).
 	operationParameters := makeType(name + "Parameters")
 	operationParameters.Description = operationParameters.Name + " holds parameters to " + name
@@ -220,13 +231,130 @@ func (b *OpenAPI2Builder) buildFromNamedOperation(name string, operation *openap
 				name := namedResponse.Name + " " + contentType
 				makeFieldAndAppendToType(fieldInfo, operationResponses, name)
 			}
+			response := b.buildResponse(name, namedResponse.Name, namedResponse.Value, fieldInfo, produces)
+			methodResponses = append(methodResponses, response)
 		}
 		if len(operationResponses.Fields) > 0 {
 			b.model.addType(operationResponses)
 			responseTypeName = operationResponses.Name
 		}
+		markErrorResponses(methodResponses)
+	}
+	return parametersTypeName, responseTypeName, methodResponses
+}
+
+// Builds a Response of the method with the given name from the field that describes its body in each of the media types
+// that it produces and from its headers.
+func (b *OpenAPI2Builder) buildResponse(methodName, statusCode string, responseOrRef *openapiv2.ResponseValue, fieldInfo *FieldInfo, produces []string) *Response {
+	r := &Response{StatusCode: statusCode}
+	content := makeType("")
+	for _, contentType := range produces {
+		makeFieldAndAppendToType(fieldInfo, content, contentType)
+	}
+	r.Content = content.Fields
+	response := responseOrRef.GetResponse()
+	if ref := responseOrRef.GetJsonReference(); ref != nil {
+		response = b.responseForReference(ref.XRef)
+	}
+	if response == nil {
+		return r
+	}
+	r.Description = response.Description
+	headers := makeType("")
+	for _, namedHeader := range response.GetHeaders().GetAdditionalProperties() {
+		header := namedHeader.Value
+		fInfo := &FieldInfo{fieldName: namedHeader.Name, fieldPosition: Position_HEADER, fieldFormat: header.Format}
+		b.adaptFieldKindAndFieldType(fInfo, header.Type, header.Items)
+		fInfo.extensions = extensionsForOpenAPI2(header.VendorExtension)
+		setSerializationForCollectionFormat(fInfo, header.CollectionFormat)
+		makeFieldAndAppendToType(fInfo, headers, "")
 	}
-	return parametersTypeName, responseTypeName
+	r.Headers = headers.Fields
+	return r
+}
+
+// Builds the RequestBody of a method from the body or form data parameters of its operation in each of the media types
+// that it consumes. Forms are sent in the form media types that the operation consumes, or if there aren't any, as
+// application/x-www-form-urlencoded or, if they include files, multipart/form-data. The fields of the parameters are in the method's parameters type.
+func (b *OpenAPI2Builder) buildRequestBody(operation *openapiv2.Operation, parametersTypeName string) *RequestBody {
+	consumes := b.document.Consumes
+	if operation.Consumes != nil {
+		consumes = operation.Consumes
+	}
+	parameters := findType(b.model.Types, parametersTypeName)
+	var body *RequestBody
+	parts := make([]*Part, 0)
+	for _, paramOrRef := range operation.Parameters {
+		param := paramOrRef.GetParameter()
+		if ref := paramOrRef.GetJsonReference(); ref != nil {
+			param = b.parameterForReference(ref.XRef)
+		}
+		if bodyParameter := param.GetBodyParameter(); bodyParameter != nil {
+			body = &RequestBody{Description: bodyParameter.Description, Required: bodyParameter.Required}
+			schema := bodyParameter.GetSchema()
+			for _, contentType := range consumes {
+				mediaType := &MediaType{Name: contentType}
+				if f := parameters.FieldWithName(bodyParameter.Name); f != nil && f.Kind == FieldKind_REFERENCE {
+					mediaType.TypeName = f.Type
+				}
+				mediaType.Binary = schema.GetFormat() == "binary" ||
+					contentType == "application/octet-stream" && schema.GetXRef() == "" && len(schema.GetType().GetValue()) == 0
+				body.Content = append(body.Content, mediaType)
+			}
+		}
+		if formData := param.GetNonBodyParameter().GetFormDataParameterSubSchema(); formData != nil {
+			if body == nil {
+				body = &RequestBody{}
+			}
+			body.Required = body.Required || formData.Required
+			part := &Part{Name: formData.Name, ContentType: "text/plain", Style: "form"}
+			if formData.Type == "file" {
+				part.ContentType, part.Binary = "application/octet-stream", true
+			}
+			if f := parameters.FieldWithName(formData.Name); f != nil {
+				part.Style, part.Explode = f.Style, f.Explode
+			}
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) > 0 {
+		for _, contentType := range consumes {
+			if isFormMediaType(contentType) {
+				body.Content = append(body.Content, &MediaType{Name: contentType, Parts: parts})
+			}
+		}
+		if len(body.Content) == 0 {
+			// Files can only be sent in multipart forms.
+			contentType := "application/x-www-form-urlencoded"
+			for _, part := range parts {
+				if part.Binary {
+					contentType = "multipart/form-data"
+				}
+			}
+			body.Content = []*MediaType{{Name: contentType, Parts: parts}}
+		}
+	}
+	return body
+}
+
+// Returns the parameter definition of the document that a reference refers to, or nil if there isn't one.
+func (b *OpenAPI2Builder) parameterForReference(ref string) *openapiv2.Parameter {
+	for _, namedParameter := range b.document.GetParameters().GetAdditionalProperties() {
+		if ref == "#/parameters/"+escapePointer(namedParameter.Name) {
+			return namedParameter.Value
+		}
+	}
+	return nil
+}
+
+// Returns the response definition of the document that a reference refers to, or nil if there isn't one.
+func (b *OpenAPI2Builder) responseForReference(ref string) *openapiv2.Response {
+	for _, namedResponse := range b.document.GetResponses().GetAdditionalProperties() {
+		if ref == "#/responses/"+escapePointer(namedResponse.Name) {
+			return namedResponse.Value
+		}
+	}
+	return nil
 }
 
 // A helper method to differentiate between references and actual objects.
@@ -240,6 +368,8 @@ func (b *OpenAPI2Builder) buildFromParamOrRef(paramOrRef *openapiv2.ParametersIt
 		t := findType(b.model.Types, validTypeForRef(ref.XRef))
 		if t != nil && len(t.Fields) > 0 {
 			fInfo.fieldKind, fInfo.fieldType, fInfo.fieldName, fInfo.fieldPosition = FieldKind_REFERENCE, validTypeForRef(ref.XRef), t.Name, t.Fields[0].Position
+			fInfo.extensions = t.Fields[0].Extensions
+			fInfo.style, fInfo.explode, fInfo.allowReserved = t.Fields[0].Style, t.Fields[0].Explode, t.Fields[0].AllowReserved
 			return fInfo
 		}
 		// TODO: This might happen for symbolic references --> fInfo.Position defaults to 'BODY' which is wrong.
@@ -257,6 +387,7 @@ func (b *OpenAPI2Builder) buildFromParam(parameter *openapiv2.Parameter) (fInfo
 		fInfo = b.buildFromSchemaOrReference(bodyParam.Name, bodyParam.Schema)
 		if fInfo != nil {
 			fInfo.fieldName, fInfo.fieldPosition = bodyParam.Name, Position_BODY
+			fInfo.extensions = mergeExtensions(fInfo.extensions, extensionsForOpenAPI2(bodyParam.VendorExtension))
 			return fInfo
 		}
 	} else if nonBodyParam := parameter.GetNonBodyParameter(); nonBodyParam != nil {
@@ -274,25 +405,52 @@ func (b *OpenAPI2Builder) buildFromNonBodyParameter(nonBodyParameter *openapiv2.
 	if headerParameter != nil {
 		fInfo.fieldName, fInfo.fieldPosition, fInfo.fieldFormat = headerParameter.Name, Position_HEADER, headerParameter.Format
 		b.adaptFieldKindAndFieldType(fInfo, headerParameter.Type, headerParameter.Items)
+		fInfo.extensions = extensionsForOpenAPI2(headerParameter.VendorExtension)
+		setSerializationForCollectionFormat(fInfo, headerParameter.CollectionFormat)
 	}
 	formDataParameter := nonBodyParameter.GetFormDataParameterSubSchema()
 	if formDataParameter != nil {
 		fInfo.fieldName, fInfo.fieldPosition, fInfo.fieldFormat = formDataParameter.Name, Position_FORMDATA, formDataParameter.Format
 		b.adaptFieldKindAndFieldType(fInfo, formDataParameter.Type, formDataParameter.Items)
+		fInfo.extensions = extensionsForOpenAPI2(formDataParameter.VendorExtension)
+		setSerializationForCollectionFormat(fInfo, formDataParameter.CollectionFormat)
 	}
 	queryParameter := nonBodyParameter.GetQueryParameterSubSchema()
 	if queryParameter != nil {
 		fInfo.fieldName, fInfo.fieldPosition, fInfo.fieldFormat = queryParameter.Name, Position_QUERY, queryParameter.Format
 		b.adaptFieldKindAndFieldType(fInfo, queryParameter.Type, queryParameter.Items)
+		fInfo.extensions = extensionsForOpenAPI2(queryParameter.VendorExtension)
+		setSerializationForCollectionFormat(fInfo, queryParameter.CollectionFormat)
 	}
 	pathParameter := nonBodyParameter.GetPathParameterSubSchema()
 	if pathParameter != nil {
 		fInfo.fieldName, fInfo.fieldPosition, fInfo.fieldFormat = pathParameter.Name, Position_PATH, pathParameter.Format
 		b.adaptFieldKindAndFieldType(fInfo, pathParameter.Type, pathParameter.Items)
+		fInfo.extensions = extensionsForOpenAPI2(pathParameter.VendorExtension)
+		setSerializationForCollectionFormat(fInfo, pathParameter.CollectionFormat)
 	}
 	return fInfo
 }
 
+// Sets how a parameter is serialized from its collectionFormat, using the
+// equivalent OpenAPI 3 style and explode values.
+func setSerializationForCollectionFormat(fInfo *FieldInfo, collectionFormat string) {
+	fInfo.style = "simple"
+	if fInfo.fieldPosition == Position_QUERY || fInfo.fieldPosition == Position_FORMDATA {
+		fInfo.style = "form"
+	}
+	switch collectionFormat {
+	case "ssv":
+		fInfo.style = "spaceDelimited"
+	case "tsv":
+		fInfo.style = "tabDelimited"
+	case "pipes":
+		fInfo.style = "pipeDelimited"
+	case "multi":
+		fInfo.explode = true
+	}
+}
+
 // Changes the fieldKind and fieldType inside of 'fInfo' based on different conditions. In case of an array we have to
 // consider that it consists of indefinitely nested items.
 func (b *OpenAPI2Builder) adaptFieldKindAndFieldType(fInfo *FieldInfo, parameterType string, parameterItems *openapiv2.PrimitivesItems) {
@@ -374,7 +532,7 @@ func (b *OpenAPI2Builder) buildFromSchemaOrReference(name string, schema *openap
 //     created whenever Types are created (higher up in the callstack). This possibility can be considered as the "base condition"
 //     for the recursive approach.
 func (b *OpenAPI2Builder) buildFromSchema(name string, schema *openapiv2.Schema) (fInfo *FieldInfo) {
-	fInfo = &FieldInfo{}
+	fInfo = &FieldInfo{extensions: extensionsForOpenAPI2(schema.VendorExtension)}
 
 	t := ""
 	if schema.Type != nil && len(schema.Type.Value) == 1 && schema.Type.Value[0] != "null" {
@@ -385,6 +543,7 @@ func (b *OpenAPI2Builder) buildFromSchema(name string, schema *openapiv2.Schema)
 		fallthrough
 	case "object":
 		schemaType := makeType(name)
+		schemaType.Extensions = fInfo.extensions
 		if schema.Properties != nil && schema.Properties.AdditionalProperties != nil {
 			for _, namedSchema := range schema.Properties.AdditionalProperties {
 				fieldInfo := b.buildFromSchemaOrReference(namedSchema.Name, namedSchema.Value)
@@ -446,3 +605,30 @@ func (b *OpenAPI2Builder) buildFromSchema(name string, schema *openapiv2.Schema)
 	log.Printf("Unimplemented: could not find field info for schema with name: '%v' and properties: %v", name, schema)
 	return nil
 }
+
+// extensionsForOpenAPI2 returns the YAML values of vendor extensions by name.
+func extensionsForOpenAPI2(namedAnys []*openapiv2.NamedAny) map[string]string {
+	if len(namedAnys) == 0 {
+		return nil
+	}
+	extensions := make(map[string]string, len(namedAnys))
+	for _, namedAny := range namedAnys {
+		extensions[namedAny.Name] = strings.TrimSuffix(namedAny.GetValue().GetYaml(), "\n")
+	}
+	return extensions
+}
+
+// Returns true if a successful response of an operation has a Link header.
+func hasLinkHeaderForOpenAPI2(operation *openapiv2.Operation) bool {
+	for _, namedResponse := range operation.GetResponses().GetResponseCode() {
+		if !strings.HasPrefix(namedResponse.Name, "2") {
+			continue
+		}
+		for _, namedHeader := range namedResponse.GetValue().GetResponse().GetHeaders().GetAdditionalProperties() {
+			if isLinkHeader(namedHeader.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
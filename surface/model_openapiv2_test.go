@@ -53,3 +53,231 @@ func TestModelOpenAPIV2(t *testing.T) {
 	x, _ := protojson.Marshal(m)
 	t.Logf("Model: %s", x)
 }
+
+func TestExtensionsFromOpenAPI2(t *testing.T) {
+	document, err := openapiv2.ParseDocument([]byte(`swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      x-go-name: FetchPet
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+        x-go-name: ID
+      - name: tags
+        in: query
+        type: array
+        items: {type: string}
+        collectionFormat: multi
+      - name: colors
+        in: query
+        type: array
+        items: {type: string}
+        collectionFormat: pipes
+      responses:
+        "200":
+          description: ok
+definitions:
+  Pet:
+    type: object
+    x-go-name: Animal
+    properties:
+      tag:
+        type: string
+        x-nullable: true
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI2(document, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	if got := m.Methods[0].Extensions; !cmp.Equal(got, map[string]string{"x-go-name": "FetchPet"}) {
+		t.Errorf("unexpected method extensions %v", got)
+	}
+	parameters := findType(m.Types, "GetPetParameters")
+	if got := parameters.FieldWithName("id").Extensions; !cmp.Equal(got, map[string]string{"x-go-name": "ID"}) {
+		t.Errorf("unexpected parameter extensions %v", got)
+	}
+	// Collection formats are described with OpenAPI 3 styles.
+	if f := parameters.FieldWithName("id"); f.Style != "simple" || f.Explode {
+		t.Errorf("unexpected serialization of id: %s %t", f.Style, f.Explode)
+	}
+	if f := parameters.FieldWithName("tags"); f.Style != "form" || !f.Explode {
+		t.Errorf("unexpected serialization of tags: %s %t", f.Style, f.Explode)
+	}
+	if f := parameters.FieldWithName("colors"); f.Style != "pipeDelimited" || f.Explode {
+		t.Errorf("unexpected serialization of colors: %s %t", f.Style, f.Explode)
+	}
+	pet := findType(m.Types, "Pet")
+	if got := pet.Extensions; !cmp.Equal(got, map[string]string{"x-go-name": "Animal"}) {
+		t.Errorf("unexpected type extensions %v", got)
+	}
+	if got := pet.FieldWithName("tag").Extensions; !cmp.Equal(got, map[string]string{"x-nullable": "true"}) {
+		t.Errorf("unexpected field extensions %v", got)
+	}
+}
+
+func TestSecurityFromOpenAPI2(t *testing.T) {
+	document, err := openapiv2.ParseDocument([]byte(`swagger: "2.0"
+info:
+  title: Security
+  version: 1.0.0
+security:
+- basic: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      security:
+      - oauth: [read]
+      responses:
+        "200":
+          description: ok
+    post:
+      operationId: createPet
+      responses:
+        "200":
+          description: ok
+securityDefinitions:
+  basic: {type: basic}
+  oauth:
+    type: oauth2
+    flow: application
+    tokenUrl: https://example.com/token
+    scopes: {read: read pets}
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI2(document, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	expectedSchemes := []*SecurityScheme{
+		{Name: "basic", Type: "http", Scheme: "basic"},
+		{Name: "oauth", Type: "oauth2", Flows: []*OAuthFlow{
+			{Name: "clientCredentials", TokenUrl: "https://example.com/token", Scopes: map[string]string{"read": "read pets"}},
+		}},
+	}
+	if diff := cmp.Diff(expectedSchemes, m.SecuritySchemes, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected security schemes (-want +got):\n%s", diff)
+	}
+	expected := [][]*SecurityRequirement{
+		{{Schemes: []string{"oauth"}, Scopes: []string{"read"}}},
+		{{Schemes: []string{"basic"}}},
+	}
+	for i, method := range m.Methods {
+		if diff := cmp.Diff(expected[i], method.Security, protocmp.Transform()); diff != "" {
+			t.Errorf("unexpected security of %s (-want +got):\n%s", method.Name, diff)
+		}
+	}
+}
+
+func TestResponsesFromOpenAPI2(t *testing.T) {
+	document, err := openapiv2.ParseDocument([]byte(`swagger: "2.0"
+info:
+  title: Responses
+  version: 1.0.0
+produces: [application/json]
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: A list of pets.
+          headers:
+            X-Next: {type: array, items: {type: string}, collectionFormat: csv}
+          schema: {type: array, items: {$ref: "#/definitions/Pet"}}
+        "500":
+          $ref: "#/responses/ServerError"
+responses:
+  ServerError:
+    description: Server error.
+    schema: {$ref: "#/definitions/Error"}
+definitions:
+  Pet: {type: object, properties: {name: {type: string}}}
+  Error: {type: object, properties: {message: {type: string}}}
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI2(document, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	expected := []*Response{
+		{
+			StatusCode:  "200",
+			Description: "A list of pets.",
+			Content:     []*Field{{Name: "application/json", Type: "Pet", Kind: FieldKind_ARRAY}},
+			Headers:     []*Field{{Name: "X-Next", Type: "string", Kind: FieldKind_ARRAY, Position: Position_HEADER, Style: "simple"}},
+		},
+		{
+			StatusCode:  "500",
+			Description: "Server error.",
+			Content:     []*Field{{Name: "application/json", Type: "ServerError", Kind: FieldKind_REFERENCE}},
+			Error:       true,
+		},
+	}
+	if diff := cmp.Diff(expected, m.Methods[0].Responses, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected responses (-want +got):\n%s", diff)
+	}
+}
+
+func TestRequestBodyFromOpenAPI2(t *testing.T) {
+	document, err := openapiv2.ParseDocument([]byte(`swagger: "2.0"
+info:
+  title: Uploads
+  version: 1.0.0
+paths:
+  /pets/{id}/photo:
+    post:
+      operationId: uploadPhoto
+      parameters:
+      - {name: id, in: path, required: true, type: string}
+      - {name: caption, in: formData, type: string}
+      - {name: file, in: formData, required: true, type: file}
+      responses:
+        "200":
+          description: ok
+  /pets:
+    post:
+      operationId: createPet
+      consumes: [application/json]
+      parameters:
+      - {name: pet, in: body, required: true, schema: {$ref: "#/definitions/Pet"}}
+      responses:
+        "200":
+          description: ok
+definitions:
+  Pet: {type: object, properties: {name: {type: string}}}
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI2(document, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	expected := []*RequestBody{
+		{Required: true, Content: []*MediaType{{Name: "multipart/form-data", Parts: []*Part{
+			{Name: "caption", ContentType: "text/plain", Style: "form"},
+			{Name: "file", ContentType: "application/octet-stream", Binary: true, Style: "form"},
+		}}}},
+		{Required: true, Content: []*MediaType{{Name: "application/json", TypeName: "Pet"}}},
+	}
+	for i, method := range m.Methods {
+		if diff := cmp.Diff(expected[i], method.RequestBody, protocmp.Transform()); diff != "" {
+			t.Errorf("unexpected request body of %s (-want +got):\n%s", method.Name, diff)
+		}
+	}
+}
@@ -16,6 +16,8 @@ package surface_v1
 
 import (
 	"log"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/okkoye/gnostic/compiler"
@@ -23,17 +25,25 @@ import (
 )
 
 type OpenAPI3Builder struct {
-	model    *Model
-	document *openapiv3.Document
+	model       *Model
+	document    *openapiv3.Document
+	annotations *compiler.Annotations
 }
 
 // NewModelFromOpenAPIv3 builds a model of an API service for use in code generation.
 func NewModelFromOpenAPI3(document *openapiv3.Document, sourceName string) (*Model, error) {
-	return newOpenAPI3Builder(document).buildModel(document, sourceName)
+	return NewModelFromOpenAPI3WithAnnotations(document, nil, sourceName)
 }
 
-func newOpenAPI3Builder(document *openapiv3.Document) *OpenAPI3Builder {
-	return &OpenAPI3Builder{model: &Model{}, document: document}
+// NewModelFromOpenAPI3WithAnnotations builds a model like
+// NewModelFromOpenAPI3, using the annotations that were recorded when the
+// document was compiled to find the fields that were explicitly set to false.
+func NewModelFromOpenAPI3WithAnnotations(document *openapiv3.Document, annotations *compiler.Annotations, sourceName string) (*Model, error) {
+	return newOpenAPI3Builder(document, annotations).buildModel(document, sourceName)
+}
+
+func newOpenAPI3Builder(document *openapiv3.Document, annotations *compiler.Annotations) *OpenAPI3Builder {
+	return &OpenAPI3Builder{model: &Model{}, document: document, annotations: annotations}
 }
 
 // Fills the surface model with information from a parsed OpenAPI description. The surface model provides that information
@@ -49,6 +59,8 @@ func (b *OpenAPI3Builder) buildModel(document *openapiv3.Document, sourceName st
 	// Set model properties from passed-in document.
 	b.model.Name = document.Info.Title
 	b.buildFromDocument(document)
+	b.model.SecuritySchemes = securitySchemesForOpenAPI3(document.Components)
+	b.model.detectPagination()
 	err := b.buildSymbolicReferences(document, sourceName)
 	if err != nil {
 		log.Printf("Error while building symbolic references. This might cause the plugin to fail: %v", err)
@@ -78,7 +90,7 @@ func (b *OpenAPI3Builder) buildFromComponents(components *openapiv3.Components)
 		// The name gets passed up the callstack and is therefore contained inside fInfo. That is why we pass "" as fieldName
 		// A type with that parameter was never created, so we still need to do that.
 		t := makeType(namedParameter.Name)
-		fInfo := b.buildFromParamOrRef(namedParameter.Value)
+		fInfo := b.buildFromParamOrRef(namedParameter.Value, "/components/parameters/"+escapePointer(namedParameter.Name))
 		makeFieldAndAppendToType(fInfo, t, "")
 		if len(t.Fields) > 0 {
 			b.model.addType(t)
@@ -86,7 +98,7 @@ func (b *OpenAPI3Builder) buildFromComponents(components *openapiv3.Components)
 	}
 
 	for _, namedResponses := range components.GetResponses().GetAdditionalProperties() {
-		fInfos := b.buildFromResponseOrRef(namedResponses.Name, namedResponses.Value)
+		fInfos := b.buildFromResponseOrRef(namedResponses.Name, namedResponses.Name, namedResponses.Value)
 		for _, fInfo := range fInfos {
 			b.checkForExistence(namedResponses.Name, fInfo)
 		}
@@ -123,6 +135,7 @@ func (b *OpenAPI3Builder) buildSymbolicReferences(document *openapiv3.Document,
 			b.model.SymbolicReferences = append(b.model.SymbolicReferences, ref)
 		}
 	}
+	sort.Strings(b.model.SymbolicReferences)
 	// Clear compiler cache for recursive calls
 	compiler.ClearInfoCache()
 	return nil
@@ -157,24 +170,34 @@ func (b *OpenAPI3Builder) buildFromNamedPath(name string, pathItem *openapiv3.Pa
 				Method:      method,
 				Name:        sanitizeOperationName(op.OperationId),
 				Description: op.Description,
+				Extensions:  extensionsForOpenAPI3(op.SpecificationExtension),
 			}
 			if m.Name == "" {
 				m.Name = generateOperationName(method, name)
 			}
-			m.ParametersTypeName, m.ResponsesTypeName = b.buildFromNamedOperation(m.Name, op)
+			pointer := "/paths/" + escapePointer(name) + "/" + strings.ToLower(method)
+			m.ParametersTypeName, m.ResponsesTypeName, m.Responses = b.buildFromNamedOperation(m.Name, op, pointer)
+			m.Security = securityRequirementsForOpenAPI3(op, b.document)
+			if op.RequestBody != nil {
+				m.RequestBody = b.buildRequestBody(m.Name, op.RequestBody, pointer+"/requestBody", m.ParametersTypeName)
+			}
+			if hasLinkHeaderForOpenAPI3(op) {
+				m.Pagination = &Pagination{Style: PaginationStyle_LINK}
+			}
 			b.model.addMethod(m)
 		}
 	}
 }
 
-// Builds the "Parameters" and "Responses" types for an operation, adds them to the model, and returns the names of the types.
-// If no such Type is added to the model an empty string is returned.
-func (b *OpenAPI3Builder) buildFromNamedOperation(name string, operation *openapiv3.Operation) (parametersTypeName string, responseTypeName string) {
+// Builds the "Parameters" and "Responses" types for an operation, adds them to the model, and returns the names of the types
+// and the responses of the operation. If no such Type is added to the model an empty string is returned. The pointer is
+// the JSON pointer of the operation.
+func (b *OpenAPI3Builder) buildFromNamedOperation(name string, operation *openapiv3.Operation, pointer string) (parametersTypeName string, responseTypeName string, methodResponses []*Response) {
 	// At first, we build the operations input parameters. This includes parameters (like PATH or QUERY parameters) and a request body
 	operationParameters := makeType(name + "Parameters")
 	operationParameters.Description = operationParameters.Name + " holds parameters to " + name
-	for _, paramOrRef := range operation.Parameters {
-		fieldInfo := b.buildFromParamOrRef(paramOrRef)
+	for i, paramOrRef := range operation.Parameters {
+		fieldInfo := b.buildFromParamOrRef(paramOrRef, pointer+"/parameters/"+strconv.Itoa(i))
 		// For parameters the name of the field is contained inside fieldInfo. That is why we pass "" as fieldName
 		makeFieldAndAppendToType(fieldInfo, operationParameters, "")
 	}
@@ -194,37 +217,214 @@ func (b *OpenAPI3Builder) buildFromNamedOperation(name string, operation *openap
 		operationResponses := makeType(name + "Responses")
 		operationResponses.Description = operationResponses.Name + " holds responses of " + name
 		for _, namedResponse := range responses.ResponseOrReference {
-			fieldInfos := b.buildFromResponseOrRef(namedResponse.Name, namedResponse.Value)
+			// The fields of responses are named by their status codes and the types of their bodies by their operations.
+			typeName := operation.OperationId + convertStatusCodeToText(namedResponse.Name)
+			fieldInfos := b.buildFromResponseOrRef(namedResponse.Name, typeName, namedResponse.Value)
 			for _, fieldInfo := range fieldInfos {
 				// For responses the name of the field is contained inside fieldInfo. That is why we pass "" as fieldName.
 				makeFieldAndAppendToType(fieldInfo, operationResponses, "")
 			}
+			response := b.buildResponse(name, namedResponse.Name, namedResponse.Value, fieldInfos, namedResponse.Name+" ")
+			methodResponses = append(methodResponses, response)
 		}
 		if responses.Default != nil {
-			fieldInfos := b.buildFromResponseOrRef(operation.OperationId+"Default", responses.Default)
+			fieldInfos := b.buildFromResponseOrRef(operation.OperationId+"Default", operation.OperationId+"Default", responses.Default)
 			for _, fieldInfo := range fieldInfos {
 				makeFieldAndAppendToType(fieldInfo, operationResponses, "default")
 			}
+			response := b.buildResponse(name, "default", responses.Default, fieldInfos, operation.OperationId+"Default ")
+			methodResponses = append(methodResponses, response)
 		}
 		if len(operationResponses.Fields) > 0 {
 			b.model.addType(operationResponses)
 			responseTypeName = operationResponses.Name
 		}
+		markErrorResponses(methodResponses)
+	}
+	return parametersTypeName, responseTypeName, methodResponses
+}
+
+// Builds a Response of the method with the given name from the fields that describe its body and from its headers.
+// The names of body fields start with prefix, which is removed to leave their media types.
+func (b *OpenAPI3Builder) buildResponse(methodName, statusCode string, responseOrRef *openapiv3.ResponseOrReference, fieldInfos []*FieldInfo, prefix string) *Response {
+	r := &Response{StatusCode: statusCode}
+	content := makeType("")
+	for _, fieldInfo := range fieldInfos {
+		makeFieldAndAppendToType(fieldInfo, content, "")
+	}
+	for _, f := range content.Fields {
+		f.Name = strings.TrimPrefix(f.Name, prefix)
+	}
+	r.Content = content.Fields
+	response := responseOrRef.GetResponse()
+	if ref := responseOrRef.GetReference(); ref != nil {
+		response = b.responseForReference(ref.XRef)
+	}
+	if response == nil {
+		return r
+	}
+	r.Description = response.Description
+	r.Headers = b.buildFromHeaders(methodName+" "+statusCode, response.GetHeaders())
+	return r
+}
+
+// Builds the fields that describe headers. Types that are needed for the headers are named with the given prefix.
+func (b *OpenAPI3Builder) buildFromHeaders(prefix string, headersOrRefs *openapiv3.HeadersOrReferences) []*Field {
+	headers := makeType("")
+	for _, namedHeader := range headersOrRefs.GetAdditionalProperties() {
+		header := namedHeader.GetValue().GetHeader()
+		if ref := namedHeader.GetValue().GetReference(); ref != nil {
+			header = b.headerForReference(ref.XRef)
+		}
+		fInfo := b.buildFromSchemaOrReference(prefix+" "+namedHeader.Name, header.GetSchema())
+		if fInfo != nil {
+			fInfo.fieldName, fInfo.fieldPosition = namedHeader.Name, Position_HEADER
+			fInfo.extensions = mergeExtensions(fInfo.extensions, extensionsForOpenAPI3(header.GetSpecificationExtension()))
+		}
+		makeFieldAndAppendToType(fInfo, headers, "")
+	}
+	return headers.Fields
+}
+
+// Builds the RequestBody of a method from the request body of its operation. The pointer is the JSON pointer of the
+// request body, and the types of the bodies in each media type are found in the method's parameters type.
+func (b *OpenAPI3Builder) buildRequestBody(methodName string, reqBodyOrRef *openapiv3.RequestBodyOrReference, pointer, parametersTypeName string) *RequestBody {
+	requestBody := reqBodyOrRef.GetRequestBody()
+	if ref := reqBodyOrRef.GetReference(); ref != nil {
+		requestBody = b.requestBodyForReference(ref.XRef)
+		pointer = strings.TrimPrefix(ref.XRef, "#")
+	}
+	if requestBody == nil {
+		return nil
+	}
+	body := &RequestBody{Description: requestBody.Description, Required: requestBody.Required}
+	var bodyType *Type
+	if f := findType(b.model.Types, parametersTypeName).FieldWithName("request_body"); f != nil {
+		bodyType = findType(b.model.Types, f.Type)
+	}
+	for _, namedMediaType := range requestBody.GetContent().GetAdditionalProperties() {
+		mediaType := &MediaType{Name: namedMediaType.Name}
+		if f := bodyType.FieldWithName(namedMediaType.Name); f != nil && f.Kind == FieldKind_REFERENCE {
+			mediaType.TypeName = f.Type
+		}
+		schema := b.schemaForSchemaOrReference(namedMediaType.GetValue().GetSchema())
+		mediaType.Binary = isBinarySchemaForOpenAPI3(schema) || schema == nil && namedMediaType.Name == "application/octet-stream"
+		if isFormMediaType(namedMediaType.Name) {
+			encodings := make(map[string]*openapiv3.Encoding)
+			for _, namedEncoding := range namedMediaType.GetValue().GetEncoding().GetAdditionalProperties() {
+				encodings[namedEncoding.Name] = namedEncoding.Value
+			}
+			for _, property := range schema.GetProperties().GetAdditionalProperties() {
+				encoding := encodings[property.Name]
+				encodingPointer := pointer + "/content/" + escapePointer(namedMediaType.Name) + "/encoding/" + escapePointer(property.Name)
+				part := b.buildPart(methodName, property.Name, b.schemaForSchemaOrReference(property.Value), encoding, encodingPointer)
+				mediaType.Parts = append(mediaType.Parts, part)
+			}
+		}
+		body.Content = append(body.Content, mediaType)
+	}
+	return body
+}
+
+// Builds a Part of a form from the schema of its property and its encoding, which may be nil.
+func (b *OpenAPI3Builder) buildPart(methodName, name string, schema *openapiv3.Schema, encoding *openapiv3.Encoding, pointer string) *Part {
+	// Arrays are sent as repeated parts with the encoding of their items.
+	if schema.GetType() == "array" && len(schema.GetItems().GetSchemaOrReference()) > 0 {
+		schema = b.schemaForSchemaOrReference(schema.GetItems().GetSchemaOrReference()[0])
+	}
+	part := &Part{
+		Name:          name,
+		ContentType:   contentTypeForSchemaForOpenAPI3(schema),
+		Binary:        isBinarySchemaForOpenAPI3(schema),
+		Style:         "form",
+		AllowReserved: encoding.GetAllowReserved(),
+	}
+	if encoding.GetContentType() != "" {
+		part.ContentType = encoding.GetContentType()
+	}
+	if encoding.GetStyle() != "" {
+		part.Style = encoding.GetStyle()
+	}
+	// Models can't distinguish explode: false from an absent explode,
+	// which defaults to true for form parts.
+	part.Explode = encoding.GetExplode() || part.Style == "form" && !b.annotations.HasExplicitValue(pointer+"/explode")
+	part.Headers = b.buildFromHeaders(methodName+" "+name, encoding.GetHeaders())
+	return part
+}
+
+// Returns the default content type of a part of a multipart form with the given schema.
+func contentTypeForSchemaForOpenAPI3(schema *openapiv3.Schema) string {
+	switch {
+	case isBinarySchemaForOpenAPI3(schema):
+		return "application/octet-stream"
+	case schema.GetType() == "object" || schema.GetType() == "array" || schema.GetProperties() != nil:
+		return "application/json"
+	default:
+		return "text/plain"
+	}
+}
+
+// Returns true if a schema describes binary data.
+func isBinarySchemaForOpenAPI3(schema *openapiv3.Schema) bool {
+	return schema.GetType() == "string" && (schema.GetFormat() == "binary" || schema.GetFormat() == "base64")
+}
+
+// Returns the schema of a SchemaOrReference, following references to schemas in the components of the document.
+func (b *OpenAPI3Builder) schemaForSchemaOrReference(schemaOrRef *openapiv3.SchemaOrReference) *openapiv3.Schema {
+	if ref := schemaOrRef.GetReference(); ref != nil {
+		for _, namedSchema := range b.document.GetComponents().GetSchemas().GetAdditionalProperties() {
+			if ref.XRef == "#/components/schemas/"+escapePointer(namedSchema.Name) {
+				return namedSchema.GetValue().GetSchema()
+			}
+		}
+		return nil
 	}
-	return parametersTypeName, responseTypeName
+	return schemaOrRef.GetSchema()
+}
+
+// Returns the request body in the components of the document that a reference refers to, or nil if there isn't one.
+func (b *OpenAPI3Builder) requestBodyForReference(ref string) *openapiv3.RequestBody {
+	for _, namedRequestBody := range b.document.GetComponents().GetRequestBodies().GetAdditionalProperties() {
+		if ref == "#/components/requestBodies/"+escapePointer(namedRequestBody.Name) {
+			return namedRequestBody.GetValue().GetRequestBody()
+		}
+	}
+	return nil
+}
+
+// Returns the response in the components of the document that a reference refers to, or nil if there isn't one.
+func (b *OpenAPI3Builder) responseForReference(ref string) *openapiv3.Response {
+	for _, namedResponse := range b.document.GetComponents().GetResponses().GetAdditionalProperties() {
+		if ref == "#/components/responses/"+escapePointer(namedResponse.Name) {
+			return namedResponse.GetValue().GetResponse()
+		}
+	}
+	return nil
+}
+
+// Returns the header in the components of the document that a reference refers to, or nil if there isn't one.
+func (b *OpenAPI3Builder) headerForReference(ref string) *openapiv3.Header {
+	for _, namedHeader := range b.document.GetComponents().GetHeaders().GetAdditionalProperties() {
+		if ref == "#/components/headers/"+escapePointer(namedHeader.Name) {
+			return namedHeader.GetValue().GetHeader()
+		}
+	}
+	return nil
 }
 
 // A helper method to differentiate between references and actual objects.
 // The actual Field and Type are created in the functions which call this function
-func (b *OpenAPI3Builder) buildFromParamOrRef(paramOrRef *openapiv3.ParameterOrReference) (fInfo *FieldInfo) {
+func (b *OpenAPI3Builder) buildFromParamOrRef(paramOrRef *openapiv3.ParameterOrReference, pointer string) (fInfo *FieldInfo) {
 	fInfo = &FieldInfo{}
 	if param := paramOrRef.GetParameter(); param != nil {
-		fInfo = b.buildFromParam(param)
+		fInfo = b.buildFromParam(param, pointer)
 		return fInfo
 	} else if ref := paramOrRef.GetReference(); ref != nil {
 		t := findType(b.model.Types, validTypeForRef(ref.XRef))
 		if t != nil && len(t.Fields) > 0 {
 			fInfo.fieldKind, fInfo.fieldType, fInfo.fieldName, fInfo.fieldPosition = FieldKind_REFERENCE, validTypeForRef(ref.XRef), t.Name, t.Fields[0].Position
+			fInfo.extensions = t.Fields[0].Extensions
+			fInfo.style, fInfo.explode, fInfo.allowReserved = t.Fields[0].Style, t.Fields[0].Explode, t.Fields[0].AllowReserved
 			return fInfo
 		}
 		// TODO: This might happen for symbolic references --> fInfo.Position defaults to 'BODY' which is wrong.
@@ -236,10 +436,12 @@ func (b *OpenAPI3Builder) buildFromParamOrRef(paramOrRef *openapiv3.ParameterOrR
 }
 
 // Returns information on how to represent 'parameter' as field. This information gets propagated up the callstack.
-func (b *OpenAPI3Builder) buildFromParam(parameter *openapiv3.Parameter) (fInfo *FieldInfo) {
+// The pointer is the JSON pointer of the parameter.
+func (b *OpenAPI3Builder) buildFromParam(parameter *openapiv3.Parameter, pointer string) (fInfo *FieldInfo) {
 	if schemaOrRef := parameter.Schema; schemaOrRef != nil {
 		fInfo = b.buildFromSchemaOrReference(parameter.Name, schemaOrRef)
 		fInfo.fieldName = parameter.Name
+		fInfo.extensions = mergeExtensions(fInfo.extensions, extensionsForOpenAPI3(parameter.SpecificationExtension))
 		switch parameter.In {
 		case "body":
 			fInfo.fieldPosition = Position_BODY
@@ -252,11 +454,34 @@ func (b *OpenAPI3Builder) buildFromParam(parameter *openapiv3.Parameter) (fInfo
 		case "path":
 			fInfo.fieldPosition = Position_PATH
 		}
+		fInfo.style, fInfo.explode, fInfo.allowReserved = ParameterSerialization(b.annotations, parameter, pointer)
 		return fInfo
 	}
 	return nil
 }
 
+// ParameterSerialization returns the style of a parameter of an OpenAPI v3
+// document and whether it is exploded and allows reserved characters, with
+// the defaults for the parameter's location when they aren't specified.
+// The pointer is the JSON pointer of the parameter in the description, and
+// the annotations of the description tell if explode was set to false.
+func ParameterSerialization(annotations *compiler.Annotations, parameter *openapiv3.Parameter, pointer string) (style string, explode, allowReserved bool) {
+	style, explode, allowReserved = parameter.Style, parameter.Explode, parameter.AllowReserved
+	if style == "" {
+		// Query and cookie parameters are form parameters by default.
+		style = "simple"
+		if parameter.In == "query" || parameter.In == "cookie" {
+			style = "form"
+		}
+	}
+	// Models can't distinguish explode: false from an absent explode,
+	// which defaults to true for form parameters.
+	if !explode && style == "form" && !annotations.HasExplicitValue(pointer+"/explode") {
+		explode = true
+	}
+	return style, explode, allowReserved
+}
+
 // A helper method to differentiate between references and actual objects
 func (b *OpenAPI3Builder) buildFromRequestBodyOrRef(name string, reqBodyOrRef *openapiv3.RequestBodyOrReference) (fInfo *FieldInfo) {
 	fInfo = &FieldInfo{}
@@ -287,9 +512,9 @@ func (b *OpenAPI3Builder) buildFromRequestBody(name string, reqBody *openapiv3.R
 }
 
 // A helper method to differentiate between references and actual objects
-func (b *OpenAPI3Builder) buildFromResponseOrRef(name string, responseOrRef *openapiv3.ResponseOrReference) (fInfo []*FieldInfo) {
+func (b *OpenAPI3Builder) buildFromResponseOrRef(name, typeName string, responseOrRef *openapiv3.ResponseOrReference) (fInfo []*FieldInfo) {
 	if response := responseOrRef.GetResponse(); response != nil {
-		return b.buildFromResponse(name, response)
+		return b.buildFromResponse(name, typeName, response)
 	} else if ref := responseOrRef.GetReference(); ref != nil {
 		return []*FieldInfo{{
 			fieldKind: FieldKind_REFERENCE,
@@ -299,12 +524,13 @@ func (b *OpenAPI3Builder) buildFromResponseOrRef(name string, responseOrRef *ope
 	return nil
 }
 
-// Builds a Type for 'response' and returns information on how to use this Type as field.
-func (b *OpenAPI3Builder) buildFromResponse(name string, response *openapiv3.Response) (fInfos []*FieldInfo) {
+// Builds a Type for 'response' and returns information on how to use this Type as field. The fields are named
+// with name and the types of their bodies are named with typeName.
+func (b *OpenAPI3Builder) buildFromResponse(name, typeName string, response *openapiv3.Response) (fInfos []*FieldInfo) {
 	if response.Content != nil {
 		for _, namedMediaType := range response.Content.AdditionalProperties {
 			name := name + " " + namedMediaType.Name
-			fieldInfo := b.buildFromSchemaOrReference(name, namedMediaType.GetValue().GetSchema())
+			fieldInfo := b.buildFromSchemaOrReference(typeName+" "+namedMediaType.Name, namedMediaType.GetValue().GetSchema())
 			fieldInfo.fieldName = name
 			fInfos = append(fInfos, fieldInfo)
 		}
@@ -332,13 +558,14 @@ func (b *OpenAPI3Builder) buildFromSchemaOrReference(name string, schemaOrRefere
 //     created whenever Types are created (higher up in the callstack). This possibility can be considered as the "base condition"
 //     for the recursive approach.
 func (b *OpenAPI3Builder) buildFromSchema(name string, schema *openapiv3.Schema) (fInfo *FieldInfo) {
-	fInfo = &FieldInfo{}
+	fInfo = &FieldInfo{extensions: extensionsForOpenAPI3(schema.SpecificationExtension)}
 	// Data types according to: https://swagger.io/docs/specification/data-models/data-types/
 	switch schema.Type {
 	case "":
 		fallthrough
 	case "object":
 		schemaType := makeType(name)
+		schemaType.Extensions = fInfo.extensions
 
 		for _, namedSchema := range schema.GetProperties().GetAdditionalProperties() {
 			fieldInfo := b.buildFromSchemaOrReference(namedSchema.Name, namedSchema.Value)
@@ -462,3 +689,30 @@ func (b *OpenAPI3Builder) checkForExistence(name string, fInfo *FieldInfo) {
 		b.model.addType(t)
 	}
 }
+
+// extensionsForOpenAPI3 returns the YAML values of specification extensions by name.
+func extensionsForOpenAPI3(namedAnys []*openapiv3.NamedAny) map[string]string {
+	if len(namedAnys) == 0 {
+		return nil
+	}
+	extensions := make(map[string]string, len(namedAnys))
+	for _, namedAny := range namedAnys {
+		extensions[namedAny.Name] = strings.TrimSuffix(namedAny.GetValue().GetYaml(), "\n")
+	}
+	return extensions
+}
+
+// Returns true if a successful response of an operation has a Link header.
+func hasLinkHeaderForOpenAPI3(operation *openapiv3.Operation) bool {
+	for _, namedResponse := range operation.GetResponses().GetResponseOrReference() {
+		if !strings.HasPrefix(namedResponse.Name, "2") {
+			continue
+		}
+		for _, namedHeader := range namedResponse.GetValue().GetResponse().GetHeaders().GetAdditionalProperties() {
+			if isLinkHeader(namedHeader.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -53,3 +53,432 @@ func TestModelOpenAPIV3(t *testing.T) {
 	x, _ := protojson.Marshal(m)
 	t.Logf("Model: %s", x)
 }
+
+func TestExtensionsFromOpenAPI3(t *testing.T) {
+	document, err := openapiv3.ParseDocument([]byte(`openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      x-go-name: FetchPet
+      parameters:
+      - name: id
+        in: path
+        required: true
+        x-go-name: ID
+        schema:
+          type: string
+          x-nullable: true
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      x-go-name: Animal
+      properties:
+        tag:
+          type: string
+          x-nullable: true
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI3(document, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	if got := m.Methods[0].Extensions; !cmp.Equal(got, map[string]string{"x-go-name": "FetchPet"}) {
+		t.Errorf("unexpected method extensions %v", got)
+	}
+	parameters := findType(m.Types, "GetPetParameters")
+	if got := parameters.FieldWithName("id").Extensions; !cmp.Equal(got, map[string]string{"x-go-name": "ID", "x-nullable": "true"}) {
+		t.Errorf("unexpected parameter extensions %v", got)
+	}
+	pet := findType(m.Types, "Pet")
+	if got := pet.Extensions; !cmp.Equal(got, map[string]string{"x-go-name": "Animal"}) {
+		t.Errorf("unexpected type extensions %v", got)
+	}
+	if got := pet.FieldWithName("tag").Extensions; !cmp.Equal(got, map[string]string{"x-nullable": "true"}) {
+		t.Errorf("unexpected field extensions %v", got)
+	}
+}
+
+func TestPaginationFromOpenAPI3(t *testing.T) {
+	document, err := openapiv3.ParseDocument([]byte(`openapi: 3.0.0
+info:
+  title: Pages
+  version: 1.0.0
+paths:
+  /books:
+    get:
+      operationId: listBooks
+      parameters:
+      - {name: page_token, in: query, schema: {type: string}}
+      - {name: page_size, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/BookList"
+  /authors:
+    get:
+      operationId: listAuthors
+      parameters:
+      - {name: page, in: query, schema: {type: integer}}
+      - {name: per_page, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+  /shelves:
+    get:
+      operationId: listShelves
+      parameters:
+      - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          headers:
+            Link: {schema: {type: string}}
+  /stores:
+    get:
+      operationId: listStores
+      x-pagination: none
+      parameters:
+      - {name: offset, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    BookList:
+      type: object
+      properties:
+        books:
+          type: array
+          items: {type: string}
+        nextPageToken:
+          type: string
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI3(document, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	expected := []*Pagination{
+		{Style: PaginationStyle_TOKEN, PageParameter: "page_token", SizeParameter: "page_size", NextField: "nextPageToken", ItemsField: "books"},
+		{Style: PaginationStyle_PAGE, PageParameter: "page", SizeParameter: "per_page"},
+		{Style: PaginationStyle_LINK, SizeParameter: "limit"},
+		nil,
+	}
+	for i, method := range m.Methods {
+		if diff := cmp.Diff(expected[i], method.Pagination, protocmp.Transform()); diff != "" {
+			t.Errorf("unexpected pagination of %s (-want +got):\n%s", method.Name, diff)
+		}
+	}
+}
+
+func TestSerializationFromOpenAPI3(t *testing.T) {
+	document, annotations, err := openapiv3.ParseDocumentWithAnnotations([]byte(`openapi: 3.0.0
+info:
+  title: Serialization
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: listPets
+      parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+      - {name: tags, in: query, schema: {type: array, items: {type: string}}}
+      - {name: ids, in: query, explode: false, schema: {type: array, items: {type: string}}}
+      - {name: filter, in: query, style: deepObject, explode: true, schema: {type: object}}
+      - {name: colors, in: query, style: pipeDelimited, schema: {type: array, items: {type: string}}}
+      - {name: path, in: query, allowReserved: true, schema: {type: string}}
+      - $ref: "#/components/parameters/fields"
+      responses:
+        "200":
+          description: ok
+components:
+  parameters:
+    fields:
+      name: fields
+      in: query
+      explode: false
+      schema: {type: array, items: {type: string}}
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI3WithAnnotations(document, annotations, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	parameters := findType(m.Types, "ListPetsParameters")
+	for _, expected := range []struct {
+		name          string
+		style         string
+		explode       bool
+		allowReserved bool
+	}{
+		{name: "id", style: "simple"},
+		{name: "tags", style: "form", explode: true},
+		{name: "ids", style: "form"},
+		{name: "filter", style: "deepObject", explode: true},
+		{name: "colors", style: "pipeDelimited"},
+		{name: "path", style: "form", explode: true, allowReserved: true},
+		{name: "fields", style: "form"},
+	} {
+		f := parameters.FieldWithName(expected.name)
+		if f == nil {
+			t.Errorf("missing parameter %s", expected.name)
+		} else if f.Style != expected.style || f.Explode != expected.explode || f.AllowReserved != expected.allowReserved {
+			t.Errorf("unexpected serialization of %s: %s %t %t", expected.name, f.Style, f.Explode, f.AllowReserved)
+		}
+	}
+}
+
+func TestSecurityFromOpenAPI3(t *testing.T) {
+	document, err := openapiv3.ParseDocument([]byte(`openapi: 3.0.0
+info:
+  title: Security
+  version: 1.0.0
+security:
+- apiKey: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+    post:
+      operationId: createPet
+      security:
+      - oauth: [write, read]
+        apiKey: []
+      - bearer: []
+      responses:
+        "200":
+          description: ok
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        "200":
+          description: ok
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, name: X-API-Key, in: header}
+    bearer: {type: http, scheme: bearer, bearerFormat: JWT}
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes: {read: read pets, write: write pets}
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI3(document, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	if len(m.SecuritySchemes) != 3 {
+		t.Fatalf("unexpected security schemes: %v", m.SecuritySchemes)
+	}
+	if s := m.SecuritySchemes[0]; s.Name != "apiKey" || s.Type != "apiKey" || s.ParameterName != "X-API-Key" || s.In != "header" {
+		t.Errorf("unexpected API key scheme: %v", s)
+	}
+	if s := m.SecuritySchemes[1]; s.Type != "http" || s.Scheme != "bearer" || s.BearerFormat != "JWT" {
+		t.Errorf("unexpected bearer scheme: %v", s)
+	}
+	if s := m.SecuritySchemes[2]; len(s.Flows) != 1 || s.Flows[0].Name != "clientCredentials" ||
+		s.Flows[0].TokenUrl != "https://example.com/token" || s.Flows[0].Scopes["write"] != "write pets" {
+		t.Errorf("unexpected OAuth2 scheme: %v", s)
+	}
+	expected := [][]*SecurityRequirement{
+		{{Schemes: []string{"apiKey"}}},
+		{{Schemes: []string{"oauth", "apiKey"}, Scopes: []string{"write", "read"}}, {Schemes: []string{"bearer"}}},
+		{},
+	}
+	for i, method := range m.Methods {
+		if diff := cmp.Diff(expected[i], method.Security, protocmp.Transform()); diff != "" {
+			t.Errorf("unexpected security of %s (-want +got):\n%s", method.Name, diff)
+		}
+	}
+}
+
+func TestResponsesFromOpenAPI3(t *testing.T) {
+	document, err := openapiv3.ParseDocument([]byte(`openapi: 3.0.0
+info:
+  title: Responses
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: A list of pets.
+          headers:
+            X-Rate-Limit: {schema: {type: integer, format: int32}}
+            X-Request-Id: {$ref: "#/components/headers/RequestId"}
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: "#/components/schemas/Pet"}}
+        "404":
+          $ref: "#/components/responses/NotFound"
+        default:
+          description: An unexpected error.
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Error"}
+components:
+  headers:
+    RequestId: {schema: {type: string}}
+  responses:
+    NotFound:
+      description: Not found.
+      content:
+        application/json:
+          schema: {$ref: "#/components/schemas/Error"}
+  schemas:
+    Pet: {type: object, properties: {name: {type: string}}}
+    Error: {type: object, properties: {message: {type: string}}}
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI3(document, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	expected := []*Response{
+		{
+			StatusCode:  "200",
+			Description: "A list of pets.",
+			Content:     []*Field{{Name: "application/json", Type: "Pet", Kind: FieldKind_ARRAY}},
+			Headers: []*Field{
+				{Name: "X-Rate-Limit", Type: "integer", Format: "int32", Position: Position_HEADER},
+				{Name: "X-Request-Id", Type: "string", Position: Position_HEADER},
+			},
+		},
+		{
+			StatusCode:  "404",
+			Description: "Not found.",
+			Content:     []*Field{{Type: "NotFound", Kind: FieldKind_REFERENCE}},
+			Error:       true,
+		},
+		{
+			StatusCode:  "default",
+			Description: "An unexpected error.",
+			Content:     []*Field{{Name: "application/json", Type: "Error", Kind: FieldKind_REFERENCE}},
+			Error:       true,
+		},
+	}
+	if diff := cmp.Diff(expected, m.Methods[0].Responses, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected responses (-want +got):\n%s", diff)
+	}
+}
+
+func TestRequestBodyFromOpenAPI3(t *testing.T) {
+	document, annotations, err := openapiv3.ParseDocumentWithAnnotations([]byte(`openapi: 3.0.0
+info:
+  title: Uploads
+  version: 1.0.0
+paths:
+  /pets/{id}/photo:
+    post:
+      operationId: uploadPhoto
+      requestBody:
+        required: true
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                caption: {type: string}
+                metadata: {$ref: "#/components/schemas/Metadata"}
+                photos: {type: array, items: {type: string, format: binary}}
+            encoding:
+              caption:
+                headers:
+                  X-Language: {schema: {type: string}}
+              photos:
+                contentType: image/png, image/jpeg
+      responses:
+        "200":
+          description: ok
+  /pets/{id}/data:
+    put:
+      operationId: putData
+      requestBody:
+        $ref: "#/components/requestBodies/Data"
+      responses:
+        "200":
+          description: ok
+  /search:
+    post:
+      operationId: search
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                tags: {type: array, items: {type: string}}
+            encoding:
+              tags: {style: form, explode: false}
+      responses:
+        "200":
+          description: ok
+components:
+  requestBodies:
+    Data:
+      description: Raw data.
+      content:
+        application/octet-stream: {}
+  schemas:
+    Metadata: {type: object, properties: {name: {type: string}}}
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse document: %+v", err)
+	}
+	m, err := NewModelFromOpenAPI3WithAnnotations(document, annotations, "")
+	if err != nil {
+		t.Fatalf("Failed to create model: %+v", err)
+	}
+	expected := []*RequestBody{
+		{Required: true, Content: []*MediaType{{
+			Name:     "multipart/form-data",
+			TypeName: "uploadPhotoRequestBodymultipart/form-data",
+			Parts: []*Part{
+				{Name: "caption", ContentType: "text/plain", Style: "form", Explode: true,
+					Headers: []*Field{{Name: "X-Language", Type: "string", Position: Position_HEADER}}},
+				{Name: "metadata", ContentType: "application/json", Style: "form", Explode: true},
+				{Name: "photos", ContentType: "image/png, image/jpeg", Binary: true, Style: "form", Explode: true},
+			},
+		}}},
+		{Description: "Raw data.", Content: []*MediaType{{Name: "application/octet-stream", Binary: true}}},
+		{Content: []*MediaType{{
+			Name:     "application/x-www-form-urlencoded",
+			TypeName: "searchRequestBodyapplication/x-www-form-urlencoded",
+			Parts:    []*Part{{Name: "tags", ContentType: "text/plain", Style: "form"}},
+		}}},
+	}
+	for i, method := range m.Methods {
+		if diff := cmp.Diff(expected[i], method.RequestBody, protocmp.Transform()); diff != "" {
+			t.Errorf("unexpected request body of %s (-want +got):\n%s", method.Name, diff)
+		}
+	}
+}
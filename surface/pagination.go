@@ -0,0 +1,261 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surface_v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The names of the parameters and fields that are used for pagination,
+// written in lower case without separators.
+var (
+	pageTokenParameters = []string{"pagetoken", "cursor", "after", "continuationtoken", "nexttoken", "starttoken"}
+	nextTokenFields     = []string{"nextpagetoken", "nextcursor", "cursor", "continuationtoken", "nexttoken", "next"}
+	pageParameters      = []string{"page", "pagenumber", "pagenum"}
+	offsetParameters    = []string{"offset", "skip", "start", "startindex"}
+	sizeParameters      = []string{"pagesize", "limit", "perpage", "maxresults", "size", "count", "top"}
+)
+
+// Sets the pagination of methods that take parameters that select pages,
+// such as pageToken and page. Methods whose responses have Link headers
+// are paginated with them. The x-pagination extension of an operation
+// sets its pagination style, or turns off pagination with "none".
+func (m *Model) detectPagination() {
+	for _, method := range m.Methods {
+		linked := method.Pagination.GetStyle() == PaginationStyle_LINK
+		method.Pagination = m.paginationForMethod(method, linked)
+		if style, ok := method.Extensions["x-pagination"]; ok {
+			style = strings.ToUpper(strings.Trim(style, "\"'"))
+			if style == "NONE" || style == "FALSE" {
+				method.Pagination = nil
+			} else if value, ok := PaginationStyle_value[style]; ok {
+				if method.Pagination == nil {
+					method.Pagination = &Pagination{}
+				}
+				method.Pagination.Style = PaginationStyle(value)
+			}
+		}
+	}
+}
+
+// Returns the pagination of a method or nil if its results aren't paginated.
+func (m *Model) paginationForMethod(method *Method, linked bool) *Pagination {
+	parameters := findType(m.Types, method.ParametersTypeName)
+	p := &Pagination{
+		SizeParameter: queryParameterWithName(parameters, sizeParameters),
+	}
+	response := m.successfulResponseType(method)
+	if response != nil {
+		for _, f := range response.Fields {
+			if f.Kind == FieldKind_ARRAY {
+				p.ItemsField = f.Name
+				break
+			}
+		}
+	}
+	if token := queryParameterWithName(parameters, pageTokenParameters); token != "" {
+		if next := fieldWithName(response, nextTokenFields); next != nil {
+			p.Style, p.PageParameter, p.NextField = PaginationStyle_TOKEN, token, next.Name
+			return p
+		}
+	}
+	if linked {
+		p.Style = PaginationStyle_LINK
+		return p
+	}
+	if page := queryParameterWithName(parameters, pageParameters); page != "" {
+		p.Style, p.PageParameter = PaginationStyle_PAGE, page
+		return p
+	}
+	if offset := queryParameterWithName(parameters, offsetParameters); offset != "" {
+		p.Style, p.PageParameter = PaginationStyle_OFFSET, offset
+		return p
+	}
+	return nil
+}
+
+// Returns the type of the first successful response of a method.
+func (m *Model) successfulResponseType(method *Method) *Type {
+	responses := findType(m.Types, method.ResponsesTypeName)
+	if responses == nil {
+		return nil
+	}
+	for _, f := range responses.Fields {
+		if strings.HasPrefix(f.Name, "2") {
+			return findType(m.Types, f.Type)
+		}
+	}
+	return nil
+}
+
+// Returns the name of the first query parameter in a parameters type
+// with one of a list of names.
+func queryParameterWithName(parameters *Type, names []string) string {
+	if parameters == nil {
+		return ""
+	}
+	for _, name := range names {
+		for _, f := range parameters.Fields {
+			if f.Position == Position_QUERY && paginationName(f.Name) == name {
+				return f.Name
+			}
+		}
+	}
+	return ""
+}
+
+// Returns the first field of a type with one of a list of names.
+func fieldWithName(t *Type, names []string) *Field {
+	if t == nil {
+		return nil
+	}
+	for _, name := range names {
+		for _, f := range t.Fields {
+			if f.Kind != FieldKind_ARRAY && paginationName(f.Name) == name {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// Returns a name in lower case without underscores or hyphens.
+func paginationName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// Returns true if a header name is Link.
+func isLinkHeader(name string) bool {
+	return strings.EqualFold(name, "Link")
+}
+
+// nextLinkPattern matches the URL of the next page in a Link header.
+var nextLinkPattern = regexp.MustCompile(`<([^>]*)>\s*;\s*rel="?next"?`)
+
+// DefaultMaxPages is the number of pages that ListAll fetches at most when
+// it isn't given a limit.
+const DefaultMaxPages = 1000
+
+// ListAll calls a paginated method with a client, following its pagination
+// from the first page to the last, and returns the items of every page as
+// JSON. pageURL is the URL of the method, with its path parameters and any
+// other query parameters, and pageSize sets the page size if the method has
+// a size parameter and it isn't zero. Pages selected by number or offset
+// are fetched until one is empty, and the last page is also the one before
+// a page that was already fetched, which servers that return the same next
+// token or link again refer to. Servers that ignore the page parameter
+// never return an empty page, so an error is returned if there are more
+// than maxPages pages, or more than DefaultMaxPages if maxPages is zero or
+// less. If client is nil, http.DefaultClient is used.
+func (p *Pagination) ListAll(client *http.Client, pageURL string, pageSize int, maxPages int) ([]json.RawMessage, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	if p.SizeParameter != "" && pageSize > 0 {
+		query.Set(p.SizeParameter, strconv.Itoa(pageSize))
+	}
+	if p.Style == PaginationStyle_PAGE && query.Get(p.PageParameter) == "" {
+		query.Set(p.PageParameter, "1")
+	}
+	u.RawQuery = query.Encode()
+	items := make([]json.RawMessage, 0)
+	fetched := make(map[string]bool)
+	for u != nil && !fetched[u.String()] {
+		if len(fetched) == maxPages {
+			return nil, fmt.Errorf("unable to list %s: it has more than %d pages", pageURL, maxPages)
+		}
+		fetched[u.String()] = true
+		response, err := client.Get(u.String())
+		if err != nil {
+			return nil, err
+		}
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			response.Body.Close()
+			return nil, fmt.Errorf("unable to list %s: %s", u, response.Status)
+		}
+		var page []json.RawMessage
+		var body map[string]json.RawMessage
+		if p.ItemsField == "" {
+			err = json.NewDecoder(response.Body).Decode(&page)
+		} else if err = json.NewDecoder(response.Body).Decode(&body); err == nil && body[p.ItemsField] != nil {
+			err = json.Unmarshal(body[p.ItemsField], &page)
+		}
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if u, err = p.nextPage(u, page, body, response.Header); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// Returns the URL of the page after a page, or nil if it is the last page.
+// body is the response object when the items are in one of its fields.
+func (p *Pagination) nextPage(u *url.URL, page []json.RawMessage, body map[string]json.RawMessage, header http.Header) (*url.URL, error) {
+	query := u.Query()
+	switch p.Style {
+	case PaginationStyle_TOKEN:
+		var token string
+		if next := body[p.NextField]; next != nil {
+			if err := json.Unmarshal(next, &token); err != nil {
+				return nil, err
+			}
+		}
+		if token == "" {
+			return nil, nil
+		}
+		query.Set(p.PageParameter, token)
+	case PaginationStyle_PAGE:
+		if len(page) == 0 {
+			return nil, nil
+		}
+		n, _ := strconv.Atoi(query.Get(p.PageParameter))
+		query.Set(p.PageParameter, strconv.Itoa(n+1))
+	case PaginationStyle_OFFSET:
+		if len(page) == 0 {
+			return nil, nil
+		}
+		n, _ := strconv.Atoi(query.Get(p.PageParameter))
+		query.Set(p.PageParameter, strconv.Itoa(n+len(page)))
+	case PaginationStyle_LINK:
+		match := nextLinkPattern.FindStringSubmatch(header.Get("Link"))
+		if match == nil {
+			return nil, nil
+		}
+		return u.Parse(match[1])
+	default:
+		return nil, fmt.Errorf("unexpected pagination style %s", p.Style)
+	}
+	next := *u
+	next.RawQuery = query.Encode()
+	return &next, nil
+}
@@ -0,0 +1,292 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surface_v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	openapiv2 "github.com/okkoye/gnostic/openapiv2"
+	openapiv3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// Builds the security schemes of an OpenAPI v3 description.
+func securitySchemesForOpenAPI3(components *openapiv3.Components) []*SecurityScheme {
+	schemes := make([]*SecurityScheme, 0)
+	for _, namedScheme := range components.GetSecuritySchemes().GetAdditionalProperties() {
+		s := namedScheme.Value.GetSecurityScheme()
+		if s == nil {
+			continue
+		}
+		scheme := &SecurityScheme{
+			Name:             namedScheme.Name,
+			Type:             s.Type,
+			Description:      s.Description,
+			Scheme:           s.Scheme,
+			BearerFormat:     s.BearerFormat,
+			OpenIdConnectUrl: s.OpenIdConnectUrl,
+		}
+		if s.Type == "apiKey" {
+			scheme.ParameterName, scheme.In = s.Name, s.In
+		}
+		flows := s.GetFlows()
+		for _, flow := range []struct {
+			name string
+			flow *openapiv3.OauthFlow
+		}{
+			{"implicit", flows.GetImplicit()},
+			{"password", flows.GetPassword()},
+			{"clientCredentials", flows.GetClientCredentials()},
+			{"authorizationCode", flows.GetAuthorizationCode()},
+		} {
+			if flow.flow == nil {
+				continue
+			}
+			f := &OAuthFlow{
+				Name:             flow.name,
+				AuthorizationUrl: flow.flow.AuthorizationUrl,
+				TokenUrl:         flow.flow.TokenUrl,
+				RefreshUrl:       flow.flow.RefreshUrl,
+			}
+			for _, scope := range flow.flow.GetScopes().GetAdditionalProperties() {
+				if f.Scopes == nil {
+					f.Scopes = make(map[string]string)
+				}
+				f.Scopes[scope.Name] = scope.Value
+			}
+			scheme.Flows = append(scheme.Flows, f)
+		}
+		schemes = append(schemes, scheme)
+	}
+	sort.Slice(schemes, func(i, j int) bool { return schemes[i].Name < schemes[j].Name })
+	return schemes
+}
+
+// Builds the security schemes of an OpenAPI v2 description. Basic
+// authentication is described as the "basic" scheme of the "http" type
+// and OAuth2 flows are given their OpenAPI v3 names.
+func securitySchemesForOpenAPI2(definitions *openapiv2.SecurityDefinitions) []*SecurityScheme {
+	schemes := make([]*SecurityScheme, 0)
+	for _, namedDefinition := range definitions.GetAdditionalProperties() {
+		scheme := &SecurityScheme{Name: namedDefinition.Name}
+		var flow *OAuthFlow
+		var scopes *openapiv2.Oauth2Scopes
+		switch d := namedDefinition.Value; {
+		case d.GetBasicAuthenticationSecurity() != nil:
+			s := d.GetBasicAuthenticationSecurity()
+			scheme.Type, scheme.Scheme, scheme.Description = "http", "basic", s.Description
+		case d.GetApiKeySecurity() != nil:
+			s := d.GetApiKeySecurity()
+			scheme.Type, scheme.Description = "apiKey", s.Description
+			scheme.ParameterName, scheme.In = s.Name, s.In
+		case d.GetOauth2ImplicitSecurity() != nil:
+			s := d.GetOauth2ImplicitSecurity()
+			scheme.Description, scopes = s.Description, s.Scopes
+			flow = &OAuthFlow{Name: "implicit", AuthorizationUrl: s.AuthorizationUrl}
+		case d.GetOauth2PasswordSecurity() != nil:
+			s := d.GetOauth2PasswordSecurity()
+			scheme.Description, scopes = s.Description, s.Scopes
+			flow = &OAuthFlow{Name: "password", TokenUrl: s.TokenUrl}
+		case d.GetOauth2ApplicationSecurity() != nil:
+			s := d.GetOauth2ApplicationSecurity()
+			scheme.Description, scopes = s.Description, s.Scopes
+			flow = &OAuthFlow{Name: "clientCredentials", TokenUrl: s.TokenUrl}
+		case d.GetOauth2AccessCodeSecurity() != nil:
+			s := d.GetOauth2AccessCodeSecurity()
+			scheme.Description, scopes = s.Description, s.Scopes
+			flow = &OAuthFlow{Name: "authorizationCode", AuthorizationUrl: s.AuthorizationUrl, TokenUrl: s.TokenUrl}
+		default:
+			continue
+		}
+		if flow != nil {
+			scheme.Type = "oauth2"
+			for _, scope := range scopes.GetAdditionalProperties() {
+				if flow.Scopes == nil {
+					flow.Scopes = make(map[string]string)
+				}
+				flow.Scopes[scope.Name] = scope.Value
+			}
+			scheme.Flows = []*OAuthFlow{flow}
+		}
+		schemes = append(schemes, scheme)
+	}
+	sort.Slice(schemes, func(i, j int) bool { return schemes[i].Name < schemes[j].Name })
+	return schemes
+}
+
+// Returns the security requirements of an OpenAPI v3 operation. Operations
+// without security requirements have the requirements of the document.
+func securityRequirementsForOpenAPI3(operation *openapiv3.Operation, document *openapiv3.Document) []*SecurityRequirement {
+	requirements := operation.Security
+	if requirements == nil {
+		requirements = document.Security
+	}
+	result := make([]*SecurityRequirement, 0, len(requirements))
+	for _, requirement := range requirements {
+		r := &SecurityRequirement{}
+		for _, named := range requirement.GetAdditionalProperties() {
+			r.add(named.Name, named.GetValue().GetValue())
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// Returns the security requirements of an OpenAPI v2 operation. Operations
+// without security requirements have the requirements of the document.
+func securityRequirementsForOpenAPI2(operation *openapiv2.Operation, document *openapiv2.Document) []*SecurityRequirement {
+	requirements := operation.Security
+	if requirements == nil {
+		requirements = document.Security
+	}
+	result := make([]*SecurityRequirement, 0, len(requirements))
+	for _, requirement := range requirements {
+		r := &SecurityRequirement{}
+		for _, named := range requirement.GetAdditionalProperties() {
+			r.add(named.Name, named.GetValue().GetValue())
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// Adds a scheme and the scopes that it requires to a requirement.
+func (r *SecurityRequirement) add(scheme string, scopes []string) {
+	r.Schemes = append(r.Schemes, scheme)
+	for _, scope := range scopes {
+		found := false
+		for _, s := range r.Scopes {
+			if s == scope {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.Scopes = append(r.Scopes, scope)
+		}
+	}
+}
+
+// Credentials are the secrets that clients authenticate with.
+type Credentials struct {
+	APIKey       string // sent by apiKey schemes
+	Username     string // sent by http basic schemes
+	Password     string // sent by http basic schemes
+	Token        string // sent by http bearer schemes
+	ClientID     string // sent by oauth2 schemes to request access tokens
+	ClientSecret string // sent by oauth2 schemes to request access tokens
+	// Client requests access tokens. If it is nil, http.DefaultClient is
+	// used.
+	Client *http.Client
+}
+
+// Authenticate adds credentials to a request for a method, using the
+// security schemes of the model to satisfy the first of the method's
+// security requirements. Requests for methods without requirements are
+// unchanged. OAuth2 schemes request an access token with the scopes of the
+// requirement from their client credentials flow each time they are used.
+func (m *Model) Authenticate(request *http.Request, method *Method, credentials *Credentials) error {
+	if len(method.Security) == 0 {
+		return nil
+	}
+	requirement := method.Security[0]
+	for _, name := range requirement.Schemes {
+		scheme := m.securitySchemeWithName(name)
+		if scheme == nil {
+			return fmt.Errorf("missing security scheme %s", name)
+		}
+		switch {
+		case scheme.Type == "apiKey" && scheme.In == "header":
+			request.Header.Set(scheme.ParameterName, credentials.APIKey)
+		case scheme.Type == "apiKey" && scheme.In == "query":
+			query := request.URL.Query()
+			query.Set(scheme.ParameterName, credentials.APIKey)
+			request.URL.RawQuery = query.Encode()
+		case scheme.Type == "apiKey" && scheme.In == "cookie":
+			request.AddCookie(&http.Cookie{Name: scheme.ParameterName, Value: credentials.APIKey})
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			request.SetBasicAuth(credentials.Username, credentials.Password)
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+			request.Header.Set("Authorization", "Bearer "+credentials.Token)
+		case scheme.Type == "oauth2":
+			token, err := scheme.clientCredentialsToken(request, requirement.Scopes, credentials)
+			if err != nil {
+				return err
+			}
+			request.Header.Set("Authorization", "Bearer "+token)
+		default:
+			return fmt.Errorf("unsupported security scheme %s", name)
+		}
+	}
+	return nil
+}
+
+// Returns the security scheme of a model with a name, or nil if it has none.
+func (m *Model) securitySchemeWithName(name string) *SecurityScheme {
+	for _, scheme := range m.SecuritySchemes {
+		if scheme.Name == name {
+			return scheme
+		}
+	}
+	return nil
+}
+
+// Returns an access token with some scopes from the client credentials
+// flow of an OAuth2 security scheme, requested with the context of the
+// request that it authenticates.
+func (scheme *SecurityScheme) clientCredentialsToken(request *http.Request, scopes []string, credentials *Credentials) (string, error) {
+	for _, flow := range scheme.Flows {
+		if flow.Name != "clientCredentials" {
+			continue
+		}
+		for _, scope := range scopes {
+			if _, ok := flow.Scopes[scope]; !ok {
+				return "", fmt.Errorf("%s has no scope %s", scheme.Name, scope)
+			}
+		}
+		form := url.Values{"grant_type": {"client_credentials"}, "scope": {strings.Join(scopes, " ")}}
+		tokenRequest, err := http.NewRequest(http.MethodPost, flow.TokenUrl, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", err
+		}
+		tokenRequest = tokenRequest.WithContext(request.Context())
+		tokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		tokenRequest.SetBasicAuth(credentials.ClientID, credentials.ClientSecret)
+		client := credentials.Client
+		if client == nil {
+			client = http.DefaultClient
+		}
+		response, err := client.Do(tokenRequest)
+		if err != nil {
+			return "", err
+		}
+		defer response.Body.Close()
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return "", fmt.Errorf("unable to get an access token for %s: %s", scheme.Name, response.Status)
+		}
+		var token struct {
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+			return "", err
+		}
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("%s has no client credentials flow", scheme.Name)
+}
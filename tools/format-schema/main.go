@@ -16,6 +16,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path"
@@ -24,15 +25,41 @@ import (
 )
 
 func main() {
-	if len(os.Args) != 2 {
-		fmt.Printf("Usage: %s [filename]\n", path.Base(os.Args[0]))
+	yaml := flag.Bool("yaml", false, "write YAML instead of JSON")
+	indent := flag.Int("indent", 2, "the number of spaces in each level of indentation")
+	order := flag.String("order", "source", "the order of keys: source, canonical, or alphabetical")
+	flag.Usage = func() {
+		fmt.Printf("Usage: %s [options] [filename]\n", path.Base(os.Args[0]))
 		fmt.Printf("where [filename] is a path to a JSON schema to format.\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
 		os.Exit(0)
 	}
-	schema, err := jsonschema.NewSchemaFromFile(os.Args[1])
+	options := &jsonschema.WriterOptions{Indent: *indent}
+	if *yaml {
+		options.Format = jsonschema.FormatYAML
+	}
+	switch *order {
+	case "source":
+		options.KeyOrder = jsonschema.SourceKeyOrder
+	case "canonical":
+		options.KeyOrder = jsonschema.CanonicalKeyOrder
+	case "alphabetical":
+		options.KeyOrder = jsonschema.AlphabeticalKeyOrder
+	default:
+		flag.Usage()
+		os.Exit(1)
+	}
+	schema, err := jsonschema.NewSchemaFromFile(flag.Arg(0))
+	if err != nil {
+		panic(err)
+	}
+	output, err := schema.Write(options)
 	if err != nil {
 		panic(err)
 	}
-	output := schema.JSONString()
-	fmt.Printf("%s\n", output)
+	os.Stdout.Write(output)
 }
@@ -0,0 +1,200 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// ParseRequestLog reads samples from a text log with one request per line,
+// written as a method, a URL or path, and a status code:
+//
+//	GET /v1/pets?limit=10 200
+//	POST https://api.example.com/v1/pets 201
+//
+// Blank lines and lines starting with # are ignored.
+func ParseRequestLog(b []byte) ([]*Sample, error) {
+	samples := make([]*Sample, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected a method, a path, and a status code", line)
+		}
+		status, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid status code %q", line, fields[2])
+		}
+		samples = append(samples, &Sample{Method: strings.ToUpper(fields[0]), URL: fields[1], Status: status})
+	}
+	return samples, scanner.Err()
+}
+
+// OperationCoverage describes the requests observed for an operation.
+type OperationCoverage struct {
+	Method    string
+	Path      string
+	Requests  int
+	Responses []*ResponseCoverage
+}
+
+// ResponseCoverage describes the requests observed for a documented response.
+// Code is a status code, a range like "4XX", or "default".
+type ResponseCoverage struct {
+	Code     string
+	Requests int
+}
+
+// A CoverageReport describes the operations and responses of a description
+// that were exercised by a set of requests.
+type CoverageReport struct {
+	Operations []*OperationCoverage
+	// Unmatched counts requests that didn't match a documented operation and response.
+	Unmatched int
+}
+
+// Coverage reports the operations and responses of an OpenAPI v3
+// description that are exercised by samples.
+func Coverage(document *openapi_v3.Document, samples []*Sample) *CoverageReport {
+	m := newMatcher(document)
+	report := &CoverageReport{}
+	operations := make(map[string]*OperationCoverage)
+	for _, named := range document.GetPaths().GetPath() {
+		for _, method := range methodNames {
+			operation := operationForMethod(named.Value, method)
+			if operation == nil {
+				continue
+			}
+			c := &OperationCoverage{Method: method, Path: named.Name}
+			for _, response := range operation.GetResponses().GetResponseOrReference() {
+				c.Responses = append(c.Responses, &ResponseCoverage{Code: response.Name})
+			}
+			if operation.GetResponses().GetDefault() != nil {
+				c.Responses = append(c.Responses, &ResponseCoverage{Code: "default"})
+			}
+			operations[method+" "+named.Name] = c
+			report.Operations = append(report.Operations, c)
+		}
+	}
+	for _, sample := range samples {
+		u, err := sample.parsedURL()
+		if err != nil {
+			report.Unmatched++
+			continue
+		}
+		template, item := m.match(m.relativePath(u.EscapedPath()))
+		if item == nil || operationForMethod(item, sample.Method) == nil {
+			report.Unmatched++
+			continue
+		}
+		c := operations[sample.Method+" "+template]
+		c.Requests++
+		key := responseKey(operationForMethod(item, sample.Method).Responses, strconv.Itoa(sample.Status))
+		matched := false
+		for _, response := range c.Responses {
+			if response.Code == key {
+				response.Requests++
+				matched = true
+			}
+		}
+		if !matched {
+			report.Unmatched++
+		}
+	}
+	return report
+}
+
+// OperationCounts returns the number of operations that were exercised and the total number of operations.
+func (r *CoverageReport) OperationCounts() (int, int) {
+	covered := 0
+	for _, c := range r.Operations {
+		if c.Requests > 0 {
+			covered++
+		}
+	}
+	return covered, len(r.Operations)
+}
+
+// ResponseCounts returns the number of responses that were exercised and the total number of responses.
+func (r *CoverageReport) ResponseCounts() (int, int) {
+	covered, total := 0, 0
+	for _, c := range r.Operations {
+		for _, response := range c.Responses {
+			total++
+			if response.Requests > 0 {
+				covered++
+			}
+		}
+	}
+	return covered, total
+}
+
+// percentage returns a percentage, treating an empty set as fully covered.
+func percentage(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return 100 * float64(covered) / float64(total)
+}
+
+// OperationPercentage returns the percentage of operations that were exercised.
+func (r *CoverageReport) OperationPercentage() float64 {
+	return percentage(r.OperationCounts())
+}
+
+// ResponsePercentage returns the percentage of documented responses that were exercised.
+func (r *CoverageReport) ResponsePercentage() float64 {
+	return percentage(r.ResponseCounts())
+}
+
+// String returns a text representation of a report that lists each
+// operation and response with the number of requests that exercised it.
+func (r *CoverageReport) String() string {
+	var b strings.Builder
+	for _, c := range r.Operations {
+		mark := "✓"
+		if c.Requests == 0 {
+			mark = "✗"
+		}
+		fmt.Fprintf(&b, "%s %s %s (%d requests)\n", mark, c.Method, c.Path, c.Requests)
+		for _, response := range c.Responses {
+			mark := "✓"
+			if response.Requests == 0 {
+				mark = "✗"
+			}
+			fmt.Fprintf(&b, "    %s %s (%d requests)\n", mark, response.Code, response.Requests)
+		}
+	}
+	covered, total := r.OperationCounts()
+	fmt.Fprintf(&b, "operations: %d/%d (%.1f%%)\n", covered, total, r.OperationPercentage())
+	covered, total = r.ResponseCounts()
+	fmt.Fprintf(&b, "responses: %d/%d (%.1f%%)\n", covered, total, r.ResponsePercentage())
+	if r.Unmatched > 0 {
+		fmt.Fprintf(&b, "requests without documented operations or responses: %d\n", r.Unmatched)
+	}
+	return b.String()
+}
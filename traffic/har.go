@@ -0,0 +1,97 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// A HAR is an HTTP Archive, the format used by browsers and debugging proxies
+// to export recorded sessions. Only the fields needed to rebuild requests and
+// responses are read.
+// http://www.softwareishard.com/blog/har-12-spec/
+type HAR struct {
+	Log struct {
+		Entries []*harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harEntry struct {
+	Request struct {
+		Method   string       `json:"method"`
+		URL      string       `json:"url"`
+		Headers  []*harHeader `json:"headers"`
+		PostData *struct {
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+		} `json:"postData"`
+	} `json:"request"`
+	Response struct {
+		Status  int          `json:"status"`
+		Headers []*harHeader `json:"headers"`
+		Content struct {
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+			Encoding string `json:"encoding"`
+		} `json:"content"`
+	} `json:"response"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ParseHAR reads the samples recorded in an HTTP Archive.
+func ParseHAR(b []byte) ([]*Sample, error) {
+	var har HAR
+	if err := json.Unmarshal(b, &har); err != nil {
+		return nil, err
+	}
+	samples := make([]*Sample, 0, len(har.Log.Entries))
+	for _, entry := range har.Log.Entries {
+		sample := &Sample{
+			Method:              strings.ToUpper(entry.Request.Method),
+			URL:                 entry.Request.URL,
+			RequestHeaders:      make(map[string]string),
+			Status:              entry.Response.Status,
+			ResponseContentType: entry.Response.Content.MimeType,
+		}
+		for _, header := range entry.Request.Headers {
+			sample.RequestHeaders[strings.ToLower(header.Name)] = header.Value
+		}
+		if postData := entry.Request.PostData; postData != nil {
+			sample.RequestContentType = postData.MimeType
+			sample.RequestBody = []byte(postData.Text)
+		}
+		if sample.RequestContentType == "" {
+			sample.RequestContentType = sample.RequestHeaders["content-type"]
+		}
+		content := entry.Response.Content
+		if content.Encoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(content.Text)
+			if err != nil {
+				return nil, err
+			}
+			sample.ResponseBody = decoded
+		} else {
+			sample.ResponseBody = []byte(content.Text)
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
@@ -0,0 +1,423 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// Options control the inference of a description from samples.
+type Options struct {
+	// Title and Version are used in the info section of the description.
+	Title   string
+	Version string
+	// Hosts restricts inference to samples sent to the listed hosts.
+	// If empty, samples for all hosts are used.
+	Hosts []string
+	// IncludeStatic includes samples whose responses are page assets such as
+	// HTML, scripts, stylesheets, and images, which are skipped by default.
+	IncludeStatic bool
+}
+
+// methodNames are the methods of operations, in path item order.
+var methodNames = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+var hexRegex = regexp.MustCompile(`^[0-9a-fA-F]{12,}$`)
+
+// isIdentifier returns true for path segments that look like generated
+// identifiers and so are probably values of path parameters.
+func isIdentifier(segment string) bool {
+	if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+		return true
+	}
+	if uuidRegex.MatchString(segment) {
+		return true
+	}
+	return hexRegex.MatchString(segment) && strings.ContainsAny(segment, "0123456789")
+}
+
+// parameterName chooses a name for a path parameter from the segment before it,
+// so that the value following "/pets" is named "petId".
+func parameterName(previous string) string {
+	if previous == "" {
+		return "id"
+	}
+	name := strings.TrimSuffix(previous, "s")
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.Title(words[i])
+	}
+	name = strings.Join(words, "")
+	if name == "" {
+		return "id"
+	}
+	return name + "Id"
+}
+
+// pathTemplate converts a concrete request path into a templated path and the
+// names and values of its path parameters.
+func pathTemplate(path string) (string, []string, []string) {
+	segments := strings.Split(path, "/")
+	names := make([]string, 0)
+	values := make([]string, 0)
+	used := make(map[string]bool)
+	previous := ""
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if !isIdentifier(segment) {
+			previous = segment
+			continue
+		}
+		name := parameterName(previous)
+		for n := 2; used[name]; n++ {
+			name = parameterName(previous) + strconv.Itoa(n)
+		}
+		used[name] = true
+		names = append(names, name)
+		values = append(values, segment)
+		segments[i] = "{" + name + "}"
+		previous = ""
+	}
+	template := strings.Join(segments, "/")
+	if template == "" {
+		template = "/"
+	}
+	return template, names, values
+}
+
+// An observedOperation accumulates the samples of one method and path.
+type observedOperation struct {
+	method     string
+	path       string
+	count      int
+	pathParams []string
+	parameters map[string]*observedParameter
+	bodies     map[string]*sketch // media type -> body
+	responses  map[int]map[string]*sketch
+}
+
+type observedParameter struct {
+	name   string
+	in     string
+	count  int
+	values *sketch
+}
+
+func newObservedOperation(method string, path string) *observedOperation {
+	return &observedOperation{
+		method:     method,
+		path:       path,
+		parameters: make(map[string]*observedParameter),
+		bodies:     make(map[string]*sketch),
+		responses:  make(map[int]map[string]*sketch),
+	}
+}
+
+func (o *observedOperation) parameter(name string, in string) *observedParameter {
+	key := in + ":" + name
+	p, ok := o.parameters[key]
+	if !ok {
+		p = &observedParameter{name: name, in: in, values: newSketch()}
+		o.parameters[key] = p
+	}
+	return p
+}
+
+// add records a sample of the operation.
+func (o *observedOperation) add(sample *Sample, u *url.URL, names []string, values []string) {
+	o.count++
+	o.pathParams = names
+	for i, name := range names {
+		p := o.parameter(name, "path")
+		p.count++
+		p.values.addText(values[i])
+	}
+	for name, v := range u.Query() {
+		p := o.parameter(name, "query")
+		p.count++
+		for _, value := range v {
+			p.values.addText(value)
+		}
+	}
+	if len(sample.RequestBody) > 0 {
+		recordBody(o.bodies, sample.RequestContentType, sample.RequestBody)
+	}
+	responses, ok := o.responses[sample.Status]
+	if !ok {
+		responses = make(map[string]*sketch)
+		o.responses[sample.Status] = responses
+	}
+	if len(sample.ResponseBody) > 0 && sample.Status != http.StatusNoContent {
+		recordBody(responses, sample.ResponseContentType, sample.ResponseBody)
+	}
+}
+
+// recordBody adds a body to the sketch for its media type. Bodies that
+// can't be read as their media type are described only by the media type.
+func recordBody(bodies map[string]*sketch, contentType string, body []byte) {
+	t := mediaType(contentType)
+	if t == "" {
+		t = "application/octet-stream"
+	}
+	s, ok := bodies[t]
+	if !ok {
+		s = newSketch()
+		bodies[t] = s
+	}
+	switch {
+	case isJSON(t):
+		if err := s.addJSON(body); err != nil {
+			s.add(string(body))
+		}
+	case t == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			s.addForm(values)
+		}
+	}
+}
+
+// NewDocument infers an OpenAPI v3 description from a collection of samples.
+// The result is a starting point: it describes only what was observed.
+func NewDocument(samples []*Sample, options *Options) (*openapi_v3.Document, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	hosts := make(map[string]bool)
+	for _, host := range options.Hosts {
+		hosts[host] = true
+	}
+	servers := make(map[string]int)
+	operations := make(map[string]*observedOperation)
+	for _, sample := range samples {
+		if sample.Method == "OPTIONS" || sample.Method == "CONNECT" {
+			continue // CORS preflights and proxy tunnels aren't API calls
+		}
+		if !options.IncludeStatic && isStatic(mediaType(sample.ResponseContentType)) {
+			continue
+		}
+		u, err := sample.parsedURL()
+		if err != nil || u.Host == "" {
+			continue
+		}
+		if len(hosts) > 0 && !hosts[u.Host] {
+			continue
+		}
+		servers[u.Scheme+"://"+u.Host]++
+		path, names, values := pathTemplate(u.EscapedPath())
+		key := sample.Method + " " + path
+		o, ok := operations[key]
+		if !ok {
+			o = newObservedOperation(sample.Method, path)
+			operations[key] = o
+		}
+		o.add(sample, u, names, values)
+	}
+	if len(operations) == 0 {
+		return nil, errors.New("no API requests found in samples")
+	}
+
+	d := &openapi_v3.Document{
+		Openapi: "3.0.3",
+		Info:    &openapi_v3.Info{Title: options.Title, Version: options.Version},
+		Paths:   &openapi_v3.Paths{},
+	}
+	if d.Info.Title == "" {
+		d.Info.Title = "Inferred API"
+	}
+	if d.Info.Version == "" {
+		d.Info.Version = "0.0.0"
+	}
+	// List servers with the most frequently used first.
+	serverURLs := make([]string, 0, len(servers))
+	for server := range servers {
+		serverURLs = append(serverURLs, server)
+	}
+	sort.Slice(serverURLs, func(i, j int) bool {
+		if servers[serverURLs[i]] != servers[serverURLs[j]] {
+			return servers[serverURLs[i]] > servers[serverURLs[j]]
+		}
+		return serverURLs[i] < serverURLs[j]
+	})
+	for _, server := range serverURLs {
+		d.Servers = append(d.Servers, &openapi_v3.Server{Url: server})
+	}
+
+	paths := make(map[string]*openapi_v3.PathItem)
+	pathNames := make([]string, 0)
+	for _, o := range operations {
+		if _, ok := paths[o.path]; !ok {
+			paths[o.path] = &openapi_v3.PathItem{}
+			pathNames = append(pathNames, o.path)
+		}
+	}
+	sort.Strings(pathNames)
+	for _, path := range pathNames {
+		item := paths[path]
+		for _, method := range methodNames {
+			o, ok := operations[method+" "+path]
+			if !ok {
+				continue
+			}
+			setOperation(item, method, o.operation())
+		}
+		d.Paths.Path = append(d.Paths.Path, &openapi_v3.NamedPathItem{Name: path, Value: item})
+	}
+	return d, nil
+}
+
+func setOperation(item *openapi_v3.PathItem, method string, operation *openapi_v3.Operation) {
+	switch method {
+	case "GET":
+		item.Get = operation
+	case "PUT":
+		item.Put = operation
+	case "POST":
+		item.Post = operation
+	case "DELETE":
+		item.Delete = operation
+	case "OPTIONS":
+		item.Options = operation
+	case "HEAD":
+		item.Head = operation
+	case "PATCH":
+		item.Patch = operation
+	case "TRACE":
+		item.Trace = operation
+	}
+}
+
+// operation builds the description of an observed operation.
+func (o *observedOperation) operation() *openapi_v3.Operation {
+	operation := &openapi_v3.Operation{
+		OperationId: operationID(o.method, o.path),
+		Responses:   &openapi_v3.Responses{},
+	}
+	// Path parameters come first in path order, followed by sorted query parameters.
+	for _, name := range o.pathParams {
+		operation.Parameters = append(operation.Parameters, o.parameters["path:"+name].parameter(o.count))
+	}
+	keys := make([]string, 0)
+	for key, p := range o.parameters {
+		if p.in != "path" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		operation.Parameters = append(operation.Parameters, o.parameters[key].parameter(o.count))
+	}
+	if len(o.bodies) > 0 {
+		operation.RequestBody = &openapi_v3.RequestBodyOrReference{
+			Oneof: &openapi_v3.RequestBodyOrReference_RequestBody{
+				RequestBody: &openapi_v3.RequestBody{Content: mediaTypes(o.bodies), Required: true},
+			},
+		}
+	}
+	codes := make([]int, 0, len(o.responses))
+	for code := range o.responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		description := http.StatusText(code)
+		if description == "" {
+			description = "Response " + strconv.Itoa(code)
+		}
+		response := &openapi_v3.Response{Description: description}
+		if len(o.responses[code]) > 0 {
+			response.Content = mediaTypes(o.responses[code])
+		}
+		operation.Responses.ResponseOrReference = append(operation.Responses.ResponseOrReference,
+			&openapi_v3.NamedResponseOrReference{
+				Name: strconv.Itoa(code),
+				Value: &openapi_v3.ResponseOrReference{
+					Oneof: &openapi_v3.ResponseOrReference_Response{Response: response},
+				},
+			})
+	}
+	return operation
+}
+
+// parameter builds the description of a parameter. Query parameters are
+// required if they appeared in every sample of the operation.
+func (p *observedParameter) parameter(samples int) *openapi_v3.ParameterOrReference {
+	return &openapi_v3.ParameterOrReference{
+		Oneof: &openapi_v3.ParameterOrReference_Parameter{
+			Parameter: &openapi_v3.Parameter{
+				Name:     p.name,
+				In:       p.in,
+				Required: p.in == "path" || p.count == samples,
+				Schema:   schemaOrReference(p.values.schema()),
+			},
+		},
+	}
+}
+
+// mediaTypes describes the bodies observed for each media type.
+func mediaTypes(bodies map[string]*sketch) *openapi_v3.MediaTypes {
+	names := make([]string, 0, len(bodies))
+	for name := range bodies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	content := &openapi_v3.MediaTypes{}
+	for _, name := range names {
+		var schema *openapi_v3.Schema
+		if bodies[name].count > 0 {
+			schema = bodies[name].schema()
+		} else if strings.HasPrefix(name, "text/") {
+			schema = &openapi_v3.Schema{Type: "string"}
+		} else {
+			schema = &openapi_v3.Schema{Type: "string", Format: "binary"}
+		}
+		content.AdditionalProperties = append(content.AdditionalProperties,
+			&openapi_v3.NamedMediaType{Name: name, Value: &openapi_v3.MediaType{Schema: schemaOrReference(schema)}})
+	}
+	return content
+}
+
+// operationID builds an operation ID from a method and path,
+// so that "GET /pets/{petId}" becomes "getPetsByPetId".
+func operationID(method string, path string) string {
+	id := strings.ToLower(method)
+	by := make([]string, 0)
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") {
+			by = append(by, strings.Title(strings.Trim(segment, "{}")))
+			continue
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+		}) {
+			id += strings.Title(word)
+		}
+	}
+	if len(by) > 0 {
+		id += "By" + strings.Join(by, "And")
+	}
+	return id
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// A logRecord is one line of a JSON Lines traffic log. Bodies may be
+// written as JSON values or, for non-JSON content, as strings.
+type logRecord struct {
+	Method              string            `json:"method"`
+	URL                 string            `json:"url"`
+	Status              int               `json:"status"`
+	RequestHeaders      map[string]string `json:"requestHeaders"`
+	RequestContentType  string            `json:"requestContentType"`
+	RequestBody         json.RawMessage   `json:"requestBody"`
+	ResponseContentType string            `json:"responseContentType"`
+	ResponseBody        json.RawMessage   `json:"responseBody"`
+}
+
+// ParseJSONL reads samples from a JSON Lines traffic log with one exchange per line:
+//
+//	{"method": "GET", "url": "https://api.example.com/pets?limit=1", "status": 200,
+//	 "responseContentType": "application/json", "responseBody": [{"name": "Fido"}]}
+//
+// Blank lines are ignored.
+func ParseJSONL(b []byte) ([]*Sample, error) {
+	samples := make([]*Sample, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 64*1024), len(b)+1)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var record logRecord
+		if err := json.Unmarshal(text, &record); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		sample := &Sample{
+			Method:              strings.ToUpper(record.Method),
+			URL:                 record.URL,
+			RequestHeaders:      make(map[string]string),
+			RequestContentType:  record.RequestContentType,
+			RequestBody:         logBody(record.RequestBody, record.RequestContentType),
+			Status:              record.Status,
+			ResponseContentType: record.ResponseContentType,
+			ResponseBody:        logBody(record.ResponseBody, record.ResponseContentType),
+		}
+		for name, value := range record.RequestHeaders {
+			sample.RequestHeaders[strings.ToLower(name)] = value
+		}
+		samples = append(samples, sample)
+	}
+	return samples, scanner.Err()
+}
+
+// logBody returns the bytes of a logged body. Strings are unquoted unless
+// the content is JSON, where a string is itself a valid body.
+func logBody(raw json.RawMessage, contentType string) []byte {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if raw[0] == '"' && !isJSON(mediaType(contentType)) {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return []byte(s)
+		}
+	}
+	return []byte(raw)
+}
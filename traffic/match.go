@@ -0,0 +1,126 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// A matcher finds the documented operations for requests.
+type matcher struct {
+	document *openapi_v3.Document
+	prefixes []string // base paths of servers, longest first
+}
+
+func newMatcher(document *openapi_v3.Document) *matcher {
+	m := &matcher{document: document}
+	for _, server := range document.Servers {
+		if u, err := url.Parse(withoutVariables(server.Url)); err == nil {
+			m.prefixes = append(m.prefixes, strings.TrimSuffix(u.Path, "/"))
+		}
+	}
+	sort.Slice(m.prefixes, func(i, j int) bool { return len(m.prefixes[i]) > len(m.prefixes[j]) })
+	return m
+}
+
+// withoutVariables removes the braces around server variables so that server URLs can be parsed.
+func withoutVariables(s string) string {
+	return strings.NewReplacer("{", "", "}", "").Replace(s)
+}
+
+// relativePath removes the base path of a server from a request path.
+func (m *matcher) relativePath(path string) string {
+	for _, prefix := range m.prefixes {
+		if prefix != "" && strings.HasPrefix(path, prefix+"/") {
+			return strings.TrimPrefix(path, prefix)
+		}
+	}
+	return path
+}
+
+// match finds the path item for a request path, preferring templates with
+// fewer parameters so that "/pets/mine" matches "/pets/mine" before "/pets/{petId}".
+func (m *matcher) match(path string) (string, *openapi_v3.PathItem) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	bestParameters := -1
+	var bestName string
+	var best *openapi_v3.PathItem
+	for _, named := range m.document.GetPaths().GetPath() {
+		templateSegments := strings.Split(strings.Trim(named.Name, "/"), "/")
+		if len(templateSegments) != len(segments) {
+			continue
+		}
+		parameters := 0
+		matched := true
+		for i, t := range templateSegments {
+			if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") && segments[i] != "" {
+				parameters++
+			} else if t != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched && (best == nil || parameters < bestParameters) {
+			bestName, best, bestParameters = named.Name, named.Value, parameters
+		}
+	}
+	return bestName, best
+}
+
+func operationForMethod(item *openapi_v3.PathItem, method string) *openapi_v3.Operation {
+	switch method {
+	case "GET":
+		return item.Get
+	case "PUT":
+		return item.Put
+	case "POST":
+		return item.Post
+	case "DELETE":
+		return item.Delete
+	case "OPTIONS":
+		return item.Options
+	case "HEAD":
+		return item.Head
+	case "PATCH":
+		return item.Patch
+	case "TRACE":
+		return item.Trace
+	}
+	return nil
+}
+
+// responseKey returns the key of the documented response for a status code,
+// falling back to a range like "4XX" and then to "default". It returns ""
+// if no response covers the status code.
+func responseKey(responses *openapi_v3.Responses, code string) string {
+	if responses == nil {
+		return ""
+	}
+	for _, candidate := range []string{code, code[:1] + "XX", code[:1] + "xx"} {
+		for _, named := range responses.ResponseOrReference {
+			if named.Name == candidate {
+				return candidate
+			}
+		}
+	}
+	if responses.Default != nil {
+		return "default"
+	}
+	return ""
+}
@@ -0,0 +1,378 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// Kinds of findings reported by Refine.
+const (
+	UndocumentedOperation = "undocumented-operation"
+	UndocumentedResponse  = "undocumented-response"
+	UndocumentedProperty  = "undocumented-property"
+	SchemaMismatch        = "schema-mismatch"
+	WidenedEnum           = "widened-enum"
+)
+
+// A Finding is a difference between a description and observed traffic.
+type Finding struct {
+	Kind     string
+	Method   string
+	Path     string
+	Location string // e.g. "response 200 body .items[].kind"
+	Message  string
+	Count    int // number of samples with this finding
+}
+
+func (f *Finding) String() string {
+	s := fmt.Sprintf("%s %s %s", f.Kind, f.Method, f.Path)
+	if f.Location != "" {
+		s += " " + f.Location
+	}
+	if f.Message != "" {
+		s += ": " + f.Message
+	}
+	if f.Count > 1 {
+		s += fmt.Sprintf(" (%d samples)", f.Count)
+	}
+	return s
+}
+
+// A Report lists the findings of Refine.
+type Report struct {
+	Samples  int // number of samples checked
+	Matched  int // number of samples that matched a documented operation
+	Findings []*Finding
+}
+
+// String returns a text representation of a report, one finding per line.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d samples, %d matched documented operations, %d findings\n",
+		r.Samples, r.Matched, len(r.Findings))
+	for _, f := range r.Findings {
+		b.WriteString(f.String() + "\n")
+	}
+	return b.String()
+}
+
+// refiner checks samples against a description.
+type refiner struct {
+	*matcher
+	findings map[string]*Finding
+	quiet    bool // if true, enumerations are not widened
+}
+
+// Refine checks samples against an OpenAPI v3 description. String values
+// that are missing from enumerations are added to them, modifying the
+// document in place; all other differences are reported without changes.
+func Refine(document *openapi_v3.Document, samples []*Sample) *Report {
+	r := &refiner{matcher: newMatcher(document), findings: make(map[string]*Finding)}
+	report := &Report{}
+	for _, sample := range samples {
+		report.Samples++
+		if r.check(sample) {
+			report.Matched++
+		}
+	}
+	for _, f := range r.findings {
+		report.Findings = append(report.Findings, f)
+	}
+	sort.Slice(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Location+a.Message < b.Location+b.Message
+	})
+	return report
+}
+
+func (r *refiner) report(kind, method, path, location, message string) {
+	key := strings.Join([]string{kind, method, path, location, message}, "\x00")
+	if f, ok := r.findings[key]; ok {
+		f.Count++
+		return
+	}
+	r.findings[key] = &Finding{Kind: kind, Method: method, Path: path, Location: location, Message: message, Count: 1}
+}
+
+// check compares a sample with the description and returns true if it
+// matched a documented operation.
+func (r *refiner) check(sample *Sample) bool {
+	u, err := sample.parsedURL()
+	if err != nil {
+		return false
+	}
+	path := r.relativePath(u.EscapedPath())
+	template, item := r.match(path)
+	if item == nil {
+		template, _, _ = pathTemplate(path)
+		r.report(UndocumentedOperation, sample.Method, template, "", "")
+		return false
+	}
+	operation := operationForMethod(item, sample.Method)
+	if operation == nil {
+		r.report(UndocumentedOperation, sample.Method, template, "", "")
+		return false
+	}
+	method := sample.Method
+
+	// Check query parameters.
+	for _, p := range append(operation.Parameters, item.Parameters...) {
+		parameter := p.GetParameter()
+		if parameter == nil || parameter.In != "query" {
+			continue
+		}
+		schema := r.schema(parameter.Schema)
+		for _, text := range u.Query()[parameter.Name] {
+			var value interface{} = text
+			if schema != nil && schema.Type != "string" {
+				value = scalarValue(text)
+			}
+			r.checkValue(value, schema, method, template, "query "+parameter.Name, "")
+		}
+	}
+
+	// Check the request body.
+	if len(sample.RequestBody) > 0 && isJSON(mediaType(sample.RequestContentType)) {
+		if body := operation.RequestBody.GetRequestBody(); body != nil {
+			r.checkBody(body.Content, sample.RequestContentType, sample.RequestBody, method, template, "request body")
+		}
+	}
+
+	// Check the response.
+	code := strconv.Itoa(sample.Status)
+	response := r.response(operation.Responses, code)
+	if response == nil {
+		r.report(UndocumentedResponse, method, template, "response "+code, "")
+		return true
+	}
+	if len(sample.ResponseBody) > 0 && isJSON(mediaType(sample.ResponseContentType)) {
+		r.checkBody(response.Content, sample.ResponseContentType, sample.ResponseBody, method, template,
+			"response "+code+" body")
+	}
+	return true
+}
+
+// response finds the documented response for a status code.
+func (r *refiner) response(responses *openapi_v3.Responses, code string) *openapi_v3.Response {
+	switch key := responseKey(responses, code); key {
+	case "":
+		return nil
+	case "default":
+		return r.resolveResponse(responses.Default)
+	default:
+		for _, named := range responses.ResponseOrReference {
+			if named.Name == key {
+				return r.resolveResponse(named.Value)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *refiner) resolveResponse(v *openapi_v3.ResponseOrReference) *openapi_v3.Response {
+	if response := v.GetResponse(); response != nil {
+		return response
+	}
+	name := strings.TrimPrefix(v.GetReference().GetXRef(), "#/components/responses/")
+	for _, named := range r.document.GetComponents().GetResponses().GetAdditionalProperties() {
+		if named.Name == name {
+			return r.resolveResponse(named.Value)
+		}
+	}
+	return nil
+}
+
+// schema resolves a schema or a local reference to one.
+func (r *refiner) schema(v *openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	for depth := 0; v != nil && depth < 32; depth++ {
+		if schema := v.GetSchema(); schema != nil {
+			return schema
+		}
+		name := strings.TrimPrefix(v.GetReference().GetXRef(), "#/components/schemas/")
+		v = nil
+		for _, named := range r.document.GetComponents().GetSchemas().GetAdditionalProperties() {
+			if named.Name == name {
+				v = named.Value
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// checkBody checks a JSON body against the schema for its media type.
+func (r *refiner) checkBody(content *openapi_v3.MediaTypes, contentType string, body []byte, method, path, location string) {
+	t := mediaType(contentType)
+	var schema *openapi_v3.Schema
+	found := false
+	for _, named := range content.GetAdditionalProperties() {
+		if named.Name == t || named.Name == "*/*" || named.Name == "application/*" {
+			schema = r.schema(named.Value.GetSchema())
+			found = true
+			break
+		}
+	}
+	if !found {
+		r.report(SchemaMismatch, method, path, location, "undocumented media type "+t)
+		return
+	}
+	var value interface{}
+	if err := unmarshalJSON(body, &value); err != nil {
+		r.report(SchemaMismatch, method, path, location, "invalid JSON: "+err.Error())
+		return
+	}
+	r.checkValue(value, schema, method, path, location, "")
+}
+
+// checkValue checks a value against a schema, reporting differences at
+// the JSON path "pointer" below location. It returns true if no
+// differences were found.
+func (r *refiner) checkValue(value interface{}, schema *openapi_v3.Schema, method, path, location, pointer string) bool {
+	if schema == nil {
+		return true
+	}
+	where := strings.TrimSpace(location + " " + pointer)
+	ok := true
+	for _, s := range schema.AllOf {
+		ok = r.checkValue(value, r.schema(s), method, path, location, pointer) && ok
+	}
+	if alternatives := append(schema.OneOf, schema.AnyOf...); len(alternatives) > 0 {
+		matched := false
+		for _, s := range alternatives {
+			if r.matches(value, r.schema(s)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			r.report(SchemaMismatch, method, path, where, "value matches none of the alternative schemas")
+			ok = false
+		}
+	}
+	if value == nil {
+		if !schema.Nullable && schema.Type != "" {
+			r.report(SchemaMismatch, method, path, where, "null value for non-nullable "+schema.Type)
+			return false
+		}
+		return ok
+	}
+	actual := jsonType(value)
+	if schema.Type != "" && actual != schema.Type && !(schema.Type == "number" && actual == "integer") {
+		r.report(SchemaMismatch, method, path, where, fmt.Sprintf("expected %s, found %s", schema.Type, actual))
+		return false
+	}
+	if s, isString := value.(string); isString && len(schema.Enum) > 0 && !enumContains(schema.Enum, s) && !r.quiet {
+		bytes, _ := yaml.Marshal(s)
+		schema.Enum = append(schema.Enum, &openapi_v3.Any{Yaml: string(bytes)})
+		r.report(WidenedEnum, method, path, where, fmt.Sprintf("added %q", s))
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		if schema.Items != nil && len(schema.Items.SchemaOrReference) > 0 {
+			items := r.schema(schema.Items.SchemaOrReference[0])
+			for _, item := range v {
+				ok = r.checkValue(item, items, method, path, location, pointer+"[]") && ok
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, present := v[name]; !present {
+				r.report(SchemaMismatch, method, path, where, "missing required property "+name)
+				ok = false
+			}
+		}
+		properties := make(map[string]*openapi_v3.Schema)
+		for _, named := range schema.GetProperties().GetAdditionalProperties() {
+			properties[named.Name] = r.schema(named.Value)
+		}
+		additional := schema.AdditionalProperties.GetSchemaOrReference()
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, documented := properties[key]; documented {
+				ok = r.checkValue(v[key], property, method, path, location, pointer+"."+key) && ok
+			} else if additional != nil {
+				ok = r.checkValue(v[key], r.schema(additional), method, path, location, pointer+"."+key) && ok
+			} else if len(properties) > 0 && !schema.AdditionalProperties.GetBoolean() {
+				r.report(UndocumentedProperty, method, path, strings.TrimSpace(location+" "+pointer+"."+key), "")
+			}
+		}
+	}
+	return ok
+}
+
+// matches returns true if a value matches a schema, without reporting anything.
+func (r *refiner) matches(value interface{}, schema *openapi_v3.Schema) bool {
+	quiet := &refiner{matcher: r.matcher, findings: make(map[string]*Finding), quiet: true}
+	quiet.checkValue(value, schema, "", "", "", "")
+	for _, f := range quiet.findings {
+		if f.Kind == SchemaMismatch {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonType returns the JSON Schema type of a value decoded with UseNumber.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "null"
+}
+
+// enumContains returns true if an enumeration includes a string value.
+func enumContains(enum []*openapi_v3.Any, s string) bool {
+	for _, e := range enum {
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(e.Yaml), &value); err == nil && fmt.Sprint(value) == s {
+			return true
+		}
+	}
+	return false
+}
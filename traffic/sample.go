@@ -0,0 +1,71 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traffic builds and checks OpenAPI descriptions using recorded HTTP traffic.
+package traffic
+
+import (
+	"mime"
+	"net/url"
+	"strings"
+)
+
+// A Sample is a single observed HTTP exchange.
+type Sample struct {
+	Method              string
+	URL                 string
+	RequestHeaders      map[string]string
+	RequestContentType  string
+	RequestBody         []byte
+	Status              int
+	ResponseContentType string
+	ResponseBody        []byte
+}
+
+// mediaType returns the media type of a Content-Type value without its parameters.
+func mediaType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	t, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+	return t
+}
+
+// isJSON returns true for application/json and the +json structured syntax suffix.
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isStatic returns true for media types of page assets that are not part of an API.
+func isStatic(mediaType string) bool {
+	for _, prefix := range []string{"image/", "font/", "audio/", "video/"} {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	switch mediaType {
+	case "text/html", "text/css", "text/javascript", "application/javascript",
+		"application/x-javascript", "application/wasm":
+		return true
+	}
+	return false
+}
+
+// parsedURL returns the parsed URL of a sample.
+func (s *Sample) parsedURL() (*url.URL, error) {
+	return url.Parse(s.URL)
+}
@@ -0,0 +1,245 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+// A sketch accumulates the shape of the values observed at one location
+// in a payload, such as a request body, a property, or an array item.
+type sketch struct {
+	count      int            // number of values observed
+	types      map[string]int // JSON Schema type name -> count
+	formats    map[string]int // string format -> count
+	objects    int            // number of objects observed
+	properties map[string]*sketch
+	items      *sketch
+}
+
+func newSketch() *sketch {
+	return &sketch{types: make(map[string]int), formats: make(map[string]int)}
+}
+
+// add records a value decoded by encoding/json with UseNumber.
+func (s *sketch) add(value interface{}) {
+	s.count++
+	switch v := value.(type) {
+	case nil:
+		s.types["null"]++
+	case bool:
+		s.types["boolean"]++
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			s.types["integer"]++
+		} else {
+			s.types["number"]++
+		}
+	case string:
+		s.types["string"]++
+		if format := stringFormat(v); format != "" {
+			s.formats[format]++
+		}
+	case []interface{}:
+		s.types["array"]++
+		if s.items == nil {
+			s.items = newSketch()
+		}
+		for _, item := range v {
+			s.items.add(item)
+		}
+	case map[string]interface{}:
+		s.types["object"]++
+		s.objects++
+		if s.properties == nil {
+			s.properties = make(map[string]*sketch)
+		}
+		for key, property := range v {
+			p, ok := s.properties[key]
+			if !ok {
+				p = newSketch()
+				s.properties[key] = p
+			}
+			p.add(property)
+		}
+	}
+}
+
+// addJSON records the value of a JSON document.
+func (s *sketch) addJSON(b []byte) error {
+	var value interface{}
+	if err := unmarshalJSON(b, &value); err != nil {
+		return err
+	}
+	s.add(value)
+	return nil
+}
+
+// addText records a value read from a query string, form, or path segment.
+func (s *sketch) addText(text string) {
+	s.add(scalarValue(text))
+}
+
+// addForm records the fields of a URL-encoded form as an object.
+func (s *sketch) addForm(values url.Values) {
+	object := make(map[string]interface{})
+	for key, v := range values {
+		if len(v) > 1 {
+			items := make([]interface{}, len(v))
+			for i, item := range v {
+				items[i] = scalarValue(item)
+			}
+			object[key] = items
+		} else if len(v) == 1 {
+			object[key] = scalarValue(v[0])
+		}
+	}
+	s.add(object)
+}
+
+func unmarshalJSON(b []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}
+
+// scalarValue converts text to the JSON value it most likely represents.
+func scalarValue(text string) interface{} {
+	if _, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return json.Number(text)
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return json.Number(text)
+	}
+	if text == "true" || text == "false" {
+		return text == "true"
+	}
+	return text
+}
+
+var (
+	uuidRegex  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// stringFormat returns the OpenAPI format of a string, if it has a recognizable one.
+func stringFormat(s string) string {
+	switch {
+	case uuidRegex.MatchString(s):
+		return "uuid"
+	case emailRegex.MatchString(s):
+		return "email"
+	}
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return "date-time"
+	}
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return "date"
+	}
+	if u, err := url.Parse(s); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return "uri"
+	}
+	return ""
+}
+
+// typeNames returns the non-null types observed, with integers folded into
+// numbers when both were seen.
+func (s *sketch) typeNames() []string {
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		if name == "null" || (name == "integer" && s.types["number"] > 0) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// schema returns a schema that describes all of the values observed.
+func (s *sketch) schema() *openapi_v3.Schema {
+	names := s.typeNames()
+	var schema *openapi_v3.Schema
+	switch len(names) {
+	case 0:
+		schema = &openapi_v3.Schema{}
+	case 1:
+		schema = s.schemaForType(names[0])
+	default:
+		schema = &openapi_v3.Schema{}
+		for _, name := range names {
+			schema.AnyOf = append(schema.AnyOf, schemaOrReference(s.schemaForType(name)))
+		}
+	}
+	schema.Nullable = s.types["null"] > 0
+	return schema
+}
+
+func (s *sketch) schemaForType(name string) *openapi_v3.Schema {
+	schema := &openapi_v3.Schema{Type: name}
+	switch name {
+	case "string":
+		// Only report a format that every observed string had.
+		formats := make([]string, 0, len(s.formats))
+		for format, count := range s.formats {
+			if count == s.types["string"] {
+				formats = append(formats, format)
+			}
+		}
+		if len(formats) > 0 {
+			sort.Strings(formats)
+			schema.Format = formats[0]
+		}
+	case "array":
+		if s.items != nil && s.items.count > 0 {
+			schema.Items = &openapi_v3.ItemsItem{
+				SchemaOrReference: []*openapi_v3.SchemaOrReference{schemaOrReference(s.items.schema())},
+			}
+		}
+	case "object":
+		keys := make([]string, 0, len(s.properties))
+		for key := range s.properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if len(keys) > 0 {
+			schema.Properties = &openapi_v3.Properties{}
+		}
+		for _, key := range keys {
+			property := s.properties[key]
+			schema.Properties.AdditionalProperties = append(schema.Properties.AdditionalProperties,
+				&openapi_v3.NamedSchemaOrReference{Name: key, Value: schemaOrReference(property.schema())})
+			// A property is required if it appeared in every object.
+			if property.count == s.objects {
+				schema.Required = append(schema.Required, key)
+			}
+		}
+	}
+	return schema
+}
+
+func schemaOrReference(schema *openapi_v3.Schema) *openapi_v3.SchemaOrReference {
+	return &openapi_v3.SchemaOrReference{
+		Oneof: &openapi_v3.SchemaOrReference_Schema{Schema: schema},
+	}
+}
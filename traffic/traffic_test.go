@@ -0,0 +1,264 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"testing"
+
+	openapi_v3 "github.com/okkoye/gnostic/openapiv3"
+)
+
+const testHAR = `{
+  "log": {
+    "entries": [
+      {
+        "request": {"method": "GET", "url": "https://api.example.com/v1/pets?limit=10", "headers": []},
+        "response": {"status": 200, "content": {"mimeType": "application/json",
+          "text": "[{\"id\": 1, \"name\": \"Fido\", \"born\": \"2020-01-02T03:04:05Z\"}, {\"id\": 2, \"name\": \"Rex\", \"tag\": null}]"}}
+      },
+      {
+        "request": {"method": "GET", "url": "https://api.example.com/v1/pets", "headers": []},
+        "response": {"status": 200, "content": {"mimeType": "application/json; charset=utf-8", "text": "[]"}}
+      },
+      {
+        "request": {"method": "GET", "url": "https://api.example.com/v1/pets/42", "headers": []},
+        "response": {"status": 200, "content": {"mimeType": "application/json", "text": "eyJpZCI6IDQyLCAibmFtZSI6ICJGaWRvIn0=", "encoding": "base64"}}
+      },
+      {
+        "request": {"method": "GET", "url": "https://api.example.com/v1/pets/43", "headers": []},
+        "response": {"status": 404, "content": {"mimeType": "application/json", "text": "{\"code\": 404, \"message\": \"not found\"}"}}
+      },
+      {
+        "request": {"method": "POST", "url": "https://api.example.com/v1/pets", "headers": [],
+          "postData": {"mimeType": "application/json", "text": "{\"name\": \"Spot\", \"weight\": 4.5}"}},
+        "response": {"status": 201, "content": {"mimeType": "", "text": ""}}
+      },
+      {
+        "request": {"method": "OPTIONS", "url": "https://api.example.com/v1/pets", "headers": []},
+        "response": {"status": 204, "content": {"mimeType": "", "text": ""}}
+      },
+      {
+        "request": {"method": "GET", "url": "https://www.example.com/logo.png", "headers": []},
+        "response": {"status": 200, "content": {"mimeType": "image/png", "text": ""}}
+      }
+    ]
+  }
+}`
+
+func schemaOf(s *openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	return s.GetSchema()
+}
+
+func TestInferenceFromHAR(t *testing.T) {
+	samples, err := ParseHAR([]byte(testHAR))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	d, err := NewDocument(samples, &Options{Title: "Pets"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(d.Servers) != 1 || d.Servers[0].Url != "https://api.example.com" {
+		t.Errorf("unexpected servers: %+v", d.Servers)
+	}
+	if len(d.Paths.Path) != 2 {
+		t.Fatalf("unexpected paths: %+v", d.Paths.Path)
+	}
+	pets := d.Paths.Path[0]
+	if pets.Name != "/v1/pets" || pets.Value.Get == nil || pets.Value.Post == nil || pets.Value.Options != nil {
+		t.Errorf("unexpected path item: %s %+v", pets.Name, pets.Value)
+	}
+	list := pets.Value.Get
+	if list.OperationId != "getV1Pets" {
+		t.Errorf("unexpected operation id: %s", list.OperationId)
+	}
+	limit := list.Parameters[0].GetParameter()
+	if limit.Name != "limit" || limit.Required || schemaOf(limit.Schema).Type != "integer" {
+		t.Errorf("unexpected parameter: %+v", limit)
+	}
+	items := schemaOf(schemaOf(list.Responses.ResponseOrReference[0].Value.GetResponse().Content.AdditionalProperties[0].Value.Schema).Items.SchemaOrReference[0])
+	if items.Type != "object" || len(items.Required) != 2 || items.Required[0] != "id" || items.Required[1] != "name" {
+		t.Errorf("unexpected item schema: %+v", items)
+	}
+	for _, property := range items.Properties.AdditionalProperties {
+		s := schemaOf(property.Value)
+		switch property.Name {
+		case "born":
+			if s.Type != "string" || s.Format != "date-time" {
+				t.Errorf("unexpected schema for born: %+v", s)
+			}
+		case "tag":
+			if !s.Nullable {
+				t.Errorf("expected tag to be nullable")
+			}
+		}
+	}
+	body := schemaOf(pets.Value.Post.RequestBody.GetRequestBody().Content.AdditionalProperties[0].Value.Schema)
+	if body.Properties.AdditionalProperties[1].Name != "weight" || schemaOf(body.Properties.AdditionalProperties[1].Value).Type != "number" {
+		t.Errorf("unexpected request body: %+v", body)
+	}
+	pet := d.Paths.Path[1]
+	if pet.Name != "/v1/pets/{petId}" || pet.Value.Get.OperationId != "getV1PetsByPetId" {
+		t.Errorf("unexpected path item: %s", pet.Name)
+	}
+	if n := len(pet.Value.Get.Responses.ResponseOrReference); n != 2 {
+		t.Errorf("unexpected number of responses: %d", n)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct{ path, template string }{
+		{"/users/123/orders/9f8e7d6c5b4a39", "/users/{userId}/orders/{orderId}"},
+		{"/items/550e8400-e29b-41d4-a716-446655440000", "/items/{itemId}"},
+		{"/1/2", "/{id}/{id2}"},
+		{"/", "/"},
+		{"/v2/status", "/v2/status"},
+	}
+	for _, test := range tests {
+		template, _, _ := pathTemplate(test.path)
+		if template != test.template {
+			t.Errorf("pathTemplate(%q) = %q, expected %q", test.path, template, test.template)
+		}
+	}
+}
+
+const testDescription = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        kind:
+          type: string
+          enum: [cat, dog]
+`
+
+const testLog = `
+{"method": "GET", "url": "https://api.example.com/v1/pets?limit=2", "status": 200, "responseContentType": "application/json", "responseBody": [{"id": 1, "name": "Fido", "kind": "dog"}, {"id": 2, "name": "Nemo", "kind": "fish"}]}
+{"method": "GET", "url": "https://api.example.com/v1/pets/1", "status": 200, "responseContentType": "application/json", "responseBody": {"id": "1", "name": "Fido", "color": "brown"}}
+{"method": "GET", "url": "https://api.example.com/v1/pets/2", "status": 404, "responseContentType": "text/plain", "responseBody": "not found"}
+{"method": "DELETE", "url": "https://api.example.com/v1/pets/2", "status": 204}
+{"method": "GET", "url": "https://api.example.com/v1/owners/7", "status": 200}
+{"method": "GET", "url": "https://api.example.com/v1/owners/8", "status": 200}
+`
+
+func TestRefine(t *testing.T) {
+	d, err := openapi_v3.ParseDocument([]byte(testDescription))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	samples, err := ParseJSONL([]byte(testLog))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	report := Refine(d, samples)
+	if report.Samples != 6 || report.Matched != 3 {
+		t.Errorf("unexpected counts: %d samples, %d matched", report.Samples, report.Matched)
+	}
+	expected := []string{
+		"undocumented-operation GET /owners/{ownerId} (2 samples)",
+		"widened-enum GET /pets response 200 body [].kind: added \"fish\"",
+		"undocumented-operation DELETE /pets/{petId}",
+		"schema-mismatch GET /pets/{petId} response 200 body .id: expected integer, found string",
+		"undocumented-property GET /pets/{petId} response 200 body .color",
+		"undocumented-response GET /pets/{petId} response 404",
+	}
+	if len(report.Findings) != len(expected) {
+		t.Fatalf("unexpected findings:\n%s", report)
+	}
+	for i, f := range report.Findings {
+		if f.String() != expected[i] {
+			t.Errorf("unexpected finding %d: %s (expected %s)", i, f, expected[i])
+		}
+	}
+	kind := d.Components.Schemas.AdditionalProperties[0].Value.GetSchema().Properties.AdditionalProperties[2].Value.GetSchema()
+	if len(kind.Enum) != 3 {
+		t.Errorf("expected enum to be widened, got %+v", kind.Enum)
+	}
+}
+
+const testRequestLog = `
+# exercised by integration tests
+GET /v1/pets?limit=1 200
+GET https://api.example.com/v1/pets/7 200
+GET /v1/pets/8 500
+DELETE /v1/pets/8 204
+`
+
+func TestCoverage(t *testing.T) {
+	d, err := openapi_v3.ParseDocument([]byte(testDescription))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	samples, err := ParseRequestLog([]byte(testRequestLog))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	report := Coverage(d, samples)
+	if covered, total := report.OperationCounts(); covered != 2 || total != 2 {
+		t.Errorf("unexpected operation coverage: %d/%d", covered, total)
+	}
+	if covered, total := report.ResponseCounts(); covered != 2 || total != 2 {
+		t.Errorf("unexpected response coverage: %d/%d", covered, total)
+	}
+	if report.Unmatched != 2 {
+		t.Errorf("unexpected number of unmatched requests: %d", report.Unmatched)
+	}
+	if _, err := ParseRequestLog([]byte("GET /pets")); err == nil {
+		t.Errorf("expected error for malformed line")
+	}
+}
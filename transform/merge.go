@@ -0,0 +1,430 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// A MergeInput is a document to be merged along with the name of its source,
+// which is used to prefix its components when their names collide.
+type MergeInput struct {
+	Name     string
+	Document *yaml.Node
+}
+
+// MergeOptions control Merge.
+type MergeOptions struct {
+	// Title and Version replace the info of the first document, if set.
+	Title   string
+	Version string
+	// PrefixAll prefixes the names of all components with the names of
+	// their sources, not just the ones that collide.
+	PrefixAll bool
+}
+
+// Kinds of conflicts found by Merge.
+const (
+	ComponentConflict   = "component"
+	OperationConflict   = "operation"
+	OperationIDConflict = "operationId"
+	TagConflict         = "tag"
+)
+
+// A Conflict describes something defined differently in several merged documents.
+type Conflict struct {
+	Kind       string
+	Name       string
+	Sources    []string
+	Resolution string
+}
+
+func (c *Conflict) String() string {
+	return fmt.Sprintf("%s %s in %s: %s", c.Kind, c.Name, strings.Join(c.Sources, ", "), c.Resolution)
+}
+
+// merger accumulates a merged document.
+type merger struct {
+	options    *MergeOptions
+	root       *yaml.Node
+	components map[string]string // component reference -> name of defining source
+	paths      map[string]string // "method path" -> name of defining source
+	ids        map[string]string // operationId -> name of defining source
+	tags       map[string]*yaml.Node
+	conflicts  []*Conflict
+}
+
+// Merge combines several OpenAPI v3 documents into one. Components with the
+// same name and different definitions are renamed by prefixing them with the
+// name of their source; identical components are shared. When several
+// documents define the same operation or operation ID, the first one is kept.
+// Merge returns the combined document and a list of the conflicts it resolved.
+func Merge(inputs []*MergeInput, options *MergeOptions) (*yaml.Node, []*Conflict) {
+	if options == nil {
+		options = &MergeOptions{}
+	}
+	m := &merger{
+		options:    options,
+		root:       compiler.NewMappingNode(),
+		components: make(map[string]string),
+		paths:      make(map[string]string),
+		ids:        make(map[string]string),
+		tags:       make(map[string]*yaml.Node),
+		conflicts:  make([]*Conflict, 0),
+	}
+	// Work on copies, since documents are modified as they are merged.
+	copies := make([]*MergeInput, len(inputs))
+	for i, input := range inputs {
+		name := input.Name
+		if Prefix(name) == "" {
+			name = fmt.Sprintf("source%d", i+1)
+		}
+		copies[i] = &MergeInput{Name: name, Document: Copy(Root(input.Document))}
+	}
+	m.mergeHeader(copies)
+	for _, input := range copies {
+		m.merge(input)
+	}
+	if title := compiler.MapValueForKey(compiler.MapValueForKey(m.root, "info"), "title"); title != nil && options.Title != "" {
+		title.Value = options.Title
+	}
+	if version := compiler.MapValueForKey(compiler.MapValueForKey(m.root, "info"), "version"); version != nil && options.Version != "" {
+		version.Value = options.Version
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{m.root}}, m.conflicts
+}
+
+// mergeHeader copies the top-level fields other than paths, components,
+// and tags from the first document. Servers and security requirements are
+// kept at the top level only if all documents agree on them; otherwise
+// each document's values are moved into its path items and operations.
+func (m *merger) mergeHeader(inputs []*MergeInput) {
+	if len(inputs) == 0 {
+		return
+	}
+	first := Root(inputs[0].Document)
+	for i := 0; i+1 < len(first.Content); i += 2 {
+		switch key := first.Content[i].Value; key {
+		case "paths", "components", "tags":
+		case "servers":
+			if sameForAll(inputs, key) {
+				SetKey(m.root, key, Copy(first.Content[i+1]))
+			}
+		case "security":
+			if !m.distributeSecurity(inputs) {
+				SetKey(m.root, key, Copy(first.Content[i+1]))
+			}
+		default:
+			SetKey(m.root, key, Copy(first.Content[i+1]))
+		}
+	}
+	if !sameForAll(inputs, "servers") {
+		for _, input := range inputs {
+			root := Root(input.Document)
+			if servers := compiler.MapValueForKey(root, "servers"); servers != nil {
+				for _, item := range mapValues(compiler.MapValueForKey(root, "paths")) {
+					if compiler.MapValueForKey(item, "servers") == nil {
+						SetKey(item, "servers", Copy(servers))
+					}
+				}
+			}
+		}
+	}
+	if m.distributeSecurity(inputs) {
+		for _, input := range inputs {
+			root := Root(input.Document)
+			if security := compiler.MapValueForKey(root, "security"); security != nil {
+				for _, item := range mapValues(compiler.MapValueForKey(root, "paths")) {
+					for j := 0; j+1 < len(item.Content); j += 2 {
+						operation := item.Content[j+1]
+						if IsMethod(item.Content[j].Value) && compiler.MapValueForKey(operation, "security") == nil {
+							SetKey(operation, "security", Copy(security))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// sameForAll returns true if a top-level field is the same in all documents.
+func sameForAll(inputs []*MergeInput, key string) bool {
+	first := marshal(compiler.MapValueForKey(Root(inputs[0].Document), key))
+	for _, input := range inputs[1:] {
+		if !bytes.Equal(first, marshal(compiler.MapValueForKey(Root(input.Document), key))) {
+			return false
+		}
+	}
+	return true
+}
+
+// distributeSecurity returns true if top-level security requirements must be
+// moved into operations because they differ or may refer to renamed schemes.
+func (m *merger) distributeSecurity(inputs []*MergeInput) bool {
+	return m.options.PrefixAll || !sameForAll(inputs, "security") || securitySchemesDiffer(inputs)
+}
+
+// securitySchemesDiffer returns true if documents define security schemes
+// with the same names differently, so that identical security requirements
+// may mean different things.
+func securitySchemesDiffer(inputs []*MergeInput) bool {
+	schemes := make(map[string][]byte)
+	for _, input := range inputs {
+		section := compiler.MapValueForKey(compiler.MapValueForKey(Root(input.Document), "components"), "securitySchemes")
+		for i := 0; section != nil && i+1 < len(section.Content); i += 2 {
+			b := marshal(section.Content[i+1])
+			if existing, ok := schemes[section.Content[i].Value]; ok && !bytes.Equal(existing, b) {
+				return true
+			}
+			schemes[section.Content[i].Value] = b
+		}
+	}
+	return false
+}
+
+func mapValues(m *yaml.Node) []*yaml.Node {
+	values := make([]*yaml.Node, 0)
+	for i := 0; m != nil && i+1 < len(m.Content); i += 2 {
+		values = append(values, m.Content[i+1])
+	}
+	return values
+}
+
+// Prefix converts a source name into a prefix for component names,
+// so that "user-service" becomes "UserService".
+func Prefix(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, "")
+}
+
+// merge adds one document to the merged document.
+func (m *merger) merge(input *MergeInput) {
+	root := Root(input.Document)
+	prefix := Prefix(input.Name)
+
+	// Choose new names for components that collide with ones already merged.
+	renames := make(map[string]string)
+	components := compiler.MapValueForKey(root, "components")
+	for i := 0; components != nil && i+1 < len(components.Content); i += 2 {
+		kind, section := components.Content[i].Value, components.Content[i+1]
+		if strings.HasPrefix(kind, "x-") {
+			continue
+		}
+		for j := 0; j+1 < len(section.Content); j += 2 {
+			name := section.Content[j].Value
+			ref := ComponentReference(kind, name)
+			newName := prefix + strings.ToUpper(name[:1]) + name[1:]
+			if m.options.PrefixAll {
+				renames[ref] = newName
+				continue
+			}
+			source, exists := m.components[ref]
+			if !exists || bytes.Equal(marshal(Component(m.root, kind, name)), marshal(section.Content[j+1])) {
+				continue
+			}
+			renames[ref] = newName
+			m.conflicts = append(m.conflicts, &Conflict{
+				Kind:       ComponentConflict,
+				Name:       ref,
+				Sources:    []string{source, input.Name},
+				Resolution: fmt.Sprintf("renamed to %s in %s", newName, input.Name),
+			})
+		}
+	}
+	if len(renames) > 0 {
+		renameComponents(root, renames)
+	}
+
+	// Merge components.
+	components = compiler.MapValueForKey(root, "components")
+	for i := 0; components != nil && i+1 < len(components.Content); i += 2 {
+		kind, section := components.Content[i].Value, components.Content[i+1]
+		mergedComponents := mappingForKey(m.root, "components")
+		if strings.HasPrefix(kind, "x-") {
+			if compiler.MapValueForKey(mergedComponents, kind) == nil {
+				SetKey(mergedComponents, kind, section)
+			}
+			continue
+		}
+		mergedSection := mappingForKey(mergedComponents, kind)
+		for j := 0; j+1 < len(section.Content); j += 2 {
+			ref := ComponentReference(kind, section.Content[j].Value)
+			if _, exists := m.components[ref]; exists {
+				continue // identical to a component that is already merged
+			}
+			m.components[ref] = input.Name
+			mergedSection.Content = append(mergedSection.Content, section.Content[j], section.Content[j+1])
+		}
+	}
+
+	// Merge tags.
+	if tags := compiler.MapValueForKey(root, "tags"); tags != nil {
+		for _, tag := range tags.Content {
+			name, _ := compiler.StringForScalarNode(compiler.MapValueForKey(tag, "name"))
+			if existing, ok := m.tags[name]; ok {
+				if !bytes.Equal(marshal(existing), marshal(tag)) {
+					m.conflicts = append(m.conflicts, &Conflict{
+						Kind:       TagConflict,
+						Name:       name,
+						Sources:    []string{input.Name},
+						Resolution: "kept the first definition",
+					})
+				}
+				continue
+			}
+			m.tags[name] = tag
+			list := compiler.MapValueForKey(m.root, "tags")
+			if list == nil {
+				list = compiler.NewSequenceNode()
+				SetKey(m.root, "tags", list)
+			}
+			list.Content = append(list.Content, tag)
+		}
+	}
+
+	// Merge paths.
+	paths := compiler.MapValueForKey(root, "paths")
+	for i := 0; paths != nil && i+1 < len(paths.Content); i += 2 {
+		path, item := paths.Content[i].Value, paths.Content[i+1]
+		mergedPaths := mappingForKey(m.root, "paths")
+		mergedItem := compiler.MapValueForKey(mergedPaths, path)
+		if mergedItem == nil {
+			mergedItem = compiler.NewMappingNode()
+			SetKey(mergedPaths, path, mergedItem)
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			key, value := item.Content[j].Value, item.Content[j+1]
+			if !IsMethod(key) {
+				if compiler.MapValueForKey(mergedItem, key) == nil {
+					SetKey(mergedItem, key, value)
+				}
+				continue
+			}
+			operation := strings.ToUpper(key) + " " + path
+			if source, exists := m.paths[operation]; exists {
+				m.conflicts = append(m.conflicts, &Conflict{
+					Kind:       OperationConflict,
+					Name:       operation,
+					Sources:    []string{source, input.Name},
+					Resolution: "kept the operation from " + source,
+				})
+				continue
+			}
+			m.paths[operation] = input.Name
+			if id := compiler.MapValueForKey(value, "operationId"); id != nil && id.Value != "" {
+				if source, exists := m.ids[id.Value]; exists {
+					newID := strings.ToLower(prefix[:1]) + prefix[1:] + strings.ToUpper(id.Value[:1]) + id.Value[1:]
+					m.conflicts = append(m.conflicts, &Conflict{
+						Kind:       OperationIDConflict,
+						Name:       id.Value,
+						Sources:    []string{source, input.Name},
+						Resolution: fmt.Sprintf("renamed to %s in %s", newID, input.Name),
+					})
+					id.Value = newID
+				}
+				m.ids[id.Value] = input.Name
+			}
+			SetKey(mergedItem, key, value)
+		}
+	}
+}
+
+// mappingForKey returns the mapping node for a key, adding it if necessary.
+func mappingForKey(m *yaml.Node, key string) *yaml.Node {
+	value := compiler.MapValueForKey(m, key)
+	if value == nil {
+		value = compiler.NewMappingNode()
+		SetKey(m, key, value)
+	}
+	return value
+}
+
+// renameComponents renames components and updates the references to them.
+// renames maps references of components to their new names.
+func renameComponents(document *yaml.Node, renames map[string]string) {
+	root := Root(document)
+	components := compiler.MapValueForKey(root, "components")
+	for i := 0; components != nil && i+1 < len(components.Content); i += 2 {
+		kind, section := components.Content[i].Value, components.Content[i+1]
+		for j := 0; j+1 < len(section.Content); j += 2 {
+			if newName, ok := renames[ComponentReference(kind, section.Content[j].Value)]; ok {
+				section.Content[j].Value = newName
+			}
+		}
+	}
+	walkReferences(root, func(n *yaml.Node) {
+		kind, name, ok := parseComponentReference(n.Value)
+		if !ok {
+			return
+		}
+		ref := ComponentReference(kind, name)
+		if newName, ok := renames[ref]; ok {
+			n.Value = ComponentReference(kind, newName) + strings.TrimPrefix(n.Value, ref)
+		}
+	})
+	// Security requirements refer to security schemes by name.
+	schemes := make(map[string]string)
+	for ref, newName := range renames {
+		if kind, name, _ := parseComponentReference(ref); kind == "securitySchemes" {
+			schemes[name] = newName
+		}
+	}
+	if len(schemes) > 0 {
+		renameSecuritySchemes(root, schemes)
+	}
+}
+
+func renameSecuritySchemes(node *yaml.Node, schemes map[string]string) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "security" && value.Kind == yaml.SequenceNode {
+				for _, requirement := range value.Content {
+					for j := 0; j < len(requirement.Content); j += 2 {
+						if newName, ok := schemes[requirement.Content[j].Value]; ok {
+							requirement.Content[j].Value = newName
+						}
+					}
+				}
+				continue
+			}
+			renameSecuritySchemes(value, schemes)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		renameSecuritySchemes(child, schemes)
+	}
+}
+
+// marshal returns the YAML representation of a node, or nil for a nil node.
+func marshal(node *yaml.Node) []byte {
+	if node == nil {
+		return nil
+	}
+	return compiler.Marshal(node)
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// Normalize rewrites an OpenAPI document into a canonical form so that
+// differences between revisions reflect changes in meaning and not in
+// presentation. Paths, component names, response codes, and media types
+// are sorted, as are lists of required properties. References are
+// rewritten in a single style, comments are removed, and scalars are
+// written with the minimum quoting needed to preserve their types.
+// Both OpenAPI v2 and v3 documents are supported.
+func Normalize(document *yaml.Node) {
+	root := Root(document)
+	sortMapping(compiler.MapValueForKey(root, "paths"))
+	sortMapping(compiler.MapValueForKey(root, "webhooks"))
+	// OpenAPI v2 keeps definitions at the top level.
+	for _, key := range []string{"definitions", "parameters", "responses", "securityDefinitions"} {
+		sortMapping(compiler.MapValueForKey(root, key))
+	}
+	if components := compiler.MapValueForKey(root, "components"); components != nil {
+		sortMapping(components)
+		for i := 1; i < len(components.Content); i += 2 {
+			sortMapping(components.Content[i])
+		}
+	}
+	normalize(document, false)
+}
+
+// sortMapping sorts the entries of a mapping node by key.
+func sortMapping(m *yaml.Node) {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return
+	}
+	type entry struct{ key, value *yaml.Node }
+	entries := make([]entry, 0, len(m.Content)/2)
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		entries = append(entries, entry{m.Content[i], m.Content[i+1]})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].key.Value < entries[j].key.Value
+	})
+	for i, e := range entries {
+		m.Content[2*i], m.Content[2*i+1] = e.key, e.value
+	}
+}
+
+// normalize applies the canonical presentation to a node and everything below
+// it. names is true when the keys of a mapping node are property names
+// rather than keywords.
+func normalize(node *yaml.Node, names bool) {
+	if node == nil {
+		return
+	}
+	node.HeadComment, node.LineComment, node.FootComment = "", "", ""
+	switch node.Kind {
+	case yaml.ScalarNode:
+		node.Style = scalarStyle(node)
+	case yaml.MappingNode:
+		node.Style = 0
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			normalize(key, false)
+			if names {
+				normalize(value, false)
+				continue
+			}
+			switch key.Value {
+			case "$ref":
+				if value.Kind == yaml.ScalarNode {
+					value.Value = NormalizeReference(value.Value)
+				}
+			case "responses", "content":
+				// Response codes and media types are keys of these mappings.
+				sortMapping(value)
+			case "required":
+				if value.Kind == yaml.SequenceNode {
+					sort.SliceStable(value.Content, func(i, j int) bool {
+						return value.Content[i].Value < value.Content[j].Value
+					})
+				}
+			}
+			normalize(value, key.Value == "properties")
+		}
+	default:
+		node.Style = 0
+		for _, child := range node.Content {
+			normalize(child, false)
+		}
+	}
+}
+
+// scalarStyle returns the canonical style for a scalar: literal blocks for
+// multi-line strings and plain style otherwise. The YAML encoder adds
+// quotes where they are needed to preserve the type of a plain scalar.
+func scalarStyle(node *yaml.Node) yaml.Style {
+	if node.Tag == "!!str" && strings.Contains(strings.TrimRight(node.Value, "\n"), "\n") {
+		return yaml.LiteralStyle
+	}
+	return 0
+}
+
+// NormalizeReference rewrites a reference in a canonical style: leading
+// "./" is removed from relative file names, the fragment of a local
+// reference starts with "#/", and percent-encoded characters in the
+// fragment are decoded.
+func NormalizeReference(ref string) string {
+	i := strings.Index(ref, "#")
+	if i < 0 {
+		return strings.TrimPrefix(ref, "./")
+	}
+	file, fragment := strings.TrimPrefix(ref[:i], "./"), ref[i+1:]
+	if decoded, err := url.PathUnescape(fragment); err == nil {
+		fragment = decoded
+	}
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		fragment = "/" + fragment
+	}
+	return file + "#" + fragment
+}
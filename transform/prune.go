@@ -0,0 +1,157 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// A PruneReport lists the parts of a document that were removed by Prune.
+// Locations are written as JSON pointers.
+type PruneReport struct {
+	Components   []string `json:"components"`
+	Examples     []string `json:"examples"`
+	EmptyObjects []string `json:"emptyObjects"`
+}
+
+// Count returns the number of parts that were removed.
+func (r *PruneReport) Count() int {
+	return len(r.Components) + len(r.Examples) + len(r.EmptyObjects)
+}
+
+// emptyKeys are the keys of optional collections that have the same
+// meaning when they are empty as when they are absent.
+var emptyKeys = map[string]bool{
+	"callbacks":  true,
+	"encoding":   true,
+	"examples":   true,
+	"headers":    true,
+	"links":      true,
+	"parameters": true,
+	"properties": true,
+	"required":   true,
+	"servers":    true,
+	"tags":       true,
+	"variables":  true,
+}
+
+// Prune removes the parts of an OpenAPI v3 document that have no effect:
+// components that aren't used, examples that refer to missing components,
+// and empty optional collections like "properties: {}" and "tags: []".
+// Components that are only used by removed examples are removed too.
+func Prune(document *yaml.Node) *PruneReport {
+	report := &PruneReport{
+		Components:   make([]string, 0),
+		Examples:     make([]string, 0),
+		EmptyObjects: make([]string, 0),
+	}
+	root := Root(document)
+	examples := make(map[string]bool)
+	if components := compiler.MapValueForKey(root, "components"); components != nil {
+		if named := compiler.MapValueForKey(components, "examples"); named != nil {
+			for _, name := range compiler.SortedKeysForMap(named) {
+				examples[ComponentReference("examples", name)] = true
+			}
+		}
+	}
+	removeOrphanedExamples(root, "", examples, report)
+	removeEmptyObjects(root, "", false, report)
+	for _, ref := range PruneComponents(document) {
+		location := strings.TrimPrefix(ref, "#")
+		if strings.HasPrefix(ref, componentsPrefix+"examples/") {
+			report.Examples = append(report.Examples, location)
+		} else {
+			report.Components = append(report.Components, location)
+		}
+	}
+	return report
+}
+
+// removeOrphanedExamples removes entries of "examples" mappings that refer
+// to examples that aren't in the components of the document.
+func removeOrphanedExamples(node *yaml.Node, location string, components map[string]bool, report *PruneReport) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			removeOrphanedExamples(child, location+"/"+strconv.Itoa(i), components, report)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			childLocation := location + "/" + escape(key)
+			if key == "examples" && value.Kind == yaml.MappingNode && location != "/components" {
+				for j := 0; j+1 < len(value.Content); {
+					ref, ok := compiler.StringForScalarNode(compiler.MapValueForKey(value.Content[j+1], "$ref"))
+					if ok && strings.HasPrefix(ref, componentsPrefix+"examples/") && !components[ref] {
+						report.Examples = append(report.Examples, childLocation+"/"+escape(value.Content[j].Value))
+						value.Content = append(value.Content[:j], value.Content[j+2:]...)
+						continue
+					}
+					j += 2
+				}
+				continue
+			}
+			removeOrphanedExamples(value, childLocation, components, report)
+		}
+	}
+}
+
+// namedKeys are the keys of mappings whose own keys are names chosen by
+// the author, like the names of schemas or properties, rather than keywords.
+var namedKeys = map[string]bool{
+	"callbacks":       true,
+	"content":         true,
+	"encoding":        true,
+	"examples":        true,
+	"headers":         true,
+	"links":           true,
+	"parameters":      true,
+	"paths":           true,
+	"properties":      true,
+	"requestBodies":   true,
+	"responses":       true,
+	"schemas":         true,
+	"securitySchemes": true,
+	"variables":       true,
+	"webhooks":        true,
+}
+
+// removeEmptyObjects removes empty optional collections below a node.
+// names is true when the keys of a mapping node are names rather than keywords.
+func removeEmptyObjects(node *yaml.Node, location string, names bool, report *PruneReport) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			removeEmptyObjects(child, location+"/"+strconv.Itoa(i), false, report)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			childLocation := location + "/" + escape(key)
+			removeEmptyObjects(value, childLocation, !names && namedKeys[key] && value.Kind == yaml.MappingNode, report)
+			if !names && emptyKeys[key] && (value.Kind == yaml.MappingNode || value.Kind == yaml.SequenceNode) && len(value.Content) == 0 {
+				report.EmptyObjects = append(report.EmptyObjects, childLocation)
+				node.Content = append(node.Content[:i], node.Content[i+2:]...)
+				continue
+			}
+			i += 2
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform provides operations that rewrite OpenAPI v3 descriptions
+// represented as YAML nodes, such as finding the components that a
+// description uses and removing the ones it doesn't.
+package transform
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+const componentsPrefix = "#/components/"
+
+// Root returns the mapping node at the root of a document.
+func Root(document *yaml.Node) *yaml.Node {
+	if document != nil && document.Kind == yaml.DocumentNode && len(document.Content) > 0 {
+		return document.Content[0]
+	}
+	return document
+}
+
+// Copy returns a deep copy of a node.
+func Copy(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	c := *node
+	if node.Content != nil {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			c.Content[i] = Copy(child)
+		}
+	}
+	return &c
+}
+
+// DeleteKey removes a key and its value from a mapping node and
+// returns true if the key was present.
+func DeleteKey(m *yaml.Node, key string) bool {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SetKey sets the value of a key in a mapping node, adding the key if necessary.
+func SetKey(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, compiler.NewScalarNodeForString(key), value)
+}
+
+// References returns the values of all $ref keys found below a node,
+// along with the targets of discriminator mappings, which are also references.
+func References(node *yaml.Node) []string {
+	refs := make([]string, 0)
+	walkReferences(node, func(n *yaml.Node) {
+		refs = append(refs, n.Value)
+	})
+	return refs
+}
+
+// walkReferences calls f with each scalar node that holds a reference.
+func walkReferences(node *yaml.Node, f func(*yaml.Node)) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			walkReferences(child, f)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch {
+			case key.Value == "$ref" && value.Kind == yaml.ScalarNode:
+				f(value)
+			case key.Value == "discriminator":
+				if mapping := compiler.MapValueForKey(value, "mapping"); mapping != nil {
+					for j := 1; j < len(mapping.Content); j += 2 {
+						if strings.HasPrefix(mapping.Content[j].Value, "#/") {
+							f(mapping.Content[j])
+						}
+					}
+				}
+			default:
+				walkReferences(value, f)
+			}
+		}
+	}
+}
+
+// ComponentReference returns the reference to a component.
+func ComponentReference(kind string, name string) string {
+	return componentsPrefix + kind + "/" + escape(name)
+}
+
+// parseComponentReference returns the kind and name of the component that
+// contains the target of a local reference.
+func parseComponentReference(ref string) (kind string, name string, ok bool) {
+	if !strings.HasPrefix(ref, componentsPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(ref, componentsPrefix), "/", 3)
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	return parts[0], unescape(parts[1]), true
+}
+
+// escape and unescape convert between names and JSON Pointer reference tokens.
+func escape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+func unescape(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+// Component returns the node for a named component, or nil if there isn't one.
+func Component(document *yaml.Node, kind string, name string) *yaml.Node {
+	components := compiler.MapValueForKey(Root(document), "components")
+	return compiler.MapValueForKey(compiler.MapValueForKey(components, kind), name)
+}
+
+// securitySchemeNames returns the names of the security schemes used by
+// the security requirements below a node.
+func securitySchemeNames(node *yaml.Node, names map[string]bool) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "security" && value.Kind == yaml.SequenceNode {
+				for _, requirement := range value.Content {
+					if requirement.Kind == yaml.MappingNode {
+						for j := 0; j < len(requirement.Content); j += 2 {
+							names[requirement.Content[j].Value] = true
+						}
+					}
+				}
+				continue
+			}
+			securitySchemeNames(value, names)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		securitySchemeNames(child, names)
+	}
+}
+
+// Reachable returns the references of the components that are used,
+// directly or indirectly, by the parts of a document outside its
+// components section. Security schemes are reachable when they are named
+// in security requirements.
+func Reachable(document *yaml.Node) map[string]bool {
+	root := Root(document)
+	reachable := make(map[string]bool)
+	queue := make([]string, 0)
+	visit := func(kind string, name string) {
+		ref := ComponentReference(kind, name)
+		if !reachable[ref] {
+			reachable[ref] = true
+			queue = append(queue, ref)
+		}
+	}
+	// visitNode visits the components used by a node.
+	visitNode := func(node *yaml.Node) {
+		for _, ref := range References(node) {
+			if kind, name, ok := parseComponentReference(ref); ok {
+				visit(kind, name)
+			}
+		}
+		schemes := make(map[string]bool)
+		securitySchemeNames(node, schemes)
+		names := make([]string, 0, len(schemes))
+		for name := range schemes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			visit("securitySchemes", name)
+		}
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "components" {
+			// Wrap each entry so that the top-level security requirement is found.
+			visitNode(&yaml.Node{Kind: yaml.MappingNode, Content: root.Content[i : i+2]})
+		}
+	}
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		kind, name, _ := parseComponentReference(ref)
+		visitNode(Component(document, kind, name))
+	}
+	return reachable
+}
+
+// PruneComponents removes the components that aren't reachable from the rest
+// of a document and returns the references of the removed components in
+// sorted order. Empty component sections are removed too.
+func PruneComponents(document *yaml.Node) []string {
+	reachable := Reachable(document)
+	removed := make([]string, 0)
+	root := Root(document)
+	components := compiler.MapValueForKey(root, "components")
+	if components == nil {
+		return removed
+	}
+	for i := 0; i+1 < len(components.Content); {
+		kind, section := components.Content[i].Value, components.Content[i+1]
+		if strings.HasPrefix(kind, "x-") || section.Kind != yaml.MappingNode {
+			i += 2
+			continue
+		}
+		for j := 0; j+1 < len(section.Content); {
+			ref := ComponentReference(kind, section.Content[j].Value)
+			if reachable[ref] {
+				j += 2
+				continue
+			}
+			removed = append(removed, ref)
+			section.Content = append(section.Content[:j], section.Content[j+2:]...)
+		}
+		if len(section.Content) == 0 {
+			components.Content = append(components.Content[:i], components.Content[i+2:]...)
+			continue
+		}
+		i += 2
+	}
+	if len(components.Content) == 0 {
+		DeleteKey(root, "components")
+	}
+	sort.Strings(removed)
+	return removed
+}
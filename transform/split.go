@@ -0,0 +1,183 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// Methods are the keys of operations in path items.
+var Methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// IsMethod returns true if a path item key names an operation.
+func IsMethod(key string) bool {
+	for _, method := range Methods {
+		if key == method {
+			return true
+		}
+	}
+	return false
+}
+
+// UntaggedSlice names the slice of operations that have no tags.
+const UntaggedSlice = "untagged"
+
+// A Slice is a self-contained document that describes part of a larger one.
+type Slice struct {
+	Name     string
+	Document *yaml.Node
+}
+
+// SplitByTag returns a document for each tag that contains the operations
+// with that tag. Operations with several tags appear in several slices, and
+// operations without tags are collected in a slice named UntaggedSlice.
+// Slices are returned in the order of the document's tag list, followed by
+// any tags that are used but not listed.
+func SplitByTag(document *yaml.Node) []*Slice {
+	order := make([]string, 0)
+	if tags := compiler.MapValueForKey(Root(document), "tags"); tags != nil {
+		for _, tag := range tags.Content {
+			if name, ok := compiler.StringForScalarNode(compiler.MapValueForKey(tag, "name")); ok {
+				order = append(order, name)
+			}
+		}
+	}
+	return split(document, order, func(path string, operation *yaml.Node) []string {
+		tags := operationTags(operation)
+		if len(tags) == 0 {
+			return []string{UntaggedSlice}
+		}
+		return tags
+	})
+}
+
+// SplitByPathPrefix returns a document for each distinct prefix of paths
+// with the specified number of segments, so that with a depth of 1, "/pets"
+// and "/pets/{petId}" are in a slice named "pets". Slices are named by
+// joining the segments of their prefix with dashes.
+func SplitByPathPrefix(document *yaml.Node, depth int) []*Slice {
+	return split(document, nil, func(path string, operation *yaml.Node) []string {
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		if len(segments) > depth {
+			segments = segments[:depth]
+		}
+		for i, segment := range segments {
+			segments[i] = strings.Trim(segment, "{}")
+		}
+		name := strings.Join(segments, "-")
+		if name == "" {
+			name = "root"
+		}
+		return []string{name}
+	})
+}
+
+// split builds slices from the names that a function assigns to each operation.
+func split(document *yaml.Node, order []string, names func(path string, operation *yaml.Node) []string) []*Slice {
+	paths := compiler.MapValueForKey(Root(document), "paths")
+	used := make(map[string]bool)
+	for i := 0; paths != nil && i+1 < len(paths.Content); i += 2 {
+		item := paths.Content[i+1]
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			if IsMethod(item.Content[j].Value) {
+				for _, name := range names(paths.Content[i].Value, item.Content[j+1]) {
+					if !used[name] {
+						used[name] = true
+						order = append(order, name)
+					}
+				}
+			}
+		}
+	}
+	slices := make([]*Slice, 0)
+	done := make(map[string]bool)
+	for _, name := range order {
+		if !used[name] || done[name] {
+			continue
+		}
+		done[name] = true
+		slices = append(slices, &Slice{Name: name, Document: selectOperations(document, func(path string, operation *yaml.Node) bool {
+			for _, n := range names(path, operation) {
+				if n == name {
+					return true
+				}
+			}
+			return false
+		})})
+	}
+	return slices
+}
+
+// selectOperations returns a copy of a document that contains only the
+// selected operations, the tags they use, and the components they need.
+func selectOperations(document *yaml.Node, selected func(path string, operation *yaml.Node) bool) *yaml.Node {
+	d := Copy(document)
+	root := Root(d)
+	tags := make(map[string]bool)
+	if paths := compiler.MapValueForKey(root, "paths"); paths != nil {
+		for i := 0; i+1 < len(paths.Content); {
+			path, item := paths.Content[i].Value, paths.Content[i+1]
+			operations := 0
+			for j := 0; j+1 < len(item.Content); {
+				key, operation := item.Content[j].Value, item.Content[j+1]
+				if !IsMethod(key) {
+					j += 2
+					continue
+				}
+				if !selected(path, operation) {
+					item.Content = append(item.Content[:j], item.Content[j+2:]...)
+					continue
+				}
+				for _, tag := range operationTags(operation) {
+					tags[tag] = true
+				}
+				operations++
+				j += 2
+			}
+			if operations == 0 {
+				paths.Content = append(paths.Content[:i], paths.Content[i+2:]...)
+				continue
+			}
+			i += 2
+		}
+	}
+	if list := compiler.MapValueForKey(root, "tags"); list != nil {
+		kept := make([]*yaml.Node, 0)
+		for _, tag := range list.Content {
+			if name, _ := compiler.StringForScalarNode(compiler.MapValueForKey(tag, "name")); tags[name] {
+				kept = append(kept, tag)
+			}
+		}
+		list.Content = kept
+		if len(kept) == 0 {
+			DeleteKey(root, "tags")
+		}
+	}
+	PruneComponents(d)
+	return d
+}
+
+// operationTags returns the tags of an operation.
+func operationTags(operation *yaml.Node) []string {
+	tags := compiler.MapValueForKey(operation, "tags")
+	if tags == nil {
+		return nil
+	}
+	return compiler.StringArrayForSequenceNode(tags)
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+// A Marker identifies the parts of a document to be stripped: those with
+// an extension that has a particular value.
+type Marker struct {
+	// Extension is the name of the marking extension, such as "x-internal".
+	Extension string
+	// Value is the value that marks a part. If it is empty, parts are
+	// marked by any true boolean value.
+	Value string
+}
+
+// Marks returns true if a node carries the marking extension.
+func (m *Marker) Marks(node *yaml.Node) bool {
+	value := compiler.MapValueForKey(node, m.Extension)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return false
+	}
+	if m.Value == "" {
+		b, ok := compiler.BoolForScalarNode(value)
+		return ok && b
+	}
+	return value.Value == m.Value
+}
+
+// Strip removes the parts of an OpenAPI v3 document that are marked with an
+// extension: path items, operations, parameters, responses, schema
+// properties, components, and tags. Operations that use a marked tag are
+// removed too. References to removed components are removed where they
+// can be (from parameter lists, properties, and schema compositions), and
+// components that are no longer used are pruned. The marking extension is
+// removed from the parts that remain. Strip returns the locations of the
+// removed parts as JSON pointers.
+func Strip(document *yaml.Node, marker *Marker) []string {
+	s := &stripper{marker: marker, removedComponents: make(map[string]bool)}
+	root := Root(document)
+
+	// Remove marked components first so that references to them can be removed with the parts that use them.
+	if components := compiler.MapValueForKey(root, "components"); components != nil {
+		for i := 0; i+1 < len(components.Content); i += 2 {
+			kind, section := components.Content[i].Value, components.Content[i+1]
+			if strings.HasPrefix(kind, "x-") {
+				continue
+			}
+			s.removeEntries(section, "/components/"+kind, func(name string, value *yaml.Node) bool {
+				if marker.Marks(value) {
+					s.removedComponents[ComponentReference(kind, name)] = true
+					return true
+				}
+				return false
+			})
+		}
+	}
+
+	internalTags := make(map[string]bool)
+	if tags := compiler.MapValueForKey(root, "tags"); tags != nil {
+		s.removeItems(tags, "/tags", func(tag *yaml.Node) bool {
+			if marker.Marks(tag) {
+				name, _ := compiler.StringForScalarNode(compiler.MapValueForKey(tag, "name"))
+				internalTags[name] = true
+				return true
+			}
+			return false
+		})
+		if len(tags.Content) == 0 {
+			DeleteKey(root, "tags")
+		}
+	}
+
+	if paths := compiler.MapValueForKey(root, "paths"); paths != nil {
+		s.removeEntries(paths, "/paths", func(path string, item *yaml.Node) bool {
+			if marker.Marks(item) {
+				return true
+			}
+			operations, removed := 0, 0
+			s.removeEntries(item, "/paths/"+escape(path), func(key string, operation *yaml.Node) bool {
+				if !IsMethod(key) {
+					return false
+				}
+				operations++
+				remove := marker.Marks(operation)
+				for _, tag := range operationTags(operation) {
+					remove = remove || internalTags[tag]
+				}
+				if remove {
+					removed++
+				}
+				return remove
+			})
+			// Remove path items whose operations have all been removed.
+			return operations > 0 && removed == operations
+		})
+	}
+
+	s.strip(root, "")
+	for _, ref := range PruneComponents(document) {
+		s.removed = append(s.removed, strings.TrimPrefix(ref, "#"))
+	}
+	return s.removed
+}
+
+type stripper struct {
+	marker            *Marker
+	removed           []string
+	removedComponents map[string]bool
+}
+
+// removable returns true if a node is marked or refers to a removed component.
+func (s *stripper) removable(node *yaml.Node) bool {
+	if s.marker.Marks(node) {
+		return true
+	}
+	ref, _ := compiler.StringForScalarNode(compiler.MapValueForKey(node, "$ref"))
+	return s.removedComponents[ref]
+}
+
+// removeEntries removes the entries of a mapping for which remove returns true.
+func (s *stripper) removeEntries(m *yaml.Node, location string, remove func(key string, value *yaml.Node) bool) {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(m.Content); {
+		key := m.Content[i].Value
+		if remove(key, m.Content[i+1]) {
+			s.removed = append(s.removed, location+"/"+escape(key))
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			continue
+		}
+		i += 2
+	}
+}
+
+// removeItems removes the items of a sequence for which remove returns true.
+func (s *stripper) removeItems(sequence *yaml.Node, location string, remove func(*yaml.Node) bool) {
+	if sequence == nil || sequence.Kind != yaml.SequenceNode {
+		return
+	}
+	kept := make([]*yaml.Node, 0, len(sequence.Content))
+	for _, item := range sequence.Content {
+		if remove(item) {
+			s.removed = append(s.removed, location+"/"+itemName(item))
+			continue
+		}
+		kept = append(kept, item)
+	}
+	sequence.Content = kept
+}
+
+// itemName identifies an item of a sequence in the list of removed parts.
+func itemName(item *yaml.Node) string {
+	if name, ok := compiler.StringForScalarNode(compiler.MapValueForKey(item, "name")); ok {
+		return escape(name)
+	}
+	if ref, ok := compiler.StringForScalarNode(compiler.MapValueForKey(item, "$ref")); ok {
+		return escape(ref[strings.LastIndex(ref, "/")+1:])
+	}
+	return "-"
+}
+
+// strip removes marked parameters, responses, and properties and references
+// to removed components from everything below a node, along with the
+// marking extension itself.
+func (s *stripper) strip(node *yaml.Node, location string) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			s.strip(child, location+"/"+strconv.Itoa(i))
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		return
+	}
+	DeleteKey(node, s.marker.Extension)
+	if properties := compiler.MapValueForKey(node, "properties"); properties != nil {
+		removed := make(map[string]bool)
+		s.removeEntries(properties, location+"/properties", func(name string, value *yaml.Node) bool {
+			removed[name] = s.removable(value)
+			return removed[name]
+		})
+		if required := compiler.MapValueForKey(node, "required"); required != nil && required.Kind == yaml.SequenceNode {
+			kept := make([]*yaml.Node, 0, len(required.Content))
+			for _, name := range required.Content {
+				if !removed[name.Value] {
+					kept = append(kept, name)
+				}
+			}
+			required.Content = kept
+			if len(kept) == 0 {
+				DeleteKey(node, "required")
+			}
+		}
+	}
+	for _, key := range []string{"parameters", "allOf", "oneOf", "anyOf"} {
+		if list := compiler.MapValueForKey(node, key); list != nil && list.Kind == yaml.SequenceNode {
+			s.removeItems(list, location+"/"+key, s.removable)
+			if len(list.Content) == 0 {
+				DeleteKey(node, key)
+			}
+		}
+	}
+	if responses := compiler.MapValueForKey(node, "responses"); responses != nil && location != "/components" {
+		s.removeEntries(responses, location+"/responses", func(code string, response *yaml.Node) bool {
+			return s.removable(response)
+		})
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case strings.HasPrefix(key, "x-"):
+		case key == "properties" && value.Kind == yaml.MappingNode:
+			// Property names are arbitrary, so they aren't treated as keywords.
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				s.strip(value.Content[j+1], location+"/properties/"+escape(value.Content[j].Value))
+			}
+		default:
+			s.strip(value, location+"/"+escape(key))
+		}
+	}
+}
@@ -0,0 +1,557 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/okkoye/gnostic/compiler"
+)
+
+const testDocument = `openapi: 3.0.0
+info:
+  title: Store
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: users
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      security:
+        - petAuth: []
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        default:
+          $ref: '#/components/responses/Error'
+  /users/{userId}:
+    get:
+      tags: [users]
+      parameters:
+        - $ref: '#/components/parameters/userId'
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /health:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/User'
+    User:
+      type: object
+    Error:
+      type: object
+    Unused:
+      type: object
+  parameters:
+    userId:
+      name: userId
+      in: path
+      required: true
+      schema:
+        type: string
+  responses:
+    Error:
+      description: error
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Error'
+  securitySchemes:
+    petAuth:
+      type: apiKey
+      in: header
+      name: X-Pet-Key
+    userAuth:
+      type: apiKey
+      in: header
+      name: X-User-Key
+`
+
+func parse(t *testing.T, text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return &node
+}
+
+func TestPruneComponents(t *testing.T) {
+	d := parse(t, testDocument)
+	removed := PruneComponents(d)
+	expected := []string{"#/components/schemas/Unused", "#/components/securitySchemes/userAuth"}
+	if !reflect.DeepEqual(removed, expected) {
+		t.Errorf("unexpected removed components: %+v", removed)
+	}
+	if Component(d, "schemas", "Error") == nil {
+		t.Errorf("expected indirectly-referenced schema to be kept")
+	}
+}
+
+func TestSplitByTag(t *testing.T) {
+	slices := SplitByTag(parse(t, testDocument))
+	names := make([]string, 0)
+	for _, s := range slices {
+		names = append(names, s.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"pets", "users", UntaggedSlice}) {
+		t.Fatalf("unexpected slices: %+v", names)
+	}
+	pets := slices[0].Document
+	if keys := compiler.SortedKeysForMap(compiler.MapValueForKey(Root(pets), "paths")); !reflect.DeepEqual(keys, []string{"/pets"}) {
+		t.Errorf("unexpected paths: %+v", keys)
+	}
+	schemas := compiler.SortedKeysForMap(compiler.MapValueForKey(compiler.MapValueForKey(Root(pets), "components"), "schemas"))
+	if !reflect.DeepEqual(schemas, []string{"Error", "Pet", "User"}) {
+		t.Errorf("unexpected schemas: %+v", schemas)
+	}
+	if Component(pets, "securitySchemes", "petAuth") == nil || Component(pets, "parameters", "userId") != nil {
+		t.Errorf("unexpected components in pets slice")
+	}
+	if len(compiler.MapValueForKey(Root(pets), "tags").Content) != 1 {
+		t.Errorf("expected only the pets tag")
+	}
+	if compiler.MapValueForKey(Root(slices[2].Document), "components") != nil {
+		t.Errorf("expected untagged slice to have no components")
+	}
+}
+
+func TestSplitByPathPrefix(t *testing.T) {
+	slices := SplitByPathPrefix(parse(t, testDocument), 1)
+	names := make([]string, 0)
+	for _, s := range slices {
+		names = append(names, s.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"pets", "users", "health"}) {
+		t.Errorf("unexpected slices: %+v", names)
+	}
+}
+
+const testServiceA = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://pets.example.com
+security:
+  - auth: []
+paths:
+  /pets:
+    get:
+      operationId: list
+      responses:
+        default:
+          description: error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+    Page:
+      type: object
+  securitySchemes:
+    auth:
+      type: http
+      scheme: bearer
+`
+
+const testServiceB = `openapi: 3.0.0
+info:
+  title: Users
+  version: 2.0.0
+servers:
+  - url: https://users.example.com
+security:
+  - auth: []
+paths:
+  /users:
+    get:
+      operationId: list
+      responses:
+        default:
+          description: error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        code:
+          type: integer
+    Page:
+      type: object
+  securitySchemes:
+    auth:
+      type: apiKey
+      in: header
+      name: X-Key
+`
+
+func TestMerge(t *testing.T) {
+	merged, conflicts := Merge([]*MergeInput{
+		{Name: "pet-service", Document: parse(t, testServiceA)},
+		{Name: "user-service", Document: parse(t, testServiceB)},
+	}, &MergeOptions{Title: "Gateway"})
+	expected := []string{
+		"component #/components/schemas/Error in pet-service, user-service: renamed to UserServiceError in user-service",
+		"component #/components/securitySchemes/auth in pet-service, user-service: renamed to UserServiceAuth in user-service",
+		"operationId list in pet-service, user-service: renamed to userServiceList in user-service",
+		"operation GET /pets in pet-service, user-service: kept the operation from pet-service",
+	}
+	if len(conflicts) != len(expected) {
+		t.Fatalf("unexpected conflicts: %+v", conflicts)
+	}
+	for i, c := range conflicts {
+		if c.String() != expected[i] {
+			t.Errorf("unexpected conflict: %s (expected %s)", c, expected[i])
+		}
+	}
+	root := Root(merged)
+	if title := compiler.MapValueForKey(compiler.MapValueForKey(root, "info"), "title").Value; title != "Gateway" {
+		t.Errorf("unexpected title: %s", title)
+	}
+	schemas := compiler.SortedKeysForMap(compiler.MapValueForKey(compiler.MapValueForKey(root, "components"), "schemas"))
+	if !reflect.DeepEqual(schemas, []string{"Error", "Page", "UserServiceError"}) {
+		t.Errorf("unexpected schemas: %+v", schemas)
+	}
+	users := compiler.MapValueForKey(compiler.MapValueForKey(root, "paths"), "/users")
+	if refs := References(users); len(refs) != 1 || refs[0] != "#/components/schemas/UserServiceError" {
+		t.Errorf("unexpected references: %+v", refs)
+	}
+	// Servers and security differ, so they should be moved into path items and operations.
+	if compiler.MapValueForKey(root, "servers") != nil || compiler.MapValueForKey(users, "servers") == nil {
+		t.Errorf("expected servers to be moved into path items")
+	}
+	security := compiler.MapValueForKey(compiler.MapValueForKey(users, "get"), "security")
+	if security == nil || security.Content[0].Content[0].Value != "UserServiceAuth" {
+		t.Errorf("expected security requirement to be moved and renamed")
+	}
+	// The inputs should not be modified.
+	if compiler.MapValueForKey(compiler.MapValueForKey(Root(parse(t, testServiceB)), "paths"), "/users") == nil {
+		t.Errorf("unexpected modification of input")
+	}
+}
+
+const testInternalDocument = `openapi: 3.0.0
+info:
+  title: Store
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: admin
+    x-internal: true
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      parameters:
+        - name: debug
+          in: query
+          x-internal: true
+        - $ref: '#/components/parameters/trace'
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      x-internal: true
+      responses:
+        "200":
+          description: OK
+  /audit:
+    get:
+      tags: [admin]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Audit'
+components:
+  parameters:
+    trace:
+      name: trace
+      in: header
+      x-internal: true
+  schemas:
+    Pet:
+      type: object
+      required: [id, secret]
+      properties:
+        id:
+          type: integer
+          x-internal: false
+        secret:
+          type: string
+          x-internal: true
+        notes:
+          $ref: '#/components/schemas/Notes'
+    Notes:
+      type: string
+      x-internal: true
+    Audit:
+      type: object
+`
+
+func TestStrip(t *testing.T) {
+	document := parse(t, testInternalDocument)
+	removed := Strip(document, &Marker{Extension: "x-internal"})
+	expected := []string{
+		"/components/parameters/trace",
+		"/components/schemas/Notes",
+		"/tags/admin",
+		"/paths/~1pets/post",
+		"/paths/~1audit/get",
+		"/paths/~1audit",
+		"/paths/~1pets/get/parameters/debug",
+		"/paths/~1pets/get/parameters/trace",
+		"/components/schemas/Pet/properties/secret",
+		"/components/schemas/Pet/properties/notes",
+		"/components/schemas/Audit",
+	}
+	if !reflect.DeepEqual(removed, expected) {
+		t.Errorf("unexpected removals: %+v", removed)
+	}
+	root := Root(document)
+	pet := Component(document, "schemas", "Pet")
+	if required := compiler.StringArrayForSequenceNode(compiler.MapValueForKey(pet, "required")); !reflect.DeepEqual(required, []string{"id"}) {
+		t.Errorf("unexpected required properties: %+v", required)
+	}
+	if id := compiler.MapValueForKey(compiler.MapValueForKey(pet, "properties"), "id"); compiler.MapValueForKey(id, "x-internal") != nil {
+		t.Errorf("expected the marking extension to be removed")
+	}
+	get := compiler.MapValueForKey(compiler.MapValueForKey(compiler.MapValueForKey(root, "paths"), "/pets"), "get")
+	if compiler.MapValueForKey(get, "parameters") != nil {
+		t.Errorf("expected the empty parameter list to be removed")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	document := parse(t, `openapi: 3.0.0
+info:
+  title: 'Store'
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      responses:
+        '404':
+          $ref: './#components/responses/Not%20Found'
+        "200":
+          description: OK
+          content:
+            text/plain: {}
+            application/json:
+              schema:
+                required: [name, id]
+                properties:
+                  responses:
+                    type: string
+                  content:
+                    type: string
+  /orders: # orders
+    get: {}
+components:
+  responses:
+    Not Found:
+      description: Not found
+  schemas:
+    Pet: {}
+    Error: {}
+`)
+	Normalize(document)
+	expected := `openapi: 3.0.0
+info:
+    title: Store
+    version: 1.0.0
+paths:
+    /orders:
+        get: {}
+    /pets:
+        get:
+            responses:
+                "200":
+                    description: OK
+                    content:
+                        application/json:
+                            schema:
+                                required:
+                                    - id
+                                    - name
+                                properties:
+                                    responses:
+                                        type: string
+                                    content:
+                                        type: string
+                        text/plain: {}
+                "404":
+                    $ref: '#/components/responses/Not Found'
+components:
+    responses:
+        Not Found:
+            description: Not found
+    schemas:
+        Error: {}
+        Pet: {}
+`
+	if text := string(marshal(document)); text != expected {
+		t.Errorf("unexpected normalized document:\n%s", text)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	document := parse(t, `openapi: 3.0.0
+info:
+  title: Store
+  version: 1.0.0
+tags: []
+paths:
+  /pets:
+    get:
+      parameters: []
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+              examples:
+                cat:
+                  $ref: '#/components/examples/cat'
+                dog:
+                  $ref: '#/components/examples/dog'
+components:
+  examples:
+    cat:
+      value:
+        name: Tom
+    fish:
+      value:
+        name: Wanda
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tags:
+          type: object
+          properties: {}
+        required: {}
+    Unused:
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner: {}
+`)
+	report := Prune(document)
+	expected := &PruneReport{
+		Components:   []string{"/components/schemas/Owner", "/components/schemas/Unused"},
+		Examples:     []string{"/paths/~1pets/get/responses/200/content/application~1json/examples/dog", "/components/examples/fish"},
+		EmptyObjects: []string{"/tags", "/paths/~1pets/get/parameters", "/components/schemas/Pet/properties/tags/properties"},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	// The property named "required" is not an empty collection.
+	properties := compiler.MapValueForKey(Component(document, "schemas", "Pet"), "properties")
+	if compiler.MapValueForKey(properties, "required") == nil {
+		t.Errorf("expected the property named required to be kept")
+	}
+}
+
+func TestPruneWithoutExamples(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		expected *PruneReport
+	}{
+		{`openapi: 3.0.0
+info:
+  title: Store
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              examples:
+                cat:
+                  $ref: '#/components/examples/cat'
+`, &PruneReport{
+			Components:   []string{},
+			Examples:     []string{"/paths/~1pets/get/responses/200/content/application~1json/examples/cat"},
+			EmptyObjects: []string{"/paths/~1pets/get/responses/200/content/application~1json/examples"},
+		}},
+		{`openapi: 3.0.0
+info:
+  title: Store
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet: {}
+`, &PruneReport{
+			Components:   []string{"/components/schemas/Pet"},
+			Examples:     []string{},
+			EmptyObjects: []string{},
+		}},
+	} {
+		if report := Prune(parse(t, test.text)); !reflect.DeepEqual(report, test.expected) {
+			t.Errorf("unexpected report: %+v", report)
+		}
+	}
+}